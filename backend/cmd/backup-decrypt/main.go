@@ -0,0 +1,66 @@
+// backup-decrypt は暗号化した定期バックアップ（*.zip.umibak）を復号してZIPに戻す。
+//
+//	UMI_BACKUP_PASSPHRASE='...' go run ./cmd/backup-decrypt -in umi-mikan-backup-20240501T000000Z.zip.umibak -out backup.zip
+//
+// パスフレーズはシェルの履歴に残らないよう、引数ではなく環境変数で受け取る。
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/backup"
+)
+
+func main() {
+	in := flag.String("in", "", "暗号化したバックアップのパス")
+	out := flag.String("out", "", "復号したZIPの出力先（省略時は標準出力）")
+	flag.Parse()
+
+	if err := run(*in, *out, os.Getenv("UMI_BACKUP_PASSPHRASE")); err != nil {
+		fmt.Fprintln(os.Stderr, "backup-decrypt:", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out, passphrase string) error {
+	if in == "" {
+		return errors.New("-in is required")
+	}
+	if passphrase == "" {
+		return errors.New("UMI_BACKUP_PASSPHRASE is required")
+	}
+
+	src, err := os.Open(in)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+	r, err := backup.NewDecryptReader(src, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err := io.Copy(os.Stdout, r)
+		return err
+	}
+	// 復号に失敗した場合に壊れたZIPを残さないよう、一時ファイルに書き込んでからリネームする
+	dst, err := os.CreateTemp(filepath.Dir(out), ".backup-decrypt-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(dst.Name()) }()
+	if _, err := io.Copy(dst, r); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return os.Rename(dst.Name(), out)
+}
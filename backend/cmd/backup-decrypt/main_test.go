@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/backup"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	recipient, err := backup.NewRecipient("backup passphrase")
+	if err != nil {
+		t.Fatalf("公開鍵の作成に失敗: %v", err)
+	}
+	in := filepath.Join(dir, "backup.zip.umibak")
+	f, err := os.Create(in)
+	if err != nil {
+		t.Fatalf("ファイルの作成に失敗: %v", err)
+	}
+	w, err := backup.NewEncryptWriter(f, recipient)
+	if err != nil {
+		t.Fatalf("暗号化の開始に失敗: %v", err)
+	}
+	_, _ = w.Write([]byte("zip content"))
+	if err := w.Close(); err != nil {
+		t.Fatalf("暗号化に失敗: %v", err)
+	}
+	_ = f.Close()
+
+	t.Run("正常系: 復号した内容を出力先に書き込む", func(t *testing.T) {
+		out := filepath.Join(dir, "backup.zip")
+		if err := run(in, out, "backup passphrase"); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		got, _ := os.ReadFile(out)
+		if string(got) != "zip content" {
+			t.Errorf("復号した内容が不正: %q", got)
+		}
+	})
+
+	t.Run("異常系: パスフレーズが違う場合は出力先を作らない", func(t *testing.T) {
+		out := filepath.Join(dir, "wrong.zip")
+		if err := run(in, out, "wrong passphrase"); err == nil {
+			t.Fatal("エラーを期待したが成功した")
+		}
+		if _, err := os.Stat(out); !os.IsNotExist(err) {
+			t.Error("復号に失敗した出力先が残っている")
+		}
+	})
+}
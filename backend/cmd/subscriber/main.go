@@ -0,0 +1,1642 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/constants"
+	"github.com/project-mikan/umi.mikan/backend/container"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/accountdeletion"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/llm"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/migrate"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/requestid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/storage"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/takeout"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/taskevent"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/webhook"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/rueidis"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+// getTaskTimeout 環境変数からタスクタイムアウトを取得(デフォルト600秒)
+func getTaskTimeout() int {
+	timeoutStr := os.Getenv("TASK_TIMEOUT_SECONDS")
+	if timeoutStr == "" {
+		return 600 // デフォルト10分
+	}
+	timeout, err := strconv.Atoi(timeoutStr)
+	if err != nil || timeout <= 0 {
+		return 600 // パースエラー時もデフォルト
+	}
+	return timeout
+}
+
+// taskNotifier はジョブの状態変化をWatchTasksの購読者に通知する
+// 通知は補助的な機能のため、失敗してもログに記録するのみでジョブは継続する
+type taskNotifier struct {
+	redisClient rueidis.Client
+	userID      string
+	taskType    string
+	target      string
+	logger      *logrus.Entry
+	finished    bool
+}
+
+func newTaskNotifier(redisClient rueidis.Client, userID, taskType, target string, logger *logrus.Entry) *taskNotifier {
+	return &taskNotifier{redisClient: redisClient, userID: userID, taskType: taskType, target: target, logger: logger}
+}
+
+func (n *taskNotifier) publish(ctx context.Context, status taskevent.Status, message string) {
+	event := taskevent.NewEvent(n.userID, n.taskType, n.target, status)
+	event.Message = message
+	if err := taskevent.Publish(ctx, n.redisClient, event); err != nil {
+		n.logger.WithError(err).WithFields(logrus.Fields{
+			"user_id":   n.userID,
+			"task_type": n.taskType,
+			"status":    status,
+		}).Warn("Failed to publish task event")
+	}
+}
+
+// fail はエラーを返さずに終了する失敗（コンテンツブロックなど）を通知する
+func (n *taskNotifier) fail(ctx context.Context, reason string) {
+	n.finished = true
+	n.publish(ctx, taskevent.StatusFailed, reason)
+}
+
+// discard は結果を破棄して終了する場合に呼び出し、終了イベントを送らないようにする
+// （古いフェンシングトークンの結果は新しいロック保持者が通知する）
+func (n *taskNotifier) discard() {
+	n.finished = true
+}
+
+// finish はジョブの戻り値に応じて成功・失敗を通知する（deferで呼び出す）
+func (n *taskNotifier) finish(ctx context.Context, err error) {
+	if n.finished {
+		return
+	}
+	n.finished = true
+	if err != nil {
+		n.publish(ctx, taskevent.StatusFailed, err.Error())
+		return
+	}
+	n.publish(ctx, taskevent.StatusSucceeded, "")
+}
+
+var (
+	// Prometheus metrics
+	messagesProcessedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subscriber_messages_processed_total",
+			Help: "Total number of messages processed",
+		},
+		[]string{"message_type", "status"},
+	)
+	processingDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "subscriber_processing_duration_seconds",
+			Help:    "Duration of message processing",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"message_type"},
+	)
+	summariesGeneratedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subscriber_summaries_generated_total",
+			Help: "Total number of summaries generated",
+		},
+		[]string{"summary_type"},
+	)
+	lockOperationsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subscriber_lock_operations_total",
+			Help: "Total number of lock operations",
+		},
+		[]string{"operation", "status", "lock_type"},
+	)
+	connectionReconnectsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subscriber_connection_reconnects_total",
+			Help: "Total number of Redis connection reconnects",
+		},
+		[]string{"status"},
+	)
+	connectionStatusGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "subscriber_connection_status",
+			Help: "Current Redis connection status (1=connected, 0=disconnected)",
+		},
+		[]string{"connection_type"},
+	)
+	webhookDeliveriesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subscriber_webhook_deliveries_total",
+			Help: "Total number of webhook delivery attempts",
+		},
+		[]string{"result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(messagesProcessedCounter)
+	prometheus.MustRegister(processingDuration)
+	prometheus.MustRegister(webhookDeliveriesCounter)
+	prometheus.MustRegister(summariesGeneratedCounter)
+	prometheus.MustRegister(lockOperationsCounter)
+	prometheus.MustRegister(connectionReconnectsCounter)
+	prometheus.MustRegister(connectionStatusGauge)
+}
+
+type MonthlySummaryGenerationMessage struct {
+	Type   string `json:"type"`
+	UserID string `json:"user_id"`
+	Year   int    `json:"year"`
+	Month  int    `json:"month"`
+}
+
+type LatestTrendGenerationMessage struct {
+	Type        string `json:"type"`
+	UserID      string `json:"user_id"`
+	PeriodStart string `json:"period_start"` // ISO 8601 format
+	PeriodEnd   string `json:"period_end"`   // ISO 8601 format
+}
+
+type DiaryHighlightGenerationMessage struct {
+	Type    string `json:"type"`
+	UserID  string `json:"user_id"`
+	DiaryID string `json:"diary_id"`
+}
+
+type DiaryEmbeddingMessage struct {
+	Type    string `json:"type"`
+	UserID  string `json:"user_id"`
+	DiaryID string `json:"diary_id"`
+}
+
+type DiaryTagSuggestionMessage struct {
+	Type    string `json:"type"`
+	UserID  string `json:"user_id"`
+	DiaryID string `json:"diary_id"`
+}
+
+// maxSuggestedTags は1つの日記に提案するタグの最大数
+const maxSuggestedTags = 3
+
+func main() {
+	// マイグレーションのサブコマンド（例: server migrate up）
+	if len(os.Args) > 1 && os.Args[1] == migrate.CommandName {
+		if err := container.RunMigrateCommand(os.Args[2:], os.Stdout); err != nil {
+			logrus.WithError(err).Fatal("Failed to run migrate")
+		}
+		return
+	}
+
+	// Initialize structured logger
+	logger := logrus.WithFields(logrus.Fields{
+		"service": "subscriber",
+	})
+	logger.Info("=== umi.mikan subscriber started ===")
+
+	// Create DI container
+	diContainer, err := container.NewContainer()
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to create DI container")
+	}
+
+	// Initialize and run subscriber using DI container
+	if err := diContainer.Invoke(func(app *container.SubscriberApp, cleanup *container.Cleanup) error {
+		return runSubscriber(app, cleanup, logger)
+	}); err != nil {
+		logger.WithError(err).Fatal("Failed to start subscriber")
+	}
+}
+
+func runSubscriber(app *container.SubscriberApp, cleanup *container.Cleanup, logger *logrus.Entry) error {
+	// Redis接続確認
+	ctx := context.Background()
+	pingCmd := app.Redis.B().Ping().Build()
+	if err := app.Redis.Do(ctx, pingCmd).Error(); err != nil {
+		return fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	logger.Info("Connected to Redis successfully")
+
+	// メトリクスサーバー開始
+	metricsServer := &http.Server{Addr: ":2005"}
+	http.Handle("/metrics", promhttp.Handler())
+
+	go func() {
+		logger.Info("Metrics server starting on :2005")
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.WithError(err).Error("Metrics server error")
+		}
+	}()
+
+	// Gemini API レートリミッター（3000 RPM = 50 RPS、バースト50）
+	// チャンク分割・embedding生成の両方に適用してレートリミット超過を防ぐ
+	geminiRateLimiter := rate.NewLimiter(rate.Every(time.Minute/3000), 50)
+
+	// 猶予期間が過ぎたアカウントの削除（ADR 0037）
+	purger := &accountdeletion.Purger{
+		DB:             app.DB,
+		Redis:          app.Redis,
+		Storage:        app.Storage,
+		BackupLocalDir: app.BackupConfig.LocalDir,
+		Mailer:         app.Mailer,
+	}
+
+	// Webhookの送信（通知先は原則として公開アドレスのみ。ADR 0017）
+	webhookDispatcher := webhook.NewDispatcher(app.DB, constants.LoadWebhookAllowPrivateNetworks())
+
+	logger.WithField("max_concurrent_jobs", app.SubscriberConfig.MaxConcurrentJobs).Info("Subscriber is listening for messages...")
+
+	// Create context for subscription that can be cancelled
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Initialize connection status
+	connectionStatusGauge.WithLabelValues("pubsub").Set(0)
+
+	// Track processing messages for graceful shutdown
+	var wg sync.WaitGroup
+	processing := make(chan struct{}, app.SubscriberConfig.MaxConcurrentJobs) // Buffer to limit concurrent processing
+
+	// Set up signal handling for graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	// Start connection health monitor
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				// Ping Redis to check connection health
+				pingCmd := app.Redis.B().Ping().Build()
+				if err := app.Redis.Do(ctx, pingCmd).Error(); err != nil {
+					logger.WithError(err).Warn("Redis ping failed, connection may be unhealthy")
+					connectionStatusGauge.WithLabelValues("ping").Set(0)
+				} else {
+					connectionStatusGauge.WithLabelValues("ping").Set(1)
+				}
+			case <-subCtx.Done():
+				logger.Info("Connection health monitor stopping")
+				return
+			}
+		}
+	}()
+
+	// リトライ待ちのWebhook配信を定期的に送信する
+	go runWebhookWorker(subCtx, app.DB, webhookDispatcher, logger)
+
+	// Start subscriber with automatic reconnection
+	subErrChan := make(chan error, 1)
+	go func() {
+		// Infinite loop for automatic reconnection
+		for {
+			select {
+			case <-subCtx.Done():
+				logger.Info("Subscription context cancelled, stopping subscriber")
+				return
+			default:
+				// Attempt to subscribe
+				logger.Info("Starting Redis Pub/Sub subscription...")
+				connectionReconnectsCounter.WithLabelValues("attempt").Inc()
+				err := app.Redis.Receive(subCtx, app.Redis.B().Subscribe().Channel("diary_events").Build(), func(msg rueidis.PubSubMessage) {
+					// Mark connection as active when receiving messages
+					connectionStatusGauge.WithLabelValues("pubsub").Set(1)
+					logger.WithFields(logrus.Fields{
+						"channel": msg.Channel,
+						"message": msg.Message,
+					}).Debug("Received message")
+
+					// Track this message processing
+					wg.Add(1)
+					processing <- struct{}{} // Acquire processing slot
+
+					go func() {
+						defer func() {
+							<-processing // Release processing slot
+							wg.Done()
+						}()
+
+						start := time.Now()
+						err := processMessage(subCtx, app.DB, app.Redis, app.LLMFactory, app.LockService, geminiRateLimiter, app.Storage, app.DataExportConfig.Expiry, purger, webhookDispatcher, msg.Message, logger)
+						duration := time.Since(start)
+
+						// メトリクス更新は processMessage 内で行う
+						_ = duration // 使用しない場合の警告回避
+
+						if err != nil {
+							logger.WithError(err).Error("Failed to process message")
+						}
+					}()
+				})
+
+				if err != nil {
+					// Mark connection as lost
+					connectionStatusGauge.WithLabelValues("pubsub").Set(0)
+
+					if subCtx.Err() != nil {
+						// Context was cancelled, stop retrying
+						logger.Info("Subscription context cancelled during connection")
+						return
+					}
+
+					logger.WithError(err).Error("Redis Pub/Sub connection lost, attempting to reconnect...")
+					connectionReconnectsCounter.WithLabelValues("failed").Inc()
+
+					// Wait before attempting to reconnect
+					select {
+					case <-time.After(5 * time.Second):
+						// Continue to retry
+						continue
+					case <-subCtx.Done():
+						logger.Info("Subscription context cancelled during reconnection wait")
+						return
+					}
+				} else {
+					// Successful connection established
+					connectionReconnectsCounter.WithLabelValues("success").Inc()
+					logger.Info("Redis Pub/Sub subscription established successfully")
+				}
+			}
+		}
+	}()
+
+	// Wait for shutdown signal or subscription error
+	select {
+	case sig := <-sigChan:
+		logger.WithField("signal", sig).Info("Received signal, initiating graceful shutdown...")
+
+		// Cancel subscription context to stop receiving new messages
+		cancel()
+		logger.Info("Stopped accepting new messages")
+
+		// Create context with timeout for graceful shutdown
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer shutdownCancel()
+
+		// Wait for all processing messages to complete or timeout
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			logger.Info("All messages processed successfully")
+		case <-shutdownCtx.Done():
+			logger.Warn("Graceful shutdown timeout, some messages may not have been processed")
+		}
+
+		// Stop metrics server
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			logger.WithError(err).Error("Metrics server shutdown error")
+		} else {
+			logger.Info("Metrics server stopped")
+		}
+
+		// Cleanup resources
+		if err := cleanup.Close(); err != nil {
+			logger.WithError(err).Error("Error during cleanup")
+			return err
+		}
+
+	case err := <-subErrChan:
+		logger.WithError(err).Error("Subscription error")
+		cancel()
+		// Cleanup resources on error
+		if cleanupErr := cleanup.Close(); cleanupErr != nil {
+			logger.WithError(cleanupErr).Error("Error during cleanup")
+		}
+		return err
+	}
+
+	logger.Info("Subscriber ended")
+	return nil
+}
+
+func processMessage(ctx context.Context, db *sql.DB, redisClient rueidis.Client, llmFactory container.LLMClientFactory, lockService container.LockService, geminiRateLimiter *rate.Limiter, exportStorage storage.Storage, dataExportExpiry time.Duration, purger *accountdeletion.Purger, webhookDispatcher *webhook.Dispatcher, payload string, logger *logrus.Entry) error {
+	start := time.Now()
+
+	// まずメッセージタイプを確認
+	var baseMessage struct {
+		Type      string `json:"type"`
+		RequestID string `json:"request_id"`
+	}
+	if err := json.Unmarshal([]byte(payload), &baseMessage); err != nil {
+		messagesProcessedCounter.WithLabelValues("unknown", "error").Inc()
+		return fmt.Errorf("failed to unmarshal base message: %w", err)
+	}
+	// 依頼したRPCのリクエストIDをログに含める（スケジューラーからの依頼にはない）
+	if baseMessage.RequestID != "" {
+		ctx = requestid.WithID(ctx, baseMessage.RequestID)
+		logger = logger.WithField(requestid.LogField, baseMessage.RequestID)
+	}
+
+	var err error
+	switch baseMessage.Type {
+	case "monthly_summary":
+		processingDuration.WithLabelValues("monthly_summary").Observe(time.Since(start).Seconds())
+		var message MonthlySummaryGenerationMessage
+		if unmarshalErr := json.Unmarshal([]byte(payload), &message); unmarshalErr != nil {
+			messagesProcessedCounter.WithLabelValues("monthly_summary", "error").Inc()
+			return fmt.Errorf("failed to unmarshal monthly summary message: %w", unmarshalErr)
+		}
+		err = generateMonthlySummary(ctx, db, redisClient, llmFactory, lockService, message.UserID, message.Year, message.Month, logger)
+		if err != nil {
+			messagesProcessedCounter.WithLabelValues("monthly_summary", "error").Inc()
+		} else {
+			messagesProcessedCounter.WithLabelValues("monthly_summary", "success").Inc()
+		}
+		return err
+	case "latest_trend":
+		processingDuration.WithLabelValues("latest_trend").Observe(time.Since(start).Seconds())
+		var message LatestTrendGenerationMessage
+		if unmarshalErr := json.Unmarshal([]byte(payload), &message); unmarshalErr != nil {
+			messagesProcessedCounter.WithLabelValues("latest_trend", "error").Inc()
+			return fmt.Errorf("failed to unmarshal latest trend message: %w", unmarshalErr)
+		}
+		err = generateLatestTrend(ctx, db, redisClient, llmFactory, lockService, message.UserID, message.PeriodStart, message.PeriodEnd, logger)
+		if err != nil {
+			messagesProcessedCounter.WithLabelValues("latest_trend", "error").Inc()
+		} else {
+			messagesProcessedCounter.WithLabelValues("latest_trend", "success").Inc()
+		}
+		return err
+	case "diary_highlight":
+		processingDuration.WithLabelValues("diary_highlight").Observe(time.Since(start).Seconds())
+		var message DiaryHighlightGenerationMessage
+		if unmarshalErr := json.Unmarshal([]byte(payload), &message); unmarshalErr != nil {
+			messagesProcessedCounter.WithLabelValues("diary_highlight", "error").Inc()
+			return fmt.Errorf("failed to unmarshal diary highlight message: %w", unmarshalErr)
+		}
+		err = generateDiaryHighlight(ctx, db, redisClient, llmFactory, lockService, message.UserID, message.DiaryID, logger)
+		if err != nil {
+			messagesProcessedCounter.WithLabelValues("diary_highlight", "error").Inc()
+		} else {
+			messagesProcessedCounter.WithLabelValues("diary_highlight", "success").Inc()
+		}
+		return err
+	case "diary_embedding":
+		processingDuration.WithLabelValues("diary_embedding").Observe(time.Since(start).Seconds())
+		var message DiaryEmbeddingMessage
+		if unmarshalErr := json.Unmarshal([]byte(payload), &message); unmarshalErr != nil {
+			messagesProcessedCounter.WithLabelValues("diary_embedding", "error").Inc()
+			return fmt.Errorf("failed to unmarshal diary embedding message: %w", unmarshalErr)
+		}
+		err = generateDiaryEmbedding(ctx, db, redisClient, llmFactory, geminiRateLimiter, message.UserID, message.DiaryID, logger)
+		if err != nil {
+			messagesProcessedCounter.WithLabelValues("diary_embedding", "error").Inc()
+		} else {
+			messagesProcessedCounter.WithLabelValues("diary_embedding", "success").Inc()
+		}
+		return err
+	case taskevent.TypeDiaryTagSuggestion:
+		processingDuration.WithLabelValues(taskevent.TypeDiaryTagSuggestion).Observe(time.Since(start).Seconds())
+		var message DiaryTagSuggestionMessage
+		if unmarshalErr := json.Unmarshal([]byte(payload), &message); unmarshalErr != nil {
+			messagesProcessedCounter.WithLabelValues(taskevent.TypeDiaryTagSuggestion, "error").Inc()
+			return fmt.Errorf("failed to unmarshal diary tag suggestion message: %w", unmarshalErr)
+		}
+		err = suggestDiaryTags(ctx, db, redisClient, llmFactory, geminiRateLimiter, message.UserID, message.DiaryID, logger)
+		if err != nil {
+			messagesProcessedCounter.WithLabelValues(taskevent.TypeDiaryTagSuggestion, "error").Inc()
+		} else {
+			messagesProcessedCounter.WithLabelValues(taskevent.TypeDiaryTagSuggestion, "success").Inc()
+		}
+		return err
+	case takeout.MessageType:
+		processingDuration.WithLabelValues(takeout.MessageType).Observe(time.Since(start).Seconds())
+		var message takeout.Message
+		if unmarshalErr := json.Unmarshal([]byte(payload), &message); unmarshalErr != nil {
+			messagesProcessedCounter.WithLabelValues(takeout.MessageType, "error").Inc()
+			return fmt.Errorf("failed to unmarshal data export message: %w", unmarshalErr)
+		}
+		err = generateDataExport(ctx, db, redisClient, exportStorage, dataExportExpiry, message.UserID, message.ExportID, logger)
+		if err != nil {
+			messagesProcessedCounter.WithLabelValues(takeout.MessageType, "error").Inc()
+		} else {
+			messagesProcessedCounter.WithLabelValues(takeout.MessageType, "success").Inc()
+		}
+		return err
+	case accountdeletion.MessageType:
+		processingDuration.WithLabelValues(accountdeletion.MessageType).Observe(time.Since(start).Seconds())
+		var message accountdeletion.Message
+		if unmarshalErr := json.Unmarshal([]byte(payload), &message); unmarshalErr != nil {
+			messagesProcessedCounter.WithLabelValues(accountdeletion.MessageType, "error").Inc()
+			return fmt.Errorf("failed to unmarshal account deletion message: %w", unmarshalErr)
+		}
+		err = purgeAccount(ctx, purger, message.UserID, logger)
+		if err != nil {
+			messagesProcessedCounter.WithLabelValues(accountdeletion.MessageType, "error").Inc()
+		} else {
+			messagesProcessedCounter.WithLabelValues(accountdeletion.MessageType, "success").Inc()
+		}
+		return err
+	case webhook.DeliveryMessageType:
+		deliverWebhooks(ctx, webhookDispatcher, logger)
+		messagesProcessedCounter.WithLabelValues(webhook.DeliveryMessageType, "success").Inc()
+		return nil
+	default:
+		logger.WithField("message_type", baseMessage.Type).Warn("Unknown message type")
+		messagesProcessedCounter.WithLabelValues("unknown", "ignored").Inc()
+		return nil
+	}
+}
+
+// purgeAccount は猶予期間が過ぎたアカウントのデータをすべて削除する（ADR 0037）
+func purgeAccount(ctx context.Context, purger *accountdeletion.Purger, userID string, logger *logrus.Entry) error {
+	if purger == nil {
+		return fmt.Errorf("account deletion is not configured")
+	}
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	deleted, err := purger.Purge(ctx, parsedUserID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to purge account: %w", err)
+	}
+	if deleted {
+		logger.WithField("user_id", userID).Info("Purged deleted account")
+	}
+	return nil
+}
+
+func generateMonthlySummary(ctx context.Context, db *sql.DB, redisClient rueidis.Client, llmFactory container.LLMClientFactory, lockService container.LockService, userID string, year, month int, logger *logrus.Entry) (err error) {
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"year":    year,
+		"month":   month,
+	}).Info("Generating monthly summary")
+
+	// 1. 分散ロックを取得
+	lockKey := fmt.Sprintf("summary_lock:monthly:%s:%d:%d", userID, year, month)
+	distributedLock := lockService.NewDistributedLock(lockKey, 5*time.Minute)
+
+	fencingToken, locked, err := distributedLock.TryLock(ctx)
+	if err != nil {
+		lockOperationsCounter.WithLabelValues("acquire", "error", "monthly").Inc()
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	if !locked {
+		// Lock already held by another process, skip processing
+		lockOperationsCounter.WithLabelValues("acquire", "failed", "monthly").Inc()
+		logger.WithFields(logrus.Fields{"user_id": userID, "year": year, "month": month}).Info("Monthly summary is already being processed by another instance, skipping")
+		return nil
+	}
+
+	lockOperationsCounter.WithLabelValues("acquire", "success", "monthly").Inc()
+	logger.WithFields(logrus.Fields{"user_id": userID, "year": year, "month": month}).Debug("Acquired lock for monthly summary generation")
+
+	// タスクステータスを「処理中」に更新
+	taskKey := fmt.Sprintf("task:monthly_summary:%s:%d-%d", userID, year, month)
+	setCmd := redisClient.B().Set().Key(taskKey).Value("processing").Ex(600 * time.Second).Build()
+	redisClient.Do(ctx, setCmd)
+
+	notifier := newTaskNotifier(redisClient, userID, taskevent.TypeMonthlySummary, taskevent.MonthTarget(year, month), logger)
+	notifier.publish(ctx, taskevent.StatusProcessing, "")
+	defer func() { notifier.finish(ctx, err) }()
+
+	// 処理中はリースを自動延長し、ロックを失った場合はlockCtxがキャンセルされる
+	lockCtx, stopKeepAlive := distributedLock.KeepAlive(ctx)
+
+	// Ensure lock is released when function exits
+	defer func() {
+		stopKeepAlive()
+
+		// タスクステータスを削除
+		delCmd := redisClient.B().Del().Key(taskKey).Build()
+		redisClient.Do(ctx, delCmd)
+
+		if unlockErr := distributedLock.Unlock(ctx); unlockErr != nil {
+			lockOperationsCounter.WithLabelValues("release", "error", "monthly").Inc()
+			logger.WithError(unlockErr).WithFields(logrus.Fields{"user_id": userID, "year": year, "month": month}).Error("Failed to release lock")
+		} else {
+			lockOperationsCounter.WithLabelValues("release", "success", "monthly").Inc()
+			logger.WithFields(logrus.Fields{"user_id": userID, "year": year, "month": month}).Debug("Released lock for monthly summary generation")
+		}
+	}()
+
+	// 2. 指定された年月の日記エントリーを全て取得（ゴミ箱の日記は除く）
+	query := `
+		SELECT date, content, title, entry_time
+		FROM diaries
+		WHERE user_id = $1 AND EXTRACT(YEAR FROM date) = $2 AND EXTRACT(MONTH FROM date) = $3 AND deleted_at IS NULL
+		ORDER BY date, entry_time NULLS FIRST, entry_index
+	`
+
+	rows, err := db.QueryContext(lockCtx, query, userID, year, month)
+	if err != nil {
+		return fmt.Errorf("failed to get diary entries: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.WithError(err).Error("Failed to close rows")
+		}
+	}()
+
+	var diaryEntries []string
+	for rows.Next() {
+		var date, content, title string
+		var entryTime sql.NullInt64
+		if err := rows.Scan(&date, &content, &title, &entryTime); err != nil {
+			return fmt.Errorf("failed to scan diary entry: %w", err)
+		}
+		diaryEntries = append(diaryEntries, fmt.Sprintf("%s\n%s", diaryEntryHeading(date, title, entryTime), content))
+	}
+
+	if len(diaryEntries) == 0 {
+		return fmt.Errorf("no diary entries found for user %s, year %d, month %d", userID, year, month)
+	}
+
+	// 2. LLMで月次要約生成
+	combinedDiaryEntries := fmt.Sprintf("Diary entries for %d/%d:\n\n%s", year, month,
+		strings.Join(diaryEntries, "\n\n"))
+	monthlySummary, err := generateMonthlySummaryWithLLM(lockCtx, db, llmFactory, userID, combinedDiaryEntries, logger)
+	if err != nil {
+		// APIのコンテンツポリシーによる永続的なブロックはDBに記録してリトライを防ぐ
+		if errors.Is(err, llm.ErrContentBlocked) {
+			userUUID, parseErr := uuid.Parse(userID)
+			if parseErr != nil {
+				return fmt.Errorf("failed to parse user_id: %w", parseErr)
+			}
+			if saveErr := database.UpsertMonthlySummaryError(lockCtx, db, userUUID, year, month, "PROHIBITED_CONTENT", fencingToken); saveErr != nil {
+				logger.WithError(saveErr).WithFields(logrus.Fields{"user_id": userID, "year": year, "month": month}).Error("Failed to save monthly summary error")
+			}
+			logger.WithFields(logrus.Fields{"user_id": userID, "year": year, "month": month}).Warn("Monthly summary blocked by API content policy, saved to DB")
+			notifier.fail(ctx, "PROHIBITED_CONTENT")
+			return nil
+		}
+		return fmt.Errorf("failed to generate monthly summary with LLM: %w", err)
+	}
+
+	// 3. diary_summary_monthsに保存（成功時はerror_reasonをクリア）
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("failed to parse user_id: %w", err)
+	}
+	err = database.UpsertMonthlySummary(lockCtx, db, userUUID, year, month, monthlySummary, llm.ModelGenerateContent, fencingToken)
+	if errors.Is(err, database.ErrStaleFencingToken) {
+		// リース切れの間に新しいロック保持者が保存済みのため、古い結果は破棄する
+		lockOperationsCounter.WithLabelValues("fenced_write", "stale", "monthly").Inc()
+		logger.WithFields(logrus.Fields{"user_id": userID, "year": year, "month": month, "fencing_token": fencingToken}).Warn("Discarded monthly summary written with stale fencing token")
+		notifier.discard()
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save monthly summary: %w", err)
+	}
+
+	summariesGeneratedCounter.WithLabelValues("monthly").Inc()
+	enqueueWebhook(ctx, db, redisClient, userUUID, webhook.EventSummaryGenerated, map[string]any{
+		"year":    year,
+		"month":   month,
+		"summary": monthlySummary,
+	}, logger)
+	logger.WithFields(logrus.Fields{"user_id": userID, "year": year, "month": month}).Info("Successfully generated and saved monthly summary")
+	return nil
+}
+
+func generateMonthlySummaryWithLLM(ctx context.Context, db *sql.DB, llmFactory container.LLMClientFactory, userID, combinedEntries string, logger *logrus.Entry) (string, error) {
+	// ユーザーのGemini API keyをuser_llmsテーブルから取得
+	var apiKey string
+	query := `SELECT key FROM user_llms WHERE user_id = $1 AND llm_provider = 1`
+	err := db.QueryRowContext(ctx, query, userID).Scan(&apiKey)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to get user's Gemini API key")
+		return "", fmt.Errorf("failed to get user's Gemini API key: %w", err)
+	}
+
+	// Gemini クライアント作成
+	geminiClient, err := llmFactory.CreateGeminiClient(ctx, apiKey)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create Gemini client")
+		return "", fmt.Errorf("failed to create Gemini client: %w", err)
+	}
+	defer func() {
+		if closeErr := geminiClient.Close(); closeErr != nil {
+			logger.WithError(closeErr).Error("Failed to close Gemini client")
+		}
+	}()
+
+	// 月次要約生成
+	summary, err := geminiClient.GenerateSummary(ctx, combinedEntries)
+	if err != nil {
+		logger.WithError(err).Error("Failed to generate monthly summary")
+		return "", fmt.Errorf("failed to generate monthly summary: %w", err)
+	}
+
+	logger.Info("Successfully generated monthly summary using Gemini API")
+	return summary, nil
+}
+
+func generateLatestTrend(ctx context.Context, db *sql.DB, redisClient rueidis.Client, llmFactory container.LLMClientFactory, lockService container.LockService, userID, periodStartStr, periodEndStr string, logger *logrus.Entry) (err error) {
+	logger.WithFields(logrus.Fields{
+		"user_id":      userID,
+		"period_start": periodStartStr,
+		"period_end":   periodEndStr,
+	}).Info("Generating latest trend analysis")
+
+	// 1. 分散ロックを取得
+	lockKey := fmt.Sprintf("trend_lock:latest:%s", userID)
+	distributedLock := lockService.NewDistributedLock(lockKey, 5*time.Minute)
+
+	fencingToken, locked, err := distributedLock.TryLock(ctx)
+	if err != nil {
+		lockOperationsCounter.WithLabelValues("acquire", "error", "latest_trend").Inc()
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	if !locked {
+		// Lock already held by another process, skip processing
+		lockOperationsCounter.WithLabelValues("acquire", "failed", "latest_trend").Inc()
+		logger.WithFields(logrus.Fields{
+			"user_id": userID,
+		}).Info("Latest trend is already being processed by another instance, skipping")
+		return nil
+	}
+
+	lockOperationsCounter.WithLabelValues("acquire", "success", "latest_trend").Inc()
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+	}).Debug("Acquired lock for latest trend generation")
+
+	notifier := newTaskNotifier(redisClient, userID, taskevent.TypeLatestTrend, "", logger)
+	notifier.publish(ctx, taskevent.StatusProcessing, "")
+	defer func() { notifier.finish(ctx, err) }()
+
+	// 処理中はリースを自動延長し、ロックを失った場合はlockCtxがキャンセルされる
+	lockCtx, stopKeepAlive := distributedLock.KeepAlive(ctx)
+
+	// Ensure lock is released when function exits
+	defer func() {
+		stopKeepAlive()
+
+		if unlockErr := distributedLock.Unlock(ctx); unlockErr != nil {
+			lockOperationsCounter.WithLabelValues("release", "error", "latest_trend").Inc()
+			logger.WithError(unlockErr).WithFields(logrus.Fields{
+				"user_id": userID,
+			}).Error("Failed to release lock")
+		} else {
+			lockOperationsCounter.WithLabelValues("release", "success", "latest_trend").Inc()
+			logger.WithFields(logrus.Fields{
+				"user_id": userID,
+			}).Debug("Released lock for latest trend generation")
+		}
+	}()
+
+	// 2. 期間をパース
+	periodStart, err := time.Parse(time.RFC3339, periodStartStr)
+	if err != nil {
+		return fmt.Errorf("failed to parse period_start: %w", err)
+	}
+	periodEnd, err := time.Parse(time.RFC3339, periodEndStr)
+	if err != nil {
+		return fmt.Errorf("failed to parse period_end: %w", err)
+	}
+
+	// 3. 指定期間の日記エントリーを取得（ゴミ箱の日記は除く）
+	query := `
+		SELECT date, content, title, entry_time
+		FROM diaries
+		WHERE user_id = $1 AND date >= $2 AND date <= $3 AND deleted_at IS NULL
+		ORDER BY date, entry_time NULLS FIRST, entry_index
+	`
+
+	rows, err := db.QueryContext(lockCtx, query, userID, periodStart, periodEnd)
+	if err != nil {
+		return fmt.Errorf("failed to get diary entries: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.WithError(err).Error("Failed to close rows")
+		}
+	}()
+
+	var diaryEntries []string
+	for rows.Next() {
+		var date, content, title string
+		var entryTime sql.NullInt64
+		if err := rows.Scan(&date, &content, &title, &entryTime); err != nil {
+			return fmt.Errorf("failed to scan diary entry: %w", err)
+		}
+		diaryEntries = append(diaryEntries, fmt.Sprintf("%s\n%s", diaryEntryHeading(date, title, entryTime), content))
+	}
+
+	if len(diaryEntries) < constants.MinDiaryEntriesForTrend {
+		logger.WithFields(logrus.Fields{
+			"user_id":       userID,
+			"entry_count":   len(diaryEntries),
+			"required_days": constants.MinDiaryEntriesForTrend,
+		}).Info("Not enough diary entries for latest trend analysis")
+		return fmt.Errorf("not enough diary entries (found %d, need at least %d)", len(diaryEntries), constants.MinDiaryEntriesForTrend)
+	}
+
+	// 4. LLMでトレンド分析生成
+	// 日本時間ベースで昨日の日付を取得
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		logger.WithError(err).Warn("Failed to load Asia/Tokyo location, using fixed offset")
+		jst = time.FixedZone("Asia/Tokyo", 9*60*60)
+	}
+	periodEndJST := periodEnd.In(jst)
+	combinedDiaryEntries := fmt.Sprintf("Diary entries from %s to %s:\n\n%s", periodStart.Format("2006-01-02"), periodEnd.Format("2006-01-02"),
+		strings.Join(diaryEntries, "\n\n"))
+	trendAnalysisJSON, err := generateLatestTrendWithLLM(lockCtx, db, llmFactory, userID, combinedDiaryEntries, periodEndJST, logger)
+	if err != nil {
+		return fmt.Errorf("failed to generate latest trend with LLM: %w", err)
+	}
+
+	// 5. JSON形式のレスポンスをパース
+	var analysisData struct {
+		Health       string `json:"health"`
+		HealthReason string `json:"health_reason"`
+		Mood         string `json:"mood"`
+		MoodReason   string `json:"mood_reason"`
+		Activities   string `json:"activities"`
+	}
+	if err := json.Unmarshal([]byte(trendAnalysisJSON), &analysisData); err != nil {
+		logger.WithError(err).Error("Failed to parse trend analysis JSON")
+		return fmt.Errorf("failed to parse trend analysis JSON: %w", err)
+	}
+
+	// 6. Redisに保存（TTL: 25時間）
+	// 毎日4時に更新されるため、25時間のTTLで次回更新までの余裕を確保
+	trendData := map[string]any{
+		"user_id":       userID,
+		"health":        analysisData.Health,
+		"health_reason": analysisData.HealthReason,
+		"mood":          analysisData.Mood,
+		"mood_reason":   analysisData.MoodReason,
+		"activities":    analysisData.Activities,
+		"period_start":  periodStartStr,
+		"period_end":    periodEndStr,
+		"generated_at":  time.Now().Format(time.RFC3339),
+		"model_version": llm.ModelGenerateContent,
+	}
+
+	trendDataJSON, err := json.Marshal(trendData)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trend data: %w", err)
+	}
+
+	saved, err := saveLatestTrend(lockCtx, redisClient, userID, trendDataJSON, fencingToken, 25*time.Hour) // 25時間
+	if err != nil {
+		return fmt.Errorf("failed to save trend data to Redis: %w", err)
+	}
+	if !saved {
+		// リース切れの間に新しいロック保持者が保存済みのため、古い結果は破棄する
+		lockOperationsCounter.WithLabelValues("fenced_write", "stale", "latest_trend").Inc()
+		logger.WithFields(logrus.Fields{
+			"user_id":       userID,
+			"fencing_token": fencingToken,
+		}).Warn("Discarded latest trend written with stale fencing token")
+		notifier.discard()
+		return nil
+	}
+
+	summariesGeneratedCounter.WithLabelValues("latest_trend").Inc()
+	if userUUID, parseErr := uuid.Parse(userID); parseErr == nil {
+		enqueueWebhook(ctx, db, redisClient, userUUID, webhook.EventTrendGenerated, trendData, logger)
+	}
+	logger.WithFields(logrus.Fields{
+		"user_id": userID,
+	}).Info("Successfully generated and saved latest trend analysis")
+	return nil
+}
+
+// saveLatestTrend はトレンドデータをRedisに保存する
+// 保存済みのフェンシングトークンより古いトークンの場合は保存せずfalseを返す
+func saveLatestTrend(ctx context.Context, redisClient rueidis.Client, userID string, trendDataJSON []byte, fencingToken int64, ttl time.Duration) (bool, error) {
+	script := `
+		local current = tonumber(redis.call("GET", KEYS[2]) or "0")
+		if current > tonumber(ARGV[2]) then
+			return 0
+		end
+		redis.call("SET", KEYS[1], ARGV[1], "EX", ARGV[3])
+		redis.call("SET", KEYS[2], ARGV[2], "EX", ARGV[3])
+		return 1
+	`
+
+	trendKey := fmt.Sprintf("latest_trend:%s", userID)
+	// 保存済みトレンドのトークンを保持するキー（ロックのカウンターとは別）
+	// trendKeyはハッシュタグを含まないため、キー全体を{}で囲むとtrendKeyと同じスロットに割り当てられる
+	fencingKey := fmt.Sprintf("{%s}:fencing", trendKey)
+	cmd := redisClient.B().Eval().Script(script).Numkeys(2).Key(trendKey, fencingKey).
+		Arg(string(trendDataJSON), strconv.FormatInt(fencingToken, 10), strconv.Itoa(int(ttl.Seconds()))).Build()
+	saved, err := redisClient.Do(ctx, cmd).AsInt64()
+	if err != nil {
+		return false, err
+	}
+	return saved == 1, nil
+}
+
+func generateLatestTrendWithLLM(ctx context.Context, db *sql.DB, llmFactory container.LLMClientFactory, userID, combinedEntries string, yesterday time.Time, logger *logrus.Entry) (string, error) {
+	// ユーザーのGemini API keyをuser_llmsテーブルから取得
+	var apiKey string
+	query := `SELECT key FROM user_llms WHERE user_id = $1 AND llm_provider = 1`
+	err := db.QueryRowContext(ctx, query, userID).Scan(&apiKey)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to get user's Gemini API key")
+		return "", fmt.Errorf("failed to get user's Gemini API key: %w", err)
+	}
+
+	// Gemini クライアント作成
+	geminiClient, err := llmFactory.CreateGeminiClient(ctx, apiKey)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create Gemini client")
+		return "", fmt.Errorf("failed to create Gemini client: %w", err)
+	}
+	defer func() {
+		if closeErr := geminiClient.Close(); closeErr != nil {
+			logger.WithError(closeErr).Error("Failed to close Gemini client")
+		}
+	}()
+
+	// トレンド分析生成
+	yesterdayStr := yesterday.Format("2006-01-02")
+	analysis, err := geminiClient.GenerateLatestTrend(ctx, combinedEntries, yesterdayStr)
+	if err != nil {
+		logger.WithError(err).Error("Failed to generate latest trend analysis")
+		return "", fmt.Errorf("failed to generate latest trend analysis: %w", err)
+	}
+
+	logger.Info("Successfully generated latest trend analysis using Gemini API")
+	return analysis, nil
+}
+
+func generateDiaryHighlight(ctx context.Context, db *sql.DB, redisClient rueidis.Client, llmFactory container.LLMClientFactory, lockService container.LockService, userID, diaryID string, logger *logrus.Entry) (err error) {
+	logger.WithFields(logrus.Fields{
+		"user_id":  userID,
+		"diary_id": diaryID,
+	}).Info("Generating diary highlight")
+
+	// 1. 分散ロックを取得
+	lockKey := fmt.Sprintf("highlight_lock:%s:%s", userID, diaryID)
+	distributedLock := lockService.NewDistributedLock(lockKey, 5*time.Minute)
+
+	fencingToken, locked, err := distributedLock.TryLock(ctx)
+	if err != nil {
+		lockOperationsCounter.WithLabelValues("acquire", "error", "diary_highlight").Inc()
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	if !locked {
+		// Lock already held by another process, skip processing
+		lockOperationsCounter.WithLabelValues("acquire", "failed", "diary_highlight").Inc()
+		logger.WithFields(logrus.Fields{
+			"user_id":  userID,
+			"diary_id": diaryID,
+		}).Info("Diary highlight is already being processed by another instance, skipping")
+		return nil
+	}
+
+	lockOperationsCounter.WithLabelValues("acquire", "success", "diary_highlight").Inc()
+	logger.WithFields(logrus.Fields{
+		"user_id":  userID,
+		"diary_id": diaryID,
+	}).Debug("Acquired lock for diary highlight generation")
+
+	// タスクステータスを「処理中」に更新
+	taskKey := fmt.Sprintf("task:diary_highlight:%s:%s", userID, diaryID)
+	timeout := time.Duration(getTaskTimeout()) * time.Second
+	setCmd := redisClient.B().Set().Key(taskKey).Value("processing").Ex(timeout).Build()
+	redisClient.Do(ctx, setCmd)
+
+	notifier := newTaskNotifier(redisClient, userID, taskevent.TypeDiaryHighlight, diaryID, logger)
+	notifier.publish(ctx, taskevent.StatusProcessing, "")
+	defer func() { notifier.finish(ctx, err) }()
+
+	// 処理中はリースを自動延長し、ロックを失った場合はlockCtxがキャンセルされる
+	lockCtx, stopKeepAlive := distributedLock.KeepAlive(ctx)
+
+	// Ensure lock is released when function exits
+	defer func() {
+		stopKeepAlive()
+
+		// タスクステータスを削除
+		delCmd := redisClient.B().Del().Key(taskKey).Build()
+		redisClient.Do(ctx, delCmd)
+
+		if unlockErr := distributedLock.Unlock(ctx); unlockErr != nil {
+			lockOperationsCounter.WithLabelValues("release", "error", "diary_highlight").Inc()
+			logger.WithError(unlockErr).WithFields(logrus.Fields{
+				"user_id":  userID,
+				"diary_id": diaryID,
+			}).Error("Failed to release lock")
+		} else {
+			lockOperationsCounter.WithLabelValues("release", "success", "diary_highlight").Inc()
+			logger.WithFields(logrus.Fields{
+				"user_id":  userID,
+				"diary_id": diaryID,
+			}).Debug("Released lock for diary highlight generation")
+		}
+	}()
+
+	// 2. 日記の内容を取得（ゴミ箱に移動・削除済みの日記はスキップ）
+	var diaryContent string
+	var diaryUpdatedAt int64
+	query := `SELECT content, updated_at FROM diaries WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
+	err = db.QueryRowContext(lockCtx, query, diaryID, userID).Scan(&diaryContent, &diaryUpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.WithFields(logrus.Fields{
+			"user_id":  userID,
+			"diary_id": diaryID,
+		}).Info("Diary not found or in trash, skipping diary highlight generation")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get diary content: %w", err)
+	}
+
+	// 3. LLMでハイライト生成
+	highlights, err := generateDiaryHighlightWithLLM(lockCtx, db, llmFactory, userID, diaryContent, logger)
+	if err != nil {
+		return fmt.Errorf("failed to generate highlight with LLM: %w", err)
+	}
+
+	// 3.1. ハイライトの位置情報をバリデーション
+	contentLength := len([]rune(diaryContent))
+	validHighlights := make([]map[string]any, 0, len(highlights))
+	for _, h := range highlights {
+		// startとendを取得
+		startFloat, ok1 := h["start"].(float64)
+		endFloat, ok2 := h["end"].(float64)
+		text, ok3 := h["text"].(string)
+
+		if !ok1 || !ok2 || !ok3 {
+			logger.WithFields(logrus.Fields{
+				"highlight": h,
+			}).Warn("Invalid highlight format, skipping")
+			continue
+		}
+
+		start := int(startFloat)
+		end := int(endFloat)
+
+		// 位置情報の妥当性チェック
+		if start < 0 || end > contentLength || start >= end {
+			logger.WithFields(logrus.Fields{
+				"start":          start,
+				"end":            end,
+				"content_length": contentLength,
+			}).Warn("Invalid highlight position, skipping")
+			continue
+		}
+
+		// 実際のテキストと一致するかチェック
+		actualText := string([]rune(diaryContent)[start:end])
+		if actualText != text {
+			logger.WithFields(logrus.Fields{
+				"expected": text,
+				"actual":   actualText,
+				"start":    start,
+				"end":      end,
+			}).Warn("Highlight text mismatch, skipping")
+			continue
+		}
+
+		validHighlights = append(validHighlights, h)
+	}
+
+	// 有効なハイライトが1つもない場合はエラー
+	if len(validHighlights) == 0 {
+		return fmt.Errorf("no valid highlights generated")
+	}
+
+	// 4. diary_highlightsに保存
+	diaryUUID, err := uuid.Parse(diaryID)
+	if err != nil {
+		return fmt.Errorf("failed to parse diary_id: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("failed to parse user_id: %w", err)
+	}
+
+	// validHighlightsをJSONBに変換
+	highlightsJSON, err := json.Marshal(validHighlights)
+	if err != nil {
+		return fmt.Errorf("failed to marshal highlights: %w", err)
+	}
+
+	err = database.UpsertDiaryHighlight(lockCtx, db, diaryUUID, userUUID, highlightsJSON, fencingToken)
+	if errors.Is(err, database.ErrStaleFencingToken) {
+		// リース切れの間に新しいロック保持者が保存済みのため、古い結果は破棄する
+		lockOperationsCounter.WithLabelValues("fenced_write", "stale", "diary_highlight").Inc()
+		logger.WithFields(logrus.Fields{
+			"user_id":       userID,
+			"diary_id":      diaryID,
+			"fencing_token": fencingToken,
+		}).Warn("Discarded diary highlight written with stale fencing token")
+		notifier.discard()
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save highlight: %w", err)
+	}
+
+	summariesGeneratedCounter.WithLabelValues("diary_highlight").Inc()
+	enqueueWebhook(ctx, db, redisClient, userUUID, webhook.EventHighlightGenerated, map[string]any{
+		"diary_id":   diaryID,
+		"highlights": validHighlights,
+	}, logger)
+	logger.WithFields(logrus.Fields{
+		"user_id":  userID,
+		"diary_id": diaryID,
+	}).Info("Successfully generated and saved diary highlight")
+	return nil
+}
+
+func generateDiaryHighlightWithLLM(ctx context.Context, db *sql.DB, llmFactory container.LLMClientFactory, userID, content string, logger *logrus.Entry) ([]map[string]any, error) {
+	// ユーザーのGemini API keyをuser_llmsテーブルから取得
+	var apiKey string
+	query := `SELECT key FROM user_llms WHERE user_id = $1 AND llm_provider = 1`
+	err := db.QueryRowContext(ctx, query, userID).Scan(&apiKey)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to get user's Gemini API key")
+		return nil, fmt.Errorf("failed to get user's Gemini API key: %w", err)
+	}
+
+	// Gemini クライアント作成
+	geminiClient, err := llmFactory.CreateGeminiClient(ctx, apiKey)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create Gemini client")
+		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
+	}
+	defer func() {
+		if closeErr := geminiClient.Close(); closeErr != nil {
+			logger.WithError(closeErr).Error("Failed to close Gemini client")
+		}
+	}()
+
+	// ハイライト生成
+	highlightsJSON, err := geminiClient.GenerateHighlights(ctx, content)
+	if err != nil {
+		logger.WithError(err).Error("Failed to generate highlights")
+		return nil, fmt.Errorf("failed to generate highlights: %w", err)
+	}
+
+	// JSON文字列をパース
+	var highlights []map[string]any
+	if err := json.Unmarshal([]byte(highlightsJSON), &highlights); err != nil {
+		logger.WithError(err).WithField("response", highlightsJSON).Error("Failed to parse highlights JSON")
+		return nil, fmt.Errorf("failed to parse highlights JSON (response: %s): %w", highlightsJSON, err)
+	}
+
+	// ハイライトの数が妥当かチェック(1~5個)
+	if len(highlights) == 0 {
+		logger.Warn("LLM returned empty highlights array")
+		return nil, fmt.Errorf("LLM returned empty highlights array")
+	}
+	if len(highlights) > 5 {
+		logger.WithField("count", len(highlights)).Warn("LLM returned too many highlights, trimming to 5")
+		highlights = highlights[:5]
+	}
+
+	logger.Info("Successfully generated highlights using Gemini API")
+	return highlights, nil
+}
+
+func generateDiaryEmbedding(ctx context.Context, db *sql.DB, redisClient rueidis.Client, llmFactory container.LLMClientFactory, geminiRateLimiter *rate.Limiter, userID, diaryID string, logger *logrus.Entry) (err error) {
+	logger.WithFields(logrus.Fields{
+		"user_id":  userID,
+		"diary_id": diaryID,
+	}).Info("Generating diary embedding")
+
+	// 1. ユーザーのAPIキーと意味的検索の有効化を確認（未設定/無効の場合はスキップ）
+	var apiKey string
+	var semanticSearchEnabled bool
+	apiKeyQuery := `SELECT key, semantic_search_enabled FROM user_llms WHERE user_id = $1 AND llm_provider = 1`
+	err = db.QueryRowContext(ctx, apiKeyQuery, userID).Scan(&apiKey, &semanticSearchEnabled)
+	if err != nil {
+		// APIキー未設定はスキップ（エラーではない）
+		logger.WithFields(logrus.Fields{
+			"user_id":  userID,
+			"diary_id": diaryID,
+		}).Info("User has no Gemini API key, skipping diary embedding generation")
+		return nil
+	}
+	if !semanticSearchEnabled {
+		// 意味的検索が無効ならスキップ
+		logger.WithFields(logrus.Fields{
+			"user_id":  userID,
+			"diary_id": diaryID,
+		}).Debug("Semantic search not enabled for user, skipping diary embedding generation")
+		return nil
+	}
+
+	notifier := newTaskNotifier(redisClient, userID, taskevent.TypeDiaryEmbedding, diaryID, logger)
+	notifier.publish(ctx, taskevent.StatusProcessing, "")
+	defer func() { notifier.finish(ctx, err) }()
+
+	// 2. 日記の本文と日付を取得（ゴミ箱に移動・削除済みの日記はスキップ）
+	var diaryContent string
+	var diaryDate time.Time
+	contentQuery := `SELECT content, date FROM diaries WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
+	err = db.QueryRowContext(ctx, contentQuery, diaryID, userID).Scan(&diaryContent, &diaryDate)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.WithFields(logrus.Fields{
+			"user_id":  userID,
+			"diary_id": diaryID,
+		}).Info("Diary not found or in trash, skipping diary embedding generation")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get diary content: %w", err)
+	}
+
+	// 3. Gemini クライアント作成
+	geminiClient, err := llmFactory.CreateGeminiClient(ctx, apiKey)
+	if err != nil {
+		return fmt.Errorf("failed to create Gemini client: %w", err)
+	}
+	defer func() {
+		if closeErr := geminiClient.Close(); closeErr != nil {
+			logger.WithError(closeErr).Error("Failed to close Gemini client")
+		}
+	}()
+
+	// 4. 日記を話題ごとのチャンクに分割する（失敗時は日記全体を1チャンクとしてフォールバック）
+	if err := geminiRateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limiter cancelled before SplitDiaryIntoChunks: %w", err)
+	}
+	chunkDataList, err := geminiClient.SplitDiaryIntoChunks(ctx, diaryContent)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"user_id":  userID,
+			"diary_id": diaryID,
+		}).WithError(err).Warn("Failed to split diary into chunks, falling back to single chunk")
+		chunkDataList = []llm.DiaryChunkData{{Content: diaryContent, Summary: ""}}
+	}
+
+	// 5. UUIDをパース
+	diaryUUID, err := uuid.Parse(diaryID)
+	if err != nil {
+		return fmt.Errorf("failed to parse diary_id: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("failed to parse user_id: %w", err)
+	}
+
+	// 6. 各チャンクに日付コンテキストを付与してembedding生成（並列実行でレイテンシを削減）
+	type embeddingResult struct {
+		chunk database.DiaryChunk
+		err   error
+	}
+	embResults := make([]embeddingResult, len(chunkDataList))
+	var embWg sync.WaitGroup
+	for i, chunkData := range chunkDataList {
+		embWg.Add(1)
+		go func(idx int, cd llm.DiaryChunkData) {
+			defer embWg.Done()
+			// 時間的クエリの精度向上のため日付情報を先頭に付与する
+			enrichedChunk := fmt.Sprintf("%d年%d月%d日の日記:\n%s", diaryDate.Year(), int(diaryDate.Month()), diaryDate.Day(), cd.Content)
+			if waitErr := geminiRateLimiter.Wait(ctx); waitErr != nil {
+				embResults[idx] = embeddingResult{err: fmt.Errorf("rate limiter cancelled before GenerateEmbedding for chunk %d: %w", idx, waitErr)}
+				return
+			}
+			embedding, err := geminiClient.GenerateEmbedding(ctx, enrichedChunk, true)
+			if err != nil {
+				embResults[idx] = embeddingResult{err: fmt.Errorf("failed to generate embedding for chunk %d: %w", idx, err)}
+				return
+			}
+			embResults[idx] = embeddingResult{
+				chunk: database.DiaryChunk{
+					Index:             idx,
+					Content:           cd.Content,
+					Summary:           cd.Summary,
+					Embedding:         embedding,
+					SplitModelVersion: llm.ModelGenerateContent,
+				},
+			}
+		}(i, chunkData)
+	}
+	embWg.Wait()
+
+	diaryChunks := make([]database.DiaryChunk, 0, len(chunkDataList))
+	for _, r := range embResults {
+		if r.err != nil {
+			return r.err
+		}
+		diaryChunks = append(diaryChunks, r.chunk)
+	}
+
+	// 7. diary_embeddingsテーブルにチャンク単位でUPSERT
+	if err := database.UpsertDiaryChunkEmbeddings(ctx, db, diaryUUID, userUUID, diaryChunks, llm.ModelEmbedding); err != nil {
+		return fmt.Errorf("failed to upsert diary chunk embeddings: %w", err)
+	}
+
+	summariesGeneratedCounter.WithLabelValues("diary_embedding").Inc()
+	logger.WithFields(logrus.Fields{
+		"user_id":     userID,
+		"diary_id":    diaryID,
+		"chunk_count": len(diaryChunks),
+	}).Info("Successfully generated and saved diary chunk embeddings")
+	return nil
+}
+
+// generateDataExport は依頼されたデータエクスポートのアーカイブを作成してストレージに保存する
+// アーカイブは一時ファイルに書き出してからアップロードし、日記の件数が多くてもメモリに載せない
+func generateDataExport(ctx context.Context, db *sql.DB, redisClient rueidis.Client, exportStorage storage.Storage, expiry time.Duration, userID, exportID string, logger *logrus.Entry) (err error) {
+	fields := logrus.Fields{"user_id": userID, "export_id": exportID}
+	logger.WithFields(fields).Info("Generating data export")
+
+	exportUUID, err := uuid.Parse(exportID)
+	if err != nil {
+		return fmt.Errorf("failed to parse export_id: %w", err)
+	}
+	if exportStorage == nil {
+		return fmt.Errorf("storage is not configured")
+	}
+
+	// 1. 生成待ちの行を生成中にする（他のsubscriberが処理済み・処理中ならスキップ）
+	export, err := database.ClaimDataExport(ctx, db, exportUUID, time.Now().Unix())
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.WithFields(fields).Info("Data export is not queued, skipping")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to claim data export: %w", err)
+	}
+	if export.UserID.String() != userID {
+		return fmt.Errorf("data export does not belong to user")
+	}
+
+	notifier := newTaskNotifier(redisClient, userID, taskevent.TypeDataExport, exportID, logger)
+	notifier.publish(ctx, taskevent.StatusProcessing, "")
+	defer func() { notifier.finish(ctx, err) }()
+
+	// 失敗した場合は理由を記録し、期限を過ぎたらスケジューラーが削除する
+	defer func() {
+		if err == nil {
+			return
+		}
+		now := time.Now()
+		export.Status = database.DataExportStatusFailed
+		export.ErrorMessage = err.Error()
+		export.ExpiresAt = sql.NullInt64{Int64: now.Add(expiry).Unix(), Valid: true}
+		export.UpdatedAt = now.Unix()
+		if updateErr := export.Update(context.WithoutCancel(ctx), db); updateErr != nil {
+			logger.WithError(updateErr).WithFields(fields).Error("Failed to mark data export as failed")
+		}
+	}()
+
+	// 2. 一時ファイルにアーカイブを書き出す
+	f, err := os.CreateTemp("", "umi-export-*.zip")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	manifest, err := takeout.Write(ctx, takeout.Source{DB: db, Redis: redisClient, Storage: exportStorage}, export.UserID, f, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("failed to get archive size: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind archive: %w", err)
+	}
+
+	// 3. ストレージにアップロードして完了にする
+	key := takeout.StorageKey(export.UserID, export.ID)
+	if err := exportStorage.Put(ctx, key, f, size, takeout.ContentType); err != nil {
+		return fmt.Errorf("failed to upload archive: %w", err)
+	}
+	now := time.Now()
+	export.Status = database.DataExportStatusSucceeded
+	export.StorageKey = key
+	export.SizeBytes = size
+	export.CompletedAt = sql.NullInt64{Int64: now.Unix(), Valid: true}
+	export.ExpiresAt = sql.NullInt64{Int64: now.Add(expiry).Unix(), Valid: true}
+	export.UpdatedAt = now.Unix()
+	if err := export.Update(ctx, db); err != nil {
+		// 行を更新できなかったアーカイブは参照されないため削除する
+		if deleteErr := exportStorage.Delete(context.WithoutCancel(ctx), key); deleteErr != nil {
+			logger.WithError(deleteErr).WithFields(fields).Warn("Failed to delete orphaned archive")
+		}
+		return fmt.Errorf("failed to update data export: %w", err)
+	}
+
+	logger.WithFields(fields).WithFields(logrus.Fields{
+		"size_bytes": size,
+		"file_count": len(manifest.Files),
+	}).Info("Successfully generated data export")
+	return nil
+}
+
+const (
+	// webhookWorkerInterval はリトライ待ちのWebhook配信を確認する間隔
+	webhookWorkerInterval = 30 * time.Second
+	// webhookDeliveryRetention は送信完了した配信ログの保持期間
+	webhookDeliveryRetention = 30 * 24 * time.Hour
+)
+
+// suggestDiaryTags はユーザーの既存のタグから日記に合うものをLLMに選ばせ、提案として保存する
+// 自動タグ付けが無効、タグが1つもない、本文が空の場合はスキップする
+func suggestDiaryTags(ctx context.Context, db *sql.DB, redisClient rueidis.Client, llmFactory container.LLMClientFactory, geminiRateLimiter *rate.Limiter, userID, diaryID string, logger *logrus.Entry) (err error) {
+	fields := logrus.Fields{"user_id": userID, "diary_id": diaryID}
+	logger.WithFields(fields).Info("Suggesting diary tags")
+
+	// 1. ユーザーのAPIキーと自動タグ付けの有効化を確認（未設定/無効の場合はスキップ）
+	var apiKey string
+	var autoTaggingEnabled bool
+	apiKeyQuery := `SELECT key, auto_tagging_enabled FROM user_llms WHERE user_id = $1 AND llm_provider = 1`
+	if scanErr := db.QueryRowContext(ctx, apiKeyQuery, userID).Scan(&apiKey, &autoTaggingEnabled); scanErr != nil || !autoTaggingEnabled {
+		logger.WithFields(fields).Debug("Auto tagging not enabled for user, skipping diary tag suggestion")
+		return nil
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	diaryUUID, err := uuid.Parse(diaryID)
+	if err != nil {
+		return fmt.Errorf("invalid diary ID: %w", err)
+	}
+
+	// 2. 提案の候補となるタグと日記の本文を取得
+	tags, err := database.TagsByUserID(ctx, db, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+	if len(tags) == 0 {
+		logger.WithFields(fields).Debug("User has no tags, skipping diary tag suggestion")
+		return nil
+	}
+	diary, err := database.ActiveDiaryByID(ctx, db, diaryUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get diary: %w", err)
+	}
+	if diary.UserID != userUUID || strings.TrimSpace(diary.Content) == "" {
+		return nil
+	}
+
+	notifier := newTaskNotifier(redisClient, userID, taskevent.TypeDiaryTagSuggestion, diaryID, logger)
+	notifier.publish(ctx, taskevent.StatusProcessing, "")
+	defer func() { notifier.finish(ctx, err) }()
+
+	// 3. LLMでタグを選ぶ
+	geminiClient, err := llmFactory.CreateGeminiClient(ctx, apiKey)
+	if err != nil {
+		return fmt.Errorf("failed to create Gemini client: %w", err)
+	}
+	defer func() {
+		if closeErr := geminiClient.Close(); closeErr != nil {
+			logger.WithError(closeErr).Error("Failed to close Gemini client")
+		}
+	}()
+	if err := geminiRateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limiter cancelled before SuggestTags: %w", err)
+	}
+	tagNames := make([]string, 0, len(tags))
+	for _, t := range tags {
+		tagNames = append(tagNames, t.Name)
+	}
+	names, err := geminiClient.SuggestTags(ctx, diary.Content, tagNames)
+	if err != nil {
+		return fmt.Errorf("failed to suggest tags with LLM: %w", err)
+	}
+
+	// 4. 提案を保存し、差分同期でクライアントに提案を届ける（日記・タグが削除されていた場合は外部キー制約で失敗する）
+	tagIDs := matchSuggestedTags(names, tags)
+	err = database.RwTransaction(ctx, db, func(tx *sql.Tx) error {
+		if err := database.ReplaceDiaryTagSuggestions(ctx, tx, diaryUUID, tagIDs, time.Now().Unix()); err != nil {
+			return err
+		}
+		return database.TouchDiaries(ctx, tx, userUUID, []uuid.UUID{diaryUUID})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save diary tag suggestions: %w", err)
+	}
+
+	logger.WithFields(fields).WithField("count", len(tagIDs)).Info("Successfully suggested diary tags")
+	return nil
+}
+
+// matchSuggestedTags はLLMが返したタグ名をタグIDに変換する
+// 候補にない名前・重複は除き、最大maxSuggestedTags件に制限する
+func matchSuggestedTags(names []string, tags []*database.Tag) []uuid.UUID {
+	byName := make(map[string]uuid.UUID, len(tags))
+	for _, t := range tags {
+		byName[t.Name] = t.ID
+	}
+	seen := make(map[uuid.UUID]bool, len(names))
+	tagIDs := make([]uuid.UUID, 0, maxSuggestedTags)
+	for _, name := range names {
+		id, ok := byName[strings.TrimSpace(name)]
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		tagIDs = append(tagIDs, id)
+		if len(tagIDs) == maxSuggestedTags {
+			break
+		}
+	}
+	return tagIDs
+}
+
+// enqueueWebhook はAI生成の完了をユーザーのWebhookに配信登録する
+// 通知は補助的な機能のため、失敗しても生成処理は成功扱いにしてログに記録するのみ
+func enqueueWebhook(ctx context.Context, db *sql.DB, redisClient rueidis.Client, userID uuid.UUID, event string, data any, logger *logrus.Entry) {
+	if err := webhook.Enqueue(ctx, db, redisClient, userID, event, data); err != nil {
+		logger.WithError(err).WithFields(logrus.Fields{
+			"user_id": userID,
+			"event":   event,
+		}).Error("Failed to enqueue webhook")
+	}
+}
+
+// deliverWebhooks は送信予定時刻を過ぎたWebhook配信を送信する
+// 複数のワーカーが同時に実行しても、配信行は1つのワーカーのみが取得する
+func deliverWebhooks(ctx context.Context, dispatcher *webhook.Dispatcher, logger *logrus.Entry) {
+	result, err := dispatcher.DeliverDue(ctx)
+	webhookDeliveriesCounter.WithLabelValues("succeeded").Add(float64(result.Succeeded))
+	webhookDeliveriesCounter.WithLabelValues("retrying").Add(float64(result.Retrying))
+	webhookDeliveriesCounter.WithLabelValues("failed").Add(float64(result.Failed))
+	if err != nil {
+		logger.WithError(err).Error("Failed to deliver webhooks")
+		return
+	}
+	if result.Succeeded+result.Retrying+result.Failed > 0 {
+		logger.WithFields(logrus.Fields{
+			"succeeded": result.Succeeded,
+			"retrying":  result.Retrying,
+			"failed":    result.Failed,
+		}).Info("Delivered webhooks")
+	}
+}
+
+// runWebhookWorker はctxが終了するまで、リトライ待ちのWebhook配信の送信と古い配信ログの削除を定期的に行う
+// 新しい配信はdiary_eventsの通知で即時に送信されるため、ここでは主にバックオフ後の再送を担う
+func runWebhookWorker(ctx context.Context, db *sql.DB, dispatcher *webhook.Dispatcher, logger *logrus.Entry) {
+	ticker := time.NewTicker(webhookWorkerInterval)
+	defer ticker.Stop()
+
+	var lastPrunedAt time.Time
+	for {
+		select {
+		case <-ticker.C:
+			deliverWebhooks(ctx, dispatcher, logger)
+
+			if time.Since(lastPrunedAt) < time.Hour {
+				continue
+			}
+			lastPrunedAt = time.Now()
+			deleted, err := database.DeleteFinishedWebhookDeliveriesBefore(ctx, db, time.Now().Add(-webhookDeliveryRetention).Unix())
+			if err != nil {
+				logger.WithError(err).Error("Failed to prune webhook deliveries")
+			} else if deleted > 0 {
+				logger.WithField("deleted", deleted).Info("Pruned old webhook deliveries")
+			}
+		case <-ctx.Done():
+			logger.Info("Webhook worker stopping")
+			return
+		}
+	}
+}
+
+// diaryEntryHeading はLLMに渡す日記本文の見出しを返す（例: [2024-05-01 07:30 朝のメモ]）
+// 同じ日付に複数の日記がある場合に、どの時間帯の記述かをLLMが区別できるようにする
+func diaryEntryHeading(date, title string, entryTime sql.NullInt64) string {
+	heading := date
+	if entryTime.Valid {
+		heading += fmt.Sprintf(" %02d:%02d", entryTime.Int64/60, entryTime.Int64%60)
+	}
+	if title != "" {
+		heading += " " + title
+	}
+	return "[" + heading + "]"
+}
@@ -0,0 +1,393 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/container"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/taskevent"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/redis/rueidis"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+func TestMonthlySummaryGenerationMessage(t *testing.T) {
+	msg := MonthlySummaryGenerationMessage{
+		Type:   "monthly_summary",
+		UserID: "test-user-id",
+		Year:   2024,
+		Month:  1,
+	}
+
+	if msg.Type != "monthly_summary" {
+		t.Errorf("expected type 'monthly_summary', got '%s'", msg.Type)
+	}
+
+	if msg.UserID != "test-user-id" {
+		t.Errorf("expected user ID 'test-user-id', got '%s'", msg.UserID)
+	}
+
+	if msg.Year != 2024 {
+		t.Errorf("expected year 2024, got %d", msg.Year)
+	}
+
+	if msg.Month != 1 {
+		t.Errorf("expected month 1, got %d", msg.Month)
+	}
+}
+
+func TestDiaryEntryHeading(t *testing.T) {
+	tests := []struct {
+		title     string
+		entryTime sql.NullInt64
+		expected  string
+	}{
+		{"", sql.NullInt64{}, "[2024-05-01]"},
+		{"朝", sql.NullInt64{Int64: 7*60 + 5, Valid: true}, "[2024-05-01 07:05 朝]"},
+		{"", sql.NullInt64{Int64: 21 * 60, Valid: true}, "[2024-05-01 21:00]"},
+		{"夜", sql.NullInt64{}, "[2024-05-01 夜]"},
+	}
+	for _, tt := range tests {
+		if got := diaryEntryHeading("2024-05-01", tt.title, tt.entryTime); got != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestProcessMessage_UnknownType(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.NewEntry(logrus.New())
+
+	payload := `{"type": "unknown_type", "user_id": "test"}`
+
+	// This should not return an error for unknown message types
+	err := processMessage(ctx, nil, nil, nil, nil, nil, nil, 0, nil, nil, payload, logger)
+	if err != nil {
+		t.Errorf("expected no error for unknown message type, got %v", err)
+	}
+}
+
+func TestProcessMessage_InvalidJSON(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.NewEntry(logrus.New())
+
+	payload := `invalid json`
+
+	// This should return an error for invalid JSON
+	err := processMessage(ctx, nil, nil, nil, nil, nil, nil, 0, nil, nil, payload, logger)
+	if err == nil {
+		t.Fatal("expected error for invalid JSON, got nil")
+	}
+}
+
+func TestProcessMessage_LatestTrend_InvalidJSON(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.NewEntry(logrus.New())
+
+	// latestTrendメッセージのJSONが不正な場合はエラーを返すことを確認
+	payload := `{"type": "latest_trend", invalid_json}`
+
+	err := processMessage(ctx, nil, nil, nil, nil, nil, nil, 0, nil, nil, payload, logger)
+	if err == nil {
+		t.Fatal("不正なJSONに対してエラーが期待されますが、nilが返りました")
+	}
+}
+
+func TestProcessMessage_DiaryHighlight_InvalidJSON(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.NewEntry(logrus.New())
+
+	// diaryHighlightメッセージのJSONが不正な場合はエラーを返すことを確認
+	payload := `{"type": "diary_highlight", invalid_json}`
+
+	err := processMessage(ctx, nil, nil, nil, nil, nil, nil, 0, nil, nil, payload, logger)
+	if err == nil {
+		t.Fatal("不正なJSONに対してエラーが期待されますが、nilが返りました")
+	}
+}
+
+func TestGenerateDiaryHighlightWithLLM_NoLLMConfig(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "subscriber-highlight-test@example.com", "Subscriber Test User")
+	ctx := context.Background()
+	logger := logrus.NewEntry(logrus.New())
+
+	// LLM設定なしのユーザーでgenerateDiaryHighlightWithLLMを呼び出すと、
+	// user_llmsテーブルにレコードがないためエラーが返ることを確認
+	_, err := generateDiaryHighlightWithLLM(ctx, db, nil, userID.String(), "テストコンテンツ", logger)
+	if err == nil {
+		t.Fatal("LLM設定なしの場合はエラーが期待されますが、nilが返りました")
+	}
+}
+
+func TestSaveLatestTrend_FencingToken(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis起動失敗: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	client, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{mr.Addr()},
+		DisableCache: true,
+	})
+	if err != nil {
+		t.Fatalf("rueidisクライアント作成失敗: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	ctx := context.Background()
+	userID := "user-1"
+
+	saved, err := saveLatestTrend(ctx, client, userID, []byte(`{"mood":"new"}`), 10, 25*time.Hour)
+	if err != nil || !saved {
+		t.Fatalf("新しいトークンでの保存に失敗: saved=%v err=%v", saved, err)
+	}
+
+	// 古いトークンでの保存は拒否され、既存データは上書きされない
+	saved, err = saveLatestTrend(ctx, client, userID, []byte(`{"mood":"stale"}`), 9, 25*time.Hour)
+	if err != nil {
+		t.Fatalf("saveLatestTrend失敗: %v", err)
+	}
+	if saved {
+		t.Fatal("古いトークンでの保存は拒否されるべき")
+	}
+
+	got, err := mr.Get("latest_trend:user-1")
+	if err != nil {
+		t.Fatalf("トレンドデータ取得失敗: %v", err)
+	}
+	if got != `{"mood":"new"}` {
+		t.Errorf("期待 {\"mood\":\"new\"}, 実際 %s", got)
+	}
+	if ttl := mr.TTL("latest_trend:user-1"); ttl != 25*time.Hour {
+		t.Errorf("期待 TTL 25h, 実際 %v", ttl)
+	}
+}
+
+func TestTaskNotifier(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis起動失敗: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	newClient := func() rueidis.Client {
+		client, err := rueidis.NewClient(rueidis.ClientOption{
+			InitAddress:  []string{mr.Addr()},
+			DisableCache: true,
+		})
+		if err != nil {
+			t.Fatalf("rueidisクライアント作成失敗: %v", err)
+		}
+		t.Cleanup(client.Close)
+		return client
+	}
+	// 購読用と発行用でクライアントを分ける（miniredisは購読中の接続でPUBLISHできない）
+	subClient := newClient()
+	pubClient := newClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan taskevent.Event, 10)
+	subscribed := make(chan struct{})
+	go func() {
+		_ = taskevent.Subscribe(ctx, subClient, "user-1", func() { close(subscribed) }, func(e taskevent.Event) { received <- e })
+	}()
+	select {
+	case <-subscribed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("購読が確立されない")
+	}
+
+	next := func() taskevent.Event {
+		t.Helper()
+		select {
+		case e := <-received:
+			return e
+		case <-time.After(2 * time.Second):
+			t.Fatal("イベントを受信できなかった")
+			return taskevent.Event{}
+		}
+	}
+
+	logger := logrus.NewEntry(logrus.New())
+
+	// エラー終了時はfailedを通知する
+	notifier := newTaskNotifier(pubClient, "user-1", taskevent.TypeDiaryHighlight, "diary-1", logger)
+	notifier.finish(ctx, errors.New("no valid highlights generated"))
+	if e := next(); e.Status != taskevent.StatusFailed || e.Target != "diary-1" || e.Message != "no valid highlights generated" {
+		t.Errorf("failedイベントが不正: %+v", e)
+	}
+
+	// fail後のfinishは重複して通知しない
+	notifier = newTaskNotifier(pubClient, "user-1", taskevent.TypeMonthlySummary, "2024-05", logger)
+	notifier.fail(ctx, "PROHIBITED_CONTENT")
+	notifier.finish(ctx, nil)
+	if e := next(); e.Status != taskevent.StatusFailed || e.Message != "PROHIBITED_CONTENT" {
+		t.Errorf("failedイベントが不正: %+v", e)
+	}
+
+	// discard後は通知せず、正常終了はsucceededを通知する
+	notifier = newTaskNotifier(pubClient, "user-1", taskevent.TypeLatestTrend, "", logger)
+	notifier.discard()
+	notifier.finish(ctx, nil)
+	notifier = newTaskNotifier(pubClient, "user-1", taskevent.TypeDiaryEmbedding, "diary-2", logger)
+	notifier.finish(ctx, nil)
+	if e := next(); e.Status != taskevent.StatusSucceeded || e.TaskType != taskevent.TypeDiaryEmbedding {
+		t.Errorf("succeededイベントが不正: %+v", e)
+	}
+}
+
+func TestProcessMessage_DiaryTagSuggestion_InvalidJSON(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.NewEntry(logrus.New())
+
+	// diary_tag_suggestionメッセージのJSONが不正な場合はエラーを返すことを確認
+	payload := `{"type": "diary_tag_suggestion", invalid_json}`
+
+	err := processMessage(ctx, nil, nil, nil, nil, nil, nil, 0, nil, nil, payload, logger)
+	if err == nil {
+		t.Fatal("不正なJSONに対してエラーが期待されますが、nilが返りました")
+	}
+}
+
+func TestProcessMessage_DataExport(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.NewEntry(logrus.New())
+
+	// data_exportメッセージのJSONが不正な場合はエラーを返すことを確認
+	err := processMessage(ctx, nil, nil, nil, nil, nil, nil, 0, nil, nil, `{"type": "data_export", invalid_json}`, logger)
+	if err == nil {
+		t.Fatal("不正なJSONに対してエラーが期待されますが、nilが返りました")
+	}
+
+	// export_idが不正な場合はDBに問い合わせずにエラーを返すことを確認
+	err = processMessage(ctx, nil, nil, nil, nil, nil, nil, 0, nil, nil, `{"type": "data_export", "user_id": "user-1", "export_id": "invalid"}`, logger)
+	if err == nil {
+		t.Fatal("不正なexport_idに対してエラーが期待されますが、nilが返りました")
+	}
+}
+
+func TestMatchSuggestedTags(t *testing.T) {
+	travel := &database.Tag{ID: uuid.New(), Name: "旅行"}
+	food := &database.Tag{ID: uuid.New(), Name: "ごはん"}
+	work := &database.Tag{ID: uuid.New(), Name: "仕事"}
+	hobby := &database.Tag{ID: uuid.New(), Name: "趣味"}
+	tags := []*database.Tag{travel, food, work, hobby}
+
+	tests := []struct {
+		name     string
+		names    []string
+		expected []uuid.UUID
+	}{
+		{"候補のタグ名をIDに変換する", []string{"旅行", " ごはん "}, []uuid.UUID{travel.ID, food.ID}},
+		{"候補にない名前と重複を除く", []string{"旅行", "散歩", "旅行"}, []uuid.UUID{travel.ID}},
+		{"最大件数に制限する", []string{"旅行", "ごはん", "仕事", "趣味"}, []uuid.UUID{travel.ID, food.ID, work.ID}},
+		{"空の場合は空", nil, []uuid.UUID{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := matchSuggestedTags(tc.names, tags)
+			if len(got) != len(tc.expected) {
+				t.Fatalf("件数: 期待 %d, 実際 %d (%v)", len(tc.expected), len(got), got)
+			}
+			for i := range got {
+				if got[i] != tc.expected[i] {
+					t.Errorf("%d件目: 期待 %s, 実際 %s", i, tc.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+// newTrashTestRedis はゴミ箱のテスト用のminiredisに接続したクライアントを返す
+func newTrashTestRedis(t *testing.T) rueidis.Client {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis起動失敗: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	client, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{mr.Addr()},
+		DisableCache: true,
+	})
+	if err != nil {
+		t.Fatalf("rueidisクライアント作成失敗: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+// insertTrashedDiary はゴミ箱に移動済みの日記を作成する
+func insertTrashedDiary(t *testing.T, db *sql.DB, userID uuid.UUID, date string) uuid.UUID {
+	t.Helper()
+	diaryID := uuid.New()
+	now := time.Now().Unix()
+	_, err := db.Exec(
+		`INSERT INTO diaries (id, user_id, content, date, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		diaryID, userID, "ゴミ箱の日記", date, now, now, now,
+	)
+	if err != nil {
+		t.Fatalf("日記の作成失敗: %v", err)
+	}
+	return diaryID
+}
+
+func TestGenerateFromTrashedDiary(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	logger := logrus.NewEntry(logrus.New())
+	redisClient := newTrashTestRedis(t)
+	lockService := container.NewLockService(redisClient)
+
+	t.Run("正常系: 月次要約はゴミ箱の日記を対象にしない", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "subscriber-trash-monthly@example.com", "Subscriber Trash User")
+		insertTrashedDiary(t, db, userID, "2024-05-10")
+
+		// ゴミ箱の日記しかない月は、LLMを呼ばずに対象の日記がないエラーになる
+		err := generateMonthlySummary(ctx, db, redisClient, nil, lockService, userID.String(), 2024, 5, logger)
+		if err == nil || !strings.Contains(err.Error(), "no diary entries found") {
+			t.Fatalf("対象の日記がないエラーが期待されますが、%vが返りました", err)
+		}
+	})
+
+	t.Run("正常系: ゴミ箱の日記のハイライトは生成しない", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "subscriber-trash-highlight@example.com", "Subscriber Trash User")
+		diaryID := insertTrashedDiary(t, db, userID, "2024-05-11")
+
+		if err := generateDiaryHighlight(ctx, db, redisClient, nil, lockService, userID.String(), diaryID.String(), logger); err != nil {
+			t.Fatalf("ゴミ箱の日記はスキップされるべきですが、エラーが返りました: %v", err)
+		}
+		var count int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM diary_highlights WHERE diary_id = $1`, diaryID).Scan(&count); err != nil {
+			t.Fatalf("ハイライトの取得失敗: %v", err)
+		}
+		if count != 0 {
+			t.Errorf("ハイライトが保存されていないことが期待されますが、%d件ありました", count)
+		}
+	})
+
+	t.Run("正常系: ゴミ箱の日記の埋め込みは生成しない", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "subscriber-trash-embedding@example.com", "Subscriber Trash User")
+		testutil.CreateTestUserLLMWithSettings(t, db, userID, "test-api-key", false, false, true)
+		diaryID := insertTrashedDiary(t, db, userID, "2024-05-12")
+
+		// LLMクライアントを作成する前にスキップするため、llmFactoryはnilでよい
+		err := generateDiaryEmbedding(ctx, db, redisClient, nil, rate.NewLimiter(rate.Inf, 1), userID.String(), diaryID.String(), logger)
+		if err != nil {
+			t.Fatalf("ゴミ箱の日記はスキップされるべきですが、エラーが返りました: %v", err)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+// umi はumi.mikanのコマンドラインツール。
+//
+//	UMI_API_KEY='umi_...' go run ./cmd/umi sync -dir ~/Obsidian/diary -url https://umi.example.com/vault/
+//
+// sync はローカルのフォルダー（Obsidianのvaultなど）と日記をYYYY/MM/YYYY-MM-DD.mdのノートとして双方向に同期する。
+// APIキーはシェルの履歴に残らないよう、引数ではなく環境変数で受け取る。
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/vault"
+)
+
+const usage = `usage: umi <command> [flags]
+
+commands:
+  sync    ローカルのフォルダーと日記のノートを双方向に同期する
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := run(ctx, os.Args[1:], os.Getenv("UMI_API_KEY"), os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "umi:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, apiKey string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+	switch args[0] {
+	case "sync":
+		return runSync(ctx, args[1:], apiKey, out)
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], usage)
+	}
+}
+
+func runSync(ctx context.Context, args []string, apiKey string, out io.Writer) error {
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	dir := fs.String("dir", "", "同期するローカルのフォルダー")
+	url := fs.String("url", "", "日記のWebDAVのURL（例: https://umi.example.com/vault/）")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" || *url == "" {
+		return errors.New("-dir and -url are required")
+	}
+	if apiKey == "" {
+		return errors.New("UMI_API_KEY is required")
+	}
+	if info, err := os.Stat(*dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", *dir)
+	}
+
+	remote, err := vault.NewWebDAVRemote(*url, "umi", apiKey)
+	if err != nil {
+		return err
+	}
+	result, err := vault.NewSyncer(*dir, remote).Sync(ctx)
+	_, _ = fmt.Fprintf(out, "downloaded %d, uploaded %d, deleted locally %d, deleted remotely %d, conflicts %d\n",
+		result.Downloaded, result.Uploaded, result.DeletedLocal, result.DeletedRemote, result.Conflicts)
+	return err
+}
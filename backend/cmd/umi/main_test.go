@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/net/webdav"
+)
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(&webdav.Handler{FileSystem: webdav.NewMemFS(), LockSystem: webdav.NewMemLS()})
+	t.Cleanup(server.Close)
+
+	t.Run("正常系: syncでローカルのノートをサーバーに同期する", func(t *testing.T) {
+		dir := t.TempDir()
+		note := filepath.Join(dir, "2024", "05", "2024-05-01.md")
+		if err := os.MkdirAll(filepath.Dir(note), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(note, []byte("5月1日"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		var out bytes.Buffer
+		if err := run(t.Context(), []string{"sync", "-dir", dir, "-url", server.URL}, "umi_key", &out); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if got := out.String(); got != "downloaded 0, uploaded 1, deleted locally 0, deleted remotely 0, conflicts 0\n" {
+			t.Errorf("出力が不正: %q", got)
+		}
+	})
+
+	t.Run("異常系: 不正な引数はエラー", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			args   []string
+			apiKey string
+		}{
+			{"コマンドなし", nil, "umi_key"},
+			{"未知のコマンド", []string{"push"}, "umi_key"},
+			{"フォルダーの指定なし", []string{"sync", "-url", server.URL}, "umi_key"},
+			{"APIキーなし", []string{"sync", "-dir", t.TempDir(), "-url", server.URL}, ""},
+			{"存在しないフォルダー", []string{"sync", "-dir", filepath.Join(t.TempDir(), "missing"), "-url", server.URL}, "umi_key"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if err := run(t.Context(), tt.args, tt.apiKey, &bytes.Buffer{}); err == nil {
+					t.Error("エラーを期待したがnilだった")
+				}
+			})
+		}
+	})
+}
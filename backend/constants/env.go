@@ -2,8 +2,10 @@ package constants
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -13,6 +15,8 @@ type DBConfig struct {
 	User     string
 	Password string
 	DBName   string
+	// MigrateOnStartup が有効な場合は起動時に未適用のマイグレーションを適用する
+	MigrateOnStartup bool
 }
 
 type RedisConfig struct {
@@ -26,6 +30,8 @@ type SchedulerConfig struct {
 	LatestTrendTargetMinute    int
 	DiaryEmbeddingTargetHour   int
 	DiaryEmbeddingTargetMinute int
+	TrashPurgeTargetHour       int
+	TrashPurgeTargetMinute     int
 }
 
 type SubscriberConfig struct {
@@ -37,6 +43,80 @@ type RateLimitConfig struct {
 	LoginWindow         time.Duration
 	RegisterMaxAttempts int
 	RegisterWindow      time.Duration
+	EmailMaxAttempts    int
+	EmailWindow         time.Duration
+}
+
+// RPCRateLimitPolicy はRPC（MCPのツールを含む）ごとのレート制限
+type RPCRateLimitPolicy struct {
+	Limit  int           // Windowの間に呼び出せる回数
+	Window time.Duration // 回数を数える期間
+}
+
+type RPCRateLimitConfig struct {
+	Policies map[string]RPCRateLimitPolicy // キーはgRPCのフルメソッド名（/diary.DiaryService/...）か mcp/<ツール名>
+}
+
+type MailerConfig struct {
+	Backend  string // smtp・file・log
+	From     string
+	SMTPHost string
+	SMTPPort int
+	SMTPUser string
+	SMTPPass string
+	FileDir  string
+}
+
+type AttachmentConfig struct {
+	StorageBackend    string // "local" または "s3"
+	LocalDir          string
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3UsePathStyle    bool
+	QuotaBytes        int64
+	MaxFileBytes      int64
+}
+
+type TrashConfig struct {
+	Retention time.Duration // ゴミ箱に移動してから完全に削除するまでの期間
+}
+
+type AccountDeletionConfig struct {
+	GracePeriod time.Duration // アカウントの削除を依頼してからデータを完全に削除するまでの期間
+}
+
+type DataExportConfig struct {
+	Expiry time.Duration // データエクスポートのアーカイブをダウンロードできる期間
+}
+
+type OIDCProviderConfig struct {
+	ID           string // プロバイダーの識別子（OIDC_PROVIDERSに列挙した値）
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string // 空の場合はデフォルトのスコープ
+	RedirectURL  string
+}
+
+type WebAuthnConfig struct {
+	RPID    string   // パスキーを紐付けるドメイン
+	RPName  string   // 登録時に認証器に表示する名前
+	Origins []string // 受け付けるorigin
+}
+
+type JWTKeyConfig struct {
+	Algorithm        string        // EdDSA または RS256
+	RotationInterval time.Duration // 新しい署名鍵を作る間隔
+	PublishAhead     time.Duration // 新しい署名鍵をJWKSで公開してから署名に使い始めるまでの時間
+	AcceptHS256      bool          // 移行前にJWT_SECRETで署名したトークン（HS256）も受け付けるか
+}
+
+type BackupConfig struct {
+	LocalDir string // 定期バックアップのローカルの保存先（空の場合はローカルを保存先に選べない）
 }
 
 func LoadEnv(name string) (string, error) {
@@ -91,11 +171,12 @@ func LoadDBConfig() (*DBConfig, error) {
 		return nil, err
 	}
 	return &DBConfig{
-		Host:     host,
-		Port:     port,
-		User:     user,
-		Password: password,
-		DBName:   dbname,
+		Host:             host,
+		Port:             port,
+		User:             user,
+		Password:         password,
+		DBName:           dbname,
+		MigrateOnStartup: os.Getenv("DB_MIGRATE_ON_STARTUP") == "true",
 	}, nil
 }
 
@@ -181,12 +262,40 @@ func LoadSchedulerConfig() (*SchedulerConfig, error) {
 		return nil, fmt.Errorf("SCHEDULER_DIARY_EMBEDDING_MINUTE must be between 0 and 59, got %d", diaryEmbeddingMinute)
 	}
 
+	trashPurgeHourStr := os.Getenv("SCHEDULER_TRASH_PURGE_HOUR")
+	if trashPurgeHourStr == "" {
+		trashPurgeHourStr = "3" // デフォルトは3時（他の日次ジョブより前）
+	}
+
+	trashPurgeMinuteStr := os.Getenv("SCHEDULER_TRASH_PURGE_MINUTE")
+	if trashPurgeMinuteStr == "" {
+		trashPurgeMinuteStr = "0"
+	}
+
+	trashPurgeHour, err := strconv.Atoi(trashPurgeHourStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_TRASH_PURGE_HOUR format: %w", err)
+	}
+	if trashPurgeHour < 0 || trashPurgeHour > 23 {
+		return nil, fmt.Errorf("SCHEDULER_TRASH_PURGE_HOUR must be between 0 and 23, got %d", trashPurgeHour)
+	}
+
+	trashPurgeMinute, err := strconv.Atoi(trashPurgeMinuteStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_TRASH_PURGE_MINUTE format: %w", err)
+	}
+	if trashPurgeMinute < 0 || trashPurgeMinute > 59 {
+		return nil, fmt.Errorf("SCHEDULER_TRASH_PURGE_MINUTE must be between 0 and 59, got %d", trashPurgeMinute)
+	}
+
 	return &SchedulerConfig{
 		MonthlySummaryInterval:     monthlyInterval,
 		LatestTrendTargetHour:      latestTrendHour,
 		LatestTrendTargetMinute:    latestTrendMinute,
 		DiaryEmbeddingTargetHour:   diaryEmbeddingHour,
 		DiaryEmbeddingTargetMinute: diaryEmbeddingMinute,
+		TrashPurgeTargetHour:       trashPurgeHour,
+		TrashPurgeTargetMinute:     trashPurgeMinute,
 	}, nil
 }
 
@@ -269,11 +378,183 @@ func LoadRateLimitConfig() (*RateLimitConfig, error) {
 		return nil, fmt.Errorf("REGISTER_WINDOW must be a positive duration")
 	}
 
+	// Email rate limit config（パスワードの再設定・メールアドレスの確認のメール送信）
+	emailMaxAttemptsStr := os.Getenv("EMAIL_MAX_ATTEMPTS")
+	if emailMaxAttemptsStr == "" {
+		emailMaxAttemptsStr = "3" // デフォルト: 3回まで
+	}
+
+	emailWindowStr := os.Getenv("EMAIL_WINDOW")
+	if emailWindowStr == "" {
+		emailWindowStr = "1h" // デフォルト: 1時間
+	}
+
+	emailMaxAttempts, err := strconv.Atoi(emailMaxAttemptsStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_MAX_ATTEMPTS format: %w", err)
+	}
+
+	if emailMaxAttempts <= 0 {
+		return nil, fmt.Errorf("EMAIL_MAX_ATTEMPTS must be a positive integer")
+	}
+
+	emailWindow, err := time.ParseDuration(emailWindowStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_WINDOW format: %w", err)
+	}
+
+	if emailWindow <= 0 {
+		return nil, fmt.Errorf("EMAIL_WINDOW must be a positive duration")
+	}
+
 	return &RateLimitConfig{
 		LoginMaxAttempts:    loginMaxAttempts,
 		LoginWindow:         loginWindow,
 		RegisterMaxAttempts: registerMaxAttempts,
 		RegisterWindow:      registerWindow,
+		EmailMaxAttempts:    emailMaxAttempts,
+		EmailWindow:         emailWindow,
+	}, nil
+}
+
+// defaultRPCRateLimits はLLM・embeddingのAPIを呼び出す重いRPCのデフォルトのレート制限
+var defaultRPCRateLimits = map[string]RPCRateLimitPolicy{
+	"/diary.DiaryService/SearchDiaryEntriesSemantic": {Limit: 30, Window: time.Minute},
+	"/diary.DiaryService/TriggerDiaryHighlight":      {Limit: 30, Window: time.Hour},
+	"/diary.DiaryService/GenerateMonthlySummary":     {Limit: 10, Window: time.Hour},
+	"/diary.DiaryService/RegenerateAllEmbeddings":    {Limit: 3, Window: 24 * time.Hour},
+	"mcp/search_diary_entries_fuzzy":                 {Limit: 30, Window: time.Minute},
+}
+
+// LoadRPCRateLimitConfig はRPCごとのレート制限を読み込む。
+// RPC_RATE_LIMITS に「メソッド=回数/期間」をカンマ区切りで指定するとデフォルトを上書きし、「メソッド=off」で制限しない
+// （例: /diary.DiaryService/GenerateMonthlySummary=5/1h,mcp/search_diary_entries_fuzzy=off）
+func LoadRPCRateLimitConfig() (*RPCRateLimitConfig, error) {
+	policies := make(map[string]RPCRateLimitPolicy, len(defaultRPCRateLimits))
+	for method, policy := range defaultRPCRateLimits {
+		policies[method] = policy
+	}
+
+	for entry := range strings.SplitSeq(os.Getenv("RPC_RATE_LIMITS"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		method, value, ok := strings.Cut(entry, "=")
+		method, value = strings.TrimSpace(method), strings.TrimSpace(value)
+		if !ok || method == "" {
+			return nil, fmt.Errorf("invalid RPC_RATE_LIMITS entry %q: must be method=limit/window", entry)
+		}
+		if value == "off" {
+			delete(policies, method)
+			continue
+		}
+		limitStr, windowStr, ok := strings.Cut(value, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid RPC_RATE_LIMITS entry %q: must be method=limit/window", entry)
+		}
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("invalid RPC_RATE_LIMITS limit for %s: must be a positive integer", method)
+		}
+		window, err := time.ParseDuration(windowStr)
+		if err != nil || window < time.Second {
+			return nil, fmt.Errorf("invalid RPC_RATE_LIMITS window for %s: must be a duration of at least 1s", method)
+		}
+		policies[method] = RPCRateLimitPolicy{Limit: limit, Window: window}
+	}
+
+	return &RPCRateLimitConfig{Policies: policies}, nil
+}
+
+// LoadAttachmentConfig は添付ファイルの保存先と容量制限の設定を読み込む
+func LoadAttachmentConfig() (*AttachmentConfig, error) {
+	backend := os.Getenv("ATTACHMENT_STORAGE")
+	if backend == "" {
+		backend = "local" // デフォルト: ローカルファイルシステム
+	}
+	if backend != "local" && backend != "s3" {
+		return nil, fmt.Errorf("ATTACHMENT_STORAGE must be local or s3")
+	}
+
+	localDir := os.Getenv("ATTACHMENT_LOCAL_DIR")
+	if localDir == "" {
+		localDir = "/data/attachments"
+	}
+
+	quotaMBStr := os.Getenv("ATTACHMENT_QUOTA_MB")
+	if quotaMBStr == "" {
+		quotaMBStr = "1024" // デフォルト: 1ユーザーあたり1GiB
+	}
+	quotaMB, err := strconv.ParseInt(quotaMBStr, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ATTACHMENT_QUOTA_MB format: %w", err)
+	}
+	if quotaMB <= 0 {
+		return nil, fmt.Errorf("ATTACHMENT_QUOTA_MB must be a positive integer")
+	}
+
+	maxFileMBStr := os.Getenv("ATTACHMENT_MAX_FILE_MB")
+	if maxFileMBStr == "" {
+		maxFileMBStr = "20" // デフォルト: 1ファイル20MiB（アップロード中はメモリに保持するため大きくしすぎない）
+	}
+	maxFileMB, err := strconv.ParseInt(maxFileMBStr, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ATTACHMENT_MAX_FILE_MB format: %w", err)
+	}
+	if maxFileMB <= 0 {
+		return nil, fmt.Errorf("ATTACHMENT_MAX_FILE_MB must be a positive integer")
+	}
+
+	return &AttachmentConfig{
+		StorageBackend:    backend,
+		LocalDir:          localDir,
+		S3Endpoint:        os.Getenv("S3_ENDPOINT"),
+		S3Region:          os.Getenv("S3_REGION"),
+		S3Bucket:          os.Getenv("S3_BUCKET"),
+		S3AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+		S3SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		S3UsePathStyle:    os.Getenv("S3_USE_PATH_STYLE") == "true",
+		QuotaBytes:        quotaMB << 20,
+		MaxFileBytes:      maxFileMB << 20,
+	}, nil
+}
+
+// LoadTrashConfig はゴミ箱の保持期間を読み込む
+func LoadTrashConfig() (*TrashConfig, error) {
+	retentionDaysStr := os.Getenv("TRASH_RETENTION_DAYS")
+	if retentionDaysStr == "" {
+		retentionDaysStr = "30" // デフォルト: 30日
+	}
+	retentionDays, err := strconv.Atoi(retentionDaysStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRASH_RETENTION_DAYS format: %w", err)
+	}
+	if retentionDays <= 0 {
+		return nil, fmt.Errorf("TRASH_RETENTION_DAYS must be a positive integer")
+	}
+
+	return &TrashConfig{
+		Retention: time.Duration(retentionDays) * 24 * time.Hour,
+	}, nil
+}
+
+// LoadAccountDeletionConfig はアカウントの削除の猶予期間を読み込む（0の場合は次のスケジューラーの実行で削除する）
+func LoadAccountDeletionConfig() (*AccountDeletionConfig, error) {
+	graceDaysStr := os.Getenv("ACCOUNT_DELETION_GRACE_DAYS")
+	if graceDaysStr == "" {
+		graceDaysStr = "14" // デフォルト: 14日
+	}
+	graceDays, err := strconv.Atoi(graceDaysStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACCOUNT_DELETION_GRACE_DAYS format: %w", err)
+	}
+	if graceDays < 0 {
+		return nil, fmt.Errorf("ACCOUNT_DELETION_GRACE_DAYS must not be negative")
+	}
+
+	return &AccountDeletionConfig{
+		GracePeriod: time.Duration(graceDays) * 24 * time.Hour,
 	}, nil
 }
 
@@ -289,11 +570,23 @@ func LoadGRPCReflectionEnabled() bool {
 	return true
 }
 
-// LoadRegisterKey REGISTER_KEY環境変数を読み込む（設定されていない場合は空文字を返す）
+// LoadRegisterKey REGISTER_KEY環境変数を読み込む（設定されていない場合は空文字を返す）。
+// 招待コードへの移行期間のため、設定されている場合は招待制にし、このキーも招待コードとして受け付ける
 func LoadRegisterKey() string {
 	return os.Getenv("REGISTER_KEY")
 }
 
+// LoadInvitationRequired は新規登録に招待コードを必須にするか（INVITATION_REQUIRED=true、またはREGISTER_KEYが設定されている）
+func LoadInvitationRequired() bool {
+	return os.Getenv("INVITATION_REQUIRED") == "true" || LoadRegisterKey() != ""
+}
+
+// LoadWebhookAllowPrivateNetworks はWebhookの通知先にループバック・プライベートネットワークのアドレスを許可するか
+// （WEBHOOK_ALLOW_PRIVATE_NETWORKS=true）。Home Assistantなど自宅ネットワーク内のサービスに通知する場合に有効にする
+func LoadWebhookAllowPrivateNetworks() bool {
+	return os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS") == "true"
+}
+
 // defaultMCPServerBaseURL / defaultFrontendBaseURL は開発環境（docker compose）向けのデフォルト値
 const (
 	defaultMCPServerBaseURL = "http://localhost:2014"
@@ -319,3 +612,195 @@ func LoadFrontendBaseURL() string {
 	}
 	return defaultFrontendBaseURL
 }
+
+// LoadDataExportConfig はデータエクスポートのアーカイブの保持期間を読み込む
+func LoadDataExportConfig() (*DataExportConfig, error) {
+	expiryHoursStr := os.Getenv("DATA_EXPORT_EXPIRY_HOURS")
+	if expiryHoursStr == "" {
+		expiryHoursStr = "72" // デフォルト: 3日
+	}
+	expiryHours, err := strconv.Atoi(expiryHoursStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DATA_EXPORT_EXPIRY_HOURS format: %w", err)
+	}
+	if expiryHours <= 0 {
+		return nil, fmt.Errorf("DATA_EXPORT_EXPIRY_HOURS must be a positive integer")
+	}
+
+	return &DataExportConfig{
+		Expiry: time.Duration(expiryHours) * time.Hour,
+	}, nil
+}
+
+// LoadBackupConfig は定期バックアップの設定を読み込む
+// BACKUP_LOCAL_DIRはセルフホスト向けの任意の設定で、指定した場合のみローカルのディレクトリを保存先に選べる
+func LoadBackupConfig() *BackupConfig {
+	return &BackupConfig{
+		LocalDir: os.Getenv("BACKUP_LOCAL_DIR"),
+	}
+}
+
+// googleIssuer はOIDC_PROVIDERSにgoogleを指定した場合のissuer（プリセット）
+const googleIssuer = "https://accounts.google.com"
+
+// LoadOIDCConfig はOpenID Connectのプロバイダーの設定を読み込む。
+// OIDC_PROVIDERSにカンマ区切りで識別子を列挙し、識別子ごとに OIDC_<ID>_CLIENT_ID などを設定する。
+// googleはissuerと表示名を省略できる。未設定の場合はプロバイダーなし
+func LoadOIDCConfig() ([]OIDCProviderConfig, error) {
+	providersStr := os.Getenv("OIDC_PROVIDERS")
+	if strings.TrimSpace(providersStr) == "" {
+		return nil, nil
+	}
+	redirectURL := os.Getenv("OIDC_REDIRECT_URL")
+	if redirectURL == "" {
+		redirectURL = strings.TrimSuffix(LoadFrontendBaseURL(), "/") + "/auth/oidc/callback"
+	}
+
+	var configs []OIDCProviderConfig
+	seen := make(map[string]bool)
+	for _, id := range strings.Split(providersStr, ",") {
+		id = strings.ToLower(strings.TrimSpace(id))
+		if id == "" {
+			continue
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate oidc provider: %s", id)
+		}
+		seen[id] = true
+
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(id, "-", "_")) + "_"
+		config := OIDCProviderConfig{
+			ID:           id,
+			Name:         os.Getenv(prefix + "NAME"),
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  redirectURL,
+		}
+		if id == "google" {
+			if config.Issuer == "" {
+				config.Issuer = googleIssuer
+			}
+			if config.Name == "" {
+				config.Name = "Google"
+			}
+		}
+		if config.Name == "" {
+			config.Name = id
+		}
+		if config.Issuer == "" {
+			return nil, fmt.Errorf("%sISSUER is required", prefix)
+		}
+		if config.ClientID == "" {
+			return nil, fmt.Errorf("%sCLIENT_ID is required", prefix)
+		}
+		if scopes := os.Getenv(prefix + "SCOPES"); scopes != "" {
+			config.Scopes = strings.Fields(strings.ReplaceAll(scopes, ",", " "))
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// LoadWebAuthnConfig はパスキー（WebAuthn）の設定を読み込む。
+// WEBAUTHN_RP_ID は未設定の場合FRONTEND_BASE_URLのホスト名、WEBAUTHN_ORIGINS（カンマ区切り）は未設定の場合FRONTEND_BASE_URL
+func LoadWebAuthnConfig() (*WebAuthnConfig, error) {
+	frontendBaseURL := strings.TrimSuffix(LoadFrontendBaseURL(), "/")
+	config := &WebAuthnConfig{
+		RPID:   os.Getenv("WEBAUTHN_RP_ID"),
+		RPName: os.Getenv("WEBAUTHN_RP_NAME"),
+	}
+	if config.RPID == "" {
+		u, err := url.Parse(frontendBaseURL)
+		if err != nil || u.Hostname() == "" {
+			return nil, fmt.Errorf("invalid FRONTEND_BASE_URL for WEBAUTHN_RP_ID: %q", frontendBaseURL)
+		}
+		config.RPID = u.Hostname()
+	}
+	if config.RPName == "" {
+		config.RPName = "umi.mikan"
+	}
+	for _, origin := range strings.Split(os.Getenv("WEBAUTHN_ORIGINS"), ",") {
+		if origin = strings.TrimSuffix(strings.TrimSpace(origin), "/"); origin != "" {
+			config.Origins = append(config.Origins, origin)
+		}
+	}
+	if len(config.Origins) == 0 {
+		config.Origins = []string{frontendBaseURL}
+	}
+	return config, nil
+}
+
+// LoadMailerConfig はメールの送信設定を読み込む。
+// MAILER_BACKEND は smtp・file・log（未設定の場合はlogで、メールを送らずにログに出力する）
+func LoadMailerConfig() (*MailerConfig, error) {
+	config := &MailerConfig{
+		Backend:  os.Getenv("MAILER_BACKEND"),
+		From:     os.Getenv("MAIL_FROM"),
+		SMTPHost: os.Getenv("SMTP_HOST"),
+		SMTPUser: os.Getenv("SMTP_USERNAME"),
+		SMTPPass: os.Getenv("SMTP_PASSWORD"),
+		FileDir:  os.Getenv("MAILER_FILE_DIR"),
+		SMTPPort: 587, // デフォルト: submission（STARTTLS）
+	}
+	if config.Backend == "" {
+		config.Backend = "log"
+	}
+	if config.From == "" {
+		config.From = "noreply@localhost"
+	}
+	if portStr := os.Getenv("SMTP_PORT"); portStr != "" {
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port <= 0 || port > 65535 {
+			return nil, fmt.Errorf("invalid SMTP_PORT: %q", portStr)
+		}
+		config.SMTPPort = port
+	}
+	switch config.Backend {
+	case "smtp":
+		if config.SMTPHost == "" {
+			return nil, fmt.Errorf("SMTP_HOST is required when MAILER_BACKEND is smtp")
+		}
+	case "file":
+		if config.FileDir == "" {
+			return nil, fmt.Errorf("MAILER_FILE_DIR is required when MAILER_BACKEND is file")
+		}
+	case "log":
+	default:
+		return nil, fmt.Errorf("invalid MAILER_BACKEND: %q", config.Backend)
+	}
+	return config, nil
+}
+
+// LoadJWTKeyConfig はJWTの署名鍵の設定を読み込む。
+// JWT_SIGNING_ALG（デフォルト: EdDSA）、JWT_KEY_ROTATION_DAYS（デフォルト: 30日）、
+// JWT_KEY_PUBLISH_AHEAD（デフォルト: 1時間）、JWT_ACCEPT_HS256（デフォルト: true）
+func LoadJWTKeyConfig() (*JWTKeyConfig, error) {
+	config := &JWTKeyConfig{
+		Algorithm:        os.Getenv("JWT_SIGNING_ALG"),
+		RotationInterval: 30 * 24 * time.Hour,
+		PublishAhead:     time.Hour,
+		AcceptHS256:      os.Getenv("JWT_ACCEPT_HS256") != "false",
+	}
+	if config.Algorithm == "" {
+		config.Algorithm = "EdDSA"
+	}
+	if config.Algorithm != "EdDSA" && config.Algorithm != "RS256" {
+		return nil, fmt.Errorf("invalid JWT_SIGNING_ALG: %q", config.Algorithm)
+	}
+	if daysStr := os.Getenv("JWT_KEY_ROTATION_DAYS"); daysStr != "" {
+		days, err := strconv.Atoi(daysStr)
+		if err != nil || days <= 0 {
+			return nil, fmt.Errorf("JWT_KEY_ROTATION_DAYS must be a positive integer: %q", daysStr)
+		}
+		config.RotationInterval = time.Duration(days) * 24 * time.Hour
+	}
+	if aheadStr := os.Getenv("JWT_KEY_PUBLISH_AHEAD"); aheadStr != "" {
+		ahead, err := time.ParseDuration(aheadStr)
+		if err != nil || ahead < 0 {
+			return nil, fmt.Errorf("invalid JWT_KEY_PUBLISH_AHEAD: %q", aheadStr)
+		}
+		config.PublishAhead = ahead
+	}
+	return config, nil
+}
@@ -176,3 +176,565 @@ func TestLoadSubscriberConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadAttachmentConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		env           map[string]string
+		expectedQuota int64
+		expectedMax   int64
+		expectError   bool
+	}{
+		{
+			name:          "正常系：デフォルト値",
+			env:           map[string]string{},
+			expectedQuota: 1024 << 20,
+			expectedMax:   20 << 20,
+		},
+		{
+			name:          "正常系：S3とカスタム値",
+			env:           map[string]string{"ATTACHMENT_STORAGE": "s3", "ATTACHMENT_QUOTA_MB": "512", "ATTACHMENT_MAX_FILE_MB": "5"},
+			expectedQuota: 512 << 20,
+			expectedMax:   5 << 20,
+		},
+		{
+			name:        "異常系：未対応のストレージ",
+			env:         map[string]string{"ATTACHMENT_STORAGE": "gcs"},
+			expectError: true,
+		},
+		{
+			name:        "異常系：無効な容量（ゼロ）",
+			env:         map[string]string{"ATTACHMENT_QUOTA_MB": "0"},
+			expectError: true,
+		},
+		{
+			name:        "異常系：無効なファイルサイズ（非数値）",
+			env:         map[string]string{"ATTACHMENT_MAX_FILE_MB": "large"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"ATTACHMENT_STORAGE", "ATTACHMENT_QUOTA_MB", "ATTACHMENT_MAX_FILE_MB"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			config, err := LoadAttachmentConfig()
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if config.QuotaBytes != tt.expectedQuota || config.MaxFileBytes != tt.expectedMax {
+				t.Errorf("expected quota %d / max %d, got %d / %d", tt.expectedQuota, tt.expectedMax, config.QuotaBytes, config.MaxFileBytes)
+			}
+		})
+	}
+}
+
+func TestLoadTrashConfig(t *testing.T) {
+	tests := []struct {
+		name              string
+		retentionDays     string
+		expectedRetention time.Duration
+		expectError       bool
+	}{
+		{
+			name:              "正常系：デフォルト値",
+			retentionDays:     "",
+			expectedRetention: 30 * 24 * time.Hour,
+		},
+		{
+			name:              "正常系：カスタム値",
+			retentionDays:     "7",
+			expectedRetention: 7 * 24 * time.Hour,
+		},
+		{
+			name:          "異常系：無効な保持期間（ゼロ）",
+			retentionDays: "0",
+			expectError:   true,
+		},
+		{
+			name:          "異常系：無効な保持期間（非数値）",
+			retentionDays: "month",
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TRASH_RETENTION_DAYS", tt.retentionDays)
+
+			config, err := LoadTrashConfig()
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if config.Retention != tt.expectedRetention {
+				t.Errorf("expected retention %v, got %v", tt.expectedRetention, config.Retention)
+			}
+		})
+	}
+}
+
+func TestLoadAccountDeletionConfig(t *testing.T) {
+	tests := []struct {
+		name                string
+		graceDays           string
+		expectedGracePeriod time.Duration
+		expectError         bool
+	}{
+		{
+			name:                "正常系：デフォルト値",
+			graceDays:           "",
+			expectedGracePeriod: 14 * 24 * time.Hour,
+		},
+		{
+			name:                "正常系：猶予期間なし",
+			graceDays:           "0",
+			expectedGracePeriod: 0,
+		},
+		{
+			name:        "異常系：無効な猶予期間（負数）",
+			graceDays:   "-1",
+			expectError: true,
+		},
+		{
+			name:        "異常系：無効な猶予期間（非数値）",
+			graceDays:   "week",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ACCOUNT_DELETION_GRACE_DAYS", tt.graceDays)
+
+			config, err := LoadAccountDeletionConfig()
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if config.GracePeriod != tt.expectedGracePeriod {
+				t.Errorf("expected grace period %v, got %v", tt.expectedGracePeriod, config.GracePeriod)
+			}
+		})
+	}
+}
+
+func TestLoadRPCRateLimitConfig(t *testing.T) {
+	const semantic = "/diary.DiaryService/SearchDiaryEntriesSemantic"
+	const summary = "/diary.DiaryService/GenerateMonthlySummary"
+
+	tests := []struct {
+		name        string
+		limits      string
+		expected    map[string]RPCRateLimitPolicy // 確認するメソッドの制限
+		disabled    []string                      // 制限しないメソッド
+		expectError bool
+	}{
+		{
+			name:   "正常系：デフォルト値",
+			limits: "",
+			expected: map[string]RPCRateLimitPolicy{
+				semantic: {Limit: 30, Window: time.Minute},
+				summary:  {Limit: 10, Window: time.Hour},
+			},
+		},
+		{
+			name:   "正常系：デフォルトの上書きと追加",
+			limits: summary + "=5/30m, /user.UserService/GetUserInfo=100/1m",
+			expected: map[string]RPCRateLimitPolicy{
+				semantic:                        {Limit: 30, Window: time.Minute},
+				summary:                         {Limit: 5, Window: 30 * time.Minute},
+				"/user.UserService/GetUserInfo": {Limit: 100, Window: time.Minute},
+			},
+		},
+		{
+			name:     "正常系：offで制限しない",
+			limits:   semantic + "=off",
+			disabled: []string{semantic},
+		},
+		{
+			name:        "異常系：期間がない",
+			limits:      summary + "=5",
+			expectError: true,
+		},
+		{
+			name:        "異常系：回数が0",
+			limits:      summary + "=0/1h",
+			expectError: true,
+		},
+		{
+			name:        "異常系：期間が1秒未満",
+			limits:      summary + "=5/500ms",
+			expectError: true,
+		},
+		{
+			name:        "異常系：メソッドがない",
+			limits:      "=5/1h",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RPC_RATE_LIMITS", tt.limits)
+
+			config, err := LoadRPCRateLimitConfig()
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for method, expected := range tt.expected {
+				if got, ok := config.Policies[method]; !ok || got != expected {
+					t.Errorf("expected policy %+v for %s, got %+v (found: %t)", expected, method, got, ok)
+				}
+			}
+			for _, method := range tt.disabled {
+				if _, ok := config.Policies[method]; ok {
+					t.Errorf("expected %s to be disabled", method)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadDataExportConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		expiryHours    string
+		expectedExpiry time.Duration
+		expectError    bool
+	}{
+		{
+			name:           "正常系：デフォルト値",
+			expiryHours:    "",
+			expectedExpiry: 72 * time.Hour,
+		},
+		{
+			name:           "正常系：カスタム値",
+			expiryHours:    "24",
+			expectedExpiry: 24 * time.Hour,
+		},
+		{
+			name:        "異常系：無効な期間（負の値）",
+			expiryHours: "-1",
+			expectError: true,
+		},
+		{
+			name:        "異常系：無効な期間（非数値）",
+			expiryHours: "day",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DATA_EXPORT_EXPIRY_HOURS", tt.expiryHours)
+
+			config, err := LoadDataExportConfig()
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if config.Expiry != tt.expectedExpiry {
+				t.Errorf("expected expiry %v, got %v", tt.expectedExpiry, config.Expiry)
+			}
+		})
+	}
+}
+
+func TestLoadBackupConfig(t *testing.T) {
+	t.Run("正常系：未設定の場合はローカルの保存先なし", func(t *testing.T) {
+		t.Setenv("BACKUP_LOCAL_DIR", "")
+		if config := LoadBackupConfig(); config.LocalDir != "" {
+			t.Errorf("expected empty local dir, got %q", config.LocalDir)
+		}
+	})
+
+	t.Run("正常系：設定したディレクトリを使う", func(t *testing.T) {
+		t.Setenv("BACKUP_LOCAL_DIR", "/data/backups")
+		if config := LoadBackupConfig(); config.LocalDir != "/data/backups" {
+			t.Errorf("expected /data/backups, got %q", config.LocalDir)
+		}
+	})
+}
+
+func TestLoadInvitationRequired(t *testing.T) {
+	tests := []struct {
+		name               string
+		invitationRequired string
+		registerKey        string
+		expected           bool
+	}{
+		{"正常系：未設定の場合は誰でも登録できる", "", "", false},
+		{"正常系：INVITATION_REQUIRED=trueの場合は招待制", "true", "", true},
+		{"正常系：REGISTER_KEYが設定されている場合は招待制", "", "secret", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("INVITATION_REQUIRED", tt.invitationRequired)
+			t.Setenv("REGISTER_KEY", tt.registerKey)
+			if got := LoadInvitationRequired(); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLoadWebhookAllowPrivateNetworks(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected bool
+	}{
+		{"正常系：未設定の場合は許可しない", "", false},
+		{"正常系：trueの場合は許可する", "true", true},
+		{"正常系：true以外の値は許可しない", "1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", tt.value)
+			if got := LoadWebhookAllowPrivateNetworks(); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLoadOIDCConfig(t *testing.T) {
+	t.Run("正常系：未設定の場合はプロバイダーなし", func(t *testing.T) {
+		t.Setenv("OIDC_PROVIDERS", "")
+		configs, err := LoadOIDCConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(configs) != 0 {
+			t.Errorf("expected no providers, got %d", len(configs))
+		}
+	})
+
+	t.Run("正常系：googleはissuerと表示名を省略でき、他のプロバイダーは設定した値を使う", func(t *testing.T) {
+		t.Setenv("OIDC_PROVIDERS", "google, my-idp")
+		t.Setenv("OIDC_REDIRECT_URL", "")
+		t.Setenv("FRONTEND_BASE_URL", "https://umi.example.com")
+		t.Setenv("OIDC_GOOGLE_CLIENT_ID", "google-client")
+		t.Setenv("OIDC_GOOGLE_CLIENT_SECRET", "google-secret")
+		t.Setenv("OIDC_MY_IDP_CLIENT_ID", "my-client")
+		t.Setenv("OIDC_MY_IDP_ISSUER", "https://idp.example.com")
+		t.Setenv("OIDC_MY_IDP_NAME", "社内SSO")
+		t.Setenv("OIDC_MY_IDP_SCOPES", "openid,email")
+
+		configs, err := LoadOIDCConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(configs) != 2 {
+			t.Fatalf("expected 2 providers, got %d", len(configs))
+		}
+		google := configs[0]
+		if google.ID != "google" || google.Name != "Google" || google.Issuer != googleIssuer || google.ClientSecret != "google-secret" {
+			t.Errorf("unexpected google config: %+v", google)
+		}
+		if google.RedirectURL != "https://umi.example.com/auth/oidc/callback" {
+			t.Errorf("unexpected redirect url: %s", google.RedirectURL)
+		}
+		other := configs[1]
+		if other.ID != "my-idp" || other.Name != "社内SSO" || other.Issuer != "https://idp.example.com" || len(other.Scopes) != 2 {
+			t.Errorf("unexpected my-idp config: %+v", other)
+		}
+	})
+
+	t.Run("異常系：CLIENT_IDがない場合はエラー", func(t *testing.T) {
+		t.Setenv("OIDC_PROVIDERS", "google")
+		t.Setenv("OIDC_GOOGLE_CLIENT_ID", "")
+		if _, err := LoadOIDCConfig(); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("異常系：google以外でISSUERがない場合はエラー", func(t *testing.T) {
+		t.Setenv("OIDC_PROVIDERS", "other")
+		t.Setenv("OIDC_OTHER_CLIENT_ID", "client")
+		t.Setenv("OIDC_OTHER_ISSUER", "")
+		if _, err := LoadOIDCConfig(); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestLoadWebAuthnConfig(t *testing.T) {
+	t.Run("正常系：未設定の場合はFRONTEND_BASE_URLのホスト名とoriginを使う", func(t *testing.T) {
+		t.Setenv("FRONTEND_BASE_URL", "https://umi.example.com:8443/")
+		t.Setenv("WEBAUTHN_RP_ID", "")
+		t.Setenv("WEBAUTHN_RP_NAME", "")
+		t.Setenv("WEBAUTHN_ORIGINS", "")
+		config, err := LoadWebAuthnConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config.RPID != "umi.example.com" || config.RPName != "umi.mikan" {
+			t.Errorf("unexpected config: %+v", config)
+		}
+		if len(config.Origins) != 1 || config.Origins[0] != "https://umi.example.com:8443" {
+			t.Errorf("unexpected origins: %v", config.Origins)
+		}
+	})
+
+	t.Run("正常系：設定した値を使い、WEBAUTHN_ORIGINSはカンマ区切りで複数指定できる", func(t *testing.T) {
+		t.Setenv("WEBAUTHN_RP_ID", "example.com")
+		t.Setenv("WEBAUTHN_RP_NAME", "日記")
+		t.Setenv("WEBAUTHN_ORIGINS", "https://umi.example.com, https://app.example.com/")
+		config, err := LoadWebAuthnConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config.RPID != "example.com" || config.RPName != "日記" {
+			t.Errorf("unexpected config: %+v", config)
+		}
+		if len(config.Origins) != 2 || config.Origins[1] != "https://app.example.com" {
+			t.Errorf("unexpected origins: %v", config.Origins)
+		}
+	})
+}
+
+func TestLoadMailerConfig(t *testing.T) {
+	t.Run("正常系：未設定の場合はログに出力する", func(t *testing.T) {
+		t.Setenv("MAILER_BACKEND", "")
+		t.Setenv("MAIL_FROM", "")
+		t.Setenv("SMTP_PORT", "")
+		config, err := LoadMailerConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config.Backend != "log" || config.From != "noreply@localhost" || config.SMTPPort != 587 {
+			t.Errorf("unexpected config: %+v", config)
+		}
+	})
+
+	t.Run("正常系：SMTPの設定を読み込む", func(t *testing.T) {
+		t.Setenv("MAILER_BACKEND", "smtp")
+		t.Setenv("MAIL_FROM", "umi.mikan <noreply@example.com>")
+		t.Setenv("SMTP_HOST", "smtp.example.com")
+		t.Setenv("SMTP_PORT", "2525")
+		t.Setenv("SMTP_USERNAME", "user")
+		t.Setenv("SMTP_PASSWORD", "pass")
+		config, err := LoadMailerConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config.SMTPHost != "smtp.example.com" || config.SMTPPort != 2525 || config.SMTPUser != "user" || config.SMTPPass != "pass" {
+			t.Errorf("unexpected config: %+v", config)
+		}
+	})
+
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{name: "異常系：smtpでSMTP_HOSTがない", env: map[string]string{"MAILER_BACKEND": "smtp", "SMTP_HOST": ""}},
+		{name: "異常系：fileでMAILER_FILE_DIRがない", env: map[string]string{"MAILER_BACKEND": "file", "MAILER_FILE_DIR": ""}},
+		{name: "異常系：未対応の送信方法", env: map[string]string{"MAILER_BACKEND": "sendgrid"}},
+		{name: "異常系：SMTP_PORTが不正", env: map[string]string{"MAILER_BACKEND": "log", "SMTP_PORT": "abc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if _, err := LoadMailerConfig(); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadJWTKeyConfig(t *testing.T) {
+	t.Run("正常系：未設定の場合はEdDSAで30日ごとにローテーションする", func(t *testing.T) {
+		t.Setenv("JWT_SIGNING_ALG", "")
+		t.Setenv("JWT_KEY_ROTATION_DAYS", "")
+		t.Setenv("JWT_KEY_PUBLISH_AHEAD", "")
+		t.Setenv("JWT_ACCEPT_HS256", "")
+		config, err := LoadJWTKeyConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config.Algorithm != "EdDSA" || config.RotationInterval != 30*24*time.Hour || config.PublishAhead != time.Hour || !config.AcceptHS256 {
+			t.Errorf("unexpected config: %+v", config)
+		}
+	})
+
+	t.Run("正常系：設定した値を読み込む", func(t *testing.T) {
+		t.Setenv("JWT_SIGNING_ALG", "RS256")
+		t.Setenv("JWT_KEY_ROTATION_DAYS", "7")
+		t.Setenv("JWT_KEY_PUBLISH_AHEAD", "10m")
+		t.Setenv("JWT_ACCEPT_HS256", "false")
+		config, err := LoadJWTKeyConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config.Algorithm != "RS256" || config.RotationInterval != 7*24*time.Hour || config.PublishAhead != 10*time.Minute || config.AcceptHS256 {
+			t.Errorf("unexpected config: %+v", config)
+		}
+	})
+
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{name: "異常系：未対応のアルゴリズム", env: map[string]string{"JWT_SIGNING_ALG": "HS256"}},
+		{name: "異常系：JWT_KEY_ROTATION_DAYSが0", env: map[string]string{"JWT_KEY_ROTATION_DAYS": "0"}},
+		{name: "異常系：JWT_KEY_PUBLISH_AHEADが不正", env: map[string]string{"JWT_KEY_PUBLISH_AHEAD": "abc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if _, err := LoadJWTKeyConfig(); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
@@ -4,14 +4,26 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"log"
 	"time"
 
 	"github.com/project-mikan/umi.mikan/backend/constants"
+	"github.com/project-mikan/umi.mikan/backend/domain/model"
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/emailtoken"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/jwtkeys"
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/llm"
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/lock"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/mailer"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/mfa"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/migrate"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/oidc"
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/storage"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/webauthn"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"github.com/project-mikan/umi.mikan/backend/service/admin"
 	"github.com/project-mikan/umi.mikan/backend/service/auth"
 	"github.com/project-mikan/umi.mikan/backend/service/diary"
 	"github.com/project-mikan/umi.mikan/backend/service/entity"
@@ -59,6 +71,24 @@ func (c *Container) registerProviders() error {
 	if err := c.container.Provide(NewRateLimitConfig); err != nil {
 		return fmt.Errorf("failed to provide NewRateLimitConfig: %w", err)
 	}
+	if err := c.container.Provide(NewRPCRateLimitConfig); err != nil {
+		return fmt.Errorf("failed to provide NewRPCRateLimitConfig: %w", err)
+	}
+	if err := c.container.Provide(NewAttachmentConfig); err != nil {
+		return fmt.Errorf("failed to provide NewAttachmentConfig: %w", err)
+	}
+	if err := c.container.Provide(NewTrashConfig); err != nil {
+		return fmt.Errorf("failed to provide NewTrashConfig: %w", err)
+	}
+	if err := c.container.Provide(NewDataExportConfig); err != nil {
+		return fmt.Errorf("failed to provide NewDataExportConfig: %w", err)
+	}
+	if err := c.container.Provide(NewBackupConfig); err != nil {
+		return fmt.Errorf("failed to provide NewBackupConfig: %w", err)
+	}
+	if err := c.container.Provide(NewAccountDeletionConfig); err != nil {
+		return fmt.Errorf("failed to provide NewAccountDeletionConfig: %w", err)
+	}
 
 	// Infrastructure providers
 	if err := c.container.Provide(NewDatabase); err != nil {
@@ -76,12 +106,39 @@ func (c *Container) registerProviders() error {
 	if err := c.container.Provide(NewRateLimiter); err != nil {
 		return fmt.Errorf("failed to provide NewRateLimiter: %w", err)
 	}
+	if err := c.container.Provide(NewRPCLimiter); err != nil {
+		return fmt.Errorf("failed to provide NewRPCLimiter: %w", err)
+	}
+	if err := c.container.Provide(NewAccountChecker); err != nil {
+		return fmt.Errorf("failed to provide NewAccountChecker: %w", err)
+	}
 	if err := c.container.Provide(NewLoginAttemptLimiter); err != nil {
 		return fmt.Errorf("failed to provide NewLoginAttemptLimiter: %w", err)
 	}
 	if err := c.container.Provide(NewRegisterAttemptLimiter); err != nil {
 		return fmt.Errorf("failed to provide NewRegisterAttemptLimiter: %w", err)
 	}
+	if err := c.container.Provide(NewOIDCRegistry); err != nil {
+		return fmt.Errorf("failed to provide NewOIDCRegistry: %w", err)
+	}
+	if err := c.container.Provide(NewWebAuthnRelyingParty); err != nil {
+		return fmt.Errorf("failed to provide NewWebAuthnRelyingParty: %w", err)
+	}
+	if err := c.container.Provide(NewEmailAttemptLimiter); err != nil {
+		return fmt.Errorf("failed to provide NewEmailAttemptLimiter: %w", err)
+	}
+	if err := c.container.Provide(NewMailer); err != nil {
+		return fmt.Errorf("failed to provide NewMailer: %w", err)
+	}
+	if err := c.container.Provide(NewEmailTokenSigner); err != nil {
+		return fmt.Errorf("failed to provide NewEmailTokenSigner: %w", err)
+	}
+	if err := c.container.Provide(NewJWTKeyManager); err != nil {
+		return fmt.Errorf("failed to provide NewJWTKeyManager: %w", err)
+	}
+	if err := c.container.Provide(NewStorage); err != nil {
+		return fmt.Errorf("failed to provide NewStorage: %w", err)
+	}
 
 	// Service providers
 	if err := c.container.Provide(NewAuthService); err != nil {
@@ -96,6 +153,9 @@ func (c *Container) registerProviders() error {
 	if err := c.container.Provide(NewUserService); err != nil {
 		return fmt.Errorf("failed to provide NewUserService: %w", err)
 	}
+	if err := c.container.Provide(NewAdminService); err != nil {
+		return fmt.Errorf("failed to provide NewAdminService: %w", err)
+	}
 
 	// Application providers
 	if err := c.container.Provide(NewServerApp); err != nil {
@@ -123,11 +183,12 @@ func (c *Container) Invoke(fn any) error {
 
 // Configuration types
 type DBConfig struct {
-	Host     string
-	Port     int
-	User     string
-	Password string
-	DBName   string
+	Host             string
+	Port             int
+	User             string
+	Password         string
+	DBName           string
+	MigrateOnStartup bool // apply pending migrations after connecting (DB_MIGRATE_ON_STARTUP)
 }
 
 type RedisConfig struct {
@@ -141,6 +202,8 @@ type SchedulerConfig struct {
 	LatestTrendTargetMinute    int
 	DiaryEmbeddingTargetHour   int
 	DiaryEmbeddingTargetMinute int
+	TrashPurgeTargetHour       int
+	TrashPurgeTargetMinute     int
 }
 
 type SubscriberConfig struct {
@@ -152,6 +215,39 @@ type RateLimitConfig struct {
 	LoginWindow         time.Duration
 	RegisterMaxAttempts int
 	RegisterWindow      time.Duration
+	EmailMaxAttempts    int
+	EmailWindow         time.Duration
+}
+
+// RPCRateLimitConfig はRPC（MCPのツールを含む）ごとのレート制限の設定
+type RPCRateLimitConfig struct {
+	Policies map[string]ratelimiter.Policy
+}
+
+// AttachmentConfig は添付ファイルの保存先と容量制限の設定
+type AttachmentConfig struct {
+	Storage storage.Config
+	Limits  diary.AttachmentLimits
+}
+
+// TrashConfig はゴミ箱の設定
+type TrashConfig struct {
+	Retention time.Duration
+}
+
+// DataExportConfig はデータエクスポートの設定
+type DataExportConfig struct {
+	Expiry time.Duration
+}
+
+// BackupConfig は定期バックアップの設定
+type BackupConfig struct {
+	LocalDir string
+}
+
+// AccountDeletionConfig はアカウントの削除の設定
+type AccountDeletionConfig struct {
+	GracePeriod time.Duration
 }
 
 // LLMClientFactory creates LLM clients
@@ -195,11 +291,12 @@ func NewDBConfig() (*DBConfig, error) {
 	}
 
 	return &DBConfig{
-		Host:     config.Host,
-		Port:     config.Port,
-		User:     config.User,
-		Password: config.Password,
-		DBName:   config.DBName,
+		Host:             config.Host,
+		Port:             config.Port,
+		User:             config.User,
+		Password:         config.Password,
+		DBName:           config.DBName,
+		MigrateOnStartup: config.MigrateOnStartup,
 	}, nil
 }
 
@@ -229,6 +326,8 @@ func NewSchedulerConfig() (*SchedulerConfig, error) {
 		LatestTrendTargetMinute:    config.LatestTrendTargetMinute,
 		DiaryEmbeddingTargetHour:   config.DiaryEmbeddingTargetHour,
 		DiaryEmbeddingTargetMinute: config.DiaryEmbeddingTargetMinute,
+		TrashPurgeTargetHour:       config.TrashPurgeTargetHour,
+		TrashPurgeTargetMinute:     config.TrashPurgeTargetMinute,
 	}, nil
 }
 
@@ -256,11 +355,239 @@ func NewRateLimitConfig() (*RateLimitConfig, error) {
 		LoginWindow:         config.LoginWindow,
 		RegisterMaxAttempts: config.RegisterMaxAttempts,
 		RegisterWindow:      config.RegisterWindow,
+		EmailMaxAttempts:    config.EmailMaxAttempts,
+		EmailWindow:         config.EmailWindow,
+	}, nil
+}
+
+// NewAttachmentConfig creates attachment configuration
+func NewAttachmentConfig() (*AttachmentConfig, error) {
+	config, err := constants.LoadAttachmentConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load attachment config: %w", err)
+	}
+
+	return &AttachmentConfig{
+		Storage: storage.Config{
+			Backend:  config.StorageBackend,
+			LocalDir: config.LocalDir,
+			S3: storage.S3Config{
+				Endpoint:        config.S3Endpoint,
+				Region:          config.S3Region,
+				Bucket:          config.S3Bucket,
+				AccessKeyID:     config.S3AccessKeyID,
+				SecretAccessKey: config.S3SecretAccessKey,
+				UsePathStyle:    config.S3UsePathStyle,
+			},
+		},
+		Limits: diary.AttachmentLimits{
+			QuotaBytes:   config.QuotaBytes,
+			MaxFileBytes: config.MaxFileBytes,
+		},
+	}, nil
+}
+
+// NewRPCRateLimitConfig creates per-RPC rate limit configuration
+func NewRPCRateLimitConfig() (*RPCRateLimitConfig, error) {
+	config, err := constants.LoadRPCRateLimitConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rpc rate limit config: %w", err)
+	}
+
+	policies := make(map[string]ratelimiter.Policy, len(config.Policies))
+	for method, policy := range config.Policies {
+		policies[method] = ratelimiter.Policy{Limit: policy.Limit, Window: policy.Window}
+	}
+	return &RPCRateLimitConfig{Policies: policies}, nil
+}
+
+// NewTrashConfig creates trash configuration
+func NewTrashConfig() (*TrashConfig, error) {
+	config, err := constants.LoadTrashConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trash config: %w", err)
+	}
+
+	return &TrashConfig{
+		Retention: config.Retention,
 	}, nil
 }
 
-// NewDatabase creates a database connection with retry logic
+// NewDataExportConfig creates data export configuration
+func NewDataExportConfig() (*DataExportConfig, error) {
+	config, err := constants.LoadDataExportConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data export config: %w", err)
+	}
+
+	return &DataExportConfig{
+		Expiry: config.Expiry,
+	}, nil
+}
+
+// NewBackupConfig creates backup configuration
+func NewBackupConfig() *BackupConfig {
+	config := constants.LoadBackupConfig()
+	return &BackupConfig{
+		LocalDir: config.LocalDir,
+	}
+}
+
+// NewAccountDeletionConfig creates account deletion configuration
+func NewAccountDeletionConfig() (*AccountDeletionConfig, error) {
+	config, err := constants.LoadAccountDeletionConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account deletion config: %w", err)
+	}
+
+	return &AccountDeletionConfig{
+		GracePeriod: config.GracePeriod,
+	}, nil
+}
+
+// NewOIDCRegistry creates the OpenID Connect providers configured via OIDC_PROVIDERS
+func NewOIDCRegistry() (*oidc.Registry, error) {
+	configs, err := constants.LoadOIDCConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load oidc config: %w", err)
+	}
+
+	providers := make([]oidc.Config, 0, len(configs))
+	for _, c := range configs {
+		providers = append(providers, oidc.Config{
+			ID:           c.ID,
+			Name:         c.Name,
+			Issuer:       c.Issuer,
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			Scopes:       c.Scopes,
+			RedirectURL:  c.RedirectURL,
+		})
+	}
+	return oidc.NewRegistry(providers, nil), nil
+}
+
+// NewWebAuthnRelyingParty creates the passkey relying party configured via WEBAUTHN_RP_ID and WEBAUTHN_ORIGINS
+func NewWebAuthnRelyingParty() (*webauthn.RelyingParty, error) {
+	config, err := constants.LoadWebAuthnConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webauthn config: %w", err)
+	}
+	return webauthn.NewRelyingParty(webauthn.Config{
+		RPID:    config.RPID,
+		RPName:  config.RPName,
+		Origins: config.Origins,
+	}), nil
+}
+
+// NewMailer creates the mailer for password reset and email verification configured via MAILER_BACKEND
+func NewMailer() (mailer.Mailer, error) {
+	config, err := constants.LoadMailerConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load mailer config: %w", err)
+	}
+	m, err := mailer.New(mailer.Config{
+		Backend:  mailer.Backend(config.Backend),
+		From:     config.From,
+		SMTPHost: config.SMTPHost,
+		SMTPPort: config.SMTPPort,
+		SMTPUser: config.SMTPUser,
+		SMTPPass: config.SMTPPass,
+		FileDir:  config.FileDir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mailer: %w", err)
+	}
+	return m, nil
+}
+
+// NewEmailTokenSigner creates the signer for password reset and email verification tokens
+func NewEmailTokenSigner() (*emailtoken.Signer, error) {
+	secret, err := constants.LoadJWTSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jwt secret: %w", err)
+	}
+	return emailtoken.NewSigner(secret), nil
+}
+
+// jwtKeyInitTimeout bounds the initial load of the JWT signing keys at server startup
+const jwtKeyInitTimeout = 30 * time.Second
+
+// NewJWTKeyManager loads (and creates on first start) the asymmetric JWT signing keys,
+// makes token issuing and verification use them, and rotates them in the background
+func NewJWTKeyManager(db *sql.DB) (*jwtkeys.Manager, error) {
+	config, err := constants.LoadJWTKeyConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jwt key config: %w", err)
+	}
+	alg, err := jwtkeys.ParseAlgorithm(config.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := constants.LoadJWTSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jwt secret: %w", err)
+	}
+	store, err := jwtkeys.NewStore(db, secret)
+	if err != nil {
+		return nil, err
+	}
+	manager := jwtkeys.NewManager(store, jwtkeys.Policy{
+		Algorithm:         alg,
+		RotationInterval:  config.RotationInterval,
+		PublishAhead:      config.PublishAhead,
+		VerifyAfterRetire: model.RefreshTokenLifetime,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), jwtKeyInitTimeout)
+	defer cancel()
+	if err := manager.Refresh(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load jwt signing keys: %w", err)
+	}
+	model.SetTokenKeys(manager, config.AcceptHS256)
+	manager.Start(context.Background())
+	return manager, nil
+}
+
+// NewDatabase creates a database connection and applies pending migrations when MigrateOnStartup is enabled
 func NewDatabase(config *DBConfig) (*sql.DB, error) {
+	db, err := connectDatabase(config)
+	if err != nil {
+		return nil, err
+	}
+	if !config.MigrateOnStartup {
+		return db, nil
+	}
+
+	applied, err := migrate.UpEmbedded(context.Background(), db)
+	for _, m := range applied {
+		log.Printf("Applied migration %s", m)
+	}
+	if err != nil {
+		if closeErr := db.Close(); closeErr != nil {
+			log.Printf("Failed to close database connection: %v", closeErr)
+		}
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+	return db, nil
+}
+
+// RunMigrateCommand runs the migrate subcommand without building the DI container or migrating on startup
+func RunMigrateCommand(args []string, out io.Writer) error {
+	config, err := NewDBConfig()
+	if err != nil {
+		return err
+	}
+	db, err := connectDatabase(config)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	return migrate.Run(context.Background(), db, args, out)
+}
+
+// connectDatabase creates a database connection with retry logic
+func connectDatabase(config *DBConfig) (*sql.DB, error) {
 	const maxRetries = 5
 	const baseDelay = 2 * time.Second
 
@@ -326,6 +653,16 @@ func NewRateLimiter(redis rueidis.Client) ratelimiter.RateLimiter {
 	return ratelimiter.NewRedisRateLimiter(redis)
 }
 
+// NewAccountChecker creates the check of disabled, deletion-scheduled and session-revoked users shared by gRPC, ConnectRPC and the MCP server
+func NewAccountChecker(db *sql.DB) *middleware.AccountChecker {
+	return middleware.NewAccountChecker(db)
+}
+
+// NewRPCLimiter creates the per-user and per-API-key RPC rate limiter shared by gRPC, ConnectRPC and the MCP server
+func NewRPCLimiter(rateLimiter ratelimiter.RateLimiter, config *RPCRateLimitConfig) *ratelimiter.RPCLimiter {
+	return ratelimiter.NewRPCLimiter(rateLimiter, config.Policies)
+}
+
 // NewLoginAttemptLimiter creates a login attempt limiter
 func NewLoginAttemptLimiter(rateLimiter ratelimiter.RateLimiter, config *RateLimitConfig) *ratelimiter.LoginAttemptLimiter {
 	return ratelimiter.NewLoginAttemptLimiter(rateLimiter, config.LoginMaxAttempts, config.LoginWindow)
@@ -336,23 +673,51 @@ func NewRegisterAttemptLimiter(rateLimiter ratelimiter.RateLimiter, config *Rate
 	return ratelimiter.NewRegisterAttemptLimiter(rateLimiter, config.RegisterMaxAttempts, config.RegisterWindow)
 }
 
+// NewEmailAttemptLimiter creates an email attempt limiter
+func NewEmailAttemptLimiter(rateLimiter ratelimiter.RateLimiter, config *RateLimitConfig) *ratelimiter.EmailAttemptLimiter {
+	return ratelimiter.NewEmailAttemptLimiter(rateLimiter, config.EmailMaxAttempts, config.EmailWindow)
+}
+
+// NewStorage creates the attachment storage
+func NewStorage(config *AttachmentConfig) (storage.Storage, error) {
+	s, err := storage.New(config.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create attachment storage: %w", err)
+	}
+	return s, nil
+}
+
 // NewAuthService creates an auth service
-func NewAuthService(db *sql.DB, loginLimiter *ratelimiter.LoginAttemptLimiter, registerLimiter *ratelimiter.RegisterAttemptLimiter) *auth.AuthEntry {
+func NewAuthService(db *sql.DB, redis rueidis.Client, loginLimiter *ratelimiter.LoginAttemptLimiter, registerLimiter *ratelimiter.RegisterAttemptLimiter, oidcProviders *oidc.Registry, relyingParty *webauthn.RelyingParty, emailLimiter *ratelimiter.EmailAttemptLimiter, m mailer.Mailer, emailTokens *emailtoken.Signer) *auth.AuthEntry {
 	registerKey := constants.LoadRegisterKey()
 	return &auth.AuthEntry{
-		DB:              db,
-		LoginLimiter:    loginLimiter,
-		RegisterLimiter: registerLimiter,
-		RegisterKey:     registerKey,
+		DB:                 db,
+		LoginLimiter:       loginLimiter,
+		RegisterLimiter:    registerLimiter,
+		RegisterKey:        registerKey,
+		InvitationRequired: constants.LoadInvitationRequired(),
+		OIDCProviders:      oidcProviders,
+		OIDCStates:         &oidc.StateStore{Redis: redis},
+		MFAChallenges:      &mfa.ChallengeStore{Redis: redis},
+		WebAuthn:           relyingParty,
+		PasskeyChallenges:  &webauthn.ChallengeStore{Redis: redis},
+		Mailer:             m,
+		EmailTokens:        emailTokens,
+		UsedEmailTokens:    &emailtoken.UsedStore{Redis: redis},
+		EmailLimiter:       emailLimiter,
+		FrontendBaseURL:    constants.LoadFrontendBaseURL(),
 	}
 }
 
 // NewDiaryService creates a diary service
-func NewDiaryService(db *sql.DB, redis rueidis.Client) *diary.DiaryEntry {
+func NewDiaryService(db *sql.DB, redis rueidis.Client, attachmentStorage storage.Storage, attachmentConfig *AttachmentConfig, trashConfig *TrashConfig) *diary.DiaryEntry {
 	return &diary.DiaryEntry{
-		DB:         db,
-		Redis:      redis,
-		LLMFactory: &diaryLLMFactory{},
+		DB:               db,
+		Redis:            redis,
+		LLMFactory:       &diaryLLMFactory{},
+		Storage:          attachmentStorage,
+		AttachmentLimits: attachmentConfig.Limits,
+		TrashRetention:   trashConfig.Retention,
 	}
 }
 
@@ -362,8 +727,25 @@ func NewEntityService(db *sql.DB) *entity.EntityEntry {
 }
 
 // NewUserService creates a user service
-func NewUserService(db *sql.DB, redis rueidis.Client) *user.UserEntry {
-	return &user.UserEntry{DB: db, RedisClient: redis}
+func NewUserService(db *sql.DB, redis rueidis.Client, attachmentStorage storage.Storage, backupConfig *BackupConfig, m mailer.Mailer, emailTokens *emailtoken.Signer, accountDeletionConfig *AccountDeletionConfig, rpcLimiter *ratelimiter.RPCLimiter) *user.UserEntry {
+	return &user.UserEntry{
+		DB:                  db,
+		RedisClient:         redis,
+		Storage:             attachmentStorage,
+		BackupLocalDir:      backupConfig.LocalDir,
+		Mailer:              m,
+		EmailTokens:         emailTokens,
+		FrontendBaseURL:     constants.LoadFrontendBaseURL(),
+		DeletionGracePeriod: accountDeletionConfig.GracePeriod,
+		RPCLimiter:          rpcLimiter,
+
+		WebhookAllowPrivateNetworks: constants.LoadWebhookAllowPrivateNetworks(),
+	}
+}
+
+// NewAdminService creates an admin service
+func NewAdminService(db *sql.DB, diaryService *diary.DiaryEntry) *admin.AdminEntry {
+	return &admin.AdminEntry{DB: db, Embeddings: diaryService}
 }
 
 // Application types
@@ -376,6 +758,11 @@ type ServerApp struct {
 	DiaryService  *diary.DiaryEntry
 	EntityService *entity.EntityEntry
 	UserService   *user.UserEntry
+	AdminService  *admin.AdminEntry
+	JWTKeys       *jwtkeys.Manager        // 生成時にトークンの署名・検証に使う鍵を設定する
+	RPCLimiter    *ratelimiter.RPCLimiter // gRPC・ConnectRPC・MCPサーバーのRPCごとのレート制限
+	// gRPC・ConnectRPC・MCPサーバーで認証したユーザーの状態（無効・削除の予定・セッションの取り消し）の確認
+	AccountChecker *middleware.AccountChecker
 }
 
 // SchedulerApp represents the scheduler application
@@ -383,6 +770,9 @@ type SchedulerApp struct {
 	DB              *sql.DB
 	Redis           rueidis.Client
 	SchedulerConfig *SchedulerConfig
+	TrashConfig     *TrashConfig
+	BackupConfig    *BackupConfig
+	Storage         storage.Storage // ゴミ箱の完全な削除・期限切れのデータエクスポートの削除で実体を消すため
 }
 
 // SubscriberApp represents the subscriber application
@@ -392,6 +782,10 @@ type SubscriberApp struct {
 	LLMFactory       LLMClientFactory
 	LockService      LockService
 	SubscriberConfig *SubscriberConfig
+	DataExportConfig *DataExportConfig
+	Storage          storage.Storage // データエクスポートのアーカイブの保存先（添付ファイルと同じ）
+	BackupConfig     *BackupConfig   // アカウントの削除でローカルの定期バックアップを消すため
+	Mailer           mailer.Mailer   // アカウントの削除の完了の通知
 }
 
 // NewServerApp creates a server application
@@ -402,14 +796,22 @@ func NewServerApp(
 	diaryService *diary.DiaryEntry,
 	entityService *entity.EntityEntry,
 	userService *user.UserEntry,
+	adminService *admin.AdminEntry,
+	jwtKeys *jwtkeys.Manager,
+	rpcLimiter *ratelimiter.RPCLimiter,
+	accountChecker *middleware.AccountChecker,
 ) *ServerApp {
 	return &ServerApp{
-		DB:            db,
-		Redis:         redis,
-		AuthService:   authService,
-		DiaryService:  diaryService,
-		EntityService: entityService,
-		UserService:   userService,
+		DB:             db,
+		Redis:          redis,
+		AuthService:    authService,
+		DiaryService:   diaryService,
+		EntityService:  entityService,
+		UserService:    userService,
+		AdminService:   adminService,
+		JWTKeys:        jwtKeys,
+		RPCLimiter:     rpcLimiter,
+		AccountChecker: accountChecker,
 	}
 }
 
@@ -418,11 +820,17 @@ func NewSchedulerApp(
 	db *sql.DB,
 	redis rueidis.Client,
 	config *SchedulerConfig,
+	trashConfig *TrashConfig,
+	backupConfig *BackupConfig,
+	attachmentStorage storage.Storage,
 ) *SchedulerApp {
 	return &SchedulerApp{
 		DB:              db,
 		Redis:           redis,
 		SchedulerConfig: config,
+		TrashConfig:     trashConfig,
+		BackupConfig:    backupConfig,
+		Storage:         attachmentStorage,
 	}
 }
 
@@ -433,6 +841,10 @@ func NewSubscriberApp(
 	llmFactory LLMClientFactory,
 	lockService LockService,
 	config *SubscriberConfig,
+	dataExportConfig *DataExportConfig,
+	attachmentStorage storage.Storage,
+	backupConfig *BackupConfig,
+	m mailer.Mailer,
 ) *SubscriberApp {
 	return &SubscriberApp{
 		DB:               db,
@@ -440,6 +852,10 @@ func NewSubscriberApp(
 		LLMFactory:       llmFactory,
 		LockService:      lockService,
 		SubscriberConfig: config,
+		DataExportConfig: dataExportConfig,
+		Storage:          attachmentStorage,
+		BackupConfig:     backupConfig,
+		Mailer:           m,
 	}
 }
 
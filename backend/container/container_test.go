@@ -178,6 +178,12 @@ func TestApplicationBundles(t *testing.T) {
 			if app.UserService == nil {
 				t.Error("ServerApp.UserService should not be nil")
 			}
+			if app.AdminService == nil {
+				t.Error("ServerApp.AdminService should not be nil")
+			}
+			if app.JWTKeys == nil {
+				t.Error("ServerApp.JWTKeys should not be nil")
+			}
 		})
 		if err != nil {
 			t.Errorf("failed to resolve ServerApp: %v", err)
@@ -8,6 +8,7 @@ type AuthType int
 
 const (
 	AuthTypeEmailPassword AuthType = iota
+	AuthTypeOIDC                   // OpenID Connect（パスワードなし）
 )
 
 type AuthTableType database.UserPasswordAuthe
@@ -20,6 +21,8 @@ func GetAuthTypeFromInt16(authType int16) AuthType {
 	switch authType {
 	case AuthTypeEmailPassword.Int16():
 		return AuthTypeEmailPassword
+	case AuthTypeOIDC.Int16():
+		return AuthTypeOIDC
 	default:
 		return AuthTypeEmailPassword
 	}
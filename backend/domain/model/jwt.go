@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -10,6 +11,7 @@ import (
 	"github.com/project-mikan/umi.mikan/backend/constants"
 
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/jwtkeys"
 )
 
 const (
@@ -24,11 +26,15 @@ const (
 	tokenUseRefresh = "refresh"
 )
 
+// RefreshTokenLifetime は発行するトークンの最長の有効期限。署名鍵を切り替えた後も、この期間は前の鍵で検証する
+const RefreshTokenLifetime = refreshTokenExpirationDays
+
 type TokenDetails struct {
 	AccessToken  string
 	TokenType    string
 	ExpiresIn    int64
 	RefreshToken string
+	IssuedAt     int64 // トークンの発行日時（UNIX秒）。検証したトークンの場合のみ設定する
 }
 
 type Claims struct {
@@ -39,13 +45,40 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
-func GenerateAuthTokens(userID string) (*TokenDetails, error) {
-	jwtS, err := constants.LoadJWTSecret()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load JWT Secret: %w", err)
+// TokenKeySource はJWTの署名・検証に使う鍵セットを返す（jwtkeys.Manager）
+type TokenKeySource interface {
+	Current() *jwtkeys.Keyset
+}
+
+type tokenKeyConfig struct {
+	source      TokenKeySource
+	acceptHS256 bool
+}
+
+// tokenKeys はSetTokenKeysで設定した鍵セット。未設定の場合はJWT_SECRETのHS256で署名・検証する
+var tokenKeys atomic.Pointer[tokenKeyConfig]
+
+// SetTokenKeys はJWTの署名・検証に非対称鍵（kidで鍵を選ぶ）を使うようにする。
+// acceptHS256 がtrueの場合は、移行前にJWT_SECRETで署名したトークン（kidなし）も受け付ける。
+// sourceがnilの場合はJWT_SECRETのHS256に戻す（テスト用）
+func SetTokenKeys(source TokenKeySource, acceptHS256 bool) {
+	if source == nil {
+		tokenKeys.Store(nil)
+		return
 	}
-	jwtSecret := []byte(jwtS)
+	tokenKeys.Store(&tokenKeyConfig{source: source, acceptHS256: acceptHS256})
+}
+
+// CurrentJWKS はトークンの検証に使う公開鍵（/.well-known/jwks.json）を返す。非対称鍵を使っていない場合は空
+func CurrentJWKS() jwtkeys.JSONWebKeySet {
+	config := tokenKeys.Load()
+	if config == nil {
+		return jwtkeys.JSONWebKeySet{Keys: []jwtkeys.JSONWebKey{}}
+	}
+	return config.source.Current().JWKS(time.Now().Unix())
+}
 
+func GenerateAuthTokens(userID string) (*TokenDetails, error) {
 	// --- Access Token の生成 ---
 	accessTokenExpiration := time.Now().Add(accessTokenExpirationMinutes)
 	accessClaims := &Claims{
@@ -58,8 +91,7 @@ func GenerateAuthTokens(userID string) (*TokenDetails, error) {
 			Subject:   userID,
 		},
 	}
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	signedAccessToken, err := accessToken.SignedString(jwtSecret)
+	signedAccessToken, err := signToken(accessClaims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign access token: %w", err)
 	}
@@ -77,8 +109,7 @@ func GenerateAuthTokens(userID string) (*TokenDetails, error) {
 			Issuer:    "your-app-issuer",
 		},
 	}
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	signedRefreshToken, err := refreshToken.SignedString(jwtSecret)
+	signedRefreshToken, err := signToken(refreshClaims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign refresh token: %w", err)
 	}
@@ -93,12 +124,6 @@ func GenerateAuthTokens(userID string) (*TokenDetails, error) {
 
 // GenerateAccessToken Refresh時などRefreshTokenを変更せずにAccessTokenのみを生成する関数
 func GenerateAccessToken(userID string) (*TokenDetails, error) {
-	jwtS, err := constants.LoadJWTSecret()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load JWT Secret: %w", err)
-	}
-	jwtSecret := []byte(jwtS)
-
 	// --- Access Token の生成 ---
 	accessTokenExpiration := time.Now().Add(accessTokenExpirationMinutes)
 	accessClaims := &Claims{
@@ -111,8 +136,7 @@ func GenerateAccessToken(userID string) (*TokenDetails, error) {
 			Subject:   userID,
 		},
 	}
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	signedAccessToken, err := accessToken.SignedString(jwtSecret)
+	signedAccessToken, err := signToken(accessClaims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign access token: %w", err)
 	}
@@ -125,32 +149,20 @@ func GenerateAccessToken(userID string) (*TokenDetails, error) {
 }
 
 func ParseAuthTokens(tokenString string) (*TokenDetails, string, error) {
-	jwtS, err := constants.LoadJWTSecret()
+	claims, err := parseToken(tokenString)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to load JWT Secret: %w", err)
+		return nil, "", err
 	}
-	jwtSecret := []byte(jwtS)
-
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (any, error) {
-		// アルゴリズムがHS256であることを確認
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return jwtSecret, nil
-	})
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to parse token: %w", err)
+	details := &TokenDetails{
+		AccessToken:  tokenString,
+		TokenType:    "Bearer",
+		ExpiresIn:    claims.ExpiresAt.Unix() - time.Now().Unix(),
+		RefreshToken: "", // リフレッシュトークンはここでは不要
 	}
-
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return &TokenDetails{
-			AccessToken:  tokenString,
-			TokenType:    "Bearer",
-			ExpiresIn:    claims.ExpiresAt.Unix() - time.Now().Unix(),
-			RefreshToken: "", // リフレッシュトークンはここでは不要
-		}, claims.UserID, nil
+	if claims.IssuedAt != nil {
+		details.IssuedAt = claims.IssuedAt.Unix()
 	}
-	return nil, "", fmt.Errorf("invalid token")
+	return details, claims.UserID, nil
 }
 
 // ParseAccessToken はBearerトークンとして送られてきた文字列を検証し、
@@ -161,36 +173,92 @@ func ParseAuthTokens(tokenString string) (*TokenDetails, string, error) {
 // TokenUse が空文字の場合はこの変更以前に発行された旧アクセストークンとみなし、
 // 後方互換のため許可する（旧トークンは最長15分で自然に失効するため実害は限定的）。
 func ParseAccessToken(tokenString string) (*TokenDetails, string, error) {
-	jwtS, err := constants.LoadJWTSecret()
+	claims, err := parseToken(tokenString)
+	if err != nil {
+		return nil, "", err
+	}
+	if claims.TokenUse == tokenUseRefresh {
+		return nil, "", fmt.Errorf("refresh token is not allowed as an access token")
+	}
+
+	details := &TokenDetails{
+		AccessToken:  tokenString,
+		TokenType:    "Bearer",
+		ExpiresIn:    claims.ExpiresAt.Unix() - time.Now().Unix(),
+		RefreshToken: "",
+	}
+	if claims.IssuedAt != nil {
+		details.IssuedAt = claims.IssuedAt.Unix()
+	}
+	return details, claims.UserID, nil
+}
+
+// signToken は現在の署名鍵で署名し、ヘッダーのkidに鍵のIDを入れる。鍵セットが未設定の場合はJWT_SECRETのHS256で署名する
+func signToken(claims *Claims) (string, error) {
+	config := tokenKeys.Load()
+	if config == nil {
+		jwtS, err := constants.LoadJWTSecret()
+		if err != nil {
+			return "", fmt.Errorf("failed to load JWT Secret: %w", err)
+		}
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(jwtS))
+	}
+
+	key, err := config.source.Current().SigningKey(time.Now().Unix())
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to load JWT Secret: %w", err)
+		return "", err
+	}
+	method := jwt.GetSigningMethod(string(key.Algorithm))
+	if method == nil {
+		return "", fmt.Errorf("unsupported signing method: %s", key.Algorithm)
 	}
-	jwtSecret := []byte(jwtS)
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
+}
 
+// parseToken はkidの公開鍵で署名を検証する。kidがない場合は移行前のHS256のトークンとしてJWT_SECRETで検証する
+func parseToken(tokenString string) (*Claims, error) {
+	config := tokenKeys.Load()
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			// アルゴリズムがHS256であることを確認
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			if config != nil && !config.acceptHS256 {
+				return nil, fmt.Errorf("hs256 token is no longer accepted")
+			}
+			jwtS, err := constants.LoadJWTSecret()
+			if err != nil {
+				return nil, fmt.Errorf("failed to load JWT Secret: %w", err)
+			}
+			return []byte(jwtS), nil
+		}
+
+		if config == nil {
+			return nil, fmt.Errorf("unexpected key id: %s", kid)
+		}
+		key, err := config.source.Current().VerificationKey(kid, time.Now().Unix())
+		if err != nil {
+			return nil, err
+		}
+		// 鍵のアルゴリズムと異なるalgは受け付けない（公開鍵をHMACの鍵として使わせない）
+		if token.Method.Alg() != string(key.Algorithm) {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return jwtSecret, nil
+		return key.PublicKey, nil
 	})
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to parse token: %w", err)
+		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
 
 	claims, ok := token.Claims.(*Claims)
 	if !ok || !token.Valid {
-		return nil, "", fmt.Errorf("invalid token")
-	}
-	if claims.TokenUse == tokenUseRefresh {
-		return nil, "", fmt.Errorf("refresh token is not allowed as an access token")
+		return nil, fmt.Errorf("invalid token")
 	}
-
-	return &TokenDetails{
-		AccessToken:  tokenString,
-		TokenType:    "Bearer",
-		ExpiresIn:    claims.ExpiresAt.Unix() - time.Now().Unix(),
-		RefreshToken: "",
-	}, claims.UserID, nil
+	return claims, nil
 }
 
 // ErrMissingAuthHeader / ErrInvalidAuthFormat / ErrEmptyBearerToken は
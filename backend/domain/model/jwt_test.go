@@ -0,0 +1,169 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/jwtkeys"
+)
+
+type staticKeySource struct {
+	keyset *jwtkeys.Keyset
+}
+
+func (s *staticKeySource) Current() *jwtkeys.Keyset { return s.keyset }
+
+// setupTokenKeys は鍵セットで署名・検証するようにし、テストの終了時にHS256に戻す
+func setupTokenKeys(t *testing.T, acceptHS256 bool, keys ...*jwtkeys.Key) *staticKeySource {
+	t.Helper()
+	t.Setenv("JWT_SECRET", "test-secret")
+	source := &staticKeySource{keyset: jwtkeys.NewKeyset(keys)}
+	SetTokenKeys(source, acceptHS256)
+	t.Cleanup(func() { SetTokenKeys(nil, true) })
+	return source
+}
+
+func generateKey(t *testing.T, alg jwtkeys.Algorithm, activatesAt int64) *jwtkeys.Key {
+	t.Helper()
+	k, err := jwtkeys.GenerateKey(alg, activatesAt)
+	if err != nil {
+		t.Fatalf("GenerateKey失敗: %v", err)
+	}
+	return k
+}
+
+func tokenHeader(t *testing.T, tokenString string) map[string]any {
+	t.Helper()
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, &Claims{})
+	if err != nil {
+		t.Fatalf("トークンのパース失敗: %v", err)
+	}
+	return token.Header
+}
+
+func TestTokenKeys(t *testing.T) {
+	for _, alg := range []jwtkeys.Algorithm{jwtkeys.AlgEdDSA, jwtkeys.AlgRS256} {
+		t.Run("正常系: "+string(alg)+"で署名し、kidの公開鍵で検証できる", func(t *testing.T) {
+			key := generateKey(t, alg, time.Now().Add(-time.Hour).Unix())
+			setupTokenKeys(t, true, key)
+
+			tokens, err := GenerateAuthTokens("user-1")
+			if err != nil {
+				t.Fatalf("GenerateAuthTokens失敗: %v", err)
+			}
+			header := tokenHeader(t, tokens.AccessToken)
+			if header["kid"] != key.ID || header["alg"] != string(alg) {
+				t.Errorf("ヘッダーが期待と異なる: %v", header)
+			}
+			if _, userID, err := ParseAccessToken(tokens.AccessToken); err != nil || userID != "user-1" {
+				t.Errorf("アクセストークンの検証失敗: %v, %v", userID, err)
+			}
+			if _, userID, err := ParseAuthTokens(tokens.RefreshToken); err != nil || userID != "user-1" {
+				t.Errorf("リフレッシュトークンの検証失敗: %v, %v", userID, err)
+			}
+		})
+	}
+
+	t.Run("正常系: 公開済みの次の鍵に切り替えた後も、前の鍵で署名したトークンを検証できる", func(t *testing.T) {
+		now := time.Now()
+		oldKey := generateKey(t, jwtkeys.AlgEdDSA, now.Add(-time.Hour).Unix())
+		nextKey := generateKey(t, jwtkeys.AlgEdDSA, now.Add(time.Hour).Unix())
+		source := setupTokenKeys(t, true, oldKey, nextKey)
+
+		oldToken, err := GenerateAccessToken("user-1")
+		if err != nil {
+			t.Fatalf("GenerateAccessToken失敗: %v", err)
+		}
+		if tokenHeader(t, oldToken.AccessToken)["kid"] != oldKey.ID {
+			t.Fatal("署名に使い始める前の鍵で署名している")
+		}
+		if got := CurrentJWKS(); len(got.Keys) != 2 {
+			t.Errorf("次の鍵も公開するべき: %+v", got)
+		}
+
+		// 次の鍵に切り替える
+		nextKey.ActivatesAt = now.Add(-time.Minute).Unix()
+		oldKey.ExpiresAt = now.Add(time.Hour).Unix()
+		source.keyset = jwtkeys.NewKeyset([]*jwtkeys.Key{oldKey, nextKey})
+
+		newToken, err := GenerateAccessToken("user-1")
+		if err != nil {
+			t.Fatalf("GenerateAccessToken失敗: %v", err)
+		}
+		if tokenHeader(t, newToken.AccessToken)["kid"] != nextKey.ID {
+			t.Error("次の鍵で署名するべき")
+		}
+		for _, token := range []string{oldToken.AccessToken, newToken.AccessToken} {
+			if _, _, err := ParseAccessToken(token); err != nil {
+				t.Errorf("検証失敗: %v", err)
+			}
+		}
+	})
+
+	t.Run("異常系: 期限切れ・不明な鍵で署名したトークンは検証できない", func(t *testing.T) {
+		key := generateKey(t, jwtkeys.AlgEdDSA, time.Now().Add(-time.Hour).Unix())
+		source := setupTokenKeys(t, true, key)
+		tokens, err := GenerateAccessToken("user-1")
+		if err != nil {
+			t.Fatalf("GenerateAccessToken失敗: %v", err)
+		}
+
+		key.ExpiresAt = time.Now().Add(-time.Second).Unix()
+		if _, _, err := ParseAccessToken(tokens.AccessToken); err == nil {
+			t.Error("期限切れの鍵のトークンは拒否するべき")
+		}
+
+		source.keyset = jwtkeys.NewKeyset([]*jwtkeys.Key{generateKey(t, jwtkeys.AlgEdDSA, time.Now().Add(-time.Hour).Unix())})
+		if _, _, err := ParseAccessToken(tokens.AccessToken); err == nil {
+			t.Error("不明なkidのトークンは拒否するべき")
+		}
+	})
+
+	t.Run("正常系: 移行前のHS256のトークンはJWT_ACCEPT_HS256が有効な間だけ検証できる", func(t *testing.T) {
+		t.Setenv("JWT_SECRET", "test-secret")
+		legacy, err := GenerateAccessToken("user-1")
+		if err != nil {
+			t.Fatalf("GenerateAccessToken失敗: %v", err)
+		}
+		if _, ok := tokenHeader(t, legacy.AccessToken)["kid"]; ok {
+			t.Fatal("鍵セットが未設定の場合はkidなしのHS256で署名するべき")
+		}
+
+		key := generateKey(t, jwtkeys.AlgEdDSA, time.Now().Add(-time.Hour).Unix())
+		setupTokenKeys(t, true, key)
+		if _, _, err := ParseAccessToken(legacy.AccessToken); err != nil {
+			t.Errorf("HS256のトークンを受け付けるべき: %v", err)
+		}
+		SetTokenKeys(&staticKeySource{keyset: jwtkeys.NewKeyset([]*jwtkeys.Key{key})}, false)
+		if _, _, err := ParseAccessToken(legacy.AccessToken); err == nil {
+			t.Error("JWT_ACCEPT_HS256=falseの場合はHS256のトークンを拒否するべき")
+		}
+	})
+
+	t.Run("異常系: kidの鍵と異なるalgのトークンは検証できない", func(t *testing.T) {
+		key := generateKey(t, jwtkeys.AlgRS256, time.Now().Add(-time.Hour).Unix())
+		setupTokenKeys(t, true, key)
+		// 公開鍵をHMACの鍵として使う攻撃を想定し、kidを付けたHS256のトークンを作る
+		forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
+			UserID:           "attacker",
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		})
+		forged.Header["kid"] = key.ID
+		signed, err := forged.SignedString([]byte("test-secret"))
+		if err != nil {
+			t.Fatalf("署名失敗: %v", err)
+		}
+		if _, _, err := ParseAccessToken(signed); err == nil || !strings.Contains(err.Error(), "unexpected signing method") {
+			t.Errorf("algが異なるトークンは拒否するべき: %v", err)
+		}
+	})
+
+	t.Run("異常系: 署名に使える鍵がない場合は発行できない", func(t *testing.T) {
+		setupTokenKeys(t, true, generateKey(t, jwtkeys.AlgEdDSA, time.Now().Add(time.Hour).Unix()))
+		if _, err := GenerateAccessToken("user-1"); err == nil {
+			t.Error("署名に使い始める前の鍵しかない場合はエラーになるべき")
+		}
+	})
+}
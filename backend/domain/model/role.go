@@ -0,0 +1,13 @@
+package model
+
+// UserRole はユーザーの権限（users.role）
+type UserRole int16
+
+const (
+	UserRoleUser  UserRole = iota // 一般のユーザー
+	UserRoleAdmin                 // 管理者（AdminServiceを呼び出せる）
+)
+
+func (r UserRole) Int16() int16 {
+	return int16(r)
+}
@@ -76,19 +76,19 @@ func ValidateLoginByPasswordRequest(req *g.LoginByPasswordRequest) (*PasswordAut
 	}, nil
 }
 
-// ValidateRefreshTokenRequest 第一引数はuserID
-func ValidateRefreshTokenRequest(req *g.RefreshAccessTokenRequest) (string, error) {
+// ValidateRefreshTokenRequest 第一引数はuserID、第二引数はトークンの発行日時（UNIX秒）
+func ValidateRefreshTokenRequest(req *g.RefreshAccessTokenRequest) (string, int64, error) {
 	if req.GetRefreshToken() == "" {
-		return "", fmt.Errorf("refresh token must not be empty")
+		return "", 0, fmt.Errorf("refresh token must not be empty")
 	}
 
 	// --- トークンの検証 ---
-	_, userID, err := model.ParseAuthTokens(req.GetRefreshToken())
+	token, userID, err := model.ParseAuthTokens(req.GetRefreshToken())
 	if err != nil {
-		return "", fmt.Errorf("failed to parse refresh token: %w", err)
+		return "", 0, fmt.Errorf("failed to parse refresh token: %w", err)
 	}
 
-	return userID, nil
+	return userID, token.IssuedAt, nil
 }
 
 func EncryptPassword(password string) (string, error) {
@@ -0,0 +1,217 @@
+// Package accountdeletion はアカウントの削除を行う。削除の依頼から猶予期間が過ぎたユーザーをスケジューラーが見つけて
+// subscriberに依頼し、subscriberがDB・ストレージ・Redisからユーザーのデータをすべて削除する（ADR 0037）。
+package accountdeletion
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/mailer"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/requestid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/storage"
+	"github.com/redis/rueidis"
+)
+
+// MessageType はアカウントの削除を依頼するためにdiary_eventsチャンネルへ送るメッセージのtype
+const MessageType = "account_deletion"
+
+// Message はアカウントの削除を依頼するメッセージ
+type Message struct {
+	Type      string `json:"type"`
+	UserID    string `json:"user_id"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// enqueueBatchSize はスケジューラーの1回の実行で削除を依頼するユーザーの最大数
+const enqueueBatchSize = 100
+
+// scanCount はRedisのSCANで1回に確認するキーの数の目安
+const scanCount = 500
+
+// credentialKeyPrefixes はユーザーIDを値に持つ、ログインの途中の状態を保存するキーの接頭辞。
+// 2段階認証のチャレンジ（mfa）・パスキーのチャレンジ（webauthn）・MCPのauthorization code（mcpserver）のキーと合わせる
+var credentialKeyPrefixes = []string{"mfa_challenge:", "webauthn_challenge:", "mcp_oauth_code:"}
+
+// EnqueueDue は削除の予定日時を過ぎたユーザーの削除をsubscriberに依頼し、依頼した件数を返す。
+// subscriberが削除するまでは次の実行でも同じユーザーを依頼するが、削除はユーザーの行をロックして予定を確かめ直すため重複しても問題ない
+func EnqueueDue(ctx context.Context, db database.DB, redisClient rueidis.Client, now time.Time) (int, error) {
+	userIDs, err := database.UserIDsDueForDeletion(ctx, db, now.Unix(), enqueueBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for i, userID := range userIDs {
+		message, err := json.Marshal(Message{Type: MessageType, UserID: userID.String(), RequestID: requestid.FromContext(ctx)})
+		if err != nil {
+			return i, fmt.Errorf("failed to marshal account deletion message: %w", err)
+		}
+		publishCmd := redisClient.B().Publish().Channel("diary_events").Message(string(message)).Build()
+		if err := redisClient.Do(ctx, publishCmd).Error(); err != nil {
+			return i, fmt.Errorf("failed to publish account deletion message for user %s: %w", userID, err)
+		}
+	}
+	return len(userIDs), nil
+}
+
+// Purger はユーザーのデータを削除する
+type Purger struct {
+	DB             *sql.DB
+	Redis          rueidis.Client
+	Storage        storage.Storage // 添付ファイル・データエクスポートの保存先（nilの場合は削除しない）
+	BackupLocalDir string          // 定期バックアップのローカルの保存先（空の場合は削除しない）
+	Mailer         mailer.Mailer   // 削除の完了を知らせるメールの送信（nilの場合は送らない）
+}
+
+// Purge は削除の予定日時（now）を過ぎたユーザーのデータをすべて削除し、削除した場合はtrueを返す。
+// 削除を取り消した・既に削除したユーザーの場合は何もせずfalseを返す。
+// DBの削除の後のストレージ・Redisの削除とメールの送信は、失敗してもログに残すだけにする（ユーザーは既に存在しないため）
+func (p *Purger) Purge(ctx context.Context, userID uuid.UUID, now time.Time) (bool, error) {
+	// 添付ファイル・データエクスポートの行はユーザーの削除でCASCADEされるため、ストレージ上のキーを先に取得しておく
+	attachments, err := database.DiaryAttachmentsByUserID(ctx, p.DB, userID)
+	if err != nil {
+		return false, err
+	}
+	dataExportKeys, err := database.DataExportStorageKeysByUserID(ctx, p.DB, userID)
+	if err != nil {
+		return false, err
+	}
+
+	var deleted *database.User
+	err = database.RwTransaction(ctx, p.DB, func(tx *sql.Tx) error {
+		// 同時に削除を取り消したユーザーを削除しないよう、行をロックしてから予定を確かめ直す
+		u, err := database.UserDeletionScheduleForUpdate(ctx, tx, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return fmt.Errorf("failed to get user %s: %w", userID, err)
+		}
+		if !u.DeletionScheduledAt.Valid || u.DeletionScheduledAt.Int64 > now.Unix() {
+			return nil
+		}
+		if err := database.DeleteUserData(ctx, tx, userID); err != nil {
+			return err
+		}
+		deleted = u
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if deleted == nil {
+		return false, nil
+	}
+
+	if p.Storage != nil {
+		keys := make([]string, 0, len(attachments)*2+len(dataExportKeys))
+		for _, a := range attachments {
+			keys = append(keys, a.StorageKeys()...)
+		}
+		keys = append(keys, dataExportKeys...)
+		if err := storage.DeleteAll(ctx, p.Storage, keys); err != nil {
+			log.Printf("Failed to delete storage objects for deleted user %s: %v", userID, err)
+		}
+	}
+	// ローカルに保存した定期バックアップはサーバー上のデータのため削除する（S3・WebDAVはユーザーの保存先のため残す）
+	if p.BackupLocalDir != "" {
+		if err := os.RemoveAll(filepath.Join(p.BackupLocalDir, userID.String())); err != nil {
+			log.Printf("Failed to delete local backups for deleted user %s: %v", userID, err)
+		}
+	}
+	if p.Redis != nil {
+		if err := PurgeRedis(ctx, p.Redis, userID); err != nil {
+			log.Printf("Failed to delete redis keys for deleted user %s: %v", userID, err)
+		}
+	}
+	if p.Mailer != nil {
+		if err := p.Mailer.Send(ctx, completedMessage(deleted)); err != nil {
+			log.Printf("Failed to send account deletion email for deleted user %s: %v", userID, err)
+		}
+	}
+	return true, nil
+}
+
+func completedMessage(u *database.User) mailer.Message {
+	return mailer.Message{
+		To:      u.Email,
+		Subject: "【umi.mikan】アカウントの削除が完了しました",
+		Body: fmt.Sprintf("%s さん\n\nご依頼いただいたアカウントの削除が完了し、日記などのデータをすべて削除しました。\n"+
+			"これまでumi.mikanをご利用いただきありがとうございました。\n", u.Name),
+	}
+}
+
+// RevokeCredentials はユーザーのログインの途中の状態（2段階認証・パスキーのチャレンジ、MCPのauthorization code）を削除する
+func RevokeCredentials(ctx context.Context, redisClient rueidis.Client, userID uuid.UUID) error {
+	for _, prefix := range credentialKeyPrefixes {
+		err := scanKeys(ctx, redisClient, prefix+"*", func(keys []string) error {
+			for _, key := range keys {
+				value, err := redisClient.Do(ctx, redisClient.B().Get().Key(key).Build()).ToString()
+				if err != nil {
+					if rueidis.IsRedisNil(err) {
+						continue // 期限切れ
+					}
+					return fmt.Errorf("failed to get %s: %w", key, err)
+				}
+				if !strings.Contains(value, userID.String()) {
+					continue
+				}
+				if err := redisClient.Do(ctx, redisClient.B().Del().Key(key).Build()).Error(); err != nil {
+					return fmt.Errorf("failed to delete %s: %w", key, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PurgeRedis はキーにユーザーIDを含むもの（最新のトレンド・タスクの状態・ロック・レート制限など）と、
+// RevokeCredentials が削除するキーをすべて削除する
+func PurgeRedis(ctx context.Context, redisClient rueidis.Client, userID uuid.UUID) error {
+	if err := RevokeCredentials(ctx, redisClient, userID); err != nil {
+		return err
+	}
+	return scanKeys(ctx, redisClient, "*"+userID.String()+"*", func(keys []string) error {
+		// キーごとにスロットが異なりうるため、1つのDELにまとめずにキーごとに送る
+		cmds := make(rueidis.Commands, 0, len(keys))
+		for _, key := range keys {
+			cmds = append(cmds, redisClient.B().Del().Key(key).Build())
+		}
+		for _, resp := range redisClient.DoMulti(ctx, cmds...) {
+			if err := resp.Error(); err != nil {
+				return fmt.Errorf("failed to delete redis keys: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// scanKeys はパターンに一致するキーをSCANで少しずつ取得してfnに渡す（KEYSのようにRedisを止めない）
+func scanKeys(ctx context.Context, redisClient rueidis.Client, pattern string, fn func(keys []string) error) error {
+	var cursor uint64
+	for {
+		entry, err := redisClient.Do(ctx, redisClient.B().Scan().Cursor(cursor).Match(pattern).Count(scanCount).Build()).AsScanEntry()
+		if err != nil {
+			return fmt.Errorf("failed to scan redis keys: %w", err)
+		}
+		if err := fn(entry.Elements); err != nil {
+			return err
+		}
+		if entry.Cursor == 0 {
+			return nil
+		}
+		cursor = entry.Cursor
+	}
+}
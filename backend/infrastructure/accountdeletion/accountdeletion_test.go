@@ -0,0 +1,148 @@
+package accountdeletion
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/mailer"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/redis/rueidis"
+)
+
+func setupRedis(t *testing.T) (*miniredis.Miniredis, rueidis.Client) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis起動失敗: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	client, err := rueidis.NewClient(rueidis.ClientOption{InitAddress: []string{mr.Addr()}, DisableCache: true})
+	if err != nil {
+		t.Fatalf("rueidisクライアント作成失敗: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return mr, client
+}
+
+func TestRevokeCredentials(t *testing.T) {
+	t.Run("正常系: ユーザーIDを値に持つチャレンジ・authorization codeだけを削除する", func(t *testing.T) {
+		mr, client := setupRedis(t)
+		userID, otherID := uuid.New(), uuid.New()
+		mustSet(t, mr, "mfa_challenge:a", `{"user_id":"`+userID.String()+`"}`)
+		mustSet(t, mr, "webauthn_challenge:b", `{"user_id":"`+userID.String()+`"}`)
+		mustSet(t, mr, "mcp_oauth_code:c", `{"user_id":"`+userID.String()+`"}`)
+		mustSet(t, mr, "mcp_oauth_code:d", `{"user_id":"`+otherID.String()+`"}`)
+		mustSet(t, mr, "latest_trend:"+userID.String(), "{}")
+
+		if err := RevokeCredentials(context.Background(), client, userID); err != nil {
+			t.Fatalf("RevokeCredentials失敗: %v", err)
+		}
+		for _, key := range []string{"mfa_challenge:a", "webauthn_challenge:b", "mcp_oauth_code:c"} {
+			if mr.Exists(key) {
+				t.Errorf("%s が削除されていない", key)
+			}
+		}
+		// 他のユーザーのキーと、ログインに関係しないキーは残す
+		for _, key := range []string{"mcp_oauth_code:d", "latest_trend:" + userID.String()} {
+			if !mr.Exists(key) {
+				t.Errorf("%s が削除された", key)
+			}
+		}
+	})
+}
+
+func TestPurgeRedis(t *testing.T) {
+	t.Run("正常系: キーにユーザーIDを含むものとログインの途中の状態を削除する", func(t *testing.T) {
+		mr, client := setupRedis(t)
+		userID, otherID := uuid.New(), uuid.New()
+		mustSet(t, mr, "latest_trend:"+userID.String(), "{}")
+		mustSet(t, mr, "task:monthly_summary:"+userID.String()+":2026-10", "processing")
+		mustSet(t, mr, "mfa_challenge:a", `{"user_id":"`+userID.String()+`"}`)
+		mustSet(t, mr, "latest_trend:"+otherID.String(), "{}")
+
+		if err := PurgeRedis(context.Background(), client, userID); err != nil {
+			t.Fatalf("PurgeRedis失敗: %v", err)
+		}
+		keys := mr.Keys()
+		if len(keys) != 1 || keys[0] != "latest_trend:"+otherID.String() {
+			t.Errorf("他のユーザーのキーだけが残るべき: %v", keys)
+		}
+	})
+}
+
+func TestPurger_Purge(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("正常系: 予定日時を過ぎたユーザーのデータを削除し、完了のメールを送る", func(t *testing.T) {
+		_, client := setupRedis(t)
+		m := &mailer.FileMailer{Dir: t.TempDir(), From: "noreply@example.com"}
+		userID := testutil.CreateTestUserWithPassword(t, db, "account-deletion-purge@example.com", "Purge", "password123")
+		testutil.CreateTestUserLLM(t, db, userID, "test-api-key")
+		if err := database.ScheduleUserDeletion(ctx, db, userID, now.Unix()-20, now.Unix()-10); err != nil {
+			t.Fatalf("ScheduleUserDeletion失敗: %v", err)
+		}
+
+		p := &Purger{DB: db, Redis: client, Mailer: m}
+		deleted, err := p.Purge(ctx, userID, now)
+		if err != nil {
+			t.Fatalf("Purge失敗: %v", err)
+		}
+		if !deleted {
+			t.Fatal("削除されるべき")
+		}
+		if _, err := database.UserByID(ctx, db, userID); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("ユーザーが削除されていない: %v", err)
+		}
+		messages, err := m.Messages()
+		if err != nil {
+			t.Fatalf("Messages失敗: %v", err)
+		}
+		if len(messages) != 1 {
+			t.Errorf("完了のメールを1通送るべき: %d通", len(messages))
+		}
+
+		// 既に削除したユーザーは何もしない
+		deleted, err = p.Purge(ctx, userID, now)
+		if err != nil || deleted {
+			t.Errorf("削除済みのユーザーは何もしないべき: deleted=%t err=%v", deleted, err)
+		}
+	})
+
+	t.Run("正常系: 予定日時の前・取り消したユーザーは削除しない", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "account-deletion-pending@example.com", "Pending")
+		if err := database.ScheduleUserDeletion(ctx, db, userID, now.Unix(), now.Unix()+3600); err != nil {
+			t.Fatalf("ScheduleUserDeletion失敗: %v", err)
+		}
+		p := &Purger{DB: db}
+		deleted, err := p.Purge(ctx, userID, now)
+		if err != nil || deleted {
+			t.Errorf("予定日時の前は削除しないべき: deleted=%t err=%v", deleted, err)
+		}
+
+		if _, err := database.CancelUserDeletion(ctx, db, userID, now.Unix(), now.Unix()); err != nil {
+			t.Fatalf("CancelUserDeletion失敗: %v", err)
+		}
+		deleted, err = p.Purge(ctx, userID, now.Add(2*time.Hour))
+		if err != nil || deleted {
+			t.Errorf("取り消したユーザーは削除しないべき: deleted=%t err=%v", deleted, err)
+		}
+		if _, err := database.UserByID(ctx, db, userID); err != nil {
+			t.Errorf("ユーザーが残っているべき: %v", err)
+		}
+	})
+}
+
+func mustSet(t *testing.T, mr *miniredis.Miniredis, key, value string) {
+	t.Helper()
+	if err := mr.Set(key, value); err != nil {
+		t.Fatalf("Set失敗: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+// Package backup はユーザーの個人データのアーカイブを、ユーザーが設定した保存先へ定期的に書き込む。
+// アーカイブはデータエクスポートと同じ形式（takeout）で、設定した場合はパスフレーズから作った公開鍵で暗号化する。
+// 保存先はローカルディレクトリ・S3互換ストレージ・WebDAVで、保持数を超えた古いバックアップは削除する。
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/storage"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/takeout"
+)
+
+// 保存先の種類（user_backup_settings.destination）
+const (
+	DestinationLocal  = "local"
+	DestinationS3     = "s3"
+	DestinationWebDAV = "webdav"
+)
+
+// EncryptedExtension は暗号化したバックアップのファイル名に付ける拡張子
+const EncryptedExtension = ".umibak"
+
+// ErrLocalUnavailable はサーバーにローカルの保存先（BACKUP_LOCAL_DIR）が設定されていない場合のエラー
+var ErrLocalUnavailable = errors.New("backup: local destination is not configured")
+
+// Open は設定の保存先のストレージと、保存先の識別子を返す
+// ローカルの保存先はサーバーが決めたディレクトリの下のユーザーごとのディレクトリに限り、任意のパスには書き込ませない
+func Open(setting *database.UserBackupSetting, localDir string) (storage.Storage, string, error) {
+	switch setting.Destination {
+	case DestinationLocal:
+		if localDir == "" {
+			return nil, "", ErrLocalUnavailable
+		}
+		s, err := storage.NewLocal(filepath.Join(localDir, setting.UserID.String()))
+		return s, DestinationLocal, err
+	case DestinationS3:
+		s, err := storage.NewS3(storage.S3Config{
+			Endpoint:        setting.S3Endpoint,
+			Region:          setting.S3Region,
+			Bucket:          setting.S3Bucket,
+			AccessKeyID:     setting.S3AccessKeyID,
+			SecretAccessKey: setting.S3SecretAccessKey,
+			UsePathStyle:    setting.S3UsePathStyle,
+		})
+		return s, DestinationS3 + ":" + setting.S3Endpoint + "/" + setting.S3Bucket, err
+	case DestinationWebDAV:
+		s, err := storage.NewWebDAV(storage.WebDAVConfig{
+			URL:      setting.WebdavURL,
+			Username: setting.WebdavUsername,
+			Password: setting.WebdavPassword,
+		})
+		return s, DestinationWebDAV + ":" + setting.WebdavURL, err
+	default:
+		return nil, "", fmt.Errorf("backup: unknown destination %q", setting.Destination)
+	}
+}
+
+// ObjectKey は保存先でのバックアップのキーを返す（日時を含めるため、名前順が作成順になる）
+func ObjectKey(prefix string, now time.Time, encrypted bool) string {
+	name := "umi-mikan-backup-" + now.UTC().Format("20060102T150405Z") + ".zip"
+	if encrypted {
+		name += EncryptedExtension
+	}
+	if prefix == "" {
+		return name
+	}
+	return path.Join(prefix, name)
+}
+
+// Encrypted は設定が暗号化する設定かを返す
+func Encrypted(setting *database.UserBackupSetting) bool {
+	return len(setting.EncryptionPublicKey) > 0
+}
+
+// Run はアーカイブを作成して保存先に書き込み、保存したバックアップの行を返す
+func Run(ctx context.Context, src takeout.Source, setting *database.UserBackupSetting, localDir string, now time.Time) (*database.UserBackup, error) {
+	dest, location, err := Open(setting, localDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// アーカイブのサイズを確定させてから送るため、一時ファイルに書き込む
+	tmp, err := os.CreateTemp("", "umi-backup-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	encrypted := Encrypted(setting)
+	var w io.Writer = tmp
+	var enc io.WriteCloser
+	if encrypted {
+		enc, err = NewEncryptWriter(tmp, &Recipient{PublicKey: setting.EncryptionPublicKey, Salt: setting.EncryptionSalt})
+		if err != nil {
+			return nil, err
+		}
+		w = enc
+	}
+	if _, err := takeout.Write(ctx, src, setting.UserID, w, now); err != nil {
+		return nil, err
+	}
+	if enc != nil {
+		if err := enc.Close(); err != nil {
+			return nil, fmt.Errorf("failed to encrypt backup: %w", err)
+		}
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup size: %w", err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind backup: %w", err)
+	}
+
+	contentType := takeout.ContentType
+	if encrypted {
+		contentType = "application/octet-stream"
+	}
+	key := ObjectKey(setting.Prefix, now, encrypted)
+	if err := dest.Put(ctx, key, tmp, size, contentType); err != nil {
+		return nil, fmt.Errorf("failed to upload backup: %w", err)
+	}
+
+	backup := &database.UserBackup{
+		ID:        uuid.New(),
+		UserID:    setting.UserID,
+		Location:  location,
+		ObjectKey: key,
+		SizeBytes: size,
+		Encrypted: encrypted,
+		CreatedAt: now.Unix(),
+	}
+	if err := backup.Insert(ctx, src.DB); err != nil {
+		// 記録できないバックアップは保持数の管理から漏れるため削除する
+		_ = dest.Delete(context.WithoutCancel(ctx), key)
+		return nil, fmt.Errorf("failed to record backup: %w", err)
+	}
+
+	return backup, nil
+}
+
+// Prune は同じ保存先のバックアップのうち、新しい方からretention_count件を超えたものを削除する
+// 保存先を変更した場合、以前の保存先のバックアップはユーザーのデータとして残す
+func Prune(ctx context.Context, db database.DB, setting *database.UserBackupSetting, localDir string) error {
+	dest, location, err := Open(setting, localDir)
+	if err != nil {
+		return err
+	}
+	backups, err := database.BackupsByUserIDAndLocation(ctx, db, setting.UserID, location)
+	if err != nil {
+		return err
+	}
+	if len(backups) <= setting.RetentionCount {
+		return nil
+	}
+
+	// 削除できたものだけ行を消し、失敗したものは次回に再試行する
+	var ids []uuid.UUID
+	var errs []error
+	for _, b := range backups[setting.RetentionCount:] {
+		if err := dest.Delete(ctx, b.ObjectKey); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, b.ID)
+	}
+	if len(ids) > 0 {
+		if err := database.DeleteBackupsByIDs(ctx, db, ids); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,91 @@
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/takeout"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectKey(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	assert.Equal(t, "umi-mikan-backup-20240501T123000Z.zip", ObjectKey("", now, false))
+	assert.Equal(t, "backups/umi/umi-mikan-backup-20240501T123000Z.zip.umibak", ObjectKey("backups/umi/", now, true))
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("異常系: サーバーにローカルの保存先がない場合はErrLocalUnavailable", func(t *testing.T) {
+		_, _, err := Open(&database.UserBackupSetting{Destination: DestinationLocal}, "")
+		assert.ErrorIs(t, err, ErrLocalUnavailable)
+	})
+
+	t.Run("異常系: 未対応の保存先はエラー", func(t *testing.T) {
+		_, _, err := Open(&database.UserBackupSetting{Destination: "ftp"}, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestRunAndPrune(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	userID := testutil.CreateTestUser(t, db, "backup@example.com", "BackupUser")
+	localDir := t.TempDir()
+
+	recipient, err := NewRecipient("backup passphrase")
+	require.NoError(t, err)
+	setting := &database.UserBackupSetting{
+		UserID:              userID,
+		Destination:         DestinationLocal,
+		Prefix:              "umi",
+		RetentionCount:      2,
+		EncryptionPublicKey: recipient.PublicKey,
+		EncryptionSalt:      recipient.Salt,
+	}
+	src := takeout.Source{DB: db}
+
+	now := time.Now()
+	var backups []*database.UserBackup
+	for i := range 3 {
+		b, err := Run(ctx, src, setting, localDir, now.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, err)
+		backups = append(backups, b)
+	}
+
+	t.Run("正常系: 暗号化したアーカイブをユーザーごとのディレクトリに保存する", func(t *testing.T) {
+		encrypted, err := os.ReadFile(filepath.Join(localDir, userID.String(), filepath.FromSlash(backups[2].ObjectKey)))
+		require.NoError(t, err)
+		assert.True(t, backups[2].Encrypted)
+		assert.Equal(t, int64(len(encrypted)), backups[2].SizeBytes)
+
+		r, err := NewDecryptReader(bytes.NewReader(encrypted), "backup passphrase")
+		require.NoError(t, err)
+		plain, err := io.ReadAll(r)
+		require.NoError(t, err)
+		zr, err := zip.NewReader(bytes.NewReader(plain), int64(len(plain)))
+		require.NoError(t, err)
+		assert.Equal(t, "manifest.json", zr.File[len(zr.File)-1].Name)
+	})
+
+	t.Run("正常系: 保持数を超えた古いバックアップを削除する", func(t *testing.T) {
+		require.NoError(t, Prune(ctx, db, setting, localDir))
+
+		remaining, err := database.BackupsByUserIDAndLocation(ctx, db, userID, DestinationLocal)
+		require.NoError(t, err)
+		require.Len(t, remaining, 2)
+		assert.Equal(t, backups[2].ID, remaining[0].ID)
+		assert.Equal(t, backups[1].ID, remaining[1].ID)
+
+		_, err = os.Stat(filepath.Join(localDir, userID.String(), filepath.FromSlash(backups[0].ObjectKey)))
+		assert.True(t, os.IsNotExist(err), "古いバックアップが残っている")
+	})
+}
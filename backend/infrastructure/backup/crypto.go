@@ -0,0 +1,280 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// 暗号化したバックアップの形式
+//
+//	magic(8) | scryptのlogN, r, p(各1) | salt(16) | 一時公開鍵(32) | nonceの接頭辞(7) | 暗号文のチャンク...
+//
+// パスフレーズからscryptで導出した32バイトをX25519の秘密鍵とし、サーバーには公開鍵とソルトだけを保存する。
+// バックアップごとに一時鍵を作り、ECDHの共有秘密からHKDF-SHA256でAES-256-GCMの鍵を導出する。
+// 本文はchunkSizeごとに暗号化し（STREAM構成）、nonceは 接頭辞(7) | チャンク番号(4) | 最後のチャンクなら1(1) とする。
+// 最後のチャンクを区別するため、末尾を切り詰めたファイルは復号できない。
+const (
+	magic          = "UMIBAK1\n"
+	saltSize       = 16
+	prefixSize     = 7
+	chunkSize      = 64 * 1024
+	headerSize     = len(magic) + 3 + saltSize + 32 + prefixSize
+	hkdfInfo       = "umi.mikan backup v1"
+	scryptLogN     = 15
+	scryptR        = 8
+	scryptP        = 1
+	maxScryptLogN  = 20 // 復号時に受け付けるlogNの上限（不正なヘッダーで大量のメモリを使わせない）
+	encryptedChunk = chunkSize + 16
+)
+
+// ErrWrongPassphrase はパスフレーズが違うか、内容が改ざんされている場合のエラー
+var ErrWrongPassphrase = errors.New("backup: wrong passphrase or corrupted data")
+
+// ErrInvalidFormat は暗号化したバックアップの形式でない場合のエラー
+var ErrInvalidFormat = errors.New("backup: invalid encrypted backup")
+
+// Recipient はバックアップを暗号化するための公開鍵と、秘密鍵の導出に使うソルト
+type Recipient struct {
+	PublicKey []byte
+	Salt      []byte
+}
+
+// deriveKey はパスフレーズとソルトからX25519の秘密鍵を導出する
+func deriveKey(passphrase string, salt []byte, logN, r, p int) (*ecdh.PrivateKey, error) {
+	seed, err := scrypt.Key([]byte(passphrase), salt, 1<<logN, r, p, 32)
+	if err != nil {
+		return nil, fmt.Errorf("backup: failed to derive key: %w", err)
+	}
+	return ecdh.X25519().NewPrivateKey(seed)
+}
+
+// NewRecipient はパスフレーズから暗号化用の公開鍵を作成する（パスフレーズ自体は保存しない）
+func NewRecipient(passphrase string) (*Recipient, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("backup: failed to generate salt: %w", err)
+	}
+	key, err := deriveKey(passphrase, salt, scryptLogN, scryptR, scryptP)
+	if err != nil {
+		return nil, err
+	}
+	return &Recipient{PublicKey: key.PublicKey().Bytes(), Salt: salt}, nil
+}
+
+// fileKey は共有秘密からこのファイルのAES-GCMを作成する
+func fileKey(shared, ephemeral, recipient []byte) (cipher.AEAD, error) {
+	key, err := hkdf.Key(sha256.New, shared, append(bytes.Clone(ephemeral), recipient...), hkdfInfo, 32)
+	if err != nil {
+		return nil, fmt.Errorf("backup: failed to derive file key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce はチャンク番号と最後のチャンクかどうかからnonceを作る
+func chunkNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 0, 12)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+// encryptWriter は書き込まれた内容をチャンクごとに暗号化してwに書き込む
+type encryptWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buf     []byte
+	closed  bool
+}
+
+// NewEncryptWriter はrecipientの公開鍵で暗号化するWriterを返す
+// 最後のチャンクはCloseで書き込むため、Closeを呼ぶまでは復号できるファイルにならない
+func NewEncryptWriter(w io.Writer, recipient *Recipient) (io.WriteCloser, error) {
+	pub, err := ecdh.X25519().NewPublicKey(recipient.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("backup: invalid public key: %w", err)
+	}
+	if len(recipient.Salt) != saltSize {
+		return nil, fmt.Errorf("backup: invalid salt")
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("backup: failed to generate ephemeral key: %w", err)
+	}
+	shared, err := ephemeral.ECDH(pub)
+	if err != nil {
+		return nil, fmt.Errorf("backup: failed to compute shared secret: %w", err)
+	}
+	aead, err := fileKey(shared, ephemeral.PublicKey().Bytes(), recipient.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, prefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, fmt.Errorf("backup: failed to generate nonce: %w", err)
+	}
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, magic...)
+	header = append(header, scryptLogN, scryptR, scryptP)
+	header = append(header, recipient.Salt...)
+	header = append(header, ephemeral.PublicKey().Bytes()...)
+	header = append(header, prefix...)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{w: w, aead: aead, prefix: prefix, buf: make([]byte, 0, chunkSize)}, nil
+}
+
+func (e *encryptWriter) seal(last bool) error {
+	if !last && e.counter == ^uint32(0) {
+		return errors.New("backup: too much data")
+	}
+	out := e.aead.Seal(nil, chunkNonce(e.prefix, e.counter, last), e.buf, nil)
+	e.counter++
+	e.buf = e.buf[:0]
+	_, err := e.w.Write(out)
+	return err
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, errors.New("backup: write after close")
+	}
+	n := 0
+	for len(p) > 0 {
+		// 満杯のチャンクは続きがあると分かってから書き込む（最後のチャンクはCloseで書き込む）
+		if len(e.buf) == chunkSize {
+			if err := e.seal(false); err != nil {
+				return n, err
+			}
+		}
+		m := copy(e.buf[len(e.buf):chunkSize], p)
+		e.buf = e.buf[:len(e.buf)+m]
+		p = p[m:]
+		n += m
+	}
+	return n, nil
+}
+
+// Close は最後のチャンク（空の場合もある）を書き込む。wは閉じない
+func (e *encryptWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.seal(true)
+}
+
+// decryptReader はチャンクごとに復号して読み出す
+type decryptReader struct {
+	r       *bufio.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buf     []byte // 復号済みで未読の内容
+	chunk   []byte
+	done    bool
+}
+
+// NewDecryptReader はNewEncryptWriterで暗号化した内容をpassphraseで復号するReaderを返す
+func NewDecryptReader(r io.Reader, passphrase string) (io.Reader, error) {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, ErrInvalidFormat
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, ErrInvalidFormat
+	}
+	rest := header[len(magic):]
+	logN, scR, scP := int(rest[0]), int(rest[1]), int(rest[2])
+	if logN < 1 || logN > maxScryptLogN || scR < 1 || scP < 1 {
+		return nil, ErrInvalidFormat
+	}
+	rest = rest[3:]
+	salt, ephemeralKey, prefix := rest[:saltSize], rest[saltSize:saltSize+32], rest[saltSize+32:]
+
+	key, err := deriveKey(passphrase, salt, logN, scR, scP)
+	if err != nil {
+		return nil, err
+	}
+	ephemeral, err := ecdh.X25519().NewPublicKey(ephemeralKey)
+	if err != nil {
+		return nil, ErrInvalidFormat
+	}
+	shared, err := key.ECDH(ephemeral)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	aead, err := fileKey(shared, ephemeralKey, key.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &decryptReader{
+		r:      bufio.NewReaderSize(r, encryptedChunk+1),
+		aead:   aead,
+		prefix: bytes.Clone(prefix),
+		chunk:  make([]byte, encryptedChunk),
+	}, nil
+}
+
+// next は次のチャンクを読み込んで復号する
+// チャンクの後ろに続きがなければ最後のチャンクとして検証する
+func (d *decryptReader) next() error {
+	n, err := io.ReadFull(d.r, d.chunk)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		if errors.Is(err, io.EOF) {
+			// 最後のチャンクを受け取る前に終わった
+			return ErrWrongPassphrase
+		}
+		return err
+	}
+	last := n < encryptedChunk
+	if !last {
+		if _, err := d.r.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		}
+	}
+	plain, err := d.aead.Open(d.chunk[:0], chunkNonce(d.prefix, d.counter, last), d.chunk[:n], nil)
+	if err != nil {
+		return ErrWrongPassphrase
+	}
+	d.counter++
+	d.buf = plain
+	d.done = last
+	return nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		if err := d.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
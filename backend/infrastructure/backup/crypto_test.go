@@ -0,0 +1,77 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encrypt はplainをrecipientで暗号化した内容を返す
+func encrypt(t *testing.T, recipient *Recipient, plain []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewEncryptWriter(&buf, recipient)
+	require.NoError(t, err)
+	// 書き込みの区切りがチャンクの区切りとずれても同じ結果になるよう、半端な大きさで書き込む
+	for len(plain) > 0 {
+		n := min(len(plain), 1000)
+		_, err := w.Write(plain[:n])
+		require.NoError(t, err)
+		plain = plain[n:]
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func decrypt(encrypted []byte, passphrase string) ([]byte, error) {
+	r, err := NewDecryptReader(bytes.NewReader(encrypted), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	recipient, err := NewRecipient("correct horse battery staple")
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3 * chunkSize} {
+		plain := make([]byte, size)
+		_, _ = rand.Read(plain)
+		encrypted := encrypt(t, recipient, plain)
+
+		got, err := decrypt(encrypted, "correct horse battery staple")
+		require.NoError(t, err, "size %d", size)
+		assert.Equal(t, plain, got, "size %d", size)
+	}
+
+	plain := bytes.Repeat([]byte("日記"), chunkSize)
+	encrypted := encrypt(t, recipient, plain)
+
+	t.Run("異常系: パスフレーズが違う場合は復号できない", func(t *testing.T) {
+		_, err := decrypt(encrypted, "wrong passphrase")
+		assert.ErrorIs(t, err, ErrWrongPassphrase)
+	})
+
+	t.Run("異常系: 末尾のチャンクを切り詰めた場合は復号できない", func(t *testing.T) {
+		truncated := encrypted[:headerSize+encryptedChunk]
+		_, err := decrypt(truncated, "correct horse battery staple")
+		assert.ErrorIs(t, err, ErrWrongPassphrase)
+	})
+
+	t.Run("異常系: 改ざんされた場合は復号できない", func(t *testing.T) {
+		tampered := bytes.Clone(encrypted)
+		tampered[headerSize+10] ^= 1
+		_, err := decrypt(tampered, "correct horse battery staple")
+		assert.ErrorIs(t, err, ErrWrongPassphrase)
+	})
+
+	t.Run("異常系: 暗号化したバックアップでない場合はErrInvalidFormat", func(t *testing.T) {
+		_, err := decrypt([]byte("PK\x03\x04 not encrypted"), "correct horse battery staple")
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+}
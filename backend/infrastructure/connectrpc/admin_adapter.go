@@ -0,0 +1,92 @@
+package connect
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/grpc/grpcconnect"
+	"github.com/project-mikan/umi.mikan/backend/service/admin"
+)
+
+// AdminServiceAdapter は admin.AdminEntry を grpcconnect.AdminServiceHandler としてラップするアダプター。
+type AdminServiceAdapter struct {
+	svc *admin.AdminEntry
+}
+
+// NewAdminServiceAdapter は AdminServiceAdapter を生成する。
+func NewAdminServiceAdapter(svc *admin.AdminEntry) grpcconnect.AdminServiceHandler {
+	return &AdminServiceAdapter{svc: svc}
+}
+
+func (a *AdminServiceAdapter) ListUsers(ctx context.Context, req *connect.Request[g.ListUsersRequest]) (*connect.Response[g.ListUsersResponse], error) {
+	resp, err := a.svc.ListUsers(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AdminServiceAdapter) GetUserUsage(ctx context.Context, req *connect.Request[g.GetUserUsageRequest]) (*connect.Response[g.GetUserUsageResponse], error) {
+	resp, err := a.svc.GetUserUsage(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AdminServiceAdapter) SetUserRole(ctx context.Context, req *connect.Request[g.SetUserRoleRequest]) (*connect.Response[g.SetUserRoleResponse], error) {
+	resp, err := a.svc.SetUserRole(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AdminServiceAdapter) DisableUser(ctx context.Context, req *connect.Request[g.DisableUserRequest]) (*connect.Response[g.DisableUserResponse], error) {
+	resp, err := a.svc.DisableUser(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AdminServiceAdapter) EnableUser(ctx context.Context, req *connect.Request[g.EnableUserRequest]) (*connect.Response[g.EnableUserResponse], error) {
+	resp, err := a.svc.EnableUser(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AdminServiceAdapter) ForcePasswordReset(ctx context.Context, req *connect.Request[g.ForcePasswordResetRequest]) (*connect.Response[g.ForcePasswordResetResponse], error) {
+	resp, err := a.svc.ForcePasswordReset(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AdminServiceAdapter) RevokeUserCredentials(ctx context.Context, req *connect.Request[g.RevokeUserCredentialsRequest]) (*connect.Response[g.RevokeUserCredentialsResponse], error) {
+	resp, err := a.svc.RevokeUserCredentials(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AdminServiceAdapter) RegenerateUserEmbeddings(ctx context.Context, req *connect.Request[g.RegenerateUserEmbeddingsRequest]) (*connect.Response[g.RegenerateUserEmbeddingsResponse], error) {
+	resp, err := a.svc.RegenerateUserEmbeddings(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AdminServiceAdapter) ListAuditLogs(ctx context.Context, req *connect.Request[g.ListAuditLogsRequest]) (*connect.Response[g.ListAuditLogsResponse], error) {
+	resp, err := a.svc.ListAuditLogs(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
@@ -0,0 +1,23 @@
+package connect
+
+import (
+	"context"
+	"database/sql"
+
+	"connectrpc.com/connect"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+)
+
+// NewAdminInterceptor ConnectRPC 用の管理者向けサービスの認可インターセプターを返す。
+// gRPC の AdminInterceptor と同じく、NewAuthInterceptor がユーザーIDを注入した後に置く。
+// AdminService にストリーミングRPCはないため単項RPCのみを対象にする。
+func NewAdminInterceptor(db *sql.DB) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if err := middleware.AuthorizeAdmin(ctx, db, req.Spec().Procedure); err != nil {
+				return nil, grpcStatusToConnectError(err)
+			}
+			return next(ctx, req)
+		}
+	}
+}
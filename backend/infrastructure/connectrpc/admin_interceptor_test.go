@@ -0,0 +1,108 @@
+package connect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/alicebob/miniredis/v2"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/grpc/grpcconnect"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/redis/rueidis"
+)
+
+// testAdminHandler は管理者のインターセプターのテスト用ダミーハンドラー
+type testAdminHandler struct {
+	grpcconnect.UnimplementedAdminServiceHandler
+}
+
+func (h *testAdminHandler) ListUsers(_ context.Context, _ *connect.Request[g.ListUsersRequest]) (*connect.Response[g.ListUsersResponse], error) {
+	return connect.NewResponse(&g.ListUsersResponse{}), nil
+}
+
+func TestNewAdminInterceptor(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	mux := http.NewServeMux()
+	path, h := grpcconnect.NewAdminServiceHandler(&testAdminHandler{},
+		connect.WithInterceptors(NewAuthInterceptor(nil), NewAdminInterceptor(db)))
+	mux.Handle(path, h)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	const procedure = "/admin.AdminService/ListUsers"
+
+	t.Run("正常系: 管理者は呼び出せる", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "admin-interceptor@example.com", "管理者")
+		if _, err := db.Exec("UPDATE users SET role = 1 WHERE id = $1", userID); err != nil {
+			t.Fatalf("権限の更新に失敗: %v", err)
+		}
+		if got := connectPost(t, server, procedure, "Bearer "+generateValidTokenForTest(t, userID.String())); got != http.StatusOK {
+			t.Errorf("ステータスコード: 期待 200, 実際 %d", got)
+		}
+	})
+
+	t.Run("異常系: 一般のユーザーは403", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "admin-interceptor-user@example.com", "一般")
+		if got := connectPost(t, server, procedure, "Bearer "+generateValidTokenForTest(t, userID.String())); got != http.StatusForbidden {
+			t.Errorf("ステータスコード: 期待 403, 実際 %d", got)
+		}
+	})
+
+	t.Run("異常系: 無効にされた管理者は403", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "admin-interceptor-disabled@example.com", "無効な管理者")
+		if _, err := db.Exec("UPDATE users SET role = 1, disabled_at = $2 WHERE id = $1", userID, time.Now().Unix()); err != nil {
+			t.Fatalf("権限の更新に失敗: %v", err)
+		}
+		if got := connectPost(t, server, procedure, "Bearer "+generateValidTokenForTest(t, userID.String())); got != http.StatusForbidden {
+			t.Errorf("ステータスコード: 期待 403, 実際 %d", got)
+		}
+	})
+
+	t.Run("異常系: トークンがない場合は401", func(t *testing.T) {
+		if got := connectPost(t, server, procedure, ""); got != http.StatusUnauthorized {
+			t.Errorf("ステータスコード: 期待 401, 実際 %d", got)
+		}
+	})
+}
+
+func TestAdminHandlerOptions_RateLimit(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis起動失敗: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	client, err := rueidis.NewClient(rueidis.ClientOption{InitAddress: []string{mr.Addr()}, DisableCache: true})
+	if err != nil {
+		t.Fatalf("rueidisクライアント作成失敗: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	limiter := ratelimiter.NewRPCLimiter(ratelimiter.NewRedisRateLimiter(client), map[string]ratelimiter.Policy{
+		grpcconnect.AdminServiceListUsersProcedure: {Limit: 1, Window: time.Minute},
+	})
+	mux := http.NewServeMux()
+	path, h := grpcconnect.NewAdminServiceHandler(&testAdminHandler{}, AdminHandlerOptions(db, limiter, nil))
+	mux.Handle(path, h)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Run("異常系: 管理者のRPCも上限を超えると429", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "admin-ratelimit@example.com", "管理者")
+		if _, err := db.Exec("UPDATE users SET role = 1 WHERE id = $1", userID); err != nil {
+			t.Fatalf("権限の更新に失敗: %v", err)
+		}
+		auth := "Bearer " + generateValidTokenForTest(t, userID.String())
+		if got := connectPost(t, server, grpcconnect.AdminServiceListUsersProcedure, auth); got != http.StatusOK {
+			t.Fatalf("ステータスコード: 期待 200, 実際 %d", got)
+		}
+		if got := connectPost(t, server, grpcconnect.AdminServiceListUsersProcedure, auth); got != http.StatusTooManyRequests {
+			t.Errorf("ステータスコード: 期待 429, 実際 %d", got)
+		}
+	})
+}
@@ -51,3 +51,235 @@ func (a *AuthServiceAdapter) RefreshAccessToken(ctx context.Context, req *connec
 	}
 	return connect.NewResponse(resp), nil
 }
+
+func (a *AuthServiceAdapter) ListOIDCProviders(ctx context.Context, req *connect.Request[g.ListOIDCProvidersRequest]) (*connect.Response[g.ListOIDCProvidersResponse], error) {
+	resp, err := a.svc.ListOIDCProviders(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) StartOIDCLogin(ctx context.Context, req *connect.Request[g.StartOIDCLoginRequest]) (*connect.Response[g.StartOIDCLoginResponse], error) {
+	resp, err := a.svc.StartOIDCLogin(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) CompleteOIDCLogin(ctx context.Context, req *connect.Request[g.CompleteOIDCLoginRequest]) (*connect.Response[g.AuthResponse], error) {
+	resp, err := a.svc.CompleteOIDCLogin(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) StartOIDCLink(ctx context.Context, req *connect.Request[g.StartOIDCLoginRequest]) (*connect.Response[g.StartOIDCLoginResponse], error) {
+	resp, err := a.svc.StartOIDCLink(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) CompleteOIDCLink(ctx context.Context, req *connect.Request[g.CompleteOIDCLinkRequest]) (*connect.Response[g.CompleteOIDCLinkResponse], error) {
+	resp, err := a.svc.CompleteOIDCLink(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) ListLinkedOIDCProviders(ctx context.Context, req *connect.Request[g.ListLinkedOIDCProvidersRequest]) (*connect.Response[g.ListLinkedOIDCProvidersResponse], error) {
+	resp, err := a.svc.ListLinkedOIDCProviders(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) UnlinkOIDCProvider(ctx context.Context, req *connect.Request[g.UnlinkOIDCProviderRequest]) (*connect.Response[g.UnlinkOIDCProviderResponse], error) {
+	resp, err := a.svc.UnlinkOIDCProvider(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) VerifyMfa(ctx context.Context, req *connect.Request[g.VerifyMfaRequest]) (*connect.Response[g.AuthResponse], error) {
+	resp, err := a.svc.VerifyMfa(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) GetMfaStatus(ctx context.Context, req *connect.Request[g.GetMfaStatusRequest]) (*connect.Response[g.GetMfaStatusResponse], error) {
+	resp, err := a.svc.GetMfaStatus(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) StartTotpEnrollment(ctx context.Context, req *connect.Request[g.StartTotpEnrollmentRequest]) (*connect.Response[g.StartTotpEnrollmentResponse], error) {
+	resp, err := a.svc.StartTotpEnrollment(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) ConfirmTotpEnrollment(ctx context.Context, req *connect.Request[g.ConfirmTotpEnrollmentRequest]) (*connect.Response[g.ConfirmTotpEnrollmentResponse], error) {
+	resp, err := a.svc.ConfirmTotpEnrollment(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) RegenerateRecoveryCodes(ctx context.Context, req *connect.Request[g.RegenerateRecoveryCodesRequest]) (*connect.Response[g.ConfirmTotpEnrollmentResponse], error) {
+	resp, err := a.svc.RegenerateRecoveryCodes(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) DisableMfa(ctx context.Context, req *connect.Request[g.DisableMfaRequest]) (*connect.Response[g.DisableMfaResponse], error) {
+	resp, err := a.svc.DisableMfa(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) StartPasskeyRegistration(ctx context.Context, req *connect.Request[g.StartPasskeyRegistrationRequest]) (*connect.Response[g.PasskeyOptionsResponse], error) {
+	resp, err := a.svc.StartPasskeyRegistration(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) FinishPasskeyRegistration(ctx context.Context, req *connect.Request[g.FinishPasskeyRegistrationRequest]) (*connect.Response[g.FinishPasskeyRegistrationResponse], error) {
+	resp, err := a.svc.FinishPasskeyRegistration(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) StartPasskeyLogin(ctx context.Context, req *connect.Request[g.StartPasskeyLoginRequest]) (*connect.Response[g.PasskeyOptionsResponse], error) {
+	resp, err := a.svc.StartPasskeyLogin(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) FinishPasskeyLogin(ctx context.Context, req *connect.Request[g.FinishPasskeyLoginRequest]) (*connect.Response[g.AuthResponse], error) {
+	resp, err := a.svc.FinishPasskeyLogin(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) StartPasskeyMfa(ctx context.Context, req *connect.Request[g.StartPasskeyMfaRequest]) (*connect.Response[g.PasskeyOptionsResponse], error) {
+	resp, err := a.svc.StartPasskeyMfa(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) FinishPasskeyMfa(ctx context.Context, req *connect.Request[g.FinishPasskeyMfaRequest]) (*connect.Response[g.AuthResponse], error) {
+	resp, err := a.svc.FinishPasskeyMfa(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) ListPasskeys(ctx context.Context, req *connect.Request[g.ListPasskeysRequest]) (*connect.Response[g.ListPasskeysResponse], error) {
+	resp, err := a.svc.ListPasskeys(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) DeletePasskey(ctx context.Context, req *connect.Request[g.DeletePasskeyRequest]) (*connect.Response[g.DeletePasskeyResponse], error) {
+	resp, err := a.svc.DeletePasskey(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) RequestPasswordReset(ctx context.Context, req *connect.Request[g.RequestPasswordResetRequest]) (*connect.Response[g.RequestPasswordResetResponse], error) {
+	resp, err := a.svc.RequestPasswordReset(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) ResetPassword(ctx context.Context, req *connect.Request[g.ResetPasswordRequest]) (*connect.Response[g.ResetPasswordResponse], error) {
+	resp, err := a.svc.ResetPassword(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) VerifyEmail(ctx context.Context, req *connect.Request[g.VerifyEmailRequest]) (*connect.Response[g.VerifyEmailResponse], error) {
+	resp, err := a.svc.VerifyEmail(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) RequestEmailVerification(ctx context.Context, req *connect.Request[g.RequestEmailVerificationRequest]) (*connect.Response[g.RequestEmailVerificationResponse], error) {
+	resp, err := a.svc.RequestEmailVerification(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) CreateInvitation(ctx context.Context, req *connect.Request[g.CreateInvitationRequest]) (*connect.Response[g.CreateInvitationResponse], error) {
+	resp, err := a.svc.CreateInvitation(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) ListInvitations(ctx context.Context, req *connect.Request[g.ListInvitationsRequest]) (*connect.Response[g.ListInvitationsResponse], error) {
+	resp, err := a.svc.ListInvitations(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) RevokeInvitation(ctx context.Context, req *connect.Request[g.RevokeInvitationRequest]) (*connect.Response[g.RevokeInvitationResponse], error) {
+	resp, err := a.svc.RevokeInvitation(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AuthServiceAdapter) CancelAccountDeletion(ctx context.Context, req *connect.Request[g.CancelAccountDeletionRequest]) (*connect.Response[g.CancelAccountDeletionResponse], error) {
+	resp, err := a.svc.CancelAccountDeletion(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
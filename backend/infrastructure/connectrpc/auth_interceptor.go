@@ -2,6 +2,7 @@ package connect
 
 import (
 	"context"
+	"net/http"
 	"strings"
 
 	"connectrpc.com/connect"
@@ -15,7 +16,19 @@ func isAuthExemptProcedure(procedure string) bool {
 	case "/auth.AuthService/RegisterByPassword",
 		"/auth.AuthService/LoginByPassword",
 		"/auth.AuthService/RefreshAccessToken",
-		"/auth.AuthService/GetRegistrationConfig":
+		"/auth.AuthService/GetRegistrationConfig",
+		"/auth.AuthService/ListOIDCProviders",
+		"/auth.AuthService/StartOIDCLogin",
+		"/auth.AuthService/CompleteOIDCLogin",
+		"/auth.AuthService/VerifyMfa",
+		"/auth.AuthService/StartPasskeyLogin",
+		"/auth.AuthService/FinishPasskeyLogin",
+		"/auth.AuthService/StartPasskeyMfa",
+		"/auth.AuthService/FinishPasskeyMfa",
+		"/auth.AuthService/RequestPasswordReset",
+		"/auth.AuthService/ResetPassword",
+		"/auth.AuthService/VerifyEmail",
+		"/auth.AuthService/CancelAccountDeletion":
 		return true
 	default:
 		return false
@@ -23,48 +36,87 @@ func isAuthExemptProcedure(procedure string) bool {
 }
 
 // NewAuthInterceptor ConnectRPC 用の認証インターセプターを返す。
-// gRPC の AuthInterceptor と同じロジックで JWT を検証し、ユーザーIDをコンテキストに注入する。
+// gRPC の NewAuthInterceptor と同じロジックで JWT とユーザーの状態を検証し、ユーザーIDをコンテキストに注入する。
 // HTTPヘッダーからクライアントIPとUser-Agentも抽出してコンテキストに注入する（レートリミット用）。
-func NewAuthInterceptor() connect.UnaryInterceptorFunc {
+func NewAuthInterceptor(checker *middleware.AccountChecker) connect.UnaryInterceptorFunc {
 	return func(next connect.UnaryFunc) connect.UnaryFunc {
 		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
-			procedure := req.Spec().Procedure
-
-			// HTTPヘッダーからクライアント識別情報を取得してコンテキストに注入する。
-			// gRPC metadata の代わりに ConnectRPC では HTTP ヘッダーを参照する必要があるため、
-			// サービス層が metadata.FromIncomingContext で取れない情報をここで補完する。
-			clientIP := extractClientIP(req.Header())
-			if clientIP != "" {
-				ctx = context.WithValue(ctx, middleware.ConnectClientIPKey, clientIP)
-			}
-			userAgent := req.Header().Get("User-Agent")
-			if userAgent != "" {
-				ctx = context.WithValue(ctx, middleware.ConnectUserAgentKey, userAgent)
+			ctx, err := authenticate(ctx, checker, req.Spec().Procedure, req.Header())
+			if err != nil {
+				return nil, err
 			}
+			return next(ctx, req)
+		}
+	}
+}
 
-			// 認証不要なエンドポイントはそのまま通す
-			if isAuthExemptProcedure(procedure) {
-				return next(ctx, req)
-			}
+// NewStreamAuthInterceptor ストリーミングRPC用の認証インターセプターを返す。
+// UnaryInterceptorFunc はストリーミングハンドラーに適用されないため、NewAuthInterceptor と併用する。
+func NewStreamAuthInterceptor(checker *middleware.AccountChecker) connect.Interceptor {
+	return &streamAuthInterceptor{checker: checker}
+}
 
-			// Authorization ヘッダーからBearerトークンを抽出
-			accessToken, err := model.ExtractBearerToken(req.Header().Get("Authorization"))
-			if err != nil {
-				return nil, connect.NewError(connect.CodeUnauthenticated, err)
-			}
+type streamAuthInterceptor struct {
+	checker *middleware.AccountChecker
+}
 
-			// JWT を検証してユーザーIDを取得（リフレッシュトークンは拒否する）
-			_, userID, err := model.ParseAccessToken(accessToken)
-			if err != nil {
-				return nil, connect.NewError(connect.CodeUnauthenticated, err)
-			}
+// WrapUnary 単項RPCは NewAuthInterceptor が担当するためそのまま通す
+func (i *streamAuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return next
+}
 
-			// ユーザーIDをコンテキストに注入（gRPC ミドルウェアと同じキーを使う）
-			ctx = context.WithValue(ctx, middleware.UserIDKey, userID)
+// WrapStreamingClient サーバー側では使わないためそのまま通す
+func (i *streamAuthInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
 
-			return next(ctx, req)
+func (i *streamAuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := authenticate(ctx, i.checker, conn.Spec().Procedure, conn.RequestHeader())
+		if err != nil {
+			return err
 		}
+		return next(ctx, conn)
+	}
+}
+
+// authenticate JWT を検証し、ユーザーIDとクライアント識別情報を注入したコンテキストを返す。
+func authenticate(ctx context.Context, checker *middleware.AccountChecker, procedure string, header http.Header) (context.Context, error) {
+	// HTTPヘッダーからクライアント識別情報を取得してコンテキストに注入する。
+	// gRPC metadata の代わりに ConnectRPC では HTTP ヘッダーを参照する必要があるため、
+	// サービス層が metadata.FromIncomingContext で取れない情報をここで補完する。
+	clientIP := extractClientIP(header)
+	if clientIP != "" {
+		ctx = context.WithValue(ctx, middleware.ConnectClientIPKey, clientIP)
+	}
+	userAgent := header.Get("User-Agent")
+	if userAgent != "" {
+		ctx = context.WithValue(ctx, middleware.ConnectUserAgentKey, userAgent)
+	}
+
+	// 認証不要なエンドポイントはそのまま通す
+	if isAuthExemptProcedure(procedure) {
+		return ctx, nil
+	}
+
+	// Authorization ヘッダーからBearerトークンを抽出
+	accessToken, err := model.ExtractBearerToken(header.Get("Authorization"))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
 	}
+
+	// JWT を検証してユーザーIDを取得（リフレッシュトークンは拒否する）
+	token, userID, err := model.ParseAccessToken(accessToken)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	// 無効にした・削除を予定した・セッションを取り消したユーザーのトークンは有効期限内でも拒否する
+	if err := checker.Check(ctx, userID, token.IssuedAt); err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+
+	// ユーザーIDをコンテキストに注入（gRPC ミドルウェアと同じキーを使う）
+	return context.WithValue(ctx, middleware.UserIDKey, userID), nil
 }
 
 // extractClientIP HTTP ヘッダーからクライアントIPを取得する。
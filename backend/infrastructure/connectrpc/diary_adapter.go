@@ -2,6 +2,7 @@ package connect
 
 import (
 	"context"
+	"io"
 
 	"connectrpc.com/connect"
 	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
@@ -154,3 +155,145 @@ func (a *DiaryServiceAdapter) ExportDiaryEntries(ctx context.Context, req *conne
 	}
 	return connect.NewResponse(resp), nil
 }
+
+func (a *DiaryServiceAdapter) StreamExportDiaryEntries(ctx context.Context, req *connect.Request[g.StreamExportDiaryEntriesRequest], stream *connect.ServerStream[g.StreamExportDiaryEntriesResponse]) error {
+	if err := a.svc.SendExportDiaryEntries(ctx, req.Msg, stream.Send); err != nil {
+		return grpcStatusToConnectError(err)
+	}
+	return nil
+}
+
+func (a *DiaryServiceAdapter) WatchTasks(ctx context.Context, _ *connect.Request[g.WatchTasksRequest], stream *connect.ServerStream[g.TaskEvent]) error {
+	if err := a.svc.StreamTasks(ctx, stream.Send); err != nil {
+		return grpcStatusToConnectError(err)
+	}
+	return nil
+}
+
+func (a *DiaryServiceAdapter) UploadAttachment(ctx context.Context, stream *connect.ClientStream[g.UploadAttachmentRequest]) (*connect.Response[g.UploadAttachmentResponse], error) {
+	recv := func() (*g.UploadAttachmentRequest, error) {
+		if !stream.Receive() {
+			if err := stream.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		return stream.Msg(), nil
+	}
+	resp, err := a.svc.ReceiveAttachment(ctx, recv)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *DiaryServiceAdapter) DownloadAttachment(ctx context.Context, req *connect.Request[g.DownloadAttachmentRequest], stream *connect.ServerStream[g.DownloadAttachmentResponse]) error {
+	if err := a.svc.SendAttachment(ctx, req.Msg, stream.Send); err != nil {
+		return grpcStatusToConnectError(err)
+	}
+	return nil
+}
+
+func (a *DiaryServiceAdapter) ListAttachments(ctx context.Context, req *connect.Request[g.ListAttachmentsRequest]) (*connect.Response[g.ListAttachmentsResponse], error) {
+	resp, err := a.svc.ListAttachments(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *DiaryServiceAdapter) DeleteAttachment(ctx context.Context, req *connect.Request[g.DeleteAttachmentRequest]) (*connect.Response[g.DeleteAttachmentResponse], error) {
+	resp, err := a.svc.DeleteAttachment(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *DiaryServiceAdapter) GetAttachmentUsage(ctx context.Context, req *connect.Request[g.GetAttachmentUsageRequest]) (*connect.Response[g.GetAttachmentUsageResponse], error) {
+	resp, err := a.svc.GetAttachmentUsage(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *DiaryServiceAdapter) CreateTag(ctx context.Context, req *connect.Request[g.CreateTagRequest]) (*connect.Response[g.CreateTagResponse], error) {
+	resp, err := a.svc.CreateTag(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *DiaryServiceAdapter) ListTags(ctx context.Context, req *connect.Request[g.ListTagsRequest]) (*connect.Response[g.ListTagsResponse], error) {
+	resp, err := a.svc.ListTags(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *DiaryServiceAdapter) RenameTag(ctx context.Context, req *connect.Request[g.RenameTagRequest]) (*connect.Response[g.RenameTagResponse], error) {
+	resp, err := a.svc.RenameTag(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *DiaryServiceAdapter) MergeTags(ctx context.Context, req *connect.Request[g.MergeTagsRequest]) (*connect.Response[g.MergeTagsResponse], error) {
+	resp, err := a.svc.MergeTags(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *DiaryServiceAdapter) DeleteTag(ctx context.Context, req *connect.Request[g.DeleteTagRequest]) (*connect.Response[g.DeleteTagResponse], error) {
+	resp, err := a.svc.DeleteTag(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *DiaryServiceAdapter) SyncDiaryEntries(ctx context.Context, req *connect.Request[g.SyncDiaryEntriesRequest]) (*connect.Response[g.SyncDiaryEntriesResponse], error) {
+	resp, err := a.svc.SyncDiaryEntries(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *DiaryServiceAdapter) MergeDiaryContent(ctx context.Context, req *connect.Request[g.MergeDiaryContentRequest]) (*connect.Response[g.MergeDiaryContentResponse], error) {
+	resp, err := a.svc.MergeDiaryContent(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *DiaryServiceAdapter) ListTrash(ctx context.Context, req *connect.Request[g.ListTrashRequest]) (*connect.Response[g.ListTrashResponse], error) {
+	resp, err := a.svc.ListTrash(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *DiaryServiceAdapter) RestoreDiaryEntry(ctx context.Context, req *connect.Request[g.RestoreDiaryEntryRequest]) (*connect.Response[g.RestoreDiaryEntryResponse], error) {
+	resp, err := a.svc.RestoreDiaryEntry(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *DiaryServiceAdapter) EmptyTrash(ctx context.Context, req *connect.Request[g.EmptyTrashRequest]) (*connect.Response[g.EmptyTrashResponse], error) {
+	resp, err := a.svc.EmptyTrash(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
@@ -43,6 +43,14 @@ func (a *EntityServiceAdapter) DeleteEntity(ctx context.Context, req *connect.Re
 	return connect.NewResponse(resp), nil
 }
 
+func (a *EntityServiceAdapter) RestoreEntity(ctx context.Context, req *connect.Request[g.RestoreEntityRequest]) (*connect.Response[g.RestoreEntityResponse], error) {
+	resp, err := a.svc.RestoreEntity(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
 func (a *EntityServiceAdapter) GetEntity(ctx context.Context, req *connect.Request[g.GetEntityRequest]) (*connect.Response[g.GetEntityResponse], error) {
 	resp, err := a.svc.GetEntity(ctx, req.Msg)
 	if err != nil {
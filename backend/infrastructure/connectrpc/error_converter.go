@@ -59,5 +59,12 @@ func grpcStatusToConnectError(err error) error {
 		code = connect.CodeUnknown
 	}
 
-	return connect.NewError(code, st.Err())
+	connectErr := connect.NewError(code, st.Err())
+	// 競合時のサーバー側の現在の値など、エラーの詳細も引き継ぐ
+	for _, detail := range st.Proto().GetDetails() {
+		if d, err := connect.NewErrorDetail(detail); err == nil {
+			connectErr.AddDetail(d)
+		}
+	}
+	return connectErr
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"connectrpc.com/connect"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -139,3 +140,31 @@ func TestGrpcStatusToConnectError(t *testing.T) {
 		})
 	}
 }
+
+func TestGrpcStatusToConnectError_Details(t *testing.T) {
+	entry := &g.DiaryEntry{Id: "diary-id", Content: "サーバー側の内容", Version: 3}
+	st, err := status.New(codes.Aborted, "diary entry was updated by another client").WithDetails(entry)
+	if err != nil {
+		t.Fatalf("詳細の追加に失敗: %v", err)
+	}
+
+	var connectErr *connect.Error
+	if !errors.As(grpcStatusToConnectError(st.Err()), &connectErr) {
+		t.Fatal("*connect.Error を期待した")
+	}
+	if connectErr.Code() != connect.CodeAborted {
+		t.Errorf("コード: 期待 %v, 実際 %v", connect.CodeAborted, connectErr.Code())
+	}
+	details := connectErr.Details()
+	if len(details) != 1 {
+		t.Fatalf("詳細の件数: 期待 1, 実際 %d", len(details))
+	}
+	value, err := details[0].Value()
+	if err != nil {
+		t.Fatalf("詳細の復元に失敗: %v", err)
+	}
+	got, ok := value.(*g.DiaryEntry)
+	if !ok || got.Content != "サーバー側の内容" || got.Version != 3 {
+		t.Errorf("詳細の内容が正しくない: %v", value)
+	}
+}
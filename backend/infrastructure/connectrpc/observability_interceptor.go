@@ -0,0 +1,83 @@
+package connect
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/requestid"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"google.golang.org/grpc/codes"
+)
+
+// HandlerOptions ConnectRPCのハンドラーのインターセプターのチェーン（gRPC の middleware.ServerOptions と同じ順序）
+func HandlerOptions(limiter *ratelimiter.RPCLimiter, checker *middleware.AccountChecker) connect.HandlerOption {
+	return connect.WithInterceptors(NewObservabilityInterceptor(), NewAuthInterceptor(checker), NewRateLimitInterceptor(limiter), NewStreamAuthInterceptor(checker))
+}
+
+// AdminHandlerOptions 管理者向けのサービスのインターセプターのチェーン（認証の後に管理者であることを確認し、gRPCと同じくレート制限を適用する）
+func AdminHandlerOptions(db *sql.DB, limiter *ratelimiter.RPCLimiter, checker *middleware.AccountChecker) connect.HandlerOption {
+	return connect.WithInterceptors(NewObservabilityInterceptor(), NewAuthInterceptor(checker), NewAdminInterceptor(db), NewRateLimitInterceptor(limiter))
+}
+
+// NewObservabilityInterceptor ConnectRPC 用のリクエストIDの付与・panicからの復帰・メトリクスとアクセスログの記録を行うインターセプターを返す。
+// 最も外側に置き、単項RPCとストリーミングRPCの両方に適用する。
+func NewObservabilityInterceptor() connect.Interceptor {
+	return &observabilityInterceptor{}
+}
+
+type observabilityInterceptor struct{}
+
+func (i *observabilityInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (resp connect.AnyResponse, err error) {
+		procedure := req.Spec().Procedure
+		id := requestid.FromIncoming(req.Header().Get(requestid.Header))
+		ctx = requestid.WithID(ctx, id)
+		start := time.Now()
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				resp, err = nil, grpcStatusToConnectError(middleware.RecoverRPC(ctx, middleware.ProtocolConnect, procedure, recovered))
+			}
+			middleware.ObserveRPC(ctx, middleware.ProtocolConnect, procedure, connectCode(err), time.Since(start), err)
+			if resp != nil {
+				resp.Header().Set(requestid.Header, id)
+			} else {
+				err = withErrorMeta(err, map[string]string{requestid.Header: id})
+			}
+		}()
+		return next(ctx, req)
+	}
+}
+
+// WrapStreamingClient サーバー側では使わないためそのまま通す
+func (i *observabilityInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *observabilityInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) (err error) {
+		procedure := conn.Spec().Procedure
+		id := requestid.FromIncoming(conn.RequestHeader().Get(requestid.Header))
+		ctx = requestid.WithID(ctx, id)
+		// ストリーミングでは最初のメッセージを送るときにヘッダーを送るため、先に設定する
+		conn.ResponseHeader().Set(requestid.Header, id)
+		start := time.Now()
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = grpcStatusToConnectError(middleware.RecoverRPC(ctx, middleware.ProtocolConnect, procedure, recovered))
+			}
+			middleware.ObserveRPC(ctx, middleware.ProtocolConnect, procedure, connectCode(err), time.Since(start), err)
+		}()
+		return next(ctx, conn)
+	}
+}
+
+// connectCode メトリクスのラベルを gRPC と揃えるため、ConnectRPC のエラーコードを gRPC のコードに変換する（値は同じ）
+func connectCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	return codes.Code(connect.CodeOf(err))
+}
@@ -0,0 +1,95 @@
+package connect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/grpc/grpcconnect"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/requestid"
+)
+
+// testObservabilityHandler は記録のインターセプターのテスト用ダミーハンドラー
+type testObservabilityHandler struct {
+	grpcconnect.UnimplementedAuthServiceHandler
+	requestID string // ハンドラーが受け取ったリクエストID
+}
+
+func (h *testObservabilityHandler) GetRegistrationConfig(ctx context.Context, _ *connect.Request[g.GetRegistrationConfigRequest]) (*connect.Response[g.GetRegistrationConfigResponse], error) {
+	h.requestID = requestid.FromContext(ctx)
+	return connect.NewResponse(&g.GetRegistrationConfigResponse{}), nil
+}
+
+func (h *testObservabilityHandler) ListOIDCProviders(_ context.Context, _ *connect.Request[g.ListOIDCProvidersRequest]) (*connect.Response[g.ListOIDCProvidersResponse], error) {
+	panic("unexpected nil")
+}
+
+// connectPostWithRequestID はリクエストIDを付けて Connect プロトコルの JSON リクエストを送信する
+func connectPostWithRequestID(t *testing.T, server *httptest.Server, procedure, id string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+procedure, strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("リクエスト作成失敗: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("リクエスト送信失敗: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := resp.Body.Close(); err != nil {
+			t.Logf("resp.Body.Close 失敗: %v", err)
+		}
+	})
+	return resp
+}
+
+func TestNewObservabilityInterceptor(t *testing.T) {
+	handler := &testObservabilityHandler{}
+	mux := http.NewServeMux()
+	path, h := grpcconnect.NewAuthServiceHandler(handler, HandlerOptions(nil, nil))
+	mux.Handle(path, h)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Run("正常系: クライアントが送ったリクエストIDをハンドラーに渡し、レスポンスで返す", func(t *testing.T) {
+		resp := connectPostWithRequestID(t, server, grpcconnect.AuthServiceGetRegistrationConfigProcedure, "ios-req-1")
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("ステータスコード: 期待 200, 実際 %d", resp.StatusCode)
+		}
+		if handler.requestID != "ios-req-1" {
+			t.Errorf("ハンドラーのリクエストID: 期待 %q, 実際 %q", "ios-req-1", handler.requestID)
+		}
+		if got := resp.Header.Get(requestid.Header); got != "ios-req-1" {
+			t.Errorf("x-request-id: 期待 %q, 実際 %q", "ios-req-1", got)
+		}
+	})
+
+	t.Run("正常系: リクエストIDがない場合は生成する", func(t *testing.T) {
+		resp := connectPostWithRequestID(t, server, grpcconnect.AuthServiceGetRegistrationConfigProcedure, "")
+		got := resp.Header.Get(requestid.Header)
+		if got == "" || got != handler.requestID {
+			t.Errorf("生成したIDをハンドラーとレスポンスで共有するべき: ヘッダー %q, ハンドラー %q", got, handler.requestID)
+		}
+	})
+
+	t.Run("異常系: panicした場合は500を返し、サーバーは動き続ける", func(t *testing.T) {
+		resp := connectPostWithRequestID(t, server, grpcconnect.AuthServiceListOIDCProvidersProcedure, "panic-req")
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("ステータスコード: 期待 500, 実際 %d", resp.StatusCode)
+		}
+		if got := resp.Header.Get(requestid.Header); got != "panic-req" {
+			t.Errorf("エラーのレスポンスにもリクエストIDを付けるべき: %q", got)
+		}
+		if resp := connectPostWithRequestID(t, server, grpcconnect.AuthServiceGetRegistrationConfigProcedure, ""); resp.StatusCode != http.StatusOK {
+			t.Errorf("panicの後も呼び出せるべき: %d", resp.StatusCode)
+		}
+	})
+}
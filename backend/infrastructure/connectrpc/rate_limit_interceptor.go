@@ -0,0 +1,53 @@
+package connect
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+)
+
+// NewRateLimitInterceptor ConnectRPC 用のRPCごとのレート制限インターセプターを返す。
+// gRPC の RateLimitInterceptor と同じバケット・ヘッダーを使い、NewAuthInterceptor がユーザーIDを注入した後に置く。
+// 制限するRPCはすべて単項RPCのため、ストリーミングRPCは対象にしない。
+func NewRateLimitInterceptor(limiter *ratelimiter.RPCLimiter) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			procedure := req.Spec().Procedure
+			decision, err := middleware.CheckRateLimit(ctx, limiter, procedure)
+			if err != nil {
+				return nil, grpcStatusToConnectError(err)
+			}
+			if decision == nil {
+				return next(ctx, req)
+			}
+
+			headers := middleware.RateLimitHeaders(decision)
+			if !decision.Allowed {
+				return nil, withErrorMeta(grpcStatusToConnectError(middleware.RateLimitExceededError(procedure, decision)), headers)
+			}
+
+			resp, err := next(ctx, req)
+			if err != nil {
+				return nil, withErrorMeta(err, headers)
+			}
+			for key, value := range headers {
+				resp.Header().Set(key, value)
+			}
+			return resp, nil
+		}
+	}
+}
+
+// withErrorMeta エラーのレスポンスにもヘッダー（レート制限の状況・リクエストID）を付ける
+func withErrorMeta(err error, headers map[string]string) error {
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		for key, value := range headers {
+			connectErr.Meta().Set(key, value)
+		}
+	}
+	return err
+}
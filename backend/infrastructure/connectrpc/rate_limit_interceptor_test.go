@@ -0,0 +1,111 @@
+package connect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/grpc/grpcconnect"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/redis/rueidis"
+)
+
+// testRateLimitHandler はレート制限のインターセプターのテスト用ダミーハンドラー
+type testRateLimitHandler struct {
+	grpcconnect.UnimplementedDiaryServiceHandler
+}
+
+func (h *testRateLimitHandler) SearchDiaryEntriesSemantic(_ context.Context, _ *connect.Request[g.SearchDiaryEntriesSemanticRequest]) (*connect.Response[g.SearchDiaryEntriesSemanticResponse], error) {
+	return connect.NewResponse(&g.SearchDiaryEntriesSemanticResponse{}), nil
+}
+
+func (h *testRateLimitHandler) GetDiaryEntry(_ context.Context, _ *connect.Request[g.GetDiaryEntryRequest]) (*connect.Response[g.GetDiaryEntryResponse], error) {
+	return connect.NewResponse(&g.GetDiaryEntryResponse{}), nil
+}
+
+// connectPostResponse は Connect プロトコルの JSON リクエストを送信し、レスポンスを返す
+func connectPostResponse(t *testing.T, server *httptest.Server, procedure, authHeader string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+procedure, strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("リクエスト作成失敗: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("リクエスト送信失敗: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := resp.Body.Close(); err != nil {
+			t.Logf("resp.Body.Close 失敗: %v", err)
+		}
+	})
+	return resp
+}
+
+func TestNewRateLimitInterceptor(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis起動失敗: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	client, err := rueidis.NewClient(rueidis.ClientOption{InitAddress: []string{mr.Addr()}, DisableCache: true})
+	if err != nil {
+		t.Fatalf("rueidisクライアント作成失敗: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	limiter := ratelimiter.NewRPCLimiter(ratelimiter.NewRedisRateLimiter(client), map[string]ratelimiter.Policy{
+		grpcconnect.DiaryServiceSearchDiaryEntriesSemanticProcedure: {Limit: 2, Window: time.Minute},
+	})
+	mux := http.NewServeMux()
+	path, h := grpcconnect.NewDiaryServiceHandler(&testRateLimitHandler{},
+		connect.WithInterceptors(NewAuthInterceptor(nil), NewRateLimitInterceptor(limiter)))
+	mux.Handle(path, h)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Run("異常系: 上限を超えると429と再試行までの秒数を返す", func(t *testing.T) {
+		auth := "Bearer " + generateValidTokenForTest(t, uuid.NewString())
+		for _, remaining := range []string{"1", "0"} {
+			resp := connectPostResponse(t, server, grpcconnect.DiaryServiceSearchDiaryEntriesSemanticProcedure, auth)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("ステータスコード: 期待 200, 実際 %d", resp.StatusCode)
+			}
+			if got := resp.Header.Get("X-Ratelimit-Limit"); got != "2" {
+				t.Errorf("x-ratelimit-limit: 期待 2, 実際 %q", got)
+			}
+			if got := resp.Header.Get("X-Ratelimit-Remaining"); got != remaining {
+				t.Errorf("x-ratelimit-remaining: 期待 %s, 実際 %q", remaining, got)
+			}
+		}
+
+		resp := connectPostResponse(t, server, grpcconnect.DiaryServiceSearchDiaryEntriesSemanticProcedure, auth)
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("ステータスコード: 期待 429, 実際 %d", resp.StatusCode)
+		}
+		if resp.Header.Get("Retry-After") == "" {
+			t.Error("retry-after が設定されていない")
+		}
+	})
+
+	t.Run("正常系: 制限のないRPCは数えない", func(t *testing.T) {
+		resp := connectPostResponse(t, server, grpcconnect.DiaryServiceGetDiaryEntryProcedure, "Bearer "+generateValidTokenForTest(t, uuid.NewString()))
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("ステータスコード: 期待 200, 実際 %d", resp.StatusCode)
+		}
+		if got := resp.Header.Get("X-Ratelimit-Limit"); got != "" {
+			t.Errorf("制限のないRPCはヘッダーを付けないべき: %q", got)
+		}
+	})
+}
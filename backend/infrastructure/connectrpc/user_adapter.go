@@ -114,3 +114,82 @@ func (a *UserServiceAdapter) DeleteApiKey(ctx context.Context, req *connect.Requ
 	}
 	return connect.NewResponse(resp), nil
 }
+
+func (a *UserServiceAdapter) CreateWebhook(ctx context.Context, req *connect.Request[g.CreateWebhookRequest]) (*connect.Response[g.CreateWebhookResponse], error) {
+	resp, err := a.svc.CreateWebhook(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *UserServiceAdapter) ListWebhooks(ctx context.Context, req *connect.Request[g.ListWebhooksRequest]) (*connect.Response[g.ListWebhooksResponse], error) {
+	resp, err := a.svc.ListWebhooks(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *UserServiceAdapter) DeleteWebhook(ctx context.Context, req *connect.Request[g.DeleteWebhookRequest]) (*connect.Response[g.DeleteWebhookResponse], error) {
+	resp, err := a.svc.DeleteWebhook(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *UserServiceAdapter) ListWebhookDeliveries(ctx context.Context, req *connect.Request[g.ListWebhookDeliveriesRequest]) (*connect.Response[g.ListWebhookDeliveriesResponse], error) {
+	resp, err := a.svc.ListWebhookDeliveries(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *UserServiceAdapter) RequestDataExport(ctx context.Context, req *connect.Request[g.RequestDataExportRequest]) (*connect.Response[g.RequestDataExportResponse], error) {
+	resp, err := a.svc.RequestDataExport(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *UserServiceAdapter) ListDataExports(ctx context.Context, req *connect.Request[g.ListDataExportsRequest]) (*connect.Response[g.ListDataExportsResponse], error) {
+	resp, err := a.svc.ListDataExports(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *UserServiceAdapter) DownloadDataExport(ctx context.Context, req *connect.Request[g.DownloadDataExportRequest], stream *connect.ServerStream[g.DownloadDataExportResponse]) error {
+	if err := a.svc.SendDataExport(ctx, req.Msg, stream.Send); err != nil {
+		return grpcStatusToConnectError(err)
+	}
+	return nil
+}
+
+func (a *UserServiceAdapter) UpdateBackupSettings(ctx context.Context, req *connect.Request[g.UpdateBackupSettingsRequest]) (*connect.Response[g.UpdateBackupSettingsResponse], error) {
+	resp, err := a.svc.UpdateBackupSettings(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *UserServiceAdapter) ListSecurityEvents(ctx context.Context, req *connect.Request[g.ListSecurityEventsRequest]) (*connect.Response[g.ListSecurityEventsResponse], error) {
+	resp, err := a.svc.ListSecurityEvents(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *UserServiceAdapter) GetUsage(ctx context.Context, req *connect.Request[g.GetUsageRequest]) (*connect.Response[g.GetUsageResponse], error) {
+	resp, err := a.svc.GetUsage(ctx, req.Msg)
+	if err != nil {
+		return nil, grpcStatusToConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
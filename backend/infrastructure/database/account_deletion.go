@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleUserDeletion はユーザーの削除を依頼した日時と、データを完全に削除する予定の日時を記録する
+func ScheduleUserDeletion(ctx context.Context, db DB, userID uuid.UUID, requestedAt, scheduledAt int64) error {
+	const sqlstr = `UPDATE users SET deletion_requested_at = $2, deletion_scheduled_at = $3, updated_at = $2 WHERE id = $1`
+	if _, err := db.ExecContext(ctx, sqlstr, userID, requestedAt, scheduledAt); err != nil {
+		return fmt.Errorf("failed to schedule deletion for user %s: %w", userID, err)
+	}
+	return nil
+}
+
+// CancelUserDeletion はrequestedAtに依頼したユーザーの削除を取り消し、取り消した行数を返す
+// （既に取り消した・依頼し直した・削除した場合は0）
+func CancelUserDeletion(ctx context.Context, db DB, userID uuid.UUID, requestedAt, updatedAt int64) (int64, error) {
+	const sqlstr = `UPDATE users SET deletion_requested_at = NULL, deletion_scheduled_at = NULL, updated_at = $3
+		WHERE id = $1 AND deletion_requested_at = $2`
+	res, err := db.ExecContext(ctx, sqlstr, userID, requestedAt, updatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel deletion for user %s: %w", userID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return n, nil
+}
+
+// UserIDsDueForDeletion は削除の予定日時（now, UNIX秒）を過ぎたユーザーのIDを最大limit件返す
+func UserIDsDueForDeletion(ctx context.Context, db DB, now int64, limit int) ([]uuid.UUID, error) {
+	ids, err := queryStringSlice(ctx, db, `SELECT id FROM users
+		WHERE deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= $1
+		ORDER BY deletion_scheduled_at
+		LIMIT $2`, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users due for deletion: %w", err)
+	}
+	userIDs := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		userID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user id %q: %w", id, err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, nil
+}
+
+// UserDeletionScheduleForUpdate はユーザーの削除の予定日時を取得し、トランザクションの終了まで行をロックする
+// （予定がない場合はValidがfalse、ユーザーが存在しない場合は sql.ErrNoRows）
+func UserDeletionScheduleForUpdate(ctx context.Context, db DB, userID uuid.UUID) (*User, error) {
+	const sqlstr = `SELECT id, email, name, deletion_requested_at, deletion_scheduled_at FROM users WHERE id = $1 FOR UPDATE`
+	u := User{_exists: true}
+	if err := db.QueryRowContext(ctx, sqlstr, userID).Scan(&u.ID, &u.Email, &u.Name, &u.DeletionRequestedAt, &u.DeletionScheduledAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &u, nil
+}
+
+// DeleteUserData はユーザーとユーザーのデータをすべて削除する（トランザクション内で使用）。
+// usersへの外部キーがCASCADEでないテーブルを先に削除し、残りはユーザーの削除でCASCADEさせる。
+// 招待コードの使用の記録（invitation_uses）と管理者の監査ログは残す。
+// 削除したユーザーが発行した招待コードは発行者がNULLになり（使えなくなる）、その招待コードでの使用の記録も残る
+func DeleteUserData(ctx context.Context, db DB, userID uuid.UUID) error {
+	statements := []string{
+		`DELETE FROM entities WHERE user_id = $1`,
+		`DELETE FROM diary_summary_months WHERE user_id = $1`,
+		`DELETE FROM diaries WHERE user_id = $1`,
+		`DELETE FROM user_llms WHERE user_id = $1`,
+		`DELETE FROM user_password_authes WHERE user_id = $1`,
+		`DELETE FROM users WHERE id = $1`,
+	}
+	for _, sqlstr := range statements {
+		if _, err := db.ExecContext(ctx, sqlstr, userID); err != nil {
+			return fmt.Errorf("failed to delete user data for user %s (%s): %w", userID, sqlstr, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// AdminUserRow は管理者向けのユーザー一覧の1行（パスワードの再設定を求めているかを含む）
+type AdminUserRow struct {
+	User                  *User
+	PasswordResetRequired bool
+}
+
+// UserUsageStats は管理者向けのユーザーの利用状況の集計
+type UserUsageStats struct {
+	DiaryCount             int32
+	TrashedDiaryCount      int32
+	EmbeddingCount         int32
+	EmbeddedDiaryCount     int32
+	MonthlySummaryCount    int32
+	SemanticSearchCount    int32
+	SemanticSearchCount30d int32
+	AttachmentBytes        int64
+	APIKeyCount            int32
+	LLMKeyConfigured       bool
+}
+
+// AdminAuditLogRow は監査ログの1行（操作した管理者のメールアドレスを含む。管理者が削除された場合は空）
+type AdminAuditLogRow struct {
+	Log        *AdminAuditLog
+	AdminEmail string
+}
+
+const adminUserColumns = `u.id, u.email, u.name, u.auth_type, u.created_at, u.updated_at, u.role, u.disabled_at, u.sessions_revoked_at, u.email_verified_at,
+	u.deletion_requested_at, u.deletion_scheduled_at, COALESCE(p.reset_required, false)`
+
+// escapeLikePattern はLIKEの特殊文字をエスケープする（ESCAPE '\' と組み合わせて使う）
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// AdminUsersAfter はユーザーを (created_at, id) の昇順に、指定位置より後のものを最大limit件返す。
+// queryが空でない場合はメールアドレスか名前に部分一致するユーザーに絞り込む
+func AdminUsersAfter(ctx context.Context, db DB, query string, afterCreatedAt int64, afterID uuid.UUID, limit int) ([]*AdminUserRow, error) {
+	const sqlstr = `SELECT ` + adminUserColumns + `
+		FROM users u
+		LEFT JOIN user_password_authes p ON p.user_id = u.id
+		WHERE (u.created_at, u.id) > ($1, $2)
+			AND ($3 = '' OR u.email ILIKE '%' || $3 || '%' ESCAPE '\' OR u.name ILIKE '%' || $3 || '%' ESCAPE '\')
+		ORDER BY u.created_at ASC, u.id ASC
+		LIMIT $4`
+	rows, err := db.QueryContext(ctx, sqlstr, afterCreatedAt, afterID, escapeLikePattern(query), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	users := make([]*AdminUserRow, 0)
+	for rows.Next() {
+		row, err := scanAdminUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return users, nil
+}
+
+// AdminUserByID は管理者向けのユーザー情報を返す（存在しない場合は sql.ErrNoRows）
+func AdminUserByID(ctx context.Context, db DB, userID uuid.UUID) (*AdminUserRow, error) {
+	const sqlstr = `SELECT ` + adminUserColumns + `
+		FROM users u
+		LEFT JOIN user_password_authes p ON p.user_id = u.id
+		WHERE u.id = $1`
+	row, err := scanAdminUser(db.QueryRowContext(ctx, sqlstr, userID))
+	if err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAdminUser(s rowScanner) (*AdminUserRow, error) {
+	u := User{_exists: true}
+	row := AdminUserRow{User: &u}
+	if err := s.Scan(&u.ID, &u.Email, &u.Name, &u.AuthType, &u.CreatedAt, &u.UpdatedAt, &u.Role, &u.DisabledAt, &u.SessionsRevokedAt, &u.EmailVerifiedAt,
+		&u.DeletionRequestedAt, &u.DeletionScheduledAt, &row.PasswordResetRequired); err != nil {
+		return nil, fmt.Errorf("failed to scan user: %w", err)
+	}
+	return &row, nil
+}
+
+// UserUsageStatsByUserID はユーザーの日記・埋め込みベクトル・LLMの利用状況を集計する
+func UserUsageStatsByUserID(ctx context.Context, db DB, userID uuid.UUID) (*UserUsageStats, error) {
+	const sqlstr = `SELECT
+		(SELECT COUNT(*) FROM diaries WHERE user_id = $1 AND deleted_at IS NULL),
+		(SELECT COUNT(*) FROM diaries WHERE user_id = $1 AND deleted_at IS NOT NULL),
+		(SELECT COUNT(*) FROM diary_embeddings WHERE user_id = $1),
+		(SELECT COUNT(DISTINCT diary_id) FROM diary_embeddings WHERE user_id = $1),
+		(SELECT COUNT(*) FROM diary_summary_months WHERE user_id = $1),
+		(SELECT COUNT(*) FROM semantic_search_logs WHERE user_id = $1),
+		(SELECT COUNT(*) FROM semantic_search_logs WHERE user_id = $1 AND created_at >= NOW() - INTERVAL '30 days'),
+		(SELECT COALESCE(SUM(size_bytes + thumbnail_size_bytes), 0) FROM diary_attachments WHERE user_id = $1),
+		(SELECT COUNT(*) FROM user_api_keys WHERE user_id = $1),
+		EXISTS (SELECT 1 FROM user_llms WHERE user_id = $1 AND key <> '')`
+	var s UserUsageStats
+	if err := db.QueryRowContext(ctx, sqlstr, userID).Scan(&s.DiaryCount, &s.TrashedDiaryCount, &s.EmbeddingCount, &s.EmbeddedDiaryCount,
+		&s.MonthlySummaryCount, &s.SemanticSearchCount, &s.SemanticSearchCount30d, &s.AttachmentBytes, &s.APIKeyCount, &s.LLMKeyConfigured); err != nil {
+		return nil, fmt.Errorf("failed to query user usage: %w", err)
+	}
+	return &s, nil
+}
+
+// RevokeUserSessions はユーザーのsessions_revoked_atを更新し、それ以前に発行したリフレッシュトークンを無効にする
+func RevokeUserSessions(ctx context.Context, db DB, userID uuid.UUID, revokedAt int64) error {
+	const sqlstr = `UPDATE users SET sessions_revoked_at = $2, updated_at = $2 WHERE id = $1`
+	if _, err := db.ExecContext(ctx, sqlstr, userID, revokedAt); err != nil {
+		return fmt.Errorf("failed to revoke sessions for user %s: %w", userID, err)
+	}
+	return nil
+}
+
+// SetPasswordResetRequired はユーザーのパスワード認証情報のreset_requiredを更新し、更新した行数を返す
+// （パスワードでログインしないユーザーの場合は0）
+func SetPasswordResetRequired(ctx context.Context, db DB, userID uuid.UUID, required bool, updatedAt int64) (int64, error) {
+	const sqlstr = `UPDATE user_password_authes SET reset_required = $2, updated_at = $3 WHERE user_id = $1`
+	res, err := db.ExecContext(ctx, sqlstr, userID, required, updatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update reset_required for user %s: %w", userID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return n, nil
+}
+
+// DeleteUserAPIKeysByUserID はユーザーのAPIキーをすべて削除し、削除した件数を返す
+func DeleteUserAPIKeysByUserID(ctx context.Context, db DB, userID uuid.UUID) (int64, error) {
+	const sqlstr = `DELETE FROM user_api_keys WHERE user_id = $1`
+	res, err := db.ExecContext(ctx, sqlstr, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete api keys for user %s: %w", userID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return n, nil
+}
+
+// AdminAuditLogsBefore は監査ログを (created_at, id) の降順に、指定位置より前のものを最大limit件返す。
+// targetUserIDがuuid.Nilでない場合はそのユーザーに対する操作に絞り込む
+func AdminAuditLogsBefore(ctx context.Context, db DB, targetUserID uuid.UUID, beforeCreatedAt int64, beforeID uuid.UUID, limit int) ([]*AdminAuditLogRow, error) {
+	const sqlstr = `SELECT l.id, l.admin_user_id, l.target_user_id, l.action, l.detail, l.created_at, COALESCE(u.email, '')
+		FROM admin_audit_logs l
+		LEFT JOIN users u ON u.id = l.admin_user_id
+		WHERE (l.created_at, l.id) < ($1, $2)
+			AND ($3 = '00000000-0000-0000-0000-000000000000'::uuid OR l.target_user_id = $3)
+		ORDER BY l.created_at DESC, l.id DESC
+		LIMIT $4`
+	rows, err := db.QueryContext(ctx, sqlstr, beforeCreatedAt, beforeID, targetUserID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query admin audit logs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	logs := make([]*AdminAuditLogRow, 0)
+	for rows.Next() {
+		l := AdminAuditLog{_exists: true}
+		row := AdminAuditLogRow{Log: &l}
+		if err := rows.Scan(&l.ID, &l.AdminUserID, &l.TargetUserID, &l.Action, &l.Detail, &l.CreatedAt, &row.AdminEmail); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		logs = append(logs, &row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return logs, nil
+}
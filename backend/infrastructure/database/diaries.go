@@ -2,30 +2,27 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 )
 
-func DiariesByUserIDAndContent(ctx context.Context, db DB, userID string, content string) ([]*Diary, error) {
-	// query
-	const sqlstr = `SELECT ` +
-		`id, user_id, content, date, created_at, updated_at ` +
-		`FROM diaries ` +
-		`WHERE user_id = $1 AND content LIKE $2 ORDER BY date DESC`
-	rows, err := db.QueryContext(ctx, sqlstr, userID, "%"+content+"%")
-	if err != nil {
-		return nil, logerror(err)
-	}
-	defer func() { _ = rows.Close() }()
+// diaryColumns は手書きクエリで日記を取得する際のSELECT句（scanDiariesの順序と一致させる）
+const diaryColumns = `id, user_id, content, date, created_at, updated_at, title, entry_time, entry_index, version, change_seq, deleted_at`
 
-	// 結果をマップに格納
+// diaryEntryOrder は同じ日付内の日記の並び順（時刻未指定の日記を先頭に、時刻順・追加順）
+const diaryEntryOrder = `entry_time ASC NULLS FIRST, entry_index ASC`
+
+// scanDiaries はdiaryColumnsで取得した行を日記のスライスに変換する
+func scanDiaries(rows *sql.Rows) ([]*Diary, error) {
 	diaries := make([]*Diary, 0)
 	for rows.Next() {
-		var diary Diary
-		if err := rows.Scan(&diary.ID, &diary.UserID, &diary.Content, &diary.Date, &diary.CreatedAt, &diary.UpdatedAt); err != nil {
+		d := Diary{_exists: true}
+		if err := rows.Scan(&d.ID, &d.UserID, &d.Content, &d.Date, &d.CreatedAt, &d.UpdatedAt, &d.Title, &d.EntryTime, &d.EntryIndex, &d.Version, &d.ChangeSeq, &d.DeletedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
-		diaries = append(diaries, &diary)
+		diaries = append(diaries, &d)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -35,6 +32,46 @@ func DiariesByUserIDAndContent(ctx context.Context, db DB, userID string, conten
 	return diaries, nil
 }
 
+// DiariesByUserIDAndDateOrdered は指定ユーザーの指定日付の日記（ゴミ箱を除く）を表示順（diaryEntryOrder）で返す
+func DiariesByUserIDAndDateOrdered(ctx context.Context, db DB, userID string, date time.Time) ([]*Diary, error) {
+	const sqlstr = `SELECT ` + diaryColumns + ` FROM diaries WHERE user_id = $1 AND date = $2 AND deleted_at IS NULL ORDER BY ` + diaryEntryOrder
+	rows, err := db.QueryContext(ctx, sqlstr, userID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query diaries by date: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanDiaries(rows)
+}
+
+// NextDiaryEntryIndex は指定日付に日記を追加する際のentry_index（既存の最大値+1、日記がなければ0）と、
+// その日付のゴミ箱にない日記の件数を返す
+// ゴミ箱の日記も復元できるようentry_indexを使い続けるため、最大値はゴミ箱の日記を含めて求める
+// 同時に追加された場合はunique_user_date_entry制約で片方が失敗する
+func NextDiaryEntryIndex(ctx context.Context, db DB, userID string, date time.Time) (int, int, error) {
+	const sqlstr = `SELECT COALESCE(MAX(entry_index) + 1, 0), COUNT(*) FILTER (WHERE deleted_at IS NULL)
+		FROM diaries WHERE user_id = $1 AND date = $2`
+	var next, active int
+	if err := db.QueryRowContext(ctx, sqlstr, userID, date).Scan(&next, &active); err != nil {
+		return 0, 0, fmt.Errorf("failed to get next diary entry index: %w", err)
+	}
+	return next, active, nil
+}
+
+func DiariesByUserIDAndContent(ctx context.Context, db DB, userID string, content string) ([]*Diary, error) {
+	// query
+	const sqlstr = `SELECT ` + diaryColumns + ` ` +
+		`FROM diaries ` +
+		`WHERE user_id = $1 AND deleted_at IS NULL AND content LIKE $2 ORDER BY date DESC, ` + diaryEntryOrder
+	rows, err := db.QueryContext(ctx, sqlstr, userID, "%"+content+"%")
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanDiaries(rows)
+}
+
 // DiariesByUserIDAndKeywords は複数キーワードのいずれかを本文に含む日記をORで検索する。
 func DiariesByUserIDAndKeywords(ctx context.Context, db DB, userID string, keywords []string) ([]*Diary, error) {
 	if len(keywords) == 0 {
@@ -50,9 +87,9 @@ func DiariesByUserIDAndKeywords(ctx context.Context, db DB, userID string, keywo
 		args = append(args, "%"+kw+"%")
 	}
 
-	sqlstr := `SELECT id, user_id, content, date, created_at, updated_at FROM diaries WHERE user_id = $1 AND (` +
+	sqlstr := `SELECT ` + diaryColumns + ` FROM diaries WHERE user_id = $1 AND deleted_at IS NULL AND (` +
 		strings.Join(conditions, " OR ") +
-		`) ORDER BY date DESC`
+		`) ORDER BY date DESC, ` + diaryEntryOrder
 
 	rows, err := db.QueryContext(ctx, sqlstr, args...)
 	if err != nil {
@@ -60,18 +97,5 @@ func DiariesByUserIDAndKeywords(ctx context.Context, db DB, userID string, keywo
 	}
 	defer func() { _ = rows.Close() }()
 
-	diaries := make([]*Diary, 0)
-	for rows.Next() {
-		var diary Diary
-		if err := rows.Scan(&diary.ID, &diary.UserID, &diary.Content, &diary.Date, &diary.CreatedAt, &diary.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
-		diaries = append(diaries, &diary)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error during rows iteration: %w", err)
-	}
-
-	return diaries, nil
+	return scanDiaries(rows)
 }
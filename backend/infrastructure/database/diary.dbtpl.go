@@ -4,6 +4,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,12 +12,18 @@ import (
 
 // Diary represents a row from 'public.diaries'.
 type Diary struct {
-	ID        uuid.UUID `json:"id"`         // id
-	UserID    uuid.UUID `json:"user_id"`    // user_id
-	Content   string    `json:"content"`    // content
-	Date      time.Time `json:"date"`       // date
-	CreatedAt int64     `json:"created_at"` // created_at
-	UpdatedAt int64     `json:"updated_at"` // updated_at
+	ID         uuid.UUID     `json:"id"`          // id
+	UserID     uuid.UUID     `json:"user_id"`     // user_id
+	Content    string        `json:"content"`     // content
+	Date       time.Time     `json:"date"`        // date
+	CreatedAt  int64         `json:"created_at"`  // created_at
+	UpdatedAt  int64         `json:"updated_at"`  // updated_at
+	Title      string        `json:"title"`       // title
+	EntryTime  sql.NullInt64 `json:"entry_time"`  // entry_time
+	EntryIndex int           `json:"entry_index"` // entry_index
+	Version    int64         `json:"version"`     // version
+	ChangeSeq  int64         `json:"change_seq"`  // change_seq
+	DeletedAt  sql.NullInt64 `json:"deleted_at"`  // deleted_at
 	// xo fields
 	_exists, _deleted bool
 }
@@ -42,13 +49,13 @@ func (d *Diary) Insert(ctx context.Context, db DB) error {
 	}
 	// insert (manual)
 	const sqlstr = `INSERT INTO public.diaries (` +
-		`id, user_id, content, date, created_at, updated_at` +
+		`id, user_id, content, date, created_at, updated_at, title, entry_time, entry_index, version, change_seq, deleted_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12` +
 		`)`
 	// run
-	logf(sqlstr, d.ID, d.UserID, d.Content, d.Date, d.CreatedAt, d.UpdatedAt)
-	if _, err := db.ExecContext(ctx, sqlstr, d.ID, d.UserID, d.Content, d.Date, d.CreatedAt, d.UpdatedAt); err != nil {
+	logf(sqlstr, d.ID, d.UserID, d.Content, d.Date, d.CreatedAt, d.UpdatedAt, d.Title, d.EntryTime, d.EntryIndex, d.Version, d.ChangeSeq, d.DeletedAt)
+	if _, err := db.ExecContext(ctx, sqlstr, d.ID, d.UserID, d.Content, d.Date, d.CreatedAt, d.UpdatedAt, d.Title, d.EntryTime, d.EntryIndex, d.Version, d.ChangeSeq, d.DeletedAt); err != nil {
 		return logerror(err)
 	}
 	// set exists
@@ -66,11 +73,11 @@ func (d *Diary) Update(ctx context.Context, db DB) error {
 	}
 	// update with composite primary key
 	const sqlstr = `UPDATE public.diaries SET ` +
-		`user_id = $1, content = $2, date = $3, created_at = $4, updated_at = $5 ` +
-		`WHERE id = $6`
+		`user_id = $1, content = $2, date = $3, created_at = $4, updated_at = $5, title = $6, entry_time = $7, entry_index = $8, version = $9, change_seq = $10, deleted_at = $11 ` +
+		`WHERE id = $12`
 	// run
-	logf(sqlstr, d.UserID, d.Content, d.Date, d.CreatedAt, d.UpdatedAt, d.ID)
-	if _, err := db.ExecContext(ctx, sqlstr, d.UserID, d.Content, d.Date, d.CreatedAt, d.UpdatedAt, d.ID); err != nil {
+	logf(sqlstr, d.UserID, d.Content, d.Date, d.CreatedAt, d.UpdatedAt, d.Title, d.EntryTime, d.EntryIndex, d.Version, d.ChangeSeq, d.DeletedAt, d.ID)
+	if _, err := db.ExecContext(ctx, sqlstr, d.UserID, d.Content, d.Date, d.CreatedAt, d.UpdatedAt, d.Title, d.EntryTime, d.EntryIndex, d.Version, d.ChangeSeq, d.DeletedAt, d.ID); err != nil {
 		return logerror(err)
 	}
 	return nil
@@ -92,16 +99,16 @@ func (d *Diary) Upsert(ctx context.Context, db DB) error {
 	}
 	// upsert
 	const sqlstr = `INSERT INTO public.diaries (` +
-		`id, user_id, content, date, created_at, updated_at` +
+		`id, user_id, content, date, created_at, updated_at, title, entry_time, entry_index, version, change_seq, deleted_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12` +
 		`)` +
 		` ON CONFLICT (id) DO ` +
 		`UPDATE SET ` +
-		`user_id = EXCLUDED.user_id, content = EXCLUDED.content, date = EXCLUDED.date, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at `
+		`user_id = EXCLUDED.user_id, content = EXCLUDED.content, date = EXCLUDED.date, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at, title = EXCLUDED.title, entry_time = EXCLUDED.entry_time, entry_index = EXCLUDED.entry_index, version = EXCLUDED.version, change_seq = EXCLUDED.change_seq, deleted_at = EXCLUDED.deleted_at `
 	// run
-	logf(sqlstr, d.ID, d.UserID, d.Content, d.Date, d.CreatedAt, d.UpdatedAt)
-	if _, err := db.ExecContext(ctx, sqlstr, d.ID, d.UserID, d.Content, d.Date, d.CreatedAt, d.UpdatedAt); err != nil {
+	logf(sqlstr, d.ID, d.UserID, d.Content, d.Date, d.CreatedAt, d.UpdatedAt, d.Title, d.EntryTime, d.EntryIndex, d.Version, d.ChangeSeq, d.DeletedAt)
+	if _, err := db.ExecContext(ctx, sqlstr, d.ID, d.UserID, d.Content, d.Date, d.CreatedAt, d.UpdatedAt, d.Title, d.EntryTime, d.EntryIndex, d.Version, d.ChangeSeq, d.DeletedAt); err != nil {
 		return logerror(err)
 	}
 	// set exists
@@ -136,7 +143,7 @@ func (d *Diary) Delete(ctx context.Context, db DB) error {
 func DiaryByID(ctx context.Context, db DB, id uuid.UUID) (*Diary, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, user_id, content, date, created_at, updated_at ` +
+		`id, user_id, content, date, created_at, updated_at, title, entry_time, entry_index, version, change_seq, deleted_at ` +
 		`FROM public.diaries ` +
 		`WHERE id = $1`
 	// run
@@ -144,19 +151,87 @@ func DiaryByID(ctx context.Context, db DB, id uuid.UUID) (*Diary, error) {
 	d := Diary{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, id).Scan(&d.ID, &d.UserID, &d.Content, &d.Date, &d.CreatedAt, &d.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, id).Scan(&d.ID, &d.UserID, &d.Content, &d.Date, &d.CreatedAt, &d.UpdatedAt, &d.Title, &d.EntryTime, &d.EntryIndex, &d.Version, &d.ChangeSeq, &d.DeletedAt); err != nil {
 		return nil, logerror(err)
 	}
 	return &d, nil
 }
 
+// DiariesByDeletedAt retrieves a row from 'public.diaries' as a [Diary].
+//
+// Generated from index 'index_diaries_deleted_at'.
+func DiariesByDeletedAt(ctx context.Context, db DB, deletedAt sql.NullInt64) ([]*Diary, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`id, user_id, content, date, created_at, updated_at, title, entry_time, entry_index, version, change_seq, deleted_at ` +
+		`FROM public.diaries ` +
+		`WHERE deleted_at = $1`
+	// run
+	logf(sqlstr, deletedAt)
+	rows, err := db.QueryContext(ctx, sqlstr, deletedAt)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*Diary
+	for rows.Next() {
+		d := Diary{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&d.ID, &d.UserID, &d.Content, &d.Date, &d.CreatedAt, &d.UpdatedAt, &d.Title, &d.EntryTime, &d.EntryIndex, &d.Version, &d.ChangeSeq, &d.DeletedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
+// DiariesByUserIDChangeSeqID retrieves a row from 'public.diaries' as a [Diary].
+//
+// Generated from index 'index_diaries_user_id_and_change_seq'.
+func DiariesByUserIDChangeSeqID(ctx context.Context, db DB, userID uuid.UUID, changeSeq int64, id uuid.UUID) ([]*Diary, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`id, user_id, content, date, created_at, updated_at, title, entry_time, entry_index, version, change_seq, deleted_at ` +
+		`FROM public.diaries ` +
+		`WHERE user_id = $1 AND change_seq = $2 AND id = $3`
+	// run
+	logf(sqlstr, userID, changeSeq, id)
+	rows, err := db.QueryContext(ctx, sqlstr, userID, changeSeq, id)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*Diary
+	for rows.Next() {
+		d := Diary{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&d.ID, &d.UserID, &d.Content, &d.Date, &d.CreatedAt, &d.UpdatedAt, &d.Title, &d.EntryTime, &d.EntryIndex, &d.Version, &d.ChangeSeq, &d.DeletedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
 // DiariesByUserIDDate retrieves a row from 'public.diaries' as a [Diary].
 //
 // Generated from index 'index_diaries_user_id_and_date'.
 func DiariesByUserIDDate(ctx context.Context, db DB, userID uuid.UUID, date time.Time) ([]*Diary, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, user_id, content, date, created_at, updated_at ` +
+		`id, user_id, content, date, created_at, updated_at, title, entry_time, entry_index, version, change_seq, deleted_at ` +
 		`FROM public.diaries ` +
 		`WHERE user_id = $1 AND date = $2`
 	// run
@@ -173,7 +248,7 @@ func DiariesByUserIDDate(ctx context.Context, db DB, userID uuid.UUID, date time
 			_exists: true,
 		}
 		// scan
-		if err := rows.Scan(&d.ID, &d.UserID, &d.Content, &d.Date, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		if err := rows.Scan(&d.ID, &d.UserID, &d.Content, &d.Date, &d.CreatedAt, &d.UpdatedAt, &d.Title, &d.EntryTime, &d.EntryIndex, &d.Version, &d.ChangeSeq, &d.DeletedAt); err != nil {
 			return nil, logerror(err)
 		}
 		res = append(res, &d)
@@ -184,21 +259,21 @@ func DiariesByUserIDDate(ctx context.Context, db DB, userID uuid.UUID, date time
 	return res, nil
 }
 
-// DiaryByUserIDDate retrieves a row from 'public.diaries' as a [Diary].
+// DiaryByUserIDDateEntryIndex retrieves a row from 'public.diaries' as a [Diary].
 //
-// Generated from index 'unique_user_date'.
-func DiaryByUserIDDate(ctx context.Context, db DB, userID uuid.UUID, date time.Time) (*Diary, error) {
+// Generated from index 'unique_user_date_entry'.
+func DiaryByUserIDDateEntryIndex(ctx context.Context, db DB, userID uuid.UUID, date time.Time, entryIndex int) (*Diary, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, user_id, content, date, created_at, updated_at ` +
+		`id, user_id, content, date, created_at, updated_at, title, entry_time, entry_index, version, change_seq, deleted_at ` +
 		`FROM public.diaries ` +
-		`WHERE user_id = $1 AND date = $2`
+		`WHERE user_id = $1 AND date = $2 AND entry_index = $3`
 	// run
-	logf(sqlstr, userID, date)
+	logf(sqlstr, userID, date, entryIndex)
 	d := Diary{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, userID, date).Scan(&d.ID, &d.UserID, &d.Content, &d.Date, &d.CreatedAt, &d.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, userID, date, entryIndex).Scan(&d.ID, &d.UserID, &d.Content, &d.Date, &d.CreatedAt, &d.UpdatedAt, &d.Title, &d.EntryTime, &d.EntryIndex, &d.Version, &d.ChangeSeq, &d.DeletedAt); err != nil {
 		return nil, logerror(err)
 	}
 	return &d, nil
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const diaryAttachmentColumns = `a.id, a.diary_id, a.user_id, a.filename, a.content_type, a.size_bytes,
+	a.storage_key, a.thumbnail_key, a.taken_at, a.latitude, a.longitude, a.created_at, a.updated_at, a.thumbnail_size_bytes`
+
+// StorageKeys は添付ファイルがストレージ上に持つオブジェクトのキー（元ファイルとサムネイル）を返す
+func (da *DiaryAttachment) StorageKeys() []string {
+	keys := []string{da.StorageKey}
+	if da.ThumbnailKey != "" {
+		keys = append(keys, da.ThumbnailKey)
+	}
+	return keys
+}
+
+// scanDiaryAttachments はdiaryAttachmentColumnsで取得した行を添付ファイルのスライスに変換する
+func scanDiaryAttachments(rows *sql.Rows) ([]*DiaryAttachment, error) {
+	attachments := make([]*DiaryAttachment, 0)
+	for rows.Next() {
+		a := DiaryAttachment{_exists: true}
+		if err := rows.Scan(
+			&a.ID, &a.DiaryID, &a.UserID, &a.Filename, &a.ContentType, &a.SizeBytes,
+			&a.StorageKey, &a.ThumbnailKey, &a.TakenAt, &a.Latitude, &a.Longitude, &a.CreatedAt, &a.UpdatedAt, &a.ThumbnailSizeBytes,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		attachments = append(attachments, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return attachments, nil
+}
+
+// DiaryAttachmentsByDiaryID は日記の添付ファイルをアップロード順に返す
+func DiaryAttachmentsByDiaryID(ctx context.Context, db DB, diaryID uuid.UUID) ([]*DiaryAttachment, error) {
+	const sqlstr = `SELECT ` + diaryAttachmentColumns + `
+		FROM diary_attachments a
+		WHERE a.diary_id = $1
+		ORDER BY a.created_at ASC, a.id ASC`
+	rows, err := db.QueryContext(ctx, sqlstr, diaryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query diary attachments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanDiaryAttachments(rows)
+}
+
+// DiaryAttachmentsByUserIDAndDateRangeDays は指定期間（両端含む）の日記に添付されたファイルを
+// 日記の日付順・アップロード順に返す（エクスポート用）
+func DiaryAttachmentsByUserIDAndDateRangeDays(ctx context.Context, db DB, userID string, fromDate, toDate time.Time) ([]*DiaryAttachment, error) {
+	const sqlstr = `SELECT ` + diaryAttachmentColumns + `
+		FROM diary_attachments a
+		JOIN diaries d ON d.id = a.diary_id
+		WHERE d.user_id = $1
+		  AND d.deleted_at IS NULL
+		  AND d.date >= $2
+		  AND d.date <= $3
+		ORDER BY d.date ASC, a.created_at ASC, a.id ASC`
+	rows, err := db.QueryContext(ctx, sqlstr, userID, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query diary attachments by date range: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanDiaryAttachments(rows)
+}
+
+// DiaryAttachmentUsageByUserID はユーザーの添付ファイルの合計サイズ（バイト、サムネイルを含む）を返す
+func DiaryAttachmentUsageByUserID(ctx context.Context, db DB, userID uuid.UUID) (int64, error) {
+	const sqlstr = `SELECT COALESCE(SUM(size_bytes + thumbnail_size_bytes), 0) FROM diary_attachments WHERE user_id = $1`
+	var usage int64
+	if err := db.QueryRowContext(ctx, sqlstr, userID).Scan(&usage); err != nil {
+		return 0, fmt.Errorf("failed to query diary attachment usage: %w", err)
+	}
+	return usage, nil
+}
+
+// LockDiaryAttachmentQuota はユーザー単位のトランザクションアドバイザリロックを取得する
+// 同時アップロードで容量制限の確認と登録の間に他の登録が割り込まないようにする（トランザクション終了で解放される）
+func LockDiaryAttachmentQuota(ctx context.Context, tx *sql.Tx, userID uuid.UUID) error {
+	const sqlstr = `SELECT pg_advisory_xact_lock(hashtext('diary_attachments:' || $1::text))`
+	if _, err := tx.ExecContext(ctx, sqlstr, userID); err != nil {
+		return fmt.Errorf("failed to lock diary attachment quota: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+)
+
+// insertTestAttachment はテスト用の添付ファイル行を挿入する
+func insertTestAttachment(t *testing.T, db *sql.DB, userID, diaryID uuid.UUID, size int64) *database.DiaryAttachment {
+	t.Helper()
+	id := uuid.New()
+	attachment := &database.DiaryAttachment{
+		ID:          id,
+		DiaryID:     diaryID,
+		UserID:      userID,
+		Filename:    "photo.jpg",
+		ContentType: "image/jpeg",
+		SizeBytes:   size,
+		StorageKey:  userID.String() + "/" + id.String() + "/original",
+		CreatedAt:   1700000000,
+		UpdatedAt:   1700000000,
+	}
+	if err := attachment.Insert(context.Background(), db); err != nil {
+		t.Fatalf("添付ファイルの挿入に失敗: %v", err)
+	}
+	return attachment
+}
+
+func TestDiaryAttachments(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "diary-attachments@example.com", "DiaryAttachmentsUser")
+	ctx := context.Background()
+
+	insertTestDiary(t, db, userID, "5月の日記", "2024-05-01")
+	insertTestDiary(t, db, userID, "6月の日記", "2024-06-01")
+	diaries, err := database.DiariesByUserIDAndDateRange(ctx, db, userID.String(), 2024, 5, 2024, 6)
+	if err != nil || len(diaries) != 2 {
+		t.Fatalf("日記の取得に失敗: %v", err)
+	}
+	may, june := diaries[0], diaries[1]
+
+	insertTestAttachment(t, db, userID, may.ID, 100)
+	insertTestAttachment(t, db, userID, june.ID, 200)
+
+	t.Run("正常系: 使用容量は全添付ファイルの合計", func(t *testing.T) {
+		usage, err := database.DiaryAttachmentUsageByUserID(ctx, db, userID)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if usage != 300 {
+			t.Errorf("使用容量: 期待 300, 実際 %d", usage)
+		}
+	})
+
+	t.Run("正常系: 期間内の日記の添付ファイルのみ返す", func(t *testing.T) {
+		from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+		result, err := database.DiaryAttachmentsByUserIDAndDateRangeDays(ctx, db, userID.String(), from, to)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(result) != 1 || result[0].DiaryID != may.ID {
+			t.Errorf("取得結果が不正: %v", result)
+		}
+	})
+
+	t.Run("正常系: 日記の削除で添付ファイルの行も削除される", func(t *testing.T) {
+		if err := june.Delete(ctx, db); err != nil {
+			t.Fatalf("日記の削除に失敗: %v", err)
+		}
+		result, err := database.DiaryAttachmentsByDiaryID(ctx, db, june.ID)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(result) != 0 {
+			t.Errorf("添付ファイルが残っている: %v", result)
+		}
+	})
+}
@@ -178,13 +178,19 @@ func UpsertDiaryChunkEmbeddings(ctx context.Context, db *sql.DB, diaryID, userID
 // threshold: コサイン類似度の下限（0.0〜1.0）
 // limit: 最大取得件数（日記単位）
 func SearchDiaryEntriesByEmbedding(ctx context.Context, db DB, userID uuid.UUID, queryEmbedding []float32, limit int, threshold float64) ([]*DiaryEmbeddingSearchResult, error) {
+	return SearchDiaryEntriesByEmbeddingWithTags(ctx, db, userID, queryEmbedding, limit, threshold, nil)
+}
+
+// SearchDiaryEntriesByEmbeddingWithTags はSearchDiaryEntriesByEmbeddingの検索対象を
+// tagIDsのタグをすべて持つ日記に絞り込む（nilの場合は絞り込まない）
+func SearchDiaryEntriesByEmbeddingWithTags(ctx context.Context, db DB, userID uuid.UUID, queryEmbedding []float32, limit int, threshold float64, tagIDs []uuid.UUID) ([]*DiaryEmbeddingSearchResult, error) {
 	if limit <= 0 || limit > 50 {
 		limit = 10
 	}
 
 	// 各日記のチャンクの中で最も類似度の高いものを1件だけ選ぶ（日記単位に集約）
 	// chunk_countはその日記の全チャンク数（閾値フィルタ前の総数）を示す
-	query := `
+	query := fmt.Sprintf(`
 		SELECT diary_id, date, content, chunk_content, chunk_summary, chunk_count, similarity, model_version, chunk_model_version
 		FROM (
 			SELECT
@@ -201,15 +207,17 @@ func SearchDiaryEntriesByEmbedding(ctx context.Context, db DB, userID uuid.UUID,
 			FROM diary_embeddings e
 			JOIN diaries d ON d.id = e.diary_id
 			WHERE e.user_id = $1
+				AND d.deleted_at IS NULL
 				AND 1 - (e.embedding <=> $2::halfvec) >= $3
+				AND %s
 		) ranked
 		WHERE rn = 1
 		ORDER BY similarity DESC
 		LIMIT $4
-	`
+	`, fmt.Sprintf(diaryHasAllTagsCondition, 5))
 
 	embeddingStr := embeddingToSQL(queryEmbedding)
-	rows, err := db.QueryContext(ctx, query, userID, embeddingStr, threshold, limit)
+	rows, err := db.QueryContext(ctx, query, userID, embeddingStr, threshold, limit, uuidArray(tagIDs))
 	if err != nil {
 		return nil, fmt.Errorf("failed to search diary entries by embedding: %w", err)
 	}
@@ -252,6 +260,7 @@ func DiaryIDsWithoutEmbeddings(ctx context.Context, db DB, userID uuid.UUID) ([]
 		SELECT d.id
 		FROM diaries d
 		WHERE d.user_id = $1
+		  AND d.deleted_at IS NULL
 		  AND NOT EXISTS (
 		    SELECT 1 FROM diary_embeddings e WHERE e.diary_id = d.id
 		  )
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // DiariesByUserIDAndDateRange は指定ユーザーの指定期間（開始年月〜終了年月）の全日記を返す。
-// 開始月の1日から終了月の末日までを対象とし、date昇順で返す。
+// 開始月の1日から終了月の末日までを対象とし、date昇順（同じ日付内は時刻順）で返す。
 // 大量データ対応のため1回のSQLで取得する。
 func DiariesByUserIDAndDateRange(ctx context.Context, db DB, userID string, fromYear, fromMonth, toYear, toMonth int) ([]*Diary, error) {
 	// 開始日（月初）と終了日（月末）を計算する
@@ -19,17 +21,16 @@ func DiariesByUserIDAndDateRange(ctx context.Context, db DB, userID string, from
 }
 
 // DiariesByUserIDAndDateRangeDays は指定ユーザーの指定日付範囲（開始日〜終了日、両端含む）の
-// 全日記をdate昇順で返す。年月単位ではなく日単位で範囲を指定したい呼び出し元（MCPサーバー等）は
+// 全日記をdate昇順（同じ日付内は時刻順）で返す。年月単位ではなく日単位で範囲を指定したい呼び出し元（MCPサーバー等）は
 // こちらを直接使う。DiariesByUserIDAndDateRange はこの関数に年月からの日付計算を足しただけの薄いラッパー。
 func DiariesByUserIDAndDateRangeDays(ctx context.Context, db DB, userID string, fromDate, toDate time.Time) ([]*Diary, error) {
-	const sqlstr = `
-		SELECT id, user_id, content, date, created_at, updated_at
+	const sqlstr = `SELECT ` + diaryColumns + `
 		FROM diaries
 		WHERE user_id = $1
+		  AND deleted_at IS NULL
 		  AND date >= $2
 		  AND date <= $3
-		ORDER BY date ASC
-	`
+		ORDER BY date ASC, ` + diaryEntryOrder
 
 	rows, err := db.QueryContext(ctx, sqlstr, userID, fromDate, toDate)
 	if err != nil {
@@ -37,18 +38,116 @@ func DiariesByUserIDAndDateRangeDays(ctx context.Context, db DB, userID string,
 	}
 	defer func() { _ = rows.Close() }()
 
-	diaries := make([]*Diary, 0)
-	for rows.Next() {
-		var d Diary
-		if err := rows.Scan(&d.ID, &d.UserID, &d.Content, &d.Date, &d.CreatedAt, &d.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan diary row: %w", err)
+	return scanDiaries(rows)
+}
+
+// DiariesPageByUserIDAndDateRange は指定ユーザーの指定日付範囲（両端含む）の日記のうち、
+// tagIDsのタグをすべて持つものをafterの次から最大limit件返す（afterがnilなら先頭から）
+// 並び順はDiariesByUserIDAndDateRangeDaysと同じで、同じ並び順の行をキーにして続きを取得するため、件数が多くても一定の速さで読み進められる
+func DiariesPageByUserIDAndDateRange(ctx context.Context, db DB, userID uuid.UUID, fromDate, toDate time.Time, tagIDs []uuid.UUID, after *Diary, limit int) ([]*Diary, error) {
+	// 時刻未指定（NULL）を先頭に並べるため、-1に置き換えて比較する
+	sqlstr := `SELECT ` + diaryColumns + `
+		FROM diaries d
+		WHERE d.user_id = $1
+		  AND d.deleted_at IS NULL
+		  AND d.date >= $2
+		  AND d.date <= $3
+		  AND ` + fmt.Sprintf(diaryHasAllTagsCondition, 4) + `
+		  AND (d.date, COALESCE(d.entry_time, -1), d.entry_index, d.id) > ($5, $6, $7, $8)
+		ORDER BY d.date ASC, COALESCE(d.entry_time, -1) ASC, d.entry_index ASC, d.id ASC
+		LIMIT $9`
+
+	// 最初のページは範囲の先頭より前の位置から読み始める
+	afterDate, afterTime, afterIndex, afterID := fromDate.AddDate(0, 0, -1), int64(-1), -1, uuid.Nil
+	if after != nil {
+		afterDate, afterIndex, afterID = after.Date, after.EntryIndex, after.ID
+		if after.EntryTime.Valid {
+			afterTime = after.EntryTime.Int64
 		}
-		diaries = append(diaries, &d)
 	}
 
+	var tagArg any
+	if len(tagIDs) > 0 {
+		tagArg = uuidArray(tagIDs)
+	}
+	rows, err := db.QueryContext(ctx, sqlstr, userID, fromDate, toDate, tagArg, afterDate, afterTime, afterIndex, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query diaries page by date range: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanDiaries(rows)
+}
+
+// DiaryMonthCount は年月ごとの日記の件数
+type DiaryMonthCount struct {
+	Year  int
+	Month int
+	Count int
+}
+
+// DiaryMonthCountsByUserIDAndDateRange は指定ユーザーの指定日付範囲（両端含む）の日記のうち、
+// tagIDsのタグをすべて持つものの件数を年月ごとに年月順で返す（日記がない月は含まない）
+func DiaryMonthCountsByUserIDAndDateRange(ctx context.Context, db DB, userID uuid.UUID, fromDate, toDate time.Time, tagIDs []uuid.UUID) ([]DiaryMonthCount, error) {
+	sqlstr := `SELECT EXTRACT(YEAR FROM d.date)::int, EXTRACT(MONTH FROM d.date)::int, COUNT(*)
+		FROM diaries d
+		WHERE d.user_id = $1
+		  AND d.deleted_at IS NULL
+		  AND d.date >= $2
+		  AND d.date <= $3
+		  AND ` + fmt.Sprintf(diaryHasAllTagsCondition, 4) + `
+		GROUP BY 1, 2
+		ORDER BY 1, 2`
+
+	var tagArg any
+	if len(tagIDs) > 0 {
+		tagArg = uuidArray(tagIDs)
+	}
+	rows, err := db.QueryContext(ctx, sqlstr, userID, fromDate, toDate, tagArg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query diary month counts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := make([]DiaryMonthCount, 0)
+	for rows.Next() {
+		var c DiaryMonthCount
+		if err := rows.Scan(&c.Year, &c.Month, &c.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		counts = append(counts, c)
+	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error during rows iteration: %w", err)
 	}
+	return counts, nil
+}
 
-	return diaries, nil
+// MonthlySummariesByUserIDAndRange は指定ユーザーの指定期間（開始年月〜終了年月）の生成済みの月次要約を年月順で返す
+func MonthlySummariesByUserIDAndRange(ctx context.Context, db DB, userID uuid.UUID, fromYear, fromMonth, toYear, toMonth int) ([]*DiarySummaryMonth, error) {
+	const sqlstr = `SELECT id, user_id, year, month, summary, created_at, updated_at, model_version, error_reason, fencing_token
+		FROM diary_summary_months
+		WHERE user_id = $1
+		  AND (year, month) >= ($2, $3)
+		  AND (year, month) <= ($4, $5)
+		  AND summary <> ''
+		ORDER BY year ASC, month ASC`
+	rows, err := db.QueryContext(ctx, sqlstr, userID, fromYear, fromMonth, toYear, toMonth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query monthly summaries by range: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	summaries := make([]*DiarySummaryMonth, 0)
+	for rows.Next() {
+		s := DiarySummaryMonth{_exists: true}
+		if err := rows.Scan(&s.ID, &s.UserID, &s.Year, &s.Month, &s.Summary, &s.CreatedAt, &s.UpdatedAt, &s.ModelVersion, &s.ErrorReason, &s.FencingToken); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		summaries = append(summaries, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return summaries, nil
 }
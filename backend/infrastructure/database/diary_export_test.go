@@ -3,6 +3,7 @@ package database_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
 	"github.com/project-mikan/umi.mikan/backend/testutil"
@@ -94,3 +95,44 @@ func TestDiariesByUserIDAndDateRange_DBError(t *testing.T) {
 		t.Fatal("DBエラー時にエラーが返ることを期待したがnilが返った")
 	}
 }
+
+func TestDiariesPageByUserIDAndDateRange(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "diary-export-page@example.com", "DiaryExportPageUser")
+	ctx := context.Background()
+
+	insertTestDiary(t, db, userID, "1件目", "2024-02-01")
+	insertTestDiary(t, db, userID, "2件目", "2024-02-02")
+	insertTestDiary(t, db, userID, "3件目", "2024-02-03")
+	insertTestDiary(t, db, userID, "範囲外", "2024-03-01")
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 前のページの最後の日記から続きを取得する", func(t *testing.T) {
+		first, err := database.DiariesPageByUserIDAndDateRange(ctx, db, userID, from, to, nil, nil, 2)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(first) != 2 || first[0].Content != "1件目" || first[1].Content != "2件目" {
+			t.Fatalf("1ページ目が正しくない: %v", first)
+		}
+		second, err := database.DiariesPageByUserIDAndDateRange(ctx, db, userID, from, to, nil, first[1], 2)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(second) != 1 || second[0].Content != "3件目" {
+			t.Errorf("2ページ目が正しくない: %v", second)
+		}
+	})
+
+	t.Run("正常系: 年月ごとの件数を返す", func(t *testing.T) {
+		counts, err := database.DiaryMonthCountsByUserIDAndDateRange(ctx, db, userID, from, to.AddDate(0, 1, 0), nil)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		expected := []database.DiaryMonthCount{{Year: 2024, Month: 2, Count: 3}, {Year: 2024, Month: 3, Count: 1}}
+		if len(counts) != len(expected) || counts[0] != expected[0] || counts[1] != expected[1] {
+			t.Errorf("件数が正しくない: %v", counts)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UpsertDiaryHighlight は生成したハイライトをdiary_highlightsテーブルに保存する
+// 保存済みのfencing_tokenより古いトークンでの書き込みはErrStaleFencingTokenを返して拒否する
+func UpsertDiaryHighlight(ctx context.Context, db DB, diaryID, userID uuid.UUID, highlights []byte, fencingToken int64) error {
+	const sqlstr = `
+		INSERT INTO diary_highlights (id, diary_id, user_id, highlights, created_at, updated_at, fencing_token)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (diary_id) DO UPDATE SET
+			highlights = EXCLUDED.highlights,
+			updated_at = EXCLUDED.updated_at,
+			fencing_token = EXCLUDED.fencing_token
+		WHERE diary_highlights.fencing_token <= EXCLUDED.fencing_token
+	`
+	now := time.Now()
+	result, err := db.ExecContext(ctx, sqlstr, uuid.New(), diaryID, userID, highlights, now, now, fencingToken)
+	if err != nil {
+		return fmt.Errorf("failed to upsert diary highlight: %w", err)
+	}
+	return checkFencedWrite(result)
+}
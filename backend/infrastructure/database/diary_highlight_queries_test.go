@@ -0,0 +1,44 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+)
+
+func TestUpsertDiaryHighlight_FencingToken(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	userID := testutil.CreateTestUser(t, db, "diary-highlight-fencing@example.com", "User")
+
+	diaryID := uuid.New()
+	now := time.Now().Unix()
+	_, err := db.ExecContext(ctx, `INSERT INTO diaries (id, user_id, content, date, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		diaryID, userID, "今日は海に行った", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), now, now)
+	if err != nil {
+		t.Fatalf("日記の作成失敗: %v", err)
+	}
+
+	if err := database.UpsertDiaryHighlight(ctx, db, diaryID, userID, []byte(`[{"start":0,"end":2,"text":"今日"}]`), 20); err != nil {
+		t.Fatalf("UpsertDiaryHighlight失敗: %v", err)
+	}
+
+	// 古いトークンでの書き込みは拒否される
+	err = database.UpsertDiaryHighlight(ctx, db, diaryID, userID, []byte(`[{"start":3,"end":4,"text":"海"}]`), 19)
+	if !errors.Is(err, database.ErrStaleFencingToken) {
+		t.Fatalf("ErrStaleFencingTokenが期待されるが、実際 %v", err)
+	}
+
+	highlight, err := database.DiaryHighlightByDiaryID(ctx, db, diaryID)
+	if err != nil {
+		t.Fatalf("DiaryHighlightByDiaryID失敗: %v", err)
+	}
+	if highlight.FencingToken != 20 {
+		t.Errorf("期待 20, 実際 %d", highlight.FencingToken)
+	}
+}
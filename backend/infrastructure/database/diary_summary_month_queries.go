@@ -8,19 +8,45 @@ import (
 	"github.com/google/uuid"
 )
 
+// UpsertMonthlySummary は生成した月次要約をdiary_summary_monthsテーブルに保存する（成功時はerror_reasonをクリア）
+// 保存済みのfencing_tokenより古いトークンでの書き込みはErrStaleFencingTokenを返して拒否する
+func UpsertMonthlySummary(ctx context.Context, db DB, userID uuid.UUID, year, month int, summary, modelVersion string, fencingToken int64) error {
+	const sqlstr = `
+		INSERT INTO diary_summary_months (id, user_id, year, month, summary, model_version, created_at, updated_at, fencing_token)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, year, month) DO UPDATE SET
+			summary = EXCLUDED.summary,
+			model_version = EXCLUDED.model_version,
+			updated_at = EXCLUDED.updated_at,
+			error_reason = '',
+			fencing_token = EXCLUDED.fencing_token
+		WHERE diary_summary_months.fencing_token <= EXCLUDED.fencing_token
+	`
+	now := time.Now().Unix()
+	result, err := db.ExecContext(ctx, sqlstr, uuid.New(), userID, year, month, summary, modelVersion, now, now, fencingToken)
+	if err != nil {
+		return fmt.Errorf("failed to upsert monthly summary: %w", err)
+	}
+	return checkFencedWrite(result)
+}
+
 // UpsertMonthlySummaryError はLLM生成の永続的なエラーをdiary_summary_monthsテーブルに保存する
 // error_reasonが設定された月はスケジューラーに再キューイングされない（日記更新がない限り）
-func UpsertMonthlySummaryError(ctx context.Context, db DB, userID uuid.UUID, year, month int, errorReason string) error {
+// 保存済みのfencing_tokenより古いトークンでの書き込みはErrStaleFencingTokenを返して拒否する
+func UpsertMonthlySummaryError(ctx context.Context, db DB, userID uuid.UUID, year, month int, errorReason string, fencingToken int64) error {
 	const sqlstr = `
-		INSERT INTO diary_summary_months (id, user_id, year, month, summary, error_reason, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, '', $5, $6, $7)
+		INSERT INTO diary_summary_months (id, user_id, year, month, summary, error_reason, created_at, updated_at, fencing_token)
+		VALUES ($1, $2, $3, $4, '', $5, $6, $7, $8)
 		ON CONFLICT (user_id, year, month) DO UPDATE SET
 			error_reason = EXCLUDED.error_reason,
-			updated_at = EXCLUDED.updated_at
+			updated_at = EXCLUDED.updated_at,
+			fencing_token = EXCLUDED.fencing_token
+		WHERE diary_summary_months.fencing_token <= EXCLUDED.fencing_token
 	`
 	now := time.Now().UnixMilli()
-	if _, err := db.ExecContext(ctx, sqlstr, uuid.New(), userID, year, month, errorReason, now, now); err != nil {
+	result, err := db.ExecContext(ctx, sqlstr, uuid.New(), userID, year, month, errorReason, now, now, fencingToken)
+	if err != nil {
 		return fmt.Errorf("failed to upsert monthly summary error: %w", err)
 	}
-	return nil
+	return checkFencedWrite(result)
 }
@@ -2,6 +2,7 @@ package database_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -24,7 +25,7 @@ func TestUpsertMonthlySummaryError(t *testing.T) {
 	}
 
 	t.Run("エラーレコードを新規作成できる", func(t *testing.T) {
-		err := database.UpsertMonthlySummaryError(ctx, db, userID, 2020, 4, "PROHIBITED_CONTENT")
+		err := database.UpsertMonthlySummaryError(ctx, db, userID, 2020, 4, "PROHIBITED_CONTENT", 0)
 		if err != nil {
 			t.Fatalf("UpsertMonthlySummaryError失敗: %v", err)
 		}
@@ -42,7 +43,7 @@ func TestUpsertMonthlySummaryError(t *testing.T) {
 	})
 
 	t.Run("エラーレコードが既存の場合は上書きする", func(t *testing.T) {
-		err := database.UpsertMonthlySummaryError(ctx, db, userID, 2020, 4, "OTHER_ERROR")
+		err := database.UpsertMonthlySummaryError(ctx, db, userID, 2020, 4, "OTHER_ERROR", 0)
 		if err != nil {
 			t.Fatalf("UpsertMonthlySummaryError失敗: %v", err)
 		}
@@ -73,7 +74,7 @@ func TestUpsertMonthlySummaryError(t *testing.T) {
 			t.Fatalf("サマリーの挿入に失敗: %v", err)
 		}
 
-		err := database.UpsertMonthlySummaryError(ctx, db, userID, 2020, 5, "PROHIBITED_CONTENT")
+		err := database.UpsertMonthlySummaryError(ctx, db, userID, 2020, 5, "PROHIBITED_CONTENT", 0)
 		if err != nil {
 			t.Fatalf("UpsertMonthlySummaryError失敗: %v", err)
 		}
@@ -102,3 +103,48 @@ func TestUpsertMonthlySummaryError(t *testing.T) {
 		}
 	})
 }
+
+func TestUpsertMonthlySummary_FencingToken(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	userID := testutil.CreateTestUser(t, db, "monthly-summary-fencing@example.com", "User")
+
+	t.Run("新しいトークンでの書き込みは成功する", func(t *testing.T) {
+		if err := database.UpsertMonthlySummary(ctx, db, userID, 2021, 1, "1回目の要約", "model-a", 10); err != nil {
+			t.Fatalf("UpsertMonthlySummary失敗: %v", err)
+		}
+		if err := database.UpsertMonthlySummary(ctx, db, userID, 2021, 1, "2回目の要約", "model-a", 11); err != nil {
+			t.Fatalf("UpsertMonthlySummary失敗: %v", err)
+		}
+
+		summary, err := database.DiarySummaryMonthByUserIDYearMonth(ctx, db, userID, 2021, 1)
+		if err != nil {
+			t.Fatalf("DiarySummaryMonthByUserIDYearMonth失敗: %v", err)
+		}
+		if summary.Summary != "2回目の要約" {
+			t.Errorf("期待 2回目の要約, 実際 %q", summary.Summary)
+		}
+		if summary.FencingToken != 11 {
+			t.Errorf("期待 11, 実際 %d", summary.FencingToken)
+		}
+	})
+
+	t.Run("古いトークンでの書き込みはErrStaleFencingTokenで拒否される", func(t *testing.T) {
+		err := database.UpsertMonthlySummary(ctx, db, userID, 2021, 1, "古い保持者の要約", "model-a", 5)
+		if !errors.Is(err, database.ErrStaleFencingToken) {
+			t.Fatalf("ErrStaleFencingTokenが期待されるが、実際 %v", err)
+		}
+		err = database.UpsertMonthlySummaryError(ctx, db, userID, 2021, 1, "PROHIBITED_CONTENT", 5)
+		if !errors.Is(err, database.ErrStaleFencingToken) {
+			t.Fatalf("ErrStaleFencingTokenが期待されるが、実際 %v", err)
+		}
+
+		summary, err := database.DiarySummaryMonthByUserIDYearMonth(ctx, db, userID, 2021, 1)
+		if err != nil {
+			t.Fatalf("DiarySummaryMonthByUserIDYearMonth失敗: %v", err)
+		}
+		if summary.Summary != "2回目の要約" || summary.ErrorReason != "" {
+			t.Errorf("古いトークンで上書きされてはいけない: summary=%q error_reason=%q", summary.Summary, summary.ErrorReason)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// NextDiaryChangeSeq はユーザーの日記の変更番号を1つ進めて返す
+// 採番した行はトランザクションの終了までロックされるため、必ず日記を変更するトランザクション内で呼び出すこと
+func NextDiaryChangeSeq(ctx context.Context, db DB, userID uuid.UUID) (int64, error) {
+	const sqlstr = `INSERT INTO diary_change_sequences (user_id, last_seq) VALUES ($1, 1)
+		ON CONFLICT (user_id) DO UPDATE SET last_seq = diary_change_sequences.last_seq + 1
+		RETURNING last_seq`
+	var seq int64
+	if err := db.QueryRowContext(ctx, sqlstr, userID).Scan(&seq); err != nil {
+		return 0, fmt.Errorf("failed to allocate diary change seq: %w", err)
+	}
+	return seq, nil
+}
+
+// TouchDiaries は本文以外（タグなど）が変わった日記に新しい変更番号を付け、差分同期の対象にする
+// versionは利用者による編集ではないため増やさない。トランザクション内で呼び出すこと
+func TouchDiaries(ctx context.Context, db DB, userID uuid.UUID, diaryIDs []uuid.UUID) error {
+	if len(diaryIDs) == 0 {
+		return nil
+	}
+	seq, err := NextDiaryChangeSeq(ctx, db, userID)
+	if err != nil {
+		return err
+	}
+	const sqlstr = `UPDATE diaries SET change_seq = $3 WHERE user_id = $1 AND id = ANY($2::uuid[])`
+	if _, err := db.ExecContext(ctx, sqlstr, userID, uuidArray(diaryIDs), seq); err != nil {
+		return fmt.Errorf("failed to touch diaries: %w", err)
+	}
+	return nil
+}
+
+// TouchDiariesByTagIDs はtagIDsのいずれかが付いている（提案を含む）日記を差分同期の対象にする
+// タグの統合・削除で関連が消える前に、同じトランザクション内で呼び出すこと
+func TouchDiariesByTagIDs(ctx context.Context, db DB, userID uuid.UUID, tagIDs []uuid.UUID) error {
+	diaryIDs, err := diaryIDsByTagIDs(ctx, db, tagIDs)
+	if err != nil {
+		return err
+	}
+	return TouchDiaries(ctx, db, userID, diaryIDs)
+}
+
+// diaryIDsByTagIDs はtagIDsのいずれかが付いている（提案を含む）日記のIDを返す
+func diaryIDsByTagIDs(ctx context.Context, db DB, tagIDs []uuid.UUID) ([]uuid.UUID, error) {
+	const sqlstr = `SELECT diary_id FROM diary_tags WHERE tag_id = ANY($1::uuid[])
+		UNION
+		SELECT diary_id FROM diary_tag_suggestions WHERE tag_id = ANY($1::uuid[])`
+	ids, err := queryStringSlice(ctx, db, sqlstr, uuidArray(tagIDs))
+	if err != nil {
+		return nil, err
+	}
+	diaryIDs := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		diaryID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse diary id: %w", err)
+		}
+		diaryIDs = append(diaryIDs, diaryID)
+	}
+	return diaryIDs, nil
+}
+
+// DiariesChangedSince はユーザーの日記のうち、(change_seq, id) が指定位置より後のものを
+// 変更番号順に最大limit件返す（差分同期用）
+func DiariesChangedSince(ctx context.Context, db DB, userID uuid.UUID, afterSeq int64, afterID uuid.UUID, limit int) ([]*Diary, error) {
+	const sqlstr = `SELECT ` + diaryColumns + ` FROM diaries
+		WHERE user_id = $1 AND deleted_at IS NULL AND (change_seq, id) > ($2, $3)
+		ORDER BY change_seq ASC, id ASC
+		LIMIT $4`
+	rows, err := db.QueryContext(ctx, sqlstr, userID, afterSeq, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query changed diaries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanDiaries(rows)
+}
+
+// DiaryTombstonesChangedSince はユーザーの削除済み日記のうち、(change_seq, diary_id) が指定位置より後のものを
+// 変更番号順に最大limit件返す（差分同期用）
+func DiaryTombstonesChangedSince(ctx context.Context, db DB, userID uuid.UUID, afterSeq int64, afterID uuid.UUID, limit int) ([]*DiaryTombstone, error) {
+	const sqlstr = `SELECT diary_id, user_id, date, change_seq, deleted_at FROM diary_tombstones
+		WHERE user_id = $1 AND (change_seq, diary_id) > ($2, $3)
+		ORDER BY change_seq ASC, diary_id ASC
+		LIMIT $4`
+	rows, err := db.QueryContext(ctx, sqlstr, userID, afterSeq, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query diary tombstones: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	tombstones := make([]*DiaryTombstone, 0)
+	for rows.Next() {
+		t := DiaryTombstone{_exists: true}
+		if err := rows.Scan(&t.DiaryID, &t.UserID, &t.Date, &t.ChangeSeq, &t.DeletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		tombstones = append(tombstones, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return tombstones, nil
+}
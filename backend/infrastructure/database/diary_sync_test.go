@@ -0,0 +1,109 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+)
+
+func TestDiarySync(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "diary-sync-db@example.com", "DiarySyncDBUser")
+	ctx := context.Background()
+
+	// 変更番号を採番する前から存在する日記（change_seq = 0）
+	insertTestDiary(t, db, userID, "6月1日の日記", "2024-06-01")
+	insertTestDiary(t, db, userID, "6月2日の日記", "2024-06-02")
+
+	t.Run("正常系: 変更番号はユーザーごとに1ずつ増える", func(t *testing.T) {
+		first, err := database.NextDiaryChangeSeq(ctx, db, userID)
+		if err != nil {
+			t.Fatalf("採番に失敗: %v", err)
+		}
+		second, err := database.NextDiaryChangeSeq(ctx, db, userID)
+		if err != nil {
+			t.Fatalf("採番に失敗: %v", err)
+		}
+		if second != first+1 {
+			t.Errorf("変更番号が連続していない: %d, %d", first, second)
+		}
+	})
+
+	diaries, err := database.DiariesChangedSince(ctx, db, userID, -1, uuid.Nil, 10)
+	if err != nil {
+		t.Fatalf("変更の取得に失敗: %v", err)
+	}
+	if len(diaries) != 2 {
+		t.Fatalf("既存の日記が全件返っていない: %d件", len(diaries))
+	}
+
+	t.Run("正常系: TouchDiariesで変更した日記だけが後から返る", func(t *testing.T) {
+		target := diaries[0]
+		if err := database.TouchDiaries(ctx, db, userID, []uuid.UUID{target.ID}); err != nil {
+			t.Fatalf("変更番号の更新に失敗: %v", err)
+		}
+		changed, err := database.DiariesChangedSince(ctx, db, userID, 0, diaries[1].ID, 10)
+		if err != nil {
+			t.Fatalf("変更の取得に失敗: %v", err)
+		}
+		if len(changed) != 1 || changed[0].ID != target.ID {
+			t.Fatalf("変更した日記が返っていない: %+v", changed)
+		}
+		if changed[0].Version != target.Version {
+			t.Errorf("TouchDiariesでversionが変わった: %d -> %d", target.Version, changed[0].Version)
+		}
+	})
+
+	t.Run("正常系: ゴミ箱に移動すると日記の代わりに削除の記録が返る", func(t *testing.T) {
+		target := diaries[1]
+		seq, err := database.NextDiaryChangeSeq(ctx, db, userID)
+		if err != nil {
+			t.Fatalf("採番に失敗: %v", err)
+		}
+		if err := database.TrashDiary(ctx, db, target, seq, 1700000000); err != nil {
+			t.Fatalf("ゴミ箱への移動に失敗: %v", err)
+		}
+		tombstones, err := database.DiaryTombstonesChangedSince(ctx, db, userID, seq-1, uuid.Nil, 10)
+		if err != nil {
+			t.Fatalf("削除の記録の取得に失敗: %v", err)
+		}
+		if len(tombstones) != 1 || tombstones[0].DiaryID != target.ID || tombstones[0].ChangeSeq != seq {
+			t.Fatalf("削除の記録が正しくない: %+v", tombstones)
+		}
+		changed, err := database.DiariesChangedSince(ctx, db, userID, seq-1, uuid.Nil, 10)
+		if err != nil {
+			t.Fatalf("変更の取得に失敗: %v", err)
+		}
+		if len(changed) != 0 {
+			t.Errorf("ゴミ箱の日記が返った: %+v", changed)
+		}
+	})
+
+	t.Run("正常系: 復元すると削除の記録が消えて日記が返る", func(t *testing.T) {
+		target := diaries[1]
+		seq, err := database.NextDiaryChangeSeq(ctx, db, userID)
+		if err != nil {
+			t.Fatalf("採番に失敗: %v", err)
+		}
+		if err := database.RestoreDiary(ctx, db, target, seq); err != nil {
+			t.Fatalf("復元に失敗: %v", err)
+		}
+		tombstones, err := database.DiaryTombstonesChangedSince(ctx, db, userID, 0, uuid.Nil, 10)
+		if err != nil {
+			t.Fatalf("削除の記録の取得に失敗: %v", err)
+		}
+		if len(tombstones) != 0 {
+			t.Errorf("削除の記録が残っている: %+v", tombstones)
+		}
+		changed, err := database.DiariesChangedSince(ctx, db, userID, seq-1, uuid.Nil, 10)
+		if err != nil {
+			t.Fatalf("変更の取得に失敗: %v", err)
+		}
+		if len(changed) != 1 || changed[0].ID != target.ID || changed[0].Trashed() {
+			t.Fatalf("復元した日記が返っていない: %+v", changed)
+		}
+	})
+}
@@ -11,12 +11,13 @@ import (
 
 // DiaryHighlight represents a row from 'public.diary_highlights'.
 type DiaryHighlight struct {
-	ID         uuid.UUID `json:"id"`         // id
-	DiaryID    uuid.UUID `json:"diary_id"`   // diary_id
-	UserID     uuid.UUID `json:"user_id"`    // user_id
-	Highlights []byte    `json:"highlights"` // highlights
-	CreatedAt  time.Time `json:"created_at"` // created_at
-	UpdatedAt  time.Time `json:"updated_at"` // updated_at
+	ID           uuid.UUID `json:"id"`            // id
+	DiaryID      uuid.UUID `json:"diary_id"`      // diary_id
+	UserID       uuid.UUID `json:"user_id"`       // user_id
+	Highlights   []byte    `json:"highlights"`    // highlights
+	CreatedAt    time.Time `json:"created_at"`    // created_at
+	UpdatedAt    time.Time `json:"updated_at"`    // updated_at
+	FencingToken int64     `json:"fencing_token"` // fencing_token
 	// xo fields
 	_exists, _deleted bool
 }
@@ -42,13 +43,13 @@ func (dh *DiaryHighlight) Insert(ctx context.Context, db DB) error {
 	}
 	// insert (manual)
 	const sqlstr = `INSERT INTO public.diary_highlights (` +
-		`id, diary_id, user_id, highlights, created_at, updated_at` +
+		`id, diary_id, user_id, highlights, created_at, updated_at, fencing_token` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6` +
+		`$1, $2, $3, $4, $5, $6, $7` +
 		`)`
 	// run
-	logf(sqlstr, dh.ID, dh.DiaryID, dh.UserID, dh.Highlights, dh.CreatedAt, dh.UpdatedAt)
-	if _, err := db.ExecContext(ctx, sqlstr, dh.ID, dh.DiaryID, dh.UserID, dh.Highlights, dh.CreatedAt, dh.UpdatedAt); err != nil {
+	logf(sqlstr, dh.ID, dh.DiaryID, dh.UserID, dh.Highlights, dh.CreatedAt, dh.UpdatedAt, dh.FencingToken)
+	if _, err := db.ExecContext(ctx, sqlstr, dh.ID, dh.DiaryID, dh.UserID, dh.Highlights, dh.CreatedAt, dh.UpdatedAt, dh.FencingToken); err != nil {
 		return logerror(err)
 	}
 	// set exists
@@ -66,11 +67,11 @@ func (dh *DiaryHighlight) Update(ctx context.Context, db DB) error {
 	}
 	// update with composite primary key
 	const sqlstr = `UPDATE public.diary_highlights SET ` +
-		`diary_id = $1, user_id = $2, highlights = $3, created_at = $4, updated_at = $5 ` +
-		`WHERE id = $6`
+		`diary_id = $1, user_id = $2, highlights = $3, created_at = $4, updated_at = $5, fencing_token = $6 ` +
+		`WHERE id = $7`
 	// run
-	logf(sqlstr, dh.DiaryID, dh.UserID, dh.Highlights, dh.CreatedAt, dh.UpdatedAt, dh.ID)
-	if _, err := db.ExecContext(ctx, sqlstr, dh.DiaryID, dh.UserID, dh.Highlights, dh.CreatedAt, dh.UpdatedAt, dh.ID); err != nil {
+	logf(sqlstr, dh.DiaryID, dh.UserID, dh.Highlights, dh.CreatedAt, dh.UpdatedAt, dh.FencingToken, dh.ID)
+	if _, err := db.ExecContext(ctx, sqlstr, dh.DiaryID, dh.UserID, dh.Highlights, dh.CreatedAt, dh.UpdatedAt, dh.FencingToken, dh.ID); err != nil {
 		return logerror(err)
 	}
 	return nil
@@ -92,16 +93,16 @@ func (dh *DiaryHighlight) Upsert(ctx context.Context, db DB) error {
 	}
 	// upsert
 	const sqlstr = `INSERT INTO public.diary_highlights (` +
-		`id, diary_id, user_id, highlights, created_at, updated_at` +
+		`id, diary_id, user_id, highlights, created_at, updated_at, fencing_token` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6` +
+		`$1, $2, $3, $4, $5, $6, $7` +
 		`)` +
 		` ON CONFLICT (id) DO ` +
 		`UPDATE SET ` +
-		`diary_id = EXCLUDED.diary_id, user_id = EXCLUDED.user_id, highlights = EXCLUDED.highlights, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at `
+		`diary_id = EXCLUDED.diary_id, user_id = EXCLUDED.user_id, highlights = EXCLUDED.highlights, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at, fencing_token = EXCLUDED.fencing_token `
 	// run
-	logf(sqlstr, dh.ID, dh.DiaryID, dh.UserID, dh.Highlights, dh.CreatedAt, dh.UpdatedAt)
-	if _, err := db.ExecContext(ctx, sqlstr, dh.ID, dh.DiaryID, dh.UserID, dh.Highlights, dh.CreatedAt, dh.UpdatedAt); err != nil {
+	logf(sqlstr, dh.ID, dh.DiaryID, dh.UserID, dh.Highlights, dh.CreatedAt, dh.UpdatedAt, dh.FencingToken)
+	if _, err := db.ExecContext(ctx, sqlstr, dh.ID, dh.DiaryID, dh.UserID, dh.Highlights, dh.CreatedAt, dh.UpdatedAt, dh.FencingToken); err != nil {
 		return logerror(err)
 	}
 	// set exists
@@ -136,7 +137,7 @@ func (dh *DiaryHighlight) Delete(ctx context.Context, db DB) error {
 func DiaryHighlightByDiaryID(ctx context.Context, db DB, diaryID uuid.UUID) (*DiaryHighlight, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, diary_id, user_id, highlights, created_at, updated_at ` +
+		`id, diary_id, user_id, highlights, created_at, updated_at, fencing_token ` +
 		`FROM public.diary_highlights ` +
 		`WHERE diary_id = $1`
 	// run
@@ -144,7 +145,7 @@ func DiaryHighlightByDiaryID(ctx context.Context, db DB, diaryID uuid.UUID) (*Di
 	dh := DiaryHighlight{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, diaryID).Scan(&dh.ID, &dh.DiaryID, &dh.UserID, &dh.Highlights, &dh.CreatedAt, &dh.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, diaryID).Scan(&dh.ID, &dh.DiaryID, &dh.UserID, &dh.Highlights, &dh.CreatedAt, &dh.UpdatedAt, &dh.FencingToken); err != nil {
 		return nil, logerror(err)
 	}
 	return &dh, nil
@@ -156,7 +157,7 @@ func DiaryHighlightByDiaryID(ctx context.Context, db DB, diaryID uuid.UUID) (*Di
 func DiaryHighlightByID(ctx context.Context, db DB, id uuid.UUID) (*DiaryHighlight, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, diary_id, user_id, highlights, created_at, updated_at ` +
+		`id, diary_id, user_id, highlights, created_at, updated_at, fencing_token ` +
 		`FROM public.diary_highlights ` +
 		`WHERE id = $1`
 	// run
@@ -164,7 +165,7 @@ func DiaryHighlightByID(ctx context.Context, db DB, id uuid.UUID) (*DiaryHighlig
 	dh := DiaryHighlight{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, id).Scan(&dh.ID, &dh.DiaryID, &dh.UserID, &dh.Highlights, &dh.CreatedAt, &dh.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, id).Scan(&dh.ID, &dh.DiaryID, &dh.UserID, &dh.Highlights, &dh.CreatedAt, &dh.UpdatedAt, &dh.FencingToken); err != nil {
 		return nil, logerror(err)
 	}
 	return &dh, nil
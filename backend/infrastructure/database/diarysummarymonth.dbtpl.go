@@ -19,6 +19,7 @@ type DiarySummaryMonth struct {
 	UpdatedAt    int64     `json:"updated_at"`    // updated_at
 	ModelVersion string    `json:"model_version"` // model_version
 	ErrorReason  string    `json:"error_reason"`  // error_reason
+	FencingToken int64     `json:"fencing_token"` // fencing_token
 	// xo fields
 	_exists, _deleted bool
 }
@@ -44,13 +45,13 @@ func (dsm *DiarySummaryMonth) Insert(ctx context.Context, db DB) error {
 	}
 	// insert (manual)
 	const sqlstr = `INSERT INTO public.diary_summary_months (` +
-		`id, user_id, year, month, summary, created_at, updated_at, model_version, error_reason` +
+		`id, user_id, year, month, summary, created_at, updated_at, model_version, error_reason, fencing_token` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6, $7, $8, $9` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10` +
 		`)`
 	// run
-	logf(sqlstr, dsm.ID, dsm.UserID, dsm.Year, dsm.Month, dsm.Summary, dsm.CreatedAt, dsm.UpdatedAt, dsm.ModelVersion, dsm.ErrorReason)
-	if _, err := db.ExecContext(ctx, sqlstr, dsm.ID, dsm.UserID, dsm.Year, dsm.Month, dsm.Summary, dsm.CreatedAt, dsm.UpdatedAt, dsm.ModelVersion, dsm.ErrorReason); err != nil {
+	logf(sqlstr, dsm.ID, dsm.UserID, dsm.Year, dsm.Month, dsm.Summary, dsm.CreatedAt, dsm.UpdatedAt, dsm.ModelVersion, dsm.ErrorReason, dsm.FencingToken)
+	if _, err := db.ExecContext(ctx, sqlstr, dsm.ID, dsm.UserID, dsm.Year, dsm.Month, dsm.Summary, dsm.CreatedAt, dsm.UpdatedAt, dsm.ModelVersion, dsm.ErrorReason, dsm.FencingToken); err != nil {
 		return logerror(err)
 	}
 	// set exists
@@ -68,11 +69,11 @@ func (dsm *DiarySummaryMonth) Update(ctx context.Context, db DB) error {
 	}
 	// update with composite primary key
 	const sqlstr = `UPDATE public.diary_summary_months SET ` +
-		`user_id = $1, year = $2, month = $3, summary = $4, created_at = $5, updated_at = $6, model_version = $7, error_reason = $8 ` +
-		`WHERE id = $9`
+		`user_id = $1, year = $2, month = $3, summary = $4, created_at = $5, updated_at = $6, model_version = $7, error_reason = $8, fencing_token = $9 ` +
+		`WHERE id = $10`
 	// run
-	logf(sqlstr, dsm.UserID, dsm.Year, dsm.Month, dsm.Summary, dsm.CreatedAt, dsm.UpdatedAt, dsm.ModelVersion, dsm.ErrorReason, dsm.ID)
-	if _, err := db.ExecContext(ctx, sqlstr, dsm.UserID, dsm.Year, dsm.Month, dsm.Summary, dsm.CreatedAt, dsm.UpdatedAt, dsm.ModelVersion, dsm.ErrorReason, dsm.ID); err != nil {
+	logf(sqlstr, dsm.UserID, dsm.Year, dsm.Month, dsm.Summary, dsm.CreatedAt, dsm.UpdatedAt, dsm.ModelVersion, dsm.ErrorReason, dsm.FencingToken, dsm.ID)
+	if _, err := db.ExecContext(ctx, sqlstr, dsm.UserID, dsm.Year, dsm.Month, dsm.Summary, dsm.CreatedAt, dsm.UpdatedAt, dsm.ModelVersion, dsm.ErrorReason, dsm.FencingToken, dsm.ID); err != nil {
 		return logerror(err)
 	}
 	return nil
@@ -94,16 +95,16 @@ func (dsm *DiarySummaryMonth) Upsert(ctx context.Context, db DB) error {
 	}
 	// upsert
 	const sqlstr = `INSERT INTO public.diary_summary_months (` +
-		`id, user_id, year, month, summary, created_at, updated_at, model_version, error_reason` +
+		`id, user_id, year, month, summary, created_at, updated_at, model_version, error_reason, fencing_token` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6, $7, $8, $9` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10` +
 		`)` +
 		` ON CONFLICT (id) DO ` +
 		`UPDATE SET ` +
-		`user_id = EXCLUDED.user_id, year = EXCLUDED.year, month = EXCLUDED.month, summary = EXCLUDED.summary, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at, model_version = EXCLUDED.model_version, error_reason = EXCLUDED.error_reason `
+		`user_id = EXCLUDED.user_id, year = EXCLUDED.year, month = EXCLUDED.month, summary = EXCLUDED.summary, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at, model_version = EXCLUDED.model_version, error_reason = EXCLUDED.error_reason, fencing_token = EXCLUDED.fencing_token `
 	// run
-	logf(sqlstr, dsm.ID, dsm.UserID, dsm.Year, dsm.Month, dsm.Summary, dsm.CreatedAt, dsm.UpdatedAt, dsm.ModelVersion, dsm.ErrorReason)
-	if _, err := db.ExecContext(ctx, sqlstr, dsm.ID, dsm.UserID, dsm.Year, dsm.Month, dsm.Summary, dsm.CreatedAt, dsm.UpdatedAt, dsm.ModelVersion, dsm.ErrorReason); err != nil {
+	logf(sqlstr, dsm.ID, dsm.UserID, dsm.Year, dsm.Month, dsm.Summary, dsm.CreatedAt, dsm.UpdatedAt, dsm.ModelVersion, dsm.ErrorReason, dsm.FencingToken)
+	if _, err := db.ExecContext(ctx, sqlstr, dsm.ID, dsm.UserID, dsm.Year, dsm.Month, dsm.Summary, dsm.CreatedAt, dsm.UpdatedAt, dsm.ModelVersion, dsm.ErrorReason, dsm.FencingToken); err != nil {
 		return logerror(err)
 	}
 	// set exists
@@ -138,7 +139,7 @@ func (dsm *DiarySummaryMonth) Delete(ctx context.Context, db DB) error {
 func DiarySummaryMonthByID(ctx context.Context, db DB, id uuid.UUID) (*DiarySummaryMonth, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, user_id, year, month, summary, created_at, updated_at, model_version, error_reason ` +
+		`id, user_id, year, month, summary, created_at, updated_at, model_version, error_reason, fencing_token ` +
 		`FROM public.diary_summary_months ` +
 		`WHERE id = $1`
 	// run
@@ -146,7 +147,7 @@ func DiarySummaryMonthByID(ctx context.Context, db DB, id uuid.UUID) (*DiarySumm
 	dsm := DiarySummaryMonth{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, id).Scan(&dsm.ID, &dsm.UserID, &dsm.Year, &dsm.Month, &dsm.Summary, &dsm.CreatedAt, &dsm.UpdatedAt, &dsm.ModelVersion, &dsm.ErrorReason); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, id).Scan(&dsm.ID, &dsm.UserID, &dsm.Year, &dsm.Month, &dsm.Summary, &dsm.CreatedAt, &dsm.UpdatedAt, &dsm.ModelVersion, &dsm.ErrorReason, &dsm.FencingToken); err != nil {
 		return nil, logerror(err)
 	}
 	return &dsm, nil
@@ -158,7 +159,7 @@ func DiarySummaryMonthByID(ctx context.Context, db DB, id uuid.UUID) (*DiarySumm
 func DiarySummaryMonthsByUserIDYearMonth(ctx context.Context, db DB, userID uuid.UUID, year, month int) ([]*DiarySummaryMonth, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, user_id, year, month, summary, created_at, updated_at, model_version, error_reason ` +
+		`id, user_id, year, month, summary, created_at, updated_at, model_version, error_reason, fencing_token ` +
 		`FROM public.diary_summary_months ` +
 		`WHERE user_id = $1 AND year = $2 AND month = $3`
 	// run
@@ -175,7 +176,7 @@ func DiarySummaryMonthsByUserIDYearMonth(ctx context.Context, db DB, userID uuid
 			_exists: true,
 		}
 		// scan
-		if err := rows.Scan(&dsm.ID, &dsm.UserID, &dsm.Year, &dsm.Month, &dsm.Summary, &dsm.CreatedAt, &dsm.UpdatedAt, &dsm.ModelVersion, &dsm.ErrorReason); err != nil {
+		if err := rows.Scan(&dsm.ID, &dsm.UserID, &dsm.Year, &dsm.Month, &dsm.Summary, &dsm.CreatedAt, &dsm.UpdatedAt, &dsm.ModelVersion, &dsm.ErrorReason, &dsm.FencingToken); err != nil {
 			return nil, logerror(err)
 		}
 		res = append(res, &dsm)
@@ -192,7 +193,7 @@ func DiarySummaryMonthsByUserIDYearMonth(ctx context.Context, db DB, userID uuid
 func DiarySummaryMonthByUserIDYearMonth(ctx context.Context, db DB, userID uuid.UUID, year, month int) (*DiarySummaryMonth, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, user_id, year, month, summary, created_at, updated_at, model_version, error_reason ` +
+		`id, user_id, year, month, summary, created_at, updated_at, model_version, error_reason, fencing_token ` +
 		`FROM public.diary_summary_months ` +
 		`WHERE user_id = $1 AND year = $2 AND month = $3`
 	// run
@@ -200,7 +201,7 @@ func DiarySummaryMonthByUserIDYearMonth(ctx context.Context, db DB, userID uuid.
 	dsm := DiarySummaryMonth{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, userID, year, month).Scan(&dsm.ID, &dsm.UserID, &dsm.Year, &dsm.Month, &dsm.Summary, &dsm.CreatedAt, &dsm.UpdatedAt, &dsm.ModelVersion, &dsm.ErrorReason); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, userID, year, month).Scan(&dsm.ID, &dsm.UserID, &dsm.Year, &dsm.Month, &dsm.Summary, &dsm.CreatedAt, &dsm.UpdatedAt, &dsm.ModelVersion, &dsm.ErrorReason, &dsm.FencingToken); err != nil {
 		return nil, logerror(err)
 	}
 	return &dsm, nil
@@ -18,6 +18,8 @@ type Entity struct {
 	Memo       sql.NullString `json:"memo"`        // memo
 	CreatedAt  int64          `json:"created_at"`  // created_at
 	UpdatedAt  int64          `json:"updated_at"`  // updated_at
+	Version    int64          `json:"version"`     // version
+	DeletedAt  sql.NullInt64  `json:"deleted_at"`  // deleted_at
 	// xo fields
 	_exists, _deleted bool
 }
@@ -43,13 +45,13 @@ func (e *Entity) Insert(ctx context.Context, db DB) error {
 	}
 	// insert (manual)
 	const sqlstr = `INSERT INTO public.entities (` +
-		`id, user_id, name, category_id, memo, created_at, updated_at` +
+		`id, user_id, name, category_id, memo, created_at, updated_at, version, deleted_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6, $7` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9` +
 		`)`
 	// run
-	logf(sqlstr, e.ID, e.UserID, e.Name, e.CategoryID, e.Memo, e.CreatedAt, e.UpdatedAt)
-	if _, err := db.ExecContext(ctx, sqlstr, e.ID, e.UserID, e.Name, e.CategoryID, e.Memo, e.CreatedAt, e.UpdatedAt); err != nil {
+	logf(sqlstr, e.ID, e.UserID, e.Name, e.CategoryID, e.Memo, e.CreatedAt, e.UpdatedAt, e.Version, e.DeletedAt)
+	if _, err := db.ExecContext(ctx, sqlstr, e.ID, e.UserID, e.Name, e.CategoryID, e.Memo, e.CreatedAt, e.UpdatedAt, e.Version, e.DeletedAt); err != nil {
 		return logerror(err)
 	}
 	// set exists
@@ -67,11 +69,11 @@ func (e *Entity) Update(ctx context.Context, db DB) error {
 	}
 	// update with composite primary key
 	const sqlstr = `UPDATE public.entities SET ` +
-		`user_id = $1, name = $2, category_id = $3, memo = $4, created_at = $5, updated_at = $6 ` +
-		`WHERE id = $7`
+		`user_id = $1, name = $2, category_id = $3, memo = $4, created_at = $5, updated_at = $6, version = $7, deleted_at = $8 ` +
+		`WHERE id = $9`
 	// run
-	logf(sqlstr, e.UserID, e.Name, e.CategoryID, e.Memo, e.CreatedAt, e.UpdatedAt, e.ID)
-	if _, err := db.ExecContext(ctx, sqlstr, e.UserID, e.Name, e.CategoryID, e.Memo, e.CreatedAt, e.UpdatedAt, e.ID); err != nil {
+	logf(sqlstr, e.UserID, e.Name, e.CategoryID, e.Memo, e.CreatedAt, e.UpdatedAt, e.Version, e.DeletedAt, e.ID)
+	if _, err := db.ExecContext(ctx, sqlstr, e.UserID, e.Name, e.CategoryID, e.Memo, e.CreatedAt, e.UpdatedAt, e.Version, e.DeletedAt, e.ID); err != nil {
 		return logerror(err)
 	}
 	return nil
@@ -93,16 +95,16 @@ func (e *Entity) Upsert(ctx context.Context, db DB) error {
 	}
 	// upsert
 	const sqlstr = `INSERT INTO public.entities (` +
-		`id, user_id, name, category_id, memo, created_at, updated_at` +
+		`id, user_id, name, category_id, memo, created_at, updated_at, version, deleted_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6, $7` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9` +
 		`)` +
 		` ON CONFLICT (id) DO ` +
 		`UPDATE SET ` +
-		`user_id = EXCLUDED.user_id, name = EXCLUDED.name, category_id = EXCLUDED.category_id, memo = EXCLUDED.memo, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at `
+		`user_id = EXCLUDED.user_id, name = EXCLUDED.name, category_id = EXCLUDED.category_id, memo = EXCLUDED.memo, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at, version = EXCLUDED.version, deleted_at = EXCLUDED.deleted_at `
 	// run
-	logf(sqlstr, e.ID, e.UserID, e.Name, e.CategoryID, e.Memo, e.CreatedAt, e.UpdatedAt)
-	if _, err := db.ExecContext(ctx, sqlstr, e.ID, e.UserID, e.Name, e.CategoryID, e.Memo, e.CreatedAt, e.UpdatedAt); err != nil {
+	logf(sqlstr, e.ID, e.UserID, e.Name, e.CategoryID, e.Memo, e.CreatedAt, e.UpdatedAt, e.Version, e.DeletedAt)
+	if _, err := db.ExecContext(ctx, sqlstr, e.ID, e.UserID, e.Name, e.CategoryID, e.Memo, e.CreatedAt, e.UpdatedAt, e.Version, e.DeletedAt); err != nil {
 		return logerror(err)
 	}
 	// set exists
@@ -137,7 +139,7 @@ func (e *Entity) Delete(ctx context.Context, db DB) error {
 func EntityByID(ctx context.Context, db DB, id uuid.UUID) (*Entity, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, user_id, name, category_id, memo, created_at, updated_at ` +
+		`id, user_id, name, category_id, memo, created_at, updated_at, version, deleted_at ` +
 		`FROM public.entities ` +
 		`WHERE id = $1`
 	// run
@@ -145,7 +147,7 @@ func EntityByID(ctx context.Context, db DB, id uuid.UUID) (*Entity, error) {
 	e := Entity{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, id).Scan(&e.ID, &e.UserID, &e.Name, &e.CategoryID, &e.Memo, &e.CreatedAt, &e.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, id).Scan(&e.ID, &e.UserID, &e.Name, &e.CategoryID, &e.Memo, &e.CreatedAt, &e.UpdatedAt, &e.Version, &e.DeletedAt); err != nil {
 		return nil, logerror(err)
 	}
 	return &e, nil
@@ -157,7 +159,7 @@ func EntityByID(ctx context.Context, db DB, id uuid.UUID) (*Entity, error) {
 func EntityByUserIDName(ctx context.Context, db DB, userID uuid.UUID, name string) (*Entity, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, user_id, name, category_id, memo, created_at, updated_at ` +
+		`id, user_id, name, category_id, memo, created_at, updated_at, version, deleted_at ` +
 		`FROM public.entities ` +
 		`WHERE user_id = $1 AND name = $2`
 	// run
@@ -165,7 +167,7 @@ func EntityByUserIDName(ctx context.Context, db DB, userID uuid.UUID, name strin
 	e := Entity{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, userID, name).Scan(&e.ID, &e.UserID, &e.Name, &e.CategoryID, &e.Memo, &e.CreatedAt, &e.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, userID, name).Scan(&e.ID, &e.UserID, &e.Name, &e.CategoryID, &e.Memo, &e.CreatedAt, &e.UpdatedAt, &e.Version, &e.DeletedAt); err != nil {
 		return nil, logerror(err)
 	}
 	return &e, nil
@@ -177,7 +179,7 @@ func EntityByUserIDName(ctx context.Context, db DB, userID uuid.UUID, name strin
 func EntitiesByCategoryID(ctx context.Context, db DB, categoryID int) ([]*Entity, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, user_id, name, category_id, memo, created_at, updated_at ` +
+		`id, user_id, name, category_id, memo, created_at, updated_at, version, deleted_at ` +
 		`FROM public.entities ` +
 		`WHERE category_id = $1`
 	// run
@@ -194,7 +196,41 @@ func EntitiesByCategoryID(ctx context.Context, db DB, categoryID int) ([]*Entity
 			_exists: true,
 		}
 		// scan
-		if err := rows.Scan(&e.ID, &e.UserID, &e.Name, &e.CategoryID, &e.Memo, &e.CreatedAt, &e.UpdatedAt); err != nil {
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Name, &e.CategoryID, &e.Memo, &e.CreatedAt, &e.UpdatedAt, &e.Version, &e.DeletedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
+// EntitiesByDeletedAt retrieves a row from 'public.entities' as a [Entity].
+//
+// Generated from index 'index_entities_deleted_at'.
+func EntitiesByDeletedAt(ctx context.Context, db DB, deletedAt sql.NullInt64) ([]*Entity, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`id, user_id, name, category_id, memo, created_at, updated_at, version, deleted_at ` +
+		`FROM public.entities ` +
+		`WHERE deleted_at = $1`
+	// run
+	logf(sqlstr, deletedAt)
+	rows, err := db.QueryContext(ctx, sqlstr, deletedAt)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*Entity
+	for rows.Next() {
+		e := Entity{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Name, &e.CategoryID, &e.Memo, &e.CreatedAt, &e.UpdatedAt, &e.Version, &e.DeletedAt); err != nil {
 			return nil, logerror(err)
 		}
 		res = append(res, &e)
@@ -211,7 +247,7 @@ func EntitiesByCategoryID(ctx context.Context, db DB, categoryID int) ([]*Entity
 func EntitiesByUserID(ctx context.Context, db DB, userID uuid.UUID) ([]*Entity, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, user_id, name, category_id, memo, created_at, updated_at ` +
+		`id, user_id, name, category_id, memo, created_at, updated_at, version, deleted_at ` +
 		`FROM public.entities ` +
 		`WHERE user_id = $1`
 	// run
@@ -228,7 +264,7 @@ func EntitiesByUserID(ctx context.Context, db DB, userID uuid.UUID) ([]*Entity,
 			_exists: true,
 		}
 		// scan
-		if err := rows.Scan(&e.ID, &e.UserID, &e.Name, &e.CategoryID, &e.Memo, &e.CreatedAt, &e.UpdatedAt); err != nil {
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Name, &e.CategoryID, &e.Memo, &e.CreatedAt, &e.UpdatedAt, &e.Version, &e.DeletedAt); err != nil {
 			return nil, logerror(err)
 		}
 		res = append(res, &e)
@@ -15,7 +15,7 @@ func AliasesByUserID(ctx context.Context, db DB, userID uuid.UUID) (map[string][
 		SELECT ea.id, ea.entity_id, ea.created_at, ea.updated_at, ea.alias
 		FROM entity_aliases ea
 		INNER JOIN entities e ON ea.entity_id = e.id
-		WHERE e.user_id = $1
+		WHERE e.user_id = $1 AND e.deleted_at IS NULL
 		ORDER BY ea.entity_id, ea.created_at
 	`
 	rows, err := db.QueryContext(ctx, sqlstr, userID)
@@ -45,7 +45,7 @@ func CountAliasMatchingName(ctx context.Context, db DB, userID uuid.UUID, name s
 	const sqlstr = `
 		SELECT COUNT(*) FROM entity_aliases ea
 		INNER JOIN entities e ON ea.entity_id = e.id
-		WHERE e.user_id = $1 AND ea.alias = $2
+		WHERE e.user_id = $1 AND e.deleted_at IS NULL AND ea.alias = $2
 	`
 	return queryCount(ctx, db, sqlstr, userID, name)
 }
@@ -55,7 +55,7 @@ func CountAliasMatchingName(ctx context.Context, db DB, userID uuid.UUID, name s
 func CountEntityMatchingAlias(ctx context.Context, db DB, userID uuid.UUID, alias string) (int, error) {
 	const sqlstr = `
 		SELECT COUNT(*) FROM entities
-		WHERE user_id = $1 AND name = $2
+		WHERE user_id = $1 AND deleted_at IS NULL AND name = $2
 	`
 	return queryCount(ctx, db, sqlstr, userID, alias)
 }
@@ -65,7 +65,7 @@ func CountAliasDuplicate(ctx context.Context, db DB, userID uuid.UUID, alias str
 	const sqlstr = `
 		SELECT COUNT(*) FROM entity_aliases ea
 		INNER JOIN entities e ON ea.entity_id = e.id
-		WHERE e.user_id = $1 AND ea.alias = $2
+		WHERE e.user_id = $1 AND e.deleted_at IS NULL AND ea.alias = $2
 	`
 	return queryCount(ctx, db, sqlstr, userID, alias)
 }
@@ -76,7 +76,7 @@ func CountAliasDuplicateExcluding(ctx context.Context, db DB, userID uuid.UUID,
 	const sqlstr = `
 		SELECT COUNT(*) FROM entity_aliases ea
 		INNER JOIN entities e ON ea.entity_id = e.id
-		WHERE e.user_id = $1 AND ea.alias = $2 AND ea.id != $3
+		WHERE e.user_id = $1 AND e.deleted_at IS NULL AND ea.alias = $2 AND ea.id != $3
 	`
 	return queryCount(ctx, db, sqlstr, userID, alias, excludeAliasID)
 }
@@ -91,9 +91,9 @@ func SearchEntitiesByQuery(ctx context.Context, db DB, userID uuid.UUID, query s
 
 	if query == "" {
 		const sqlstr = `
-			SELECT DISTINCT e.id, e.user_id, e.created_at, e.updated_at, e.category_id, e.name, e.memo
+			SELECT DISTINCT e.id, e.user_id, e.created_at, e.updated_at, e.category_id, e.name, e.memo, e.version
 			FROM entities e
-			WHERE e.user_id = $1
+			WHERE e.user_id = $1 AND e.deleted_at IS NULL
 			ORDER BY e.name
 		`
 		rows, err = db.QueryContext(ctx, sqlstr, userID)
@@ -111,10 +111,10 @@ func SearchEntitiesByQuery(ctx context.Context, db DB, userID uuid.UUID, query s
 		}
 
 		const sqlstr = `
-			SELECT DISTINCT e.id, e.user_id, e.created_at, e.updated_at, e.category_id, e.name, e.memo
+			SELECT DISTINCT e.id, e.user_id, e.created_at, e.updated_at, e.category_id, e.name, e.memo, e.version
 			FROM entities e
 			LEFT JOIN entity_aliases ea ON e.id = ea.entity_id
-			WHERE e.user_id = $1
+			WHERE e.user_id = $1 AND e.deleted_at IS NULL
 			AND (e.name ILIKE $2 ESCAPE '\' OR ea.alias ILIKE $2 ESCAPE '\')
 			ORDER BY e.name
 		`
@@ -128,7 +128,7 @@ func SearchEntitiesByQuery(ctx context.Context, db DB, userID uuid.UUID, query s
 	var entities []*Entity
 	for rows.Next() {
 		var e Entity
-		if err := rows.Scan(&e.ID, &e.UserID, &e.CreatedAt, &e.UpdatedAt, &e.CategoryID, &e.Name, &e.Memo); err != nil {
+		if err := rows.Scan(&e.ID, &e.UserID, &e.CreatedAt, &e.UpdatedAt, &e.CategoryID, &e.Name, &e.Memo, &e.Version); err != nil {
 			return nil, fmt.Errorf("failed to scan entity: %w", err)
 		}
 		entities = append(entities, &e)
@@ -138,3 +138,14 @@ func SearchEntitiesByQuery(ctx context.Context, db DB, userID uuid.UUID, query s
 	}
 	return entities, nil
 }
+
+// EntityByIDForUpdate はエンティティを取得し、トランザクションの終了まで行をロックする
+func EntityByIDForUpdate(ctx context.Context, db DB, id uuid.UUID) (*Entity, error) {
+	const sqlstr = `SELECT id, user_id, name, category_id, memo, created_at, updated_at, version, deleted_at
+		FROM entities WHERE id = $1 FOR UPDATE`
+	e := Entity{_exists: true}
+	if err := db.QueryRowContext(ctx, sqlstr, id).Scan(&e.ID, &e.UserID, &e.Name, &e.CategoryID, &e.Memo, &e.CreatedAt, &e.UpdatedAt, &e.Version, &e.DeletedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &e, nil
+}
@@ -21,7 +21,7 @@ func RelatedKeywordsByUserIDAndKeyword(ctx context.Context, db DB, userID string
 		SELECT e.name, ea.alias
 		FROM entities e
 		LEFT JOIN entity_aliases ea ON e.id = ea.entity_id
-		WHERE e.user_id = $1
+		WHERE e.user_id = $1 AND e.deleted_at IS NULL
 		AND (
 			LOWER(e.name) = LOWER($2)
 			OR EXISTS (
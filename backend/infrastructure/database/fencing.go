@@ -0,0 +1,24 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrStaleFencingToken は分散ロックのフェンシングトークンが既に保存済みのものより古い場合のエラー
+// ロックのリースが切れた古い保持者による書き込みを拒否するために使う
+var ErrStaleFencingToken = errors.New("stale fencing token")
+
+// checkFencedWrite は ON CONFLICT DO UPDATE ... WHERE fencing_token <= ... の結果を検証する
+// 更新行が0件の場合はWHERE条件で弾かれた（より新しいトークンで書き込み済み）とみなす
+func checkFencedWrite(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return ErrStaleFencingToken
+	}
+	return nil
+}
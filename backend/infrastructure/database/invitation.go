@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ConsumeInvitation は有効な（取り消されていない・期限内・上限に達していない・発行者が削除されていない）招待コードの使用回数を1つ増やし、IDを返す。
+// 条件を満たす招待コードがない場合はokがfalseになる（同時に使っても上限を超えない）
+func ConsumeInvitation(ctx context.Context, db DB, codeHash string, now int64) (uuid.UUID, bool, error) {
+	const sqlstr = `UPDATE invitations SET use_count = use_count + 1, updated_at = $2 ` +
+		`WHERE code_hash = $1 AND created_by IS NOT NULL AND revoked_at IS NULL AND expires_at > $2 AND use_count < max_uses ` +
+		`RETURNING id`
+	var id uuid.UUID
+	if err := db.QueryRowContext(ctx, sqlstr, codeHash, now).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, false, nil
+		}
+		return uuid.Nil, false, fmt.Errorf("failed to consume invitation: %w", err)
+	}
+	return id, true, nil
+}
+
+// InvitationsByCreatedBy はユーザーが発行した招待コードを新しい順に返す
+func InvitationsByCreatedBy(ctx context.Context, db DB, createdBy uuid.UUID) ([]*Invitation, error) {
+	const sqlstr = `SELECT id, code_hash, created_by, note, max_uses, use_count, expires_at, revoked_at, created_at, updated_at ` +
+		`FROM invitations WHERE created_by = $1 ORDER BY created_at DESC, id DESC`
+	rows, err := db.QueryContext(ctx, sqlstr, createdBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*Invitation
+	for rows.Next() {
+		i := Invitation{_exists: true}
+		if err := rows.Scan(&i.ID, &i.CodeHash, &i.CreatedBy, &i.Note, &i.MaxUses, &i.UseCount, &i.ExpiresAt, &i.RevokedAt, &i.CreatedAt, &i.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan invitation: %w", err)
+		}
+		res = append(res, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate invitations: %w", err)
+	}
+	return res, nil
+}
+
+// InvitationUseWithUser は招待コードでの登録と、登録したユーザーの名前
+type InvitationUseWithUser struct {
+	InvitationID uuid.UUID
+	UserID       uuid.UUID
+	UserName     string // 削除されたユーザーの場合は空
+	UsedAt       int64
+}
+
+// InvitationUsesByCreatedBy はユーザーが発行した招待コードでの登録を登録順に返す
+func InvitationUsesByCreatedBy(ctx context.Context, db DB, createdBy uuid.UUID) ([]InvitationUseWithUser, error) {
+	const sqlstr = `SELECT iu.invitation_id, iu.user_id, COALESCE(u.name, ''), iu.created_at ` +
+		`FROM invitation_uses iu ` +
+		`JOIN invitations i ON i.id = iu.invitation_id ` +
+		`LEFT JOIN users u ON u.id = iu.user_id ` +
+		`WHERE i.created_by = $1 ORDER BY iu.created_at, iu.id`
+	rows, err := db.QueryContext(ctx, sqlstr, createdBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitation uses: %w", err)
+	}
+	defer rows.Close()
+
+	var res []InvitationUseWithUser
+	for rows.Next() {
+		var u InvitationUseWithUser
+		if err := rows.Scan(&u.InvitationID, &u.UserID, &u.UserName, &u.UsedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan invitation use: %w", err)
+		}
+		res = append(res, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate invitation uses: %w", err)
+	}
+	return res, nil
+}
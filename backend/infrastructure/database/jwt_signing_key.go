@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListJwtSigningKeys はJWTの署名鍵を新しい順（署名に使い始める日時の降順）に返す
+func ListJwtSigningKeys(ctx context.Context, db DB) ([]*JwtSigningKey, error) {
+	const sqlstr = `SELECT id, algorithm, private_key, public_key, activates_at, expires_at, created_at ` +
+		`FROM jwt_signing_keys ORDER BY activates_at DESC, id`
+	rows, err := db.QueryContext(ctx, sqlstr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jwt signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*JwtSigningKey
+	for rows.Next() {
+		k := JwtSigningKey{_exists: true}
+		if err := rows.Scan(&k.ID, &k.Algorithm, &k.PrivateKey, &k.PublicKey, &k.ActivatesAt, &k.ExpiresAt, &k.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan jwt signing key: %w", err)
+		}
+		res = append(res, &k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate jwt signing keys: %w", err)
+	}
+	return res, nil
+}
+
+// LockJwtSigningKeys はトランザクションの終了までJWTの署名鍵の変更を直列にする（複数のサーバーが同時にローテーションしないため）
+func LockJwtSigningKeys(ctx context.Context, db DB) error {
+	if _, err := db.ExecContext(ctx, `LOCK TABLE jwt_signing_keys IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return fmt.Errorf("failed to lock jwt signing keys: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredJwtSigningKeys は検証に使わなくなった署名鍵を削除する
+func DeleteExpiredJwtSigningKeys(ctx context.Context, db DB, now int64) error {
+	const sqlstr = `DELETE FROM jwt_signing_keys WHERE expires_at IS NOT NULL AND expires_at <= $1`
+	if _, err := db.ExecContext(ctx, sqlstr, now); err != nil {
+		return fmt.Errorf("failed to delete expired jwt signing keys: %w", err)
+	}
+	return nil
+}
+
+// ExpireJwtSigningKeys は新しい鍵（exceptID）以外の期限のない署名鍵に、検証に使わなくなる日時を設定する
+func ExpireJwtSigningKeys(ctx context.Context, db DB, exceptID string, expiresAt int64) error {
+	const sqlstr = `UPDATE jwt_signing_keys SET expires_at = $2 WHERE expires_at IS NULL AND id <> $1`
+	if _, err := db.ExecContext(ctx, sqlstr, exceptID, expiresAt); err != nil {
+		return fmt.Errorf("failed to expire jwt signing keys: %w", err)
+	}
+	return nil
+}
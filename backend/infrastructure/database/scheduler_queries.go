@@ -23,7 +23,7 @@ func MonthsNeedingMonthlySummary(ctx context.Context, db DB, userID string) ([]Y
 				MAX(d.updated_at) as latest_diary_updated_at,
 				COUNT(*) as diary_count
 			FROM diaries d
-			WHERE d.user_id = $1
+			WHERE d.user_id = $1 AND d.deleted_at IS NULL
 			GROUP BY EXTRACT(YEAR FROM d.date), EXTRACT(MONTH FROM d.date)
 			HAVING COUNT(*) >= 1
 		)
@@ -62,6 +62,7 @@ func DiaryCountInMonth(ctx context.Context, db DB, userID string, year, month in
 	const sqlstr = `
 		SELECT COUNT(*) FROM diaries
 		WHERE user_id = $1
+		AND deleted_at IS NULL
 		AND EXTRACT(YEAR FROM date) = $2
 		AND EXTRACT(MONTH FROM date) = $3
 	`
@@ -70,7 +71,7 @@ func DiaryCountInMonth(ctx context.Context, db DB, userID string, year, month in
 
 // DiaryCountInDateRange は指定ユーザーの指定期間内の日記件数を返す
 func DiaryCountInDateRange(ctx context.Context, db DB, userID string, from, to time.Time) (int, error) {
-	const sqlstr = `SELECT COUNT(*) FROM diaries WHERE user_id = $1 AND date >= $2 AND date <= $3`
+	const sqlstr = `SELECT COUNT(*) FROM diaries WHERE user_id = $1 AND deleted_at IS NULL AND date >= $2 AND date <= $3`
 	return queryCount(ctx, db, sqlstr, userID, from, to)
 }
 
@@ -82,6 +83,7 @@ func DiaryIDsNeedingEmbedding(ctx context.Context, db DB, userID string, targetD
 		FROM diaries d
 		WHERE d.user_id = $1
 		  AND d.date = $2
+		  AND d.deleted_at IS NULL
 		  AND (
 		    NOT EXISTS (SELECT 1 FROM diary_embeddings de WHERE de.diary_id = d.id)
 		    OR (SELECT MAX(de.updated_at) FROM diary_embeddings de WHERE de.diary_id = d.id) < to_timestamp(d.updated_at / 1000.0)
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEventsBefore はユーザーのセキュリティイベントを (created_at, id) の降順に、指定位置より前のものを最大limit件返す。
+// eventTypeが空でない場合はその種類に絞り込む
+func SecurityEventsBefore(ctx context.Context, db DB, userID uuid.UUID, eventType string, beforeCreatedAt int64, beforeID uuid.UUID, limit int) ([]*SecurityEvent, error) {
+	const sqlstr = `SELECT id, user_id, event_type, ip_address, user_agent, detail, suspicious, created_at
+		FROM security_events
+		WHERE user_id = $1 AND ($2 = '' OR event_type = $2) AND (created_at, id) < ($3, $4)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5`
+	rows, err := db.QueryContext(ctx, sqlstr, userID, eventType, beforeCreatedAt, beforeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query security events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	events := make([]*SecurityEvent, 0)
+	for rows.Next() {
+		e := SecurityEvent{_exists: true}
+		if err := rows.Scan(&e.ID, &e.UserID, &e.EventType, &e.IPAddress, &e.UserAgent, &e.Detail, &e.Suspicious, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return events, nil
+}
+
+// KnownLoginClient はユーザーのそれまでのログインの成功に、同じIPアドレス・User-Agentがあるかを返す。
+// hasLoginはそれまでにログインに成功したことがあるか
+func KnownLoginClient(ctx context.Context, db DB, userID uuid.UUID, loginEventType, ipAddress, userAgent string) (hasLogin, knownIP, knownUserAgent bool, err error) {
+	const sqlstr = `SELECT COUNT(*) > 0, COALESCE(BOOL_OR(ip_address = $3), false), COALESCE(BOOL_OR(user_agent = $4), false)
+		FROM security_events
+		WHERE user_id = $1 AND event_type = $2`
+	if err := db.QueryRowContext(ctx, sqlstr, userID, loginEventType, ipAddress, userAgent).Scan(&hasLogin, &knownIP, &knownUserAgent); err != nil {
+		return false, false, false, fmt.Errorf("failed to query known login client: %w", err)
+	}
+	return hasLogin, knownIP, knownUserAgent, nil
+}
@@ -0,0 +1,206 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const tagColumns = `t.id, t.user_id, t.name, t.color, t.created_at, t.updated_at`
+
+// diaryHasAllTagsCondition は日記dが$Nのタグをすべて持つかどうかの条件（%dにプレースホルダ番号を入れる）
+// $NがNULLの場合は絞り込まない
+const diaryHasAllTagsCondition = `($%[1]d::uuid[] IS NULL OR d.id IN (
+	SELECT dt.diary_id FROM diary_tags dt
+	WHERE dt.tag_id = ANY($%[1]d::uuid[])
+	GROUP BY dt.diary_id
+	HAVING COUNT(*) = cardinality($%[1]d::uuid[])
+))`
+
+// TagWithCount はタグとそのタグが付いた日記の件数
+type TagWithCount struct {
+	*Tag
+	DiaryCount int
+}
+
+// uuidArray はUUIDのスライスをuuid[]パラメータに変換する（nilの場合はNULL）
+func uuidArray(ids []uuid.UUID) any {
+	if ids == nil {
+		return nil
+	}
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, id.String())
+	}
+	return pq.Array(strs)
+}
+
+// scanTags はtagColumnsで取得した行をタグのスライスに変換する
+func scanTags(rows *sql.Rows) ([]*Tag, error) {
+	tags := make([]*Tag, 0)
+	for rows.Next() {
+		t := Tag{_exists: true}
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Name, &t.Color, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		tags = append(tags, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return tags, nil
+}
+
+// TagsWithCountByUserID はユーザーのタグを名前順に、付いている日記の件数とともに返す
+func TagsWithCountByUserID(ctx context.Context, db DB, userID uuid.UUID) ([]*TagWithCount, error) {
+	const sqlstr = `SELECT ` + tagColumns + `, COUNT(dt.diary_id)
+		FROM tags t
+		LEFT JOIN diary_tags dt ON dt.tag_id = t.id
+			AND dt.diary_id IN (SELECT id FROM diaries WHERE user_id = $1 AND deleted_at IS NULL)
+		WHERE t.user_id = $1
+		GROUP BY t.id
+		ORDER BY t.name ASC`
+	rows, err := db.QueryContext(ctx, sqlstr, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	tags := make([]*TagWithCount, 0)
+	for rows.Next() {
+		t := TagWithCount{Tag: &Tag{_exists: true}}
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Name, &t.Color, &t.CreatedAt, &t.UpdatedAt, &t.DiaryCount); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		tags = append(tags, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return tags, nil
+}
+
+// TagsByUserID はユーザーのタグを名前順に返す
+func TagsByUserID(ctx context.Context, db DB, userID uuid.UUID) ([]*Tag, error) {
+	const sqlstr = `SELECT ` + tagColumns + ` FROM tags t WHERE t.user_id = $1 ORDER BY t.name ASC`
+	rows, err := db.QueryContext(ctx, sqlstr, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanTags(rows)
+}
+
+// TagsByUserIDAndIDs はidsのうちユーザーが持つタグを返す（他ユーザーのタグ・存在しないIDは含まない）
+func TagsByUserIDAndIDs(ctx context.Context, db DB, userID uuid.UUID, ids []uuid.UUID) ([]*Tag, error) {
+	const sqlstr = `SELECT ` + tagColumns + ` FROM tags t WHERE t.user_id = $1 AND t.id = ANY($2::uuid[]) ORDER BY t.name ASC`
+	rows, err := db.QueryContext(ctx, sqlstr, userID, uuidArray(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags by ids: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanTags(rows)
+}
+
+// tagsByDiaryIDs はtable（diary_tags/diary_tag_suggestions）で日記に紐付くタグを日記IDごとに名前順で返す
+func tagsByDiaryIDs(ctx context.Context, db DB, table string, diaryIDs []uuid.UUID) (map[uuid.UUID][]*Tag, error) {
+	result := make(map[uuid.UUID][]*Tag)
+	if len(diaryIDs) == 0 {
+		return result, nil
+	}
+	sqlstr := `SELECT r.diary_id, ` + tagColumns + `
+		FROM ` + table + ` r
+		JOIN tags t ON t.id = r.tag_id
+		WHERE r.diary_id = ANY($1::uuid[])
+		ORDER BY t.name ASC`
+	rows, err := db.QueryContext(ctx, sqlstr, uuidArray(diaryIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var diaryID uuid.UUID
+		t := Tag{_exists: true}
+		if err := rows.Scan(&diaryID, &t.ID, &t.UserID, &t.Name, &t.Color, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		result[diaryID] = append(result[diaryID], &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return result, nil
+}
+
+// TagsByDiaryIDs は日記に付いているタグを日記IDごとに返す
+func TagsByDiaryIDs(ctx context.Context, db DB, diaryIDs []uuid.UUID) (map[uuid.UUID][]*Tag, error) {
+	return tagsByDiaryIDs(ctx, db, "diary_tags", diaryIDs)
+}
+
+// SuggestedTagsByDiaryIDs はLLMが日記に提案したタグを日記IDごとに返す
+func SuggestedTagsByDiaryIDs(ctx context.Context, db DB, diaryIDs []uuid.UUID) (map[uuid.UUID][]*Tag, error) {
+	return tagsByDiaryIDs(ctx, db, "diary_tag_suggestions", diaryIDs)
+}
+
+// replaceDiaryTagRelations はtableの日記diaryIDの関連をtagIDsで置き換える
+func replaceDiaryTagRelations(ctx context.Context, db DB, table string, diaryID uuid.UUID, tagIDs []uuid.UUID, now int64) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM `+table+` WHERE diary_id = $1`, diaryID); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", table, err)
+	}
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	sqlstr := `INSERT INTO ` + table + ` (diary_id, tag_id, created_at)
+		SELECT $1, tag_id, $3 FROM unnest($2::uuid[]) AS tag_id
+		ON CONFLICT DO NOTHING`
+	if _, err := db.ExecContext(ctx, sqlstr, diaryID, uuidArray(tagIDs), now); err != nil {
+		return fmt.Errorf("failed to insert %s: %w", table, err)
+	}
+	return nil
+}
+
+// ReplaceDiaryTags は日記のタグをtagIDsで置き換える（タグの所有者の確認は呼び出し側で行う）
+func ReplaceDiaryTags(ctx context.Context, db DB, diaryID uuid.UUID, tagIDs []uuid.UUID, now int64) error {
+	return replaceDiaryTagRelations(ctx, db, "diary_tags", diaryID, tagIDs, now)
+}
+
+// ReplaceDiaryTagSuggestions は日記へのタグの提案をtagIDsで置き換える
+func ReplaceDiaryTagSuggestions(ctx context.Context, db DB, diaryID uuid.UUID, tagIDs []uuid.UUID, now int64) error {
+	return replaceDiaryTagRelations(ctx, db, "diary_tag_suggestions", diaryID, tagIDs, now)
+}
+
+// MergeTags はsourceIDsのタグが付いた日記・提案をtargetIDに付け替え、sourceIDsのタグを削除する
+// トランザクション内で呼び出すこと
+func MergeTags(ctx context.Context, db DB, sourceIDs []uuid.UUID, targetID uuid.UUID, now int64) error {
+	for _, table := range []string{"diary_tags", "diary_tag_suggestions"} {
+		sqlstr := `INSERT INTO ` + table + ` (diary_id, tag_id, created_at)
+			SELECT DISTINCT diary_id, $2::uuid, $3::bigint FROM ` + table + ` WHERE tag_id = ANY($1::uuid[])
+			ON CONFLICT DO NOTHING`
+		if _, err := db.ExecContext(ctx, sqlstr, uuidArray(sourceIDs), targetID, now); err != nil {
+			return fmt.Errorf("failed to merge %s: %w", table, err)
+		}
+	}
+	// 関連はON DELETE CASCADEで削除される
+	if _, err := db.ExecContext(ctx, `DELETE FROM tags WHERE id = ANY($1::uuid[])`, uuidArray(sourceIDs)); err != nil {
+		return fmt.Errorf("failed to delete merged tags: %w", err)
+	}
+	return nil
+}
+
+// DiaryCountByTagID はタグが付いた日記の件数を返す
+func DiaryCountByTagID(ctx context.Context, db DB, tagID uuid.UUID) (int, error) {
+	const sqlstr = `SELECT COUNT(*) FROM diary_tags dt
+		JOIN diaries d ON d.id = dt.diary_id
+		WHERE dt.tag_id = $1 AND d.deleted_at IS NULL`
+	return queryCount(ctx, db, sqlstr, tagID)
+}
+
+// DiaryIDsByUserIDAndAllTags はtagIDsのタグをすべて持つユーザーの日記のIDを返す
+func DiaryIDsByUserIDAndAllTags(ctx context.Context, db DB, userID string, tagIDs []uuid.UUID) ([]string, error) {
+	sqlstr := `SELECT d.id FROM diaries d WHERE d.user_id = $1 AND d.deleted_at IS NULL AND ` + fmt.Sprintf(diaryHasAllTagsCondition, 2)
+	return queryStringSlice(ctx, db, sqlstr, userID, uuidArray(tagIDs))
+}
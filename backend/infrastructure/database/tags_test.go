@@ -0,0 +1,105 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+)
+
+// insertTestTag はテスト用のタグを挿入する
+func insertTestTag(t *testing.T, db database.DB, userID uuid.UUID, name string) *database.Tag {
+	t.Helper()
+	tag := &database.Tag{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Color:     "#9ca3af",
+		CreatedAt: 1700000000,
+		UpdatedAt: 1700000000,
+	}
+	if err := tag.Insert(context.Background(), db); err != nil {
+		t.Fatalf("タグの挿入に失敗: %v", err)
+	}
+	return tag
+}
+
+func TestTags(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "tags@example.com", "TagsUser")
+	ctx := context.Background()
+
+	insertTestDiary(t, db, userID, "5月1日の日記", "2024-05-01")
+	insertTestDiary(t, db, userID, "5月2日の日記", "2024-05-02")
+	diaries, err := database.DiariesByUserIDAndDateRange(ctx, db, userID.String(), 2024, 5, 2024, 5)
+	if err != nil || len(diaries) != 2 {
+		t.Fatalf("日記の取得に失敗: %v", err)
+	}
+	first, second := diaries[0], diaries[1]
+
+	travel := insertTestTag(t, db, userID, "旅行")
+	food := insertTestTag(t, db, userID, "ごはん")
+	walk := insertTestTag(t, db, userID, "散歩")
+
+	if err := database.ReplaceDiaryTags(ctx, db, first.ID, []uuid.UUID{travel.ID, food.ID}, 1700000000); err != nil {
+		t.Fatalf("タグの設定に失敗: %v", err)
+	}
+	if err := database.ReplaceDiaryTags(ctx, db, second.ID, []uuid.UUID{walk.ID}, 1700000000); err != nil {
+		t.Fatalf("タグの設定に失敗: %v", err)
+	}
+
+	t.Run("正常系: すべてのタグを持つ日記のみ返す", func(t *testing.T) {
+		ids, err := database.DiaryIDsByUserIDAndAllTags(ctx, db, userID.String(), []uuid.UUID{travel.ID, food.ID})
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(ids) != 1 || ids[0] != first.ID.String() {
+			t.Errorf("取得結果が不正: %v", ids)
+		}
+	})
+
+	t.Run("正常系: 日記ごとのタグを名前順に返す", func(t *testing.T) {
+		tags, err := database.TagsByDiaryIDs(ctx, db, []uuid.UUID{first.ID, second.ID})
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(tags[first.ID]) != 2 || tags[first.ID][0].Name != "ごはん" {
+			t.Errorf("1日目のタグが不正: %v", tags[first.ID])
+		}
+		if len(tags[second.ID]) != 1 {
+			t.Errorf("2日目のタグが不正: %v", tags[second.ID])
+		}
+	})
+
+	t.Run("正常系: 統合すると元のタグは削除され日記は統合先に付け替わる", func(t *testing.T) {
+		if err := database.ReplaceDiaryTagSuggestions(ctx, db, second.ID, []uuid.UUID{food.ID}, 1700000000); err != nil {
+			t.Fatalf("提案の保存に失敗: %v", err)
+		}
+		if err := database.MergeTags(ctx, db, []uuid.UUID{walk.ID, food.ID}, travel.ID, 1700000000); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		count, err := database.DiaryCountByTagID(ctx, db, travel.ID)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if count != 2 {
+			t.Errorf("統合後の件数: 期待 2, 実際 %d", count)
+		}
+		tags, err := database.TagsWithCountByUserID(ctx, db, userID)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(tags) != 1 || tags[0].ID != travel.ID || tags[0].DiaryCount != 2 {
+			t.Errorf("統合後のタグが不正: %v", tags)
+		}
+		suggestions, err := database.SuggestedTagsByDiaryIDs(ctx, db, []uuid.UUID{second.ID})
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(suggestions[second.ID]) != 1 || suggestions[second.ID][0].ID != travel.ID {
+			t.Errorf("提案が統合先に付け替わっていない: %v", suggestions[second.ID])
+		}
+	})
+}
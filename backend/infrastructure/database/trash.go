@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Trashed は日記がゴミ箱にあるかどうかを返す
+func (d *Diary) Trashed() bool {
+	return d.DeletedAt.Valid
+}
+
+// Trashed はエンティティがゴミ箱にあるかどうかを返す
+func (e *Entity) Trashed() bool {
+	return e.DeletedAt.Valid
+}
+
+// ActiveDiaryByID はゴミ箱にない日記を取得する（ゴミ箱にある場合は存在しない日記と同じくsql.ErrNoRowsを返す）
+func ActiveDiaryByID(ctx context.Context, db DB, id uuid.UUID) (*Diary, error) {
+	d, err := DiaryByID(ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.Trashed() {
+		return nil, sql.ErrNoRows
+	}
+	return d, nil
+}
+
+// ActiveEntityByID はゴミ箱にないエンティティを取得する（ゴミ箱にある場合は存在しないエンティティと同じくsql.ErrNoRowsを返す）
+func ActiveEntityByID(ctx context.Context, db DB, id uuid.UUID) (*Entity, error) {
+	e, err := EntityByID(ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Trashed() {
+		return nil, sql.ErrNoRows
+	}
+	return e, nil
+}
+
+// ActiveEntitiesByUserID はユーザーのゴミ箱にないエンティティを返す
+func ActiveEntitiesByUserID(ctx context.Context, db DB, userID uuid.UUID) ([]*Entity, error) {
+	const sqlstr = `SELECT ` + entityColumns + ` FROM entities
+		WHERE user_id = $1 AND deleted_at IS NULL`
+	return queryEntities(ctx, db, sqlstr, userID)
+}
+
+// TrashDiary は日記をゴミ箱に移動し、差分同期でクライアントに削除を伝えるための記録を変更番号seqで残す
+// 日記の行と関連（添付ファイル・ハイライト・埋め込みなど）は復元できるよう完全に削除するまで残す
+func TrashDiary(ctx context.Context, db DB, d *Diary, seq, now int64) error {
+	d.DeletedAt = sql.NullInt64{Int64: now, Valid: true}
+	d.ChangeSeq = seq
+	if err := d.Update(ctx, db); err != nil {
+		return err
+	}
+	tombstone := &DiaryTombstone{
+		DiaryID:   d.ID,
+		UserID:    d.UserID,
+		Date:      d.Date,
+		ChangeSeq: seq,
+		DeletedAt: now,
+	}
+	// 復元した後に再びゴミ箱に移動した場合は、以前の削除の記録を上書きする
+	return tombstone.Upsert(ctx, db)
+}
+
+// RestoreDiary はゴミ箱の日記を元に戻し、変更番号seqを付けて差分同期の対象にする
+// 削除の記録より新しい変更番号で日記が返るため、削除を受け取ったクライアントにも日記が戻る
+func RestoreDiary(ctx context.Context, db DB, d *Diary, seq int64) error {
+	d.DeletedAt = sql.NullInt64{}
+	d.ChangeSeq = seq
+	if err := d.Update(ctx, db); err != nil {
+		return err
+	}
+	const sqlstr = `DELETE FROM diary_tombstones WHERE diary_id = $1`
+	if _, err := db.ExecContext(ctx, sqlstr, d.ID); err != nil {
+		return fmt.Errorf("failed to delete diary tombstone: %w", err)
+	}
+	return nil
+}
+
+// TrashedDiariesByUserID はユーザーのゴミ箱の日記を、ゴミ箱に移動した日時の新しい順に返す
+func TrashedDiariesByUserID(ctx context.Context, db DB, userID uuid.UUID) ([]*Diary, error) {
+	const sqlstr = `SELECT ` + diaryColumns + ` FROM diaries
+		WHERE user_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id ASC`
+	rows, err := db.QueryContext(ctx, sqlstr, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trashed diaries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanDiaries(rows)
+}
+
+// TrashedEntitiesByUserID はユーザーのゴミ箱のエンティティを、ゴミ箱に移動した日時の新しい順に返す
+func TrashedEntitiesByUserID(ctx context.Context, db DB, userID uuid.UUID) ([]*Entity, error) {
+	const sqlstr = `SELECT ` + entityColumns + ` FROM entities
+		WHERE user_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id ASC`
+	return queryEntities(ctx, db, sqlstr, userID)
+}
+
+// entityColumns は手書きクエリでエンティティを取得する際のSELECT句（queryEntitiesの順序と一致させる）
+const entityColumns = `id, user_id, name, category_id, memo, created_at, updated_at, version, deleted_at`
+
+// queryEntities はentityColumnsで取得するクエリを実行してエンティティのスライスを返す
+func queryEntities(ctx context.Context, db DB, sqlstr string, args ...any) ([]*Entity, error) {
+	rows, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query entities: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	entities := make([]*Entity, 0)
+	for rows.Next() {
+		e := Entity{_exists: true}
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Name, &e.CategoryID, &e.Memo, &e.CreatedAt, &e.UpdatedAt, &e.Version, &e.DeletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		entities = append(entities, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return entities, nil
+}
+
+// PurgedTrash はゴミ箱から完全に削除した件数と、ストレージから実体を削除すべき添付ファイル
+type PurgedTrash struct {
+	Diaries     int64
+	Entities    int64
+	Attachments []*DiaryAttachment
+}
+
+// PurgeExpiredTrash は全ユーザーのゴミ箱のうち、before（UNIX秒）より前にゴミ箱に移動した日記とエンティティを完全に削除する
+// トランザクション内で呼び出すこと
+func PurgeExpiredTrash(ctx context.Context, db DB, before int64) (*PurgedTrash, error) {
+	return purgeTrash(ctx, db, `deleted_at < $1`, before)
+}
+
+// EmptyTrashByUserID はユーザーのゴミ箱の日記とエンティティをすべて完全に削除する
+// トランザクション内で呼び出すこと
+func EmptyTrashByUserID(ctx context.Context, db DB, userID uuid.UUID) (*PurgedTrash, error) {
+	return purgeTrash(ctx, db, `user_id = $1`, userID)
+}
+
+// purgeTrash はゴミ箱の日記とエンティティのうちcondに一致するものを完全に削除する
+// ハイライト・埋め込み・タグ・添付ファイルの行はCASCADEで削除される。削除の記録（diary_tombstones）は残す
+func purgeTrash(ctx context.Context, db DB, cond string, arg any) (*PurgedTrash, error) {
+	// 同時に復元された日記を削除しないよう、対象の行をロックしてから条件を確かめ直す
+	diaryIDs, err := queryStringSlice(ctx, db, `SELECT id FROM diaries WHERE deleted_at IS NOT NULL AND `+cond+` FOR UPDATE`, arg)
+	if err != nil {
+		return nil, err
+	}
+	purged := &PurgedTrash{Attachments: make([]*DiaryAttachment, 0)}
+	if len(diaryIDs) > 0 {
+		// 添付ファイルの行はCASCADEで消えるため、ストレージ上のキーを先に取得しておく
+		rows, err := db.QueryContext(ctx, `SELECT `+diaryAttachmentColumns+`
+			FROM diary_attachments a WHERE a.diary_id = ANY($1::uuid[])`, pq.Array(diaryIDs))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query trashed diary attachments: %w", err)
+		}
+		purged.Attachments, err = scanDiaryAttachments(rows)
+		_ = rows.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := db.ExecContext(ctx, `DELETE FROM diaries WHERE id = ANY($1::uuid[])`, pq.Array(diaryIDs))
+		if err != nil {
+			return nil, fmt.Errorf("failed to purge trashed diaries: %w", err)
+		}
+		if purged.Diaries, err = res.RowsAffected(); err != nil {
+			return nil, fmt.Errorf("failed to get purged diary count: %w", err)
+		}
+	}
+
+	res, err := db.ExecContext(ctx, `DELETE FROM entities WHERE deleted_at IS NOT NULL AND `+cond, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge trashed entities: %w", err)
+	}
+	if purged.Entities, err = res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to get purged entity count: %w", err)
+	}
+	return purged, nil
+}
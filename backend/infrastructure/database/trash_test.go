@@ -0,0 +1,77 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+)
+
+func TestPurgeExpiredTrash(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	userID := testutil.CreateTestUser(t, db, "purge-trash@example.com", "PurgeTrashUser")
+
+	now := time.Now().Unix()
+	insertDiary := func(day int, deletedAt sql.NullInt64) *database.Diary {
+		d := &database.Diary{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Content:   "日記",
+			Date:      time.Date(2024, 8, day, 0, 0, 0, 0, time.UTC),
+			CreatedAt: now,
+			UpdatedAt: now,
+			Version:   1,
+			DeletedAt: deletedAt,
+		}
+		if err := d.Insert(ctx, db); err != nil {
+			t.Fatalf("日記の作成に失敗: %v", err)
+		}
+		return d
+	}
+	active := insertDiary(1, sql.NullInt64{})
+	expired := insertDiary(2, sql.NullInt64{Int64: now - 100, Valid: true})
+	recent := insertDiary(3, sql.NullInt64{Int64: now, Valid: true})
+
+	t.Run("正常系: 期限を過ぎたゴミ箱の日記だけを削除する", func(t *testing.T) {
+		purged, err := database.PurgeExpiredTrash(ctx, db, now-10)
+		if err != nil {
+			t.Fatalf("削除に失敗: %v", err)
+		}
+		if purged.Diaries != 1 {
+			t.Errorf("削除件数が正しくない: %d", purged.Diaries)
+		}
+		if _, err := database.DiaryByID(ctx, db, expired.ID); err != sql.ErrNoRows {
+			t.Errorf("期限を過ぎた日記が残っている: %v", err)
+		}
+		for _, d := range []*database.Diary{active, recent} {
+			if _, err := database.DiaryByID(ctx, db, d.ID); err != nil {
+				t.Errorf("削除対象外の日記が消えた: %v", err)
+			}
+		}
+	})
+
+	t.Run("正常系: ゴミ箱を空にするとゴミ箱の日記だけを削除する", func(t *testing.T) {
+		purged, err := database.EmptyTrashByUserID(ctx, db, userID)
+		if err != nil {
+			t.Fatalf("削除に失敗: %v", err)
+		}
+		if purged.Diaries != 1 {
+			t.Errorf("削除件数が正しくない: %d", purged.Diaries)
+		}
+		if _, err := database.ActiveDiaryByID(ctx, db, active.ID); err != nil {
+			t.Errorf("ゴミ箱にない日記が消えた: %v", err)
+		}
+		trashed, err := database.TrashedDiariesByUserID(ctx, db, userID)
+		if err != nil {
+			t.Fatalf("ゴミ箱の取得に失敗: %v", err)
+		}
+		if len(trashed) != 0 {
+			t.Errorf("ゴミ箱に日記が残っている: %d件", len(trashed))
+		}
+	})
+}
@@ -4,18 +4,25 @@ package database
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
 )
 
 // User represents a row from 'public.users'.
 type User struct {
-	ID        uuid.UUID `json:"id"`         // id
-	Email     string    `json:"email"`      // email
-	Name      string    `json:"name"`       // name
-	AuthType  int16     `json:"auth_type"`  // auth_type
-	CreatedAt int64     `json:"created_at"` // created_at
-	UpdatedAt int64     `json:"updated_at"` // updated_at
+	ID                  uuid.UUID     `json:"id"`                    // id
+	Email               string        `json:"email"`                 // email
+	Name                string        `json:"name"`                  // name
+	AuthType            int16         `json:"auth_type"`             // auth_type
+	CreatedAt           int64         `json:"created_at"`            // created_at
+	UpdatedAt           int64         `json:"updated_at"`            // updated_at
+	Role                int16         `json:"role"`                  // role
+	DisabledAt          sql.NullInt64 `json:"disabled_at"`           // disabled_at
+	SessionsRevokedAt   sql.NullInt64 `json:"sessions_revoked_at"`   // sessions_revoked_at
+	EmailVerifiedAt     sql.NullInt64 `json:"email_verified_at"`     // email_verified_at
+	DeletionRequestedAt sql.NullInt64 `json:"deletion_requested_at"` // deletion_requested_at
+	DeletionScheduledAt sql.NullInt64 `json:"deletion_scheduled_at"` // deletion_scheduled_at
 	// xo fields
 	_exists, _deleted bool
 }
@@ -41,13 +48,13 @@ func (u *User) Insert(ctx context.Context, db DB) error {
 	}
 	// insert (manual)
 	const sqlstr = `INSERT INTO public.users (` +
-		`id, email, name, auth_type, created_at, updated_at` +
+		`id, email, name, auth_type, created_at, updated_at, role, disabled_at, sessions_revoked_at, email_verified_at, deletion_requested_at, deletion_scheduled_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12` +
 		`)`
 	// run
-	logf(sqlstr, u.ID, u.Email, u.Name, u.AuthType, u.CreatedAt, u.UpdatedAt)
-	if _, err := db.ExecContext(ctx, sqlstr, u.ID, u.Email, u.Name, u.AuthType, u.CreatedAt, u.UpdatedAt); err != nil {
+	logf(sqlstr, u.ID, u.Email, u.Name, u.AuthType, u.CreatedAt, u.UpdatedAt, u.Role, u.DisabledAt, u.SessionsRevokedAt, u.EmailVerifiedAt, u.DeletionRequestedAt, u.DeletionScheduledAt)
+	if _, err := db.ExecContext(ctx, sqlstr, u.ID, u.Email, u.Name, u.AuthType, u.CreatedAt, u.UpdatedAt, u.Role, u.DisabledAt, u.SessionsRevokedAt, u.EmailVerifiedAt, u.DeletionRequestedAt, u.DeletionScheduledAt); err != nil {
 		return logerror(err)
 	}
 	// set exists
@@ -65,11 +72,11 @@ func (u *User) Update(ctx context.Context, db DB) error {
 	}
 	// update with composite primary key
 	const sqlstr = `UPDATE public.users SET ` +
-		`email = $1, name = $2, auth_type = $3, created_at = $4, updated_at = $5 ` +
-		`WHERE id = $6`
+		`email = $1, name = $2, auth_type = $3, created_at = $4, updated_at = $5, role = $6, disabled_at = $7, sessions_revoked_at = $8, email_verified_at = $9, deletion_requested_at = $10, deletion_scheduled_at = $11 ` +
+		`WHERE id = $12`
 	// run
-	logf(sqlstr, u.Email, u.Name, u.AuthType, u.CreatedAt, u.UpdatedAt, u.ID)
-	if _, err := db.ExecContext(ctx, sqlstr, u.Email, u.Name, u.AuthType, u.CreatedAt, u.UpdatedAt, u.ID); err != nil {
+	logf(sqlstr, u.Email, u.Name, u.AuthType, u.CreatedAt, u.UpdatedAt, u.Role, u.DisabledAt, u.SessionsRevokedAt, u.EmailVerifiedAt, u.DeletionRequestedAt, u.DeletionScheduledAt, u.ID)
+	if _, err := db.ExecContext(ctx, sqlstr, u.Email, u.Name, u.AuthType, u.CreatedAt, u.UpdatedAt, u.Role, u.DisabledAt, u.SessionsRevokedAt, u.EmailVerifiedAt, u.DeletionRequestedAt, u.DeletionScheduledAt, u.ID); err != nil {
 		return logerror(err)
 	}
 	return nil
@@ -91,16 +98,16 @@ func (u *User) Upsert(ctx context.Context, db DB) error {
 	}
 	// upsert
 	const sqlstr = `INSERT INTO public.users (` +
-		`id, email, name, auth_type, created_at, updated_at` +
+		`id, email, name, auth_type, created_at, updated_at, role, disabled_at, sessions_revoked_at, email_verified_at, deletion_requested_at, deletion_scheduled_at` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12` +
 		`)` +
 		` ON CONFLICT (id) DO ` +
 		`UPDATE SET ` +
-		`email = EXCLUDED.email, name = EXCLUDED.name, auth_type = EXCLUDED.auth_type, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at `
+		`email = EXCLUDED.email, name = EXCLUDED.name, auth_type = EXCLUDED.auth_type, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at, role = EXCLUDED.role, disabled_at = EXCLUDED.disabled_at, sessions_revoked_at = EXCLUDED.sessions_revoked_at, email_verified_at = EXCLUDED.email_verified_at, deletion_requested_at = EXCLUDED.deletion_requested_at, deletion_scheduled_at = EXCLUDED.deletion_scheduled_at `
 	// run
-	logf(sqlstr, u.ID, u.Email, u.Name, u.AuthType, u.CreatedAt, u.UpdatedAt)
-	if _, err := db.ExecContext(ctx, sqlstr, u.ID, u.Email, u.Name, u.AuthType, u.CreatedAt, u.UpdatedAt); err != nil {
+	logf(sqlstr, u.ID, u.Email, u.Name, u.AuthType, u.CreatedAt, u.UpdatedAt, u.Role, u.DisabledAt, u.SessionsRevokedAt, u.EmailVerifiedAt, u.DeletionRequestedAt, u.DeletionScheduledAt)
+	if _, err := db.ExecContext(ctx, sqlstr, u.ID, u.Email, u.Name, u.AuthType, u.CreatedAt, u.UpdatedAt, u.Role, u.DisabledAt, u.SessionsRevokedAt, u.EmailVerifiedAt, u.DeletionRequestedAt, u.DeletionScheduledAt); err != nil {
 		return logerror(err)
 	}
 	// set exists
@@ -135,7 +142,7 @@ func (u *User) Delete(ctx context.Context, db DB) error {
 func UsersByEmail(ctx context.Context, db DB, email string) ([]*User, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, email, name, auth_type, created_at, updated_at ` +
+		`id, email, name, auth_type, created_at, updated_at, role, disabled_at, sessions_revoked_at, email_verified_at, deletion_requested_at, deletion_scheduled_at ` +
 		`FROM public.users ` +
 		`WHERE email = $1`
 	// run
@@ -152,7 +159,7 @@ func UsersByEmail(ctx context.Context, db DB, email string) ([]*User, error) {
 			_exists: true,
 		}
 		// scan
-		if err := rows.Scan(&u.ID, &u.Email, &u.Name, &u.AuthType, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		if err := rows.Scan(&u.ID, &u.Email, &u.Name, &u.AuthType, &u.CreatedAt, &u.UpdatedAt, &u.Role, &u.DisabledAt, &u.SessionsRevokedAt, &u.EmailVerifiedAt, &u.DeletionRequestedAt, &u.DeletionScheduledAt); err != nil {
 			return nil, logerror(err)
 		}
 		res = append(res, &u)
@@ -169,7 +176,7 @@ func UsersByEmail(ctx context.Context, db DB, email string) ([]*User, error) {
 func UserByEmail(ctx context.Context, db DB, email string) (*User, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, email, name, auth_type, created_at, updated_at ` +
+		`id, email, name, auth_type, created_at, updated_at, role, disabled_at, sessions_revoked_at, email_verified_at, deletion_requested_at, deletion_scheduled_at ` +
 		`FROM public.users ` +
 		`WHERE email = $1`
 	// run
@@ -177,7 +184,7 @@ func UserByEmail(ctx context.Context, db DB, email string) (*User, error) {
 	u := User{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, email).Scan(&u.ID, &u.Email, &u.Name, &u.AuthType, &u.CreatedAt, &u.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, email).Scan(&u.ID, &u.Email, &u.Name, &u.AuthType, &u.CreatedAt, &u.UpdatedAt, &u.Role, &u.DisabledAt, &u.SessionsRevokedAt, &u.EmailVerifiedAt, &u.DeletionRequestedAt, &u.DeletionScheduledAt); err != nil {
 		return nil, logerror(err)
 	}
 	return &u, nil
@@ -189,7 +196,7 @@ func UserByEmail(ctx context.Context, db DB, email string) (*User, error) {
 func UserByID(ctx context.Context, db DB, id uuid.UUID) (*User, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`id, email, name, auth_type, created_at, updated_at ` +
+		`id, email, name, auth_type, created_at, updated_at, role, disabled_at, sessions_revoked_at, email_verified_at, deletion_requested_at, deletion_scheduled_at ` +
 		`FROM public.users ` +
 		`WHERE id = $1`
 	// run
@@ -197,7 +204,7 @@ func UserByID(ctx context.Context, db DB, id uuid.UUID) (*User, error) {
 	u := User{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, id).Scan(&u.ID, &u.Email, &u.Name, &u.AuthType, &u.CreatedAt, &u.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, id).Scan(&u.ID, &u.Email, &u.Name, &u.AuthType, &u.CreatedAt, &u.UpdatedAt, &u.Role, &u.DisabledAt, &u.SessionsRevokedAt, &u.EmailVerifiedAt, &u.DeletionRequestedAt, &u.DeletionScheduledAt); err != nil {
 		return nil, logerror(err)
 	}
 	return &u, nil
@@ -16,3 +16,17 @@ func UpdateUserAPIKeyLastUsed(ctx context.Context, db DB, id uuid.UUID, lastUsed
 	}
 	return nil
 }
+
+// ActiveUserAPIKeyByKeyHash はハッシュが一致するAPIキーのうち、持ち主のユーザーが無効にされておらず削除の予定もないものを返す。
+// 無効なユーザーのキーは存在しないキーと同じく sql.ErrNoRows を返す（有効期限は呼び出し側で確認する）。
+func ActiveUserAPIKeyByKeyHash(ctx context.Context, db DB, keyHash string) (*UserAPIKey, error) {
+	const sqlstr = `SELECT k.id, k.user_id, k.name, k.key_hash, k.key_prefix, k.expires_at, k.last_used_at, k.created_at, k.updated_at
+		FROM user_api_keys k
+		JOIN users u ON u.id = k.user_id
+		WHERE k.key_hash = $1 AND u.disabled_at IS NULL AND u.deletion_scheduled_at IS NULL`
+	k := UserAPIKey{_exists: true}
+	if err := db.QueryRowContext(ctx, sqlstr, keyHash).Scan(&k.ID, &k.UserID, &k.Name, &k.KeyHash, &k.KeyPrefix, &k.ExpiresAt, &k.LastUsedAt, &k.CreatedAt, &k.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to get active api key: %w", err)
+	}
+	return &k, nil
+}
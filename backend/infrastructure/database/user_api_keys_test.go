@@ -3,6 +3,7 @@ package database_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
 
 	"github.com/google/uuid"
@@ -75,3 +76,33 @@ func TestUpdateUserAPIKeyLastUsed_DBError(t *testing.T) {
 		}
 	})
 }
+
+func TestActiveUserAPIKeyByKeyHash(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "active-api-key@example.com", "ActiveAPIKeyUser")
+	ctx := context.Background()
+	keyID := insertTestAPIKey(t, db, userID)
+	key, err := database.UserAPIKeyByID(ctx, db, keyID)
+	if err != nil {
+		t.Fatalf("APIキーの取得に失敗: %v", err)
+	}
+
+	t.Run("正常系: 有効なユーザーのキーを返す", func(t *testing.T) {
+		got, err := database.ActiveUserAPIKeyByKeyHash(ctx, db, key.KeyHash)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if got.ID != keyID || got.ExpiresAt != key.ExpiresAt {
+			t.Errorf("APIキー: 期待 %+v, 実際 %+v", key, got)
+		}
+	})
+
+	t.Run("異常系: 無効にされたユーザーのキーは存在しないキーと同じく扱う", func(t *testing.T) {
+		if _, err := db.Exec("UPDATE users SET disabled_at = 1700000000 WHERE id = $1", userID); err != nil {
+			t.Fatalf("ユーザーの更新に失敗: %v", err)
+		}
+		if _, err := database.ActiveUserAPIKeyByKeyHash(ctx, db, key.KeyHash); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("sql.ErrNoRows を期待したが %v が返った", err)
+		}
+	})
+}
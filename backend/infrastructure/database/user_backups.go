@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// 定期バックアップの最後の実行結果（user_backup_settings.last_status）
+const (
+	BackupStatusSucceeded = "succeeded"
+	BackupStatusFailed    = "failed"
+)
+
+const userBackupSettingColumns = `user_id, enabled, destination, prefix, interval_hours, retention_count, ` +
+	`s3_endpoint, s3_region, s3_bucket, s3_access_key_id, s3_secret_access_key, s3_use_path_style, ` +
+	`webdav_url, webdav_username, webdav_password, encryption_public_key, encryption_salt, ` +
+	`next_run_at, last_run_at, last_status, last_error, last_size_bytes, created_at, updated_at`
+
+// ClaimDueBackupSettings は実行予定日時（now, UNIX秒）を過ぎた有効な設定を最大limit件取得し、次回の実行予定日時を進める
+// 複数のスケジューラーが同時に実行しても同じ設定を二重に実行しないよう、取得と更新を1つのクエリで行う
+func ClaimDueBackupSettings(ctx context.Context, db DB, now int64, limit int) ([]*UserBackupSetting, error) {
+	const sqlstr = `UPDATE user_backup_settings SET next_run_at = $1::bigint + interval_hours * 3600
+		WHERE user_id IN (
+			SELECT user_id FROM user_backup_settings
+			WHERE enabled AND next_run_at <= $1::bigint
+			ORDER BY next_run_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + userBackupSettingColumns
+	rows, err := db.QueryContext(ctx, sqlstr, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim backup settings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	settings := make([]*UserBackupSetting, 0)
+	for rows.Next() {
+		s := UserBackupSetting{_exists: true}
+		if err := rows.Scan(&s.UserID, &s.Enabled, &s.Destination, &s.Prefix, &s.IntervalHours, &s.RetentionCount,
+			&s.S3Endpoint, &s.S3Region, &s.S3Bucket, &s.S3AccessKeyID, &s.S3SecretAccessKey, &s.S3UsePathStyle,
+			&s.WebdavURL, &s.WebdavUsername, &s.WebdavPassword, &s.EncryptionPublicKey, &s.EncryptionSalt,
+			&s.NextRunAt, &s.LastRunAt, &s.LastStatus, &s.LastError, &s.LastSizeBytes, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		settings = append(settings, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return settings, nil
+}
+
+// RecordBackupResult はバックアップの実行結果を記録する
+// 設定の他の列は更新しないため、実行中にユーザーが設定を変更しても上書きしない（サイズは成功した場合のみ更新する）
+func RecordBackupResult(ctx context.Context, db DB, userID uuid.UUID, ranAt int64, status, errorMessage string, sizeBytes int64) error {
+	const sqlstr = `UPDATE user_backup_settings
+		SET last_run_at = $2, last_status = $3, last_error = $4,
+			last_size_bytes = CASE WHEN $3 = 'succeeded' THEN $5 ELSE last_size_bytes END
+		WHERE user_id = $1`
+	if _, err := db.ExecContext(ctx, sqlstr, userID, ranAt, status, errorMessage, sizeBytes); err != nil {
+		return fmt.Errorf("failed to record backup result: %w", err)
+	}
+	return nil
+}
+
+// BackupsByUserIDAndLocation はユーザーが保存先locationに保存したバックアップを新しい順に返す
+func BackupsByUserIDAndLocation(ctx context.Context, db DB, userID uuid.UUID, location string) ([]*UserBackup, error) {
+	const sqlstr = `SELECT id, user_id, location, object_key, size_bytes, encrypted, created_at FROM user_backups
+		WHERE user_id = $1 AND location = $2
+		ORDER BY created_at DESC, id ASC`
+	rows, err := db.QueryContext(ctx, sqlstr, userID, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query backups: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	backups := make([]*UserBackup, 0)
+	for rows.Next() {
+		b := UserBackup{_exists: true}
+		if err := rows.Scan(&b.ID, &b.UserID, &b.Location, &b.ObjectKey, &b.SizeBytes, &b.Encrypted, &b.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		backups = append(backups, &b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return backups, nil
+}
+
+// DeleteBackupsByIDs は指定したバックアップの行を削除する
+func DeleteBackupsByIDs(ctx context.Context, db DB, ids []uuid.UUID) error {
+	const sqlstr = `DELETE FROM user_backups WHERE id = ANY($1::uuid[])`
+	if _, err := db.ExecContext(ctx, sqlstr, pq.Array(ids)); err != nil {
+		return fmt.Errorf("failed to delete backups: %w", err)
+	}
+	return nil
+}
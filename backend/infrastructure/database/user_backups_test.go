@@ -0,0 +1,74 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+)
+
+func TestBackupSettings(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	dueUserID := testutil.CreateTestUser(t, db, "backup-due@example.com", "BackupDueUser")
+	laterUserID := testutil.CreateTestUser(t, db, "backup-later@example.com", "BackupLaterUser")
+
+	now := time.Now().Unix()
+	for userID, nextRunAt := range map[string]int64{dueUserID.String(): now - 60, laterUserID.String(): now + 3600} {
+		if _, err := db.Exec(`INSERT INTO user_backup_settings (user_id, enabled, destination, interval_hours, next_run_at, created_at, updated_at)
+			VALUES ($1, TRUE, 'local', 6, $2, $3, $3)`, userID, nextRunAt, now); err != nil {
+			t.Fatalf("バックアップ設定の作成に失敗: %v", err)
+		}
+	}
+
+	t.Run("正常系: 実行予定を過ぎた設定だけを取得し、次回の実行予定を進める", func(t *testing.T) {
+		claimed, err := database.ClaimDueBackupSettings(ctx, db, now, 100)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		var found bool
+		for _, s := range claimed {
+			if s.UserID == laterUserID {
+				t.Error("実行予定前の設定が取得された")
+			}
+			if s.UserID == dueUserID {
+				found = true
+				if s.NextRunAt != now+6*3600 {
+					t.Errorf("次回の実行予定: 期待 %d, 実際 %d", now+6*3600, s.NextRunAt)
+				}
+			}
+		}
+		if !found {
+			t.Fatal("実行予定を過ぎた設定が取得されていない")
+		}
+
+		// 2回目は次回の実行予定が進んでいるため取得しない
+		again, err := database.ClaimDueBackupSettings(ctx, db, now, 100)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		for _, s := range again {
+			if s.UserID == dueUserID {
+				t.Error("同じ設定が2回取得された")
+			}
+		}
+	})
+
+	t.Run("正常系: 失敗した場合は最後に成功したサイズを残す", func(t *testing.T) {
+		if err := database.RecordBackupResult(ctx, db, dueUserID, now, database.BackupStatusSucceeded, "", 1024); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if err := database.RecordBackupResult(ctx, db, dueUserID, now+1, database.BackupStatusFailed, "upload failed", 0); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		s, err := database.UserBackupSettingByUserID(ctx, db, dueUserID)
+		if err != nil {
+			t.Fatalf("設定の取得に失敗: %v", err)
+		}
+		if s.LastStatus != database.BackupStatusFailed || s.LastError != "upload failed" || s.LastSizeBytes != 1024 || s.LastRunAt.Int64 != now+1 {
+			t.Errorf("実行結果が不正: %+v", s)
+		}
+	})
+}
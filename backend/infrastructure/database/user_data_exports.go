@@ -0,0 +1,252 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// データエクスポートの状態（user_data_exports.status）
+const (
+	DataExportStatusQueued     = "queued"
+	DataExportStatusProcessing = "processing"
+	DataExportStatusSucceeded  = "succeeded"
+	DataExportStatusFailed     = "failed"
+)
+
+const userDataExportColumns = `id, user_id, status, storage_key, size_bytes, error_message, completed_at, expires_at, created_at, updated_at`
+
+// queryUserDataExports はuserDataExportColumnsで取得するクエリを実行してデータエクスポートのスライスを返す
+func queryUserDataExports(ctx context.Context, db DB, sqlstr string, args ...any) ([]*UserDataExport, error) {
+	rows, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query data exports: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	exports := make([]*UserDataExport, 0)
+	for rows.Next() {
+		e := UserDataExport{_exists: true}
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Status, &e.StorageKey, &e.SizeBytes, &e.ErrorMessage, &e.CompletedAt, &e.ExpiresAt, &e.CreatedAt, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		exports = append(exports, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return exports, nil
+}
+
+// RecentDataExportsByUserID はユーザーのデータエクスポートを新しい順に最大limit件返す
+func RecentDataExportsByUserID(ctx context.Context, db DB, userID uuid.UUID, limit int) ([]*UserDataExport, error) {
+	const sqlstr = `SELECT ` + userDataExportColumns + ` FROM user_data_exports
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id ASC
+		LIMIT $2`
+	return queryUserDataExports(ctx, db, sqlstr, userID, limit)
+}
+
+// InProgressDataExportCountByUserID はユーザーの生成待ち・生成中のデータエクスポートのうち、since（UNIX秒）以降に更新されたものの件数を返す
+// 生成中にsubscriberが停止した場合に新しいエクスポートを依頼できなくならないよう、古いものは数えない
+func InProgressDataExportCountByUserID(ctx context.Context, db DB, userID uuid.UUID, since int64) (int, error) {
+	const sqlstr = `SELECT COUNT(*) FROM user_data_exports
+		WHERE user_id = $1 AND status IN ('queued', 'processing') AND updated_at >= $2`
+	var count int
+	if err := db.QueryRowContext(ctx, sqlstr, userID, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count in-progress data exports: %w", err)
+	}
+	return count, nil
+}
+
+// LockDataExportRequest はユーザー単位のトランザクションアドバイザリロックを取得する
+// 同時に依頼された場合に、作成中のエクスポートの確認と登録の間に他の登録が割り込まないようにする（トランザクション終了で解放される）
+func LockDataExportRequest(ctx context.Context, tx *sql.Tx, userID uuid.UUID) error {
+	const sqlstr = `SELECT pg_advisory_xact_lock(hashtext('user_data_exports:' || $1::text))`
+	if _, err := tx.ExecContext(ctx, sqlstr, userID); err != nil {
+		return fmt.Errorf("failed to lock data export request: %w", err)
+	}
+	return nil
+}
+
+// ClaimDataExport は生成待ちのデータエクスポートを生成中にして返す
+// 同じメッセージを複数のsubscriberが受け取っても1つだけが生成するよう、生成待ちでなければsql.ErrNoRowsを返す
+func ClaimDataExport(ctx context.Context, db DB, id uuid.UUID, now int64) (*UserDataExport, error) {
+	const sqlstr = `UPDATE user_data_exports SET status = 'processing', updated_at = $2
+		WHERE id = $1 AND status = 'queued'
+		RETURNING ` + userDataExportColumns
+	exports, err := queryUserDataExports(ctx, db, sqlstr, id, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(exports) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return exports[0], nil
+}
+
+// ExpiredDataExports はダウンロードの期限（before, UNIX秒）を過ぎたデータエクスポートと、
+// staleBefore（UNIX秒）より前から生成待ち・生成中のまま止まっているデータエクスポートを返す
+func ExpiredDataExports(ctx context.Context, db DB, before, staleBefore int64) ([]*UserDataExport, error) {
+	const sqlstr = `SELECT ` + userDataExportColumns + ` FROM user_data_exports
+		WHERE expires_at < $1
+		   OR (status IN ('queued', 'processing') AND updated_at < $2)`
+	return queryUserDataExports(ctx, db, sqlstr, before, staleBefore)
+}
+
+// DeleteDataExportsByIDs は指定したデータエクスポートの行を削除する
+func DeleteDataExportsByIDs(ctx context.Context, db DB, ids []uuid.UUID) error {
+	const sqlstr = `DELETE FROM user_data_exports WHERE id = ANY($1::uuid[])`
+	if _, err := db.ExecContext(ctx, sqlstr, pq.Array(ids)); err != nil {
+		return fmt.Errorf("failed to delete data exports: %w", err)
+	}
+	return nil
+}
+
+// DataExportStorageKeysByUserID はユーザーのデータエクスポートのうち、アーカイブを保存済みのもののストレージ上のキーを返す
+func DataExportStorageKeysByUserID(ctx context.Context, db DB, userID uuid.UUID) ([]string, error) {
+	const sqlstr = `SELECT storage_key FROM user_data_exports WHERE user_id = $1 AND storage_key <> ''`
+	return queryStringSlice(ctx, db, sqlstr, userID)
+}
+
+// ExportedDiary はデータエクスポートに含める日記と、付いているタグ名
+type ExportedDiary struct {
+	Diary
+	TagNames []string
+}
+
+// ForEachDiaryForExport はユーザーの全日記（ゴミ箱を含む）を日付順にfnへ渡す
+// 日記の件数が多くてもメモリに載せないよう、1行ずつ読み込む
+func ForEachDiaryForExport(ctx context.Context, db DB, userID uuid.UUID, fn func(*ExportedDiary) error) error {
+	const sqlstr = `SELECT ` + diaryColumns + `,
+			COALESCE((
+				SELECT array_agg(t.name ORDER BY t.name)
+				FROM diary_tags dt JOIN tags t ON t.id = dt.tag_id
+				WHERE dt.diary_id = diaries.id
+			), '{}')
+		FROM diaries
+		WHERE user_id = $1
+		ORDER BY date ASC, ` + diaryEntryOrder
+	rows, err := db.QueryContext(ctx, sqlstr, userID)
+	if err != nil {
+		return fmt.Errorf("failed to query diaries for export: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		d := ExportedDiary{Diary: Diary{_exists: true}}
+		if err := rows.Scan(&d.ID, &d.UserID, &d.Content, &d.Date, &d.CreatedAt, &d.UpdatedAt, &d.Title, &d.EntryTime, &d.EntryIndex, &d.Version, &d.ChangeSeq, &d.DeletedAt, pq.Array(&d.TagNames)); err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := fn(&d); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return nil
+}
+
+// ExportedEntity はデータエクスポートに含めるエンティティと、そのエイリアス
+type ExportedEntity struct {
+	Entity
+	Aliases []string
+}
+
+// ForEachEntityForExport はユーザーの全エンティティ（ゴミ箱を含む）を名前順にfnへ渡す
+func ForEachEntityForExport(ctx context.Context, db DB, userID uuid.UUID, fn func(*ExportedEntity) error) error {
+	const sqlstr = `SELECT ` + entityColumns + `,
+			COALESCE((
+				SELECT array_agg(ea.alias ORDER BY ea.alias)
+				FROM entity_aliases ea
+				WHERE ea.entity_id = entities.id
+			), '{}')
+		FROM entities
+		WHERE user_id = $1
+		ORDER BY name ASC, id ASC`
+	rows, err := db.QueryContext(ctx, sqlstr, userID)
+	if err != nil {
+		return fmt.Errorf("failed to query entities for export: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		e := ExportedEntity{Entity: Entity{_exists: true}}
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Name, &e.CategoryID, &e.Memo, &e.CreatedAt, &e.UpdatedAt, &e.Version, &e.DeletedAt, pq.Array(&e.Aliases)); err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := fn(&e); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return nil
+}
+
+// ForEachMonthlySummaryForExport はユーザーの月次要約を年月順にfnへ渡す
+func ForEachMonthlySummaryForExport(ctx context.Context, db DB, userID uuid.UUID, fn func(*DiarySummaryMonth) error) error {
+	const sqlstr = `SELECT id, user_id, year, month, summary, created_at, updated_at, model_version, error_reason, fencing_token
+		FROM diary_summary_months
+		WHERE user_id = $1
+		ORDER BY year ASC, month ASC`
+	rows, err := db.QueryContext(ctx, sqlstr, userID)
+	if err != nil {
+		return fmt.Errorf("failed to query monthly summaries for export: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		s := DiarySummaryMonth{_exists: true}
+		if err := rows.Scan(&s.ID, &s.UserID, &s.Year, &s.Month, &s.Summary, &s.CreatedAt, &s.UpdatedAt, &s.ModelVersion, &s.ErrorReason, &s.FencingToken); err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := fn(&s); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return nil
+}
+
+// ExportedHighlight はデータエクスポートに含めるハイライトと、対象の日記の日付
+type ExportedHighlight struct {
+	DiaryHighlight
+	Date time.Time
+}
+
+// ForEachHighlightForExport はユーザーのハイライトを日記の日付順にfnへ渡す
+func ForEachHighlightForExport(ctx context.Context, db DB, userID uuid.UUID, fn func(*ExportedHighlight) error) error {
+	const sqlstr = `SELECT h.id, h.diary_id, h.user_id, h.highlights, h.created_at, h.updated_at, h.fencing_token, d.date
+		FROM diary_highlights h
+		JOIN diaries d ON d.id = h.diary_id
+		WHERE h.user_id = $1
+		ORDER BY d.date ASC, d.entry_time ASC NULLS FIRST, d.entry_index ASC`
+	rows, err := db.QueryContext(ctx, sqlstr, userID)
+	if err != nil {
+		return fmt.Errorf("failed to query highlights for export: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		h := ExportedHighlight{DiaryHighlight: DiaryHighlight{_exists: true}}
+		if err := rows.Scan(&h.ID, &h.DiaryID, &h.UserID, &h.Highlights, &h.CreatedAt, &h.UpdatedAt, &h.FencingToken, &h.Date); err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := fn(&h); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return nil
+}
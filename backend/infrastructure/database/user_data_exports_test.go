@@ -0,0 +1,86 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+)
+
+func TestDataExports(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	userID := testutil.CreateTestUser(t, db, "data-export@example.com", "DataExportUser")
+
+	now := time.Now().Unix()
+	insertExport := func(exportStatus string, updatedAt int64, expiresAt sql.NullInt64) *database.UserDataExport {
+		e := &database.UserDataExport{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Status:    exportStatus,
+			ExpiresAt: expiresAt,
+			CreatedAt: updatedAt,
+			UpdatedAt: updatedAt,
+		}
+		if err := e.Insert(ctx, db); err != nil {
+			t.Fatalf("データエクスポートの作成に失敗: %v", err)
+		}
+		return e
+	}
+	queued := insertExport(database.DataExportStatusQueued, now, sql.NullInt64{})
+	stale := insertExport(database.DataExportStatusProcessing, now-7200, sql.NullInt64{})
+	expired := insertExport(database.DataExportStatusSucceeded, now-100, sql.NullInt64{Int64: now - 10, Valid: true})
+	active := insertExport(database.DataExportStatusSucceeded, now-100, sql.NullInt64{Int64: now + 3600, Valid: true})
+
+	t.Run("正常系: 止まった生成中のエクスポートは作成中として数えない", func(t *testing.T) {
+		count, err := database.InProgressDataExportCountByUserID(ctx, db, userID, now-3600)
+		if err != nil {
+			t.Fatalf("件数の取得に失敗: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("作成中の件数: 期待 1, 実際 %d", count)
+		}
+	})
+
+	t.Run("正常系: 生成待ちのエクスポートは1回だけ生成中にできる", func(t *testing.T) {
+		claimed, err := database.ClaimDataExport(ctx, db, queued.ID, now)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if claimed.Status != database.DataExportStatusProcessing {
+			t.Errorf("状態: 期待 processing, 実際 %s", claimed.Status)
+		}
+		if _, err := database.ClaimDataExport(ctx, db, queued.ID, now); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("2回目は sql.ErrNoRows が期待されるが %v", err)
+		}
+	})
+
+	t.Run("正常系: 期限切れと止まったエクスポートだけを削除対象にする", func(t *testing.T) {
+		exports, err := database.ExpiredDataExports(ctx, db, now, now-3600)
+		if err != nil {
+			t.Fatalf("取得に失敗: %v", err)
+		}
+		got := make(map[uuid.UUID]bool)
+		for _, e := range exports {
+			got[e.ID] = true
+		}
+		if len(got) != 2 || !got[stale.ID] || !got[expired.ID] {
+			t.Errorf("削除対象が正しくない: %v", got)
+		}
+
+		if err := database.DeleteDataExportsByIDs(ctx, db, []uuid.UUID{stale.ID, expired.ID}); err != nil {
+			t.Fatalf("削除に失敗: %v", err)
+		}
+		if _, err := database.UserDataExportByID(ctx, db, active.ID); err != nil {
+			t.Errorf("削除対象外のエクスポートが消えた: %v", err)
+		}
+		if _, err := database.UserDataExportByID(ctx, db, expired.ID); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("期限切れのエクスポートが残っている: %v", err)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// MarkEmailVerified はユーザーのメールアドレスを確認済みにする（確認済みの場合は日時を変えない）。
+// 確認したメールアドレスから変更されている場合は更新せずfalseを返す
+func MarkEmailVerified(ctx context.Context, db DB, userID uuid.UUID, email string, verifiedAt int64) (bool, error) {
+	const sqlstr = `UPDATE users SET email_verified_at = COALESCE(email_verified_at, $3), updated_at = $3 WHERE id = $1 AND email = $2`
+	res, err := db.ExecContext(ctx, sqlstr, userID, email, verifiedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark email as verified for user %s: %w", userID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return n == 1, nil
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// UseTotpStep はTOTPのコードを使ったタイムステップを記録する。
+// 記録済みのタイムステップ以前の場合は更新せずfalseを返す（同じコードを同時に使った場合も1回だけ成功する）
+func UseTotpStep(ctx context.Context, db DB, userID uuid.UUID, step, updatedAt int64) (bool, error) {
+	const sqlstr = `UPDATE user_totps SET last_used_step = $2, updated_at = $3 WHERE user_id = $1 AND last_used_step < $2`
+	res, err := db.ExecContext(ctx, sqlstr, userID, step, updatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to update totp step for user %s: %w", userID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return n == 1, nil
+}
+
+// UseRecoveryCode は未使用のリカバリーコードを使用済みにする。該当するコードがない場合はfalseを返す
+func UseRecoveryCode(ctx context.Context, db DB, userID uuid.UUID, codeHashed string, usedAt int64) (bool, error) {
+	const sqlstr = `UPDATE user_recovery_codes SET used_at = $3 WHERE user_id = $1 AND code_hashed = $2 AND used_at IS NULL`
+	res, err := db.ExecContext(ctx, sqlstr, userID, codeHashed, usedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code for user %s: %w", userID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return n == 1, nil
+}
+
+// CountUnusedRecoveryCodes はユーザーの未使用のリカバリーコードの数を返す
+func CountUnusedRecoveryCodes(ctx context.Context, db DB, userID uuid.UUID) (int, error) {
+	const sqlstr = `SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+	var count int
+	if err := db.QueryRowContext(ctx, sqlstr, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes for user %s: %w", userID, err)
+	}
+	return count, nil
+}
+
+// DeleteRecoveryCodesByUserID はユーザーのリカバリーコードをすべて削除する
+func DeleteRecoveryCodesByUserID(ctx context.Context, db DB, userID uuid.UUID) error {
+	const sqlstr = `DELETE FROM user_recovery_codes WHERE user_id = $1`
+	if _, err := db.ExecContext(ctx, sqlstr, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes for user %s: %w", userID, err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// UserOauthesByUserID はユーザーが連携しているOpenID Connectのプロバイダーを連携した順に返す
+func UserOauthesByUserID(ctx context.Context, db DB, userID uuid.UUID) ([]*UserOauthe, error) {
+	const sqlstr = `SELECT id, user_id, provider, subject, email, created_at, updated_at
+		FROM user_oauthes
+		WHERE user_id = $1
+		ORDER BY created_at ASC, provider ASC`
+	rows, err := db.QueryContext(ctx, sqlstr, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user oauthes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	oauthes := make([]*UserOauthe, 0)
+	for rows.Next() {
+		uo := UserOauthe{_exists: true}
+		if err := rows.Scan(&uo.ID, &uo.UserID, &uo.Provider, &uo.Subject, &uo.Email, &uo.CreatedAt, &uo.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		oauthes = append(oauthes, &uo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return oauthes, nil
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// UsePasskey はパスキーの署名カウンターと最後に使った日時を更新する。
+// 検証した時から署名カウンターが変わっている場合は更新せずfalseを返す（同じレスポンスを同時に使った場合も1回だけ成功する）
+func UsePasskey(ctx context.Context, db DB, id uuid.UUID, prevSignCount, signCount, usedAt int64) (bool, error) {
+	const sqlstr = `UPDATE user_passkeys SET sign_count = $3, last_used_at = $4, updated_at = $4 WHERE id = $1 AND sign_count = $2`
+	res, err := db.ExecContext(ctx, sqlstr, id, prevSignCount, signCount, usedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to update passkey %s: %w", id, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return n == 1, nil
+}
+
+// CountPasskeysByUserID はユーザーが登録したパスキーの数を返す
+func CountPasskeysByUserID(ctx context.Context, db DB, userID uuid.UUID) (int, error) {
+	const sqlstr = `SELECT COUNT(*) FROM user_passkeys WHERE user_id = $1`
+	var count int
+	if err := db.QueryRowContext(ctx, sqlstr, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count passkeys for user %s: %w", userID, err)
+	}
+	return count, nil
+}
@@ -134,7 +134,7 @@ func PendingMonthlySummaryCount(ctx context.Context, db DB, userID uuid.UUID) (i
 				EXTRACT(MONTH FROM d.date) as month,
 				MAX(d.updated_at) as latest_diary_updated_at
 			FROM diaries d
-			WHERE d.user_id = $1
+			WHERE d.user_id = $1 AND d.deleted_at IS NULL
 			GROUP BY EXTRACT(YEAR FROM d.date), EXTRACT(MONTH FROM d.date)
 		)
 		SELECT COUNT(*)
@@ -185,6 +185,7 @@ func PendingEmbeddingCount(ctx context.Context, db DB, userID uuid.UUID) (int32,
 		SELECT COUNT(*)
 		FROM diaries d
 		WHERE d.user_id = $1
+		  AND d.deleted_at IS NULL
 		  AND NOT EXISTS (
 		    SELECT 1 FROM diary_embeddings e WHERE e.diary_id = d.id
 		  )
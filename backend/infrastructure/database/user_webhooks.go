@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Webhook配信の状態（user_webhook_deliveries.status）
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+// WebhookDeliveryJob は配信ワーカーが送信する配信行と、その送信先の情報
+type WebhookDeliveryJob struct {
+	Delivery *UserWebhookDelivery
+	URL      string
+	Secret   string
+}
+
+// EnqueueWebhookDeliveries は指定イベントを購読しているユーザーの全Webhookに対して配信行をpendingで登録する
+// 登録した件数を返す（購読しているWebhookがなければ0）
+func EnqueueWebhookDeliveries(ctx context.Context, db DB, userID uuid.UUID, event string, payload []byte, now int64) (int64, error) {
+	const sqlstr = `
+		INSERT INTO user_webhook_deliveries (
+			id, webhook_id, user_id, event, payload, status,
+			attempts, response_status, last_error, next_attempt_at, created_at, updated_at
+		)
+		SELECT gen_random_uuid(), w.id, w.user_id, $2::text, $3, 'pending', 0, 0, '', $4, $4, $4
+		FROM user_webhooks w
+		WHERE w.user_id = $1
+		AND w.events ? $2::text
+	`
+	result, err := db.ExecContext(ctx, sqlstr, userID, event, payload, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected, nil
+}
+
+// ClaimDueWebhookDeliveries は送信予定時刻を過ぎたpendingの配信行を最大limit件取得する
+// 取得した行はnext_attempt_atをleaseUntilまで延ばし、送信中に他のワーカーが重複して取得しないようにする
+// 送信中にワーカーが停止した場合も、leaseUntilを過ぎれば再度取得される
+func ClaimDueWebhookDeliveries(ctx context.Context, db DB, now, leaseUntil int64, limit int) ([]*WebhookDeliveryJob, error) {
+	const sqlstr = `
+		UPDATE user_webhook_deliveries d
+		SET next_attempt_at = $2, updated_at = $1
+		FROM user_webhooks w
+		WHERE w.id = d.webhook_id
+		AND d.id IN (
+			SELECT id FROM user_webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING d.id, d.webhook_id, d.user_id, d.event, d.payload, d.status,
+			d.attempts, d.response_status, d.last_error, d.next_attempt_at, d.delivered_at,
+			d.created_at, d.updated_at, w.url, w.secret
+	`
+	rows, err := db.QueryContext(ctx, sqlstr, now, leaseUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var jobs []*WebhookDeliveryJob
+	for rows.Next() {
+		d := UserWebhookDelivery{_exists: true}
+		job := &WebhookDeliveryJob{Delivery: &d}
+		if err := rows.Scan(
+			&d.ID, &d.WebhookID, &d.UserID, &d.Event, &d.Payload, &d.Status,
+			&d.Attempts, &d.ResponseStatus, &d.LastError, &d.NextAttemptAt, &d.DeliveredAt,
+			&d.CreatedAt, &d.UpdatedAt, &job.URL, &job.Secret,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return jobs, nil
+}
+
+// RecentWebhookDeliveries は指定Webhookの配信ログを新しい順に最大limit件返す
+func RecentWebhookDeliveries(ctx context.Context, db DB, webhookID uuid.UUID, limit int) ([]*UserWebhookDelivery, error) {
+	const sqlstr = `
+		SELECT id, webhook_id, user_id, event, payload, status,
+			attempts, response_status, last_error, next_attempt_at, delivered_at,
+			created_at, updated_at
+		FROM user_webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2
+	`
+	rows, err := db.QueryContext(ctx, sqlstr, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var deliveries []*UserWebhookDelivery
+	for rows.Next() {
+		d := UserWebhookDelivery{_exists: true}
+		if err := rows.Scan(
+			&d.ID, &d.WebhookID, &d.UserID, &d.Event, &d.Payload, &d.Status,
+			&d.Attempts, &d.ResponseStatus, &d.LastError, &d.NextAttemptAt, &d.DeliveredAt,
+			&d.CreatedAt, &d.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return deliveries, nil
+}
+
+// DeleteFinishedWebhookDeliveriesBefore は送信が完了（成功または失敗確定）した配信ログのうち、
+// 指定時刻より前に作成されたものを削除し、削除件数を返す
+func DeleteFinishedWebhookDeliveriesBefore(ctx context.Context, db DB, before int64) (int64, error) {
+	const sqlstr = `DELETE FROM user_webhook_deliveries WHERE status <> 'pending' AND created_at < $1`
+	result, err := db.ExecContext(ctx, sqlstr, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected, nil
+}
@@ -0,0 +1,100 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+)
+
+// insertTestWebhook はテスト用のWebhook行を挿入する
+func insertTestWebhook(t *testing.T, db *sql.DB, userID uuid.UUID, events string) uuid.UUID {
+	t.Helper()
+	webhook := &database.UserWebhook{
+		ID:        uuid.New(),
+		UserID:    userID,
+		URL:       "https://example.com/hook",
+		Secret:    "whsec_test",
+		Events:    []byte(events),
+		CreatedAt: 1700000000,
+		UpdatedAt: 1700000000,
+	}
+	if err := webhook.Insert(context.Background(), db); err != nil {
+		t.Fatalf("Webhookの挿入に失敗: %v", err)
+	}
+	return webhook.ID
+}
+
+func TestEnqueueAndClaimWebhookDeliveries(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "webhook-enqueue@example.com", "WebhookEnqueueUser")
+	ctx := context.Background()
+
+	subscribed := insertTestWebhook(t, db, userID, `["diary.created", "summary.generated"]`)
+	insertTestWebhook(t, db, userID, `["diary.deleted"]`)
+
+	t.Run("正常系: 購読しているWebhookにのみ配信行が登録される", func(t *testing.T) {
+		count, err := database.EnqueueWebhookDeliveries(ctx, db, userID, "diary.created", []byte(`{"event":"diary.created"}`), 1700000100)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if count != 1 {
+			t.Fatalf("登録件数: 期待 1, 実際 %d", count)
+		}
+	})
+
+	t.Run("正常系: 送信予定時刻を過ぎた配信行が取得され、リース期間中は再取得されない", func(t *testing.T) {
+		jobs, err := database.ClaimDueWebhookDeliveries(ctx, db, 1700000100, 1700000400, 10)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(jobs) != 1 {
+			t.Fatalf("取得件数: 期待 1, 実際 %d", len(jobs))
+		}
+		job := jobs[0]
+		if job.Delivery.WebhookID != subscribed || job.URL != "https://example.com/hook" || job.Secret != "whsec_test" {
+			t.Errorf("取得した配信行が不正: %+v", job)
+		}
+		if job.Delivery.NextAttemptAt != 1700000400 {
+			t.Errorf("NextAttemptAt: 期待 1700000400, 実際 %d", job.Delivery.NextAttemptAt)
+		}
+
+		again, err := database.ClaimDueWebhookDeliveries(ctx, db, 1700000200, 1700000500, 10)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(again) != 0 {
+			t.Errorf("リース期間中に再取得された: %d件", len(again))
+		}
+
+		// 取得した行はそのまま更新できる
+		job.Delivery.Status = database.WebhookDeliveryStatusSucceeded
+		job.Delivery.Attempts = 1
+		job.Delivery.ResponseStatus = 200
+		job.Delivery.DeliveredAt = sql.NullInt64{Int64: 1700000101, Valid: true}
+		if err := job.Delivery.Update(ctx, db); err != nil {
+			t.Fatalf("配信行の更新に失敗: %v", err)
+		}
+
+		deliveries, err := database.RecentWebhookDeliveries(ctx, db, subscribed, 10)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(deliveries) != 1 || deliveries[0].Status != database.WebhookDeliveryStatusSucceeded {
+			t.Errorf("配信ログが不正: %+v", deliveries)
+		}
+	})
+
+	t.Run("正常系: 完了済みの古い配信ログが削除される", func(t *testing.T) {
+		deleted, err := database.DeleteFinishedWebhookDeliveriesBefore(ctx, db, 1700000200)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if deleted != 1 {
+			t.Errorf("削除件数: 期待 1, 実際 %d", deleted)
+		}
+	})
+}
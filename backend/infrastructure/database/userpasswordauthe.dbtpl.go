@@ -14,6 +14,7 @@ type UserPasswordAuthe struct {
 	PasswordHashed string    `json:"password_hashed"` // password_hashed
 	CreatedAt      int64     `json:"created_at"`      // created_at
 	UpdatedAt      int64     `json:"updated_at"`      // updated_at
+	ResetRequired  bool      `json:"reset_required"`  // reset_required
 	// xo fields
 	_exists, _deleted bool
 }
@@ -39,13 +40,13 @@ func (upa *UserPasswordAuthe) Insert(ctx context.Context, db DB) error {
 	}
 	// insert (manual)
 	const sqlstr = `INSERT INTO public.user_password_authes (` +
-		`user_id, password_hashed, created_at, updated_at` +
+		`user_id, password_hashed, created_at, updated_at, reset_required` +
 		`) VALUES (` +
-		`$1, $2, $3, $4` +
+		`$1, $2, $3, $4, $5` +
 		`)`
 	// run
-	logf(sqlstr, upa.UserID, upa.PasswordHashed, upa.CreatedAt, upa.UpdatedAt)
-	if _, err := db.ExecContext(ctx, sqlstr, upa.UserID, upa.PasswordHashed, upa.CreatedAt, upa.UpdatedAt); err != nil {
+	logf(sqlstr, upa.UserID, upa.PasswordHashed, upa.CreatedAt, upa.UpdatedAt, upa.ResetRequired)
+	if _, err := db.ExecContext(ctx, sqlstr, upa.UserID, upa.PasswordHashed, upa.CreatedAt, upa.UpdatedAt, upa.ResetRequired); err != nil {
 		return logerror(err)
 	}
 	// set exists
@@ -63,11 +64,11 @@ func (upa *UserPasswordAuthe) Update(ctx context.Context, db DB) error {
 	}
 	// update with composite primary key
 	const sqlstr = `UPDATE public.user_password_authes SET ` +
-		`password_hashed = $1, created_at = $2, updated_at = $3 ` +
-		`WHERE user_id = $4`
+		`password_hashed = $1, created_at = $2, updated_at = $3, reset_required = $4 ` +
+		`WHERE user_id = $5`
 	// run
-	logf(sqlstr, upa.PasswordHashed, upa.CreatedAt, upa.UpdatedAt, upa.UserID)
-	if _, err := db.ExecContext(ctx, sqlstr, upa.PasswordHashed, upa.CreatedAt, upa.UpdatedAt, upa.UserID); err != nil {
+	logf(sqlstr, upa.PasswordHashed, upa.CreatedAt, upa.UpdatedAt, upa.ResetRequired, upa.UserID)
+	if _, err := db.ExecContext(ctx, sqlstr, upa.PasswordHashed, upa.CreatedAt, upa.UpdatedAt, upa.ResetRequired, upa.UserID); err != nil {
 		return logerror(err)
 	}
 	return nil
@@ -89,16 +90,16 @@ func (upa *UserPasswordAuthe) Upsert(ctx context.Context, db DB) error {
 	}
 	// upsert
 	const sqlstr = `INSERT INTO public.user_password_authes (` +
-		`user_id, password_hashed, created_at, updated_at` +
+		`user_id, password_hashed, created_at, updated_at, reset_required` +
 		`) VALUES (` +
-		`$1, $2, $3, $4` +
+		`$1, $2, $3, $4, $5` +
 		`)` +
 		` ON CONFLICT (user_id) DO ` +
 		`UPDATE SET ` +
-		`password_hashed = EXCLUDED.password_hashed, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at `
+		`password_hashed = EXCLUDED.password_hashed, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at, reset_required = EXCLUDED.reset_required `
 	// run
-	logf(sqlstr, upa.UserID, upa.PasswordHashed, upa.CreatedAt, upa.UpdatedAt)
-	if _, err := db.ExecContext(ctx, sqlstr, upa.UserID, upa.PasswordHashed, upa.CreatedAt, upa.UpdatedAt); err != nil {
+	logf(sqlstr, upa.UserID, upa.PasswordHashed, upa.CreatedAt, upa.UpdatedAt, upa.ResetRequired)
+	if _, err := db.ExecContext(ctx, sqlstr, upa.UserID, upa.PasswordHashed, upa.CreatedAt, upa.UpdatedAt, upa.ResetRequired); err != nil {
 		return logerror(err)
 	}
 	// set exists
@@ -133,7 +134,7 @@ func (upa *UserPasswordAuthe) Delete(ctx context.Context, db DB) error {
 func UserPasswordAutheByUserID(ctx context.Context, db DB, userID uuid.UUID) (*UserPasswordAuthe, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`user_id, password_hashed, created_at, updated_at ` +
+		`user_id, password_hashed, created_at, updated_at, reset_required ` +
 		`FROM public.user_password_authes ` +
 		`WHERE user_id = $1`
 	// run
@@ -141,7 +142,7 @@ func UserPasswordAutheByUserID(ctx context.Context, db DB, userID uuid.UUID) (*U
 	upa := UserPasswordAuthe{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, userID).Scan(&upa.UserID, &upa.PasswordHashed, &upa.CreatedAt, &upa.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, userID).Scan(&upa.UserID, &upa.PasswordHashed, &upa.CreatedAt, &upa.UpdatedAt, &upa.ResetRequired); err != nil {
 		return nil, logerror(err)
 	}
 	return &upa, nil
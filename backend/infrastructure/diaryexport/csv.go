@@ -0,0 +1,81 @@
+package diaryexport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+)
+
+// utf8BOM はExcelでUTF-8として開けるよう、CSVの先頭に付ける
+const utf8BOM = "\ufeff"
+
+var csvHeader = []string{"type", "date", "time", "title", "tags", "content", "created_at", "updated_at"}
+
+// csvWriter は1行に1件の日記を書き込むdiaries.csvを出力する
+// 月次要約はtypeをmonthly_summary、dateをYYYY-MMとした行にする
+type csvWriter struct {
+	file bufferedFile
+	w    *csv.Writer
+}
+
+func newCSVWriter(emit EmitFunc) (*csvWriter, error) {
+	cw := &csvWriter{file: bufferedFile{path: "diaries.csv", contentType: "text/csv; charset=utf-8", emit: emit}}
+	cw.file.buf.WriteString(utf8BOM)
+	cw.w = csv.NewWriter(&cw.file.buf)
+	if err := cw.write(csvHeader); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (w *csvWriter) write(record []string) error {
+	if err := w.w.Write(record); err != nil {
+		return err
+	}
+	w.w.Flush()
+	if err := w.w.Error(); err != nil {
+		return err
+	}
+	return w.file.flushIfFull()
+}
+
+func (w *csvWriter) WriteMonthlySummary(s *database.DiarySummaryMonth) error {
+	return w.write([]string{
+		"monthly_summary",
+		fmt.Sprintf("%04d-%02d", s.Year, s.Month),
+		"",
+		"",
+		"",
+		s.Summary,
+		strconv.FormatInt(s.CreatedAt, 10),
+		strconv.FormatInt(s.UpdatedAt, 10),
+	})
+}
+
+func (w *csvWriter) WriteDay(_ time.Time, entries []Entry) error {
+	for _, e := range entries {
+		d := e.Diary
+		err := w.write([]string{
+			"diary",
+			d.Date.Format(dateLayout),
+			entryTime(d),
+			d.Title,
+			strings.Join(e.Tags, ";"),
+			d.Content,
+			strconv.FormatInt(d.CreatedAt, 10),
+			strconv.FormatInt(d.UpdatedAt, 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *csvWriter) Close() error {
+	return w.file.flush()
+}
@@ -0,0 +1,108 @@
+// Package diaryexport は日記をJSON Lines・Markdown・CSV・HTMLのファイルに変換する
+// 日記は日付順に1日分ずつ受け取り、変換した内容を少しずつ出力するため、期間が長くてもメモリに載せない
+package diaryexport
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+)
+
+// Format はエクスポートの形式
+type Format int
+
+const (
+	FormatJSONL Format = iota + 1
+	FormatMarkdown
+	FormatCSV
+	FormatHTML
+)
+
+const (
+	dateLayout  = "2006-01-02"
+	monthLayout = "2006-01"
+
+	// flushSize はバッファした内容をEmitFuncに渡す目安のバイト数
+	flushSize = 64 * 1024
+)
+
+// Entry はエクスポートする日記と、付いているタグ名
+type Entry struct {
+	Diary *database.Diary
+	Tags  []string
+}
+
+// EmitFunc は変換したファイルの内容の一部を受け取る
+// 同じpathの内容を呼ばれた順に連結するとファイルになる
+type EmitFunc func(path, contentType string, data []byte) error
+
+// Writer は日付順に渡された日記を変換する
+type Writer interface {
+	// WriteMonthlySummary は月次要約を書き込む（その月の日記より前に呼び出す）
+	WriteMonthlySummary(s *database.DiarySummaryMonth) error
+	// WriteDay は同じ日付の日記をまとめて書き込む
+	WriteDay(date time.Time, entries []Entry) error
+	// Close はバッファした内容を出力して変換を終える
+	Close() error
+}
+
+// Options は形式ごとの追加の設定
+type Options struct {
+	Title  string                     // HTMLの表題（空の場合は「日記」）
+	Months []database.DiaryMonthCount // HTMLの目次に載せる年月
+}
+
+// NewWriter はformatの形式で変換するWriterを返す
+func NewWriter(format Format, emit EmitFunc, opts Options) (Writer, error) {
+	switch format {
+	case FormatJSONL:
+		return &jsonlWriter{file: bufferedFile{path: "diaries.jsonl", contentType: "application/x-ndjson", emit: emit}}, nil
+	case FormatMarkdown:
+		return &markdownWriter{emit: emit}, nil
+	case FormatCSV:
+		return newCSVWriter(emit)
+	case FormatHTML:
+		return newHTMLWriter(emit, opts)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %d", format)
+	}
+}
+
+// entryTime は時刻（0時からの経過分）をHH:MM形式で返す（未指定の場合は空文字）
+func entryTime(d *database.Diary) string {
+	if !d.EntryTime.Valid {
+		return ""
+	}
+	return fmt.Sprintf("%02d:%02d", d.EntryTime.Int64/60, d.EntryTime.Int64%60)
+}
+
+// bufferedFile は1つのファイルの内容をバッファし、一定の大きさごとにEmitFuncに渡す
+type bufferedFile struct {
+	path        string
+	contentType string
+	emit        EmitFunc
+	buf         bytes.Buffer
+	emitted     bool
+}
+
+// flushIfFull はバッファがflushSizeを超えた場合に出力する
+func (f *bufferedFile) flushIfFull() error {
+	if f.buf.Len() < flushSize {
+		return nil
+	}
+	return f.flush()
+}
+
+// flush はバッファした内容を出力する
+// 内容が空のファイルも受け取れるよう、一度も出力していなければ空でも出力する
+func (f *bufferedFile) flush() error {
+	if f.buf.Len() == 0 && f.emitted {
+		return nil
+	}
+	data := bytes.Clone(f.buf.Bytes())
+	f.buf.Reset()
+	f.emitted = true
+	return f.emit(f.path, f.contentType, data)
+}
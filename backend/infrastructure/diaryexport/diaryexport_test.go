@@ -0,0 +1,114 @@
+package diaryexport
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// run はformatで日記と月次要約を変換し、パスごとに連結した内容を返す
+func run(t *testing.T, format Format, opts Options, summary *database.DiarySummaryMonth, days ...[]Entry) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	w, err := NewWriter(format, func(path, _ string, data []byte) error {
+		files[path] += string(data)
+		return nil
+	}, opts)
+	require.NoError(t, err)
+	if summary != nil {
+		require.NoError(t, w.WriteMonthlySummary(summary))
+	}
+	for _, entries := range days {
+		require.NoError(t, w.WriteDay(entries[0].Diary.Date, entries))
+	}
+	require.NoError(t, w.Close())
+	return files
+}
+
+func testEntries() []Entry {
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	return []Entry{
+		{Diary: &database.Diary{ID: uuid.New(), Date: date, Content: "朝の日記\n"}, Tags: []string{"旅行"}},
+		{Diary: &database.Diary{ID: uuid.New(), Date: date, Title: "夜", Content: "夜の<日記>", EntryTime: sql.NullInt64{Int64: 21*60 + 5, Valid: true}}, Tags: []string{"仕事", "旅行"}},
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	_, err := NewWriter(Format(0), nil, Options{})
+	assert.Error(t, err)
+}
+
+func TestDayMarkdown(t *testing.T) {
+	entries := testEntries()
+	expected := "---\ndate: 2024-05-01\ntags: [\"仕事\", \"旅行\"]\n---\n\n## 無題\n\n朝の日記\n\n## 21:05 夜\n\n夜の<日記>\n"
+	assert.Equal(t, expected, DayMarkdown(entries[0].Diary.Date, entries))
+
+	t.Run("正常系: 時刻も題名もない1件だけの日は見出しを付けない", func(t *testing.T) {
+		assert.Equal(t, "---\ndate: 2024-05-01\ntags: [\"旅行\"]\n---\n\n朝の日記\n", DayMarkdown(entries[0].Diary.Date, entries[:1]))
+	})
+}
+
+func TestWriters(t *testing.T) {
+	summary := &database.DiarySummaryMonth{Year: 2024, Month: 5, Summary: "五月の要約"}
+
+	t.Run("正常系: JSON Linesは1行に1件", func(t *testing.T) {
+		files := run(t, FormatJSONL, Options{}, summary, testEntries())
+		lines := strings.Split(strings.TrimRight(files["diaries.jsonl"], "\n"), "\n")
+		require.Len(t, lines, 3)
+		assert.Contains(t, lines[0], `"type":"monthly_summary"`)
+		assert.Contains(t, lines[2], `"time":"21:05"`)
+	})
+
+	t.Run("正常系: Markdownは1日1ファイルと月次要約のファイル", func(t *testing.T) {
+		files := run(t, FormatMarkdown, Options{}, summary, testEntries())
+		assert.Contains(t, files, "2024/05/2024-05-01.md")
+		assert.Equal(t, "---\ntype: monthly_summary\nyear: 2024\nmonth: 5\n---\n\n五月の要約\n", files["2024/05/summary.md"])
+	})
+
+	t.Run("正常系: CSVはBOMと見出し行を付ける", func(t *testing.T) {
+		files := run(t, FormatCSV, Options{}, summary, testEntries())
+		content := files["diaries.csv"]
+		require.True(t, strings.HasPrefix(content, utf8BOM))
+		records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(content, utf8BOM))).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+		assert.Equal(t, csvHeader, records[0])
+		assert.Equal(t, []string{"monthly_summary", "2024-05", "", "", "", "五月の要約", "0", "0"}, records[1])
+		assert.Equal(t, "仕事;旅行", records[3][4])
+	})
+
+	t.Run("正常系: HTMLは目次を先頭に置き、本文をエスケープする", func(t *testing.T) {
+		opts := Options{Title: "テスト", Months: []database.DiaryMonthCount{{Year: 2024, Month: 5, Count: 2}}}
+		content := run(t, FormatHTML, opts, summary, testEntries())["diaries.html"]
+		assert.Contains(t, content, `<a href="#m-2024-05">2024年5月</a>（2件）`)
+		assert.Less(t, strings.Index(content, "<nav>"), strings.Index(content, `<section id="m-2024-05">`))
+		assert.Contains(t, content, "夜の&lt;日記&gt;")
+		assert.True(t, strings.HasSuffix(content, "</section>\n</body>\n</html>\n"))
+	})
+
+	t.Run("正常系: 日記がなくても単一ファイルの形式は空のファイルを返す", func(t *testing.T) {
+		files := run(t, FormatJSONL, Options{}, nil)
+		assert.Equal(t, map[string]string{"diaries.jsonl": ""}, files)
+	})
+
+	t.Run("正常系: 大きい内容は複数回に分けて出力する", func(t *testing.T) {
+		var calls int
+		w, err := NewWriter(FormatJSONL, func(_, _ string, _ []byte) error {
+			calls++
+			return nil
+		}, Options{})
+		require.NoError(t, err)
+		large := []Entry{{Diary: &database.Diary{Date: time.Now(), Content: strings.Repeat("あ", flushSize)}}}
+		require.NoError(t, w.WriteDay(large[0].Diary.Date, large))
+		require.NoError(t, w.WriteDay(large[0].Diary.Date, large))
+		require.NoError(t, w.Close())
+		assert.Equal(t, 2, calls)
+	})
+}
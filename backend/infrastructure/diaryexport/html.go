@@ -0,0 +1,100 @@
+package diaryexport
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+)
+
+// htmlStyle は外部のファイルに依存せずに読めるよう、HTMLに埋め込むスタイル
+const htmlStyle = `body{font-family:sans-serif;line-height:1.7;max-width:48rem;margin:0 auto;padding:1rem;color:#222}
+nav ol{padding-left:1.5rem}
+section{margin-top:3rem}
+h2{border-bottom:2px solid #f08300;padding-bottom:.25rem}
+article{margin-top:1.5rem}
+.summary{background:#fff7ed;padding:.75rem 1rem;border-radius:.5rem}
+.tags{color:#666;font-size:.9em}
+.content{white-space:pre-wrap}
+@media print{section{break-before:page}}`
+
+// htmlWriter は目次付きの1つのHTML（diaries.html）を出力する
+// 目次は先頭に置くため、日記を読み込む前に年月の一覧（Options.Months）を受け取る
+type htmlWriter struct {
+	file  bufferedFile
+	month string // 出力中の月（YYYY-MM）
+}
+
+func newHTMLWriter(emit EmitFunc, opts Options) (*htmlWriter, error) {
+	w := &htmlWriter{file: bufferedFile{path: "diaries.html", contentType: "text/html; charset=utf-8", emit: emit}}
+	title := opts.Title
+	if title == "" {
+		title = "日記"
+	}
+
+	b := &w.file.buf
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"ja\">\n<head>\n<meta charset=\"utf-8\">\n")
+	b.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	b.WriteString("<title>" + html.EscapeString(title) + "</title>\n")
+	b.WriteString("<style>\n" + htmlStyle + "\n</style>\n</head>\n<body>\n")
+	b.WriteString("<h1>" + html.EscapeString(title) + "</h1>\n")
+	b.WriteString("<nav>\n<h2>目次</h2>\n<ol>\n")
+	for _, m := range opts.Months {
+		fmt.Fprintf(b, "<li><a href=\"#m-%04d-%02d\">%d年%d月</a>（%d件）</li>\n", m.Year, m.Month, m.Year, m.Month, m.Count)
+	}
+	b.WriteString("</ol>\n</nav>\n")
+	return w, w.file.flushIfFull()
+}
+
+// startMonth は月が変わった場合に前の月の節を閉じ、新しい月の節を開始する
+func (w *htmlWriter) startMonth(year, month int) {
+	key := fmt.Sprintf("%04d-%02d", year, month)
+	if key == w.month {
+		return
+	}
+	b := &w.file.buf
+	if w.month != "" {
+		b.WriteString("</section>\n")
+	}
+	w.month = key
+	fmt.Fprintf(b, "<section id=\"m-%s\">\n<h2>%d年%d月</h2>\n", key, year, month)
+}
+
+func (w *htmlWriter) WriteMonthlySummary(s *database.DiarySummaryMonth) error {
+	w.startMonth(s.Year, s.Month)
+	b := &w.file.buf
+	b.WriteString("<div class=\"summary\">\n<h3>月次要約</h3>\n")
+	b.WriteString("<div class=\"content\">" + html.EscapeString(strings.TrimRight(s.Summary, "\n")) + "</div>\n</div>\n")
+	return w.file.flushIfFull()
+}
+
+func (w *htmlWriter) WriteDay(date time.Time, entries []Entry) error {
+	w.startMonth(date.Year(), int(date.Month()))
+	b := &w.file.buf
+	fmt.Fprintf(b, "<article id=\"d-%s\">\n<h3>%s</h3>\n", date.Format(dateLayout), date.Format(dateLayout))
+	for _, e := range entries {
+		if heading := entryHeading(e.Diary); heading != "" {
+			b.WriteString("<h4>" + html.EscapeString(heading) + "</h4>\n")
+		}
+		if len(e.Tags) > 0 {
+			tags := make([]string, len(e.Tags))
+			for i, t := range e.Tags {
+				tags[i] = "#" + html.EscapeString(t)
+			}
+			b.WriteString("<p class=\"tags\">" + strings.Join(tags, " ") + "</p>\n")
+		}
+		b.WriteString("<div class=\"content\">" + html.EscapeString(strings.TrimRight(e.Diary.Content, "\n")) + "</div>\n")
+	}
+	b.WriteString("</article>\n")
+	return w.file.flushIfFull()
+}
+
+func (w *htmlWriter) Close() error {
+	if w.month != "" {
+		w.file.buf.WriteString("</section>\n")
+	}
+	w.file.buf.WriteString("</body>\n</html>\n")
+	return w.file.flush()
+}
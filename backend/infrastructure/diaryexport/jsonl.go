@@ -0,0 +1,73 @@
+package diaryexport
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+)
+
+// jsonlRecord はJSON Linesの1行（typeで日記と月次要約を区別する）
+type jsonlRecord struct {
+	Type      string   `json:"type"`
+	ID        string   `json:"id,omitempty"`
+	Date      string   `json:"date,omitempty"`
+	Time      string   `json:"time,omitempty"`
+	Title     string   `json:"title,omitempty"`
+	Content   string   `json:"content,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Year      int      `json:"year,omitempty"`
+	Month     int      `json:"month,omitempty"`
+	Summary   string   `json:"summary,omitempty"`
+	CreatedAt int64    `json:"created_at"`
+	UpdatedAt int64    `json:"updated_at"`
+}
+
+// jsonlWriter は1行に1件の日記を書き込むdiaries.jsonlを出力する
+type jsonlWriter struct {
+	file bufferedFile
+}
+
+func (w *jsonlWriter) write(r jsonlRecord) error {
+	// json.Encoderは1件ごとに改行を付ける
+	if err := json.NewEncoder(&w.file.buf).Encode(r); err != nil {
+		return err
+	}
+	return w.file.flushIfFull()
+}
+
+func (w *jsonlWriter) WriteMonthlySummary(s *database.DiarySummaryMonth) error {
+	return w.write(jsonlRecord{
+		Type:      "monthly_summary",
+		Year:      s.Year,
+		Month:     s.Month,
+		Summary:   s.Summary,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	})
+}
+
+func (w *jsonlWriter) WriteDay(_ time.Time, entries []Entry) error {
+	for _, e := range entries {
+		d := e.Diary
+		err := w.write(jsonlRecord{
+			Type:      "diary",
+			ID:        d.ID.String(),
+			Date:      d.Date.Format(dateLayout),
+			Time:      entryTime(d),
+			Title:     d.Title,
+			Content:   d.Content,
+			Tags:      e.Tags,
+			CreatedAt: d.CreatedAt,
+			UpdatedAt: d.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *jsonlWriter) Close() error {
+	return w.file.flush()
+}
@@ -0,0 +1,103 @@
+package diaryexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+)
+
+const markdownContentType = "text/markdown; charset=utf-8"
+
+// markdownWriter は1日1ファイル（YYYY/MM/YYYY-MM-DD.md）のMarkdownを出力する
+// 月次要約はその月のディレクトリのsummary.mdに出力する
+type markdownWriter struct {
+	emit EmitFunc
+}
+
+// yamlString はYAMLの文字列としてそのまま書ける形に引用する
+// JSONの文字列はYAMLのダブルクォート文字列としても正しい
+func yamlString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// DayPath は日付のMarkdownファイルのパスを返す
+func DayPath(date time.Time) string {
+	return path.Join(date.Format("2006"), date.Format("01"), date.Format(dateLayout)+".md")
+}
+
+// DayMarkdown は同じ日付の日記を、日付とタグをYAML front matterに持つ1つのMarkdownにする
+// 時刻か題名がある日記と、複数の日記がある日は日記ごとに見出しを付ける
+func DayMarkdown(date time.Time, entries []Entry) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.WriteString("date: " + date.Format(dateLayout) + "\n")
+	if tags := dayTags(entries); len(tags) > 0 {
+		quoted := make([]string, len(tags))
+		for i, t := range tags {
+			quoted[i] = yamlString(t)
+		}
+		b.WriteString("tags: [" + strings.Join(quoted, ", ") + "]\n")
+	}
+	b.WriteString("---\n")
+
+	for _, e := range entries {
+		b.WriteString("\n")
+		if heading := entryHeading(e.Diary); heading != "" || len(entries) > 1 {
+			if heading == "" {
+				heading = "無題"
+			}
+			b.WriteString("## " + heading + "\n\n")
+		}
+		b.WriteString(strings.TrimRight(e.Diary.Content, "\n"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// entryHeading は時刻と題名を並べた見出しを返す（どちらもない場合は空文字）
+func entryHeading(d *database.Diary) string {
+	parts := make([]string, 0, 2)
+	if t := entryTime(d); t != "" {
+		parts = append(parts, t)
+	}
+	if d.Title != "" {
+		parts = append(parts, d.Title)
+	}
+	return strings.Join(parts, " ")
+}
+
+// dayTags はその日の日記に付いているタグ名を重複を除いて名前順に返す
+func dayTags(entries []Entry) []string {
+	seen := make(map[string]bool)
+	tags := make([]string, 0)
+	for _, e := range entries {
+		for _, t := range e.Tags {
+			if !seen[t] {
+				seen[t] = true
+				tags = append(tags, t)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func (w *markdownWriter) WriteMonthlySummary(s *database.DiarySummaryMonth) error {
+	p := path.Join(fmt.Sprintf("%04d", s.Year), fmt.Sprintf("%02d", s.Month), "summary.md")
+	content := fmt.Sprintf("---\ntype: monthly_summary\nyear: %d\nmonth: %d\n---\n\n%s\n", s.Year, s.Month, strings.TrimRight(s.Summary, "\n"))
+	return w.emit(p, markdownContentType, []byte(content))
+}
+
+func (w *markdownWriter) WriteDay(date time.Time, entries []Entry) error {
+	return w.emit(DayPath(date), markdownContentType, []byte(DayMarkdown(date, entries)))
+}
+
+func (w *markdownWriter) Close() error {
+	return nil
+}
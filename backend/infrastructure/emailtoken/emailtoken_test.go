@@ -0,0 +1,89 @@
+package emailtoken
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/rueidis"
+)
+
+func TestSigner(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := NewSigner("test-secret")
+
+	t.Run("正常系: 署名したトークンを検証できる", func(t *testing.T) {
+		token, issued, err := s.Sign(PurposePasswordReset, "user-1", "binding", time.Hour, now)
+		if err != nil {
+			t.Fatalf("Sign失敗: %v", err)
+		}
+		claims, err := s.Verify(token, PurposePasswordReset, now.Add(59*time.Minute))
+		if err != nil {
+			t.Fatalf("Verify失敗: %v", err)
+		}
+		if claims.ID != issued.ID || claims.UserID != "user-1" || claims.Binding != "binding" {
+			t.Errorf("Claimsが期待と異なる: %+v", claims)
+		}
+	})
+
+	token, _, err := s.Sign(PurposePasswordReset, "user-1", "", time.Hour, now)
+	if err != nil {
+		t.Fatalf("Sign失敗: %v", err)
+	}
+	payload, sig, _ := strings.Cut(token, ".")
+	other, _, _ := NewSigner("other-secret").Sign(PurposePasswordReset, "user-1", "", time.Hour, now)
+
+	tests := []struct {
+		name    string
+		token   string
+		purpose Purpose
+		now     time.Time
+		wantErr error
+	}{
+		{name: "異常系: 有効期限切れ", token: token, purpose: PurposePasswordReset, now: now.Add(time.Hour), wantErr: ErrExpiredToken},
+		{name: "異常系: 用途が異なる", token: token, purpose: PurposeEmailVerification, now: now, wantErr: ErrInvalidToken},
+		{name: "異常系: 別のシークレットで署名", token: other, purpose: PurposePasswordReset, now: now, wantErr: ErrInvalidToken},
+		{name: "異常系: 内容を書き換えた", token: payload + "x." + sig, purpose: PurposePasswordReset, now: now, wantErr: ErrInvalidToken},
+		{name: "異常系: 署名がない", token: payload, purpose: PurposePasswordReset, now: now, wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Verify(tt.token, tt.purpose, tt.now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("%vを期待したが %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestUsedStore(t *testing.T) {
+	t.Run("正常系: トークンは1回だけ使え、記録は有効期限までで消える", func(t *testing.T) {
+		mr, err := miniredis.Run()
+		if err != nil {
+			t.Fatalf("miniredis起動失敗: %v", err)
+		}
+		t.Cleanup(mr.Close)
+		client, err := rueidis.NewClient(rueidis.ClientOption{InitAddress: []string{mr.Addr()}, DisableCache: true})
+		if err != nil {
+			t.Fatalf("rueidisクライアント作成失敗: %v", err)
+		}
+		t.Cleanup(client.Close)
+		store := &UsedStore{Redis: client}
+
+		now := time.Now()
+		claims := &Claims{ID: "token-1", ExpiresAt: now.Add(10 * time.Minute).Unix()}
+		for i, want := range []bool{true, false} {
+			ok, err := store.Consume(t.Context(), claims, now)
+			if err != nil || ok != want {
+				t.Errorf("%d回目: %vを期待したが %v, %v", i+1, want, ok, err)
+			}
+		}
+		if ttl := mr.TTL(usedKeyPrefix + "token-1"); ttl <= 0 || ttl > 10*time.Minute+time.Second {
+			t.Errorf("TTLが期待と異なる: %v", ttl)
+		}
+		if ok, err := store.Consume(t.Context(), &Claims{ID: "token-2", ExpiresAt: now.Unix() - 1}, now); err != nil || ok {
+			t.Errorf("有効期限切れは使えないことを期待した: %v, %v", ok, err)
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package emailtoken
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/rueidis"
+)
+
+// usedKeyPrefix はRedis上で使用済みのトークンを記録する際のキー接頭辞
+const usedKeyPrefix = "email_token_used:"
+
+// UsedStore は使用済みのトークンの識別子を有効期限までRedisに記録する
+type UsedStore struct {
+	Redis rueidis.Client
+}
+
+// Consume はトークンを使用済みにする。既に使用済みの場合はokがfalseになる（同時に使った場合も1回だけ成功する）
+func (s *UsedStore) Consume(ctx context.Context, claims *Claims, now time.Time) (bool, error) {
+	ttl := time.Unix(claims.ExpiresAt, 0).Sub(now)
+	if ttl <= 0 {
+		return false, nil
+	}
+	// 有効期限を過ぎたトークンは署名の検証で弾けるため、記録は有効期限まででよい（端数は切り上げる）
+	cmd := s.Redis.B().Set().Key(usedKeyPrefix + claims.ID).Value("1").Nx().Ex(ttl.Truncate(time.Second) + time.Second).Build()
+	if err := s.Redis.Do(ctx, cmd).Error(); err != nil {
+		if rueidis.IsRedisNil(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to mark email token as used: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,114 @@
+package emailtoken
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Purpose はトークンの用途。用途が異なるトークンは検証に通らない
+type Purpose string
+
+const (
+	PurposePasswordReset         Purpose = "password_reset"          // パスワードの再設定
+	PurposeEmailVerification     Purpose = "email_verification"      // メールアドレスの確認
+	PurposeAccountDeletionCancel Purpose = "account_deletion_cancel" // アカウントの削除の取り消し
+)
+
+// keyDerivationLabel はJWTの署名鍵からメールのトークンの署名鍵を導出する際のラベル。
+// 同じ鍵で署名するとアクセストークンとして受理される形式と混同しうるため、鍵を分ける
+const keyDerivationLabel = "umi.mikan email token v1"
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token is expired")
+)
+
+// Claims はトークンに含める値
+type Claims struct {
+	ID        string  `json:"jti"` // 1回だけ使えるようにするための識別子
+	Purpose   Purpose `json:"pur"`
+	UserID    string  `json:"sub"`
+	Binding   string  `json:"bnd"` // 発行した時点の状態（変わると使えなくなる。例: メールアドレス）
+	ExpiresAt int64   `json:"exp"` // 有効期限（UNIX秒）
+}
+
+// Signer はメールで送るトークンを署名・検証する。
+// トークンは base64url(JSONのClaims) + "." + base64url(HMAC-SHA256) の形式
+type Signer struct {
+	key []byte
+}
+
+// NewSigner はJWTの署名に使うシークレットから導出した鍵で署名するSignerを作成する
+func NewSigner(secret string) *Signer {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(keyDerivationLabel))
+	return &Signer{key: mac.Sum(nil)}
+}
+
+// Sign はユーザーのトークンを作成する
+func (s *Signer) Sign(purpose Purpose, userID, binding string, ttl time.Duration, now time.Time) (string, *Claims, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", nil, fmt.Errorf("failed to generate token id: %w", err)
+	}
+	claims := &Claims{
+		ID:        hex.EncodeToString(id),
+		Purpose:   purpose,
+		UserID:    userID,
+		Binding:   binding,
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal token claims: %w", err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)), claims, nil
+}
+
+// Verify は署名・用途・有効期限を検証してClaimsを返す。1回だけ使えるかは UsedStore で確認する
+func (s *Signer) Verify(token string, purpose Purpose, now time.Time) (*Claims, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(signature, s.sign(encoded)) {
+		return nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.Purpose != purpose || claims.ID == "" || claims.UserID == "" {
+		return nil, ErrInvalidToken
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+	return &claims, nil
+}
+
+func (s *Signer) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// Fingerprint は値をトークンのBindingに含める短いハッシュにする（パスワードのハッシュなどをそのまま含めないため）
+func Fingerprint(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
@@ -0,0 +1,1538 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: admin/admin.proto
+
+package grpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ユーザーの権限
+type UserRole int32
+
+const (
+	UserRole_USER_ROLE_USER  UserRole = 0
+	UserRole_USER_ROLE_ADMIN UserRole = 1
+)
+
+// Enum value maps for UserRole.
+var (
+	UserRole_name = map[int32]string{
+		0: "USER_ROLE_USER",
+		1: "USER_ROLE_ADMIN",
+	}
+	UserRole_value = map[string]int32{
+		"USER_ROLE_USER":  0,
+		"USER_ROLE_ADMIN": 1,
+	}
+)
+
+func (x UserRole) Enum() *UserRole {
+	p := new(UserRole)
+	*p = x
+	return p
+}
+
+func (x UserRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (UserRole) Type() protoreflect.EnumType {
+	return &file_admin_admin_proto_enumTypes[0]
+}
+
+func (x UserRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserRole.Descriptor instead.
+func (UserRole) EnumDescriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{0}
+}
+
+// 管理者向けのユーザー情報
+type AdminUser struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email                 string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Name                  string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Role                  UserRole               `protobuf:"varint,4,opt,name=role,proto3,enum=admin.UserRole" json:"role,omitempty"`
+	CreatedAt             int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                                       // 登録日時（UNIX秒）
+	DisabledAt            int64                  `protobuf:"varint,6,opt,name=disabled_at,json=disabledAt,proto3" json:"disabled_at,omitempty"`                                    // 無効にした日時（UNIX秒、有効な場合は0）
+	PasswordResetRequired bool                   `protobuf:"varint,7,opt,name=password_reset_required,json=passwordResetRequired,proto3" json:"password_reset_required,omitempty"` // パスワードの再設定を求めている
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *AdminUser) Reset() {
+	*x = AdminUser{}
+	mi := &file_admin_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminUser) ProtoMessage() {}
+
+func (x *AdminUser) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminUser.ProtoReflect.Descriptor instead.
+func (*AdminUser) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AdminUser) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AdminUser) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *AdminUser) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AdminUser) GetRole() UserRole {
+	if x != nil {
+		return x.Role
+	}
+	return UserRole_USER_ROLE_USER
+}
+
+func (x *AdminUser) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *AdminUser) GetDisabledAt() int64 {
+	if x != nil {
+		return x.DisabledAt
+	}
+	return 0
+}
+
+func (x *AdminUser) GetPasswordResetRequired() bool {
+	if x != nil {
+		return x.PasswordResetRequired
+	}
+	return false
+}
+
+// ユーザーの利用状況
+type UserUsage struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	DiaryCount              int32                  `protobuf:"varint,1,opt,name=diary_count,json=diaryCount,proto3" json:"diary_count,omitempty"`                                         // 日記の件数（ゴミ箱を除く）
+	TrashedDiaryCount       int32                  `protobuf:"varint,2,opt,name=trashed_diary_count,json=trashedDiaryCount,proto3" json:"trashed_diary_count,omitempty"`                  // ゴミ箱の日記の件数
+	EmbeddingCount          int32                  `protobuf:"varint,3,opt,name=embedding_count,json=embeddingCount,proto3" json:"embedding_count,omitempty"`                             // 埋め込みベクトルのチャンク数
+	EmbeddedDiaryCount      int32                  `protobuf:"varint,4,opt,name=embedded_diary_count,json=embeddedDiaryCount,proto3" json:"embedded_diary_count,omitempty"`               // 埋め込みベクトルを生成済みの日記の件数
+	MonthlySummaryCount     int32                  `protobuf:"varint,5,opt,name=monthly_summary_count,json=monthlySummaryCount,proto3" json:"monthly_summary_count,omitempty"`            // 生成済みの月次要約の件数（LLMの呼び出し）
+	SemanticSearchCount     int32                  `protobuf:"varint,6,opt,name=semantic_search_count,json=semanticSearchCount,proto3" json:"semantic_search_count,omitempty"`            // 意味的検索の回数（LLMの呼び出し）
+	SemanticSearchCount_30D int32                  `protobuf:"varint,7,opt,name=semantic_search_count_30d,json=semanticSearchCount30d,proto3" json:"semantic_search_count_30d,omitempty"` // 過去30日間の意味的検索の回数
+	AttachmentBytes         int64                  `protobuf:"varint,8,opt,name=attachment_bytes,json=attachmentBytes,proto3" json:"attachment_bytes,omitempty"`                          // 添付ファイルの合計サイズ
+	ApiKeyCount             int32                  `protobuf:"varint,9,opt,name=api_key_count,json=apiKeyCount,proto3" json:"api_key_count,omitempty"`                                    // 発行済みのAPIキーの件数
+	LlmKeyConfigured        bool                   `protobuf:"varint,10,opt,name=llm_key_configured,json=llmKeyConfigured,proto3" json:"llm_key_configured,omitempty"`                    // GeminiのAPIキーを設定している
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *UserUsage) Reset() {
+	*x = UserUsage{}
+	mi := &file_admin_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserUsage) ProtoMessage() {}
+
+func (x *UserUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserUsage.ProtoReflect.Descriptor instead.
+func (*UserUsage) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserUsage) GetDiaryCount() int32 {
+	if x != nil {
+		return x.DiaryCount
+	}
+	return 0
+}
+
+func (x *UserUsage) GetTrashedDiaryCount() int32 {
+	if x != nil {
+		return x.TrashedDiaryCount
+	}
+	return 0
+}
+
+func (x *UserUsage) GetEmbeddingCount() int32 {
+	if x != nil {
+		return x.EmbeddingCount
+	}
+	return 0
+}
+
+func (x *UserUsage) GetEmbeddedDiaryCount() int32 {
+	if x != nil {
+		return x.EmbeddedDiaryCount
+	}
+	return 0
+}
+
+func (x *UserUsage) GetMonthlySummaryCount() int32 {
+	if x != nil {
+		return x.MonthlySummaryCount
+	}
+	return 0
+}
+
+func (x *UserUsage) GetSemanticSearchCount() int32 {
+	if x != nil {
+		return x.SemanticSearchCount
+	}
+	return 0
+}
+
+func (x *UserUsage) GetSemanticSearchCount_30D() int32 {
+	if x != nil {
+		return x.SemanticSearchCount_30D
+	}
+	return 0
+}
+
+func (x *UserUsage) GetAttachmentBytes() int64 {
+	if x != nil {
+		return x.AttachmentBytes
+	}
+	return 0
+}
+
+func (x *UserUsage) GetApiKeyCount() int32 {
+	if x != nil {
+		return x.ApiKeyCount
+	}
+	return 0
+}
+
+func (x *UserUsage) GetLlmKeyConfigured() bool {
+	if x != nil {
+		return x.LlmKeyConfigured
+	}
+	return false
+}
+
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`   // メールアドレスか名前の部分一致（空の場合はすべて）
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"` // 前回のnext_cursor（初回は空）
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`  // 返す件数（デフォルト50、最大200）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_admin_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListUsersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*AdminUser           `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_admin_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListUsersResponse) GetUsers() []*AdminUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListUsersResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type GetUserUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserUsageRequest) Reset() {
+	*x = GetUserUsageRequest{}
+	mi := &file_admin_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserUsageRequest) ProtoMessage() {}
+
+func (x *GetUserUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUserUsageRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetUserUsageRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *AdminUser             `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Usage         *UserUsage             `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
+	HourlyMetrics []*UsageHourlyMetric   `protobuf:"bytes,3,rep,name=hourly_metrics,json=hourlyMetrics,proto3" json:"hourly_metrics,omitempty"` // 過去24時間の1時間ごとのジョブの処理件数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserUsageResponse) Reset() {
+	*x = GetUserUsageResponse{}
+	mi := &file_admin_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserUsageResponse) ProtoMessage() {}
+
+func (x *GetUserUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUserUsageResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetUserUsageResponse) GetUser() *AdminUser {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *GetUserUsageResponse) GetUsage() *UserUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *GetUserUsageResponse) GetHourlyMetrics() []*UsageHourlyMetric {
+	if x != nil {
+		return x.HourlyMetrics
+	}
+	return nil
+}
+
+// 1時間ごとのジョブの処理件数
+type UsageHourlyMetric struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp                 int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                                                    // 該当時間のUnixタイムスタンプ
+	MonthlySummariesProcessed int32                  `protobuf:"varint,2,opt,name=monthly_summaries_processed,json=monthlySummariesProcessed,proto3" json:"monthly_summaries_processed,omitempty"` // 生成した月次要約数
+	DiaryEmbeddingsProcessed  int32                  `protobuf:"varint,3,opt,name=diary_embeddings_processed,json=diaryEmbeddingsProcessed,proto3" json:"diary_embeddings_processed,omitempty"`    // 生成したembedding数
+	SemanticSearchesProcessed int32                  `protobuf:"varint,4,opt,name=semantic_searches_processed,json=semanticSearchesProcessed,proto3" json:"semantic_searches_processed,omitempty"` // 意味的検索のAIリクエスト数
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *UsageHourlyMetric) Reset() {
+	*x = UsageHourlyMetric{}
+	mi := &file_admin_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageHourlyMetric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageHourlyMetric) ProtoMessage() {}
+
+func (x *UsageHourlyMetric) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageHourlyMetric.ProtoReflect.Descriptor instead.
+func (*UsageHourlyMetric) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UsageHourlyMetric) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *UsageHourlyMetric) GetMonthlySummariesProcessed() int32 {
+	if x != nil {
+		return x.MonthlySummariesProcessed
+	}
+	return 0
+}
+
+func (x *UsageHourlyMetric) GetDiaryEmbeddingsProcessed() int32 {
+	if x != nil {
+		return x.DiaryEmbeddingsProcessed
+	}
+	return 0
+}
+
+func (x *UsageHourlyMetric) GetSemanticSearchesProcessed() int32 {
+	if x != nil {
+		return x.SemanticSearchesProcessed
+	}
+	return 0
+}
+
+type SetUserRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          UserRole               `protobuf:"varint,2,opt,name=role,proto3,enum=admin.UserRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserRoleRequest) Reset() {
+	*x = SetUserRoleRequest{}
+	mi := &file_admin_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserRoleRequest) ProtoMessage() {}
+
+func (x *SetUserRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetUserRoleRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetUserRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserRoleRequest) GetRole() UserRole {
+	if x != nil {
+		return x.Role
+	}
+	return UserRole_USER_ROLE_USER
+}
+
+type SetUserRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *AdminUser             `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserRoleResponse) Reset() {
+	*x = SetUserRoleResponse{}
+	mi := &file_admin_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserRoleResponse) ProtoMessage() {}
+
+func (x *SetUserRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserRoleResponse.ProtoReflect.Descriptor instead.
+func (*SetUserRoleResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetUserRoleResponse) GetUser() *AdminUser {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DisableUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // 監査ログに残す理由（任意）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableUserRequest) Reset() {
+	*x = DisableUserRequest{}
+	mi := &file_admin_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableUserRequest) ProtoMessage() {}
+
+func (x *DisableUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableUserRequest.ProtoReflect.Descriptor instead.
+func (*DisableUserRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DisableUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DisableUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DisableUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *AdminUser             `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableUserResponse) Reset() {
+	*x = DisableUserResponse{}
+	mi := &file_admin_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableUserResponse) ProtoMessage() {}
+
+func (x *DisableUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableUserResponse.ProtoReflect.Descriptor instead.
+func (*DisableUserResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DisableUserResponse) GetUser() *AdminUser {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type EnableUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableUserRequest) Reset() {
+	*x = EnableUserRequest{}
+	mi := &file_admin_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableUserRequest) ProtoMessage() {}
+
+func (x *EnableUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableUserRequest.ProtoReflect.Descriptor instead.
+func (*EnableUserRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EnableUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type EnableUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *AdminUser             `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableUserResponse) Reset() {
+	*x = EnableUserResponse{}
+	mi := &file_admin_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableUserResponse) ProtoMessage() {}
+
+func (x *EnableUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableUserResponse.ProtoReflect.Descriptor instead.
+func (*EnableUserResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EnableUserResponse) GetUser() *AdminUser {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ForcePasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForcePasswordResetRequest) Reset() {
+	*x = ForcePasswordResetRequest{}
+	mi := &file_admin_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForcePasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForcePasswordResetRequest) ProtoMessage() {}
+
+func (x *ForcePasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForcePasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*ForcePasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ForcePasswordResetRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ForcePasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *AdminUser             `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForcePasswordResetResponse) Reset() {
+	*x = ForcePasswordResetResponse{}
+	mi := &file_admin_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForcePasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForcePasswordResetResponse) ProtoMessage() {}
+
+func (x *ForcePasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForcePasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*ForcePasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ForcePasswordResetResponse) GetUser() *AdminUser {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type RevokeUserCredentialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeUserCredentialsRequest) Reset() {
+	*x = RevokeUserCredentialsRequest{}
+	mi := &file_admin_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeUserCredentialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeUserCredentialsRequest) ProtoMessage() {}
+
+func (x *RevokeUserCredentialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeUserCredentialsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeUserCredentialsRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RevokeUserCredentialsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RevokeUserCredentialsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	RevokedApiKeyCount int32                  `protobuf:"varint,1,opt,name=revoked_api_key_count,json=revokedApiKeyCount,proto3" json:"revoked_api_key_count,omitempty"` // 削除したAPIキーの件数
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RevokeUserCredentialsResponse) Reset() {
+	*x = RevokeUserCredentialsResponse{}
+	mi := &file_admin_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeUserCredentialsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeUserCredentialsResponse) ProtoMessage() {}
+
+func (x *RevokeUserCredentialsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeUserCredentialsResponse.ProtoReflect.Descriptor instead.
+func (*RevokeUserCredentialsResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RevokeUserCredentialsResponse) GetRevokedApiKeyCount() int32 {
+	if x != nil {
+		return x.RevokedApiKeyCount
+	}
+	return 0
+}
+
+type RegenerateUserEmbeddingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateUserEmbeddingsRequest) Reset() {
+	*x = RegenerateUserEmbeddingsRequest{}
+	mi := &file_admin_admin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateUserEmbeddingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateUserEmbeddingsRequest) ProtoMessage() {}
+
+func (x *RegenerateUserEmbeddingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateUserEmbeddingsRequest.ProtoReflect.Descriptor instead.
+func (*RegenerateUserEmbeddingsRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RegenerateUserEmbeddingsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RegenerateUserEmbeddingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QueuedCount   int32                  `protobuf:"varint,1,opt,name=queued_count,json=queuedCount,proto3" json:"queued_count,omitempty"` // キューに追加した日記数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateUserEmbeddingsResponse) Reset() {
+	*x = RegenerateUserEmbeddingsResponse{}
+	mi := &file_admin_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateUserEmbeddingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateUserEmbeddingsResponse) ProtoMessage() {}
+
+func (x *RegenerateUserEmbeddingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateUserEmbeddingsResponse.ProtoReflect.Descriptor instead.
+func (*RegenerateUserEmbeddingsResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RegenerateUserEmbeddingsResponse) GetQueuedCount() int32 {
+	if x != nil {
+		return x.QueuedCount
+	}
+	return 0
+}
+
+// 管理者の操作の記録
+type AuditLog struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AdminUserId   string                 `protobuf:"bytes,2,opt,name=admin_user_id,json=adminUserId,proto3" json:"admin_user_id,omitempty"`
+	AdminEmail    string                 `protobuf:"bytes,3,opt,name=admin_email,json=adminEmail,proto3" json:"admin_email,omitempty"` // 管理者のメールアドレス（管理者が削除された場合は空）
+	TargetUserId  string                 `protobuf:"bytes,4,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	Action        string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`                         // 操作（set_user_role, disable_user, enable_user, force_password_reset, revoke_user_credentials, regenerate_user_embeddings）
+	Detail        string                 `protobuf:"bytes,6,opt,name=detail,proto3" json:"detail,omitempty"`                         // 操作の詳細（JSON）
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // 操作した日時（UNIX秒）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditLog) Reset() {
+	*x = AuditLog{}
+	mi := &file_admin_admin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLog) ProtoMessage() {}
+
+func (x *AuditLog) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLog.ProtoReflect.Descriptor instead.
+func (*AuditLog) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AuditLog) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditLog) GetAdminUserId() string {
+	if x != nil {
+		return x.AdminUserId
+	}
+	return ""
+}
+
+func (x *AuditLog) GetAdminEmail() string {
+	if x != nil {
+		return x.AdminEmail
+	}
+	return ""
+}
+
+func (x *AuditLog) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+func (x *AuditLog) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditLog) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *AuditLog) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ListAuditLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetUserId  string                 `protobuf:"bytes,1,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"` // 指定した場合はそのユーザーに対する操作に絞り込む
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`                                   // 前回のnext_cursor（初回は空）
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                    // 返す件数（デフォルト50、最大200）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditLogsRequest) Reset() {
+	*x = ListAuditLogsRequest{}
+	mi := &file_admin_admin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditLogsRequest) ProtoMessage() {}
+
+func (x *ListAuditLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditLogsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditLogsRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListAuditLogsRequest) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+func (x *ListAuditLogsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListAuditLogsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAuditLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          []*AuditLog            `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditLogsResponse) Reset() {
+	*x = ListAuditLogsResponse{}
+	mi := &file_admin_admin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditLogsResponse) ProtoMessage() {}
+
+func (x *ListAuditLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditLogsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditLogsResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListAuditLogsResponse) GetLogs() []*AuditLog {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *ListAuditLogsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListAuditLogsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+var File_admin_admin_proto protoreflect.FileDescriptor
+
+const file_admin_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x11admin/admin.proto\x12\x05admin\"\xe2\x01\n" +
+	"\tAdminUser\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12#\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x0f.admin.UserRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1f\n" +
+	"\vdisabled_at\x18\x06 \x01(\x03R\n" +
+	"disabledAt\x126\n" +
+	"\x17password_reset_required\x18\a \x01(\bR\x15passwordResetRequired\"\xd7\x03\n" +
+	"\tUserUsage\x12\x1f\n" +
+	"\vdiary_count\x18\x01 \x01(\x05R\n" +
+	"diaryCount\x12.\n" +
+	"\x13trashed_diary_count\x18\x02 \x01(\x05R\x11trashedDiaryCount\x12'\n" +
+	"\x0fembedding_count\x18\x03 \x01(\x05R\x0eembeddingCount\x120\n" +
+	"\x14embedded_diary_count\x18\x04 \x01(\x05R\x12embeddedDiaryCount\x122\n" +
+	"\x15monthly_summary_count\x18\x05 \x01(\x05R\x13monthlySummaryCount\x122\n" +
+	"\x15semantic_search_count\x18\x06 \x01(\x05R\x13semanticSearchCount\x129\n" +
+	"\x19semantic_search_count_30d\x18\a \x01(\x05R\x16semanticSearchCount30d\x12)\n" +
+	"\x10attachment_bytes\x18\b \x01(\x03R\x0fattachmentBytes\x12\"\n" +
+	"\rapi_key_count\x18\t \x01(\x05R\vapiKeyCount\x12,\n" +
+	"\x12llm_key_configured\x18\n" +
+	" \x01(\bR\x10llmKeyConfigured\"V\n" +
+	"\x10ListUsersRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"w\n" +
+	"\x11ListUsersResponse\x12&\n" +
+	"\x05users\x18\x01 \x03(\v2\x10.admin.AdminUserR\x05users\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\".\n" +
+	"\x13GetUserUsageRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xa5\x01\n" +
+	"\x14GetUserUsageResponse\x12$\n" +
+	"\x04user\x18\x01 \x01(\v2\x10.admin.AdminUserR\x04user\x12&\n" +
+	"\x05usage\x18\x02 \x01(\v2\x10.admin.UserUsageR\x05usage\x12?\n" +
+	"\x0ehourly_metrics\x18\x03 \x03(\v2\x18.admin.UsageHourlyMetricR\rhourlyMetrics\"\xef\x01\n" +
+	"\x11UsageHourlyMetric\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12>\n" +
+	"\x1bmonthly_summaries_processed\x18\x02 \x01(\x05R\x19monthlySummariesProcessed\x12<\n" +
+	"\x1adiary_embeddings_processed\x18\x03 \x01(\x05R\x18diaryEmbeddingsProcessed\x12>\n" +
+	"\x1bsemantic_searches_processed\x18\x04 \x01(\x05R\x19semanticSearchesProcessed\"R\n" +
+	"\x12SetUserRoleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x0f.admin.UserRoleR\x04role\";\n" +
+	"\x13SetUserRoleResponse\x12$\n" +
+	"\x04user\x18\x01 \x01(\v2\x10.admin.AdminUserR\x04user\"E\n" +
+	"\x12DisableUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\";\n" +
+	"\x13DisableUserResponse\x12$\n" +
+	"\x04user\x18\x01 \x01(\v2\x10.admin.AdminUserR\x04user\",\n" +
+	"\x11EnableUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\":\n" +
+	"\x12EnableUserResponse\x12$\n" +
+	"\x04user\x18\x01 \x01(\v2\x10.admin.AdminUserR\x04user\"4\n" +
+	"\x19ForcePasswordResetRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"B\n" +
+	"\x1aForcePasswordResetResponse\x12$\n" +
+	"\x04user\x18\x01 \x01(\v2\x10.admin.AdminUserR\x04user\"7\n" +
+	"\x1cRevokeUserCredentialsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"R\n" +
+	"\x1dRevokeUserCredentialsResponse\x121\n" +
+	"\x15revoked_api_key_count\x18\x01 \x01(\x05R\x12revokedApiKeyCount\":\n" +
+	"\x1fRegenerateUserEmbeddingsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"E\n" +
+	" RegenerateUserEmbeddingsResponse\x12!\n" +
+	"\fqueued_count\x18\x01 \x01(\x05R\vqueuedCount\"\xd4\x01\n" +
+	"\bAuditLog\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\"\n" +
+	"\radmin_user_id\x18\x02 \x01(\tR\vadminUserId\x12\x1f\n" +
+	"\vadmin_email\x18\x03 \x01(\tR\n" +
+	"adminEmail\x12$\n" +
+	"\x0etarget_user_id\x18\x04 \x01(\tR\ftargetUserId\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x16\n" +
+	"\x06detail\x18\x06 \x01(\tR\x06detail\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\"j\n" +
+	"\x14ListAuditLogsRequest\x12$\n" +
+	"\x0etarget_user_id\x18\x01 \x01(\tR\ftargetUserId\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"x\n" +
+	"\x15ListAuditLogsResponse\x12#\n" +
+	"\x04logs\x18\x01 \x03(\v2\x0f.admin.AuditLogR\x04logs\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore*3\n" +
+	"\bUserRole\x12\x12\n" +
+	"\x0eUSER_ROLE_USER\x10\x00\x12\x13\n" +
+	"\x0fUSER_ROLE_ADMIN\x10\x012\xde\x05\n" +
+	"\fAdminService\x12>\n" +
+	"\tListUsers\x12\x17.admin.ListUsersRequest\x1a\x18.admin.ListUsersResponse\x12G\n" +
+	"\fGetUserUsage\x12\x1a.admin.GetUserUsageRequest\x1a\x1b.admin.GetUserUsageResponse\x12D\n" +
+	"\vSetUserRole\x12\x19.admin.SetUserRoleRequest\x1a\x1a.admin.SetUserRoleResponse\x12D\n" +
+	"\vDisableUser\x12\x19.admin.DisableUserRequest\x1a\x1a.admin.DisableUserResponse\x12A\n" +
+	"\n" +
+	"EnableUser\x12\x18.admin.EnableUserRequest\x1a\x19.admin.EnableUserResponse\x12Y\n" +
+	"\x12ForcePasswordReset\x12 .admin.ForcePasswordResetRequest\x1a!.admin.ForcePasswordResetResponse\x12b\n" +
+	"\x15RevokeUserCredentials\x12#.admin.RevokeUserCredentialsRequest\x1a$.admin.RevokeUserCredentialsResponse\x12k\n" +
+	"\x18RegenerateUserEmbeddings\x12&.admin.RegenerateUserEmbeddingsRequest\x1a'.admin.RegenerateUserEmbeddingsResponse\x12J\n" +
+	"\rListAuditLogs\x12\x1b.admin.ListAuditLogsRequest\x1a\x1c.admin.ListAuditLogsResponseB@Z>github.com/project-mikan/umi.mikan/backend/infrastructure/grpcb\x06proto3"
+
+var (
+	file_admin_admin_proto_rawDescOnce sync.Once
+	file_admin_admin_proto_rawDescData []byte
+)
+
+func file_admin_admin_proto_rawDescGZIP() []byte {
+	file_admin_admin_proto_rawDescOnce.Do(func() {
+		file_admin_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_admin_admin_proto_rawDesc), len(file_admin_admin_proto_rawDesc)))
+	})
+	return file_admin_admin_proto_rawDescData
+}
+
+var file_admin_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_admin_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_admin_admin_proto_goTypes = []any{
+	(UserRole)(0),                            // 0: admin.UserRole
+	(*AdminUser)(nil),                        // 1: admin.AdminUser
+	(*UserUsage)(nil),                        // 2: admin.UserUsage
+	(*ListUsersRequest)(nil),                 // 3: admin.ListUsersRequest
+	(*ListUsersResponse)(nil),                // 4: admin.ListUsersResponse
+	(*GetUserUsageRequest)(nil),              // 5: admin.GetUserUsageRequest
+	(*GetUserUsageResponse)(nil),             // 6: admin.GetUserUsageResponse
+	(*UsageHourlyMetric)(nil),                // 7: admin.UsageHourlyMetric
+	(*SetUserRoleRequest)(nil),               // 8: admin.SetUserRoleRequest
+	(*SetUserRoleResponse)(nil),              // 9: admin.SetUserRoleResponse
+	(*DisableUserRequest)(nil),               // 10: admin.DisableUserRequest
+	(*DisableUserResponse)(nil),              // 11: admin.DisableUserResponse
+	(*EnableUserRequest)(nil),                // 12: admin.EnableUserRequest
+	(*EnableUserResponse)(nil),               // 13: admin.EnableUserResponse
+	(*ForcePasswordResetRequest)(nil),        // 14: admin.ForcePasswordResetRequest
+	(*ForcePasswordResetResponse)(nil),       // 15: admin.ForcePasswordResetResponse
+	(*RevokeUserCredentialsRequest)(nil),     // 16: admin.RevokeUserCredentialsRequest
+	(*RevokeUserCredentialsResponse)(nil),    // 17: admin.RevokeUserCredentialsResponse
+	(*RegenerateUserEmbeddingsRequest)(nil),  // 18: admin.RegenerateUserEmbeddingsRequest
+	(*RegenerateUserEmbeddingsResponse)(nil), // 19: admin.RegenerateUserEmbeddingsResponse
+	(*AuditLog)(nil),                         // 20: admin.AuditLog
+	(*ListAuditLogsRequest)(nil),             // 21: admin.ListAuditLogsRequest
+	(*ListAuditLogsResponse)(nil),            // 22: admin.ListAuditLogsResponse
+}
+var file_admin_admin_proto_depIdxs = []int32{
+	0,  // 0: admin.AdminUser.role:type_name -> admin.UserRole
+	1,  // 1: admin.ListUsersResponse.users:type_name -> admin.AdminUser
+	1,  // 2: admin.GetUserUsageResponse.user:type_name -> admin.AdminUser
+	2,  // 3: admin.GetUserUsageResponse.usage:type_name -> admin.UserUsage
+	7,  // 4: admin.GetUserUsageResponse.hourly_metrics:type_name -> admin.UsageHourlyMetric
+	0,  // 5: admin.SetUserRoleRequest.role:type_name -> admin.UserRole
+	1,  // 6: admin.SetUserRoleResponse.user:type_name -> admin.AdminUser
+	1,  // 7: admin.DisableUserResponse.user:type_name -> admin.AdminUser
+	1,  // 8: admin.EnableUserResponse.user:type_name -> admin.AdminUser
+	1,  // 9: admin.ForcePasswordResetResponse.user:type_name -> admin.AdminUser
+	20, // 10: admin.ListAuditLogsResponse.logs:type_name -> admin.AuditLog
+	3,  // 11: admin.AdminService.ListUsers:input_type -> admin.ListUsersRequest
+	5,  // 12: admin.AdminService.GetUserUsage:input_type -> admin.GetUserUsageRequest
+	8,  // 13: admin.AdminService.SetUserRole:input_type -> admin.SetUserRoleRequest
+	10, // 14: admin.AdminService.DisableUser:input_type -> admin.DisableUserRequest
+	12, // 15: admin.AdminService.EnableUser:input_type -> admin.EnableUserRequest
+	14, // 16: admin.AdminService.ForcePasswordReset:input_type -> admin.ForcePasswordResetRequest
+	16, // 17: admin.AdminService.RevokeUserCredentials:input_type -> admin.RevokeUserCredentialsRequest
+	18, // 18: admin.AdminService.RegenerateUserEmbeddings:input_type -> admin.RegenerateUserEmbeddingsRequest
+	21, // 19: admin.AdminService.ListAuditLogs:input_type -> admin.ListAuditLogsRequest
+	4,  // 20: admin.AdminService.ListUsers:output_type -> admin.ListUsersResponse
+	6,  // 21: admin.AdminService.GetUserUsage:output_type -> admin.GetUserUsageResponse
+	9,  // 22: admin.AdminService.SetUserRole:output_type -> admin.SetUserRoleResponse
+	11, // 23: admin.AdminService.DisableUser:output_type -> admin.DisableUserResponse
+	13, // 24: admin.AdminService.EnableUser:output_type -> admin.EnableUserResponse
+	15, // 25: admin.AdminService.ForcePasswordReset:output_type -> admin.ForcePasswordResetResponse
+	17, // 26: admin.AdminService.RevokeUserCredentials:output_type -> admin.RevokeUserCredentialsResponse
+	19, // 27: admin.AdminService.RegenerateUserEmbeddings:output_type -> admin.RegenerateUserEmbeddingsResponse
+	22, // 28: admin.AdminService.ListAuditLogs:output_type -> admin.ListAuditLogsResponse
+	20, // [20:29] is the sub-list for method output_type
+	11, // [11:20] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_admin_admin_proto_init() }
+func file_admin_admin_proto_init() {
+	if File_admin_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_admin_proto_rawDesc), len(file_admin_admin_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_admin_admin_proto_goTypes,
+		DependencyIndexes: file_admin_admin_proto_depIdxs,
+		EnumInfos:         file_admin_admin_proto_enumTypes,
+		MessageInfos:      file_admin_admin_proto_msgTypes,
+	}.Build()
+	File_admin_admin_proto = out.File
+	file_admin_admin_proto_goTypes = nil
+	file_admin_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,563 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             v3.21.12
+// source: admin/admin.proto
+
+package grpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AdminService_ListUsers_FullMethodName                = "/admin.AdminService/ListUsers"
+	AdminService_GetUserUsage_FullMethodName             = "/admin.AdminService/GetUserUsage"
+	AdminService_SetUserRole_FullMethodName              = "/admin.AdminService/SetUserRole"
+	AdminService_DisableUser_FullMethodName              = "/admin.AdminService/DisableUser"
+	AdminService_EnableUser_FullMethodName               = "/admin.AdminService/EnableUser"
+	AdminService_ForcePasswordReset_FullMethodName       = "/admin.AdminService/ForcePasswordReset"
+	AdminService_RevokeUserCredentials_FullMethodName    = "/admin.AdminService/RevokeUserCredentials"
+	AdminService_RegenerateUserEmbeddings_FullMethodName = "/admin.AdminService/RegenerateUserEmbeddings"
+	AdminService_ListAuditLogs_FullMethodName            = "/admin.AdminService/ListAuditLogs"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdminService は運用者向けのユーザー管理を提供するサービスです。
+// 管理者（role=USER_ROLE_ADMIN）のユーザーのみ呼び出せます（それ以外はPermissionDenied）。
+// ユーザーを変更する操作はすべて監査ログに記録され、ListAuditLogsで確認できます。
+type AdminServiceClient interface {
+	// ListUsers はユーザーを登録日時の古い順に返します。
+	// queryを指定した場合はメールアドレスか名前に部分一致するユーザーに絞り込みます。
+	//
+	// 例:
+	//
+	//	request: { query: "example.com", limit: 50 }
+	//	response: { users: [{ id: "uuid", email: "user@example.com", ... }], next_cursor: "1700000000_uuid", has_more: true }
+	//
+	// エラー:
+	//   - InvalidArgument: cursorが不正
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	// GetUserUsage はユーザーの日記・埋め込みベクトル・LLMの利用状況と、過去24時間のジョブの処理件数を返します。
+	//
+	// 例:
+	//
+	//	request: { user_id: "uuid" }
+	//	response: { user: { ... }, usage: { diary_count: 120, embedding_count: 300, semantic_search_count: 42, ... } }
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	GetUserUsage(ctx context.Context, in *GetUserUsageRequest, opts ...grpc.CallOption) (*GetUserUsageResponse, error)
+	// SetUserRole はユーザーの権限を変更します。自分自身の権限は変更できません。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	//   - FailedPrecondition: 自分自身の権限を変更しようとした
+	SetUserRole(ctx context.Context, in *SetUserRoleRequest, opts ...grpc.CallOption) (*SetUserRoleResponse, error)
+	// DisableUser はユーザーを無効にします。無効なユーザーはログイン・トークンの更新・APIキーでの認証ができません。
+	// 発行済みのアクセストークンも次のリクエストから使えなくなります（状態は最長10秒キャッシュするため、反映が遅れることがあります）。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	//   - FailedPrecondition: 自分自身を無効にしようとした
+	DisableUser(ctx context.Context, in *DisableUserRequest, opts ...grpc.CallOption) (*DisableUserResponse, error)
+	// EnableUser は無効にしたユーザーを有効に戻します。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	EnableUser(ctx context.Context, in *EnableUserRequest, opts ...grpc.CallOption) (*EnableUserResponse, error)
+	// ForcePasswordReset はユーザーにパスワードの再設定を求めます。
+	// すべてのセッションを取り消し、次のログインではpassword_reset_requiredが返ります。
+	// パスワードを変更するまでトークンの更新はできません。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない、またはパスワードでログインするユーザーでない
+	ForcePasswordReset(ctx context.Context, in *ForcePasswordResetRequest, opts ...grpc.CallOption) (*ForcePasswordResetResponse, error)
+	// RevokeUserCredentials はユーザーのすべてのセッション（リフレッシュトークン）とAPIキーを取り消します。
+	//
+	// 例:
+	//
+	//	request: { user_id: "uuid" }
+	//	response: { revoked_api_key_count: 2 }
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	RevokeUserCredentials(ctx context.Context, in *RevokeUserCredentialsRequest, opts ...grpc.CallOption) (*RevokeUserCredentialsResponse, error)
+	// RegenerateUserEmbeddings はユーザーの埋め込みベクトルが未生成の日記をキューに追加します
+	// （DiaryServiceのRegenerateAllEmbeddingsをユーザーに代わって実行します）。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	//   - FailedPrecondition: ユーザーがGeminiのAPIキーを設定していない、または意味的検索が無効
+	//   - ResourceExhausted: 再生成が実行中
+	RegenerateUserEmbeddings(ctx context.Context, in *RegenerateUserEmbeddingsRequest, opts ...grpc.CallOption) (*RegenerateUserEmbeddingsResponse, error)
+	// ListAuditLogs は管理者の操作の監査ログを新しい順に返します。
+	//
+	// 例:
+	//
+	//	request: { target_user_id: "uuid", limit: 50 }
+	//	response: { logs: [{ action: "disable_user", admin_email: "admin@example.com", ... }], next_cursor: "...", has_more: false }
+	//
+	// エラー:
+	//   - InvalidArgument: cursorが不正
+	ListAuditLogs(ctx context.Context, in *ListAuditLogsRequest, opts ...grpc.CallOption) (*ListAuditLogsResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetUserUsage(ctx context.Context, in *GetUserUsageRequest, opts ...grpc.CallOption) (*GetUserUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserUsageResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetUserUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetUserRole(ctx context.Context, in *SetUserRoleRequest, opts ...grpc.CallOption) (*SetUserRoleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetUserRoleResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetUserRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DisableUser(ctx context.Context, in *DisableUserRequest, opts ...grpc.CallOption) (*DisableUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableUserResponse)
+	err := c.cc.Invoke(ctx, AdminService_DisableUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) EnableUser(ctx context.Context, in *EnableUserRequest, opts ...grpc.CallOption) (*EnableUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnableUserResponse)
+	err := c.cc.Invoke(ctx, AdminService_EnableUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ForcePasswordReset(ctx context.Context, in *ForcePasswordResetRequest, opts ...grpc.CallOption) (*ForcePasswordResetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForcePasswordResetResponse)
+	err := c.cc.Invoke(ctx, AdminService_ForcePasswordReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RevokeUserCredentials(ctx context.Context, in *RevokeUserCredentialsRequest, opts ...grpc.CallOption) (*RevokeUserCredentialsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeUserCredentialsResponse)
+	err := c.cc.Invoke(ctx, AdminService_RevokeUserCredentials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RegenerateUserEmbeddings(ctx context.Context, in *RegenerateUserEmbeddingsRequest, opts ...grpc.CallOption) (*RegenerateUserEmbeddingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegenerateUserEmbeddingsResponse)
+	err := c.cc.Invoke(ctx, AdminService_RegenerateUserEmbeddings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListAuditLogs(ctx context.Context, in *ListAuditLogsRequest, opts ...grpc.CallOption) (*ListAuditLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditLogsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListAuditLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// AdminService は運用者向けのユーザー管理を提供するサービスです。
+// 管理者（role=USER_ROLE_ADMIN）のユーザーのみ呼び出せます（それ以外はPermissionDenied）。
+// ユーザーを変更する操作はすべて監査ログに記録され、ListAuditLogsで確認できます。
+type AdminServiceServer interface {
+	// ListUsers はユーザーを登録日時の古い順に返します。
+	// queryを指定した場合はメールアドレスか名前に部分一致するユーザーに絞り込みます。
+	//
+	// 例:
+	//
+	//	request: { query: "example.com", limit: 50 }
+	//	response: { users: [{ id: "uuid", email: "user@example.com", ... }], next_cursor: "1700000000_uuid", has_more: true }
+	//
+	// エラー:
+	//   - InvalidArgument: cursorが不正
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	// GetUserUsage はユーザーの日記・埋め込みベクトル・LLMの利用状況と、過去24時間のジョブの処理件数を返します。
+	//
+	// 例:
+	//
+	//	request: { user_id: "uuid" }
+	//	response: { user: { ... }, usage: { diary_count: 120, embedding_count: 300, semantic_search_count: 42, ... } }
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	GetUserUsage(context.Context, *GetUserUsageRequest) (*GetUserUsageResponse, error)
+	// SetUserRole はユーザーの権限を変更します。自分自身の権限は変更できません。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	//   - FailedPrecondition: 自分自身の権限を変更しようとした
+	SetUserRole(context.Context, *SetUserRoleRequest) (*SetUserRoleResponse, error)
+	// DisableUser はユーザーを無効にします。無効なユーザーはログイン・トークンの更新・APIキーでの認証ができません。
+	// 発行済みのアクセストークンも次のリクエストから使えなくなります（状態は最長10秒キャッシュするため、反映が遅れることがあります）。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	//   - FailedPrecondition: 自分自身を無効にしようとした
+	DisableUser(context.Context, *DisableUserRequest) (*DisableUserResponse, error)
+	// EnableUser は無効にしたユーザーを有効に戻します。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	EnableUser(context.Context, *EnableUserRequest) (*EnableUserResponse, error)
+	// ForcePasswordReset はユーザーにパスワードの再設定を求めます。
+	// すべてのセッションを取り消し、次のログインではpassword_reset_requiredが返ります。
+	// パスワードを変更するまでトークンの更新はできません。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない、またはパスワードでログインするユーザーでない
+	ForcePasswordReset(context.Context, *ForcePasswordResetRequest) (*ForcePasswordResetResponse, error)
+	// RevokeUserCredentials はユーザーのすべてのセッション（リフレッシュトークン）とAPIキーを取り消します。
+	//
+	// 例:
+	//
+	//	request: { user_id: "uuid" }
+	//	response: { revoked_api_key_count: 2 }
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	RevokeUserCredentials(context.Context, *RevokeUserCredentialsRequest) (*RevokeUserCredentialsResponse, error)
+	// RegenerateUserEmbeddings はユーザーの埋め込みベクトルが未生成の日記をキューに追加します
+	// （DiaryServiceのRegenerateAllEmbeddingsをユーザーに代わって実行します）。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	//   - FailedPrecondition: ユーザーがGeminiのAPIキーを設定していない、または意味的検索が無効
+	//   - ResourceExhausted: 再生成が実行中
+	RegenerateUserEmbeddings(context.Context, *RegenerateUserEmbeddingsRequest) (*RegenerateUserEmbeddingsResponse, error)
+	// ListAuditLogs は管理者の操作の監査ログを新しい順に返します。
+	//
+	// 例:
+	//
+	//	request: { target_user_id: "uuid", limit: 50 }
+	//	response: { logs: [{ action: "disable_user", admin_email: "admin@example.com", ... }], next_cursor: "...", has_more: false }
+	//
+	// エラー:
+	//   - InvalidArgument: cursorが不正
+	ListAuditLogs(context.Context, *ListAuditLogsRequest) (*ListAuditLogsResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedAdminServiceServer) GetUserUsage(context.Context, *GetUserUsageRequest) (*GetUserUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserUsage not implemented")
+}
+func (UnimplementedAdminServiceServer) SetUserRole(context.Context, *SetUserRoleRequest) (*SetUserRoleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetUserRole not implemented")
+}
+func (UnimplementedAdminServiceServer) DisableUser(context.Context, *DisableUserRequest) (*DisableUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DisableUser not implemented")
+}
+func (UnimplementedAdminServiceServer) EnableUser(context.Context, *EnableUserRequest) (*EnableUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EnableUser not implemented")
+}
+func (UnimplementedAdminServiceServer) ForcePasswordReset(context.Context, *ForcePasswordResetRequest) (*ForcePasswordResetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ForcePasswordReset not implemented")
+}
+func (UnimplementedAdminServiceServer) RevokeUserCredentials(context.Context, *RevokeUserCredentialsRequest) (*RevokeUserCredentialsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeUserCredentials not implemented")
+}
+func (UnimplementedAdminServiceServer) RegenerateUserEmbeddings(context.Context, *RegenerateUserEmbeddingsRequest) (*RegenerateUserEmbeddingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegenerateUserEmbeddings not implemented")
+}
+func (UnimplementedAdminServiceServer) ListAuditLogs(context.Context, *ListAuditLogsRequest) (*ListAuditLogsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAuditLogs not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call panics, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetUserUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetUserUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetUserUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetUserUsage(ctx, req.(*GetUserUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetUserRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetUserRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetUserRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetUserRole(ctx, req.(*SetUserRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DisableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DisableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DisableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DisableUser(ctx, req.(*DisableUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_EnableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).EnableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_EnableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).EnableUser(ctx, req.(*EnableUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ForcePasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForcePasswordResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ForcePasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ForcePasswordReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ForcePasswordReset(ctx, req.(*ForcePasswordResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RevokeUserCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeUserCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RevokeUserCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RevokeUserCredentials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RevokeUserCredentials(ctx, req.(*RevokeUserCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RegenerateUserEmbeddings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegenerateUserEmbeddingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RegenerateUserEmbeddings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RegenerateUserEmbeddings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RegenerateUserEmbeddings(ctx, req.(*RegenerateUserEmbeddingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListAuditLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListAuditLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListAuditLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListAuditLogs(ctx, req.(*ListAuditLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "admin.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListUsers",
+			Handler:    _AdminService_ListUsers_Handler,
+		},
+		{
+			MethodName: "GetUserUsage",
+			Handler:    _AdminService_GetUserUsage_Handler,
+		},
+		{
+			MethodName: "SetUserRole",
+			Handler:    _AdminService_SetUserRole_Handler,
+		},
+		{
+			MethodName: "DisableUser",
+			Handler:    _AdminService_DisableUser_Handler,
+		},
+		{
+			MethodName: "EnableUser",
+			Handler:    _AdminService_EnableUser_Handler,
+		},
+		{
+			MethodName: "ForcePasswordReset",
+			Handler:    _AdminService_ForcePasswordReset_Handler,
+		},
+		{
+			MethodName: "RevokeUserCredentials",
+			Handler:    _AdminService_RevokeUserCredentials_Handler,
+		},
+		{
+			MethodName: "RegenerateUserEmbeddings",
+			Handler:    _AdminService_RegenerateUserEmbeddings_Handler,
+		},
+		{
+			MethodName: "ListAuditLogs",
+			Handler:    _AdminService_ListAuditLogs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin/admin.proto",
+}
@@ -19,10 +19,39 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_GetRegistrationConfig_FullMethodName = "/auth.AuthService/GetRegistrationConfig"
-	AuthService_RegisterByPassword_FullMethodName    = "/auth.AuthService/RegisterByPassword"
-	AuthService_LoginByPassword_FullMethodName       = "/auth.AuthService/LoginByPassword"
-	AuthService_RefreshAccessToken_FullMethodName    = "/auth.AuthService/RefreshAccessToken"
+	AuthService_GetRegistrationConfig_FullMethodName     = "/auth.AuthService/GetRegistrationConfig"
+	AuthService_RegisterByPassword_FullMethodName        = "/auth.AuthService/RegisterByPassword"
+	AuthService_LoginByPassword_FullMethodName           = "/auth.AuthService/LoginByPassword"
+	AuthService_RefreshAccessToken_FullMethodName        = "/auth.AuthService/RefreshAccessToken"
+	AuthService_ListOIDCProviders_FullMethodName         = "/auth.AuthService/ListOIDCProviders"
+	AuthService_StartOIDCLogin_FullMethodName            = "/auth.AuthService/StartOIDCLogin"
+	AuthService_CompleteOIDCLogin_FullMethodName         = "/auth.AuthService/CompleteOIDCLogin"
+	AuthService_StartOIDCLink_FullMethodName             = "/auth.AuthService/StartOIDCLink"
+	AuthService_CompleteOIDCLink_FullMethodName          = "/auth.AuthService/CompleteOIDCLink"
+	AuthService_ListLinkedOIDCProviders_FullMethodName   = "/auth.AuthService/ListLinkedOIDCProviders"
+	AuthService_UnlinkOIDCProvider_FullMethodName        = "/auth.AuthService/UnlinkOIDCProvider"
+	AuthService_VerifyMfa_FullMethodName                 = "/auth.AuthService/VerifyMfa"
+	AuthService_GetMfaStatus_FullMethodName              = "/auth.AuthService/GetMfaStatus"
+	AuthService_StartTotpEnrollment_FullMethodName       = "/auth.AuthService/StartTotpEnrollment"
+	AuthService_ConfirmTotpEnrollment_FullMethodName     = "/auth.AuthService/ConfirmTotpEnrollment"
+	AuthService_RegenerateRecoveryCodes_FullMethodName   = "/auth.AuthService/RegenerateRecoveryCodes"
+	AuthService_DisableMfa_FullMethodName                = "/auth.AuthService/DisableMfa"
+	AuthService_StartPasskeyRegistration_FullMethodName  = "/auth.AuthService/StartPasskeyRegistration"
+	AuthService_FinishPasskeyRegistration_FullMethodName = "/auth.AuthService/FinishPasskeyRegistration"
+	AuthService_StartPasskeyLogin_FullMethodName         = "/auth.AuthService/StartPasskeyLogin"
+	AuthService_FinishPasskeyLogin_FullMethodName        = "/auth.AuthService/FinishPasskeyLogin"
+	AuthService_StartPasskeyMfa_FullMethodName           = "/auth.AuthService/StartPasskeyMfa"
+	AuthService_FinishPasskeyMfa_FullMethodName          = "/auth.AuthService/FinishPasskeyMfa"
+	AuthService_ListPasskeys_FullMethodName              = "/auth.AuthService/ListPasskeys"
+	AuthService_DeletePasskey_FullMethodName             = "/auth.AuthService/DeletePasskey"
+	AuthService_RequestPasswordReset_FullMethodName      = "/auth.AuthService/RequestPasswordReset"
+	AuthService_ResetPassword_FullMethodName             = "/auth.AuthService/ResetPassword"
+	AuthService_VerifyEmail_FullMethodName               = "/auth.AuthService/VerifyEmail"
+	AuthService_RequestEmailVerification_FullMethodName  = "/auth.AuthService/RequestEmailVerification"
+	AuthService_CreateInvitation_FullMethodName          = "/auth.AuthService/CreateInvitation"
+	AuthService_ListInvitations_FullMethodName           = "/auth.AuthService/ListInvitations"
+	AuthService_RevokeInvitation_FullMethodName          = "/auth.AuthService/RevokeInvitation"
+	AuthService_CancelAccountDeletion_FullMethodName     = "/auth.AuthService/CancelAccountDeletion"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -33,7 +62,7 @@ const (
 // JWT（Access Token + Refresh Token）ベースの認証を提供します。
 type AuthServiceClient interface {
 	// GetRegistrationConfig は新規登録に必要な設定情報を取得します。
-	// REGISTER_KEY環境変数が設定されている場合、登録キーが必要かどうかを返します。
+	// 招待制（INVITATION_REQUIRED、またはREGISTER_KEYが設定されている）の場合、登録に招待コードが必要かどうかを返します。
 	//
 	// 例:
 	//
@@ -43,25 +72,26 @@ type AuthServiceClient interface {
 	// エラー: なし（常に成功）
 	GetRegistrationConfig(ctx context.Context, in *GetRegistrationConfigRequest, opts ...grpc.CallOption) (*GetRegistrationConfigResponse, error)
 	// RegisterByPassword はメールアドレスとパスワードで新規ユーザーを登録します。
-	// REGISTER_KEYが設定されている場合は、正しい登録キーが必要です。
+	// 招待制の場合は、有効な招待コード（CreateInvitationで発行）が必要です。
 	//
 	// 例:
 	//
-	//	request: { email: "user@example.com", password: "pass123", name: "太郎", register_key: "secret" }
+	//	request: { email: "user@example.com", password: "pass123", name: "太郎", register_key: "abcd-efgh-ijkl-mnop" }
 	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
 	//
 	// エラー:
 	//   - AlreadyExists: メールアドレスが既に登録されている
-	//   - InvalidArgument: 登録キーが必須だが提供されていない
-	//   - PermissionDenied: 登録キーが不正
+	//   - PermissionDenied: 招待コードが必須だが提供されていない、または不正・期限切れ・使用回数の上限に達した
 	//   - InvalidArgument: バリデーションエラー（メール形式、パスワード長など）
 	RegisterByPassword(ctx context.Context, in *RegisterByPasswordRequest, opts ...grpc.CallOption) (*AuthResponse, error)
 	// LoginByPassword はメールアドレスとパスワードでログインします。
+	// 2段階認証を有効にしているユーザーはトークンを発行せず、mfa_required と mfa_token を返します（VerifyMfaで続ける）。
 	//
 	// 例:
 	//
 	//	request: { email: "user@example.com", password: "pass123" }
 	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//	response（2段階認証）: { mfa_required: true, mfa_token: "..." }
 	//
 	// エラー:
 	//   - Unauthenticated: メールアドレスまたはパスワードが不正
@@ -78,6 +108,287 @@ type AuthServiceClient interface {
 	// エラー:
 	//   - Unauthenticated: Refresh Tokenが無効または期限切れ
 	RefreshAccessToken(ctx context.Context, in *RefreshAccessTokenRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	// ListOIDCProviders はOpenID Connectでログインできるプロバイダーの一覧を取得します。
+	// OIDC_PROVIDERS環境変数で設定したプロバイダーを設定した順に返します。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { providers: [{ id: "google", name: "Google" }] }
+	//
+	// エラー: なし（設定がない場合は空）
+	ListOIDCProviders(ctx context.Context, in *ListOIDCProvidersRequest, opts ...grpc.CallOption) (*ListOIDCProvidersResponse, error)
+	// StartOIDCLogin はOpenID Connectのログインを開始し、IdPの認可URLを返します。
+	// クライアントは認可URLにリダイレクトし、コールバックで受け取ったstateとcodeでCompleteOIDCLoginを呼び出します（10分以内）。
+	//
+	// 例:
+	//
+	//	request: { provider: "google" }
+	//	response: { authorization_url: "https://accounts.google.com/o/oauth2/v2/auth?...", state: "..." }
+	//
+	// エラー:
+	//   - NotFound: プロバイダーが設定されていない
+	//   - Unavailable: IdPのdiscoveryに失敗した
+	StartOIDCLogin(ctx context.Context, in *StartOIDCLoginRequest, opts ...grpc.CallOption) (*StartOIDCLoginResponse, error)
+	// CompleteOIDCLogin はIdPからのコールバックのstateとcodeでログインを完了し、トークンを発行します。
+	// プロバイダーのアカウントが未連携の場合、確認済みの同じメールアドレスのユーザーがいれば連携してログインし、
+	// いなければ新規ユーザーとして登録します（招待制の場合は招待コードが必要）。
+	//
+	// 例:
+	//
+	//	request: { state: "...", code: "...", register_key: "" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - InvalidArgument: stateが無効・期限切れ、またはStartOIDCLinkで開始したstate
+	//   - Unauthenticated: 認可コードの交換またはIDトークンの検証に失敗した
+	//   - FailedPrecondition: 未連携で、IdPがメールアドレスを確認していない
+	//   - PermissionDenied: アカウントが無効、または招待コードが不正
+	CompleteOIDCLogin(ctx context.Context, in *CompleteOIDCLoginRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	// StartOIDCLink はログイン中のユーザーにプロバイダーのアカウントを連携するため、IdPの認可URLを返します（要認証）。
+	// コールバックで受け取ったstateとcodeでCompleteOIDCLinkを呼び出します。
+	//
+	// エラー:
+	//   - NotFound: プロバイダーが設定されていない
+	//   - AlreadyExists: このプロバイダーは既に連携している
+	StartOIDCLink(ctx context.Context, in *StartOIDCLoginRequest, opts ...grpc.CallOption) (*StartOIDCLoginResponse, error)
+	// CompleteOIDCLink はIdPからのコールバックのstateとcodeで、ログイン中のユーザーにプロバイダーのアカウントを連携します（要認証）。
+	// メールアドレスが異なるプロバイダーのアカウントも連携できます。
+	//
+	// 例:
+	//
+	//	request: { state: "...", code: "..." }
+	//	response: { provider: { provider: "google", email: "user@gmail.com", created_at: 1700000000 } }
+	//
+	// エラー:
+	//   - InvalidArgument: stateが無効・期限切れ、または別のユーザーが開始したstate
+	//   - Unauthenticated: 認可コードの交換またはIDトークンの検証に失敗した
+	//   - AlreadyExists: プロバイダーのアカウントが別のユーザーに連携済み、またはこのプロバイダーは既に連携している
+	CompleteOIDCLink(ctx context.Context, in *CompleteOIDCLinkRequest, opts ...grpc.CallOption) (*CompleteOIDCLinkResponse, error)
+	// ListLinkedOIDCProviders はログイン中のユーザーに連携しているプロバイダーの一覧を取得します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { providers: [{ provider: "google", email: "user@gmail.com", created_at: 1700000000 }], has_password: true }
+	ListLinkedOIDCProviders(ctx context.Context, in *ListLinkedOIDCProvidersRequest, opts ...grpc.CallOption) (*ListLinkedOIDCProvidersResponse, error)
+	// UnlinkOIDCProvider はログイン中のユーザーからプロバイダーの連携を解除します（要認証）。
+	//
+	// エラー:
+	//   - NotFound: このプロバイダーは連携していない
+	//   - FailedPrecondition: パスワード・他に連携しているプロバイダー・パスキーのいずれもない（ログインできなくなる）
+	UnlinkOIDCProvider(ctx context.Context, in *UnlinkOIDCProviderRequest, opts ...grpc.CallOption) (*UnlinkOIDCProviderResponse, error)
+	// VerifyMfa はログインの2段階目として、認証アプリのコードかリカバリーコードを確認してトークンを発行します。
+	// mfa_tokenはパスワードの確認から5分間有効で、コードを間違えても有効期限までは再入力できます。
+	// ログインと同じレート制限を、クライアントとユーザーごとに適用します。
+	//
+	// 例:
+	//
+	//	request: { mfa_token: "...", code: "123456" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - Unauthenticated: mfa_tokenが無効・期限切れ、またはコードが不正
+	//   - ResourceExhausted: 試行回数の上限を超えた
+	VerifyMfa(ctx context.Context, in *VerifyMfaRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	// GetMfaStatus はログイン中のユーザーの2段階認証の状態を取得します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { enabled: true, recovery_codes_remaining: 8 }
+	GetMfaStatus(ctx context.Context, in *GetMfaStatusRequest, opts ...grpc.CallOption) (*GetMfaStatusResponse, error)
+	// StartTotpEnrollment は認証アプリの登録を開始し、秘密鍵とQRコードにするURIを返します（要認証）。
+	// ConfirmTotpEnrollmentで認証アプリのコードを確認するまで2段階認証は有効になりません。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { secret: "JBSWY3DPEHPK3PXP...", provisioning_uri: "otpauth://totp/umi.mikan:user@example.com?..." }
+	//
+	// エラー:
+	//   - AlreadyExists: 既に2段階認証を有効にしている
+	StartTotpEnrollment(ctx context.Context, in *StartTotpEnrollmentRequest, opts ...grpc.CallOption) (*StartTotpEnrollmentResponse, error)
+	// ConfirmTotpEnrollment は認証アプリのコードを確認して2段階認証を有効にし、リカバリーコードを返します（要認証）。
+	// リカバリーコードはこのレスポンスでのみ返します。
+	//
+	// 例:
+	//
+	//	request: { code: "123456" }
+	//	response: { recovery_codes: ["abcde-fghij", ...] }
+	//
+	// エラー:
+	//   - FailedPrecondition: 登録を開始していない、または既に有効
+	//   - InvalidArgument: コードが不正
+	ConfirmTotpEnrollment(ctx context.Context, in *ConfirmTotpEnrollmentRequest, opts ...grpc.CallOption) (*ConfirmTotpEnrollmentResponse, error)
+	// RegenerateRecoveryCodes は認証アプリのコードを確認してリカバリーコードを作り直します（要認証）。
+	// 以前のリカバリーコードは使えなくなります。
+	//
+	// エラー:
+	//   - FailedPrecondition: 2段階認証を有効にしていない
+	//   - InvalidArgument: コードが不正
+	RegenerateRecoveryCodes(ctx context.Context, in *RegenerateRecoveryCodesRequest, opts ...grpc.CallOption) (*ConfirmTotpEnrollmentResponse, error)
+	// DisableMfa は2段階認証を無効にします（要認証）。
+	// 現在のパスワードか認証アプリのコードのどちらかが必要です（パスワードを持たないユーザーはコードのみ）。
+	//
+	// 例:
+	//
+	//	request: { password: "pass123" } または { code: "123456" }
+	//	response: {}
+	//
+	// エラー:
+	//   - FailedPrecondition: 2段階認証を有効にしていない
+	//   - InvalidArgument: パスワードもコードもない
+	//   - PermissionDenied: パスワードまたはコードが不正
+	DisableMfa(ctx context.Context, in *DisableMfaRequest, opts ...grpc.CallOption) (*DisableMfaResponse, error)
+	// StartPasskeyRegistration はパスキーの登録を開始し、navigator.credentials.create に渡すオプションを返します（要認証）。
+	// オプションは PublicKeyCredentialCreationOptionsJSON のJSON文字列で、チャレンジは5分間有効です。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { options_json: "{\"challenge\":\"...\",\"rp\":{\"id\":\"umi.mikan.example\",...},...}" }
+	StartPasskeyRegistration(ctx context.Context, in *StartPasskeyRegistrationRequest, opts ...grpc.CallOption) (*PasskeyOptionsResponse, error)
+	// FinishPasskeyRegistration は認証器のレスポンスを検証してパスキーを登録します（要認証）。
+	//
+	// 例:
+	//
+	//	request: { credential_json: "{\"id\":\"...\",\"response\":{\"attestationObject\":\"...\",...}}", name: "iPhone" }
+	//	response: { passkey: { id: "...", name: "iPhone", created_at: 1700000000 } }
+	//
+	// エラー:
+	//   - InvalidArgument: レスポンスが不正、チャレンジが無効・期限切れ、または名前が長すぎる
+	//   - AlreadyExists: このパスキーは登録済み
+	FinishPasskeyRegistration(ctx context.Context, in *FinishPasskeyRegistrationRequest, opts ...grpc.CallOption) (*FinishPasskeyRegistrationResponse, error)
+	// StartPasskeyLogin はパスキーでのログインを開始し、navigator.credentials.get に渡すオプションを返します。
+	// 端末に保存されたパスキーから選ばせるため、メールアドレスは不要です。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { options_json: "{\"challenge\":\"...\",\"rpId\":\"umi.mikan.example\",...}" }
+	StartPasskeyLogin(ctx context.Context, in *StartPasskeyLoginRequest, opts ...grpc.CallOption) (*PasskeyOptionsResponse, error)
+	// FinishPasskeyLogin は認証器のレスポンスを検証してトークンを発行します。
+	// 生体認証・PINで確認したパスキーは2つの要素を満たすため、2段階認証を有効にしていても2段階目は求めません。
+	//
+	// 例:
+	//
+	//	request: { credential_json: "{\"id\":\"...\",\"response\":{\"signature\":\"...\",...}}" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - InvalidArgument: レスポンスが不正
+	//   - Unauthenticated: チャレンジが無効・期限切れ、未登録のパスキー、または署名が不正
+	//   - ResourceExhausted: 試行回数の上限を超えた
+	FinishPasskeyLogin(ctx context.Context, in *FinishPasskeyLoginRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	// StartPasskeyMfa はログインの2段階目としてパスキーでの認証を開始します。
+	// mfa_methodsに "passkey" を含むAuthResponseのmfa_tokenが必要です。
+	//
+	// エラー:
+	//   - Unauthenticated: mfa_tokenが無効・期限切れ
+	//   - FailedPrecondition: パスキーを登録していない
+	StartPasskeyMfa(ctx context.Context, in *StartPasskeyMfaRequest, opts ...grpc.CallOption) (*PasskeyOptionsResponse, error)
+	// FinishPasskeyMfa は認証器のレスポンスを検証してトークンを発行します（VerifyMfaのパスキー版）。
+	// VerifyMfaと同じレート制限を、クライアントとユーザーごとに適用します。
+	//
+	// エラー:
+	//   - InvalidArgument: レスポンスが不正
+	//   - Unauthenticated: mfa_token・チャレンジが無効・期限切れ、別のユーザーのパスキー、または署名が不正
+	//   - ResourceExhausted: 試行回数の上限を超えた
+	FinishPasskeyMfa(ctx context.Context, in *FinishPasskeyMfaRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	// ListPasskeys はログイン中のユーザーが登録したパスキーの一覧を取得します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { passkeys: [{ id: "...", name: "iPhone", transports: ["internal", "hybrid"], created_at: 1700000000, last_used_at: 1700001000 }] }
+	ListPasskeys(ctx context.Context, in *ListPasskeysRequest, opts ...grpc.CallOption) (*ListPasskeysResponse, error)
+	// DeletePasskey はログイン中のユーザーのパスキーを削除します（要認証）。
+	//
+	// エラー:
+	//   - NotFound: パスキーが存在しない、または他のユーザーのパスキー
+	//   - FailedPrecondition: パスワードも連携しているプロバイダーもなく、最後のパスキー（ログインできなくなる）
+	DeletePasskey(ctx context.Context, in *DeletePasskeyRequest, opts ...grpc.CallOption) (*DeletePasskeyResponse, error)
+	// RequestPasswordReset はパスワードの再設定のリンクをメールで送ります。
+	// メールアドレスが登録されているかどうかを知られないよう、登録されていない場合も成功を返します。
+	// リンクは1時間有効で、1回だけ使えます。
+	//
+	// 例:
+	//
+	//	request: { email: "user@example.com" }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: メールアドレスが空
+	//   - ResourceExhausted: 送信回数の上限を超えた
+	RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*RequestPasswordResetResponse, error)
+	// ResetPassword はメールのリンクのトークンでパスワードを再設定します。
+	// 再設定するとメールアドレスも確認済みになり、それまでのセッション（リフレッシュトークン）は使えなくなります。
+	// パスワードを持たないユーザー（OpenID Connectで登録）はパスワードを設定できます。
+	//
+	// 例:
+	//
+	//	request: { token: "...", new_password: "newPassword123" }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: トークンが不正・期限切れ・使用済み、またはパスワードが短い
+	//   - PermissionDenied: アカウントが無効
+	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error)
+	// VerifyEmail はメールのリンクのトークンでメールアドレスを確認済みにします。
+	// リンクは24時間有効で、1回だけ使えます。リンクを送った後にメールアドレスを変えた場合は使えません。
+	//
+	// 例:
+	//
+	//	request: { token: "..." }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: トークンが不正・期限切れ・使用済み
+	VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error)
+	// RequestEmailVerification はログイン中のユーザーに確認のリンクをメールで送り直します（要認証）。
+	//
+	// エラー:
+	//   - FailedPrecondition: 既に確認済み
+	//   - ResourceExhausted: 送信回数の上限を超えた
+	RequestEmailVerification(ctx context.Context, in *RequestEmailVerificationRequest, opts ...grpc.CallOption) (*RequestEmailVerificationResponse, error)
+	// CreateInvitation は新規登録に使う招待コードを発行します（要認証）。
+	// コードは発行時のレスポンスでのみ返します（DBにはハッシュのみを保存）。
+	// 使用回数は1〜10回（管理者は1000回まで）、有効期限は1〜30日（管理者は365日まで）で、省略時は1回・7日です。
+	//
+	// 例:
+	//
+	//	request: { max_uses: 3, expires_in_days: 7, note: "家族用" }
+	//	response: { code: "abcd-efgh-ijkl-mnop", invitation: { id: "...", max_uses: 3, use_count: 0, expires_at: 1700604800, ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: 使用回数・有効期限が範囲外、またはメモが長すぎる
+	//   - PermissionDenied: アカウントが無効
+	CreateInvitation(ctx context.Context, in *CreateInvitationRequest, opts ...grpc.CallOption) (*CreateInvitationResponse, error)
+	// ListInvitations はログイン中のユーザーが発行した招待コードを、新しい順に使ったユーザーとともに返します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { invitations: [{ id: "...", note: "家族用", max_uses: 3, use_count: 1, uses: [{ user_id: "...", user_name: "太郎", used_at: 1700001000 }], ... }] }
+	ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*ListInvitationsResponse, error)
+	// RevokeInvitation はログイン中のユーザーが発行した招待コードを取り消します（要認証）。
+	// 取り消した招待コードでは登録できなくなります（登録済みのユーザーには影響しません）。
+	//
+	// エラー:
+	//   - NotFound: 招待コードが存在しない、または他のユーザーが発行した
+	RevokeInvitation(ctx context.Context, in *RevokeInvitationRequest, opts ...grpc.CallOption) (*RevokeInvitationResponse, error)
+	// CancelAccountDeletion はメールのリンクのトークンでアカウントの削除を取り消します。
+	// リンクは削除の予定日時まで有効です。取り消した後は改めてログインしてください（APIキーは再発行が必要です）。
+	//
+	// 例:
+	//
+	//	request: { token: "..." }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: トークンが不正・期限切れ・使用済み、または削除が既に取り消されている
+	CancelAccountDeletion(ctx context.Context, in *CancelAccountDeletionRequest, opts ...grpc.CallOption) (*CancelAccountDeletionResponse, error)
 }
 
 type authServiceClient struct {
@@ -88,210 +399,1391 @@ func NewAuthServiceClient(cc grpc.ClientConnInterface) AuthServiceClient {
 	return &authServiceClient{cc}
 }
 
-func (c *authServiceClient) GetRegistrationConfig(ctx context.Context, in *GetRegistrationConfigRequest, opts ...grpc.CallOption) (*GetRegistrationConfigResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetRegistrationConfigResponse)
-	err := c.cc.Invoke(ctx, AuthService_GetRegistrationConfig_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func (c *authServiceClient) GetRegistrationConfig(ctx context.Context, in *GetRegistrationConfigRequest, opts ...grpc.CallOption) (*GetRegistrationConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRegistrationConfigResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetRegistrationConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RegisterByPassword(ctx context.Context, in *RegisterByPasswordRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_RegisterByPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) LoginByPassword(ctx context.Context, in *LoginByPasswordRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_LoginByPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RefreshAccessToken(ctx context.Context, in *RefreshAccessTokenRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_RefreshAccessToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListOIDCProviders(ctx context.Context, in *ListOIDCProvidersRequest, opts ...grpc.CallOption) (*ListOIDCProvidersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOIDCProvidersResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListOIDCProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) StartOIDCLogin(ctx context.Context, in *StartOIDCLoginRequest, opts ...grpc.CallOption) (*StartOIDCLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartOIDCLoginResponse)
+	err := c.cc.Invoke(ctx, AuthService_StartOIDCLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CompleteOIDCLogin(ctx context.Context, in *CompleteOIDCLoginRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_CompleteOIDCLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) StartOIDCLink(ctx context.Context, in *StartOIDCLoginRequest, opts ...grpc.CallOption) (*StartOIDCLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartOIDCLoginResponse)
+	err := c.cc.Invoke(ctx, AuthService_StartOIDCLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CompleteOIDCLink(ctx context.Context, in *CompleteOIDCLinkRequest, opts ...grpc.CallOption) (*CompleteOIDCLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteOIDCLinkResponse)
+	err := c.cc.Invoke(ctx, AuthService_CompleteOIDCLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListLinkedOIDCProviders(ctx context.Context, in *ListLinkedOIDCProvidersRequest, opts ...grpc.CallOption) (*ListLinkedOIDCProvidersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLinkedOIDCProvidersResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListLinkedOIDCProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) UnlinkOIDCProvider(ctx context.Context, in *UnlinkOIDCProviderRequest, opts ...grpc.CallOption) (*UnlinkOIDCProviderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlinkOIDCProviderResponse)
+	err := c.cc.Invoke(ctx, AuthService_UnlinkOIDCProvider_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) VerifyMfa(ctx context.Context, in *VerifyMfaRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_VerifyMfa_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetMfaStatus(ctx context.Context, in *GetMfaStatusRequest, opts ...grpc.CallOption) (*GetMfaStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMfaStatusResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetMfaStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) StartTotpEnrollment(ctx context.Context, in *StartTotpEnrollmentRequest, opts ...grpc.CallOption) (*StartTotpEnrollmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartTotpEnrollmentResponse)
+	err := c.cc.Invoke(ctx, AuthService_StartTotpEnrollment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ConfirmTotpEnrollment(ctx context.Context, in *ConfirmTotpEnrollmentRequest, opts ...grpc.CallOption) (*ConfirmTotpEnrollmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmTotpEnrollmentResponse)
+	err := c.cc.Invoke(ctx, AuthService_ConfirmTotpEnrollment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RegenerateRecoveryCodes(ctx context.Context, in *RegenerateRecoveryCodesRequest, opts ...grpc.CallOption) (*ConfirmTotpEnrollmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmTotpEnrollmentResponse)
+	err := c.cc.Invoke(ctx, AuthService_RegenerateRecoveryCodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DisableMfa(ctx context.Context, in *DisableMfaRequest, opts ...grpc.CallOption) (*DisableMfaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableMfaResponse)
+	err := c.cc.Invoke(ctx, AuthService_DisableMfa_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) StartPasskeyRegistration(ctx context.Context, in *StartPasskeyRegistrationRequest, opts ...grpc.CallOption) (*PasskeyOptionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PasskeyOptionsResponse)
+	err := c.cc.Invoke(ctx, AuthService_StartPasskeyRegistration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) FinishPasskeyRegistration(ctx context.Context, in *FinishPasskeyRegistrationRequest, opts ...grpc.CallOption) (*FinishPasskeyRegistrationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FinishPasskeyRegistrationResponse)
+	err := c.cc.Invoke(ctx, AuthService_FinishPasskeyRegistration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) StartPasskeyLogin(ctx context.Context, in *StartPasskeyLoginRequest, opts ...grpc.CallOption) (*PasskeyOptionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PasskeyOptionsResponse)
+	err := c.cc.Invoke(ctx, AuthService_StartPasskeyLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) FinishPasskeyLogin(ctx context.Context, in *FinishPasskeyLoginRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_FinishPasskeyLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) StartPasskeyMfa(ctx context.Context, in *StartPasskeyMfaRequest, opts ...grpc.CallOption) (*PasskeyOptionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PasskeyOptionsResponse)
+	err := c.cc.Invoke(ctx, AuthService_StartPasskeyMfa_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) FinishPasskeyMfa(ctx context.Context, in *FinishPasskeyMfaRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_FinishPasskeyMfa_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListPasskeys(ctx context.Context, in *ListPasskeysRequest, opts ...grpc.CallOption) (*ListPasskeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPasskeysResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListPasskeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DeletePasskey(ctx context.Context, in *DeletePasskeyRequest, opts ...grpc.CallOption) (*DeletePasskeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePasskeyResponse)
+	err := c.cc.Invoke(ctx, AuthService_DeletePasskey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*RequestPasswordResetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestPasswordResetResponse)
+	err := c.cc.Invoke(ctx, AuthService_RequestPasswordReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetPasswordResponse)
+	err := c.cc.Invoke(ctx, AuthService_ResetPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyEmailResponse)
+	err := c.cc.Invoke(ctx, AuthService_VerifyEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RequestEmailVerification(ctx context.Context, in *RequestEmailVerificationRequest, opts ...grpc.CallOption) (*RequestEmailVerificationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestEmailVerificationResponse)
+	err := c.cc.Invoke(ctx, AuthService_RequestEmailVerification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CreateInvitation(ctx context.Context, in *CreateInvitationRequest, opts ...grpc.CallOption) (*CreateInvitationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateInvitationResponse)
+	err := c.cc.Invoke(ctx, AuthService_CreateInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*ListInvitationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInvitationsResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListInvitations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeInvitation(ctx context.Context, in *RevokeInvitationRequest, opts ...grpc.CallOption) (*RevokeInvitationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeInvitationResponse)
+	err := c.cc.Invoke(ctx, AuthService_RevokeInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CancelAccountDeletion(ctx context.Context, in *CancelAccountDeletionRequest, opts ...grpc.CallOption) (*CancelAccountDeletionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelAccountDeletionResponse)
+	err := c.cc.Invoke(ctx, AuthService_CancelAccountDeletion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+// All implementations must embed UnimplementedAuthServiceServer
+// for forward compatibility.
+//
+// AuthService はユーザー認証と登録を管理するサービスです。
+// JWT（Access Token + Refresh Token）ベースの認証を提供します。
+type AuthServiceServer interface {
+	// GetRegistrationConfig は新規登録に必要な設定情報を取得します。
+	// 招待制（INVITATION_REQUIRED、またはREGISTER_KEYが設定されている）の場合、登録に招待コードが必要かどうかを返します。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { register_key_required: true }
+	//
+	// エラー: なし（常に成功）
+	GetRegistrationConfig(context.Context, *GetRegistrationConfigRequest) (*GetRegistrationConfigResponse, error)
+	// RegisterByPassword はメールアドレスとパスワードで新規ユーザーを登録します。
+	// 招待制の場合は、有効な招待コード（CreateInvitationで発行）が必要です。
+	//
+	// 例:
+	//
+	//	request: { email: "user@example.com", password: "pass123", name: "太郎", register_key: "abcd-efgh-ijkl-mnop" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - AlreadyExists: メールアドレスが既に登録されている
+	//   - PermissionDenied: 招待コードが必須だが提供されていない、または不正・期限切れ・使用回数の上限に達した
+	//   - InvalidArgument: バリデーションエラー（メール形式、パスワード長など）
+	RegisterByPassword(context.Context, *RegisterByPasswordRequest) (*AuthResponse, error)
+	// LoginByPassword はメールアドレスとパスワードでログインします。
+	// 2段階認証を有効にしているユーザーはトークンを発行せず、mfa_required と mfa_token を返します（VerifyMfaで続ける）。
+	//
+	// 例:
+	//
+	//	request: { email: "user@example.com", password: "pass123" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//	response（2段階認証）: { mfa_required: true, mfa_token: "..." }
+	//
+	// エラー:
+	//   - Unauthenticated: メールアドレスまたはパスワードが不正
+	//   - NotFound: ユーザーが存在しない
+	LoginByPassword(context.Context, *LoginByPasswordRequest) (*AuthResponse, error)
+	// RefreshAccessToken はRefresh Tokenを使用してAccess Tokenを更新します。
+	// Access Tokenの有効期限は15分、Refresh Tokenの有効期限は30日です。
+	//
+	// 例:
+	//
+	//	request: { refresh_token: "..." }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - Unauthenticated: Refresh Tokenが無効または期限切れ
+	RefreshAccessToken(context.Context, *RefreshAccessTokenRequest) (*AuthResponse, error)
+	// ListOIDCProviders はOpenID Connectでログインできるプロバイダーの一覧を取得します。
+	// OIDC_PROVIDERS環境変数で設定したプロバイダーを設定した順に返します。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { providers: [{ id: "google", name: "Google" }] }
+	//
+	// エラー: なし（設定がない場合は空）
+	ListOIDCProviders(context.Context, *ListOIDCProvidersRequest) (*ListOIDCProvidersResponse, error)
+	// StartOIDCLogin はOpenID Connectのログインを開始し、IdPの認可URLを返します。
+	// クライアントは認可URLにリダイレクトし、コールバックで受け取ったstateとcodeでCompleteOIDCLoginを呼び出します（10分以内）。
+	//
+	// 例:
+	//
+	//	request: { provider: "google" }
+	//	response: { authorization_url: "https://accounts.google.com/o/oauth2/v2/auth?...", state: "..." }
+	//
+	// エラー:
+	//   - NotFound: プロバイダーが設定されていない
+	//   - Unavailable: IdPのdiscoveryに失敗した
+	StartOIDCLogin(context.Context, *StartOIDCLoginRequest) (*StartOIDCLoginResponse, error)
+	// CompleteOIDCLogin はIdPからのコールバックのstateとcodeでログインを完了し、トークンを発行します。
+	// プロバイダーのアカウントが未連携の場合、確認済みの同じメールアドレスのユーザーがいれば連携してログインし、
+	// いなければ新規ユーザーとして登録します（招待制の場合は招待コードが必要）。
+	//
+	// 例:
+	//
+	//	request: { state: "...", code: "...", register_key: "" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - InvalidArgument: stateが無効・期限切れ、またはStartOIDCLinkで開始したstate
+	//   - Unauthenticated: 認可コードの交換またはIDトークンの検証に失敗した
+	//   - FailedPrecondition: 未連携で、IdPがメールアドレスを確認していない
+	//   - PermissionDenied: アカウントが無効、または招待コードが不正
+	CompleteOIDCLogin(context.Context, *CompleteOIDCLoginRequest) (*AuthResponse, error)
+	// StartOIDCLink はログイン中のユーザーにプロバイダーのアカウントを連携するため、IdPの認可URLを返します（要認証）。
+	// コールバックで受け取ったstateとcodeでCompleteOIDCLinkを呼び出します。
+	//
+	// エラー:
+	//   - NotFound: プロバイダーが設定されていない
+	//   - AlreadyExists: このプロバイダーは既に連携している
+	StartOIDCLink(context.Context, *StartOIDCLoginRequest) (*StartOIDCLoginResponse, error)
+	// CompleteOIDCLink はIdPからのコールバックのstateとcodeで、ログイン中のユーザーにプロバイダーのアカウントを連携します（要認証）。
+	// メールアドレスが異なるプロバイダーのアカウントも連携できます。
+	//
+	// 例:
+	//
+	//	request: { state: "...", code: "..." }
+	//	response: { provider: { provider: "google", email: "user@gmail.com", created_at: 1700000000 } }
+	//
+	// エラー:
+	//   - InvalidArgument: stateが無効・期限切れ、または別のユーザーが開始したstate
+	//   - Unauthenticated: 認可コードの交換またはIDトークンの検証に失敗した
+	//   - AlreadyExists: プロバイダーのアカウントが別のユーザーに連携済み、またはこのプロバイダーは既に連携している
+	CompleteOIDCLink(context.Context, *CompleteOIDCLinkRequest) (*CompleteOIDCLinkResponse, error)
+	// ListLinkedOIDCProviders はログイン中のユーザーに連携しているプロバイダーの一覧を取得します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { providers: [{ provider: "google", email: "user@gmail.com", created_at: 1700000000 }], has_password: true }
+	ListLinkedOIDCProviders(context.Context, *ListLinkedOIDCProvidersRequest) (*ListLinkedOIDCProvidersResponse, error)
+	// UnlinkOIDCProvider はログイン中のユーザーからプロバイダーの連携を解除します（要認証）。
+	//
+	// エラー:
+	//   - NotFound: このプロバイダーは連携していない
+	//   - FailedPrecondition: パスワード・他に連携しているプロバイダー・パスキーのいずれもない（ログインできなくなる）
+	UnlinkOIDCProvider(context.Context, *UnlinkOIDCProviderRequest) (*UnlinkOIDCProviderResponse, error)
+	// VerifyMfa はログインの2段階目として、認証アプリのコードかリカバリーコードを確認してトークンを発行します。
+	// mfa_tokenはパスワードの確認から5分間有効で、コードを間違えても有効期限までは再入力できます。
+	// ログインと同じレート制限を、クライアントとユーザーごとに適用します。
+	//
+	// 例:
+	//
+	//	request: { mfa_token: "...", code: "123456" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - Unauthenticated: mfa_tokenが無効・期限切れ、またはコードが不正
+	//   - ResourceExhausted: 試行回数の上限を超えた
+	VerifyMfa(context.Context, *VerifyMfaRequest) (*AuthResponse, error)
+	// GetMfaStatus はログイン中のユーザーの2段階認証の状態を取得します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { enabled: true, recovery_codes_remaining: 8 }
+	GetMfaStatus(context.Context, *GetMfaStatusRequest) (*GetMfaStatusResponse, error)
+	// StartTotpEnrollment は認証アプリの登録を開始し、秘密鍵とQRコードにするURIを返します（要認証）。
+	// ConfirmTotpEnrollmentで認証アプリのコードを確認するまで2段階認証は有効になりません。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { secret: "JBSWY3DPEHPK3PXP...", provisioning_uri: "otpauth://totp/umi.mikan:user@example.com?..." }
+	//
+	// エラー:
+	//   - AlreadyExists: 既に2段階認証を有効にしている
+	StartTotpEnrollment(context.Context, *StartTotpEnrollmentRequest) (*StartTotpEnrollmentResponse, error)
+	// ConfirmTotpEnrollment は認証アプリのコードを確認して2段階認証を有効にし、リカバリーコードを返します（要認証）。
+	// リカバリーコードはこのレスポンスでのみ返します。
+	//
+	// 例:
+	//
+	//	request: { code: "123456" }
+	//	response: { recovery_codes: ["abcde-fghij", ...] }
+	//
+	// エラー:
+	//   - FailedPrecondition: 登録を開始していない、または既に有効
+	//   - InvalidArgument: コードが不正
+	ConfirmTotpEnrollment(context.Context, *ConfirmTotpEnrollmentRequest) (*ConfirmTotpEnrollmentResponse, error)
+	// RegenerateRecoveryCodes は認証アプリのコードを確認してリカバリーコードを作り直します（要認証）。
+	// 以前のリカバリーコードは使えなくなります。
+	//
+	// エラー:
+	//   - FailedPrecondition: 2段階認証を有効にしていない
+	//   - InvalidArgument: コードが不正
+	RegenerateRecoveryCodes(context.Context, *RegenerateRecoveryCodesRequest) (*ConfirmTotpEnrollmentResponse, error)
+	// DisableMfa は2段階認証を無効にします（要認証）。
+	// 現在のパスワードか認証アプリのコードのどちらかが必要です（パスワードを持たないユーザーはコードのみ）。
+	//
+	// 例:
+	//
+	//	request: { password: "pass123" } または { code: "123456" }
+	//	response: {}
+	//
+	// エラー:
+	//   - FailedPrecondition: 2段階認証を有効にしていない
+	//   - InvalidArgument: パスワードもコードもない
+	//   - PermissionDenied: パスワードまたはコードが不正
+	DisableMfa(context.Context, *DisableMfaRequest) (*DisableMfaResponse, error)
+	// StartPasskeyRegistration はパスキーの登録を開始し、navigator.credentials.create に渡すオプションを返します（要認証）。
+	// オプションは PublicKeyCredentialCreationOptionsJSON のJSON文字列で、チャレンジは5分間有効です。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { options_json: "{\"challenge\":\"...\",\"rp\":{\"id\":\"umi.mikan.example\",...},...}" }
+	StartPasskeyRegistration(context.Context, *StartPasskeyRegistrationRequest) (*PasskeyOptionsResponse, error)
+	// FinishPasskeyRegistration は認証器のレスポンスを検証してパスキーを登録します（要認証）。
+	//
+	// 例:
+	//
+	//	request: { credential_json: "{\"id\":\"...\",\"response\":{\"attestationObject\":\"...\",...}}", name: "iPhone" }
+	//	response: { passkey: { id: "...", name: "iPhone", created_at: 1700000000 } }
+	//
+	// エラー:
+	//   - InvalidArgument: レスポンスが不正、チャレンジが無効・期限切れ、または名前が長すぎる
+	//   - AlreadyExists: このパスキーは登録済み
+	FinishPasskeyRegistration(context.Context, *FinishPasskeyRegistrationRequest) (*FinishPasskeyRegistrationResponse, error)
+	// StartPasskeyLogin はパスキーでのログインを開始し、navigator.credentials.get に渡すオプションを返します。
+	// 端末に保存されたパスキーから選ばせるため、メールアドレスは不要です。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { options_json: "{\"challenge\":\"...\",\"rpId\":\"umi.mikan.example\",...}" }
+	StartPasskeyLogin(context.Context, *StartPasskeyLoginRequest) (*PasskeyOptionsResponse, error)
+	// FinishPasskeyLogin は認証器のレスポンスを検証してトークンを発行します。
+	// 生体認証・PINで確認したパスキーは2つの要素を満たすため、2段階認証を有効にしていても2段階目は求めません。
+	//
+	// 例:
+	//
+	//	request: { credential_json: "{\"id\":\"...\",\"response\":{\"signature\":\"...\",...}}" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - InvalidArgument: レスポンスが不正
+	//   - Unauthenticated: チャレンジが無効・期限切れ、未登録のパスキー、または署名が不正
+	//   - ResourceExhausted: 試行回数の上限を超えた
+	FinishPasskeyLogin(context.Context, *FinishPasskeyLoginRequest) (*AuthResponse, error)
+	// StartPasskeyMfa はログインの2段階目としてパスキーでの認証を開始します。
+	// mfa_methodsに "passkey" を含むAuthResponseのmfa_tokenが必要です。
+	//
+	// エラー:
+	//   - Unauthenticated: mfa_tokenが無効・期限切れ
+	//   - FailedPrecondition: パスキーを登録していない
+	StartPasskeyMfa(context.Context, *StartPasskeyMfaRequest) (*PasskeyOptionsResponse, error)
+	// FinishPasskeyMfa は認証器のレスポンスを検証してトークンを発行します（VerifyMfaのパスキー版）。
+	// VerifyMfaと同じレート制限を、クライアントとユーザーごとに適用します。
+	//
+	// エラー:
+	//   - InvalidArgument: レスポンスが不正
+	//   - Unauthenticated: mfa_token・チャレンジが無効・期限切れ、別のユーザーのパスキー、または署名が不正
+	//   - ResourceExhausted: 試行回数の上限を超えた
+	FinishPasskeyMfa(context.Context, *FinishPasskeyMfaRequest) (*AuthResponse, error)
+	// ListPasskeys はログイン中のユーザーが登録したパスキーの一覧を取得します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { passkeys: [{ id: "...", name: "iPhone", transports: ["internal", "hybrid"], created_at: 1700000000, last_used_at: 1700001000 }] }
+	ListPasskeys(context.Context, *ListPasskeysRequest) (*ListPasskeysResponse, error)
+	// DeletePasskey はログイン中のユーザーのパスキーを削除します（要認証）。
+	//
+	// エラー:
+	//   - NotFound: パスキーが存在しない、または他のユーザーのパスキー
+	//   - FailedPrecondition: パスワードも連携しているプロバイダーもなく、最後のパスキー（ログインできなくなる）
+	DeletePasskey(context.Context, *DeletePasskeyRequest) (*DeletePasskeyResponse, error)
+	// RequestPasswordReset はパスワードの再設定のリンクをメールで送ります。
+	// メールアドレスが登録されているかどうかを知られないよう、登録されていない場合も成功を返します。
+	// リンクは1時間有効で、1回だけ使えます。
+	//
+	// 例:
+	//
+	//	request: { email: "user@example.com" }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: メールアドレスが空
+	//   - ResourceExhausted: 送信回数の上限を超えた
+	RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*RequestPasswordResetResponse, error)
+	// ResetPassword はメールのリンクのトークンでパスワードを再設定します。
+	// 再設定するとメールアドレスも確認済みになり、それまでのセッション（リフレッシュトークン）は使えなくなります。
+	// パスワードを持たないユーザー（OpenID Connectで登録）はパスワードを設定できます。
+	//
+	// 例:
+	//
+	//	request: { token: "...", new_password: "newPassword123" }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: トークンが不正・期限切れ・使用済み、またはパスワードが短い
+	//   - PermissionDenied: アカウントが無効
+	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error)
+	// VerifyEmail はメールのリンクのトークンでメールアドレスを確認済みにします。
+	// リンクは24時間有効で、1回だけ使えます。リンクを送った後にメールアドレスを変えた場合は使えません。
+	//
+	// 例:
+	//
+	//	request: { token: "..." }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: トークンが不正・期限切れ・使用済み
+	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error)
+	// RequestEmailVerification はログイン中のユーザーに確認のリンクをメールで送り直します（要認証）。
+	//
+	// エラー:
+	//   - FailedPrecondition: 既に確認済み
+	//   - ResourceExhausted: 送信回数の上限を超えた
+	RequestEmailVerification(context.Context, *RequestEmailVerificationRequest) (*RequestEmailVerificationResponse, error)
+	// CreateInvitation は新規登録に使う招待コードを発行します（要認証）。
+	// コードは発行時のレスポンスでのみ返します（DBにはハッシュのみを保存）。
+	// 使用回数は1〜10回（管理者は1000回まで）、有効期限は1〜30日（管理者は365日まで）で、省略時は1回・7日です。
+	//
+	// 例:
+	//
+	//	request: { max_uses: 3, expires_in_days: 7, note: "家族用" }
+	//	response: { code: "abcd-efgh-ijkl-mnop", invitation: { id: "...", max_uses: 3, use_count: 0, expires_at: 1700604800, ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: 使用回数・有効期限が範囲外、またはメモが長すぎる
+	//   - PermissionDenied: アカウントが無効
+	CreateInvitation(context.Context, *CreateInvitationRequest) (*CreateInvitationResponse, error)
+	// ListInvitations はログイン中のユーザーが発行した招待コードを、新しい順に使ったユーザーとともに返します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { invitations: [{ id: "...", note: "家族用", max_uses: 3, use_count: 1, uses: [{ user_id: "...", user_name: "太郎", used_at: 1700001000 }], ... }] }
+	ListInvitations(context.Context, *ListInvitationsRequest) (*ListInvitationsResponse, error)
+	// RevokeInvitation はログイン中のユーザーが発行した招待コードを取り消します（要認証）。
+	// 取り消した招待コードでは登録できなくなります（登録済みのユーザーには影響しません）。
+	//
+	// エラー:
+	//   - NotFound: 招待コードが存在しない、または他のユーザーが発行した
+	RevokeInvitation(context.Context, *RevokeInvitationRequest) (*RevokeInvitationResponse, error)
+	// CancelAccountDeletion はメールのリンクのトークンでアカウントの削除を取り消します。
+	// リンクは削除の予定日時まで有効です。取り消した後は改めてログインしてください（APIキーは再発行が必要です）。
+	//
+	// 例:
+	//
+	//	request: { token: "..." }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: トークンが不正・期限切れ・使用済み、または削除が既に取り消されている
+	CancelAccountDeletion(context.Context, *CancelAccountDeletionRequest) (*CancelAccountDeletionResponse, error)
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+// UnimplementedAuthServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuthServiceServer struct{}
+
+func (UnimplementedAuthServiceServer) GetRegistrationConfig(context.Context, *GetRegistrationConfigRequest) (*GetRegistrationConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRegistrationConfig not implemented")
+}
+func (UnimplementedAuthServiceServer) RegisterByPassword(context.Context, *RegisterByPasswordRequest) (*AuthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterByPassword not implemented")
+}
+func (UnimplementedAuthServiceServer) LoginByPassword(context.Context, *LoginByPasswordRequest) (*AuthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LoginByPassword not implemented")
+}
+func (UnimplementedAuthServiceServer) RefreshAccessToken(context.Context, *RefreshAccessTokenRequest) (*AuthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RefreshAccessToken not implemented")
+}
+func (UnimplementedAuthServiceServer) ListOIDCProviders(context.Context, *ListOIDCProvidersRequest) (*ListOIDCProvidersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListOIDCProviders not implemented")
+}
+func (UnimplementedAuthServiceServer) StartOIDCLogin(context.Context, *StartOIDCLoginRequest) (*StartOIDCLoginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartOIDCLogin not implemented")
+}
+func (UnimplementedAuthServiceServer) CompleteOIDCLogin(context.Context, *CompleteOIDCLoginRequest) (*AuthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteOIDCLogin not implemented")
+}
+func (UnimplementedAuthServiceServer) StartOIDCLink(context.Context, *StartOIDCLoginRequest) (*StartOIDCLoginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartOIDCLink not implemented")
+}
+func (UnimplementedAuthServiceServer) CompleteOIDCLink(context.Context, *CompleteOIDCLinkRequest) (*CompleteOIDCLinkResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteOIDCLink not implemented")
+}
+func (UnimplementedAuthServiceServer) ListLinkedOIDCProviders(context.Context, *ListLinkedOIDCProvidersRequest) (*ListLinkedOIDCProvidersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLinkedOIDCProviders not implemented")
+}
+func (UnimplementedAuthServiceServer) UnlinkOIDCProvider(context.Context, *UnlinkOIDCProviderRequest) (*UnlinkOIDCProviderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnlinkOIDCProvider not implemented")
+}
+func (UnimplementedAuthServiceServer) VerifyMfa(context.Context, *VerifyMfaRequest) (*AuthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyMfa not implemented")
+}
+func (UnimplementedAuthServiceServer) GetMfaStatus(context.Context, *GetMfaStatusRequest) (*GetMfaStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMfaStatus not implemented")
+}
+func (UnimplementedAuthServiceServer) StartTotpEnrollment(context.Context, *StartTotpEnrollmentRequest) (*StartTotpEnrollmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartTotpEnrollment not implemented")
+}
+func (UnimplementedAuthServiceServer) ConfirmTotpEnrollment(context.Context, *ConfirmTotpEnrollmentRequest) (*ConfirmTotpEnrollmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmTotpEnrollment not implemented")
+}
+func (UnimplementedAuthServiceServer) RegenerateRecoveryCodes(context.Context, *RegenerateRecoveryCodesRequest) (*ConfirmTotpEnrollmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegenerateRecoveryCodes not implemented")
+}
+func (UnimplementedAuthServiceServer) DisableMfa(context.Context, *DisableMfaRequest) (*DisableMfaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DisableMfa not implemented")
+}
+func (UnimplementedAuthServiceServer) StartPasskeyRegistration(context.Context, *StartPasskeyRegistrationRequest) (*PasskeyOptionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartPasskeyRegistration not implemented")
+}
+func (UnimplementedAuthServiceServer) FinishPasskeyRegistration(context.Context, *FinishPasskeyRegistrationRequest) (*FinishPasskeyRegistrationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FinishPasskeyRegistration not implemented")
+}
+func (UnimplementedAuthServiceServer) StartPasskeyLogin(context.Context, *StartPasskeyLoginRequest) (*PasskeyOptionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartPasskeyLogin not implemented")
+}
+func (UnimplementedAuthServiceServer) FinishPasskeyLogin(context.Context, *FinishPasskeyLoginRequest) (*AuthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FinishPasskeyLogin not implemented")
+}
+func (UnimplementedAuthServiceServer) StartPasskeyMfa(context.Context, *StartPasskeyMfaRequest) (*PasskeyOptionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartPasskeyMfa not implemented")
+}
+func (UnimplementedAuthServiceServer) FinishPasskeyMfa(context.Context, *FinishPasskeyMfaRequest) (*AuthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FinishPasskeyMfa not implemented")
+}
+func (UnimplementedAuthServiceServer) ListPasskeys(context.Context, *ListPasskeysRequest) (*ListPasskeysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPasskeys not implemented")
+}
+func (UnimplementedAuthServiceServer) DeletePasskey(context.Context, *DeletePasskeyRequest) (*DeletePasskeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeletePasskey not implemented")
+}
+func (UnimplementedAuthServiceServer) RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*RequestPasswordResetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestPasswordReset not implemented")
+}
+func (UnimplementedAuthServiceServer) ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedAuthServiceServer) VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (UnimplementedAuthServiceServer) RequestEmailVerification(context.Context, *RequestEmailVerificationRequest) (*RequestEmailVerificationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestEmailVerification not implemented")
+}
+func (UnimplementedAuthServiceServer) CreateInvitation(context.Context, *CreateInvitationRequest) (*CreateInvitationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateInvitation not implemented")
+}
+func (UnimplementedAuthServiceServer) ListInvitations(context.Context, *ListInvitationsRequest) (*ListInvitationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListInvitations not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeInvitation(context.Context, *RevokeInvitationRequest) (*RevokeInvitationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeInvitation not implemented")
+}
+func (UnimplementedAuthServiceServer) CancelAccountDeletion(context.Context, *CancelAccountDeletionRequest) (*CancelAccountDeletionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelAccountDeletion not implemented")
+}
+func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
+func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServiceServer will
+// result in compilation errors.
+type UnsafeAuthServiceServer interface {
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
+	// If the following call panics, it indicates UnimplementedAuthServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuthService_ServiceDesc, srv)
+}
+
+func _AuthService_GetRegistrationConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegistrationConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetRegistrationConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetRegistrationConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetRegistrationConfig(ctx, req.(*GetRegistrationConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RegisterByPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterByPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RegisterByPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RegisterByPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RegisterByPassword(ctx, req.(*RegisterByPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_LoginByPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginByPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).LoginByPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_LoginByPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).LoginByPassword(ctx, req.(*LoginByPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RefreshAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshAccessTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RefreshAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RefreshAccessToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RefreshAccessToken(ctx, req.(*RefreshAccessTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListOIDCProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOIDCProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListOIDCProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListOIDCProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListOIDCProviders(ctx, req.(*ListOIDCProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_StartOIDCLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartOIDCLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).StartOIDCLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_StartOIDCLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).StartOIDCLogin(ctx, req.(*StartOIDCLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CompleteOIDCLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteOIDCLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CompleteOIDCLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CompleteOIDCLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CompleteOIDCLogin(ctx, req.(*CompleteOIDCLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_StartOIDCLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartOIDCLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).StartOIDCLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_StartOIDCLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).StartOIDCLink(ctx, req.(*StartOIDCLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CompleteOIDCLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteOIDCLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CompleteOIDCLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CompleteOIDCLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CompleteOIDCLink(ctx, req.(*CompleteOIDCLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListLinkedOIDCProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLinkedOIDCProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListLinkedOIDCProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListLinkedOIDCProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListLinkedOIDCProviders(ctx, req.(*ListLinkedOIDCProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_UnlinkOIDCProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlinkOIDCProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).UnlinkOIDCProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_UnlinkOIDCProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).UnlinkOIDCProvider(ctx, req.(*UnlinkOIDCProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_VerifyMfa_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyMfaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).VerifyMfa(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_VerifyMfa_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).VerifyMfa(ctx, req.(*VerifyMfaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetMfaStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMfaStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetMfaStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetMfaStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetMfaStatus(ctx, req.(*GetMfaStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_StartTotpEnrollment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartTotpEnrollmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).StartTotpEnrollment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_StartTotpEnrollment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).StartTotpEnrollment(ctx, req.(*StartTotpEnrollmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ConfirmTotpEnrollment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTotpEnrollmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ConfirmTotpEnrollment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ConfirmTotpEnrollment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ConfirmTotpEnrollment(ctx, req.(*ConfirmTotpEnrollmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RegenerateRecoveryCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegenerateRecoveryCodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RegenerateRecoveryCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RegenerateRecoveryCodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RegenerateRecoveryCodes(ctx, req.(*RegenerateRecoveryCodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DisableMfa_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableMfaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DisableMfa(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DisableMfa_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DisableMfa(ctx, req.(*DisableMfaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_StartPasskeyRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartPasskeyRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).StartPasskeyRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_StartPasskeyRegistration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).StartPasskeyRegistration(ctx, req.(*StartPasskeyRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_FinishPasskeyRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishPasskeyRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).FinishPasskeyRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_FinishPasskeyRegistration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).FinishPasskeyRegistration(ctx, req.(*FinishPasskeyRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_StartPasskeyLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartPasskeyLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).StartPasskeyLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_StartPasskeyLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).StartPasskeyLogin(ctx, req.(*StartPasskeyLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_FinishPasskeyLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishPasskeyLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).FinishPasskeyLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_FinishPasskeyLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).FinishPasskeyLogin(ctx, req.(*FinishPasskeyLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_StartPasskeyMfa_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartPasskeyMfaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).StartPasskeyMfa(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_StartPasskeyMfa_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).StartPasskeyMfa(ctx, req.(*StartPasskeyMfaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_FinishPasskeyMfa_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishPasskeyMfaRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AuthServiceServer).FinishPasskeyMfa(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_FinishPasskeyMfa_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).FinishPasskeyMfa(ctx, req.(*FinishPasskeyMfaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *authServiceClient) RegisterByPassword(ctx context.Context, in *RegisterByPasswordRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(AuthResponse)
-	err := c.cc.Invoke(ctx, AuthService_RegisterByPassword_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _AuthService_ListPasskeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPasskeysRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListPasskeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListPasskeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListPasskeys(ctx, req.(*ListPasskeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *authServiceClient) LoginByPassword(ctx context.Context, in *LoginByPasswordRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(AuthResponse)
-	err := c.cc.Invoke(ctx, AuthService_LoginByPassword_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _AuthService_DeletePasskey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePasskeyRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DeletePasskey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DeletePasskey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DeletePasskey(ctx, req.(*DeletePasskeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *authServiceClient) RefreshAccessToken(ctx context.Context, in *RefreshAccessTokenRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(AuthResponse)
-	err := c.cc.Invoke(ctx, AuthService_RefreshAccessToken_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _AuthService_RequestPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPasswordResetRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// AuthServiceServer is the server API for AuthService service.
-// All implementations must embed UnimplementedAuthServiceServer
-// for forward compatibility.
-//
-// AuthService はユーザー認証と登録を管理するサービスです。
-// JWT（Access Token + Refresh Token）ベースの認証を提供します。
-type AuthServiceServer interface {
-	// GetRegistrationConfig は新規登録に必要な設定情報を取得します。
-	// REGISTER_KEY環境変数が設定されている場合、登録キーが必要かどうかを返します。
-	//
-	// 例:
-	//
-	//	request: {}
-	//	response: { register_key_required: true }
-	//
-	// エラー: なし（常に成功）
-	GetRegistrationConfig(context.Context, *GetRegistrationConfigRequest) (*GetRegistrationConfigResponse, error)
-	// RegisterByPassword はメールアドレスとパスワードで新規ユーザーを登録します。
-	// REGISTER_KEYが設定されている場合は、正しい登録キーが必要です。
-	//
-	// 例:
-	//
-	//	request: { email: "user@example.com", password: "pass123", name: "太郎", register_key: "secret" }
-	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
-	//
-	// エラー:
-	//   - AlreadyExists: メールアドレスが既に登録されている
-	//   - InvalidArgument: 登録キーが必須だが提供されていない
-	//   - PermissionDenied: 登録キーが不正
-	//   - InvalidArgument: バリデーションエラー（メール形式、パスワード長など）
-	RegisterByPassword(context.Context, *RegisterByPasswordRequest) (*AuthResponse, error)
-	// LoginByPassword はメールアドレスとパスワードでログインします。
-	//
-	// 例:
-	//
-	//	request: { email: "user@example.com", password: "pass123" }
-	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
-	//
-	// エラー:
-	//   - Unauthenticated: メールアドレスまたはパスワードが不正
-	//   - NotFound: ユーザーが存在しない
-	LoginByPassword(context.Context, *LoginByPasswordRequest) (*AuthResponse, error)
-	// RefreshAccessToken はRefresh Tokenを使用してAccess Tokenを更新します。
-	// Access Tokenの有効期限は15分、Refresh Tokenの有効期限は30日です。
-	//
-	// 例:
-	//
-	//	request: { refresh_token: "..." }
-	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
-	//
-	// エラー:
-	//   - Unauthenticated: Refresh Tokenが無効または期限切れ
-	RefreshAccessToken(context.Context, *RefreshAccessTokenRequest) (*AuthResponse, error)
-	mustEmbedUnimplementedAuthServiceServer()
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RequestPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RequestPasswordReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RequestPasswordReset(ctx, req.(*RequestPasswordResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedAuthServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedAuthServiceServer struct{}
-
-func (UnimplementedAuthServiceServer) GetRegistrationConfig(context.Context, *GetRegistrationConfigRequest) (*GetRegistrationConfigResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetRegistrationConfig not implemented")
-}
-func (UnimplementedAuthServiceServer) RegisterByPassword(context.Context, *RegisterByPasswordRequest) (*AuthResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method RegisterByPassword not implemented")
-}
-func (UnimplementedAuthServiceServer) LoginByPassword(context.Context, *LoginByPasswordRequest) (*AuthResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method LoginByPassword not implemented")
-}
-func (UnimplementedAuthServiceServer) RefreshAccessToken(context.Context, *RefreshAccessTokenRequest) (*AuthResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method RefreshAccessToken not implemented")
+func _AuthService_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ResetPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
-func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
 
-// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to AuthServiceServer will
-// result in compilation errors.
-type UnsafeAuthServiceServer interface {
-	mustEmbedUnimplementedAuthServiceServer()
+func _AuthService_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_VerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).VerifyEmail(ctx, req.(*VerifyEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
-	// If the following call panics, it indicates UnimplementedAuthServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _AuthService_RequestEmailVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEmailVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&AuthService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RequestEmailVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RequestEmailVerification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RequestEmailVerification(ctx, req.(*RequestEmailVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_GetRegistrationConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRegistrationConfigRequest)
+func _AuthService_CreateInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInvitationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).GetRegistrationConfig(ctx, in)
+		return srv.(AuthServiceServer).CreateInvitation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_GetRegistrationConfig_FullMethodName,
+		FullMethod: AuthService_CreateInvitation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).GetRegistrationConfig(ctx, req.(*GetRegistrationConfigRequest))
+		return srv.(AuthServiceServer).CreateInvitation(ctx, req.(*CreateInvitationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_RegisterByPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterByPasswordRequest)
+func _AuthService_ListInvitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitationsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).RegisterByPassword(ctx, in)
+		return srv.(AuthServiceServer).ListInvitations(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_RegisterByPassword_FullMethodName,
+		FullMethod: AuthService_ListInvitations_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).RegisterByPassword(ctx, req.(*RegisterByPasswordRequest))
+		return srv.(AuthServiceServer).ListInvitations(ctx, req.(*ListInvitationsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_LoginByPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginByPasswordRequest)
+func _AuthService_RevokeInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeInvitationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).LoginByPassword(ctx, in)
+		return srv.(AuthServiceServer).RevokeInvitation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_LoginByPassword_FullMethodName,
+		FullMethod: AuthService_RevokeInvitation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).LoginByPassword(ctx, req.(*LoginByPasswordRequest))
+		return srv.(AuthServiceServer).RevokeInvitation(ctx, req.(*RevokeInvitationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_RefreshAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RefreshAccessTokenRequest)
+func _AuthService_CancelAccountDeletion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelAccountDeletionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).RefreshAccessToken(ctx, in)
+		return srv.(AuthServiceServer).CancelAccountDeletion(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_RefreshAccessToken_FullMethodName,
+		FullMethod: AuthService_CancelAccountDeletion_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).RefreshAccessToken(ctx, req.(*RefreshAccessTokenRequest))
+		return srv.(AuthServiceServer).CancelAccountDeletion(ctx, req.(*CancelAccountDeletionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -319,6 +1811,122 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RefreshAccessToken",
 			Handler:    _AuthService_RefreshAccessToken_Handler,
 		},
+		{
+			MethodName: "ListOIDCProviders",
+			Handler:    _AuthService_ListOIDCProviders_Handler,
+		},
+		{
+			MethodName: "StartOIDCLogin",
+			Handler:    _AuthService_StartOIDCLogin_Handler,
+		},
+		{
+			MethodName: "CompleteOIDCLogin",
+			Handler:    _AuthService_CompleteOIDCLogin_Handler,
+		},
+		{
+			MethodName: "StartOIDCLink",
+			Handler:    _AuthService_StartOIDCLink_Handler,
+		},
+		{
+			MethodName: "CompleteOIDCLink",
+			Handler:    _AuthService_CompleteOIDCLink_Handler,
+		},
+		{
+			MethodName: "ListLinkedOIDCProviders",
+			Handler:    _AuthService_ListLinkedOIDCProviders_Handler,
+		},
+		{
+			MethodName: "UnlinkOIDCProvider",
+			Handler:    _AuthService_UnlinkOIDCProvider_Handler,
+		},
+		{
+			MethodName: "VerifyMfa",
+			Handler:    _AuthService_VerifyMfa_Handler,
+		},
+		{
+			MethodName: "GetMfaStatus",
+			Handler:    _AuthService_GetMfaStatus_Handler,
+		},
+		{
+			MethodName: "StartTotpEnrollment",
+			Handler:    _AuthService_StartTotpEnrollment_Handler,
+		},
+		{
+			MethodName: "ConfirmTotpEnrollment",
+			Handler:    _AuthService_ConfirmTotpEnrollment_Handler,
+		},
+		{
+			MethodName: "RegenerateRecoveryCodes",
+			Handler:    _AuthService_RegenerateRecoveryCodes_Handler,
+		},
+		{
+			MethodName: "DisableMfa",
+			Handler:    _AuthService_DisableMfa_Handler,
+		},
+		{
+			MethodName: "StartPasskeyRegistration",
+			Handler:    _AuthService_StartPasskeyRegistration_Handler,
+		},
+		{
+			MethodName: "FinishPasskeyRegistration",
+			Handler:    _AuthService_FinishPasskeyRegistration_Handler,
+		},
+		{
+			MethodName: "StartPasskeyLogin",
+			Handler:    _AuthService_StartPasskeyLogin_Handler,
+		},
+		{
+			MethodName: "FinishPasskeyLogin",
+			Handler:    _AuthService_FinishPasskeyLogin_Handler,
+		},
+		{
+			MethodName: "StartPasskeyMfa",
+			Handler:    _AuthService_StartPasskeyMfa_Handler,
+		},
+		{
+			MethodName: "FinishPasskeyMfa",
+			Handler:    _AuthService_FinishPasskeyMfa_Handler,
+		},
+		{
+			MethodName: "ListPasskeys",
+			Handler:    _AuthService_ListPasskeys_Handler,
+		},
+		{
+			MethodName: "DeletePasskey",
+			Handler:    _AuthService_DeletePasskey_Handler,
+		},
+		{
+			MethodName: "RequestPasswordReset",
+			Handler:    _AuthService_RequestPasswordReset_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _AuthService_ResetPassword_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _AuthService_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "RequestEmailVerification",
+			Handler:    _AuthService_RequestEmailVerification_Handler,
+		},
+		{
+			MethodName: "CreateInvitation",
+			Handler:    _AuthService_CreateInvitation_Handler,
+		},
+		{
+			MethodName: "ListInvitations",
+			Handler:    _AuthService_ListInvitations_Handler,
+		},
+		{
+			MethodName: "RevokeInvitation",
+			Handler:    _AuthService_RevokeInvitation_Handler,
+		},
+		{
+			MethodName: "CancelAccountDeletion",
+			Handler:    _AuthService_CancelAccountDeletion_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth/auth.proto",
@@ -21,6 +21,224 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// エクスポートの形式
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0 // JSON Linesとして扱う
+	ExportFormat_EXPORT_FORMAT_JSONL       ExportFormat = 1
+	ExportFormat_EXPORT_FORMAT_MARKDOWN    ExportFormat = 2
+	ExportFormat_EXPORT_FORMAT_CSV         ExportFormat = 3
+	ExportFormat_EXPORT_FORMAT_HTML        ExportFormat = 4
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "EXPORT_FORMAT_JSONL",
+		2: "EXPORT_FORMAT_MARKDOWN",
+		3: "EXPORT_FORMAT_CSV",
+		4: "EXPORT_FORMAT_HTML",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED": 0,
+		"EXPORT_FORMAT_JSONL":       1,
+		"EXPORT_FORMAT_MARKDOWN":    2,
+		"EXPORT_FORMAT_CSV":         3,
+		"EXPORT_FORMAT_HTML":        4,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_diary_diary_proto_enumTypes[0].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_diary_diary_proto_enumTypes[0]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{0}
+}
+
+// タスクの状態
+type TaskStatus int32
+
+const (
+	TaskStatus_TASK_STATUS_UNSPECIFIED TaskStatus = 0
+	TaskStatus_TASK_STATUS_QUEUED      TaskStatus = 1 // キューに追加済み
+	TaskStatus_TASK_STATUS_PROCESSING  TaskStatus = 2 // 処理中
+	TaskStatus_TASK_STATUS_SUCCEEDED   TaskStatus = 3 // 完了
+	TaskStatus_TASK_STATUS_FAILED      TaskStatus = 4 // 失敗
+)
+
+// Enum value maps for TaskStatus.
+var (
+	TaskStatus_name = map[int32]string{
+		0: "TASK_STATUS_UNSPECIFIED",
+		1: "TASK_STATUS_QUEUED",
+		2: "TASK_STATUS_PROCESSING",
+		3: "TASK_STATUS_SUCCEEDED",
+		4: "TASK_STATUS_FAILED",
+	}
+	TaskStatus_value = map[string]int32{
+		"TASK_STATUS_UNSPECIFIED": 0,
+		"TASK_STATUS_QUEUED":      1,
+		"TASK_STATUS_PROCESSING":  2,
+		"TASK_STATUS_SUCCEEDED":   3,
+		"TASK_STATUS_FAILED":      4,
+	}
+)
+
+func (x TaskStatus) Enum() *TaskStatus {
+	p := new(TaskStatus)
+	*p = x
+	return p
+}
+
+func (x TaskStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_diary_diary_proto_enumTypes[1].Descriptor()
+}
+
+func (TaskStatus) Type() protoreflect.EnumType {
+	return &file_diary_diary_proto_enumTypes[1]
+}
+
+func (x TaskStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskStatus.Descriptor instead.
+func (TaskStatus) EnumDescriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{1}
+}
+
+// 差分同期でクライアントから送る変更の種類
+type DiaryMutationType int32
+
+const (
+	DiaryMutationType_DIARY_MUTATION_TYPE_UNSPECIFIED DiaryMutationType = 0
+	DiaryMutationType_DIARY_MUTATION_TYPE_CREATE      DiaryMutationType = 1 // 作成（idはクライアントで生成したUUID）
+	DiaryMutationType_DIARY_MUTATION_TYPE_UPDATE      DiaryMutationType = 2 // 更新
+	DiaryMutationType_DIARY_MUTATION_TYPE_DELETE      DiaryMutationType = 3 // 削除
+)
+
+// Enum value maps for DiaryMutationType.
+var (
+	DiaryMutationType_name = map[int32]string{
+		0: "DIARY_MUTATION_TYPE_UNSPECIFIED",
+		1: "DIARY_MUTATION_TYPE_CREATE",
+		2: "DIARY_MUTATION_TYPE_UPDATE",
+		3: "DIARY_MUTATION_TYPE_DELETE",
+	}
+	DiaryMutationType_value = map[string]int32{
+		"DIARY_MUTATION_TYPE_UNSPECIFIED": 0,
+		"DIARY_MUTATION_TYPE_CREATE":      1,
+		"DIARY_MUTATION_TYPE_UPDATE":      2,
+		"DIARY_MUTATION_TYPE_DELETE":      3,
+	}
+)
+
+func (x DiaryMutationType) Enum() *DiaryMutationType {
+	p := new(DiaryMutationType)
+	*p = x
+	return p
+}
+
+func (x DiaryMutationType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DiaryMutationType) Descriptor() protoreflect.EnumDescriptor {
+	return file_diary_diary_proto_enumTypes[2].Descriptor()
+}
+
+func (DiaryMutationType) Type() protoreflect.EnumType {
+	return &file_diary_diary_proto_enumTypes[2]
+}
+
+func (x DiaryMutationType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DiaryMutationType.Descriptor instead.
+func (DiaryMutationType) EnumDescriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{2}
+}
+
+// 差分同期での変更の反映結果
+type DiaryMutationStatus int32
+
+const (
+	DiaryMutationStatus_DIARY_MUTATION_STATUS_UNSPECIFIED DiaryMutationStatus = 0
+	DiaryMutationStatus_DIARY_MUTATION_STATUS_APPLIED     DiaryMutationStatus = 1 // 反映した（同じ変更を再送した場合を含む）
+	DiaryMutationStatus_DIARY_MUTATION_STATUS_CONFLICT    DiaryMutationStatus = 2 // サーバー側で先に変更・削除されていたため反映しなかった
+	DiaryMutationStatus_DIARY_MUTATION_STATUS_REJECTED    DiaryMutationStatus = 3 // 内容が不正なため反映しなかった
+)
+
+// Enum value maps for DiaryMutationStatus.
+var (
+	DiaryMutationStatus_name = map[int32]string{
+		0: "DIARY_MUTATION_STATUS_UNSPECIFIED",
+		1: "DIARY_MUTATION_STATUS_APPLIED",
+		2: "DIARY_MUTATION_STATUS_CONFLICT",
+		3: "DIARY_MUTATION_STATUS_REJECTED",
+	}
+	DiaryMutationStatus_value = map[string]int32{
+		"DIARY_MUTATION_STATUS_UNSPECIFIED": 0,
+		"DIARY_MUTATION_STATUS_APPLIED":     1,
+		"DIARY_MUTATION_STATUS_CONFLICT":    2,
+		"DIARY_MUTATION_STATUS_REJECTED":    3,
+	}
+)
+
+func (x DiaryMutationStatus) Enum() *DiaryMutationStatus {
+	p := new(DiaryMutationStatus)
+	*p = x
+	return p
+}
+
+func (x DiaryMutationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DiaryMutationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_diary_diary_proto_enumTypes[3].Descriptor()
+}
+
+func (DiaryMutationStatus) Type() protoreflect.EnumType {
+	return &file_diary_diary_proto_enumTypes[3]
+}
+
+func (x DiaryMutationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DiaryMutationStatus.Descriptor instead.
+func (DiaryMutationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{3}
+}
+
 type YMD struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Year          uint32                 `protobuf:"varint,1,opt,name=year,proto3" json:"year,omitempty"`
@@ -133,21 +351,78 @@ func (x *YM) GetMonth() uint32 {
 	return 0
 }
 
+type HM struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hour          uint32                 `protobuf:"varint,1,opt,name=hour,proto3" json:"hour,omitempty"`     // 0〜23
+	Minute        uint32                 `protobuf:"varint,2,opt,name=minute,proto3" json:"minute,omitempty"` // 0〜59
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HM) Reset() {
+	*x = HM{}
+	mi := &file_diary_diary_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HM) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HM) ProtoMessage() {}
+
+func (x *HM) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HM.ProtoReflect.Descriptor instead.
+func (*HM) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HM) GetHour() uint32 {
+	if x != nil {
+		return x.Hour
+	}
+	return 0
+}
+
+func (x *HM) GetMinute() uint32 {
+	if x != nil {
+		return x.Minute
+	}
+	return 0
+}
+
 // 日記エントリのメッセージ
 type DiaryEntry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                 // 日記ID
-	Date          *YMD                   `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`                             // 日付
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`                       // 内容
-	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // 作成日時（Unix timestamp）
-	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // 更新日時（Unix timestamp）
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                            // 日記ID
+	Date          *YMD                   `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`                                        // 日付
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`                                  // 内容
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`            // 作成日時（Unix timestamp）
+	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`            // 更新日時（Unix timestamp）
+	Title         string                 `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty"`                                      // タイトル（未指定の場合は空文字）
+	Time          *HM                    `protobuf:"bytes,7,opt,name=time,proto3" json:"time,omitempty"`                                        // 時刻（未指定の場合は未設定）
+	Tags          []*Tag                 `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`                                        // 日記に付いているタグ（名前順）
+	SuggestedTags []*Tag                 `protobuf:"bytes,9,rep,name=suggested_tags,json=suggestedTags,proto3" json:"suggested_tags,omitempty"` // LLMが提案したタグ（自動タグ付けが有効な場合のみ）
+	Version       int64                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`                                // 日記のバージョン（編集のたびに増える。差分同期のbase_versionに使う）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DiaryEntry) Reset() {
 	*x = DiaryEntry{}
-	mi := &file_diary_diary_proto_msgTypes[2]
+	mi := &file_diary_diary_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -159,7 +434,7 @@ func (x *DiaryEntry) String() string {
 func (*DiaryEntry) ProtoMessage() {}
 
 func (x *DiaryEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[2]
+	mi := &file_diary_diary_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -172,7 +447,7 @@ func (x *DiaryEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiaryEntry.ProtoReflect.Descriptor instead.
 func (*DiaryEntry) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{2}
+	return file_diary_diary_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *DiaryEntry) GetId() string {
@@ -210,18 +485,57 @@ func (x *DiaryEntry) GetUpdatedAt() int64 {
 	return 0
 }
 
+func (x *DiaryEntry) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DiaryEntry) GetTime() *HM {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *DiaryEntry) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *DiaryEntry) GetSuggestedTags() []*Tag {
+	if x != nil {
+		return x.SuggestedTags
+	}
+	return nil
+}
+
+func (x *DiaryEntry) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 // 新しい日記エントリを作成するためのリクエスト
 type CreateDiaryEntryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
 	Date          *YMD                   `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`                 // タイトル（任意、100文字以内）
+	Time          *HM                    `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`                   // 時刻（任意）
+	Additional    bool                   `protobuf:"varint,5,opt,name=additional,proto3" json:"additional,omitempty"`      // trueの場合、同じ日付に既存のエントリがあっても別のエントリとして追加する
+	TagIds        []string               `protobuf:"bytes,6,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"` // 日記に付けるタグのID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateDiaryEntryRequest) Reset() {
 	*x = CreateDiaryEntryRequest{}
-	mi := &file_diary_diary_proto_msgTypes[3]
+	mi := &file_diary_diary_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -233,7 +547,7 @@ func (x *CreateDiaryEntryRequest) String() string {
 func (*CreateDiaryEntryRequest) ProtoMessage() {}
 
 func (x *CreateDiaryEntryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[3]
+	mi := &file_diary_diary_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -246,7 +560,7 @@ func (x *CreateDiaryEntryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateDiaryEntryRequest.ProtoReflect.Descriptor instead.
 func (*CreateDiaryEntryRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{3}
+	return file_diary_diary_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreateDiaryEntryRequest) GetContent() string {
@@ -263,6 +577,34 @@ func (x *CreateDiaryEntryRequest) GetDate() *YMD {
 	return nil
 }
 
+func (x *CreateDiaryEntryRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateDiaryEntryRequest) GetTime() *HM {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *CreateDiaryEntryRequest) GetAdditional() bool {
+	if x != nil {
+		return x.Additional
+	}
+	return false
+}
+
+func (x *CreateDiaryEntryRequest) GetTagIds() []string {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
 // 日記エントリを作成した結果を返すレスポンス
 type CreateDiaryEntryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -273,7 +615,7 @@ type CreateDiaryEntryResponse struct {
 
 func (x *CreateDiaryEntryResponse) Reset() {
 	*x = CreateDiaryEntryResponse{}
-	mi := &file_diary_diary_proto_msgTypes[4]
+	mi := &file_diary_diary_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -285,7 +627,7 @@ func (x *CreateDiaryEntryResponse) String() string {
 func (*CreateDiaryEntryResponse) ProtoMessage() {}
 
 func (x *CreateDiaryEntryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[4]
+	mi := &file_diary_diary_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -298,7 +640,7 @@ func (x *CreateDiaryEntryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateDiaryEntryResponse.ProtoReflect.Descriptor instead.
 func (*CreateDiaryEntryResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{4}
+	return file_diary_diary_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CreateDiaryEntryResponse) GetEntry() *DiaryEntry {
@@ -318,7 +660,7 @@ type GetDiaryEntryRequest struct {
 
 func (x *GetDiaryEntryRequest) Reset() {
 	*x = GetDiaryEntryRequest{}
-	mi := &file_diary_diary_proto_msgTypes[5]
+	mi := &file_diary_diary_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -330,7 +672,7 @@ func (x *GetDiaryEntryRequest) String() string {
 func (*GetDiaryEntryRequest) ProtoMessage() {}
 
 func (x *GetDiaryEntryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[5]
+	mi := &file_diary_diary_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -343,7 +685,7 @@ func (x *GetDiaryEntryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDiaryEntryRequest.ProtoReflect.Descriptor instead.
 func (*GetDiaryEntryRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{5}
+	return file_diary_diary_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetDiaryEntryRequest) GetDate() *YMD {
@@ -363,7 +705,7 @@ type GetDiaryEntriesRequest struct {
 
 func (x *GetDiaryEntriesRequest) Reset() {
 	*x = GetDiaryEntriesRequest{}
-	mi := &file_diary_diary_proto_msgTypes[6]
+	mi := &file_diary_diary_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -375,7 +717,7 @@ func (x *GetDiaryEntriesRequest) String() string {
 func (*GetDiaryEntriesRequest) ProtoMessage() {}
 
 func (x *GetDiaryEntriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[6]
+	mi := &file_diary_diary_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -388,7 +730,7 @@ func (x *GetDiaryEntriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDiaryEntriesRequest.ProtoReflect.Descriptor instead.
 func (*GetDiaryEntriesRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{6}
+	return file_diary_diary_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetDiaryEntriesRequest) GetDates() []*YMD {
@@ -408,7 +750,7 @@ type GetDiaryEntriesByMonthRequest struct {
 
 func (x *GetDiaryEntriesByMonthRequest) Reset() {
 	*x = GetDiaryEntriesByMonthRequest{}
-	mi := &file_diary_diary_proto_msgTypes[7]
+	mi := &file_diary_diary_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -420,7 +762,7 @@ func (x *GetDiaryEntriesByMonthRequest) String() string {
 func (*GetDiaryEntriesByMonthRequest) ProtoMessage() {}
 
 func (x *GetDiaryEntriesByMonthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[7]
+	mi := &file_diary_diary_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,7 +775,7 @@ func (x *GetDiaryEntriesByMonthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDiaryEntriesByMonthRequest.ProtoReflect.Descriptor instead.
 func (*GetDiaryEntriesByMonthRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{7}
+	return file_diary_diary_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetDiaryEntriesByMonthRequest) GetMonth() *YM {
@@ -446,13 +788,14 @@ func (x *GetDiaryEntriesByMonthRequest) GetMonth() *YM {
 type SearchDiaryEntriesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Keyword       string                 `protobuf:"bytes,1,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	TagIds        []string               `protobuf:"bytes,2,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"` // 指定したタグをすべて持つ日記に絞り込む（keywordが空の場合はタグのみで絞り込む）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SearchDiaryEntriesRequest) Reset() {
 	*x = SearchDiaryEntriesRequest{}
-	mi := &file_diary_diary_proto_msgTypes[8]
+	mi := &file_diary_diary_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -464,7 +807,7 @@ func (x *SearchDiaryEntriesRequest) String() string {
 func (*SearchDiaryEntriesRequest) ProtoMessage() {}
 
 func (x *SearchDiaryEntriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[8]
+	mi := &file_diary_diary_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -477,7 +820,7 @@ func (x *SearchDiaryEntriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchDiaryEntriesRequest.ProtoReflect.Descriptor instead.
 func (*SearchDiaryEntriesRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{8}
+	return file_diary_diary_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SearchDiaryEntriesRequest) GetKeyword() string {
@@ -487,6 +830,13 @@ func (x *SearchDiaryEntriesRequest) GetKeyword() string {
 	return ""
 }
 
+func (x *SearchDiaryEntriesRequest) GetTagIds() []string {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
 type SearchDiaryEntriesResponse struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	SearchedKeyword  string                 `protobuf:"bytes,1,opt,name=searched_keyword,json=searchedKeyword,proto3" json:"searched_keyword,omitempty"` // 実際に検索した単語
@@ -498,7 +848,7 @@ type SearchDiaryEntriesResponse struct {
 
 func (x *SearchDiaryEntriesResponse) Reset() {
 	*x = SearchDiaryEntriesResponse{}
-	mi := &file_diary_diary_proto_msgTypes[9]
+	mi := &file_diary_diary_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -510,7 +860,7 @@ func (x *SearchDiaryEntriesResponse) String() string {
 func (*SearchDiaryEntriesResponse) ProtoMessage() {}
 
 func (x *SearchDiaryEntriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[9]
+	mi := &file_diary_diary_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -523,7 +873,7 @@ func (x *SearchDiaryEntriesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchDiaryEntriesResponse.ProtoReflect.Descriptor instead.
 func (*SearchDiaryEntriesResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{9}
+	return file_diary_diary_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SearchDiaryEntriesResponse) GetSearchedKeyword() string {
@@ -556,7 +906,7 @@ type GetDiaryEntriesResponse struct {
 
 func (x *GetDiaryEntriesResponse) Reset() {
 	*x = GetDiaryEntriesResponse{}
-	mi := &file_diary_diary_proto_msgTypes[10]
+	mi := &file_diary_diary_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -568,7 +918,7 @@ func (x *GetDiaryEntriesResponse) String() string {
 func (*GetDiaryEntriesResponse) ProtoMessage() {}
 
 func (x *GetDiaryEntriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[10]
+	mi := &file_diary_diary_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -581,7 +931,7 @@ func (x *GetDiaryEntriesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDiaryEntriesResponse.ProtoReflect.Descriptor instead.
 func (*GetDiaryEntriesResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{10}
+	return file_diary_diary_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetDiaryEntriesResponse) GetEntries() []*DiaryEntry {
@@ -600,7 +950,7 @@ type GetDiaryEntriesByMonthResponse struct {
 
 func (x *GetDiaryEntriesByMonthResponse) Reset() {
 	*x = GetDiaryEntriesByMonthResponse{}
-	mi := &file_diary_diary_proto_msgTypes[11]
+	mi := &file_diary_diary_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -612,7 +962,7 @@ func (x *GetDiaryEntriesByMonthResponse) String() string {
 func (*GetDiaryEntriesByMonthResponse) ProtoMessage() {}
 
 func (x *GetDiaryEntriesByMonthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[11]
+	mi := &file_diary_diary_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -625,7 +975,7 @@ func (x *GetDiaryEntriesByMonthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDiaryEntriesByMonthResponse.ProtoReflect.Descriptor instead.
 func (*GetDiaryEntriesByMonthResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{11}
+	return file_diary_diary_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetDiaryEntriesByMonthResponse) GetEntries() []*DiaryEntry {
@@ -638,14 +988,15 @@ func (x *GetDiaryEntriesByMonthResponse) GetEntries() []*DiaryEntry {
 // 日記エントリを取得した結果を返すレスポンス
 type GetDiaryEntryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entry         *DiaryEntry            `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	Entry         *DiaryEntry            `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`     // 表示順で先頭のエントリ
+	Entries       []*DiaryEntry          `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"` // 同じ日付の全エントリ（表示順）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetDiaryEntryResponse) Reset() {
 	*x = GetDiaryEntryResponse{}
-	mi := &file_diary_diary_proto_msgTypes[12]
+	mi := &file_diary_diary_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -657,7 +1008,7 @@ func (x *GetDiaryEntryResponse) String() string {
 func (*GetDiaryEntryResponse) ProtoMessage() {}
 
 func (x *GetDiaryEntryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[12]
+	mi := &file_diary_diary_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -670,7 +1021,7 @@ func (x *GetDiaryEntryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDiaryEntryResponse.ProtoReflect.Descriptor instead.
 func (*GetDiaryEntryResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{12}
+	return file_diary_diary_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetDiaryEntryResponse) GetEntry() *DiaryEntry {
@@ -680,20 +1031,33 @@ func (x *GetDiaryEntryResponse) GetEntry() *DiaryEntry {
 	return nil
 }
 
+func (x *GetDiaryEntryResponse) GetEntries() []*DiaryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
 // 日記エントリを更新するためのリクエスト
 type UpdateDiaryEntryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	Date          *YMD                   `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title           *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"` // 指定した場合のみ更新する
+	Content         string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Date            *YMD                   `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
+	Time            *HM                    `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`                                                      // 指定した場合のみ更新する
+	ClearTime       bool                   `protobuf:"varint,6,opt,name=clear_time,json=clearTime,proto3" json:"clear_time,omitempty"`                          // trueの場合、時刻を未指定に戻す
+	Additional      bool                   `protobuf:"varint,7,opt,name=additional,proto3" json:"additional,omitempty"`                                         // trueの場合、日付の変更先に既存のエントリがあっても別のエントリとして追加する
+	TagIds          []string               `protobuf:"bytes,8,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`                                    // set_tagsがtrueの場合の日記のタグのID
+	SetTags         bool                   `protobuf:"varint,9,opt,name=set_tags,json=setTags,proto3" json:"set_tags,omitempty"`                                // trueの場合、タグをtag_idsで置き換える（空の場合はすべて外す）
+	ExpectedVersion *int64                 `protobuf:"varint,10,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"` // 指定した場合、サーバーのversionと一致しなければ更新せずにAbortedを返す
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateDiaryEntryRequest) Reset() {
 	*x = UpdateDiaryEntryRequest{}
-	mi := &file_diary_diary_proto_msgTypes[13]
+	mi := &file_diary_diary_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -705,7 +1069,7 @@ func (x *UpdateDiaryEntryRequest) String() string {
 func (*UpdateDiaryEntryRequest) ProtoMessage() {}
 
 func (x *UpdateDiaryEntryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[13]
+	mi := &file_diary_diary_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -718,7 +1082,7 @@ func (x *UpdateDiaryEntryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDiaryEntryRequest.ProtoReflect.Descriptor instead.
 func (*UpdateDiaryEntryRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{13}
+	return file_diary_diary_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *UpdateDiaryEntryRequest) GetId() string {
@@ -729,8 +1093,8 @@ func (x *UpdateDiaryEntryRequest) GetId() string {
 }
 
 func (x *UpdateDiaryEntryRequest) GetTitle() string {
-	if x != nil {
-		return x.Title
+	if x != nil && x.Title != nil {
+		return *x.Title
 	}
 	return ""
 }
@@ -749,6 +1113,48 @@ func (x *UpdateDiaryEntryRequest) GetDate() *YMD {
 	return nil
 }
 
+func (x *UpdateDiaryEntryRequest) GetTime() *HM {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *UpdateDiaryEntryRequest) GetClearTime() bool {
+	if x != nil {
+		return x.ClearTime
+	}
+	return false
+}
+
+func (x *UpdateDiaryEntryRequest) GetAdditional() bool {
+	if x != nil {
+		return x.Additional
+	}
+	return false
+}
+
+func (x *UpdateDiaryEntryRequest) GetTagIds() []string {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+func (x *UpdateDiaryEntryRequest) GetSetTags() bool {
+	if x != nil {
+		return x.SetTags
+	}
+	return false
+}
+
+func (x *UpdateDiaryEntryRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
 // 更新された日記エントリを返すレスポンス
 type UpdateDiaryEntryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -759,7 +1165,7 @@ type UpdateDiaryEntryResponse struct {
 
 func (x *UpdateDiaryEntryResponse) Reset() {
 	*x = UpdateDiaryEntryResponse{}
-	mi := &file_diary_diary_proto_msgTypes[14]
+	mi := &file_diary_diary_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -771,7 +1177,7 @@ func (x *UpdateDiaryEntryResponse) String() string {
 func (*UpdateDiaryEntryResponse) ProtoMessage() {}
 
 func (x *UpdateDiaryEntryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[14]
+	mi := &file_diary_diary_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -784,7 +1190,7 @@ func (x *UpdateDiaryEntryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDiaryEntryResponse.ProtoReflect.Descriptor instead.
 func (*UpdateDiaryEntryResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{14}
+	return file_diary_diary_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *UpdateDiaryEntryResponse) GetEntry() *DiaryEntry {
@@ -804,7 +1210,7 @@ type DeleteDiaryEntryRequest struct {
 
 func (x *DeleteDiaryEntryRequest) Reset() {
 	*x = DeleteDiaryEntryRequest{}
-	mi := &file_diary_diary_proto_msgTypes[15]
+	mi := &file_diary_diary_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -816,7 +1222,7 @@ func (x *DeleteDiaryEntryRequest) String() string {
 func (*DeleteDiaryEntryRequest) ProtoMessage() {}
 
 func (x *DeleteDiaryEntryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[15]
+	mi := &file_diary_diary_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -829,7 +1235,7 @@ func (x *DeleteDiaryEntryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteDiaryEntryRequest.ProtoReflect.Descriptor instead.
 func (*DeleteDiaryEntryRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{15}
+	return file_diary_diary_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *DeleteDiaryEntryRequest) GetId() string {
@@ -849,7 +1255,7 @@ type DeleteDiaryEntryResponse struct {
 
 func (x *DeleteDiaryEntryResponse) Reset() {
 	*x = DeleteDiaryEntryResponse{}
-	mi := &file_diary_diary_proto_msgTypes[16]
+	mi := &file_diary_diary_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -861,7 +1267,7 @@ func (x *DeleteDiaryEntryResponse) String() string {
 func (*DeleteDiaryEntryResponse) ProtoMessage() {}
 
 func (x *DeleteDiaryEntryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[16]
+	mi := &file_diary_diary_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -874,7 +1280,7 @@ func (x *DeleteDiaryEntryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteDiaryEntryResponse.ProtoReflect.Descriptor instead.
 func (*DeleteDiaryEntryResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{16}
+	return file_diary_diary_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *DeleteDiaryEntryResponse) GetSuccess() bool {
@@ -900,7 +1306,7 @@ type MonthlySummary struct {
 
 func (x *MonthlySummary) Reset() {
 	*x = MonthlySummary{}
-	mi := &file_diary_diary_proto_msgTypes[17]
+	mi := &file_diary_diary_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -912,7 +1318,7 @@ func (x *MonthlySummary) String() string {
 func (*MonthlySummary) ProtoMessage() {}
 
 func (x *MonthlySummary) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[17]
+	mi := &file_diary_diary_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -925,7 +1331,7 @@ func (x *MonthlySummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MonthlySummary.ProtoReflect.Descriptor instead.
 func (*MonthlySummary) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{17}
+	return file_diary_diary_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *MonthlySummary) GetId() string {
@@ -987,7 +1393,7 @@ type GenerateMonthlySummaryRequest struct {
 
 func (x *GenerateMonthlySummaryRequest) Reset() {
 	*x = GenerateMonthlySummaryRequest{}
-	mi := &file_diary_diary_proto_msgTypes[18]
+	mi := &file_diary_diary_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -999,7 +1405,7 @@ func (x *GenerateMonthlySummaryRequest) String() string {
 func (*GenerateMonthlySummaryRequest) ProtoMessage() {}
 
 func (x *GenerateMonthlySummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[18]
+	mi := &file_diary_diary_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1012,7 +1418,7 @@ func (x *GenerateMonthlySummaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateMonthlySummaryRequest.ProtoReflect.Descriptor instead.
 func (*GenerateMonthlySummaryRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{18}
+	return file_diary_diary_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GenerateMonthlySummaryRequest) GetMonth() *YM {
@@ -1032,7 +1438,7 @@ type GenerateMonthlySummaryResponse struct {
 
 func (x *GenerateMonthlySummaryResponse) Reset() {
 	*x = GenerateMonthlySummaryResponse{}
-	mi := &file_diary_diary_proto_msgTypes[19]
+	mi := &file_diary_diary_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1044,7 +1450,7 @@ func (x *GenerateMonthlySummaryResponse) String() string {
 func (*GenerateMonthlySummaryResponse) ProtoMessage() {}
 
 func (x *GenerateMonthlySummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[19]
+	mi := &file_diary_diary_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1057,7 +1463,7 @@ func (x *GenerateMonthlySummaryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateMonthlySummaryResponse.ProtoReflect.Descriptor instead.
 func (*GenerateMonthlySummaryResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{19}
+	return file_diary_diary_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GenerateMonthlySummaryResponse) GetSummary() *MonthlySummary {
@@ -1077,7 +1483,7 @@ type GetMonthlySummaryRequest struct {
 
 func (x *GetMonthlySummaryRequest) Reset() {
 	*x = GetMonthlySummaryRequest{}
-	mi := &file_diary_diary_proto_msgTypes[20]
+	mi := &file_diary_diary_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1089,7 +1495,7 @@ func (x *GetMonthlySummaryRequest) String() string {
 func (*GetMonthlySummaryRequest) ProtoMessage() {}
 
 func (x *GetMonthlySummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[20]
+	mi := &file_diary_diary_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1102,7 +1508,7 @@ func (x *GetMonthlySummaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMonthlySummaryRequest.ProtoReflect.Descriptor instead.
 func (*GetMonthlySummaryRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{20}
+	return file_diary_diary_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GetMonthlySummaryRequest) GetMonth() *YM {
@@ -1122,7 +1528,7 @@ type GetMonthlySummaryResponse struct {
 
 func (x *GetMonthlySummaryResponse) Reset() {
 	*x = GetMonthlySummaryResponse{}
-	mi := &file_diary_diary_proto_msgTypes[21]
+	mi := &file_diary_diary_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1134,7 +1540,7 @@ func (x *GetMonthlySummaryResponse) String() string {
 func (*GetMonthlySummaryResponse) ProtoMessage() {}
 
 func (x *GetMonthlySummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[21]
+	mi := &file_diary_diary_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1147,7 +1553,7 @@ func (x *GetMonthlySummaryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMonthlySummaryResponse.ProtoReflect.Descriptor instead.
 func (*GetMonthlySummaryResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{21}
+	return file_diary_diary_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetMonthlySummaryResponse) GetSummary() *MonthlySummary {
@@ -1166,7 +1572,7 @@ type GetLatestTrendRequest struct {
 
 func (x *GetLatestTrendRequest) Reset() {
 	*x = GetLatestTrendRequest{}
-	mi := &file_diary_diary_proto_msgTypes[22]
+	mi := &file_diary_diary_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1178,7 +1584,7 @@ func (x *GetLatestTrendRequest) String() string {
 func (*GetLatestTrendRequest) ProtoMessage() {}
 
 func (x *GetLatestTrendRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[22]
+	mi := &file_diary_diary_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1191,7 +1597,7 @@ func (x *GetLatestTrendRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLatestTrendRequest.ProtoReflect.Descriptor instead.
 func (*GetLatestTrendRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{22}
+	return file_diary_diary_proto_rawDescGZIP(), []int{23}
 }
 
 // 直近トレンド分析取得レスポンス
@@ -1212,7 +1618,7 @@ type GetLatestTrendResponse struct {
 
 func (x *GetLatestTrendResponse) Reset() {
 	*x = GetLatestTrendResponse{}
-	mi := &file_diary_diary_proto_msgTypes[23]
+	mi := &file_diary_diary_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1224,7 +1630,7 @@ func (x *GetLatestTrendResponse) String() string {
 func (*GetLatestTrendResponse) ProtoMessage() {}
 
 func (x *GetLatestTrendResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[23]
+	mi := &file_diary_diary_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1237,7 +1643,7 @@ func (x *GetLatestTrendResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLatestTrendResponse.ProtoReflect.Descriptor instead.
 func (*GetLatestTrendResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{23}
+	return file_diary_diary_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetLatestTrendResponse) GetHealth() string {
@@ -1312,7 +1718,7 @@ type TriggerLatestTrendRequest struct {
 
 func (x *TriggerLatestTrendRequest) Reset() {
 	*x = TriggerLatestTrendRequest{}
-	mi := &file_diary_diary_proto_msgTypes[24]
+	mi := &file_diary_diary_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1324,7 +1730,7 @@ func (x *TriggerLatestTrendRequest) String() string {
 func (*TriggerLatestTrendRequest) ProtoMessage() {}
 
 func (x *TriggerLatestTrendRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[24]
+	mi := &file_diary_diary_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1337,7 +1743,7 @@ func (x *TriggerLatestTrendRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerLatestTrendRequest.ProtoReflect.Descriptor instead.
 func (*TriggerLatestTrendRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{24}
+	return file_diary_diary_proto_rawDescGZIP(), []int{25}
 }
 
 // 直近トレンド分析生成トリガーレスポンス（デバッグ用）
@@ -1351,7 +1757,7 @@ type TriggerLatestTrendResponse struct {
 
 func (x *TriggerLatestTrendResponse) Reset() {
 	*x = TriggerLatestTrendResponse{}
-	mi := &file_diary_diary_proto_msgTypes[25]
+	mi := &file_diary_diary_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1363,7 +1769,7 @@ func (x *TriggerLatestTrendResponse) String() string {
 func (*TriggerLatestTrendResponse) ProtoMessage() {}
 
 func (x *TriggerLatestTrendResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[25]
+	mi := &file_diary_diary_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1376,7 +1782,7 @@ func (x *TriggerLatestTrendResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerLatestTrendResponse.ProtoReflect.Descriptor instead.
 func (*TriggerLatestTrendResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{25}
+	return file_diary_diary_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *TriggerLatestTrendResponse) GetSuccess() bool {
@@ -1396,15 +1802,16 @@ func (x *TriggerLatestTrendResponse) GetMessage() string {
 // 意味的検索リクエスト
 type SearchDiaryEntriesSemanticRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`  // 自然言語クエリ
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // 上位何件返すか (default: 10, max: 50)
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                 // 自然言語クエリ
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                // 上位何件返すか (default: 10, max: 50)
+	TagIds        []string               `protobuf:"bytes,3,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"` // 指定したタグをすべて持つ日記に絞り込む
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SearchDiaryEntriesSemanticRequest) Reset() {
 	*x = SearchDiaryEntriesSemanticRequest{}
-	mi := &file_diary_diary_proto_msgTypes[26]
+	mi := &file_diary_diary_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1416,7 +1823,7 @@ func (x *SearchDiaryEntriesSemanticRequest) String() string {
 func (*SearchDiaryEntriesSemanticRequest) ProtoMessage() {}
 
 func (x *SearchDiaryEntriesSemanticRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[26]
+	mi := &file_diary_diary_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1429,7 +1836,7 @@ func (x *SearchDiaryEntriesSemanticRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use SearchDiaryEntriesSemanticRequest.ProtoReflect.Descriptor instead.
 func (*SearchDiaryEntriesSemanticRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{26}
+	return file_diary_diary_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *SearchDiaryEntriesSemanticRequest) GetQuery() string {
@@ -1446,6 +1853,13 @@ func (x *SearchDiaryEntriesSemanticRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *SearchDiaryEntriesSemanticRequest) GetTagIds() []string {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
 // 意味的検索の1件分の結果
 type SemanticSearchResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1461,7 +1875,7 @@ type SemanticSearchResult struct {
 
 func (x *SemanticSearchResult) Reset() {
 	*x = SemanticSearchResult{}
-	mi := &file_diary_diary_proto_msgTypes[27]
+	mi := &file_diary_diary_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1473,7 +1887,7 @@ func (x *SemanticSearchResult) String() string {
 func (*SemanticSearchResult) ProtoMessage() {}
 
 func (x *SemanticSearchResult) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[27]
+	mi := &file_diary_diary_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1486,7 +1900,7 @@ func (x *SemanticSearchResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SemanticSearchResult.ProtoReflect.Descriptor instead.
 func (*SemanticSearchResult) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{27}
+	return file_diary_diary_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *SemanticSearchResult) GetDiaryId() string {
@@ -1543,7 +1957,7 @@ type SearchDiaryEntriesSemanticResponse struct {
 
 func (x *SearchDiaryEntriesSemanticResponse) Reset() {
 	*x = SearchDiaryEntriesSemanticResponse{}
-	mi := &file_diary_diary_proto_msgTypes[28]
+	mi := &file_diary_diary_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1555,7 +1969,7 @@ func (x *SearchDiaryEntriesSemanticResponse) String() string {
 func (*SearchDiaryEntriesSemanticResponse) ProtoMessage() {}
 
 func (x *SearchDiaryEntriesSemanticResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[28]
+	mi := &file_diary_diary_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1568,7 +1982,7 @@ func (x *SearchDiaryEntriesSemanticResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use SearchDiaryEntriesSemanticResponse.ProtoReflect.Descriptor instead.
 func (*SearchDiaryEntriesSemanticResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{28}
+	return file_diary_diary_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *SearchDiaryEntriesSemanticResponse) GetResults() []*SemanticSearchResult {
@@ -1602,7 +2016,7 @@ type TriggerDiaryHighlightRequest struct {
 
 func (x *TriggerDiaryHighlightRequest) Reset() {
 	*x = TriggerDiaryHighlightRequest{}
-	mi := &file_diary_diary_proto_msgTypes[29]
+	mi := &file_diary_diary_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1614,7 +2028,7 @@ func (x *TriggerDiaryHighlightRequest) String() string {
 func (*TriggerDiaryHighlightRequest) ProtoMessage() {}
 
 func (x *TriggerDiaryHighlightRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[29]
+	mi := &file_diary_diary_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1627,7 +2041,7 @@ func (x *TriggerDiaryHighlightRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerDiaryHighlightRequest.ProtoReflect.Descriptor instead.
 func (*TriggerDiaryHighlightRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{29}
+	return file_diary_diary_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *TriggerDiaryHighlightRequest) GetDiaryId() string {
@@ -1648,7 +2062,7 @@ type TriggerDiaryHighlightResponse struct {
 
 func (x *TriggerDiaryHighlightResponse) Reset() {
 	*x = TriggerDiaryHighlightResponse{}
-	mi := &file_diary_diary_proto_msgTypes[30]
+	mi := &file_diary_diary_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1660,7 +2074,7 @@ func (x *TriggerDiaryHighlightResponse) String() string {
 func (*TriggerDiaryHighlightResponse) ProtoMessage() {}
 
 func (x *TriggerDiaryHighlightResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[30]
+	mi := &file_diary_diary_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1673,7 +2087,7 @@ func (x *TriggerDiaryHighlightResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerDiaryHighlightResponse.ProtoReflect.Descriptor instead.
 func (*TriggerDiaryHighlightResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{30}
+	return file_diary_diary_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *TriggerDiaryHighlightResponse) GetQueued() bool {
@@ -1700,7 +2114,7 @@ type GetDiaryHighlightRequest struct {
 
 func (x *GetDiaryHighlightRequest) Reset() {
 	*x = GetDiaryHighlightRequest{}
-	mi := &file_diary_diary_proto_msgTypes[31]
+	mi := &file_diary_diary_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1712,7 +2126,7 @@ func (x *GetDiaryHighlightRequest) String() string {
 func (*GetDiaryHighlightRequest) ProtoMessage() {}
 
 func (x *GetDiaryHighlightRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[31]
+	mi := &file_diary_diary_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1725,7 +2139,7 @@ func (x *GetDiaryHighlightRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDiaryHighlightRequest.ProtoReflect.Descriptor instead.
 func (*GetDiaryHighlightRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{31}
+	return file_diary_diary_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *GetDiaryHighlightRequest) GetDiaryId() string {
@@ -1747,7 +2161,7 @@ type HighlightRange struct {
 
 func (x *HighlightRange) Reset() {
 	*x = HighlightRange{}
-	mi := &file_diary_diary_proto_msgTypes[32]
+	mi := &file_diary_diary_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1759,7 +2173,7 @@ func (x *HighlightRange) String() string {
 func (*HighlightRange) ProtoMessage() {}
 
 func (x *HighlightRange) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[32]
+	mi := &file_diary_diary_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1772,7 +2186,7 @@ func (x *HighlightRange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HighlightRange.ProtoReflect.Descriptor instead.
 func (*HighlightRange) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{32}
+	return file_diary_diary_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *HighlightRange) GetStart() int32 {
@@ -1808,7 +2222,7 @@ type GetDiaryHighlightResponse struct {
 
 func (x *GetDiaryHighlightResponse) Reset() {
 	*x = GetDiaryHighlightResponse{}
-	mi := &file_diary_diary_proto_msgTypes[33]
+	mi := &file_diary_diary_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1820,7 +2234,7 @@ func (x *GetDiaryHighlightResponse) String() string {
 func (*GetDiaryHighlightResponse) ProtoMessage() {}
 
 func (x *GetDiaryHighlightResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[33]
+	mi := &file_diary_diary_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1833,7 +2247,7 @@ func (x *GetDiaryHighlightResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDiaryHighlightResponse.ProtoReflect.Descriptor instead.
 func (*GetDiaryHighlightResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{33}
+	return file_diary_diary_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *GetDiaryHighlightResponse) GetHighlights() []*HighlightRange {
@@ -1866,7 +2280,7 @@ type RegenerateAllEmbeddingsRequest struct {
 
 func (x *RegenerateAllEmbeddingsRequest) Reset() {
 	*x = RegenerateAllEmbeddingsRequest{}
-	mi := &file_diary_diary_proto_msgTypes[34]
+	mi := &file_diary_diary_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1878,7 +2292,7 @@ func (x *RegenerateAllEmbeddingsRequest) String() string {
 func (*RegenerateAllEmbeddingsRequest) ProtoMessage() {}
 
 func (x *RegenerateAllEmbeddingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[34]
+	mi := &file_diary_diary_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1891,7 +2305,7 @@ func (x *RegenerateAllEmbeddingsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegenerateAllEmbeddingsRequest.ProtoReflect.Descriptor instead.
 func (*RegenerateAllEmbeddingsRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{34}
+	return file_diary_diary_proto_rawDescGZIP(), []int{35}
 }
 
 // 全日記のembedding再生成レスポンス
@@ -1905,7 +2319,7 @@ type RegenerateAllEmbeddingsResponse struct {
 
 func (x *RegenerateAllEmbeddingsResponse) Reset() {
 	*x = RegenerateAllEmbeddingsResponse{}
-	mi := &file_diary_diary_proto_msgTypes[35]
+	mi := &file_diary_diary_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1917,7 +2331,7 @@ func (x *RegenerateAllEmbeddingsResponse) String() string {
 func (*RegenerateAllEmbeddingsResponse) ProtoMessage() {}
 
 func (x *RegenerateAllEmbeddingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[35]
+	mi := &file_diary_diary_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1930,7 +2344,7 @@ func (x *RegenerateAllEmbeddingsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegenerateAllEmbeddingsResponse.ProtoReflect.Descriptor instead.
 func (*RegenerateAllEmbeddingsResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{35}
+	return file_diary_diary_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *RegenerateAllEmbeddingsResponse) GetSuccess() bool {
@@ -1957,7 +2371,7 @@ type GetDiaryEmbeddingStatusRequest struct {
 
 func (x *GetDiaryEmbeddingStatusRequest) Reset() {
 	*x = GetDiaryEmbeddingStatusRequest{}
-	mi := &file_diary_diary_proto_msgTypes[36]
+	mi := &file_diary_diary_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1969,7 +2383,7 @@ func (x *GetDiaryEmbeddingStatusRequest) String() string {
 func (*GetDiaryEmbeddingStatusRequest) ProtoMessage() {}
 
 func (x *GetDiaryEmbeddingStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[36]
+	mi := &file_diary_diary_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1982,7 +2396,7 @@ func (x *GetDiaryEmbeddingStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDiaryEmbeddingStatusRequest.ProtoReflect.Descriptor instead.
 func (*GetDiaryEmbeddingStatusRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{36}
+	return file_diary_diary_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *GetDiaryEmbeddingStatusRequest) GetDiaryId() string {
@@ -1995,15 +2409,16 @@ func (x *GetDiaryEmbeddingStatusRequest) GetDiaryId() string {
 // 日記エクスポートリクエスト
 type ExportDiaryEntriesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	From          *YM                    `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"` // 開始年月（その月の1日から）
-	To            *YM                    `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`     // 終了年月（その月の末日まで）
+	From          *YM                    `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`                   // 開始年月（その月の1日から）
+	To            *YM                    `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`                       // 終了年月（その月の末日まで）
+	TagIds        []string               `protobuf:"bytes,3,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"` // 指定したタグをすべて持つ日記に絞り込む
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ExportDiaryEntriesRequest) Reset() {
 	*x = ExportDiaryEntriesRequest{}
-	mi := &file_diary_diary_proto_msgTypes[37]
+	mi := &file_diary_diary_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2015,7 +2430,7 @@ func (x *ExportDiaryEntriesRequest) String() string {
 func (*ExportDiaryEntriesRequest) ProtoMessage() {}
 
 func (x *ExportDiaryEntriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[37]
+	mi := &file_diary_diary_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2028,7 +2443,7 @@ func (x *ExportDiaryEntriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExportDiaryEntriesRequest.ProtoReflect.Descriptor instead.
 func (*ExportDiaryEntriesRequest) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{37}
+	return file_diary_diary_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *ExportDiaryEntriesRequest) GetFrom() *YM {
@@ -2045,18 +2460,26 @@ func (x *ExportDiaryEntriesRequest) GetTo() *YM {
 	return nil
 }
 
+func (x *ExportDiaryEntriesRequest) GetTagIds() []string {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
 // 日記エクスポートレスポンス
 type ExportDiaryEntriesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Entries       []*DiaryEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // 取得した日記の総件数
+	Attachments   []*Attachment          `protobuf:"bytes,3,rep,name=attachments,proto3" json:"attachments,omitempty"`                  // 期間内の日記の添付ファイル（内容はDownloadAttachmentで取得する）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ExportDiaryEntriesResponse) Reset() {
 	*x = ExportDiaryEntriesResponse{}
-	mi := &file_diary_diary_proto_msgTypes[38]
+	mi := &file_diary_diary_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2068,7 +2491,7 @@ func (x *ExportDiaryEntriesResponse) String() string {
 func (*ExportDiaryEntriesResponse) ProtoMessage() {}
 
 func (x *ExportDiaryEntriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[38]
+	mi := &file_diary_diary_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2081,7 +2504,7 @@ func (x *ExportDiaryEntriesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExportDiaryEntriesResponse.ProtoReflect.Descriptor instead.
 func (*ExportDiaryEntriesResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{38}
+	return file_diary_diary_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *ExportDiaryEntriesResponse) GetEntries() []*DiaryEntry {
@@ -2098,35 +2521,40 @@ func (x *ExportDiaryEntriesResponse) GetTotalCount() int32 {
 	return 0
 }
 
-// 日記のRAGインデックス状態取得レスポンス
-type GetDiaryEmbeddingStatusResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Indexed           bool                   `protobuf:"varint,1,opt,name=indexed,proto3" json:"indexed,omitempty"`                                               // インデックス済みかどうか
-	ModelVersion      string                 `protobuf:"bytes,2,opt,name=model_version,json=modelVersion,proto3" json:"model_version,omitempty"`                  // embedding生成に使用したモデル
-	CreatedAt         int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                          // 埋め込み作成日時（Unix timestamp）
-	UpdatedAt         int64                  `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                          // 埋め込み更新日時（Unix timestamp）
-	ChunkModelVersion string                 `protobuf:"bytes,6,opt,name=chunk_model_version,json=chunkModelVersion,proto3" json:"chunk_model_version,omitempty"` // チャンク分割に使用したLLMモデル
-	ChunkCount        int32                  `protobuf:"varint,7,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`                       // チャンク総数
-	ChunkSummaries    []string               `protobuf:"bytes,8,rep,name=chunk_summaries,json=chunkSummaries,proto3" json:"chunk_summaries,omitempty"`            // 各チャンクの概要（chunk_index順）
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *ExportDiaryEntriesResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
 }
 
-func (x *GetDiaryEmbeddingStatusResponse) Reset() {
-	*x = GetDiaryEmbeddingStatusResponse{}
-	mi := &file_diary_diary_proto_msgTypes[39]
+// ストリーミングでの日記エクスポートリクエスト
+type StreamExportDiaryEntriesRequest struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	From                    *YM                    `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`                   // 開始年月（その月の1日から）
+	To                      *YM                    `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`                       // 終了年月（その月の末日まで）
+	TagIds                  []string               `protobuf:"bytes,3,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"` // 指定したタグをすべて持つ日記に絞り込む
+	Format                  ExportFormat           `protobuf:"varint,4,opt,name=format,proto3,enum=diary.ExportFormat" json:"format,omitempty"`
+	IncludeMonthlySummaries bool                   `protobuf:"varint,5,opt,name=include_monthly_summaries,json=includeMonthlySummaries,proto3" json:"include_monthly_summaries,omitempty"` // 生成済みの月次要約を各月の先頭に含める
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *StreamExportDiaryEntriesRequest) Reset() {
+	*x = StreamExportDiaryEntriesRequest{}
+	mi := &file_diary_diary_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDiaryEmbeddingStatusResponse) String() string {
+func (x *StreamExportDiaryEntriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDiaryEmbeddingStatusResponse) ProtoMessage() {}
+func (*StreamExportDiaryEntriesRequest) ProtoMessage() {}
 
-func (x *GetDiaryEmbeddingStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_diary_diary_proto_msgTypes[39]
+func (x *StreamExportDiaryEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2137,58 +2565,2514 @@ func (x *GetDiaryEmbeddingStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDiaryEmbeddingStatusResponse.ProtoReflect.Descriptor instead.
-func (*GetDiaryEmbeddingStatusResponse) Descriptor() ([]byte, []int) {
-	return file_diary_diary_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use StreamExportDiaryEntriesRequest.ProtoReflect.Descriptor instead.
+func (*StreamExportDiaryEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *GetDiaryEmbeddingStatusResponse) GetIndexed() bool {
+func (x *StreamExportDiaryEntriesRequest) GetFrom() *YM {
 	if x != nil {
-		return x.Indexed
+		return x.From
 	}
-	return false
+	return nil
 }
 
-func (x *GetDiaryEmbeddingStatusResponse) GetModelVersion() string {
+func (x *StreamExportDiaryEntriesRequest) GetTo() *YM {
 	if x != nil {
-		return x.ModelVersion
+		return x.To
 	}
-	return ""
+	return nil
 }
 
-func (x *GetDiaryEmbeddingStatusResponse) GetCreatedAt() int64 {
+func (x *StreamExportDiaryEntriesRequest) GetTagIds() []string {
 	if x != nil {
-		return x.CreatedAt
+		return x.TagIds
 	}
-	return 0
+	return nil
 }
 
-func (x *GetDiaryEmbeddingStatusResponse) GetUpdatedAt() int64 {
+func (x *StreamExportDiaryEntriesRequest) GetFormat() ExportFormat {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Format
 	}
-	return 0
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
 }
 
-func (x *GetDiaryEmbeddingStatusResponse) GetChunkModelVersion() string {
+func (x *StreamExportDiaryEntriesRequest) GetIncludeMonthlySummaries() bool {
 	if x != nil {
-		return x.ChunkModelVersion
+		return x.IncludeMonthlySummaries
 	}
-	return ""
+	return false
 }
 
-func (x *GetDiaryEmbeddingStatusResponse) GetChunkCount() int32 {
-	if x != nil {
-		return x.ChunkCount
-	}
-	return 0
+// ストリーミングでの日記エクスポートレスポンス
+type StreamExportDiaryEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                  // ファイルのパス（/区切り）
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // ファイルのContent-Type
+	Chunk         []byte                 `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`                                // ファイルの内容の一部
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDiaryEmbeddingStatusResponse) GetChunkSummaries() []string {
-	if x != nil {
-		return x.ChunkSummaries
-	}
-	return nil
+func (x *StreamExportDiaryEntriesResponse) Reset() {
+	*x = StreamExportDiaryEntriesResponse{}
+	mi := &file_diary_diary_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamExportDiaryEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamExportDiaryEntriesResponse) ProtoMessage() {}
+
+func (x *StreamExportDiaryEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamExportDiaryEntriesResponse.ProtoReflect.Descriptor instead.
+func (*StreamExportDiaryEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StreamExportDiaryEntriesResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *StreamExportDiaryEntriesResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *StreamExportDiaryEntriesResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+// 日記のRAGインデックス状態取得レスポンス
+type GetDiaryEmbeddingStatusResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Indexed           bool                   `protobuf:"varint,1,opt,name=indexed,proto3" json:"indexed,omitempty"`                                               // インデックス済みかどうか
+	ModelVersion      string                 `protobuf:"bytes,2,opt,name=model_version,json=modelVersion,proto3" json:"model_version,omitempty"`                  // embedding生成に使用したモデル
+	CreatedAt         int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                          // 埋め込み作成日時（Unix timestamp）
+	UpdatedAt         int64                  `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                          // 埋め込み更新日時（Unix timestamp）
+	ChunkModelVersion string                 `protobuf:"bytes,6,opt,name=chunk_model_version,json=chunkModelVersion,proto3" json:"chunk_model_version,omitempty"` // チャンク分割に使用したLLMモデル
+	ChunkCount        int32                  `protobuf:"varint,7,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`                       // チャンク総数
+	ChunkSummaries    []string               `protobuf:"bytes,8,rep,name=chunk_summaries,json=chunkSummaries,proto3" json:"chunk_summaries,omitempty"`            // 各チャンクの概要（chunk_index順）
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetDiaryEmbeddingStatusResponse) Reset() {
+	*x = GetDiaryEmbeddingStatusResponse{}
+	mi := &file_diary_diary_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDiaryEmbeddingStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDiaryEmbeddingStatusResponse) ProtoMessage() {}
+
+func (x *GetDiaryEmbeddingStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDiaryEmbeddingStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetDiaryEmbeddingStatusResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetDiaryEmbeddingStatusResponse) GetIndexed() bool {
+	if x != nil {
+		return x.Indexed
+	}
+	return false
+}
+
+func (x *GetDiaryEmbeddingStatusResponse) GetModelVersion() string {
+	if x != nil {
+		return x.ModelVersion
+	}
+	return ""
+}
+
+func (x *GetDiaryEmbeddingStatusResponse) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *GetDiaryEmbeddingStatusResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *GetDiaryEmbeddingStatusResponse) GetChunkModelVersion() string {
+	if x != nil {
+		return x.ChunkModelVersion
+	}
+	return ""
+}
+
+func (x *GetDiaryEmbeddingStatusResponse) GetChunkCount() int32 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+func (x *GetDiaryEmbeddingStatusResponse) GetChunkSummaries() []string {
+	if x != nil {
+		return x.ChunkSummaries
+	}
+	return nil
+}
+
+// タスク状態監視用のリクエスト
+type WatchTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTasksRequest) Reset() {
+	*x = WatchTasksRequest{}
+	mi := &file_diary_diary_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTasksRequest) ProtoMessage() {}
+
+func (x *WatchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTasksRequest.ProtoReflect.Descriptor instead.
+func (*WatchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{43}
+}
+
+// タスクの状態変化イベント
+type TaskEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskType      string                 `protobuf:"bytes,1,opt,name=task_type,json=taskType,proto3" json:"task_type,omitempty"` // タスク種別: "monthly_summary", "latest_trend", "diary_highlight", "diary_embedding", "diary_tag_suggestion", "data_export"
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`                     // 対象: 月次要約はYYYY-MM形式, ハイライト/embeddingは日記ID, トレンドは空
+	Status        TaskStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=diary.TaskStatus" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`      // 失敗時のエラー内容
+	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // 状態が変化した時刻 (Unixタイムスタンプ)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_diary_diary_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *TaskEvent) GetTaskType() string {
+	if x != nil {
+		return x.TaskType
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *TaskEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// 撮影位置
+type GeoPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoPoint) Reset() {
+	*x = GeoPoint{}
+	mi := &file_diary_diary_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoPoint) ProtoMessage() {}
+
+func (x *GeoPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoPoint.ProtoReflect.Descriptor instead.
+func (*GeoPoint) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GeoPoint) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GeoPoint) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+// 添付ファイル
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DiaryId       string                 `protobuf:"bytes,2,opt,name=diary_id,json=diaryId,proto3" json:"diary_id,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	HasThumbnail  bool                   `protobuf:"varint,6,opt,name=has_thumbnail,json=hasThumbnail,proto3" json:"has_thumbnail,omitempty"` // サムネイルがあるかどうか
+	TakenAt       int64                  `protobuf:"varint,7,opt,name=taken_at,json=takenAt,proto3" json:"taken_at,omitempty"`                // EXIFの撮影日時（Unix timestamp、不明な場合は0）
+	Location      *GeoPoint              `protobuf:"bytes,8,opt,name=location,proto3" json:"location,omitempty"`                              // EXIFの撮影位置（不明な場合は未設定）
+	CreatedAt     int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_diary_diary_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Attachment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Attachment) GetDiaryId() string {
+	if x != nil {
+		return x.DiaryId
+	}
+	return ""
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *Attachment) GetHasThumbnail() bool {
+	if x != nil {
+		return x.HasThumbnail
+	}
+	return false
+}
+
+func (x *Attachment) GetTakenAt() int64 {
+	if x != nil {
+		return x.TakenAt
+	}
+	return 0
+}
+
+func (x *Attachment) GetLocation() *GeoPoint {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Attachment) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// アップロードする添付ファイルの情報
+type AttachmentMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DiaryId       string                 `protobuf:"bytes,1,opt,name=diary_id,json=diaryId,proto3" json:"diary_id,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                          // 255文字以内
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // 省略時はファイルの内容から判定する
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentMetadata) Reset() {
+	*x = AttachmentMetadata{}
+	mi := &file_diary_diary_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentMetadata) ProtoMessage() {}
+
+func (x *AttachmentMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentMetadata.ProtoReflect.Descriptor instead.
+func (*AttachmentMetadata) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AttachmentMetadata) GetDiaryId() string {
+	if x != nil {
+		return x.DiaryId
+	}
+	return ""
+}
+
+func (x *AttachmentMetadata) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *AttachmentMetadata) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+// 添付ファイルのアップロードリクエスト（ストリームの最初はmetadata、以降はchunk）
+type UploadAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadAttachmentRequest_Metadata
+	//	*UploadAttachmentRequest_Chunk
+	Payload       isUploadAttachmentRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_diary_diary_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *UploadAttachmentRequest) GetPayload() isUploadAttachmentRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetMetadata() *AttachmentMetadata {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadAttachmentRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadAttachmentRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadAttachmentRequest_Payload interface {
+	isUploadAttachmentRequest_Payload()
+}
+
+type UploadAttachmentRequest_Metadata struct {
+	Metadata *AttachmentMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type UploadAttachmentRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadAttachmentRequest_Metadata) isUploadAttachmentRequest_Payload() {}
+
+func (*UploadAttachmentRequest_Chunk) isUploadAttachmentRequest_Payload() {}
+
+type UploadAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentResponse) Reset() {
+	*x = UploadAttachmentResponse{}
+	mi := &file_diary_diary_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentResponse) ProtoMessage() {}
+
+func (x *UploadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *UploadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+type DownloadAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Thumbnail     bool                   `protobuf:"varint,2,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"` // trueの場合はサムネイルを返す
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
+	mi := &file_diary_diary_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentRequest) ProtoMessage() {}
+
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DownloadAttachmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DownloadAttachmentRequest) GetThumbnail() bool {
+	if x != nil {
+		return x.Thumbnail
+	}
+	return false
+}
+
+// 添付ファイルのダウンロードレスポンス（ストリームの最初はattachment、以降はchunk）
+type DownloadAttachmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*DownloadAttachmentResponse_Attachment
+	//	*DownloadAttachmentResponse_Chunk
+	Payload       isDownloadAttachmentResponse_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_diary_diary_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentResponse) ProtoMessage() {}
+
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DownloadAttachmentResponse) GetPayload() isDownloadAttachmentResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadAttachmentResponse_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadAttachmentResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadAttachmentResponse_Payload interface {
+	isDownloadAttachmentResponse_Payload()
+}
+
+type DownloadAttachmentResponse_Attachment struct {
+	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3,oneof"`
+}
+
+type DownloadAttachmentResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DownloadAttachmentResponse_Attachment) isDownloadAttachmentResponse_Payload() {}
+
+func (*DownloadAttachmentResponse_Chunk) isDownloadAttachmentResponse_Payload() {}
+
+type ListAttachmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DiaryId       string                 `protobuf:"bytes,1,opt,name=diary_id,json=diaryId,proto3" json:"diary_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsRequest) Reset() {
+	*x = ListAttachmentsRequest{}
+	mi := &file_diary_diary_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsRequest) ProtoMessage() {}
+
+func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListAttachmentsRequest) GetDiaryId() string {
+	if x != nil {
+		return x.DiaryId
+	}
+	return ""
+}
+
+type ListAttachmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsResponse) Reset() {
+	*x = ListAttachmentsResponse{}
+	mi := &file_diary_diary_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsResponse) ProtoMessage() {}
+
+func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type DeleteAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentRequest) Reset() {
+	*x = DeleteAttachmentRequest{}
+	mi := &file_diary_diary_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentRequest) ProtoMessage() {}
+
+func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DeleteAttachmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentResponse) Reset() {
+	*x = DeleteAttachmentResponse{}
+	mi := &file_diary_diary_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentResponse) ProtoMessage() {}
+
+func (x *DeleteAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DeleteAttachmentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetAttachmentUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentUsageRequest) Reset() {
+	*x = GetAttachmentUsageRequest{}
+	mi := &file_diary_diary_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentUsageRequest) ProtoMessage() {}
+
+func (x *GetAttachmentUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetAttachmentUsageRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{56}
+}
+
+type GetAttachmentUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UsedBytes     int64                  `protobuf:"varint,1,opt,name=used_bytes,json=usedBytes,proto3" json:"used_bytes,omitempty"`            // 使用中の容量（バイト）
+	QuotaBytes    int64                  `protobuf:"varint,2,opt,name=quota_bytes,json=quotaBytes,proto3" json:"quota_bytes,omitempty"`         // 容量の上限（バイト）
+	MaxFileBytes  int64                  `protobuf:"varint,3,opt,name=max_file_bytes,json=maxFileBytes,proto3" json:"max_file_bytes,omitempty"` // 1ファイルあたりの上限（バイト）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentUsageResponse) Reset() {
+	*x = GetAttachmentUsageResponse{}
+	mi := &file_diary_diary_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentUsageResponse) ProtoMessage() {}
+
+func (x *GetAttachmentUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetAttachmentUsageResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetAttachmentUsageResponse) GetUsedBytes() int64 {
+	if x != nil {
+		return x.UsedBytes
+	}
+	return 0
+}
+
+func (x *GetAttachmentUsageResponse) GetQuotaBytes() int64 {
+	if x != nil {
+		return x.QuotaBytes
+	}
+	return 0
+}
+
+func (x *GetAttachmentUsageResponse) GetMaxFileBytes() int64 {
+	if x != nil {
+		return x.MaxFileBytes
+	}
+	return 0
+}
+
+// タグ
+type Tag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Color         string                 `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`                              // 表示色（#rrggbb形式）
+	DiaryCount    int32                  `protobuf:"varint,4,opt,name=diary_count,json=diaryCount,proto3" json:"diary_count,omitempty"` // タグが付いた日記の件数（ListTags・MergeTagsのみ設定）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_diary_diary_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *Tag) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Tag) GetDiaryCount() int32 {
+	if x != nil {
+		return x.DiaryCount
+	}
+	return 0
+}
+
+type CreateTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`   // 50文字以内
+	Color         string                 `protobuf:"bytes,2,opt,name=color,proto3" json:"color,omitempty"` // #rrggbb形式（省略時はデフォルトの色）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTagRequest) Reset() {
+	*x = CreateTagRequest{}
+	mi := &file_diary_diary_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTagRequest) ProtoMessage() {}
+
+func (x *CreateTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTagRequest.ProtoReflect.Descriptor instead.
+func (*CreateTagRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CreateTagRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTagRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+type CreateTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTagResponse) Reset() {
+	*x = CreateTagResponse{}
+	mi := &file_diary_diary_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTagResponse) ProtoMessage() {}
+
+func (x *CreateTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTagResponse.ProtoReflect.Descriptor instead.
+func (*CreateTagResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CreateTagResponse) GetTag() *Tag {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+type ListTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_diary_diary_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{61}
+}
+
+type ListTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*Tag                 `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_diary_diary_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListTagsResponse) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type RenameTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Color         *string                `protobuf:"bytes,3,opt,name=color,proto3,oneof" json:"color,omitempty"` // 指定した場合のみ変更する
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTagRequest) Reset() {
+	*x = RenameTagRequest{}
+	mi := &file_diary_diary_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTagRequest) ProtoMessage() {}
+
+func (x *RenameTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTagRequest.ProtoReflect.Descriptor instead.
+func (*RenameTagRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *RenameTagRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RenameTagRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RenameTagRequest) GetColor() string {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return ""
+}
+
+type RenameTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTagResponse) Reset() {
+	*x = RenameTagResponse{}
+	mi := &file_diary_diary_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTagResponse) ProtoMessage() {}
+
+func (x *RenameTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTagResponse.ProtoReflect.Descriptor instead.
+func (*RenameTagResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RenameTagResponse) GetTag() *Tag {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+type MergeTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourceTagIds  []string               `protobuf:"bytes,1,rep,name=source_tag_ids,json=sourceTagIds,proto3" json:"source_tag_ids,omitempty"` // 統合して削除するタグ
+	TargetTagId   string                 `protobuf:"bytes,2,opt,name=target_tag_id,json=targetTagId,proto3" json:"target_tag_id,omitempty"`    // 統合先のタグ
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeTagsRequest) Reset() {
+	*x = MergeTagsRequest{}
+	mi := &file_diary_diary_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTagsRequest) ProtoMessage() {}
+
+func (x *MergeTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTagsRequest.ProtoReflect.Descriptor instead.
+func (*MergeTagsRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *MergeTagsRequest) GetSourceTagIds() []string {
+	if x != nil {
+		return x.SourceTagIds
+	}
+	return nil
+}
+
+func (x *MergeTagsRequest) GetTargetTagId() string {
+	if x != nil {
+		return x.TargetTagId
+	}
+	return ""
+}
+
+type MergeTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"` // 統合後のタグ
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeTagsResponse) Reset() {
+	*x = MergeTagsResponse{}
+	mi := &file_diary_diary_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTagsResponse) ProtoMessage() {}
+
+func (x *MergeTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTagsResponse.ProtoReflect.Descriptor instead.
+func (*MergeTagsResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *MergeTagsResponse) GetTag() *Tag {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+type DeleteTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTagRequest) Reset() {
+	*x = DeleteTagRequest{}
+	mi := &file_diary_diary_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTagRequest) ProtoMessage() {}
+
+func (x *DeleteTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTagRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTagRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *DeleteTagRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTagResponse) Reset() {
+	*x = DeleteTagResponse{}
+	mi := &file_diary_diary_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTagResponse) ProtoMessage() {}
+
+func (x *DeleteTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTagResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTagResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *DeleteTagResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// 差分同期でクライアントから送る変更
+type DiaryMutation struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ClientMutationId string                 `protobuf:"bytes,1,opt,name=client_mutation_id,json=clientMutationId,proto3" json:"client_mutation_id,omitempty"` // 結果を突き合わせるためのクライアント側のID
+	Type             DiaryMutationType      `protobuf:"varint,2,opt,name=type,proto3,enum=diary.DiaryMutationType" json:"type,omitempty"`
+	Id               string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`                                       // 日記ID
+	BaseVersion      int64                  `protobuf:"varint,4,opt,name=base_version,json=baseVersion,proto3" json:"base_version,omitempty"` // 更新・削除の元にしたサーバーのversion
+	Content          string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`                             // 作成・更新の内容
+	Date             *YMD                   `protobuf:"bytes,6,opt,name=date,proto3" json:"date,omitempty"`                                   // 作成・更新の日付
+	Title            string                 `protobuf:"bytes,7,opt,name=title,proto3" json:"title,omitempty"`                                 // 作成・更新のタイトル
+	Time             *HM                    `protobuf:"bytes,8,opt,name=time,proto3" json:"time,omitempty"`                                   // 作成・更新の時刻（未指定の場合は時刻なし）
+	TagIds           []string               `protobuf:"bytes,9,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`                 // set_tagsがtrueの場合の日記のタグのID
+	SetTags          bool                   `protobuf:"varint,10,opt,name=set_tags,json=setTags,proto3" json:"set_tags,omitempty"`            // trueの場合、タグをtag_idsで置き換える
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DiaryMutation) Reset() {
+	*x = DiaryMutation{}
+	mi := &file_diary_diary_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiaryMutation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiaryMutation) ProtoMessage() {}
+
+func (x *DiaryMutation) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiaryMutation.ProtoReflect.Descriptor instead.
+func (*DiaryMutation) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *DiaryMutation) GetClientMutationId() string {
+	if x != nil {
+		return x.ClientMutationId
+	}
+	return ""
+}
+
+func (x *DiaryMutation) GetType() DiaryMutationType {
+	if x != nil {
+		return x.Type
+	}
+	return DiaryMutationType_DIARY_MUTATION_TYPE_UNSPECIFIED
+}
+
+func (x *DiaryMutation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DiaryMutation) GetBaseVersion() int64 {
+	if x != nil {
+		return x.BaseVersion
+	}
+	return 0
+}
+
+func (x *DiaryMutation) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *DiaryMutation) GetDate() *YMD {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *DiaryMutation) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DiaryMutation) GetTime() *HM {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *DiaryMutation) GetTagIds() []string {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+func (x *DiaryMutation) GetSetTags() bool {
+	if x != nil {
+		return x.SetTags
+	}
+	return false
+}
+
+type DiaryMutationResult struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ClientMutationId string                 `protobuf:"bytes,1,opt,name=client_mutation_id,json=clientMutationId,proto3" json:"client_mutation_id,omitempty"`
+	Status           DiaryMutationStatus    `protobuf:"varint,2,opt,name=status,proto3,enum=diary.DiaryMutationStatus" json:"status,omitempty"`
+	Entry            *DiaryEntry            `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`                                               // APPLIEDの場合は反映後の日記、CONFLICTの場合はサーバー側の現在の日記（削除済みの場合は未設定）
+	DeletedOnServer  bool                   `protobuf:"varint,4,opt,name=deleted_on_server,json=deletedOnServer,proto3" json:"deleted_on_server,omitempty"` // CONFLICTの原因がサーバー側での削除の場合はtrue
+	ErrorMessage     string                 `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`             // REJECTEDの理由
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DiaryMutationResult) Reset() {
+	*x = DiaryMutationResult{}
+	mi := &file_diary_diary_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiaryMutationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiaryMutationResult) ProtoMessage() {}
+
+func (x *DiaryMutationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiaryMutationResult.ProtoReflect.Descriptor instead.
+func (*DiaryMutationResult) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *DiaryMutationResult) GetClientMutationId() string {
+	if x != nil {
+		return x.ClientMutationId
+	}
+	return ""
+}
+
+func (x *DiaryMutationResult) GetStatus() DiaryMutationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DiaryMutationStatus_DIARY_MUTATION_STATUS_UNSPECIFIED
+}
+
+func (x *DiaryMutationResult) GetEntry() *DiaryEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *DiaryMutationResult) GetDeletedOnServer() bool {
+	if x != nil {
+		return x.DeletedOnServer
+	}
+	return false
+}
+
+func (x *DiaryMutationResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// 削除された日記
+type DiaryTombstone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                 // 日記ID
+	Date          *YMD                   `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`                             // 削除した日記の日付
+	DeletedAt     int64                  `protobuf:"varint,3,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"` // 削除日時（Unix timestamp）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiaryTombstone) Reset() {
+	*x = DiaryTombstone{}
+	mi := &file_diary_diary_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiaryTombstone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiaryTombstone) ProtoMessage() {}
+
+func (x *DiaryTombstone) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiaryTombstone.ProtoReflect.Descriptor instead.
+func (*DiaryTombstone) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *DiaryTombstone) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DiaryTombstone) GetDate() *YMD {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *DiaryTombstone) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+type SyncDiaryEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`       // 前回のnext_cursor（初回は空）
+	Mutations     []*DiaryMutation       `protobuf:"bytes,2,rep,name=mutations,proto3" json:"mutations,omitempty"` // クライアント側の変更（最大100件、順に反映する）
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`        // 返す変更の最大件数（デフォルト200、最大500）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncDiaryEntriesRequest) Reset() {
+	*x = SyncDiaryEntriesRequest{}
+	mi := &file_diary_diary_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncDiaryEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncDiaryEntriesRequest) ProtoMessage() {}
+
+func (x *SyncDiaryEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncDiaryEntriesRequest.ProtoReflect.Descriptor instead.
+func (*SyncDiaryEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *SyncDiaryEntriesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SyncDiaryEntriesRequest) GetMutations() []*DiaryMutation {
+	if x != nil {
+		return x.Mutations
+	}
+	return nil
+}
+
+func (x *SyncDiaryEntriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SyncDiaryEntriesResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Results        []*DiaryMutationResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`                                     // mutationsと同じ順の反映結果
+	ChangedEntries []*DiaryEntry          `protobuf:"bytes,2,rep,name=changed_entries,json=changedEntries,proto3" json:"changed_entries,omitempty"` // cursor以降に作成・更新された日記（反映したmutationsを含む）
+	Tombstones     []*DiaryTombstone      `protobuf:"bytes,3,rep,name=tombstones,proto3" json:"tombstones,omitempty"`                               // cursor以降に削除された日記
+	NextCursor     string                 `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`             // 次回の同期に使うカーソル
+	HasMore        bool                   `protobuf:"varint,5,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`                     // trueの場合、next_cursorで続きを取得する
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SyncDiaryEntriesResponse) Reset() {
+	*x = SyncDiaryEntriesResponse{}
+	mi := &file_diary_diary_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncDiaryEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncDiaryEntriesResponse) ProtoMessage() {}
+
+func (x *SyncDiaryEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncDiaryEntriesResponse.ProtoReflect.Descriptor instead.
+func (*SyncDiaryEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *SyncDiaryEntriesResponse) GetResults() []*DiaryMutationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SyncDiaryEntriesResponse) GetChangedEntries() []*DiaryEntry {
+	if x != nil {
+		return x.ChangedEntries
+	}
+	return nil
+}
+
+func (x *SyncDiaryEntriesResponse) GetTombstones() []*DiaryTombstone {
+	if x != nil {
+		return x.Tombstones
+	}
+	return nil
+}
+
+func (x *SyncDiaryEntriesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *SyncDiaryEntriesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type MergeDiaryContentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          string                 `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`     // 編集前の内容（両方の編集の元になった版）
+	Ours          string                 `protobuf:"bytes,2,opt,name=ours,proto3" json:"ours,omitempty"`     // 手元の編集後の内容
+	Theirs        string                 `protobuf:"bytes,3,opt,name=theirs,proto3" json:"theirs,omitempty"` // サーバー側の編集後の内容
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeDiaryContentRequest) Reset() {
+	*x = MergeDiaryContentRequest{}
+	mi := &file_diary_diary_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeDiaryContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeDiaryContentRequest) ProtoMessage() {}
+
+func (x *MergeDiaryContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeDiaryContentRequest.ProtoReflect.Descriptor instead.
+func (*MergeDiaryContentRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *MergeDiaryContentRequest) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *MergeDiaryContentRequest) GetOurs() string {
+	if x != nil {
+		return x.Ours
+	}
+	return ""
+}
+
+func (x *MergeDiaryContentRequest) GetTheirs() string {
+	if x != nil {
+		return x.Theirs
+	}
+	return ""
+}
+
+type MergeDiaryContentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Merged        string                 `protobuf:"bytes,1,opt,name=merged,proto3" json:"merged,omitempty"` // マージした内容（競合がある場合はマーカーを含む）
+	HasConflicts  bool                   `protobuf:"varint,2,opt,name=has_conflicts,json=hasConflicts,proto3" json:"has_conflicts,omitempty"`
+	ConflictCount int32                  `protobuf:"varint,3,opt,name=conflict_count,json=conflictCount,proto3" json:"conflict_count,omitempty"` // 競合した箇所の数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeDiaryContentResponse) Reset() {
+	*x = MergeDiaryContentResponse{}
+	mi := &file_diary_diary_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeDiaryContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeDiaryContentResponse) ProtoMessage() {}
+
+func (x *MergeDiaryContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeDiaryContentResponse.ProtoReflect.Descriptor instead.
+func (*MergeDiaryContentResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *MergeDiaryContentResponse) GetMerged() string {
+	if x != nil {
+		return x.Merged
+	}
+	return ""
+}
+
+func (x *MergeDiaryContentResponse) GetHasConflicts() bool {
+	if x != nil {
+		return x.HasConflicts
+	}
+	return false
+}
+
+func (x *MergeDiaryContentResponse) GetConflictCount() int32 {
+	if x != nil {
+		return x.ConflictCount
+	}
+	return 0
+}
+
+type ListTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_diary_diary_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{76}
+}
+
+// ゴミ箱の日記
+type TrashedDiaryEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *DiaryEntry            `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	DeletedAt     int64                  `protobuf:"varint,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"` // ゴミ箱に移動した日時（UNIX秒）
+	PurgeAt       int64                  `protobuf:"varint,3,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"`       // 完全に削除される日時の目安（UNIX秒）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrashedDiaryEntry) Reset() {
+	*x = TrashedDiaryEntry{}
+	mi := &file_diary_diary_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashedDiaryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashedDiaryEntry) ProtoMessage() {}
+
+func (x *TrashedDiaryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashedDiaryEntry.ProtoReflect.Descriptor instead.
+func (*TrashedDiaryEntry) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *TrashedDiaryEntry) GetEntry() *DiaryEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *TrashedDiaryEntry) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+func (x *TrashedDiaryEntry) GetPurgeAt() int64 {
+	if x != nil {
+		return x.PurgeAt
+	}
+	return 0
+}
+
+// ゴミ箱のエンティティ（元に戻すときはEntityServiceのRestoreEntityを使う）
+type TrashedEntity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DeletedAt     int64                  `protobuf:"varint,3,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"` // ゴミ箱に移動した日時（UNIX秒）
+	PurgeAt       int64                  `protobuf:"varint,4,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"`       // 完全に削除される日時の目安（UNIX秒）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrashedEntity) Reset() {
+	*x = TrashedEntity{}
+	mi := &file_diary_diary_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashedEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashedEntity) ProtoMessage() {}
+
+func (x *TrashedEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashedEntity.ProtoReflect.Descriptor instead.
+func (*TrashedEntity) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *TrashedEntity) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TrashedEntity) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TrashedEntity) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+func (x *TrashedEntity) GetPurgeAt() int64 {
+	if x != nil {
+		return x.PurgeAt
+	}
+	return 0
+}
+
+type ListTrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DiaryEntries  []*TrashedDiaryEntry   `protobuf:"bytes,1,rep,name=diary_entries,json=diaryEntries,proto3" json:"diary_entries,omitempty"`
+	Entities      []*TrashedEntity       `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty"`
+	RetentionDays int32                  `protobuf:"varint,3,opt,name=retention_days,json=retentionDays,proto3" json:"retention_days,omitempty"` // ゴミ箱の保持期間（日）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
+	mi := &file_diary_diary_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse) ProtoMessage() {}
+
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ListTrashResponse) GetDiaryEntries() []*TrashedDiaryEntry {
+	if x != nil {
+		return x.DiaryEntries
+	}
+	return nil
+}
+
+func (x *ListTrashResponse) GetEntities() []*TrashedEntity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *ListTrashResponse) GetRetentionDays() int32 {
+	if x != nil {
+		return x.RetentionDays
+	}
+	return 0
+}
+
+type RestoreDiaryEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreDiaryEntryRequest) Reset() {
+	*x = RestoreDiaryEntryRequest{}
+	mi := &file_diary_diary_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreDiaryEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreDiaryEntryRequest) ProtoMessage() {}
+
+func (x *RestoreDiaryEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreDiaryEntryRequest.ProtoReflect.Descriptor instead.
+func (*RestoreDiaryEntryRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *RestoreDiaryEntryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestoreDiaryEntryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *DiaryEntry            `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreDiaryEntryResponse) Reset() {
+	*x = RestoreDiaryEntryResponse{}
+	mi := &file_diary_diary_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreDiaryEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreDiaryEntryResponse) ProtoMessage() {}
+
+func (x *RestoreDiaryEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreDiaryEntryResponse.ProtoReflect.Descriptor instead.
+func (*RestoreDiaryEntryResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *RestoreDiaryEntryResponse) GetEntry() *DiaryEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type EmptyTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmptyTrashRequest) Reset() {
+	*x = EmptyTrashRequest{}
+	mi := &file_diary_diary_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmptyTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyTrashRequest) ProtoMessage() {}
+
+func (x *EmptyTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyTrashRequest.ProtoReflect.Descriptor instead.
+func (*EmptyTrashRequest) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{82}
+}
+
+type EmptyTrashResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	DeletedDiaryCount  int32                  `protobuf:"varint,1,opt,name=deleted_diary_count,json=deletedDiaryCount,proto3" json:"deleted_diary_count,omitempty"`
+	DeletedEntityCount int32                  `protobuf:"varint,2,opt,name=deleted_entity_count,json=deletedEntityCount,proto3" json:"deleted_entity_count,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *EmptyTrashResponse) Reset() {
+	*x = EmptyTrashResponse{}
+	mi := &file_diary_diary_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmptyTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyTrashResponse) ProtoMessage() {}
+
+func (x *EmptyTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_diary_diary_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyTrashResponse.ProtoReflect.Descriptor instead.
+func (*EmptyTrashResponse) Descriptor() ([]byte, []int) {
+	return file_diary_diary_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *EmptyTrashResponse) GetDeletedDiaryCount() int32 {
+	if x != nil {
+		return x.DeletedDiaryCount
+	}
+	return 0
+}
+
+func (x *EmptyTrashResponse) GetDeletedEntityCount() int32 {
+	if x != nil {
+		return x.DeletedEntityCount
+	}
+	return 0
 }
 
 var File_diary_diary_proto protoreflect.FileDescriptor
@@ -2202,7 +5086,10 @@ const file_diary_diary_proto_rawDesc = "" +
 	"\x03day\x18\x03 \x01(\rR\x03day\".\n" +
 	"\x02YM\x12\x12\n" +
 	"\x04year\x18\x01 \x01(\rR\x04year\x12\x14\n" +
-	"\x05month\x18\x02 \x01(\rR\x05month\"\x94\x01\n" +
+	"\x05month\x18\x02 \x01(\rR\x05month\"0\n" +
+	"\x02HM\x12\x12\n" +
+	"\x04hour\x18\x01 \x01(\rR\x04hour\x12\x16\n" +
+	"\x06minute\x18\x02 \x01(\rR\x06minute\"\xb6\x02\n" +
 	"\n" +
 	"DiaryEntry\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
@@ -2212,11 +5099,25 @@ const file_diary_diary_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\"S\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\x12\x14\n" +
+	"\x05title\x18\x06 \x01(\tR\x05title\x12\x1d\n" +
+	"\x04time\x18\a \x01(\v2\t.diary.HMR\x04time\x12\x1e\n" +
+	"\x04tags\x18\b \x03(\v2\n" +
+	".diary.TagR\x04tags\x121\n" +
+	"\x0esuggested_tags\x18\t \x03(\v2\n" +
+	".diary.TagR\rsuggestedTags\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x03R\aversion\"\xc1\x01\n" +
 	"\x17CreateDiaryEntryRequest\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x12\x1e\n" +
 	"\x04date\x18\x02 \x01(\v2\n" +
-	".diary.YMDR\x04date\"C\n" +
+	".diary.YMDR\x04date\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1d\n" +
+	"\x04time\x18\x04 \x01(\v2\t.diary.HMR\x04time\x12\x1e\n" +
+	"\n" +
+	"additional\x18\x05 \x01(\bR\n" +
+	"additional\x12\x17\n" +
+	"\atag_ids\x18\x06 \x03(\tR\x06tagIds\"C\n" +
 	"\x18CreateDiaryEntryResponse\x12'\n" +
 	"\x05entry\x18\x01 \x01(\v2\x11.diary.DiaryEntryR\x05entry\"6\n" +
 	"\x14GetDiaryEntryRequest\x12\x1e\n" +
@@ -2226,9 +5127,10 @@ const file_diary_diary_proto_rawDesc = "" +
 	"\x05dates\x18\x01 \x03(\v2\n" +
 	".diary.YMDR\x05dates\"@\n" +
 	"\x1dGetDiaryEntriesByMonthRequest\x12\x1f\n" +
-	"\x05month\x18\x01 \x01(\v2\t.diary.YMR\x05month\"5\n" +
+	"\x05month\x18\x01 \x01(\v2\t.diary.YMR\x05month\"N\n" +
 	"\x19SearchDiaryEntriesRequest\x12\x18\n" +
-	"\akeyword\x18\x01 \x01(\tR\akeyword\"\xa1\x01\n" +
+	"\akeyword\x18\x01 \x01(\tR\akeyword\x12\x17\n" +
+	"\atag_ids\x18\x02 \x03(\tR\x06tagIds\"\xa1\x01\n" +
 	"\x1aSearchDiaryEntriesResponse\x12)\n" +
 	"\x10searched_keyword\x18\x01 \x01(\tR\x0fsearchedKeyword\x12+\n" +
 	"\aentries\x18\x02 \x03(\v2\x11.diary.DiaryEntryR\aentries\x12+\n" +
@@ -2236,15 +5138,28 @@ const file_diary_diary_proto_rawDesc = "" +
 	"\x17GetDiaryEntriesResponse\x12+\n" +
 	"\aentries\x18\x01 \x03(\v2\x11.diary.DiaryEntryR\aentries\"M\n" +
 	"\x1eGetDiaryEntriesByMonthResponse\x12+\n" +
-	"\aentries\x18\x01 \x03(\v2\x11.diary.DiaryEntryR\aentries\"@\n" +
+	"\aentries\x18\x01 \x03(\v2\x11.diary.DiaryEntryR\aentries\"m\n" +
 	"\x15GetDiaryEntryResponse\x12'\n" +
-	"\x05entry\x18\x01 \x01(\v2\x11.diary.DiaryEntryR\x05entry\"y\n" +
+	"\x05entry\x18\x01 \x01(\v2\x11.diary.DiaryEntryR\x05entry\x12+\n" +
+	"\aentries\x18\x02 \x03(\v2\x11.diary.DiaryEntryR\aentries\"\xdf\x02\n" +
 	"\x17UpdateDiaryEntryRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x18\n" +
 	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1e\n" +
 	"\x04date\x18\x04 \x01(\v2\n" +
-	".diary.YMDR\x04date\"C\n" +
+	".diary.YMDR\x04date\x12\x1d\n" +
+	"\x04time\x18\x05 \x01(\v2\t.diary.HMR\x04time\x12\x1d\n" +
+	"\n" +
+	"clear_time\x18\x06 \x01(\bR\tclearTime\x12\x1e\n" +
+	"\n" +
+	"additional\x18\a \x01(\bR\n" +
+	"additional\x12\x17\n" +
+	"\atag_ids\x18\b \x03(\tR\x06tagIds\x12\x19\n" +
+	"\bset_tags\x18\t \x01(\bR\asetTags\x12.\n" +
+	"\x10expected_version\x18\n" +
+	" \x01(\x03H\x01R\x0fexpectedVersion\x88\x01\x01B\b\n" +
+	"\x06_titleB\x13\n" +
+	"\x11_expected_version\"C\n" +
 	"\x18UpdateDiaryEntryResponse\x12'\n" +
 	"\x05entry\x18\x01 \x01(\v2\x11.diary.DiaryEntryR\x05entry\")\n" +
 	"\x17DeleteDiaryEntryRequest\x12\x0e\n" +
@@ -2287,10 +5202,11 @@ const file_diary_diary_proto_rawDesc = "" +
 	"\x19TriggerLatestTrendRequest\"P\n" +
 	"\x1aTriggerLatestTrendResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"O\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"h\n" +
 	"!SearchDiaryEntriesSemanticRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xd1\x01\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x17\n" +
+	"\atag_ids\x18\x03 \x03(\tR\x06tagIds\"\xd1\x01\n" +
 	"\x14SemanticSearchResult\x12\x19\n" +
 	"\bdiary_id\x18\x01 \x01(\tR\adiaryId\x12\x1e\n" +
 	"\x04date\x18\x02 \x01(\v2\n" +
@@ -2331,14 +5247,26 @@ const file_diary_diary_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12!\n" +
 	"\fqueued_count\x18\x02 \x01(\x05R\vqueuedCount\";\n" +
 	"\x1eGetDiaryEmbeddingStatusRequest\x12\x19\n" +
-	"\bdiary_id\x18\x01 \x01(\tR\adiaryId\"U\n" +
+	"\bdiary_id\x18\x01 \x01(\tR\adiaryId\"n\n" +
 	"\x19ExportDiaryEntriesRequest\x12\x1d\n" +
 	"\x04from\x18\x01 \x01(\v2\t.diary.YMR\x04from\x12\x19\n" +
-	"\x02to\x18\x02 \x01(\v2\t.diary.YMR\x02to\"j\n" +
+	"\x02to\x18\x02 \x01(\v2\t.diary.YMR\x02to\x12\x17\n" +
+	"\atag_ids\x18\x03 \x03(\tR\x06tagIds\"\x9f\x01\n" +
 	"\x1aExportDiaryEntriesResponse\x12+\n" +
 	"\aentries\x18\x01 \x03(\v2\x11.diary.DiaryEntryR\aentries\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\x98\x02\n" +
+	"totalCount\x123\n" +
+	"\vattachments\x18\x03 \x03(\v2\x11.diary.AttachmentR\vattachments\"\xdd\x01\n" +
+	"\x1fStreamExportDiaryEntriesRequest\x12\x1d\n" +
+	"\x04from\x18\x01 \x01(\v2\t.diary.YMR\x04from\x12\x19\n" +
+	"\x02to\x18\x02 \x01(\v2\t.diary.YMR\x02to\x12\x17\n" +
+	"\atag_ids\x18\x03 \x03(\tR\x06tagIds\x12+\n" +
+	"\x06format\x18\x04 \x01(\x0e2\x13.diary.ExportFormatR\x06format\x12:\n" +
+	"\x19include_monthly_summaries\x18\x05 \x01(\bR\x17includeMonthlySummaries\"o\n" +
+	" StreamExportDiaryEntriesResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x14\n" +
+	"\x05chunk\x18\x03 \x01(\fR\x05chunk\"\x98\x02\n" +
 	"\x1fGetDiaryEmbeddingStatusResponse\x12\x18\n" +
 	"\aindexed\x18\x01 \x01(\bR\aindexed\x12#\n" +
 	"\rmodel_version\x18\x02 \x01(\tR\fmodelVersion\x12\x1d\n" +
@@ -2349,10 +5277,197 @@ const file_diary_diary_proto_rawDesc = "" +
 	"\x13chunk_model_version\x18\x06 \x01(\tR\x11chunkModelVersion\x12\x1f\n" +
 	"\vchunk_count\x18\a \x01(\x05R\n" +
 	"chunkCount\x12'\n" +
-	"\x0fchunk_summaries\x18\b \x03(\tR\x0echunkSummaries2\xb4\f\n" +
+	"\x0fchunk_summaries\x18\b \x03(\tR\x0echunkSummaries\"\x13\n" +
+	"\x11WatchTasksRequest\"\xa3\x01\n" +
+	"\tTaskEvent\x12\x1b\n" +
+	"\ttask_type\x18\x01 \x01(\tR\btaskType\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12)\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x11.diary.TaskStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\"D\n" +
+	"\bGeoPoint\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\xa1\x02\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bdiary_id\x18\x02 \x01(\tR\adiaryId\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes\x12#\n" +
+	"\rhas_thumbnail\x18\x06 \x01(\bR\fhasThumbnail\x12\x19\n" +
+	"\btaken_at\x18\a \x01(\x03R\atakenAt\x12+\n" +
+	"\blocation\x18\b \x01(\v2\x0f.diary.GeoPointR\blocation\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\"n\n" +
+	"\x12AttachmentMetadata\x12\x19\n" +
+	"\bdiary_id\x18\x01 \x01(\tR\adiaryId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\"u\n" +
+	"\x17UploadAttachmentRequest\x127\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x19.diary.AttachmentMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"M\n" +
+	"\x18UploadAttachmentResponse\x121\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x11.diary.AttachmentR\n" +
+	"attachment\"I\n" +
+	"\x19DownloadAttachmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tthumbnail\x18\x02 \x01(\bR\tthumbnail\"t\n" +
+	"\x1aDownloadAttachmentResponse\x123\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x11.diary.AttachmentH\x00R\n" +
+	"attachment\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"3\n" +
+	"\x16ListAttachmentsRequest\x12\x19\n" +
+	"\bdiary_id\x18\x01 \x01(\tR\adiaryId\"N\n" +
+	"\x17ListAttachmentsResponse\x123\n" +
+	"\vattachments\x18\x01 \x03(\v2\x11.diary.AttachmentR\vattachments\")\n" +
+	"\x17DeleteAttachmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"4\n" +
+	"\x18DeleteAttachmentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x1b\n" +
+	"\x19GetAttachmentUsageRequest\"\x82\x01\n" +
+	"\x1aGetAttachmentUsageResponse\x12\x1d\n" +
+	"\n" +
+	"used_bytes\x18\x01 \x01(\x03R\tusedBytes\x12\x1f\n" +
+	"\vquota_bytes\x18\x02 \x01(\x03R\n" +
+	"quotaBytes\x12$\n" +
+	"\x0emax_file_bytes\x18\x03 \x01(\x03R\fmaxFileBytes\"`\n" +
+	"\x03Tag\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\x12\x1f\n" +
+	"\vdiary_count\x18\x04 \x01(\x05R\n" +
+	"diaryCount\"<\n" +
+	"\x10CreateTagRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x02 \x01(\tR\x05color\"1\n" +
+	"\x11CreateTagResponse\x12\x1c\n" +
+	"\x03tag\x18\x01 \x01(\v2\n" +
+	".diary.TagR\x03tag\"\x11\n" +
+	"\x0fListTagsRequest\"2\n" +
+	"\x10ListTagsResponse\x12\x1e\n" +
+	"\x04tags\x18\x01 \x03(\v2\n" +
+	".diary.TagR\x04tags\"[\n" +
+	"\x10RenameTagRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\x05color\x18\x03 \x01(\tH\x00R\x05color\x88\x01\x01B\b\n" +
+	"\x06_color\"1\n" +
+	"\x11RenameTagResponse\x12\x1c\n" +
+	"\x03tag\x18\x01 \x01(\v2\n" +
+	".diary.TagR\x03tag\"\\\n" +
+	"\x10MergeTagsRequest\x12$\n" +
+	"\x0esource_tag_ids\x18\x01 \x03(\tR\fsourceTagIds\x12\"\n" +
+	"\rtarget_tag_id\x18\x02 \x01(\tR\vtargetTagId\"1\n" +
+	"\x11MergeTagsResponse\x12\x1c\n" +
+	"\x03tag\x18\x01 \x01(\v2\n" +
+	".diary.TagR\x03tag\"\"\n" +
+	"\x10DeleteTagRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
+	"\x11DeleteTagResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xc1\x02\n" +
+	"\rDiaryMutation\x12,\n" +
+	"\x12client_mutation_id\x18\x01 \x01(\tR\x10clientMutationId\x12,\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x18.diary.DiaryMutationTypeR\x04type\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12!\n" +
+	"\fbase_version\x18\x04 \x01(\x03R\vbaseVersion\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x12\x1e\n" +
+	"\x04date\x18\x06 \x01(\v2\n" +
+	".diary.YMDR\x04date\x12\x14\n" +
+	"\x05title\x18\a \x01(\tR\x05title\x12\x1d\n" +
+	"\x04time\x18\b \x01(\v2\t.diary.HMR\x04time\x12\x17\n" +
+	"\atag_ids\x18\t \x03(\tR\x06tagIds\x12\x19\n" +
+	"\bset_tags\x18\n" +
+	" \x01(\bR\asetTags\"\xf1\x01\n" +
+	"\x13DiaryMutationResult\x12,\n" +
+	"\x12client_mutation_id\x18\x01 \x01(\tR\x10clientMutationId\x122\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1a.diary.DiaryMutationStatusR\x06status\x12'\n" +
+	"\x05entry\x18\x03 \x01(\v2\x11.diary.DiaryEntryR\x05entry\x12*\n" +
+	"\x11deleted_on_server\x18\x04 \x01(\bR\x0fdeletedOnServer\x12#\n" +
+	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\"_\n" +
+	"\x0eDiaryTombstone\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\x04date\x18\x02 \x01(\v2\n" +
+	".diary.YMDR\x04date\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x03 \x01(\x03R\tdeletedAt\"{\n" +
+	"\x17SyncDiaryEntriesRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x122\n" +
+	"\tmutations\x18\x02 \x03(\v2\x14.diary.DiaryMutationR\tmutations\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xff\x01\n" +
+	"\x18SyncDiaryEntriesResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.diary.DiaryMutationResultR\aresults\x12:\n" +
+	"\x0fchanged_entries\x18\x02 \x03(\v2\x11.diary.DiaryEntryR\x0echangedEntries\x125\n" +
+	"\n" +
+	"tombstones\x18\x03 \x03(\v2\x15.diary.DiaryTombstoneR\n" +
+	"tombstones\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x05 \x01(\bR\ahasMore\"Z\n" +
+	"\x18MergeDiaryContentRequest\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\tR\x04base\x12\x12\n" +
+	"\x04ours\x18\x02 \x01(\tR\x04ours\x12\x16\n" +
+	"\x06theirs\x18\x03 \x01(\tR\x06theirs\"\x7f\n" +
+	"\x19MergeDiaryContentResponse\x12\x16\n" +
+	"\x06merged\x18\x01 \x01(\tR\x06merged\x12#\n" +
+	"\rhas_conflicts\x18\x02 \x01(\bR\fhasConflicts\x12%\n" +
+	"\x0econflict_count\x18\x03 \x01(\x05R\rconflictCount\"\x12\n" +
+	"\x10ListTrashRequest\"v\n" +
+	"\x11TrashedDiaryEntry\x12'\n" +
+	"\x05entry\x18\x01 \x01(\v2\x11.diary.DiaryEntryR\x05entry\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x02 \x01(\x03R\tdeletedAt\x12\x19\n" +
+	"\bpurge_at\x18\x03 \x01(\x03R\apurgeAt\"m\n" +
+	"\rTrashedEntity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x03 \x01(\x03R\tdeletedAt\x12\x19\n" +
+	"\bpurge_at\x18\x04 \x01(\x03R\apurgeAt\"\xab\x01\n" +
+	"\x11ListTrashResponse\x12=\n" +
+	"\rdiary_entries\x18\x01 \x03(\v2\x18.diary.TrashedDiaryEntryR\fdiaryEntries\x120\n" +
+	"\bentities\x18\x02 \x03(\v2\x14.diary.TrashedEntityR\bentities\x12%\n" +
+	"\x0eretention_days\x18\x03 \x01(\x05R\rretentionDays\"*\n" +
+	"\x18RestoreDiaryEntryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"D\n" +
+	"\x19RestoreDiaryEntryResponse\x12'\n" +
+	"\x05entry\x18\x01 \x01(\v2\x11.diary.DiaryEntryR\x05entry\"\x13\n" +
+	"\x11EmptyTrashRequest\"v\n" +
+	"\x12EmptyTrashResponse\x12.\n" +
+	"\x13deleted_diary_count\x18\x01 \x01(\x05R\x11deletedDiaryCount\x120\n" +
+	"\x14deleted_entity_count\x18\x02 \x01(\x05R\x12deletedEntityCount*\x91\x01\n" +
+	"\fExportFormat\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13EXPORT_FORMAT_JSONL\x10\x01\x12\x1a\n" +
+	"\x16EXPORT_FORMAT_MARKDOWN\x10\x02\x12\x15\n" +
+	"\x11EXPORT_FORMAT_CSV\x10\x03\x12\x16\n" +
+	"\x12EXPORT_FORMAT_HTML\x10\x04*\x90\x01\n" +
+	"\n" +
+	"TaskStatus\x12\x1b\n" +
+	"\x17TASK_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12TASK_STATUS_QUEUED\x10\x01\x12\x1a\n" +
+	"\x16TASK_STATUS_PROCESSING\x10\x02\x12\x19\n" +
+	"\x15TASK_STATUS_SUCCEEDED\x10\x03\x12\x16\n" +
+	"\x12TASK_STATUS_FAILED\x10\x04*\x98\x01\n" +
+	"\x11DiaryMutationType\x12#\n" +
+	"\x1fDIARY_MUTATION_TYPE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aDIARY_MUTATION_TYPE_CREATE\x10\x01\x12\x1e\n" +
+	"\x1aDIARY_MUTATION_TYPE_UPDATE\x10\x02\x12\x1e\n" +
+	"\x1aDIARY_MUTATION_TYPE_DELETE\x10\x03*\xa7\x01\n" +
+	"\x13DiaryMutationStatus\x12%\n" +
+	"!DIARY_MUTATION_STATUS_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dDIARY_MUTATION_STATUS_APPLIED\x10\x01\x12\"\n" +
+	"\x1eDIARY_MUTATION_STATUS_CONFLICT\x10\x02\x12\"\n" +
+	"\x1eDIARY_MUTATION_STATUS_REJECTED\x10\x032\xda\x16\n" +
 	"\fDiaryService\x12S\n" +
 	"\x10CreateDiaryEntry\x12\x1e.diary.CreateDiaryEntryRequest\x1a\x1f.diary.CreateDiaryEntryResponse\x12S\n" +
-	"\x10UpdateDiaryEntry\x12\x1e.diary.UpdateDiaryEntryRequest\x1a\x1f.diary.UpdateDiaryEntryResponse\x12S\n" +
+	"\x10UpdateDiaryEntry\x12\x1e.diary.UpdateDiaryEntryRequest\x1a\x1f.diary.UpdateDiaryEntryResponse\x12V\n" +
+	"\x11MergeDiaryContent\x12\x1f.diary.MergeDiaryContentRequest\x1a .diary.MergeDiaryContentResponse\x12S\n" +
 	"\x10DeleteDiaryEntry\x12\x1e.diary.DeleteDiaryEntryRequest\x1a\x1f.diary.DeleteDiaryEntryResponse\x12J\n" +
 	"\rGetDiaryEntry\x12\x1b.diary.GetDiaryEntryRequest\x1a\x1c.diary.GetDiaryEntryResponse\x12P\n" +
 	"\x0fGetDiaryEntries\x12\x1d.diary.GetDiaryEntriesRequest\x1a\x1e.diary.GetDiaryEntriesResponse\x12e\n" +
@@ -2367,7 +5482,25 @@ const file_diary_diary_proto_rawDesc = "" +
 	"\x11GetDiaryHighlight\x12\x1f.diary.GetDiaryHighlightRequest\x1a .diary.GetDiaryHighlightResponse\x12h\n" +
 	"\x17RegenerateAllEmbeddings\x12%.diary.RegenerateAllEmbeddingsRequest\x1a&.diary.RegenerateAllEmbeddingsResponse\x12h\n" +
 	"\x17GetDiaryEmbeddingStatus\x12%.diary.GetDiaryEmbeddingStatusRequest\x1a&.diary.GetDiaryEmbeddingStatusResponse\x12Y\n" +
-	"\x12ExportDiaryEntries\x12 .diary.ExportDiaryEntriesRequest\x1a!.diary.ExportDiaryEntriesResponseB@Z>github.com/project-mikan/umi.mikan/backend/infrastructure/grpcb\x06proto3"
+	"\x12ExportDiaryEntries\x12 .diary.ExportDiaryEntriesRequest\x1a!.diary.ExportDiaryEntriesResponse\x12m\n" +
+	"\x18StreamExportDiaryEntries\x12&.diary.StreamExportDiaryEntriesRequest\x1a'.diary.StreamExportDiaryEntriesResponse0\x01\x12:\n" +
+	"\n" +
+	"WatchTasks\x12\x18.diary.WatchTasksRequest\x1a\x10.diary.TaskEvent0\x01\x12U\n" +
+	"\x10UploadAttachment\x12\x1e.diary.UploadAttachmentRequest\x1a\x1f.diary.UploadAttachmentResponse(\x01\x12[\n" +
+	"\x12DownloadAttachment\x12 .diary.DownloadAttachmentRequest\x1a!.diary.DownloadAttachmentResponse0\x01\x12P\n" +
+	"\x0fListAttachments\x12\x1d.diary.ListAttachmentsRequest\x1a\x1e.diary.ListAttachmentsResponse\x12S\n" +
+	"\x10DeleteAttachment\x12\x1e.diary.DeleteAttachmentRequest\x1a\x1f.diary.DeleteAttachmentResponse\x12Y\n" +
+	"\x12GetAttachmentUsage\x12 .diary.GetAttachmentUsageRequest\x1a!.diary.GetAttachmentUsageResponse\x12>\n" +
+	"\tCreateTag\x12\x17.diary.CreateTagRequest\x1a\x18.diary.CreateTagResponse\x12;\n" +
+	"\bListTags\x12\x16.diary.ListTagsRequest\x1a\x17.diary.ListTagsResponse\x12>\n" +
+	"\tRenameTag\x12\x17.diary.RenameTagRequest\x1a\x18.diary.RenameTagResponse\x12>\n" +
+	"\tMergeTags\x12\x17.diary.MergeTagsRequest\x1a\x18.diary.MergeTagsResponse\x12>\n" +
+	"\tDeleteTag\x12\x17.diary.DeleteTagRequest\x1a\x18.diary.DeleteTagResponse\x12S\n" +
+	"\x10SyncDiaryEntries\x12\x1e.diary.SyncDiaryEntriesRequest\x1a\x1f.diary.SyncDiaryEntriesResponse\x12>\n" +
+	"\tListTrash\x12\x17.diary.ListTrashRequest\x1a\x18.diary.ListTrashResponse\x12V\n" +
+	"\x11RestoreDiaryEntry\x12\x1f.diary.RestoreDiaryEntryRequest\x1a .diary.RestoreDiaryEntryResponse\x12A\n" +
+	"\n" +
+	"EmptyTrash\x12\x18.diary.EmptyTrashRequest\x1a\x19.diary.EmptyTrashResponseB@Z>github.com/project-mikan/umi.mikan/backend/infrastructure/grpcb\x06proto3"
 
 var (
 	file_diary_diary_proto_rawDescOnce sync.Once
@@ -2381,112 +5514,229 @@ func file_diary_diary_proto_rawDescGZIP() []byte {
 	return file_diary_diary_proto_rawDescData
 }
 
-var file_diary_diary_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
+var file_diary_diary_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_diary_diary_proto_msgTypes = make([]protoimpl.MessageInfo, 84)
 var file_diary_diary_proto_goTypes = []any{
-	(*YMD)(nil),                                // 0: diary.YMD
-	(*YM)(nil),                                 // 1: diary.YM
-	(*DiaryEntry)(nil),                         // 2: diary.DiaryEntry
-	(*CreateDiaryEntryRequest)(nil),            // 3: diary.CreateDiaryEntryRequest
-	(*CreateDiaryEntryResponse)(nil),           // 4: diary.CreateDiaryEntryResponse
-	(*GetDiaryEntryRequest)(nil),               // 5: diary.GetDiaryEntryRequest
-	(*GetDiaryEntriesRequest)(nil),             // 6: diary.GetDiaryEntriesRequest
-	(*GetDiaryEntriesByMonthRequest)(nil),      // 7: diary.GetDiaryEntriesByMonthRequest
-	(*SearchDiaryEntriesRequest)(nil),          // 8: diary.SearchDiaryEntriesRequest
-	(*SearchDiaryEntriesResponse)(nil),         // 9: diary.SearchDiaryEntriesResponse
-	(*GetDiaryEntriesResponse)(nil),            // 10: diary.GetDiaryEntriesResponse
-	(*GetDiaryEntriesByMonthResponse)(nil),     // 11: diary.GetDiaryEntriesByMonthResponse
-	(*GetDiaryEntryResponse)(nil),              // 12: diary.GetDiaryEntryResponse
-	(*UpdateDiaryEntryRequest)(nil),            // 13: diary.UpdateDiaryEntryRequest
-	(*UpdateDiaryEntryResponse)(nil),           // 14: diary.UpdateDiaryEntryResponse
-	(*DeleteDiaryEntryRequest)(nil),            // 15: diary.DeleteDiaryEntryRequest
-	(*DeleteDiaryEntryResponse)(nil),           // 16: diary.DeleteDiaryEntryResponse
-	(*MonthlySummary)(nil),                     // 17: diary.MonthlySummary
-	(*GenerateMonthlySummaryRequest)(nil),      // 18: diary.GenerateMonthlySummaryRequest
-	(*GenerateMonthlySummaryResponse)(nil),     // 19: diary.GenerateMonthlySummaryResponse
-	(*GetMonthlySummaryRequest)(nil),           // 20: diary.GetMonthlySummaryRequest
-	(*GetMonthlySummaryResponse)(nil),          // 21: diary.GetMonthlySummaryResponse
-	(*GetLatestTrendRequest)(nil),              // 22: diary.GetLatestTrendRequest
-	(*GetLatestTrendResponse)(nil),             // 23: diary.GetLatestTrendResponse
-	(*TriggerLatestTrendRequest)(nil),          // 24: diary.TriggerLatestTrendRequest
-	(*TriggerLatestTrendResponse)(nil),         // 25: diary.TriggerLatestTrendResponse
-	(*SearchDiaryEntriesSemanticRequest)(nil),  // 26: diary.SearchDiaryEntriesSemanticRequest
-	(*SemanticSearchResult)(nil),               // 27: diary.SemanticSearchResult
-	(*SearchDiaryEntriesSemanticResponse)(nil), // 28: diary.SearchDiaryEntriesSemanticResponse
-	(*TriggerDiaryHighlightRequest)(nil),       // 29: diary.TriggerDiaryHighlightRequest
-	(*TriggerDiaryHighlightResponse)(nil),      // 30: diary.TriggerDiaryHighlightResponse
-	(*GetDiaryHighlightRequest)(nil),           // 31: diary.GetDiaryHighlightRequest
-	(*HighlightRange)(nil),                     // 32: diary.HighlightRange
-	(*GetDiaryHighlightResponse)(nil),          // 33: diary.GetDiaryHighlightResponse
-	(*RegenerateAllEmbeddingsRequest)(nil),     // 34: diary.RegenerateAllEmbeddingsRequest
-	(*RegenerateAllEmbeddingsResponse)(nil),    // 35: diary.RegenerateAllEmbeddingsResponse
-	(*GetDiaryEmbeddingStatusRequest)(nil),     // 36: diary.GetDiaryEmbeddingStatusRequest
-	(*ExportDiaryEntriesRequest)(nil),          // 37: diary.ExportDiaryEntriesRequest
-	(*ExportDiaryEntriesResponse)(nil),         // 38: diary.ExportDiaryEntriesResponse
-	(*GetDiaryEmbeddingStatusResponse)(nil),    // 39: diary.GetDiaryEmbeddingStatusResponse
+	(ExportFormat)(0),                          // 0: diary.ExportFormat
+	(TaskStatus)(0),                            // 1: diary.TaskStatus
+	(DiaryMutationType)(0),                     // 2: diary.DiaryMutationType
+	(DiaryMutationStatus)(0),                   // 3: diary.DiaryMutationStatus
+	(*YMD)(nil),                                // 4: diary.YMD
+	(*YM)(nil),                                 // 5: diary.YM
+	(*HM)(nil),                                 // 6: diary.HM
+	(*DiaryEntry)(nil),                         // 7: diary.DiaryEntry
+	(*CreateDiaryEntryRequest)(nil),            // 8: diary.CreateDiaryEntryRequest
+	(*CreateDiaryEntryResponse)(nil),           // 9: diary.CreateDiaryEntryResponse
+	(*GetDiaryEntryRequest)(nil),               // 10: diary.GetDiaryEntryRequest
+	(*GetDiaryEntriesRequest)(nil),             // 11: diary.GetDiaryEntriesRequest
+	(*GetDiaryEntriesByMonthRequest)(nil),      // 12: diary.GetDiaryEntriesByMonthRequest
+	(*SearchDiaryEntriesRequest)(nil),          // 13: diary.SearchDiaryEntriesRequest
+	(*SearchDiaryEntriesResponse)(nil),         // 14: diary.SearchDiaryEntriesResponse
+	(*GetDiaryEntriesResponse)(nil),            // 15: diary.GetDiaryEntriesResponse
+	(*GetDiaryEntriesByMonthResponse)(nil),     // 16: diary.GetDiaryEntriesByMonthResponse
+	(*GetDiaryEntryResponse)(nil),              // 17: diary.GetDiaryEntryResponse
+	(*UpdateDiaryEntryRequest)(nil),            // 18: diary.UpdateDiaryEntryRequest
+	(*UpdateDiaryEntryResponse)(nil),           // 19: diary.UpdateDiaryEntryResponse
+	(*DeleteDiaryEntryRequest)(nil),            // 20: diary.DeleteDiaryEntryRequest
+	(*DeleteDiaryEntryResponse)(nil),           // 21: diary.DeleteDiaryEntryResponse
+	(*MonthlySummary)(nil),                     // 22: diary.MonthlySummary
+	(*GenerateMonthlySummaryRequest)(nil),      // 23: diary.GenerateMonthlySummaryRequest
+	(*GenerateMonthlySummaryResponse)(nil),     // 24: diary.GenerateMonthlySummaryResponse
+	(*GetMonthlySummaryRequest)(nil),           // 25: diary.GetMonthlySummaryRequest
+	(*GetMonthlySummaryResponse)(nil),          // 26: diary.GetMonthlySummaryResponse
+	(*GetLatestTrendRequest)(nil),              // 27: diary.GetLatestTrendRequest
+	(*GetLatestTrendResponse)(nil),             // 28: diary.GetLatestTrendResponse
+	(*TriggerLatestTrendRequest)(nil),          // 29: diary.TriggerLatestTrendRequest
+	(*TriggerLatestTrendResponse)(nil),         // 30: diary.TriggerLatestTrendResponse
+	(*SearchDiaryEntriesSemanticRequest)(nil),  // 31: diary.SearchDiaryEntriesSemanticRequest
+	(*SemanticSearchResult)(nil),               // 32: diary.SemanticSearchResult
+	(*SearchDiaryEntriesSemanticResponse)(nil), // 33: diary.SearchDiaryEntriesSemanticResponse
+	(*TriggerDiaryHighlightRequest)(nil),       // 34: diary.TriggerDiaryHighlightRequest
+	(*TriggerDiaryHighlightResponse)(nil),      // 35: diary.TriggerDiaryHighlightResponse
+	(*GetDiaryHighlightRequest)(nil),           // 36: diary.GetDiaryHighlightRequest
+	(*HighlightRange)(nil),                     // 37: diary.HighlightRange
+	(*GetDiaryHighlightResponse)(nil),          // 38: diary.GetDiaryHighlightResponse
+	(*RegenerateAllEmbeddingsRequest)(nil),     // 39: diary.RegenerateAllEmbeddingsRequest
+	(*RegenerateAllEmbeddingsResponse)(nil),    // 40: diary.RegenerateAllEmbeddingsResponse
+	(*GetDiaryEmbeddingStatusRequest)(nil),     // 41: diary.GetDiaryEmbeddingStatusRequest
+	(*ExportDiaryEntriesRequest)(nil),          // 42: diary.ExportDiaryEntriesRequest
+	(*ExportDiaryEntriesResponse)(nil),         // 43: diary.ExportDiaryEntriesResponse
+	(*StreamExportDiaryEntriesRequest)(nil),    // 44: diary.StreamExportDiaryEntriesRequest
+	(*StreamExportDiaryEntriesResponse)(nil),   // 45: diary.StreamExportDiaryEntriesResponse
+	(*GetDiaryEmbeddingStatusResponse)(nil),    // 46: diary.GetDiaryEmbeddingStatusResponse
+	(*WatchTasksRequest)(nil),                  // 47: diary.WatchTasksRequest
+	(*TaskEvent)(nil),                          // 48: diary.TaskEvent
+	(*GeoPoint)(nil),                           // 49: diary.GeoPoint
+	(*Attachment)(nil),                         // 50: diary.Attachment
+	(*AttachmentMetadata)(nil),                 // 51: diary.AttachmentMetadata
+	(*UploadAttachmentRequest)(nil),            // 52: diary.UploadAttachmentRequest
+	(*UploadAttachmentResponse)(nil),           // 53: diary.UploadAttachmentResponse
+	(*DownloadAttachmentRequest)(nil),          // 54: diary.DownloadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil),         // 55: diary.DownloadAttachmentResponse
+	(*ListAttachmentsRequest)(nil),             // 56: diary.ListAttachmentsRequest
+	(*ListAttachmentsResponse)(nil),            // 57: diary.ListAttachmentsResponse
+	(*DeleteAttachmentRequest)(nil),            // 58: diary.DeleteAttachmentRequest
+	(*DeleteAttachmentResponse)(nil),           // 59: diary.DeleteAttachmentResponse
+	(*GetAttachmentUsageRequest)(nil),          // 60: diary.GetAttachmentUsageRequest
+	(*GetAttachmentUsageResponse)(nil),         // 61: diary.GetAttachmentUsageResponse
+	(*Tag)(nil),                                // 62: diary.Tag
+	(*CreateTagRequest)(nil),                   // 63: diary.CreateTagRequest
+	(*CreateTagResponse)(nil),                  // 64: diary.CreateTagResponse
+	(*ListTagsRequest)(nil),                    // 65: diary.ListTagsRequest
+	(*ListTagsResponse)(nil),                   // 66: diary.ListTagsResponse
+	(*RenameTagRequest)(nil),                   // 67: diary.RenameTagRequest
+	(*RenameTagResponse)(nil),                  // 68: diary.RenameTagResponse
+	(*MergeTagsRequest)(nil),                   // 69: diary.MergeTagsRequest
+	(*MergeTagsResponse)(nil),                  // 70: diary.MergeTagsResponse
+	(*DeleteTagRequest)(nil),                   // 71: diary.DeleteTagRequest
+	(*DeleteTagResponse)(nil),                  // 72: diary.DeleteTagResponse
+	(*DiaryMutation)(nil),                      // 73: diary.DiaryMutation
+	(*DiaryMutationResult)(nil),                // 74: diary.DiaryMutationResult
+	(*DiaryTombstone)(nil),                     // 75: diary.DiaryTombstone
+	(*SyncDiaryEntriesRequest)(nil),            // 76: diary.SyncDiaryEntriesRequest
+	(*SyncDiaryEntriesResponse)(nil),           // 77: diary.SyncDiaryEntriesResponse
+	(*MergeDiaryContentRequest)(nil),           // 78: diary.MergeDiaryContentRequest
+	(*MergeDiaryContentResponse)(nil),          // 79: diary.MergeDiaryContentResponse
+	(*ListTrashRequest)(nil),                   // 80: diary.ListTrashRequest
+	(*TrashedDiaryEntry)(nil),                  // 81: diary.TrashedDiaryEntry
+	(*TrashedEntity)(nil),                      // 82: diary.TrashedEntity
+	(*ListTrashResponse)(nil),                  // 83: diary.ListTrashResponse
+	(*RestoreDiaryEntryRequest)(nil),           // 84: diary.RestoreDiaryEntryRequest
+	(*RestoreDiaryEntryResponse)(nil),          // 85: diary.RestoreDiaryEntryResponse
+	(*EmptyTrashRequest)(nil),                  // 86: diary.EmptyTrashRequest
+	(*EmptyTrashResponse)(nil),                 // 87: diary.EmptyTrashResponse
 }
 var file_diary_diary_proto_depIdxs = []int32{
-	0,  // 0: diary.DiaryEntry.date:type_name -> diary.YMD
-	0,  // 1: diary.CreateDiaryEntryRequest.date:type_name -> diary.YMD
-	2,  // 2: diary.CreateDiaryEntryResponse.entry:type_name -> diary.DiaryEntry
-	0,  // 3: diary.GetDiaryEntryRequest.date:type_name -> diary.YMD
-	0,  // 4: diary.GetDiaryEntriesRequest.dates:type_name -> diary.YMD
-	1,  // 5: diary.GetDiaryEntriesByMonthRequest.month:type_name -> diary.YM
-	2,  // 6: diary.SearchDiaryEntriesResponse.entries:type_name -> diary.DiaryEntry
-	2,  // 7: diary.GetDiaryEntriesResponse.entries:type_name -> diary.DiaryEntry
-	2,  // 8: diary.GetDiaryEntriesByMonthResponse.entries:type_name -> diary.DiaryEntry
-	2,  // 9: diary.GetDiaryEntryResponse.entry:type_name -> diary.DiaryEntry
-	0,  // 10: diary.UpdateDiaryEntryRequest.date:type_name -> diary.YMD
-	2,  // 11: diary.UpdateDiaryEntryResponse.entry:type_name -> diary.DiaryEntry
-	1,  // 12: diary.MonthlySummary.month:type_name -> diary.YM
-	1,  // 13: diary.GenerateMonthlySummaryRequest.month:type_name -> diary.YM
-	17, // 14: diary.GenerateMonthlySummaryResponse.summary:type_name -> diary.MonthlySummary
-	1,  // 15: diary.GetMonthlySummaryRequest.month:type_name -> diary.YM
-	17, // 16: diary.GetMonthlySummaryResponse.summary:type_name -> diary.MonthlySummary
-	0,  // 17: diary.SemanticSearchResult.date:type_name -> diary.YMD
-	27, // 18: diary.SearchDiaryEntriesSemanticResponse.results:type_name -> diary.SemanticSearchResult
-	32, // 19: diary.GetDiaryHighlightResponse.highlights:type_name -> diary.HighlightRange
-	1,  // 20: diary.ExportDiaryEntriesRequest.from:type_name -> diary.YM
-	1,  // 21: diary.ExportDiaryEntriesRequest.to:type_name -> diary.YM
-	2,  // 22: diary.ExportDiaryEntriesResponse.entries:type_name -> diary.DiaryEntry
-	3,  // 23: diary.DiaryService.CreateDiaryEntry:input_type -> diary.CreateDiaryEntryRequest
-	13, // 24: diary.DiaryService.UpdateDiaryEntry:input_type -> diary.UpdateDiaryEntryRequest
-	15, // 25: diary.DiaryService.DeleteDiaryEntry:input_type -> diary.DeleteDiaryEntryRequest
-	5,  // 26: diary.DiaryService.GetDiaryEntry:input_type -> diary.GetDiaryEntryRequest
-	6,  // 27: diary.DiaryService.GetDiaryEntries:input_type -> diary.GetDiaryEntriesRequest
-	7,  // 28: diary.DiaryService.GetDiaryEntriesByMonth:input_type -> diary.GetDiaryEntriesByMonthRequest
-	8,  // 29: diary.DiaryService.SearchDiaryEntries:input_type -> diary.SearchDiaryEntriesRequest
-	18, // 30: diary.DiaryService.GenerateMonthlySummary:input_type -> diary.GenerateMonthlySummaryRequest
-	20, // 31: diary.DiaryService.GetMonthlySummary:input_type -> diary.GetMonthlySummaryRequest
-	22, // 32: diary.DiaryService.GetLatestTrend:input_type -> diary.GetLatestTrendRequest
-	24, // 33: diary.DiaryService.TriggerLatestTrend:input_type -> diary.TriggerLatestTrendRequest
-	26, // 34: diary.DiaryService.SearchDiaryEntriesSemantic:input_type -> diary.SearchDiaryEntriesSemanticRequest
-	29, // 35: diary.DiaryService.TriggerDiaryHighlight:input_type -> diary.TriggerDiaryHighlightRequest
-	31, // 36: diary.DiaryService.GetDiaryHighlight:input_type -> diary.GetDiaryHighlightRequest
-	34, // 37: diary.DiaryService.RegenerateAllEmbeddings:input_type -> diary.RegenerateAllEmbeddingsRequest
-	36, // 38: diary.DiaryService.GetDiaryEmbeddingStatus:input_type -> diary.GetDiaryEmbeddingStatusRequest
-	37, // 39: diary.DiaryService.ExportDiaryEntries:input_type -> diary.ExportDiaryEntriesRequest
-	4,  // 40: diary.DiaryService.CreateDiaryEntry:output_type -> diary.CreateDiaryEntryResponse
-	14, // 41: diary.DiaryService.UpdateDiaryEntry:output_type -> diary.UpdateDiaryEntryResponse
-	16, // 42: diary.DiaryService.DeleteDiaryEntry:output_type -> diary.DeleteDiaryEntryResponse
-	12, // 43: diary.DiaryService.GetDiaryEntry:output_type -> diary.GetDiaryEntryResponse
-	10, // 44: diary.DiaryService.GetDiaryEntries:output_type -> diary.GetDiaryEntriesResponse
-	11, // 45: diary.DiaryService.GetDiaryEntriesByMonth:output_type -> diary.GetDiaryEntriesByMonthResponse
-	9,  // 46: diary.DiaryService.SearchDiaryEntries:output_type -> diary.SearchDiaryEntriesResponse
-	19, // 47: diary.DiaryService.GenerateMonthlySummary:output_type -> diary.GenerateMonthlySummaryResponse
-	21, // 48: diary.DiaryService.GetMonthlySummary:output_type -> diary.GetMonthlySummaryResponse
-	23, // 49: diary.DiaryService.GetLatestTrend:output_type -> diary.GetLatestTrendResponse
-	25, // 50: diary.DiaryService.TriggerLatestTrend:output_type -> diary.TriggerLatestTrendResponse
-	28, // 51: diary.DiaryService.SearchDiaryEntriesSemantic:output_type -> diary.SearchDiaryEntriesSemanticResponse
-	30, // 52: diary.DiaryService.TriggerDiaryHighlight:output_type -> diary.TriggerDiaryHighlightResponse
-	33, // 53: diary.DiaryService.GetDiaryHighlight:output_type -> diary.GetDiaryHighlightResponse
-	35, // 54: diary.DiaryService.RegenerateAllEmbeddings:output_type -> diary.RegenerateAllEmbeddingsResponse
-	39, // 55: diary.DiaryService.GetDiaryEmbeddingStatus:output_type -> diary.GetDiaryEmbeddingStatusResponse
-	38, // 56: diary.DiaryService.ExportDiaryEntries:output_type -> diary.ExportDiaryEntriesResponse
-	40, // [40:57] is the sub-list for method output_type
-	23, // [23:40] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	4,  // 0: diary.DiaryEntry.date:type_name -> diary.YMD
+	6,  // 1: diary.DiaryEntry.time:type_name -> diary.HM
+	62, // 2: diary.DiaryEntry.tags:type_name -> diary.Tag
+	62, // 3: diary.DiaryEntry.suggested_tags:type_name -> diary.Tag
+	4,  // 4: diary.CreateDiaryEntryRequest.date:type_name -> diary.YMD
+	6,  // 5: diary.CreateDiaryEntryRequest.time:type_name -> diary.HM
+	7,  // 6: diary.CreateDiaryEntryResponse.entry:type_name -> diary.DiaryEntry
+	4,  // 7: diary.GetDiaryEntryRequest.date:type_name -> diary.YMD
+	4,  // 8: diary.GetDiaryEntriesRequest.dates:type_name -> diary.YMD
+	5,  // 9: diary.GetDiaryEntriesByMonthRequest.month:type_name -> diary.YM
+	7,  // 10: diary.SearchDiaryEntriesResponse.entries:type_name -> diary.DiaryEntry
+	7,  // 11: diary.GetDiaryEntriesResponse.entries:type_name -> diary.DiaryEntry
+	7,  // 12: diary.GetDiaryEntriesByMonthResponse.entries:type_name -> diary.DiaryEntry
+	7,  // 13: diary.GetDiaryEntryResponse.entry:type_name -> diary.DiaryEntry
+	7,  // 14: diary.GetDiaryEntryResponse.entries:type_name -> diary.DiaryEntry
+	4,  // 15: diary.UpdateDiaryEntryRequest.date:type_name -> diary.YMD
+	6,  // 16: diary.UpdateDiaryEntryRequest.time:type_name -> diary.HM
+	7,  // 17: diary.UpdateDiaryEntryResponse.entry:type_name -> diary.DiaryEntry
+	5,  // 18: diary.MonthlySummary.month:type_name -> diary.YM
+	5,  // 19: diary.GenerateMonthlySummaryRequest.month:type_name -> diary.YM
+	22, // 20: diary.GenerateMonthlySummaryResponse.summary:type_name -> diary.MonthlySummary
+	5,  // 21: diary.GetMonthlySummaryRequest.month:type_name -> diary.YM
+	22, // 22: diary.GetMonthlySummaryResponse.summary:type_name -> diary.MonthlySummary
+	4,  // 23: diary.SemanticSearchResult.date:type_name -> diary.YMD
+	32, // 24: diary.SearchDiaryEntriesSemanticResponse.results:type_name -> diary.SemanticSearchResult
+	37, // 25: diary.GetDiaryHighlightResponse.highlights:type_name -> diary.HighlightRange
+	5,  // 26: diary.ExportDiaryEntriesRequest.from:type_name -> diary.YM
+	5,  // 27: diary.ExportDiaryEntriesRequest.to:type_name -> diary.YM
+	7,  // 28: diary.ExportDiaryEntriesResponse.entries:type_name -> diary.DiaryEntry
+	50, // 29: diary.ExportDiaryEntriesResponse.attachments:type_name -> diary.Attachment
+	5,  // 30: diary.StreamExportDiaryEntriesRequest.from:type_name -> diary.YM
+	5,  // 31: diary.StreamExportDiaryEntriesRequest.to:type_name -> diary.YM
+	0,  // 32: diary.StreamExportDiaryEntriesRequest.format:type_name -> diary.ExportFormat
+	1,  // 33: diary.TaskEvent.status:type_name -> diary.TaskStatus
+	49, // 34: diary.Attachment.location:type_name -> diary.GeoPoint
+	51, // 35: diary.UploadAttachmentRequest.metadata:type_name -> diary.AttachmentMetadata
+	50, // 36: diary.UploadAttachmentResponse.attachment:type_name -> diary.Attachment
+	50, // 37: diary.DownloadAttachmentResponse.attachment:type_name -> diary.Attachment
+	50, // 38: diary.ListAttachmentsResponse.attachments:type_name -> diary.Attachment
+	62, // 39: diary.CreateTagResponse.tag:type_name -> diary.Tag
+	62, // 40: diary.ListTagsResponse.tags:type_name -> diary.Tag
+	62, // 41: diary.RenameTagResponse.tag:type_name -> diary.Tag
+	62, // 42: diary.MergeTagsResponse.tag:type_name -> diary.Tag
+	2,  // 43: diary.DiaryMutation.type:type_name -> diary.DiaryMutationType
+	4,  // 44: diary.DiaryMutation.date:type_name -> diary.YMD
+	6,  // 45: diary.DiaryMutation.time:type_name -> diary.HM
+	3,  // 46: diary.DiaryMutationResult.status:type_name -> diary.DiaryMutationStatus
+	7,  // 47: diary.DiaryMutationResult.entry:type_name -> diary.DiaryEntry
+	4,  // 48: diary.DiaryTombstone.date:type_name -> diary.YMD
+	73, // 49: diary.SyncDiaryEntriesRequest.mutations:type_name -> diary.DiaryMutation
+	74, // 50: diary.SyncDiaryEntriesResponse.results:type_name -> diary.DiaryMutationResult
+	7,  // 51: diary.SyncDiaryEntriesResponse.changed_entries:type_name -> diary.DiaryEntry
+	75, // 52: diary.SyncDiaryEntriesResponse.tombstones:type_name -> diary.DiaryTombstone
+	7,  // 53: diary.TrashedDiaryEntry.entry:type_name -> diary.DiaryEntry
+	81, // 54: diary.ListTrashResponse.diary_entries:type_name -> diary.TrashedDiaryEntry
+	82, // 55: diary.ListTrashResponse.entities:type_name -> diary.TrashedEntity
+	7,  // 56: diary.RestoreDiaryEntryResponse.entry:type_name -> diary.DiaryEntry
+	8,  // 57: diary.DiaryService.CreateDiaryEntry:input_type -> diary.CreateDiaryEntryRequest
+	18, // 58: diary.DiaryService.UpdateDiaryEntry:input_type -> diary.UpdateDiaryEntryRequest
+	78, // 59: diary.DiaryService.MergeDiaryContent:input_type -> diary.MergeDiaryContentRequest
+	20, // 60: diary.DiaryService.DeleteDiaryEntry:input_type -> diary.DeleteDiaryEntryRequest
+	10, // 61: diary.DiaryService.GetDiaryEntry:input_type -> diary.GetDiaryEntryRequest
+	11, // 62: diary.DiaryService.GetDiaryEntries:input_type -> diary.GetDiaryEntriesRequest
+	12, // 63: diary.DiaryService.GetDiaryEntriesByMonth:input_type -> diary.GetDiaryEntriesByMonthRequest
+	13, // 64: diary.DiaryService.SearchDiaryEntries:input_type -> diary.SearchDiaryEntriesRequest
+	23, // 65: diary.DiaryService.GenerateMonthlySummary:input_type -> diary.GenerateMonthlySummaryRequest
+	25, // 66: diary.DiaryService.GetMonthlySummary:input_type -> diary.GetMonthlySummaryRequest
+	27, // 67: diary.DiaryService.GetLatestTrend:input_type -> diary.GetLatestTrendRequest
+	29, // 68: diary.DiaryService.TriggerLatestTrend:input_type -> diary.TriggerLatestTrendRequest
+	31, // 69: diary.DiaryService.SearchDiaryEntriesSemantic:input_type -> diary.SearchDiaryEntriesSemanticRequest
+	34, // 70: diary.DiaryService.TriggerDiaryHighlight:input_type -> diary.TriggerDiaryHighlightRequest
+	36, // 71: diary.DiaryService.GetDiaryHighlight:input_type -> diary.GetDiaryHighlightRequest
+	39, // 72: diary.DiaryService.RegenerateAllEmbeddings:input_type -> diary.RegenerateAllEmbeddingsRequest
+	41, // 73: diary.DiaryService.GetDiaryEmbeddingStatus:input_type -> diary.GetDiaryEmbeddingStatusRequest
+	42, // 74: diary.DiaryService.ExportDiaryEntries:input_type -> diary.ExportDiaryEntriesRequest
+	44, // 75: diary.DiaryService.StreamExportDiaryEntries:input_type -> diary.StreamExportDiaryEntriesRequest
+	47, // 76: diary.DiaryService.WatchTasks:input_type -> diary.WatchTasksRequest
+	52, // 77: diary.DiaryService.UploadAttachment:input_type -> diary.UploadAttachmentRequest
+	54, // 78: diary.DiaryService.DownloadAttachment:input_type -> diary.DownloadAttachmentRequest
+	56, // 79: diary.DiaryService.ListAttachments:input_type -> diary.ListAttachmentsRequest
+	58, // 80: diary.DiaryService.DeleteAttachment:input_type -> diary.DeleteAttachmentRequest
+	60, // 81: diary.DiaryService.GetAttachmentUsage:input_type -> diary.GetAttachmentUsageRequest
+	63, // 82: diary.DiaryService.CreateTag:input_type -> diary.CreateTagRequest
+	65, // 83: diary.DiaryService.ListTags:input_type -> diary.ListTagsRequest
+	67, // 84: diary.DiaryService.RenameTag:input_type -> diary.RenameTagRequest
+	69, // 85: diary.DiaryService.MergeTags:input_type -> diary.MergeTagsRequest
+	71, // 86: diary.DiaryService.DeleteTag:input_type -> diary.DeleteTagRequest
+	76, // 87: diary.DiaryService.SyncDiaryEntries:input_type -> diary.SyncDiaryEntriesRequest
+	80, // 88: diary.DiaryService.ListTrash:input_type -> diary.ListTrashRequest
+	84, // 89: diary.DiaryService.RestoreDiaryEntry:input_type -> diary.RestoreDiaryEntryRequest
+	86, // 90: diary.DiaryService.EmptyTrash:input_type -> diary.EmptyTrashRequest
+	9,  // 91: diary.DiaryService.CreateDiaryEntry:output_type -> diary.CreateDiaryEntryResponse
+	19, // 92: diary.DiaryService.UpdateDiaryEntry:output_type -> diary.UpdateDiaryEntryResponse
+	79, // 93: diary.DiaryService.MergeDiaryContent:output_type -> diary.MergeDiaryContentResponse
+	21, // 94: diary.DiaryService.DeleteDiaryEntry:output_type -> diary.DeleteDiaryEntryResponse
+	17, // 95: diary.DiaryService.GetDiaryEntry:output_type -> diary.GetDiaryEntryResponse
+	15, // 96: diary.DiaryService.GetDiaryEntries:output_type -> diary.GetDiaryEntriesResponse
+	16, // 97: diary.DiaryService.GetDiaryEntriesByMonth:output_type -> diary.GetDiaryEntriesByMonthResponse
+	14, // 98: diary.DiaryService.SearchDiaryEntries:output_type -> diary.SearchDiaryEntriesResponse
+	24, // 99: diary.DiaryService.GenerateMonthlySummary:output_type -> diary.GenerateMonthlySummaryResponse
+	26, // 100: diary.DiaryService.GetMonthlySummary:output_type -> diary.GetMonthlySummaryResponse
+	28, // 101: diary.DiaryService.GetLatestTrend:output_type -> diary.GetLatestTrendResponse
+	30, // 102: diary.DiaryService.TriggerLatestTrend:output_type -> diary.TriggerLatestTrendResponse
+	33, // 103: diary.DiaryService.SearchDiaryEntriesSemantic:output_type -> diary.SearchDiaryEntriesSemanticResponse
+	35, // 104: diary.DiaryService.TriggerDiaryHighlight:output_type -> diary.TriggerDiaryHighlightResponse
+	38, // 105: diary.DiaryService.GetDiaryHighlight:output_type -> diary.GetDiaryHighlightResponse
+	40, // 106: diary.DiaryService.RegenerateAllEmbeddings:output_type -> diary.RegenerateAllEmbeddingsResponse
+	46, // 107: diary.DiaryService.GetDiaryEmbeddingStatus:output_type -> diary.GetDiaryEmbeddingStatusResponse
+	43, // 108: diary.DiaryService.ExportDiaryEntries:output_type -> diary.ExportDiaryEntriesResponse
+	45, // 109: diary.DiaryService.StreamExportDiaryEntries:output_type -> diary.StreamExportDiaryEntriesResponse
+	48, // 110: diary.DiaryService.WatchTasks:output_type -> diary.TaskEvent
+	53, // 111: diary.DiaryService.UploadAttachment:output_type -> diary.UploadAttachmentResponse
+	55, // 112: diary.DiaryService.DownloadAttachment:output_type -> diary.DownloadAttachmentResponse
+	57, // 113: diary.DiaryService.ListAttachments:output_type -> diary.ListAttachmentsResponse
+	59, // 114: diary.DiaryService.DeleteAttachment:output_type -> diary.DeleteAttachmentResponse
+	61, // 115: diary.DiaryService.GetAttachmentUsage:output_type -> diary.GetAttachmentUsageResponse
+	64, // 116: diary.DiaryService.CreateTag:output_type -> diary.CreateTagResponse
+	66, // 117: diary.DiaryService.ListTags:output_type -> diary.ListTagsResponse
+	68, // 118: diary.DiaryService.RenameTag:output_type -> diary.RenameTagResponse
+	70, // 119: diary.DiaryService.MergeTags:output_type -> diary.MergeTagsResponse
+	72, // 120: diary.DiaryService.DeleteTag:output_type -> diary.DeleteTagResponse
+	77, // 121: diary.DiaryService.SyncDiaryEntries:output_type -> diary.SyncDiaryEntriesResponse
+	83, // 122: diary.DiaryService.ListTrash:output_type -> diary.ListTrashResponse
+	85, // 123: diary.DiaryService.RestoreDiaryEntry:output_type -> diary.RestoreDiaryEntryResponse
+	87, // 124: diary.DiaryService.EmptyTrash:output_type -> diary.EmptyTrashResponse
+	91, // [91:125] is the sub-list for method output_type
+	57, // [57:91] is the sub-list for method input_type
+	57, // [57:57] is the sub-list for extension type_name
+	57, // [57:57] is the sub-list for extension extendee
+	0,  // [0:57] is the sub-list for field type_name
 }
 
 func init() { file_diary_diary_proto_init() }
@@ -2494,18 +5744,29 @@ func file_diary_diary_proto_init() {
 	if File_diary_diary_proto != nil {
 		return
 	}
+	file_diary_diary_proto_msgTypes[14].OneofWrappers = []any{}
+	file_diary_diary_proto_msgTypes[48].OneofWrappers = []any{
+		(*UploadAttachmentRequest_Metadata)(nil),
+		(*UploadAttachmentRequest_Chunk)(nil),
+	}
+	file_diary_diary_proto_msgTypes[51].OneofWrappers = []any{
+		(*DownloadAttachmentResponse_Attachment)(nil),
+		(*DownloadAttachmentResponse_Chunk)(nil),
+	}
+	file_diary_diary_proto_msgTypes[63].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_diary_diary_proto_rawDesc), len(file_diary_diary_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   40,
+			NumEnums:      4,
+			NumMessages:   84,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_diary_diary_proto_goTypes,
 		DependencyIndexes: file_diary_diary_proto_depIdxs,
+		EnumInfos:         file_diary_diary_proto_enumTypes,
 		MessageInfos:      file_diary_diary_proto_msgTypes,
 	}.Build()
 	File_diary_diary_proto = out.File
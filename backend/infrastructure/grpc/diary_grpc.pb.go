@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	DiaryService_CreateDiaryEntry_FullMethodName           = "/diary.DiaryService/CreateDiaryEntry"
 	DiaryService_UpdateDiaryEntry_FullMethodName           = "/diary.DiaryService/UpdateDiaryEntry"
+	DiaryService_MergeDiaryContent_FullMethodName          = "/diary.DiaryService/MergeDiaryContent"
 	DiaryService_DeleteDiaryEntry_FullMethodName           = "/diary.DiaryService/DeleteDiaryEntry"
 	DiaryService_GetDiaryEntry_FullMethodName              = "/diary.DiaryService/GetDiaryEntry"
 	DiaryService_GetDiaryEntries_FullMethodName            = "/diary.DiaryService/GetDiaryEntries"
@@ -36,6 +37,22 @@ const (
 	DiaryService_RegenerateAllEmbeddings_FullMethodName    = "/diary.DiaryService/RegenerateAllEmbeddings"
 	DiaryService_GetDiaryEmbeddingStatus_FullMethodName    = "/diary.DiaryService/GetDiaryEmbeddingStatus"
 	DiaryService_ExportDiaryEntries_FullMethodName         = "/diary.DiaryService/ExportDiaryEntries"
+	DiaryService_StreamExportDiaryEntries_FullMethodName   = "/diary.DiaryService/StreamExportDiaryEntries"
+	DiaryService_WatchTasks_FullMethodName                 = "/diary.DiaryService/WatchTasks"
+	DiaryService_UploadAttachment_FullMethodName           = "/diary.DiaryService/UploadAttachment"
+	DiaryService_DownloadAttachment_FullMethodName         = "/diary.DiaryService/DownloadAttachment"
+	DiaryService_ListAttachments_FullMethodName            = "/diary.DiaryService/ListAttachments"
+	DiaryService_DeleteAttachment_FullMethodName           = "/diary.DiaryService/DeleteAttachment"
+	DiaryService_GetAttachmentUsage_FullMethodName         = "/diary.DiaryService/GetAttachmentUsage"
+	DiaryService_CreateTag_FullMethodName                  = "/diary.DiaryService/CreateTag"
+	DiaryService_ListTags_FullMethodName                   = "/diary.DiaryService/ListTags"
+	DiaryService_RenameTag_FullMethodName                  = "/diary.DiaryService/RenameTag"
+	DiaryService_MergeTags_FullMethodName                  = "/diary.DiaryService/MergeTags"
+	DiaryService_DeleteTag_FullMethodName                  = "/diary.DiaryService/DeleteTag"
+	DiaryService_SyncDiaryEntries_FullMethodName           = "/diary.DiaryService/SyncDiaryEntries"
+	DiaryService_ListTrash_FullMethodName                  = "/diary.DiaryService/ListTrash"
+	DiaryService_RestoreDiaryEntry_FullMethodName          = "/diary.DiaryService/RestoreDiaryEntry"
+	DiaryService_EmptyTrash_FullMethodName                 = "/diary.DiaryService/EmptyTrash"
 )
 
 // DiaryServiceClient is the client API for DiaryService service.
@@ -46,15 +63,17 @@ const (
 // AI要約生成機能を提供するサービスです。
 type DiaryServiceClient interface {
 	// CreateDiaryEntry は新しい日記エントリを作成します。
-	// 1日1エントリの制約があり、同じ日付に複数のエントリは作成できません。
+	// 通常は1日1エントリで、同じ日付に既にエントリがある場合はエラーになります。
+	// additional: true を指定した場合のみ、同じ日付に別のエントリとして追加できます（朝のメモと夜の振り返りなど）。
 	//
 	// 例:
 	//
 	//	request: { content: "今日は友人と会った", date: { year: 2025, month: 10, day: 9 } }
 	//	response: { entry: { id: "uuid", content: "...", ... } }
+	//	request: { content: "朝のメモ", date: { year: 2025, month: 10, day: 9 }, title: "朝", time: { hour: 7, minute: 30 }, additional: true }
 	//
 	// エラー:
-	//   - AlreadyExists: 指定された日付の日記が既に存在する
+	//   - AlreadyExists: 指定された日付の日記が既に存在する（additional: false の場合）
 	//   - InvalidArgument: 日付が不正
 	CreateDiaryEntry(ctx context.Context, in *CreateDiaryEntryRequest, opts ...grpc.CallOption) (*CreateDiaryEntryResponse, error)
 	// UpdateDiaryEntry は既存の日記エントリを更新します。
@@ -64,22 +83,46 @@ type DiaryServiceClient interface {
 	//	request: { id: "uuid", content: "更新された内容", date: { year: 2025, month: 10, day: 9 } }
 	//	response: { entry: { id: "uuid", content: "更新された内容", ... } }
 	//
+	// title・timeは指定した場合のみ更新します（clear_time: true で時刻を未指定に戻します）。
+	//
+	// expected_versionを指定した場合、他のクライアントが先に更新していれば上書きせずにAbortedを返します。
+	// Abortedのエラーの詳細（details）にはサーバー側の現在のDiaryEntryが含まれるため、
+	// クライアントはMergeDiaryContentで手元の内容とマージしてから再度更新できます。
+	//
 	// エラー:
+	//   - AlreadyExists: 日付の変更先に日記が既に存在する（additional: false の場合）
 	//   - NotFound: 日記エントリが見つからない
 	//   - PermissionDenied: 他のユーザーの日記にアクセスしようとした
+	//   - Aborted: expected_versionがサーバーのversionと一致しない
 	UpdateDiaryEntry(ctx context.Context, in *UpdateDiaryEntryRequest, opts ...grpc.CallOption) (*UpdateDiaryEntryResponse, error)
-	// DeleteDiaryEntry は日記エントリを削除します。
+	// MergeDiaryContent は同じ日記への2つの編集を、編集前の内容（base）をもとに行単位で3方向マージします。
+	// 片方だけが変更した行はその変更を採用し、両方が同じ箇所を別々に変更した場合は
+	// 競合として両方の内容をマーカー（<<<<<<< / ======= / >>>>>>>）で囲んで残します。
+	// 日記は保存しません。
+	//
+	// 例:
+	//
+	//	request: { base: "朝\n昼\n夜\n", ours: "朝ごはん\n昼\n夜\n", theirs: "朝\n昼\n夜ふかし\n" }
+	//	response: { merged: "朝ごはん\n昼\n夜ふかし\n", has_conflicts: false, conflict_count: 0 }
+	//
+	// エラー:
+	//   - InvalidArgument: 内容が大きすぎてマージできない
+	MergeDiaryContent(ctx context.Context, in *MergeDiaryContentRequest, opts ...grpc.CallOption) (*MergeDiaryContentResponse, error)
+	// DeleteDiaryEntry は日記エントリをゴミ箱に移動します。
+	// ゴミ箱の日記は一覧・検索などに表示されず、保持期間内であればRestoreDiaryEntryで元に戻せます。
+	// 保持期間を過ぎると添付ファイルなどとともに完全に削除されます。
 	//
 	// エラー:
 	//   - NotFound: 日記エントリが見つからない
 	//   - PermissionDenied: 他のユーザーの日記にアクセスしようとした
 	DeleteDiaryEntry(ctx context.Context, in *DeleteDiaryEntryRequest, opts ...grpc.CallOption) (*DeleteDiaryEntryResponse, error)
 	// GetDiaryEntry は指定された日付の日記エントリを取得します。
+	// entryには表示順で先頭のエントリ、entriesにはその日付の全エントリが入ります。
 	//
 	// 例:
 	//
 	//	request: { date: { year: 2025, month: 10, day: 9 } }
-	//	response: { entry: { id: "uuid", content: "...", ... } }
+	//	response: { entry: { id: "uuid", content: "...", ... }, entries: [{ id: "uuid", ... }] }
 	//
 	// エラー:
 	//   - NotFound: 指定された日付の日記が存在しない
@@ -95,6 +138,7 @@ type DiaryServiceClient interface {
 	// エラー: なし（存在する日記のみ返される）
 	GetDiaryEntries(ctx context.Context, in *GetDiaryEntriesRequest, opts ...grpc.CallOption) (*GetDiaryEntriesResponse, error)
 	// GetDiaryEntriesByMonth は指定された月の全日記エントリを取得します。
+	// 日付順に並び、同じ日付のエントリは連続して返されます（時刻未指定のエントリが先頭、以降は時刻順）。
 	//
 	// 例:
 	//
@@ -221,6 +265,147 @@ type DiaryServiceClient interface {
 	// エラー:
 	//   - InvalidArgument: 開始年月が終了年月より後の場合
 	ExportDiaryEntries(ctx context.Context, in *ExportDiaryEntriesRequest, opts ...grpc.CallOption) (*ExportDiaryEntriesResponse, error)
+	// StreamExportDiaryEntries は指定期間の日記を指定した形式のファイルに変換し、サーバーストリーミングで返します。
+	// 日記を一定件数ずつ読み込みながら送信するため、期間が長くてもサーバーのメモリ使用量は一定です。
+	// 各レスポンスはファイルのパスと内容の一部で、同じパスのchunkを受け取った順に連結するとファイルになります。
+	//
+	// 形式:
+	//   - EXPORT_FORMAT_JSONL: diaries.jsonl（1行に1件の日記。月次要約を含める場合は type で区別）
+	//   - EXPORT_FORMAT_MARKDOWN: YYYY/MM/YYYY-MM-DD.md（1日1ファイル、YAML front matter付き）、月次要約は YYYY/MM/summary.md
+	//   - EXPORT_FORMAT_CSV: diaries.csv（UTF-8 BOM付き）
+	//   - EXPORT_FORMAT_HTML: diaries.html（目次付きの1ファイル）
+	//
+	// 例:
+	//
+	//	request: { from: { year: 2024, month: 4 }, to: { year: 2024, month: 6 }, format: EXPORT_FORMAT_MARKDOWN }
+	//	response(stream): { path: "2024/04/2024-04-01.md", content_type: "text/markdown; charset=utf-8", chunk: "..." }
+	//
+	// エラー:
+	//   - InvalidArgument: 開始年月が終了年月より後の場合、未対応の形式の場合
+	StreamExportDiaryEntries(ctx context.Context, in *StreamExportDiaryEntriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamExportDiaryEntriesResponse], error)
+	// WatchTasks は呼び出しユーザーの非同期タスク（月次要約・トレンド分析・ハイライト・embedding生成）の
+	// 状態変化をサーバーストリーミングで通知します。
+	// 接続直後に現在キュー済み・処理中のタスクを送信し、以降は状態が変わるたびにイベントを送信します。
+	// クライアントが切断するまでストリームは終了しません。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response(stream): { task_type: "monthly_summary", target: "2024-05", status: TASK_STATUS_SUCCEEDED, timestamp: 1234567890 }
+	//
+	// エラー:
+	//   - Unauthenticated: 認証されていない
+	WatchTasks(ctx context.Context, in *WatchTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TaskEvent], error)
+	// UploadAttachment は日記に写真・ファイルを添付します（クライアントストリーミング）。
+	// 最初のメッセージでmetadataを送り、以降のメッセージでファイルの内容をchunk（1MiB以下推奨）に分けて送ります。
+	// JPEG/PNG/GIFはサムネイルを生成し、JPEGはEXIFから撮影日時・撮影位置を抽出します。
+	//
+	// 例:
+	//
+	//	request(stream): { metadata: { diary_id: "uuid", filename: "IMG_0001.jpg", content_type: "image/jpeg" } }, { chunk: "..." }, ...
+	//	response: { attachment: { id: "uuid", size_bytes: 123456, has_thumbnail: true, taken_at: 1714516200, ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: 最初のメッセージがmetadataでない、ファイル名が不正、ファイルが空
+	//   - NotFound: 日記が存在しない（他のユーザーの日記を含む）
+	//   - ResourceExhausted: ファイルサイズの上限またはユーザーごとの容量制限を超えた
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse], error)
+	// DownloadAttachment は添付ファイルの内容を取得します（サーバーストリーミング）。
+	// 最初のメッセージで添付ファイルの情報を送り、以降のメッセージで内容をchunkに分けて送ります。
+	// thumbnail: true の場合はサムネイル（JPEG）を返します。
+	//
+	// エラー:
+	//   - NotFound: 添付ファイルが存在しない、またはサムネイルがない
+	DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error)
+	// ListAttachments は日記の添付ファイルをアップロード順に取得します。
+	//
+	// エラー:
+	//   - NotFound: 日記が存在しない
+	ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error)
+	// DeleteAttachment は添付ファイルを削除します。
+	//
+	// エラー:
+	//   - NotFound: 添付ファイルが存在しない
+	DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error)
+	// GetAttachmentUsage は添付ファイルの使用容量と上限を取得します。
+	GetAttachmentUsage(ctx context.Context, in *GetAttachmentUsageRequest, opts ...grpc.CallOption) (*GetAttachmentUsageResponse, error)
+	// CreateTag はタグを作成します。colorを省略した場合はデフォルトの色を使います。
+	//
+	// 例:
+	//
+	//	request: { name: "旅行", color: "#4f9dde" }
+	//	response: { tag: { id: "uuid", name: "旅行", color: "#4f9dde", diary_count: 0 } }
+	//
+	// エラー:
+	//   - InvalidArgument: 名前が空または50文字を超える、色が#rrggbb形式でない
+	//   - AlreadyExists: 同じ名前のタグがある
+	CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error)
+	// ListTags はタグを名前順に、付いている日記の件数とともに取得します。
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	// RenameTag はタグの名前を変更します。colorを指定した場合は色も変更します。
+	//
+	// エラー:
+	//   - InvalidArgument: 名前・色が不正
+	//   - NotFound: タグが存在しない
+	//   - AlreadyExists: 同じ名前のタグがある
+	RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error)
+	// MergeTags はsource_tag_idsのタグが付いた日記をtarget_tag_idのタグに付け替え、
+	// source_tag_idsのタグを削除します。
+	//
+	// 例:
+	//
+	//	request: { source_tag_ids: ["uuid-a", "uuid-b"], target_tag_id: "uuid-c" }
+	//	response: { tag: { id: "uuid-c", name: "旅行", diary_count: 12 } }
+	//
+	// エラー:
+	//   - InvalidArgument: source_tag_idsが空、またはtarget_tag_idを含む
+	//   - NotFound: タグが存在しない
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error)
+	// DeleteTag はタグを削除します。日記からも外れます。
+	//
+	// エラー:
+	//   - NotFound: タグが存在しない
+	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error)
+	// SyncDiaryEntries はオフラインのクライアント（iOSアプリ）と日記を差分同期します。
+	// mutationsのクライアント側の変更を順に反映した後、cursor以降にサーバー側で変更・削除された日記を
+	// 変更順に返します。初回はcursorを空にすると全件を返します。
+	// 更新・削除はbase_versionがサーバーのversionと一致する場合のみ反映し、一致しない場合は上書きせずに
+	// CONFLICTとしてサーバー側の日記を返します。
+	//
+	// 例:
+	//
+	//	request: {
+	//	  cursor: "42_uuid",
+	//	  mutations: [{ client_mutation_id: "m1", type: DIARY_MUTATION_TYPE_UPDATE, id: "uuid", base_version: 3, content: "..." }]
+	//	}
+	//	response: {
+	//	  results: [{ client_mutation_id: "m1", status: DIARY_MUTATION_STATUS_CONFLICT, entry: { id: "uuid", version: 4, ... } }],
+	//	  changed_entries: [...], tombstones: [...], next_cursor: "45_uuid", has_more: false
+	//	}
+	//
+	// エラー:
+	//   - InvalidArgument: cursorが不正、mutationsが多すぎる
+	SyncDiaryEntries(ctx context.Context, in *SyncDiaryEntriesRequest, opts ...grpc.CallOption) (*SyncDiaryEntriesResponse, error)
+	// ListTrash はゴミ箱の日記とエンティティを、ゴミ箱に移動した日時の新しい順に返します。
+	// purge_atを過ぎると完全に削除されます。
+	//
+	// 例:
+	//
+	//	response: {
+	//	  diary_entries: [{ entry: { id: "uuid", ... }, deleted_at: 1700000000, purge_at: 1702592000 }],
+	//	  entities: [{ id: "uuid", name: "山田太郎", deleted_at: 1700000000, purge_at: 1702592000 }],
+	//	  retention_days: 30
+	//	}
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	// RestoreDiaryEntry はゴミ箱の日記を元に戻します。
+	// 差分同期では変更された日記として再び返ります。
+	//
+	// エラー:
+	//   - NotFound: ゴミ箱に日記が見つからない（完全に削除済みを含む）
+	RestoreDiaryEntry(ctx context.Context, in *RestoreDiaryEntryRequest, opts ...grpc.CallOption) (*RestoreDiaryEntryResponse, error)
+	// EmptyTrash はゴミ箱の日記とエンティティを保持期間を待たずに完全に削除します。
+	// 日記の添付ファイル・ハイライト・埋め込みベクトルも削除され、元に戻せません。
+	EmptyTrash(ctx context.Context, in *EmptyTrashRequest, opts ...grpc.CallOption) (*EmptyTrashResponse, error)
 }
 
 type diaryServiceClient struct {
@@ -251,6 +436,16 @@ func (c *diaryServiceClient) UpdateDiaryEntry(ctx context.Context, in *UpdateDia
 	return out, nil
 }
 
+func (c *diaryServiceClient) MergeDiaryContent(ctx context.Context, in *MergeDiaryContentRequest, opts ...grpc.CallOption) (*MergeDiaryContentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeDiaryContentResponse)
+	err := c.cc.Invoke(ctx, DiaryService_MergeDiaryContent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *diaryServiceClient) DeleteDiaryEntry(ctx context.Context, in *DeleteDiaryEntryRequest, opts ...grpc.CallOption) (*DeleteDiaryEntryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteDiaryEntryResponse)
@@ -401,6 +596,196 @@ func (c *diaryServiceClient) ExportDiaryEntries(ctx context.Context, in *ExportD
 	return out, nil
 }
 
+func (c *diaryServiceClient) StreamExportDiaryEntries(ctx context.Context, in *StreamExportDiaryEntriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamExportDiaryEntriesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DiaryService_ServiceDesc.Streams[0], DiaryService_StreamExportDiaryEntries_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamExportDiaryEntriesRequest, StreamExportDiaryEntriesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DiaryService_StreamExportDiaryEntriesClient = grpc.ServerStreamingClient[StreamExportDiaryEntriesResponse]
+
+func (c *diaryServiceClient) WatchTasks(ctx context.Context, in *WatchTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TaskEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DiaryService_ServiceDesc.Streams[1], DiaryService_WatchTasks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchTasksRequest, TaskEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DiaryService_WatchTasksClient = grpc.ServerStreamingClient[TaskEvent]
+
+func (c *diaryServiceClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DiaryService_ServiceDesc.Streams[2], DiaryService_UploadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadAttachmentRequest, UploadAttachmentResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DiaryService_UploadAttachmentClient = grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse]
+
+func (c *diaryServiceClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DiaryService_ServiceDesc.Streams[3], DiaryService_DownloadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadAttachmentRequest, DownloadAttachmentResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DiaryService_DownloadAttachmentClient = grpc.ServerStreamingClient[DownloadAttachmentResponse]
+
+func (c *diaryServiceClient) ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAttachmentsResponse)
+	err := c.cc.Invoke(ctx, DiaryService_ListAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diaryServiceClient) DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAttachmentResponse)
+	err := c.cc.Invoke(ctx, DiaryService_DeleteAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diaryServiceClient) GetAttachmentUsage(ctx context.Context, in *GetAttachmentUsageRequest, opts ...grpc.CallOption) (*GetAttachmentUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAttachmentUsageResponse)
+	err := c.cc.Invoke(ctx, DiaryService_GetAttachmentUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diaryServiceClient) CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTagResponse)
+	err := c.cc.Invoke(ctx, DiaryService_CreateTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diaryServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, DiaryService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diaryServiceClient) RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameTagResponse)
+	err := c.cc.Invoke(ctx, DiaryService_RenameTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diaryServiceClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeTagsResponse)
+	err := c.cc.Invoke(ctx, DiaryService_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diaryServiceClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTagResponse)
+	err := c.cc.Invoke(ctx, DiaryService_DeleteTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diaryServiceClient) SyncDiaryEntries(ctx context.Context, in *SyncDiaryEntriesRequest, opts ...grpc.CallOption) (*SyncDiaryEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncDiaryEntriesResponse)
+	err := c.cc.Invoke(ctx, DiaryService_SyncDiaryEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diaryServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, DiaryService_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diaryServiceClient) RestoreDiaryEntry(ctx context.Context, in *RestoreDiaryEntryRequest, opts ...grpc.CallOption) (*RestoreDiaryEntryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreDiaryEntryResponse)
+	err := c.cc.Invoke(ctx, DiaryService_RestoreDiaryEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diaryServiceClient) EmptyTrash(ctx context.Context, in *EmptyTrashRequest, opts ...grpc.CallOption) (*EmptyTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyTrashResponse)
+	err := c.cc.Invoke(ctx, DiaryService_EmptyTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DiaryServiceServer is the server API for DiaryService service.
 // All implementations must embed UnimplementedDiaryServiceServer
 // for forward compatibility.
@@ -409,15 +794,17 @@ func (c *diaryServiceClient) ExportDiaryEntries(ctx context.Context, in *ExportD
 // AI要約生成機能を提供するサービスです。
 type DiaryServiceServer interface {
 	// CreateDiaryEntry は新しい日記エントリを作成します。
-	// 1日1エントリの制約があり、同じ日付に複数のエントリは作成できません。
+	// 通常は1日1エントリで、同じ日付に既にエントリがある場合はエラーになります。
+	// additional: true を指定した場合のみ、同じ日付に別のエントリとして追加できます（朝のメモと夜の振り返りなど）。
 	//
 	// 例:
 	//
 	//	request: { content: "今日は友人と会った", date: { year: 2025, month: 10, day: 9 } }
 	//	response: { entry: { id: "uuid", content: "...", ... } }
+	//	request: { content: "朝のメモ", date: { year: 2025, month: 10, day: 9 }, title: "朝", time: { hour: 7, minute: 30 }, additional: true }
 	//
 	// エラー:
-	//   - AlreadyExists: 指定された日付の日記が既に存在する
+	//   - AlreadyExists: 指定された日付の日記が既に存在する（additional: false の場合）
 	//   - InvalidArgument: 日付が不正
 	CreateDiaryEntry(context.Context, *CreateDiaryEntryRequest) (*CreateDiaryEntryResponse, error)
 	// UpdateDiaryEntry は既存の日記エントリを更新します。
@@ -427,22 +814,46 @@ type DiaryServiceServer interface {
 	//	request: { id: "uuid", content: "更新された内容", date: { year: 2025, month: 10, day: 9 } }
 	//	response: { entry: { id: "uuid", content: "更新された内容", ... } }
 	//
+	// title・timeは指定した場合のみ更新します（clear_time: true で時刻を未指定に戻します）。
+	//
+	// expected_versionを指定した場合、他のクライアントが先に更新していれば上書きせずにAbortedを返します。
+	// Abortedのエラーの詳細（details）にはサーバー側の現在のDiaryEntryが含まれるため、
+	// クライアントはMergeDiaryContentで手元の内容とマージしてから再度更新できます。
+	//
 	// エラー:
+	//   - AlreadyExists: 日付の変更先に日記が既に存在する（additional: false の場合）
 	//   - NotFound: 日記エントリが見つからない
 	//   - PermissionDenied: 他のユーザーの日記にアクセスしようとした
+	//   - Aborted: expected_versionがサーバーのversionと一致しない
 	UpdateDiaryEntry(context.Context, *UpdateDiaryEntryRequest) (*UpdateDiaryEntryResponse, error)
-	// DeleteDiaryEntry は日記エントリを削除します。
+	// MergeDiaryContent は同じ日記への2つの編集を、編集前の内容（base）をもとに行単位で3方向マージします。
+	// 片方だけが変更した行はその変更を採用し、両方が同じ箇所を別々に変更した場合は
+	// 競合として両方の内容をマーカー（<<<<<<< / ======= / >>>>>>>）で囲んで残します。
+	// 日記は保存しません。
+	//
+	// 例:
+	//
+	//	request: { base: "朝\n昼\n夜\n", ours: "朝ごはん\n昼\n夜\n", theirs: "朝\n昼\n夜ふかし\n" }
+	//	response: { merged: "朝ごはん\n昼\n夜ふかし\n", has_conflicts: false, conflict_count: 0 }
+	//
+	// エラー:
+	//   - InvalidArgument: 内容が大きすぎてマージできない
+	MergeDiaryContent(context.Context, *MergeDiaryContentRequest) (*MergeDiaryContentResponse, error)
+	// DeleteDiaryEntry は日記エントリをゴミ箱に移動します。
+	// ゴミ箱の日記は一覧・検索などに表示されず、保持期間内であればRestoreDiaryEntryで元に戻せます。
+	// 保持期間を過ぎると添付ファイルなどとともに完全に削除されます。
 	//
 	// エラー:
 	//   - NotFound: 日記エントリが見つからない
 	//   - PermissionDenied: 他のユーザーの日記にアクセスしようとした
 	DeleteDiaryEntry(context.Context, *DeleteDiaryEntryRequest) (*DeleteDiaryEntryResponse, error)
 	// GetDiaryEntry は指定された日付の日記エントリを取得します。
+	// entryには表示順で先頭のエントリ、entriesにはその日付の全エントリが入ります。
 	//
 	// 例:
 	//
 	//	request: { date: { year: 2025, month: 10, day: 9 } }
-	//	response: { entry: { id: "uuid", content: "...", ... } }
+	//	response: { entry: { id: "uuid", content: "...", ... }, entries: [{ id: "uuid", ... }] }
 	//
 	// エラー:
 	//   - NotFound: 指定された日付の日記が存在しない
@@ -458,6 +869,7 @@ type DiaryServiceServer interface {
 	// エラー: なし（存在する日記のみ返される）
 	GetDiaryEntries(context.Context, *GetDiaryEntriesRequest) (*GetDiaryEntriesResponse, error)
 	// GetDiaryEntriesByMonth は指定された月の全日記エントリを取得します。
+	// 日付順に並び、同じ日付のエントリは連続して返されます（時刻未指定のエントリが先頭、以降は時刻順）。
 	//
 	// 例:
 	//
@@ -584,6 +996,147 @@ type DiaryServiceServer interface {
 	// エラー:
 	//   - InvalidArgument: 開始年月が終了年月より後の場合
 	ExportDiaryEntries(context.Context, *ExportDiaryEntriesRequest) (*ExportDiaryEntriesResponse, error)
+	// StreamExportDiaryEntries は指定期間の日記を指定した形式のファイルに変換し、サーバーストリーミングで返します。
+	// 日記を一定件数ずつ読み込みながら送信するため、期間が長くてもサーバーのメモリ使用量は一定です。
+	// 各レスポンスはファイルのパスと内容の一部で、同じパスのchunkを受け取った順に連結するとファイルになります。
+	//
+	// 形式:
+	//   - EXPORT_FORMAT_JSONL: diaries.jsonl（1行に1件の日記。月次要約を含める場合は type で区別）
+	//   - EXPORT_FORMAT_MARKDOWN: YYYY/MM/YYYY-MM-DD.md（1日1ファイル、YAML front matter付き）、月次要約は YYYY/MM/summary.md
+	//   - EXPORT_FORMAT_CSV: diaries.csv（UTF-8 BOM付き）
+	//   - EXPORT_FORMAT_HTML: diaries.html（目次付きの1ファイル）
+	//
+	// 例:
+	//
+	//	request: { from: { year: 2024, month: 4 }, to: { year: 2024, month: 6 }, format: EXPORT_FORMAT_MARKDOWN }
+	//	response(stream): { path: "2024/04/2024-04-01.md", content_type: "text/markdown; charset=utf-8", chunk: "..." }
+	//
+	// エラー:
+	//   - InvalidArgument: 開始年月が終了年月より後の場合、未対応の形式の場合
+	StreamExportDiaryEntries(*StreamExportDiaryEntriesRequest, grpc.ServerStreamingServer[StreamExportDiaryEntriesResponse]) error
+	// WatchTasks は呼び出しユーザーの非同期タスク（月次要約・トレンド分析・ハイライト・embedding生成）の
+	// 状態変化をサーバーストリーミングで通知します。
+	// 接続直後に現在キュー済み・処理中のタスクを送信し、以降は状態が変わるたびにイベントを送信します。
+	// クライアントが切断するまでストリームは終了しません。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response(stream): { task_type: "monthly_summary", target: "2024-05", status: TASK_STATUS_SUCCEEDED, timestamp: 1234567890 }
+	//
+	// エラー:
+	//   - Unauthenticated: 認証されていない
+	WatchTasks(*WatchTasksRequest, grpc.ServerStreamingServer[TaskEvent]) error
+	// UploadAttachment は日記に写真・ファイルを添付します（クライアントストリーミング）。
+	// 最初のメッセージでmetadataを送り、以降のメッセージでファイルの内容をchunk（1MiB以下推奨）に分けて送ります。
+	// JPEG/PNG/GIFはサムネイルを生成し、JPEGはEXIFから撮影日時・撮影位置を抽出します。
+	//
+	// 例:
+	//
+	//	request(stream): { metadata: { diary_id: "uuid", filename: "IMG_0001.jpg", content_type: "image/jpeg" } }, { chunk: "..." }, ...
+	//	response: { attachment: { id: "uuid", size_bytes: 123456, has_thumbnail: true, taken_at: 1714516200, ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: 最初のメッセージがmetadataでない、ファイル名が不正、ファイルが空
+	//   - NotFound: 日記が存在しない（他のユーザーの日記を含む）
+	//   - ResourceExhausted: ファイルサイズの上限またはユーザーごとの容量制限を超えた
+	UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]) error
+	// DownloadAttachment は添付ファイルの内容を取得します（サーバーストリーミング）。
+	// 最初のメッセージで添付ファイルの情報を送り、以降のメッセージで内容をchunkに分けて送ります。
+	// thumbnail: true の場合はサムネイル（JPEG）を返します。
+	//
+	// エラー:
+	//   - NotFound: 添付ファイルが存在しない、またはサムネイルがない
+	DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error
+	// ListAttachments は日記の添付ファイルをアップロード順に取得します。
+	//
+	// エラー:
+	//   - NotFound: 日記が存在しない
+	ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error)
+	// DeleteAttachment は添付ファイルを削除します。
+	//
+	// エラー:
+	//   - NotFound: 添付ファイルが存在しない
+	DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error)
+	// GetAttachmentUsage は添付ファイルの使用容量と上限を取得します。
+	GetAttachmentUsage(context.Context, *GetAttachmentUsageRequest) (*GetAttachmentUsageResponse, error)
+	// CreateTag はタグを作成します。colorを省略した場合はデフォルトの色を使います。
+	//
+	// 例:
+	//
+	//	request: { name: "旅行", color: "#4f9dde" }
+	//	response: { tag: { id: "uuid", name: "旅行", color: "#4f9dde", diary_count: 0 } }
+	//
+	// エラー:
+	//   - InvalidArgument: 名前が空または50文字を超える、色が#rrggbb形式でない
+	//   - AlreadyExists: 同じ名前のタグがある
+	CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error)
+	// ListTags はタグを名前順に、付いている日記の件数とともに取得します。
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	// RenameTag はタグの名前を変更します。colorを指定した場合は色も変更します。
+	//
+	// エラー:
+	//   - InvalidArgument: 名前・色が不正
+	//   - NotFound: タグが存在しない
+	//   - AlreadyExists: 同じ名前のタグがある
+	RenameTag(context.Context, *RenameTagRequest) (*RenameTagResponse, error)
+	// MergeTags はsource_tag_idsのタグが付いた日記をtarget_tag_idのタグに付け替え、
+	// source_tag_idsのタグを削除します。
+	//
+	// 例:
+	//
+	//	request: { source_tag_ids: ["uuid-a", "uuid-b"], target_tag_id: "uuid-c" }
+	//	response: { tag: { id: "uuid-c", name: "旅行", diary_count: 12 } }
+	//
+	// エラー:
+	//   - InvalidArgument: source_tag_idsが空、またはtarget_tag_idを含む
+	//   - NotFound: タグが存在しない
+	MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error)
+	// DeleteTag はタグを削除します。日記からも外れます。
+	//
+	// エラー:
+	//   - NotFound: タグが存在しない
+	DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error)
+	// SyncDiaryEntries はオフラインのクライアント（iOSアプリ）と日記を差分同期します。
+	// mutationsのクライアント側の変更を順に反映した後、cursor以降にサーバー側で変更・削除された日記を
+	// 変更順に返します。初回はcursorを空にすると全件を返します。
+	// 更新・削除はbase_versionがサーバーのversionと一致する場合のみ反映し、一致しない場合は上書きせずに
+	// CONFLICTとしてサーバー側の日記を返します。
+	//
+	// 例:
+	//
+	//	request: {
+	//	  cursor: "42_uuid",
+	//	  mutations: [{ client_mutation_id: "m1", type: DIARY_MUTATION_TYPE_UPDATE, id: "uuid", base_version: 3, content: "..." }]
+	//	}
+	//	response: {
+	//	  results: [{ client_mutation_id: "m1", status: DIARY_MUTATION_STATUS_CONFLICT, entry: { id: "uuid", version: 4, ... } }],
+	//	  changed_entries: [...], tombstones: [...], next_cursor: "45_uuid", has_more: false
+	//	}
+	//
+	// エラー:
+	//   - InvalidArgument: cursorが不正、mutationsが多すぎる
+	SyncDiaryEntries(context.Context, *SyncDiaryEntriesRequest) (*SyncDiaryEntriesResponse, error)
+	// ListTrash はゴミ箱の日記とエンティティを、ゴミ箱に移動した日時の新しい順に返します。
+	// purge_atを過ぎると完全に削除されます。
+	//
+	// 例:
+	//
+	//	response: {
+	//	  diary_entries: [{ entry: { id: "uuid", ... }, deleted_at: 1700000000, purge_at: 1702592000 }],
+	//	  entities: [{ id: "uuid", name: "山田太郎", deleted_at: 1700000000, purge_at: 1702592000 }],
+	//	  retention_days: 30
+	//	}
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
+	// RestoreDiaryEntry はゴミ箱の日記を元に戻します。
+	// 差分同期では変更された日記として再び返ります。
+	//
+	// エラー:
+	//   - NotFound: ゴミ箱に日記が見つからない（完全に削除済みを含む）
+	RestoreDiaryEntry(context.Context, *RestoreDiaryEntryRequest) (*RestoreDiaryEntryResponse, error)
+	// EmptyTrash はゴミ箱の日記とエンティティを保持期間を待たずに完全に削除します。
+	// 日記の添付ファイル・ハイライト・埋め込みベクトルも削除され、元に戻せません。
+	EmptyTrash(context.Context, *EmptyTrashRequest) (*EmptyTrashResponse, error)
 	mustEmbedUnimplementedDiaryServiceServer()
 }
 
@@ -600,6 +1153,9 @@ func (UnimplementedDiaryServiceServer) CreateDiaryEntry(context.Context, *Create
 func (UnimplementedDiaryServiceServer) UpdateDiaryEntry(context.Context, *UpdateDiaryEntryRequest) (*UpdateDiaryEntryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateDiaryEntry not implemented")
 }
+func (UnimplementedDiaryServiceServer) MergeDiaryContent(context.Context, *MergeDiaryContentRequest) (*MergeDiaryContentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeDiaryContent not implemented")
+}
 func (UnimplementedDiaryServiceServer) DeleteDiaryEntry(context.Context, *DeleteDiaryEntryRequest) (*DeleteDiaryEntryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteDiaryEntry not implemented")
 }
@@ -645,6 +1201,54 @@ func (UnimplementedDiaryServiceServer) GetDiaryEmbeddingStatus(context.Context,
 func (UnimplementedDiaryServiceServer) ExportDiaryEntries(context.Context, *ExportDiaryEntriesRequest) (*ExportDiaryEntriesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ExportDiaryEntries not implemented")
 }
+func (UnimplementedDiaryServiceServer) StreamExportDiaryEntries(*StreamExportDiaryEntriesRequest, grpc.ServerStreamingServer[StreamExportDiaryEntriesResponse]) error {
+	return status.Error(codes.Unimplemented, "method StreamExportDiaryEntries not implemented")
+}
+func (UnimplementedDiaryServiceServer) WatchTasks(*WatchTasksRequest, grpc.ServerStreamingServer[TaskEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchTasks not implemented")
+}
+func (UnimplementedDiaryServiceServer) UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]) error {
+	return status.Error(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedDiaryServiceServer) DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error {
+	return status.Error(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (UnimplementedDiaryServiceServer) ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAttachments not implemented")
+}
+func (UnimplementedDiaryServiceServer) DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteAttachment not implemented")
+}
+func (UnimplementedDiaryServiceServer) GetAttachmentUsage(context.Context, *GetAttachmentUsageRequest) (*GetAttachmentUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAttachmentUsage not implemented")
+}
+func (UnimplementedDiaryServiceServer) CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateTag not implemented")
+}
+func (UnimplementedDiaryServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedDiaryServiceServer) RenameTag(context.Context, *RenameTagRequest) (*RenameTagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (UnimplementedDiaryServiceServer) MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedDiaryServiceServer) DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteTag not implemented")
+}
+func (UnimplementedDiaryServiceServer) SyncDiaryEntries(context.Context, *SyncDiaryEntriesRequest) (*SyncDiaryEntriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SyncDiaryEntries not implemented")
+}
+func (UnimplementedDiaryServiceServer) ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedDiaryServiceServer) RestoreDiaryEntry(context.Context, *RestoreDiaryEntryRequest) (*RestoreDiaryEntryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreDiaryEntry not implemented")
+}
+func (UnimplementedDiaryServiceServer) EmptyTrash(context.Context, *EmptyTrashRequest) (*EmptyTrashResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EmptyTrash not implemented")
+}
 func (UnimplementedDiaryServiceServer) mustEmbedUnimplementedDiaryServiceServer() {}
 func (UnimplementedDiaryServiceServer) testEmbeddedByValue()                      {}
 
@@ -702,6 +1306,24 @@ func _DiaryService_UpdateDiaryEntry_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DiaryService_MergeDiaryContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeDiaryContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiaryServiceServer).MergeDiaryContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiaryService_MergeDiaryContent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiaryServiceServer).MergeDiaryContent(ctx, req.(*MergeDiaryContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DiaryService_DeleteDiaryEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteDiaryEntryRequest)
 	if err := dec(in); err != nil {
@@ -972,6 +1594,262 @@ func _DiaryService_ExportDiaryEntries_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DiaryService_StreamExportDiaryEntries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamExportDiaryEntriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DiaryServiceServer).StreamExportDiaryEntries(m, &grpc.GenericServerStream[StreamExportDiaryEntriesRequest, StreamExportDiaryEntriesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DiaryService_StreamExportDiaryEntriesServer = grpc.ServerStreamingServer[StreamExportDiaryEntriesResponse]
+
+func _DiaryService_WatchTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTasksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DiaryServiceServer).WatchTasks(m, &grpc.GenericServerStream[WatchTasksRequest, TaskEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DiaryService_WatchTasksServer = grpc.ServerStreamingServer[TaskEvent]
+
+func _DiaryService_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DiaryServiceServer).UploadAttachment(&grpc.GenericServerStream[UploadAttachmentRequest, UploadAttachmentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DiaryService_UploadAttachmentServer = grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]
+
+func _DiaryService_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadAttachmentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DiaryServiceServer).DownloadAttachment(m, &grpc.GenericServerStream[DownloadAttachmentRequest, DownloadAttachmentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DiaryService_DownloadAttachmentServer = grpc.ServerStreamingServer[DownloadAttachmentResponse]
+
+func _DiaryService_ListAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiaryServiceServer).ListAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiaryService_ListAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiaryServiceServer).ListAttachments(ctx, req.(*ListAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiaryService_DeleteAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiaryServiceServer).DeleteAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiaryService_DeleteAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiaryServiceServer).DeleteAttachment(ctx, req.(*DeleteAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiaryService_GetAttachmentUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiaryServiceServer).GetAttachmentUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiaryService_GetAttachmentUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiaryServiceServer).GetAttachmentUsage(ctx, req.(*GetAttachmentUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiaryService_CreateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiaryServiceServer).CreateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiaryService_CreateTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiaryServiceServer).CreateTag(ctx, req.(*CreateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiaryService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiaryServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiaryService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiaryServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiaryService_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiaryServiceServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiaryService_RenameTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiaryServiceServer).RenameTag(ctx, req.(*RenameTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiaryService_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiaryServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiaryService_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiaryServiceServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiaryService_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiaryServiceServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiaryService_DeleteTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiaryServiceServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiaryService_SyncDiaryEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncDiaryEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiaryServiceServer).SyncDiaryEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiaryService_SyncDiaryEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiaryServiceServer).SyncDiaryEntries(ctx, req.(*SyncDiaryEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiaryService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiaryServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiaryService_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiaryServiceServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiaryService_RestoreDiaryEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreDiaryEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiaryServiceServer).RestoreDiaryEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiaryService_RestoreDiaryEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiaryServiceServer).RestoreDiaryEntry(ctx, req.(*RestoreDiaryEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiaryService_EmptyTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiaryServiceServer).EmptyTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiaryService_EmptyTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiaryServiceServer).EmptyTrash(ctx, req.(*EmptyTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DiaryService_ServiceDesc is the grpc.ServiceDesc for DiaryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -987,6 +1865,10 @@ var DiaryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateDiaryEntry",
 			Handler:    _DiaryService_UpdateDiaryEntry_Handler,
 		},
+		{
+			MethodName: "MergeDiaryContent",
+			Handler:    _DiaryService_MergeDiaryContent_Handler,
+		},
 		{
 			MethodName: "DeleteDiaryEntry",
 			Handler:    _DiaryService_DeleteDiaryEntry_Handler,
@@ -1047,7 +1929,76 @@ var DiaryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExportDiaryEntries",
 			Handler:    _DiaryService_ExportDiaryEntries_Handler,
 		},
+		{
+			MethodName: "ListAttachments",
+			Handler:    _DiaryService_ListAttachments_Handler,
+		},
+		{
+			MethodName: "DeleteAttachment",
+			Handler:    _DiaryService_DeleteAttachment_Handler,
+		},
+		{
+			MethodName: "GetAttachmentUsage",
+			Handler:    _DiaryService_GetAttachmentUsage_Handler,
+		},
+		{
+			MethodName: "CreateTag",
+			Handler:    _DiaryService_CreateTag_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _DiaryService_ListTags_Handler,
+		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _DiaryService_RenameTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _DiaryService_MergeTags_Handler,
+		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _DiaryService_DeleteTag_Handler,
+		},
+		{
+			MethodName: "SyncDiaryEntries",
+			Handler:    _DiaryService_SyncDiaryEntries_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _DiaryService_ListTrash_Handler,
+		},
+		{
+			MethodName: "RestoreDiaryEntry",
+			Handler:    _DiaryService_RestoreDiaryEntry_Handler,
+		},
+		{
+			MethodName: "EmptyTrash",
+			Handler:    _DiaryService_EmptyTrash_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamExportDiaryEntries",
+			Handler:       _DiaryService_StreamExportDiaryEntries_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchTasks",
+			Handler:       _DiaryService_WatchTasks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadAttachment",
+			Handler:       _DiaryService_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _DiaryService_DownloadAttachment_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "diary/diary.proto",
 }
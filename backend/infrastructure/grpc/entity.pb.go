@@ -139,6 +139,7 @@ type Entity struct {
 	Aliases       []*EntityAlias         `protobuf:"bytes,5,rep,name=aliases,proto3" json:"aliases,omitempty"` // エイリアスのリスト
 	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       int64                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"` // バージョン（更新のたびに増える。UpdateEntityのexpected_versionに使う）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -222,6 +223,13 @@ func (x *Entity) GetUpdatedAt() int64 {
 	return 0
 }
 
+func (x *Entity) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 // エイリアス
 type EntityAlias struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -407,13 +415,14 @@ func (x *CreateEntityResponse) GetEntity() *Entity {
 
 // エンティティ更新リクエスト
 type UpdateEntityRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Category      EntityCategory         `protobuf:"varint,3,opt,name=category,proto3,enum=entity.EntityCategory" json:"category,omitempty"`
-	Memo          string                 `protobuf:"bytes,4,opt,name=memo,proto3" json:"memo,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Category        EntityCategory         `protobuf:"varint,3,opt,name=category,proto3,enum=entity.EntityCategory" json:"category,omitempty"`
+	Memo            string                 `protobuf:"bytes,4,opt,name=memo,proto3" json:"memo,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"` // 指定した場合、サーバーのversionと一致しなければ更新せずにAbortedを返す
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateEntityRequest) Reset() {
@@ -474,6 +483,13 @@ func (x *UpdateEntityRequest) GetMemo() string {
 	return ""
 }
 
+func (x *UpdateEntityRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
 // エンティティ更新レスポンス
 type UpdateEntityResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -609,6 +625,96 @@ func (x *DeleteEntityResponse) GetSuccess() bool {
 	return false
 }
 
+// エンティティ復元リクエスト
+type RestoreEntityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreEntityRequest) Reset() {
+	*x = RestoreEntityRequest{}
+	mi := &file_entity_entity_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreEntityRequest) ProtoMessage() {}
+
+func (x *RestoreEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_entity_entity_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreEntityRequest.ProtoReflect.Descriptor instead.
+func (*RestoreEntityRequest) Descriptor() ([]byte, []int) {
+	return file_entity_entity_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RestoreEntityRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// エンティティ復元レスポンス
+type RestoreEntityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entity        *Entity                `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreEntityResponse) Reset() {
+	*x = RestoreEntityResponse{}
+	mi := &file_entity_entity_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreEntityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreEntityResponse) ProtoMessage() {}
+
+func (x *RestoreEntityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_entity_entity_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreEntityResponse.ProtoReflect.Descriptor instead.
+func (*RestoreEntityResponse) Descriptor() ([]byte, []int) {
+	return file_entity_entity_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RestoreEntityResponse) GetEntity() *Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
 // エンティティ取得リクエスト
 type GetEntityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -619,7 +725,7 @@ type GetEntityRequest struct {
 
 func (x *GetEntityRequest) Reset() {
 	*x = GetEntityRequest{}
-	mi := &file_entity_entity_proto_msgTypes[9]
+	mi := &file_entity_entity_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -631,7 +737,7 @@ func (x *GetEntityRequest) String() string {
 func (*GetEntityRequest) ProtoMessage() {}
 
 func (x *GetEntityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_entity_proto_msgTypes[9]
+	mi := &file_entity_entity_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -644,7 +750,7 @@ func (x *GetEntityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEntityRequest.ProtoReflect.Descriptor instead.
 func (*GetEntityRequest) Descriptor() ([]byte, []int) {
-	return file_entity_entity_proto_rawDescGZIP(), []int{9}
+	return file_entity_entity_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetEntityRequest) GetId() string {
@@ -664,7 +770,7 @@ type GetEntityResponse struct {
 
 func (x *GetEntityResponse) Reset() {
 	*x = GetEntityResponse{}
-	mi := &file_entity_entity_proto_msgTypes[10]
+	mi := &file_entity_entity_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -676,7 +782,7 @@ func (x *GetEntityResponse) String() string {
 func (*GetEntityResponse) ProtoMessage() {}
 
 func (x *GetEntityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_entity_proto_msgTypes[10]
+	mi := &file_entity_entity_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -689,7 +795,7 @@ func (x *GetEntityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEntityResponse.ProtoReflect.Descriptor instead.
 func (*GetEntityResponse) Descriptor() ([]byte, []int) {
-	return file_entity_entity_proto_rawDescGZIP(), []int{10}
+	return file_entity_entity_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetEntityResponse) GetEntity() *Entity {
@@ -710,7 +816,7 @@ type ListEntitiesRequest struct {
 
 func (x *ListEntitiesRequest) Reset() {
 	*x = ListEntitiesRequest{}
-	mi := &file_entity_entity_proto_msgTypes[11]
+	mi := &file_entity_entity_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -722,7 +828,7 @@ func (x *ListEntitiesRequest) String() string {
 func (*ListEntitiesRequest) ProtoMessage() {}
 
 func (x *ListEntitiesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_entity_proto_msgTypes[11]
+	mi := &file_entity_entity_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -735,7 +841,7 @@ func (x *ListEntitiesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListEntitiesRequest.ProtoReflect.Descriptor instead.
 func (*ListEntitiesRequest) Descriptor() ([]byte, []int) {
-	return file_entity_entity_proto_rawDescGZIP(), []int{11}
+	return file_entity_entity_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListEntitiesRequest) GetCategory() EntityCategory {
@@ -762,7 +868,7 @@ type ListEntitiesResponse struct {
 
 func (x *ListEntitiesResponse) Reset() {
 	*x = ListEntitiesResponse{}
-	mi := &file_entity_entity_proto_msgTypes[12]
+	mi := &file_entity_entity_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -774,7 +880,7 @@ func (x *ListEntitiesResponse) String() string {
 func (*ListEntitiesResponse) ProtoMessage() {}
 
 func (x *ListEntitiesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_entity_proto_msgTypes[12]
+	mi := &file_entity_entity_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -787,7 +893,7 @@ func (x *ListEntitiesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListEntitiesResponse.ProtoReflect.Descriptor instead.
 func (*ListEntitiesResponse) Descriptor() ([]byte, []int) {
-	return file_entity_entity_proto_rawDescGZIP(), []int{12}
+	return file_entity_entity_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListEntitiesResponse) GetEntities() []*Entity {
@@ -808,7 +914,7 @@ type CreateEntityAliasRequest struct {
 
 func (x *CreateEntityAliasRequest) Reset() {
 	*x = CreateEntityAliasRequest{}
-	mi := &file_entity_entity_proto_msgTypes[13]
+	mi := &file_entity_entity_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -820,7 +926,7 @@ func (x *CreateEntityAliasRequest) String() string {
 func (*CreateEntityAliasRequest) ProtoMessage() {}
 
 func (x *CreateEntityAliasRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_entity_proto_msgTypes[13]
+	mi := &file_entity_entity_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -833,7 +939,7 @@ func (x *CreateEntityAliasRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateEntityAliasRequest.ProtoReflect.Descriptor instead.
 func (*CreateEntityAliasRequest) Descriptor() ([]byte, []int) {
-	return file_entity_entity_proto_rawDescGZIP(), []int{13}
+	return file_entity_entity_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *CreateEntityAliasRequest) GetEntityId() string {
@@ -860,7 +966,7 @@ type CreateEntityAliasResponse struct {
 
 func (x *CreateEntityAliasResponse) Reset() {
 	*x = CreateEntityAliasResponse{}
-	mi := &file_entity_entity_proto_msgTypes[14]
+	mi := &file_entity_entity_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -872,7 +978,7 @@ func (x *CreateEntityAliasResponse) String() string {
 func (*CreateEntityAliasResponse) ProtoMessage() {}
 
 func (x *CreateEntityAliasResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_entity_proto_msgTypes[14]
+	mi := &file_entity_entity_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -885,7 +991,7 @@ func (x *CreateEntityAliasResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateEntityAliasResponse.ProtoReflect.Descriptor instead.
 func (*CreateEntityAliasResponse) Descriptor() ([]byte, []int) {
-	return file_entity_entity_proto_rawDescGZIP(), []int{14}
+	return file_entity_entity_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *CreateEntityAliasResponse) GetAlias() *EntityAlias {
@@ -906,7 +1012,7 @@ type UpdateEntityAliasRequest struct {
 
 func (x *UpdateEntityAliasRequest) Reset() {
 	*x = UpdateEntityAliasRequest{}
-	mi := &file_entity_entity_proto_msgTypes[15]
+	mi := &file_entity_entity_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -918,7 +1024,7 @@ func (x *UpdateEntityAliasRequest) String() string {
 func (*UpdateEntityAliasRequest) ProtoMessage() {}
 
 func (x *UpdateEntityAliasRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_entity_proto_msgTypes[15]
+	mi := &file_entity_entity_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -931,7 +1037,7 @@ func (x *UpdateEntityAliasRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateEntityAliasRequest.ProtoReflect.Descriptor instead.
 func (*UpdateEntityAliasRequest) Descriptor() ([]byte, []int) {
-	return file_entity_entity_proto_rawDescGZIP(), []int{15}
+	return file_entity_entity_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *UpdateEntityAliasRequest) GetId() string {
@@ -958,7 +1064,7 @@ type UpdateEntityAliasResponse struct {
 
 func (x *UpdateEntityAliasResponse) Reset() {
 	*x = UpdateEntityAliasResponse{}
-	mi := &file_entity_entity_proto_msgTypes[16]
+	mi := &file_entity_entity_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -970,7 +1076,7 @@ func (x *UpdateEntityAliasResponse) String() string {
 func (*UpdateEntityAliasResponse) ProtoMessage() {}
 
 func (x *UpdateEntityAliasResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_entity_proto_msgTypes[16]
+	mi := &file_entity_entity_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -983,7 +1089,7 @@ func (x *UpdateEntityAliasResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateEntityAliasResponse.ProtoReflect.Descriptor instead.
 func (*UpdateEntityAliasResponse) Descriptor() ([]byte, []int) {
-	return file_entity_entity_proto_rawDescGZIP(), []int{16}
+	return file_entity_entity_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *UpdateEntityAliasResponse) GetAlias() *EntityAlias {
@@ -1003,7 +1109,7 @@ type DeleteEntityAliasRequest struct {
 
 func (x *DeleteEntityAliasRequest) Reset() {
 	*x = DeleteEntityAliasRequest{}
-	mi := &file_entity_entity_proto_msgTypes[17]
+	mi := &file_entity_entity_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1015,7 +1121,7 @@ func (x *DeleteEntityAliasRequest) String() string {
 func (*DeleteEntityAliasRequest) ProtoMessage() {}
 
 func (x *DeleteEntityAliasRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_entity_proto_msgTypes[17]
+	mi := &file_entity_entity_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1028,7 +1134,7 @@ func (x *DeleteEntityAliasRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteEntityAliasRequest.ProtoReflect.Descriptor instead.
 func (*DeleteEntityAliasRequest) Descriptor() ([]byte, []int) {
-	return file_entity_entity_proto_rawDescGZIP(), []int{17}
+	return file_entity_entity_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *DeleteEntityAliasRequest) GetId() string {
@@ -1048,7 +1154,7 @@ type DeleteEntityAliasResponse struct {
 
 func (x *DeleteEntityAliasResponse) Reset() {
 	*x = DeleteEntityAliasResponse{}
-	mi := &file_entity_entity_proto_msgTypes[18]
+	mi := &file_entity_entity_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1060,7 +1166,7 @@ func (x *DeleteEntityAliasResponse) String() string {
 func (*DeleteEntityAliasResponse) ProtoMessage() {}
 
 func (x *DeleteEntityAliasResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_entity_proto_msgTypes[18]
+	mi := &file_entity_entity_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1073,7 +1179,7 @@ func (x *DeleteEntityAliasResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteEntityAliasResponse.ProtoReflect.Descriptor instead.
 func (*DeleteEntityAliasResponse) Descriptor() ([]byte, []int) {
-	return file_entity_entity_proto_rawDescGZIP(), []int{18}
+	return file_entity_entity_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DeleteEntityAliasResponse) GetSuccess() bool {
@@ -1093,7 +1199,7 @@ type SearchEntitiesRequest struct {
 
 func (x *SearchEntitiesRequest) Reset() {
 	*x = SearchEntitiesRequest{}
-	mi := &file_entity_entity_proto_msgTypes[19]
+	mi := &file_entity_entity_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1105,7 +1211,7 @@ func (x *SearchEntitiesRequest) String() string {
 func (*SearchEntitiesRequest) ProtoMessage() {}
 
 func (x *SearchEntitiesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_entity_proto_msgTypes[19]
+	mi := &file_entity_entity_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1118,7 +1224,7 @@ func (x *SearchEntitiesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchEntitiesRequest.ProtoReflect.Descriptor instead.
 func (*SearchEntitiesRequest) Descriptor() ([]byte, []int) {
-	return file_entity_entity_proto_rawDescGZIP(), []int{19}
+	return file_entity_entity_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *SearchEntitiesRequest) GetQuery() string {
@@ -1138,7 +1244,7 @@ type SearchEntitiesResponse struct {
 
 func (x *SearchEntitiesResponse) Reset() {
 	*x = SearchEntitiesResponse{}
-	mi := &file_entity_entity_proto_msgTypes[20]
+	mi := &file_entity_entity_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1150,7 +1256,7 @@ func (x *SearchEntitiesResponse) String() string {
 func (*SearchEntitiesResponse) ProtoMessage() {}
 
 func (x *SearchEntitiesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_entity_entity_proto_msgTypes[20]
+	mi := &file_entity_entity_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1163,7 +1269,7 @@ func (x *SearchEntitiesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchEntitiesResponse.ProtoReflect.Descriptor instead.
 func (*SearchEntitiesResponse) Descriptor() ([]byte, []int) {
-	return file_entity_entity_proto_rawDescGZIP(), []int{20}
+	return file_entity_entity_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *SearchEntitiesResponse) GetEntities() []*Entity {
@@ -1181,7 +1287,7 @@ const file_entity_entity_proto_rawDesc = "" +
 	"\bPosition\x12\x14\n" +
 	"\x05start\x18\x01 \x01(\rR\x05start\x12\x10\n" +
 	"\x03end\x18\x02 \x01(\rR\x03end\x12\x19\n" +
-	"\balias_id\x18\x03 \x01(\tR\aaliasId\"\xe1\x01\n" +
+	"\balias_id\x18\x03 \x01(\tR\aaliasId\"\xfb\x01\n" +
 	"\x06Entity\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x122\n" +
@@ -1191,7 +1297,8 @@ const file_entity_entity_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\x03R\tupdatedAt\"\x8e\x01\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion\"\x8e\x01\n" +
 	"\vEntityAlias\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tentity_id\x18\x02 \x01(\tR\bentityId\x12\x14\n" +
@@ -1205,18 +1312,24 @@ const file_entity_entity_proto_rawDesc = "" +
 	"\bcategory\x18\x02 \x01(\x0e2\x16.entity.EntityCategoryR\bcategory\x12\x12\n" +
 	"\x04memo\x18\x03 \x01(\tR\x04memo\">\n" +
 	"\x14CreateEntityResponse\x12&\n" +
-	"\x06entity\x18\x01 \x01(\v2\x0e.entity.EntityR\x06entity\"\x81\x01\n" +
+	"\x06entity\x18\x01 \x01(\v2\x0e.entity.EntityR\x06entity\"\xc6\x01\n" +
 	"\x13UpdateEntityRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x122\n" +
 	"\bcategory\x18\x03 \x01(\x0e2\x16.entity.EntityCategoryR\bcategory\x12\x12\n" +
-	"\x04memo\x18\x04 \x01(\tR\x04memo\">\n" +
+	"\x04memo\x18\x04 \x01(\tR\x04memo\x12.\n" +
+	"\x10expected_version\x18\x05 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\">\n" +
 	"\x14UpdateEntityResponse\x12&\n" +
 	"\x06entity\x18\x01 \x01(\v2\x0e.entity.EntityR\x06entity\"%\n" +
 	"\x13DeleteEntityRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
 	"\x14DeleteEntityResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\"\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"&\n" +
+	"\x14RestoreEntityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
+	"\x15RestoreEntityResponse\x12&\n" +
+	"\x06entity\x18\x01 \x01(\v2\x0e.entity.EntityR\x06entity\"\"\n" +
 	"\x10GetEntityRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\";\n" +
 	"\x11GetEntityResponse\x12&\n" +
@@ -1247,11 +1360,12 @@ const file_entity_entity_proto_rawDesc = "" +
 	"\x0eEntityCategory\x12\x0f\n" +
 	"\vNO_CATEGORY\x10\x00\x12\n" +
 	"\n" +
-	"\x06PEOPLE\x10\x012\xdc\x05\n" +
+	"\x06PEOPLE\x10\x012\xaa\x06\n" +
 	"\rEntityService\x12I\n" +
 	"\fCreateEntity\x12\x1b.entity.CreateEntityRequest\x1a\x1c.entity.CreateEntityResponse\x12I\n" +
 	"\fUpdateEntity\x12\x1b.entity.UpdateEntityRequest\x1a\x1c.entity.UpdateEntityResponse\x12I\n" +
-	"\fDeleteEntity\x12\x1b.entity.DeleteEntityRequest\x1a\x1c.entity.DeleteEntityResponse\x12@\n" +
+	"\fDeleteEntity\x12\x1b.entity.DeleteEntityRequest\x1a\x1c.entity.DeleteEntityResponse\x12L\n" +
+	"\rRestoreEntity\x12\x1c.entity.RestoreEntityRequest\x1a\x1d.entity.RestoreEntityResponse\x12@\n" +
 	"\tGetEntity\x12\x18.entity.GetEntityRequest\x1a\x19.entity.GetEntityResponse\x12I\n" +
 	"\fListEntities\x12\x1b.entity.ListEntitiesRequest\x1a\x1c.entity.ListEntitiesResponse\x12X\n" +
 	"\x11CreateEntityAlias\x12 .entity.CreateEntityAliasRequest\x1a!.entity.CreateEntityAliasResponse\x12X\n" +
@@ -1272,7 +1386,7 @@ func file_entity_entity_proto_rawDescGZIP() []byte {
 }
 
 var file_entity_entity_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_entity_entity_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_entity_entity_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_entity_entity_proto_goTypes = []any{
 	(EntityCategory)(0),               // 0: entity.EntityCategory
 	(*Position)(nil),                  // 1: entity.Position
@@ -1284,18 +1398,20 @@ var file_entity_entity_proto_goTypes = []any{
 	(*UpdateEntityResponse)(nil),      // 7: entity.UpdateEntityResponse
 	(*DeleteEntityRequest)(nil),       // 8: entity.DeleteEntityRequest
 	(*DeleteEntityResponse)(nil),      // 9: entity.DeleteEntityResponse
-	(*GetEntityRequest)(nil),          // 10: entity.GetEntityRequest
-	(*GetEntityResponse)(nil),         // 11: entity.GetEntityResponse
-	(*ListEntitiesRequest)(nil),       // 12: entity.ListEntitiesRequest
-	(*ListEntitiesResponse)(nil),      // 13: entity.ListEntitiesResponse
-	(*CreateEntityAliasRequest)(nil),  // 14: entity.CreateEntityAliasRequest
-	(*CreateEntityAliasResponse)(nil), // 15: entity.CreateEntityAliasResponse
-	(*UpdateEntityAliasRequest)(nil),  // 16: entity.UpdateEntityAliasRequest
-	(*UpdateEntityAliasResponse)(nil), // 17: entity.UpdateEntityAliasResponse
-	(*DeleteEntityAliasRequest)(nil),  // 18: entity.DeleteEntityAliasRequest
-	(*DeleteEntityAliasResponse)(nil), // 19: entity.DeleteEntityAliasResponse
-	(*SearchEntitiesRequest)(nil),     // 20: entity.SearchEntitiesRequest
-	(*SearchEntitiesResponse)(nil),    // 21: entity.SearchEntitiesResponse
+	(*RestoreEntityRequest)(nil),      // 10: entity.RestoreEntityRequest
+	(*RestoreEntityResponse)(nil),     // 11: entity.RestoreEntityResponse
+	(*GetEntityRequest)(nil),          // 12: entity.GetEntityRequest
+	(*GetEntityResponse)(nil),         // 13: entity.GetEntityResponse
+	(*ListEntitiesRequest)(nil),       // 14: entity.ListEntitiesRequest
+	(*ListEntitiesResponse)(nil),      // 15: entity.ListEntitiesResponse
+	(*CreateEntityAliasRequest)(nil),  // 16: entity.CreateEntityAliasRequest
+	(*CreateEntityAliasResponse)(nil), // 17: entity.CreateEntityAliasResponse
+	(*UpdateEntityAliasRequest)(nil),  // 18: entity.UpdateEntityAliasRequest
+	(*UpdateEntityAliasResponse)(nil), // 19: entity.UpdateEntityAliasResponse
+	(*DeleteEntityAliasRequest)(nil),  // 20: entity.DeleteEntityAliasRequest
+	(*DeleteEntityAliasResponse)(nil), // 21: entity.DeleteEntityAliasResponse
+	(*SearchEntitiesRequest)(nil),     // 22: entity.SearchEntitiesRequest
+	(*SearchEntitiesResponse)(nil),    // 23: entity.SearchEntitiesResponse
 }
 var file_entity_entity_proto_depIdxs = []int32{
 	0,  // 0: entity.Entity.category:type_name -> entity.EntityCategory
@@ -1304,35 +1420,38 @@ var file_entity_entity_proto_depIdxs = []int32{
 	2,  // 3: entity.CreateEntityResponse.entity:type_name -> entity.Entity
 	0,  // 4: entity.UpdateEntityRequest.category:type_name -> entity.EntityCategory
 	2,  // 5: entity.UpdateEntityResponse.entity:type_name -> entity.Entity
-	2,  // 6: entity.GetEntityResponse.entity:type_name -> entity.Entity
-	0,  // 7: entity.ListEntitiesRequest.category:type_name -> entity.EntityCategory
-	2,  // 8: entity.ListEntitiesResponse.entities:type_name -> entity.Entity
-	3,  // 9: entity.CreateEntityAliasResponse.alias:type_name -> entity.EntityAlias
-	3,  // 10: entity.UpdateEntityAliasResponse.alias:type_name -> entity.EntityAlias
-	2,  // 11: entity.SearchEntitiesResponse.entities:type_name -> entity.Entity
-	4,  // 12: entity.EntityService.CreateEntity:input_type -> entity.CreateEntityRequest
-	6,  // 13: entity.EntityService.UpdateEntity:input_type -> entity.UpdateEntityRequest
-	8,  // 14: entity.EntityService.DeleteEntity:input_type -> entity.DeleteEntityRequest
-	10, // 15: entity.EntityService.GetEntity:input_type -> entity.GetEntityRequest
-	12, // 16: entity.EntityService.ListEntities:input_type -> entity.ListEntitiesRequest
-	14, // 17: entity.EntityService.CreateEntityAlias:input_type -> entity.CreateEntityAliasRequest
-	16, // 18: entity.EntityService.UpdateEntityAlias:input_type -> entity.UpdateEntityAliasRequest
-	18, // 19: entity.EntityService.DeleteEntityAlias:input_type -> entity.DeleteEntityAliasRequest
-	20, // 20: entity.EntityService.SearchEntities:input_type -> entity.SearchEntitiesRequest
-	5,  // 21: entity.EntityService.CreateEntity:output_type -> entity.CreateEntityResponse
-	7,  // 22: entity.EntityService.UpdateEntity:output_type -> entity.UpdateEntityResponse
-	9,  // 23: entity.EntityService.DeleteEntity:output_type -> entity.DeleteEntityResponse
-	11, // 24: entity.EntityService.GetEntity:output_type -> entity.GetEntityResponse
-	13, // 25: entity.EntityService.ListEntities:output_type -> entity.ListEntitiesResponse
-	15, // 26: entity.EntityService.CreateEntityAlias:output_type -> entity.CreateEntityAliasResponse
-	17, // 27: entity.EntityService.UpdateEntityAlias:output_type -> entity.UpdateEntityAliasResponse
-	19, // 28: entity.EntityService.DeleteEntityAlias:output_type -> entity.DeleteEntityAliasResponse
-	21, // 29: entity.EntityService.SearchEntities:output_type -> entity.SearchEntitiesResponse
-	21, // [21:30] is the sub-list for method output_type
-	12, // [12:21] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	2,  // 6: entity.RestoreEntityResponse.entity:type_name -> entity.Entity
+	2,  // 7: entity.GetEntityResponse.entity:type_name -> entity.Entity
+	0,  // 8: entity.ListEntitiesRequest.category:type_name -> entity.EntityCategory
+	2,  // 9: entity.ListEntitiesResponse.entities:type_name -> entity.Entity
+	3,  // 10: entity.CreateEntityAliasResponse.alias:type_name -> entity.EntityAlias
+	3,  // 11: entity.UpdateEntityAliasResponse.alias:type_name -> entity.EntityAlias
+	2,  // 12: entity.SearchEntitiesResponse.entities:type_name -> entity.Entity
+	4,  // 13: entity.EntityService.CreateEntity:input_type -> entity.CreateEntityRequest
+	6,  // 14: entity.EntityService.UpdateEntity:input_type -> entity.UpdateEntityRequest
+	8,  // 15: entity.EntityService.DeleteEntity:input_type -> entity.DeleteEntityRequest
+	10, // 16: entity.EntityService.RestoreEntity:input_type -> entity.RestoreEntityRequest
+	12, // 17: entity.EntityService.GetEntity:input_type -> entity.GetEntityRequest
+	14, // 18: entity.EntityService.ListEntities:input_type -> entity.ListEntitiesRequest
+	16, // 19: entity.EntityService.CreateEntityAlias:input_type -> entity.CreateEntityAliasRequest
+	18, // 20: entity.EntityService.UpdateEntityAlias:input_type -> entity.UpdateEntityAliasRequest
+	20, // 21: entity.EntityService.DeleteEntityAlias:input_type -> entity.DeleteEntityAliasRequest
+	22, // 22: entity.EntityService.SearchEntities:input_type -> entity.SearchEntitiesRequest
+	5,  // 23: entity.EntityService.CreateEntity:output_type -> entity.CreateEntityResponse
+	7,  // 24: entity.EntityService.UpdateEntity:output_type -> entity.UpdateEntityResponse
+	9,  // 25: entity.EntityService.DeleteEntity:output_type -> entity.DeleteEntityResponse
+	11, // 26: entity.EntityService.RestoreEntity:output_type -> entity.RestoreEntityResponse
+	13, // 27: entity.EntityService.GetEntity:output_type -> entity.GetEntityResponse
+	15, // 28: entity.EntityService.ListEntities:output_type -> entity.ListEntitiesResponse
+	17, // 29: entity.EntityService.CreateEntityAlias:output_type -> entity.CreateEntityAliasResponse
+	19, // 30: entity.EntityService.UpdateEntityAlias:output_type -> entity.UpdateEntityAliasResponse
+	21, // 31: entity.EntityService.DeleteEntityAlias:output_type -> entity.DeleteEntityAliasResponse
+	23, // 32: entity.EntityService.SearchEntities:output_type -> entity.SearchEntitiesResponse
+	23, // [23:33] is the sub-list for method output_type
+	13, // [13:23] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_entity_entity_proto_init() }
@@ -1340,13 +1459,14 @@ func file_entity_entity_proto_init() {
 	if File_entity_entity_proto != nil {
 		return
 	}
+	file_entity_entity_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_entity_entity_proto_rawDesc), len(file_entity_entity_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   21,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
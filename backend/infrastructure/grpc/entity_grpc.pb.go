@@ -22,6 +22,7 @@ const (
 	EntityService_CreateEntity_FullMethodName      = "/entity.EntityService/CreateEntity"
 	EntityService_UpdateEntity_FullMethodName      = "/entity.EntityService/UpdateEntity"
 	EntityService_DeleteEntity_FullMethodName      = "/entity.EntityService/DeleteEntity"
+	EntityService_RestoreEntity_FullMethodName     = "/entity.EntityService/RestoreEntity"
 	EntityService_GetEntity_FullMethodName         = "/entity.EntityService/GetEntity"
 	EntityService_ListEntities_FullMethodName      = "/entity.EntityService/ListEntities"
 	EntityService_CreateEntityAlias_FullMethodName = "/entity.EntityService/CreateEntityAlias"
@@ -53,16 +54,28 @@ type EntityServiceClient interface {
 	//
 	//	request: { id: "uuid", name: "山田花子", category: PEOPLE, memo: "同僚" }
 	//
+	// expected_versionを指定した場合、他のクライアントが先に更新していれば上書きせずにAbortedを返します。
+	// Abortedのエラーの詳細（details）にはサーバー側の現在のEntityが含まれます。
+	//
 	// エラー:
 	//   - NotFound: エンティティが見つからない
 	//   - PermissionDenied: 他のユーザーのエンティティにアクセスしようとした
+	//   - Aborted: expected_versionがサーバーのversionと一致しない
 	UpdateEntity(ctx context.Context, in *UpdateEntityRequest, opts ...grpc.CallOption) (*UpdateEntityResponse, error)
-	// DeleteEntity はエンティティとそれに紐づくエイリアスを削除します。
+	// DeleteEntity はエンティティをゴミ箱に移動します。
+	// ゴミ箱のエンティティは一覧・検索・キーワード展開に使われず、保持期間内であればRestoreEntityで元に戻せます。
+	// 保持期間を過ぎるとエイリアスとともに完全に削除されます。
 	//
 	// エラー:
 	//   - NotFound: エンティティが見つからない
 	//   - PermissionDenied: 他のユーザーのエンティティにアクセスしようとした
 	DeleteEntity(ctx context.Context, in *DeleteEntityRequest, opts ...grpc.CallOption) (*DeleteEntityResponse, error)
+	// RestoreEntity はゴミ箱のエンティティを元に戻します。
+	//
+	// エラー:
+	//   - NotFound: ゴミ箱にエンティティが見つからない（完全に削除済みを含む）
+	//   - AlreadyExists: 同じ名前のエンティティ、または名前・エイリアスと重複するエイリアスが既にある
+	RestoreEntity(ctx context.Context, in *RestoreEntityRequest, opts ...grpc.CallOption) (*RestoreEntityResponse, error)
 	// GetEntity は指定されたIDのエンティティを取得します。
 	// エイリアスも含めて返されます。
 	//
@@ -158,6 +171,16 @@ func (c *entityServiceClient) DeleteEntity(ctx context.Context, in *DeleteEntity
 	return out, nil
 }
 
+func (c *entityServiceClient) RestoreEntity(ctx context.Context, in *RestoreEntityRequest, opts ...grpc.CallOption) (*RestoreEntityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreEntityResponse)
+	err := c.cc.Invoke(ctx, EntityService_RestoreEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *entityServiceClient) GetEntity(ctx context.Context, in *GetEntityRequest, opts ...grpc.CallOption) (*GetEntityResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetEntityResponse)
@@ -241,16 +264,28 @@ type EntityServiceServer interface {
 	//
 	//	request: { id: "uuid", name: "山田花子", category: PEOPLE, memo: "同僚" }
 	//
+	// expected_versionを指定した場合、他のクライアントが先に更新していれば上書きせずにAbortedを返します。
+	// Abortedのエラーの詳細（details）にはサーバー側の現在のEntityが含まれます。
+	//
 	// エラー:
 	//   - NotFound: エンティティが見つからない
 	//   - PermissionDenied: 他のユーザーのエンティティにアクセスしようとした
+	//   - Aborted: expected_versionがサーバーのversionと一致しない
 	UpdateEntity(context.Context, *UpdateEntityRequest) (*UpdateEntityResponse, error)
-	// DeleteEntity はエンティティとそれに紐づくエイリアスを削除します。
+	// DeleteEntity はエンティティをゴミ箱に移動します。
+	// ゴミ箱のエンティティは一覧・検索・キーワード展開に使われず、保持期間内であればRestoreEntityで元に戻せます。
+	// 保持期間を過ぎるとエイリアスとともに完全に削除されます。
 	//
 	// エラー:
 	//   - NotFound: エンティティが見つからない
 	//   - PermissionDenied: 他のユーザーのエンティティにアクセスしようとした
 	DeleteEntity(context.Context, *DeleteEntityRequest) (*DeleteEntityResponse, error)
+	// RestoreEntity はゴミ箱のエンティティを元に戻します。
+	//
+	// エラー:
+	//   - NotFound: ゴミ箱にエンティティが見つからない（完全に削除済みを含む）
+	//   - AlreadyExists: 同じ名前のエンティティ、または名前・エイリアスと重複するエイリアスが既にある
+	RestoreEntity(context.Context, *RestoreEntityRequest) (*RestoreEntityResponse, error)
 	// GetEntity は指定されたIDのエンティティを取得します。
 	// エイリアスも含めて返されます。
 	//
@@ -325,6 +360,9 @@ func (UnimplementedEntityServiceServer) UpdateEntity(context.Context, *UpdateEnt
 func (UnimplementedEntityServiceServer) DeleteEntity(context.Context, *DeleteEntityRequest) (*DeleteEntityResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteEntity not implemented")
 }
+func (UnimplementedEntityServiceServer) RestoreEntity(context.Context, *RestoreEntityRequest) (*RestoreEntityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreEntity not implemented")
+}
 func (UnimplementedEntityServiceServer) GetEntity(context.Context, *GetEntityRequest) (*GetEntityResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetEntity not implemented")
 }
@@ -418,6 +456,24 @@ func _EntityService_DeleteEntity_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EntityService_RestoreEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntityServiceServer).RestoreEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntityService_RestoreEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntityServiceServer).RestoreEntity(ctx, req.(*RestoreEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _EntityService_GetEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetEntityRequest)
 	if err := dec(in); err != nil {
@@ -545,6 +601,10 @@ var EntityService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteEntity",
 			Handler:    _EntityService_DeleteEntity_Handler,
 		},
+		{
+			MethodName: "RestoreEntity",
+			Handler:    _EntityService_RestoreEntity_Handler,
+		},
 		{
 			MethodName: "GetEntity",
 			Handler:    _EntityService_GetEntity_Handler,
@@ -0,0 +1,469 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: admin/admin.proto
+
+package grpcconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	grpc "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AdminServiceName is the fully-qualified name of the AdminService service.
+	AdminServiceName = "admin.AdminService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AdminServiceListUsersProcedure is the fully-qualified name of the AdminService's ListUsers RPC.
+	AdminServiceListUsersProcedure = "/admin.AdminService/ListUsers"
+	// AdminServiceGetUserUsageProcedure is the fully-qualified name of the AdminService's GetUserUsage
+	// RPC.
+	AdminServiceGetUserUsageProcedure = "/admin.AdminService/GetUserUsage"
+	// AdminServiceSetUserRoleProcedure is the fully-qualified name of the AdminService's SetUserRole
+	// RPC.
+	AdminServiceSetUserRoleProcedure = "/admin.AdminService/SetUserRole"
+	// AdminServiceDisableUserProcedure is the fully-qualified name of the AdminService's DisableUser
+	// RPC.
+	AdminServiceDisableUserProcedure = "/admin.AdminService/DisableUser"
+	// AdminServiceEnableUserProcedure is the fully-qualified name of the AdminService's EnableUser RPC.
+	AdminServiceEnableUserProcedure = "/admin.AdminService/EnableUser"
+	// AdminServiceForcePasswordResetProcedure is the fully-qualified name of the AdminService's
+	// ForcePasswordReset RPC.
+	AdminServiceForcePasswordResetProcedure = "/admin.AdminService/ForcePasswordReset"
+	// AdminServiceRevokeUserCredentialsProcedure is the fully-qualified name of the AdminService's
+	// RevokeUserCredentials RPC.
+	AdminServiceRevokeUserCredentialsProcedure = "/admin.AdminService/RevokeUserCredentials"
+	// AdminServiceRegenerateUserEmbeddingsProcedure is the fully-qualified name of the AdminService's
+	// RegenerateUserEmbeddings RPC.
+	AdminServiceRegenerateUserEmbeddingsProcedure = "/admin.AdminService/RegenerateUserEmbeddings"
+	// AdminServiceListAuditLogsProcedure is the fully-qualified name of the AdminService's
+	// ListAuditLogs RPC.
+	AdminServiceListAuditLogsProcedure = "/admin.AdminService/ListAuditLogs"
+)
+
+// AdminServiceClient is a client for the admin.AdminService service.
+type AdminServiceClient interface {
+	// ListUsers はユーザーを登録日時の古い順に返します。
+	// queryを指定した場合はメールアドレスか名前に部分一致するユーザーに絞り込みます。
+	//
+	// 例:
+	//
+	//	request: { query: "example.com", limit: 50 }
+	//	response: { users: [{ id: "uuid", email: "user@example.com", ... }], next_cursor: "1700000000_uuid", has_more: true }
+	//
+	// エラー:
+	//   - InvalidArgument: cursorが不正
+	ListUsers(context.Context, *connect.Request[grpc.ListUsersRequest]) (*connect.Response[grpc.ListUsersResponse], error)
+	// GetUserUsage はユーザーの日記・埋め込みベクトル・LLMの利用状況と、過去24時間のジョブの処理件数を返します。
+	//
+	// 例:
+	//
+	//	request: { user_id: "uuid" }
+	//	response: { user: { ... }, usage: { diary_count: 120, embedding_count: 300, semantic_search_count: 42, ... } }
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	GetUserUsage(context.Context, *connect.Request[grpc.GetUserUsageRequest]) (*connect.Response[grpc.GetUserUsageResponse], error)
+	// SetUserRole はユーザーの権限を変更します。自分自身の権限は変更できません。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	//   - FailedPrecondition: 自分自身の権限を変更しようとした
+	SetUserRole(context.Context, *connect.Request[grpc.SetUserRoleRequest]) (*connect.Response[grpc.SetUserRoleResponse], error)
+	// DisableUser はユーザーを無効にします。無効なユーザーはログイン・トークンの更新・APIキーでの認証ができません。
+	// 発行済みのアクセストークンも次のリクエストから使えなくなります（状態は最長10秒キャッシュするため、反映が遅れることがあります）。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	//   - FailedPrecondition: 自分自身を無効にしようとした
+	DisableUser(context.Context, *connect.Request[grpc.DisableUserRequest]) (*connect.Response[grpc.DisableUserResponse], error)
+	// EnableUser は無効にしたユーザーを有効に戻します。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	EnableUser(context.Context, *connect.Request[grpc.EnableUserRequest]) (*connect.Response[grpc.EnableUserResponse], error)
+	// ForcePasswordReset はユーザーにパスワードの再設定を求めます。
+	// すべてのセッションを取り消し、次のログインではpassword_reset_requiredが返ります。
+	// パスワードを変更するまでトークンの更新はできません。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない、またはパスワードでログインするユーザーでない
+	ForcePasswordReset(context.Context, *connect.Request[grpc.ForcePasswordResetRequest]) (*connect.Response[grpc.ForcePasswordResetResponse], error)
+	// RevokeUserCredentials はユーザーのすべてのセッション（リフレッシュトークン）とAPIキーを取り消します。
+	//
+	// 例:
+	//
+	//	request: { user_id: "uuid" }
+	//	response: { revoked_api_key_count: 2 }
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	RevokeUserCredentials(context.Context, *connect.Request[grpc.RevokeUserCredentialsRequest]) (*connect.Response[grpc.RevokeUserCredentialsResponse], error)
+	// RegenerateUserEmbeddings はユーザーの埋め込みベクトルが未生成の日記をキューに追加します
+	// （DiaryServiceのRegenerateAllEmbeddingsをユーザーに代わって実行します）。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	//   - FailedPrecondition: ユーザーがGeminiのAPIキーを設定していない、または意味的検索が無効
+	//   - ResourceExhausted: 再生成が実行中
+	RegenerateUserEmbeddings(context.Context, *connect.Request[grpc.RegenerateUserEmbeddingsRequest]) (*connect.Response[grpc.RegenerateUserEmbeddingsResponse], error)
+	// ListAuditLogs は管理者の操作の監査ログを新しい順に返します。
+	//
+	// 例:
+	//
+	//	request: { target_user_id: "uuid", limit: 50 }
+	//	response: { logs: [{ action: "disable_user", admin_email: "admin@example.com", ... }], next_cursor: "...", has_more: false }
+	//
+	// エラー:
+	//   - InvalidArgument: cursorが不正
+	ListAuditLogs(context.Context, *connect.Request[grpc.ListAuditLogsRequest]) (*connect.Response[grpc.ListAuditLogsResponse], error)
+}
+
+// NewAdminServiceClient constructs a client for the admin.AdminService service. By default, it uses
+// the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AdminServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	adminServiceMethods := grpc.File_admin_admin_proto.Services().ByName("AdminService").Methods()
+	return &adminServiceClient{
+		listUsers: connect.NewClient[grpc.ListUsersRequest, grpc.ListUsersResponse](
+			httpClient,
+			baseURL+AdminServiceListUsersProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ListUsers")),
+			connect.WithClientOptions(opts...),
+		),
+		getUserUsage: connect.NewClient[grpc.GetUserUsageRequest, grpc.GetUserUsageResponse](
+			httpClient,
+			baseURL+AdminServiceGetUserUsageProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("GetUserUsage")),
+			connect.WithClientOptions(opts...),
+		),
+		setUserRole: connect.NewClient[grpc.SetUserRoleRequest, grpc.SetUserRoleResponse](
+			httpClient,
+			baseURL+AdminServiceSetUserRoleProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SetUserRole")),
+			connect.WithClientOptions(opts...),
+		),
+		disableUser: connect.NewClient[grpc.DisableUserRequest, grpc.DisableUserResponse](
+			httpClient,
+			baseURL+AdminServiceDisableUserProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("DisableUser")),
+			connect.WithClientOptions(opts...),
+		),
+		enableUser: connect.NewClient[grpc.EnableUserRequest, grpc.EnableUserResponse](
+			httpClient,
+			baseURL+AdminServiceEnableUserProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("EnableUser")),
+			connect.WithClientOptions(opts...),
+		),
+		forcePasswordReset: connect.NewClient[grpc.ForcePasswordResetRequest, grpc.ForcePasswordResetResponse](
+			httpClient,
+			baseURL+AdminServiceForcePasswordResetProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ForcePasswordReset")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeUserCredentials: connect.NewClient[grpc.RevokeUserCredentialsRequest, grpc.RevokeUserCredentialsResponse](
+			httpClient,
+			baseURL+AdminServiceRevokeUserCredentialsProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("RevokeUserCredentials")),
+			connect.WithClientOptions(opts...),
+		),
+		regenerateUserEmbeddings: connect.NewClient[grpc.RegenerateUserEmbeddingsRequest, grpc.RegenerateUserEmbeddingsResponse](
+			httpClient,
+			baseURL+AdminServiceRegenerateUserEmbeddingsProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("RegenerateUserEmbeddings")),
+			connect.WithClientOptions(opts...),
+		),
+		listAuditLogs: connect.NewClient[grpc.ListAuditLogsRequest, grpc.ListAuditLogsResponse](
+			httpClient,
+			baseURL+AdminServiceListAuditLogsProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ListAuditLogs")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// adminServiceClient implements AdminServiceClient.
+type adminServiceClient struct {
+	listUsers                *connect.Client[grpc.ListUsersRequest, grpc.ListUsersResponse]
+	getUserUsage             *connect.Client[grpc.GetUserUsageRequest, grpc.GetUserUsageResponse]
+	setUserRole              *connect.Client[grpc.SetUserRoleRequest, grpc.SetUserRoleResponse]
+	disableUser              *connect.Client[grpc.DisableUserRequest, grpc.DisableUserResponse]
+	enableUser               *connect.Client[grpc.EnableUserRequest, grpc.EnableUserResponse]
+	forcePasswordReset       *connect.Client[grpc.ForcePasswordResetRequest, grpc.ForcePasswordResetResponse]
+	revokeUserCredentials    *connect.Client[grpc.RevokeUserCredentialsRequest, grpc.RevokeUserCredentialsResponse]
+	regenerateUserEmbeddings *connect.Client[grpc.RegenerateUserEmbeddingsRequest, grpc.RegenerateUserEmbeddingsResponse]
+	listAuditLogs            *connect.Client[grpc.ListAuditLogsRequest, grpc.ListAuditLogsResponse]
+}
+
+// ListUsers calls admin.AdminService.ListUsers.
+func (c *adminServiceClient) ListUsers(ctx context.Context, req *connect.Request[grpc.ListUsersRequest]) (*connect.Response[grpc.ListUsersResponse], error) {
+	return c.listUsers.CallUnary(ctx, req)
+}
+
+// GetUserUsage calls admin.AdminService.GetUserUsage.
+func (c *adminServiceClient) GetUserUsage(ctx context.Context, req *connect.Request[grpc.GetUserUsageRequest]) (*connect.Response[grpc.GetUserUsageResponse], error) {
+	return c.getUserUsage.CallUnary(ctx, req)
+}
+
+// SetUserRole calls admin.AdminService.SetUserRole.
+func (c *adminServiceClient) SetUserRole(ctx context.Context, req *connect.Request[grpc.SetUserRoleRequest]) (*connect.Response[grpc.SetUserRoleResponse], error) {
+	return c.setUserRole.CallUnary(ctx, req)
+}
+
+// DisableUser calls admin.AdminService.DisableUser.
+func (c *adminServiceClient) DisableUser(ctx context.Context, req *connect.Request[grpc.DisableUserRequest]) (*connect.Response[grpc.DisableUserResponse], error) {
+	return c.disableUser.CallUnary(ctx, req)
+}
+
+// EnableUser calls admin.AdminService.EnableUser.
+func (c *adminServiceClient) EnableUser(ctx context.Context, req *connect.Request[grpc.EnableUserRequest]) (*connect.Response[grpc.EnableUserResponse], error) {
+	return c.enableUser.CallUnary(ctx, req)
+}
+
+// ForcePasswordReset calls admin.AdminService.ForcePasswordReset.
+func (c *adminServiceClient) ForcePasswordReset(ctx context.Context, req *connect.Request[grpc.ForcePasswordResetRequest]) (*connect.Response[grpc.ForcePasswordResetResponse], error) {
+	return c.forcePasswordReset.CallUnary(ctx, req)
+}
+
+// RevokeUserCredentials calls admin.AdminService.RevokeUserCredentials.
+func (c *adminServiceClient) RevokeUserCredentials(ctx context.Context, req *connect.Request[grpc.RevokeUserCredentialsRequest]) (*connect.Response[grpc.RevokeUserCredentialsResponse], error) {
+	return c.revokeUserCredentials.CallUnary(ctx, req)
+}
+
+// RegenerateUserEmbeddings calls admin.AdminService.RegenerateUserEmbeddings.
+func (c *adminServiceClient) RegenerateUserEmbeddings(ctx context.Context, req *connect.Request[grpc.RegenerateUserEmbeddingsRequest]) (*connect.Response[grpc.RegenerateUserEmbeddingsResponse], error) {
+	return c.regenerateUserEmbeddings.CallUnary(ctx, req)
+}
+
+// ListAuditLogs calls admin.AdminService.ListAuditLogs.
+func (c *adminServiceClient) ListAuditLogs(ctx context.Context, req *connect.Request[grpc.ListAuditLogsRequest]) (*connect.Response[grpc.ListAuditLogsResponse], error) {
+	return c.listAuditLogs.CallUnary(ctx, req)
+}
+
+// AdminServiceHandler is an implementation of the admin.AdminService service.
+type AdminServiceHandler interface {
+	// ListUsers はユーザーを登録日時の古い順に返します。
+	// queryを指定した場合はメールアドレスか名前に部分一致するユーザーに絞り込みます。
+	//
+	// 例:
+	//
+	//	request: { query: "example.com", limit: 50 }
+	//	response: { users: [{ id: "uuid", email: "user@example.com", ... }], next_cursor: "1700000000_uuid", has_more: true }
+	//
+	// エラー:
+	//   - InvalidArgument: cursorが不正
+	ListUsers(context.Context, *connect.Request[grpc.ListUsersRequest]) (*connect.Response[grpc.ListUsersResponse], error)
+	// GetUserUsage はユーザーの日記・埋め込みベクトル・LLMの利用状況と、過去24時間のジョブの処理件数を返します。
+	//
+	// 例:
+	//
+	//	request: { user_id: "uuid" }
+	//	response: { user: { ... }, usage: { diary_count: 120, embedding_count: 300, semantic_search_count: 42, ... } }
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	GetUserUsage(context.Context, *connect.Request[grpc.GetUserUsageRequest]) (*connect.Response[grpc.GetUserUsageResponse], error)
+	// SetUserRole はユーザーの権限を変更します。自分自身の権限は変更できません。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	//   - FailedPrecondition: 自分自身の権限を変更しようとした
+	SetUserRole(context.Context, *connect.Request[grpc.SetUserRoleRequest]) (*connect.Response[grpc.SetUserRoleResponse], error)
+	// DisableUser はユーザーを無効にします。無効なユーザーはログイン・トークンの更新・APIキーでの認証ができません。
+	// 発行済みのアクセストークンも次のリクエストから使えなくなります（状態は最長10秒キャッシュするため、反映が遅れることがあります）。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	//   - FailedPrecondition: 自分自身を無効にしようとした
+	DisableUser(context.Context, *connect.Request[grpc.DisableUserRequest]) (*connect.Response[grpc.DisableUserResponse], error)
+	// EnableUser は無効にしたユーザーを有効に戻します。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	EnableUser(context.Context, *connect.Request[grpc.EnableUserRequest]) (*connect.Response[grpc.EnableUserResponse], error)
+	// ForcePasswordReset はユーザーにパスワードの再設定を求めます。
+	// すべてのセッションを取り消し、次のログインではpassword_reset_requiredが返ります。
+	// パスワードを変更するまでトークンの更新はできません。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない、またはパスワードでログインするユーザーでない
+	ForcePasswordReset(context.Context, *connect.Request[grpc.ForcePasswordResetRequest]) (*connect.Response[grpc.ForcePasswordResetResponse], error)
+	// RevokeUserCredentials はユーザーのすべてのセッション（リフレッシュトークン）とAPIキーを取り消します。
+	//
+	// 例:
+	//
+	//	request: { user_id: "uuid" }
+	//	response: { revoked_api_key_count: 2 }
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	RevokeUserCredentials(context.Context, *connect.Request[grpc.RevokeUserCredentialsRequest]) (*connect.Response[grpc.RevokeUserCredentialsResponse], error)
+	// RegenerateUserEmbeddings はユーザーの埋め込みベクトルが未生成の日記をキューに追加します
+	// （DiaryServiceのRegenerateAllEmbeddingsをユーザーに代わって実行します）。
+	//
+	// エラー:
+	//   - NotFound: ユーザーが存在しない
+	//   - FailedPrecondition: ユーザーがGeminiのAPIキーを設定していない、または意味的検索が無効
+	//   - ResourceExhausted: 再生成が実行中
+	RegenerateUserEmbeddings(context.Context, *connect.Request[grpc.RegenerateUserEmbeddingsRequest]) (*connect.Response[grpc.RegenerateUserEmbeddingsResponse], error)
+	// ListAuditLogs は管理者の操作の監査ログを新しい順に返します。
+	//
+	// 例:
+	//
+	//	request: { target_user_id: "uuid", limit: 50 }
+	//	response: { logs: [{ action: "disable_user", admin_email: "admin@example.com", ... }], next_cursor: "...", has_more: false }
+	//
+	// エラー:
+	//   - InvalidArgument: cursorが不正
+	ListAuditLogs(context.Context, *connect.Request[grpc.ListAuditLogsRequest]) (*connect.Response[grpc.ListAuditLogsResponse], error)
+}
+
+// NewAdminServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	adminServiceMethods := grpc.File_admin_admin_proto.Services().ByName("AdminService").Methods()
+	adminServiceListUsersHandler := connect.NewUnaryHandler(
+		AdminServiceListUsersProcedure,
+		svc.ListUsers,
+		connect.WithSchema(adminServiceMethods.ByName("ListUsers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceGetUserUsageHandler := connect.NewUnaryHandler(
+		AdminServiceGetUserUsageProcedure,
+		svc.GetUserUsage,
+		connect.WithSchema(adminServiceMethods.ByName("GetUserUsage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetUserRoleHandler := connect.NewUnaryHandler(
+		AdminServiceSetUserRoleProcedure,
+		svc.SetUserRole,
+		connect.WithSchema(adminServiceMethods.ByName("SetUserRole")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceDisableUserHandler := connect.NewUnaryHandler(
+		AdminServiceDisableUserProcedure,
+		svc.DisableUser,
+		connect.WithSchema(adminServiceMethods.ByName("DisableUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceEnableUserHandler := connect.NewUnaryHandler(
+		AdminServiceEnableUserProcedure,
+		svc.EnableUser,
+		connect.WithSchema(adminServiceMethods.ByName("EnableUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceForcePasswordResetHandler := connect.NewUnaryHandler(
+		AdminServiceForcePasswordResetProcedure,
+		svc.ForcePasswordReset,
+		connect.WithSchema(adminServiceMethods.ByName("ForcePasswordReset")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceRevokeUserCredentialsHandler := connect.NewUnaryHandler(
+		AdminServiceRevokeUserCredentialsProcedure,
+		svc.RevokeUserCredentials,
+		connect.WithSchema(adminServiceMethods.ByName("RevokeUserCredentials")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceRegenerateUserEmbeddingsHandler := connect.NewUnaryHandler(
+		AdminServiceRegenerateUserEmbeddingsProcedure,
+		svc.RegenerateUserEmbeddings,
+		connect.WithSchema(adminServiceMethods.ByName("RegenerateUserEmbeddings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceListAuditLogsHandler := connect.NewUnaryHandler(
+		AdminServiceListAuditLogsProcedure,
+		svc.ListAuditLogs,
+		connect.WithSchema(adminServiceMethods.ByName("ListAuditLogs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/admin.AdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AdminServiceListUsersProcedure:
+			adminServiceListUsersHandler.ServeHTTP(w, r)
+		case AdminServiceGetUserUsageProcedure:
+			adminServiceGetUserUsageHandler.ServeHTTP(w, r)
+		case AdminServiceSetUserRoleProcedure:
+			adminServiceSetUserRoleHandler.ServeHTTP(w, r)
+		case AdminServiceDisableUserProcedure:
+			adminServiceDisableUserHandler.ServeHTTP(w, r)
+		case AdminServiceEnableUserProcedure:
+			adminServiceEnableUserHandler.ServeHTTP(w, r)
+		case AdminServiceForcePasswordResetProcedure:
+			adminServiceForcePasswordResetHandler.ServeHTTP(w, r)
+		case AdminServiceRevokeUserCredentialsProcedure:
+			adminServiceRevokeUserCredentialsHandler.ServeHTTP(w, r)
+		case AdminServiceRegenerateUserEmbeddingsProcedure:
+			adminServiceRegenerateUserEmbeddingsHandler.ServeHTTP(w, r)
+		case AdminServiceListAuditLogsProcedure:
+			adminServiceListAuditLogsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAdminServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAdminServiceHandler struct{}
+
+func (UnimplementedAdminServiceHandler) ListUsers(context.Context, *connect.Request[grpc.ListUsersRequest]) (*connect.Response[grpc.ListUsersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("admin.AdminService.ListUsers is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) GetUserUsage(context.Context, *connect.Request[grpc.GetUserUsageRequest]) (*connect.Response[grpc.GetUserUsageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("admin.AdminService.GetUserUsage is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetUserRole(context.Context, *connect.Request[grpc.SetUserRoleRequest]) (*connect.Response[grpc.SetUserRoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("admin.AdminService.SetUserRole is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) DisableUser(context.Context, *connect.Request[grpc.DisableUserRequest]) (*connect.Response[grpc.DisableUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("admin.AdminService.DisableUser is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) EnableUser(context.Context, *connect.Request[grpc.EnableUserRequest]) (*connect.Response[grpc.EnableUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("admin.AdminService.EnableUser is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ForcePasswordReset(context.Context, *connect.Request[grpc.ForcePasswordResetRequest]) (*connect.Response[grpc.ForcePasswordResetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("admin.AdminService.ForcePasswordReset is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) RevokeUserCredentials(context.Context, *connect.Request[grpc.RevokeUserCredentialsRequest]) (*connect.Response[grpc.RevokeUserCredentialsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("admin.AdminService.RevokeUserCredentials is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) RegenerateUserEmbeddings(context.Context, *connect.Request[grpc.RegenerateUserEmbeddingsRequest]) (*connect.Response[grpc.RegenerateUserEmbeddingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("admin.AdminService.RegenerateUserEmbeddings is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ListAuditLogs(context.Context, *connect.Request[grpc.ListAuditLogsRequest]) (*connect.Response[grpc.ListAuditLogsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("admin.AdminService.ListAuditLogs is not implemented"))
+}
@@ -45,12 +45,96 @@ const (
 	// AuthServiceRefreshAccessTokenProcedure is the fully-qualified name of the AuthService's
 	// RefreshAccessToken RPC.
 	AuthServiceRefreshAccessTokenProcedure = "/auth.AuthService/RefreshAccessToken"
+	// AuthServiceListOIDCProvidersProcedure is the fully-qualified name of the AuthService's
+	// ListOIDCProviders RPC.
+	AuthServiceListOIDCProvidersProcedure = "/auth.AuthService/ListOIDCProviders"
+	// AuthServiceStartOIDCLoginProcedure is the fully-qualified name of the AuthService's
+	// StartOIDCLogin RPC.
+	AuthServiceStartOIDCLoginProcedure = "/auth.AuthService/StartOIDCLogin"
+	// AuthServiceCompleteOIDCLoginProcedure is the fully-qualified name of the AuthService's
+	// CompleteOIDCLogin RPC.
+	AuthServiceCompleteOIDCLoginProcedure = "/auth.AuthService/CompleteOIDCLogin"
+	// AuthServiceStartOIDCLinkProcedure is the fully-qualified name of the AuthService's StartOIDCLink
+	// RPC.
+	AuthServiceStartOIDCLinkProcedure = "/auth.AuthService/StartOIDCLink"
+	// AuthServiceCompleteOIDCLinkProcedure is the fully-qualified name of the AuthService's
+	// CompleteOIDCLink RPC.
+	AuthServiceCompleteOIDCLinkProcedure = "/auth.AuthService/CompleteOIDCLink"
+	// AuthServiceListLinkedOIDCProvidersProcedure is the fully-qualified name of the AuthService's
+	// ListLinkedOIDCProviders RPC.
+	AuthServiceListLinkedOIDCProvidersProcedure = "/auth.AuthService/ListLinkedOIDCProviders"
+	// AuthServiceUnlinkOIDCProviderProcedure is the fully-qualified name of the AuthService's
+	// UnlinkOIDCProvider RPC.
+	AuthServiceUnlinkOIDCProviderProcedure = "/auth.AuthService/UnlinkOIDCProvider"
+	// AuthServiceVerifyMfaProcedure is the fully-qualified name of the AuthService's VerifyMfa RPC.
+	AuthServiceVerifyMfaProcedure = "/auth.AuthService/VerifyMfa"
+	// AuthServiceGetMfaStatusProcedure is the fully-qualified name of the AuthService's GetMfaStatus
+	// RPC.
+	AuthServiceGetMfaStatusProcedure = "/auth.AuthService/GetMfaStatus"
+	// AuthServiceStartTotpEnrollmentProcedure is the fully-qualified name of the AuthService's
+	// StartTotpEnrollment RPC.
+	AuthServiceStartTotpEnrollmentProcedure = "/auth.AuthService/StartTotpEnrollment"
+	// AuthServiceConfirmTotpEnrollmentProcedure is the fully-qualified name of the AuthService's
+	// ConfirmTotpEnrollment RPC.
+	AuthServiceConfirmTotpEnrollmentProcedure = "/auth.AuthService/ConfirmTotpEnrollment"
+	// AuthServiceRegenerateRecoveryCodesProcedure is the fully-qualified name of the AuthService's
+	// RegenerateRecoveryCodes RPC.
+	AuthServiceRegenerateRecoveryCodesProcedure = "/auth.AuthService/RegenerateRecoveryCodes"
+	// AuthServiceDisableMfaProcedure is the fully-qualified name of the AuthService's DisableMfa RPC.
+	AuthServiceDisableMfaProcedure = "/auth.AuthService/DisableMfa"
+	// AuthServiceStartPasskeyRegistrationProcedure is the fully-qualified name of the AuthService's
+	// StartPasskeyRegistration RPC.
+	AuthServiceStartPasskeyRegistrationProcedure = "/auth.AuthService/StartPasskeyRegistration"
+	// AuthServiceFinishPasskeyRegistrationProcedure is the fully-qualified name of the AuthService's
+	// FinishPasskeyRegistration RPC.
+	AuthServiceFinishPasskeyRegistrationProcedure = "/auth.AuthService/FinishPasskeyRegistration"
+	// AuthServiceStartPasskeyLoginProcedure is the fully-qualified name of the AuthService's
+	// StartPasskeyLogin RPC.
+	AuthServiceStartPasskeyLoginProcedure = "/auth.AuthService/StartPasskeyLogin"
+	// AuthServiceFinishPasskeyLoginProcedure is the fully-qualified name of the AuthService's
+	// FinishPasskeyLogin RPC.
+	AuthServiceFinishPasskeyLoginProcedure = "/auth.AuthService/FinishPasskeyLogin"
+	// AuthServiceStartPasskeyMfaProcedure is the fully-qualified name of the AuthService's
+	// StartPasskeyMfa RPC.
+	AuthServiceStartPasskeyMfaProcedure = "/auth.AuthService/StartPasskeyMfa"
+	// AuthServiceFinishPasskeyMfaProcedure is the fully-qualified name of the AuthService's
+	// FinishPasskeyMfa RPC.
+	AuthServiceFinishPasskeyMfaProcedure = "/auth.AuthService/FinishPasskeyMfa"
+	// AuthServiceListPasskeysProcedure is the fully-qualified name of the AuthService's ListPasskeys
+	// RPC.
+	AuthServiceListPasskeysProcedure = "/auth.AuthService/ListPasskeys"
+	// AuthServiceDeletePasskeyProcedure is the fully-qualified name of the AuthService's DeletePasskey
+	// RPC.
+	AuthServiceDeletePasskeyProcedure = "/auth.AuthService/DeletePasskey"
+	// AuthServiceRequestPasswordResetProcedure is the fully-qualified name of the AuthService's
+	// RequestPasswordReset RPC.
+	AuthServiceRequestPasswordResetProcedure = "/auth.AuthService/RequestPasswordReset"
+	// AuthServiceResetPasswordProcedure is the fully-qualified name of the AuthService's ResetPassword
+	// RPC.
+	AuthServiceResetPasswordProcedure = "/auth.AuthService/ResetPassword"
+	// AuthServiceVerifyEmailProcedure is the fully-qualified name of the AuthService's VerifyEmail RPC.
+	AuthServiceVerifyEmailProcedure = "/auth.AuthService/VerifyEmail"
+	// AuthServiceRequestEmailVerificationProcedure is the fully-qualified name of the AuthService's
+	// RequestEmailVerification RPC.
+	AuthServiceRequestEmailVerificationProcedure = "/auth.AuthService/RequestEmailVerification"
+	// AuthServiceCreateInvitationProcedure is the fully-qualified name of the AuthService's
+	// CreateInvitation RPC.
+	AuthServiceCreateInvitationProcedure = "/auth.AuthService/CreateInvitation"
+	// AuthServiceListInvitationsProcedure is the fully-qualified name of the AuthService's
+	// ListInvitations RPC.
+	AuthServiceListInvitationsProcedure = "/auth.AuthService/ListInvitations"
+	// AuthServiceRevokeInvitationProcedure is the fully-qualified name of the AuthService's
+	// RevokeInvitation RPC.
+	AuthServiceRevokeInvitationProcedure = "/auth.AuthService/RevokeInvitation"
+	// AuthServiceCancelAccountDeletionProcedure is the fully-qualified name of the AuthService's
+	// CancelAccountDeletion RPC.
+	AuthServiceCancelAccountDeletionProcedure = "/auth.AuthService/CancelAccountDeletion"
 )
 
 // AuthServiceClient is a client for the auth.AuthService service.
 type AuthServiceClient interface {
 	// GetRegistrationConfig は新規登録に必要な設定情報を取得します。
-	// REGISTER_KEY環境変数が設定されている場合、登録キーが必要かどうかを返します。
+	// 招待制（INVITATION_REQUIRED、またはREGISTER_KEYが設定されている）の場合、登録に招待コードが必要かどうかを返します。
 	//
 	// 例:
 	//
@@ -60,25 +144,26 @@ type AuthServiceClient interface {
 	// エラー: なし（常に成功）
 	GetRegistrationConfig(context.Context, *connect.Request[grpc.GetRegistrationConfigRequest]) (*connect.Response[grpc.GetRegistrationConfigResponse], error)
 	// RegisterByPassword はメールアドレスとパスワードで新規ユーザーを登録します。
-	// REGISTER_KEYが設定されている場合は、正しい登録キーが必要です。
+	// 招待制の場合は、有効な招待コード（CreateInvitationで発行）が必要です。
 	//
 	// 例:
 	//
-	//	request: { email: "user@example.com", password: "pass123", name: "太郎", register_key: "secret" }
+	//	request: { email: "user@example.com", password: "pass123", name: "太郎", register_key: "abcd-efgh-ijkl-mnop" }
 	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
 	//
 	// エラー:
 	//   - AlreadyExists: メールアドレスが既に登録されている
-	//   - InvalidArgument: 登録キーが必須だが提供されていない
-	//   - PermissionDenied: 登録キーが不正
+	//   - PermissionDenied: 招待コードが必須だが提供されていない、または不正・期限切れ・使用回数の上限に達した
 	//   - InvalidArgument: バリデーションエラー（メール形式、パスワード長など）
 	RegisterByPassword(context.Context, *connect.Request[grpc.RegisterByPasswordRequest]) (*connect.Response[grpc.AuthResponse], error)
 	// LoginByPassword はメールアドレスとパスワードでログインします。
+	// 2段階認証を有効にしているユーザーはトークンを発行せず、mfa_required と mfa_token を返します（VerifyMfaで続ける）。
 	//
 	// 例:
 	//
 	//	request: { email: "user@example.com", password: "pass123" }
 	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//	response（2段階認証）: { mfa_required: true, mfa_token: "..." }
 	//
 	// エラー:
 	//   - Unauthenticated: メールアドレスまたはパスワードが不正
@@ -95,6 +180,287 @@ type AuthServiceClient interface {
 	// エラー:
 	//   - Unauthenticated: Refresh Tokenが無効または期限切れ
 	RefreshAccessToken(context.Context, *connect.Request[grpc.RefreshAccessTokenRequest]) (*connect.Response[grpc.AuthResponse], error)
+	// ListOIDCProviders はOpenID Connectでログインできるプロバイダーの一覧を取得します。
+	// OIDC_PROVIDERS環境変数で設定したプロバイダーを設定した順に返します。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { providers: [{ id: "google", name: "Google" }] }
+	//
+	// エラー: なし（設定がない場合は空）
+	ListOIDCProviders(context.Context, *connect.Request[grpc.ListOIDCProvidersRequest]) (*connect.Response[grpc.ListOIDCProvidersResponse], error)
+	// StartOIDCLogin はOpenID Connectのログインを開始し、IdPの認可URLを返します。
+	// クライアントは認可URLにリダイレクトし、コールバックで受け取ったstateとcodeでCompleteOIDCLoginを呼び出します（10分以内）。
+	//
+	// 例:
+	//
+	//	request: { provider: "google" }
+	//	response: { authorization_url: "https://accounts.google.com/o/oauth2/v2/auth?...", state: "..." }
+	//
+	// エラー:
+	//   - NotFound: プロバイダーが設定されていない
+	//   - Unavailable: IdPのdiscoveryに失敗した
+	StartOIDCLogin(context.Context, *connect.Request[grpc.StartOIDCLoginRequest]) (*connect.Response[grpc.StartOIDCLoginResponse], error)
+	// CompleteOIDCLogin はIdPからのコールバックのstateとcodeでログインを完了し、トークンを発行します。
+	// プロバイダーのアカウントが未連携の場合、確認済みの同じメールアドレスのユーザーがいれば連携してログインし、
+	// いなければ新規ユーザーとして登録します（招待制の場合は招待コードが必要）。
+	//
+	// 例:
+	//
+	//	request: { state: "...", code: "...", register_key: "" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - InvalidArgument: stateが無効・期限切れ、またはStartOIDCLinkで開始したstate
+	//   - Unauthenticated: 認可コードの交換またはIDトークンの検証に失敗した
+	//   - FailedPrecondition: 未連携で、IdPがメールアドレスを確認していない
+	//   - PermissionDenied: アカウントが無効、または招待コードが不正
+	CompleteOIDCLogin(context.Context, *connect.Request[grpc.CompleteOIDCLoginRequest]) (*connect.Response[grpc.AuthResponse], error)
+	// StartOIDCLink はログイン中のユーザーにプロバイダーのアカウントを連携するため、IdPの認可URLを返します（要認証）。
+	// コールバックで受け取ったstateとcodeでCompleteOIDCLinkを呼び出します。
+	//
+	// エラー:
+	//   - NotFound: プロバイダーが設定されていない
+	//   - AlreadyExists: このプロバイダーは既に連携している
+	StartOIDCLink(context.Context, *connect.Request[grpc.StartOIDCLoginRequest]) (*connect.Response[grpc.StartOIDCLoginResponse], error)
+	// CompleteOIDCLink はIdPからのコールバックのstateとcodeで、ログイン中のユーザーにプロバイダーのアカウントを連携します（要認証）。
+	// メールアドレスが異なるプロバイダーのアカウントも連携できます。
+	//
+	// 例:
+	//
+	//	request: { state: "...", code: "..." }
+	//	response: { provider: { provider: "google", email: "user@gmail.com", created_at: 1700000000 } }
+	//
+	// エラー:
+	//   - InvalidArgument: stateが無効・期限切れ、または別のユーザーが開始したstate
+	//   - Unauthenticated: 認可コードの交換またはIDトークンの検証に失敗した
+	//   - AlreadyExists: プロバイダーのアカウントが別のユーザーに連携済み、またはこのプロバイダーは既に連携している
+	CompleteOIDCLink(context.Context, *connect.Request[grpc.CompleteOIDCLinkRequest]) (*connect.Response[grpc.CompleteOIDCLinkResponse], error)
+	// ListLinkedOIDCProviders はログイン中のユーザーに連携しているプロバイダーの一覧を取得します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { providers: [{ provider: "google", email: "user@gmail.com", created_at: 1700000000 }], has_password: true }
+	ListLinkedOIDCProviders(context.Context, *connect.Request[grpc.ListLinkedOIDCProvidersRequest]) (*connect.Response[grpc.ListLinkedOIDCProvidersResponse], error)
+	// UnlinkOIDCProvider はログイン中のユーザーからプロバイダーの連携を解除します（要認証）。
+	//
+	// エラー:
+	//   - NotFound: このプロバイダーは連携していない
+	//   - FailedPrecondition: パスワード・他に連携しているプロバイダー・パスキーのいずれもない（ログインできなくなる）
+	UnlinkOIDCProvider(context.Context, *connect.Request[grpc.UnlinkOIDCProviderRequest]) (*connect.Response[grpc.UnlinkOIDCProviderResponse], error)
+	// VerifyMfa はログインの2段階目として、認証アプリのコードかリカバリーコードを確認してトークンを発行します。
+	// mfa_tokenはパスワードの確認から5分間有効で、コードを間違えても有効期限までは再入力できます。
+	// ログインと同じレート制限を、クライアントとユーザーごとに適用します。
+	//
+	// 例:
+	//
+	//	request: { mfa_token: "...", code: "123456" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - Unauthenticated: mfa_tokenが無効・期限切れ、またはコードが不正
+	//   - ResourceExhausted: 試行回数の上限を超えた
+	VerifyMfa(context.Context, *connect.Request[grpc.VerifyMfaRequest]) (*connect.Response[grpc.AuthResponse], error)
+	// GetMfaStatus はログイン中のユーザーの2段階認証の状態を取得します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { enabled: true, recovery_codes_remaining: 8 }
+	GetMfaStatus(context.Context, *connect.Request[grpc.GetMfaStatusRequest]) (*connect.Response[grpc.GetMfaStatusResponse], error)
+	// StartTotpEnrollment は認証アプリの登録を開始し、秘密鍵とQRコードにするURIを返します（要認証）。
+	// ConfirmTotpEnrollmentで認証アプリのコードを確認するまで2段階認証は有効になりません。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { secret: "JBSWY3DPEHPK3PXP...", provisioning_uri: "otpauth://totp/umi.mikan:user@example.com?..." }
+	//
+	// エラー:
+	//   - AlreadyExists: 既に2段階認証を有効にしている
+	StartTotpEnrollment(context.Context, *connect.Request[grpc.StartTotpEnrollmentRequest]) (*connect.Response[grpc.StartTotpEnrollmentResponse], error)
+	// ConfirmTotpEnrollment は認証アプリのコードを確認して2段階認証を有効にし、リカバリーコードを返します（要認証）。
+	// リカバリーコードはこのレスポンスでのみ返します。
+	//
+	// 例:
+	//
+	//	request: { code: "123456" }
+	//	response: { recovery_codes: ["abcde-fghij", ...] }
+	//
+	// エラー:
+	//   - FailedPrecondition: 登録を開始していない、または既に有効
+	//   - InvalidArgument: コードが不正
+	ConfirmTotpEnrollment(context.Context, *connect.Request[grpc.ConfirmTotpEnrollmentRequest]) (*connect.Response[grpc.ConfirmTotpEnrollmentResponse], error)
+	// RegenerateRecoveryCodes は認証アプリのコードを確認してリカバリーコードを作り直します（要認証）。
+	// 以前のリカバリーコードは使えなくなります。
+	//
+	// エラー:
+	//   - FailedPrecondition: 2段階認証を有効にしていない
+	//   - InvalidArgument: コードが不正
+	RegenerateRecoveryCodes(context.Context, *connect.Request[grpc.RegenerateRecoveryCodesRequest]) (*connect.Response[grpc.ConfirmTotpEnrollmentResponse], error)
+	// DisableMfa は2段階認証を無効にします（要認証）。
+	// 現在のパスワードか認証アプリのコードのどちらかが必要です（パスワードを持たないユーザーはコードのみ）。
+	//
+	// 例:
+	//
+	//	request: { password: "pass123" } または { code: "123456" }
+	//	response: {}
+	//
+	// エラー:
+	//   - FailedPrecondition: 2段階認証を有効にしていない
+	//   - InvalidArgument: パスワードもコードもない
+	//   - PermissionDenied: パスワードまたはコードが不正
+	DisableMfa(context.Context, *connect.Request[grpc.DisableMfaRequest]) (*connect.Response[grpc.DisableMfaResponse], error)
+	// StartPasskeyRegistration はパスキーの登録を開始し、navigator.credentials.create に渡すオプションを返します（要認証）。
+	// オプションは PublicKeyCredentialCreationOptionsJSON のJSON文字列で、チャレンジは5分間有効です。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { options_json: "{\"challenge\":\"...\",\"rp\":{\"id\":\"umi.mikan.example\",...},...}" }
+	StartPasskeyRegistration(context.Context, *connect.Request[grpc.StartPasskeyRegistrationRequest]) (*connect.Response[grpc.PasskeyOptionsResponse], error)
+	// FinishPasskeyRegistration は認証器のレスポンスを検証してパスキーを登録します（要認証）。
+	//
+	// 例:
+	//
+	//	request: { credential_json: "{\"id\":\"...\",\"response\":{\"attestationObject\":\"...\",...}}", name: "iPhone" }
+	//	response: { passkey: { id: "...", name: "iPhone", created_at: 1700000000 } }
+	//
+	// エラー:
+	//   - InvalidArgument: レスポンスが不正、チャレンジが無効・期限切れ、または名前が長すぎる
+	//   - AlreadyExists: このパスキーは登録済み
+	FinishPasskeyRegistration(context.Context, *connect.Request[grpc.FinishPasskeyRegistrationRequest]) (*connect.Response[grpc.FinishPasskeyRegistrationResponse], error)
+	// StartPasskeyLogin はパスキーでのログインを開始し、navigator.credentials.get に渡すオプションを返します。
+	// 端末に保存されたパスキーから選ばせるため、メールアドレスは不要です。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { options_json: "{\"challenge\":\"...\",\"rpId\":\"umi.mikan.example\",...}" }
+	StartPasskeyLogin(context.Context, *connect.Request[grpc.StartPasskeyLoginRequest]) (*connect.Response[grpc.PasskeyOptionsResponse], error)
+	// FinishPasskeyLogin は認証器のレスポンスを検証してトークンを発行します。
+	// 生体認証・PINで確認したパスキーは2つの要素を満たすため、2段階認証を有効にしていても2段階目は求めません。
+	//
+	// 例:
+	//
+	//	request: { credential_json: "{\"id\":\"...\",\"response\":{\"signature\":\"...\",...}}" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - InvalidArgument: レスポンスが不正
+	//   - Unauthenticated: チャレンジが無効・期限切れ、未登録のパスキー、または署名が不正
+	//   - ResourceExhausted: 試行回数の上限を超えた
+	FinishPasskeyLogin(context.Context, *connect.Request[grpc.FinishPasskeyLoginRequest]) (*connect.Response[grpc.AuthResponse], error)
+	// StartPasskeyMfa はログインの2段階目としてパスキーでの認証を開始します。
+	// mfa_methodsに "passkey" を含むAuthResponseのmfa_tokenが必要です。
+	//
+	// エラー:
+	//   - Unauthenticated: mfa_tokenが無効・期限切れ
+	//   - FailedPrecondition: パスキーを登録していない
+	StartPasskeyMfa(context.Context, *connect.Request[grpc.StartPasskeyMfaRequest]) (*connect.Response[grpc.PasskeyOptionsResponse], error)
+	// FinishPasskeyMfa は認証器のレスポンスを検証してトークンを発行します（VerifyMfaのパスキー版）。
+	// VerifyMfaと同じレート制限を、クライアントとユーザーごとに適用します。
+	//
+	// エラー:
+	//   - InvalidArgument: レスポンスが不正
+	//   - Unauthenticated: mfa_token・チャレンジが無効・期限切れ、別のユーザーのパスキー、または署名が不正
+	//   - ResourceExhausted: 試行回数の上限を超えた
+	FinishPasskeyMfa(context.Context, *connect.Request[grpc.FinishPasskeyMfaRequest]) (*connect.Response[grpc.AuthResponse], error)
+	// ListPasskeys はログイン中のユーザーが登録したパスキーの一覧を取得します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { passkeys: [{ id: "...", name: "iPhone", transports: ["internal", "hybrid"], created_at: 1700000000, last_used_at: 1700001000 }] }
+	ListPasskeys(context.Context, *connect.Request[grpc.ListPasskeysRequest]) (*connect.Response[grpc.ListPasskeysResponse], error)
+	// DeletePasskey はログイン中のユーザーのパスキーを削除します（要認証）。
+	//
+	// エラー:
+	//   - NotFound: パスキーが存在しない、または他のユーザーのパスキー
+	//   - FailedPrecondition: パスワードも連携しているプロバイダーもなく、最後のパスキー（ログインできなくなる）
+	DeletePasskey(context.Context, *connect.Request[grpc.DeletePasskeyRequest]) (*connect.Response[grpc.DeletePasskeyResponse], error)
+	// RequestPasswordReset はパスワードの再設定のリンクをメールで送ります。
+	// メールアドレスが登録されているかどうかを知られないよう、登録されていない場合も成功を返します。
+	// リンクは1時間有効で、1回だけ使えます。
+	//
+	// 例:
+	//
+	//	request: { email: "user@example.com" }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: メールアドレスが空
+	//   - ResourceExhausted: 送信回数の上限を超えた
+	RequestPasswordReset(context.Context, *connect.Request[grpc.RequestPasswordResetRequest]) (*connect.Response[grpc.RequestPasswordResetResponse], error)
+	// ResetPassword はメールのリンクのトークンでパスワードを再設定します。
+	// 再設定するとメールアドレスも確認済みになり、それまでのセッション（リフレッシュトークン）は使えなくなります。
+	// パスワードを持たないユーザー（OpenID Connectで登録）はパスワードを設定できます。
+	//
+	// 例:
+	//
+	//	request: { token: "...", new_password: "newPassword123" }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: トークンが不正・期限切れ・使用済み、またはパスワードが短い
+	//   - PermissionDenied: アカウントが無効
+	ResetPassword(context.Context, *connect.Request[grpc.ResetPasswordRequest]) (*connect.Response[grpc.ResetPasswordResponse], error)
+	// VerifyEmail はメールのリンクのトークンでメールアドレスを確認済みにします。
+	// リンクは24時間有効で、1回だけ使えます。リンクを送った後にメールアドレスを変えた場合は使えません。
+	//
+	// 例:
+	//
+	//	request: { token: "..." }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: トークンが不正・期限切れ・使用済み
+	VerifyEmail(context.Context, *connect.Request[grpc.VerifyEmailRequest]) (*connect.Response[grpc.VerifyEmailResponse], error)
+	// RequestEmailVerification はログイン中のユーザーに確認のリンクをメールで送り直します（要認証）。
+	//
+	// エラー:
+	//   - FailedPrecondition: 既に確認済み
+	//   - ResourceExhausted: 送信回数の上限を超えた
+	RequestEmailVerification(context.Context, *connect.Request[grpc.RequestEmailVerificationRequest]) (*connect.Response[grpc.RequestEmailVerificationResponse], error)
+	// CreateInvitation は新規登録に使う招待コードを発行します（要認証）。
+	// コードは発行時のレスポンスでのみ返します（DBにはハッシュのみを保存）。
+	// 使用回数は1〜10回（管理者は1000回まで）、有効期限は1〜30日（管理者は365日まで）で、省略時は1回・7日です。
+	//
+	// 例:
+	//
+	//	request: { max_uses: 3, expires_in_days: 7, note: "家族用" }
+	//	response: { code: "abcd-efgh-ijkl-mnop", invitation: { id: "...", max_uses: 3, use_count: 0, expires_at: 1700604800, ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: 使用回数・有効期限が範囲外、またはメモが長すぎる
+	//   - PermissionDenied: アカウントが無効
+	CreateInvitation(context.Context, *connect.Request[grpc.CreateInvitationRequest]) (*connect.Response[grpc.CreateInvitationResponse], error)
+	// ListInvitations はログイン中のユーザーが発行した招待コードを、新しい順に使ったユーザーとともに返します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { invitations: [{ id: "...", note: "家族用", max_uses: 3, use_count: 1, uses: [{ user_id: "...", user_name: "太郎", used_at: 1700001000 }], ... }] }
+	ListInvitations(context.Context, *connect.Request[grpc.ListInvitationsRequest]) (*connect.Response[grpc.ListInvitationsResponse], error)
+	// RevokeInvitation はログイン中のユーザーが発行した招待コードを取り消します（要認証）。
+	// 取り消した招待コードでは登録できなくなります（登録済みのユーザーには影響しません）。
+	//
+	// エラー:
+	//   - NotFound: 招待コードが存在しない、または他のユーザーが発行した
+	RevokeInvitation(context.Context, *connect.Request[grpc.RevokeInvitationRequest]) (*connect.Response[grpc.RevokeInvitationResponse], error)
+	// CancelAccountDeletion はメールのリンクのトークンでアカウントの削除を取り消します。
+	// リンクは削除の予定日時まで有効です。取り消した後は改めてログインしてください（APIキーは再発行が必要です）。
+	//
+	// 例:
+	//
+	//	request: { token: "..." }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: トークンが不正・期限切れ・使用済み、または削除が既に取り消されている
+	CancelAccountDeletion(context.Context, *connect.Request[grpc.CancelAccountDeletionRequest]) (*connect.Response[grpc.CancelAccountDeletionResponse], error)
 }
 
 // NewAuthServiceClient constructs a client for the auth.AuthService service. By default, it uses
@@ -132,15 +498,218 @@ func NewAuthServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(authServiceMethods.ByName("RefreshAccessToken")),
 			connect.WithClientOptions(opts...),
 		),
+		listOIDCProviders: connect.NewClient[grpc.ListOIDCProvidersRequest, grpc.ListOIDCProvidersResponse](
+			httpClient,
+			baseURL+AuthServiceListOIDCProvidersProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ListOIDCProviders")),
+			connect.WithClientOptions(opts...),
+		),
+		startOIDCLogin: connect.NewClient[grpc.StartOIDCLoginRequest, grpc.StartOIDCLoginResponse](
+			httpClient,
+			baseURL+AuthServiceStartOIDCLoginProcedure,
+			connect.WithSchema(authServiceMethods.ByName("StartOIDCLogin")),
+			connect.WithClientOptions(opts...),
+		),
+		completeOIDCLogin: connect.NewClient[grpc.CompleteOIDCLoginRequest, grpc.AuthResponse](
+			httpClient,
+			baseURL+AuthServiceCompleteOIDCLoginProcedure,
+			connect.WithSchema(authServiceMethods.ByName("CompleteOIDCLogin")),
+			connect.WithClientOptions(opts...),
+		),
+		startOIDCLink: connect.NewClient[grpc.StartOIDCLoginRequest, grpc.StartOIDCLoginResponse](
+			httpClient,
+			baseURL+AuthServiceStartOIDCLinkProcedure,
+			connect.WithSchema(authServiceMethods.ByName("StartOIDCLink")),
+			connect.WithClientOptions(opts...),
+		),
+		completeOIDCLink: connect.NewClient[grpc.CompleteOIDCLinkRequest, grpc.CompleteOIDCLinkResponse](
+			httpClient,
+			baseURL+AuthServiceCompleteOIDCLinkProcedure,
+			connect.WithSchema(authServiceMethods.ByName("CompleteOIDCLink")),
+			connect.WithClientOptions(opts...),
+		),
+		listLinkedOIDCProviders: connect.NewClient[grpc.ListLinkedOIDCProvidersRequest, grpc.ListLinkedOIDCProvidersResponse](
+			httpClient,
+			baseURL+AuthServiceListLinkedOIDCProvidersProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ListLinkedOIDCProviders")),
+			connect.WithClientOptions(opts...),
+		),
+		unlinkOIDCProvider: connect.NewClient[grpc.UnlinkOIDCProviderRequest, grpc.UnlinkOIDCProviderResponse](
+			httpClient,
+			baseURL+AuthServiceUnlinkOIDCProviderProcedure,
+			connect.WithSchema(authServiceMethods.ByName("UnlinkOIDCProvider")),
+			connect.WithClientOptions(opts...),
+		),
+		verifyMfa: connect.NewClient[grpc.VerifyMfaRequest, grpc.AuthResponse](
+			httpClient,
+			baseURL+AuthServiceVerifyMfaProcedure,
+			connect.WithSchema(authServiceMethods.ByName("VerifyMfa")),
+			connect.WithClientOptions(opts...),
+		),
+		getMfaStatus: connect.NewClient[grpc.GetMfaStatusRequest, grpc.GetMfaStatusResponse](
+			httpClient,
+			baseURL+AuthServiceGetMfaStatusProcedure,
+			connect.WithSchema(authServiceMethods.ByName("GetMfaStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		startTotpEnrollment: connect.NewClient[grpc.StartTotpEnrollmentRequest, grpc.StartTotpEnrollmentResponse](
+			httpClient,
+			baseURL+AuthServiceStartTotpEnrollmentProcedure,
+			connect.WithSchema(authServiceMethods.ByName("StartTotpEnrollment")),
+			connect.WithClientOptions(opts...),
+		),
+		confirmTotpEnrollment: connect.NewClient[grpc.ConfirmTotpEnrollmentRequest, grpc.ConfirmTotpEnrollmentResponse](
+			httpClient,
+			baseURL+AuthServiceConfirmTotpEnrollmentProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ConfirmTotpEnrollment")),
+			connect.WithClientOptions(opts...),
+		),
+		regenerateRecoveryCodes: connect.NewClient[grpc.RegenerateRecoveryCodesRequest, grpc.ConfirmTotpEnrollmentResponse](
+			httpClient,
+			baseURL+AuthServiceRegenerateRecoveryCodesProcedure,
+			connect.WithSchema(authServiceMethods.ByName("RegenerateRecoveryCodes")),
+			connect.WithClientOptions(opts...),
+		),
+		disableMfa: connect.NewClient[grpc.DisableMfaRequest, grpc.DisableMfaResponse](
+			httpClient,
+			baseURL+AuthServiceDisableMfaProcedure,
+			connect.WithSchema(authServiceMethods.ByName("DisableMfa")),
+			connect.WithClientOptions(opts...),
+		),
+		startPasskeyRegistration: connect.NewClient[grpc.StartPasskeyRegistrationRequest, grpc.PasskeyOptionsResponse](
+			httpClient,
+			baseURL+AuthServiceStartPasskeyRegistrationProcedure,
+			connect.WithSchema(authServiceMethods.ByName("StartPasskeyRegistration")),
+			connect.WithClientOptions(opts...),
+		),
+		finishPasskeyRegistration: connect.NewClient[grpc.FinishPasskeyRegistrationRequest, grpc.FinishPasskeyRegistrationResponse](
+			httpClient,
+			baseURL+AuthServiceFinishPasskeyRegistrationProcedure,
+			connect.WithSchema(authServiceMethods.ByName("FinishPasskeyRegistration")),
+			connect.WithClientOptions(opts...),
+		),
+		startPasskeyLogin: connect.NewClient[grpc.StartPasskeyLoginRequest, grpc.PasskeyOptionsResponse](
+			httpClient,
+			baseURL+AuthServiceStartPasskeyLoginProcedure,
+			connect.WithSchema(authServiceMethods.ByName("StartPasskeyLogin")),
+			connect.WithClientOptions(opts...),
+		),
+		finishPasskeyLogin: connect.NewClient[grpc.FinishPasskeyLoginRequest, grpc.AuthResponse](
+			httpClient,
+			baseURL+AuthServiceFinishPasskeyLoginProcedure,
+			connect.WithSchema(authServiceMethods.ByName("FinishPasskeyLogin")),
+			connect.WithClientOptions(opts...),
+		),
+		startPasskeyMfa: connect.NewClient[grpc.StartPasskeyMfaRequest, grpc.PasskeyOptionsResponse](
+			httpClient,
+			baseURL+AuthServiceStartPasskeyMfaProcedure,
+			connect.WithSchema(authServiceMethods.ByName("StartPasskeyMfa")),
+			connect.WithClientOptions(opts...),
+		),
+		finishPasskeyMfa: connect.NewClient[grpc.FinishPasskeyMfaRequest, grpc.AuthResponse](
+			httpClient,
+			baseURL+AuthServiceFinishPasskeyMfaProcedure,
+			connect.WithSchema(authServiceMethods.ByName("FinishPasskeyMfa")),
+			connect.WithClientOptions(opts...),
+		),
+		listPasskeys: connect.NewClient[grpc.ListPasskeysRequest, grpc.ListPasskeysResponse](
+			httpClient,
+			baseURL+AuthServiceListPasskeysProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ListPasskeys")),
+			connect.WithClientOptions(opts...),
+		),
+		deletePasskey: connect.NewClient[grpc.DeletePasskeyRequest, grpc.DeletePasskeyResponse](
+			httpClient,
+			baseURL+AuthServiceDeletePasskeyProcedure,
+			connect.WithSchema(authServiceMethods.ByName("DeletePasskey")),
+			connect.WithClientOptions(opts...),
+		),
+		requestPasswordReset: connect.NewClient[grpc.RequestPasswordResetRequest, grpc.RequestPasswordResetResponse](
+			httpClient,
+			baseURL+AuthServiceRequestPasswordResetProcedure,
+			connect.WithSchema(authServiceMethods.ByName("RequestPasswordReset")),
+			connect.WithClientOptions(opts...),
+		),
+		resetPassword: connect.NewClient[grpc.ResetPasswordRequest, grpc.ResetPasswordResponse](
+			httpClient,
+			baseURL+AuthServiceResetPasswordProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ResetPassword")),
+			connect.WithClientOptions(opts...),
+		),
+		verifyEmail: connect.NewClient[grpc.VerifyEmailRequest, grpc.VerifyEmailResponse](
+			httpClient,
+			baseURL+AuthServiceVerifyEmailProcedure,
+			connect.WithSchema(authServiceMethods.ByName("VerifyEmail")),
+			connect.WithClientOptions(opts...),
+		),
+		requestEmailVerification: connect.NewClient[grpc.RequestEmailVerificationRequest, grpc.RequestEmailVerificationResponse](
+			httpClient,
+			baseURL+AuthServiceRequestEmailVerificationProcedure,
+			connect.WithSchema(authServiceMethods.ByName("RequestEmailVerification")),
+			connect.WithClientOptions(opts...),
+		),
+		createInvitation: connect.NewClient[grpc.CreateInvitationRequest, grpc.CreateInvitationResponse](
+			httpClient,
+			baseURL+AuthServiceCreateInvitationProcedure,
+			connect.WithSchema(authServiceMethods.ByName("CreateInvitation")),
+			connect.WithClientOptions(opts...),
+		),
+		listInvitations: connect.NewClient[grpc.ListInvitationsRequest, grpc.ListInvitationsResponse](
+			httpClient,
+			baseURL+AuthServiceListInvitationsProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ListInvitations")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeInvitation: connect.NewClient[grpc.RevokeInvitationRequest, grpc.RevokeInvitationResponse](
+			httpClient,
+			baseURL+AuthServiceRevokeInvitationProcedure,
+			connect.WithSchema(authServiceMethods.ByName("RevokeInvitation")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelAccountDeletion: connect.NewClient[grpc.CancelAccountDeletionRequest, grpc.CancelAccountDeletionResponse](
+			httpClient,
+			baseURL+AuthServiceCancelAccountDeletionProcedure,
+			connect.WithSchema(authServiceMethods.ByName("CancelAccountDeletion")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // authServiceClient implements AuthServiceClient.
 type authServiceClient struct {
-	getRegistrationConfig *connect.Client[grpc.GetRegistrationConfigRequest, grpc.GetRegistrationConfigResponse]
-	registerByPassword    *connect.Client[grpc.RegisterByPasswordRequest, grpc.AuthResponse]
-	loginByPassword       *connect.Client[grpc.LoginByPasswordRequest, grpc.AuthResponse]
-	refreshAccessToken    *connect.Client[grpc.RefreshAccessTokenRequest, grpc.AuthResponse]
+	getRegistrationConfig     *connect.Client[grpc.GetRegistrationConfigRequest, grpc.GetRegistrationConfigResponse]
+	registerByPassword        *connect.Client[grpc.RegisterByPasswordRequest, grpc.AuthResponse]
+	loginByPassword           *connect.Client[grpc.LoginByPasswordRequest, grpc.AuthResponse]
+	refreshAccessToken        *connect.Client[grpc.RefreshAccessTokenRequest, grpc.AuthResponse]
+	listOIDCProviders         *connect.Client[grpc.ListOIDCProvidersRequest, grpc.ListOIDCProvidersResponse]
+	startOIDCLogin            *connect.Client[grpc.StartOIDCLoginRequest, grpc.StartOIDCLoginResponse]
+	completeOIDCLogin         *connect.Client[grpc.CompleteOIDCLoginRequest, grpc.AuthResponse]
+	startOIDCLink             *connect.Client[grpc.StartOIDCLoginRequest, grpc.StartOIDCLoginResponse]
+	completeOIDCLink          *connect.Client[grpc.CompleteOIDCLinkRequest, grpc.CompleteOIDCLinkResponse]
+	listLinkedOIDCProviders   *connect.Client[grpc.ListLinkedOIDCProvidersRequest, grpc.ListLinkedOIDCProvidersResponse]
+	unlinkOIDCProvider        *connect.Client[grpc.UnlinkOIDCProviderRequest, grpc.UnlinkOIDCProviderResponse]
+	verifyMfa                 *connect.Client[grpc.VerifyMfaRequest, grpc.AuthResponse]
+	getMfaStatus              *connect.Client[grpc.GetMfaStatusRequest, grpc.GetMfaStatusResponse]
+	startTotpEnrollment       *connect.Client[grpc.StartTotpEnrollmentRequest, grpc.StartTotpEnrollmentResponse]
+	confirmTotpEnrollment     *connect.Client[grpc.ConfirmTotpEnrollmentRequest, grpc.ConfirmTotpEnrollmentResponse]
+	regenerateRecoveryCodes   *connect.Client[grpc.RegenerateRecoveryCodesRequest, grpc.ConfirmTotpEnrollmentResponse]
+	disableMfa                *connect.Client[grpc.DisableMfaRequest, grpc.DisableMfaResponse]
+	startPasskeyRegistration  *connect.Client[grpc.StartPasskeyRegistrationRequest, grpc.PasskeyOptionsResponse]
+	finishPasskeyRegistration *connect.Client[grpc.FinishPasskeyRegistrationRequest, grpc.FinishPasskeyRegistrationResponse]
+	startPasskeyLogin         *connect.Client[grpc.StartPasskeyLoginRequest, grpc.PasskeyOptionsResponse]
+	finishPasskeyLogin        *connect.Client[grpc.FinishPasskeyLoginRequest, grpc.AuthResponse]
+	startPasskeyMfa           *connect.Client[grpc.StartPasskeyMfaRequest, grpc.PasskeyOptionsResponse]
+	finishPasskeyMfa          *connect.Client[grpc.FinishPasskeyMfaRequest, grpc.AuthResponse]
+	listPasskeys              *connect.Client[grpc.ListPasskeysRequest, grpc.ListPasskeysResponse]
+	deletePasskey             *connect.Client[grpc.DeletePasskeyRequest, grpc.DeletePasskeyResponse]
+	requestPasswordReset      *connect.Client[grpc.RequestPasswordResetRequest, grpc.RequestPasswordResetResponse]
+	resetPassword             *connect.Client[grpc.ResetPasswordRequest, grpc.ResetPasswordResponse]
+	verifyEmail               *connect.Client[grpc.VerifyEmailRequest, grpc.VerifyEmailResponse]
+	requestEmailVerification  *connect.Client[grpc.RequestEmailVerificationRequest, grpc.RequestEmailVerificationResponse]
+	createInvitation          *connect.Client[grpc.CreateInvitationRequest, grpc.CreateInvitationResponse]
+	listInvitations           *connect.Client[grpc.ListInvitationsRequest, grpc.ListInvitationsResponse]
+	revokeInvitation          *connect.Client[grpc.RevokeInvitationRequest, grpc.RevokeInvitationResponse]
+	cancelAccountDeletion     *connect.Client[grpc.CancelAccountDeletionRequest, grpc.CancelAccountDeletionResponse]
 }
 
 // GetRegistrationConfig calls auth.AuthService.GetRegistrationConfig.
@@ -163,10 +732,155 @@ func (c *authServiceClient) RefreshAccessToken(ctx context.Context, req *connect
 	return c.refreshAccessToken.CallUnary(ctx, req)
 }
 
+// ListOIDCProviders calls auth.AuthService.ListOIDCProviders.
+func (c *authServiceClient) ListOIDCProviders(ctx context.Context, req *connect.Request[grpc.ListOIDCProvidersRequest]) (*connect.Response[grpc.ListOIDCProvidersResponse], error) {
+	return c.listOIDCProviders.CallUnary(ctx, req)
+}
+
+// StartOIDCLogin calls auth.AuthService.StartOIDCLogin.
+func (c *authServiceClient) StartOIDCLogin(ctx context.Context, req *connect.Request[grpc.StartOIDCLoginRequest]) (*connect.Response[grpc.StartOIDCLoginResponse], error) {
+	return c.startOIDCLogin.CallUnary(ctx, req)
+}
+
+// CompleteOIDCLogin calls auth.AuthService.CompleteOIDCLogin.
+func (c *authServiceClient) CompleteOIDCLogin(ctx context.Context, req *connect.Request[grpc.CompleteOIDCLoginRequest]) (*connect.Response[grpc.AuthResponse], error) {
+	return c.completeOIDCLogin.CallUnary(ctx, req)
+}
+
+// StartOIDCLink calls auth.AuthService.StartOIDCLink.
+func (c *authServiceClient) StartOIDCLink(ctx context.Context, req *connect.Request[grpc.StartOIDCLoginRequest]) (*connect.Response[grpc.StartOIDCLoginResponse], error) {
+	return c.startOIDCLink.CallUnary(ctx, req)
+}
+
+// CompleteOIDCLink calls auth.AuthService.CompleteOIDCLink.
+func (c *authServiceClient) CompleteOIDCLink(ctx context.Context, req *connect.Request[grpc.CompleteOIDCLinkRequest]) (*connect.Response[grpc.CompleteOIDCLinkResponse], error) {
+	return c.completeOIDCLink.CallUnary(ctx, req)
+}
+
+// ListLinkedOIDCProviders calls auth.AuthService.ListLinkedOIDCProviders.
+func (c *authServiceClient) ListLinkedOIDCProviders(ctx context.Context, req *connect.Request[grpc.ListLinkedOIDCProvidersRequest]) (*connect.Response[grpc.ListLinkedOIDCProvidersResponse], error) {
+	return c.listLinkedOIDCProviders.CallUnary(ctx, req)
+}
+
+// UnlinkOIDCProvider calls auth.AuthService.UnlinkOIDCProvider.
+func (c *authServiceClient) UnlinkOIDCProvider(ctx context.Context, req *connect.Request[grpc.UnlinkOIDCProviderRequest]) (*connect.Response[grpc.UnlinkOIDCProviderResponse], error) {
+	return c.unlinkOIDCProvider.CallUnary(ctx, req)
+}
+
+// VerifyMfa calls auth.AuthService.VerifyMfa.
+func (c *authServiceClient) VerifyMfa(ctx context.Context, req *connect.Request[grpc.VerifyMfaRequest]) (*connect.Response[grpc.AuthResponse], error) {
+	return c.verifyMfa.CallUnary(ctx, req)
+}
+
+// GetMfaStatus calls auth.AuthService.GetMfaStatus.
+func (c *authServiceClient) GetMfaStatus(ctx context.Context, req *connect.Request[grpc.GetMfaStatusRequest]) (*connect.Response[grpc.GetMfaStatusResponse], error) {
+	return c.getMfaStatus.CallUnary(ctx, req)
+}
+
+// StartTotpEnrollment calls auth.AuthService.StartTotpEnrollment.
+func (c *authServiceClient) StartTotpEnrollment(ctx context.Context, req *connect.Request[grpc.StartTotpEnrollmentRequest]) (*connect.Response[grpc.StartTotpEnrollmentResponse], error) {
+	return c.startTotpEnrollment.CallUnary(ctx, req)
+}
+
+// ConfirmTotpEnrollment calls auth.AuthService.ConfirmTotpEnrollment.
+func (c *authServiceClient) ConfirmTotpEnrollment(ctx context.Context, req *connect.Request[grpc.ConfirmTotpEnrollmentRequest]) (*connect.Response[grpc.ConfirmTotpEnrollmentResponse], error) {
+	return c.confirmTotpEnrollment.CallUnary(ctx, req)
+}
+
+// RegenerateRecoveryCodes calls auth.AuthService.RegenerateRecoveryCodes.
+func (c *authServiceClient) RegenerateRecoveryCodes(ctx context.Context, req *connect.Request[grpc.RegenerateRecoveryCodesRequest]) (*connect.Response[grpc.ConfirmTotpEnrollmentResponse], error) {
+	return c.regenerateRecoveryCodes.CallUnary(ctx, req)
+}
+
+// DisableMfa calls auth.AuthService.DisableMfa.
+func (c *authServiceClient) DisableMfa(ctx context.Context, req *connect.Request[grpc.DisableMfaRequest]) (*connect.Response[grpc.DisableMfaResponse], error) {
+	return c.disableMfa.CallUnary(ctx, req)
+}
+
+// StartPasskeyRegistration calls auth.AuthService.StartPasskeyRegistration.
+func (c *authServiceClient) StartPasskeyRegistration(ctx context.Context, req *connect.Request[grpc.StartPasskeyRegistrationRequest]) (*connect.Response[grpc.PasskeyOptionsResponse], error) {
+	return c.startPasskeyRegistration.CallUnary(ctx, req)
+}
+
+// FinishPasskeyRegistration calls auth.AuthService.FinishPasskeyRegistration.
+func (c *authServiceClient) FinishPasskeyRegistration(ctx context.Context, req *connect.Request[grpc.FinishPasskeyRegistrationRequest]) (*connect.Response[grpc.FinishPasskeyRegistrationResponse], error) {
+	return c.finishPasskeyRegistration.CallUnary(ctx, req)
+}
+
+// StartPasskeyLogin calls auth.AuthService.StartPasskeyLogin.
+func (c *authServiceClient) StartPasskeyLogin(ctx context.Context, req *connect.Request[grpc.StartPasskeyLoginRequest]) (*connect.Response[grpc.PasskeyOptionsResponse], error) {
+	return c.startPasskeyLogin.CallUnary(ctx, req)
+}
+
+// FinishPasskeyLogin calls auth.AuthService.FinishPasskeyLogin.
+func (c *authServiceClient) FinishPasskeyLogin(ctx context.Context, req *connect.Request[grpc.FinishPasskeyLoginRequest]) (*connect.Response[grpc.AuthResponse], error) {
+	return c.finishPasskeyLogin.CallUnary(ctx, req)
+}
+
+// StartPasskeyMfa calls auth.AuthService.StartPasskeyMfa.
+func (c *authServiceClient) StartPasskeyMfa(ctx context.Context, req *connect.Request[grpc.StartPasskeyMfaRequest]) (*connect.Response[grpc.PasskeyOptionsResponse], error) {
+	return c.startPasskeyMfa.CallUnary(ctx, req)
+}
+
+// FinishPasskeyMfa calls auth.AuthService.FinishPasskeyMfa.
+func (c *authServiceClient) FinishPasskeyMfa(ctx context.Context, req *connect.Request[grpc.FinishPasskeyMfaRequest]) (*connect.Response[grpc.AuthResponse], error) {
+	return c.finishPasskeyMfa.CallUnary(ctx, req)
+}
+
+// ListPasskeys calls auth.AuthService.ListPasskeys.
+func (c *authServiceClient) ListPasskeys(ctx context.Context, req *connect.Request[grpc.ListPasskeysRequest]) (*connect.Response[grpc.ListPasskeysResponse], error) {
+	return c.listPasskeys.CallUnary(ctx, req)
+}
+
+// DeletePasskey calls auth.AuthService.DeletePasskey.
+func (c *authServiceClient) DeletePasskey(ctx context.Context, req *connect.Request[grpc.DeletePasskeyRequest]) (*connect.Response[grpc.DeletePasskeyResponse], error) {
+	return c.deletePasskey.CallUnary(ctx, req)
+}
+
+// RequestPasswordReset calls auth.AuthService.RequestPasswordReset.
+func (c *authServiceClient) RequestPasswordReset(ctx context.Context, req *connect.Request[grpc.RequestPasswordResetRequest]) (*connect.Response[grpc.RequestPasswordResetResponse], error) {
+	return c.requestPasswordReset.CallUnary(ctx, req)
+}
+
+// ResetPassword calls auth.AuthService.ResetPassword.
+func (c *authServiceClient) ResetPassword(ctx context.Context, req *connect.Request[grpc.ResetPasswordRequest]) (*connect.Response[grpc.ResetPasswordResponse], error) {
+	return c.resetPassword.CallUnary(ctx, req)
+}
+
+// VerifyEmail calls auth.AuthService.VerifyEmail.
+func (c *authServiceClient) VerifyEmail(ctx context.Context, req *connect.Request[grpc.VerifyEmailRequest]) (*connect.Response[grpc.VerifyEmailResponse], error) {
+	return c.verifyEmail.CallUnary(ctx, req)
+}
+
+// RequestEmailVerification calls auth.AuthService.RequestEmailVerification.
+func (c *authServiceClient) RequestEmailVerification(ctx context.Context, req *connect.Request[grpc.RequestEmailVerificationRequest]) (*connect.Response[grpc.RequestEmailVerificationResponse], error) {
+	return c.requestEmailVerification.CallUnary(ctx, req)
+}
+
+// CreateInvitation calls auth.AuthService.CreateInvitation.
+func (c *authServiceClient) CreateInvitation(ctx context.Context, req *connect.Request[grpc.CreateInvitationRequest]) (*connect.Response[grpc.CreateInvitationResponse], error) {
+	return c.createInvitation.CallUnary(ctx, req)
+}
+
+// ListInvitations calls auth.AuthService.ListInvitations.
+func (c *authServiceClient) ListInvitations(ctx context.Context, req *connect.Request[grpc.ListInvitationsRequest]) (*connect.Response[grpc.ListInvitationsResponse], error) {
+	return c.listInvitations.CallUnary(ctx, req)
+}
+
+// RevokeInvitation calls auth.AuthService.RevokeInvitation.
+func (c *authServiceClient) RevokeInvitation(ctx context.Context, req *connect.Request[grpc.RevokeInvitationRequest]) (*connect.Response[grpc.RevokeInvitationResponse], error) {
+	return c.revokeInvitation.CallUnary(ctx, req)
+}
+
+// CancelAccountDeletion calls auth.AuthService.CancelAccountDeletion.
+func (c *authServiceClient) CancelAccountDeletion(ctx context.Context, req *connect.Request[grpc.CancelAccountDeletionRequest]) (*connect.Response[grpc.CancelAccountDeletionResponse], error) {
+	return c.cancelAccountDeletion.CallUnary(ctx, req)
+}
+
 // AuthServiceHandler is an implementation of the auth.AuthService service.
 type AuthServiceHandler interface {
 	// GetRegistrationConfig は新規登録に必要な設定情報を取得します。
-	// REGISTER_KEY環境変数が設定されている場合、登録キーが必要かどうかを返します。
+	// 招待制（INVITATION_REQUIRED、またはREGISTER_KEYが設定されている）の場合、登録に招待コードが必要かどうかを返します。
 	//
 	// 例:
 	//
@@ -176,25 +890,26 @@ type AuthServiceHandler interface {
 	// エラー: なし（常に成功）
 	GetRegistrationConfig(context.Context, *connect.Request[grpc.GetRegistrationConfigRequest]) (*connect.Response[grpc.GetRegistrationConfigResponse], error)
 	// RegisterByPassword はメールアドレスとパスワードで新規ユーザーを登録します。
-	// REGISTER_KEYが設定されている場合は、正しい登録キーが必要です。
+	// 招待制の場合は、有効な招待コード（CreateInvitationで発行）が必要です。
 	//
 	// 例:
 	//
-	//	request: { email: "user@example.com", password: "pass123", name: "太郎", register_key: "secret" }
+	//	request: { email: "user@example.com", password: "pass123", name: "太郎", register_key: "abcd-efgh-ijkl-mnop" }
 	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
 	//
 	// エラー:
 	//   - AlreadyExists: メールアドレスが既に登録されている
-	//   - InvalidArgument: 登録キーが必須だが提供されていない
-	//   - PermissionDenied: 登録キーが不正
+	//   - PermissionDenied: 招待コードが必須だが提供されていない、または不正・期限切れ・使用回数の上限に達した
 	//   - InvalidArgument: バリデーションエラー（メール形式、パスワード長など）
 	RegisterByPassword(context.Context, *connect.Request[grpc.RegisterByPasswordRequest]) (*connect.Response[grpc.AuthResponse], error)
 	// LoginByPassword はメールアドレスとパスワードでログインします。
+	// 2段階認証を有効にしているユーザーはトークンを発行せず、mfa_required と mfa_token を返します（VerifyMfaで続ける）。
 	//
 	// 例:
 	//
 	//	request: { email: "user@example.com", password: "pass123" }
 	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//	response（2段階認証）: { mfa_required: true, mfa_token: "..." }
 	//
 	// エラー:
 	//   - Unauthenticated: メールアドレスまたはパスワードが不正
@@ -211,6 +926,287 @@ type AuthServiceHandler interface {
 	// エラー:
 	//   - Unauthenticated: Refresh Tokenが無効または期限切れ
 	RefreshAccessToken(context.Context, *connect.Request[grpc.RefreshAccessTokenRequest]) (*connect.Response[grpc.AuthResponse], error)
+	// ListOIDCProviders はOpenID Connectでログインできるプロバイダーの一覧を取得します。
+	// OIDC_PROVIDERS環境変数で設定したプロバイダーを設定した順に返します。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { providers: [{ id: "google", name: "Google" }] }
+	//
+	// エラー: なし（設定がない場合は空）
+	ListOIDCProviders(context.Context, *connect.Request[grpc.ListOIDCProvidersRequest]) (*connect.Response[grpc.ListOIDCProvidersResponse], error)
+	// StartOIDCLogin はOpenID Connectのログインを開始し、IdPの認可URLを返します。
+	// クライアントは認可URLにリダイレクトし、コールバックで受け取ったstateとcodeでCompleteOIDCLoginを呼び出します（10分以内）。
+	//
+	// 例:
+	//
+	//	request: { provider: "google" }
+	//	response: { authorization_url: "https://accounts.google.com/o/oauth2/v2/auth?...", state: "..." }
+	//
+	// エラー:
+	//   - NotFound: プロバイダーが設定されていない
+	//   - Unavailable: IdPのdiscoveryに失敗した
+	StartOIDCLogin(context.Context, *connect.Request[grpc.StartOIDCLoginRequest]) (*connect.Response[grpc.StartOIDCLoginResponse], error)
+	// CompleteOIDCLogin はIdPからのコールバックのstateとcodeでログインを完了し、トークンを発行します。
+	// プロバイダーのアカウントが未連携の場合、確認済みの同じメールアドレスのユーザーがいれば連携してログインし、
+	// いなければ新規ユーザーとして登録します（招待制の場合は招待コードが必要）。
+	//
+	// 例:
+	//
+	//	request: { state: "...", code: "...", register_key: "" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - InvalidArgument: stateが無効・期限切れ、またはStartOIDCLinkで開始したstate
+	//   - Unauthenticated: 認可コードの交換またはIDトークンの検証に失敗した
+	//   - FailedPrecondition: 未連携で、IdPがメールアドレスを確認していない
+	//   - PermissionDenied: アカウントが無効、または招待コードが不正
+	CompleteOIDCLogin(context.Context, *connect.Request[grpc.CompleteOIDCLoginRequest]) (*connect.Response[grpc.AuthResponse], error)
+	// StartOIDCLink はログイン中のユーザーにプロバイダーのアカウントを連携するため、IdPの認可URLを返します（要認証）。
+	// コールバックで受け取ったstateとcodeでCompleteOIDCLinkを呼び出します。
+	//
+	// エラー:
+	//   - NotFound: プロバイダーが設定されていない
+	//   - AlreadyExists: このプロバイダーは既に連携している
+	StartOIDCLink(context.Context, *connect.Request[grpc.StartOIDCLoginRequest]) (*connect.Response[grpc.StartOIDCLoginResponse], error)
+	// CompleteOIDCLink はIdPからのコールバックのstateとcodeで、ログイン中のユーザーにプロバイダーのアカウントを連携します（要認証）。
+	// メールアドレスが異なるプロバイダーのアカウントも連携できます。
+	//
+	// 例:
+	//
+	//	request: { state: "...", code: "..." }
+	//	response: { provider: { provider: "google", email: "user@gmail.com", created_at: 1700000000 } }
+	//
+	// エラー:
+	//   - InvalidArgument: stateが無効・期限切れ、または別のユーザーが開始したstate
+	//   - Unauthenticated: 認可コードの交換またはIDトークンの検証に失敗した
+	//   - AlreadyExists: プロバイダーのアカウントが別のユーザーに連携済み、またはこのプロバイダーは既に連携している
+	CompleteOIDCLink(context.Context, *connect.Request[grpc.CompleteOIDCLinkRequest]) (*connect.Response[grpc.CompleteOIDCLinkResponse], error)
+	// ListLinkedOIDCProviders はログイン中のユーザーに連携しているプロバイダーの一覧を取得します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { providers: [{ provider: "google", email: "user@gmail.com", created_at: 1700000000 }], has_password: true }
+	ListLinkedOIDCProviders(context.Context, *connect.Request[grpc.ListLinkedOIDCProvidersRequest]) (*connect.Response[grpc.ListLinkedOIDCProvidersResponse], error)
+	// UnlinkOIDCProvider はログイン中のユーザーからプロバイダーの連携を解除します（要認証）。
+	//
+	// エラー:
+	//   - NotFound: このプロバイダーは連携していない
+	//   - FailedPrecondition: パスワード・他に連携しているプロバイダー・パスキーのいずれもない（ログインできなくなる）
+	UnlinkOIDCProvider(context.Context, *connect.Request[grpc.UnlinkOIDCProviderRequest]) (*connect.Response[grpc.UnlinkOIDCProviderResponse], error)
+	// VerifyMfa はログインの2段階目として、認証アプリのコードかリカバリーコードを確認してトークンを発行します。
+	// mfa_tokenはパスワードの確認から5分間有効で、コードを間違えても有効期限までは再入力できます。
+	// ログインと同じレート制限を、クライアントとユーザーごとに適用します。
+	//
+	// 例:
+	//
+	//	request: { mfa_token: "...", code: "123456" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - Unauthenticated: mfa_tokenが無効・期限切れ、またはコードが不正
+	//   - ResourceExhausted: 試行回数の上限を超えた
+	VerifyMfa(context.Context, *connect.Request[grpc.VerifyMfaRequest]) (*connect.Response[grpc.AuthResponse], error)
+	// GetMfaStatus はログイン中のユーザーの2段階認証の状態を取得します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { enabled: true, recovery_codes_remaining: 8 }
+	GetMfaStatus(context.Context, *connect.Request[grpc.GetMfaStatusRequest]) (*connect.Response[grpc.GetMfaStatusResponse], error)
+	// StartTotpEnrollment は認証アプリの登録を開始し、秘密鍵とQRコードにするURIを返します（要認証）。
+	// ConfirmTotpEnrollmentで認証アプリのコードを確認するまで2段階認証は有効になりません。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { secret: "JBSWY3DPEHPK3PXP...", provisioning_uri: "otpauth://totp/umi.mikan:user@example.com?..." }
+	//
+	// エラー:
+	//   - AlreadyExists: 既に2段階認証を有効にしている
+	StartTotpEnrollment(context.Context, *connect.Request[grpc.StartTotpEnrollmentRequest]) (*connect.Response[grpc.StartTotpEnrollmentResponse], error)
+	// ConfirmTotpEnrollment は認証アプリのコードを確認して2段階認証を有効にし、リカバリーコードを返します（要認証）。
+	// リカバリーコードはこのレスポンスでのみ返します。
+	//
+	// 例:
+	//
+	//	request: { code: "123456" }
+	//	response: { recovery_codes: ["abcde-fghij", ...] }
+	//
+	// エラー:
+	//   - FailedPrecondition: 登録を開始していない、または既に有効
+	//   - InvalidArgument: コードが不正
+	ConfirmTotpEnrollment(context.Context, *connect.Request[grpc.ConfirmTotpEnrollmentRequest]) (*connect.Response[grpc.ConfirmTotpEnrollmentResponse], error)
+	// RegenerateRecoveryCodes は認証アプリのコードを確認してリカバリーコードを作り直します（要認証）。
+	// 以前のリカバリーコードは使えなくなります。
+	//
+	// エラー:
+	//   - FailedPrecondition: 2段階認証を有効にしていない
+	//   - InvalidArgument: コードが不正
+	RegenerateRecoveryCodes(context.Context, *connect.Request[grpc.RegenerateRecoveryCodesRequest]) (*connect.Response[grpc.ConfirmTotpEnrollmentResponse], error)
+	// DisableMfa は2段階認証を無効にします（要認証）。
+	// 現在のパスワードか認証アプリのコードのどちらかが必要です（パスワードを持たないユーザーはコードのみ）。
+	//
+	// 例:
+	//
+	//	request: { password: "pass123" } または { code: "123456" }
+	//	response: {}
+	//
+	// エラー:
+	//   - FailedPrecondition: 2段階認証を有効にしていない
+	//   - InvalidArgument: パスワードもコードもない
+	//   - PermissionDenied: パスワードまたはコードが不正
+	DisableMfa(context.Context, *connect.Request[grpc.DisableMfaRequest]) (*connect.Response[grpc.DisableMfaResponse], error)
+	// StartPasskeyRegistration はパスキーの登録を開始し、navigator.credentials.create に渡すオプションを返します（要認証）。
+	// オプションは PublicKeyCredentialCreationOptionsJSON のJSON文字列で、チャレンジは5分間有効です。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { options_json: "{\"challenge\":\"...\",\"rp\":{\"id\":\"umi.mikan.example\",...},...}" }
+	StartPasskeyRegistration(context.Context, *connect.Request[grpc.StartPasskeyRegistrationRequest]) (*connect.Response[grpc.PasskeyOptionsResponse], error)
+	// FinishPasskeyRegistration は認証器のレスポンスを検証してパスキーを登録します（要認証）。
+	//
+	// 例:
+	//
+	//	request: { credential_json: "{\"id\":\"...\",\"response\":{\"attestationObject\":\"...\",...}}", name: "iPhone" }
+	//	response: { passkey: { id: "...", name: "iPhone", created_at: 1700000000 } }
+	//
+	// エラー:
+	//   - InvalidArgument: レスポンスが不正、チャレンジが無効・期限切れ、または名前が長すぎる
+	//   - AlreadyExists: このパスキーは登録済み
+	FinishPasskeyRegistration(context.Context, *connect.Request[grpc.FinishPasskeyRegistrationRequest]) (*connect.Response[grpc.FinishPasskeyRegistrationResponse], error)
+	// StartPasskeyLogin はパスキーでのログインを開始し、navigator.credentials.get に渡すオプションを返します。
+	// 端末に保存されたパスキーから選ばせるため、メールアドレスは不要です。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { options_json: "{\"challenge\":\"...\",\"rpId\":\"umi.mikan.example\",...}" }
+	StartPasskeyLogin(context.Context, *connect.Request[grpc.StartPasskeyLoginRequest]) (*connect.Response[grpc.PasskeyOptionsResponse], error)
+	// FinishPasskeyLogin は認証器のレスポンスを検証してトークンを発行します。
+	// 生体認証・PINで確認したパスキーは2つの要素を満たすため、2段階認証を有効にしていても2段階目は求めません。
+	//
+	// 例:
+	//
+	//	request: { credential_json: "{\"id\":\"...\",\"response\":{\"signature\":\"...\",...}}" }
+	//	response: { access_token: "...", refresh_token: "...", expires_in: 900 }
+	//
+	// エラー:
+	//   - InvalidArgument: レスポンスが不正
+	//   - Unauthenticated: チャレンジが無効・期限切れ、未登録のパスキー、または署名が不正
+	//   - ResourceExhausted: 試行回数の上限を超えた
+	FinishPasskeyLogin(context.Context, *connect.Request[grpc.FinishPasskeyLoginRequest]) (*connect.Response[grpc.AuthResponse], error)
+	// StartPasskeyMfa はログインの2段階目としてパスキーでの認証を開始します。
+	// mfa_methodsに "passkey" を含むAuthResponseのmfa_tokenが必要です。
+	//
+	// エラー:
+	//   - Unauthenticated: mfa_tokenが無効・期限切れ
+	//   - FailedPrecondition: パスキーを登録していない
+	StartPasskeyMfa(context.Context, *connect.Request[grpc.StartPasskeyMfaRequest]) (*connect.Response[grpc.PasskeyOptionsResponse], error)
+	// FinishPasskeyMfa は認証器のレスポンスを検証してトークンを発行します（VerifyMfaのパスキー版）。
+	// VerifyMfaと同じレート制限を、クライアントとユーザーごとに適用します。
+	//
+	// エラー:
+	//   - InvalidArgument: レスポンスが不正
+	//   - Unauthenticated: mfa_token・チャレンジが無効・期限切れ、別のユーザーのパスキー、または署名が不正
+	//   - ResourceExhausted: 試行回数の上限を超えた
+	FinishPasskeyMfa(context.Context, *connect.Request[grpc.FinishPasskeyMfaRequest]) (*connect.Response[grpc.AuthResponse], error)
+	// ListPasskeys はログイン中のユーザーが登録したパスキーの一覧を取得します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { passkeys: [{ id: "...", name: "iPhone", transports: ["internal", "hybrid"], created_at: 1700000000, last_used_at: 1700001000 }] }
+	ListPasskeys(context.Context, *connect.Request[grpc.ListPasskeysRequest]) (*connect.Response[grpc.ListPasskeysResponse], error)
+	// DeletePasskey はログイン中のユーザーのパスキーを削除します（要認証）。
+	//
+	// エラー:
+	//   - NotFound: パスキーが存在しない、または他のユーザーのパスキー
+	//   - FailedPrecondition: パスワードも連携しているプロバイダーもなく、最後のパスキー（ログインできなくなる）
+	DeletePasskey(context.Context, *connect.Request[grpc.DeletePasskeyRequest]) (*connect.Response[grpc.DeletePasskeyResponse], error)
+	// RequestPasswordReset はパスワードの再設定のリンクをメールで送ります。
+	// メールアドレスが登録されているかどうかを知られないよう、登録されていない場合も成功を返します。
+	// リンクは1時間有効で、1回だけ使えます。
+	//
+	// 例:
+	//
+	//	request: { email: "user@example.com" }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: メールアドレスが空
+	//   - ResourceExhausted: 送信回数の上限を超えた
+	RequestPasswordReset(context.Context, *connect.Request[grpc.RequestPasswordResetRequest]) (*connect.Response[grpc.RequestPasswordResetResponse], error)
+	// ResetPassword はメールのリンクのトークンでパスワードを再設定します。
+	// 再設定するとメールアドレスも確認済みになり、それまでのセッション（リフレッシュトークン）は使えなくなります。
+	// パスワードを持たないユーザー（OpenID Connectで登録）はパスワードを設定できます。
+	//
+	// 例:
+	//
+	//	request: { token: "...", new_password: "newPassword123" }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: トークンが不正・期限切れ・使用済み、またはパスワードが短い
+	//   - PermissionDenied: アカウントが無効
+	ResetPassword(context.Context, *connect.Request[grpc.ResetPasswordRequest]) (*connect.Response[grpc.ResetPasswordResponse], error)
+	// VerifyEmail はメールのリンクのトークンでメールアドレスを確認済みにします。
+	// リンクは24時間有効で、1回だけ使えます。リンクを送った後にメールアドレスを変えた場合は使えません。
+	//
+	// 例:
+	//
+	//	request: { token: "..." }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: トークンが不正・期限切れ・使用済み
+	VerifyEmail(context.Context, *connect.Request[grpc.VerifyEmailRequest]) (*connect.Response[grpc.VerifyEmailResponse], error)
+	// RequestEmailVerification はログイン中のユーザーに確認のリンクをメールで送り直します（要認証）。
+	//
+	// エラー:
+	//   - FailedPrecondition: 既に確認済み
+	//   - ResourceExhausted: 送信回数の上限を超えた
+	RequestEmailVerification(context.Context, *connect.Request[grpc.RequestEmailVerificationRequest]) (*connect.Response[grpc.RequestEmailVerificationResponse], error)
+	// CreateInvitation は新規登録に使う招待コードを発行します（要認証）。
+	// コードは発行時のレスポンスでのみ返します（DBにはハッシュのみを保存）。
+	// 使用回数は1〜10回（管理者は1000回まで）、有効期限は1〜30日（管理者は365日まで）で、省略時は1回・7日です。
+	//
+	// 例:
+	//
+	//	request: { max_uses: 3, expires_in_days: 7, note: "家族用" }
+	//	response: { code: "abcd-efgh-ijkl-mnop", invitation: { id: "...", max_uses: 3, use_count: 0, expires_at: 1700604800, ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: 使用回数・有効期限が範囲外、またはメモが長すぎる
+	//   - PermissionDenied: アカウントが無効
+	CreateInvitation(context.Context, *connect.Request[grpc.CreateInvitationRequest]) (*connect.Response[grpc.CreateInvitationResponse], error)
+	// ListInvitations はログイン中のユーザーが発行した招待コードを、新しい順に使ったユーザーとともに返します（要認証）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { invitations: [{ id: "...", note: "家族用", max_uses: 3, use_count: 1, uses: [{ user_id: "...", user_name: "太郎", used_at: 1700001000 }], ... }] }
+	ListInvitations(context.Context, *connect.Request[grpc.ListInvitationsRequest]) (*connect.Response[grpc.ListInvitationsResponse], error)
+	// RevokeInvitation はログイン中のユーザーが発行した招待コードを取り消します（要認証）。
+	// 取り消した招待コードでは登録できなくなります（登録済みのユーザーには影響しません）。
+	//
+	// エラー:
+	//   - NotFound: 招待コードが存在しない、または他のユーザーが発行した
+	RevokeInvitation(context.Context, *connect.Request[grpc.RevokeInvitationRequest]) (*connect.Response[grpc.RevokeInvitationResponse], error)
+	// CancelAccountDeletion はメールのリンクのトークンでアカウントの削除を取り消します。
+	// リンクは削除の予定日時まで有効です。取り消した後は改めてログインしてください（APIキーは再発行が必要です）。
+	//
+	// 例:
+	//
+	//	request: { token: "..." }
+	//	response: {}
+	//
+	// エラー:
+	//   - InvalidArgument: トークンが不正・期限切れ・使用済み、または削除が既に取り消されている
+	CancelAccountDeletion(context.Context, *connect.Request[grpc.CancelAccountDeletionRequest]) (*connect.Response[grpc.CancelAccountDeletionResponse], error)
 }
 
 // NewAuthServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -244,6 +1240,180 @@ func NewAuthServiceHandler(svc AuthServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(authServiceMethods.ByName("RefreshAccessToken")),
 		connect.WithHandlerOptions(opts...),
 	)
+	authServiceListOIDCProvidersHandler := connect.NewUnaryHandler(
+		AuthServiceListOIDCProvidersProcedure,
+		svc.ListOIDCProviders,
+		connect.WithSchema(authServiceMethods.ByName("ListOIDCProviders")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceStartOIDCLoginHandler := connect.NewUnaryHandler(
+		AuthServiceStartOIDCLoginProcedure,
+		svc.StartOIDCLogin,
+		connect.WithSchema(authServiceMethods.ByName("StartOIDCLogin")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceCompleteOIDCLoginHandler := connect.NewUnaryHandler(
+		AuthServiceCompleteOIDCLoginProcedure,
+		svc.CompleteOIDCLogin,
+		connect.WithSchema(authServiceMethods.ByName("CompleteOIDCLogin")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceStartOIDCLinkHandler := connect.NewUnaryHandler(
+		AuthServiceStartOIDCLinkProcedure,
+		svc.StartOIDCLink,
+		connect.WithSchema(authServiceMethods.ByName("StartOIDCLink")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceCompleteOIDCLinkHandler := connect.NewUnaryHandler(
+		AuthServiceCompleteOIDCLinkProcedure,
+		svc.CompleteOIDCLink,
+		connect.WithSchema(authServiceMethods.ByName("CompleteOIDCLink")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceListLinkedOIDCProvidersHandler := connect.NewUnaryHandler(
+		AuthServiceListLinkedOIDCProvidersProcedure,
+		svc.ListLinkedOIDCProviders,
+		connect.WithSchema(authServiceMethods.ByName("ListLinkedOIDCProviders")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceUnlinkOIDCProviderHandler := connect.NewUnaryHandler(
+		AuthServiceUnlinkOIDCProviderProcedure,
+		svc.UnlinkOIDCProvider,
+		connect.WithSchema(authServiceMethods.ByName("UnlinkOIDCProvider")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceVerifyMfaHandler := connect.NewUnaryHandler(
+		AuthServiceVerifyMfaProcedure,
+		svc.VerifyMfa,
+		connect.WithSchema(authServiceMethods.ByName("VerifyMfa")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceGetMfaStatusHandler := connect.NewUnaryHandler(
+		AuthServiceGetMfaStatusProcedure,
+		svc.GetMfaStatus,
+		connect.WithSchema(authServiceMethods.ByName("GetMfaStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceStartTotpEnrollmentHandler := connect.NewUnaryHandler(
+		AuthServiceStartTotpEnrollmentProcedure,
+		svc.StartTotpEnrollment,
+		connect.WithSchema(authServiceMethods.ByName("StartTotpEnrollment")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceConfirmTotpEnrollmentHandler := connect.NewUnaryHandler(
+		AuthServiceConfirmTotpEnrollmentProcedure,
+		svc.ConfirmTotpEnrollment,
+		connect.WithSchema(authServiceMethods.ByName("ConfirmTotpEnrollment")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRegenerateRecoveryCodesHandler := connect.NewUnaryHandler(
+		AuthServiceRegenerateRecoveryCodesProcedure,
+		svc.RegenerateRecoveryCodes,
+		connect.WithSchema(authServiceMethods.ByName("RegenerateRecoveryCodes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceDisableMfaHandler := connect.NewUnaryHandler(
+		AuthServiceDisableMfaProcedure,
+		svc.DisableMfa,
+		connect.WithSchema(authServiceMethods.ByName("DisableMfa")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceStartPasskeyRegistrationHandler := connect.NewUnaryHandler(
+		AuthServiceStartPasskeyRegistrationProcedure,
+		svc.StartPasskeyRegistration,
+		connect.WithSchema(authServiceMethods.ByName("StartPasskeyRegistration")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceFinishPasskeyRegistrationHandler := connect.NewUnaryHandler(
+		AuthServiceFinishPasskeyRegistrationProcedure,
+		svc.FinishPasskeyRegistration,
+		connect.WithSchema(authServiceMethods.ByName("FinishPasskeyRegistration")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceStartPasskeyLoginHandler := connect.NewUnaryHandler(
+		AuthServiceStartPasskeyLoginProcedure,
+		svc.StartPasskeyLogin,
+		connect.WithSchema(authServiceMethods.ByName("StartPasskeyLogin")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceFinishPasskeyLoginHandler := connect.NewUnaryHandler(
+		AuthServiceFinishPasskeyLoginProcedure,
+		svc.FinishPasskeyLogin,
+		connect.WithSchema(authServiceMethods.ByName("FinishPasskeyLogin")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceStartPasskeyMfaHandler := connect.NewUnaryHandler(
+		AuthServiceStartPasskeyMfaProcedure,
+		svc.StartPasskeyMfa,
+		connect.WithSchema(authServiceMethods.ByName("StartPasskeyMfa")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceFinishPasskeyMfaHandler := connect.NewUnaryHandler(
+		AuthServiceFinishPasskeyMfaProcedure,
+		svc.FinishPasskeyMfa,
+		connect.WithSchema(authServiceMethods.ByName("FinishPasskeyMfa")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceListPasskeysHandler := connect.NewUnaryHandler(
+		AuthServiceListPasskeysProcedure,
+		svc.ListPasskeys,
+		connect.WithSchema(authServiceMethods.ByName("ListPasskeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceDeletePasskeyHandler := connect.NewUnaryHandler(
+		AuthServiceDeletePasskeyProcedure,
+		svc.DeletePasskey,
+		connect.WithSchema(authServiceMethods.ByName("DeletePasskey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRequestPasswordResetHandler := connect.NewUnaryHandler(
+		AuthServiceRequestPasswordResetProcedure,
+		svc.RequestPasswordReset,
+		connect.WithSchema(authServiceMethods.ByName("RequestPasswordReset")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceResetPasswordHandler := connect.NewUnaryHandler(
+		AuthServiceResetPasswordProcedure,
+		svc.ResetPassword,
+		connect.WithSchema(authServiceMethods.ByName("ResetPassword")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceVerifyEmailHandler := connect.NewUnaryHandler(
+		AuthServiceVerifyEmailProcedure,
+		svc.VerifyEmail,
+		connect.WithSchema(authServiceMethods.ByName("VerifyEmail")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRequestEmailVerificationHandler := connect.NewUnaryHandler(
+		AuthServiceRequestEmailVerificationProcedure,
+		svc.RequestEmailVerification,
+		connect.WithSchema(authServiceMethods.ByName("RequestEmailVerification")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceCreateInvitationHandler := connect.NewUnaryHandler(
+		AuthServiceCreateInvitationProcedure,
+		svc.CreateInvitation,
+		connect.WithSchema(authServiceMethods.ByName("CreateInvitation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceListInvitationsHandler := connect.NewUnaryHandler(
+		AuthServiceListInvitationsProcedure,
+		svc.ListInvitations,
+		connect.WithSchema(authServiceMethods.ByName("ListInvitations")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRevokeInvitationHandler := connect.NewUnaryHandler(
+		AuthServiceRevokeInvitationProcedure,
+		svc.RevokeInvitation,
+		connect.WithSchema(authServiceMethods.ByName("RevokeInvitation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceCancelAccountDeletionHandler := connect.NewUnaryHandler(
+		AuthServiceCancelAccountDeletionProcedure,
+		svc.CancelAccountDeletion,
+		connect.WithSchema(authServiceMethods.ByName("CancelAccountDeletion")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/auth.AuthService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AuthServiceGetRegistrationConfigProcedure:
@@ -254,6 +1424,64 @@ func NewAuthServiceHandler(svc AuthServiceHandler, opts ...connect.HandlerOption
 			authServiceLoginByPasswordHandler.ServeHTTP(w, r)
 		case AuthServiceRefreshAccessTokenProcedure:
 			authServiceRefreshAccessTokenHandler.ServeHTTP(w, r)
+		case AuthServiceListOIDCProvidersProcedure:
+			authServiceListOIDCProvidersHandler.ServeHTTP(w, r)
+		case AuthServiceStartOIDCLoginProcedure:
+			authServiceStartOIDCLoginHandler.ServeHTTP(w, r)
+		case AuthServiceCompleteOIDCLoginProcedure:
+			authServiceCompleteOIDCLoginHandler.ServeHTTP(w, r)
+		case AuthServiceStartOIDCLinkProcedure:
+			authServiceStartOIDCLinkHandler.ServeHTTP(w, r)
+		case AuthServiceCompleteOIDCLinkProcedure:
+			authServiceCompleteOIDCLinkHandler.ServeHTTP(w, r)
+		case AuthServiceListLinkedOIDCProvidersProcedure:
+			authServiceListLinkedOIDCProvidersHandler.ServeHTTP(w, r)
+		case AuthServiceUnlinkOIDCProviderProcedure:
+			authServiceUnlinkOIDCProviderHandler.ServeHTTP(w, r)
+		case AuthServiceVerifyMfaProcedure:
+			authServiceVerifyMfaHandler.ServeHTTP(w, r)
+		case AuthServiceGetMfaStatusProcedure:
+			authServiceGetMfaStatusHandler.ServeHTTP(w, r)
+		case AuthServiceStartTotpEnrollmentProcedure:
+			authServiceStartTotpEnrollmentHandler.ServeHTTP(w, r)
+		case AuthServiceConfirmTotpEnrollmentProcedure:
+			authServiceConfirmTotpEnrollmentHandler.ServeHTTP(w, r)
+		case AuthServiceRegenerateRecoveryCodesProcedure:
+			authServiceRegenerateRecoveryCodesHandler.ServeHTTP(w, r)
+		case AuthServiceDisableMfaProcedure:
+			authServiceDisableMfaHandler.ServeHTTP(w, r)
+		case AuthServiceStartPasskeyRegistrationProcedure:
+			authServiceStartPasskeyRegistrationHandler.ServeHTTP(w, r)
+		case AuthServiceFinishPasskeyRegistrationProcedure:
+			authServiceFinishPasskeyRegistrationHandler.ServeHTTP(w, r)
+		case AuthServiceStartPasskeyLoginProcedure:
+			authServiceStartPasskeyLoginHandler.ServeHTTP(w, r)
+		case AuthServiceFinishPasskeyLoginProcedure:
+			authServiceFinishPasskeyLoginHandler.ServeHTTP(w, r)
+		case AuthServiceStartPasskeyMfaProcedure:
+			authServiceStartPasskeyMfaHandler.ServeHTTP(w, r)
+		case AuthServiceFinishPasskeyMfaProcedure:
+			authServiceFinishPasskeyMfaHandler.ServeHTTP(w, r)
+		case AuthServiceListPasskeysProcedure:
+			authServiceListPasskeysHandler.ServeHTTP(w, r)
+		case AuthServiceDeletePasskeyProcedure:
+			authServiceDeletePasskeyHandler.ServeHTTP(w, r)
+		case AuthServiceRequestPasswordResetProcedure:
+			authServiceRequestPasswordResetHandler.ServeHTTP(w, r)
+		case AuthServiceResetPasswordProcedure:
+			authServiceResetPasswordHandler.ServeHTTP(w, r)
+		case AuthServiceVerifyEmailProcedure:
+			authServiceVerifyEmailHandler.ServeHTTP(w, r)
+		case AuthServiceRequestEmailVerificationProcedure:
+			authServiceRequestEmailVerificationHandler.ServeHTTP(w, r)
+		case AuthServiceCreateInvitationProcedure:
+			authServiceCreateInvitationHandler.ServeHTTP(w, r)
+		case AuthServiceListInvitationsProcedure:
+			authServiceListInvitationsHandler.ServeHTTP(w, r)
+		case AuthServiceRevokeInvitationProcedure:
+			authServiceRevokeInvitationHandler.ServeHTTP(w, r)
+		case AuthServiceCancelAccountDeletionProcedure:
+			authServiceCancelAccountDeletionHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -278,3 +1506,119 @@ func (UnimplementedAuthServiceHandler) LoginByPassword(context.Context, *connect
 func (UnimplementedAuthServiceHandler) RefreshAccessToken(context.Context, *connect.Request[grpc.RefreshAccessTokenRequest]) (*connect.Response[grpc.AuthResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.RefreshAccessToken is not implemented"))
 }
+
+func (UnimplementedAuthServiceHandler) ListOIDCProviders(context.Context, *connect.Request[grpc.ListOIDCProvidersRequest]) (*connect.Response[grpc.ListOIDCProvidersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.ListOIDCProviders is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) StartOIDCLogin(context.Context, *connect.Request[grpc.StartOIDCLoginRequest]) (*connect.Response[grpc.StartOIDCLoginResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.StartOIDCLogin is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) CompleteOIDCLogin(context.Context, *connect.Request[grpc.CompleteOIDCLoginRequest]) (*connect.Response[grpc.AuthResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.CompleteOIDCLogin is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) StartOIDCLink(context.Context, *connect.Request[grpc.StartOIDCLoginRequest]) (*connect.Response[grpc.StartOIDCLoginResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.StartOIDCLink is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) CompleteOIDCLink(context.Context, *connect.Request[grpc.CompleteOIDCLinkRequest]) (*connect.Response[grpc.CompleteOIDCLinkResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.CompleteOIDCLink is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ListLinkedOIDCProviders(context.Context, *connect.Request[grpc.ListLinkedOIDCProvidersRequest]) (*connect.Response[grpc.ListLinkedOIDCProvidersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.ListLinkedOIDCProviders is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) UnlinkOIDCProvider(context.Context, *connect.Request[grpc.UnlinkOIDCProviderRequest]) (*connect.Response[grpc.UnlinkOIDCProviderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.UnlinkOIDCProvider is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) VerifyMfa(context.Context, *connect.Request[grpc.VerifyMfaRequest]) (*connect.Response[grpc.AuthResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.VerifyMfa is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) GetMfaStatus(context.Context, *connect.Request[grpc.GetMfaStatusRequest]) (*connect.Response[grpc.GetMfaStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.GetMfaStatus is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) StartTotpEnrollment(context.Context, *connect.Request[grpc.StartTotpEnrollmentRequest]) (*connect.Response[grpc.StartTotpEnrollmentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.StartTotpEnrollment is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ConfirmTotpEnrollment(context.Context, *connect.Request[grpc.ConfirmTotpEnrollmentRequest]) (*connect.Response[grpc.ConfirmTotpEnrollmentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.ConfirmTotpEnrollment is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RegenerateRecoveryCodes(context.Context, *connect.Request[grpc.RegenerateRecoveryCodesRequest]) (*connect.Response[grpc.ConfirmTotpEnrollmentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.RegenerateRecoveryCodes is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) DisableMfa(context.Context, *connect.Request[grpc.DisableMfaRequest]) (*connect.Response[grpc.DisableMfaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.DisableMfa is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) StartPasskeyRegistration(context.Context, *connect.Request[grpc.StartPasskeyRegistrationRequest]) (*connect.Response[grpc.PasskeyOptionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.StartPasskeyRegistration is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) FinishPasskeyRegistration(context.Context, *connect.Request[grpc.FinishPasskeyRegistrationRequest]) (*connect.Response[grpc.FinishPasskeyRegistrationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.FinishPasskeyRegistration is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) StartPasskeyLogin(context.Context, *connect.Request[grpc.StartPasskeyLoginRequest]) (*connect.Response[grpc.PasskeyOptionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.StartPasskeyLogin is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) FinishPasskeyLogin(context.Context, *connect.Request[grpc.FinishPasskeyLoginRequest]) (*connect.Response[grpc.AuthResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.FinishPasskeyLogin is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) StartPasskeyMfa(context.Context, *connect.Request[grpc.StartPasskeyMfaRequest]) (*connect.Response[grpc.PasskeyOptionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.StartPasskeyMfa is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) FinishPasskeyMfa(context.Context, *connect.Request[grpc.FinishPasskeyMfaRequest]) (*connect.Response[grpc.AuthResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.FinishPasskeyMfa is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ListPasskeys(context.Context, *connect.Request[grpc.ListPasskeysRequest]) (*connect.Response[grpc.ListPasskeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.ListPasskeys is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) DeletePasskey(context.Context, *connect.Request[grpc.DeletePasskeyRequest]) (*connect.Response[grpc.DeletePasskeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.DeletePasskey is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RequestPasswordReset(context.Context, *connect.Request[grpc.RequestPasswordResetRequest]) (*connect.Response[grpc.RequestPasswordResetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.RequestPasswordReset is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ResetPassword(context.Context, *connect.Request[grpc.ResetPasswordRequest]) (*connect.Response[grpc.ResetPasswordResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.ResetPassword is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) VerifyEmail(context.Context, *connect.Request[grpc.VerifyEmailRequest]) (*connect.Response[grpc.VerifyEmailResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.VerifyEmail is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RequestEmailVerification(context.Context, *connect.Request[grpc.RequestEmailVerificationRequest]) (*connect.Response[grpc.RequestEmailVerificationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.RequestEmailVerification is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) CreateInvitation(context.Context, *connect.Request[grpc.CreateInvitationRequest]) (*connect.Response[grpc.CreateInvitationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.CreateInvitation is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ListInvitations(context.Context, *connect.Request[grpc.ListInvitationsRequest]) (*connect.Response[grpc.ListInvitationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.ListInvitations is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RevokeInvitation(context.Context, *connect.Request[grpc.RevokeInvitationRequest]) (*connect.Response[grpc.RevokeInvitationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.RevokeInvitation is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) CancelAccountDeletion(context.Context, *connect.Request[grpc.CancelAccountDeletionRequest]) (*connect.Response[grpc.CancelAccountDeletionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("auth.AuthService.CancelAccountDeletion is not implemented"))
+}
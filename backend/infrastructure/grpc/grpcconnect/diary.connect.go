@@ -39,6 +39,9 @@ const (
 	// DiaryServiceUpdateDiaryEntryProcedure is the fully-qualified name of the DiaryService's
 	// UpdateDiaryEntry RPC.
 	DiaryServiceUpdateDiaryEntryProcedure = "/diary.DiaryService/UpdateDiaryEntry"
+	// DiaryServiceMergeDiaryContentProcedure is the fully-qualified name of the DiaryService's
+	// MergeDiaryContent RPC.
+	DiaryServiceMergeDiaryContentProcedure = "/diary.DiaryService/MergeDiaryContent"
 	// DiaryServiceDeleteDiaryEntryProcedure is the fully-qualified name of the DiaryService's
 	// DeleteDiaryEntry RPC.
 	DiaryServiceDeleteDiaryEntryProcedure = "/diary.DiaryService/DeleteDiaryEntry"
@@ -84,20 +87,62 @@ const (
 	// DiaryServiceExportDiaryEntriesProcedure is the fully-qualified name of the DiaryService's
 	// ExportDiaryEntries RPC.
 	DiaryServiceExportDiaryEntriesProcedure = "/diary.DiaryService/ExportDiaryEntries"
+	// DiaryServiceStreamExportDiaryEntriesProcedure is the fully-qualified name of the DiaryService's
+	// StreamExportDiaryEntries RPC.
+	DiaryServiceStreamExportDiaryEntriesProcedure = "/diary.DiaryService/StreamExportDiaryEntries"
+	// DiaryServiceWatchTasksProcedure is the fully-qualified name of the DiaryService's WatchTasks RPC.
+	DiaryServiceWatchTasksProcedure = "/diary.DiaryService/WatchTasks"
+	// DiaryServiceUploadAttachmentProcedure is the fully-qualified name of the DiaryService's
+	// UploadAttachment RPC.
+	DiaryServiceUploadAttachmentProcedure = "/diary.DiaryService/UploadAttachment"
+	// DiaryServiceDownloadAttachmentProcedure is the fully-qualified name of the DiaryService's
+	// DownloadAttachment RPC.
+	DiaryServiceDownloadAttachmentProcedure = "/diary.DiaryService/DownloadAttachment"
+	// DiaryServiceListAttachmentsProcedure is the fully-qualified name of the DiaryService's
+	// ListAttachments RPC.
+	DiaryServiceListAttachmentsProcedure = "/diary.DiaryService/ListAttachments"
+	// DiaryServiceDeleteAttachmentProcedure is the fully-qualified name of the DiaryService's
+	// DeleteAttachment RPC.
+	DiaryServiceDeleteAttachmentProcedure = "/diary.DiaryService/DeleteAttachment"
+	// DiaryServiceGetAttachmentUsageProcedure is the fully-qualified name of the DiaryService's
+	// GetAttachmentUsage RPC.
+	DiaryServiceGetAttachmentUsageProcedure = "/diary.DiaryService/GetAttachmentUsage"
+	// DiaryServiceCreateTagProcedure is the fully-qualified name of the DiaryService's CreateTag RPC.
+	DiaryServiceCreateTagProcedure = "/diary.DiaryService/CreateTag"
+	// DiaryServiceListTagsProcedure is the fully-qualified name of the DiaryService's ListTags RPC.
+	DiaryServiceListTagsProcedure = "/diary.DiaryService/ListTags"
+	// DiaryServiceRenameTagProcedure is the fully-qualified name of the DiaryService's RenameTag RPC.
+	DiaryServiceRenameTagProcedure = "/diary.DiaryService/RenameTag"
+	// DiaryServiceMergeTagsProcedure is the fully-qualified name of the DiaryService's MergeTags RPC.
+	DiaryServiceMergeTagsProcedure = "/diary.DiaryService/MergeTags"
+	// DiaryServiceDeleteTagProcedure is the fully-qualified name of the DiaryService's DeleteTag RPC.
+	DiaryServiceDeleteTagProcedure = "/diary.DiaryService/DeleteTag"
+	// DiaryServiceSyncDiaryEntriesProcedure is the fully-qualified name of the DiaryService's
+	// SyncDiaryEntries RPC.
+	DiaryServiceSyncDiaryEntriesProcedure = "/diary.DiaryService/SyncDiaryEntries"
+	// DiaryServiceListTrashProcedure is the fully-qualified name of the DiaryService's ListTrash RPC.
+	DiaryServiceListTrashProcedure = "/diary.DiaryService/ListTrash"
+	// DiaryServiceRestoreDiaryEntryProcedure is the fully-qualified name of the DiaryService's
+	// RestoreDiaryEntry RPC.
+	DiaryServiceRestoreDiaryEntryProcedure = "/diary.DiaryService/RestoreDiaryEntry"
+	// DiaryServiceEmptyTrashProcedure is the fully-qualified name of the DiaryService's EmptyTrash RPC.
+	DiaryServiceEmptyTrashProcedure = "/diary.DiaryService/EmptyTrash"
 )
 
 // DiaryServiceClient is a client for the diary.DiaryService service.
 type DiaryServiceClient interface {
 	// CreateDiaryEntry は新しい日記エントリを作成します。
-	// 1日1エントリの制約があり、同じ日付に複数のエントリは作成できません。
+	// 通常は1日1エントリで、同じ日付に既にエントリがある場合はエラーになります。
+	// additional: true を指定した場合のみ、同じ日付に別のエントリとして追加できます（朝のメモと夜の振り返りなど）。
 	//
 	// 例:
 	//
 	//	request: { content: "今日は友人と会った", date: { year: 2025, month: 10, day: 9 } }
 	//	response: { entry: { id: "uuid", content: "...", ... } }
+	//	request: { content: "朝のメモ", date: { year: 2025, month: 10, day: 9 }, title: "朝", time: { hour: 7, minute: 30 }, additional: true }
 	//
 	// エラー:
-	//   - AlreadyExists: 指定された日付の日記が既に存在する
+	//   - AlreadyExists: 指定された日付の日記が既に存在する（additional: false の場合）
 	//   - InvalidArgument: 日付が不正
 	CreateDiaryEntry(context.Context, *connect.Request[grpc.CreateDiaryEntryRequest]) (*connect.Response[grpc.CreateDiaryEntryResponse], error)
 	// UpdateDiaryEntry は既存の日記エントリを更新します。
@@ -107,22 +152,46 @@ type DiaryServiceClient interface {
 	//	request: { id: "uuid", content: "更新された内容", date: { year: 2025, month: 10, day: 9 } }
 	//	response: { entry: { id: "uuid", content: "更新された内容", ... } }
 	//
+	// title・timeは指定した場合のみ更新します（clear_time: true で時刻を未指定に戻します）。
+	//
+	// expected_versionを指定した場合、他のクライアントが先に更新していれば上書きせずにAbortedを返します。
+	// Abortedのエラーの詳細（details）にはサーバー側の現在のDiaryEntryが含まれるため、
+	// クライアントはMergeDiaryContentで手元の内容とマージしてから再度更新できます。
+	//
 	// エラー:
+	//   - AlreadyExists: 日付の変更先に日記が既に存在する（additional: false の場合）
 	//   - NotFound: 日記エントリが見つからない
 	//   - PermissionDenied: 他のユーザーの日記にアクセスしようとした
+	//   - Aborted: expected_versionがサーバーのversionと一致しない
 	UpdateDiaryEntry(context.Context, *connect.Request[grpc.UpdateDiaryEntryRequest]) (*connect.Response[grpc.UpdateDiaryEntryResponse], error)
-	// DeleteDiaryEntry は日記エントリを削除します。
+	// MergeDiaryContent は同じ日記への2つの編集を、編集前の内容（base）をもとに行単位で3方向マージします。
+	// 片方だけが変更した行はその変更を採用し、両方が同じ箇所を別々に変更した場合は
+	// 競合として両方の内容をマーカー（<<<<<<< / ======= / >>>>>>>）で囲んで残します。
+	// 日記は保存しません。
+	//
+	// 例:
+	//
+	//	request: { base: "朝\n昼\n夜\n", ours: "朝ごはん\n昼\n夜\n", theirs: "朝\n昼\n夜ふかし\n" }
+	//	response: { merged: "朝ごはん\n昼\n夜ふかし\n", has_conflicts: false, conflict_count: 0 }
+	//
+	// エラー:
+	//   - InvalidArgument: 内容が大きすぎてマージできない
+	MergeDiaryContent(context.Context, *connect.Request[grpc.MergeDiaryContentRequest]) (*connect.Response[grpc.MergeDiaryContentResponse], error)
+	// DeleteDiaryEntry は日記エントリをゴミ箱に移動します。
+	// ゴミ箱の日記は一覧・検索などに表示されず、保持期間内であればRestoreDiaryEntryで元に戻せます。
+	// 保持期間を過ぎると添付ファイルなどとともに完全に削除されます。
 	//
 	// エラー:
 	//   - NotFound: 日記エントリが見つからない
 	//   - PermissionDenied: 他のユーザーの日記にアクセスしようとした
 	DeleteDiaryEntry(context.Context, *connect.Request[grpc.DeleteDiaryEntryRequest]) (*connect.Response[grpc.DeleteDiaryEntryResponse], error)
 	// GetDiaryEntry は指定された日付の日記エントリを取得します。
+	// entryには表示順で先頭のエントリ、entriesにはその日付の全エントリが入ります。
 	//
 	// 例:
 	//
 	//	request: { date: { year: 2025, month: 10, day: 9 } }
-	//	response: { entry: { id: "uuid", content: "...", ... } }
+	//	response: { entry: { id: "uuid", content: "...", ... }, entries: [{ id: "uuid", ... }] }
 	//
 	// エラー:
 	//   - NotFound: 指定された日付の日記が存在しない
@@ -138,6 +207,7 @@ type DiaryServiceClient interface {
 	// エラー: なし（存在する日記のみ返される）
 	GetDiaryEntries(context.Context, *connect.Request[grpc.GetDiaryEntriesRequest]) (*connect.Response[grpc.GetDiaryEntriesResponse], error)
 	// GetDiaryEntriesByMonth は指定された月の全日記エントリを取得します。
+	// 日付順に並び、同じ日付のエントリは連続して返されます（時刻未指定のエントリが先頭、以降は時刻順）。
 	//
 	// 例:
 	//
@@ -264,6 +334,147 @@ type DiaryServiceClient interface {
 	// エラー:
 	//   - InvalidArgument: 開始年月が終了年月より後の場合
 	ExportDiaryEntries(context.Context, *connect.Request[grpc.ExportDiaryEntriesRequest]) (*connect.Response[grpc.ExportDiaryEntriesResponse], error)
+	// StreamExportDiaryEntries は指定期間の日記を指定した形式のファイルに変換し、サーバーストリーミングで返します。
+	// 日記を一定件数ずつ読み込みながら送信するため、期間が長くてもサーバーのメモリ使用量は一定です。
+	// 各レスポンスはファイルのパスと内容の一部で、同じパスのchunkを受け取った順に連結するとファイルになります。
+	//
+	// 形式:
+	//   - EXPORT_FORMAT_JSONL: diaries.jsonl（1行に1件の日記。月次要約を含める場合は type で区別）
+	//   - EXPORT_FORMAT_MARKDOWN: YYYY/MM/YYYY-MM-DD.md（1日1ファイル、YAML front matter付き）、月次要約は YYYY/MM/summary.md
+	//   - EXPORT_FORMAT_CSV: diaries.csv（UTF-8 BOM付き）
+	//   - EXPORT_FORMAT_HTML: diaries.html（目次付きの1ファイル）
+	//
+	// 例:
+	//
+	//	request: { from: { year: 2024, month: 4 }, to: { year: 2024, month: 6 }, format: EXPORT_FORMAT_MARKDOWN }
+	//	response(stream): { path: "2024/04/2024-04-01.md", content_type: "text/markdown; charset=utf-8", chunk: "..." }
+	//
+	// エラー:
+	//   - InvalidArgument: 開始年月が終了年月より後の場合、未対応の形式の場合
+	StreamExportDiaryEntries(context.Context, *connect.Request[grpc.StreamExportDiaryEntriesRequest]) (*connect.ServerStreamForClient[grpc.StreamExportDiaryEntriesResponse], error)
+	// WatchTasks は呼び出しユーザーの非同期タスク（月次要約・トレンド分析・ハイライト・embedding生成）の
+	// 状態変化をサーバーストリーミングで通知します。
+	// 接続直後に現在キュー済み・処理中のタスクを送信し、以降は状態が変わるたびにイベントを送信します。
+	// クライアントが切断するまでストリームは終了しません。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response(stream): { task_type: "monthly_summary", target: "2024-05", status: TASK_STATUS_SUCCEEDED, timestamp: 1234567890 }
+	//
+	// エラー:
+	//   - Unauthenticated: 認証されていない
+	WatchTasks(context.Context, *connect.Request[grpc.WatchTasksRequest]) (*connect.ServerStreamForClient[grpc.TaskEvent], error)
+	// UploadAttachment は日記に写真・ファイルを添付します（クライアントストリーミング）。
+	// 最初のメッセージでmetadataを送り、以降のメッセージでファイルの内容をchunk（1MiB以下推奨）に分けて送ります。
+	// JPEG/PNG/GIFはサムネイルを生成し、JPEGはEXIFから撮影日時・撮影位置を抽出します。
+	//
+	// 例:
+	//
+	//	request(stream): { metadata: { diary_id: "uuid", filename: "IMG_0001.jpg", content_type: "image/jpeg" } }, { chunk: "..." }, ...
+	//	response: { attachment: { id: "uuid", size_bytes: 123456, has_thumbnail: true, taken_at: 1714516200, ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: 最初のメッセージがmetadataでない、ファイル名が不正、ファイルが空
+	//   - NotFound: 日記が存在しない（他のユーザーの日記を含む）
+	//   - ResourceExhausted: ファイルサイズの上限またはユーザーごとの容量制限を超えた
+	UploadAttachment(context.Context) *connect.ClientStreamForClient[grpc.UploadAttachmentRequest, grpc.UploadAttachmentResponse]
+	// DownloadAttachment は添付ファイルの内容を取得します（サーバーストリーミング）。
+	// 最初のメッセージで添付ファイルの情報を送り、以降のメッセージで内容をchunkに分けて送ります。
+	// thumbnail: true の場合はサムネイル（JPEG）を返します。
+	//
+	// エラー:
+	//   - NotFound: 添付ファイルが存在しない、またはサムネイルがない
+	DownloadAttachment(context.Context, *connect.Request[grpc.DownloadAttachmentRequest]) (*connect.ServerStreamForClient[grpc.DownloadAttachmentResponse], error)
+	// ListAttachments は日記の添付ファイルをアップロード順に取得します。
+	//
+	// エラー:
+	//   - NotFound: 日記が存在しない
+	ListAttachments(context.Context, *connect.Request[grpc.ListAttachmentsRequest]) (*connect.Response[grpc.ListAttachmentsResponse], error)
+	// DeleteAttachment は添付ファイルを削除します。
+	//
+	// エラー:
+	//   - NotFound: 添付ファイルが存在しない
+	DeleteAttachment(context.Context, *connect.Request[grpc.DeleteAttachmentRequest]) (*connect.Response[grpc.DeleteAttachmentResponse], error)
+	// GetAttachmentUsage は添付ファイルの使用容量と上限を取得します。
+	GetAttachmentUsage(context.Context, *connect.Request[grpc.GetAttachmentUsageRequest]) (*connect.Response[grpc.GetAttachmentUsageResponse], error)
+	// CreateTag はタグを作成します。colorを省略した場合はデフォルトの色を使います。
+	//
+	// 例:
+	//
+	//	request: { name: "旅行", color: "#4f9dde" }
+	//	response: { tag: { id: "uuid", name: "旅行", color: "#4f9dde", diary_count: 0 } }
+	//
+	// エラー:
+	//   - InvalidArgument: 名前が空または50文字を超える、色が#rrggbb形式でない
+	//   - AlreadyExists: 同じ名前のタグがある
+	CreateTag(context.Context, *connect.Request[grpc.CreateTagRequest]) (*connect.Response[grpc.CreateTagResponse], error)
+	// ListTags はタグを名前順に、付いている日記の件数とともに取得します。
+	ListTags(context.Context, *connect.Request[grpc.ListTagsRequest]) (*connect.Response[grpc.ListTagsResponse], error)
+	// RenameTag はタグの名前を変更します。colorを指定した場合は色も変更します。
+	//
+	// エラー:
+	//   - InvalidArgument: 名前・色が不正
+	//   - NotFound: タグが存在しない
+	//   - AlreadyExists: 同じ名前のタグがある
+	RenameTag(context.Context, *connect.Request[grpc.RenameTagRequest]) (*connect.Response[grpc.RenameTagResponse], error)
+	// MergeTags はsource_tag_idsのタグが付いた日記をtarget_tag_idのタグに付け替え、
+	// source_tag_idsのタグを削除します。
+	//
+	// 例:
+	//
+	//	request: { source_tag_ids: ["uuid-a", "uuid-b"], target_tag_id: "uuid-c" }
+	//	response: { tag: { id: "uuid-c", name: "旅行", diary_count: 12 } }
+	//
+	// エラー:
+	//   - InvalidArgument: source_tag_idsが空、またはtarget_tag_idを含む
+	//   - NotFound: タグが存在しない
+	MergeTags(context.Context, *connect.Request[grpc.MergeTagsRequest]) (*connect.Response[grpc.MergeTagsResponse], error)
+	// DeleteTag はタグを削除します。日記からも外れます。
+	//
+	// エラー:
+	//   - NotFound: タグが存在しない
+	DeleteTag(context.Context, *connect.Request[grpc.DeleteTagRequest]) (*connect.Response[grpc.DeleteTagResponse], error)
+	// SyncDiaryEntries はオフラインのクライアント（iOSアプリ）と日記を差分同期します。
+	// mutationsのクライアント側の変更を順に反映した後、cursor以降にサーバー側で変更・削除された日記を
+	// 変更順に返します。初回はcursorを空にすると全件を返します。
+	// 更新・削除はbase_versionがサーバーのversionと一致する場合のみ反映し、一致しない場合は上書きせずに
+	// CONFLICTとしてサーバー側の日記を返します。
+	//
+	// 例:
+	//
+	//	request: {
+	//	  cursor: "42_uuid",
+	//	  mutations: [{ client_mutation_id: "m1", type: DIARY_MUTATION_TYPE_UPDATE, id: "uuid", base_version: 3, content: "..." }]
+	//	}
+	//	response: {
+	//	  results: [{ client_mutation_id: "m1", status: DIARY_MUTATION_STATUS_CONFLICT, entry: { id: "uuid", version: 4, ... } }],
+	//	  changed_entries: [...], tombstones: [...], next_cursor: "45_uuid", has_more: false
+	//	}
+	//
+	// エラー:
+	//   - InvalidArgument: cursorが不正、mutationsが多すぎる
+	SyncDiaryEntries(context.Context, *connect.Request[grpc.SyncDiaryEntriesRequest]) (*connect.Response[grpc.SyncDiaryEntriesResponse], error)
+	// ListTrash はゴミ箱の日記とエンティティを、ゴミ箱に移動した日時の新しい順に返します。
+	// purge_atを過ぎると完全に削除されます。
+	//
+	// 例:
+	//
+	//	response: {
+	//	  diary_entries: [{ entry: { id: "uuid", ... }, deleted_at: 1700000000, purge_at: 1702592000 }],
+	//	  entities: [{ id: "uuid", name: "山田太郎", deleted_at: 1700000000, purge_at: 1702592000 }],
+	//	  retention_days: 30
+	//	}
+	ListTrash(context.Context, *connect.Request[grpc.ListTrashRequest]) (*connect.Response[grpc.ListTrashResponse], error)
+	// RestoreDiaryEntry はゴミ箱の日記を元に戻します。
+	// 差分同期では変更された日記として再び返ります。
+	//
+	// エラー:
+	//   - NotFound: ゴミ箱に日記が見つからない（完全に削除済みを含む）
+	RestoreDiaryEntry(context.Context, *connect.Request[grpc.RestoreDiaryEntryRequest]) (*connect.Response[grpc.RestoreDiaryEntryResponse], error)
+	// EmptyTrash はゴミ箱の日記とエンティティを保持期間を待たずに完全に削除します。
+	// 日記の添付ファイル・ハイライト・埋め込みベクトルも削除され、元に戻せません。
+	EmptyTrash(context.Context, *connect.Request[grpc.EmptyTrashRequest]) (*connect.Response[grpc.EmptyTrashResponse], error)
 }
 
 // NewDiaryServiceClient constructs a client for the diary.DiaryService service. By default, it uses
@@ -289,6 +500,12 @@ func NewDiaryServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(diaryServiceMethods.ByName("UpdateDiaryEntry")),
 			connect.WithClientOptions(opts...),
 		),
+		mergeDiaryContent: connect.NewClient[grpc.MergeDiaryContentRequest, grpc.MergeDiaryContentResponse](
+			httpClient,
+			baseURL+DiaryServiceMergeDiaryContentProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("MergeDiaryContent")),
+			connect.WithClientOptions(opts...),
+		),
 		deleteDiaryEntry: connect.NewClient[grpc.DeleteDiaryEntryRequest, grpc.DeleteDiaryEntryResponse](
 			httpClient,
 			baseURL+DiaryServiceDeleteDiaryEntryProcedure,
@@ -379,6 +596,102 @@ func NewDiaryServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(diaryServiceMethods.ByName("ExportDiaryEntries")),
 			connect.WithClientOptions(opts...),
 		),
+		streamExportDiaryEntries: connect.NewClient[grpc.StreamExportDiaryEntriesRequest, grpc.StreamExportDiaryEntriesResponse](
+			httpClient,
+			baseURL+DiaryServiceStreamExportDiaryEntriesProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("StreamExportDiaryEntries")),
+			connect.WithClientOptions(opts...),
+		),
+		watchTasks: connect.NewClient[grpc.WatchTasksRequest, grpc.TaskEvent](
+			httpClient,
+			baseURL+DiaryServiceWatchTasksProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("WatchTasks")),
+			connect.WithClientOptions(opts...),
+		),
+		uploadAttachment: connect.NewClient[grpc.UploadAttachmentRequest, grpc.UploadAttachmentResponse](
+			httpClient,
+			baseURL+DiaryServiceUploadAttachmentProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("UploadAttachment")),
+			connect.WithClientOptions(opts...),
+		),
+		downloadAttachment: connect.NewClient[grpc.DownloadAttachmentRequest, grpc.DownloadAttachmentResponse](
+			httpClient,
+			baseURL+DiaryServiceDownloadAttachmentProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("DownloadAttachment")),
+			connect.WithClientOptions(opts...),
+		),
+		listAttachments: connect.NewClient[grpc.ListAttachmentsRequest, grpc.ListAttachmentsResponse](
+			httpClient,
+			baseURL+DiaryServiceListAttachmentsProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("ListAttachments")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteAttachment: connect.NewClient[grpc.DeleteAttachmentRequest, grpc.DeleteAttachmentResponse](
+			httpClient,
+			baseURL+DiaryServiceDeleteAttachmentProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("DeleteAttachment")),
+			connect.WithClientOptions(opts...),
+		),
+		getAttachmentUsage: connect.NewClient[grpc.GetAttachmentUsageRequest, grpc.GetAttachmentUsageResponse](
+			httpClient,
+			baseURL+DiaryServiceGetAttachmentUsageProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("GetAttachmentUsage")),
+			connect.WithClientOptions(opts...),
+		),
+		createTag: connect.NewClient[grpc.CreateTagRequest, grpc.CreateTagResponse](
+			httpClient,
+			baseURL+DiaryServiceCreateTagProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("CreateTag")),
+			connect.WithClientOptions(opts...),
+		),
+		listTags: connect.NewClient[grpc.ListTagsRequest, grpc.ListTagsResponse](
+			httpClient,
+			baseURL+DiaryServiceListTagsProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("ListTags")),
+			connect.WithClientOptions(opts...),
+		),
+		renameTag: connect.NewClient[grpc.RenameTagRequest, grpc.RenameTagResponse](
+			httpClient,
+			baseURL+DiaryServiceRenameTagProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("RenameTag")),
+			connect.WithClientOptions(opts...),
+		),
+		mergeTags: connect.NewClient[grpc.MergeTagsRequest, grpc.MergeTagsResponse](
+			httpClient,
+			baseURL+DiaryServiceMergeTagsProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("MergeTags")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteTag: connect.NewClient[grpc.DeleteTagRequest, grpc.DeleteTagResponse](
+			httpClient,
+			baseURL+DiaryServiceDeleteTagProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("DeleteTag")),
+			connect.WithClientOptions(opts...),
+		),
+		syncDiaryEntries: connect.NewClient[grpc.SyncDiaryEntriesRequest, grpc.SyncDiaryEntriesResponse](
+			httpClient,
+			baseURL+DiaryServiceSyncDiaryEntriesProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("SyncDiaryEntries")),
+			connect.WithClientOptions(opts...),
+		),
+		listTrash: connect.NewClient[grpc.ListTrashRequest, grpc.ListTrashResponse](
+			httpClient,
+			baseURL+DiaryServiceListTrashProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("ListTrash")),
+			connect.WithClientOptions(opts...),
+		),
+		restoreDiaryEntry: connect.NewClient[grpc.RestoreDiaryEntryRequest, grpc.RestoreDiaryEntryResponse](
+			httpClient,
+			baseURL+DiaryServiceRestoreDiaryEntryProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("RestoreDiaryEntry")),
+			connect.WithClientOptions(opts...),
+		),
+		emptyTrash: connect.NewClient[grpc.EmptyTrashRequest, grpc.EmptyTrashResponse](
+			httpClient,
+			baseURL+DiaryServiceEmptyTrashProcedure,
+			connect.WithSchema(diaryServiceMethods.ByName("EmptyTrash")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -386,6 +699,7 @@ func NewDiaryServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 type diaryServiceClient struct {
 	createDiaryEntry           *connect.Client[grpc.CreateDiaryEntryRequest, grpc.CreateDiaryEntryResponse]
 	updateDiaryEntry           *connect.Client[grpc.UpdateDiaryEntryRequest, grpc.UpdateDiaryEntryResponse]
+	mergeDiaryContent          *connect.Client[grpc.MergeDiaryContentRequest, grpc.MergeDiaryContentResponse]
 	deleteDiaryEntry           *connect.Client[grpc.DeleteDiaryEntryRequest, grpc.DeleteDiaryEntryResponse]
 	getDiaryEntry              *connect.Client[grpc.GetDiaryEntryRequest, grpc.GetDiaryEntryResponse]
 	getDiaryEntries            *connect.Client[grpc.GetDiaryEntriesRequest, grpc.GetDiaryEntriesResponse]
@@ -401,6 +715,22 @@ type diaryServiceClient struct {
 	regenerateAllEmbeddings    *connect.Client[grpc.RegenerateAllEmbeddingsRequest, grpc.RegenerateAllEmbeddingsResponse]
 	getDiaryEmbeddingStatus    *connect.Client[grpc.GetDiaryEmbeddingStatusRequest, grpc.GetDiaryEmbeddingStatusResponse]
 	exportDiaryEntries         *connect.Client[grpc.ExportDiaryEntriesRequest, grpc.ExportDiaryEntriesResponse]
+	streamExportDiaryEntries   *connect.Client[grpc.StreamExportDiaryEntriesRequest, grpc.StreamExportDiaryEntriesResponse]
+	watchTasks                 *connect.Client[grpc.WatchTasksRequest, grpc.TaskEvent]
+	uploadAttachment           *connect.Client[grpc.UploadAttachmentRequest, grpc.UploadAttachmentResponse]
+	downloadAttachment         *connect.Client[grpc.DownloadAttachmentRequest, grpc.DownloadAttachmentResponse]
+	listAttachments            *connect.Client[grpc.ListAttachmentsRequest, grpc.ListAttachmentsResponse]
+	deleteAttachment           *connect.Client[grpc.DeleteAttachmentRequest, grpc.DeleteAttachmentResponse]
+	getAttachmentUsage         *connect.Client[grpc.GetAttachmentUsageRequest, grpc.GetAttachmentUsageResponse]
+	createTag                  *connect.Client[grpc.CreateTagRequest, grpc.CreateTagResponse]
+	listTags                   *connect.Client[grpc.ListTagsRequest, grpc.ListTagsResponse]
+	renameTag                  *connect.Client[grpc.RenameTagRequest, grpc.RenameTagResponse]
+	mergeTags                  *connect.Client[grpc.MergeTagsRequest, grpc.MergeTagsResponse]
+	deleteTag                  *connect.Client[grpc.DeleteTagRequest, grpc.DeleteTagResponse]
+	syncDiaryEntries           *connect.Client[grpc.SyncDiaryEntriesRequest, grpc.SyncDiaryEntriesResponse]
+	listTrash                  *connect.Client[grpc.ListTrashRequest, grpc.ListTrashResponse]
+	restoreDiaryEntry          *connect.Client[grpc.RestoreDiaryEntryRequest, grpc.RestoreDiaryEntryResponse]
+	emptyTrash                 *connect.Client[grpc.EmptyTrashRequest, grpc.EmptyTrashResponse]
 }
 
 // CreateDiaryEntry calls diary.DiaryService.CreateDiaryEntry.
@@ -413,6 +743,11 @@ func (c *diaryServiceClient) UpdateDiaryEntry(ctx context.Context, req *connect.
 	return c.updateDiaryEntry.CallUnary(ctx, req)
 }
 
+// MergeDiaryContent calls diary.DiaryService.MergeDiaryContent.
+func (c *diaryServiceClient) MergeDiaryContent(ctx context.Context, req *connect.Request[grpc.MergeDiaryContentRequest]) (*connect.Response[grpc.MergeDiaryContentResponse], error) {
+	return c.mergeDiaryContent.CallUnary(ctx, req)
+}
+
 // DeleteDiaryEntry calls diary.DiaryService.DeleteDiaryEntry.
 func (c *diaryServiceClient) DeleteDiaryEntry(ctx context.Context, req *connect.Request[grpc.DeleteDiaryEntryRequest]) (*connect.Response[grpc.DeleteDiaryEntryResponse], error) {
 	return c.deleteDiaryEntry.CallUnary(ctx, req)
@@ -488,18 +823,100 @@ func (c *diaryServiceClient) ExportDiaryEntries(ctx context.Context, req *connec
 	return c.exportDiaryEntries.CallUnary(ctx, req)
 }
 
+// StreamExportDiaryEntries calls diary.DiaryService.StreamExportDiaryEntries.
+func (c *diaryServiceClient) StreamExportDiaryEntries(ctx context.Context, req *connect.Request[grpc.StreamExportDiaryEntriesRequest]) (*connect.ServerStreamForClient[grpc.StreamExportDiaryEntriesResponse], error) {
+	return c.streamExportDiaryEntries.CallServerStream(ctx, req)
+}
+
+// WatchTasks calls diary.DiaryService.WatchTasks.
+func (c *diaryServiceClient) WatchTasks(ctx context.Context, req *connect.Request[grpc.WatchTasksRequest]) (*connect.ServerStreamForClient[grpc.TaskEvent], error) {
+	return c.watchTasks.CallServerStream(ctx, req)
+}
+
+// UploadAttachment calls diary.DiaryService.UploadAttachment.
+func (c *diaryServiceClient) UploadAttachment(ctx context.Context) *connect.ClientStreamForClient[grpc.UploadAttachmentRequest, grpc.UploadAttachmentResponse] {
+	return c.uploadAttachment.CallClientStream(ctx)
+}
+
+// DownloadAttachment calls diary.DiaryService.DownloadAttachment.
+func (c *diaryServiceClient) DownloadAttachment(ctx context.Context, req *connect.Request[grpc.DownloadAttachmentRequest]) (*connect.ServerStreamForClient[grpc.DownloadAttachmentResponse], error) {
+	return c.downloadAttachment.CallServerStream(ctx, req)
+}
+
+// ListAttachments calls diary.DiaryService.ListAttachments.
+func (c *diaryServiceClient) ListAttachments(ctx context.Context, req *connect.Request[grpc.ListAttachmentsRequest]) (*connect.Response[grpc.ListAttachmentsResponse], error) {
+	return c.listAttachments.CallUnary(ctx, req)
+}
+
+// DeleteAttachment calls diary.DiaryService.DeleteAttachment.
+func (c *diaryServiceClient) DeleteAttachment(ctx context.Context, req *connect.Request[grpc.DeleteAttachmentRequest]) (*connect.Response[grpc.DeleteAttachmentResponse], error) {
+	return c.deleteAttachment.CallUnary(ctx, req)
+}
+
+// GetAttachmentUsage calls diary.DiaryService.GetAttachmentUsage.
+func (c *diaryServiceClient) GetAttachmentUsage(ctx context.Context, req *connect.Request[grpc.GetAttachmentUsageRequest]) (*connect.Response[grpc.GetAttachmentUsageResponse], error) {
+	return c.getAttachmentUsage.CallUnary(ctx, req)
+}
+
+// CreateTag calls diary.DiaryService.CreateTag.
+func (c *diaryServiceClient) CreateTag(ctx context.Context, req *connect.Request[grpc.CreateTagRequest]) (*connect.Response[grpc.CreateTagResponse], error) {
+	return c.createTag.CallUnary(ctx, req)
+}
+
+// ListTags calls diary.DiaryService.ListTags.
+func (c *diaryServiceClient) ListTags(ctx context.Context, req *connect.Request[grpc.ListTagsRequest]) (*connect.Response[grpc.ListTagsResponse], error) {
+	return c.listTags.CallUnary(ctx, req)
+}
+
+// RenameTag calls diary.DiaryService.RenameTag.
+func (c *diaryServiceClient) RenameTag(ctx context.Context, req *connect.Request[grpc.RenameTagRequest]) (*connect.Response[grpc.RenameTagResponse], error) {
+	return c.renameTag.CallUnary(ctx, req)
+}
+
+// MergeTags calls diary.DiaryService.MergeTags.
+func (c *diaryServiceClient) MergeTags(ctx context.Context, req *connect.Request[grpc.MergeTagsRequest]) (*connect.Response[grpc.MergeTagsResponse], error) {
+	return c.mergeTags.CallUnary(ctx, req)
+}
+
+// DeleteTag calls diary.DiaryService.DeleteTag.
+func (c *diaryServiceClient) DeleteTag(ctx context.Context, req *connect.Request[grpc.DeleteTagRequest]) (*connect.Response[grpc.DeleteTagResponse], error) {
+	return c.deleteTag.CallUnary(ctx, req)
+}
+
+// SyncDiaryEntries calls diary.DiaryService.SyncDiaryEntries.
+func (c *diaryServiceClient) SyncDiaryEntries(ctx context.Context, req *connect.Request[grpc.SyncDiaryEntriesRequest]) (*connect.Response[grpc.SyncDiaryEntriesResponse], error) {
+	return c.syncDiaryEntries.CallUnary(ctx, req)
+}
+
+// ListTrash calls diary.DiaryService.ListTrash.
+func (c *diaryServiceClient) ListTrash(ctx context.Context, req *connect.Request[grpc.ListTrashRequest]) (*connect.Response[grpc.ListTrashResponse], error) {
+	return c.listTrash.CallUnary(ctx, req)
+}
+
+// RestoreDiaryEntry calls diary.DiaryService.RestoreDiaryEntry.
+func (c *diaryServiceClient) RestoreDiaryEntry(ctx context.Context, req *connect.Request[grpc.RestoreDiaryEntryRequest]) (*connect.Response[grpc.RestoreDiaryEntryResponse], error) {
+	return c.restoreDiaryEntry.CallUnary(ctx, req)
+}
+
+// EmptyTrash calls diary.DiaryService.EmptyTrash.
+func (c *diaryServiceClient) EmptyTrash(ctx context.Context, req *connect.Request[grpc.EmptyTrashRequest]) (*connect.Response[grpc.EmptyTrashResponse], error) {
+	return c.emptyTrash.CallUnary(ctx, req)
+}
+
 // DiaryServiceHandler is an implementation of the diary.DiaryService service.
 type DiaryServiceHandler interface {
 	// CreateDiaryEntry は新しい日記エントリを作成します。
-	// 1日1エントリの制約があり、同じ日付に複数のエントリは作成できません。
+	// 通常は1日1エントリで、同じ日付に既にエントリがある場合はエラーになります。
+	// additional: true を指定した場合のみ、同じ日付に別のエントリとして追加できます（朝のメモと夜の振り返りなど）。
 	//
 	// 例:
 	//
 	//	request: { content: "今日は友人と会った", date: { year: 2025, month: 10, day: 9 } }
 	//	response: { entry: { id: "uuid", content: "...", ... } }
+	//	request: { content: "朝のメモ", date: { year: 2025, month: 10, day: 9 }, title: "朝", time: { hour: 7, minute: 30 }, additional: true }
 	//
 	// エラー:
-	//   - AlreadyExists: 指定された日付の日記が既に存在する
+	//   - AlreadyExists: 指定された日付の日記が既に存在する（additional: false の場合）
 	//   - InvalidArgument: 日付が不正
 	CreateDiaryEntry(context.Context, *connect.Request[grpc.CreateDiaryEntryRequest]) (*connect.Response[grpc.CreateDiaryEntryResponse], error)
 	// UpdateDiaryEntry は既存の日記エントリを更新します。
@@ -509,22 +926,46 @@ type DiaryServiceHandler interface {
 	//	request: { id: "uuid", content: "更新された内容", date: { year: 2025, month: 10, day: 9 } }
 	//	response: { entry: { id: "uuid", content: "更新された内容", ... } }
 	//
+	// title・timeは指定した場合のみ更新します（clear_time: true で時刻を未指定に戻します）。
+	//
+	// expected_versionを指定した場合、他のクライアントが先に更新していれば上書きせずにAbortedを返します。
+	// Abortedのエラーの詳細（details）にはサーバー側の現在のDiaryEntryが含まれるため、
+	// クライアントはMergeDiaryContentで手元の内容とマージしてから再度更新できます。
+	//
 	// エラー:
+	//   - AlreadyExists: 日付の変更先に日記が既に存在する（additional: false の場合）
 	//   - NotFound: 日記エントリが見つからない
 	//   - PermissionDenied: 他のユーザーの日記にアクセスしようとした
+	//   - Aborted: expected_versionがサーバーのversionと一致しない
 	UpdateDiaryEntry(context.Context, *connect.Request[grpc.UpdateDiaryEntryRequest]) (*connect.Response[grpc.UpdateDiaryEntryResponse], error)
-	// DeleteDiaryEntry は日記エントリを削除します。
+	// MergeDiaryContent は同じ日記への2つの編集を、編集前の内容（base）をもとに行単位で3方向マージします。
+	// 片方だけが変更した行はその変更を採用し、両方が同じ箇所を別々に変更した場合は
+	// 競合として両方の内容をマーカー（<<<<<<< / ======= / >>>>>>>）で囲んで残します。
+	// 日記は保存しません。
+	//
+	// 例:
+	//
+	//	request: { base: "朝\n昼\n夜\n", ours: "朝ごはん\n昼\n夜\n", theirs: "朝\n昼\n夜ふかし\n" }
+	//	response: { merged: "朝ごはん\n昼\n夜ふかし\n", has_conflicts: false, conflict_count: 0 }
+	//
+	// エラー:
+	//   - InvalidArgument: 内容が大きすぎてマージできない
+	MergeDiaryContent(context.Context, *connect.Request[grpc.MergeDiaryContentRequest]) (*connect.Response[grpc.MergeDiaryContentResponse], error)
+	// DeleteDiaryEntry は日記エントリをゴミ箱に移動します。
+	// ゴミ箱の日記は一覧・検索などに表示されず、保持期間内であればRestoreDiaryEntryで元に戻せます。
+	// 保持期間を過ぎると添付ファイルなどとともに完全に削除されます。
 	//
 	// エラー:
 	//   - NotFound: 日記エントリが見つからない
 	//   - PermissionDenied: 他のユーザーの日記にアクセスしようとした
 	DeleteDiaryEntry(context.Context, *connect.Request[grpc.DeleteDiaryEntryRequest]) (*connect.Response[grpc.DeleteDiaryEntryResponse], error)
 	// GetDiaryEntry は指定された日付の日記エントリを取得します。
+	// entryには表示順で先頭のエントリ、entriesにはその日付の全エントリが入ります。
 	//
 	// 例:
 	//
 	//	request: { date: { year: 2025, month: 10, day: 9 } }
-	//	response: { entry: { id: "uuid", content: "...", ... } }
+	//	response: { entry: { id: "uuid", content: "...", ... }, entries: [{ id: "uuid", ... }] }
 	//
 	// エラー:
 	//   - NotFound: 指定された日付の日記が存在しない
@@ -540,6 +981,7 @@ type DiaryServiceHandler interface {
 	// エラー: なし（存在する日記のみ返される）
 	GetDiaryEntries(context.Context, *connect.Request[grpc.GetDiaryEntriesRequest]) (*connect.Response[grpc.GetDiaryEntriesResponse], error)
 	// GetDiaryEntriesByMonth は指定された月の全日記エントリを取得します。
+	// 日付順に並び、同じ日付のエントリは連続して返されます（時刻未指定のエントリが先頭、以降は時刻順）。
 	//
 	// 例:
 	//
@@ -666,6 +1108,147 @@ type DiaryServiceHandler interface {
 	// エラー:
 	//   - InvalidArgument: 開始年月が終了年月より後の場合
 	ExportDiaryEntries(context.Context, *connect.Request[grpc.ExportDiaryEntriesRequest]) (*connect.Response[grpc.ExportDiaryEntriesResponse], error)
+	// StreamExportDiaryEntries は指定期間の日記を指定した形式のファイルに変換し、サーバーストリーミングで返します。
+	// 日記を一定件数ずつ読み込みながら送信するため、期間が長くてもサーバーのメモリ使用量は一定です。
+	// 各レスポンスはファイルのパスと内容の一部で、同じパスのchunkを受け取った順に連結するとファイルになります。
+	//
+	// 形式:
+	//   - EXPORT_FORMAT_JSONL: diaries.jsonl（1行に1件の日記。月次要約を含める場合は type で区別）
+	//   - EXPORT_FORMAT_MARKDOWN: YYYY/MM/YYYY-MM-DD.md（1日1ファイル、YAML front matter付き）、月次要約は YYYY/MM/summary.md
+	//   - EXPORT_FORMAT_CSV: diaries.csv（UTF-8 BOM付き）
+	//   - EXPORT_FORMAT_HTML: diaries.html（目次付きの1ファイル）
+	//
+	// 例:
+	//
+	//	request: { from: { year: 2024, month: 4 }, to: { year: 2024, month: 6 }, format: EXPORT_FORMAT_MARKDOWN }
+	//	response(stream): { path: "2024/04/2024-04-01.md", content_type: "text/markdown; charset=utf-8", chunk: "..." }
+	//
+	// エラー:
+	//   - InvalidArgument: 開始年月が終了年月より後の場合、未対応の形式の場合
+	StreamExportDiaryEntries(context.Context, *connect.Request[grpc.StreamExportDiaryEntriesRequest], *connect.ServerStream[grpc.StreamExportDiaryEntriesResponse]) error
+	// WatchTasks は呼び出しユーザーの非同期タスク（月次要約・トレンド分析・ハイライト・embedding生成）の
+	// 状態変化をサーバーストリーミングで通知します。
+	// 接続直後に現在キュー済み・処理中のタスクを送信し、以降は状態が変わるたびにイベントを送信します。
+	// クライアントが切断するまでストリームは終了しません。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response(stream): { task_type: "monthly_summary", target: "2024-05", status: TASK_STATUS_SUCCEEDED, timestamp: 1234567890 }
+	//
+	// エラー:
+	//   - Unauthenticated: 認証されていない
+	WatchTasks(context.Context, *connect.Request[grpc.WatchTasksRequest], *connect.ServerStream[grpc.TaskEvent]) error
+	// UploadAttachment は日記に写真・ファイルを添付します（クライアントストリーミング）。
+	// 最初のメッセージでmetadataを送り、以降のメッセージでファイルの内容をchunk（1MiB以下推奨）に分けて送ります。
+	// JPEG/PNG/GIFはサムネイルを生成し、JPEGはEXIFから撮影日時・撮影位置を抽出します。
+	//
+	// 例:
+	//
+	//	request(stream): { metadata: { diary_id: "uuid", filename: "IMG_0001.jpg", content_type: "image/jpeg" } }, { chunk: "..." }, ...
+	//	response: { attachment: { id: "uuid", size_bytes: 123456, has_thumbnail: true, taken_at: 1714516200, ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: 最初のメッセージがmetadataでない、ファイル名が不正、ファイルが空
+	//   - NotFound: 日記が存在しない（他のユーザーの日記を含む）
+	//   - ResourceExhausted: ファイルサイズの上限またはユーザーごとの容量制限を超えた
+	UploadAttachment(context.Context, *connect.ClientStream[grpc.UploadAttachmentRequest]) (*connect.Response[grpc.UploadAttachmentResponse], error)
+	// DownloadAttachment は添付ファイルの内容を取得します（サーバーストリーミング）。
+	// 最初のメッセージで添付ファイルの情報を送り、以降のメッセージで内容をchunkに分けて送ります。
+	// thumbnail: true の場合はサムネイル（JPEG）を返します。
+	//
+	// エラー:
+	//   - NotFound: 添付ファイルが存在しない、またはサムネイルがない
+	DownloadAttachment(context.Context, *connect.Request[grpc.DownloadAttachmentRequest], *connect.ServerStream[grpc.DownloadAttachmentResponse]) error
+	// ListAttachments は日記の添付ファイルをアップロード順に取得します。
+	//
+	// エラー:
+	//   - NotFound: 日記が存在しない
+	ListAttachments(context.Context, *connect.Request[grpc.ListAttachmentsRequest]) (*connect.Response[grpc.ListAttachmentsResponse], error)
+	// DeleteAttachment は添付ファイルを削除します。
+	//
+	// エラー:
+	//   - NotFound: 添付ファイルが存在しない
+	DeleteAttachment(context.Context, *connect.Request[grpc.DeleteAttachmentRequest]) (*connect.Response[grpc.DeleteAttachmentResponse], error)
+	// GetAttachmentUsage は添付ファイルの使用容量と上限を取得します。
+	GetAttachmentUsage(context.Context, *connect.Request[grpc.GetAttachmentUsageRequest]) (*connect.Response[grpc.GetAttachmentUsageResponse], error)
+	// CreateTag はタグを作成します。colorを省略した場合はデフォルトの色を使います。
+	//
+	// 例:
+	//
+	//	request: { name: "旅行", color: "#4f9dde" }
+	//	response: { tag: { id: "uuid", name: "旅行", color: "#4f9dde", diary_count: 0 } }
+	//
+	// エラー:
+	//   - InvalidArgument: 名前が空または50文字を超える、色が#rrggbb形式でない
+	//   - AlreadyExists: 同じ名前のタグがある
+	CreateTag(context.Context, *connect.Request[grpc.CreateTagRequest]) (*connect.Response[grpc.CreateTagResponse], error)
+	// ListTags はタグを名前順に、付いている日記の件数とともに取得します。
+	ListTags(context.Context, *connect.Request[grpc.ListTagsRequest]) (*connect.Response[grpc.ListTagsResponse], error)
+	// RenameTag はタグの名前を変更します。colorを指定した場合は色も変更します。
+	//
+	// エラー:
+	//   - InvalidArgument: 名前・色が不正
+	//   - NotFound: タグが存在しない
+	//   - AlreadyExists: 同じ名前のタグがある
+	RenameTag(context.Context, *connect.Request[grpc.RenameTagRequest]) (*connect.Response[grpc.RenameTagResponse], error)
+	// MergeTags はsource_tag_idsのタグが付いた日記をtarget_tag_idのタグに付け替え、
+	// source_tag_idsのタグを削除します。
+	//
+	// 例:
+	//
+	//	request: { source_tag_ids: ["uuid-a", "uuid-b"], target_tag_id: "uuid-c" }
+	//	response: { tag: { id: "uuid-c", name: "旅行", diary_count: 12 } }
+	//
+	// エラー:
+	//   - InvalidArgument: source_tag_idsが空、またはtarget_tag_idを含む
+	//   - NotFound: タグが存在しない
+	MergeTags(context.Context, *connect.Request[grpc.MergeTagsRequest]) (*connect.Response[grpc.MergeTagsResponse], error)
+	// DeleteTag はタグを削除します。日記からも外れます。
+	//
+	// エラー:
+	//   - NotFound: タグが存在しない
+	DeleteTag(context.Context, *connect.Request[grpc.DeleteTagRequest]) (*connect.Response[grpc.DeleteTagResponse], error)
+	// SyncDiaryEntries はオフラインのクライアント（iOSアプリ）と日記を差分同期します。
+	// mutationsのクライアント側の変更を順に反映した後、cursor以降にサーバー側で変更・削除された日記を
+	// 変更順に返します。初回はcursorを空にすると全件を返します。
+	// 更新・削除はbase_versionがサーバーのversionと一致する場合のみ反映し、一致しない場合は上書きせずに
+	// CONFLICTとしてサーバー側の日記を返します。
+	//
+	// 例:
+	//
+	//	request: {
+	//	  cursor: "42_uuid",
+	//	  mutations: [{ client_mutation_id: "m1", type: DIARY_MUTATION_TYPE_UPDATE, id: "uuid", base_version: 3, content: "..." }]
+	//	}
+	//	response: {
+	//	  results: [{ client_mutation_id: "m1", status: DIARY_MUTATION_STATUS_CONFLICT, entry: { id: "uuid", version: 4, ... } }],
+	//	  changed_entries: [...], tombstones: [...], next_cursor: "45_uuid", has_more: false
+	//	}
+	//
+	// エラー:
+	//   - InvalidArgument: cursorが不正、mutationsが多すぎる
+	SyncDiaryEntries(context.Context, *connect.Request[grpc.SyncDiaryEntriesRequest]) (*connect.Response[grpc.SyncDiaryEntriesResponse], error)
+	// ListTrash はゴミ箱の日記とエンティティを、ゴミ箱に移動した日時の新しい順に返します。
+	// purge_atを過ぎると完全に削除されます。
+	//
+	// 例:
+	//
+	//	response: {
+	//	  diary_entries: [{ entry: { id: "uuid", ... }, deleted_at: 1700000000, purge_at: 1702592000 }],
+	//	  entities: [{ id: "uuid", name: "山田太郎", deleted_at: 1700000000, purge_at: 1702592000 }],
+	//	  retention_days: 30
+	//	}
+	ListTrash(context.Context, *connect.Request[grpc.ListTrashRequest]) (*connect.Response[grpc.ListTrashResponse], error)
+	// RestoreDiaryEntry はゴミ箱の日記を元に戻します。
+	// 差分同期では変更された日記として再び返ります。
+	//
+	// エラー:
+	//   - NotFound: ゴミ箱に日記が見つからない（完全に削除済みを含む）
+	RestoreDiaryEntry(context.Context, *connect.Request[grpc.RestoreDiaryEntryRequest]) (*connect.Response[grpc.RestoreDiaryEntryResponse], error)
+	// EmptyTrash はゴミ箱の日記とエンティティを保持期間を待たずに完全に削除します。
+	// 日記の添付ファイル・ハイライト・埋め込みベクトルも削除され、元に戻せません。
+	EmptyTrash(context.Context, *connect.Request[grpc.EmptyTrashRequest]) (*connect.Response[grpc.EmptyTrashResponse], error)
 }
 
 // NewDiaryServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -687,6 +1270,12 @@ func NewDiaryServiceHandler(svc DiaryServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(diaryServiceMethods.ByName("UpdateDiaryEntry")),
 		connect.WithHandlerOptions(opts...),
 	)
+	diaryServiceMergeDiaryContentHandler := connect.NewUnaryHandler(
+		DiaryServiceMergeDiaryContentProcedure,
+		svc.MergeDiaryContent,
+		connect.WithSchema(diaryServiceMethods.ByName("MergeDiaryContent")),
+		connect.WithHandlerOptions(opts...),
+	)
 	diaryServiceDeleteDiaryEntryHandler := connect.NewUnaryHandler(
 		DiaryServiceDeleteDiaryEntryProcedure,
 		svc.DeleteDiaryEntry,
@@ -777,12 +1366,110 @@ func NewDiaryServiceHandler(svc DiaryServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(diaryServiceMethods.ByName("ExportDiaryEntries")),
 		connect.WithHandlerOptions(opts...),
 	)
+	diaryServiceStreamExportDiaryEntriesHandler := connect.NewServerStreamHandler(
+		DiaryServiceStreamExportDiaryEntriesProcedure,
+		svc.StreamExportDiaryEntries,
+		connect.WithSchema(diaryServiceMethods.ByName("StreamExportDiaryEntries")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceWatchTasksHandler := connect.NewServerStreamHandler(
+		DiaryServiceWatchTasksProcedure,
+		svc.WatchTasks,
+		connect.WithSchema(diaryServiceMethods.ByName("WatchTasks")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceUploadAttachmentHandler := connect.NewClientStreamHandler(
+		DiaryServiceUploadAttachmentProcedure,
+		svc.UploadAttachment,
+		connect.WithSchema(diaryServiceMethods.ByName("UploadAttachment")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceDownloadAttachmentHandler := connect.NewServerStreamHandler(
+		DiaryServiceDownloadAttachmentProcedure,
+		svc.DownloadAttachment,
+		connect.WithSchema(diaryServiceMethods.ByName("DownloadAttachment")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceListAttachmentsHandler := connect.NewUnaryHandler(
+		DiaryServiceListAttachmentsProcedure,
+		svc.ListAttachments,
+		connect.WithSchema(diaryServiceMethods.ByName("ListAttachments")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceDeleteAttachmentHandler := connect.NewUnaryHandler(
+		DiaryServiceDeleteAttachmentProcedure,
+		svc.DeleteAttachment,
+		connect.WithSchema(diaryServiceMethods.ByName("DeleteAttachment")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceGetAttachmentUsageHandler := connect.NewUnaryHandler(
+		DiaryServiceGetAttachmentUsageProcedure,
+		svc.GetAttachmentUsage,
+		connect.WithSchema(diaryServiceMethods.ByName("GetAttachmentUsage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceCreateTagHandler := connect.NewUnaryHandler(
+		DiaryServiceCreateTagProcedure,
+		svc.CreateTag,
+		connect.WithSchema(diaryServiceMethods.ByName("CreateTag")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceListTagsHandler := connect.NewUnaryHandler(
+		DiaryServiceListTagsProcedure,
+		svc.ListTags,
+		connect.WithSchema(diaryServiceMethods.ByName("ListTags")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceRenameTagHandler := connect.NewUnaryHandler(
+		DiaryServiceRenameTagProcedure,
+		svc.RenameTag,
+		connect.WithSchema(diaryServiceMethods.ByName("RenameTag")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceMergeTagsHandler := connect.NewUnaryHandler(
+		DiaryServiceMergeTagsProcedure,
+		svc.MergeTags,
+		connect.WithSchema(diaryServiceMethods.ByName("MergeTags")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceDeleteTagHandler := connect.NewUnaryHandler(
+		DiaryServiceDeleteTagProcedure,
+		svc.DeleteTag,
+		connect.WithSchema(diaryServiceMethods.ByName("DeleteTag")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceSyncDiaryEntriesHandler := connect.NewUnaryHandler(
+		DiaryServiceSyncDiaryEntriesProcedure,
+		svc.SyncDiaryEntries,
+		connect.WithSchema(diaryServiceMethods.ByName("SyncDiaryEntries")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceListTrashHandler := connect.NewUnaryHandler(
+		DiaryServiceListTrashProcedure,
+		svc.ListTrash,
+		connect.WithSchema(diaryServiceMethods.ByName("ListTrash")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceRestoreDiaryEntryHandler := connect.NewUnaryHandler(
+		DiaryServiceRestoreDiaryEntryProcedure,
+		svc.RestoreDiaryEntry,
+		connect.WithSchema(diaryServiceMethods.ByName("RestoreDiaryEntry")),
+		connect.WithHandlerOptions(opts...),
+	)
+	diaryServiceEmptyTrashHandler := connect.NewUnaryHandler(
+		DiaryServiceEmptyTrashProcedure,
+		svc.EmptyTrash,
+		connect.WithSchema(diaryServiceMethods.ByName("EmptyTrash")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/diary.DiaryService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case DiaryServiceCreateDiaryEntryProcedure:
 			diaryServiceCreateDiaryEntryHandler.ServeHTTP(w, r)
 		case DiaryServiceUpdateDiaryEntryProcedure:
 			diaryServiceUpdateDiaryEntryHandler.ServeHTTP(w, r)
+		case DiaryServiceMergeDiaryContentProcedure:
+			diaryServiceMergeDiaryContentHandler.ServeHTTP(w, r)
 		case DiaryServiceDeleteDiaryEntryProcedure:
 			diaryServiceDeleteDiaryEntryHandler.ServeHTTP(w, r)
 		case DiaryServiceGetDiaryEntryProcedure:
@@ -813,6 +1500,38 @@ func NewDiaryServiceHandler(svc DiaryServiceHandler, opts ...connect.HandlerOpti
 			diaryServiceGetDiaryEmbeddingStatusHandler.ServeHTTP(w, r)
 		case DiaryServiceExportDiaryEntriesProcedure:
 			diaryServiceExportDiaryEntriesHandler.ServeHTTP(w, r)
+		case DiaryServiceStreamExportDiaryEntriesProcedure:
+			diaryServiceStreamExportDiaryEntriesHandler.ServeHTTP(w, r)
+		case DiaryServiceWatchTasksProcedure:
+			diaryServiceWatchTasksHandler.ServeHTTP(w, r)
+		case DiaryServiceUploadAttachmentProcedure:
+			diaryServiceUploadAttachmentHandler.ServeHTTP(w, r)
+		case DiaryServiceDownloadAttachmentProcedure:
+			diaryServiceDownloadAttachmentHandler.ServeHTTP(w, r)
+		case DiaryServiceListAttachmentsProcedure:
+			diaryServiceListAttachmentsHandler.ServeHTTP(w, r)
+		case DiaryServiceDeleteAttachmentProcedure:
+			diaryServiceDeleteAttachmentHandler.ServeHTTP(w, r)
+		case DiaryServiceGetAttachmentUsageProcedure:
+			diaryServiceGetAttachmentUsageHandler.ServeHTTP(w, r)
+		case DiaryServiceCreateTagProcedure:
+			diaryServiceCreateTagHandler.ServeHTTP(w, r)
+		case DiaryServiceListTagsProcedure:
+			diaryServiceListTagsHandler.ServeHTTP(w, r)
+		case DiaryServiceRenameTagProcedure:
+			diaryServiceRenameTagHandler.ServeHTTP(w, r)
+		case DiaryServiceMergeTagsProcedure:
+			diaryServiceMergeTagsHandler.ServeHTTP(w, r)
+		case DiaryServiceDeleteTagProcedure:
+			diaryServiceDeleteTagHandler.ServeHTTP(w, r)
+		case DiaryServiceSyncDiaryEntriesProcedure:
+			diaryServiceSyncDiaryEntriesHandler.ServeHTTP(w, r)
+		case DiaryServiceListTrashProcedure:
+			diaryServiceListTrashHandler.ServeHTTP(w, r)
+		case DiaryServiceRestoreDiaryEntryProcedure:
+			diaryServiceRestoreDiaryEntryHandler.ServeHTTP(w, r)
+		case DiaryServiceEmptyTrashProcedure:
+			diaryServiceEmptyTrashHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -830,6 +1549,10 @@ func (UnimplementedDiaryServiceHandler) UpdateDiaryEntry(context.Context, *conne
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.UpdateDiaryEntry is not implemented"))
 }
 
+func (UnimplementedDiaryServiceHandler) MergeDiaryContent(context.Context, *connect.Request[grpc.MergeDiaryContentRequest]) (*connect.Response[grpc.MergeDiaryContentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.MergeDiaryContent is not implemented"))
+}
+
 func (UnimplementedDiaryServiceHandler) DeleteDiaryEntry(context.Context, *connect.Request[grpc.DeleteDiaryEntryRequest]) (*connect.Response[grpc.DeleteDiaryEntryResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.DeleteDiaryEntry is not implemented"))
 }
@@ -889,3 +1612,67 @@ func (UnimplementedDiaryServiceHandler) GetDiaryEmbeddingStatus(context.Context,
 func (UnimplementedDiaryServiceHandler) ExportDiaryEntries(context.Context, *connect.Request[grpc.ExportDiaryEntriesRequest]) (*connect.Response[grpc.ExportDiaryEntriesResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.ExportDiaryEntries is not implemented"))
 }
+
+func (UnimplementedDiaryServiceHandler) StreamExportDiaryEntries(context.Context, *connect.Request[grpc.StreamExportDiaryEntriesRequest], *connect.ServerStream[grpc.StreamExportDiaryEntriesResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.StreamExportDiaryEntries is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) WatchTasks(context.Context, *connect.Request[grpc.WatchTasksRequest], *connect.ServerStream[grpc.TaskEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.WatchTasks is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) UploadAttachment(context.Context, *connect.ClientStream[grpc.UploadAttachmentRequest]) (*connect.Response[grpc.UploadAttachmentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.UploadAttachment is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) DownloadAttachment(context.Context, *connect.Request[grpc.DownloadAttachmentRequest], *connect.ServerStream[grpc.DownloadAttachmentResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.DownloadAttachment is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) ListAttachments(context.Context, *connect.Request[grpc.ListAttachmentsRequest]) (*connect.Response[grpc.ListAttachmentsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.ListAttachments is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) DeleteAttachment(context.Context, *connect.Request[grpc.DeleteAttachmentRequest]) (*connect.Response[grpc.DeleteAttachmentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.DeleteAttachment is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) GetAttachmentUsage(context.Context, *connect.Request[grpc.GetAttachmentUsageRequest]) (*connect.Response[grpc.GetAttachmentUsageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.GetAttachmentUsage is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) CreateTag(context.Context, *connect.Request[grpc.CreateTagRequest]) (*connect.Response[grpc.CreateTagResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.CreateTag is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) ListTags(context.Context, *connect.Request[grpc.ListTagsRequest]) (*connect.Response[grpc.ListTagsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.ListTags is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) RenameTag(context.Context, *connect.Request[grpc.RenameTagRequest]) (*connect.Response[grpc.RenameTagResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.RenameTag is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) MergeTags(context.Context, *connect.Request[grpc.MergeTagsRequest]) (*connect.Response[grpc.MergeTagsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.MergeTags is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) DeleteTag(context.Context, *connect.Request[grpc.DeleteTagRequest]) (*connect.Response[grpc.DeleteTagResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.DeleteTag is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) SyncDiaryEntries(context.Context, *connect.Request[grpc.SyncDiaryEntriesRequest]) (*connect.Response[grpc.SyncDiaryEntriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.SyncDiaryEntries is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) ListTrash(context.Context, *connect.Request[grpc.ListTrashRequest]) (*connect.Response[grpc.ListTrashResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.ListTrash is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) RestoreDiaryEntry(context.Context, *connect.Request[grpc.RestoreDiaryEntryRequest]) (*connect.Response[grpc.RestoreDiaryEntryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.RestoreDiaryEntry is not implemented"))
+}
+
+func (UnimplementedDiaryServiceHandler) EmptyTrash(context.Context, *connect.Request[grpc.EmptyTrashRequest]) (*connect.Response[grpc.EmptyTrashResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("diary.DiaryService.EmptyTrash is not implemented"))
+}
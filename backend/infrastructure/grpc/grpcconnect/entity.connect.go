@@ -42,6 +42,9 @@ const (
 	// EntityServiceDeleteEntityProcedure is the fully-qualified name of the EntityService's
 	// DeleteEntity RPC.
 	EntityServiceDeleteEntityProcedure = "/entity.EntityService/DeleteEntity"
+	// EntityServiceRestoreEntityProcedure is the fully-qualified name of the EntityService's
+	// RestoreEntity RPC.
+	EntityServiceRestoreEntityProcedure = "/entity.EntityService/RestoreEntity"
 	// EntityServiceGetEntityProcedure is the fully-qualified name of the EntityService's GetEntity RPC.
 	EntityServiceGetEntityProcedure = "/entity.EntityService/GetEntity"
 	// EntityServiceListEntitiesProcedure is the fully-qualified name of the EntityService's
@@ -79,16 +82,28 @@ type EntityServiceClient interface {
 	//
 	//	request: { id: "uuid", name: "山田花子", category: PEOPLE, memo: "同僚" }
 	//
+	// expected_versionを指定した場合、他のクライアントが先に更新していれば上書きせずにAbortedを返します。
+	// Abortedのエラーの詳細（details）にはサーバー側の現在のEntityが含まれます。
+	//
 	// エラー:
 	//   - NotFound: エンティティが見つからない
 	//   - PermissionDenied: 他のユーザーのエンティティにアクセスしようとした
+	//   - Aborted: expected_versionがサーバーのversionと一致しない
 	UpdateEntity(context.Context, *connect.Request[grpc.UpdateEntityRequest]) (*connect.Response[grpc.UpdateEntityResponse], error)
-	// DeleteEntity はエンティティとそれに紐づくエイリアスを削除します。
+	// DeleteEntity はエンティティをゴミ箱に移動します。
+	// ゴミ箱のエンティティは一覧・検索・キーワード展開に使われず、保持期間内であればRestoreEntityで元に戻せます。
+	// 保持期間を過ぎるとエイリアスとともに完全に削除されます。
 	//
 	// エラー:
 	//   - NotFound: エンティティが見つからない
 	//   - PermissionDenied: 他のユーザーのエンティティにアクセスしようとした
 	DeleteEntity(context.Context, *connect.Request[grpc.DeleteEntityRequest]) (*connect.Response[grpc.DeleteEntityResponse], error)
+	// RestoreEntity はゴミ箱のエンティティを元に戻します。
+	//
+	// エラー:
+	//   - NotFound: ゴミ箱にエンティティが見つからない（完全に削除済みを含む）
+	//   - AlreadyExists: 同じ名前のエンティティ、または名前・エイリアスと重複するエイリアスが既にある
+	RestoreEntity(context.Context, *connect.Request[grpc.RestoreEntityRequest]) (*connect.Response[grpc.RestoreEntityResponse], error)
 	// GetEntity は指定されたIDのエンティティを取得します。
 	// エイリアスも含めて返されます。
 	//
@@ -175,6 +190,12 @@ func NewEntityServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(entityServiceMethods.ByName("DeleteEntity")),
 			connect.WithClientOptions(opts...),
 		),
+		restoreEntity: connect.NewClient[grpc.RestoreEntityRequest, grpc.RestoreEntityResponse](
+			httpClient,
+			baseURL+EntityServiceRestoreEntityProcedure,
+			connect.WithSchema(entityServiceMethods.ByName("RestoreEntity")),
+			connect.WithClientOptions(opts...),
+		),
 		getEntity: connect.NewClient[grpc.GetEntityRequest, grpc.GetEntityResponse](
 			httpClient,
 			baseURL+EntityServiceGetEntityProcedure,
@@ -219,6 +240,7 @@ type entityServiceClient struct {
 	createEntity      *connect.Client[grpc.CreateEntityRequest, grpc.CreateEntityResponse]
 	updateEntity      *connect.Client[grpc.UpdateEntityRequest, grpc.UpdateEntityResponse]
 	deleteEntity      *connect.Client[grpc.DeleteEntityRequest, grpc.DeleteEntityResponse]
+	restoreEntity     *connect.Client[grpc.RestoreEntityRequest, grpc.RestoreEntityResponse]
 	getEntity         *connect.Client[grpc.GetEntityRequest, grpc.GetEntityResponse]
 	listEntities      *connect.Client[grpc.ListEntitiesRequest, grpc.ListEntitiesResponse]
 	createEntityAlias *connect.Client[grpc.CreateEntityAliasRequest, grpc.CreateEntityAliasResponse]
@@ -242,6 +264,11 @@ func (c *entityServiceClient) DeleteEntity(ctx context.Context, req *connect.Req
 	return c.deleteEntity.CallUnary(ctx, req)
 }
 
+// RestoreEntity calls entity.EntityService.RestoreEntity.
+func (c *entityServiceClient) RestoreEntity(ctx context.Context, req *connect.Request[grpc.RestoreEntityRequest]) (*connect.Response[grpc.RestoreEntityResponse], error) {
+	return c.restoreEntity.CallUnary(ctx, req)
+}
+
 // GetEntity calls entity.EntityService.GetEntity.
 func (c *entityServiceClient) GetEntity(ctx context.Context, req *connect.Request[grpc.GetEntityRequest]) (*connect.Response[grpc.GetEntityResponse], error) {
 	return c.getEntity.CallUnary(ctx, req)
@@ -290,16 +317,28 @@ type EntityServiceHandler interface {
 	//
 	//	request: { id: "uuid", name: "山田花子", category: PEOPLE, memo: "同僚" }
 	//
+	// expected_versionを指定した場合、他のクライアントが先に更新していれば上書きせずにAbortedを返します。
+	// Abortedのエラーの詳細（details）にはサーバー側の現在のEntityが含まれます。
+	//
 	// エラー:
 	//   - NotFound: エンティティが見つからない
 	//   - PermissionDenied: 他のユーザーのエンティティにアクセスしようとした
+	//   - Aborted: expected_versionがサーバーのversionと一致しない
 	UpdateEntity(context.Context, *connect.Request[grpc.UpdateEntityRequest]) (*connect.Response[grpc.UpdateEntityResponse], error)
-	// DeleteEntity はエンティティとそれに紐づくエイリアスを削除します。
+	// DeleteEntity はエンティティをゴミ箱に移動します。
+	// ゴミ箱のエンティティは一覧・検索・キーワード展開に使われず、保持期間内であればRestoreEntityで元に戻せます。
+	// 保持期間を過ぎるとエイリアスとともに完全に削除されます。
 	//
 	// エラー:
 	//   - NotFound: エンティティが見つからない
 	//   - PermissionDenied: 他のユーザーのエンティティにアクセスしようとした
 	DeleteEntity(context.Context, *connect.Request[grpc.DeleteEntityRequest]) (*connect.Response[grpc.DeleteEntityResponse], error)
+	// RestoreEntity はゴミ箱のエンティティを元に戻します。
+	//
+	// エラー:
+	//   - NotFound: ゴミ箱にエンティティが見つからない（完全に削除済みを含む）
+	//   - AlreadyExists: 同じ名前のエンティティ、または名前・エイリアスと重複するエイリアスが既にある
+	RestoreEntity(context.Context, *connect.Request[grpc.RestoreEntityRequest]) (*connect.Response[grpc.RestoreEntityResponse], error)
 	// GetEntity は指定されたIDのエンティティを取得します。
 	// エイリアスも含めて返されます。
 	//
@@ -382,6 +421,12 @@ func NewEntityServiceHandler(svc EntityServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(entityServiceMethods.ByName("DeleteEntity")),
 		connect.WithHandlerOptions(opts...),
 	)
+	entityServiceRestoreEntityHandler := connect.NewUnaryHandler(
+		EntityServiceRestoreEntityProcedure,
+		svc.RestoreEntity,
+		connect.WithSchema(entityServiceMethods.ByName("RestoreEntity")),
+		connect.WithHandlerOptions(opts...),
+	)
 	entityServiceGetEntityHandler := connect.NewUnaryHandler(
 		EntityServiceGetEntityProcedure,
 		svc.GetEntity,
@@ -426,6 +471,8 @@ func NewEntityServiceHandler(svc EntityServiceHandler, opts ...connect.HandlerOp
 			entityServiceUpdateEntityHandler.ServeHTTP(w, r)
 		case EntityServiceDeleteEntityProcedure:
 			entityServiceDeleteEntityHandler.ServeHTTP(w, r)
+		case EntityServiceRestoreEntityProcedure:
+			entityServiceRestoreEntityHandler.ServeHTTP(w, r)
 		case EntityServiceGetEntityProcedure:
 			entityServiceGetEntityHandler.ServeHTTP(w, r)
 		case EntityServiceListEntitiesProcedure:
@@ -459,6 +506,10 @@ func (UnimplementedEntityServiceHandler) DeleteEntity(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("entity.EntityService.DeleteEntity is not implemented"))
 }
 
+func (UnimplementedEntityServiceHandler) RestoreEntity(context.Context, *connect.Request[grpc.RestoreEntityRequest]) (*connect.Response[grpc.RestoreEntityResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("entity.EntityService.RestoreEntity is not implemented"))
+}
+
 func (UnimplementedEntityServiceHandler) GetEntity(context.Context, *connect.Request[grpc.GetEntityRequest]) (*connect.Response[grpc.GetEntityResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("entity.EntityService.GetEntity is not implemented"))
 }
@@ -67,6 +67,35 @@ const (
 	// UserServiceDeleteApiKeyProcedure is the fully-qualified name of the UserService's DeleteApiKey
 	// RPC.
 	UserServiceDeleteApiKeyProcedure = "/user.UserService/DeleteApiKey"
+	// UserServiceCreateWebhookProcedure is the fully-qualified name of the UserService's CreateWebhook
+	// RPC.
+	UserServiceCreateWebhookProcedure = "/user.UserService/CreateWebhook"
+	// UserServiceListWebhooksProcedure is the fully-qualified name of the UserService's ListWebhooks
+	// RPC.
+	UserServiceListWebhooksProcedure = "/user.UserService/ListWebhooks"
+	// UserServiceDeleteWebhookProcedure is the fully-qualified name of the UserService's DeleteWebhook
+	// RPC.
+	UserServiceDeleteWebhookProcedure = "/user.UserService/DeleteWebhook"
+	// UserServiceListWebhookDeliveriesProcedure is the fully-qualified name of the UserService's
+	// ListWebhookDeliveries RPC.
+	UserServiceListWebhookDeliveriesProcedure = "/user.UserService/ListWebhookDeliveries"
+	// UserServiceRequestDataExportProcedure is the fully-qualified name of the UserService's
+	// RequestDataExport RPC.
+	UserServiceRequestDataExportProcedure = "/user.UserService/RequestDataExport"
+	// UserServiceListDataExportsProcedure is the fully-qualified name of the UserService's
+	// ListDataExports RPC.
+	UserServiceListDataExportsProcedure = "/user.UserService/ListDataExports"
+	// UserServiceDownloadDataExportProcedure is the fully-qualified name of the UserService's
+	// DownloadDataExport RPC.
+	UserServiceDownloadDataExportProcedure = "/user.UserService/DownloadDataExport"
+	// UserServiceUpdateBackupSettingsProcedure is the fully-qualified name of the UserService's
+	// UpdateBackupSettings RPC.
+	UserServiceUpdateBackupSettingsProcedure = "/user.UserService/UpdateBackupSettings"
+	// UserServiceListSecurityEventsProcedure is the fully-qualified name of the UserService's
+	// ListSecurityEvents RPC.
+	UserServiceListSecurityEventsProcedure = "/user.UserService/ListSecurityEvents"
+	// UserServiceGetUsageProcedure is the fully-qualified name of the UserService's GetUsage RPC.
+	UserServiceGetUsageProcedure = "/user.UserService/GetUsage"
 )
 
 // UserServiceClient is a client for the user.UserService service.
@@ -124,13 +153,15 @@ type UserServiceClient interface {
 	// エラー:
 	//   - NotFound: 指定されたプロバイダーのキーが存在しない
 	DeleteLLMKey(context.Context, *connect.Request[grpc.DeleteLLMKeyRequest]) (*connect.Response[grpc.DeleteLLMKeyResponse], error)
-	// DeleteAccount はユーザーアカウントと関連データを完全に削除します。
-	// 日記、エンティティ、要約など全てのデータがカスケード削除されます。
+	// DeleteAccount はユーザーアカウントの削除を依頼します。
+	// すぐにログイン・APIキーを使えなくし、猶予期間（ACCOUNT_DELETION_GRACE_DAYS）の後に
+	// 日記、エンティティ、要約など全てのデータを完全に削除します。
+	// 猶予期間の間はメールのリンクから削除を取り消せます（AuthService.CancelAccountDeletion）。
 	//
 	// 例:
 	//
 	//	request: {}
-	//	response: { success: true, message: "アカウントを削除しました" }
+	//	response: { success: true, message: "accountDeleteSuccess", deletion_scheduled_at: 1701209600 }
 	//
 	// エラー:
 	//   - Internal: 削除処理エラー
@@ -197,6 +228,119 @@ type UserServiceClient interface {
 	// エラー:
 	//   - NotFound: 指定されたキーが存在しない、または他ユーザーのキー
 	DeleteApiKey(context.Context, *connect.Request[grpc.DeleteApiKeyRequest]) (*connect.Response[grpc.DeleteApiKeyResponse], error)
+	// CreateWebhook は日記やAI生成のイベントを通知するWebhookエンドポイントを登録します。
+	// 送信リクエストは X-Umi-Signature ヘッダーでHMAC-SHA256署名され、署名用シークレットはレスポンスで一度だけ返されます。
+	//
+	// 例:
+	//
+	//	request: { url: "https://example.com/hook", events: ["diary.created", "summary.generated"] }
+	//	response: { secret: "whsec_...", info: { id: "...", url: "https://example.com/hook", events: [...], ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: URLが不正、またはイベントが空・未対応
+	//   - ResourceExhausted: 登録数の上限に達している
+	//   - Internal: データベースエラー
+	CreateWebhook(context.Context, *connect.Request[grpc.CreateWebhookRequest]) (*connect.Response[grpc.CreateWebhookResponse], error)
+	// ListWebhooks は登録済みWebhookの一覧を返します（シークレットは含まれません）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { webhooks: [{ id: "...", url: "https://example.com/hook", events: ["diary.created"], ... }] }
+	//
+	// エラー: なし（Webhookがない場合は空配列）
+	ListWebhooks(context.Context, *connect.Request[grpc.ListWebhooksRequest]) (*connect.Response[grpc.ListWebhooksResponse], error)
+	// DeleteWebhook は指定されたWebhookと配信ログを削除します。
+	//
+	// 例:
+	//
+	//	request: { id: "..." }
+	//	response: { success: true, message: "webhookDeleted" }
+	//
+	// エラー:
+	//   - NotFound: 指定されたWebhookが存在しない、または他ユーザーのWebhook
+	DeleteWebhook(context.Context, *connect.Request[grpc.DeleteWebhookRequest]) (*connect.Response[grpc.DeleteWebhookResponse], error)
+	// ListWebhookDeliveries は指定されたWebhookの配信ログを新しい順に返します。
+	//
+	// 例:
+	//
+	//	request: { webhook_id: "...", limit: 20 }
+	//	response: { deliveries: [{ id: "...", event: "diary.created", status: "succeeded", attempts: 1, response_status: 200, ... }] }
+	//
+	// エラー:
+	//   - NotFound: 指定されたWebhookが存在しない、または他ユーザーのWebhook
+	ListWebhookDeliveries(context.Context, *connect.Request[grpc.ListWebhookDeliveriesRequest]) (*connect.Response[grpc.ListWebhookDeliveriesResponse], error)
+	// RequestDataExport は個人データの持ち出し用アーカイブ（ZIP）の作成を依頼します。
+	// 日記・エンティティとエイリアス・月次要約・ハイライト・トレンド分析・APIキーのメタデータ・設定・添付ファイルを
+	// JSONとMarkdownで含み、内容の一覧をmanifest.jsonに記録します。作成はsubscriberで非同期に行い、
+	// 進捗はWatchTasks（task_type: "data_export"）で通知されます。アーカイブは期限を過ぎると削除されます。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { export: { id: "...", status: "queued", ... } }
+	//
+	// エラー:
+	//   - FailedPrecondition: 作成中のエクスポートがある
+	//   - Internal: データベースエラー、キューへの追加に失敗
+	RequestDataExport(context.Context, *connect.Request[grpc.RequestDataExportRequest]) (*connect.Response[grpc.RequestDataExportResponse], error)
+	// ListDataExports は依頼したデータエクスポートを新しい順に返します（期限切れで削除されたものは含まれません）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { exports: [{ id: "...", status: "succeeded", size_bytes: 1024, expires_at: 1700000000, ... }] }
+	//
+	// エラー: なし（エクスポートがない場合は空配列）
+	ListDataExports(context.Context, *connect.Request[grpc.ListDataExportsRequest]) (*connect.Response[grpc.ListDataExportsResponse], error)
+	// DownloadDataExport は作成済みのアーカイブを取得します（サーバーストリーミング）。
+	// 最初のメッセージでエクスポートの情報を送り、以降のメッセージでZIPの内容をchunkに分けて送ります。
+	//
+	// エラー:
+	//   - NotFound: エクスポートが存在しない、または期限切れ
+	//   - FailedPrecondition: アーカイブを作成中、または作成に失敗した
+	DownloadDataExport(context.Context, *connect.Request[grpc.DownloadDataExportRequest]) (*connect.ServerStreamForClient[grpc.DownloadDataExportResponse], error)
+	// UpdateBackupSettings は日記の定期バックアップの設定を登録・更新します。
+	// スケジューラーが interval_hours ごとにデータエクスポートと同じ形式のアーカイブを作成して保存先に書き込み、
+	// 新しい方から retention_count 件を超えた古いバックアップを削除します。実行結果は GetUserInfo の backup で確認できます。
+	// 保存先の認証情報（S3のシークレット、WebDAVのパスワード）は空の場合は変更せず、レスポンスにも含めません。
+	// passphrase を指定するとバックアップを暗号化します（サーバーには公開鍵だけを保存するため、復号にはパスフレーズが必要です）。
+	//
+	// 例:
+	//
+	//	request: { enabled: true, destination: "webdav", interval_hours: 24, retention_count: 7,
+	//	           webdav: { url: "https://cloud.example.com/remote.php/dav/files/me/", username: "me", password: "..." },
+	//	           passphrase: "correct horse battery staple" }
+	//	response: { backup: { enabled: true, destination: "webdav", encrypted: true, next_run_at: 1700000000, ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: 保存先・間隔・保持数・接続設定が不正、またはパスフレーズが短い
+	//   - FailedPrecondition: サーバーにローカルの保存先が設定されていない（destination: "local"）
+	//   - Internal: データベースエラー
+	UpdateBackupSettings(context.Context, *connect.Request[grpc.UpdateBackupSettingsRequest]) (*connect.Response[grpc.UpdateBackupSettingsResponse], error)
+	// ListSecurityEvents は自分のアカウントのセキュリティイベント（ログイン、パスワードの変更、APIキーの発行・使用など）を新しい順に返します。
+	// それまでと異なるIPアドレス・User-Agentからのログインは suspicious が true になります。
+	//
+	// 例:
+	//
+	//	request: { event_type: "login_success", limit: 50 }
+	//	response: { events: [{ event_type: "login_success", ip_address: "203.0.113.1", suspicious: true, ... }], next_cursor: "1700000000_uuid", has_more: true }
+	//
+	// エラー:
+	//   - InvalidArgument: event_typeが未対応、またはcursorが不正
+	ListSecurityEvents(context.Context, *connect.Request[grpc.ListSecurityEventsRequest]) (*connect.Response[grpc.ListSecurityEventsResponse], error)
+	// GetUsage はレート制限のあるRPC・MCPのツールごとに、現在のウィンドウでの使用回数と上限を返します（回数は増やしません）。
+	// ログインしているユーザーのバケットと、APIキーごとのバケットを返します。上限に達したRPCは ResourceExhausted を返します。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { usages: [{ method: "/diary.DiaryService/SearchDiaryEntriesSemantic", limit: 30, window_seconds: 60, used: 3, remaining: 27, reset_in_seconds: 42 }],
+	//	            api_keys: [{ api_key_id: "uuid", name: "Claude Desktop", usages: [{ method: "mcp/search_diary_entries_fuzzy", ... }] }] }
+	//
+	// エラー:
+	//   - Internal: データベースエラー、またはRedisエラー
+	GetUsage(context.Context, *connect.Request[grpc.GetUsageRequest]) (*connect.Response[grpc.GetUsageResponse], error)
 }
 
 // NewUserServiceClient constructs a client for the user.UserService service. By default, it uses
@@ -282,6 +426,66 @@ func NewUserServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(userServiceMethods.ByName("DeleteApiKey")),
 			connect.WithClientOptions(opts...),
 		),
+		createWebhook: connect.NewClient[grpc.CreateWebhookRequest, grpc.CreateWebhookResponse](
+			httpClient,
+			baseURL+UserServiceCreateWebhookProcedure,
+			connect.WithSchema(userServiceMethods.ByName("CreateWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		listWebhooks: connect.NewClient[grpc.ListWebhooksRequest, grpc.ListWebhooksResponse](
+			httpClient,
+			baseURL+UserServiceListWebhooksProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ListWebhooks")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteWebhook: connect.NewClient[grpc.DeleteWebhookRequest, grpc.DeleteWebhookResponse](
+			httpClient,
+			baseURL+UserServiceDeleteWebhookProcedure,
+			connect.WithSchema(userServiceMethods.ByName("DeleteWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		listWebhookDeliveries: connect.NewClient[grpc.ListWebhookDeliveriesRequest, grpc.ListWebhookDeliveriesResponse](
+			httpClient,
+			baseURL+UserServiceListWebhookDeliveriesProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ListWebhookDeliveries")),
+			connect.WithClientOptions(opts...),
+		),
+		requestDataExport: connect.NewClient[grpc.RequestDataExportRequest, grpc.RequestDataExportResponse](
+			httpClient,
+			baseURL+UserServiceRequestDataExportProcedure,
+			connect.WithSchema(userServiceMethods.ByName("RequestDataExport")),
+			connect.WithClientOptions(opts...),
+		),
+		listDataExports: connect.NewClient[grpc.ListDataExportsRequest, grpc.ListDataExportsResponse](
+			httpClient,
+			baseURL+UserServiceListDataExportsProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ListDataExports")),
+			connect.WithClientOptions(opts...),
+		),
+		downloadDataExport: connect.NewClient[grpc.DownloadDataExportRequest, grpc.DownloadDataExportResponse](
+			httpClient,
+			baseURL+UserServiceDownloadDataExportProcedure,
+			connect.WithSchema(userServiceMethods.ByName("DownloadDataExport")),
+			connect.WithClientOptions(opts...),
+		),
+		updateBackupSettings: connect.NewClient[grpc.UpdateBackupSettingsRequest, grpc.UpdateBackupSettingsResponse](
+			httpClient,
+			baseURL+UserServiceUpdateBackupSettingsProcedure,
+			connect.WithSchema(userServiceMethods.ByName("UpdateBackupSettings")),
+			connect.WithClientOptions(opts...),
+		),
+		listSecurityEvents: connect.NewClient[grpc.ListSecurityEventsRequest, grpc.ListSecurityEventsResponse](
+			httpClient,
+			baseURL+UserServiceListSecurityEventsProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ListSecurityEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		getUsage: connect.NewClient[grpc.GetUsageRequest, grpc.GetUsageResponse](
+			httpClient,
+			baseURL+UserServiceGetUsageProcedure,
+			connect.WithSchema(userServiceMethods.ByName("GetUsage")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -299,6 +503,16 @@ type userServiceClient struct {
 	createApiKey              *connect.Client[grpc.CreateApiKeyRequest, grpc.CreateApiKeyResponse]
 	listApiKeys               *connect.Client[grpc.ListApiKeysRequest, grpc.ListApiKeysResponse]
 	deleteApiKey              *connect.Client[grpc.DeleteApiKeyRequest, grpc.DeleteApiKeyResponse]
+	createWebhook             *connect.Client[grpc.CreateWebhookRequest, grpc.CreateWebhookResponse]
+	listWebhooks              *connect.Client[grpc.ListWebhooksRequest, grpc.ListWebhooksResponse]
+	deleteWebhook             *connect.Client[grpc.DeleteWebhookRequest, grpc.DeleteWebhookResponse]
+	listWebhookDeliveries     *connect.Client[grpc.ListWebhookDeliveriesRequest, grpc.ListWebhookDeliveriesResponse]
+	requestDataExport         *connect.Client[grpc.RequestDataExportRequest, grpc.RequestDataExportResponse]
+	listDataExports           *connect.Client[grpc.ListDataExportsRequest, grpc.ListDataExportsResponse]
+	downloadDataExport        *connect.Client[grpc.DownloadDataExportRequest, grpc.DownloadDataExportResponse]
+	updateBackupSettings      *connect.Client[grpc.UpdateBackupSettingsRequest, grpc.UpdateBackupSettingsResponse]
+	listSecurityEvents        *connect.Client[grpc.ListSecurityEventsRequest, grpc.ListSecurityEventsResponse]
+	getUsage                  *connect.Client[grpc.GetUsageRequest, grpc.GetUsageResponse]
 }
 
 // UpdateUserName calls user.UserService.UpdateUserName.
@@ -361,6 +575,56 @@ func (c *userServiceClient) DeleteApiKey(ctx context.Context, req *connect.Reque
 	return c.deleteApiKey.CallUnary(ctx, req)
 }
 
+// CreateWebhook calls user.UserService.CreateWebhook.
+func (c *userServiceClient) CreateWebhook(ctx context.Context, req *connect.Request[grpc.CreateWebhookRequest]) (*connect.Response[grpc.CreateWebhookResponse], error) {
+	return c.createWebhook.CallUnary(ctx, req)
+}
+
+// ListWebhooks calls user.UserService.ListWebhooks.
+func (c *userServiceClient) ListWebhooks(ctx context.Context, req *connect.Request[grpc.ListWebhooksRequest]) (*connect.Response[grpc.ListWebhooksResponse], error) {
+	return c.listWebhooks.CallUnary(ctx, req)
+}
+
+// DeleteWebhook calls user.UserService.DeleteWebhook.
+func (c *userServiceClient) DeleteWebhook(ctx context.Context, req *connect.Request[grpc.DeleteWebhookRequest]) (*connect.Response[grpc.DeleteWebhookResponse], error) {
+	return c.deleteWebhook.CallUnary(ctx, req)
+}
+
+// ListWebhookDeliveries calls user.UserService.ListWebhookDeliveries.
+func (c *userServiceClient) ListWebhookDeliveries(ctx context.Context, req *connect.Request[grpc.ListWebhookDeliveriesRequest]) (*connect.Response[grpc.ListWebhookDeliveriesResponse], error) {
+	return c.listWebhookDeliveries.CallUnary(ctx, req)
+}
+
+// RequestDataExport calls user.UserService.RequestDataExport.
+func (c *userServiceClient) RequestDataExport(ctx context.Context, req *connect.Request[grpc.RequestDataExportRequest]) (*connect.Response[grpc.RequestDataExportResponse], error) {
+	return c.requestDataExport.CallUnary(ctx, req)
+}
+
+// ListDataExports calls user.UserService.ListDataExports.
+func (c *userServiceClient) ListDataExports(ctx context.Context, req *connect.Request[grpc.ListDataExportsRequest]) (*connect.Response[grpc.ListDataExportsResponse], error) {
+	return c.listDataExports.CallUnary(ctx, req)
+}
+
+// DownloadDataExport calls user.UserService.DownloadDataExport.
+func (c *userServiceClient) DownloadDataExport(ctx context.Context, req *connect.Request[grpc.DownloadDataExportRequest]) (*connect.ServerStreamForClient[grpc.DownloadDataExportResponse], error) {
+	return c.downloadDataExport.CallServerStream(ctx, req)
+}
+
+// UpdateBackupSettings calls user.UserService.UpdateBackupSettings.
+func (c *userServiceClient) UpdateBackupSettings(ctx context.Context, req *connect.Request[grpc.UpdateBackupSettingsRequest]) (*connect.Response[grpc.UpdateBackupSettingsResponse], error) {
+	return c.updateBackupSettings.CallUnary(ctx, req)
+}
+
+// ListSecurityEvents calls user.UserService.ListSecurityEvents.
+func (c *userServiceClient) ListSecurityEvents(ctx context.Context, req *connect.Request[grpc.ListSecurityEventsRequest]) (*connect.Response[grpc.ListSecurityEventsResponse], error) {
+	return c.listSecurityEvents.CallUnary(ctx, req)
+}
+
+// GetUsage calls user.UserService.GetUsage.
+func (c *userServiceClient) GetUsage(ctx context.Context, req *connect.Request[grpc.GetUsageRequest]) (*connect.Response[grpc.GetUsageResponse], error) {
+	return c.getUsage.CallUnary(ctx, req)
+}
+
 // UserServiceHandler is an implementation of the user.UserService service.
 type UserServiceHandler interface {
 	// UpdateUserName はユーザー名を変更します。
@@ -416,13 +680,15 @@ type UserServiceHandler interface {
 	// エラー:
 	//   - NotFound: 指定されたプロバイダーのキーが存在しない
 	DeleteLLMKey(context.Context, *connect.Request[grpc.DeleteLLMKeyRequest]) (*connect.Response[grpc.DeleteLLMKeyResponse], error)
-	// DeleteAccount はユーザーアカウントと関連データを完全に削除します。
-	// 日記、エンティティ、要約など全てのデータがカスケード削除されます。
+	// DeleteAccount はユーザーアカウントの削除を依頼します。
+	// すぐにログイン・APIキーを使えなくし、猶予期間（ACCOUNT_DELETION_GRACE_DAYS）の後に
+	// 日記、エンティティ、要約など全てのデータを完全に削除します。
+	// 猶予期間の間はメールのリンクから削除を取り消せます（AuthService.CancelAccountDeletion）。
 	//
 	// 例:
 	//
 	//	request: {}
-	//	response: { success: true, message: "アカウントを削除しました" }
+	//	response: { success: true, message: "accountDeleteSuccess", deletion_scheduled_at: 1701209600 }
 	//
 	// エラー:
 	//   - Internal: 削除処理エラー
@@ -489,6 +755,119 @@ type UserServiceHandler interface {
 	// エラー:
 	//   - NotFound: 指定されたキーが存在しない、または他ユーザーのキー
 	DeleteApiKey(context.Context, *connect.Request[grpc.DeleteApiKeyRequest]) (*connect.Response[grpc.DeleteApiKeyResponse], error)
+	// CreateWebhook は日記やAI生成のイベントを通知するWebhookエンドポイントを登録します。
+	// 送信リクエストは X-Umi-Signature ヘッダーでHMAC-SHA256署名され、署名用シークレットはレスポンスで一度だけ返されます。
+	//
+	// 例:
+	//
+	//	request: { url: "https://example.com/hook", events: ["diary.created", "summary.generated"] }
+	//	response: { secret: "whsec_...", info: { id: "...", url: "https://example.com/hook", events: [...], ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: URLが不正、またはイベントが空・未対応
+	//   - ResourceExhausted: 登録数の上限に達している
+	//   - Internal: データベースエラー
+	CreateWebhook(context.Context, *connect.Request[grpc.CreateWebhookRequest]) (*connect.Response[grpc.CreateWebhookResponse], error)
+	// ListWebhooks は登録済みWebhookの一覧を返します（シークレットは含まれません）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { webhooks: [{ id: "...", url: "https://example.com/hook", events: ["diary.created"], ... }] }
+	//
+	// エラー: なし（Webhookがない場合は空配列）
+	ListWebhooks(context.Context, *connect.Request[grpc.ListWebhooksRequest]) (*connect.Response[grpc.ListWebhooksResponse], error)
+	// DeleteWebhook は指定されたWebhookと配信ログを削除します。
+	//
+	// 例:
+	//
+	//	request: { id: "..." }
+	//	response: { success: true, message: "webhookDeleted" }
+	//
+	// エラー:
+	//   - NotFound: 指定されたWebhookが存在しない、または他ユーザーのWebhook
+	DeleteWebhook(context.Context, *connect.Request[grpc.DeleteWebhookRequest]) (*connect.Response[grpc.DeleteWebhookResponse], error)
+	// ListWebhookDeliveries は指定されたWebhookの配信ログを新しい順に返します。
+	//
+	// 例:
+	//
+	//	request: { webhook_id: "...", limit: 20 }
+	//	response: { deliveries: [{ id: "...", event: "diary.created", status: "succeeded", attempts: 1, response_status: 200, ... }] }
+	//
+	// エラー:
+	//   - NotFound: 指定されたWebhookが存在しない、または他ユーザーのWebhook
+	ListWebhookDeliveries(context.Context, *connect.Request[grpc.ListWebhookDeliveriesRequest]) (*connect.Response[grpc.ListWebhookDeliveriesResponse], error)
+	// RequestDataExport は個人データの持ち出し用アーカイブ（ZIP）の作成を依頼します。
+	// 日記・エンティティとエイリアス・月次要約・ハイライト・トレンド分析・APIキーのメタデータ・設定・添付ファイルを
+	// JSONとMarkdownで含み、内容の一覧をmanifest.jsonに記録します。作成はsubscriberで非同期に行い、
+	// 進捗はWatchTasks（task_type: "data_export"）で通知されます。アーカイブは期限を過ぎると削除されます。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { export: { id: "...", status: "queued", ... } }
+	//
+	// エラー:
+	//   - FailedPrecondition: 作成中のエクスポートがある
+	//   - Internal: データベースエラー、キューへの追加に失敗
+	RequestDataExport(context.Context, *connect.Request[grpc.RequestDataExportRequest]) (*connect.Response[grpc.RequestDataExportResponse], error)
+	// ListDataExports は依頼したデータエクスポートを新しい順に返します（期限切れで削除されたものは含まれません）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { exports: [{ id: "...", status: "succeeded", size_bytes: 1024, expires_at: 1700000000, ... }] }
+	//
+	// エラー: なし（エクスポートがない場合は空配列）
+	ListDataExports(context.Context, *connect.Request[grpc.ListDataExportsRequest]) (*connect.Response[grpc.ListDataExportsResponse], error)
+	// DownloadDataExport は作成済みのアーカイブを取得します（サーバーストリーミング）。
+	// 最初のメッセージでエクスポートの情報を送り、以降のメッセージでZIPの内容をchunkに分けて送ります。
+	//
+	// エラー:
+	//   - NotFound: エクスポートが存在しない、または期限切れ
+	//   - FailedPrecondition: アーカイブを作成中、または作成に失敗した
+	DownloadDataExport(context.Context, *connect.Request[grpc.DownloadDataExportRequest], *connect.ServerStream[grpc.DownloadDataExportResponse]) error
+	// UpdateBackupSettings は日記の定期バックアップの設定を登録・更新します。
+	// スケジューラーが interval_hours ごとにデータエクスポートと同じ形式のアーカイブを作成して保存先に書き込み、
+	// 新しい方から retention_count 件を超えた古いバックアップを削除します。実行結果は GetUserInfo の backup で確認できます。
+	// 保存先の認証情報（S3のシークレット、WebDAVのパスワード）は空の場合は変更せず、レスポンスにも含めません。
+	// passphrase を指定するとバックアップを暗号化します（サーバーには公開鍵だけを保存するため、復号にはパスフレーズが必要です）。
+	//
+	// 例:
+	//
+	//	request: { enabled: true, destination: "webdav", interval_hours: 24, retention_count: 7,
+	//	           webdav: { url: "https://cloud.example.com/remote.php/dav/files/me/", username: "me", password: "..." },
+	//	           passphrase: "correct horse battery staple" }
+	//	response: { backup: { enabled: true, destination: "webdav", encrypted: true, next_run_at: 1700000000, ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: 保存先・間隔・保持数・接続設定が不正、またはパスフレーズが短い
+	//   - FailedPrecondition: サーバーにローカルの保存先が設定されていない（destination: "local"）
+	//   - Internal: データベースエラー
+	UpdateBackupSettings(context.Context, *connect.Request[grpc.UpdateBackupSettingsRequest]) (*connect.Response[grpc.UpdateBackupSettingsResponse], error)
+	// ListSecurityEvents は自分のアカウントのセキュリティイベント（ログイン、パスワードの変更、APIキーの発行・使用など）を新しい順に返します。
+	// それまでと異なるIPアドレス・User-Agentからのログインは suspicious が true になります。
+	//
+	// 例:
+	//
+	//	request: { event_type: "login_success", limit: 50 }
+	//	response: { events: [{ event_type: "login_success", ip_address: "203.0.113.1", suspicious: true, ... }], next_cursor: "1700000000_uuid", has_more: true }
+	//
+	// エラー:
+	//   - InvalidArgument: event_typeが未対応、またはcursorが不正
+	ListSecurityEvents(context.Context, *connect.Request[grpc.ListSecurityEventsRequest]) (*connect.Response[grpc.ListSecurityEventsResponse], error)
+	// GetUsage はレート制限のあるRPC・MCPのツールごとに、現在のウィンドウでの使用回数と上限を返します（回数は増やしません）。
+	// ログインしているユーザーのバケットと、APIキーごとのバケットを返します。上限に達したRPCは ResourceExhausted を返します。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { usages: [{ method: "/diary.DiaryService/SearchDiaryEntriesSemantic", limit: 30, window_seconds: 60, used: 3, remaining: 27, reset_in_seconds: 42 }],
+	//	            api_keys: [{ api_key_id: "uuid", name: "Claude Desktop", usages: [{ method: "mcp/search_diary_entries_fuzzy", ... }] }] }
+	//
+	// エラー:
+	//   - Internal: データベースエラー、またはRedisエラー
+	GetUsage(context.Context, *connect.Request[grpc.GetUsageRequest]) (*connect.Response[grpc.GetUsageResponse], error)
 }
 
 // NewUserServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -570,6 +949,66 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(userServiceMethods.ByName("DeleteApiKey")),
 		connect.WithHandlerOptions(opts...),
 	)
+	userServiceCreateWebhookHandler := connect.NewUnaryHandler(
+		UserServiceCreateWebhookProcedure,
+		svc.CreateWebhook,
+		connect.WithSchema(userServiceMethods.ByName("CreateWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListWebhooksHandler := connect.NewUnaryHandler(
+		UserServiceListWebhooksProcedure,
+		svc.ListWebhooks,
+		connect.WithSchema(userServiceMethods.ByName("ListWebhooks")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceDeleteWebhookHandler := connect.NewUnaryHandler(
+		UserServiceDeleteWebhookProcedure,
+		svc.DeleteWebhook,
+		connect.WithSchema(userServiceMethods.ByName("DeleteWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListWebhookDeliveriesHandler := connect.NewUnaryHandler(
+		UserServiceListWebhookDeliveriesProcedure,
+		svc.ListWebhookDeliveries,
+		connect.WithSchema(userServiceMethods.ByName("ListWebhookDeliveries")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceRequestDataExportHandler := connect.NewUnaryHandler(
+		UserServiceRequestDataExportProcedure,
+		svc.RequestDataExport,
+		connect.WithSchema(userServiceMethods.ByName("RequestDataExport")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListDataExportsHandler := connect.NewUnaryHandler(
+		UserServiceListDataExportsProcedure,
+		svc.ListDataExports,
+		connect.WithSchema(userServiceMethods.ByName("ListDataExports")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceDownloadDataExportHandler := connect.NewServerStreamHandler(
+		UserServiceDownloadDataExportProcedure,
+		svc.DownloadDataExport,
+		connect.WithSchema(userServiceMethods.ByName("DownloadDataExport")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceUpdateBackupSettingsHandler := connect.NewUnaryHandler(
+		UserServiceUpdateBackupSettingsProcedure,
+		svc.UpdateBackupSettings,
+		connect.WithSchema(userServiceMethods.ByName("UpdateBackupSettings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListSecurityEventsHandler := connect.NewUnaryHandler(
+		UserServiceListSecurityEventsProcedure,
+		svc.ListSecurityEvents,
+		connect.WithSchema(userServiceMethods.ByName("ListSecurityEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceGetUsageHandler := connect.NewUnaryHandler(
+		UserServiceGetUsageProcedure,
+		svc.GetUsage,
+		connect.WithSchema(userServiceMethods.ByName("GetUsage")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/user.UserService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case UserServiceUpdateUserNameProcedure:
@@ -596,6 +1035,26 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 			userServiceListApiKeysHandler.ServeHTTP(w, r)
 		case UserServiceDeleteApiKeyProcedure:
 			userServiceDeleteApiKeyHandler.ServeHTTP(w, r)
+		case UserServiceCreateWebhookProcedure:
+			userServiceCreateWebhookHandler.ServeHTTP(w, r)
+		case UserServiceListWebhooksProcedure:
+			userServiceListWebhooksHandler.ServeHTTP(w, r)
+		case UserServiceDeleteWebhookProcedure:
+			userServiceDeleteWebhookHandler.ServeHTTP(w, r)
+		case UserServiceListWebhookDeliveriesProcedure:
+			userServiceListWebhookDeliveriesHandler.ServeHTTP(w, r)
+		case UserServiceRequestDataExportProcedure:
+			userServiceRequestDataExportHandler.ServeHTTP(w, r)
+		case UserServiceListDataExportsProcedure:
+			userServiceListDataExportsHandler.ServeHTTP(w, r)
+		case UserServiceDownloadDataExportProcedure:
+			userServiceDownloadDataExportHandler.ServeHTTP(w, r)
+		case UserServiceUpdateBackupSettingsProcedure:
+			userServiceUpdateBackupSettingsHandler.ServeHTTP(w, r)
+		case UserServiceListSecurityEventsProcedure:
+			userServiceListSecurityEventsHandler.ServeHTTP(w, r)
+		case UserServiceGetUsageProcedure:
+			userServiceGetUsageHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -652,3 +1111,43 @@ func (UnimplementedUserServiceHandler) ListApiKeys(context.Context, *connect.Req
 func (UnimplementedUserServiceHandler) DeleteApiKey(context.Context, *connect.Request[grpc.DeleteApiKeyRequest]) (*connect.Response[grpc.DeleteApiKeyResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.DeleteApiKey is not implemented"))
 }
+
+func (UnimplementedUserServiceHandler) CreateWebhook(context.Context, *connect.Request[grpc.CreateWebhookRequest]) (*connect.Response[grpc.CreateWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.CreateWebhook is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListWebhooks(context.Context, *connect.Request[grpc.ListWebhooksRequest]) (*connect.Response[grpc.ListWebhooksResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.ListWebhooks is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) DeleteWebhook(context.Context, *connect.Request[grpc.DeleteWebhookRequest]) (*connect.Response[grpc.DeleteWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.DeleteWebhook is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListWebhookDeliveries(context.Context, *connect.Request[grpc.ListWebhookDeliveriesRequest]) (*connect.Response[grpc.ListWebhookDeliveriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.ListWebhookDeliveries is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) RequestDataExport(context.Context, *connect.Request[grpc.RequestDataExportRequest]) (*connect.Response[grpc.RequestDataExportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.RequestDataExport is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListDataExports(context.Context, *connect.Request[grpc.ListDataExportsRequest]) (*connect.Response[grpc.ListDataExportsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.ListDataExports is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) DownloadDataExport(context.Context, *connect.Request[grpc.DownloadDataExportRequest], *connect.ServerStream[grpc.DownloadDataExportResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.DownloadDataExport is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) UpdateBackupSettings(context.Context, *connect.Request[grpc.UpdateBackupSettingsRequest]) (*connect.Response[grpc.UpdateBackupSettingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.UpdateBackupSettings is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListSecurityEvents(context.Context, *connect.Request[grpc.ListSecurityEventsRequest]) (*connect.Response[grpc.ListSecurityEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.ListSecurityEvents is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) GetUsage(context.Context, *connect.Request[grpc.GetUsageRequest]) (*connect.Response[grpc.GetUsageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("user.UserService.GetUsage is not implemented"))
+}
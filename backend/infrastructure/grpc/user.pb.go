@@ -374,7 +374,11 @@ type GetUserInfoResponse struct {
 	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Email string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
 	// LLMキー情報（存在する場合）
-	LlmKeys       []*LLMKeyInfo `protobuf:"bytes,3,rep,name=llm_keys,json=llmKeys,proto3" json:"llm_keys,omitempty"`
+	LlmKeys []*LLMKeyInfo `protobuf:"bytes,3,rep,name=llm_keys,json=llmKeys,proto3" json:"llm_keys,omitempty"`
+	// 定期バックアップの設定と実行結果（未設定の場合は含まれない）
+	Backup *BackupStatus `protobuf:"bytes,4,opt,name=backup,proto3" json:"backup,omitempty"`
+	// メールアドレスを確認済みか（未確認の場合はAuthServiceのRequestEmailVerificationで確認のメールを送り直せる）
+	EmailVerified bool `protobuf:"varint,5,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -430,6 +434,20 @@ func (x *GetUserInfoResponse) GetLlmKeys() []*LLMKeyInfo {
 	return nil
 }
 
+func (x *GetUserInfoResponse) GetBackup() *BackupStatus {
+	if x != nil {
+		return x.Backup
+	}
+	return nil
+}
+
+func (x *GetUserInfoResponse) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
+}
+
 // LLMキー情報
 type LLMKeyInfo struct {
 	state                  protoimpl.MessageState `protogen:"open.v1"`
@@ -438,6 +456,7 @@ type LLMKeyInfo struct {
 	AutoSummaryMonthly     bool                   `protobuf:"varint,4,opt,name=auto_summary_monthly,json=autoSummaryMonthly,proto3" json:"auto_summary_monthly,omitempty"`               // 月毎の自動要約生成
 	AutoLatestTrendEnabled bool                   `protobuf:"varint,5,opt,name=auto_latest_trend_enabled,json=autoLatestTrendEnabled,proto3" json:"auto_latest_trend_enabled,omitempty"` // 直近トレンド分析の自動生成
 	SemanticSearchEnabled  bool                   `protobuf:"varint,6,opt,name=semantic_search_enabled,json=semanticSearchEnabled,proto3" json:"semantic_search_enabled,omitempty"`      // 意味的検索（RAG）機能の有効化
+	AutoTaggingEnabled     bool                   `protobuf:"varint,7,opt,name=auto_tagging_enabled,json=autoTaggingEnabled,proto3" json:"auto_tagging_enabled,omitempty"`               // 新しい日記へのタグの自動提案
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
@@ -507,6 +526,13 @@ func (x *LLMKeyInfo) GetSemanticSearchEnabled() bool {
 	return false
 }
 
+func (x *LLMKeyInfo) GetAutoTaggingEnabled() bool {
+	if x != nil {
+		return x.AutoTaggingEnabled
+	}
+	return false
+}
+
 // LLMキー削除用のリクエスト
 type DeleteLLMKeyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -644,11 +670,12 @@ func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
 
 // アカウント削除用のレスポンス
 type DeleteAccountResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Success             bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message             string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	DeletionScheduledAt int64                  `protobuf:"varint,3,opt,name=deletion_scheduled_at,json=deletionScheduledAt,proto3" json:"deletion_scheduled_at,omitempty"` // データを完全に削除する予定の日時（UNIX秒）
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *DeleteAccountResponse) Reset() {
@@ -695,6 +722,13 @@ func (x *DeleteAccountResponse) GetMessage() string {
 	return ""
 }
 
+func (x *DeleteAccountResponse) GetDeletionScheduledAt() int64 {
+	if x != nil {
+		return x.DeletionScheduledAt
+	}
+	return 0
+}
+
 // 自動要約設定更新用のリクエスト
 type UpdateAutoSummarySettingsRequest struct {
 	state                  protoimpl.MessageState `protogen:"open.v1"`
@@ -702,6 +736,7 @@ type UpdateAutoSummarySettingsRequest struct {
 	AutoSummaryMonthly     bool                   `protobuf:"varint,3,opt,name=auto_summary_monthly,json=autoSummaryMonthly,proto3" json:"auto_summary_monthly,omitempty"`               // 月毎の自動要約生成
 	AutoLatestTrendEnabled bool                   `protobuf:"varint,4,opt,name=auto_latest_trend_enabled,json=autoLatestTrendEnabled,proto3" json:"auto_latest_trend_enabled,omitempty"` // 直近トレンド分析の自動生成
 	SemanticSearchEnabled  bool                   `protobuf:"varint,5,opt,name=semantic_search_enabled,json=semanticSearchEnabled,proto3" json:"semantic_search_enabled,omitempty"`      // 意味的検索（RAG）機能の有効化
+	AutoTaggingEnabled     bool                   `protobuf:"varint,6,opt,name=auto_tagging_enabled,json=autoTaggingEnabled,proto3" json:"auto_tagging_enabled,omitempty"`               // 新しい日記へのタグの自動提案
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
@@ -764,6 +799,13 @@ func (x *UpdateAutoSummarySettingsRequest) GetSemanticSearchEnabled() bool {
 	return false
 }
 
+func (x *UpdateAutoSummarySettingsRequest) GetAutoTaggingEnabled() bool {
+	if x != nil {
+		return x.AutoTaggingEnabled
+	}
+	return false
+}
+
 // 自動要約設定更新用のレスポンス
 type UpdateAutoSummarySettingsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -868,6 +910,7 @@ type GetAutoSummarySettingsResponse struct {
 	AutoSummaryMonthly     bool                   `protobuf:"varint,2,opt,name=auto_summary_monthly,json=autoSummaryMonthly,proto3" json:"auto_summary_monthly,omitempty"`               // 月毎の自動要約生成
 	AutoLatestTrendEnabled bool                   `protobuf:"varint,3,opt,name=auto_latest_trend_enabled,json=autoLatestTrendEnabled,proto3" json:"auto_latest_trend_enabled,omitempty"` // 直近トレンド分析の自動生成
 	SemanticSearchEnabled  bool                   `protobuf:"varint,4,opt,name=semantic_search_enabled,json=semanticSearchEnabled,proto3" json:"semantic_search_enabled,omitempty"`      // 意味的検索（RAG）機能の有効化
+	AutoTaggingEnabled     bool                   `protobuf:"varint,5,opt,name=auto_tagging_enabled,json=autoTaggingEnabled,proto3" json:"auto_tagging_enabled,omitempty"`               // 新しい日記へのタグの自動提案
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
@@ -923,6 +966,13 @@ func (x *GetAutoSummarySettingsResponse) GetSemanticSearchEnabled() bool {
 	return false
 }
 
+func (x *GetAutoSummarySettingsResponse) GetAutoTaggingEnabled() bool {
+	if x != nil {
+		return x.AutoTaggingEnabled
+	}
+	return false
+}
+
 // Pub/Subメトリクス取得用のリクエスト
 type GetPubSubMetricsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1658,219 +1708,2278 @@ func (x *DeleteApiKeyResponse) GetMessage() string {
 	return ""
 }
 
-var File_user_user_proto protoreflect.FileDescriptor
+// Webhook情報（シークレットは含まない）
+type WebhookInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`       // 通知先URL
+	Events        []string               `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"` // 通知対象のイベント名
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_user_user_proto_rawDesc = "" +
-	"\n" +
-	"\x0fuser/user.proto\x12\x04user\"2\n" +
-	"\x15UpdateUserNameRequest\x12\x19\n" +
-	"\bnew_name\x18\x01 \x01(\tR\anewName\"L\n" +
-	"\x16UpdateUserNameResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"e\n" +
-	"\x15ChangePasswordRequest\x12)\n" +
-	"\x10current_password\x18\x01 \x01(\tR\x0fcurrentPassword\x12!\n" +
-	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"L\n" +
-	"\x16ChangePasswordResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"J\n" +
-	"\x13UpdateLLMKeyRequest\x12!\n" +
-	"\fllm_provider\x18\x01 \x01(\x05R\vllmProvider\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\tR\x03key\"J\n" +
-	"\x14UpdateLLMKeyResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x14\n" +
-	"\x12GetUserInfoRequest\"l\n" +
-	"\x13GetUserInfoResponse\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
-	"\x05email\x18\x02 \x01(\tR\x05email\x12+\n" +
-	"\bllm_keys\x18\x03 \x03(\v2\x10.user.LLMKeyInfoR\allmKeys\"\xe6\x01\n" +
-	"\n" +
-	"LLMKeyInfo\x12!\n" +
-	"\fllm_provider\x18\x01 \x01(\x05R\vllmProvider\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\tR\x03key\x120\n" +
-	"\x14auto_summary_monthly\x18\x04 \x01(\bR\x12autoSummaryMonthly\x129\n" +
-	"\x19auto_latest_trend_enabled\x18\x05 \x01(\bR\x16autoLatestTrendEnabled\x126\n" +
-	"\x17semantic_search_enabled\x18\x06 \x01(\bR\x15semanticSearchEnabled\"8\n" +
-	"\x13DeleteLLMKeyRequest\x12!\n" +
-	"\fllm_provider\x18\x01 \x01(\x05R\vllmProvider\"J\n" +
-	"\x14DeleteLLMKeyResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x16\n" +
-	"\x14DeleteAccountRequest\"K\n" +
-	"\x15DeleteAccountResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xea\x01\n" +
-	" UpdateAutoSummarySettingsRequest\x12!\n" +
-	"\fllm_provider\x18\x01 \x01(\x05R\vllmProvider\x120\n" +
-	"\x14auto_summary_monthly\x18\x03 \x01(\bR\x12autoSummaryMonthly\x129\n" +
-	"\x19auto_latest_trend_enabled\x18\x04 \x01(\bR\x16autoLatestTrendEnabled\x126\n" +
-	"\x17semantic_search_enabled\x18\x05 \x01(\bR\x15semanticSearchEnabled\"W\n" +
-	"!UpdateAutoSummarySettingsResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"B\n" +
-	"\x1dGetAutoSummarySettingsRequest\x12!\n" +
-	"\fllm_provider\x18\x01 \x01(\x05R\vllmProvider\"\xc5\x01\n" +
-	"\x1eGetAutoSummarySettingsResponse\x120\n" +
-	"\x14auto_summary_monthly\x18\x02 \x01(\bR\x12autoSummaryMonthly\x129\n" +
-	"\x19auto_latest_trend_enabled\x18\x03 \x01(\bR\x16autoLatestTrendEnabled\x126\n" +
-	"\x17semantic_search_enabled\x18\x04 \x01(\bR\x15semanticSearchEnabled\"\x19\n" +
-	"\x17GetPubSubMetricsRequest\"\xc7\x01\n" +
-	"\x18GetPubSubMetricsResponse\x12:\n" +
-	"\x0ehourly_metrics\x18\x01 \x03(\v2\x13.user.HourlyMetricsR\rhourlyMetrics\x12?\n" +
-	"\x10processing_tasks\x18\x02 \x03(\v2\x14.user.ProcessingTaskR\x0fprocessingTasks\x12.\n" +
-	"\asummary\x18\x03 \x01(\v2\x14.user.MetricsSummaryR\asummary\"\xc7\x03\n" +
-	"\rHourlyMetrics\x12\x1c\n" +
-	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12>\n" +
-	"\x1bmonthly_summaries_processed\x18\x03 \x01(\x05R\x19monthlySummariesProcessed\x128\n" +
-	"\x18monthly_summaries_failed\x18\x05 \x01(\x05R\x16monthlySummariesFailed\x126\n" +
-	"\x17latest_trends_processed\x18\x06 \x01(\x05R\x15latestTrendsProcessed\x120\n" +
-	"\x14latest_trends_failed\x18\a \x01(\x05R\x12latestTrendsFailed\x12<\n" +
-	"\x1adiary_embeddings_processed\x18\b \x01(\x05R\x18diaryEmbeddingsProcessed\x126\n" +
-	"\x17diary_embeddings_failed\x18\t \x01(\x05R\x15diaryEmbeddingsFailed\x12>\n" +
-	"\x1bsemantic_searches_processed\x18\n" +
-	" \x01(\x05R\x19semanticSearchesProcessed\"`\n" +
-	"\x0eProcessingTask\x12\x1b\n" +
-	"\ttask_type\x18\x01 \x01(\tR\btaskType\x12\x12\n" +
-	"\x04date\x18\x02 \x01(\tR\x04date\x12\x1d\n" +
-	"\n" +
-	"started_at\x18\x03 \x01(\x03R\tstartedAt\"\x85\x04\n" +
-	"\x0eMetricsSummary\x126\n" +
-	"\x17total_monthly_summaries\x18\x02 \x01(\x05R\x15totalMonthlySummaries\x12:\n" +
-	"\x19pending_monthly_summaries\x18\x04 \x01(\x05R\x17pendingMonthlySummaries\x12?\n" +
-	"\x1cauto_summary_monthly_enabled\x18\x06 \x01(\bR\x19autoSummaryMonthlyEnabled\x129\n" +
-	"\x19auto_latest_trend_enabled\x18\a \x01(\bR\x16autoLatestTrendEnabled\x129\n" +
-	"\x19latest_trend_generated_at\x18\b \x01(\tR\x16latestTrendGeneratedAt\x126\n" +
-	"\x17semantic_search_enabled\x18\t \x01(\bR\x15semanticSearchEnabled\x12)\n" +
-	"\x10total_embeddings\x18\n" +
-	" \x01(\x05R\x0ftotalEmbeddings\x12-\n" +
-	"\x12pending_embeddings\x18\v \x01(\x05R\x11pendingEmbeddings\x126\n" +
-	"\x17total_embedding_diaries\x18\f \x01(\x05R\x15totalEmbeddingDiaries\"\xaf\x01\n" +
-	"\n" +
-	"ApiKeyInfo\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
-	"\n" +
-	"key_prefix\x18\x03 \x01(\tR\tkeyPrefix\x12 \n" +
-	"\flast_used_at\x18\x04 \x01(\x03R\n" +
-	"lastUsedAt\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"expires_at\x18\x06 \x01(\x03R\texpiresAt\")\n" +
-	"\x13CreateApiKeyRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"U\n" +
-	"\x14CreateApiKeyResponse\x12\x17\n" +
-	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12$\n" +
-	"\x04info\x18\x02 \x01(\v2\x10.user.ApiKeyInfoR\x04info\"\x14\n" +
-	"\x12ListApiKeysRequest\"B\n" +
-	"\x13ListApiKeysResponse\x12+\n" +
-	"\bapi_keys\x18\x01 \x03(\v2\x10.user.ApiKeyInfoR\aapiKeys\"%\n" +
-	"\x13DeleteApiKeyRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"J\n" +
-	"\x14DeleteApiKeyResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xbb\a\n" +
-	"\vUserService\x12K\n" +
-	"\x0eUpdateUserName\x12\x1b.user.UpdateUserNameRequest\x1a\x1c.user.UpdateUserNameResponse\x12K\n" +
-	"\x0eChangePassword\x12\x1b.user.ChangePasswordRequest\x1a\x1c.user.ChangePasswordResponse\x12E\n" +
-	"\fUpdateLLMKey\x12\x19.user.UpdateLLMKeyRequest\x1a\x1a.user.UpdateLLMKeyResponse\x12B\n" +
-	"\vGetUserInfo\x12\x18.user.GetUserInfoRequest\x1a\x19.user.GetUserInfoResponse\x12E\n" +
-	"\fDeleteLLMKey\x12\x19.user.DeleteLLMKeyRequest\x1a\x1a.user.DeleteLLMKeyResponse\x12H\n" +
-	"\rDeleteAccount\x12\x1a.user.DeleteAccountRequest\x1a\x1b.user.DeleteAccountResponse\x12l\n" +
-	"\x19UpdateAutoSummarySettings\x12&.user.UpdateAutoSummarySettingsRequest\x1a'.user.UpdateAutoSummarySettingsResponse\x12c\n" +
-	"\x16GetAutoSummarySettings\x12#.user.GetAutoSummarySettingsRequest\x1a$.user.GetAutoSummarySettingsResponse\x12Q\n" +
-	"\x10GetPubSubMetrics\x12\x1d.user.GetPubSubMetricsRequest\x1a\x1e.user.GetPubSubMetricsResponse\x12E\n" +
-	"\fCreateApiKey\x12\x19.user.CreateApiKeyRequest\x1a\x1a.user.CreateApiKeyResponse\x12B\n" +
-	"\vListApiKeys\x12\x18.user.ListApiKeysRequest\x1a\x19.user.ListApiKeysResponse\x12E\n" +
-	"\fDeleteApiKey\x12\x19.user.DeleteApiKeyRequest\x1a\x1a.user.DeleteApiKeyResponseB@Z>github.com/project-mikan/umi.mikan/backend/infrastructure/grpcb\x06proto3"
+func (x *WebhookInfo) Reset() {
+	*x = WebhookInfo{}
+	mi := &file_user_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_user_user_proto_rawDescOnce sync.Once
-	file_user_user_proto_rawDescData []byte
-)
+func (x *WebhookInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_user_user_proto_rawDescGZIP() []byte {
-	file_user_user_proto_rawDescOnce.Do(func() {
-		file_user_user_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_user_user_proto_rawDesc), len(file_user_user_proto_rawDesc)))
-	})
-	return file_user_user_proto_rawDescData
+func (*WebhookInfo) ProtoMessage() {}
+
+func (x *WebhookInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
-var file_user_user_proto_goTypes = []any{
-	(*UpdateUserNameRequest)(nil),             // 0: user.UpdateUserNameRequest
-	(*UpdateUserNameResponse)(nil),            // 1: user.UpdateUserNameResponse
-	(*ChangePasswordRequest)(nil),             // 2: user.ChangePasswordRequest
-	(*ChangePasswordResponse)(nil),            // 3: user.ChangePasswordResponse
-	(*UpdateLLMKeyRequest)(nil),               // 4: user.UpdateLLMKeyRequest
-	(*UpdateLLMKeyResponse)(nil),              // 5: user.UpdateLLMKeyResponse
-	(*GetUserInfoRequest)(nil),                // 6: user.GetUserInfoRequest
-	(*GetUserInfoResponse)(nil),               // 7: user.GetUserInfoResponse
-	(*LLMKeyInfo)(nil),                        // 8: user.LLMKeyInfo
-	(*DeleteLLMKeyRequest)(nil),               // 9: user.DeleteLLMKeyRequest
-	(*DeleteLLMKeyResponse)(nil),              // 10: user.DeleteLLMKeyResponse
-	(*DeleteAccountRequest)(nil),              // 11: user.DeleteAccountRequest
-	(*DeleteAccountResponse)(nil),             // 12: user.DeleteAccountResponse
-	(*UpdateAutoSummarySettingsRequest)(nil),  // 13: user.UpdateAutoSummarySettingsRequest
-	(*UpdateAutoSummarySettingsResponse)(nil), // 14: user.UpdateAutoSummarySettingsResponse
-	(*GetAutoSummarySettingsRequest)(nil),     // 15: user.GetAutoSummarySettingsRequest
-	(*GetAutoSummarySettingsResponse)(nil),    // 16: user.GetAutoSummarySettingsResponse
-	(*GetPubSubMetricsRequest)(nil),           // 17: user.GetPubSubMetricsRequest
-	(*GetPubSubMetricsResponse)(nil),          // 18: user.GetPubSubMetricsResponse
-	(*HourlyMetrics)(nil),                     // 19: user.HourlyMetrics
-	(*ProcessingTask)(nil),                    // 20: user.ProcessingTask
-	(*MetricsSummary)(nil),                    // 21: user.MetricsSummary
-	(*ApiKeyInfo)(nil),                        // 22: user.ApiKeyInfo
-	(*CreateApiKeyRequest)(nil),               // 23: user.CreateApiKeyRequest
-	(*CreateApiKeyResponse)(nil),              // 24: user.CreateApiKeyResponse
-	(*ListApiKeysRequest)(nil),                // 25: user.ListApiKeysRequest
-	(*ListApiKeysResponse)(nil),               // 26: user.ListApiKeysResponse
-	(*DeleteApiKeyRequest)(nil),               // 27: user.DeleteApiKeyRequest
-	(*DeleteApiKeyResponse)(nil),              // 28: user.DeleteApiKeyResponse
+// Deprecated: Use WebhookInfo.ProtoReflect.Descriptor instead.
+func (*WebhookInfo) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{29}
 }
-var file_user_user_proto_depIdxs = []int32{
-	8,  // 0: user.GetUserInfoResponse.llm_keys:type_name -> user.LLMKeyInfo
-	19, // 1: user.GetPubSubMetricsResponse.hourly_metrics:type_name -> user.HourlyMetrics
-	20, // 2: user.GetPubSubMetricsResponse.processing_tasks:type_name -> user.ProcessingTask
-	21, // 3: user.GetPubSubMetricsResponse.summary:type_name -> user.MetricsSummary
-	22, // 4: user.CreateApiKeyResponse.info:type_name -> user.ApiKeyInfo
-	22, // 5: user.ListApiKeysResponse.api_keys:type_name -> user.ApiKeyInfo
-	0,  // 6: user.UserService.UpdateUserName:input_type -> user.UpdateUserNameRequest
-	2,  // 7: user.UserService.ChangePassword:input_type -> user.ChangePasswordRequest
-	4,  // 8: user.UserService.UpdateLLMKey:input_type -> user.UpdateLLMKeyRequest
-	6,  // 9: user.UserService.GetUserInfo:input_type -> user.GetUserInfoRequest
-	9,  // 10: user.UserService.DeleteLLMKey:input_type -> user.DeleteLLMKeyRequest
-	11, // 11: user.UserService.DeleteAccount:input_type -> user.DeleteAccountRequest
-	13, // 12: user.UserService.UpdateAutoSummarySettings:input_type -> user.UpdateAutoSummarySettingsRequest
-	15, // 13: user.UserService.GetAutoSummarySettings:input_type -> user.GetAutoSummarySettingsRequest
-	17, // 14: user.UserService.GetPubSubMetrics:input_type -> user.GetPubSubMetricsRequest
-	23, // 15: user.UserService.CreateApiKey:input_type -> user.CreateApiKeyRequest
-	25, // 16: user.UserService.ListApiKeys:input_type -> user.ListApiKeysRequest
-	27, // 17: user.UserService.DeleteApiKey:input_type -> user.DeleteApiKeyRequest
-	1,  // 18: user.UserService.UpdateUserName:output_type -> user.UpdateUserNameResponse
-	3,  // 19: user.UserService.ChangePassword:output_type -> user.ChangePasswordResponse
-	5,  // 20: user.UserService.UpdateLLMKey:output_type -> user.UpdateLLMKeyResponse
-	7,  // 21: user.UserService.GetUserInfo:output_type -> user.GetUserInfoResponse
-	10, // 22: user.UserService.DeleteLLMKey:output_type -> user.DeleteLLMKeyResponse
-	12, // 23: user.UserService.DeleteAccount:output_type -> user.DeleteAccountResponse
-	14, // 24: user.UserService.UpdateAutoSummarySettings:output_type -> user.UpdateAutoSummarySettingsResponse
-	16, // 25: user.UserService.GetAutoSummarySettings:output_type -> user.GetAutoSummarySettingsResponse
-	18, // 26: user.UserService.GetPubSubMetrics:output_type -> user.GetPubSubMetricsResponse
-	24, // 27: user.UserService.CreateApiKey:output_type -> user.CreateApiKeyResponse
-	26, // 28: user.UserService.ListApiKeys:output_type -> user.ListApiKeysResponse
-	28, // 29: user.UserService.DeleteApiKey:output_type -> user.DeleteApiKeyResponse
-	18, // [18:30] is the sub-list for method output_type
-	6,  // [6:18] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+
+func (x *WebhookInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
 }
 
-func init() { file_user_user_proto_init() }
-func file_user_user_proto_init() {
-	if File_user_user_proto != nil {
-		return
+func (x *WebhookInfo) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookInfo) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *WebhookInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// Webhook登録用のリクエスト
+type CreateWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`       // 通知先URL（http/httpsのみ）
+	Events        []string               `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"` // diary.created, diary.updated, diary.deleted, diary.restored, summary.generated, trend.generated, highlight.generated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_user_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Webhook登録用のレスポンス
+type CreateWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"` // 署名用シークレット（この登録時にのみ返される）
+	Info          *WebhookInfo           `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookResponse) Reset() {
+	*x = CreateWebhookResponse{}
+	mi := &file_user_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookResponse) ProtoMessage() {}
+
+func (x *CreateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateWebhookResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *CreateWebhookResponse) GetInfo() *WebhookInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+// Webhook一覧取得用のリクエスト
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_user_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{32}
+}
+
+// Webhook一覧取得用のレスポンス
+type ListWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*WebhookInfo         `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_user_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListWebhooksResponse) GetWebhooks() []*WebhookInfo {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+// Webhook削除用のリクエスト
+type DeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_user_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Webhook削除用のレスポンス
+type DeleteWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_user_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteWebhookResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteWebhookResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Webhookの配信ログ
+type WebhookDelivery struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Event          string                 `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`                                          // イベント名
+	Status         string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                                        // pending / succeeded / failed
+	Attempts       int32                  `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`                                   // 送信試行回数
+	ResponseStatus int32                  `protobuf:"varint,5,opt,name=response_status,json=responseStatus,proto3" json:"response_status,omitempty"` // 直近の試行のHTTPステータス（通信エラー時は0）
+	LastError      string                 `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`                 // 直近の試行のエラー内容
+	NextAttemptAt  int64                  `protobuf:"varint,7,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`  // 次回送信予定時刻（Unix秒、pendingの場合のみ有効）
+	DeliveredAt    int64                  `protobuf:"varint,8,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`          // 送信成功日時（Unix秒、未成功の場合は0）
+	CreatedAt      int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_user_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *WebhookDelivery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetResponseStatus() int32 {
+	if x != nil {
+		return x.ResponseStatus
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() int64 {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetDeliveredAt() int64 {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// 配信ログ取得用のリクエスト
+type ListWebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // 取得件数（0の場合は20件、最大100件）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_user_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// 配信ログ取得用のレスポンス
+type ListWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_user_user_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+// 個人データのエクスポート
+type DataExport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                                 // queued / processing / succeeded / failed
+	SizeBytes     int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`         // アーカイブのサイズ（succeededの場合のみ有効）
+	ErrorMessage  string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // 作成に失敗した場合のエラー内容
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt   int64                  `protobuf:"varint,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"` // 作成の完了日時（Unix秒、未完了の場合は0）
+	ExpiresAt     int64                  `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`       // ダウンロードの期限（Unix秒、未完了の場合は0）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataExport) Reset() {
+	*x = DataExport{}
+	mi := &file_user_user_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataExport) ProtoMessage() {}
+
+func (x *DataExport) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataExport.ProtoReflect.Descriptor instead.
+func (*DataExport) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DataExport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DataExport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DataExport) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *DataExport) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *DataExport) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *DataExport) GetCompletedAt() int64 {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return 0
+}
+
+func (x *DataExport) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type RequestDataExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestDataExportRequest) Reset() {
+	*x = RequestDataExportRequest{}
+	mi := &file_user_user_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestDataExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestDataExportRequest) ProtoMessage() {}
+
+func (x *RequestDataExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestDataExportRequest.ProtoReflect.Descriptor instead.
+func (*RequestDataExportRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{40}
+}
+
+type RequestDataExportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Export        *DataExport            `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestDataExportResponse) Reset() {
+	*x = RequestDataExportResponse{}
+	mi := &file_user_user_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestDataExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestDataExportResponse) ProtoMessage() {}
+
+func (x *RequestDataExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestDataExportResponse.ProtoReflect.Descriptor instead.
+func (*RequestDataExportResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RequestDataExportResponse) GetExport() *DataExport {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+type ListDataExportsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDataExportsRequest) Reset() {
+	*x = ListDataExportsRequest{}
+	mi := &file_user_user_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDataExportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDataExportsRequest) ProtoMessage() {}
+
+func (x *ListDataExportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDataExportsRequest.ProtoReflect.Descriptor instead.
+func (*ListDataExportsRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{42}
+}
+
+type ListDataExportsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exports       []*DataExport          `protobuf:"bytes,1,rep,name=exports,proto3" json:"exports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDataExportsResponse) Reset() {
+	*x = ListDataExportsResponse{}
+	mi := &file_user_user_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDataExportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDataExportsResponse) ProtoMessage() {}
+
+func (x *ListDataExportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDataExportsResponse.ProtoReflect.Descriptor instead.
+func (*ListDataExportsResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListDataExportsResponse) GetExports() []*DataExport {
+	if x != nil {
+		return x.Exports
+	}
+	return nil
+}
+
+type DownloadDataExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadDataExportRequest) Reset() {
+	*x = DownloadDataExportRequest{}
+	mi := &file_user_user_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadDataExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadDataExportRequest) ProtoMessage() {}
+
+func (x *DownloadDataExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadDataExportRequest.ProtoReflect.Descriptor instead.
+func (*DownloadDataExportRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DownloadDataExportRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// アーカイブのダウンロードレスポンス（ストリームの最初はexport、以降はchunk）
+type DownloadDataExportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*DownloadDataExportResponse_Export
+	//	*DownloadDataExportResponse_Chunk
+	Payload       isDownloadDataExportResponse_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadDataExportResponse) Reset() {
+	*x = DownloadDataExportResponse{}
+	mi := &file_user_user_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadDataExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadDataExportResponse) ProtoMessage() {}
+
+func (x *DownloadDataExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadDataExportResponse.ProtoReflect.Descriptor instead.
+func (*DownloadDataExportResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DownloadDataExportResponse) GetPayload() isDownloadDataExportResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *DownloadDataExportResponse) GetExport() *DataExport {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadDataExportResponse_Export); ok {
+			return x.Export
+		}
+	}
+	return nil
+}
+
+func (x *DownloadDataExportResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadDataExportResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadDataExportResponse_Payload interface {
+	isDownloadDataExportResponse_Payload()
+}
+
+type DownloadDataExportResponse_Export struct {
+	Export *DataExport `protobuf:"bytes,1,opt,name=export,proto3,oneof"`
+}
+
+type DownloadDataExportResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DownloadDataExportResponse_Export) isDownloadDataExportResponse_Payload() {}
+
+func (*DownloadDataExportResponse_Chunk) isDownloadDataExportResponse_Payload() {}
+
+// S3互換ストレージのバックアップの保存先
+type S3BackupDestination struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint        string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"` // "https://s3.ap-northeast-1.amazonaws.com" など
+	Region          string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	Bucket          string                 `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	AccessKeyId     string                 `protobuf:"bytes,4,opt,name=access_key_id,json=accessKeyId,proto3" json:"access_key_id,omitempty"`
+	SecretAccessKey string                 `protobuf:"bytes,5,opt,name=secret_access_key,json=secretAccessKey,proto3" json:"secret_access_key,omitempty"` // 更新時は空の場合は変更しない。レスポンスでは常に空
+	UsePathStyle    bool                   `protobuf:"varint,6,opt,name=use_path_style,json=usePathStyle,proto3" json:"use_path_style,omitempty"`         // バケット名をパスに含める（MinIOなど）
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *S3BackupDestination) Reset() {
+	*x = S3BackupDestination{}
+	mi := &file_user_user_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *S3BackupDestination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S3BackupDestination) ProtoMessage() {}
+
+func (x *S3BackupDestination) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S3BackupDestination.ProtoReflect.Descriptor instead.
+func (*S3BackupDestination) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *S3BackupDestination) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *S3BackupDestination) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *S3BackupDestination) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *S3BackupDestination) GetAccessKeyId() string {
+	if x != nil {
+		return x.AccessKeyId
+	}
+	return ""
+}
+
+func (x *S3BackupDestination) GetSecretAccessKey() string {
+	if x != nil {
+		return x.SecretAccessKey
+	}
+	return ""
+}
+
+func (x *S3BackupDestination) GetUsePathStyle() bool {
+	if x != nil {
+		return x.UsePathStyle
+	}
+	return false
+}
+
+// WebDAVのバックアップの保存先
+type WebDAVBackupDestination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"` // 保存先のコレクションのURL
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"` // 更新時は空の場合は変更しない。レスポンスでは常に空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebDAVBackupDestination) Reset() {
+	*x = WebDAVBackupDestination{}
+	mi := &file_user_user_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebDAVBackupDestination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebDAVBackupDestination) ProtoMessage() {}
+
+func (x *WebDAVBackupDestination) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebDAVBackupDestination.ProtoReflect.Descriptor instead.
+func (*WebDAVBackupDestination) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *WebDAVBackupDestination) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebDAVBackupDestination) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *WebDAVBackupDestination) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// 定期バックアップの設定と実行結果
+type BackupStatus struct {
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	Enabled        bool                     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Destination    string                   `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"` // local / s3 / webdav
+	Prefix         string                   `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`           // 保存先でのキーの接頭辞
+	IntervalHours  int32                    `protobuf:"varint,4,opt,name=interval_hours,json=intervalHours,proto3" json:"interval_hours,omitempty"`
+	RetentionCount int32                    `protobuf:"varint,5,opt,name=retention_count,json=retentionCount,proto3" json:"retention_count,omitempty"`
+	Encrypted      bool                     `protobuf:"varint,6,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	S3             *S3BackupDestination     `protobuf:"bytes,7,opt,name=s3,proto3" json:"s3,omitempty"`                                                // destinationがs3の場合のみ
+	Webdav         *WebDAVBackupDestination `protobuf:"bytes,8,opt,name=webdav,proto3" json:"webdav,omitempty"`                                        // destinationがwebdavの場合のみ
+	LastRunAt      int64                    `protobuf:"varint,9,opt,name=last_run_at,json=lastRunAt,proto3" json:"last_run_at,omitempty"`              // 最後に実行した日時（Unix秒、未実行の場合は0）
+	LastStatus     string                   `protobuf:"bytes,10,opt,name=last_status,json=lastStatus,proto3" json:"last_status,omitempty"`             // succeeded / failed（未実行の場合は空）
+	LastError      string                   `protobuf:"bytes,11,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`                // 最後の実行が失敗した場合のエラー内容
+	LastSizeBytes  int64                    `protobuf:"varint,12,opt,name=last_size_bytes,json=lastSizeBytes,proto3" json:"last_size_bytes,omitempty"` // 最後に成功したバックアップのサイズ
+	NextRunAt      int64                    `protobuf:"varint,13,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`             // 次回の実行予定日時（Unix秒）
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BackupStatus) Reset() {
+	*x = BackupStatus{}
+	mi := &file_user_user_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupStatus) ProtoMessage() {}
+
+func (x *BackupStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupStatus.ProtoReflect.Descriptor instead.
+func (*BackupStatus) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *BackupStatus) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *BackupStatus) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *BackupStatus) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *BackupStatus) GetIntervalHours() int32 {
+	if x != nil {
+		return x.IntervalHours
+	}
+	return 0
+}
+
+func (x *BackupStatus) GetRetentionCount() int32 {
+	if x != nil {
+		return x.RetentionCount
+	}
+	return 0
+}
+
+func (x *BackupStatus) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
+}
+
+func (x *BackupStatus) GetS3() *S3BackupDestination {
+	if x != nil {
+		return x.S3
+	}
+	return nil
+}
+
+func (x *BackupStatus) GetWebdav() *WebDAVBackupDestination {
+	if x != nil {
+		return x.Webdav
+	}
+	return nil
+}
+
+func (x *BackupStatus) GetLastRunAt() int64 {
+	if x != nil {
+		return x.LastRunAt
+	}
+	return 0
+}
+
+func (x *BackupStatus) GetLastStatus() string {
+	if x != nil {
+		return x.LastStatus
+	}
+	return ""
+}
+
+func (x *BackupStatus) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *BackupStatus) GetLastSizeBytes() int64 {
+	if x != nil {
+		return x.LastSizeBytes
+	}
+	return 0
+}
+
+func (x *BackupStatus) GetNextRunAt() int64 {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return 0
+}
+
+type UpdateBackupSettingsRequest struct {
+	state             protoimpl.MessageState   `protogen:"open.v1"`
+	Enabled           bool                     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Destination       string                   `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`                                       // local / s3 / webdav
+	Prefix            string                   `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`                                                 // 保存先でのキーの接頭辞（"umi-mikan/backups" など、空の場合は保存先の直下）
+	IntervalHours     int32                    `protobuf:"varint,4,opt,name=interval_hours,json=intervalHours,proto3" json:"interval_hours,omitempty"`             // 1〜720（0の場合は24）
+	RetentionCount    int32                    `protobuf:"varint,5,opt,name=retention_count,json=retentionCount,proto3" json:"retention_count,omitempty"`          // 1〜100（0の場合は7）
+	S3                *S3BackupDestination     `protobuf:"bytes,6,opt,name=s3,proto3" json:"s3,omitempty"`                                                         // destinationがs3の場合に必須
+	Webdav            *WebDAVBackupDestination `protobuf:"bytes,7,opt,name=webdav,proto3" json:"webdav,omitempty"`                                                 // destinationがwebdavの場合に必須
+	Passphrase        string                   `protobuf:"bytes,8,opt,name=passphrase,proto3" json:"passphrase,omitempty"`                                         // 指定すると暗号化の鍵を作り直す（12文字以上）。空の場合は変更しない
+	DisableEncryption bool                     `protobuf:"varint,9,opt,name=disable_encryption,json=disableEncryption,proto3" json:"disable_encryption,omitempty"` // 暗号化をやめる（passphraseより優先）
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateBackupSettingsRequest) Reset() {
+	*x = UpdateBackupSettingsRequest{}
+	mi := &file_user_user_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBackupSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBackupSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateBackupSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBackupSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBackupSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *UpdateBackupSettingsRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *UpdateBackupSettingsRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *UpdateBackupSettingsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *UpdateBackupSettingsRequest) GetIntervalHours() int32 {
+	if x != nil {
+		return x.IntervalHours
+	}
+	return 0
+}
+
+func (x *UpdateBackupSettingsRequest) GetRetentionCount() int32 {
+	if x != nil {
+		return x.RetentionCount
+	}
+	return 0
+}
+
+func (x *UpdateBackupSettingsRequest) GetS3() *S3BackupDestination {
+	if x != nil {
+		return x.S3
+	}
+	return nil
+}
+
+func (x *UpdateBackupSettingsRequest) GetWebdav() *WebDAVBackupDestination {
+	if x != nil {
+		return x.Webdav
+	}
+	return nil
+}
+
+func (x *UpdateBackupSettingsRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+func (x *UpdateBackupSettingsRequest) GetDisableEncryption() bool {
+	if x != nil {
+		return x.DisableEncryption
+	}
+	return false
+}
+
+type UpdateBackupSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backup        *BackupStatus          `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBackupSettingsResponse) Reset() {
+	*x = UpdateBackupSettingsResponse{}
+	mi := &file_user_user_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBackupSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBackupSettingsResponse) ProtoMessage() {}
+
+func (x *UpdateBackupSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBackupSettingsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBackupSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *UpdateBackupSettingsResponse) GetBackup() *BackupStatus {
+	if x != nil {
+		return x.Backup
+	}
+	return nil
+}
+
+// セキュリティイベント
+type SecurityEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`  // login_success / login_failure / token_refresh / password_change / api_key_create / api_key_delete / api_key_use / oauth_consent / account_deletion_request / account_deletion_cancel
+	IpAddress     string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`  // 取得できない場合は unknown
+	UserAgent     string                 `protobuf:"bytes,4,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`  // 取得できない場合は unknown
+	Detail        string                 `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`                         // イベントの詳細（JSON、ログインの方法やAPIキーのIDなど）
+	Suspicious    bool                   `protobuf:"varint,6,opt,name=suspicious,proto3" json:"suspicious,omitempty"`                // それまでのログインと異なるIPアドレス・User-Agentからのログイン
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // 発生した日時（Unix秒）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecurityEvent) Reset() {
+	*x = SecurityEvent{}
+	mi := &file_user_user_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecurityEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityEvent) ProtoMessage() {}
+
+func (x *SecurityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityEvent.ProtoReflect.Descriptor instead.
+func (*SecurityEvent) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SecurityEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SecurityEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *SecurityEvent) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *SecurityEvent) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *SecurityEvent) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *SecurityEvent) GetSuspicious() bool {
+	if x != nil {
+		return x.Suspicious
+	}
+	return false
+}
+
+func (x *SecurityEvent) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ListSecurityEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // 指定した場合はその種類に絞り込む
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`                        // 前回のnext_cursor（初回は空）
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                         // 返す件数（デフォルト50、最大200）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecurityEventsRequest) Reset() {
+	*x = ListSecurityEventsRequest{}
+	mi := &file_user_user_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecurityEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecurityEventsRequest) ProtoMessage() {}
+
+func (x *ListSecurityEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecurityEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListSecurityEventsRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListSecurityEventsRequest) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *ListSecurityEventsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListSecurityEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListSecurityEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*SecurityEvent       `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecurityEventsResponse) Reset() {
+	*x = ListSecurityEventsResponse{}
+	mi := &file_user_user_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecurityEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecurityEventsResponse) ProtoMessage() {}
+
+func (x *ListSecurityEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecurityEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListSecurityEventsResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListSecurityEventsResponse) GetEvents() []*SecurityEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListSecurityEventsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListSecurityEventsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+// RPCごとのレート制限の使用状況
+type RateLimitUsage struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Method         string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`                                          // gRPCのフルメソッド名、またはMCPのツール（mcp/<ツール名>）
+	Limit          int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                           // ウィンドウの間に呼び出せる回数
+	WindowSeconds  int64                  `protobuf:"varint,3,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`      // 回数を数える期間（秒）
+	Used           int32                  `protobuf:"varint,4,opt,name=used,proto3" json:"used,omitempty"`                                             // 現在のウィンドウでの使用回数
+	Remaining      int32                  `protobuf:"varint,5,opt,name=remaining,proto3" json:"remaining,omitempty"`                                   // 残りの回数
+	ResetInSeconds int64                  `protobuf:"varint,6,opt,name=reset_in_seconds,json=resetInSeconds,proto3" json:"reset_in_seconds,omitempty"` // 最も古い呼び出しがウィンドウから外れるまでの秒数（使っていない場合は0）
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RateLimitUsage) Reset() {
+	*x = RateLimitUsage{}
+	mi := &file_user_user_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitUsage) ProtoMessage() {}
+
+func (x *RateLimitUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitUsage.ProtoReflect.Descriptor instead.
+func (*RateLimitUsage) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RateLimitUsage) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *RateLimitUsage) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RateLimitUsage) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *RateLimitUsage) GetUsed() int32 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *RateLimitUsage) GetRemaining() int32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *RateLimitUsage) GetResetInSeconds() int64 {
+	if x != nil {
+		return x.ResetInSeconds
+	}
+	return 0
+}
+
+// APIキーごとの使用状況
+type ApiKeyUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeyId      string                 `protobuf:"bytes,1,opt,name=api_key_id,json=apiKeyId,proto3" json:"api_key_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Usages        []*RateLimitUsage      `protobuf:"bytes,3,rep,name=usages,proto3" json:"usages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiKeyUsage) Reset() {
+	*x = ApiKeyUsage{}
+	mi := &file_user_user_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiKeyUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiKeyUsage) ProtoMessage() {}
+
+func (x *ApiKeyUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiKeyUsage.ProtoReflect.Descriptor instead.
+func (*ApiKeyUsage) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ApiKeyUsage) GetApiKeyId() string {
+	if x != nil {
+		return x.ApiKeyId
+	}
+	return ""
+}
+
+func (x *ApiKeyUsage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiKeyUsage) GetUsages() []*RateLimitUsage {
+	if x != nil {
+		return x.Usages
+	}
+	return nil
+}
+
+type GetUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	mi := &file_user_user_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{56}
+}
+
+type GetUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usages        []*RateLimitUsage      `protobuf:"bytes,1,rep,name=usages,proto3" json:"usages,omitempty"`                  // ログイン（JWT）のバケット
+	ApiKeys       []*ApiKeyUsage         `protobuf:"bytes,2,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"` // APIキーごとのバケット（作成日時の降順）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	mi := &file_user_user_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetUsageResponse) GetUsages() []*RateLimitUsage {
+	if x != nil {
+		return x.Usages
+	}
+	return nil
+}
+
+func (x *GetUsageResponse) GetApiKeys() []*ApiKeyUsage {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+var File_user_user_proto protoreflect.FileDescriptor
+
+const file_user_user_proto_rawDesc = "" +
+	"\n" +
+	"\x0fuser/user.proto\x12\x04user\"2\n" +
+	"\x15UpdateUserNameRequest\x12\x19\n" +
+	"\bnew_name\x18\x01 \x01(\tR\anewName\"L\n" +
+	"\x16UpdateUserNameResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"e\n" +
+	"\x15ChangePasswordRequest\x12)\n" +
+	"\x10current_password\x18\x01 \x01(\tR\x0fcurrentPassword\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"L\n" +
+	"\x16ChangePasswordResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"J\n" +
+	"\x13UpdateLLMKeyRequest\x12!\n" +
+	"\fllm_provider\x18\x01 \x01(\x05R\vllmProvider\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"J\n" +
+	"\x14UpdateLLMKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x14\n" +
+	"\x12GetUserInfoRequest\"\xbf\x01\n" +
+	"\x13GetUserInfoResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12+\n" +
+	"\bllm_keys\x18\x03 \x03(\v2\x10.user.LLMKeyInfoR\allmKeys\x12*\n" +
+	"\x06backup\x18\x04 \x01(\v2\x12.user.BackupStatusR\x06backup\x12%\n" +
+	"\x0eemail_verified\x18\x05 \x01(\bR\remailVerified\"\x98\x02\n" +
+	"\n" +
+	"LLMKeyInfo\x12!\n" +
+	"\fllm_provider\x18\x01 \x01(\x05R\vllmProvider\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x120\n" +
+	"\x14auto_summary_monthly\x18\x04 \x01(\bR\x12autoSummaryMonthly\x129\n" +
+	"\x19auto_latest_trend_enabled\x18\x05 \x01(\bR\x16autoLatestTrendEnabled\x126\n" +
+	"\x17semantic_search_enabled\x18\x06 \x01(\bR\x15semanticSearchEnabled\x120\n" +
+	"\x14auto_tagging_enabled\x18\a \x01(\bR\x12autoTaggingEnabled\"8\n" +
+	"\x13DeleteLLMKeyRequest\x12!\n" +
+	"\fllm_provider\x18\x01 \x01(\x05R\vllmProvider\"J\n" +
+	"\x14DeleteLLMKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x16\n" +
+	"\x14DeleteAccountRequest\"\x7f\n" +
+	"\x15DeleteAccountResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\x15deletion_scheduled_at\x18\x03 \x01(\x03R\x13deletionScheduledAt\"\x9c\x02\n" +
+	" UpdateAutoSummarySettingsRequest\x12!\n" +
+	"\fllm_provider\x18\x01 \x01(\x05R\vllmProvider\x120\n" +
+	"\x14auto_summary_monthly\x18\x03 \x01(\bR\x12autoSummaryMonthly\x129\n" +
+	"\x19auto_latest_trend_enabled\x18\x04 \x01(\bR\x16autoLatestTrendEnabled\x126\n" +
+	"\x17semantic_search_enabled\x18\x05 \x01(\bR\x15semanticSearchEnabled\x120\n" +
+	"\x14auto_tagging_enabled\x18\x06 \x01(\bR\x12autoTaggingEnabled\"W\n" +
+	"!UpdateAutoSummarySettingsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"B\n" +
+	"\x1dGetAutoSummarySettingsRequest\x12!\n" +
+	"\fllm_provider\x18\x01 \x01(\x05R\vllmProvider\"\xf7\x01\n" +
+	"\x1eGetAutoSummarySettingsResponse\x120\n" +
+	"\x14auto_summary_monthly\x18\x02 \x01(\bR\x12autoSummaryMonthly\x129\n" +
+	"\x19auto_latest_trend_enabled\x18\x03 \x01(\bR\x16autoLatestTrendEnabled\x126\n" +
+	"\x17semantic_search_enabled\x18\x04 \x01(\bR\x15semanticSearchEnabled\x120\n" +
+	"\x14auto_tagging_enabled\x18\x05 \x01(\bR\x12autoTaggingEnabled\"\x19\n" +
+	"\x17GetPubSubMetricsRequest\"\xc7\x01\n" +
+	"\x18GetPubSubMetricsResponse\x12:\n" +
+	"\x0ehourly_metrics\x18\x01 \x03(\v2\x13.user.HourlyMetricsR\rhourlyMetrics\x12?\n" +
+	"\x10processing_tasks\x18\x02 \x03(\v2\x14.user.ProcessingTaskR\x0fprocessingTasks\x12.\n" +
+	"\asummary\x18\x03 \x01(\v2\x14.user.MetricsSummaryR\asummary\"\xc7\x03\n" +
+	"\rHourlyMetrics\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12>\n" +
+	"\x1bmonthly_summaries_processed\x18\x03 \x01(\x05R\x19monthlySummariesProcessed\x128\n" +
+	"\x18monthly_summaries_failed\x18\x05 \x01(\x05R\x16monthlySummariesFailed\x126\n" +
+	"\x17latest_trends_processed\x18\x06 \x01(\x05R\x15latestTrendsProcessed\x120\n" +
+	"\x14latest_trends_failed\x18\a \x01(\x05R\x12latestTrendsFailed\x12<\n" +
+	"\x1adiary_embeddings_processed\x18\b \x01(\x05R\x18diaryEmbeddingsProcessed\x126\n" +
+	"\x17diary_embeddings_failed\x18\t \x01(\x05R\x15diaryEmbeddingsFailed\x12>\n" +
+	"\x1bsemantic_searches_processed\x18\n" +
+	" \x01(\x05R\x19semanticSearchesProcessed\"`\n" +
+	"\x0eProcessingTask\x12\x1b\n" +
+	"\ttask_type\x18\x01 \x01(\tR\btaskType\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\x03R\tstartedAt\"\x85\x04\n" +
+	"\x0eMetricsSummary\x126\n" +
+	"\x17total_monthly_summaries\x18\x02 \x01(\x05R\x15totalMonthlySummaries\x12:\n" +
+	"\x19pending_monthly_summaries\x18\x04 \x01(\x05R\x17pendingMonthlySummaries\x12?\n" +
+	"\x1cauto_summary_monthly_enabled\x18\x06 \x01(\bR\x19autoSummaryMonthlyEnabled\x129\n" +
+	"\x19auto_latest_trend_enabled\x18\a \x01(\bR\x16autoLatestTrendEnabled\x129\n" +
+	"\x19latest_trend_generated_at\x18\b \x01(\tR\x16latestTrendGeneratedAt\x126\n" +
+	"\x17semantic_search_enabled\x18\t \x01(\bR\x15semanticSearchEnabled\x12)\n" +
+	"\x10total_embeddings\x18\n" +
+	" \x01(\x05R\x0ftotalEmbeddings\x12-\n" +
+	"\x12pending_embeddings\x18\v \x01(\x05R\x11pendingEmbeddings\x126\n" +
+	"\x17total_embedding_diaries\x18\f \x01(\x05R\x15totalEmbeddingDiaries\"\xaf\x01\n" +
+	"\n" +
+	"ApiKeyInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"key_prefix\x18\x03 \x01(\tR\tkeyPrefix\x12 \n" +
+	"\flast_used_at\x18\x04 \x01(\x03R\n" +
+	"lastUsedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03R\texpiresAt\")\n" +
+	"\x13CreateApiKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"U\n" +
+	"\x14CreateApiKeyResponse\x12\x17\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12$\n" +
+	"\x04info\x18\x02 \x01(\v2\x10.user.ApiKeyInfoR\x04info\"\x14\n" +
+	"\x12ListApiKeysRequest\"B\n" +
+	"\x13ListApiKeysResponse\x12+\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\x10.user.ApiKeyInfoR\aapiKeys\"%\n" +
+	"\x13DeleteApiKeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"J\n" +
+	"\x14DeleteApiKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"f\n" +
+	"\vWebhookInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06events\x18\x03 \x03(\tR\x06events\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\"@\n" +
+	"\x14CreateWebhookRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06events\x18\x02 \x03(\tR\x06events\"V\n" +
+	"\x15CreateWebhookResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12%\n" +
+	"\x04info\x18\x02 \x01(\v2\x11.user.WebhookInfoR\x04info\"\x15\n" +
+	"\x13ListWebhooksRequest\"E\n" +
+	"\x14ListWebhooksResponse\x12-\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x11.user.WebhookInfoR\bwebhooks\"&\n" +
+	"\x14DeleteWebhookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"K\n" +
+	"\x15DeleteWebhookResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x9d\x02\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05event\x18\x02 \x01(\tR\x05event\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\x04 \x01(\x05R\battempts\x12'\n" +
+	"\x0fresponse_status\x18\x05 \x01(\x05R\x0eresponseStatus\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x06 \x01(\tR\tlastError\x12&\n" +
+	"\x0fnext_attempt_at\x18\a \x01(\x03R\rnextAttemptAt\x12!\n" +
+	"\fdelivered_at\x18\b \x01(\x03R\vdeliveredAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\"S\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"V\n" +
+	"\x1dListWebhookDeliveriesResponse\x125\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x15.user.WebhookDeliveryR\n" +
+	"deliveries\"\xd9\x01\n" +
+	"\n" +
+	"DataExport\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12!\n" +
+	"\fcompleted_at\x18\x06 \x01(\x03R\vcompletedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x03R\texpiresAt\"\x1a\n" +
+	"\x18RequestDataExportRequest\"E\n" +
+	"\x19RequestDataExportResponse\x12(\n" +
+	"\x06export\x18\x01 \x01(\v2\x10.user.DataExportR\x06export\"\x18\n" +
+	"\x16ListDataExportsRequest\"E\n" +
+	"\x17ListDataExportsResponse\x12*\n" +
+	"\aexports\x18\x01 \x03(\v2\x10.user.DataExportR\aexports\"+\n" +
+	"\x19DownloadDataExportRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"k\n" +
+	"\x1aDownloadDataExportResponse\x12*\n" +
+	"\x06export\x18\x01 \x01(\v2\x10.user.DataExportH\x00R\x06export\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"\xd7\x01\n" +
+	"\x13S3BackupDestination\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x16\n" +
+	"\x06bucket\x18\x03 \x01(\tR\x06bucket\x12\"\n" +
+	"\raccess_key_id\x18\x04 \x01(\tR\vaccessKeyId\x12*\n" +
+	"\x11secret_access_key\x18\x05 \x01(\tR\x0fsecretAccessKey\x12$\n" +
+	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\"c\n" +
+	"\x17WebDAVBackupDestination\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"\xda\x03\n" +
+	"\fBackupStatus\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12 \n" +
+	"\vdestination\x18\x02 \x01(\tR\vdestination\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12%\n" +
+	"\x0einterval_hours\x18\x04 \x01(\x05R\rintervalHours\x12'\n" +
+	"\x0fretention_count\x18\x05 \x01(\x05R\x0eretentionCount\x12\x1c\n" +
+	"\tencrypted\x18\x06 \x01(\bR\tencrypted\x12)\n" +
+	"\x02s3\x18\a \x01(\v2\x19.user.S3BackupDestinationR\x02s3\x125\n" +
+	"\x06webdav\x18\b \x01(\v2\x1d.user.WebDAVBackupDestinationR\x06webdav\x12\x1e\n" +
+	"\vlast_run_at\x18\t \x01(\x03R\tlastRunAt\x12\x1f\n" +
+	"\vlast_status\x18\n" +
+	" \x01(\tR\n" +
+	"lastStatus\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\v \x01(\tR\tlastError\x12&\n" +
+	"\x0flast_size_bytes\x18\f \x01(\x03R\rlastSizeBytes\x12\x1e\n" +
+	"\vnext_run_at\x18\r \x01(\x03R\tnextRunAt\"\xf2\x02\n" +
+	"\x1bUpdateBackupSettingsRequest\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12 \n" +
+	"\vdestination\x18\x02 \x01(\tR\vdestination\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12%\n" +
+	"\x0einterval_hours\x18\x04 \x01(\x05R\rintervalHours\x12'\n" +
+	"\x0fretention_count\x18\x05 \x01(\x05R\x0eretentionCount\x12)\n" +
+	"\x02s3\x18\x06 \x01(\v2\x19.user.S3BackupDestinationR\x02s3\x125\n" +
+	"\x06webdav\x18\a \x01(\v2\x1d.user.WebDAVBackupDestinationR\x06webdav\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\b \x01(\tR\n" +
+	"passphrase\x12-\n" +
+	"\x12disable_encryption\x18\t \x01(\bR\x11disableEncryption\"J\n" +
+	"\x1cUpdateBackupSettingsResponse\x12*\n" +
+	"\x06backup\x18\x01 \x01(\v2\x12.user.BackupStatusR\x06backup\"\xd3\x01\n" +
+	"\rSecurityEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x03 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x04 \x01(\tR\tuserAgent\x12\x16\n" +
+	"\x06detail\x18\x05 \x01(\tR\x06detail\x12\x1e\n" +
+	"\n" +
+	"suspicious\x18\x06 \x01(\bR\n" +
+	"suspicious\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\"h\n" +
+	"\x19ListSecurityEventsRequest\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x01 \x01(\tR\teventType\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x85\x01\n" +
+	"\x1aListSecurityEventsResponse\x12+\n" +
+	"\x06events\x18\x01 \x03(\v2\x13.user.SecurityEventR\x06events\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"\xc1\x01\n" +
+	"\x0eRateLimitUsage\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12%\n" +
+	"\x0ewindow_seconds\x18\x03 \x01(\x03R\rwindowSeconds\x12\x12\n" +
+	"\x04used\x18\x04 \x01(\x05R\x04used\x12\x1c\n" +
+	"\tremaining\x18\x05 \x01(\x05R\tremaining\x12(\n" +
+	"\x10reset_in_seconds\x18\x06 \x01(\x03R\x0eresetInSeconds\"m\n" +
+	"\vApiKeyUsage\x12\x1c\n" +
+	"\n" +
+	"api_key_id\x18\x01 \x01(\tR\bapiKeyId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12,\n" +
+	"\x06usages\x18\x03 \x03(\v2\x14.user.RateLimitUsageR\x06usages\"\x11\n" +
+	"\x0fGetUsageRequest\"n\n" +
+	"\x10GetUsageResponse\x12,\n" +
+	"\x06usages\x18\x01 \x03(\v2\x14.user.RateLimitUsageR\x06usages\x12,\n" +
+	"\bapi_keys\x18\x02 \x03(\v2\x11.user.ApiKeyUsageR\aapiKeys2\xec\r\n" +
+	"\vUserService\x12K\n" +
+	"\x0eUpdateUserName\x12\x1b.user.UpdateUserNameRequest\x1a\x1c.user.UpdateUserNameResponse\x12K\n" +
+	"\x0eChangePassword\x12\x1b.user.ChangePasswordRequest\x1a\x1c.user.ChangePasswordResponse\x12E\n" +
+	"\fUpdateLLMKey\x12\x19.user.UpdateLLMKeyRequest\x1a\x1a.user.UpdateLLMKeyResponse\x12B\n" +
+	"\vGetUserInfo\x12\x18.user.GetUserInfoRequest\x1a\x19.user.GetUserInfoResponse\x12E\n" +
+	"\fDeleteLLMKey\x12\x19.user.DeleteLLMKeyRequest\x1a\x1a.user.DeleteLLMKeyResponse\x12H\n" +
+	"\rDeleteAccount\x12\x1a.user.DeleteAccountRequest\x1a\x1b.user.DeleteAccountResponse\x12l\n" +
+	"\x19UpdateAutoSummarySettings\x12&.user.UpdateAutoSummarySettingsRequest\x1a'.user.UpdateAutoSummarySettingsResponse\x12c\n" +
+	"\x16GetAutoSummarySettings\x12#.user.GetAutoSummarySettingsRequest\x1a$.user.GetAutoSummarySettingsResponse\x12Q\n" +
+	"\x10GetPubSubMetrics\x12\x1d.user.GetPubSubMetricsRequest\x1a\x1e.user.GetPubSubMetricsResponse\x12E\n" +
+	"\fCreateApiKey\x12\x19.user.CreateApiKeyRequest\x1a\x1a.user.CreateApiKeyResponse\x12B\n" +
+	"\vListApiKeys\x12\x18.user.ListApiKeysRequest\x1a\x19.user.ListApiKeysResponse\x12E\n" +
+	"\fDeleteApiKey\x12\x19.user.DeleteApiKeyRequest\x1a\x1a.user.DeleteApiKeyResponse\x12H\n" +
+	"\rCreateWebhook\x12\x1a.user.CreateWebhookRequest\x1a\x1b.user.CreateWebhookResponse\x12E\n" +
+	"\fListWebhooks\x12\x19.user.ListWebhooksRequest\x1a\x1a.user.ListWebhooksResponse\x12H\n" +
+	"\rDeleteWebhook\x12\x1a.user.DeleteWebhookRequest\x1a\x1b.user.DeleteWebhookResponse\x12`\n" +
+	"\x15ListWebhookDeliveries\x12\".user.ListWebhookDeliveriesRequest\x1a#.user.ListWebhookDeliveriesResponse\x12T\n" +
+	"\x11RequestDataExport\x12\x1e.user.RequestDataExportRequest\x1a\x1f.user.RequestDataExportResponse\x12N\n" +
+	"\x0fListDataExports\x12\x1c.user.ListDataExportsRequest\x1a\x1d.user.ListDataExportsResponse\x12Y\n" +
+	"\x12DownloadDataExport\x12\x1f.user.DownloadDataExportRequest\x1a .user.DownloadDataExportResponse0\x01\x12]\n" +
+	"\x14UpdateBackupSettings\x12!.user.UpdateBackupSettingsRequest\x1a\".user.UpdateBackupSettingsResponse\x12W\n" +
+	"\x12ListSecurityEvents\x12\x1f.user.ListSecurityEventsRequest\x1a .user.ListSecurityEventsResponse\x129\n" +
+	"\bGetUsage\x12\x15.user.GetUsageRequest\x1a\x16.user.GetUsageResponseB@Z>github.com/project-mikan/umi.mikan/backend/infrastructure/grpcb\x06proto3"
+
+var (
+	file_user_user_proto_rawDescOnce sync.Once
+	file_user_user_proto_rawDescData []byte
+)
+
+func file_user_user_proto_rawDescGZIP() []byte {
+	file_user_user_proto_rawDescOnce.Do(func() {
+		file_user_user_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_user_user_proto_rawDesc), len(file_user_user_proto_rawDesc)))
+	})
+	return file_user_user_proto_rawDescData
+}
+
+var file_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
+var file_user_user_proto_goTypes = []any{
+	(*UpdateUserNameRequest)(nil),             // 0: user.UpdateUserNameRequest
+	(*UpdateUserNameResponse)(nil),            // 1: user.UpdateUserNameResponse
+	(*ChangePasswordRequest)(nil),             // 2: user.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),            // 3: user.ChangePasswordResponse
+	(*UpdateLLMKeyRequest)(nil),               // 4: user.UpdateLLMKeyRequest
+	(*UpdateLLMKeyResponse)(nil),              // 5: user.UpdateLLMKeyResponse
+	(*GetUserInfoRequest)(nil),                // 6: user.GetUserInfoRequest
+	(*GetUserInfoResponse)(nil),               // 7: user.GetUserInfoResponse
+	(*LLMKeyInfo)(nil),                        // 8: user.LLMKeyInfo
+	(*DeleteLLMKeyRequest)(nil),               // 9: user.DeleteLLMKeyRequest
+	(*DeleteLLMKeyResponse)(nil),              // 10: user.DeleteLLMKeyResponse
+	(*DeleteAccountRequest)(nil),              // 11: user.DeleteAccountRequest
+	(*DeleteAccountResponse)(nil),             // 12: user.DeleteAccountResponse
+	(*UpdateAutoSummarySettingsRequest)(nil),  // 13: user.UpdateAutoSummarySettingsRequest
+	(*UpdateAutoSummarySettingsResponse)(nil), // 14: user.UpdateAutoSummarySettingsResponse
+	(*GetAutoSummarySettingsRequest)(nil),     // 15: user.GetAutoSummarySettingsRequest
+	(*GetAutoSummarySettingsResponse)(nil),    // 16: user.GetAutoSummarySettingsResponse
+	(*GetPubSubMetricsRequest)(nil),           // 17: user.GetPubSubMetricsRequest
+	(*GetPubSubMetricsResponse)(nil),          // 18: user.GetPubSubMetricsResponse
+	(*HourlyMetrics)(nil),                     // 19: user.HourlyMetrics
+	(*ProcessingTask)(nil),                    // 20: user.ProcessingTask
+	(*MetricsSummary)(nil),                    // 21: user.MetricsSummary
+	(*ApiKeyInfo)(nil),                        // 22: user.ApiKeyInfo
+	(*CreateApiKeyRequest)(nil),               // 23: user.CreateApiKeyRequest
+	(*CreateApiKeyResponse)(nil),              // 24: user.CreateApiKeyResponse
+	(*ListApiKeysRequest)(nil),                // 25: user.ListApiKeysRequest
+	(*ListApiKeysResponse)(nil),               // 26: user.ListApiKeysResponse
+	(*DeleteApiKeyRequest)(nil),               // 27: user.DeleteApiKeyRequest
+	(*DeleteApiKeyResponse)(nil),              // 28: user.DeleteApiKeyResponse
+	(*WebhookInfo)(nil),                       // 29: user.WebhookInfo
+	(*CreateWebhookRequest)(nil),              // 30: user.CreateWebhookRequest
+	(*CreateWebhookResponse)(nil),             // 31: user.CreateWebhookResponse
+	(*ListWebhooksRequest)(nil),               // 32: user.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),              // 33: user.ListWebhooksResponse
+	(*DeleteWebhookRequest)(nil),              // 34: user.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),             // 35: user.DeleteWebhookResponse
+	(*WebhookDelivery)(nil),                   // 36: user.WebhookDelivery
+	(*ListWebhookDeliveriesRequest)(nil),      // 37: user.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil),     // 38: user.ListWebhookDeliveriesResponse
+	(*DataExport)(nil),                        // 39: user.DataExport
+	(*RequestDataExportRequest)(nil),          // 40: user.RequestDataExportRequest
+	(*RequestDataExportResponse)(nil),         // 41: user.RequestDataExportResponse
+	(*ListDataExportsRequest)(nil),            // 42: user.ListDataExportsRequest
+	(*ListDataExportsResponse)(nil),           // 43: user.ListDataExportsResponse
+	(*DownloadDataExportRequest)(nil),         // 44: user.DownloadDataExportRequest
+	(*DownloadDataExportResponse)(nil),        // 45: user.DownloadDataExportResponse
+	(*S3BackupDestination)(nil),               // 46: user.S3BackupDestination
+	(*WebDAVBackupDestination)(nil),           // 47: user.WebDAVBackupDestination
+	(*BackupStatus)(nil),                      // 48: user.BackupStatus
+	(*UpdateBackupSettingsRequest)(nil),       // 49: user.UpdateBackupSettingsRequest
+	(*UpdateBackupSettingsResponse)(nil),      // 50: user.UpdateBackupSettingsResponse
+	(*SecurityEvent)(nil),                     // 51: user.SecurityEvent
+	(*ListSecurityEventsRequest)(nil),         // 52: user.ListSecurityEventsRequest
+	(*ListSecurityEventsResponse)(nil),        // 53: user.ListSecurityEventsResponse
+	(*RateLimitUsage)(nil),                    // 54: user.RateLimitUsage
+	(*ApiKeyUsage)(nil),                       // 55: user.ApiKeyUsage
+	(*GetUsageRequest)(nil),                   // 56: user.GetUsageRequest
+	(*GetUsageResponse)(nil),                  // 57: user.GetUsageResponse
+}
+var file_user_user_proto_depIdxs = []int32{
+	8,  // 0: user.GetUserInfoResponse.llm_keys:type_name -> user.LLMKeyInfo
+	48, // 1: user.GetUserInfoResponse.backup:type_name -> user.BackupStatus
+	19, // 2: user.GetPubSubMetricsResponse.hourly_metrics:type_name -> user.HourlyMetrics
+	20, // 3: user.GetPubSubMetricsResponse.processing_tasks:type_name -> user.ProcessingTask
+	21, // 4: user.GetPubSubMetricsResponse.summary:type_name -> user.MetricsSummary
+	22, // 5: user.CreateApiKeyResponse.info:type_name -> user.ApiKeyInfo
+	22, // 6: user.ListApiKeysResponse.api_keys:type_name -> user.ApiKeyInfo
+	29, // 7: user.CreateWebhookResponse.info:type_name -> user.WebhookInfo
+	29, // 8: user.ListWebhooksResponse.webhooks:type_name -> user.WebhookInfo
+	36, // 9: user.ListWebhookDeliveriesResponse.deliveries:type_name -> user.WebhookDelivery
+	39, // 10: user.RequestDataExportResponse.export:type_name -> user.DataExport
+	39, // 11: user.ListDataExportsResponse.exports:type_name -> user.DataExport
+	39, // 12: user.DownloadDataExportResponse.export:type_name -> user.DataExport
+	46, // 13: user.BackupStatus.s3:type_name -> user.S3BackupDestination
+	47, // 14: user.BackupStatus.webdav:type_name -> user.WebDAVBackupDestination
+	46, // 15: user.UpdateBackupSettingsRequest.s3:type_name -> user.S3BackupDestination
+	47, // 16: user.UpdateBackupSettingsRequest.webdav:type_name -> user.WebDAVBackupDestination
+	48, // 17: user.UpdateBackupSettingsResponse.backup:type_name -> user.BackupStatus
+	51, // 18: user.ListSecurityEventsResponse.events:type_name -> user.SecurityEvent
+	54, // 19: user.ApiKeyUsage.usages:type_name -> user.RateLimitUsage
+	54, // 20: user.GetUsageResponse.usages:type_name -> user.RateLimitUsage
+	55, // 21: user.GetUsageResponse.api_keys:type_name -> user.ApiKeyUsage
+	0,  // 22: user.UserService.UpdateUserName:input_type -> user.UpdateUserNameRequest
+	2,  // 23: user.UserService.ChangePassword:input_type -> user.ChangePasswordRequest
+	4,  // 24: user.UserService.UpdateLLMKey:input_type -> user.UpdateLLMKeyRequest
+	6,  // 25: user.UserService.GetUserInfo:input_type -> user.GetUserInfoRequest
+	9,  // 26: user.UserService.DeleteLLMKey:input_type -> user.DeleteLLMKeyRequest
+	11, // 27: user.UserService.DeleteAccount:input_type -> user.DeleteAccountRequest
+	13, // 28: user.UserService.UpdateAutoSummarySettings:input_type -> user.UpdateAutoSummarySettingsRequest
+	15, // 29: user.UserService.GetAutoSummarySettings:input_type -> user.GetAutoSummarySettingsRequest
+	17, // 30: user.UserService.GetPubSubMetrics:input_type -> user.GetPubSubMetricsRequest
+	23, // 31: user.UserService.CreateApiKey:input_type -> user.CreateApiKeyRequest
+	25, // 32: user.UserService.ListApiKeys:input_type -> user.ListApiKeysRequest
+	27, // 33: user.UserService.DeleteApiKey:input_type -> user.DeleteApiKeyRequest
+	30, // 34: user.UserService.CreateWebhook:input_type -> user.CreateWebhookRequest
+	32, // 35: user.UserService.ListWebhooks:input_type -> user.ListWebhooksRequest
+	34, // 36: user.UserService.DeleteWebhook:input_type -> user.DeleteWebhookRequest
+	37, // 37: user.UserService.ListWebhookDeliveries:input_type -> user.ListWebhookDeliveriesRequest
+	40, // 38: user.UserService.RequestDataExport:input_type -> user.RequestDataExportRequest
+	42, // 39: user.UserService.ListDataExports:input_type -> user.ListDataExportsRequest
+	44, // 40: user.UserService.DownloadDataExport:input_type -> user.DownloadDataExportRequest
+	49, // 41: user.UserService.UpdateBackupSettings:input_type -> user.UpdateBackupSettingsRequest
+	52, // 42: user.UserService.ListSecurityEvents:input_type -> user.ListSecurityEventsRequest
+	56, // 43: user.UserService.GetUsage:input_type -> user.GetUsageRequest
+	1,  // 44: user.UserService.UpdateUserName:output_type -> user.UpdateUserNameResponse
+	3,  // 45: user.UserService.ChangePassword:output_type -> user.ChangePasswordResponse
+	5,  // 46: user.UserService.UpdateLLMKey:output_type -> user.UpdateLLMKeyResponse
+	7,  // 47: user.UserService.GetUserInfo:output_type -> user.GetUserInfoResponse
+	10, // 48: user.UserService.DeleteLLMKey:output_type -> user.DeleteLLMKeyResponse
+	12, // 49: user.UserService.DeleteAccount:output_type -> user.DeleteAccountResponse
+	14, // 50: user.UserService.UpdateAutoSummarySettings:output_type -> user.UpdateAutoSummarySettingsResponse
+	16, // 51: user.UserService.GetAutoSummarySettings:output_type -> user.GetAutoSummarySettingsResponse
+	18, // 52: user.UserService.GetPubSubMetrics:output_type -> user.GetPubSubMetricsResponse
+	24, // 53: user.UserService.CreateApiKey:output_type -> user.CreateApiKeyResponse
+	26, // 54: user.UserService.ListApiKeys:output_type -> user.ListApiKeysResponse
+	28, // 55: user.UserService.DeleteApiKey:output_type -> user.DeleteApiKeyResponse
+	31, // 56: user.UserService.CreateWebhook:output_type -> user.CreateWebhookResponse
+	33, // 57: user.UserService.ListWebhooks:output_type -> user.ListWebhooksResponse
+	35, // 58: user.UserService.DeleteWebhook:output_type -> user.DeleteWebhookResponse
+	38, // 59: user.UserService.ListWebhookDeliveries:output_type -> user.ListWebhookDeliveriesResponse
+	41, // 60: user.UserService.RequestDataExport:output_type -> user.RequestDataExportResponse
+	43, // 61: user.UserService.ListDataExports:output_type -> user.ListDataExportsResponse
+	45, // 62: user.UserService.DownloadDataExport:output_type -> user.DownloadDataExportResponse
+	50, // 63: user.UserService.UpdateBackupSettings:output_type -> user.UpdateBackupSettingsResponse
+	53, // 64: user.UserService.ListSecurityEvents:output_type -> user.ListSecurityEventsResponse
+	57, // 65: user.UserService.GetUsage:output_type -> user.GetUsageResponse
+	44, // [44:66] is the sub-list for method output_type
+	22, // [22:44] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_user_user_proto_init() }
+func file_user_user_proto_init() {
+	if File_user_user_proto != nil {
+		return
+	}
+	file_user_user_proto_msgTypes[45].OneofWrappers = []any{
+		(*DownloadDataExportResponse_Export)(nil),
+		(*DownloadDataExportResponse_Chunk)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1878,7 +3987,7 @@ func file_user_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_user_proto_rawDesc), len(file_user_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   29,
+			NumMessages:   58,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
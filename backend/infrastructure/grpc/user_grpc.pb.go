@@ -31,6 +31,16 @@ const (
 	UserService_CreateApiKey_FullMethodName              = "/user.UserService/CreateApiKey"
 	UserService_ListApiKeys_FullMethodName               = "/user.UserService/ListApiKeys"
 	UserService_DeleteApiKey_FullMethodName              = "/user.UserService/DeleteApiKey"
+	UserService_CreateWebhook_FullMethodName             = "/user.UserService/CreateWebhook"
+	UserService_ListWebhooks_FullMethodName              = "/user.UserService/ListWebhooks"
+	UserService_DeleteWebhook_FullMethodName             = "/user.UserService/DeleteWebhook"
+	UserService_ListWebhookDeliveries_FullMethodName     = "/user.UserService/ListWebhookDeliveries"
+	UserService_RequestDataExport_FullMethodName         = "/user.UserService/RequestDataExport"
+	UserService_ListDataExports_FullMethodName           = "/user.UserService/ListDataExports"
+	UserService_DownloadDataExport_FullMethodName        = "/user.UserService/DownloadDataExport"
+	UserService_UpdateBackupSettings_FullMethodName      = "/user.UserService/UpdateBackupSettings"
+	UserService_ListSecurityEvents_FullMethodName        = "/user.UserService/ListSecurityEvents"
+	UserService_GetUsage_FullMethodName                  = "/user.UserService/GetUsage"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -93,13 +103,15 @@ type UserServiceClient interface {
 	// エラー:
 	//   - NotFound: 指定されたプロバイダーのキーが存在しない
 	DeleteLLMKey(ctx context.Context, in *DeleteLLMKeyRequest, opts ...grpc.CallOption) (*DeleteLLMKeyResponse, error)
-	// DeleteAccount はユーザーアカウントと関連データを完全に削除します。
-	// 日記、エンティティ、要約など全てのデータがカスケード削除されます。
+	// DeleteAccount はユーザーアカウントの削除を依頼します。
+	// すぐにログイン・APIキーを使えなくし、猶予期間（ACCOUNT_DELETION_GRACE_DAYS）の後に
+	// 日記、エンティティ、要約など全てのデータを完全に削除します。
+	// 猶予期間の間はメールのリンクから削除を取り消せます（AuthService.CancelAccountDeletion）。
 	//
 	// 例:
 	//
 	//	request: {}
-	//	response: { success: true, message: "アカウントを削除しました" }
+	//	response: { success: true, message: "accountDeleteSuccess", deletion_scheduled_at: 1701209600 }
 	//
 	// エラー:
 	//   - Internal: 削除処理エラー
@@ -166,6 +178,119 @@ type UserServiceClient interface {
 	// エラー:
 	//   - NotFound: 指定されたキーが存在しない、または他ユーザーのキー
 	DeleteApiKey(ctx context.Context, in *DeleteApiKeyRequest, opts ...grpc.CallOption) (*DeleteApiKeyResponse, error)
+	// CreateWebhook は日記やAI生成のイベントを通知するWebhookエンドポイントを登録します。
+	// 送信リクエストは X-Umi-Signature ヘッダーでHMAC-SHA256署名され、署名用シークレットはレスポンスで一度だけ返されます。
+	//
+	// 例:
+	//
+	//	request: { url: "https://example.com/hook", events: ["diary.created", "summary.generated"] }
+	//	response: { secret: "whsec_...", info: { id: "...", url: "https://example.com/hook", events: [...], ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: URLが不正、またはイベントが空・未対応
+	//   - ResourceExhausted: 登録数の上限に達している
+	//   - Internal: データベースエラー
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	// ListWebhooks は登録済みWebhookの一覧を返します（シークレットは含まれません）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { webhooks: [{ id: "...", url: "https://example.com/hook", events: ["diary.created"], ... }] }
+	//
+	// エラー: なし（Webhookがない場合は空配列）
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	// DeleteWebhook は指定されたWebhookと配信ログを削除します。
+	//
+	// 例:
+	//
+	//	request: { id: "..." }
+	//	response: { success: true, message: "webhookDeleted" }
+	//
+	// エラー:
+	//   - NotFound: 指定されたWebhookが存在しない、または他ユーザーのWebhook
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	// ListWebhookDeliveries は指定されたWebhookの配信ログを新しい順に返します。
+	//
+	// 例:
+	//
+	//	request: { webhook_id: "...", limit: 20 }
+	//	response: { deliveries: [{ id: "...", event: "diary.created", status: "succeeded", attempts: 1, response_status: 200, ... }] }
+	//
+	// エラー:
+	//   - NotFound: 指定されたWebhookが存在しない、または他ユーザーのWebhook
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	// RequestDataExport は個人データの持ち出し用アーカイブ（ZIP）の作成を依頼します。
+	// 日記・エンティティとエイリアス・月次要約・ハイライト・トレンド分析・APIキーのメタデータ・設定・添付ファイルを
+	// JSONとMarkdownで含み、内容の一覧をmanifest.jsonに記録します。作成はsubscriberで非同期に行い、
+	// 進捗はWatchTasks（task_type: "data_export"）で通知されます。アーカイブは期限を過ぎると削除されます。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { export: { id: "...", status: "queued", ... } }
+	//
+	// エラー:
+	//   - FailedPrecondition: 作成中のエクスポートがある
+	//   - Internal: データベースエラー、キューへの追加に失敗
+	RequestDataExport(ctx context.Context, in *RequestDataExportRequest, opts ...grpc.CallOption) (*RequestDataExportResponse, error)
+	// ListDataExports は依頼したデータエクスポートを新しい順に返します（期限切れで削除されたものは含まれません）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { exports: [{ id: "...", status: "succeeded", size_bytes: 1024, expires_at: 1700000000, ... }] }
+	//
+	// エラー: なし（エクスポートがない場合は空配列）
+	ListDataExports(ctx context.Context, in *ListDataExportsRequest, opts ...grpc.CallOption) (*ListDataExportsResponse, error)
+	// DownloadDataExport は作成済みのアーカイブを取得します（サーバーストリーミング）。
+	// 最初のメッセージでエクスポートの情報を送り、以降のメッセージでZIPの内容をchunkに分けて送ります。
+	//
+	// エラー:
+	//   - NotFound: エクスポートが存在しない、または期限切れ
+	//   - FailedPrecondition: アーカイブを作成中、または作成に失敗した
+	DownloadDataExport(ctx context.Context, in *DownloadDataExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadDataExportResponse], error)
+	// UpdateBackupSettings は日記の定期バックアップの設定を登録・更新します。
+	// スケジューラーが interval_hours ごとにデータエクスポートと同じ形式のアーカイブを作成して保存先に書き込み、
+	// 新しい方から retention_count 件を超えた古いバックアップを削除します。実行結果は GetUserInfo の backup で確認できます。
+	// 保存先の認証情報（S3のシークレット、WebDAVのパスワード）は空の場合は変更せず、レスポンスにも含めません。
+	// passphrase を指定するとバックアップを暗号化します（サーバーには公開鍵だけを保存するため、復号にはパスフレーズが必要です）。
+	//
+	// 例:
+	//
+	//	request: { enabled: true, destination: "webdav", interval_hours: 24, retention_count: 7,
+	//	           webdav: { url: "https://cloud.example.com/remote.php/dav/files/me/", username: "me", password: "..." },
+	//	           passphrase: "correct horse battery staple" }
+	//	response: { backup: { enabled: true, destination: "webdav", encrypted: true, next_run_at: 1700000000, ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: 保存先・間隔・保持数・接続設定が不正、またはパスフレーズが短い
+	//   - FailedPrecondition: サーバーにローカルの保存先が設定されていない（destination: "local"）
+	//   - Internal: データベースエラー
+	UpdateBackupSettings(ctx context.Context, in *UpdateBackupSettingsRequest, opts ...grpc.CallOption) (*UpdateBackupSettingsResponse, error)
+	// ListSecurityEvents は自分のアカウントのセキュリティイベント（ログイン、パスワードの変更、APIキーの発行・使用など）を新しい順に返します。
+	// それまでと異なるIPアドレス・User-Agentからのログインは suspicious が true になります。
+	//
+	// 例:
+	//
+	//	request: { event_type: "login_success", limit: 50 }
+	//	response: { events: [{ event_type: "login_success", ip_address: "203.0.113.1", suspicious: true, ... }], next_cursor: "1700000000_uuid", has_more: true }
+	//
+	// エラー:
+	//   - InvalidArgument: event_typeが未対応、またはcursorが不正
+	ListSecurityEvents(ctx context.Context, in *ListSecurityEventsRequest, opts ...grpc.CallOption) (*ListSecurityEventsResponse, error)
+	// GetUsage はレート制限のあるRPC・MCPのツールごとに、現在のウィンドウでの使用回数と上限を返します（回数は増やしません）。
+	// ログインしているユーザーのバケットと、APIキーごとのバケットを返します。上限に達したRPCは ResourceExhausted を返します。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { usages: [{ method: "/diary.DiaryService/SearchDiaryEntriesSemantic", limit: 30, window_seconds: 60, used: 3, remaining: 27, reset_in_seconds: 42 }],
+	//	            api_keys: [{ api_key_id: "uuid", name: "Claude Desktop", usages: [{ method: "mcp/search_diary_entries_fuzzy", ... }] }] }
+	//
+	// エラー:
+	//   - Internal: データベースエラー、またはRedisエラー
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
 }
 
 type userServiceClient struct {
@@ -296,6 +421,115 @@ func (c *userServiceClient) DeleteApiKey(ctx context.Context, in *DeleteApiKeyRe
 	return out, nil
 }
 
+func (c *userServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, UserService_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, UserService_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, UserService_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, UserService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RequestDataExport(ctx context.Context, in *RequestDataExportRequest, opts ...grpc.CallOption) (*RequestDataExportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestDataExportResponse)
+	err := c.cc.Invoke(ctx, UserService_RequestDataExport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListDataExports(ctx context.Context, in *ListDataExportsRequest, opts ...grpc.CallOption) (*ListDataExportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDataExportsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListDataExports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DownloadDataExport(ctx context.Context, in *DownloadDataExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadDataExportResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], UserService_DownloadDataExport_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadDataExportRequest, DownloadDataExportResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_DownloadDataExportClient = grpc.ServerStreamingClient[DownloadDataExportResponse]
+
+func (c *userServiceClient) UpdateBackupSettings(ctx context.Context, in *UpdateBackupSettingsRequest, opts ...grpc.CallOption) (*UpdateBackupSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateBackupSettingsResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateBackupSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListSecurityEvents(ctx context.Context, in *ListSecurityEventsRequest, opts ...grpc.CallOption) (*ListSecurityEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSecurityEventsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListSecurityEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -356,13 +590,15 @@ type UserServiceServer interface {
 	// エラー:
 	//   - NotFound: 指定されたプロバイダーのキーが存在しない
 	DeleteLLMKey(context.Context, *DeleteLLMKeyRequest) (*DeleteLLMKeyResponse, error)
-	// DeleteAccount はユーザーアカウントと関連データを完全に削除します。
-	// 日記、エンティティ、要約など全てのデータがカスケード削除されます。
+	// DeleteAccount はユーザーアカウントの削除を依頼します。
+	// すぐにログイン・APIキーを使えなくし、猶予期間（ACCOUNT_DELETION_GRACE_DAYS）の後に
+	// 日記、エンティティ、要約など全てのデータを完全に削除します。
+	// 猶予期間の間はメールのリンクから削除を取り消せます（AuthService.CancelAccountDeletion）。
 	//
 	// 例:
 	//
 	//	request: {}
-	//	response: { success: true, message: "アカウントを削除しました" }
+	//	response: { success: true, message: "accountDeleteSuccess", deletion_scheduled_at: 1701209600 }
 	//
 	// エラー:
 	//   - Internal: 削除処理エラー
@@ -429,6 +665,119 @@ type UserServiceServer interface {
 	// エラー:
 	//   - NotFound: 指定されたキーが存在しない、または他ユーザーのキー
 	DeleteApiKey(context.Context, *DeleteApiKeyRequest) (*DeleteApiKeyResponse, error)
+	// CreateWebhook は日記やAI生成のイベントを通知するWebhookエンドポイントを登録します。
+	// 送信リクエストは X-Umi-Signature ヘッダーでHMAC-SHA256署名され、署名用シークレットはレスポンスで一度だけ返されます。
+	//
+	// 例:
+	//
+	//	request: { url: "https://example.com/hook", events: ["diary.created", "summary.generated"] }
+	//	response: { secret: "whsec_...", info: { id: "...", url: "https://example.com/hook", events: [...], ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: URLが不正、またはイベントが空・未対応
+	//   - ResourceExhausted: 登録数の上限に達している
+	//   - Internal: データベースエラー
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	// ListWebhooks は登録済みWebhookの一覧を返します（シークレットは含まれません）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { webhooks: [{ id: "...", url: "https://example.com/hook", events: ["diary.created"], ... }] }
+	//
+	// エラー: なし（Webhookがない場合は空配列）
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	// DeleteWebhook は指定されたWebhookと配信ログを削除します。
+	//
+	// 例:
+	//
+	//	request: { id: "..." }
+	//	response: { success: true, message: "webhookDeleted" }
+	//
+	// エラー:
+	//   - NotFound: 指定されたWebhookが存在しない、または他ユーザーのWebhook
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	// ListWebhookDeliveries は指定されたWebhookの配信ログを新しい順に返します。
+	//
+	// 例:
+	//
+	//	request: { webhook_id: "...", limit: 20 }
+	//	response: { deliveries: [{ id: "...", event: "diary.created", status: "succeeded", attempts: 1, response_status: 200, ... }] }
+	//
+	// エラー:
+	//   - NotFound: 指定されたWebhookが存在しない、または他ユーザーのWebhook
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	// RequestDataExport は個人データの持ち出し用アーカイブ（ZIP）の作成を依頼します。
+	// 日記・エンティティとエイリアス・月次要約・ハイライト・トレンド分析・APIキーのメタデータ・設定・添付ファイルを
+	// JSONとMarkdownで含み、内容の一覧をmanifest.jsonに記録します。作成はsubscriberで非同期に行い、
+	// 進捗はWatchTasks（task_type: "data_export"）で通知されます。アーカイブは期限を過ぎると削除されます。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { export: { id: "...", status: "queued", ... } }
+	//
+	// エラー:
+	//   - FailedPrecondition: 作成中のエクスポートがある
+	//   - Internal: データベースエラー、キューへの追加に失敗
+	RequestDataExport(context.Context, *RequestDataExportRequest) (*RequestDataExportResponse, error)
+	// ListDataExports は依頼したデータエクスポートを新しい順に返します（期限切れで削除されたものは含まれません）。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { exports: [{ id: "...", status: "succeeded", size_bytes: 1024, expires_at: 1700000000, ... }] }
+	//
+	// エラー: なし（エクスポートがない場合は空配列）
+	ListDataExports(context.Context, *ListDataExportsRequest) (*ListDataExportsResponse, error)
+	// DownloadDataExport は作成済みのアーカイブを取得します（サーバーストリーミング）。
+	// 最初のメッセージでエクスポートの情報を送り、以降のメッセージでZIPの内容をchunkに分けて送ります。
+	//
+	// エラー:
+	//   - NotFound: エクスポートが存在しない、または期限切れ
+	//   - FailedPrecondition: アーカイブを作成中、または作成に失敗した
+	DownloadDataExport(*DownloadDataExportRequest, grpc.ServerStreamingServer[DownloadDataExportResponse]) error
+	// UpdateBackupSettings は日記の定期バックアップの設定を登録・更新します。
+	// スケジューラーが interval_hours ごとにデータエクスポートと同じ形式のアーカイブを作成して保存先に書き込み、
+	// 新しい方から retention_count 件を超えた古いバックアップを削除します。実行結果は GetUserInfo の backup で確認できます。
+	// 保存先の認証情報（S3のシークレット、WebDAVのパスワード）は空の場合は変更せず、レスポンスにも含めません。
+	// passphrase を指定するとバックアップを暗号化します（サーバーには公開鍵だけを保存するため、復号にはパスフレーズが必要です）。
+	//
+	// 例:
+	//
+	//	request: { enabled: true, destination: "webdav", interval_hours: 24, retention_count: 7,
+	//	           webdav: { url: "https://cloud.example.com/remote.php/dav/files/me/", username: "me", password: "..." },
+	//	           passphrase: "correct horse battery staple" }
+	//	response: { backup: { enabled: true, destination: "webdav", encrypted: true, next_run_at: 1700000000, ... } }
+	//
+	// エラー:
+	//   - InvalidArgument: 保存先・間隔・保持数・接続設定が不正、またはパスフレーズが短い
+	//   - FailedPrecondition: サーバーにローカルの保存先が設定されていない（destination: "local"）
+	//   - Internal: データベースエラー
+	UpdateBackupSettings(context.Context, *UpdateBackupSettingsRequest) (*UpdateBackupSettingsResponse, error)
+	// ListSecurityEvents は自分のアカウントのセキュリティイベント（ログイン、パスワードの変更、APIキーの発行・使用など）を新しい順に返します。
+	// それまでと異なるIPアドレス・User-Agentからのログインは suspicious が true になります。
+	//
+	// 例:
+	//
+	//	request: { event_type: "login_success", limit: 50 }
+	//	response: { events: [{ event_type: "login_success", ip_address: "203.0.113.1", suspicious: true, ... }], next_cursor: "1700000000_uuid", has_more: true }
+	//
+	// エラー:
+	//   - InvalidArgument: event_typeが未対応、またはcursorが不正
+	ListSecurityEvents(context.Context, *ListSecurityEventsRequest) (*ListSecurityEventsResponse, error)
+	// GetUsage はレート制限のあるRPC・MCPのツールごとに、現在のウィンドウでの使用回数と上限を返します（回数は増やしません）。
+	// ログインしているユーザーのバケットと、APIキーごとのバケットを返します。上限に達したRPCは ResourceExhausted を返します。
+	//
+	// 例:
+	//
+	//	request: {}
+	//	response: { usages: [{ method: "/diary.DiaryService/SearchDiaryEntriesSemantic", limit: 30, window_seconds: 60, used: 3, remaining: 27, reset_in_seconds: 42 }],
+	//	            api_keys: [{ api_key_id: "uuid", name: "Claude Desktop", usages: [{ method: "mcp/search_diary_entries_fuzzy", ... }] }] }
+	//
+	// エラー:
+	//   - Internal: データベースエラー、またはRedisエラー
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -475,6 +824,36 @@ func (UnimplementedUserServiceServer) ListApiKeys(context.Context, *ListApiKeysR
 func (UnimplementedUserServiceServer) DeleteApiKey(context.Context, *DeleteApiKeyRequest) (*DeleteApiKeyResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteApiKey not implemented")
 }
+func (UnimplementedUserServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedUserServiceServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedUserServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedUserServiceServer) RequestDataExport(context.Context, *RequestDataExportRequest) (*RequestDataExportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestDataExport not implemented")
+}
+func (UnimplementedUserServiceServer) ListDataExports(context.Context, *ListDataExportsRequest) (*ListDataExportsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDataExports not implemented")
+}
+func (UnimplementedUserServiceServer) DownloadDataExport(*DownloadDataExportRequest, grpc.ServerStreamingServer[DownloadDataExportResponse]) error {
+	return status.Error(codes.Unimplemented, "method DownloadDataExport not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateBackupSettings(context.Context, *UpdateBackupSettingsRequest) (*UpdateBackupSettingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateBackupSettings not implemented")
+}
+func (UnimplementedUserServiceServer) ListSecurityEvents(context.Context, *ListSecurityEventsRequest) (*ListSecurityEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSecurityEvents not implemented")
+}
+func (UnimplementedUserServiceServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUsage not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -712,6 +1091,179 @@ func _UserService_DeleteApiKey_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RequestDataExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestDataExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RequestDataExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RequestDataExport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RequestDataExport(ctx, req.(*RequestDataExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListDataExports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDataExportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListDataExports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListDataExports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListDataExports(ctx, req.(*ListDataExportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DownloadDataExport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadDataExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).DownloadDataExport(m, &grpc.GenericServerStream[DownloadDataExportRequest, DownloadDataExportResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_DownloadDataExportServer = grpc.ServerStreamingServer[DownloadDataExportResponse]
+
+func _UserService_UpdateBackupSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBackupSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateBackupSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateBackupSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateBackupSettings(ctx, req.(*UpdateBackupSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListSecurityEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecurityEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListSecurityEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListSecurityEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListSecurityEvents(ctx, req.(*ListSecurityEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -767,7 +1319,49 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteApiKey",
 			Handler:    _UserService_DeleteApiKey_Handler,
 		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _UserService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _UserService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _UserService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _UserService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RequestDataExport",
+			Handler:    _UserService_RequestDataExport_Handler,
+		},
+		{
+			MethodName: "ListDataExports",
+			Handler:    _UserService_ListDataExports_Handler,
+		},
+		{
+			MethodName: "UpdateBackupSettings",
+			Handler:    _UserService_UpdateBackupSettings_Handler,
+		},
+		{
+			MethodName: "ListSecurityEvents",
+			Handler:    _UserService_ListSecurityEvents_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _UserService_GetUsage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadDataExport",
+			Handler:       _UserService_DownloadDataExport_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "user/user.proto",
 }
@@ -0,0 +1,34 @@
+package invitation
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// codeBytes は招待コードのランダムなバイト数（Base32で16文字、80ビット）
+const codeBytes = 10
+
+var codeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateCode は招待コード（xxxx-xxxx-xxxx-xxxx）を生成する。
+// DBにはHashCodeのハッシュのみを保存し、コード自体は発行時に一度だけ発行者に表示する
+func GenerateCode() (string, error) {
+	buf := make([]byte, codeBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate invitation code: %w", err)
+	}
+	s := strings.ToLower(codeEncoding.EncodeToString(buf))
+	return s[:4] + "-" + s[4:8] + "-" + s[8:12] + "-" + s[12:16], nil
+}
+
+// HashCode は招待コードのハッシュを返す。
+// 大文字・小文字、区切りのハイフンと空白は区別しない（入力の揺れを許容する）
+func HashCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
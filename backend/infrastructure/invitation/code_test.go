@@ -0,0 +1,35 @@
+package invitation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateCode(t *testing.T) {
+	t.Run("正常系: 重複しないコードを生成し、表記の揺れがあっても同じハッシュになる", func(t *testing.T) {
+		seen := map[string]bool{}
+		for range 100 {
+			code, err := GenerateCode()
+			if err != nil {
+				t.Fatalf("GenerateCode失敗: %v", err)
+			}
+			if len(code) != 19 || strings.Count(code, "-") != 3 {
+				t.Errorf("コードの形式が期待と異なる: %s", code)
+			}
+			if seen[code] {
+				t.Errorf("重複したコード: %s", code)
+			}
+			seen[code] = true
+		}
+		code, err := GenerateCode()
+		if err != nil {
+			t.Fatalf("GenerateCode失敗: %v", err)
+		}
+		if HashCode(code) != HashCode(" "+strings.ToUpper(strings.ReplaceAll(code, "-", ""))+" ") {
+			t.Error("表記の揺れでハッシュが変わった")
+		}
+		if HashCode(code) == HashCode("aaaa-bbbb-cccc-dddd") {
+			t.Error("異なるコードが同じハッシュになった")
+		}
+	})
+}
@@ -0,0 +1,128 @@
+// Package jwtkeys はJWTの署名・検証に使う非対称鍵（EdDSA・RS256）の鍵セットを提供する。
+// 鍵はDBに保存し、一定の間隔で新しい鍵に切り替える（ADR 0035）。
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+)
+
+// Algorithm はJWTの署名アルゴリズム（JWSのalg）
+type Algorithm string
+
+const (
+	AlgEdDSA Algorithm = "EdDSA" // Ed25519
+	AlgRS256 Algorithm = "RS256" // RSA 2048ビット + SHA-256
+)
+
+// rsaKeyBits はRS256の鍵の長さ
+const rsaKeyBits = 2048
+
+// ParseAlgorithm は設定の値をAlgorithmに変換する
+func ParseAlgorithm(s string) (Algorithm, error) {
+	switch Algorithm(s) {
+	case AlgEdDSA, AlgRS256:
+		return Algorithm(s), nil
+	default:
+		return "", fmt.Errorf("unsupported jwt signing algorithm: %q", s)
+	}
+}
+
+// Key はJWTの署名鍵
+type Key struct {
+	ID          string // JWTのヘッダーのkid
+	Algorithm   Algorithm
+	PrivateKey  crypto.Signer // ed25519.PrivateKey または *rsa.PrivateKey
+	PublicKey   crypto.PublicKey
+	ActivatesAt int64 // 署名に使い始める日時（UNIX秒）
+	ExpiresAt   int64 // 検証に使わなくなる日時（UNIX秒、0の場合は次の鍵に切り替えるまで）
+}
+
+// GenerateKey は新しい鍵を作成する。kidは公開鍵のSHA-256から作る
+func GenerateKey(alg Algorithm, activatesAt int64) (*Key, error) {
+	var signer crypto.Signer
+	switch alg {
+	case AlgEdDSA:
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate ed25519 key: %w", err)
+		}
+		signer = priv
+	case AlgRS256:
+		priv, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate rsa key: %w", err)
+		}
+		signer = priv
+	default:
+		return nil, fmt.Errorf("unsupported jwt signing algorithm: %q", alg)
+	}
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	return &Key{
+		ID:          keyID(der),
+		Algorithm:   alg,
+		PrivateKey:  signer,
+		PublicKey:   signer.Public(),
+		ActivatesAt: activatesAt,
+	}, nil
+}
+
+func keyID(publicKeyDER []byte) string {
+	sum := sha256.Sum256(publicKeyDER)
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+// JSONWebKey はJWKS（RFC 7517）で公開する公開鍵
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"` // OKP
+	X         string `json:"x,omitempty"`   // OKP
+	N         string `json:"n,omitempty"`   // RSA
+	E         string `json:"e,omitempty"`   // RSA
+}
+
+// JSONWebKeySet は /.well-known/jwks.json の応答
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWK は公開鍵をJWKの形式にする
+func (k *Key) JWK() JSONWebKey {
+	jwk := JSONWebKey{KeyID: k.ID, Use: "sig", Algorithm: string(k.Algorithm)}
+	switch pub := k.PublicKey.(type) {
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	}
+	return jwk
+}
+
+// matchesAlgorithm は公開鍵の型がアルゴリズムと一致するかを返す（DBの値を信用しすぎないため）
+func matchesAlgorithm(alg Algorithm, pub crypto.PublicKey) bool {
+	switch pub.(type) {
+	case ed25519.PublicKey:
+		return alg == AlgEdDSA
+	case *rsa.PublicKey:
+		return alg == AlgRS256
+	default:
+		return false
+	}
+}
@@ -0,0 +1,63 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"testing"
+)
+
+func TestGenerateKey(t *testing.T) {
+	t.Run("正常系: EdDSAの鍵はOKPのJWKになる", func(t *testing.T) {
+		k, err := GenerateKey(AlgEdDSA, 100)
+		if err != nil {
+			t.Fatalf("GenerateKey失敗: %v", err)
+		}
+		if _, ok := k.PrivateKey.(ed25519.PrivateKey); !ok || k.ActivatesAt != 100 || k.ID == "" {
+			t.Fatalf("鍵が期待と異なる: %+v", k)
+		}
+		jwk := k.JWK()
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if jwk.KeyType != "OKP" || jwk.Curve != "Ed25519" || jwk.KeyID != k.ID || jwk.Algorithm != "EdDSA" || err != nil || len(x) != ed25519.PublicKeySize {
+			t.Errorf("JWKが期待と異なる: %+v", jwk)
+		}
+	})
+
+	t.Run("正常系: RS256の鍵はRSAのJWKになる", func(t *testing.T) {
+		k, err := GenerateKey(AlgRS256, 100)
+		if err != nil {
+			t.Fatalf("GenerateKey失敗: %v", err)
+		}
+		pub, ok := k.PublicKey.(*rsa.PublicKey)
+		if !ok || pub.N.BitLen() != rsaKeyBits {
+			t.Fatalf("鍵が期待と異なる: %+v", k)
+		}
+		jwk := k.JWK()
+		if jwk.KeyType != "RSA" || jwk.E != "AQAB" || jwk.N == "" || jwk.Use != "sig" {
+			t.Errorf("JWKが期待と異なる: %+v", jwk)
+		}
+	})
+
+	t.Run("正常系: 鍵ごとに異なるkidになる", func(t *testing.T) {
+		first, err := GenerateKey(AlgEdDSA, 0)
+		if err != nil {
+			t.Fatalf("GenerateKey失敗: %v", err)
+		}
+		second, err := GenerateKey(AlgEdDSA, 0)
+		if err != nil {
+			t.Fatalf("GenerateKey失敗: %v", err)
+		}
+		if first.ID == second.ID {
+			t.Error("kidが重複している")
+		}
+	})
+
+	t.Run("異常系: 未対応のアルゴリズム", func(t *testing.T) {
+		if _, err := GenerateKey("HS256", 0); err == nil {
+			t.Error("エラーになるべき")
+		}
+		if _, err := ParseAlgorithm("none"); err == nil {
+			t.Error("エラーになるべき")
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package jwtkeys
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	ErrNoSigningKey = errors.New("no active jwt signing key")
+	ErrUnknownKey   = errors.New("unknown jwt key id")
+)
+
+// Keyset は署名と検証に使う鍵の集合
+type Keyset struct {
+	keys []*Key // 署名に使い始める日時の降順
+}
+
+// NewKeyset は鍵の集合を作成する
+func NewKeyset(keys []*Key) *Keyset {
+	sorted := append([]*Key(nil), keys...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ActivatesAt > sorted[j].ActivatesAt })
+	return &Keyset{keys: sorted}
+}
+
+// SigningKey は署名に使う鍵（署名に使い始めた鍵のうち最も新しいもの）を返す
+func (s *Keyset) SigningKey(now int64) (*Key, error) {
+	for _, k := range s.keys {
+		if k.ActivatesAt <= now && !k.expired(now) {
+			return k, nil
+		}
+	}
+	return nil, ErrNoSigningKey
+}
+
+// VerificationKey はkidの鍵を返す。署名に使い始める前の鍵も、切り替えた後の期限内の鍵も検証に使う
+func (s *Keyset) VerificationKey(kid string, now int64) (*Key, error) {
+	for _, k := range s.keys {
+		if k.ID == kid && !k.expired(now) {
+			return k, nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+// JWKS は検証に使う公開鍵を返す。署名に使い始める前の鍵も公開し、外部の検証者が事前に取得できるようにする
+func (s *Keyset) JWKS(now int64) JSONWebKeySet {
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, k := range s.keys {
+		if !k.expired(now) {
+			set.Keys = append(set.Keys, k.JWK())
+		}
+	}
+	return set
+}
+
+func (k *Key) expired(now int64) bool {
+	return k.ExpiresAt != 0 && k.ExpiresAt <= now
+}
@@ -0,0 +1,67 @@
+package jwtkeys
+
+import (
+	"errors"
+	"testing"
+)
+
+func mustGenerateKey(t *testing.T, activatesAt, expiresAt int64) *Key {
+	t.Helper()
+	k, err := GenerateKey(AlgEdDSA, activatesAt)
+	if err != nil {
+		t.Fatalf("GenerateKey失敗: %v", err)
+	}
+	k.ExpiresAt = expiresAt
+	return k
+}
+
+func TestKeyset(t *testing.T) {
+	const now = 1_000
+	retired := mustGenerateKey(t, 100, 2_000) // 次の鍵に切り替えたが、検証には使う
+	current := mustGenerateKey(t, 500, 0)
+	next := mustGenerateKey(t, 1_500, 0) // 公開済みで、まだ署名に使わない
+	expired := mustGenerateKey(t, 50, 900)
+	keyset := NewKeyset([]*Key{retired, next, expired, current})
+
+	t.Run("正常系: 署名に使い始めた鍵のうち最も新しい鍵で署名する", func(t *testing.T) {
+		got, err := keyset.SigningKey(now)
+		if err != nil || got != current {
+			t.Errorf("署名鍵が期待と異なる: %v, %v", got, err)
+		}
+		got, err = keyset.SigningKey(1_500)
+		if err != nil || got != next {
+			t.Errorf("切り替え後の署名鍵が期待と異なる: %v, %v", got, err)
+		}
+	})
+
+	t.Run("正常系: 期限内の鍵はすべて検証に使い、JWKSで公開する", func(t *testing.T) {
+		for _, k := range []*Key{retired, current, next} {
+			if got, err := keyset.VerificationKey(k.ID, now); err != nil || got != k {
+				t.Errorf("検証鍵が期待と異なる: %v, %v", got, err)
+			}
+		}
+		jwks := keyset.JWKS(now)
+		if len(jwks.Keys) != 3 || jwks.Keys[0].KeyID != next.ID {
+			t.Errorf("JWKSが期待と異なる: %+v", jwks)
+		}
+	})
+
+	t.Run("異常系: 期限切れ・不明な鍵は検証に使わない", func(t *testing.T) {
+		if _, err := keyset.VerificationKey(expired.ID, now); !errors.Is(err, ErrUnknownKey) {
+			t.Errorf("ErrUnknownKeyになるべき: %v", err)
+		}
+		if _, err := keyset.VerificationKey("unknown", now); !errors.Is(err, ErrUnknownKey) {
+			t.Errorf("ErrUnknownKeyになるべき: %v", err)
+		}
+	})
+
+	t.Run("異常系: 鍵がない場合は署名できず、JWKSは空の配列", func(t *testing.T) {
+		empty := NewKeyset(nil)
+		if _, err := empty.SigningKey(now); !errors.Is(err, ErrNoSigningKey) {
+			t.Errorf("ErrNoSigningKeyになるべき: %v", err)
+		}
+		if jwks := empty.JWKS(now); jwks.Keys == nil || len(jwks.Keys) != 0 {
+			t.Errorf("空の配列になるべき: %+v", jwks)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package jwtkeys
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// refreshInterval は鍵のローテーションの確認とDBからの再読み込みの間隔。
+// 他のサーバーが作った鍵も PublishAhead より前に読み込めるよう、PublishAhead より短くする
+const refreshInterval = time.Minute
+
+// Manager はDBの鍵を定期的にローテーション・再読み込みし、現在の鍵セットを保持する
+type Manager struct {
+	store  *Store
+	policy Policy
+	keyset atomic.Pointer[Keyset]
+}
+
+// NewManager はManagerを作成する。Refresh を呼ぶまで鍵セットは空
+func NewManager(store *Store, policy Policy) *Manager {
+	m := &Manager{store: store, policy: policy}
+	m.keyset.Store(NewKeyset(nil))
+	return m
+}
+
+// Current は現在の鍵セットを返す
+func (m *Manager) Current() *Keyset {
+	return m.keyset.Load()
+}
+
+// Refresh は必要に応じて鍵をローテーションし、DBから鍵セットを読み込み直す
+func (m *Manager) Refresh(ctx context.Context) error {
+	now := time.Now()
+	rotated, err := m.store.Rotate(ctx, m.policy, now)
+	if err != nil {
+		return err
+	}
+	if rotated {
+		log.Printf("Rotated JWT signing key (algorithm: %s)", m.policy.Algorithm)
+	}
+	keyset, err := m.store.Load(ctx, now)
+	if err != nil {
+		return err
+	}
+	m.keyset.Store(keyset)
+	return nil
+}
+
+// Start はctxがキャンセルされるまで定期的に Refresh する。失敗した場合は前回の鍵セットを使い続ける
+func (m *Manager) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.Refresh(ctx); err != nil {
+					log.Printf("Failed to refresh JWT signing keys: %v", err)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,177 @@
+package jwtkeys
+
+import (
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+)
+
+// encryptionInfo はJWT_SECRETから秘密鍵の暗号化の鍵を導出する際のラベル
+const encryptionInfo = "umi.mikan jwt signing keys v1"
+
+// Policy は鍵のローテーションの設定
+type Policy struct {
+	Algorithm         Algorithm
+	RotationInterval  time.Duration // 新しい鍵を作る間隔
+	PublishAhead      time.Duration // 新しい鍵をJWKSで公開してから署名に使い始めるまでの時間
+	VerifyAfterRetire time.Duration // 次の鍵に切り替えた後も検証に使う時間（発行したトークンの最長の有効期限）
+}
+
+// Store はDBに保存した鍵を読み込み、ローテーションする
+type Store struct {
+	DB   *sql.DB
+	aead cipher.AEAD
+}
+
+// NewStore は秘密鍵をJWT_SECRETから導出した鍵で暗号化して保存するStoreを作成する
+func NewStore(db *sql.DB, secret string) (*Store, error) {
+	key, err := hkdf.Key(sha256.New, []byte(secret), nil, encryptionInfo, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive jwt key encryption key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcm: %w", err)
+	}
+	return &Store{DB: db, aead: aead}, nil
+}
+
+// Load は期限内の鍵をすべて読み込む
+func (s *Store) Load(ctx context.Context, now time.Time) (*Keyset, error) {
+	rows, err := database.ListJwtSigningKeys(ctx, s.DB)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*Key, 0, len(rows))
+	for _, row := range rows {
+		if row.ExpiresAt.Valid && row.ExpiresAt.Int64 <= now.Unix() {
+			continue
+		}
+		k, err := s.decode(row)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load jwt signing key %s: %w", row.ID, err)
+		}
+		keys = append(keys, k)
+	}
+	return NewKeyset(keys), nil
+}
+
+// Rotate は鍵がない場合は作成し、最も新しい鍵を作ってから RotationInterval が経っている場合は次の鍵を作る。
+// 次の鍵は PublishAhead 後に署名に使い始め、それまでの鍵はその VerifyAfterRetire 後まで検証に使う。
+// 複数のサーバーが同時に呼んでも、テーブルのロックで1つだけ作る。作成した場合はtrueを返す
+func (s *Store) Rotate(ctx context.Context, policy Policy, now time.Time) (bool, error) {
+	rotated := false
+	err := database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		if err := database.LockJwtSigningKeys(ctx, tx); err != nil {
+			return err
+		}
+		if err := database.DeleteExpiredJwtSigningKeys(ctx, tx, now.Unix()); err != nil {
+			return err
+		}
+		rows, err := database.ListJwtSigningKeys(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		activatesAt := now.Unix()
+		if len(rows) > 0 {
+			if time.Unix(rows[0].CreatedAt, 0).Add(policy.RotationInterval).After(now) {
+				return nil
+			}
+			// 既に鍵がある場合は、検証者が新しい鍵を取得するまで待ってから署名に使う
+			activatesAt = now.Add(policy.PublishAhead).Unix()
+		}
+		k, err := GenerateKey(policy.Algorithm, activatesAt)
+		if err != nil {
+			return err
+		}
+		row, err := s.encode(k, now.Unix())
+		if err != nil {
+			return err
+		}
+		if err := row.Insert(ctx, tx); err != nil {
+			return fmt.Errorf("failed to insert jwt signing key: %w", err)
+		}
+		if err := database.ExpireJwtSigningKeys(ctx, tx, k.ID, time.Unix(activatesAt, 0).Add(policy.VerifyAfterRetire).Unix()); err != nil {
+			return err
+		}
+		rotated = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate jwt signing keys: %w", err)
+	}
+	return rotated, nil
+}
+
+func (s *Store) encode(k *Key, now int64) (*database.JwtSigningKey, error) {
+	privateDER, err := x509.MarshalPKCS8PrivateKey(k.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(k.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return &database.JwtSigningKey{
+		ID:          k.ID,
+		Algorithm:   string(k.Algorithm),
+		PrivateKey:  s.aead.Seal(nonce, nonce, privateDER, []byte(k.ID)), // kidを追加データにし、他の行の秘密鍵と入れ替えられないようにする
+		PublicKey:   publicDER,
+		ActivatesAt: k.ActivatesAt,
+		CreatedAt:   now,
+	}, nil
+}
+
+func (s *Store) decode(row *database.JwtSigningKey) (*Key, error) {
+	alg, err := ParseAlgorithm(row.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if len(row.PrivateKey) < s.aead.NonceSize() {
+		return nil, errors.New("encrypted private key is too short")
+	}
+	nonce, sealed := row.PrivateKey[:s.aead.NonceSize()], row.PrivateKey[s.aead.NonceSize():]
+	privateDER, err := s.aead.Open(nil, nonce, sealed, []byte(row.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt private key (JWT_SECRET changed?): %w", err)
+	}
+	priv, err := x509.ParsePKCS8PrivateKey(privateDER)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	signer, ok := priv.(crypto.Signer)
+	if !ok || !matchesAlgorithm(alg, signer.Public()) {
+		return nil, fmt.Errorf("private key does not match algorithm %s", alg)
+	}
+	k := &Key{
+		ID:          row.ID,
+		Algorithm:   alg,
+		PrivateKey:  signer,
+		PublicKey:   signer.Public(),
+		ActivatesAt: row.ActivatesAt,
+	}
+	if row.ExpiresAt.Valid {
+		k.ExpiresAt = row.ExpiresAt.Int64
+	}
+	return k, nil
+}
@@ -0,0 +1,88 @@
+package jwtkeys_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/jwtkeys"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+)
+
+func TestStore_Rotate(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	clear := func() {
+		if _, err := db.Exec("DELETE FROM jwt_signing_keys"); err != nil {
+			t.Fatalf("署名鍵の削除失敗: %v", err)
+		}
+	}
+	clear()
+	t.Cleanup(clear)
+
+	store, err := jwtkeys.NewStore(db, "test-secret")
+	if err != nil {
+		t.Fatalf("NewStore失敗: %v", err)
+	}
+	ctx := context.Background()
+	policy := jwtkeys.Policy{Algorithm: jwtkeys.AlgEdDSA, RotationInterval: 24 * time.Hour, PublishAhead: time.Hour, VerifyAfterRetire: 48 * time.Hour}
+	start := time.Unix(1_700_000_000, 0)
+
+	t.Run("正常系: 鍵がない場合はすぐに署名に使う鍵を作り、間隔が経つまで作らない", func(t *testing.T) {
+		for i, now := range []time.Time{start, start.Add(time.Hour)} {
+			if _, err := store.Rotate(ctx, policy, now); err != nil {
+				t.Fatalf("%d回目のRotate失敗: %v", i+1, err)
+			}
+		}
+		keyset, err := store.Load(ctx, start)
+		if err != nil {
+			t.Fatalf("Load失敗: %v", err)
+		}
+		if _, err := keyset.SigningKey(start.Unix()); err != nil || len(keyset.JWKS(start.Unix()).Keys) != 1 {
+			t.Errorf("鍵が1つだけ作られるべき: %+v, %v", keyset.JWKS(start.Unix()), err)
+		}
+	})
+
+	t.Run("正常系: 間隔が経つと次の鍵を公開し、切り替え後も前の鍵を一定期間だけ検証に使う", func(t *testing.T) {
+		now := start.Add(policy.RotationInterval)
+		before, err := store.Load(ctx, now)
+		if err != nil {
+			t.Fatalf("Load失敗: %v", err)
+		}
+		oldKey, err := before.SigningKey(now.Unix())
+		if err != nil {
+			t.Fatalf("SigningKey失敗: %v", err)
+		}
+
+		rotated, err := store.Rotate(ctx, policy, now)
+		if err != nil || !rotated {
+			t.Fatalf("Rotate失敗: %v, %v", rotated, err)
+		}
+		keyset, err := store.Load(ctx, now)
+		if err != nil {
+			t.Fatalf("Load失敗: %v", err)
+		}
+		if signing, _ := keyset.SigningKey(now.Unix()); signing == nil || signing.ID != oldKey.ID {
+			t.Error("公開期間中は前の鍵で署名するべき")
+		}
+		if len(keyset.JWKS(now.Unix()).Keys) != 2 {
+			t.Error("次の鍵も公開するべき")
+		}
+
+		switched := now.Add(policy.PublishAhead)
+		if signing, _ := keyset.SigningKey(switched.Unix()); signing == nil || signing.ID == oldKey.ID {
+			t.Error("公開期間の後は次の鍵で署名するべき")
+		}
+		if _, err := keyset.VerificationKey(oldKey.ID, switched.Add(policy.VerifyAfterRetire-time.Second).Unix()); err != nil {
+			t.Errorf("切り替え後も前の鍵で検証できるべき: %v", err)
+		}
+
+		expired := switched.Add(policy.VerifyAfterRetire)
+		if _, err := store.Rotate(ctx, policy, expired); err != nil {
+			t.Fatalf("Rotate失敗: %v", err)
+		}
+		if _, err := database.JwtSigningKeyByID(ctx, db, oldKey.ID); err == nil {
+			t.Error("期限切れの鍵は削除するべき")
+		}
+	})
+}
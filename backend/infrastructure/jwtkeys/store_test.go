@@ -0,0 +1,69 @@
+package jwtkeys
+
+import (
+	"testing"
+)
+
+func TestStore_encode(t *testing.T) {
+	store, err := NewStore(nil, "test-secret")
+	if err != nil {
+		t.Fatalf("NewStore失敗: %v", err)
+	}
+
+	for _, alg := range []Algorithm{AlgEdDSA, AlgRS256} {
+		t.Run("正常系: "+string(alg)+"の秘密鍵を暗号化して保存し、復号できる", func(t *testing.T) {
+			k, err := GenerateKey(alg, 100)
+			if err != nil {
+				t.Fatalf("GenerateKey失敗: %v", err)
+			}
+			row, err := store.encode(k, 50)
+			if err != nil {
+				t.Fatalf("encode失敗: %v", err)
+			}
+			got, err := store.decode(row)
+			if err != nil {
+				t.Fatalf("decode失敗: %v", err)
+			}
+			if got.ID != k.ID || got.Algorithm != alg || got.ActivatesAt != 100 || got.JWK() != k.JWK() {
+				t.Errorf("復号した鍵が期待と異なる: %+v", got)
+			}
+		})
+	}
+
+	t.Run("異常系: JWT_SECRETが異なる・他の鍵のIDに差し替えた場合は復号できない", func(t *testing.T) {
+		k, err := GenerateKey(AlgEdDSA, 100)
+		if err != nil {
+			t.Fatalf("GenerateKey失敗: %v", err)
+		}
+		row, err := store.encode(k, 50)
+		if err != nil {
+			t.Fatalf("encode失敗: %v", err)
+		}
+		other, err := NewStore(nil, "other-secret")
+		if err != nil {
+			t.Fatalf("NewStore失敗: %v", err)
+		}
+		if _, err := other.decode(row); err == nil {
+			t.Error("異なるJWT_SECRETでは復号できないべき")
+		}
+		row.ID = "other-key"
+		if _, err := store.decode(row); err == nil {
+			t.Error("IDを差し替えた場合は復号できないべき")
+		}
+	})
+
+	t.Run("異常系: アルゴリズムと鍵の種類が一致しない", func(t *testing.T) {
+		k, err := GenerateKey(AlgEdDSA, 100)
+		if err != nil {
+			t.Fatalf("GenerateKey失敗: %v", err)
+		}
+		row, err := store.encode(k, 50)
+		if err != nil {
+			t.Fatalf("encode失敗: %v", err)
+		}
+		row.Algorithm = string(AlgRS256)
+		if _, err := store.decode(row); err == nil {
+			t.Error("エラーになるべき")
+		}
+	})
+}
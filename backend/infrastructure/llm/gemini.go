@@ -469,3 +469,61 @@ func (g *GeminiClient) GenerateHighlights(ctx context.Context, diaryContent stri
 
 	return "", fmt.Errorf("unexpected content type")
 }
+
+// SuggestTags は日記の内容に合うタグをtagNamesの中から最大3つ選んで返す
+// 出力はtagNamesのいずれかに制限し、新しいタグ名は作らせない
+func (g *GeminiClient) SuggestTags(ctx context.Context, diaryContent string, tagNames []string) ([]string, error) {
+	prompt := fmt.Sprintf(`以下の日記の内容を読んで、日記に付けるのにふさわしいタグを候補の中から0~3個選んでください。
+
+【選択基準】
+- 日記の主な話題・出来事・場所・気分を表しているタグを選ぶ
+- 少し触れているだけの話題のタグは選ばない
+- ふさわしいタグがなければ空の配列を返す
+
+タグの候補:
+%s
+
+日記の内容:
+%s
+`, strings.Join(tagNames, "\n"), diaryContent)
+
+	contents := genai.Text(prompt)
+
+	// 候補以外のタグ名を返さないようにenumで制限する
+	schema := &genai.Schema{
+		Type: genai.TypeArray,
+		Items: &genai.Schema{
+			Type:   genai.TypeString,
+			Format: "enum",
+			Enum:   tagNames,
+		},
+	}
+
+	zero := float32(0)
+	config := &genai.GenerateContentConfig{
+		Temperature:      &zero,
+		ResponseMIMEType: "application/json",
+		ResponseSchema:   schema,
+		SafetySettings:   noSafetySettings,
+	}
+
+	resp, err := g.client.Models.GenerateContent(ctx, ModelGenerateContent, contents, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest tags: %w", err)
+	}
+
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil || len(resp.Candidates[0].Content.Parts) == 0 {
+		return nil, buildBlockedContentError(resp)
+	}
+
+	textPart := resp.Candidates[0].Content.Parts[0]
+	if textPart == nil {
+		return nil, fmt.Errorf("nil content part returned from tag suggestion")
+	}
+
+	var names []string
+	if err := json.Unmarshal([]byte(textPart.Text), &names); err != nil {
+		return nil, fmt.Errorf("failed to parse tag suggestion response as JSON: %w", err)
+	}
+	return names, nil
+}
@@ -4,17 +4,25 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/rueidis"
 )
 
+// ErrLockNotHeld はロックを保持していない（失効・他プロセスに奪取された）場合のエラー
+var ErrLockNotHeld = errors.New("lock is not held by this instance")
+
 // DistributedLockInterface defines the interface for distributed locks
 type DistributedLockInterface interface {
-	TryLock(ctx context.Context) (bool, error)
+	TryLock(ctx context.Context) (int64, bool, error)
 	Unlock(ctx context.Context) error
 	Extend(ctx context.Context, newDuration time.Duration) error
+	KeepAlive(ctx context.Context) (context.Context, context.CancelFunc)
 	IsLocked(ctx context.Context) (bool, error)
 	IsOwnedByMe(ctx context.Context) (bool, error)
 }
@@ -41,25 +49,45 @@ func NewDistributedLock(client rueidis.Client, key string, duration time.Duratio
 }
 
 // TryLock attempts to acquire the lock
-// Returns true if lock is acquired, false if already locked by another process
-func (dl *DistributedLock) TryLock(ctx context.Context) (bool, error) {
-	// Use SET key value NX EX seconds command
-	// NX: only set if key does not exist
-	// EX: set expiration time in seconds
-	cmd := dl.client.B().Set().Key(dl.key).Value(dl.value).Nx().Ex(dl.duration).Build()
+// Returns the fencing token and true if lock is acquired, false if already locked by another process.
+// The fencing token increases monotonically on every acquisition of the same lock key, so writers
+// can reject results produced by a stale holder whose lease has already expired.
+// The token is never lower than the Redis server time in milliseconds, so it keeps increasing
+// even if the counter is lost (expiry, AOF loss, failover or flush).
+func (dl *DistributedLock) TryLock(ctx context.Context) (int64, bool, error) {
+	// SET NX PX とフェンシングトークンの採番を1つのスクリプトで原子的に行う
+	// カウンターが消えても以前のトークンより小さくならないよう、Redisの現在時刻（ミリ秒）を下限にする。
+	// 書き込み側はDBに保存したトークン以下の書き込みを捨てるため、小さくなると追いつくまで結果が失われる
+	script := `
+		if not redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+			return 0
+		end
+		local now = redis.call("TIME")
+		local floor = tonumber(now[1]) * 1000 + math.floor(tonumber(now[2]) / 1000)
+		local token = redis.call("INCR", KEYS[2])
+		if token < floor then
+			token = floor
+			redis.call("SET", KEYS[2], string.format("%d", token))
+		end
+		redis.call("PEXPIRE", KEYS[2], ARGV[3])
+		return token
+	`
+
+	cmd := dl.client.B().Eval().Script(script).Numkeys(2).Key(dl.key, fencingKey(dl.key)).
+		Arg(dl.value, ttlMillis(dl.duration), ttlMillis(fencingCounterTTL)).Build()
 	result := dl.client.Do(ctx, cmd)
 
 	if result.Error() != nil {
-		return false, fmt.Errorf("failed to acquire lock: %w", result.Error())
+		return 0, false, fmt.Errorf("failed to acquire lock: %w", result.Error())
 	}
 
-	// If the result is "OK", lock was acquired
-	response, err := result.ToString()
+	token, err := result.AsInt64()
 	if err != nil {
-		return false, fmt.Errorf("failed to parse lock result: %w", err)
+		return 0, false, fmt.Errorf("failed to parse lock result: %w", err)
 	}
 
-	return response == "OK", nil
+	// 0 はロック取得失敗（INCRの結果は常に1以上）
+	return token, token > 0, nil
 }
 
 // Unlock releases the lock if it's held by this instance
@@ -84,26 +112,76 @@ func (dl *DistributedLock) Unlock(ctx context.Context) error {
 }
 
 // Extend extends the lock duration if it's held by this instance
+// Returns ErrLockNotHeld if the lock has expired or is owned by another instance
 func (dl *DistributedLock) Extend(ctx context.Context, newDuration time.Duration) error {
 	// Lua script to extend expiration only if we own the lock
 	script := `
 		if redis.call("GET", KEYS[1]) == ARGV[1] then
-			return redis.call("EXPIRE", KEYS[1], ARGV[2])
+			return redis.call("PEXPIRE", KEYS[1], ARGV[2])
 		else
 			return 0
 		end
 	`
 
-	cmd := dl.client.B().Eval().Script(script).Numkeys(1).Key(dl.key).Arg(dl.value).Arg(fmt.Sprintf("%d", int(newDuration.Seconds()))).Build()
+	cmd := dl.client.B().Eval().Script(script).Numkeys(1).Key(dl.key).Arg(dl.value, ttlMillis(newDuration)).Build()
 	result := dl.client.Do(ctx, cmd)
 
 	if result.Error() != nil {
 		return fmt.Errorf("failed to extend lock: %w", result.Error())
 	}
 
+	extended, err := result.AsInt64()
+	if err != nil {
+		return fmt.Errorf("failed to parse extend result: %w", err)
+	}
+	if extended == 0 {
+		return ErrLockNotHeld
+	}
+
 	return nil
 }
 
+// KeepAlive starts a watchdog that periodically extends the lock while ctx is alive
+// The returned context is cancelled when the lock is lost (expired or taken over),
+// so long-running work such as LLM calls can stop before writing stale results.
+// Call the returned CancelFunc to stop the watchdog before unlocking.
+func (dl *DistributedLock) KeepAlive(ctx context.Context) (context.Context, context.CancelFunc) {
+	lockCtx, cancel := context.WithCancel(ctx)
+
+	// リース期間の1/3ごとに延長し、一時的なRedisエラーでも失効前に再試行できるようにする
+	interval := max(dl.duration/3, minKeepAliveInterval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-lockCtx.Done():
+				return
+			case <-ticker.C:
+				err := dl.Extend(lockCtx, dl.duration)
+				if err == nil {
+					continue
+				}
+				if lockCtx.Err() != nil {
+					return
+				}
+				if errors.Is(err, ErrLockNotHeld) {
+					// ロックを失った場合は処理を中断させる
+					log.Printf("Lost distributed lock %s, cancelling holder context", dl.key)
+					cancel()
+					return
+				}
+				// 一時的なエラーは次回の延長で再試行する
+				log.Printf("Failed to extend distributed lock %s: %v", dl.key, err)
+			}
+		}
+	}()
+
+	return lockCtx, cancel
+}
+
 // IsLocked checks if the lock exists (regardless of owner)
 func (dl *DistributedLock) IsLocked(ctx context.Context) (bool, error) {
 	cmd := dl.client.B().Exists().Key(dl.key).Build()
@@ -142,6 +220,41 @@ func (dl *DistributedLock) IsOwnedByMe(ctx context.Context) (bool, error) {
 	return value == dl.value, nil
 }
 
+// fencingKey returns the key of the fencing token counter for the given lock key
+// The counter shares the hash slot with the lock key so that both can be used in one script on Redis Cluster.
+// Lock keys must not contain braces other than a single hash tag.
+func fencingKey(lockKey string) string {
+	// ハッシュタグを含むキーはそのタグでスロットが決まるため、末尾に付け足すだけで同じスロットになる
+	if hasHashTag(lockKey) {
+		return lockKey + ":fencing"
+	}
+	return fmt.Sprintf("{%s}:fencing", lockKey)
+}
+
+// hasHashTag はRedis Clusterのハッシュタグ（最初の{と次の}の間が空でない）を含むかを判定する
+func hasHashTag(key string) bool {
+	start := strings.IndexByte(key, '{')
+	if start < 0 {
+		return false
+	}
+	end := strings.IndexByte(key[start+1:], '}')
+	return end > 0
+}
+
+// fencingCounterTTL はフェンシングトークンのカウンターの有効期限（ロックキーごとにキーが残り続けないため）
+// リースより十分に長くする。期限切れで消えても、時刻を下限にするためトークンは小さくならない
+const fencingCounterTTL = 24 * time.Hour
+
+// minKeepAliveInterval はKeepAliveの延長間隔の下限（短いリースでRedisに過剰な負荷をかけないため）
+const minKeepAliveInterval = 10 * time.Millisecond
+
+// ttlMillis はリース期間をPX/PEXPIRE用のミリ秒に変換する
+// 1ミリ秒未満の端数は切り上げ、0以下のリースでもRedisがエラーにならないよう最低1ミリ秒にする
+func ttlMillis(d time.Duration) string {
+	ms := (d + time.Millisecond - 1) / time.Millisecond
+	return strconv.FormatInt(max(int64(ms), 1), 10)
+}
+
 // generateUniqueValue generates a unique value for the lock
 func generateUniqueValue() string {
 	bytes := make([]byte, 16)
@@ -0,0 +1,278 @@
+package lock
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestRedis(t *testing.T) (*miniredis.Miniredis, rueidis.Client) {
+	t.Helper()
+
+	// miniredisでテスト用Redisサーバーを起動
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	// rueidisクライアントを作成（テスト用にキャッシュを無効化）
+	client, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{mr.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	return mr, client
+}
+
+func TestDistributedLock_TryLock(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("取得ごとにフェンシングトークンが単調増加する", func(t *testing.T) {
+		mr, client := setupTestRedis(t)
+
+		first := NewDistributedLock(client, "test:lock", time.Minute)
+		token1, locked, err := first.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+		require.NoError(t, first.Unlock(ctx))
+
+		second := NewDistributedLock(client, "test:lock", time.Minute)
+		token2, locked, err := second.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		assert.Greater(t, token1, int64(0))
+		assert.Greater(t, token2, token1)
+
+		// カウンターはロックキーと同じハッシュスロットになるキーに、有効期限付きで保存される
+		counter, err := mr.Get("{test:lock}:fencing")
+		require.NoError(t, err)
+		assert.Equal(t, strconv.FormatInt(token2, 10), counter)
+		assert.Equal(t, fencingCounterTTL, mr.TTL("{test:lock}:fencing"))
+	})
+
+	t.Run("トークンはRedisの現在時刻（ミリ秒）を下限にする", func(t *testing.T) {
+		mr, client := setupTestRedis(t)
+		now := time.UnixMilli(1_700_000_000_123)
+		mr.SetTime(now)
+
+		dl := NewDistributedLock(client, "test:lock", time.Minute)
+		token, locked, err := dl.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+		assert.Equal(t, now.UnixMilli(), token)
+	})
+
+	t.Run("カウンターが消えてもトークンは小さくならない", func(t *testing.T) {
+		mr, client := setupTestRedis(t)
+		mr.SetTime(time.UnixMilli(1_700_000_000_000))
+
+		first := NewDistributedLock(client, "test:lock", time.Minute)
+		token1, locked, err := first.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+		require.NoError(t, first.Unlock(ctx))
+
+		// 同じミリ秒内の取得でも増加する
+		second := NewDistributedLock(client, "test:lock", time.Minute)
+		token2, locked, err := second.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+		require.NoError(t, second.Unlock(ctx))
+		assert.Greater(t, token2, token1)
+
+		// フェイルオーバー・FLUSHなどでカウンターが失われた場合
+		mr.Del("{test:lock}:fencing")
+		mr.SetTime(time.UnixMilli(1_700_000_001_000))
+		third := NewDistributedLock(client, "test:lock", time.Minute)
+		token3, locked, err := third.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+		assert.Greater(t, token3, token2)
+	})
+
+	t.Run("カウンターはロックキーごとに独立している", func(t *testing.T) {
+		mr, client := setupTestRedis(t)
+
+		a := NewDistributedLock(client, "test:lock:a", time.Minute)
+		_, locked, err := a.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		b := NewDistributedLock(client, "test:lock:b", time.Minute)
+		_, locked, err = b.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+		assert.True(t, mr.Exists("{test:lock:a}:fencing"))
+		assert.True(t, mr.Exists("{test:lock:b}:fencing"))
+	})
+
+	t.Run("1秒未満のリースでも取得できる", func(t *testing.T) {
+		mr, client := setupTestRedis(t)
+
+		dl := NewDistributedLock(client, "test:lock", 500*time.Millisecond)
+		_, locked, err := dl.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+		assert.Equal(t, 500*time.Millisecond, mr.TTL("test:lock"))
+	})
+
+	t.Run("保持中のロックは取得できずトークンは0", func(t *testing.T) {
+		_, client := setupTestRedis(t)
+
+		holder := NewDistributedLock(client, "test:lock", time.Minute)
+		_, locked, err := holder.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		other := NewDistributedLock(client, "test:lock", time.Minute)
+		token, locked, err := other.TryLock(ctx)
+		require.NoError(t, err)
+		assert.False(t, locked)
+		assert.Equal(t, int64(0), token)
+	})
+}
+
+func TestDistributedLock_Extend(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("保持中のロックは延長できる", func(t *testing.T) {
+		mr, client := setupTestRedis(t)
+
+		dl := NewDistributedLock(client, "test:lock", time.Minute)
+		_, locked, err := dl.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		require.NoError(t, dl.Extend(ctx, 10*time.Minute))
+		assert.Equal(t, 10*time.Minute, mr.TTL("test:lock"))
+	})
+
+	t.Run("ミリ秒単位の端数を切り上げて延長する", func(t *testing.T) {
+		mr, client := setupTestRedis(t)
+
+		dl := NewDistributedLock(client, "test:lock", time.Minute)
+		_, locked, err := dl.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		require.NoError(t, dl.Extend(ctx, 1500*time.Microsecond))
+		assert.Equal(t, 2*time.Millisecond, mr.TTL("test:lock"))
+	})
+
+	t.Run("失効したロックの延長はErrLockNotHeldを返す", func(t *testing.T) {
+		mr, client := setupTestRedis(t)
+
+		dl := NewDistributedLock(client, "test:lock", time.Minute)
+		_, locked, err := dl.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		mr.FastForward(2 * time.Minute)
+
+		assert.ErrorIs(t, dl.Extend(ctx, time.Minute), ErrLockNotHeld)
+	})
+
+	t.Run("他プロセスに奪取されたロックの延長はErrLockNotHeldを返す", func(t *testing.T) {
+		mr, client := setupTestRedis(t)
+
+		dl := NewDistributedLock(client, "test:lock", time.Minute)
+		_, locked, err := dl.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		mr.FastForward(2 * time.Minute)
+		other := NewDistributedLock(client, "test:lock", time.Minute)
+		_, locked, err = other.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		assert.ErrorIs(t, dl.Extend(ctx, time.Minute), ErrLockNotHeld)
+		owned, err := other.IsOwnedByMe(ctx)
+		require.NoError(t, err)
+		assert.True(t, owned)
+	})
+}
+
+func TestDistributedLock_KeepAlive(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("保持中はリースが延長される", func(t *testing.T) {
+		mr, client := setupTestRedis(t)
+
+		dl := NewDistributedLock(client, "test:lock", 3*time.Second)
+		_, locked, err := dl.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		// TTLを短くしておき、ウォッチドッグによる延長で元に戻ることを確認する
+		mr.SetTTL("test:lock", 2*time.Second)
+
+		lockCtx, stop := dl.KeepAlive(ctx)
+		defer stop()
+
+		assert.Eventually(t, func() bool {
+			return mr.TTL("test:lock") == 3*time.Second
+		}, 3*time.Second, 100*time.Millisecond)
+		assert.NoError(t, lockCtx.Err())
+	})
+
+	t.Run("ロックを失うとコンテキストがキャンセルされる", func(t *testing.T) {
+		mr, client := setupTestRedis(t)
+
+		dl := NewDistributedLock(client, "test:lock", 3*time.Second)
+		_, locked, err := dl.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		lockCtx, stop := dl.KeepAlive(ctx)
+		defer stop()
+
+		// 他プロセスがロックを奪取した状態を再現する
+		require.NoError(t, mr.Set("test:lock", "other-owner"))
+
+		select {
+		case <-lockCtx.Done():
+		case <-time.After(3 * time.Second):
+			t.Fatal("ロックを失ってもコンテキストがキャンセルされない")
+		}
+	})
+
+	t.Run("停止後は延長しない", func(t *testing.T) {
+		_, client := setupTestRedis(t)
+
+		dl := NewDistributedLock(client, "test:lock", 3*time.Second)
+		_, locked, err := dl.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		lockCtx, stop := dl.KeepAlive(ctx)
+		stop()
+
+		assert.ErrorIs(t, lockCtx.Err(), context.Canceled)
+	})
+}
+
+func TestFencingKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		lockKey  string
+		expected string
+	}{
+		{name: "正常系: ハッシュタグなしのキーはキー全体をハッシュタグにする", lockKey: "trend_lock:latest:user", expected: "{trend_lock:latest:user}:fencing"},
+		{name: "正常系: ハッシュタグ付きのキーはそのまま末尾に付け足す", lockKey: "lock:{user}:a", expected: "lock:{user}:a:fencing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fencingKey(tt.lockKey))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package mailer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileMailer はメールを送信せず、ディレクトリに1通ずつ .eml ファイルとして保存する。
+// ファイル名は送信した時刻の順に並ぶ（ローカルでの確認・テスト用）
+type FileMailer struct {
+	Dir  string
+	From string
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	now := time.Now()
+	data, err := buildMessage(m.From, msg, now)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.Dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Errorf("failed to generate mail file name: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000"), hex.EncodeToString(suffix))
+	if err := os.WriteFile(filepath.Join(m.Dir, name), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write mail file: %w", err)
+	}
+	return nil
+}
+
+// Messages は保存したメールを保存した順に返す
+func (m *FileMailer) Messages() ([]Message, error) {
+	entries, err := os.ReadDir(m.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read mail directory: %w", err)
+	}
+	var messages []Message
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".eml" {
+			continue
+		}
+		f, err := os.Open(filepath.Join(m.Dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open mail file: %w", err)
+		}
+		msg, err := mail.ReadMessage(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to parse mail file %s: %w", e.Name(), err)
+		}
+		body, err := io.ReadAll(msg.Body)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read mail body %s: %w", e.Name(), err)
+		}
+		subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode subject %s: %w", e.Name(), err)
+		}
+		messages = append(messages, Message{
+			To:      msg.Header.Get("To"),
+			Subject: subject,
+			Body:    strings.ReplaceAll(string(body), "\r\n", "\n"),
+		})
+	}
+	return messages, nil
+}
@@ -0,0 +1,15 @@
+package mailer
+
+import (
+	"context"
+	"log"
+)
+
+// LogMailer はメールを送信せず、宛先・件名・本文をログに出力する（開発用）。
+// 本文にパスワードの再設定のリンクなどを含むため、本番環境では使わない
+type LogMailer struct{}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	log.Printf("mailer: to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
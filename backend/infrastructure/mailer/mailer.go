@@ -0,0 +1,82 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Message は送信するメール（本文はプレーンテキスト）
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer はメールを送信する
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Backend はメールの送信方法
+type Backend string
+
+const (
+	BackendSMTP Backend = "smtp" // SMTPサーバーで送信する
+	BackendFile Backend = "file" // ディレクトリに1通ずつ .eml ファイルとして保存する（ローカルでの確認・テスト用）
+	BackendLog  Backend = "log"  // ログに出力する（開発用）
+)
+
+// Config はメールの送信設定
+type Config struct {
+	Backend  Backend
+	From     string // 送信元のメールアドレス
+	SMTPHost string
+	SMTPPort int
+	SMTPUser string // 空の場合は認証しない
+	SMTPPass string
+	FileDir  string // fileの保存先
+}
+
+// New は設定の送信方法のMailerを作成する
+func New(config Config) (Mailer, error) {
+	switch config.Backend {
+	case BackendSMTP:
+		if config.SMTPHost == "" || config.From == "" {
+			return nil, fmt.Errorf("smtp host and from address are required")
+		}
+		return &SMTPMailer{config: config}, nil
+	case BackendFile:
+		if config.FileDir == "" {
+			return nil, fmt.Errorf("file directory is required")
+		}
+		return &FileMailer{Dir: config.FileDir, From: config.From}, nil
+	case BackendLog, "":
+		return &LogMailer{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported mailer backend: %s", config.Backend)
+	}
+}
+
+// buildMessage はRFC 5322形式のメッセージを作成する。件名はUTF-8でエンコードし、本文はUTF-8のプレーンテキストにする
+func buildMessage(from string, msg Message, now time.Time) ([]byte, error) {
+	for _, v := range []string{from, msg.To, msg.Subject} {
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, fmt.Errorf("header must not contain newlines")
+		}
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return buf.Bytes(), nil
+}
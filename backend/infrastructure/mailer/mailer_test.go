@@ -0,0 +1,79 @@
+package mailer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileMailer(t *testing.T) {
+	t.Run("正常系: 保存したメールを保存した順に読み出せる", func(t *testing.T) {
+		m := &FileMailer{Dir: t.TempDir(), From: "noreply@example.com"}
+		for _, subject := range []string{"パスワードの再設定", "メールアドレスの確認"} {
+			if err := m.Send(t.Context(), Message{To: "user@example.com", Subject: subject, Body: "1行目\n2行目"}); err != nil {
+				t.Fatalf("Send失敗: %v", err)
+			}
+		}
+		messages, err := m.Messages()
+		if err != nil {
+			t.Fatalf("Messages失敗: %v", err)
+		}
+		if len(messages) != 2 {
+			t.Fatalf("メールの数が期待と異なる: %d", len(messages))
+		}
+		if messages[0].Subject != "パスワードの再設定" || messages[1].Subject != "メールアドレスの確認" {
+			t.Errorf("件名・順序が期待と異なる: %+v", messages)
+		}
+		if messages[0].To != "user@example.com" || messages[0].Body != "1行目\n2行目" {
+			t.Errorf("宛先・本文が期待と異なる: %+v", messages[0])
+		}
+	})
+
+	t.Run("正常系: 保存先がない場合は空", func(t *testing.T) {
+		m := &FileMailer{Dir: t.TempDir() + "/none"}
+		messages, err := m.Messages()
+		if err != nil || len(messages) != 0 {
+			t.Errorf("空を期待したが %v, %v", messages, err)
+		}
+	})
+}
+
+func TestBuildMessage(t *testing.T) {
+	t.Run("正常系: 件名をエンコードし、改行をCRLFにする", func(t *testing.T) {
+		data, err := buildMessage("noreply@example.com", Message{To: "user@example.com", Subject: "確認", Body: "a\nb"}, time.Unix(0, 0))
+		if err != nil {
+			t.Fatalf("buildMessage失敗: %v", err)
+		}
+		s := string(data)
+		if !strings.Contains(s, "Subject: =?UTF-8?b?") || !strings.HasSuffix(s, "\r\n\r\na\r\nb") {
+			t.Errorf("メッセージが期待と異なる: %q", s)
+		}
+	})
+
+	t.Run("異常系: ヘッダーに改行を含む（ヘッダーインジェクション）", func(t *testing.T) {
+		if _, err := buildMessage("noreply@example.com", Message{To: "user@example.com\r\nBcc: evil@example.com"}, time.Now()); err == nil {
+			t.Error("エラーを期待したが成功した")
+		}
+	})
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{name: "正常系: 未設定の場合はログ", config: Config{}},
+		{name: "正常系: SMTP", config: Config{Backend: BackendSMTP, SMTPHost: "smtp.example.com", SMTPPort: 587, From: "noreply@example.com"}},
+		{name: "異常系: SMTPでホストがない", config: Config{Backend: BackendSMTP, From: "noreply@example.com"}, wantErr: true},
+		{name: "異常系: fileで保存先がない", config: Config{Backend: BackendFile}, wantErr: true},
+		{name: "異常系: 未対応の送信方法", config: Config{Backend: "sendgrid"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("エラーが期待と異なる: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPMailer はSMTPサーバーでメールを送信する。サーバーが対応していればSTARTTLSで暗号化する
+type SMTPMailer struct {
+	config Config
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	data, err := buildMessage(m.config.From, msg, time.Now())
+	if err != nil {
+		return err
+	}
+	addr := net.JoinHostPort(m.config.SMTPHost, strconv.Itoa(m.config.SMTPPort))
+	var auth smtp.Auth
+	if m.config.SMTPUser != "" {
+		// PlainAuthはTLSで接続していない場合（localhost以外）は認証情報を送らずにエラーにする
+		auth = smtp.PlainAuth("", m.config.SMTPUser, m.config.SMTPPass, m.config.SMTPHost)
+	}
+
+	// smtp.SendMailはコンテキストに対応していないため、キャンセルされた場合は結果を待たずに返す
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(addr, auth, m.config.From, []string{msg.To}, data)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send mail via smtp: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to send mail via smtp: %w", ctx.Err())
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/project-mikan/umi.mikan/backend/domain/model"
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/securityevent"
 	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // notifyLastUsedUpdate はlast_used_at更新goroutineの完了をテストに通知するフック。
@@ -22,7 +24,9 @@ var notifyLastUsedUpdate = func(err error) {}
 // トークンは2種類を受け付ける:
 //   - APIキー（umi_プレフィックス）: DBのSHA-256ハッシュと照合する。MCPクライアント向けの長期キー。
 //   - JWTアクセストークン: gRPC/ConnectRPCの認証インターセプターと同じロジックで検証する（リフレッシュトークンは拒否）。
-func AuthMiddleware(db *sql.DB, next http.Handler) http.Handler {
+//
+// どちらの場合もcheckerでユーザーが使える状態か（JWTの場合はセッションの取り消しも）を確認する。
+func AuthMiddleware(db *sql.DB, checker *middleware.AccountChecker, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		token, err := model.ExtractBearerToken(r.Header.Get("Authorization"))
 		if err != nil {
@@ -30,10 +34,10 @@ func AuthMiddleware(db *sql.DB, next http.Handler) http.Handler {
 			return
 		}
 
-		var userID string
+		var userID, apiKeyID string
 		if model.IsAPIKey(token) {
-			// APIキー認証: ハッシュでDB照合する
-			apiKey, err := database.UserAPIKeyByKeyHash(r.Context(), db, model.HashAPIKey(token))
+			// APIキー認証: ハッシュでDB照合する（無効にされたユーザーのキーは存在しないキーと同じく拒否する）
+			apiKey, err := database.ActiveUserAPIKeyByKeyHash(r.Context(), db, model.HashAPIKey(token))
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
 					http.Error(w, "invalid api key", http.StatusUnauthorized)
@@ -50,27 +54,61 @@ func AuthMiddleware(db *sql.DB, next http.Handler) http.Handler {
 				return
 			}
 			userID = apiKey.UserID.String()
+			apiKeyID = apiKey.ID.String()
+			// キーの検索でも無効・削除を予定したユーザーを除いているが、JWTと同じ経路でも確認する
+			if err := checker.CheckUser(r.Context(), userID); err != nil {
+				writeAccountCheckError(w, err)
+				return
+			}
 
-			// 最終使用日時の更新は認証結果に影響しないbest-effort処理なので、
+			// 最終使用日時の更新とセキュリティイベントの記録は認証結果に影響しないbest-effort処理なので、
 			// レスポンスを遅延させないよう非同期化する（毎リクエストの同期DB書き込みを避ける）。
-			go func(keyID uuid.UUID) {
-				updateErr := database.UpdateUserAPIKeyLastUsed(context.Background(), db, keyID, time.Now().Unix())
+			// イベントのIPアドレス・User-Agentはリクエストから取得し、レスポンス後もキャンセルされないコンテキストで記録する
+			eventCtx := context.WithoutCancel(middleware.WithHTTPClient(r.Context(), r))
+			go func(apiKey *database.UserAPIKey) {
+				now := time.Now()
+				if err := securityevent.RecordAPIKeyUse(eventCtx, db, apiKey, now); err != nil {
+					log.Printf("failed to record api key use: %v", err)
+				}
+				updateErr := database.UpdateUserAPIKeyLastUsed(context.Background(), db, apiKey.ID, now.Unix())
 				if updateErr != nil {
 					log.Printf("failed to update api key last_used_at: %v", updateErr)
 				}
 				notifyLastUsedUpdate(updateErr)
-			}(apiKey.ID)
+			}(apiKey)
 		} else {
 			// JWTアクセストークン認証（リフレッシュトークンは拒否する）
-			_, jwtUserID, err := model.ParseAccessToken(token)
+			parsed, jwtUserID, err := model.ParseAccessToken(token)
 			if err != nil {
 				http.Error(w, "invalid access token", http.StatusUnauthorized)
 				return
 			}
+			// 無効にした・削除を予定した・セッションを取り消したユーザーのトークンは有効期限内でも拒否する
+			if err := checker.Check(r.Context(), jwtUserID, parsed.IssuedAt); err != nil {
+				writeAccountCheckError(w, err)
+				return
+			}
 			userID = jwtUserID
 		}
 
 		ctx := context.WithValue(r.Context(), middleware.UserIDKey, userID)
+		if apiKeyID != "" {
+			// レート制限をAPIキーごとに数える（同じユーザーのWebアプリやほかのキーに影響させない）
+			ctx = context.WithValue(ctx, middleware.APIKeyIDKey, apiKeyID)
+		}
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// writeAccountCheckError はAccountCheckerのエラー（gRPCのステータス）をHTTPのステータスで返す
+func writeAccountCheckError(w http.ResponseWriter, err error) {
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		http.Error(w, status.Convert(err).Message(), http.StatusUnauthorized)
+	case codes.PermissionDenied:
+		http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+	default:
+		log.Printf("failed to check account: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}
+}
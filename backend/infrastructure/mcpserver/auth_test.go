@@ -82,7 +82,7 @@ func TestAuthMiddleware(t *testing.T) {
 				req.Header.Set("Authorization", tt.authHeader)
 			}
 			rec := httptest.NewRecorder()
-			AuthMiddleware(nil, next).ServeHTTP(rec, req)
+			AuthMiddleware(nil, nil, next).ServeHTTP(rec, req)
 
 			if rec.Code != tt.expectedStatus {
 				t.Errorf("ステータスコード: 期待 %d, 実際 %d", tt.expectedStatus, rec.Code)
@@ -124,7 +124,7 @@ func TestAuthMiddleware_APIKey(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
 		rec := httptest.NewRecorder()
-		AuthMiddleware(db, next).ServeHTTP(rec, req)
+		AuthMiddleware(db, middleware.NewAccountChecker(db), next).ServeHTTP(rec, req)
 		return rec.Code, capturedUserID
 	}
 
@@ -209,3 +209,47 @@ func TestAuthMiddleware_APIKey(t *testing.T) {
 		}
 	})
 }
+
+func TestAuthMiddleware_AccountStatus(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	handler := AuthMiddleware(db, middleware.NewAccountChecker(db), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	callWithToken := func(t *testing.T, token string) int {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("正常系: 有効なユーザーのJWTで認証できる", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "mcp-account-active@example.com", "MCPActiveUser")
+		if code := callWithToken(t, generateValidTokenForTest(t, userID.String())); code != http.StatusOK {
+			t.Errorf("ステータスコード: 期待 200, 実際 %d", code)
+		}
+	})
+
+	t.Run("異常系: セッションを取り消す前に発行したJWTは401", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "mcp-account-revoked@example.com", "MCPRevokedUser")
+		token := generateValidTokenForTest(t, userID.String())
+		if _, err := db.Exec("UPDATE users SET sessions_revoked_at = $2 WHERE id = $1", userID, time.Now().Unix()); err != nil {
+			t.Fatalf("ユーザーの更新に失敗: %v", err)
+		}
+		if code := callWithToken(t, token); code != http.StatusUnauthorized {
+			t.Errorf("ステータスコード: 期待 401, 実際 %d", code)
+		}
+	})
+
+	t.Run("異常系: 無効にしたユーザーのJWTは403", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "mcp-account-disabled@example.com", "MCPDisabledUser")
+		token := generateValidTokenForTest(t, userID.String())
+		if _, err := db.Exec("UPDATE users SET disabled_at = $2 WHERE id = $1", userID, time.Now().Unix()); err != nil {
+			t.Fatalf("ユーザーの更新に失敗: %v", err)
+		}
+		if code := callWithToken(t, token); code != http.StatusForbidden {
+			t.Errorf("ステータスコード: 期待 403, 実際 %d", code)
+		}
+	})
+}
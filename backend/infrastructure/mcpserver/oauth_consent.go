@@ -1,12 +1,19 @@
 package mcpserver
 
 import (
+	"database/sql"
 	"encoding/json"
+	"log"
 	"net/http"
 	"net/url"
 
+	"github.com/google/uuid"
 	"github.com/project-mikan/umi.mikan/backend/domain/model"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/securityevent"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
 	"github.com/redis/rueidis"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // consentRequest はフロントエンドの同意画面から送られるリクエストボディ。
@@ -32,7 +39,10 @@ type consentResponse struct {
 // redirect_uriへの遷移先URLを返す。JWT検証には既存のmodel.ParseAccessTokenをそのまま使う
 // （AuthMiddlewareのJWT分岐と同じロジック。APIキーは対象外 — ブラウザ経由の同意フローで
 // APIキーを使う想定はない）。
-func newConsentHandler(redisClient rueidis.Client) http.HandlerFunc {
+// checkerで無効にした・セッションを取り消したユーザーのトークンでは同意できないようにする
+// （同意で発行したトークンはセッションの取り消しより後の発行日時になるため）。
+// dbがnilでない場合は、同意したことをユーザーのセキュリティイベントに記録する。
+func newConsentHandler(redisClient rueidis.Client, db *sql.DB, checker *middleware.AccountChecker) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			writeOAuthError(w, http.StatusMethodNotAllowed, "invalid_request", "method not allowed")
@@ -44,11 +54,22 @@ func newConsentHandler(redisClient rueidis.Client) http.HandlerFunc {
 			writeOAuthError(w, http.StatusUnauthorized, "unauthorized", "missing or invalid bearer token")
 			return
 		}
-		_, userID, err := model.ParseAccessToken(token)
+		parsed, userID, err := model.ParseAccessToken(token)
 		if err != nil {
 			writeOAuthError(w, http.StatusUnauthorized, "unauthorized", "invalid access token")
 			return
 		}
+		if err := checker.Check(r.Context(), userID, parsed.IssuedAt); err != nil {
+			switch status.Code(err) {
+			case codes.Unauthenticated:
+				writeOAuthError(w, http.StatusUnauthorized, "unauthorized", status.Convert(err).Message())
+			case codes.PermissionDenied:
+				writeOAuthError(w, http.StatusForbidden, "access_denied", status.Convert(err).Message())
+			default:
+				writeOAuthError(w, http.StatusInternalServerError, "server_error", "failed to check account")
+			}
+			return
+		}
 
 		var req consentRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -96,6 +117,15 @@ func newConsentHandler(redisClient rueidis.Client) http.HandlerFunc {
 			return
 		}
 
+		if db != nil {
+			if parsedUserID, err := uuid.Parse(userID); err == nil {
+				detail := map[string]any{"client_id": req.ClientID, "redirect_uri": req.RedirectURI}
+				if err := securityevent.Record(middleware.WithHTTPClient(r.Context(), r), db, parsedUserID, securityevent.OAuthConsent, detail); err != nil {
+					log.Printf("failed to record oauth consent: %v", err)
+				}
+			}
+		}
+
 		dest, err := url.Parse(req.RedirectURI)
 		if err != nil {
 			writeOAuthError(w, http.StatusInternalServerError, "server_error", "invalid redirect_uri")
@@ -16,7 +16,7 @@ func TestNewConsentHandler(t *testing.T) {
 		if err := storeClientRegistration(t.Context(), redisClient, "c1", []string{"https://claude.ai/callback"}); err != nil {
 			t.Fatalf("storeClientRegistration失敗: %v", err)
 		}
-		handler := newConsentHandler(redisClient)
+		handler := newConsentHandler(redisClient, nil, nil)
 		token := generateValidTokenForTest(t, uuid.New().String())
 
 		body := `{"client_id":"c1","redirect_uri":"https://claude.ai/callback","code_challenge":"abc","code_challenge_method":"S256","state":"xyz"}`
@@ -46,7 +46,7 @@ func TestNewConsentHandler(t *testing.T) {
 
 	t.Run("異常系: Authorizationヘッダーがないと401になる", func(t *testing.T) {
 		redisClient := setupTestRedisForOAuthStoreTest(t)
-		handler := newConsentHandler(redisClient)
+		handler := newConsentHandler(redisClient, nil, nil)
 
 		body := `{"client_id":"c1","redirect_uri":"https://claude.ai/callback","code_challenge":"abc","code_challenge_method":"S256"}`
 		req := httptest.NewRequest(http.MethodPost, "/oauth/consent", strings.NewReader(body))
@@ -61,7 +61,7 @@ func TestNewConsentHandler(t *testing.T) {
 
 	t.Run("異常系: code_challengeがないとinvalid_requestになる", func(t *testing.T) {
 		redisClient := setupTestRedisForOAuthStoreTest(t)
-		handler := newConsentHandler(redisClient)
+		handler := newConsentHandler(redisClient, nil, nil)
 		token := generateValidTokenForTest(t, uuid.New().String())
 
 		body := `{"client_id":"c1","redirect_uri":"https://claude.ai/callback","code_challenge_method":"S256"}`
@@ -78,7 +78,7 @@ func TestNewConsentHandler(t *testing.T) {
 
 	t.Run("異常系: redirect_uriが不正だとinvalid_requestになる", func(t *testing.T) {
 		redisClient := setupTestRedisForOAuthStoreTest(t)
-		handler := newConsentHandler(redisClient)
+		handler := newConsentHandler(redisClient, nil, nil)
 		token := generateValidTokenForTest(t, uuid.New().String())
 
 		body := `{"client_id":"c1","redirect_uri":"javascript:alert(1)","code_challenge":"abc","code_challenge_method":"S256"}`
@@ -98,7 +98,7 @@ func TestNewConsentHandler(t *testing.T) {
 		if err := storeClientRegistration(t.Context(), redisClient, "c1", []string{"https://claude.ai/callback"}); err != nil {
 			t.Fatalf("storeClientRegistration失敗: %v", err)
 		}
-		handler := newConsentHandler(redisClient)
+		handler := newConsentHandler(redisClient, nil, nil)
 		token := generateValidTokenForTest(t, uuid.New().String())
 
 		body := `{"client_id":"c1","redirect_uri":"https://evil.example.com/collect","code_challenge":"abc","code_challenge_method":"S256"}`
@@ -3,6 +3,8 @@ package mcpserver
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/project-mikan/umi.mikan/backend/domain/model"
 )
 
 // oauthMetadataHandlers は、MCP仕様のAuthorization
@@ -27,6 +29,7 @@ type authorizationServerMetadata struct {
 	GrantTypesSupported               []string `json:"grant_types_supported"`
 	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
 	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	JWKSURI                           string   `json:"jwks_uri"`
 }
 
 // newProtectedResourceMetadataHandler は /.well-known/oauth-protected-resource を提供する。
@@ -52,10 +55,21 @@ func newAuthorizationServerMetadataHandler(baseURL string) http.HandlerFunc {
 			GrantTypesSupported:               []string{"authorization_code"},
 			CodeChallengeMethodsSupported:     []string{"S256"},
 			TokenEndpointAuthMethodsSupported: []string{"none"},
+			JWKSURI:                           baseURL + jwksPath,
 		})
 	}
 }
 
+// newJWKSHandler は /.well-known/jwks.json を提供する。
+// アクセストークンの署名を検証する公開鍵（署名に使い始める前の次の鍵と、切り替えた後の前の鍵を含む）を返す。
+// 鍵は定期的に切り替わるため、キャッシュは短くする
+func newJWKSHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		writeJSON(w, http.StatusOK, model.CurrentJWKS())
+	}
+}
+
 // writeJSON はJSONレスポンスを書き込む共通ヘルパー
 func writeJSON(w http.ResponseWriter, statusCode int, body any) {
 	w.Header().Set("Content-Type", "application/json")
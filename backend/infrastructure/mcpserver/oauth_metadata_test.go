@@ -5,6 +5,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/domain/model"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/jwtkeys"
 )
 
 func TestNewProtectedResourceMetadataHandler(t *testing.T) {
@@ -52,8 +56,43 @@ func TestNewAuthorizationServerMetadataHandler(t *testing.T) {
 		if resp.RegistrationEndpoint != "https://umi-mikan-api.usuyuki.net"+oauthRegisterPath {
 			t.Errorf("registration_endpointが期待と異なる: %s", resp.RegistrationEndpoint)
 		}
+		if resp.JWKSURI != "https://umi-mikan-api.usuyuki.net"+jwksPath {
+			t.Errorf("jwks_uriが期待と異なる: %s", resp.JWKSURI)
+		}
 		if len(resp.CodeChallengeMethodsSupported) != 1 || resp.CodeChallengeMethodsSupported[0] != "S256" {
 			t.Errorf("code_challenge_methods_supportedが期待と異なる: %v", resp.CodeChallengeMethodsSupported)
 		}
 	})
 }
+
+type staticKeySource struct {
+	keyset *jwtkeys.Keyset
+}
+
+func (s *staticKeySource) Current() *jwtkeys.Keyset { return s.keyset }
+
+func TestNewJWKSHandler(t *testing.T) {
+	t.Run("正常系: 検証に使う公開鍵をJWKSで返す", func(t *testing.T) {
+		key, err := jwtkeys.GenerateKey(jwtkeys.AlgEdDSA, time.Now().Unix())
+		if err != nil {
+			t.Fatalf("GenerateKey失敗: %v", err)
+		}
+		model.SetTokenKeys(&staticKeySource{keyset: jwtkeys.NewKeyset([]*jwtkeys.Key{key})}, true)
+		t.Cleanup(func() { model.SetTokenKeys(nil, true) })
+
+		req := httptest.NewRequest(http.MethodGet, jwksPath, nil)
+		w := httptest.NewRecorder()
+		newJWKSHandler()(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("ステータスコードが期待と異なる: got %d, want %d", w.Code, http.StatusOK)
+		}
+		var resp jwtkeys.JSONWebKeySet
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("レスポンスのJSONパース失敗: %v", err)
+		}
+		if len(resp.Keys) != 1 || resp.Keys[0].KeyID != key.ID || resp.Keys[0].KeyType != "OKP" {
+			t.Errorf("keysが期待と異なる: %+v", resp.Keys)
+		}
+	})
+}
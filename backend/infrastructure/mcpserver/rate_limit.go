@@ -0,0 +1,38 @@
+package mcpserver
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+)
+
+// toolRateLimitPrefix はツールのレート制限のメソッド名の接頭辞（RPC_RATE_LIMITS では mcp/<ツール名> で指定する）
+const toolRateLimitPrefix = "mcp/"
+
+// rateLimitMiddleware はツールの呼び出しをgRPC・ConnectRPCと同じRPCLimiterで制限する。
+// APIキーで認証した場合はキーごと、JWTの場合はユーザーごとに数える。
+// 上限に達した場合は、AIクライアントが待ってから再試行できるよう、次に呼び出せるまでの時間をツールのエラーとして返す
+func rateLimitMiddleware(limiter *ratelimiter.RPCLimiter) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callReq, ok := req.(*mcp.CallToolRequest)
+			if !ok || callReq.Params == nil {
+				return next(ctx, method, req)
+			}
+			toolMethod := toolRateLimitPrefix + callReq.Params.Name
+			decision, err := middleware.CheckRateLimit(ctx, limiter, toolMethod)
+			if err != nil {
+				return nil, friendlyError(err)
+			}
+			if decision != nil && !decision.Allowed {
+				return &mcp.CallToolResult{
+					IsError: true,
+					Content: []mcp.Content{&mcp.TextContent{Text: friendlyError(middleware.RateLimitExceededError(toolMethod, decision)).Error()}},
+				}, nil
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
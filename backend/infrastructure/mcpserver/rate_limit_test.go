@@ -0,0 +1,72 @@
+package mcpserver
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"github.com/redis/rueidis"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis起動失敗: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	client, err := rueidis.NewClient(rueidis.ClientOption{InitAddress: []string{mr.Addr()}, DisableCache: true})
+	if err != nil {
+		t.Fatalf("rueidisクライアント作成失敗: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	limiter := ratelimiter.NewRPCLimiter(ratelimiter.NewRedisRateLimiter(client), map[string]ratelimiter.Policy{
+		"mcp/search_diary_entries_fuzzy": {Limit: 1, Window: time.Minute},
+	})
+	calls := 0
+	handler := rateLimitMiddleware(limiter)(func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		calls++
+		return &mcp.CallToolResult{}, nil
+	})
+	callTool := func(ctx context.Context, name string) *mcp.CallToolResult {
+		t.Helper()
+		result, err := handler(ctx, "tools/call", &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: name}})
+		if err != nil {
+			t.Fatalf("ツールの呼び出しに失敗: %v", err)
+		}
+		return result.(*mcp.CallToolResult)
+	}
+
+	t.Run("異常系: 上限を超えるとツールのエラーを返す", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), middleware.APIKeyIDKey, "key-1")
+		if result := callTool(ctx, "search_diary_entries_fuzzy"); result.IsError {
+			t.Fatal("1回目は許可されるべき")
+		}
+		result := callTool(ctx, "search_diary_entries_fuzzy")
+		if !result.IsError {
+			t.Fatal("上限を超えた場合はエラーになるべき")
+		}
+		if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "try again in") {
+			t.Errorf("再試行までの時間を含むべき: %s", text)
+		}
+		if calls != 1 {
+			t.Errorf("拒否した呼び出しはツールを実行しないべき: %d回", calls)
+		}
+	})
+
+	t.Run("正常系: 別のAPIキーと制限のないツールは呼び出せる", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), middleware.APIKeyIDKey, "key-2")
+		if result := callTool(ctx, "search_diary_entries_fuzzy"); result.IsError {
+			t.Error("別のAPIキーは許可されるべき")
+		}
+		ctx = context.WithValue(context.Background(), middleware.APIKeyIDKey, "key-1")
+		if result := callTool(ctx, "get_diary_entry"); result.IsError {
+			t.Error("制限のないツールは許可されるべき")
+		}
+	})
+}
@@ -9,6 +9,8 @@ import (
 	"net/http"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
 	"github.com/project-mikan/umi.mikan/backend/service/diary"
 	"github.com/project-mikan/umi.mikan/backend/service/user"
 	"github.com/redis/rueidis"
@@ -30,14 +32,19 @@ const (
 	oauthAuthorizePath                   = "/oauth/authorize"
 	oauthConsentPath                     = "/oauth/consent"
 	oauthTokenPath                       = "/oauth/token"
+	jwksPath                             = "/.well-known/jwks.json"
 )
 
 // frontendConsentPath はフロントエンド（SvelteKit）側の同意画面のパス
 const frontendConsentPath = "/oauth/authorize"
 
-// NewServer は日記操作ツールを登録したMCPサーバーを作成する
-func NewServer(diaryService *diary.DiaryEntry) *mcp.Server {
+// NewServer は日記操作ツールを登録したMCPサーバーを作成する。
+// limiterがnilでない場合はツールの呼び出しをレート制限する
+func NewServer(diaryService *diary.DiaryEntry, limiter *ratelimiter.RPCLimiter) *mcp.Server {
 	server := mcp.NewServer(&mcp.Implementation{Name: serverName, Version: serverVersion}, nil)
+	if limiter != nil {
+		server.AddReceivingMiddleware(rateLimitMiddleware(limiter))
+	}
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_diary_entries_by_range",
@@ -61,24 +68,27 @@ func NewServer(diaryService *diary.DiaryEntry) *mcp.Server {
 // OAuth 2.0 Discovery・Dynamic Client Registration・Authorization Code + PKCEフローの
 // 各エンドポイント（adr/0016参照）をまとめて登録したハンドラーを作成する。
 //
+//   - limiter: ツールの呼び出しのレート制限（gRPC・ConnectRPCと共通、nilの場合は制限しない）。
+//   - checker: 認証したユーザーの状態の確認（gRPC・ConnectRPCと共通、nilの場合は確認しない）。
 //   - baseURL: このMCPサーバー自身の公開URL（例: https://umi-mikan-api.usuyuki.net）。
 //     OAuth Discoveryメタデータ内のエンドポイントURL組み立てに使う。
 //   - frontendBaseURL: フロントエンド（SvelteKit）の公開URL。/oauth/authorizeの
 //     リダイレクト先（ログイン・同意画面）組み立てに使う。
-func NewHTTPHandler(diaryService *diary.DiaryEntry, db *sql.DB, redisClient rueidis.Client, userService *user.UserEntry, baseURL, frontendBaseURL string) http.Handler {
-	server := NewServer(diaryService)
+func NewHTTPHandler(diaryService *diary.DiaryEntry, db *sql.DB, redisClient rueidis.Client, userService *user.UserEntry, limiter *ratelimiter.RPCLimiter, checker *middleware.AccountChecker, baseURL, frontendBaseURL string) http.Handler {
+	server := NewServer(diaryService, limiter)
 	mcpHandler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
 		return server
 	}, &mcp.StreamableHTTPOptions{Stateless: true})
 
 	mux := http.NewServeMux()
-	mux.Handle(mcpPath, AuthMiddleware(db, mcpHandler))
+	mux.Handle(mcpPath, AuthMiddleware(db, checker, mcpHandler))
 	mux.HandleFunc(oauthProtectedResourceMetadataPath, newProtectedResourceMetadataHandler(baseURL))
 	mux.HandleFunc(oauthAuthorizationServerMetadataPath, newAuthorizationServerMetadataHandler(baseURL))
 	mux.HandleFunc(oauthRegisterPath, newRegisterHandler(redisClient))
 	mux.HandleFunc(oauthAuthorizePath, newAuthorizeHandler(redisClient, frontendBaseURL))
-	mux.HandleFunc(oauthConsentPath, newConsentHandler(redisClient))
+	mux.HandleFunc(oauthConsentPath, newConsentHandler(redisClient, db, checker))
 	mux.HandleFunc(oauthTokenPath, newTokenHandler(redisClient, userService))
+	mux.HandleFunc(jwksPath, newJWKSHandler())
 
 	return mux
 }
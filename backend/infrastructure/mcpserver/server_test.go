@@ -36,7 +36,7 @@ func setupTestRedisForServerTest(t *testing.T) rueidis.Client {
 
 func TestNewServer(t *testing.T) {
 	t.Run("正常系: ツールを登録したサーバーが生成される", func(t *testing.T) {
-		server := NewServer(&diary.DiaryEntry{})
+		server := NewServer(&diary.DiaryEntry{}, nil)
 		if server == nil {
 			t.Fatal("サーバーがnilで返された")
 		}
@@ -46,7 +46,7 @@ func TestNewServer(t *testing.T) {
 func TestNewHTTPHandler(t *testing.T) {
 	t.Run("異常系: 認証ヘッダーがないリクエストは401", func(t *testing.T) {
 		redisClient := setupTestRedisForServerTest(t)
-		handler := NewHTTPHandler(&diary.DiaryEntry{}, nil, redisClient, &user.UserEntry{}, "http://localhost:2014", "http://localhost:2000")
+		handler := NewHTTPHandler(&diary.DiaryEntry{}, nil, redisClient, &user.UserEntry{}, nil, nil, "http://localhost:2014", "http://localhost:2000")
 		ts := httptest.NewServer(handler)
 		defer ts.Close()
 
@@ -65,7 +65,7 @@ func TestNewHTTPHandler(t *testing.T) {
 
 	t.Run("正常系: 有効なトークンでinitializeが成功する", func(t *testing.T) {
 		redisClient := setupTestRedisForServerTest(t)
-		handler := NewHTTPHandler(&diary.DiaryEntry{}, nil, redisClient, &user.UserEntry{}, "http://localhost:2014", "http://localhost:2000")
+		handler := NewHTTPHandler(&diary.DiaryEntry{}, nil, redisClient, &user.UserEntry{}, nil, nil, "http://localhost:2014", "http://localhost:2000")
 		ts := httptest.NewServer(handler)
 		defer ts.Close()
 
@@ -40,13 +40,18 @@ func friendlyError(err error) error {
 func toDiaryEntryOutputs(diaries []*database.Diary) []DiaryEntryOutput {
 	entries := make([]DiaryEntryOutput, 0, len(diaries))
 	for _, d := range diaries {
-		entries = append(entries, DiaryEntryOutput{
+		entry := DiaryEntryOutput{
 			ID:        d.ID.String(),
 			Date:      d.Date.Format(dateLayout),
+			Title:     d.Title,
 			Content:   d.Content,
 			CreatedAt: d.CreatedAt,
 			UpdatedAt: d.UpdatedAt,
-		})
+		}
+		if d.EntryTime.Valid {
+			entry.Time = fmt.Sprintf("%02d:%02d", d.EntryTime.Int64/60, d.EntryTime.Int64%60)
+		}
+		entries = append(entries, entry)
 	}
 	return entries
 }
@@ -30,6 +30,8 @@ type GetDiaryEntriesByRangeOutput struct {
 type DiaryEntryOutput struct {
 	ID        string `json:"id" jsonschema:"日記ID"`
 	Date      string `json:"date" jsonschema:"日記の日付（YYYY-MM-DD形式）"`
+	Title     string `json:"title,omitempty" jsonschema:"日記のタイトル（未指定の場合は省略）"`
+	Time      string `json:"time,omitempty" jsonschema:"日記の時刻（HH:MM形式、未指定の場合は省略）。同じ日付に複数の日記がある場合に時間帯を区別する"`
 	Content   string `json:"content" jsonschema:"日記本文"`
 	CreatedAt int64  `json:"createdAt" jsonschema:"作成日時（UnixTime秒）"`
 	UpdatedAt int64  `json:"updatedAt" jsonschema:"更新日時（UnixTime秒）"`
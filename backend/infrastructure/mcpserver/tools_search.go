@@ -29,7 +29,7 @@ func searchDiaryEntriesFulltextHandler(diaryService *diary.DiaryEntry) mcp.ToolH
 			return nil, SearchDiaryEntriesFulltextOutput{}, fmt.Errorf("keyword is required")
 		}
 
-		result, err := diaryService.SearchDiaryEntriesByUserID(ctx, userID, input.Keyword)
+		result, err := diaryService.SearchDiaryEntriesByUserID(ctx, userID, input.Keyword, nil)
 		if err != nil {
 			return nil, SearchDiaryEntriesFulltextOutput{}, friendlyError(err)
 		}
@@ -87,7 +87,7 @@ func searchDiaryEntriesFuzzyHandler(diaryService *diary.DiaryEntry) mcp.ToolHand
 		if input.Limit != nil {
 			limit = min(max(*input.Limit, 0), maxFuzzyLimit)
 		}
-		outcome, err := diaryService.SearchDiaryEntriesSemanticByUserID(ctx, userID, input.Query, limit)
+		outcome, err := diaryService.SearchDiaryEntriesSemanticByUserID(ctx, userID, input.Query, limit, nil)
 		if err != nil {
 			return nil, SearchDiaryEntriesFuzzyOutput{}, friendlyError(err)
 		}
@@ -0,0 +1,257 @@
+// Package media は添付画像のメタデータ抽出（EXIF）とサムネイル生成を行う。
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"time"
+)
+
+// Metadata は画像から抽出したメタデータ
+type Metadata struct {
+	// TakenAt は撮影日時（不明な場合はゼロ値）
+	TakenAt time.Time
+	// HasLocation はLatitude/Longitudeが有効かどうか
+	HasLocation bool
+	Latitude    float64
+	Longitude   float64
+	// Orientation はEXIFの向き（1〜8、不明な場合は0）
+	Orientation int
+}
+
+// EXIFのタグ
+const (
+	tagOrientation        = 0x0112
+	tagDateTime           = 0x0132
+	tagExifIFD            = 0x8769
+	tagGPSIFD             = 0x8825
+	tagDateTimeOriginal   = 0x9003
+	tagOffsetTimeOriginal = 0x9011
+	tagGPSLatitudeRef     = 0x0001
+	tagGPSLatitude        = 0x0002
+	tagGPSLongitudeRef    = 0x0003
+	tagGPSLongitude       = 0x0004
+)
+
+// EXIFの値の型ごとのバイト数（インデックスが型番号）
+var exifTypeSizes = [...]int{0, 1, 1, 2, 4, 8, 1, 1, 2, 4, 8, 4, 8}
+
+const exifTypeRational = 5
+
+// ExtractMetadata はJPEGのEXIFから撮影日時・位置情報・向きを抽出する
+// EXIFがない・壊れている場合は取得できた項目だけを返す（エラーにはしない）
+// タイムゾーン情報（OffsetTimeOriginal）がない撮影日時はlocのローカル時刻として扱う
+func ExtractMetadata(data []byte, loc *time.Location) Metadata {
+	tiff := findExifTIFF(data)
+	if tiff == nil {
+		return Metadata{}
+	}
+	r, ok := newTIFFReader(tiff)
+	if !ok {
+		return Metadata{}
+	}
+
+	var meta Metadata
+	ifd0 := r.readIFD(r.order.Uint32(tiff[4:8]))
+	if v, ok := r.uint(ifd0, tagOrientation); ok && v >= 1 && v <= 8 {
+		meta.Orientation = int(v)
+	}
+
+	dateTime := r.ascii(ifd0, tagDateTime)
+	offset := ""
+	if exifOffset, ok := r.uint(ifd0, tagExifIFD); ok {
+		exifIFD := r.readIFD(exifOffset)
+		if original := r.ascii(exifIFD, tagDateTimeOriginal); original != "" {
+			dateTime = original
+		}
+		offset = r.ascii(exifIFD, tagOffsetTimeOriginal)
+	}
+	meta.TakenAt = parseExifDateTime(dateTime, offset, loc)
+
+	if gpsOffset, ok := r.uint(ifd0, tagGPSIFD); ok {
+		gps := r.readIFD(gpsOffset)
+		lat, latOK := r.degrees(gps, tagGPSLatitude)
+		lon, lonOK := r.degrees(gps, tagGPSLongitude)
+		if latOK && lonOK && lat <= 90 && lon <= 180 {
+			if r.ascii(gps, tagGPSLatitudeRef) == "S" {
+				lat = -lat
+			}
+			if r.ascii(gps, tagGPSLongitudeRef) == "W" {
+				lon = -lon
+			}
+			meta.HasLocation = true
+			meta.Latitude = lat
+			meta.Longitude = lon
+		}
+	}
+	return meta
+}
+
+// findExifTIFF はJPEGのAPP1セグメントからEXIFのTIFFデータを探す
+func findExifTIFF(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil
+		}
+		marker := data[pos+1]
+		// 0xFFの埋め草はスキップする
+		if marker == 0xFF {
+			pos++
+			continue
+		}
+		// SOS（画像データ開始）・EOIより後にEXIFはない
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if length < 2 || pos+2+length > len(data) {
+			return nil
+		}
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		pos += 2 + length
+	}
+	return nil
+}
+
+type ifdEntry struct {
+	typ   uint16
+	count uint32
+	value []byte // 値が4バイト以下の場合はエントリ内の値、それ以外はオフセット
+}
+
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func newTIFFReader(data []byte) (*tiffReader, bool) {
+	if len(data) < 8 {
+		return nil, false
+	}
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, false
+	}
+	if order.Uint16(data[2:4]) != 42 {
+		return nil, false
+	}
+	return &tiffReader{data: data, order: order}, true
+}
+
+// readIFD はoffsetのIFDのエントリをタグごとに返す
+func (r *tiffReader) readIFD(offset uint32) map[uint16]ifdEntry {
+	entries := map[uint16]ifdEntry{}
+	if uint64(offset)+2 > uint64(len(r.data)) {
+		return entries
+	}
+	count := int(r.order.Uint16(r.data[offset:]))
+	pos := int(offset) + 2
+	for range count {
+		if pos+12 > len(r.data) {
+			break
+		}
+		entries[r.order.Uint16(r.data[pos:])] = ifdEntry{
+			typ:   r.order.Uint16(r.data[pos+2:]),
+			count: r.order.Uint32(r.data[pos+4:]),
+			value: r.data[pos+8 : pos+12],
+		}
+		pos += 12
+	}
+	return entries
+}
+
+// valueBytes はエントリの値のバイト列を返す
+func (r *tiffReader) valueBytes(e ifdEntry) ([]byte, bool) {
+	if int(e.typ) >= len(exifTypeSizes) || exifTypeSizes[e.typ] == 0 {
+		return nil, false
+	}
+	size := uint64(exifTypeSizes[e.typ]) * uint64(e.count)
+	if size <= 4 {
+		return e.value[:size], true
+	}
+	offset := uint64(r.order.Uint32(e.value))
+	if offset+size > uint64(len(r.data)) {
+		return nil, false
+	}
+	return r.data[offset : offset+size], true
+}
+
+// uint はSHORT/LONGの値を返す
+func (r *tiffReader) uint(ifd map[uint16]ifdEntry, tag uint16) (uint32, bool) {
+	e, ok := ifd[tag]
+	if !ok || e.count == 0 {
+		return 0, false
+	}
+	switch e.typ {
+	case 3:
+		return uint32(r.order.Uint16(e.value)), true
+	case 4:
+		return r.order.Uint32(e.value), true
+	}
+	return 0, false
+}
+
+// ascii はASCIIの値を返す（末尾のNULは除く）
+func (r *tiffReader) ascii(ifd map[uint16]ifdEntry, tag uint16) string {
+	e, ok := ifd[tag]
+	if !ok || e.typ != 2 {
+		return ""
+	}
+	b, ok := r.valueBytes(e)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimRight(string(b), "\x00"))
+}
+
+// degrees はGPSの度・分・秒（RATIONAL×3）を度に変換する
+func (r *tiffReader) degrees(ifd map[uint16]ifdEntry, tag uint16) (float64, bool) {
+	e, ok := ifd[tag]
+	if !ok || e.typ != exifTypeRational || e.count != 3 {
+		return 0, false
+	}
+	b, ok := r.valueBytes(e)
+	if !ok {
+		return 0, false
+	}
+	var parts [3]float64
+	for i := range parts {
+		num := r.order.Uint32(b[i*8:])
+		den := r.order.Uint32(b[i*8+4:])
+		if den == 0 {
+			return 0, false
+		}
+		parts[i] = float64(num) / float64(den)
+	}
+	return parts[0] + parts[1]/60 + parts[2]/3600, true
+}
+
+// parseExifDateTime は "2006:01:02 15:04:05" 形式の日時を解析する
+func parseExifDateTime(value, offset string, loc *time.Location) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	if offset != "" {
+		if t, err := time.Parse("2006:01:02 15:04:05-07:00", value+offset); err == nil {
+			return t
+		}
+	}
+	t, err := time.ParseInLocation("2006:01:02 15:04:05", value, loc)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,166 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math"
+	"testing"
+	"time"
+)
+
+// buildExifAPP1 は向き・撮影日時・位置情報を含むEXIF（ビッグエンディアン）のAPP1セグメントを作る
+func buildExifAPP1() []byte {
+	var tiff bytes.Buffer
+	be := binary.BigEndian
+	u16 := func(v uint16) { _ = binary.Write(&tiff, be, v) }
+	u32 := func(v uint32) { _ = binary.Write(&tiff, be, v) }
+	entry := func(tag, typ uint16, count, value uint32) { u16(tag); u16(typ); u32(count); u32(value) }
+
+	// 各領域のオフセット（ヘッダー8バイト、IFD0: 3件、Exif IFD: 2件、GPS IFD: 4件）
+	const (
+		ifd0Offset   = 8
+		exifOffset   = ifd0Offset + 2 + 3*12 + 4
+		gpsOffset    = exifOffset + 2 + 2*12 + 4
+		dateOffset   = gpsOffset + 2 + 4*12 + 4
+		tzOffset     = dateOffset + 20
+		latOffset    = tzOffset + 7
+		lonOffset    = latOffset + 24
+		orientation6 = 6 << 16 // SHORTは4バイト領域の先頭に左詰めで入る
+		refN         = 'N' << 24
+		refE         = 'E' << 24
+	)
+
+	tiff.WriteString("MM")
+	u16(42)
+	u32(ifd0Offset)
+
+	u16(3)
+	entry(tagOrientation, 3, 1, orientation6)
+	entry(tagExifIFD, 4, 1, exifOffset)
+	entry(tagGPSIFD, 4, 1, gpsOffset)
+	u32(0)
+
+	u16(2)
+	entry(tagDateTimeOriginal, 2, 20, dateOffset)
+	entry(tagOffsetTimeOriginal, 2, 7, tzOffset)
+	u32(0)
+
+	u16(4)
+	entry(tagGPSLatitudeRef, 2, 2, refN)
+	entry(tagGPSLatitude, exifTypeRational, 3, latOffset)
+	entry(tagGPSLongitudeRef, 2, 2, refE)
+	entry(tagGPSLongitude, exifTypeRational, 3, lonOffset)
+	u32(0)
+
+	tiff.WriteString("2024:05:01 07:30:00\x00")
+	tiff.WriteString("+09:00\x00")
+	// 35度39分36秒、139度42分0秒
+	for _, v := range []uint32{35, 1, 39, 1, 36, 1, 139, 1, 42, 1, 0, 1} {
+		u32(v)
+	}
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	be.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// testJPEG はw×hのJPEGを作り、exifが指定されていればSOIの直後に挿入する
+func testJPEG(t *testing.T, w, h int, exif []byte) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("JPEGエンコード失敗: %v", err)
+	}
+	data := buf.Bytes()
+	if exif == nil {
+		return data
+	}
+	return append(append(append([]byte{}, data[:2]...), exif...), data[2:]...)
+}
+
+func TestExtractMetadata(t *testing.T) {
+	meta := ExtractMetadata(testJPEG(t, 8, 8, buildExifAPP1()), time.UTC)
+
+	expectedTakenAt := time.Date(2024, 5, 1, 7, 30, 0, 0, time.FixedZone("", 9*60*60))
+	if !meta.TakenAt.Equal(expectedTakenAt) {
+		t.Errorf("TakenAt: 期待 %v, 実際 %v", expectedTakenAt, meta.TakenAt)
+	}
+	if !meta.HasLocation || math.Abs(meta.Latitude-35.66) > 1e-9 || math.Abs(meta.Longitude-139.7) > 1e-9 {
+		t.Errorf("位置情報が不正: %+v", meta)
+	}
+	if meta.Orientation != 6 {
+		t.Errorf("Orientation: 期待 6, 実際 %d", meta.Orientation)
+	}
+}
+
+func TestExtractMetadata_NoExif(t *testing.T) {
+	tests := map[string][]byte{
+		"EXIFなしのJPEG": testJPEG(t, 8, 8, nil),
+		"JPEG以外":      []byte("%PDF-1.7"),
+		"壊れたEXIF":     append([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x10}, []byte("Exif\x00\x00MM\x00\x2a\xff\xff\xff\xff")...),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if meta := ExtractMetadata(data, time.UTC); meta != (Metadata{}) {
+				t.Errorf("空のメタデータを期待したが %+v", meta)
+			}
+		})
+	}
+}
+
+func TestGenerateThumbnail(t *testing.T) {
+	data := testJPEG(t, 640, 320, nil)
+
+	tests := []struct {
+		name        string
+		orientation int
+		width       int
+		height      int
+	}{
+		{"向きの指定なし", 0, ThumbnailMaxSize, ThumbnailMaxSize / 2},
+		{"180度回転", 3, ThumbnailMaxSize, ThumbnailMaxSize / 2},
+		{"90度回転で縦横が入れ替わる", 6, ThumbnailMaxSize / 2, ThumbnailMaxSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumb, err := GenerateThumbnail(data, tt.orientation)
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			img, err := jpeg.Decode(bytes.NewReader(thumb))
+			if err != nil {
+				t.Fatalf("サムネイルのデコード失敗: %v", err)
+			}
+			if img.Bounds().Dx() != tt.width || img.Bounds().Dy() != tt.height {
+				t.Errorf("サイズ: 期待 %dx%d, 実際 %v", tt.width, tt.height, img.Bounds())
+			}
+		})
+	}
+
+	t.Run("小さい画像は拡大しない", func(t *testing.T) {
+		thumb, err := GenerateThumbnail(testJPEG(t, 40, 20, nil), 1)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		img, _ := jpeg.Decode(bytes.NewReader(thumb))
+		if img.Bounds().Dx() != 40 || img.Bounds().Dy() != 20 {
+			t.Errorf("サイズが変わっている: %v", img.Bounds())
+		}
+	})
+
+	t.Run("画像以外はエラー", func(t *testing.T) {
+		if _, err := GenerateThumbnail([]byte("not an image"), 0); err == nil {
+			t.Error("エラーを期待したがnil")
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// サムネイル生成の対象形式のデコーダーを登録する
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	// ThumbnailMaxSize はサムネイルの長辺のピクセル数
+	ThumbnailMaxSize = 320
+	// ThumbnailContentType はサムネイルの形式
+	ThumbnailContentType = "image/jpeg"
+	// maxDecodePixels はデコードを許可する最大画素数（展開後に巨大になる画像によるメモリ枯渇を防ぐ）
+	maxDecodePixels  = 50_000_000
+	thumbnailQuality = 80
+)
+
+// ErrImageTooLarge は画素数が大きすぎてサムネイルを生成しない場合のエラー
+var ErrImageTooLarge = errors.New("media: image too large")
+
+// CanGenerateThumbnail はサムネイルを生成できる形式かを返す
+func CanGenerateThumbnail(contentType string) bool {
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
+
+// GenerateThumbnail は画像を長辺ThumbnailMaxSize以下に縮小したJPEGを返す
+// orientationはEXIFの向き（1〜8）で、縮小後に回転・反転して正しい向きにする
+func GenerateThumbnail(data []byte, orientation int) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("media: failed to decode image config: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxDecodePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("media: failed to decode image: %w", err)
+	}
+
+	thumb := applyOrientation(resize(src, ThumbnailMaxSize), orientation)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, fmt.Errorf("media: failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// resize は長辺がmaxSize以下になるよう、面積平均で縮小する（拡大はしない）
+func resize(src image.Image, maxSize int) *image.RGBA {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if w > maxSize || h > maxSize {
+		if w >= h {
+			dw, dh = maxSize, max(1, h*maxSize/w)
+		} else {
+			dw, dh = max(1, w*maxSize/h), maxSize
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := range dh {
+		y0 := b.Min.Y + y*h/dh
+		y1 := max(y0+1, b.Min.Y+(y+1)*h/dh)
+		for x := range dw {
+			x0 := b.Min.X + x*w/dw
+			x1 := max(x0+1, b.Min.X+(x+1)*w/dw)
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					bl += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
+
+// applyOrientation はEXIFの向きに従って画像を回転・反転する
+func applyOrientation(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	// 5〜8は90度回転を含むため縦横が入れ替わる
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := range h {
+		for x := range w {
+			var dx, dy int
+			switch orientation {
+			case 2: // 左右反転
+				dx, dy = w-1-x, y
+			case 3: // 180度回転
+				dx, dy = w-1-x, h-1-y
+			case 4: // 上下反転
+				dx, dy = x, h-1-y
+			case 5: // 左右反転して反時計回りに90度回転
+				dx, dy = y, x
+			case 6: // 時計回りに90度回転
+				dx, dy = h-1-y, x
+			case 7: // 左右反転して時計回りに90度回転
+				dx, dy = h-1-y, w-1-x
+			case 8: // 反時計回りに90度回転
+				dx, dy = y, w-1-x
+			}
+			dst.SetRGBA(dx, dy, src.RGBAAt(x, y))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,59 @@
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/redis/rueidis"
+)
+
+// challengeTTL はパスワードを確認してから2段階目のコードを入力するまでの猶予
+const challengeTTL = 5 * time.Minute
+
+// challengeKeyPrefix はRedis上でチャレンジを保存する際のキー接頭辞
+const challengeKeyPrefix = "mfa_challenge:"
+
+// ChallengeStore はパスワードを確認したユーザーのチャレンジのトークンをRedisに保存する。
+// トークンはJWTを発行するまでの間だけ有効で、成功した時に削除する
+type ChallengeStore struct {
+	Redis rueidis.Client
+}
+
+// Create はユーザーのチャレンジを作成してトークンを返す
+func (s *ChallengeStore) Create(ctx context.Context, userID string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate mfa token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	cmd := s.Redis.B().Set().Key(challengeKeyPrefix + token).Value(userID).Ex(challengeTTL).Build()
+	if err := s.Redis.Do(ctx, cmd).Error(); err != nil {
+		return "", fmt.Errorf("failed to store mfa challenge: %w", err)
+	}
+	return token, nil
+}
+
+// Get はトークンのユーザーIDを返す。コードを間違えても再入力できるよう、取得しても削除しない。
+// 存在しない・期限切れの場合はokがfalseになる
+func (s *ChallengeStore) Get(ctx context.Context, token string) (string, bool, error) {
+	userID, err := s.Redis.Do(ctx, s.Redis.B().Get().Key(challengeKeyPrefix+token).Build()).ToString()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to get mfa challenge: %w", err)
+	}
+	return userID, true, nil
+}
+
+// Consume はトークンを削除する。同時に検証に成功した場合に二重にトークンを発行しないよう、削除できた場合のみokがtrueになる
+func (s *ChallengeStore) Consume(ctx context.Context, token string) (bool, error) {
+	n, err := s.Redis.Do(ctx, s.Redis.B().Del().Key(challengeKeyPrefix+token).Build()).AsInt64()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete mfa challenge: %w", err)
+	}
+	return n == 1, nil
+}
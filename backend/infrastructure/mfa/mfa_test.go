@@ -0,0 +1,165 @@
+package mfa
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/rueidis"
+)
+
+// rfcSecret はRFC 6238の付録Bのテストベクター（SHA-1）の秘密鍵
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestValidateTOTP(t *testing.T) {
+	// RFC 6238の付録Bの8桁のコードの下6桁
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, v := range vectors {
+		t.Run("正常系: RFC 6238のテストベクターと一致する "+v.code, func(t *testing.T) {
+			step, ok := ValidateTOTP(rfcSecret, v.code, time.Unix(v.unix, 0), 0)
+			if !ok {
+				t.Fatal("コードが一致しなかった")
+			}
+			if step != v.unix/30 {
+				t.Errorf("タイムステップが期待と異なる: got %d, want %d", step, v.unix/30)
+			}
+		})
+	}
+
+	t.Run("正常系: 前後1ステップの時計のずれは許容する", func(t *testing.T) {
+		if _, ok := ValidateTOTP(rfcSecret, "287082", time.Unix(59+30, 0), 0); !ok {
+			t.Error("1ステップ後のコードが一致しなかった")
+		}
+		if _, ok := ValidateTOTP(rfcSecret, "287082", time.Unix(59+90, 0), 0); ok {
+			t.Error("3ステップ後でもコードが一致した")
+		}
+	})
+
+	t.Run("異常系: 使用済みのタイムステップのコードは使えない", func(t *testing.T) {
+		if _, ok := ValidateTOTP(rfcSecret, "287082", time.Unix(59, 0), 1); ok {
+			t.Error("使用済みのコードが一致した")
+		}
+	})
+
+	t.Run("異常系: 桁数が異なるコード・不正な秘密鍵は一致しない", func(t *testing.T) {
+		if _, ok := ValidateTOTP(rfcSecret, "94287082", time.Unix(59, 0), 0); ok {
+			t.Error("8桁のコードが一致した")
+		}
+		if _, ok := ValidateTOTP("!!invalid!!", "287082", time.Unix(59, 0), 0); ok {
+			t.Error("不正な秘密鍵で一致した")
+		}
+	})
+}
+
+func TestGenerateSecretAndProvisioningURI(t *testing.T) {
+	t.Run("正常系: 生成した秘密鍵で現在のコードを検証でき、URIに秘密鍵と発行者を含む", func(t *testing.T) {
+		secret, err := GenerateSecret()
+		if err != nil {
+			t.Fatalf("GenerateSecret失敗: %v", err)
+		}
+		now := time.Now()
+		code, err := GenerateCode(secret, now)
+		if err != nil {
+			t.Fatalf("GenerateCode失敗: %v", err)
+		}
+		if _, ok := ValidateTOTP(secret, code, now, 0); !ok {
+			t.Error("生成した秘密鍵のコードが一致しなかった")
+		}
+
+		u, err := url.Parse(ProvisioningURI("user@example.com", secret))
+		if err != nil {
+			t.Fatalf("URIが不正: %v", err)
+		}
+		if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/umi.mikan:user@example.com" {
+			t.Errorf("URIが期待と異なる: %s", u)
+		}
+		if u.Query().Get("secret") != secret || u.Query().Get("issuer") != Issuer {
+			t.Errorf("URIのパラメーターが期待と異なる: %s", u.RawQuery)
+		}
+	})
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	t.Run("正常系: 重複しないコードを生成し、表記の揺れがあっても同じハッシュになる", func(t *testing.T) {
+		codes, err := GenerateRecoveryCodes()
+		if err != nil {
+			t.Fatalf("GenerateRecoveryCodes失敗: %v", err)
+		}
+		if len(codes) != RecoveryCodeCount {
+			t.Fatalf("コードの数が期待と異なる: %d", len(codes))
+		}
+		seen := map[string]bool{}
+		for _, code := range codes {
+			if len(code) != 11 || code[5] != '-' || !IsRecoveryCode(code) {
+				t.Errorf("コードの形式が期待と異なる: %s", code)
+			}
+			if seen[code] {
+				t.Errorf("重複したコード: %s", code)
+			}
+			seen[code] = true
+		}
+		code := codes[0]
+		if HashRecoveryCode(code) != HashRecoveryCode(strings.ToUpper(strings.ReplaceAll(code, "-", " "))) {
+			t.Error("表記の揺れでハッシュが変わった")
+		}
+		if IsRecoveryCode("123456") {
+			t.Error("TOTPのコードをリカバリーコードと判定した")
+		}
+	})
+}
+
+func TestChallengeStore(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis起動失敗: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	client, err := rueidis.NewClient(rueidis.ClientOption{InitAddress: []string{mr.Addr()}, DisableCache: true})
+	if err != nil {
+		t.Fatalf("rueidisクライアント作成失敗: %v", err)
+	}
+	t.Cleanup(client.Close)
+	store := &ChallengeStore{Redis: client}
+
+	t.Run("正常系: 作成したチャレンジは何度でも取得でき、削除は1回だけ成功する", func(t *testing.T) {
+		token, err := store.Create(t.Context(), "user-1")
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		for range 2 {
+			userID, ok, err := store.Get(t.Context(), token)
+			if err != nil || !ok || userID != "user-1" {
+				t.Fatalf("Getの結果が期待と異なる: %s, %v, %v", userID, ok, err)
+			}
+		}
+		if ok, err := store.Consume(t.Context(), token); err != nil || !ok {
+			t.Fatalf("Consumeの結果が期待と異なる: %v, %v", ok, err)
+		}
+		if ok, _ := store.Consume(t.Context(), token); ok {
+			t.Error("同じトークンを2回削除できた")
+		}
+	})
+
+	t.Run("異常系: 期限切れのチャレンジは取得できない", func(t *testing.T) {
+		token, err := store.Create(t.Context(), "user-1")
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		mr.FastForward(challengeTTL + time.Second)
+		if _, ok, err := store.Get(t.Context(), token); err != nil || ok {
+			t.Errorf("期限切れのチャレンジを取得できた: %v, %v", ok, err)
+		}
+	})
+}
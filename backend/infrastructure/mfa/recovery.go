@@ -0,0 +1,46 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// RecoveryCodeCount は一度に発行するリカバリーコードの数
+const RecoveryCodeCount = 10
+
+// recoveryCodeBytes はリカバリーコードのランダムなバイト数（Base32で10文字、50ビット）
+const recoveryCodeBytes = 7
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateRecoveryCodes はリカバリーコード（xxxxx-xxxxx）を生成する。
+// DBにはHashRecoveryCodeのハッシュのみを保存し、コード自体は発行時に一度だけユーザーに表示する
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, RecoveryCodeCount)
+	for range RecoveryCodeCount {
+		buf := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		s := strings.ToLower(recoveryEncoding.EncodeToString(buf))[:10]
+		codes = append(codes, s[:5]+"-"+s[5:])
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode はリカバリーコードのハッシュを返す。
+// 大文字・小文字、区切りのハイフンと空白は区別しない（入力の揺れを許容する）
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsRecoveryCode はコードがTOTPのコードではなくリカバリーコードの形式かどうかを返す
+func IsRecoveryCode(code string) bool {
+	return len(strings.NewReplacer("-", "", " ", "").Replace(code)) == 10
+}
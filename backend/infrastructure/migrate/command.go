@@ -0,0 +1,129 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/migrations"
+)
+
+// CommandName はserver・subscriber・schedulerの各バイナリでマイグレーションを実行するサブコマンドの名前
+const CommandName = "migrate"
+
+// DefaultModelDir はlintで読み込むdbtplのモデルのディレクトリ（backendのディレクトリからの相対パス）
+const DefaultModelDir = "infrastructure/database"
+
+const usage = `usage: migrate <command> [flags]
+
+commands:
+  up                 未適用のマイグレーションをすべて適用する
+  down [-steps N]    新しいものからN件（デフォルト: 1）のマイグレーションを取り消す
+  status             マイグレーションの適用状況を表示する
+  lint [-dir DIR]    dbtplのモデルとデータベースの列が一致するか確認する`
+
+// UpEmbedded は埋め込んだマイグレーションをすべて適用する（起動時のマイグレーションで使う）
+func UpEmbedded(ctx context.Context, db *sql.DB) ([]*Migration, error) {
+	all, err := Load(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	return NewMigrator(db, all).Up(ctx)
+}
+
+// Run はmigrateサブコマンドを実行する
+func Run(ctx context.Context, db *sql.DB, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+	all, err := Load(migrations.FS)
+	if err != nil {
+		return err
+	}
+	migrator := NewMigrator(db, all)
+
+	switch args[0] {
+	case "up":
+		if err := flag.NewFlagSet("up", flag.ContinueOnError).Parse(args[1:]); err != nil {
+			return err
+		}
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			_, _ = fmt.Fprintf(out, "applied %s\n", m)
+		}
+		if err == nil && len(applied) == 0 {
+			_, _ = fmt.Fprintln(out, "no pending migrations")
+		}
+		return err
+	case "down":
+		fs := flag.NewFlagSet("down", flag.ContinueOnError)
+		steps := fs.Int("steps", 1, "取り消すマイグレーションの数")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *steps <= 0 {
+			return errors.New("-steps must be a positive integer")
+		}
+		reverted, err := migrator.Down(ctx, *steps)
+		for _, m := range reverted {
+			_, _ = fmt.Fprintf(out, "reverted %s\n", m)
+		}
+		return err
+	case "status":
+		if err := flag.NewFlagSet("status", flag.ContinueOnError).Parse(args[1:]); err != nil {
+			return err
+		}
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			_, _ = fmt.Fprintf(out, "%04d_%s\t%s\n", s.Version, s.Name, describeStatus(s))
+		}
+		return nil
+	case "lint":
+		fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+		dir := fs.String("dir", DefaultModelDir, "dbtplのモデルのディレクトリ")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		models, err := ParseModels(*dir)
+		if err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			return fmt.Errorf("no dbtpl models found in %s", *dir)
+		}
+		problems, err := LintModels(ctx, db, models)
+		if err != nil {
+			return err
+		}
+		for _, p := range problems {
+			_, _ = fmt.Fprintln(out, p)
+		}
+		if len(problems) > 0 {
+			return fmt.Errorf("found %d mismatches between the models and the database", len(problems))
+		}
+		_, _ = fmt.Fprintf(out, "%d models match the database\n", len(models))
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], usage)
+	}
+}
+
+func describeStatus(s Status) string {
+	switch {
+	case s.AppliedAt == 0:
+		return "pending"
+	case s.Migration == nil:
+		return "applied (unknown to this binary)"
+	case s.Modified:
+		return "applied (modified after applied)"
+	default:
+		return "applied at " + time.Unix(s.AppliedAt, 0).UTC().Format(time.RFC3339)
+	}
+}
@@ -0,0 +1,174 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// modelCommentPattern はdbtplが生成するモデルの型のコメント（例: "User represents a row from 'public.users'."）
+var modelCommentPattern = regexp.MustCompile(`represents a row from '([a-z0-9_]+)\.([a-z0-9_]+)'`)
+
+// Model はdbtplが生成したモデルの型と、対応するテーブル
+type Model struct {
+	Type    string
+	Schema  string
+	Table   string
+	Columns []ModelColumn
+}
+
+// ModelColumn はモデルのフィールドに対応する列
+type ModelColumn struct {
+	Name     string
+	Nullable bool // sql.Null*・uuid.NullUUID かポインター型の場合はNULLを許容する列とみなす
+}
+
+// ParseModels はディレクトリの *.dbtpl.go からモデルを読み込む
+func ParseModels(dir string) ([]Model, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.dbtpl.go"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+
+	var models []Model
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE || gen.Doc == nil {
+				continue
+			}
+			match := modelCommentPattern.FindStringSubmatch(gen.Doc.Text())
+			if match == nil {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				models = append(models, Model{
+					Type:    typeSpec.Name.Name,
+					Schema:  match[1],
+					Table:   match[2],
+					Columns: modelColumns(structType),
+				})
+			}
+		}
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].Table < models[j].Table })
+	return models, nil
+}
+
+func modelColumns(structType *ast.StructType) []ModelColumn {
+	var columns []ModelColumn
+	for _, field := range structType.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		name, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		columns = append(columns, ModelColumn{Name: name, Nullable: isNullableType(field.Type)})
+	}
+	return columns
+}
+
+func isNullableType(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return true
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return false
+		}
+		return (pkg.Name == "sql" && strings.HasPrefix(t.Sel.Name, "Null")) || (pkg.Name == "uuid" && t.Sel.Name == "NullUUID")
+	}
+	return false
+}
+
+// LintModels はモデルと実際のデータベースの列を比べ、一致しない点を返す
+// （列の有無とNULLの許容のみを比べ、型は比べない）
+func LintModels(ctx context.Context, db *sql.DB, models []Model) ([]string, error) {
+	var problems []string
+	for _, model := range models {
+		columns, err := tableColumns(ctx, db, model.Schema, model.Table)
+		if err != nil {
+			return nil, err
+		}
+		if len(columns) == 0 {
+			problems = append(problems, fmt.Sprintf("%s: table %s.%s does not exist", model.Type, model.Schema, model.Table))
+			continue
+		}
+
+		for _, column := range model.Columns {
+			nullable, ok := columns[column.Name]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: column %s.%s does not exist", model.Type, model.Table, column.Name))
+				continue
+			}
+			delete(columns, column.Name)
+			if nullable.valid && nullable.nullable != column.Nullable {
+				problems = append(problems, fmt.Sprintf("%s: column %s.%s nullable is %t in the database but %t in the model",
+					model.Type, model.Table, column.Name, nullable.nullable, column.Nullable))
+			}
+		}
+		missing := make([]string, 0, len(columns))
+		for name := range columns {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		for _, name := range missing {
+			problems = append(problems, fmt.Sprintf("%s: column %s.%s is missing in the model", model.Type, model.Table, name))
+		}
+	}
+	return problems, nil
+}
+
+type columnNullability struct {
+	nullable bool
+	valid    bool // NULLの許容をモデルの型から判断できる列か
+}
+
+func tableColumns(ctx context.Context, db *sql.DB, schema, table string) (map[string]columnNullability, error) {
+	rows, err := db.QueryContext(ctx, `SELECT column_name, is_nullable = 'YES', data_type NOT IN ('bytea', 'json', 'jsonb', 'ARRAY', 'USER-DEFINED')
+FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2`, schema, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query columns of %s.%s: %w", schema, table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns := make(map[string]columnNullability)
+	for rows.Next() {
+		var name string
+		var c columnNullability
+		if err := rows.Scan(&name, &c.nullable, &c.valid); err != nil {
+			return nil, fmt.Errorf("failed to scan columns: %w", err)
+		}
+		columns[name] = c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return columns, nil
+}
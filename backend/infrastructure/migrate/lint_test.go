@@ -0,0 +1,41 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseModels(t *testing.T) {
+	t.Run("正常系: dbtplのモデルからテーブルと列を読み込む", func(t *testing.T) {
+		models, err := ParseModels("../database")
+		require.NoError(t, err)
+
+		byTable := make(map[string]Model)
+		for _, m := range models {
+			byTable[m.Table] = m
+		}
+		users, ok := byTable["users"]
+		require.True(t, ok)
+		assert.Equal(t, "User", users.Type)
+		assert.Equal(t, "public", users.Schema)
+		assert.Contains(t, users.Columns, ModelColumn{Name: "email", Nullable: false})
+
+		diaries, ok := byTable["diaries"]
+		require.True(t, ok)
+		assert.Contains(t, diaries.Columns, ModelColumn{Name: "entry_time", Nullable: true})
+		assert.Contains(t, diaries.Columns, ModelColumn{Name: "deleted_at", Nullable: true})
+		assert.Contains(t, diaries.Columns, ModelColumn{Name: "content", Nullable: false})
+
+		invitations, ok := byTable["invitations"]
+		require.True(t, ok)
+		assert.Contains(t, invitations.Columns, ModelColumn{Name: "created_by", Nullable: true})
+	})
+
+	t.Run("正常系: モデルがないディレクトリは空", func(t *testing.T) {
+		models, err := ParseModels(t.TempDir())
+		require.NoError(t, err)
+		assert.Empty(t, models)
+	})
+}
@@ -0,0 +1,103 @@
+// Package migrate はバージョン付きのマイグレーションをデータベースに適用する。
+// 適用したマイグレーションはチェックサムとともに schema_migrations テーブルに記録する（ADR 0028）。
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BaselineVersion はマイグレーションを導入した時点のスキーマのバージョン
+const BaselineVersion = 1
+
+// noTransactionDirective を含むスクリプトはトランザクションの外で実行する
+// （CREATE INDEX CONCURRENTLY などトランザクションの中で実行できない文のため）
+const noTransactionDirective = "-- migrate:no-transaction"
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Script はマイグレーションの片方向のSQL
+type Script struct {
+	SQL           string
+	NoTransaction bool
+}
+
+// Migration は1つのバージョンのマイグレーション
+type Migration struct {
+	Version int64
+	Name    string
+	Up      *Script
+	Down    *Script // nilの場合は戻せない
+}
+
+// Checksum は適用後の変更を検出するためのupのSQLのハッシュ
+func (m *Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up.SQL))
+	return hex.EncodeToString(sum[:])
+}
+
+// String はログに出すための `<バージョン>_<名前>`
+func (m *Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// Load はディレクトリ直下のSQLファイルからマイグレーションをバージョン順に読み込む
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version: %s", entry.Name())
+		}
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, m.Name, match[2])
+		}
+		script := &Script{
+			SQL:           string(data),
+			NoTransaction: strings.Contains(string(data), noTransactionDirective),
+		}
+		if match[3] == "up" {
+			m.Up = script
+		} else {
+			m.Down = script
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == nil {
+			return nil, fmt.Errorf("migration %s has no up file", m)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
@@ -0,0 +1,74 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/project-mikan/umi.mikan/backend/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("正常系: バージョン順に読み込み、downのファイルとトランザクションの指定を反映する", func(t *testing.T) {
+		all, err := Load(fstest.MapFS{
+			"0010_add_index.up.sql":      {Data: []byte("-- migrate:no-transaction\nCREATE INDEX CONCURRENTLY idx ON t (c);")},
+			"0002_create_t.up.sql":       {Data: []byte("CREATE TABLE t (c INT);")},
+			"0002_create_t.down.sql":     {Data: []byte("DROP TABLE t;")},
+			"README.md":                  {Data: []byte("SQL以外のファイルは無視する")},
+			"0010_add_index.down.sql":    {Data: []byte("DROP INDEX idx;")},
+			"0001_baseline.up.sql":       {Data: []byte("CREATE TABLE users (id UUID);")},
+			"subdir/0003_ignored.up.sql": {Data: []byte("SELECT 1;")},
+		})
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+
+		assert.Equal(t, "0001_baseline", all[0].String())
+		assert.Nil(t, all[0].Down)
+		assert.Equal(t, "0002_create_t", all[1].String())
+		assert.Equal(t, "DROP TABLE t;", all[1].Down.SQL)
+		assert.False(t, all[1].Up.NoTransaction)
+		assert.Equal(t, int64(10), all[2].Version)
+		assert.True(t, all[2].Up.NoTransaction)
+		assert.False(t, all[2].Down.NoTransaction)
+	})
+
+	t.Run("正常系: チェックサムはupのSQLだけで決まる", func(t *testing.T) {
+		a := &Migration{Version: 2, Name: "a", Up: &Script{SQL: "SELECT 1;"}}
+		b := &Migration{Version: 3, Name: "b", Up: &Script{SQL: "SELECT 1;"}, Down: &Script{SQL: "SELECT 2;"}}
+		c := &Migration{Version: 2, Name: "a", Up: &Script{SQL: "SELECT 2;"}}
+		assert.Len(t, a.Checksum(), 64)
+		assert.Equal(t, a.Checksum(), b.Checksum())
+		assert.NotEqual(t, a.Checksum(), c.Checksum())
+	})
+
+	t.Run("正常系: 埋め込んだマイグレーションの最初はdownのないベースライン", func(t *testing.T) {
+		all, err := Load(migrations.FS)
+		require.NoError(t, err)
+		require.NotEmpty(t, all)
+		assert.Equal(t, int64(BaselineVersion), all[0].Version)
+		assert.Nil(t, all[0].Down)
+		assert.Contains(t, all[0].Up.SQL, "CREATE TABLE IF NOT EXISTS users")
+	})
+
+	t.Run("異常系: 不正なマイグレーションのファイルはエラー", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			files fstest.MapFS
+		}{
+			{"ファイル名の形式が不正", fstest.MapFS{"create_t.sql": {Data: []byte("SELECT 1;")}}},
+			{"バージョンが0", fstest.MapFS{"0000_zero.up.sql": {Data: []byte("SELECT 1;")}}},
+			{"upのファイルがない", fstest.MapFS{"0002_create_t.down.sql": {Data: []byte("DROP TABLE t;")}}},
+			{"同じバージョンで名前が異なる", fstest.MapFS{
+				"0002_create_t.up.sql": {Data: []byte("SELECT 1;")},
+				"0002_create_u.up.sql": {Data: []byte("SELECT 2;")},
+			}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := Load(tt.files)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
@@ -0,0 +1,271 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// advisoryLockKey は同時に起動したプロセスが同じマイグレーションを重ねて適用しないためのアドバイザリロックのキー
+const advisoryLockKey int64 = 0x756d695f6d6967 // "umi_mig"
+
+// baselineTable が存在する場合は、マイグレーションの導入前に schema/ から作成したデータベースとみなす
+const baselineTable = "public.users"
+
+// ErrIrreversible はdownのファイルがないマイグレーションを戻そうとした場合のエラー
+var ErrIrreversible = errors.New("migration is irreversible")
+
+// Status はマイグレーションの適用状況
+type Status struct {
+	Version   int64
+	Name      string
+	Migration *Migration // このバイナリが知らないバージョンが適用されている場合はnil
+	AppliedAt int64      // 未適用の場合は0
+	Modified  bool       // 適用後にupのSQLが変更された
+}
+
+// Migrator はマイグレーションを適用・取り消しする
+type Migrator struct {
+	DB         *sql.DB
+	Migrations []*Migration
+	Now        func() time.Time
+}
+
+func NewMigrator(db *sql.DB, migrations []*Migration) *Migrator {
+	return &Migrator{DB: db, Migrations: migrations, Now: time.Now}
+}
+
+type appliedMigration struct {
+	name      string
+	checksum  string
+	appliedAt int64
+}
+
+// Up は未適用のマイグレーションをバージョン順にすべて適用し、適用したマイグレーションを返す
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	var done []*Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		if err := m.adoptBaseline(ctx, conn); err != nil {
+			return err
+		}
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+
+		var latest int64
+		for version := range applied {
+			latest = max(latest, version)
+		}
+		for _, migration := range m.Migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			// 後から古いバージョンを追加すると環境によって適用順が変わるため受け付けない
+			if migration.Version < latest {
+				return fmt.Errorf("migration %s is older than the applied version %d", migration, latest)
+			}
+			if err := m.run(ctx, conn, migration.Up, func(ex executor) error {
+				_, err := ex.ExecContext(ctx,
+					"INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES ($1, $2, $3, $4)",
+					migration.Version, migration.Name, migration.Checksum(), m.Now().Unix())
+				return err
+			}); err != nil {
+				return fmt.Errorf("failed to apply migration %s: %w", migration, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down は新しいものから steps 件のマイグレーションを取り消し、取り消したマイグレーションを返す
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	var done []*Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+
+		byVersion := m.byVersion()
+		versions := make([]int64, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+		for _, version := range versions[:min(steps, len(versions))] {
+			migration := byVersion[version]
+			if migration.Down == nil {
+				return fmt.Errorf("failed to revert migration %s: %w", migration, ErrIrreversible)
+			}
+			if err := m.run(ctx, conn, migration.Down, func(ex executor) error {
+				_, err := ex.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", version)
+				return err
+			}); err != nil {
+				return fmt.Errorf("failed to revert migration %s: %w", migration, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status はすべてのマイグレーションと、このバイナリが知らない適用済みのバージョンの状況をバージョン順に返す
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.Migrations {
+			status := Status{Version: migration.Version, Name: migration.Name, Migration: migration}
+			if a, ok := applied[migration.Version]; ok {
+				status.AppliedAt = a.appliedAt
+				status.Modified = a.checksum != migration.Checksum()
+				delete(applied, migration.Version)
+			}
+			statuses = append(statuses, status)
+		}
+		for version, a := range applied {
+			statuses = append(statuses, Status{Version: version, Name: a.name, AppliedAt: a.appliedAt})
+		}
+		return nil
+	})
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, err
+}
+
+// withLock はアドバイザリロックを取得した接続で fn を実行する（ロックは接続に紐づくため同じ接続を使い続ける）
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// ctxがキャンセルされていてもロックを解放する
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", advisoryLockKey)
+	}()
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    checksum VARCHAR(64) NOT NULL, -- upのSQLのSHA-256ハッシュ（hex）
+    applied_at BIGINT NOT NULL
+)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	return fn(conn)
+}
+
+// adoptBaseline はマイグレーションの導入前に作成したデータベースに、ベースラインを適用済みとして記録する
+func (m *Migrator) adoptBaseline(ctx context.Context, conn *sql.Conn) error {
+	if len(m.Migrations) == 0 || m.Migrations[0].Version != BaselineVersion {
+		return nil
+	}
+	var exists bool
+	if err := conn.QueryRowContext(ctx,
+		"SELECT NOT EXISTS (SELECT 1 FROM schema_migrations) AND to_regclass($1) IS NOT NULL", baselineTable,
+	).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check baseline: %w", err)
+	}
+	if !exists {
+		return nil
+	}
+	baseline := m.Migrations[0]
+	if _, err := conn.ExecContext(ctx,
+		"INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES ($1, $2, $3, $4)",
+		baseline.Version, baseline.Name, baseline.Checksum(), m.Now().Unix()); err != nil {
+		return fmt.Errorf("failed to record baseline: %w", err)
+	}
+	return nil
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schema_migrations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	applied := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var version int64
+		var a appliedMigration
+		if err := rows.Scan(&version, &a.name, &a.checksum, &a.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[version] = a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+	return applied, nil
+}
+
+// verify は適用済みのマイグレーションがこのバイナリのものと一致することを確認する
+func (m *Migrator) verify(applied map[int64]appliedMigration) error {
+	byVersion := m.byVersion()
+	for version, a := range applied {
+		migration, ok := byVersion[version]
+		if !ok {
+			return fmt.Errorf("applied migration %04d_%s is unknown to this binary", version, a.name)
+		}
+		if a.checksum != migration.Checksum() {
+			return fmt.Errorf("applied migration %s has been modified (checksum mismatch)", migration)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) byVersion() map[int64]*Migration {
+	byVersion := make(map[int64]*Migration, len(m.Migrations))
+	for _, migration := range m.Migrations {
+		byVersion[migration.Version] = migration
+	}
+	return byVersion
+}
+
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// run はスクリプトと schema_migrations の更新を1つのトランザクションで実行する
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, script *Script, record func(ex executor) error) error {
+	if script.NoTransaction {
+		if _, err := conn.ExecContext(ctx, script.SQL); err != nil {
+			return err
+		}
+		return record(conn)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.ExecContext(ctx, script.SQL); err != nil {
+		return err
+	}
+	if err := record(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
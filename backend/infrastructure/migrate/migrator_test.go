@@ -0,0 +1,162 @@
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSchema はテストごとのスキーマを作成し、そのスキーマを search_path にした接続を返す
+func setupSchema(t *testing.T) (*sql.DB, string) {
+	t.Helper()
+	admin := testutil.SetupTestDB(t)
+	schema := "migrate_test_" + uuid.NewString()[:8]
+	_, err := admin.Exec(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE; CREATE SCHEMA %s", schema, schema))
+	require.NoError(t, err)
+	t.Cleanup(func() { _, _ = admin.Exec(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", schema)) })
+
+	config := testutil.DefaultTestDBConfig()
+	db, err := sql.Open("postgres", fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable search_path=%s",
+		config.Host, config.Port, config.User, config.Password, config.DBName, schema))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, schema
+}
+
+func TestMigrator(t *testing.T) {
+	notes := &Migration{Version: 2, Name: "create_notes",
+		Up:   &Script{SQL: "CREATE TABLE notes (id INT PRIMARY KEY, body TEXT NOT NULL);"},
+		Down: &Script{SQL: "DROP TABLE notes;"}}
+	memo := &Migration{Version: 3, Name: "add_memo",
+		Up:   &Script{SQL: "-- migrate:no-transaction\nALTER TABLE notes ADD COLUMN memo TEXT;", NoTransaction: true},
+		Down: &Script{SQL: "ALTER TABLE notes DROP COLUMN memo;"}}
+
+	t.Run("正常系: 未適用のマイグレーションを適用し、取り消せる", func(t *testing.T) {
+		db, _ := setupSchema(t)
+		m := NewMigrator(db, []*Migration{notes, memo})
+
+		applied, err := m.Up(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []*Migration{notes, memo}, applied)
+		_, err = db.Exec("INSERT INTO notes (id, body, memo) VALUES (1, '本文', 'メモ')")
+		require.NoError(t, err)
+
+		// 2回目は何も適用しない
+		applied, err = m.Up(t.Context())
+		require.NoError(t, err)
+		assert.Empty(t, applied)
+
+		statuses, err := m.Status(t.Context())
+		require.NoError(t, err)
+		require.Len(t, statuses, 2)
+		assert.NotZero(t, statuses[0].AppliedAt)
+		assert.NotZero(t, statuses[1].AppliedAt)
+
+		reverted, err := m.Down(t.Context(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, []*Migration{memo}, reverted)
+		_, err = db.Exec("SELECT memo FROM notes")
+		assert.Error(t, err)
+
+		statuses, err = m.Status(t.Context())
+		require.NoError(t, err)
+		assert.Zero(t, statuses[1].AppliedAt)
+	})
+
+	t.Run("異常系: 失敗したマイグレーションはロールバックし、記録しない", func(t *testing.T) {
+		db, _ := setupSchema(t)
+		broken := &Migration{Version: 3, Name: "broken", Up: &Script{SQL: "CREATE TABLE broken (id INT); SELECT 1/0;"}}
+		m := NewMigrator(db, []*Migration{notes, broken})
+
+		applied, err := m.Up(t.Context())
+		require.Error(t, err)
+		assert.Equal(t, []*Migration{notes}, applied)
+		var exists bool
+		require.NoError(t, db.QueryRow("SELECT to_regclass('broken') IS NOT NULL").Scan(&exists))
+		assert.False(t, exists)
+
+		statuses, err := m.Status(t.Context())
+		require.NoError(t, err)
+		assert.Zero(t, statuses[1].AppliedAt)
+	})
+
+	t.Run("異常系: 適用後に変更したマイグレーションと戻せないマイグレーションはエラー", func(t *testing.T) {
+		db, _ := setupSchema(t)
+		irreversible := &Migration{Version: 2, Name: "create_notes", Up: notes.Up}
+		_, err := NewMigrator(db, []*Migration{irreversible}).Up(t.Context())
+		require.NoError(t, err)
+
+		_, err = NewMigrator(db, []*Migration{irreversible}).Down(t.Context(), 1)
+		assert.ErrorIs(t, err, ErrIrreversible)
+
+		modified := &Migration{Version: 2, Name: "create_notes", Up: &Script{SQL: notes.Up.SQL + "\n-- 変更"}}
+		_, err = NewMigrator(db, []*Migration{modified}).Up(t.Context())
+		assert.ErrorContains(t, err, "checksum mismatch")
+		statuses, err := NewMigrator(db, []*Migration{modified}).Status(t.Context())
+		require.NoError(t, err)
+		assert.True(t, statuses[0].Modified)
+	})
+
+	t.Run("異常系: 適用済みのバージョンより古いマイグレーションを後から追加するとエラー", func(t *testing.T) {
+		db, _ := setupSchema(t)
+		_, err := NewMigrator(db, []*Migration{withUp(memo, "CREATE TABLE notes (id INT);")}).Up(t.Context())
+		require.NoError(t, err)
+
+		_, err = NewMigrator(db, []*Migration{notes, withUp(memo, "CREATE TABLE notes (id INT);")}).Up(t.Context())
+		assert.ErrorContains(t, err, "older than the applied version")
+	})
+
+	t.Run("正常系: 導入前から存在するデータベースはベースラインを実行せずに適用済みとして記録する", func(t *testing.T) {
+		db, _ := setupSchema(t)
+		// テスト用のデータベースには public.users が存在する
+		baseline := &Migration{Version: BaselineVersion, Name: "baseline", Up: &Script{SQL: "SELECT 1/0;"}}
+		m := NewMigrator(db, []*Migration{baseline, notes})
+
+		applied, err := m.Up(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []*Migration{notes}, applied)
+		statuses, err := m.Status(t.Context())
+		require.NoError(t, err)
+		assert.NotZero(t, statuses[0].AppliedAt)
+	})
+}
+
+// withUp はupのSQLを差し替えた戻せないマイグレーションを返す
+func withUp(m *Migration, sql string) *Migration {
+	return &Migration{Version: m.Version, Name: m.Name, Up: &Script{SQL: sql}}
+}
+
+func TestLintModels(t *testing.T) {
+	db, schema := setupSchema(t)
+	_, err := db.Exec("CREATE TABLE notes (id INT PRIMARY KEY, body TEXT NOT NULL, memo TEXT, payload JSONB)")
+	require.NoError(t, err)
+
+	t.Run("正常系: 列とNULLの許容が一致する場合は問題なし", func(t *testing.T) {
+		problems, err := LintModels(t.Context(), db, []Model{{Type: "Note", Schema: schema, Table: "notes", Columns: []ModelColumn{
+			{Name: "id"}, {Name: "body"}, {Name: "memo", Nullable: true}, {Name: "payload"},
+		}}})
+		require.NoError(t, err)
+		assert.Empty(t, problems)
+	})
+
+	t.Run("異常系: 一致しない列とないテーブルを報告する", func(t *testing.T) {
+		problems, err := LintModels(t.Context(), db, []Model{
+			{Type: "Note", Schema: schema, Table: "notes", Columns: []ModelColumn{
+				{Name: "id"}, {Name: "body", Nullable: true}, {Name: "title"}, {Name: "payload"},
+			}},
+			{Type: "DiaryEntity", Schema: schema, Table: "diary_entities"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"Note: column notes.body nullable is false in the database but true in the model",
+			"Note: column notes.title does not exist",
+			"Note: column notes.memo is missing in the model",
+			fmt.Sprintf("DiaryEntity: table %s.diary_entities does not exist", schema),
+		}, problems)
+	})
+}
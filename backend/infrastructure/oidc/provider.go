@@ -0,0 +1,316 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+// GoogleProviderID はGoogleのプリセットのプロバイダーの識別子
+const GoogleProviderID = "google"
+
+// GoogleIssuer はGoogleのOpenID Connectのissuer
+const GoogleIssuer = "https://accounts.google.com"
+
+// DefaultScopes はスコープを設定しない場合に要求するスコープ
+var DefaultScopes = []string{"openid", "email", "profile"}
+
+// httpTimeout はIdPへのリクエスト（discovery・JWKS・トークンの交換）のタイムアウト
+const httpTimeout = 10 * time.Second
+
+// Config はOpenID Connectのプロバイダーの設定
+type Config struct {
+	ID           string // プロバイダーの識別子（user_oauthes.provider に保存する）
+	Name         string // ログイン画面に表示する名前
+	Issuer       string // discovery（<issuer>/.well-known/openid-configuration）に使う
+	ClientID     string
+	ClientSecret string // PKCEのみの公開クライアントの場合は空
+	Scopes       []string
+	RedirectURL  string // IdPからのリダイレクト先（フロントエンドのコールバック）
+}
+
+// Identity はIDトークンで確認したプロバイダーのユーザー
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// AuthRequest はIdPの認可画面へのリダイレクトに使う値。
+// State・Nonce・CodeVerifierはコールバックでログインを完了するまで保存する
+type AuthRequest struct {
+	URL          string
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+// metadata はdiscoveryで取得するプロバイダーのメタデータ
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider はdiscoveryで設定するOpenID Connectのプロバイダー。
+// メタデータと公開鍵は最初に使う時に取得してキャッシュし、未知の鍵IDのIDトークンを受け取った場合は公開鍵を取得し直す
+type Provider struct {
+	config Config
+	client *http.Client
+
+	mu       sync.Mutex
+	metadata *metadata
+	keys     map[string]*rsa.PublicKey
+}
+
+// NewProvider はプロバイダーを作成する。clientがnilの場合はタイムアウトを設定したクライアントを使う
+func NewProvider(config Config, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: httpTimeout}
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = DefaultScopes
+	}
+	return &Provider{config: config, client: client}
+}
+
+func (p *Provider) ID() string {
+	return p.config.ID
+}
+
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// NewAuthRequest は認可コードフロー（PKCE・nonce付き）の認可URLを作成する
+func (p *Provider) NewAuthRequest(ctx context.Context) (*AuthRequest, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+	url := p.oauth2Config(md).AuthCodeURL(state, oauth2.S256ChallengeOption(verifier), oauth2.SetAuthURLParam("nonce", nonce))
+	return &AuthRequest{URL: url, State: state, Nonce: nonce, CodeVerifier: verifier}, nil
+}
+
+// Exchange は認可コードをトークンに交換し、IDトークンを検証してユーザーを返す
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := p.oauth2Config(md).Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.client), code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("token response does not contain id_token")
+	}
+	return p.verifyIDToken(ctx, md, rawIDToken, nonce)
+}
+
+func (p *Provider) oauth2Config(md *metadata) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint:     oauth2.Endpoint{AuthURL: md.AuthorizationEndpoint, TokenURL: md.TokenEndpoint},
+		RedirectURL:  p.config.RedirectURL,
+		Scopes:       p.config.Scopes,
+	}
+}
+
+// idTokenClaims はIDトークンのうち検証とユーザーの特定に使うクレーム
+type idTokenClaims struct {
+	Nonce         string       `json:"nonce"`
+	Email         string       `json:"email"`
+	EmailVerified flexibleBool `json:"email_verified"`
+	Name          string       `json:"name"`
+	jwt.RegisteredClaims
+}
+
+// flexibleBool は真偽値か "true" / "false" の文字列を受け付ける（email_verifiedを文字列で返すプロバイダーがあるため）
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*b = flexibleBool(v)
+	case string:
+		*b = flexibleBool(v == "true")
+	default:
+		*b = false
+	}
+	return nil
+}
+
+// verifyIDToken はIDトークンの署名（RS256）・issuer・audience・有効期限・nonceを検証する
+func (p *Provider) verifyIDToken(ctx context.Context, md *metadata, rawIDToken, nonce string) (*Identity, error) {
+	var claims idTokenClaims
+	_, err := jwt.ParseWithClaims(rawIDToken, &claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.publicKey(ctx, md, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithIssuer(md.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New("invalid id token: nonce mismatch")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("invalid id token: missing sub")
+	}
+	return &Identity{
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(strings.TrimSpace(claims.Email)),
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+// discover はプロバイダーのメタデータを取得する（取得できた場合はキャッシュする）
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	var md metadata
+	if err := p.getJSON(ctx, strings.TrimSuffix(p.config.Issuer, "/")+"/.well-known/openid-configuration", &md); err != nil {
+		return nil, fmt.Errorf("failed to discover %s: %w", p.config.ID, err)
+	}
+	// issuerが一致しないメタデータは使わない（OpenID Connect Discovery 1.0 4.3）
+	if md.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("issuer mismatch: configured %q, discovered %q", p.config.Issuer, md.Issuer)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return nil, fmt.Errorf("incomplete provider metadata for %s", p.config.ID)
+	}
+	p.metadata = &md
+	return p.metadata, nil
+}
+
+// publicKey は鍵IDの公開鍵を返す。キャッシュにない場合はJWKSを取得し直す（鍵のローテーションに対応する）
+func (p *Provider) publicKey(ctx context.Context, md *metadata, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := lookupKey(p.keys, kid); ok {
+		return key, nil
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := p.getJSON(ctx, md.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		key, err := rsaPublicKey(k.N, k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwk %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	p.keys = keys
+
+	if key, ok := lookupKey(p.keys, kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key id %q", kid)
+}
+
+// lookupKey は鍵IDの公開鍵を返す。鍵IDがないトークンは、鍵が1つの場合のみその鍵を使う
+func lookupKey(keys map[string]*rsa.PublicKey, kid string) (*rsa.PublicKey, bool) {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	key, ok := keys[kid]
+	return key, ok
+}
+
+func rsaPublicKey(n, e string) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode modulus: %w", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exponent: %w", err)
+	}
+	exponent := new(big.Int).SetBytes(eBytes)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("exponent is too large")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(nBytes), E: int(exponent.Int64())}, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// randomString はstate・nonceに使う推測できない文字列を返す
+func randomString() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random string: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,212 @@
+package oidc
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/oidc/oidctest"
+	"github.com/redis/rueidis"
+)
+
+// newTestProvider はモックのIdPとそのプロバイダーを作成する
+func newTestProvider(t *testing.T) (*oidctest.Server, *Provider) {
+	t.Helper()
+	idp := oidctest.NewServer(t)
+	p := NewProvider(Config{
+		ID:          "mock",
+		Name:        "Mock",
+		Issuer:      idp.Issuer(),
+		ClientID:    oidctest.ClientID,
+		RedirectURL: "http://localhost:5173/auth/oidc/callback",
+	}, idp.Client())
+	return idp, p
+}
+
+func TestProvider_NewAuthRequest(t *testing.T) {
+	t.Run("正常系: PKCE（S256）・nonce・stateを付けた認可URLを作成する", func(t *testing.T) {
+		idp, p := newTestProvider(t)
+		req, err := p.NewAuthRequest(t.Context())
+		if err != nil {
+			t.Fatalf("NewAuthRequest失敗: %v", err)
+		}
+		u, err := url.Parse(req.URL)
+		if err != nil {
+			t.Fatalf("認可URLが不正: %v", err)
+		}
+		if !strings.HasPrefix(req.URL, idp.URL+"/authorize?") {
+			t.Errorf("認可エンドポイントが期待と異なる: %s", req.URL)
+		}
+		q := u.Query()
+		if q.Get("state") != req.State || q.Get("nonce") != req.Nonce {
+			t.Errorf("state・nonceが一致しない: %s", req.URL)
+		}
+		if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+			t.Errorf("PKCEのパラメーターがない: %s", req.URL)
+		}
+		if q.Get("scope") != "openid email profile" {
+			t.Errorf("スコープが期待と異なる: %s", q.Get("scope"))
+		}
+		if req.CodeVerifier == "" || strings.Contains(req.URL, req.CodeVerifier) {
+			t.Error("code_verifierが生成されていないか、認可URLに含まれている")
+		}
+	})
+
+	t.Run("異常系: discoveryのissuerが設定と異なる場合はエラー", func(t *testing.T) {
+		idp := oidctest.NewServer(t)
+		p := NewProvider(Config{ID: "mock", Issuer: idp.Issuer() + "/other", ClientID: oidctest.ClientID}, idp.Client())
+		if _, err := p.NewAuthRequest(t.Context()); err == nil {
+			t.Error("エラーにならなかった")
+		}
+	})
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	claims := oidctest.Claims{Subject: "sub-1", Email: "User@Example.com", EmailVerified: true, Name: "User"}
+
+	t.Run("正常系: 認可コードを交換してIDトークンのユーザーを返す（メールアドレスは小文字にする）", func(t *testing.T) {
+		idp, p := newTestProvider(t)
+		req, err := p.NewAuthRequest(t.Context())
+		if err != nil {
+			t.Fatalf("NewAuthRequest失敗: %v", err)
+		}
+		_, code := idp.Authorize(t, req.URL, claims)
+
+		identity, err := p.Exchange(t.Context(), code, req.CodeVerifier, req.Nonce)
+		if err != nil {
+			t.Fatalf("Exchange失敗: %v", err)
+		}
+		want := Identity{Subject: "sub-1", Email: "user@example.com", EmailVerified: true, Name: "User"}
+		if *identity != want {
+			t.Errorf("ユーザーが期待と異なる: got %+v, want %+v", *identity, want)
+		}
+	})
+
+	t.Run("異常系: code_verifierが異なる場合はエラー", func(t *testing.T) {
+		idp, p := newTestProvider(t)
+		req, _ := p.NewAuthRequest(t.Context())
+		_, code := idp.Authorize(t, req.URL, claims)
+		if _, err := p.Exchange(t.Context(), code, "wrong-verifier", req.Nonce); err == nil {
+			t.Error("エラーにならなかった")
+		}
+	})
+
+	t.Run("異常系: nonceが異なる場合はエラー（IDトークンの使い回しを防ぐ）", func(t *testing.T) {
+		idp, p := newTestProvider(t)
+		req, _ := p.NewAuthRequest(t.Context())
+		_, code := idp.Authorize(t, req.URL, claims)
+		if _, err := p.Exchange(t.Context(), code, req.CodeVerifier, "other-nonce"); err == nil {
+			t.Error("エラーにならなかった")
+		}
+	})
+
+	invalidTokens := []struct {
+		name string
+		hook func(jwt.MapClaims)
+	}{
+		{"異常系: audienceが別のクライアントの場合はエラー", func(c jwt.MapClaims) { c["aud"] = "other-client" }},
+		{"異常系: issuerが異なる場合はエラー", func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }},
+		{"異常系: 有効期限切れの場合はエラー", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{"異常系: subがない場合はエラー", func(c jwt.MapClaims) { delete(c, "sub") }},
+	}
+	for _, tt := range invalidTokens {
+		t.Run(tt.name, func(t *testing.T) {
+			idp, p := newTestProvider(t)
+			idp.IDTokenHook = tt.hook
+			req, _ := p.NewAuthRequest(t.Context())
+			_, code := idp.Authorize(t, req.URL, claims)
+			if _, err := p.Exchange(t.Context(), code, req.CodeVerifier, req.Nonce); err == nil {
+				t.Error("エラーにならなかった")
+			}
+		})
+	}
+}
+
+func TestFlexibleBool(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"正常系: 真偽値", `true`, true},
+		{"正常系: 文字列のtrue", `"true"`, true},
+		{"正常系: 文字列のfalse", `"false"`, false},
+		{"正常系: その他の値はfalse", `1`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b flexibleBool
+			if err := b.UnmarshalJSON([]byte(tt.input)); err != nil {
+				t.Fatalf("UnmarshalJSON失敗: %v", err)
+			}
+			if bool(b) != tt.want {
+				t.Errorf("got %v, want %v", b, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("正常系: 識別子でプロバイダーを取得できる", func(t *testing.T) {
+		r := NewRegistry([]Config{{ID: "google", Issuer: GoogleIssuer}, {ID: "mock"}}, nil)
+		if p, ok := r.Provider("mock"); !ok || p.ID() != "mock" {
+			t.Error("プロバイダーを取得できなかった")
+		}
+		if _, ok := r.Provider("unknown"); ok {
+			t.Error("設定していないプロバイダーを取得できた")
+		}
+	})
+
+	t.Run("正常系: nilのRegistryはプロバイダーなし", func(t *testing.T) {
+		var r *Registry
+		if len(r.Providers()) != 0 {
+			t.Error("プロバイダーが空ではない")
+		}
+	})
+}
+
+func TestStateStore(t *testing.T) {
+	setup := func(t *testing.T) (*miniredis.Miniredis, *StateStore) {
+		t.Helper()
+		mr, err := miniredis.Run()
+		if err != nil {
+			t.Fatalf("miniredis起動失敗: %v", err)
+		}
+		t.Cleanup(mr.Close)
+		client, err := rueidis.NewClient(rueidis.ClientOption{InitAddress: []string{mr.Addr()}, DisableCache: true})
+		if err != nil {
+			t.Fatalf("rueidisクライアント作成失敗: %v", err)
+		}
+		t.Cleanup(client.Close)
+		return mr, &StateStore{Redis: client}
+	}
+
+	t.Run("正常系: 保存した状態は1回だけ取得できる", func(t *testing.T) {
+		_, store := setup(t)
+		data := LoginState{Provider: "google", Nonce: "n", CodeVerifier: "v", LinkUserID: "user-1"}
+		if err := store.Save(t.Context(), "state-1", data); err != nil {
+			t.Fatalf("Save失敗: %v", err)
+		}
+		got, ok, err := store.Consume(t.Context(), "state-1")
+		if err != nil || !ok || got != data {
+			t.Fatalf("Consumeの結果が期待と異なる: got %+v, ok %v, err %v", got, ok, err)
+		}
+		if _, ok, _ := store.Consume(t.Context(), "state-1"); ok {
+			t.Error("同じstateを2回取得できた")
+		}
+	})
+
+	t.Run("異常系: 期限切れの状態は取得できない", func(t *testing.T) {
+		mr, store := setup(t)
+		if err := store.Save(t.Context(), "state-1", LoginState{Provider: "google"}); err != nil {
+			t.Fatalf("Save失敗: %v", err)
+		}
+		mr.FastForward(stateTTL + time.Second)
+		if _, ok, err := store.Consume(t.Context(), "state-1"); err != nil || ok {
+			t.Errorf("期限切れの状態を取得できた: ok %v, err %v", ok, err)
+		}
+	})
+}
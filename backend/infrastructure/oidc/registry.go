@@ -0,0 +1,96 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/redis/rueidis"
+)
+
+// Registry は設定したプロバイダーの一覧（設定した順）
+type Registry struct {
+	providers []*Provider
+}
+
+// NewRegistry は設定からプロバイダーの一覧を作成する
+func NewRegistry(configs []Config, client *http.Client) *Registry {
+	r := &Registry{}
+	for _, c := range configs {
+		r.providers = append(r.providers, NewProvider(c, client))
+	}
+	return r
+}
+
+// Providers は設定したプロバイダーを返す（nilのRegistryは空）
+func (r *Registry) Providers() []*Provider {
+	if r == nil {
+		return nil
+	}
+	return r.providers
+}
+
+// Provider は識別子のプロバイダーを返す
+func (r *Registry) Provider(id string) (*Provider, bool) {
+	for _, p := range r.Providers() {
+		if p.ID() == id {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// stateTTL はIdPにリダイレクトしてからログインを完了するまでの猶予
+const stateTTL = 10 * time.Minute
+
+// stateKeyPrefix はRedis上でログインの状態を保存する際のキー接頭辞
+const stateKeyPrefix = "oidc_state:"
+
+// LoginState はIdPにリダイレクトしてからコールバックでログインを完了するまで保存する値
+type LoginState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	LinkUserID   string `json:"link_user_id,omitempty"` // 既存のユーザーへの連携の場合は連携するユーザーのID
+}
+
+// StateStore はログインの状態をstateをキーにRedisに保存する
+type StateStore struct {
+	Redis rueidis.Client
+}
+
+// Save はログインの状態をTTL付きで保存する
+func (s *StateStore) Save(ctx context.Context, state string, data LoginState) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal oidc state: %w", err)
+	}
+	cmd := s.Redis.B().Set().Key(stateKeyPrefix + state).Value(string(payload)).Ex(stateTTL).Build()
+	if err := s.Redis.Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("failed to store oidc state: %w", err)
+	}
+	return nil
+}
+
+// Consume はログインの状態を取得と同時に削除する（同じstateで2回ログインできないようにする）。
+// 存在しない・期限切れの場合はokがfalseになる
+func (s *StateStore) Consume(ctx context.Context, state string) (LoginState, bool, error) {
+	result := s.Redis.Do(ctx, s.Redis.B().Getdel().Key(stateKeyPrefix+state).Build())
+	if err := result.Error(); err != nil {
+		if rueidis.IsRedisNil(err) {
+			return LoginState{}, false, nil
+		}
+		return LoginState{}, false, fmt.Errorf("failed to get and delete oidc state: %w", err)
+	}
+	payload, err := result.ToString()
+	if err != nil {
+		return LoginState{}, false, fmt.Errorf("failed to parse oidc state: %w", err)
+	}
+	var data LoginState
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return LoginState{}, false, fmt.Errorf("failed to unmarshal oidc state: %w", err)
+	}
+	return data, true, nil
+}
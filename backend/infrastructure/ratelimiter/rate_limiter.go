@@ -13,6 +13,8 @@ import (
 type RateLimiter interface {
 	// IsAllowed 指定されたキーに対してアクションが許可されているかチェック
 	IsAllowed(ctx context.Context, key string, limit int, window time.Duration) (bool, int, time.Duration, error)
+	// Count 指定されたキーのウィンドウ内の回数を、回数を増やさずに取得
+	Count(ctx context.Context, key string, window time.Duration) (int, time.Duration, error)
 	// Reset 指定されたキーのレート制限をリセット
 	Reset(ctx context.Context, key string) error
 }
@@ -94,6 +96,50 @@ func (r *RedisRateLimiter) IsAllowed(ctx context.Context, key string, limit int,
 	return allowed, remaining, resetDuration, nil
 }
 
+// Count ウィンドウ内の回数と、最も古い回数がウィンドウから外れるまでの時間を返す（回数は増やさない）
+func (r *RedisRateLimiter) Count(ctx context.Context, key string, window time.Duration) (int, time.Duration, error) {
+	now := time.Now()
+	windowStart := now.Add(-window).UnixNano()
+
+	// IsAllowedと同じ計算でリセットまでの時間を求める（古いエントリは削除せず、範囲外として数えない）
+	script := `
+		local key = KEYS[1]
+		local window_start = ARGV[1]
+		local now = tonumber(ARGV[2])
+		local ttl = tonumber(ARGV[3])
+
+		local current = redis.call('ZCOUNT', key, '(' .. window_start, '+inf')
+		local reset_time = 0
+		if current > 0 then
+			local oldest = redis.call('ZRANGEBYSCORE', key, '(' .. window_start, '+inf', 'WITHSCORES', 'LIMIT', 0, 1)
+			if #oldest >= 2 then
+				reset_time = oldest[2] + (ttl * 1000000000) - now
+				if reset_time < 0 then
+					reset_time = 0
+				end
+			end
+		end
+		return {current, reset_time}
+	`
+
+	cmd := r.client.B().Eval().Script(script).Numkeys(1).Key(key).
+		Arg(strconv.FormatInt(windowStart, 10)).
+		Arg(strconv.FormatInt(now.UnixNano(), 10)).
+		Arg(strconv.Itoa(int(window.Seconds()))).
+		Build()
+
+	result, err := r.client.Do(ctx, cmd).AsIntSlice()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to execute rate limit count: %w", err)
+	}
+
+	if len(result) != 2 {
+		return 0, 0, fmt.Errorf("unexpected result length from Redis script: %d", len(result))
+	}
+
+	return int(result[0]), time.Duration(result[1]) * time.Nanosecond, nil
+}
+
 // Reset 指定されたキーのレート制限をリセット
 func (r *RedisRateLimiter) Reset(ctx context.Context, key string) error {
 	cmd := r.client.B().Del().Key(key).Build()
@@ -159,3 +205,25 @@ func (r *RegisterAttemptLimiter) ResetAttempts(ctx context.Context, identifier s
 	key := fmt.Sprintf("register_attempts:%s", identifier)
 	return r.rateLimiter.Reset(ctx, key)
 }
+
+// EmailAttemptLimiter メール送信（パスワードの再設定・メールアドレスの確認）専用のレート制限
+type EmailAttemptLimiter struct {
+	rateLimiter RateLimiter
+	maxAttempts int
+	window      time.Duration
+}
+
+// NewEmailAttemptLimiter 新しいEmailAttemptLimiterを作成
+func NewEmailAttemptLimiter(rateLimiter RateLimiter, maxAttempts int, window time.Duration) *EmailAttemptLimiter {
+	return &EmailAttemptLimiter{
+		rateLimiter: rateLimiter,
+		maxAttempts: maxAttempts,
+		window:      window,
+	}
+}
+
+// CheckAttempt メール送信が許可されているかチェック
+func (e *EmailAttemptLimiter) CheckAttempt(ctx context.Context, identifier string) (bool, int, time.Duration, error) {
+	key := fmt.Sprintf("email_attempts:%s", identifier)
+	return e.rateLimiter.IsAllowed(ctx, key, e.maxAttempts, e.window)
+}
@@ -221,3 +221,56 @@ func TestRedisRateLimiter_SlidingWindow(t *testing.T) {
 
 // NOTE: Redis接続失敗テストは統合テストレベルで行う方が適切
 // 単体テストでは正常なRedis接続を前提とした機能テストに集中する
+
+func TestEmailAttemptLimiter_CheckAttempt(t *testing.T) {
+	redisClient, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	emailLimiter := NewEmailAttemptLimiter(NewRedisRateLimiter(redisClient), 2, time.Hour)
+	ctx := context.Background()
+
+	// 制限内の送信
+	for range 2 {
+		allowed, _, _, err := emailLimiter.CheckAttempt(ctx, "user@example.com")
+		require.NoError(t, err)
+		assert.True(t, allowed, "制限内であれば許可されるべき")
+	}
+
+	// 制限を超える送信
+	allowed, _, _, err := emailLimiter.CheckAttempt(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.False(t, allowed, "制限を超えた場合は許可されないべき")
+
+	// 識別子ごとに数える
+	allowed, _, _, err = emailLimiter.CheckAttempt(ctx, "other@example.com")
+	require.NoError(t, err)
+	assert.True(t, allowed, "別の識別子は許可されるべき")
+}
+
+func TestRedisRateLimiter_Count(t *testing.T) {
+	redisClient, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	rateLimiter := NewRedisRateLimiter(redisClient)
+	ctx := context.Background()
+
+	// 使っていないキーは0回
+	count, resetIn, err := rateLimiter.Count(ctx, "count_key", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, time.Duration(0), resetIn)
+
+	for range 3 {
+		_, _, _, err := rateLimiter.IsAllowed(ctx, "count_key", 5, time.Minute)
+		require.NoError(t, err)
+	}
+
+	// 数えても回数は増えない
+	for range 2 {
+		count, resetIn, err = rateLimiter.Count(ctx, "count_key", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count, "使用回数が正しいべき")
+		assert.Greater(t, resetIn, time.Duration(0))
+		assert.LessOrEqual(t, resetIn, time.Minute)
+	}
+}
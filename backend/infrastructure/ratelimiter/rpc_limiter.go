@@ -0,0 +1,106 @@
+package ratelimiter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Policy RPCごとのレート制限
+type Policy struct {
+	Limit  int           // Windowの間に呼び出せる回数
+	Window time.Duration // 回数を数える期間
+}
+
+// Decision RPCのレート制限の確認の結果
+type Decision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration // 拒否した場合に、次に呼び出せるまでの時間
+}
+
+// MethodUsage RPCごとの使用状況
+type MethodUsage struct {
+	Method  string
+	Limit   int
+	Window  time.Duration
+	Used    int
+	ResetIn time.Duration // 最も古い呼び出しがウィンドウから外れるまでの時間（使っていない場合は0）
+}
+
+// UserSubject JWTで認証したリクエストのバケット（ユーザーごと）
+func UserSubject(userID string) string {
+	return "user:" + userID
+}
+
+// APIKeySubject APIキーで認証したリクエストのバケット（APIキーごと）。
+// MCPクライアントが上限に達しても、同じユーザーのWebアプリやほかのキーは使える
+func APIKeySubject(apiKeyID string) string {
+	return "api_key:" + apiKeyID
+}
+
+// RPCLimiter RPC（MCPのツールを含む）ごとのレート制限（ADR 0038）
+type RPCLimiter struct {
+	rateLimiter RateLimiter
+	policies    map[string]Policy
+}
+
+// NewRPCLimiter 新しいRPCLimiterを作成（policiesのキーはgRPCのフルメソッド名か mcp/<ツール名>）
+func NewRPCLimiter(rateLimiter RateLimiter, policies map[string]Policy) *RPCLimiter {
+	return &RPCLimiter{
+		rateLimiter: rateLimiter,
+		policies:    policies,
+	}
+}
+
+// Check メソッドの呼び出しを数え、許可されているかチェック。
+// メソッドに制限がない場合はnilを返す
+func (l *RPCLimiter) Check(ctx context.Context, method, subject string) (*Decision, error) {
+	policy, ok := l.policies[method]
+	if !ok {
+		return nil, nil
+	}
+	allowed, remaining, resetTime, err := l.rateLimiter.IsAllowed(ctx, rpcKey(method, subject), policy.Limit, policy.Window)
+	if err != nil {
+		return nil, err
+	}
+	decision := &Decision{Allowed: allowed, Limit: policy.Limit, Remaining: remaining}
+	if !allowed {
+		// 秒単位で返すため、切り捨てて0秒にならないようにする
+		decision.RetryAfter = max(resetTime.Round(time.Second), time.Second)
+	}
+	return decision, nil
+}
+
+// Usage 制限のあるすべてのメソッドの使用状況をメソッド名の順に返す（回数は増やさない）
+func (l *RPCLimiter) Usage(ctx context.Context, subject string) ([]MethodUsage, error) {
+	methods := make([]string, 0, len(l.policies))
+	for method := range l.policies {
+		methods = append(methods, method)
+	}
+	slices.Sort(methods)
+
+	usages := make([]MethodUsage, 0, len(methods))
+	for _, method := range methods {
+		policy := l.policies[method]
+		used, resetIn, err := l.rateLimiter.Count(ctx, rpcKey(method, subject), policy.Window)
+		if err != nil {
+			return nil, err
+		}
+		usages = append(usages, MethodUsage{
+			Method:  method,
+			Limit:   policy.Limit,
+			Window:  policy.Window,
+			Used:    used,
+			ResetIn: resetIn,
+		})
+	}
+	return usages, nil
+}
+
+// rpcKey はバケットごとのキーを返す（キーはウィンドウの期間が過ぎると期限切れになる）
+func rpcKey(method, subject string) string {
+	return fmt.Sprintf("rpc_rate:%s:%s", subject, method)
+}
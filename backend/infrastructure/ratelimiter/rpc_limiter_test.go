@@ -0,0 +1,86 @@
+package ratelimiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPCLimiter_Check(t *testing.T) {
+	redisClient, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	limiter := NewRPCLimiter(NewRedisRateLimiter(redisClient), map[string]Policy{
+		"/diary.DiaryService/SearchDiaryEntriesSemantic": {Limit: 2, Window: time.Minute},
+	})
+	ctx := context.Background()
+	method := "/diary.DiaryService/SearchDiaryEntriesSemantic"
+
+	t.Run("正常系：制限のないメソッドはnilを返す", func(t *testing.T) {
+		decision, err := limiter.Check(ctx, "/diary.DiaryService/GetDiaryEntry", UserSubject("user-1"))
+		require.NoError(t, err)
+		assert.Nil(t, decision)
+	})
+
+	t.Run("異常系：上限を超えると拒否し、再試行までの時間を返す", func(t *testing.T) {
+		for i := range 2 {
+			decision, err := limiter.Check(ctx, method, UserSubject("user-1"))
+			require.NoError(t, err)
+			assert.True(t, decision.Allowed)
+			assert.Equal(t, 2, decision.Limit)
+			assert.Equal(t, 1-i, decision.Remaining)
+		}
+
+		decision, err := limiter.Check(ctx, method, UserSubject("user-1"))
+		require.NoError(t, err)
+		assert.False(t, decision.Allowed)
+		assert.GreaterOrEqual(t, decision.RetryAfter, time.Second)
+		assert.LessOrEqual(t, decision.RetryAfter, time.Minute)
+	})
+
+	t.Run("正常系：ユーザーとAPIキーのバケットは別に数える", func(t *testing.T) {
+		decision, err := limiter.Check(ctx, method, APIKeySubject("key-1"))
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed, "APIキーのバケットは使われていないべき")
+
+		decision, err = limiter.Check(ctx, method, UserSubject("user-2"))
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed, "別のユーザーのバケットは使われていないべき")
+	})
+}
+
+func TestRPCLimiter_Usage(t *testing.T) {
+	redisClient, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	limiter := NewRPCLimiter(NewRedisRateLimiter(redisClient), map[string]Policy{
+		"mcp/search_diary_entries_fuzzy":                 {Limit: 30, Window: time.Minute},
+		"/diary.DiaryService/SearchDiaryEntriesSemantic": {Limit: 5, Window: time.Hour},
+	})
+	ctx := context.Background()
+
+	_, err := limiter.Check(ctx, "/diary.DiaryService/SearchDiaryEntriesSemantic", UserSubject("user-1"))
+	require.NoError(t, err)
+
+	usages, err := limiter.Usage(ctx, UserSubject("user-1"))
+	require.NoError(t, err)
+	require.Len(t, usages, 2)
+
+	// メソッド名の順に返す
+	assert.Equal(t, "/diary.DiaryService/SearchDiaryEntriesSemantic", usages[0].Method)
+	assert.Equal(t, 5, usages[0].Limit)
+	assert.Equal(t, time.Hour, usages[0].Window)
+	assert.Equal(t, 1, usages[0].Used)
+	assert.Greater(t, usages[0].ResetIn, time.Duration(0))
+
+	assert.Equal(t, "mcp/search_diary_entries_fuzzy", usages[1].Method)
+	assert.Equal(t, 0, usages[1].Used)
+
+	// 使用状況の取得では回数を増やさない
+	usages, err = limiter.Usage(ctx, UserSubject("user-1"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, usages[0].Used)
+}
@@ -0,0 +1,58 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Header リクエストIDを受け取り・返すヘッダー（gRPCのメタデータ・ConnectRPCのHTTPヘッダーで共通）
+const Header = "x-request-id"
+
+// LogField ログにリクエストIDを出すときのフィールド名（server・subscriberで共通）。
+// diary_eventsチャンネルのメッセージにも同じ名前で含め、subscriberのログを依頼したRPCと結び付ける
+const LogField = "request_id"
+
+// maxLength クライアントから受け取るリクエストIDの最大長
+const maxLength = 128
+
+type contextKey struct{}
+
+// New 新しいリクエストIDを生成
+func New() string {
+	return uuid.NewString()
+}
+
+// FromIncoming クライアントが送ったリクエストIDを使い、ない場合や不正な場合は新しく生成する。
+// ログに出すため、英数字と「-」「_」「.」だけを受け付ける
+func FromIncoming(id string) string {
+	if !valid(id) {
+		return New()
+	}
+	return id
+}
+
+// WithID リクエストIDをコンテキストに注入
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext コンテキストからリクエストIDを取得（ない場合は空文字）
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFromIncoming(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		wantSame bool
+	}{
+		{"正常系: クライアントが送ったIDを使う", "3f2a9c1e-7b4d-4e8a-9f00-1234567890ab", true},
+		{"正常系: 英数字と記号のIDを使う", "ios.req_42", true},
+		{"異常系: 空の場合は生成する", "", false},
+		{"異常系: 改行を含む場合は生成する", "abc\nforged=1", false},
+		{"異常系: 長すぎる場合は生成する", strings.Repeat("a", 129), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromIncoming(tt.id)
+			if tt.wantSame && got != tt.id {
+				t.Errorf("期待 %q, 実際 %q", tt.id, got)
+			}
+			if !tt.wantSame && (got == tt.id || !valid(got)) {
+				t.Errorf("新しいIDを生成するべき: %q", got)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("正常系: 注入したIDを取得できる", func(t *testing.T) {
+		ctx := WithID(context.Background(), "req-1")
+		if got := FromContext(ctx); got != "req-1" {
+			t.Errorf("期待 %q, 実際 %q", "req-1", got)
+		}
+	})
+
+	t.Run("正常系: 注入していない場合は空文字", func(t *testing.T) {
+		if got := FromContext(context.Background()); got != "" {
+			t.Errorf("空文字を期待したが %q", got)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+// Package securityevent は認証・アカウントのセキュリティイベント（ログイン、パスワードの変更、APIキーの発行など）を
+// security_events に記録する。ユーザーは ListSecurityEvents で自分のイベントを確認できる（ADR 0036）。
+package securityevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+)
+
+// Type はセキュリティイベントの種類
+type Type string
+
+const (
+	LoginSuccess   Type = "login_success"
+	LoginFailure   Type = "login_failure"
+	TokenRefresh   Type = "token_refresh"
+	PasswordChange Type = "password_change"
+	APIKeyCreate   Type = "api_key_create"
+	APIKeyDelete   Type = "api_key_delete"
+	APIKeyUse      Type = "api_key_use"
+	OAuthConsent   Type = "oauth_consent"
+
+	AccountDeletionRequest Type = "account_deletion_request"
+	AccountDeletionCancel  Type = "account_deletion_cancel"
+)
+
+// 記録するIPアドレス（ip_addressのVARCHAR(64)に合わせる）とUser-Agentの最大文字数。
+// どちらもクライアントが送るヘッダーの値のため、長すぎる値で記録に失敗したりテーブルを肥大化させたりしない
+const (
+	maxIPAddressLength = 64
+	maxUserAgentLength = 512
+)
+
+// apiKeyUseInterval はAPIキーの使用を記録する最短の間隔。
+// MCP・WebDAVのクライアントはリクエストのたびにキーを送るため、すべては記録しない
+const apiKeyUseInterval = time.Hour
+
+// ParseType はクライアントから受け取った種類を検証する
+func ParseType(s string) (Type, bool) {
+	switch t := Type(s); t {
+	case LoginSuccess, LoginFailure, TokenRefresh, PasswordChange, APIKeyCreate, APIKeyDelete, APIKeyUse, OAuthConsent,
+		AccountDeletionRequest, AccountDeletionCancel:
+		return t, true
+	default:
+		return "", false
+	}
+}
+
+// Record はユーザーのセキュリティイベントを記録する。
+// IPアドレスとUser-Agentはコンテキストから取得する（HTTPのハンドラーでは middleware.WithHTTPClient で注入する）。
+// ログインの成功は、それまでのログインにないIPアドレスかUser-Agentからの場合に suspicious にする（初めてのログインを除く）
+func Record(ctx context.Context, db database.DB, userID uuid.UUID, eventType Type, detail map[string]any) error {
+	ipAddress := truncate(middleware.ClientIP(ctx), maxIPAddressLength)
+	userAgent := truncate(middleware.UserAgent(ctx), maxUserAgentLength)
+
+	suspicious := false
+	if eventType == LoginSuccess {
+		hasLogin, knownIP, knownUserAgent, err := database.KnownLoginClient(ctx, db, userID, string(LoginSuccess), ipAddress, userAgent)
+		if err != nil {
+			return err
+		}
+		if hasLogin && (!knownIP || !knownUserAgent) {
+			suspicious = true
+			if detail == nil {
+				detail = map[string]any{}
+			}
+			detail["new_ip"] = !knownIP
+			detail["new_user_agent"] = !knownUserAgent
+			log.Printf("security event: login from a new client for user %s (new_ip: %t, new_user_agent: %t)", userID, !knownIP, !knownUserAgent)
+		}
+	}
+
+	if detail == nil {
+		detail = map[string]any{}
+	}
+	detailJSON, err := json.Marshal(detail)
+	if err != nil {
+		return fmt.Errorf("failed to marshal security event detail: %w", err)
+	}
+	event := &database.SecurityEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		EventType:  string(eventType),
+		IPAddress:  ipAddress,
+		UserAgent:  userAgent,
+		Detail:     detailJSON,
+		Suspicious: suspicious,
+		CreatedAt:  time.Now().Unix(),
+	}
+	if err := event.Insert(ctx, db); err != nil {
+		return fmt.Errorf("failed to insert security event: %w", err)
+	}
+	return nil
+}
+
+// RecordAPIKeyUse はAPIキーの使用を記録する。前回の使用（last_used_at）から apiKeyUseInterval 以内の場合は記録しない
+func RecordAPIKeyUse(ctx context.Context, db database.DB, apiKey *database.UserAPIKey, now time.Time) error {
+	if apiKey.LastUsedAt.Valid && now.Unix()-apiKey.LastUsedAt.Int64 < int64(apiKeyUseInterval/time.Second) {
+		return nil
+	}
+	return Record(ctx, db, apiKey.UserID, APIKeyUse, map[string]any{"api_key_id": apiKey.ID, "name": apiKey.Name})
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
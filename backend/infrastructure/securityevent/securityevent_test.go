@@ -0,0 +1,186 @@
+package securityevent
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+)
+
+func TestParseType(t *testing.T) {
+	t.Run("正常系: 定義済みの種類を受け付ける", func(t *testing.T) {
+		for _, typ := range []Type{LoginSuccess, LoginFailure, TokenRefresh, PasswordChange, APIKeyCreate, APIKeyDelete, APIKeyUse, OAuthConsent, AccountDeletionRequest, AccountDeletionCancel} {
+			got, ok := ParseType(string(typ))
+			if !ok || got != typ {
+				t.Errorf("ParseType(%q) = %q, %t", typ, got, ok)
+			}
+		}
+	})
+
+	t.Run("異常系: 未定義の種類は受け付けない", func(t *testing.T) {
+		for _, s := range []string{"", "login", "LOGIN_SUCCESS"} {
+			if _, ok := ParseType(s); ok {
+				t.Errorf("ParseType(%q) が受け付けられた", s)
+			}
+		}
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	t.Run("正常系: 最大文字数を超える場合は文字単位で切り詰める", func(t *testing.T) {
+		if got := truncate("あいうえお", 3); got != "あいう" {
+			t.Errorf("期待 %q, 実際 %q", "あいう", got)
+		}
+	})
+
+	t.Run("正常系: 最大文字数以下の場合はそのまま返す", func(t *testing.T) {
+		if got := truncate("abc", 3); got != "abc" {
+			t.Errorf("期待 %q, 実際 %q", "abc", got)
+		}
+	})
+}
+
+// clientContext は指定したIPアドレス・User-AgentのHTTPリクエストのコンテキストを作る
+func clientContext(ip, userAgent string) context.Context {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = ip + ":12345"
+	r.Header.Set("User-Agent", userAgent)
+	return middleware.WithHTTPClient(context.Background(), r)
+}
+
+func listEvents(t *testing.T, db *sql.DB, userID uuid.UUID, eventType Type) []*database.SecurityEvent {
+	t.Helper()
+	events, err := database.SecurityEventsBefore(context.Background(), db, userID, string(eventType), math.MaxInt64, uuid.Max, 100)
+	if err != nil {
+		t.Fatalf("イベントの取得に失敗: %v", err)
+	}
+	return events
+}
+
+func TestRecord(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+
+	t.Run("正常系: IPアドレス・User-Agent・詳細を記録する", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "security-event-record@example.com", "SecurityEventRecordUser")
+		ctx := clientContext("192.0.2.1", "TestAgent/1.0")
+		if err := Record(ctx, db, userID, PasswordChange, map[string]any{"method": "change"}); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		events := listEvents(t, db, userID, PasswordChange)
+		if len(events) != 1 {
+			t.Fatalf("期待件数 1 に対して %d 件", len(events))
+		}
+		e := events[0]
+		if e.IPAddress != "192.0.2.1" || e.UserAgent != "TestAgent/1.0" {
+			t.Errorf("IPアドレス・User-Agent: %q, %q", e.IPAddress, e.UserAgent)
+		}
+		var detail map[string]any
+		if err := json.Unmarshal(e.Detail, &detail); err != nil || detail["method"] != "change" {
+			t.Errorf("詳細: %s (%v)", e.Detail, err)
+		}
+		if e.Suspicious {
+			t.Error("ログイン以外のイベントがsuspiciousになった")
+		}
+	})
+
+	t.Run("正常系: 長すぎるUser-Agentは切り詰めて記録する", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "security-event-long-ua@example.com", "SecurityEventLongUAUser")
+		ctx := clientContext("192.0.2.1", strings.Repeat("a", maxUserAgentLength+100))
+		if err := Record(ctx, db, userID, TokenRefresh, nil); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		events := listEvents(t, db, userID, TokenRefresh)
+		if len(events) != 1 || len(events[0].UserAgent) != maxUserAgentLength {
+			t.Fatalf("User-Agentが切り詰められていない: %d 件", len(events))
+		}
+	})
+
+	t.Run("正常系: 新しいIPアドレス・User-Agentからのログインをsuspiciousにする", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "security-event-detect@example.com", "SecurityEventDetectUser")
+		logins := []struct {
+			ip, userAgent  string
+			wantSuspicious bool
+		}{
+			{"192.0.2.1", "Browser/1.0", false}, // 初めてのログインは比べるものがないため対象外
+			{"192.0.2.1", "Browser/1.0", false}, // 同じクライアント
+			{"198.51.100.1", "Browser/1.0", true},
+			{"192.0.2.1", "Other/2.0", true},
+			{"198.51.100.1", "Other/2.0", false}, // どちらも以前のログインにある
+		}
+		// 同じ秒に記録したイベントは並び順が決まらないため、suspiciousの件数の増え方で確認する
+		wantCount := 0
+		for i, login := range logins {
+			if err := Record(clientContext(login.ip, login.userAgent), db, userID, LoginSuccess, map[string]any{"method": "password"}); err != nil {
+				t.Fatalf("%d 回目: 予期しないエラー: %v", i+1, err)
+			}
+			if login.wantSuspicious {
+				wantCount++
+			}
+			count := 0
+			for _, e := range listEvents(t, db, userID, LoginSuccess) {
+				if e.Suspicious {
+					count++
+				}
+			}
+			if count != wantCount {
+				t.Errorf("%d 回目: suspiciousの件数 期待 %d, 実際 %d", i+1, wantCount, count)
+			}
+		}
+	})
+
+	t.Run("異常系: 記録したイベントは更新できない", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "security-event-append@example.com", "SecurityEventAppendUser")
+		if err := Record(clientContext("192.0.2.1", "TestAgent/1.0"), db, userID, LoginFailure, nil); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if _, err := db.Exec("UPDATE security_events SET suspicious = true WHERE user_id = $1", userID); err == nil {
+			t.Error("更新できてしまった")
+		}
+	})
+}
+
+func TestRecordAPIKeyUse(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "security-event-api-key@example.com", "SecurityEventAPIKeyUser")
+	ctx := clientContext("192.0.2.1", "MCPClient/1.0")
+	now := time.Now()
+	apiKey := &database.UserAPIKey{ID: uuid.New(), UserID: userID, Name: "key"}
+
+	t.Run("正常系: 初めての使用は記録する", func(t *testing.T) {
+		if err := RecordAPIKeyUse(ctx, db, apiKey, now); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if n := len(listEvents(t, db, userID, APIKeyUse)); n != 1 {
+			t.Errorf("期待件数 1 に対して %d 件", n)
+		}
+	})
+
+	t.Run("正常系: 前回の使用から1時間以内は記録しない", func(t *testing.T) {
+		apiKey.LastUsedAt = sql.NullInt64{Int64: now.Add(-30 * time.Minute).Unix(), Valid: true}
+		if err := RecordAPIKeyUse(ctx, db, apiKey, now); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if n := len(listEvents(t, db, userID, APIKeyUse)); n != 1 {
+			t.Errorf("期待件数 1 に対して %d 件", n)
+		}
+	})
+
+	t.Run("正常系: 前回の使用から1時間以上経っている場合は記録する", func(t *testing.T) {
+		apiKey.LastUsedAt = sql.NullInt64{Int64: now.Add(-2 * time.Hour).Unix(), Valid: true}
+		if err := RecordAPIKeyUse(ctx, db, apiKey, now); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if n := len(listEvents(t, db, userID, APIKeyUse)); n != 2 {
+			t.Errorf("期待件数 2 に対して %d 件", n)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Local はローカルファイルシステムにオブジェクトを保存するストレージ
+// キーのスラッシュ区切りをそのままディレクトリ階層として使う
+type Local struct {
+	Root string
+}
+
+// NewLocal はrootを保存先とするLocalストレージを作成する（ディレクトリは最初の保存時に作成する）
+func NewLocal(root string) (*Local, error) {
+	if root == "" {
+		return nil, errors.New("storage: local dir is required")
+	}
+	return &Local{Root: root}, nil
+}
+
+func (s *Local) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.Root, filepath.FromSlash(key)), nil
+}
+
+// Put は一時ファイルに書き込んでからリネームする（書き込み途中のファイルを読ませない）
+func (s *Local) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("storage: failed to create dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("storage: failed to create temp file: %w", err)
+	}
+	// リネーム後は存在しないため、Removeのエラーは無視する
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("storage: failed to write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("storage: failed to close object: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("storage: failed to rename object: %w", err)
+	}
+	return nil
+}
+
+func (s *Local) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("storage: failed to open object: %w", err)
+	}
+	return f, nil
+}
+
+func (s *Local) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("storage: failed to delete object: %w", err)
+	}
+	return nil
+}
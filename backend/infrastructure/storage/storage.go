@@ -0,0 +1,79 @@
+// Package storage は添付ファイルの実体を保存するオブジェクトストレージを抽象化する。
+// ローカルファイルシステムとS3互換ストレージ（本番のS3/R2、ローカル検証用のMinIO）を切り替えて使う。
+// 定期バックアップの保存先としては、これらに加えてWebDAV（Nextcloudなど）も扱う。
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrNotFound は指定されたキーのオブジェクトが存在しない場合のエラー
+var ErrNotFound = errors.New("storage: object not found")
+
+// ErrInvalidKey はキーにディレクトリトラバーサルなど不正な要素が含まれる場合のエラー
+var ErrInvalidKey = errors.New("storage: invalid key")
+
+// Storage はキー単位でオブジェクトを読み書きするストレージ
+type Storage interface {
+	// Put はrの内容をkeyに保存する（sizeはrのバイト数）。同じキーは上書きする
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get はkeyのオブジェクトを返す。存在しない場合はErrNotFoundを返す
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete はkeyのオブジェクトを削除する。存在しない場合もエラーにしない
+	Delete(ctx context.Context, key string) error
+}
+
+// Backend はストレージの種類
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// Config はストレージの設定
+type Config struct {
+	Backend string
+	// LocalDir はlocalバックエンドの保存先ディレクトリ
+	LocalDir string
+	// S3はs3バックエンドの接続設定
+	S3 S3Config
+}
+
+// New は設定に応じたストレージを作成する
+func New(cfg Config) (Storage, error) {
+	switch cfg.Backend {
+	case BackendLocal:
+		return NewLocal(cfg.LocalDir)
+	case BackendS3:
+		return NewS3(cfg.S3)
+	default:
+		return nil, errors.New("storage: unknown backend " + cfg.Backend)
+	}
+}
+
+// validateKey はキーが "a/b/c" 形式の相対パスかを検証する
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// DeleteAll はkeysのオブジェクトをすべて削除する
+// 途中で失敗しても残りの削除を続け、失敗したものをまとめたエラーを返す
+func DeleteAll(ctx context.Context, s Storage, keys []string) error {
+	var errs []error
+	for _, key := range keys {
+		if err := s.Delete(ctx, key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
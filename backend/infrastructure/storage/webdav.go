@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// webdavRequestTimeout は1リクエストあたりのタイムアウト
+const webdavRequestTimeout = 5 * time.Minute
+
+// WebDAVConfig はWebDAVサーバーの接続設定
+type WebDAVConfig struct {
+	// URL は "https://nextcloud.example.com/remote.php/dav/files/user/" のような保存先のコレクションのURL
+	URL      string
+	Username string
+	Password string
+}
+
+// WebDAV はWebDAV（PUT/GET/DELETEとMKCOL）でオブジェクトを保存するストレージ
+// キーのスラッシュ区切りをそのままコレクションの階層として使う
+type WebDAV struct {
+	Config     WebDAVConfig
+	HTTPClient *http.Client
+
+	base *url.URL
+}
+
+// NewWebDAV はWebDAVストレージを作成する
+func NewWebDAV(cfg WebDAVConfig) (*WebDAV, error) {
+	base, err := url.Parse(cfg.URL)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("storage: invalid webdav url %q", cfg.URL)
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	base.RawPath = ""
+	return &WebDAV{
+		Config:     cfg,
+		HTTPClient: &http.Client{Timeout: webdavRequestTimeout},
+		base:       base,
+	}, nil
+}
+
+// resourceURL はbaseからの相対パス（エスケープ前）のURLを返す
+func (s *WebDAV) resourceURL(path string) string {
+	u := *s.base
+	u.Path += path
+	return u.String()
+}
+
+func (s *WebDAV) do(ctx context.Context, method, path string, body io.Reader, size int64, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.resourceURL(path), body)
+	if err != nil {
+		return nil, fmt.Errorf("storage: failed to create request: %w", err)
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if s.Config.Username != "" || s.Config.Password != "" {
+		req.SetBasicAuth(s.Config.Username, s.Config.Password)
+	}
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage: webdav request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// webdavError はレスポンスの先頭を含めたエラーを返す
+func webdavError(op string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("storage: webdav %s failed with status %d: %s", op, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// mkcols はキーの親のコレクションを上の階層から順に作成する
+// 既に存在する場合、サーバーは405（Method Not Allowed）を返すため成功として扱う
+func (s *WebDAV) mkcols(ctx context.Context, key string) error {
+	parts := strings.Split(key, "/")
+	for i := 1; i < len(parts); i++ {
+		resp, err := s.do(ctx, "MKCOL", strings.Join(parts[:i], "/")+"/", nil, 0, "")
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusMethodNotAllowed {
+			return fmt.Errorf("storage: webdav mkcol failed with status %d", resp.StatusCode)
+		}
+	}
+	return nil
+}
+
+func (s *WebDAV) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if err := s.mkcols(ctx, key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, r, size, contentType)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		return webdavError("put", resp)
+	}
+	return nil
+}
+
+func (s *WebDAV) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, key, nil, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		defer func() { _ = resp.Body.Close() }()
+		return nil, webdavError("get", resp)
+	}
+	return resp.Body, nil
+}
+
+func (s *WebDAV) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, 0, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return webdavError("delete", resp)
+	}
+	return nil
+}
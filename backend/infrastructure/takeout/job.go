@@ -0,0 +1,29 @@
+package takeout
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/taskevent"
+)
+
+// MessageType はアーカイブの作成を依頼するためにdiary_eventsチャンネルへ送るメッセージのtype
+const MessageType = taskevent.TypeDataExport
+
+// StaleAfter は作成待ち・作成中のまま更新されないエクスポートを、subscriberが停止したものとみなすまでの時間
+// これを過ぎると新しいエクスポートを依頼でき、スケジューラーが行を削除する
+const StaleAfter = 3 * time.Hour
+
+// Message はアーカイブの作成を依頼するメッセージ
+type Message struct {
+	Type      string `json:"type"`
+	UserID    string `json:"user_id"`
+	ExportID  string `json:"export_id"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// StorageKey はアーカイブのストレージ上のキーを返す
+// 添付ファイルのキー（ユーザーID/...）と重ならないよう、exports/ の下に置く
+func StorageKey(userID, exportID uuid.UUID) string {
+	return "exports/" + userID.String() + "/" + exportID.String() + ".zip"
+}
@@ -0,0 +1,477 @@
+package takeout
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/storage"
+	"github.com/redis/rueidis"
+)
+
+// dateLayout は日記の日付の形式
+const dateLayout = "2006-01-02"
+
+type profileJSON struct {
+	ID        string          `json:"id"`
+	Email     string          `json:"email"`
+	Name      string          `json:"name"`
+	CreatedAt int64           `json:"created_at"`
+	UpdatedAt int64           `json:"updated_at"`
+	LLM       *llmSettingJSON `json:"llm"` // LLMキー未設定の場合はnull
+	Webhooks  []webhookJSON   `json:"webhooks"`
+}
+
+// llmSettingJSON はLLMの設定（キー本体は含めない）
+type llmSettingJSON struct {
+	Provider               int16 `json:"provider"`
+	AutoSummaryMonthly     bool  `json:"auto_summary_monthly"`
+	AutoLatestTrendEnabled bool  `json:"auto_latest_trend_enabled"`
+	SemanticSearchEnabled  bool  `json:"semantic_search_enabled"`
+	AutoTaggingEnabled     bool  `json:"auto_tagging_enabled"`
+	CreatedAt              int64 `json:"created_at"`
+	UpdatedAt              int64 `json:"updated_at"`
+}
+
+// webhookJSON はWebhookの設定（署名用シークレットは含めない）
+type webhookJSON struct {
+	ID        string          `json:"id"`
+	URL       string          `json:"url"`
+	Events    json.RawMessage `json:"events"`
+	CreatedAt int64           `json:"created_at"`
+}
+
+func writeProfile(ctx context.Context, src Source, a *archive, userID uuid.UUID) error {
+	user, err := database.UserByID(ctx, src.DB, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	profile := profileJSON{
+		ID:        user.ID.String(),
+		Email:     user.Email,
+		Name:      user.Name,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+		Webhooks:  make([]webhookJSON, 0),
+	}
+
+	llm, err := database.UserLlmByUserIDLlmProvider(ctx, src.DB, userID, 1) // Gemini
+	switch {
+	case err == nil:
+		profile.LLM = &llmSettingJSON{
+			Provider:               llm.LlmProvider,
+			AutoSummaryMonthly:     llm.AutoSummaryMonthly,
+			AutoLatestTrendEnabled: llm.AutoLatestTrendEnabled,
+			SemanticSearchEnabled:  llm.SemanticSearchEnabled,
+			AutoTaggingEnabled:     llm.AutoTaggingEnabled,
+			CreatedAt:              llm.CreatedAt,
+			UpdatedAt:              llm.UpdatedAt,
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("failed to get llm settings: %w", err)
+	}
+
+	webhooks, err := database.UserWebhooksByUserID(ctx, src.DB, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	for _, w := range webhooks {
+		profile.Webhooks = append(profile.Webhooks, webhookJSON{
+			ID:        w.ID.String(),
+			URL:       w.URL,
+			Events:    json.RawMessage(w.Events),
+			CreatedAt: w.CreatedAt,
+		})
+	}
+
+	return a.writeJSON("profile.json", "アカウント情報と設定（LLMキー・Webhookのシークレットは含まない）", 1, profile)
+}
+
+// apiKeyJSON はAPIキーのメタデータ（キー本体・ハッシュは含めない）
+type apiKeyJSON struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	KeyPrefix  string `json:"key_prefix"`
+	LastUsedAt *int64 `json:"last_used_at"`
+	ExpiresAt  int64  `json:"expires_at"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+func writeAPIKeys(ctx context.Context, src Source, a *archive, userID uuid.UUID) error {
+	keys, err := database.UserAPIKeysByUserID(ctx, src.DB, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get api keys: %w", err)
+	}
+	out := make([]apiKeyJSON, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, apiKeyJSON{
+			ID:         k.ID.String(),
+			Name:       k.Name,
+			KeyPrefix:  k.KeyPrefix,
+			LastUsedAt: optionalInt64(k.LastUsedAt),
+			ExpiresAt:  k.ExpiresAt,
+			CreatedAt:  k.CreatedAt,
+		})
+	}
+	return a.writeJSON("api_keys.json", "発行したAPIキーのメタデータ（キー本体は含まない）", len(out), out)
+}
+
+type tagJSON struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Color     string `json:"color"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+func writeTags(ctx context.Context, src Source, a *archive, userID uuid.UUID) error {
+	tags, err := database.TagsByUserID(ctx, src.DB, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+	out := make([]tagJSON, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, tagJSON{ID: t.ID.String(), Name: t.Name, Color: t.Color, CreatedAt: t.CreatedAt})
+	}
+	return a.writeJSON("tags.json", "タグ", len(out), out)
+}
+
+type diaryJSON struct {
+	ID        string   `json:"id"`
+	Date      string   `json:"date"`
+	EntryTime *int64   `json:"entry_time"` // 0時からの分（時刻未指定の場合はnull）
+	Title     string   `json:"title"`
+	Content   string   `json:"content"`
+	Tags      []string `json:"tags"`
+	CreatedAt int64    `json:"created_at"`
+	UpdatedAt int64    `json:"updated_at"`
+	DeletedAt *int64   `json:"deleted_at"` // ゴミ箱に移動した日時（ゴミ箱にない場合はnull）
+}
+
+func writeDiaries(ctx context.Context, src Source, a *archive, userID uuid.UUID) error {
+	return a.streamJSON("diaries.json", "日記（ゴミ箱の日記を含む）", func(add func(any) error) error {
+		return database.ForEachDiaryForExport(ctx, src.DB, userID, func(d *database.ExportedDiary) error {
+			return add(diaryJSON{
+				ID:        d.ID.String(),
+				Date:      d.Date.Format(dateLayout),
+				EntryTime: optionalInt64(d.EntryTime),
+				Title:     d.Title,
+				Content:   d.Content,
+				Tags:      d.TagNames,
+				CreatedAt: d.CreatedAt,
+				UpdatedAt: d.UpdatedAt,
+				DeletedAt: optionalInt64(d.DeletedAt),
+			})
+		})
+	})
+}
+
+// writeDiaryMarkdown は日記を月ごとのMarkdownファイル（markdown/diaries/YYYY/YYYY-MM.md）に書き込む
+// ゴミ箱の日記は含めない
+func writeDiaryMarkdown(ctx context.Context, src Source, a *archive, userID uuid.UUID) error {
+	var (
+		w       io.Writer
+		current string
+		count   int
+	)
+	finish := func() {
+		if current != "" {
+			a.record(current, FormatMarkdown, "日記（月ごと）", count)
+		}
+	}
+	err := database.ForEachDiaryForExport(ctx, src.DB, userID, func(d *database.ExportedDiary) error {
+		if d.Trashed() {
+			return nil
+		}
+		p := path.Join("markdown", "diaries", d.Date.Format("2006"), d.Date.Format("2006-01")+".md")
+		if p != current {
+			finish()
+			var err error
+			if w, err = a.create(p); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "# %s\n", d.Date.Format("2006年1月")); err != nil {
+				return err
+			}
+			current, count = p, 0
+		}
+		count++
+		_, err := io.WriteString(w, "\n"+diaryMarkdown(&d.Diary, d.TagNames))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write diary markdown: %w", err)
+	}
+	finish()
+	return nil
+}
+
+// diaryMarkdown は1件の日記のMarkdownを返す（見出し・タグ・本文）
+func diaryMarkdown(d *database.Diary, tags []string) string {
+	var b strings.Builder
+	b.WriteString("## " + d.Date.Format(dateLayout))
+	if d.EntryTime.Valid {
+		fmt.Fprintf(&b, " %02d:%02d", d.EntryTime.Int64/60, d.EntryTime.Int64%60)
+	}
+	if d.Title != "" {
+		b.WriteString(" " + d.Title)
+	}
+	b.WriteString("\n\n")
+	if len(tags) > 0 {
+		for i, t := range tags {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString("#" + t)
+		}
+		b.WriteString("\n\n")
+	}
+	b.WriteString(strings.TrimRight(d.Content, "\n"))
+	b.WriteString("\n")
+	return b.String()
+}
+
+type attachmentJSON struct {
+	ID          string   `json:"id"`
+	DiaryID     string   `json:"diary_id"`
+	Filename    string   `json:"filename"`
+	ContentType string   `json:"content_type"`
+	SizeBytes   int64    `json:"size_bytes"`
+	TakenAt     *int64   `json:"taken_at"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	CreatedAt   int64    `json:"created_at"`
+	Path        string   `json:"path"` // アーカイブ内の内容のパス（内容を含めない場合は空文字）
+}
+
+// attachmentPath は添付ファイルの内容のアーカイブ内のパスを返す
+// ファイル名にディレクトリの区切りが含まれていてもアーカイブの外を指さないよう、最後の要素だけを使う
+func attachmentPath(id uuid.UUID, filename string) string {
+	name := path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if name == "." || name == "/" || name == ".." {
+		name = "file"
+	}
+	return path.Join("attachments", id.String(), name)
+}
+
+func writeAttachments(ctx context.Context, src Source, a *archive, userID uuid.UUID) error {
+	attachments, err := database.DiaryAttachmentsByUserID(ctx, src.DB, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get attachments: %w", err)
+	}
+	out := make([]attachmentJSON, 0, len(attachments))
+	for _, at := range attachments {
+		item := attachmentJSON{
+			ID:          at.ID.String(),
+			DiaryID:     at.DiaryID.String(),
+			Filename:    at.Filename,
+			ContentType: at.ContentType,
+			SizeBytes:   at.SizeBytes,
+			TakenAt:     optionalInt64(at.TakenAt),
+			CreatedAt:   at.CreatedAt,
+		}
+		if at.Latitude.Valid && at.Longitude.Valid {
+			item.Latitude, item.Longitude = &at.Latitude.Float64, &at.Longitude.Float64
+		}
+		if src.Storage != nil {
+			p := attachmentPath(at.ID, at.Filename)
+			copied, err := a.copyObject(ctx, src.Storage, at.StorageKey, p)
+			if err != nil {
+				return err
+			}
+			if copied {
+				item.Path = p
+				a.record(p, FormatBinary, "添付ファイルの内容", 1)
+			}
+		}
+		out = append(out, item)
+	}
+	return a.writeJSON("attachments.json", "添付ファイルのメタデータ", len(out), out)
+}
+
+// copyObject はストレージのオブジェクトをアーカイブのpにコピーする
+// オブジェクトが存在しない場合はコピーせずにfalseを返す
+func (a *archive) copyObject(ctx context.Context, s storage.Storage, key, p string) (bool, error) {
+	r, err := s.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read attachment %s: %w", key, err)
+	}
+	defer func() { _ = r.Close() }()
+
+	w, err := a.create(p)
+	if err != nil {
+		return false, err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		return false, fmt.Errorf("failed to copy attachment %s: %w", key, err)
+	}
+	return true, nil
+}
+
+type entityJSON struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	CategoryID int      `json:"category_id"`
+	Memo       string   `json:"memo"`
+	Aliases    []string `json:"aliases"`
+	CreatedAt  int64    `json:"created_at"`
+	UpdatedAt  int64    `json:"updated_at"`
+	DeletedAt  *int64   `json:"deleted_at"`
+}
+
+func writeEntities(ctx context.Context, src Source, a *archive, userID uuid.UUID) error {
+	err := a.streamJSON("entities.json", "エンティティとエイリアス（ゴミ箱のエンティティを含む）", func(add func(any) error) error {
+		return database.ForEachEntityForExport(ctx, src.DB, userID, func(e *database.ExportedEntity) error {
+			return add(entityJSON{
+				ID:         e.ID.String(),
+				Name:       e.Name,
+				CategoryID: e.CategoryID,
+				Memo:       e.Memo.String,
+				Aliases:    e.Aliases,
+				CreatedAt:  e.CreatedAt,
+				UpdatedAt:  e.UpdatedAt,
+				DeletedAt:  optionalInt64(e.DeletedAt),
+			})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	const p = "markdown/entities.md"
+	w, err := a.create(p)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, "# エンティティ\n"); err != nil {
+		return err
+	}
+	count := 0
+	err = database.ForEachEntityForExport(ctx, src.DB, userID, func(e *database.ExportedEntity) error {
+		if e.Trashed() {
+			return nil
+		}
+		count++
+		_, err := io.WriteString(w, "\n"+entityMarkdown(&e.Entity, e.Aliases))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write entity markdown: %w", err)
+	}
+	a.record(p, FormatMarkdown, "エンティティ", count)
+	return nil
+}
+
+// entityMarkdown は1件のエンティティのMarkdownを返す
+func entityMarkdown(e *database.Entity, aliases []string) string {
+	var b strings.Builder
+	b.WriteString("## " + e.Name + "\n")
+	if len(aliases) > 0 {
+		b.WriteString("\n別名: " + strings.Join(aliases, "、") + "\n")
+	}
+	if memo := strings.TrimSpace(e.Memo.String); memo != "" {
+		b.WriteString("\n" + memo + "\n")
+	}
+	return b.String()
+}
+
+type monthlySummaryJSON struct {
+	Year         int    `json:"year"`
+	Month        int    `json:"month"`
+	Summary      string `json:"summary"`
+	ModelVersion string `json:"model_version"`
+	CreatedAt    int64  `json:"created_at"`
+	UpdatedAt    int64  `json:"updated_at"`
+}
+
+func writeMonthlySummaries(ctx context.Context, src Source, a *archive, userID uuid.UUID) error {
+	err := a.streamJSON("monthly_summaries.json", "月次要約", func(add func(any) error) error {
+		return database.ForEachMonthlySummaryForExport(ctx, src.DB, userID, func(s *database.DiarySummaryMonth) error {
+			if s.Summary == "" {
+				return nil
+			}
+			return add(monthlySummaryJSON{
+				Year:         s.Year,
+				Month:        s.Month,
+				Summary:      s.Summary,
+				ModelVersion: s.ModelVersion,
+				CreatedAt:    s.CreatedAt,
+				UpdatedAt:    s.UpdatedAt,
+			})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	const p = "markdown/monthly_summaries.md"
+	w, err := a.create(p)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, "# 月次要約\n"); err != nil {
+		return err
+	}
+	count := 0
+	err = database.ForEachMonthlySummaryForExport(ctx, src.DB, userID, func(s *database.DiarySummaryMonth) error {
+		if s.Summary == "" {
+			return nil
+		}
+		count++
+		_, err := fmt.Fprintf(w, "\n## %d年%d月\n\n%s\n", s.Year, s.Month, strings.TrimRight(s.Summary, "\n"))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write monthly summary markdown: %w", err)
+	}
+	a.record(p, FormatMarkdown, "月次要約", count)
+	return nil
+}
+
+type highlightJSON struct {
+	DiaryID    string          `json:"diary_id"`
+	Date       string          `json:"date"`
+	Highlights json.RawMessage `json:"highlights"`
+	UpdatedAt  int64           `json:"updated_at"`
+}
+
+func writeHighlights(ctx context.Context, src Source, a *archive, userID uuid.UUID) error {
+	return a.streamJSON("highlights.json", "日記のハイライト", func(add func(any) error) error {
+		return database.ForEachHighlightForExport(ctx, src.DB, userID, func(h *database.ExportedHighlight) error {
+			return add(highlightJSON{
+				DiaryID:    h.DiaryID.String(),
+				Date:       h.Date.Format(dateLayout),
+				Highlights: json.RawMessage(h.Highlights),
+				UpdatedAt:  h.UpdatedAt.Unix(),
+			})
+		})
+	})
+}
+
+// writeLatestTrend はRedisに保存されている直近のトレンド分析を書き込む
+// トレンド分析は最新の1件のみ保持しているため、過去の分析は含まれない
+func writeLatestTrend(ctx context.Context, src Source, a *archive, userID uuid.UUID) error {
+	if src.Redis == nil {
+		return nil
+	}
+	data, err := src.Redis.Do(ctx, src.Redis.B().Get().Key("latest_trend:"+userID.String()).Build()).AsBytes()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get latest trend: %w", err)
+	}
+	if !json.Valid(data) {
+		return nil
+	}
+	return a.writeJSON("latest_trend.json", "直近のトレンド分析", 1, json.RawMessage(data))
+}
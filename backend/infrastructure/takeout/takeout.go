@@ -0,0 +1,198 @@
+// Package takeout はユーザーの個人データをまとめて持ち出すためのアーカイブ（ZIP）を作成する。
+// 日記・エンティティ・月次要約・ハイライトなどをJSONとMarkdownの両方で出力し、内容の一覧をmanifest.jsonに記録する。
+// 件数が多くてもメモリに載せないよう、データベースから1行ずつ読み込んでそのままアーカイブに書き込む。
+package takeout
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/storage"
+	"github.com/redis/rueidis"
+)
+
+// FormatVersion はアーカイブの形式のバージョン（互換性のない変更をした場合に上げる）
+const FormatVersion = 1
+
+// ContentType はアーカイブのContent-Type
+const ContentType = "application/zip"
+
+// 出力形式（manifest.jsonのformat）
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+	FormatBinary   = "binary"
+)
+
+// Source はアーカイブに含めるデータの取得元
+type Source struct {
+	DB    database.DB
+	Redis rueidis.Client // nilの場合はトレンド分析を含めない
+	// Storage は添付ファイルの保存先（nilの場合は添付ファイルの内容を含めない）
+	Storage storage.Storage
+}
+
+// Manifest はアーカイブの内容の一覧（manifest.json）
+type Manifest struct {
+	FormatVersion int            `json:"format_version"`
+	UserID        string         `json:"user_id"`
+	GeneratedAt   int64          `json:"generated_at"`
+	Files         []ManifestFile `json:"files"`
+}
+
+// ManifestFile はアーカイブに含まれるファイル
+type ManifestFile struct {
+	Path        string `json:"path"`
+	Format      string `json:"format"`
+	Description string `json:"description"`
+	Records     int    `json:"records"` // 含まれる件数（Markdownは日記などの件数、添付ファイルの内容は1）
+}
+
+// archive はZIPへの書き込みと、manifest.jsonに記録するファイルの一覧を保持する
+type archive struct {
+	zw       *zip.Writer
+	modified time.Time
+	manifest *Manifest
+}
+
+// create はアーカイブに新しいファイルを作成する（以前に作成したファイルへの書き込みは終了する）
+func (a *archive) create(path string) (io.Writer, error) {
+	w, err := a.zw.CreateHeader(&zip.FileHeader{Name: path, Method: zip.Deflate, Modified: a.modified})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s in archive: %w", path, err)
+	}
+	return w, nil
+}
+
+func (a *archive) record(path, format, description string, records int) {
+	a.manifest.Files = append(a.manifest.Files, ManifestFile{
+		Path:        path,
+		Format:      format,
+		Description: description,
+		Records:     records,
+	})
+}
+
+// writeJSON はvを整形したJSONファイルとしてアーカイブに書き込む
+func (a *archive) writeJSON(path, description string, records int, v any) error {
+	w, err := a.create(path)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	a.record(path, FormatJSON, description, records)
+	return nil
+}
+
+// Write はユーザーの個人データのアーカイブをwに書き込み、内容の一覧を返す
+func Write(ctx context.Context, src Source, userID uuid.UUID, w io.Writer, now time.Time) (*Manifest, error) {
+	a := &archive{
+		zw:       zip.NewWriter(w),
+		modified: now,
+		manifest: &Manifest{FormatVersion: FormatVersion, UserID: userID.String(), GeneratedAt: now.Unix()},
+	}
+
+	steps := []func(context.Context, Source, *archive, uuid.UUID) error{
+		writeProfile,
+		writeAPIKeys,
+		writeTags,
+		writeDiaries,
+		writeDiaryMarkdown,
+		writeAttachments,
+		writeEntities,
+		writeMonthlySummaries,
+		writeHighlights,
+		writeLatestTrend,
+	}
+	for _, step := range steps {
+		if err := step(ctx, src, a, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 件数が確定してから書き込むため、manifest.jsonはアーカイブの最後に置く（一覧には含めない）
+	mw, err := a.create("manifest.json")
+	if err != nil {
+		return nil, err
+	}
+	enc := json.NewEncoder(mw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(a.manifest); err != nil {
+		return nil, fmt.Errorf("failed to write manifest.json: %w", err)
+	}
+
+	if err := a.zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return a.manifest, nil
+}
+
+// jsonArrayWriter はJSON配列を1要素ずつ書き込む（全件をメモリに載せずに配列を出力するため）
+type jsonArrayWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (j *jsonArrayWriter) add(v any) error {
+	b, err := json.MarshalIndent(v, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	sep := ",\n  "
+	if j.count == 0 {
+		sep = "[\n  "
+	}
+	if _, err := io.WriteString(j.w, sep); err != nil {
+		return err
+	}
+	if _, err := j.w.Write(b); err != nil {
+		return err
+	}
+	j.count++
+	return nil
+}
+
+func (j *jsonArrayWriter) close() error {
+	end := "\n]\n"
+	if j.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.w, end)
+	return err
+}
+
+// streamJSON はeachが渡す要素をJSON配列のファイルとしてアーカイブに書き込む
+func (a *archive) streamJSON(path, description string, each func(add func(any) error) error) error {
+	w, err := a.create(path)
+	if err != nil {
+		return err
+	}
+	arr := &jsonArrayWriter{w: w}
+	if err := each(arr.add); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := arr.close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	a.record(path, FormatJSON, description, arr.count)
+	return nil
+}
+
+// optionalInt64 はNULLの場合にJSONのnullとして出力する
+func optionalInt64(v sql.NullInt64) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int64
+}
@@ -0,0 +1,151 @@
+package takeout
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/storage"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONArrayWriter(t *testing.T) {
+	t.Run("正常系: 要素がない場合は空の配列", func(t *testing.T) {
+		var buf bytes.Buffer
+		arr := &jsonArrayWriter{w: &buf}
+		require.NoError(t, arr.close())
+		assert.Equal(t, "[]\n", buf.String())
+	})
+
+	t.Run("正常系: 1要素ずつ書き込んだ結果がJSON配列になる", func(t *testing.T) {
+		var buf bytes.Buffer
+		arr := &jsonArrayWriter{w: &buf}
+		require.NoError(t, arr.add(map[string]int{"a": 1}))
+		require.NoError(t, arr.add(map[string]int{"a": 2}))
+		require.NoError(t, arr.close())
+
+		var got []map[string]int
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		assert.Equal(t, []map[string]int{{"a": 1}, {"a": 2}}, got)
+		assert.Equal(t, 2, arr.count)
+	})
+}
+
+func TestDiaryMarkdown(t *testing.T) {
+	d := &database.Diary{
+		Date:      time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Title:     "朝のメモ",
+		Content:   "本文\n",
+		EntryTime: sql.NullInt64{Int64: 7*60 + 30, Valid: true},
+	}
+	assert.Equal(t, "## 2024-05-01 07:30 朝のメモ\n\n#仕事 #旅行\n\n本文\n", diaryMarkdown(d, []string{"仕事", "旅行"}))
+
+	d.Title, d.EntryTime = "", sql.NullInt64{}
+	assert.Equal(t, "## 2024-05-01\n\n本文\n", diaryMarkdown(d, nil))
+}
+
+func TestEntityMarkdown(t *testing.T) {
+	e := &database.Entity{Name: "山田太郎", Memo: sql.NullString{String: "大学の友人", Valid: true}}
+	assert.Equal(t, "## 山田太郎\n\n別名: 太郎、やまだ\n\n大学の友人\n", entityMarkdown(e, []string{"太郎", "やまだ"}))
+	assert.Equal(t, "## 山田太郎\n", entityMarkdown(&database.Entity{Name: "山田太郎"}, nil))
+}
+
+func TestAttachmentPath(t *testing.T) {
+	id := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	tests := []struct {
+		name     string
+		filename string
+		expected string
+	}{
+		{name: "正常系: ファイル名をそのまま使う", filename: "photo.jpg", expected: "attachments/" + id.String() + "/photo.jpg"},
+		{name: "正常系: ディレクトリを含む場合は最後の要素だけを使う", filename: "../../etc/passwd", expected: "attachments/" + id.String() + "/passwd"},
+		{name: "正常系: Windowsの区切り文字も除く", filename: `C:\Users\photo.jpg`, expected: "attachments/" + id.String() + "/photo.jpg"},
+		{name: "正常系: 空の場合は既定の名前", filename: "", expected: "attachments/" + id.String() + "/file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, attachmentPath(id, tt.filename))
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	userID := testutil.CreateTestUser(t, db, "takeout@example.com", "TakeoutUser")
+
+	now := time.Now().Unix()
+	insertDiary := func(date time.Time, content string, deletedAt sql.NullInt64) *database.Diary {
+		d := &database.Diary{ID: uuid.New(), UserID: userID, Content: content, Date: date, CreatedAt: now, UpdatedAt: now, Version: 1, DeletedAt: deletedAt}
+		require.NoError(t, d.Insert(ctx, db))
+		return d
+	}
+	diary := insertDiary(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), "五月の日記", sql.NullInt64{})
+	insertDiary(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), "ゴミ箱の日記", sql.NullInt64{Int64: now, Valid: true})
+
+	entity := &database.Entity{ID: uuid.New(), UserID: userID, Name: "山田", CategoryID: 1, CreatedAt: now, UpdatedAt: now, Version: 1}
+	require.NoError(t, entity.Insert(ctx, db))
+	alias := &database.EntityAlias{ID: uuid.New(), EntityID: entity.ID, Alias: "やまちゃん", CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, alias.Insert(ctx, db))
+
+	st, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	attachment := &database.DiaryAttachment{
+		ID: uuid.New(), DiaryID: diary.ID, UserID: userID, Filename: "memo.txt", ContentType: "text/plain",
+		SizeBytes: 5, StorageKey: userID.String() + "/memo/original", CreatedAt: now, UpdatedAt: now,
+	}
+	require.NoError(t, attachment.Insert(ctx, db))
+	require.NoError(t, st.Put(ctx, attachment.StorageKey, strings.NewReader("hello"), 5, "text/plain"))
+
+	var buf bytes.Buffer
+	manifest, err := Write(ctx, Source{DB: db, Storage: st}, userID, &buf, time.Now())
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		files[f.Name] = string(b)
+	}
+
+	t.Run("正常系: manifest.jsonに全ファイルが記録される", func(t *testing.T) {
+		require.Contains(t, files, "manifest.json")
+		var got Manifest
+		require.NoError(t, json.Unmarshal([]byte(files["manifest.json"]), &got))
+		assert.Equal(t, FormatVersion, got.FormatVersion)
+		assert.Equal(t, manifest.Files, got.Files)
+		for _, f := range got.Files {
+			assert.Contains(t, files, f.Path)
+		}
+	})
+
+	t.Run("正常系: 日記はJSONにゴミ箱を含め、Markdownには含めない", func(t *testing.T) {
+		var diaries []diaryJSON
+		require.NoError(t, json.Unmarshal([]byte(files["diaries.json"]), &diaries))
+		assert.Len(t, diaries, 2)
+		assert.Contains(t, files["markdown/diaries/2024/2024-05.md"], "五月の日記")
+		assert.NotContains(t, files, "markdown/diaries/2024/2024-06.md")
+	})
+
+	t.Run("正常系: エンティティとエイリアス、添付ファイルの内容を含む", func(t *testing.T) {
+		var entities []entityJSON
+		require.NoError(t, json.Unmarshal([]byte(files["entities.json"]), &entities))
+		require.Len(t, entities, 1)
+		assert.Equal(t, []string{"やまちゃん"}, entities[0].Aliases)
+		assert.Equal(t, "hello", files[attachmentPath(attachment.ID, attachment.Filename)])
+	})
+}
@@ -0,0 +1,102 @@
+package taskevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/rueidis"
+)
+
+// タスク種別（diary_eventsチャンネルのメッセージtypeと同じ値）
+const (
+	TypeMonthlySummary     = "monthly_summary"
+	TypeLatestTrend        = "latest_trend"
+	TypeDiaryHighlight     = "diary_highlight"
+	TypeDiaryEmbedding     = "diary_embedding"
+	TypeDiaryTagSuggestion = "diary_tag_suggestion"
+	TypeDataExport         = "data_export"
+)
+
+// Status はタスクの状態
+type Status string
+
+const (
+	StatusQueued     Status = "queued"
+	StatusProcessing Status = "processing"
+	StatusSucceeded  Status = "succeeded"
+	StatusFailed     Status = "failed"
+)
+
+// Event はタスクの状態変化を表すイベント
+type Event struct {
+	UserID    string `json:"user_id"`
+	TaskType  string `json:"task_type"`
+	Target    string `json:"target"` // 月次要約: YYYY-MM, ハイライト/embedding: 日記ID, データエクスポート: エクスポートID, トレンド: 空
+	Status    Status `json:"status"`
+	Message   string `json:"message,omitempty"` // 失敗時のエラー内容
+	Timestamp int64  `json:"timestamp"`         // Unix秒
+}
+
+// Channel はユーザーごとのタスクイベント配信チャンネル名を返す
+// ユーザー単位で分けることで購読側のフィルタリングを不要にする
+func Channel(userID string) string {
+	return fmt.Sprintf("task_events:%s", userID)
+}
+
+// MonthTarget は月次要約タスクのTargetを返す
+func MonthTarget(year, month int) string {
+	return fmt.Sprintf("%04d-%02d", year, month)
+}
+
+// NewEvent は現在時刻のイベントを生成する
+func NewEvent(userID, taskType, target string, status Status) Event {
+	return Event{
+		UserID:    userID,
+		TaskType:  taskType,
+		Target:    target,
+		Status:    status,
+		Timestamp: time.Now().Unix(),
+	}
+}
+
+// Publish はイベントをユーザーのチャンネルに配信する
+// 購読者がいない場合は何もしない（Pub/Subのためイベントは保持されない）
+func Publish(ctx context.Context, client rueidis.Client, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task event: %w", err)
+	}
+	cmd := client.B().Publish().Channel(Channel(event.UserID)).Message(string(payload)).Build()
+	if err := client.Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("failed to publish task event: %w", err)
+	}
+	return nil
+}
+
+// Subscribe はユーザーのチャンネルを購読し、受信したイベントごとにhandlerを呼び出す
+// Redisから購読の確立が通知されるとonSubscribedを呼び出す（nilの場合は呼び出さない）
+// ctxがキャンセルされるまでブロックする。不正な形式のメッセージは読み飛ばす
+func Subscribe(ctx context.Context, client rueidis.Client, userID string, onSubscribed func(), handler func(Event)) error {
+	channel := Channel(userID)
+	if onSubscribed != nil {
+		ctx = rueidis.WithOnSubscriptionHook(ctx, func(s rueidis.PubSubSubscription) {
+			if s.Kind == "subscribe" && s.Channel == channel {
+				onSubscribed()
+			}
+		})
+	}
+	cmd := client.B().Subscribe().Channel(channel).Build()
+	err := client.Receive(ctx, cmd, func(msg rueidis.PubSubMessage) {
+		var event Event
+		if err := json.Unmarshal([]byte(msg.Message), &event); err != nil {
+			return
+		}
+		handler(event)
+	})
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to subscribe task events: %w", err)
+	}
+	return nil
+}
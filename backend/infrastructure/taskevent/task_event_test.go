@@ -0,0 +1,91 @@
+package taskevent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestRedis(t *testing.T) *miniredis.Miniredis {
+	t.Helper()
+
+	// miniredisでテスト用Redisサーバーを起動
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+	return mr
+}
+
+// newTestClient はrueidisクライアントを作成する（テスト用にキャッシュを無効化）
+// miniredisはRESP3の購読中コネクションでPUBLISHを受け付けないため、購読側と配信側で別クライアントを使う
+func newTestClient(t *testing.T, mr *miniredis.Miniredis) rueidis.Client {
+	t.Helper()
+
+	client, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{mr.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestMonthTarget(t *testing.T) {
+	assert.Equal(t, "2024-05", MonthTarget(2024, 5))
+	assert.Equal(t, "2024-12", MonthTarget(2024, 12))
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	mr := setupTestRedis(t)
+	subscriber := newTestClient(t, mr)
+	publisher := newTestClient(t, mr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan Event, 1)
+	subscribed := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- Subscribe(ctx, subscriber, "user-1", func() { close(subscribed) }, func(e Event) {
+			received <- e
+		})
+	}()
+
+	// 購読の確立が通知された時点でRedis側にも購読者が登録されている
+	select {
+	case <-subscribed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("購読の確立が通知されない")
+	}
+	assert.Equal(t, 1, mr.PubSubNumSub(Channel("user-1"))[Channel("user-1")])
+
+	// 他ユーザーのイベントは届かない
+	require.NoError(t, Publish(ctx, publisher, NewEvent("user-2", TypeLatestTrend, "", StatusQueued)))
+	// 不正な形式のメッセージは読み飛ばされる
+	mr.Publish(Channel("user-1"), "not-json")
+
+	event := NewEvent("user-1", TypeMonthlySummary, MonthTarget(2024, 5), StatusSucceeded)
+	require.NoError(t, Publish(ctx, publisher, event))
+
+	select {
+	case got := <-received:
+		assert.Equal(t, event, got)
+	case <-time.After(2 * time.Second):
+		t.Fatal("イベントを受信できなかった")
+	}
+
+	// キャンセルで購読が終了し、エラーは返らない
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("キャンセル後も購読が終了しない")
+	}
+}
@@ -0,0 +1,234 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// clientRequestTimeout は1リクエストあたりのタイムアウト
+const clientRequestTimeout = time.Minute
+
+// propfindBody はPROPFINDで取得するプロパティ（ETagとコレクションかどうか）
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:"><d:prop><d:getetag/><d:resourcetype/></d:prop></d:propfind>`
+
+// WebDAVRemote はWebDAVで公開されたノートのフォルダーを同期先とするRemote
+// 日記のWebDAVのエンドポイント（NewHTTPHandler）に限らず、ノートを置いた一般のWebDAVサーバーとも同期できる
+type WebDAVRemote struct {
+	Username   string
+	Password   string
+	HTTPClient *http.Client
+
+	base *url.URL
+}
+
+// NewWebDAVRemote はbaseURLのコレクションを同期先とするWebDAVRemoteを返す
+func NewWebDAVRemote(baseURL, username, password string) (*WebDAVRemote, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("vault: invalid webdav url %q", baseURL)
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	base.RawPath = ""
+	return &WebDAVRemote{
+		Username:   username,
+		Password:   password,
+		HTTPClient: &http.Client{Timeout: clientRequestTimeout},
+		base:       base,
+	}, nil
+}
+
+func (r *WebDAVRemote) do(ctx context.Context, method, p string, body []byte, header http.Header) (*http.Response, error) {
+	u := *r.base
+	u.Path += p
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return nil, fmt.Errorf("vault: failed to create request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if r.Username != "" || r.Password != "" {
+		req.SetBasicAuth(r.Username, r.Password)
+	}
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault: webdav request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// webdavError はレスポンスの先頭を含めたエラーを返す
+func webdavError(op string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("vault: webdav %s failed with status %d: %s", op, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// multistatus はPROPFINDのレスポンス
+type multistatus struct {
+	Responses []struct {
+		Href     string `xml:"href"`
+		Propstat []struct {
+			Prop struct {
+				ETag         string `xml:"getetag"`
+				ResourceType struct {
+					Collection *struct{} `xml:"collection"`
+				} `xml:"resourcetype"`
+			} `xml:"prop"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+// davEntry はPROPFINDで取得したコレクション直下の1件
+type davEntry struct {
+	path  string // baseからの相対パス
+	etag  string
+	isDir bool
+}
+
+// propfind はコレクションdir直下のリソースを返す（dir自身は含めない）
+// Depth: infinityを許可しないサーバーが多いため、1階層ずつ取得する
+func (r *WebDAVRemote) propfind(ctx context.Context, dir string) ([]davEntry, error) {
+	resp, err := r.do(ctx, "PROPFIND", dir, []byte(propfindBody), http.Header{
+		"Depth":        {"1"},
+		"Content-Type": {"application/xml; charset=utf-8"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, webdavError("propfind", resp)
+	}
+	var ms multistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, fmt.Errorf("vault: failed to parse propfind response: %w", err)
+	}
+
+	entries := make([]davEntry, 0, len(ms.Responses))
+	for _, res := range ms.Responses {
+		href, err := url.Parse(res.Href)
+		if err != nil {
+			continue
+		}
+		rel, ok := strings.CutPrefix(href.Path, r.base.Path)
+		if !ok {
+			continue
+		}
+		e := davEntry{path: strings.Trim(rel, "/")}
+		for _, ps := range res.Propstat {
+			if ps.Prop.ETag != "" {
+				e.etag = ps.Prop.ETag
+			}
+			if ps.Prop.ResourceType.Collection != nil {
+				e.isDir = true
+			}
+		}
+		if e.path == strings.Trim(dir, "/") {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func (r *WebDAVRemote) List(ctx context.Context) ([]RemoteFile, error) {
+	files := make([]RemoteFile, 0)
+	var walk func(dir string, depth int) error
+	walk = func(dir string, depth int) error {
+		entries, err := r.propfind(ctx, dir)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			switch {
+			case e.isDir && depth < 2 && IsDirPath(e.path):
+				if err := walk(e.path+"/", depth+1); err != nil {
+					return err
+				}
+			case !e.isDir && depth == 2:
+				if _, _, ok := ParseNotePath(e.path); ok {
+					files = append(files, RemoteFile{Path: e.path, ETag: e.etag})
+				}
+			}
+		}
+		return nil
+	}
+	if err := walk("", 0); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (r *WebDAVRemote) Get(ctx context.Context, p string) ([]byte, string, error) {
+	resp, err := r.do(ctx, http.MethodGet, p, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", ErrNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, "", webdavError("get", resp)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("vault: failed to read %s: %w", p, err)
+	}
+	return data, resp.Header.Get("ETag"), nil
+}
+
+func (r *WebDAVRemote) Put(ctx context.Context, p string, data []byte) error {
+	// 日記のエンドポイントでは年月のコレクションは常に存在するが、一般のWebDAVサーバーでは作成が必要になる
+	// 既に存在する場合、サーバーは405（Method Not Allowed）を返すため成功として扱う
+	for _, dir := range []string{path.Dir(path.Dir(p)), path.Dir(p)} {
+		resp, err := r.do(ctx, "MKCOL", dir+"/", nil, nil)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusMethodNotAllowed {
+			return fmt.Errorf("vault: webdav mkcol failed with status %d", resp.StatusCode)
+		}
+	}
+
+	resp, err := r.do(ctx, http.MethodPut, p, data, http.Header{"Content-Type": {ContentType}})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		return webdavError("put", resp)
+	}
+	return nil
+}
+
+func (r *WebDAVRemote) Delete(ctx context.Context, p string) error {
+	resp, err := r.do(ctx, http.MethodDelete, p, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		return webdavError("delete", resp)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package vault
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/webdav"
+)
+
+func TestWebDAVRemote(t *testing.T) {
+	handler := &webdav.Handler{Prefix: "/dav", FileSystem: webdav.NewMemFS(), LockSystem: webdav.NewMemLS()}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, password, ok := r.BasicAuth(); !ok || password != "umi_key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	ctx := t.Context()
+	remote, err := NewWebDAVRemote(server.URL+"/dav", "umi", "umi_key")
+	require.NoError(t, err)
+
+	const may1 = "2024/05/2024-05-01.md"
+	require.NoError(t, remote.Put(ctx, may1, []byte("5月1日")))
+	require.NoError(t, remote.Put(ctx, "2024/05/2024-05-01-2.md", []byte("5月1日の2件目")))
+	// 日記のノートでないファイルは一覧に含めない
+	require.NoError(t, handler.FileSystem.Mkdir(ctx, "/2024/05/attachments", 0o755))
+	f, err := handler.FileSystem.OpenFile(ctx, "/2024/05/memo.md", os.O_CREATE|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	t.Run("正常系: 日記のノートの一覧とETagを返す", func(t *testing.T) {
+		files, err := remote.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		paths := []string{files[0].Path, files[1].Path}
+		assert.ElementsMatch(t, []string{may1, "2024/05/2024-05-01-2.md"}, paths)
+
+		data, etag, err := remote.Get(ctx, may1)
+		require.NoError(t, err)
+		assert.Equal(t, "5月1日", string(data))
+		for _, f := range files {
+			if f.Path == may1 {
+				assert.Equal(t, etag, f.ETag)
+			}
+		}
+	})
+
+	t.Run("正常系: 削除したノートはErrNotFound", func(t *testing.T) {
+		require.NoError(t, remote.Delete(ctx, "2024/05/2024-05-01-2.md"))
+		_, _, err := remote.Get(ctx, "2024/05/2024-05-01-2.md")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorIs(t, remote.Delete(ctx, "2024/05/2024-05-01-2.md"), ErrNotFound)
+	})
+
+	t.Run("正常系: ローカルのフォルダーと同期できる", func(t *testing.T) {
+		s := NewSyncer(t.TempDir(), remote)
+		result, err := s.Sync(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, Result{Downloaded: 1}, result)
+
+		require.NoError(t, os.WriteFile(s.localPath(may1), []byte("ローカルで編集"), 0o644))
+		result, err = s.Sync(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, Result{Uploaded: 1}, result)
+		data, _, err := remote.Get(ctx, may1)
+		require.NoError(t, err)
+		assert.Equal(t, "ローカルで編集", string(data))
+
+		result, err = s.Sync(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, Result{}, result)
+	})
+
+	t.Run("異常系: 認証に失敗した場合はエラー", func(t *testing.T) {
+		wrong, err := NewWebDAVRemote(server.URL+"/dav/", "umi", "wrong")
+		require.NoError(t, err)
+		_, err = wrong.List(ctx)
+		assert.ErrorContains(t, err, "401")
+	})
+}
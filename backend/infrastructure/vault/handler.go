@@ -0,0 +1,130 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/domain/model"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/securityevent"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"github.com/project-mikan/umi.mikan/backend/service/diary"
+	"golang.org/x/net/webdav"
+)
+
+const (
+	// Path はWebDAVのエンドポイントをマウントするパス
+	Path = "/vault/"
+
+	// maxNoteSize は書き込めるノートの最大バイト数
+	maxNoteSize = 1 << 20
+
+	// basicAuthRealm はBasic認証を求めるときのrealm
+	basicAuthRealm = `Basic realm="umi.mikan vault", charset="UTF-8"`
+)
+
+// lockSystems はユーザーごとのWebDAVのロック
+// パスはユーザーごとの仮想的なものであり、他のユーザーのロックと衝突しないよう分ける
+type lockSystems struct {
+	mu    sync.Mutex
+	locks map[uuid.UUID]webdav.LockSystem
+}
+
+func (l *lockSystems) get(userID uuid.UUID) webdav.LockSystem {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ls, ok := l.locks[userID]
+	if !ok {
+		ls = webdav.NewMemLS()
+		l.locks[userID] = ls
+	}
+	return ls
+}
+
+// NewHTTPHandler は日記をMarkdownのノートとしてWebDAVで公開するハンドラーを返す（Pathにマウントする）
+// ObsidianのWebDAV同期プラグインや `umi sync` から使う。認証はBasic認証で、パスワードにAPIキーを指定する
+func NewHTTPHandler(diaryService *diary.DiaryEntry, db *sql.DB) http.Handler {
+	locks := &lockSystems{locks: make(map[uuid.UUID]webdav.LockSystem)}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := authenticate(w, r, db)
+		if !ok {
+			return
+		}
+		if r.Method == http.MethodPut {
+			// 途中までしか受信できなかった内容で日記を更新しないよう、本文をすべて受信してから処理する
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxNoteSize))
+			if err != nil {
+				var maxErr *http.MaxBytesError
+				if errors.As(err, &maxErr) {
+					http.Error(w, "note is too large", http.StatusRequestEntityTooLarge)
+					return
+				}
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		ctx := context.WithValue(r.Context(), middleware.UserIDKey, userID.String())
+		h := &webdav.Handler{
+			Prefix:     strings.TrimSuffix(Path, "/"),
+			FileSystem: &diaryFS{store: newStore(diaryService, db, userID)},
+			LockSystem: locks.get(userID),
+			Logger: func(r *http.Request, err error) {
+				if err != nil && !errors.Is(err, ErrInvalidNote) {
+					log.Printf("vault: %s %s failed: %v", r.Method, r.URL.Path, err)
+				}
+			},
+		}
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// authenticate はBasic認証のパスワードのAPIキーを検証してユーザーIDを返す
+// WebDAVクライアントは有効期限の短いアクセストークンを更新できないため、APIキーのみ受け付ける
+func authenticate(w http.ResponseWriter, r *http.Request, db *sql.DB) (uuid.UUID, bool) {
+	_, key, ok := r.BasicAuth()
+	if !ok || !model.IsAPIKey(key) {
+		w.Header().Set("WWW-Authenticate", basicAuthRealm)
+		http.Error(w, "api key is required", http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+
+	apiKey, err := database.ActiveUserAPIKeyByKeyHash(r.Context(), db, model.HashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.Header().Set("WWW-Authenticate", basicAuthRealm)
+			http.Error(w, "invalid api key", http.StatusUnauthorized)
+			return uuid.Nil, false
+		}
+		log.Printf("vault: failed to look up api key: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return uuid.Nil, false
+	}
+	if time.Now().Unix() >= apiKey.ExpiresAt {
+		w.Header().Set("WWW-Authenticate", basicAuthRealm)
+		http.Error(w, "api key expired", http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+
+	// 最終使用日時の更新とセキュリティイベントの記録は認証結果に影響しないため、レスポンスを遅延させないよう非同期で行う
+	eventCtx := context.WithoutCancel(middleware.WithHTTPClient(r.Context(), r))
+	go func(apiKey *database.UserAPIKey) {
+		now := time.Now()
+		if err := securityevent.RecordAPIKeyUse(eventCtx, db, apiKey, now); err != nil {
+			log.Printf("vault: failed to record api key use: %v", err)
+		}
+		if err := database.UpdateUserAPIKeyLastUsed(context.Background(), db, apiKey.ID, now.Unix()); err != nil {
+			log.Printf("vault: failed to update api key last_used_at: %v", err)
+		}
+	}(apiKey)
+	return apiKey.UserID, true
+}
@@ -0,0 +1,102 @@
+package vault
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/service/diary"
+	"github.com/project-mikan/umi.mikan/backend/service/user"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPHandler(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "vault-webdav@example.com", "VaultWebDAVUser")
+	ctx := testutil.CreateAuthenticatedContext(userID)
+	diaryService := &diary.DiaryEntry{DB: db}
+
+	key, err := (&user.UserEntry{DB: db}).CreateApiKey(ctx, &g.CreateApiKeyRequest{Name: "vault"})
+	require.NoError(t, err)
+	_, err = diaryService.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{
+		Content: "5月1日",
+		Date:    &g.YMD{Year: 2024, Month: 5, Day: 1},
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle(Path, NewHTTPHandler(diaryService, db))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	remote, err := NewWebDAVRemote(server.URL+Path, "umi", key.ApiKey)
+	require.NoError(t, err)
+
+	const may1 = "2024/05/2024-05-01.md"
+	getNote := func(t *testing.T, p string) *Note {
+		t.Helper()
+		data, _, err := remote.Get(ctx, p)
+		require.NoError(t, err)
+		note, err := Parse(p, data)
+		require.NoError(t, err)
+		return note
+	}
+
+	t.Run("正常系: 日記をノートとして一覧・取得できる", func(t *testing.T) {
+		files, err := remote.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Equal(t, may1, files[0].Path)
+
+		note := getNote(t, may1)
+		assert.NotEmpty(t, note.ID)
+		assert.NotZero(t, note.UpdatedAt)
+		assert.Equal(t, "5月1日", note.Content)
+	})
+
+	t.Run("正常系: 書き込んだノートで日記を更新し、ないタグは作成する", func(t *testing.T) {
+		note := getNote(t, may1)
+		note.Content = "川沿いを散歩した"
+		note.Tags = []string{"散歩"}
+		require.NoError(t, remote.Put(ctx, may1, Render(note)))
+
+		updated := getNote(t, may1)
+		assert.Equal(t, note.ID, updated.ID)
+		assert.Equal(t, "川沿いを散歩した", updated.Content)
+		assert.Equal(t, []string{"散歩"}, updated.Tags)
+	})
+
+	t.Run("正常系: 古い内容からの書き込みは上書きせず競合コピーとして追加する", func(t *testing.T) {
+		stale := getNote(t, may1)
+		stale.UpdatedAt -= 60
+		stale.Content = "古い端末での編集"
+		require.NoError(t, remote.Put(ctx, may1, Render(stale)))
+
+		assert.Equal(t, "川沿いを散歩した", getNote(t, may1).Content)
+		conflict := getNote(t, "2024/05/2024-05-01-2.md")
+		assert.NotEqual(t, stale.ID, conflict.ID)
+		assert.True(t, strings.HasSuffix(conflict.Title, conflictTitleSuffix))
+		assert.Equal(t, "古い端末での編集", conflict.Content)
+	})
+
+	t.Run("正常系: 削除したノートの日記はゴミ箱に移動する", func(t *testing.T) {
+		require.NoError(t, remote.Delete(ctx, "2024/05/2024-05-01-2.md"))
+		files, err := remote.List(ctx)
+		require.NoError(t, err)
+		assert.Len(t, files, 1)
+	})
+
+	t.Run("異常系: 日記のノートでないパスには書き込めない", func(t *testing.T) {
+		assert.Error(t, remote.Put(ctx, "2024/05/memo.md", []byte("メモ")))
+	})
+
+	t.Run("異常系: APIキーが不正な場合は401", func(t *testing.T) {
+		wrong, err := NewWebDAVRemote(server.URL+Path, "umi", "umi_invalid")
+		require.NoError(t, err)
+		_, err = wrong.List(ctx)
+		assert.ErrorContains(t, err, "401")
+	})
+}
@@ -0,0 +1,374 @@
+package vault
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/service/diary"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// diaryTitleMaxLength は日記の題名の最大文字数（diaries.titleのVARCHAR(100)に合わせる）
+const diaryTitleMaxLength = 100
+
+// conflictTitleSuffix はサーバーで競合した書き込みを別の日記として保存するときに題名に付ける文字列
+const conflictTitleSuffix = "（競合したコピー）"
+
+var (
+	minDate = time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxDate = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// noteFile はサーバーの日記を変換したノート
+type noteFile struct {
+	path  string
+	diary *database.Diary
+	tags  []string
+	data  []byte
+}
+
+// store は1人のユーザーの日記をノートとして読み書きする
+// WebDAVのリクエストごとに作成し、同じリクエストの中では読み込んだ月の日記を使い回す
+type store struct {
+	diaryService *diary.DiaryEntry
+	db           *sql.DB
+	userID       uuid.UUID
+
+	entities map[string][]string // エンティティ名 -> 本文で探す名前と別名（初めて使うときに読み込む）
+	months   map[string][]*noteFile
+}
+
+func newStore(diaryService *diary.DiaryEntry, db *sql.DB, userID uuid.UUID) *store {
+	return &store{diaryService: diaryService, db: db, userID: userID, months: make(map[string][]*noteFile)}
+}
+
+// years は日記がある年を返す
+func (s *store) years(ctx context.Context) ([]int, error) {
+	counts, err := database.DiaryMonthCountsByUserIDAndDateRange(ctx, s.db, s.userID, minDate, maxDate, nil)
+	if err != nil {
+		return nil, err
+	}
+	years := make([]int, 0)
+	for _, c := range counts {
+		if len(years) == 0 || years[len(years)-1] != c.Year {
+			years = append(years, c.Year)
+		}
+	}
+	return years, nil
+}
+
+// monthsOf は年の中で日記がある月を返す
+func (s *store) monthsOf(ctx context.Context, year int) ([]int, error) {
+	from := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	counts, err := database.DiaryMonthCountsByUserIDAndDateRange(ctx, s.db, s.userID, from, from.AddDate(1, 0, -1), nil)
+	if err != nil {
+		return nil, err
+	}
+	months := make([]int, 0, len(counts))
+	for _, c := range counts {
+		months = append(months, c.Month)
+	}
+	return months, nil
+}
+
+// monthNotes は年月の日記をノートにして返す
+func (s *store) monthNotes(ctx context.Context, year, month int) ([]*noteFile, error) {
+	key := fmt.Sprintf("%04d/%02d", year, month)
+	if notes, ok := s.months[key]; ok {
+		return notes, nil
+	}
+
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	diaries, err := database.DiariesByUserIDAndDateRangeDays(ctx, s.db, s.userID.String(), from, from.AddDate(0, 1, -1))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, len(diaries))
+	for i, d := range diaries {
+		ids[i] = d.ID
+	}
+	tagsByDiary, err := database.TagsByDiaryIDs(ctx, s.db, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loadEntities(ctx); err != nil {
+		return nil, err
+	}
+
+	notes := make([]*noteFile, 0, len(diaries))
+	n := 0
+	for i, d := range diaries {
+		// 同じ日付の日記はDiariesByUserIDAndDateRangeDaysの並び順で番号を付ける
+		if i > 0 && diaries[i-1].Date.Equal(d.Date) {
+			n++
+		} else {
+			n = 0
+		}
+		tags := make([]string, 0, len(tagsByDiary[d.ID]))
+		for _, t := range tagsByDiary[d.ID] {
+			tags = append(tags, t.Name)
+		}
+		sort.Strings(tags)
+		note := &Note{
+			ID:        d.ID.String(),
+			Date:      d.Date,
+			UpdatedAt: d.UpdatedAt,
+			Title:     d.Title,
+			Time:      noteTime(d.EntryTime),
+			Tags:      tags,
+			Entities:  s.mentionedEntities(d.Content),
+			Content:   d.Content,
+		}
+		notes = append(notes, &noteFile{path: NotePath(d.Date, n), diary: d, tags: tags, data: Render(note)})
+	}
+	s.months[key] = notes
+	return notes, nil
+}
+
+// note はパスのノートを返す（ない場合はnil）
+func (s *store) note(ctx context.Context, p string) (*noteFile, error) {
+	date, _, ok := ParseNotePath(p)
+	if !ok {
+		return nil, nil
+	}
+	notes, err := s.monthNotes(ctx, date.Year(), int(date.Month()))
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range notes {
+		if n.path == p {
+			return n, nil
+		}
+	}
+	return nil, nil
+}
+
+// loadEntities はエンティティの名前と別名を読み込む
+func (s *store) loadEntities(ctx context.Context) error {
+	if s.entities != nil {
+		return nil
+	}
+	entities, err := database.ActiveEntitiesByUserID(ctx, s.db, s.userID)
+	if err != nil {
+		return err
+	}
+	aliases, err := database.AliasesByUserID(ctx, s.db, s.userID)
+	if err != nil {
+		return err
+	}
+	s.entities = make(map[string][]string, len(entities))
+	for _, e := range entities {
+		names := []string{e.Name}
+		for _, a := range aliases[e.ID.String()] {
+			names = append(names, a.Alias)
+		}
+		s.entities[e.Name] = names
+	}
+	return nil
+}
+
+// mentionedEntities は本文に名前か別名が含まれるエンティティの名前を名前順に返す
+func (s *store) mentionedEntities(content string) []string {
+	mentioned := make([]string, 0)
+	for name, names := range s.entities {
+		if slices.ContainsFunc(names, func(n string) bool { return n != "" && strings.Contains(content, n) }) {
+			mentioned = append(mentioned, name)
+		}
+	}
+	sort.Strings(mentioned)
+	return mentioned
+}
+
+// save はパスpに書き込まれたノートを日記に反映する
+//
+//   - front matterのidの日記（idがない場合はpの日記）を更新する。どちらもない場合は新しい日記として追加する
+//   - front matterのupdated_atがサーバーの日記と異なる場合は、他で更新された後の古い内容からの書き込みのため上書きせず、
+//     書き込まれた内容を同じ日付の別の日記（競合コピー）として追加する
+//   - 日付の変更とentitiesは反映しない（entitiesは本文から求める値のため）
+func (s *store) save(ctx context.Context, p string, data []byte) error {
+	note, err := Parse(p, data)
+	if err != nil {
+		return err
+	}
+	date, _, _ := ParseNotePath(p)
+	defer s.forget(date)
+
+	target, err := s.target(ctx, p, note)
+	if err != nil {
+		return err
+	}
+	tagIDs, err := s.tagIDs(ctx, note.Tags)
+	if err != nil {
+		return err
+	}
+	hm, err := noteHM(note.Time)
+	if err != nil {
+		return err
+	}
+
+	if target == nil {
+		return s.create(ctx, date, note.Title, hm, note.Content, tagIDs)
+	}
+	defer s.forget(target.diary.Date)
+	if target.matches(note) {
+		return nil
+	}
+	if note.UpdatedAt != target.diary.UpdatedAt {
+		return s.create(ctx, target.diary.Date, conflictTitle(note.Title), hm, note.Content, tagIDs)
+	}
+
+	version := target.diary.Version
+	_, err = s.diaryService.UpdateDiaryEntry(ctx, &g.UpdateDiaryEntryRequest{
+		Id:              target.diary.ID.String(),
+		Title:           &note.Title,
+		Content:         note.Content,
+		Time:            hm,
+		ClearTime:       hm == nil,
+		TagIds:          tagIDs,
+		SetTags:         true,
+		ExpectedVersion: &version,
+	})
+	if status.Code(err) == codes.Aborted {
+		// updated_atを確認した後に更新された
+		return s.create(ctx, target.diary.Date, conflictTitle(note.Title), hm, note.Content, tagIDs)
+	}
+	return err
+}
+
+// target は書き込まれたノートで更新する日記を返す（新しい日記として追加する場合はnil）
+func (s *store) target(ctx context.Context, p string, note *Note) (*noteFile, error) {
+	if note.ID == "" {
+		return s.note(ctx, p)
+	}
+	id, err := uuid.Parse(note.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid id %q", ErrInvalidNote, note.ID)
+	}
+	d, err := database.ActiveDiaryByID(ctx, s.db, id)
+	switch {
+	case errors.Is(err, sql.ErrNoRows) || (err == nil && d.UserID != s.userID):
+		// 削除された日記のノートは新しい日記として追加する（他のユーザーの日記のIDも存在しないものとして扱う）
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	notes, err := s.monthNotes(ctx, d.Date.Year(), int(d.Date.Month()))
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range notes {
+		if n.diary.ID == d.ID {
+			return n, nil
+		}
+	}
+	return nil, nil
+}
+
+// matches はノートの内容がすでにサーバーの日記と同じかどうかを返す
+// ダウンロードしたノートをそのまま書き戻すクライアントのために、変更のない書き込みでupdated_atを進めない
+func (n *noteFile) matches(note *Note) bool {
+	tags := slices.Clone(note.Tags)
+	sort.Strings(tags)
+	return n.diary.Content == note.Content && n.diary.Title == note.Title &&
+		noteTime(n.diary.EntryTime) == note.Time && slices.Equal(n.tags, tags)
+}
+
+// create は日記を同じ日付の別のエントリとして追加する
+func (s *store) create(ctx context.Context, date time.Time, title string, hm *g.HM, content string, tagIDs []string) error {
+	_, err := s.diaryService.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{
+		Content:    content,
+		Date:       &g.YMD{Year: uint32(date.Year()), Month: uint32(date.Month()), Day: uint32(date.Day())},
+		Title:      title,
+		Time:       hm,
+		Additional: true,
+		TagIds:     tagIDs,
+	})
+	return err
+}
+
+// remove はパスのノートの日記をゴミ箱に移動する
+func (s *store) remove(ctx context.Context, p string) (bool, error) {
+	n, err := s.note(ctx, p)
+	if err != nil || n == nil {
+		return false, err
+	}
+	defer s.forget(n.diary.Date)
+	_, err = s.diaryService.DeleteDiaryEntry(ctx, &g.DeleteDiaryEntryRequest{Id: n.diary.ID.String()})
+	return true, err
+}
+
+// tagIDs はタグ名をIDにする（ないタグは作成する）
+func (s *store) tagIDs(ctx context.Context, names []string) ([]string, error) {
+	tags, err := database.TagsByUserID(ctx, s.db, s.userID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]string, len(tags))
+	for _, t := range tags {
+		byName[t.Name] = t.ID.String()
+	}
+	ids := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if id, ok := byName[name]; ok {
+			ids = append(ids, id)
+			continue
+		}
+		resp, err := s.diaryService.CreateTag(ctx, &g.CreateTagRequest{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		byName[name] = resp.Tag.Id
+		ids = append(ids, resp.Tag.Id)
+	}
+	return ids, nil
+}
+
+// conflictTitle は競合コピーの題名を返す（日記の題名の最大文字数に収まるよう元の題名を切り詰める）
+func conflictTitle(title string) string {
+	runes := []rune(title)
+	if limit := diaryTitleMaxLength - utf8.RuneCountInString(conflictTitleSuffix); len(runes) > limit {
+		runes = runes[:limit]
+	}
+	return string(runes) + conflictTitleSuffix
+}
+
+// forget は書き込みの後に読み直すよう、日付の月の日記を使い回さないようにする
+func (s *store) forget(date time.Time) {
+	delete(s.months, date.Format("2006/01"))
+}
+
+// noteTime は時刻（0時からの経過分）をHH:MM形式で返す（未指定の場合は空文字）
+func noteTime(t sql.NullInt64) string {
+	if !t.Valid {
+		return ""
+	}
+	return fmt.Sprintf("%02d:%02d", t.Int64/60, t.Int64%60)
+}
+
+// noteHM はHH:MM形式の時刻をHMにする（空の場合はnil）
+func noteHM(s string) (*g.HM, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid time %q", ErrInvalidNote, s)
+	}
+	return &g.HM{Hour: uint32(t.Hour()), Minute: uint32(t.Minute())}, nil
+}
@@ -0,0 +1,344 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StateFileName は前回の同期の状態を保存するファイル名（同期するフォルダーの直下に置く）
+const StateFileName = ".umi-sync.json"
+
+// ErrNotFound はサーバーにノートがない場合のエラー
+var ErrNotFound = errors.New("note not found")
+
+// RemoteFile はサーバーのノートのパスと、内容が変わると変わる値（WebDAVのETag）
+type RemoteFile struct {
+	Path string
+	ETag string
+}
+
+// Remote は同期先のサーバー
+type Remote interface {
+	// List は日記のノートをすべて返す
+	List(ctx context.Context) ([]RemoteFile, error)
+	// Get はノートの内容とETagを返す（ない場合はErrNotFound）
+	Get(ctx context.Context, p string) ([]byte, string, error)
+	// Put はノートを書き込む
+	Put(ctx context.Context, p string, data []byte) error
+	// Delete はノートを削除する（ない場合はErrNotFound）
+	Delete(ctx context.Context, p string) error
+}
+
+// fileState は前回の同期が終わった時点のノートの状態
+type fileState struct {
+	Hash string `json:"hash"` // ローカルのファイルの内容のハッシュ
+	ETag string `json:"etag"` // サーバーのETag
+}
+
+type syncState struct {
+	Files map[string]fileState `json:"files"`
+}
+
+// Result は同期で行った操作の件数
+type Result struct {
+	Downloaded    int // サーバーからローカルに反映したノート
+	Uploaded      int // ローカルからサーバーに反映したノート
+	DeletedLocal  int // サーバーで削除されたためローカルで削除したノート
+	DeletedRemote int // ローカルで削除されたためサーバーで削除したノート
+	Conflicts     int // 競合コピーを作成したノート
+}
+
+// Syncer はローカルのフォルダーとサーバーのノートを双方向に同期する
+//
+// 前回の同期の時点のローカルのファイルのハッシュとサーバーのETag（日記のupdated_atで変わる）を
+// StateFileNameに保存し、それぞれと比べてどちら側で変更されたかを判断する。
+// 両側で変更されていた場合はどちらも上書きせず、ローカルの内容を競合コピー（ConflictPath）として残す。
+type Syncer struct {
+	Dir    string
+	Remote Remote
+	Now    func() time.Time
+}
+
+// NewSyncer はdirをremoteと同期するSyncerを返す
+func NewSyncer(dir string, remote Remote) *Syncer {
+	return &Syncer{Dir: dir, Remote: remote, Now: time.Now}
+}
+
+// Sync は1回同期する
+// 途中で失敗した場合も、それまでに反映したノートの状態は保存する
+func (s *Syncer) Sync(ctx context.Context) (Result, error) {
+	var result Result
+	state, err := s.loadState()
+	if err != nil {
+		return result, err
+	}
+	local, err := s.localHashes()
+	if err != nil {
+		return result, err
+	}
+	remoteFiles, err := s.Remote.List(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to list remote notes: %w", err)
+	}
+	remote := make(map[string]string, len(remoteFiles))
+	for _, f := range remoteFiles {
+		remote[f.Path] = f.ETag
+	}
+
+	paths := make(map[string]bool)
+	for p := range local {
+		paths[p] = true
+	}
+	for p := range remote {
+		paths[p] = true
+	}
+	for p := range state.Files {
+		paths[p] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	for _, p := range sorted {
+		if err := ctx.Err(); err != nil {
+			return result, s.finish(state, err)
+		}
+		if err := s.syncPath(ctx, state, &result, p, local, remote); err != nil {
+			return result, s.finish(state, fmt.Errorf("failed to sync %s: %w", p, err))
+		}
+	}
+	return result, s.finish(state, nil)
+}
+
+// finish は状態を保存し、syncErrがあればそれを優先して返す
+func (s *Syncer) finish(state *syncState, syncErr error) error {
+	if err := s.saveState(state); err != nil && syncErr == nil {
+		return err
+	}
+	return syncErr
+}
+
+// syncPath は1つのパスについて、ローカルとサーバーのどちらで変更されたかを判断して反映する
+func (s *Syncer) syncPath(ctx context.Context, state *syncState, result *Result, p string, local, remote map[string]string) error {
+	localHash, inLocal := local[p]
+	etag, inRemote := remote[p]
+	prev, known := state.Files[p]
+	localChanged := inLocal != known || (inLocal && localHash != prev.Hash)
+	remoteChanged := inRemote != known || (inRemote && etag != prev.ETag)
+
+	switch {
+	case !localChanged && !remoteChanged:
+		if !inLocal && !inRemote {
+			delete(state.Files, p)
+		}
+		return nil
+
+	case inLocal && inRemote && !localChanged:
+		result.Downloaded++
+		return s.download(ctx, state, p)
+
+	case inLocal && inRemote && !remoteChanged:
+		result.Uploaded++
+		return s.upload(ctx, state, p)
+
+	case inLocal && inRemote:
+		// 両側で変更された場合は、内容が同じでなければローカルの内容を競合コピーに退避してサーバーの内容にする
+		data, etag, err := s.Remote.Get(ctx, p)
+		if err != nil {
+			return err
+		}
+		if Hash(data) != localHash {
+			if err := s.moveToConflictCopy(p); err != nil {
+				return err
+			}
+			result.Conflicts++
+			result.Downloaded++
+		}
+		return s.writeLocal(state, p, data, etag)
+
+	case inLocal && !known:
+		result.Uploaded++
+		return s.upload(ctx, state, p)
+
+	case inLocal:
+		// サーバーで削除された。ローカルで変更していた場合は内容を失わないよう競合コピーとして残す
+		if localChanged {
+			if err := s.moveToConflictCopy(p); err != nil {
+				return err
+			}
+			result.Conflicts++
+		} else {
+			if err := os.Remove(s.localPath(p)); err != nil {
+				return err
+			}
+			result.DeletedLocal++
+		}
+		delete(state.Files, p)
+		return nil
+
+	case !inRemote:
+		// 両側で削除された
+		delete(state.Files, p)
+		return nil
+
+	case !known || remoteChanged:
+		// ローカルにない新しいノートか、ローカルで削除した後にサーバーで変更されたノート
+		result.Downloaded++
+		return s.download(ctx, state, p)
+
+	default:
+		// ローカルで削除された（サーバーではゴミ箱に移動する）
+		if err := s.Remote.Delete(ctx, p); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		result.DeletedRemote++
+		delete(state.Files, p)
+		return nil
+	}
+}
+
+// download はサーバーのノートをローカルに書き込む
+func (s *Syncer) download(ctx context.Context, state *syncState, p string) error {
+	data, etag, err := s.Remote.Get(ctx, p)
+	if err != nil {
+		return err
+	}
+	return s.writeLocal(state, p, data, etag)
+}
+
+// upload はローカルのノートをサーバーに書き込み、サーバーで更新されたfront matter（idやupdated_at）をローカルに反映する
+// 一覧を取得した後にサーバーで変更されていた場合、サーバーは書き込んだ内容を同じ日付の別の日記（競合コピー）として保存するため、
+// このパスにはサーバーの内容が書き込まれ、競合コピーは次の同期で取り込まれる
+func (s *Syncer) upload(ctx context.Context, state *syncState, p string) error {
+	data, err := os.ReadFile(s.localPath(p))
+	if err != nil {
+		return err
+	}
+	if err := s.Remote.Put(ctx, p, data); err != nil {
+		return err
+	}
+	updated, etag, err := s.Remote.Get(ctx, p)
+	if errors.Is(err, ErrNotFound) {
+		// 同じ日付の日記の数より大きい番号のパスに新しいノートを書いた場合は、サーバーでは別の番号のパスになる
+		// サーバーに保存済みのため、二重に作成しないようローカルのファイルは削除し、次の同期で新しいパスに取り込む
+		delete(state.Files, p)
+		return os.Remove(s.localPath(p))
+	}
+	if err != nil {
+		return err
+	}
+	return s.writeLocal(state, p, updated, etag)
+}
+
+// writeLocal はノートをローカルに書き込み、同期した状態として記録する
+func (s *Syncer) writeLocal(state *syncState, p string, data []byte, etag string) error {
+	if err := writeFileAtomic(s.localPath(p), data); err != nil {
+		return err
+	}
+	state.Files[p] = fileState{Hash: Hash(data), ETag: etag}
+	return nil
+}
+
+// moveToConflictCopy はローカルのノートを競合コピーのパスに移動する
+func (s *Syncer) moveToConflictCopy(p string) error {
+	return os.Rename(s.localPath(p), s.localPath(ConflictPath(p, s.Now())))
+}
+
+func (s *Syncer) localPath(p string) string {
+	return filepath.Join(s.Dir, filepath.FromSlash(p))
+}
+
+// localHashes はローカルのフォルダーにある日記のノートのハッシュをパスごとに返す
+// Obsidianの設定（.obsidian）などの隠しディレクトリと、日記のノートのパスでないファイルは対象にしない
+func (s *Syncer) localHashes() (map[string]string, error) {
+	hashes := make(map[string]string)
+	err := filepath.WalkDir(s.Dir, func(fp string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if fp != s.Dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(s.Dir, fp)
+		if err != nil {
+			return err
+		}
+		p := filepath.ToSlash(rel)
+		if _, _, ok := ParseNotePath(p); !ok || !d.Type().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(fp)
+		if err != nil {
+			return err
+		}
+		hashes[p] = Hash(data)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", s.Dir, err)
+	}
+	return hashes, nil
+}
+
+func (s *Syncer) loadState() (*syncState, error) {
+	state := &syncState{Files: make(map[string]fileState)}
+	data, err := os.ReadFile(filepath.Join(s.Dir, StateFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", StateFileName, err)
+	}
+	if state.Files == nil {
+		state.Files = make(map[string]fileState)
+	}
+	return state, nil
+}
+
+func (s *Syncer) saveState(state *syncState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(s.Dir, StateFileName), append(data, '\n'))
+}
+
+// writeFileAtomic は書き込みの途中で中断しても壊れたファイルを残さないよう、一時ファイルに書いてからリネームする
+func writeFileAtomic(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	if current, err := os.ReadFile(name); err == nil && bytes.Equal(current, data) {
+		return nil
+	}
+	f, err := os.CreateTemp(filepath.Dir(name), ".umi-sync-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
@@ -0,0 +1,168 @@
+package vault
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRemote はノートをメモリに保存するRemote（ETagは内容のハッシュ）
+type memoryRemote struct {
+	files map[string][]byte
+}
+
+func (m *memoryRemote) List(context.Context) ([]RemoteFile, error) {
+	files := make([]RemoteFile, 0, len(m.files))
+	for p, data := range m.files {
+		files = append(files, RemoteFile{Path: p, ETag: Hash(data)})
+	}
+	return files, nil
+}
+
+func (m *memoryRemote) Get(_ context.Context, p string) ([]byte, string, error) {
+	data, ok := m.files[p]
+	if !ok {
+		return nil, "", ErrNotFound
+	}
+	return data, Hash(data), nil
+}
+
+func (m *memoryRemote) Put(_ context.Context, p string, data []byte) error {
+	m.files[p] = data
+	return nil
+}
+
+func (m *memoryRemote) Delete(_ context.Context, p string) error {
+	if _, ok := m.files[p]; !ok {
+		return ErrNotFound
+	}
+	delete(m.files, p)
+	return nil
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	const (
+		may1 = "2024/05/2024-05-01.md"
+		may2 = "2024/05/2024-05-02.md"
+	)
+	now := time.Date(2024, 5, 3, 12, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) (*Syncer, *memoryRemote) {
+		t.Helper()
+		remote := &memoryRemote{files: map[string][]byte{
+			may1: []byte("5月1日"),
+			may2: []byte("5月2日"),
+		}}
+		s := NewSyncer(t.TempDir(), remote)
+		s.Now = func() time.Time { return now }
+		_, err := s.Sync(t.Context())
+		require.NoError(t, err)
+		return s, remote
+	}
+	readLocal := func(t *testing.T, s *Syncer, p string) string {
+		t.Helper()
+		data, err := os.ReadFile(s.localPath(p))
+		require.NoError(t, err)
+		return string(data)
+	}
+	writeLocal := func(t *testing.T, s *Syncer, p, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(s.localPath(p)), 0o755))
+		require.NoError(t, os.WriteFile(s.localPath(p), []byte(content), 0o644))
+	}
+
+	t.Run("正常系: 初回はサーバーのノートをすべて取り込み、2回目は何もしない", func(t *testing.T) {
+		s, _ := setup(t)
+		assert.Equal(t, "5月1日", readLocal(t, s, may1))
+		assert.Equal(t, "5月2日", readLocal(t, s, may2))
+
+		result, err := s.Sync(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, Result{}, result)
+	})
+
+	t.Run("正常系: 片側で変更したノートをもう片側に反映する", func(t *testing.T) {
+		s, remote := setup(t)
+		writeLocal(t, s, may1, "ローカルで編集")
+		remote.files[may2] = []byte("サーバーで編集")
+
+		result, err := s.Sync(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, Result{Uploaded: 1, Downloaded: 1}, result)
+		assert.Equal(t, "ローカルで編集", string(remote.files[may1]))
+		assert.Equal(t, "サーバーで編集", readLocal(t, s, may2))
+	})
+
+	t.Run("正常系: 両側で変更した場合はローカルの内容を競合コピーに残してサーバーの内容にする", func(t *testing.T) {
+		s, remote := setup(t)
+		writeLocal(t, s, may1, "ローカルで編集")
+		remote.files[may1] = []byte("サーバーで編集")
+
+		result, err := s.Sync(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Conflicts)
+		assert.Equal(t, "サーバーで編集", readLocal(t, s, may1))
+		assert.Equal(t, "サーバーで編集", string(remote.files[may1]))
+		assert.Equal(t, "ローカルで編集", readLocal(t, s, ConflictPath(may1, now)))
+
+		// 競合コピーは同期の対象にならない
+		result, err = s.Sync(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, Result{}, result)
+	})
+
+	t.Run("正常系: 片側で削除したノートをもう片側でも削除する", func(t *testing.T) {
+		s, remote := setup(t)
+		require.NoError(t, os.Remove(s.localPath(may1)))
+		delete(remote.files, may2)
+
+		result, err := s.Sync(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, Result{DeletedLocal: 1, DeletedRemote: 1}, result)
+		assert.Empty(t, remote.files)
+		assert.NoFileExists(t, s.localPath(may2))
+	})
+
+	t.Run("正常系: サーバーで削除されたノートをローカルで編集していた場合は競合コピーに残す", func(t *testing.T) {
+		s, remote := setup(t)
+		writeLocal(t, s, may1, "ローカルで編集")
+		delete(remote.files, may1)
+
+		result, err := s.Sync(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Conflicts)
+		assert.NoFileExists(t, s.localPath(may1))
+		assert.Equal(t, "ローカルで編集", readLocal(t, s, ConflictPath(may1, now)))
+	})
+
+	t.Run("正常系: ローカルで作成したノートを追加し、日記のノートでないファイルは無視する", func(t *testing.T) {
+		s, remote := setup(t)
+		writeLocal(t, s, "2024/05/2024-05-03.md", "新しい日記")
+		writeLocal(t, s, "2024/05/memo.md", "メモ")
+		writeLocal(t, s, ".obsidian/2024/05/2024-05-04.md", "設定")
+
+		result, err := s.Sync(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, Result{Uploaded: 1}, result)
+		assert.Equal(t, "新しい日記", string(remote.files["2024/05/2024-05-03.md"]))
+		assert.Len(t, remote.files, 3)
+	})
+
+	t.Run("正常系: 初回に両側に異なる内容がある場合は競合コピーに残す", func(t *testing.T) {
+		remote := &memoryRemote{files: map[string][]byte{may1: []byte("サーバー")}}
+		s := NewSyncer(t.TempDir(), remote)
+		s.Now = func() time.Time { return now }
+		writeLocal(t, s, may1, "ローカル")
+
+		result, err := s.Sync(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Conflicts)
+		assert.Equal(t, "サーバー", readLocal(t, s, may1))
+		assert.Equal(t, "ローカル", readLocal(t, s, ConflictPath(may1, now)))
+	})
+}
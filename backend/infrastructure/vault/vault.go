@@ -0,0 +1,220 @@
+// Package vault は日記をObsidianなどで扱えるMarkdownのフォルダー（YYYY/MM/YYYY-MM-DD.md）として読み書きする
+// 日記とMarkdownの相互変換、ローカルのフォルダーとの双方向同期（Syncer）、日記をWebDAVで公開するハンドラーを提供する
+package vault
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/diaryexport"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	dateLayout      = "2006-01-02"
+	updatedAtLayout = time.RFC3339
+	// conflictLayout は競合コピーのファイル名に付ける日時の形式
+	conflictLayout = "20060102-150405"
+
+	// ContentType はノートのMIMEタイプ
+	ContentType = "text/markdown; charset=utf-8"
+)
+
+// ErrInvalidNote はfront matterを解析できない場合のエラー
+var ErrInvalidNote = errors.New("invalid note")
+
+// notePathPattern は日記のノートのパス（YYYY/MM/YYYY-MM-DD.md、同じ日の2件目以降はYYYY-MM-DD-N.md）
+var notePathPattern = regexp.MustCompile(`^(\d{4})/(\d{2})/((\d{4})-(\d{2})-(\d{2}))(?:-([2-9]|[1-9]\d+))?\.md$`)
+
+// Note は1件の日記を表すMarkdownのノート
+type Note struct {
+	ID        string    // 日記ID（ローカルで新しく書いたノートは空）
+	Date      time.Time // 日付（UTCの0時）
+	UpdatedAt int64     // サーバーで最後に更新された日時（UNIX秒、新しいノートは0）
+	Title     string
+	Time      string   // 時刻（HH:MM、未指定の場合は空）
+	Tags      []string // タグ名
+	Entities  []string // 本文に名前か別名が含まれるエンティティ（読み取り専用）
+	Content   string
+}
+
+// frontMatter はノートのYAML front matter
+// Obsidianはプロパティを編集すると書式を変えることがあるため、解析はYAMLとして行う
+type frontMatter struct {
+	ID        string   `yaml:"id"`
+	Date      string   `yaml:"date"`
+	UpdatedAt string   `yaml:"updated_at"`
+	Title     string   `yaml:"title"`
+	Time      string   `yaml:"time"`
+	Tags      []string `yaml:"tags"`
+	Entities  []string `yaml:"entities"`
+}
+
+// NotePath は日付のn番目（0始まり）の日記のノートのパスを返す
+func NotePath(date time.Time, n int) string {
+	if n == 0 {
+		return diaryexport.DayPath(date)
+	}
+	return path.Join(date.Format("2006"), date.Format("01"), fmt.Sprintf("%s-%d.md", date.Format(dateLayout), n+1))
+}
+
+// ParseNotePath はノートのパスから日付と同じ日付の中での順番（0始まり）を返す
+// 日記のノートのパスでない場合（競合コピーやObsidianの他のノートなど）はokがfalse
+func ParseNotePath(p string) (date time.Time, n int, ok bool) {
+	m := notePathPattern.FindStringSubmatch(p)
+	if m == nil || m[1] != m[4] || m[2] != m[5] {
+		return time.Time{}, 0, false
+	}
+	date, err := time.Parse(dateLayout, m[3])
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	if m[7] != "" {
+		if n, err = strconv.Atoi(m[7]); err != nil {
+			return time.Time{}, 0, false
+		}
+		n--
+	}
+	return date, n, true
+}
+
+// IsDirPath は年（YYYY）か年月（YYYY/MM）のディレクトリのパスかどうかを返す
+func IsDirPath(p string) bool {
+	parts := strings.Split(p, "/")
+	if len(parts) > 2 {
+		return false
+	}
+	if _, err := time.Parse("2006", parts[0]); err != nil {
+		return false
+	}
+	if len(parts) == 2 {
+		if _, err := time.Parse("01", parts[1]); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ConflictPath は競合したノートを退避するパスを返す
+// 日記のノートのパスの形式に一致しないため、同期の対象にならない
+func ConflictPath(p string, now time.Time) string {
+	return strings.TrimSuffix(p, ".md") + " (conflict " + now.UTC().Format(conflictLayout) + ").md"
+}
+
+// Hash はノートの内容のハッシュを返す
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// yamlString はYAMLの文字列としてそのまま書ける形に引用する
+func yamlString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+func yamlList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = yamlString(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// Render はノートをfront matter付きのMarkdownにする
+func Render(n *Note) []byte {
+	var b bytes.Buffer
+	b.WriteString("---\n")
+	if n.ID != "" {
+		b.WriteString("id: " + n.ID + "\n")
+	}
+	b.WriteString("date: " + n.Date.Format(dateLayout) + "\n")
+	if n.UpdatedAt != 0 {
+		b.WriteString("updated_at: " + yamlString(time.Unix(n.UpdatedAt, 0).UTC().Format(updatedAtLayout)) + "\n")
+	}
+	if n.Title != "" {
+		b.WriteString("title: " + yamlString(n.Title) + "\n")
+	}
+	if n.Time != "" {
+		b.WriteString("time: " + yamlString(n.Time) + "\n")
+	}
+	b.WriteString("tags: " + yamlList(n.Tags) + "\n")
+	b.WriteString("entities: " + yamlList(n.Entities) + "\n")
+	b.WriteString("---\n\n")
+	b.WriteString(n.Content)
+	b.WriteString("\n")
+	return b.Bytes()
+}
+
+// Parse はMarkdownをノートにする
+// front matterがない場合はファイル全体を本文とし、日付はパスから決める
+func Parse(p string, data []byte) (*Note, error) {
+	date, _, ok := ParseNotePath(p)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a diary note path", ErrInvalidNote, p)
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	note := &Note{Date: date, Tags: []string{}, Entities: []string{}}
+
+	if rest, found := strings.CutPrefix(text, "---\n"); found {
+		raw, body, closed := strings.Cut(rest, "\n---\n")
+		if !closed {
+			if raw, closed = strings.CutSuffix(rest, "\n---"); !closed {
+				return nil, fmt.Errorf("%w: front matter is not closed", ErrInvalidNote)
+			}
+		}
+		var fm frontMatter
+		if err := yaml.Unmarshal([]byte(raw), &fm); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidNote, err)
+		}
+		if err := fm.apply(note); err != nil {
+			return nil, err
+		}
+		// Renderがfront matterの後に入れる空行を取り除く
+		text = strings.TrimPrefix(body, "\n")
+	}
+	note.Content = strings.TrimSuffix(text, "\n")
+	return note, nil
+}
+
+// apply はfront matterの値をノートに反映する
+func (fm *frontMatter) apply(n *Note) error {
+	n.ID = fm.ID
+	if fm.Date != "" {
+		date, err := time.Parse(dateLayout, fm.Date)
+		if err != nil {
+			return fmt.Errorf("%w: invalid date %q", ErrInvalidNote, fm.Date)
+		}
+		n.Date = date
+	}
+	if fm.UpdatedAt != "" {
+		updatedAt, err := time.Parse(updatedAtLayout, fm.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("%w: invalid updated_at %q", ErrInvalidNote, fm.UpdatedAt)
+		}
+		n.UpdatedAt = updatedAt.Unix()
+	}
+	if fm.Time != "" {
+		if _, err := time.Parse("15:04", fm.Time); err != nil {
+			return fmt.Errorf("%w: invalid time %q", ErrInvalidNote, fm.Time)
+		}
+	}
+	n.Title = fm.Title
+	n.Time = fm.Time
+	if fm.Tags != nil {
+		n.Tags = fm.Tags
+	}
+	if fm.Entities != nil {
+		n.Entities = fm.Entities
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package vault
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotePath(t *testing.T) {
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2024/05/2024-05-01.md", NotePath(date, 0))
+	assert.Equal(t, "2024/05/2024-05-01-2.md", NotePath(date, 1))
+
+	tests := []struct {
+		path string
+		n    int
+		ok   bool
+	}{
+		{"2024/05/2024-05-01.md", 0, true},
+		{"2024/05/2024-05-01-12.md", 11, true},
+		{"2024/05/2024-05-01-1.md", 0, false},
+		{"2024/06/2024-05-01.md", 0, false},
+		{"2024/05/2024-05-32.md", 0, false},
+		{"2024/05/2024-05-01 (conflict 20240501-120000).md", 0, false},
+		{"2024/05/summary.md", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, n, ok := ParseNotePath(tt.path)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, date, got)
+				assert.Equal(t, tt.n, n)
+			}
+		})
+	}
+}
+
+func TestRenderParse(t *testing.T) {
+	note := &Note{
+		ID:        "7b0c9b0e-6b0a-4b7e-9d55-1d6f0f0a7d11",
+		Date:      time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 5, 1, 21, 30, 0, 0, time.UTC).Unix(),
+		Title:     `散歩 "夕方"`,
+		Time:      "18:30",
+		Tags:      []string{"散歩", "a, b"},
+		Entities:  []string{"太郎"},
+		Content:   "---\n川沿いを歩いた\n",
+	}
+
+	t.Run("正常系: Renderした内容をParseすると同じノートになる", func(t *testing.T) {
+		got, err := Parse("2024/05/2024-05-01.md", Render(note))
+		require.NoError(t, err)
+		assert.Equal(t, note, got)
+	})
+
+	t.Run("正常系: Obsidianが書き換えたfront matterも読める", func(t *testing.T) {
+		data := "---\r\nid: " + note.ID + "\r\ndate: 2024-05-01\r\ntags:\r\n  - 散歩\r\nupdated_at: 2024-05-01T21:30:00Z\r\n---\r\n\r\n本文\r\n"
+		got, err := Parse("2024/05/2024-05-01.md", []byte(data))
+		require.NoError(t, err)
+		assert.Equal(t, note.ID, got.ID)
+		assert.Equal(t, note.UpdatedAt, got.UpdatedAt)
+		assert.Equal(t, []string{"散歩"}, got.Tags)
+		assert.Equal(t, "本文", got.Content)
+	})
+
+	t.Run("正常系: front matterがない場合は全体を本文とし、日付はパスから決める", func(t *testing.T) {
+		got, err := Parse("2024/05/2024-05-01-2.md", []byte("新しい日記\n"))
+		require.NoError(t, err)
+		assert.Empty(t, got.ID)
+		assert.Equal(t, note.Date, got.Date)
+		assert.Equal(t, "新しい日記", got.Content)
+	})
+
+	t.Run("異常系: 不正なfront matterはErrInvalidNote", func(t *testing.T) {
+		for _, data := range []string{
+			"---\nid: x\n",
+			"---\nupdated_at: yesterday\n---\n",
+			"---\ntime: 25:00\n---\n",
+			"---\ntags: [\n---\n",
+		} {
+			_, err := Parse("2024/05/2024-05-01.md", []byte(data))
+			assert.True(t, errors.Is(err, ErrInvalidNote), "%q: %v", data, err)
+		}
+	})
+}
+
+func TestConflictTitle(t *testing.T) {
+	assert.Equal(t, "散歩"+conflictTitleSuffix, conflictTitle("散歩"))
+	long := conflictTitle(string(make([]rune, diaryTitleMaxLength)))
+	assert.Equal(t, diaryTitleMaxLength, len([]rune(long)))
+}
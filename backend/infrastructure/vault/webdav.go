@@ -0,0 +1,274 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/webdav"
+)
+
+// diaryFS はユーザーの日記をYYYY/MM/YYYY-MM-DD.mdのノートとして公開するwebdav.FileSystem
+// ディレクトリは日記の日付から決まるため作成・削除できず、ノートの削除は日記をゴミ箱に移動する
+type diaryFS struct {
+	store *store
+}
+
+var _ webdav.FileSystem = (*diaryFS)(nil)
+
+// cleanPath はWebDAVのパス（"/2024/05/2024-05-01.md"）をスラッシュ区切りの相対パスにする（ルートは空文字）
+func cleanPath(name string) string {
+	return strings.Trim(name, "/")
+}
+
+func isWrite(flag int) bool {
+	return flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) != 0
+}
+
+// Mkdir は年と年月のディレクトリは常に存在するためos.ErrExistを返す（WebDAVクライアントには405を返す）
+func (fsys *diaryFS) Mkdir(_ context.Context, name string, _ os.FileMode) error {
+	p := cleanPath(name)
+	if p == "" || IsDirPath(p) {
+		return os.ErrExist
+	}
+	return os.ErrPermission
+}
+
+func (fsys *diaryFS) OpenFile(ctx context.Context, name string, flag int, _ os.FileMode) (webdav.File, error) {
+	p := cleanPath(name)
+	if p == "" || IsDirPath(p) {
+		if isWrite(flag) {
+			return nil, os.ErrPermission
+		}
+		return &dirFile{ctx: ctx, fsys: fsys, path: p}, nil
+	}
+	if _, _, ok := ParseNotePath(p); !ok {
+		if isWrite(flag) {
+			return nil, os.ErrPermission
+		}
+		return nil, os.ErrNotExist
+	}
+
+	if isWrite(flag) {
+		return &noteWriter{ctx: ctx, fsys: fsys, path: p}, nil
+	}
+	n, err := fsys.store.note(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if n == nil {
+		return nil, os.ErrNotExist
+	}
+	return &noteReader{Reader: bytes.NewReader(n.data), info: n.info()}, nil
+}
+
+func (fsys *diaryFS) RemoveAll(ctx context.Context, name string) error {
+	p := cleanPath(name)
+	if _, _, ok := ParseNotePath(p); !ok {
+		return os.ErrPermission
+	}
+	removed, err := fsys.store.remove(ctx, p)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return os.ErrNotExist
+	}
+	return nil
+}
+
+// Rename はノートのパスが日記の日付と順番から決まるため許可しない
+func (fsys *diaryFS) Rename(context.Context, string, string) error {
+	return os.ErrPermission
+}
+
+func (fsys *diaryFS) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	p := cleanPath(name)
+	if p == "" || IsDirPath(p) {
+		return dirInfo(p), nil
+	}
+	n, err := fsys.store.note(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if n == nil {
+		return nil, os.ErrNotExist
+	}
+	return n.info(), nil
+}
+
+// children はディレクトリ直下のエントリを返す
+func (fsys *diaryFS) children(ctx context.Context, p string) ([]os.FileInfo, error) {
+	if p == "" {
+		years, err := fsys.store.years(ctx)
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]os.FileInfo, len(years))
+		for i, y := range years {
+			infos[i] = dirInfo(fmt.Sprintf("%04d", y))
+		}
+		return infos, nil
+	}
+
+	year, month, isMonth := strings.Cut(p, "/")
+	y, _ := strconv.Atoi(year)
+	if !isMonth {
+		months, err := fsys.store.monthsOf(ctx, y)
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]os.FileInfo, len(months))
+		for i, m := range months {
+			infos[i] = dirInfo(fmt.Sprintf("%s/%02d", year, m))
+		}
+		return infos, nil
+	}
+
+	m, _ := strconv.Atoi(month)
+	notes, err := fsys.store.monthNotes(ctx, y, m)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]os.FileInfo, len(notes))
+	for i, n := range notes {
+		infos[i] = n.info()
+	}
+	return infos, nil
+}
+
+// fileInfo はノートとディレクトリのos.FileInfo
+// ノートのETagは内容のハッシュとし、同期するクライアントが内容を取得せずに変更を検出できるようにする
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+	etag    string
+}
+
+var (
+	_ webdav.ETager       = fileInfo{}
+	_ webdav.ContentTyper = fileInfo{}
+)
+
+func dirInfo(p string) fileInfo {
+	return fileInfo{name: p[strings.LastIndex(p, "/")+1:], dir: true}
+}
+
+func (n *noteFile) info() fileInfo {
+	return fileInfo{
+		name:    n.path[strings.LastIndex(n.path, "/")+1:],
+		size:    int64(len(n.data)),
+		modTime: time.Unix(n.diary.UpdatedAt, 0),
+		etag:    `"` + Hash(n.data) + `"`,
+	}
+}
+
+func (i fileInfo) Name() string       { return i.name }
+func (i fileInfo) Size() int64        { return i.size }
+func (i fileInfo) ModTime() time.Time { return i.modTime }
+func (i fileInfo) IsDir() bool        { return i.dir }
+func (i fileInfo) Sys() any           { return nil }
+
+func (i fileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o755
+	}
+	return 0o644
+}
+
+// ETag はノートの内容のハッシュを返す（ディレクトリはwebdav.ErrNotImplementedで既定の値にする）
+func (i fileInfo) ETag(context.Context) (string, error) {
+	if i.etag == "" {
+		return "", webdav.ErrNotImplemented
+	}
+	return i.etag, nil
+}
+
+// ContentType は内容を読まずにMIMEタイプを返す
+func (i fileInfo) ContentType(context.Context) (string, error) {
+	if i.dir {
+		return "", webdav.ErrNotImplemented
+	}
+	return ContentType, nil
+}
+
+// dirFile は年・年月のディレクトリ
+type dirFile struct {
+	ctx  context.Context
+	fsys *diaryFS
+	path string
+
+	entries []os.FileInfo
+	read    bool
+}
+
+func (d *dirFile) Close() error                   { return nil }
+func (d *dirFile) Read([]byte) (int, error)       { return 0, os.ErrInvalid }
+func (d *dirFile) Seek(int64, int) (int64, error) { return 0, os.ErrInvalid }
+func (d *dirFile) Write([]byte) (int, error)      { return 0, os.ErrPermission }
+func (d *dirFile) Stat() (os.FileInfo, error)     { return dirInfo(d.path), nil }
+func (d *dirFile) Readdir(count int) ([]os.FileInfo, error) {
+	if !d.read {
+		entries, err := d.fsys.children(d.ctx, d.path)
+		if err != nil {
+			return nil, err
+		}
+		d.entries, d.read = entries, true
+	}
+	if count <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	n := min(count, len(d.entries))
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
+
+// noteReader は読み取り用に開いたノート
+type noteReader struct {
+	*bytes.Reader
+	info fileInfo
+}
+
+func (r *noteReader) Close() error                       { return nil }
+func (r *noteReader) Write([]byte) (int, error)          { return 0, os.ErrPermission }
+func (r *noteReader) Stat() (os.FileInfo, error)         { return r.info, nil }
+func (r *noteReader) Readdir(int) ([]os.FileInfo, error) { return nil, os.ErrInvalid }
+
+// noteWriter は書き込み用に開いたノート
+// 書き込まれた内容はCloseで日記に反映する（webdav.FileのCloseはcontextを受け取らないため、開いたときのものを使う）
+// webdav.HandlerはPUTの本文の受信に失敗してもCloseを呼ぶため、NewHTTPHandlerで本文をすべて受信してから渡す
+type noteWriter struct {
+	ctx  context.Context
+	fsys *diaryFS
+	path string
+	buf  bytes.Buffer
+}
+
+func (w *noteWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *noteWriter) Close() error {
+	return w.fsys.store.save(w.ctx, w.path, w.buf.Bytes())
+}
+
+func (w *noteWriter) Read([]byte) (int, error)           { return 0, os.ErrInvalid }
+func (w *noteWriter) Seek(int64, int) (int64, error)     { return 0, os.ErrInvalid }
+func (w *noteWriter) Readdir(int) ([]os.FileInfo, error) { return nil, os.ErrInvalid }
+func (w *noteWriter) Stat() (os.FileInfo, error) {
+	return fileInfo{name: w.path[strings.LastIndex(w.path, "/")+1:], size: int64(w.buf.Len()), modTime: time.Now()}, nil
+}
@@ -0,0 +1,161 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// authenticatorDataのフラグ（WebAuthn Level 2 6.1）
+const (
+	flagUserPresent      = 0x01
+	flagUserVerified     = 0x04
+	flagBackupEligible   = 0x08
+	flagBackupState      = 0x10
+	flagAttestedCredData = 0x40
+)
+
+// COSEのアルゴリズム（RFC 9053）
+const (
+	AlgES256 = -7
+	AlgEdDSA = -8
+	AlgRS256 = -257
+)
+
+// supportedAlgorithms は受け付ける公開鍵のアルゴリズム（優先する順）
+var supportedAlgorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+// authenticatorData は認証器が署名するデータ
+type authenticatorData struct {
+	raw          []byte
+	rpIDHash     []byte
+	flags        byte
+	signCount    uint32
+	credentialID []byte // 登録の場合のみ
+	publicKey    []byte // 登録の場合のみ（COSE鍵のCBOR）
+}
+
+func (d *authenticatorData) userPresent() bool    { return d.flags&flagUserPresent != 0 }
+func (d *authenticatorData) userVerified() bool   { return d.flags&flagUserVerified != 0 }
+func (d *authenticatorData) backupEligible() bool { return d.flags&flagBackupEligible != 0 }
+func (d *authenticatorData) backupState() bool    { return d.flags&flagBackupState != 0 }
+
+// parseAuthenticatorData はauthenticatorDataを解析する。拡張のデータは使わないため読み飛ばす
+func parseAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	if len(raw) < 37 {
+		return nil, errors.New("authenticator data is too short")
+	}
+	d := &authenticatorData{
+		raw:       raw,
+		rpIDHash:  raw[:32],
+		flags:     raw[32],
+		signCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+	if d.flags&flagAttestedCredData == 0 {
+		return d, nil
+	}
+
+	// attestedCredentialData: AAGUID(16) + 長さ(2) + credentialId + COSE鍵
+	rest := raw[37:]
+	if len(rest) < 18 {
+		return nil, errors.New("attested credential data is too short")
+	}
+	idLen := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if idLen == 0 || len(rest) < idLen {
+		return nil, errors.New("invalid credential id length")
+	}
+	d.credentialID = rest[:idLen]
+	rest = rest[idLen:]
+	_, after, err := decodeCBOR(rest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential public key: %w", err)
+	}
+	d.publicKey = rest[:len(rest)-len(after)]
+	return d, nil
+}
+
+// parsePublicKey はCOSE鍵を公開鍵とアルゴリズムに変換する
+func parsePublicKey(coseKey []byte) (crypto.PublicKey, int64, error) {
+	v, rest, err := decodeCBOR(coseKey)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(rest) != 0 {
+		return nil, 0, errors.New("trailing data after cose key")
+	}
+	m, ok := v.(map[any]any)
+	if !ok {
+		return nil, 0, errors.New("cose key is not a map")
+	}
+	kty, _ := m[int64(1)].(int64)
+	alg, _ := m[int64(3)].(int64)
+
+	switch {
+	case kty == 2 && alg == AlgES256: // EC2
+		crv, _ := m[int64(-1)].(int64)
+		x, _ := m[int64(-2)].([]byte)
+		y, _ := m[int64(-3)].([]byte)
+		if crv != 1 || len(x) != 32 || len(y) != 32 {
+			return nil, 0, errors.New("invalid ec2 key")
+		}
+		// 非圧縮形式（0x04 || x || y）に変換して曲線上の点であることも確認する
+		point := append(append([]byte{0x04}, x...), y...)
+		key, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid ec2 key: %w", err)
+		}
+		return key, alg, nil
+	case kty == 1 && alg == AlgEdDSA: // OKP
+		crv, _ := m[int64(-1)].(int64)
+		x, _ := m[int64(-2)].([]byte)
+		if crv != 6 || len(x) != ed25519.PublicKeySize {
+			return nil, 0, errors.New("invalid okp key")
+		}
+		return ed25519.PublicKey(x), alg, nil
+	case kty == 3 && alg == AlgRS256: // RSA
+		n, _ := m[int64(-1)].([]byte)
+		e, _ := m[int64(-2)].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, 0, errors.New("invalid rsa key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, alg, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported cose key (kty=%d, alg=%d)", kty, alg)
+	}
+}
+
+// verifySignature はCOSE鍵でauthenticatorData || SHA-256(clientDataJSON) の署名を検証する
+func verifySignature(coseKey, authData, clientDataJSON, signature []byte) error {
+	key, alg, err := parsePublicKey(coseKey)
+	if err != nil {
+		return err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte(nil), authData...), clientDataHash[:]...)
+
+	switch alg {
+	case AlgES256:
+		digest := sha256.Sum256(signed)
+		if !ecdsa.VerifyASN1(key.(*ecdsa.PublicKey), digest[:], signature) {
+			return errors.New("invalid signature")
+		}
+	case AlgEdDSA:
+		if !ed25519.Verify(key.(ed25519.PublicKey), signed, signature) {
+			return errors.New("invalid signature")
+		}
+	case AlgRS256:
+		digest := sha256.Sum256(signed)
+		if err := rsa.VerifyPKCS1v15(key.(*rsa.PublicKey), crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("invalid signature")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package webauthn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/rueidis"
+)
+
+// challengeTTL は登録・認証を開始してから完了するまでの猶予
+const challengeTTL = 5 * time.Minute
+
+// challengeKeyPrefix はRedis上でチャレンジを保存する際のキー接頭辞
+const challengeKeyPrefix = "webauthn_challenge:"
+
+// Ceremony はチャレンジを発行した操作の種類
+type Ceremony string
+
+const (
+	CeremonyRegistration Ceremony = "registration" // パスキーの登録
+	CeremonyLogin        Ceremony = "login"        // パスキーでのログイン
+	CeremonyMFA          Ceremony = "mfa"          // 2段階目の認証
+)
+
+// Session はチャレンジに紐付けて保存する値
+type Session struct {
+	Ceremony Ceremony `json:"ceremony"`
+	UserID   string   `json:"user_id,omitempty"` // 登録・2段階目の認証の場合は対象のユーザーのID
+}
+
+// ChallengeStore はチャレンジをキーにセッションをRedisに保存する
+type ChallengeStore struct {
+	Redis rueidis.Client
+}
+
+// Create はチャレンジを生成してセッションを保存する
+func (s *ChallengeStore) Create(ctx context.Context, session Session) (string, error) {
+	challenge, err := NewChallenge()
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(session)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal webauthn session: %w", err)
+	}
+	cmd := s.Redis.B().Set().Key(challengeKeyPrefix + challenge).Value(string(payload)).Ex(challengeTTL).Build()
+	if err := s.Redis.Do(ctx, cmd).Error(); err != nil {
+		return "", fmt.Errorf("failed to store webauthn challenge: %w", err)
+	}
+	return challenge, nil
+}
+
+// Consume はセッションを取得と同時に削除する（同じチャレンジを2回使えないようにする）。
+// 存在しない・期限切れの場合はokがfalseになる
+func (s *ChallengeStore) Consume(ctx context.Context, challenge string) (Session, bool, error) {
+	payload, err := s.Redis.Do(ctx, s.Redis.B().Getdel().Key(challengeKeyPrefix+challenge).Build()).ToString()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return Session{}, false, nil
+		}
+		return Session{}, false, fmt.Errorf("failed to get webauthn challenge: %w", err)
+	}
+	var session Session
+	if err := json.Unmarshal([]byte(payload), &session); err != nil {
+		return Session{}, false, fmt.Errorf("failed to unmarshal webauthn session: %w", err)
+	}
+	return session, true, nil
+}
@@ -0,0 +1,350 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ceremonyTimeout はブラウザ・OSに渡す登録・認証のタイムアウト（チャレンジの有効期限と同じ）
+const ceremonyTimeout = challengeTTL
+
+// Config はRelying Party（このサービス）の設定
+type Config struct {
+	RPID    string   // パスキーを紐付けるドメイン（例: umi.mikan.example）
+	RPName  string   // 登録時に認証器に表示する名前
+	Origins []string // clientDataJSONのoriginとして受け付ける値（例: https://umi.mikan.example）
+}
+
+// RelyingParty は登録・認証のオプションの作成とレスポンスの検証を行う
+type RelyingParty struct {
+	config Config
+}
+
+// NewRelyingParty はRelyingPartyを作成する
+func NewRelyingParty(config Config) *RelyingParty {
+	return &RelyingParty{config: config}
+}
+
+// User は登録するユーザー。IDは認証器に保存され、パスキーでのログイン時にuserHandleとして返る
+type User struct {
+	ID          []byte
+	Name        string
+	DisplayName string
+}
+
+// CredentialDescriptor は登録済みのパスキー（excludeCredentials・allowCredentials に使う）
+type CredentialDescriptor struct {
+	ID         []byte
+	Transports []string
+}
+
+// Credential は登録を検証したパスキー
+type Credential struct {
+	ID             []byte
+	PublicKey      []byte // COSE鍵のCBOR
+	SignCount      uint32
+	Transports     []string
+	BackupEligible bool // 複数の端末に同期できるパスキー
+}
+
+// Registration は登録のレスポンス（PublicKeyCredential.toJSON()）を解析したもの
+type Registration struct {
+	clientData        clientData
+	rawClientData     []byte
+	attestationObject []byte
+	transports        []string
+}
+
+// Assertion は認証のレスポンス（PublicKeyCredential.toJSON()）を解析したもの
+type Assertion struct {
+	CredentialID  []byte
+	UserHandle    []byte // 認証器に保存されたユーザーID（パスキーでは必ず返る）
+	clientData    clientData
+	rawClientData []byte
+	authData      []byte
+	signature     []byte
+}
+
+// Challenge はレスポンスに含まれるチャレンジを返す
+func (r *Registration) Challenge() string { return r.clientData.Challenge }
+
+// Challenge はレスポンスに含まれるチャレンジを返す
+func (a *Assertion) Challenge() string { return a.clientData.Challenge }
+
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+type credentialJSON struct {
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string   `json:"clientDataJSON"`
+		AttestationObject string   `json:"attestationObject"`
+		Transports        []string `json:"transports"`
+		AuthenticatorData string   `json:"authenticatorData"`
+		Signature         string   `json:"signature"`
+		UserHandle        string   `json:"userHandle"`
+	} `json:"response"`
+}
+
+type descriptorJSON struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// NewChallenge はランダムなチャレンジ（base64url）を生成する
+func NewChallenge() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webauthn challenge: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// CreationOptions は navigator.credentials.create に渡すオプション（PublicKeyCredentialCreationOptionsJSON）を返す。
+// 端末にユーザーを保存するパスキー（discoverable credential）として、生体認証・PINでの確認を求める
+func (rp *RelyingParty) CreationOptions(user User, challenge string, exclude []CredentialDescriptor) ([]byte, error) {
+	params := make([]map[string]any, 0, len(supportedAlgorithms))
+	for _, alg := range supportedAlgorithms {
+		params = append(params, map[string]any{"type": "public-key", "alg": alg})
+	}
+	return json.Marshal(map[string]any{
+		"challenge": challenge,
+		"rp":        map[string]string{"id": rp.config.RPID, "name": rp.config.RPName},
+		"user": map[string]string{
+			"id":          base64.RawURLEncoding.EncodeToString(user.ID),
+			"name":        user.Name,
+			"displayName": user.DisplayName,
+		},
+		"pubKeyCredParams":   params,
+		"timeout":            ceremonyTimeout.Milliseconds(),
+		"attestation":        "none",
+		"excludeCredentials": descriptors(exclude),
+		"authenticatorSelection": map[string]any{
+			"residentKey":        "required",
+			"requireResidentKey": true,
+			"userVerification":   "required",
+		},
+	})
+}
+
+// RequestOptions は navigator.credentials.get に渡すオプション（PublicKeyCredentialRequestOptionsJSON）を返す。
+// allowが空の場合は端末に保存されたパスキーから選ばせる
+func (rp *RelyingParty) RequestOptions(challenge string, allow []CredentialDescriptor, requireUserVerification bool) ([]byte, error) {
+	userVerification := "preferred"
+	if requireUserVerification {
+		userVerification = "required"
+	}
+	return json.Marshal(map[string]any{
+		"challenge":        challenge,
+		"rpId":             rp.config.RPID,
+		"timeout":          ceremonyTimeout.Milliseconds(),
+		"userVerification": userVerification,
+		"allowCredentials": descriptors(allow),
+	})
+}
+
+func descriptors(creds []CredentialDescriptor) []descriptorJSON {
+	out := make([]descriptorJSON, 0, len(creds))
+	for _, c := range creds {
+		out = append(out, descriptorJSON{Type: "public-key", ID: base64.RawURLEncoding.EncodeToString(c.ID), Transports: c.Transports})
+	}
+	return out
+}
+
+// ParseRegistration は登録のレスポンスを解析する
+func ParseRegistration(data []byte) (*Registration, error) {
+	var c credentialJSON
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid credential json: %w", err)
+	}
+	if c.Type != "public-key" {
+		return nil, errors.New("invalid credential type")
+	}
+	rawClientData, cd, err := parseClientData(c.Response.ClientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	attestationObject, err := decodeBase64URL(c.Response.AttestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestationObject: %w", err)
+	}
+	return &Registration{clientData: cd, rawClientData: rawClientData, attestationObject: attestationObject, transports: c.Response.Transports}, nil
+}
+
+// ParseAssertion は認証のレスポンスを解析する
+func ParseAssertion(data []byte) (*Assertion, error) {
+	var c credentialJSON
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid credential json: %w", err)
+	}
+	if c.Type != "public-key" {
+		return nil, errors.New("invalid credential type")
+	}
+	rawID := c.RawID
+	if rawID == "" {
+		rawID = c.ID
+	}
+	credentialID, err := decodeBase64URL(rawID)
+	if err != nil || len(credentialID) == 0 {
+		return nil, errors.New("invalid credential id")
+	}
+	rawClientData, cd, err := parseClientData(c.Response.ClientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	authData, err := decodeBase64URL(c.Response.AuthenticatorData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authenticatorData: %w", err)
+	}
+	signature, err := decodeBase64URL(c.Response.Signature)
+	if err != nil || len(signature) == 0 {
+		return nil, errors.New("invalid signature")
+	}
+	userHandle, err := decodeBase64URL(c.Response.UserHandle)
+	if err != nil {
+		return nil, fmt.Errorf("invalid userHandle: %w", err)
+	}
+	return &Assertion{
+		CredentialID:  credentialID,
+		UserHandle:    userHandle,
+		clientData:    cd,
+		rawClientData: rawClientData,
+		authData:      authData,
+		signature:     signature,
+	}, nil
+}
+
+// VerifyRegistration は登録のレスポンスを検証する。
+// attestationは "none" を要求するため、attStmt（認証器の製造元の証明）は検証しない
+func (rp *RelyingParty) VerifyRegistration(r *Registration, challenge string) (*Credential, error) {
+	if err := rp.verifyClientData(r.clientData, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+	v, rest, err := decodeCBOR(r.attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestationObject: %w", err)
+	}
+	obj, ok := v.(map[any]any)
+	if !ok || len(rest) != 0 {
+		return nil, errors.New("invalid attestationObject")
+	}
+	rawAuthData, ok := obj["authData"].([]byte)
+	if !ok {
+		return nil, errors.New("attestationObject has no authData")
+	}
+	authData, err := rp.verifyAuthenticatorData(rawAuthData, true)
+	if err != nil {
+		return nil, err
+	}
+	if authData.credentialID == nil {
+		return nil, errors.New("authenticator data has no attested credential")
+	}
+	if _, _, err := parsePublicKey(authData.publicKey); err != nil {
+		return nil, err
+	}
+	return &Credential{
+		ID:             bytes.Clone(authData.credentialID),
+		PublicKey:      bytes.Clone(authData.publicKey),
+		SignCount:      authData.signCount,
+		Transports:     r.transports,
+		BackupEligible: authData.backupEligible(),
+	}, nil
+}
+
+// VerifyAssertion は認証のレスポンスを登録済みの公開鍵で検証し、新しい署名カウンターを返す。
+// カウンターが進んでいない場合は複製された認証器の可能性があるため拒否する（同期するパスキーは常に0を返す）
+func (rp *RelyingParty) VerifyAssertion(a *Assertion, challenge string, publicKey []byte, signCount uint32, requireUserVerification bool) (uint32, error) {
+	if err := rp.verifyClientData(a.clientData, "webauthn.get", challenge); err != nil {
+		return 0, err
+	}
+	authData, err := rp.verifyAuthenticatorData(a.authData, requireUserVerification)
+	if err != nil {
+		return 0, err
+	}
+	if err := verifySignature(publicKey, a.authData, a.rawClientData, a.signature); err != nil {
+		return 0, err
+	}
+	if (authData.signCount != 0 || signCount != 0) && authData.signCount <= signCount {
+		return 0, errors.New("sign count did not increase")
+	}
+	return authData.signCount, nil
+}
+
+func (rp *RelyingParty) verifyClientData(cd clientData, typ, challenge string) error {
+	if cd.Type != typ {
+		return fmt.Errorf("unexpected client data type %q", cd.Type)
+	}
+	if challenge == "" || subtle.ConstantTimeCompare([]byte(cd.Challenge), []byte(challenge)) != 1 {
+		return errors.New("challenge mismatch")
+	}
+	if !slices.Contains(rp.config.Origins, cd.Origin) {
+		return fmt.Errorf("unexpected origin %q", cd.Origin)
+	}
+	if cd.CrossOrigin {
+		return errors.New("cross-origin requests are not allowed")
+	}
+	return nil
+}
+
+func (rp *RelyingParty) verifyAuthenticatorData(raw []byte, requireUserVerification bool) (*authenticatorData, error) {
+	d, err := parseAuthenticatorData(raw)
+	if err != nil {
+		return nil, err
+	}
+	rpIDHash := sha256.Sum256([]byte(rp.config.RPID))
+	if subtle.ConstantTimeCompare(d.rpIDHash, rpIDHash[:]) != 1 {
+		return nil, errors.New("rp id hash mismatch")
+	}
+	if !d.userPresent() {
+		return nil, errors.New("user presence is required")
+	}
+	if requireUserVerification && !d.userVerified() {
+		return nil, errors.New("user verification is required")
+	}
+	if d.backupState() && !d.backupEligible() {
+		return nil, errors.New("invalid backup flags")
+	}
+	return d, nil
+}
+
+func parseClientData(encoded string) ([]byte, clientData, error) {
+	raw, err := decodeBase64URL(encoded)
+	if err != nil {
+		return nil, clientData{}, fmt.Errorf("invalid clientDataJSON: %w", err)
+	}
+	var cd clientData
+	if err := json.Unmarshal(raw, &cd); err != nil {
+		return nil, clientData{}, fmt.Errorf("invalid clientDataJSON: %w", err)
+	}
+	return raw, cd, nil
+}
+
+// decodeBase64URL はパディングの有無に関わらずbase64urlをデコードする
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// EncodeID はcredential IDなどのバイト列をDBに保存する文字列（base64url）にする
+func EncodeID(id []byte) string {
+	return base64.RawURLEncoding.EncodeToString(id)
+}
+
+// DecodeID は EncodeID で文字列にしたバイト列を元に戻す
+func DecodeID(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
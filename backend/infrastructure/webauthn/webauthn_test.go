@@ -0,0 +1,251 @@
+package webauthn
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/webauthn/webauthntest"
+	"github.com/redis/rueidis"
+)
+
+const (
+	testRPID   = "localhost"
+	testOrigin = "http://localhost:5173"
+)
+
+func newTestRelyingParty() *RelyingParty {
+	return NewRelyingParty(Config{RPID: testRPID, RPName: "umi.mikan", Origins: []string{testOrigin}})
+}
+
+// register は認証器でパスキーを登録し、検証したパスキーを返す
+func register(t *testing.T, rp *RelyingParty, a *webauthntest.Authenticator) *Credential {
+	t.Helper()
+	challenge, err := NewChallenge()
+	if err != nil {
+		t.Fatalf("NewChallenge失敗: %v", err)
+	}
+	options, err := rp.CreationOptions(User{ID: []byte("user-1"), Name: "test@example.com", DisplayName: "Test"}, challenge, nil)
+	if err != nil {
+		t.Fatalf("CreationOptions失敗: %v", err)
+	}
+	r, err := ParseRegistration(a.Register(t, options))
+	if err != nil {
+		t.Fatalf("ParseRegistration失敗: %v", err)
+	}
+	cred, err := rp.VerifyRegistration(r, challenge)
+	if err != nil {
+		t.Fatalf("VerifyRegistration失敗: %v", err)
+	}
+	return cred
+}
+
+// login は認証器で認証のレスポンスを作成して解析する
+func login(t *testing.T, rp *RelyingParty, a *webauthntest.Authenticator) (*Assertion, string) {
+	t.Helper()
+	challenge, err := NewChallenge()
+	if err != nil {
+		t.Fatalf("NewChallenge失敗: %v", err)
+	}
+	options, err := rp.RequestOptions(challenge, nil, true)
+	if err != nil {
+		t.Fatalf("RequestOptions失敗: %v", err)
+	}
+	assertion, err := ParseAssertion(a.Login(t, options))
+	if err != nil {
+		t.Fatalf("ParseAssertion失敗: %v", err)
+	}
+	return assertion, challenge
+}
+
+func TestRelyingParty_VerifyRegistration(t *testing.T) {
+	t.Run("正常系: 公開鍵・credential ID・transportsを返す", func(t *testing.T) {
+		rp := newTestRelyingParty()
+		a := webauthntest.NewAuthenticator(t, testRPID, testOrigin)
+		cred := register(t, rp, a)
+		if EncodeID(cred.ID) != EncodeID(a.CredentialID) {
+			t.Errorf("credential IDが一致しない")
+		}
+		if _, alg, err := parsePublicKey(cred.PublicKey); err != nil || alg != AlgES256 {
+			t.Errorf("公開鍵が不正: alg=%d, err=%v", alg, err)
+		}
+		if len(cred.Transports) != 2 || cred.BackupEligible {
+			t.Errorf("transports・フラグが期待と異なる: %+v", cred)
+		}
+	})
+
+	tests := []struct {
+		name  string
+		setup func(a *webauthntest.Authenticator)
+		rp    *RelyingParty
+	}{
+		{
+			name:  "異常系: originが異なる",
+			setup: func(a *webauthntest.Authenticator) { a.Origin = "https://evil.example" },
+		},
+		{
+			name:  "異常系: RP IDが異なる",
+			setup: func(a *webauthntest.Authenticator) { a.RPID = "evil.example" },
+		},
+		{
+			name:  "異常系: 生体認証・PINで確認していない",
+			setup: func(a *webauthntest.Authenticator) { a.UserVerified = false },
+		},
+		{
+			name: "異常系: 種類が認証（webauthn.get）",
+			setup: func(a *webauthntest.Authenticator) {
+				a.ClientDataHook = func(cd map[string]any) { cd["type"] = "webauthn.get" }
+			},
+		},
+		{
+			name: "異常系: チャレンジが異なる",
+			setup: func(a *webauthntest.Authenticator) {
+				a.ClientDataHook = func(cd map[string]any) { cd["challenge"] = "other" }
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := newTestRelyingParty()
+			a := webauthntest.NewAuthenticator(t, testRPID, testOrigin)
+			tt.setup(a)
+			challenge, _ := NewChallenge()
+			options, err := rp.CreationOptions(User{ID: []byte("user-1")}, challenge, nil)
+			if err != nil {
+				t.Fatalf("CreationOptions失敗: %v", err)
+			}
+			r, err := ParseRegistration(a.Register(t, options))
+			if err != nil {
+				t.Fatalf("ParseRegistration失敗: %v", err)
+			}
+			if _, err := rp.VerifyRegistration(r, challenge); err == nil {
+				t.Error("エラーを期待したが成功した")
+			}
+		})
+	}
+}
+
+func TestRelyingParty_VerifyAssertion(t *testing.T) {
+	t.Run("正常系: 署名を検証して署名カウンターを返し、userHandleが登録時のユーザーIDになる", func(t *testing.T) {
+		rp := newTestRelyingParty()
+		a := webauthntest.NewAuthenticator(t, testRPID, testOrigin)
+		cred := register(t, rp, a)
+
+		assertion, challenge := login(t, rp, a)
+		if string(assertion.UserHandle) != "user-1" || EncodeID(assertion.CredentialID) != EncodeID(cred.ID) {
+			t.Errorf("userHandle・credential IDが期待と異なる: %+v", assertion)
+		}
+		count, err := rp.VerifyAssertion(assertion, challenge, cred.PublicKey, cred.SignCount, true)
+		if err != nil {
+			t.Fatalf("VerifyAssertion失敗: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("署名カウンターが期待と異なる: %d", count)
+		}
+	})
+
+	t.Run("正常系: 同期するパスキーは署名カウンターが0のまま", func(t *testing.T) {
+		rp := newTestRelyingParty()
+		a := webauthntest.NewAuthenticator(t, testRPID, testOrigin)
+		a.Synced = true
+		cred := register(t, rp, a)
+		if !cred.BackupEligible {
+			t.Error("BackupEligibleを期待した")
+		}
+		for range 2 {
+			assertion, challenge := login(t, rp, a)
+			if _, err := rp.VerifyAssertion(assertion, challenge, cred.PublicKey, 0, true); err != nil {
+				t.Fatalf("VerifyAssertion失敗: %v", err)
+			}
+		}
+	})
+
+	t.Run("異常系: 署名カウンターが進んでいない（複製された認証器）", func(t *testing.T) {
+		rp := newTestRelyingParty()
+		a := webauthntest.NewAuthenticator(t, testRPID, testOrigin)
+		cred := register(t, rp, a)
+		assertion, challenge := login(t, rp, a)
+		if _, err := rp.VerifyAssertion(assertion, challenge, cred.PublicKey, 5, true); err == nil {
+			t.Error("エラーを期待したが成功した")
+		}
+	})
+
+	t.Run("異常系: 別の認証器の公開鍵では検証できない", func(t *testing.T) {
+		rp := newTestRelyingParty()
+		other := register(t, rp, webauthntest.NewAuthenticator(t, testRPID, testOrigin))
+		a := webauthntest.NewAuthenticator(t, testRPID, testOrigin)
+		register(t, rp, a)
+		assertion, challenge := login(t, rp, a)
+		if _, err := rp.VerifyAssertion(assertion, challenge, other.PublicKey, 0, true); err == nil {
+			t.Error("エラーを期待したが成功した")
+		}
+	})
+
+	t.Run("異常系: 生体認証・PINを求める場合にUVフラグがない", func(t *testing.T) {
+		rp := newTestRelyingParty()
+		a := webauthntest.NewAuthenticator(t, testRPID, testOrigin)
+		cred := register(t, rp, a)
+		a.UserVerified = false
+		assertion, challenge := login(t, rp, a)
+		if _, err := rp.VerifyAssertion(assertion, challenge, cred.PublicKey, cred.SignCount, true); err == nil {
+			t.Error("エラーを期待したが成功した")
+		}
+		// 2段階目の認証では確認を求めない
+		if _, err := rp.VerifyAssertion(assertion, challenge, cred.PublicKey, cred.SignCount, false); err != nil {
+			t.Errorf("VerifyAssertion失敗: %v", err)
+		}
+	})
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		wantErr bool
+	}{
+		{name: "正常系: 負の整数", input: []byte{0x38, 0x18}},
+		{name: "正常系: 入れ子のマップ", input: []byte{0xa1, 0x01, 0xa1, 0x61, 0x61, 0xf5}},
+		{name: "異常系: 途中で終わる", input: []byte{0x43, 0x01}, wantErr: true},
+		{name: "異常系: 不定長", input: []byte{0x5f}, wantErr: true},
+		{name: "異常系: タグ", input: []byte{0xc1, 0x00}, wantErr: true},
+		{name: "異常系: 長さが大きすぎる配列", input: []byte{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := decodeCBOR(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("エラーが期待と異なる: %v", err)
+			}
+		})
+	}
+}
+
+func TestChallengeStore(t *testing.T) {
+	t.Run("正常系: セッションは1回だけ取得できる", func(t *testing.T) {
+		mr, err := miniredis.Run()
+		if err != nil {
+			t.Fatalf("miniredis起動失敗: %v", err)
+		}
+		t.Cleanup(mr.Close)
+		client, err := rueidis.NewClient(rueidis.ClientOption{InitAddress: []string{mr.Addr()}, DisableCache: true})
+		if err != nil {
+			t.Fatalf("rueidisクライアント作成失敗: %v", err)
+		}
+		t.Cleanup(client.Close)
+		store := &ChallengeStore{Redis: client}
+
+		challenge, err := store.Create(t.Context(), Session{Ceremony: CeremonyRegistration, UserID: "user-1"})
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		if ttl := mr.TTL(challengeKeyPrefix + challenge); ttl != challengeTTL {
+			t.Errorf("TTLが期待と異なる: %v", ttl)
+		}
+		session, ok, err := store.Consume(t.Context(), challenge)
+		if err != nil || !ok || session.Ceremony != CeremonyRegistration || session.UserID != "user-1" {
+			t.Fatalf("セッションが期待と異なる: %+v, %v, %v", session, ok, err)
+		}
+		if _, ok, err := store.Consume(t.Context(), challenge); err != nil || ok {
+			t.Errorf("2回目は取得できないことを期待した: %v, %v", ok, err)
+		}
+	})
+}
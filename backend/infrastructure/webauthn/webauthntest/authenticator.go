@@ -0,0 +1,202 @@
+// Package webauthntest はテスト用のソフトウェアの認証器（ES256のパスキー）を提供する
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+)
+
+// Authenticator はパスキーを1つ保存するソフトウェアの認証器。
+// navigator.credentials.create・get の代わりにオプションからレスポンス（PublicKeyCredential.toJSON()）を作成する
+type Authenticator struct {
+	RPID   string
+	Origin string
+	// UserVerified がfalseの場合は生体認証・PINで確認していない（UVフラグなし）レスポンスを作成する
+	UserVerified bool
+	// Synced がtrueの場合は同期するパスキーとして署名カウンターを常に0にする
+	Synced bool
+	// ClientDataHook は署名の前にclientDataJSONを書き換える（不正なレスポンスのテスト用）
+	ClientDataHook func(clientData map[string]any)
+
+	key          *ecdsa.PrivateKey
+	CredentialID []byte
+	UserHandle   []byte
+	SignCount    uint32
+}
+
+// NewAuthenticator は認証器を作成する
+func NewAuthenticator(t testing.TB, rpID, origin string) *Authenticator {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ecdsa key: %v", err)
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		t.Fatalf("failed to generate credential id: %v", err)
+	}
+	return &Authenticator{RPID: rpID, Origin: origin, UserVerified: true, key: key, CredentialID: id}
+}
+
+// Register は登録のオプション（PublicKeyCredentialCreationOptionsJSON）からレスポンスを作成する
+func (a *Authenticator) Register(t testing.TB, optionsJSON []byte) []byte {
+	t.Helper()
+	var options struct {
+		Challenge string `json:"challenge"`
+		User      struct {
+			ID string `json:"id"`
+		} `json:"user"`
+	}
+	if err := json.Unmarshal(optionsJSON, &options); err != nil {
+		t.Fatalf("invalid creation options: %v", err)
+	}
+	userHandle, err := base64.RawURLEncoding.DecodeString(options.User.ID)
+	if err != nil {
+		t.Fatalf("invalid user id: %v", err)
+	}
+	a.UserHandle = userHandle
+
+	point, err := a.key.PublicKey.Bytes() // 非圧縮形式（0x04 || x || y）
+	if err != nil {
+		t.Fatalf("failed to encode public key: %v", err)
+	}
+	x, y := point[1:33], point[33:]
+	coseKey := encodeMap([][2][]byte{
+		{encodeInt(1), encodeInt(2)},    // kty: EC2
+		{encodeInt(3), encodeInt(-7)},   // alg: ES256
+		{encodeInt(-1), encodeInt(1)},   // crv: P-256
+		{encodeInt(-2), encodeBytes(x)}, // x
+		{encodeInt(-3), encodeBytes(y)}, // y
+	})
+
+	attested := make([]byte, 16, 18+len(a.CredentialID)+len(coseKey)) // AAGUIDは0
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.CredentialID)))
+	attested = append(attested, a.CredentialID...)
+	attested = append(attested, coseKey...)
+	authData := append(a.authenticatorData(0x40), attested...)
+
+	attestationObject := encodeMap([][2][]byte{
+		{encodeText("fmt"), encodeText("none")},
+		{encodeText("attStmt"), encodeMap(nil)},
+		{encodeText("authData"), encodeBytes(authData)},
+	})
+	return marshalCredential(t, a.CredentialID, map[string]any{
+		"clientDataJSON":    b64(a.clientData(t, "webauthn.create", options.Challenge)),
+		"attestationObject": b64(attestationObject),
+		"transports":        []string{"internal", "hybrid"},
+	})
+}
+
+// Login は認証のオプション（PublicKeyCredentialRequestOptionsJSON）からレスポンスを作成する
+func (a *Authenticator) Login(t testing.TB, optionsJSON []byte) []byte {
+	t.Helper()
+	var options struct {
+		Challenge string `json:"challenge"`
+	}
+	if err := json.Unmarshal(optionsJSON, &options); err != nil {
+		t.Fatalf("invalid request options: %v", err)
+	}
+	if !a.Synced {
+		a.SignCount++
+	}
+	authData := a.authenticatorData(0)
+	clientDataJSON := a.clientData(t, "webauthn.get", options.Challenge)
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(append([]byte(nil), authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign assertion: %v", err)
+	}
+	return marshalCredential(t, a.CredentialID, map[string]any{
+		"clientDataJSON":    b64(clientDataJSON),
+		"authenticatorData": b64(authData),
+		"signature":         b64(signature),
+		"userHandle":        b64(a.UserHandle),
+	})
+}
+
+// authenticatorData はrpIdHash・フラグ・署名カウンターを返す
+func (a *Authenticator) authenticatorData(extraFlags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+	flags := byte(0x01) | extraFlags // UP
+	if a.UserVerified {
+		flags |= 0x04
+	}
+	if a.Synced {
+		flags |= 0x08 | 0x10 // BE・BS
+	}
+	data := append([]byte(nil), rpIDHash[:]...)
+	data = append(data, flags)
+	return binary.BigEndian.AppendUint32(data, a.SignCount)
+}
+
+func (a *Authenticator) clientData(t testing.TB, typ, challenge string) []byte {
+	t.Helper()
+	cd := map[string]any{"type": typ, "challenge": challenge, "origin": a.Origin, "crossOrigin": false}
+	if a.ClientDataHook != nil {
+		a.ClientDataHook(cd)
+	}
+	data, err := json.Marshal(cd)
+	if err != nil {
+		t.Fatalf("failed to marshal client data: %v", err)
+	}
+	return data
+}
+
+func marshalCredential(t testing.TB, id []byte, response map[string]any) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{
+		"id":                      b64(id),
+		"rawId":                   b64(id),
+		"type":                    "public-key",
+		"response":                response,
+		"authenticatorAttachment": "platform",
+		"clientExtensionResults":  map[string]any{},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal credential: %v", err)
+	}
+	return data
+}
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+// 以下はレスポンスの作成に必要な範囲のCBORのエンコーダー
+
+func encodeHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(n))
+	}
+}
+
+func encodeInt(n int64) []byte {
+	if n < 0 {
+		return encodeHead(1, uint64(-1-n))
+	}
+	return encodeHead(0, uint64(n))
+}
+
+func encodeBytes(b []byte) []byte { return append(encodeHead(2, uint64(len(b))), b...) }
+
+func encodeText(s string) []byte { return append(encodeHead(3, uint64(len(s))), s...) }
+
+func encodeMap(pairs [][2][]byte) []byte {
+	out := encodeHead(5, uint64(len(pairs)))
+	for _, p := range pairs {
+		out = append(append(out, p[0]...), p[1]...)
+	}
+	return out
+}
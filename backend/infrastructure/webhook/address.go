@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+// ErrNonPublicAddress は通知先がループバック・プライベートネットワークなど公開されていないアドレスの場合のエラー
+var ErrNonPublicAddress = errors.New("webhook destination is not a public address")
+
+// nonPublicPrefixes はnetip.Addrのメソッドで判定できない、インターネットから到達できないアドレス範囲
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // 「このネットワーク」
+	netip.MustParsePrefix("100.64.0.0/10"),   // キャリアグレードNAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETFプロトコル割り当て
+	netip.MustParsePrefix("192.0.2.0/24"),    // ドキュメント用
+	netip.MustParsePrefix("198.18.0.0/15"),   // ベンチマーク用
+	netip.MustParsePrefix("198.51.100.0/24"), // ドキュメント用
+	netip.MustParsePrefix("203.0.113.0/24"),  // ドキュメント用
+	netip.MustParsePrefix("240.0.0.0/4"),     // 予約済み（ブロードキャストを含む）
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // ローカル用のNAT64
+	netip.MustParsePrefix("2001:db8::/32"),   // ドキュメント用
+}
+
+// IsPublicAddr はアドレスがインターネット上の公開アドレスかを判定する
+// ループバック・リンクローカル・RFC1918/ULA・マルチキャスト・未指定アドレスなどはfalseを返す
+func IsPublicAddr(addr netip.Addr) bool {
+	// IPv4射影IPv6アドレス（::ffff:127.0.0.1など）はIPv4として判定する
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateHost は登録時にURLのホストが公開アドレスを指しているかを検証する
+// IPアドレスとlocalhostのみを判定し、ホスト名の名前解決の結果は送信時（DialControl）に検証する
+func ValidateHost(host string, allowPrivateNetworks bool) error {
+	if allowPrivateNetworks {
+		return nil
+	}
+	name := strings.TrimSuffix(strings.ToLower(host), ".")
+	if name == "localhost" || strings.HasSuffix(name, ".localhost") {
+		return ErrNonPublicAddress
+	}
+	if addr, err := netip.ParseAddr(strings.Trim(name, "[]")); err == nil && !IsPublicAddr(addr) {
+		return ErrNonPublicAddress
+	}
+	return nil
+}
+
+// DialControl はnet.Dialer.Controlに設定し、接続先が公開アドレスでなければ接続を拒否する
+// 名前解決の後に実際に接続するアドレスを検証するため、DNSリバインディングで登録時の検証を回避されない
+func DialControl(allowPrivateNetworks bool) func(network, address string, c syscall.RawConn) error {
+	return func(_, address string, _ syscall.RawConn) error {
+		if allowPrivateNetworks {
+			return nil
+		}
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return fmt.Errorf("invalid dial address %q: %w", address, err)
+		}
+		addr, err := netip.ParseAddr(host)
+		if err != nil {
+			return fmt.Errorf("invalid dial address %q: %w", address, err)
+		}
+		if !IsPublicAddr(addr) {
+			return fmt.Errorf("%w: %s", ErrNonPublicAddress, addr)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,188 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+)
+
+const (
+	// MaxAttempts は配信を失敗確定とするまでの最大試行回数
+	MaxAttempts = 8
+	// requestTimeout は1回の送信のタイムアウト
+	requestTimeout = 10 * time.Second
+	// claimLease は取得した配信行を他のワーカーから隠す期間（送信タイムアウトより十分長くする）
+	claimLease = 2 * time.Minute
+	// claimBatchSize は1回の取得で送信する最大件数
+	claimBatchSize = 20
+	// maxErrorLength は配信ログに保存するエラー内容の最大長
+	maxErrorLength = 500
+
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 6 * time.Hour
+)
+
+// Backoff は指定回数の試行に失敗した後、次の試行までの待ち時間を返す（30秒から倍々で最大6時間）
+func Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return baseBackoff
+	}
+	backoff := baseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return backoff
+}
+
+// Result は1回の配信処理の結果件数
+type Result struct {
+	Succeeded int
+	Retrying  int
+	Failed    int
+}
+
+// Dispatcher は送信待ちの配信行を取得してWebhookエンドポイントへ送信する
+type Dispatcher struct {
+	DB         database.DB
+	HTTPClient *http.Client
+	Now        func() time.Time
+}
+
+// NewDispatcher はDispatcherを生成する
+// allowPrivateNetworksがfalseの場合、ループバック・プライベートネットワークなど公開されていないアドレスには接続しない
+func NewDispatcher(db database.DB, allowPrivateNetworks bool) *Dispatcher {
+	dialer := &net.Dialer{
+		Timeout: requestTimeout,
+		Control: DialControl(allowPrivateNetworks),
+	}
+	return &Dispatcher{
+		DB: db,
+		HTTPClient: &http.Client{
+			Timeout: requestTimeout,
+			// プロキシを経由すると接続先の検証がプロキシのアドレスに対して行われるため、直接接続する
+			Transport: &http.Transport{
+				Proxy:                 nil,
+				DialContext:           dialer.DialContext,
+				TLSHandshakeTimeout:   requestTimeout,
+				ResponseHeaderTimeout: requestTimeout,
+				MaxIdleConns:          10,
+				IdleConnTimeout:       90 * time.Second,
+			},
+			// リダイレクト先への送信は行わず、3xxは失敗として扱う
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		Now: time.Now,
+	}
+}
+
+// DeliverDue は送信予定時刻を過ぎた配信を送信し、結果を配信ログに記録する
+// 送信待ちがなくなるかctxがキャンセルされるまで繰り返す
+func (d *Dispatcher) DeliverDue(ctx context.Context) (Result, error) {
+	var result Result
+	for ctx.Err() == nil {
+		now := d.Now()
+		jobs, err := database.ClaimDueWebhookDeliveries(ctx, d.DB, now.Unix(), now.Add(claimLease).Unix(), claimBatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(jobs) == 0 {
+			return result, nil
+		}
+		for _, job := range jobs {
+			status, err := d.deliver(ctx, job)
+			if err != nil {
+				return result, err
+			}
+			switch status {
+			case database.WebhookDeliveryStatusSucceeded:
+				result.Succeeded++
+			case database.WebhookDeliveryStatusFailed:
+				result.Failed++
+			default:
+				result.Retrying++
+			}
+		}
+	}
+	return result, nil
+}
+
+// deliver は1件を送信して配信行を更新し、更新後の状態を返す
+func (d *Dispatcher) deliver(ctx context.Context, job *database.WebhookDeliveryJob) (string, error) {
+	delivery := job.Delivery
+	responseStatus, sendErr := d.send(ctx, job)
+
+	now := d.Now()
+	delivery.Attempts++
+	delivery.ResponseStatus = responseStatus
+	delivery.UpdatedAt = now.Unix()
+	switch {
+	case sendErr == nil:
+		delivery.Status = database.WebhookDeliveryStatusSucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = sql.NullInt64{Int64: now.Unix(), Valid: true}
+	case delivery.Attempts >= MaxAttempts:
+		delivery.Status = database.WebhookDeliveryStatusFailed
+		delivery.LastError = truncateError(sendErr)
+	default:
+		delivery.LastError = truncateError(sendErr)
+		delivery.NextAttemptAt = now.Add(Backoff(delivery.Attempts)).Unix()
+	}
+
+	if err := delivery.Update(ctx, d.DB); err != nil {
+		return "", fmt.Errorf("failed to record webhook delivery %s: %w", delivery.ID, err)
+	}
+	return delivery.Status, nil
+}
+
+// send は署名付きでペイロードをPOSTし、レスポンスのHTTPステータスを返す
+// 2xx以外のレスポンスと通信エラーはエラーとして返す（通信エラー時のステータスは0）
+func (d *Dispatcher) send(ctx context.Context, job *database.WebhookDeliveryJob) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(job.Delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("invalid request: %w", err)
+	}
+	timestamp := d.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "umi.mikan-webhook")
+	req.Header.Set(HeaderEvent, job.Delivery.Event)
+	req.Header.Set(HeaderDelivery, job.Delivery.ID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(job.Secret, timestamp, job.Delivery.Payload))
+
+	resp, err := d.HTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	// コネクションを再利用できるようにボディを読み捨てる（巨大なレスポンスは途中で打ち切る）
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func truncateError(err error) string {
+	message := err.Error()
+	if len(message) > maxErrorLength {
+		return message[:maxErrorLength]
+	}
+	return message
+}
@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/redis/rueidis"
+)
+
+// 通知対象のイベント名
+const (
+	EventDiaryCreated       = "diary.created"
+	EventDiaryUpdated       = "diary.updated"
+	EventDiaryDeleted       = "diary.deleted"
+	EventDiaryRestored      = "diary.restored"
+	EventSummaryGenerated   = "summary.generated"
+	EventTrendGenerated     = "trend.generated"
+	EventHighlightGenerated = "highlight.generated"
+)
+
+// Events は購読可能なイベント名の一覧
+var Events = []string{
+	EventDiaryCreated,
+	EventDiaryUpdated,
+	EventDiaryDeleted,
+	EventDiaryRestored,
+	EventSummaryGenerated,
+	EventTrendGenerated,
+	EventHighlightGenerated,
+}
+
+// IsValidEvent は購読可能なイベント名かどうかを返す
+func IsValidEvent(event string) bool {
+	return slices.Contains(Events, event)
+}
+
+// 送信リクエストのヘッダー名
+const (
+	HeaderEvent     = "X-Umi-Event"
+	HeaderDelivery  = "X-Umi-Delivery"
+	HeaderTimestamp = "X-Umi-Timestamp"
+	HeaderSignature = "X-Umi-Signature"
+)
+
+// DeliveryMessageType は配信ワーカーを起こすためにdiary_eventsチャンネルへ送るメッセージのtype
+const DeliveryMessageType = "webhook_delivery"
+
+// Payload は送信するリクエストボディ
+type Payload struct {
+	Event      string `json:"event"`
+	OccurredAt int64  `json:"occurred_at"` // Unix秒
+	Data       any    `json:"data"`
+}
+
+// GenerateSecret は署名用のシークレットを生成する（whsec_ + 32バイトの乱数のhex）
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+// Sign は送信するボディの署名を返す
+// 署名対象は "タイムスタンプ.ボディ" とし、受信側でタイムスタンプを検証することでリプレイを防げるようにする
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "%d.", timestamp)
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Enqueue はイベントを購読しているユーザーの全Webhookに配信を登録し、配信ワーカーに通知する
+// 配信はsubscriberが非同期に行うため、呼び出し元は送信結果を待たない
+func Enqueue(ctx context.Context, db database.DB, redisClient rueidis.Client, userID uuid.UUID, event string, data any) error {
+	now := time.Now().Unix()
+	body, err := json.Marshal(Payload{Event: event, OccurredAt: now, Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	count, err := database.EnqueueWebhookDeliveries(ctx, db, userID, event, body, now)
+	if err != nil {
+		return err
+	}
+	if count == 0 || redisClient == nil {
+		return nil
+	}
+
+	// 通知に失敗しても配信ワーカーの定期実行で送信されるため、配信登録自体は成功扱いにする
+	message := fmt.Sprintf(`{"type":%q}`, DeliveryMessageType)
+	cmd := redisClient.B().Publish().Channel("diary_events").Message(message).Build()
+	if err := redisClient.Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("webhook deliveries enqueued but failed to notify worker: %w", err)
+	}
+	return nil
+}
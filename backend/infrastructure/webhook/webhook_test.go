@@ -0,0 +1,229 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"diary.created"}`)
+	mac := hmac.New(sha256.New, []byte("whsec_test"))
+	mac.Write([]byte("1700000000." + string(body)))
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, expected, Sign("whsec_test", 1700000000, body))
+	// シークレットやタイムスタンプが異なれば署名も変わる
+	assert.NotEqual(t, expected, Sign("whsec_other", 1700000000, body))
+	assert.NotEqual(t, expected, Sign("whsec_test", 1700000001, body))
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, Backoff(1))
+	assert.Equal(t, time.Minute, Backoff(2))
+	assert.Equal(t, 4*time.Minute, Backoff(4))
+	// 上限で頭打ちになる
+	assert.Equal(t, 6*time.Hour, Backoff(20))
+}
+
+func TestIsValidEvent(t *testing.T) {
+	assert.True(t, IsValidEvent(EventDiaryCreated))
+	assert.True(t, IsValidEvent(EventHighlightGenerated))
+	assert.False(t, IsValidEvent("diary.*"))
+	assert.False(t, IsValidEvent(""))
+}
+
+func TestDispatcher_send(t *testing.T) {
+	var received *http.Request
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/ok", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	// httptestのサーバーはループバックで待ち受けるため、プライベートネットワークを許可する
+	d := NewDispatcher(nil, true)
+	d.Now = func() time.Time { return time.Unix(1700000000, 0) }
+	job := &database.WebhookDeliveryJob{
+		Delivery: &database.UserWebhookDelivery{
+			ID:      uuid.New(),
+			Event:   EventDiaryCreated,
+			Payload: []byte(`{"event":"diary.created"}`),
+		},
+		URL:    server.URL + "/ok",
+		Secret: "whsec_test",
+	}
+
+	t.Run("正常系: 署名付きで送信される", func(t *testing.T) {
+		status, err := d.send(context.Background(), job)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+
+		assert.Equal(t, job.Delivery.Payload, receivedBody)
+		assert.Equal(t, EventDiaryCreated, received.Header.Get(HeaderEvent))
+		assert.Equal(t, job.Delivery.ID.String(), received.Header.Get(HeaderDelivery))
+		assert.Equal(t, strconv.Itoa(1700000000), received.Header.Get(HeaderTimestamp))
+		assert.Equal(t, Sign("whsec_test", 1700000000, job.Delivery.Payload), received.Header.Get(HeaderSignature))
+	})
+
+	t.Run("異常系: 2xx以外はステータス付きでエラー", func(t *testing.T) {
+		failJob := *job
+		failJob.URL = server.URL + "/fail"
+		status, err := d.send(context.Background(), &failJob)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusInternalServerError, status)
+	})
+
+	t.Run("異常系: リダイレクトは追従せずエラー", func(t *testing.T) {
+		redirectJob := *job
+		redirectJob.URL = server.URL + "/redirect"
+		status, err := d.send(context.Background(), &redirectJob)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusFound, status)
+	})
+
+	t.Run("異常系: 接続できない場合はステータス0でエラー", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		downJob := *job
+		downJob.URL = closed.URL
+		status, err := d.send(context.Background(), &downJob)
+		assert.Error(t, err)
+		assert.Equal(t, 0, status)
+	})
+}
+
+func TestDispatcher_send_NonPublicAddress(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	d := NewDispatcher(nil, false)
+	job := &database.WebhookDeliveryJob{
+		Delivery: &database.UserWebhookDelivery{
+			ID:      uuid.New(),
+			Event:   EventDiaryCreated,
+			Payload: []byte(`{"event":"diary.created"}`),
+		},
+		URL:    server.URL,
+		Secret: "whsec_test",
+	}
+
+	// 登録後にDNSの向き先が変わった場合も、接続時にループバックへの送信を拒否する
+	status, err := d.send(context.Background(), job)
+	assert.ErrorIs(t, err, ErrNonPublicAddress)
+	assert.Equal(t, 0, status)
+	assert.False(t, called)
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		name     string
+		addr     string
+		expected bool
+	}{
+		{"正常系: 公開IPv4", "8.8.8.8", true},
+		{"正常系: 公開IPv6", "2606:4700:4700::1111", true},
+		{"異常系: ループバック", "127.0.0.1", false},
+		{"異常系: IPv6ループバック", "::1", false},
+		{"異常系: RFC1918", "10.1.2.3", false},
+		{"異常系: リンクローカル（メタデータサーバー）", "169.254.169.254", false},
+		{"異常系: IPv6リンクローカル", "fe80::1", false},
+		{"異常系: ULA", "fd12:3456::1", false},
+		{"異常系: キャリアグレードNAT", "100.64.0.1", false},
+		{"異常系: 未指定アドレス", "0.0.0.0", false},
+		{"異常系: マルチキャスト", "224.0.0.1", false},
+		{"異常系: ブロードキャスト", "255.255.255.255", false},
+		{"異常系: IPv4射影IPv6のループバック", "::ffff:127.0.0.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestDispatcher_DeliverDue(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "webhook-dispatch@example.com", "WebhookDispatchUser")
+	ctx := context.Background()
+
+	failing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	webhook := &database.UserWebhook{
+		ID:        uuid.New(),
+		UserID:    userID,
+		URL:       server.URL,
+		Secret:    "whsec_test",
+		Events:    []byte(`["summary.generated"]`),
+		CreatedAt: 1700000000,
+		UpdatedAt: 1700000000,
+	}
+	require.NoError(t, webhook.Insert(ctx, db))
+	require.NoError(t, Enqueue(ctx, db, nil, userID, EventSummaryGenerated, map[string]any{"year": 2024, "month": 5}))
+
+	now := time.Now()
+	d := NewDispatcher(db, true)
+	d.Now = func() time.Time { return now }
+
+	// 1回目は失敗してリトライ待ちになる
+	result, err := d.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Retrying: 1}, result)
+
+	deliveries, err := database.RecentWebhookDeliveries(ctx, db, webhook.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, database.WebhookDeliveryStatusPending, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, deliveries[0].ResponseStatus)
+	assert.Equal(t, now.Add(Backoff(1)).Unix(), deliveries[0].NextAttemptAt)
+
+	// バックオフ経過後に再送され成功する
+	failing = false
+	now = now.Add(Backoff(1))
+	result, err = d.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Succeeded: 1}, result)
+
+	deliveries, err = database.RecentWebhookDeliveries(ctx, db, webhook.ID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, database.WebhookDeliveryStatusSucceeded, deliveries[0].Status)
+	assert.Equal(t, 2, deliveries[0].Attempts)
+	assert.True(t, deliveries[0].DeliveredAt.Valid)
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// accountStatusCacheTTL ユーザーの状態をキャッシュする時間。
+// 無効化・削除の予定・セッションの取り消しは、最長でこの時間だけ遅れて反映される
+const accountStatusCacheTTL = 10 * time.Second
+
+// accountStatusCacheMaxEntries キャッシュの上限。超えた場合は期限切れのエントリーを捨てる
+const accountStatusCacheMaxEntries = 10000
+
+// AccountChecker 認証したユーザーが使える状態かを確認する。
+// アクセストークンは署名だけでは取り消せないため、無効にした・削除を予定した・セッションを取り消したユーザーの
+// 発行済みのトークンを有効期限まで使えないよう、認証のたびにDBの状態を確認する（ADR 0029、ADR 0037）。
+// DBの負荷を抑えるため、ユーザーごとの状態を短い時間だけキャッシュする
+type AccountChecker struct {
+	db      database.DB
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]accountStatus
+}
+
+// accountStatus キャッシュしたユーザーの状態
+type accountStatus struct {
+	found             bool
+	disabled          bool
+	deletionScheduled bool
+	sessionsRevokedAt int64 // 0の場合は取り消していない
+	expiresAt         time.Time
+}
+
+// NewAccountChecker ユーザーの状態を確認するAccountCheckerを返す
+func NewAccountChecker(db database.DB) *AccountChecker {
+	return &AccountChecker{db: db, ttl: accountStatusCacheTTL, entries: map[string]accountStatus{}}
+}
+
+// Check ユーザーが有効で、issuedAt（UNIX秒）に発行したアクセストークンがセッションの取り消しより後のものかを確認する。
+// checkerがnilの場合は確認しない（DBを使わないテスト用）
+func (c *AccountChecker) Check(ctx context.Context, userID string, issuedAt int64) error {
+	if c == nil {
+		return nil
+	}
+	account, err := c.usableStatus(ctx, userID)
+	if err != nil {
+		return err
+	}
+	// 発行日時は秒単位のため、取り消しと同じ秒に発行したトークンも取り消す（リフレッシュトークンと同じ）
+	if account.sessionsRevokedAt != 0 && issuedAt <= account.sessionsRevokedAt {
+		return status.Error(codes.Unauthenticated, "session has been revoked")
+	}
+	return nil
+}
+
+// CheckUser ユーザーが有効かだけを確認する（APIキー用。APIキーはセッションではないため、セッションの取り消しでは無効にならない）。
+// checkerがnilの場合は確認しない
+func (c *AccountChecker) CheckUser(ctx context.Context, userID string) error {
+	if c == nil {
+		return nil
+	}
+	_, err := c.usableStatus(ctx, userID)
+	return err
+}
+
+// usableStatus ユーザーの状態を取得し、存在しない・無効・削除の予定がある場合はエラーを返す
+func (c *AccountChecker) usableStatus(ctx context.Context, userID string) (accountStatus, error) {
+	account, err := c.status(ctx, userID)
+	if err != nil {
+		return accountStatus{}, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if !account.found {
+		return accountStatus{}, status.Error(codes.Unauthenticated, "user not found")
+	}
+	if account.disabled {
+		return accountStatus{}, status.Error(codes.PermissionDenied, "account is disabled")
+	}
+	if account.deletionScheduled {
+		return accountStatus{}, status.Error(codes.PermissionDenied, "account deletion is pending")
+	}
+	return account, nil
+}
+
+// status キャッシュからユーザーの状態を返す。ない場合・期限切れの場合はDBから取得する
+func (c *AccountChecker) status(ctx context.Context, userID string) (accountStatus, error) {
+	now := time.Now()
+	c.mu.Lock()
+	cached, ok := c.entries[userID]
+	c.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached, nil
+	}
+
+	fetched := accountStatus{expiresAt: now.Add(c.ttl)}
+	if id, err := uuid.Parse(userID); err == nil {
+		user, err := database.UserByID(ctx, c.db, id)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+		case err != nil:
+			return accountStatus{}, err
+		default:
+			fetched.found = true
+			fetched.disabled = user.DisabledAt.Valid
+			fetched.deletionScheduled = user.DeletionScheduledAt.Valid
+			if user.SessionsRevokedAt.Valid {
+				fetched.sessionsRevokedAt = user.SessionsRevokedAt.Int64
+			}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= accountStatusCacheMaxEntries {
+		for key, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+		// 期限内のエントリーだけで上限に達した場合はすべて捨てる
+		if len(c.entries) >= accountStatusCacheMaxEntries {
+			clear(c.entries)
+		}
+	}
+	c.entries[userID] = fetched
+	return fetched, nil
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/domain/model"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminServicePrefix は管理者のみ呼び出せるサービスのメソッド名の接頭辞
+const adminServicePrefix = "/admin.AdminService/"
+
+// NewAdminInterceptor gRPCの管理者向けサービスの認可インターセプター。
+// AuthInterceptorがユーザーIDを注入した後に置く
+func NewAdminInterceptor(db *sql.DB) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := AuthorizeAdmin(ctx, db, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthorizeAdmin 管理者向けのサービスのメソッドの場合、ユーザーが有効な管理者であることを確認する。
+// 権限はトークンに含めず毎回DBで確認する（権限を外した・無効にしたユーザーのアクセストークンが有効期限まで使えないように）
+func AuthorizeAdmin(ctx context.Context, db database.DB, fullMethod string) error {
+	if !strings.HasPrefix(fullMethod, adminServicePrefix) {
+		return nil
+	}
+
+	userIDStr, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "invalid user id")
+	}
+
+	user, err := database.UserByID(ctx, db, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Error(codes.PermissionDenied, "admin role required")
+		}
+		return status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if user.Role != model.UserRoleAdmin.Int16() || user.DisabledAt.Valid {
+		return status.Error(codes.PermissionDenied, "admin role required")
+	}
+	return nil
+}
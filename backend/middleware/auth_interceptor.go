@@ -20,14 +20,53 @@ const (
 	ConnectClientIPKey contextKey = "connectClientIP"
 	// ConnectRPCインターセプターがHTTPヘッダーから抽出したUser-Agentを格納するキー
 	ConnectUserAgentKey contextKey = "connectUserAgent"
+	// APIキーで認証したリクエスト（MCPサーバー）のキーのIDを格納するキー（レートリミット用）
+	APIKeyIDKey contextKey = "apiKeyID"
 )
 
-// AuthInterceptor gRPCの認証インターセプター
-func AuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-	// 認証が不要なメソッドをスキップ
-	if isAuthExempt(info.FullMethod) {
+// NewAuthInterceptor gRPCの認証インターセプター。
+// アクセストークンを検証した後、checkerでユーザーが使える状態かを確認する
+func NewAuthInterceptor(checker *AccountChecker) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, checker, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		// 認証済みのリクエストを処理
 		return handler(ctx, req)
 	}
+}
+
+// NewAuthStreamInterceptor gRPCのストリーミングRPC用の認証インターセプター
+func NewAuthStreamInterceptor(checker *AccountChecker) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), checker, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		// ユーザーIDを注入したコンテキストをハンドラーに渡す
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextStream コンテキストを差し替えたgrpc.ServerStream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate メタデータのアクセストークンを検証し、ユーザーIDを注入したコンテキストを返す
+func authenticate(ctx context.Context, checker *AccountChecker, fullMethod string) (context.Context, error) {
+	// 認証が不要なメソッドをスキップ
+	if isAuthExempt(fullMethod) {
+		return ctx, nil
+	}
 
 	// メタデータからAuthorizationヘッダーを取得
 	md, ok := metadata.FromIncomingContext(ctx)
@@ -47,16 +86,17 @@ func AuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, h
 	}
 
 	// JWTトークンの検証（リフレッシュトークンは拒否する）
-	_, userID, err := model.ParseAccessToken(accessToken)
+	token, userID, err := model.ParseAccessToken(accessToken)
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "invalid access token: %v", err)
 	}
+	// 無効にした・削除を予定した・セッションを取り消したユーザーのトークンは有効期限内でも拒否する
+	if err := checker.Check(ctx, userID, token.IssuedAt); err != nil {
+		return nil, err
+	}
 
 	// ユーザーIDをコンテキストに追加
-	ctx = context.WithValue(ctx, UserIDKey, userID)
-
-	// 認証済みのリクエストを処理
-	return handler(ctx, req)
+	return context.WithValue(ctx, UserIDKey, userID), nil
 }
 
 // isAuthExempt 認証が不要なメソッドかどうかを判定
@@ -66,6 +106,18 @@ func isAuthExempt(method string) bool {
 		"/auth.AuthService/LoginByPassword",
 		"/auth.AuthService/RefreshAccessToken",
 		"/auth.AuthService/GetRegistrationConfig",
+		"/auth.AuthService/ListOIDCProviders",
+		"/auth.AuthService/StartOIDCLogin",
+		"/auth.AuthService/CompleteOIDCLogin",
+		"/auth.AuthService/VerifyMfa",
+		"/auth.AuthService/StartPasskeyLogin",
+		"/auth.AuthService/FinishPasskeyLogin",
+		"/auth.AuthService/StartPasskeyMfa",
+		"/auth.AuthService/FinishPasskeyMfa",
+		"/auth.AuthService/RequestPasswordReset",
+		"/auth.AuthService/ResetPassword",
+		"/auth.AuthService/VerifyEmail",
+		"/auth.AuthService/CancelAccountDeletion",
 	}
 
 	return slices.Contains(exemptMethods, method)
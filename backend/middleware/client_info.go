@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// unknownClient はIPアドレス・User-Agentを取得できない場合の値
+const unknownClient = "unknown"
+
+// ClientIP X-Forwarded-Forヘッダーを考慮してクライアントIPを取得。
+// ConnectRPC 経由の場合はインターセプターがコンテキストに注入した値を優先する。
+func ClientIP(ctx context.Context) string {
+	// ConnectRPC インターセプターが注入したIPを優先する（gRPC metadata は使えないため）
+	if ip, ok := ctx.Value(ConnectClientIPKey).(string); ok && ip != "" {
+		return ip
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		// X-Forwarded-Forヘッダーをチェック（プロキシ/ロードバランサー経由の場合）
+		if xff := md.Get("x-forwarded-for"); len(xff) > 0 {
+			// 最初のIPアドレスを使用（カンマ区切りの場合）
+			ip := strings.TrimSpace(strings.Split(xff[0], ",")[0])
+			if ip != "" {
+				return ip
+			}
+		}
+
+		// X-Real-IPヘッダーもチェック
+		if xri := md.Get("x-real-ip"); len(xri) > 0 && xri[0] != "" {
+			return strings.TrimSpace(xri[0])
+		}
+	}
+
+	// フォールバック: ピア接続からIPアドレスを取得
+	if p, ok := peer.FromContext(ctx); ok {
+		if tcpAddr, ok := p.Addr.(*net.TCPAddr); ok {
+			return tcpAddr.IP.String()
+		}
+	}
+
+	return unknownClient
+}
+
+// UserAgent 複数のヘッダー形式からUser-Agentを取得。
+// ConnectRPC 経由の場合はインターセプターがコンテキストに注入した値を優先する。
+func UserAgent(ctx context.Context) string {
+	// ConnectRPC インターセプターが注入したUser-Agentを優先する
+	if ua, ok := ctx.Value(ConnectUserAgentKey).(string); ok && ua != "" {
+		return ua
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		// 標準的なUser-Agentヘッダー
+		if ua := md.Get("user-agent"); len(ua) > 0 && ua[0] != "" {
+			return ua[0]
+		}
+
+		// gRPC Gateway経由の場合のヘッダー
+		if ua := md.Get("grpcgateway-user-agent"); len(ua) > 0 && ua[0] != "" {
+			return ua[0]
+		}
+	}
+
+	return unknownClient
+}
+
+// WithHTTPClient はHTTPのリクエストのクライアントIPとUser-Agentをコンテキストに注入する。
+// gRPC・ConnectRPCを経由しないHTTPのハンドラー（MCPサーバーなど）でも ClientIP・UserAgent で取得できるようにする
+func WithHTTPClient(ctx context.Context, r *http.Request) context.Context {
+	ip := strings.TrimSpace(strings.Split(r.Header.Get("X-Forwarded-For"), ",")[0])
+	if ip == "" {
+		ip = strings.TrimSpace(r.Header.Get("X-Real-Ip"))
+	}
+	if ip == "" {
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			ip = host
+		}
+	}
+	if ip != "" {
+		ctx = context.WithValue(ctx, ConnectClientIPKey, ip)
+	}
+	if ua := r.Header.Get("User-Agent"); ua != "" {
+		ctx = context.WithValue(ctx, ConnectUserAgentKey, ua)
+	}
+	return ctx
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/requestid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RPCのプロトコル（メトリクス・ログのprotocolラベル）
+const (
+	ProtocolGRPC    = "grpc"
+	ProtocolConnect = "connect"
+)
+
+var (
+	// rpcRequestsCounter RPCの呼び出し回数
+	rpcRequestsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "backend_rpc_requests_total",
+			Help: "Total number of RPC requests",
+		},
+		[]string{"protocol", "method", "code"},
+	)
+	// rpcDuration RPCの処理時間
+	rpcDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "backend_rpc_duration_seconds",
+			Help:    "Duration of RPC requests",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"protocol", "method", "code"},
+	)
+	// rpcPanicsCounter ハンドラーのpanicから復帰した回数
+	rpcPanicsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "backend_rpc_panics_total",
+			Help: "Total number of panics recovered in RPC handlers",
+		},
+		[]string{"protocol", "method"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(rpcRequestsCounter)
+	prometheus.MustRegister(rpcDuration)
+	prometheus.MustRegister(rpcPanicsCounter)
+}
+
+// rpcLogger RPCのアクセスログ（subscriberと同じくserviceフィールドを付ける）
+var rpcLogger = logrus.WithField("service", "server")
+
+// ObserveRPC RPCの結果をメトリクスとアクセスログに記録。
+// ログのレベルはサーバー側の失敗（Internalなど）はerror、クライアント側の失敗はwarn、成功はinfo
+func ObserveRPC(ctx context.Context, protocol, method string, code codes.Code, duration time.Duration, err error) {
+	rpcRequestsCounter.WithLabelValues(protocol, method, code.String()).Inc()
+	rpcDuration.WithLabelValues(protocol, method, code.String()).Observe(duration.Seconds())
+
+	entry := rpcLogger.WithFields(logrus.Fields{
+		requestid.LogField: requestid.FromContext(ctx),
+		"protocol":         protocol,
+		"method":           method,
+		"code":             code.String(),
+		"duration_ms":      duration.Milliseconds(),
+	})
+	switch {
+	case code == codes.OK:
+		entry.Info("RPC completed")
+	case isServerError(code):
+		entry.WithError(err).Error("RPC failed")
+	default:
+		entry.WithError(err).Warn("RPC failed")
+	}
+}
+
+// RecoverRPC ハンドラーのpanicをログ（スタックトレース付き）とメトリクスに記録し、クライアントに返すエラーを作る。
+// panicの内容は内部の情報を含みうるため、クライアントにはInternalだけを返す
+func RecoverRPC(ctx context.Context, protocol, method string, recovered any) error {
+	rpcPanicsCounter.WithLabelValues(protocol, method).Inc()
+	rpcLogger.WithFields(logrus.Fields{
+		requestid.LogField: requestid.FromContext(ctx),
+		"protocol":         protocol,
+		"method":           method,
+		"panic":            recovered,
+		"stack":            string(debug.Stack()),
+	}).Error("Recovered from panic in RPC handler")
+	return status.Error(codes.Internal, "internal error")
+}
+
+// isServerError サーバー側の問題による失敗か
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.Unimplemented, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
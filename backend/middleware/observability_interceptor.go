@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ServerOptions gRPCサーバーのインターセプターのチェーン。
+// 記録・panicからの復帰を最も外側に置き、認証の失敗やレート制限による拒否もメトリクスとログに残す
+func ServerOptions(db *sql.DB, limiter *ratelimiter.RPCLimiter, checker *AccountChecker) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(ObservabilityInterceptor, NewAuthInterceptor(checker), NewAdminInterceptor(db), NewRateLimitInterceptor(limiter)),
+		grpc.ChainStreamInterceptor(ObservabilityStreamInterceptor, NewAuthStreamInterceptor(checker)),
+	}
+}
+
+// ObservabilityInterceptor gRPCのリクエストIDの付与・panicからの復帰・メトリクスとアクセスログの記録を行うインターセプター
+func ObservabilityInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	ctx = withIncomingRequestID(ctx)
+	start := time.Now()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			resp, err = nil, RecoverRPC(ctx, ProtocolGRPC, info.FullMethod, recovered)
+		}
+		ObserveRPC(ctx, ProtocolGRPC, info.FullMethod, status.Code(err), time.Since(start), err)
+	}()
+	return handler(ctx, req)
+}
+
+// ObservabilityStreamInterceptor ストリーミングRPC用の ObservabilityInterceptor
+func ObservabilityStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	ctx := withIncomingRequestID(ss.Context())
+	start := time.Now()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = RecoverRPC(ctx, ProtocolGRPC, info.FullMethod, recovered)
+		}
+		ObserveRPC(ctx, ProtocolGRPC, info.FullMethod, status.Code(err), time.Since(start), err)
+	}()
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// withIncomingRequestID メタデータのリクエストIDを使い（ない場合は生成し）、コンテキストとレスポンスのヘッダーに設定する
+func withIncomingRequestID(ctx context.Context) context.Context {
+	var incoming string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestid.Header); len(values) > 0 {
+			incoming = values[0]
+		}
+	}
+	id := requestid.FromIncoming(incoming)
+	// 送れなくてもRPCの結果は変わらないため無視する
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.Header, id))
+	return requestid.WithID(ctx, id)
+}
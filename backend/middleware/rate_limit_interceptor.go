@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// レート制限の状況を返すヘッダー（gRPCのメタデータ・ConnectRPCのHTTPヘッダーで共通）
+const (
+	RateLimitLimitHeader     = "x-ratelimit-limit"
+	RateLimitRemainingHeader = "x-ratelimit-remaining"
+	RetryAfterHeader         = "retry-after" // 次に呼び出せるまでの秒数
+)
+
+// NewRateLimitInterceptor gRPCのRPCごとのレート制限インターセプター。
+// AuthInterceptorがユーザーIDを注入した後に置く
+func NewRateLimitInterceptor(limiter *ratelimiter.RPCLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		decision, err := CheckRateLimit(ctx, limiter, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if decision != nil {
+			// 送れなくてもRPCの結果は変わらないため無視する
+			_ = grpc.SetHeader(ctx, metadata.New(RateLimitHeaders(decision)))
+			if !decision.Allowed {
+				return nil, RateLimitExceededError(info.FullMethod, decision)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// CheckRateLimit 認証したユーザー（APIキーの場合はキー）のバケットでメソッドの呼び出しを数える。
+// 制限のないメソッドと、認証が不要なメソッドの場合はnilを返す
+func CheckRateLimit(ctx context.Context, limiter *ratelimiter.RPCLimiter, method string) (*ratelimiter.Decision, error) {
+	if limiter == nil {
+		return nil, nil
+	}
+	subject, ok := RateLimitSubject(ctx)
+	if !ok {
+		return nil, nil
+	}
+	decision, err := limiter.Check(ctx, method, subject)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "rate limit check failed: %v", err)
+	}
+	return decision, nil
+}
+
+// RateLimitSubject コンテキストからレート制限のバケットを決める（APIキーで認証した場合はキーごと、JWTの場合はユーザーごと）
+func RateLimitSubject(ctx context.Context) (string, bool) {
+	if apiKeyID, ok := ctx.Value(APIKeyIDKey).(string); ok && apiKeyID != "" {
+		return ratelimiter.APIKeySubject(apiKeyID), true
+	}
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return "", false
+	}
+	return ratelimiter.UserSubject(userID), true
+}
+
+// RateLimitHeaders レート制限の状況を返すヘッダーの値
+func RateLimitHeaders(decision *ratelimiter.Decision) map[string]string {
+	headers := map[string]string{
+		RateLimitLimitHeader:     strconv.Itoa(decision.Limit),
+		RateLimitRemainingHeader: strconv.Itoa(decision.Remaining),
+	}
+	if !decision.Allowed {
+		headers[RetryAfterHeader] = strconv.Itoa(int(decision.RetryAfter.Seconds()))
+	}
+	return headers
+}
+
+// RateLimitExceededError 上限に達した場合のエラー。次に呼び出せるまでの時間をRetryInfoの詳細に含める
+func RateLimitExceededError(method string, decision *ratelimiter.Decision) error {
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("rate limit exceeded for %s, try again in %v", method, decision.RetryAfter))
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(decision.RetryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,180 @@
+-- この一連のマイグレーションを導入する前のスキーマ（当時の schema/ のSQLファイルを番号順に連結したもの）
+-- 以降の変更は 0002 以降のマイグレーションで適用する。導入前から存在するデータベースには実行せず、適用済みとして記録する（ADR 0028）
+
+-- 1000_users.sql
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY,
+    email VARCHAR(320) NOT NULL UNIQUE, -- ログイン用のやつ
+    name VARCHAR(20) NOT NULL, -- 20文字以内(バイトでなく文字数)
+    auth_type smallint NOT NULL, -- 0:password
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+CREATE INDEX index_users_email ON users (email);
+
+-- 1100_user_password_authes.sql
+CREATE TABLE IF NOT EXISTS user_password_authes (
+    user_id UUID REFERENCES users(id) PRIMARY KEY,
+    password_hashed VARCHAR(255) NOT NULL, -- ハッシュ化されたパスワード
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+-- 1200_user_llms.sql
+CREATE TABLE IF NOT EXISTS user_llms (
+    user_id UUID REFERENCES users(id) PRIMARY KEY,
+    llm_provider  smallint NOT NULL, -- 1:Gemini
+    key  VARCHAR(100) NOT NULL,
+    auto_summary_monthly BOOLEAN NOT NULL DEFAULT FALSE, -- 月毎の自動要約生成を行うかどうか
+    auto_latest_trend_enabled BOOLEAN NOT NULL DEFAULT FALSE, -- 直近トレンド分析の自動生成を行うかどうか
+    semantic_search_enabled BOOLEAN NOT NULL DEFAULT FALSE, -- 意味的検索（RAG）機能を有効にするかどうか
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    CONSTRAINT unique_user_llm UNIQUE (user_id, llm_provider) -- ユーザごとにLLM Providerは一意
+);
+
+-- 1300_user_api_keys.sql
+-- MCPサーバーなど外部クライアント向けのAPIキー
+-- キー本体は保存せずSHA-256ハッシュのみを保持する（発行時に一度だけ平文を返す）
+CREATE TABLE IF NOT EXISTS user_api_keys (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL, -- キーの用途を示すラベル
+    key_hash VARCHAR(64) NOT NULL UNIQUE, -- APIキーのSHA-256ハッシュ（hex）
+    key_prefix VARCHAR(16) NOT NULL, -- 一覧表示用のキー先頭部分（例: umi_a1b2c3d4）
+    last_used_at BIGINT, -- 最終使用日時（Unix秒、未使用の場合はNULL）
+    expires_at BIGINT NOT NULL, -- 有効期限（Unix秒）。長期間有効な認証情報が漏洩した際の被害を限定するため必須とする
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_api_keys_user_id ON user_api_keys(user_id);
+
+-- 2000_diaries.sql
+CREATE TABLE IF NOT EXISTS diaries (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) NOT NULL,
+    content TEXT NOT NULL,
+    date DATE NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    CONSTRAINT unique_user_date UNIQUE (user_id, date) -- ユーザーごとに日付は一意
+);
+
+CREATE INDEX index_diaries_user_id_and_date ON diaries (user_id, date);
+
+-- 3100_diary_summary_months.sql
+CREATE TABLE IF NOT EXISTS diary_summary_months (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) NOT NULL,
+    year INTEGER NOT NULL,
+    month INTEGER NOT NULL,
+    summary TEXT NOT NULL DEFAULT '',
+    -- サマリー生成に使用したLLMモデル
+    model_version TEXT NOT NULL DEFAULT 'gemini-2.5-flash-lite',
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    -- LLMがコンテンツポリシーなどで生成を拒否した場合の理由（空文字は正常）
+    error_reason TEXT NOT NULL DEFAULT '',
+    CONSTRAINT unique_user_month UNIQUE (user_id, year, month),
+    CONSTRAINT check_month CHECK (month >= 1 AND month <= 12)
+);
+
+CREATE INDEX index_diary_summary_months_user_id_year_month ON diary_summary_months (user_id, year, month);
+
+-- 4000_entities.sql
+CREATE TABLE IF NOT EXISTS entities (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) NOT NULL,
+    name TEXT NOT NULL,
+    category_id INTEGER NOT NULL DEFAULT 0, -- 0:未分類(no_category), 1:人物(people)
+    memo TEXT,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    UNIQUE(user_id, name) -- 同一ユーザー内でエンティティ名の重複を禁止
+);
+
+CREATE INDEX index_entities_user_id ON entities (user_id);
+CREATE INDEX index_entities_category_id ON entities (category_id);
+
+-- 4100_entity_aliases.sql
+CREATE TABLE IF NOT EXISTS entity_aliases (
+    id UUID PRIMARY KEY,
+    entity_id UUID REFERENCES entities(id) ON DELETE CASCADE NOT NULL,
+    alias TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    -- 同じエンティティに対して同じエイリアスは登録できない
+    UNIQUE(entity_id, alias)
+);
+
+CREATE INDEX index_entity_aliases_entity_id ON entity_aliases (entity_id);
+CREATE INDEX index_entity_aliases_alias ON entity_aliases (alias);
+
+-- エイリアスがユーザー内で一意であることを保証する制約
+-- ユーザーは entities テーブル経由で取得される
+-- Note: PostgreSQLでは複数テーブルにまたがるユニーク制約は直接作成できないため、
+-- アプリケーションロジックとトリガーで対応する
+
+-- 4300_diary_highlights.sql
+-- diary_highlights テーブル
+-- 日記エントリのLLM生成ハイライト情報を格納
+CREATE TABLE diary_highlights (
+    id UUID PRIMARY KEY,
+    diary_id UUID NOT NULL REFERENCES diaries(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    highlights JSONB NOT NULL, -- ハイライト情報の配列 [{"start": 0, "end": 25, "text": "..."}]
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(diary_id)
+);
+
+-- 5000_diary_embeddings.sql
+-- pgvector拡張の有効化
+CREATE EXTENSION IF NOT EXISTS vector;
+
+-- diary_embeddings テーブル
+-- 日記を話題ごとに分割したチャンク単位でベクトル埋め込みを格納（意味的検索用）
+-- 1日記は複数チャンクに分割され、各チャンクが独立したembeddingを持つ
+CREATE TABLE diary_embeddings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    diary_id UUID NOT NULL REFERENCES diaries(id) ON DELETE CASCADE,
+    -- diaries.user_id から導出可能だが、意味的検索クエリでユーザースコープフィルタを
+    -- JOIN なしに行うためのパフォーマンス目的の意図的な非正規化
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- 日記内のチャンクインデックス（0始まり）
+    chunk_index INT NOT NULL DEFAULT 0,
+    -- チャンクのテキスト内容（検索結果のスニペット表示用）
+    chunk_content TEXT NOT NULL DEFAULT '',
+    -- チャンクの概要（検索結果に表示する短い説明）
+    chunk_summary TEXT NOT NULL DEFAULT '',
+    -- ベクトル埋め込み（Gemini gemini-embedding-001 のネイティブ次元数; halfvec はHNSWで4000次元まで対応）
+    embedding halfvec(3072) NOT NULL,
+    -- embedding生成に使用したモデル
+    model_version TEXT NOT NULL DEFAULT 'gemini-embedding-001',
+    -- チャンク分割に使用したLLMモデル
+    chunk_model_version TEXT NOT NULL DEFAULT 'gemini-2.5-flash-lite',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(diary_id, chunk_index)
+);
+
+-- コサイン類似度でのANN検索インデックス（HNSWはivfflatより行数制限がなく安定）
+-- m=16: グラフの接続数（デフォルト16）、ef_construction=64: 構築時の精度（デフォルト64）
+CREATE INDEX idx_diary_embeddings_embedding ON diary_embeddings
+    USING hnsw (embedding halfvec_cosine_ops)
+    WITH (m = 16, ef_construction = 64);
+
+CREATE INDEX idx_diary_embeddings_user_id ON diary_embeddings(user_id);
+
+-- 5200_semantic_search_logs.sql
+-- semantic_search_logs テーブル
+-- 意味的検索（RAG）のAIリクエスト履歴を格納（メトリクス集計用）
+CREATE TABLE semantic_search_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_semantic_search_logs_user_id ON semantic_search_logs(user_id);
+CREATE INDEX idx_semantic_search_logs_created_at ON semantic_search_logs(created_at);
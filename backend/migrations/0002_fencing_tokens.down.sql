@@ -0,0 +1,2 @@
+ALTER TABLE diary_highlights DROP COLUMN IF EXISTS fencing_token;
+ALTER TABLE diary_summary_months DROP COLUMN IF EXISTS fencing_token;
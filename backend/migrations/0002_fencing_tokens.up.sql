@@ -0,0 +1,4 @@
+-- 分散ロックのフェンシングトークン
+-- 書き込み時の分散ロックのフェンシングトークン（これより古いトークンでの上書きを拒否する）
+ALTER TABLE diary_summary_months ADD COLUMN fencing_token BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE diary_highlights ADD COLUMN fencing_token BIGINT NOT NULL DEFAULT 0;
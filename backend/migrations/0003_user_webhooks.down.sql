@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_webhook_deliveries;
+DROP TABLE IF EXISTS user_webhooks;
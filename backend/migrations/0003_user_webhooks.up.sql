@@ -0,0 +1,36 @@
+-- Webhookによる外部サービスへの通知（ADR 0017）
+-- 日記やAI生成のイベントを外部サービス（Home Assistant、n8nなど）へ通知するWebhookエンドポイント
+-- 署名検証のため受信側でもシークレットが必要になるので、ハッシュ化せずに保持する（発行時に一度だけ返す）
+CREATE TABLE IF NOT EXISTS user_webhooks (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    url TEXT NOT NULL, -- 通知先URL（http/httpsのみ）
+    secret VARCHAR(80) NOT NULL, -- HMAC-SHA256署名用のシークレット
+    events JSONB NOT NULL, -- 通知対象のイベント名の配列 ["diary.created", "summary.generated"]
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_webhooks_user_id ON user_webhooks(user_id);
+
+-- Webhookの配信ログ兼配信キュー
+-- イベント発生時にpendingで登録し、subscriberの配信ワーカーが送信・リトライする
+CREATE TABLE IF NOT EXISTS user_webhook_deliveries (
+    id UUID PRIMARY KEY,
+    webhook_id UUID NOT NULL REFERENCES user_webhooks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL, -- イベント名（例: diary.created）
+    payload JSONB NOT NULL, -- 送信するリクエストボディ
+    status VARCHAR(20) NOT NULL, -- pending / succeeded / failed
+    attempts INTEGER NOT NULL DEFAULT 0, -- 送信試行回数
+    response_status INTEGER NOT NULL DEFAULT 0, -- 直近の試行のHTTPステータス（通信エラー時は0）
+    last_error TEXT NOT NULL DEFAULT '', -- 直近の試行のエラー内容
+    next_attempt_at BIGINT NOT NULL, -- 次回送信予定時刻（Unix秒）
+    delivered_at BIGINT, -- 送信成功日時（Unix秒、未成功の場合はNULL）
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_webhook_deliveries_webhook_id ON user_webhook_deliveries(webhook_id, created_at DESC);
+-- 配信ワーカーが送信待ちの行を取得するための部分インデックス
+CREATE INDEX IF NOT EXISTS idx_user_webhook_deliveries_pending ON user_webhook_deliveries(next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,8 @@
+-- 同じ日付に2件以上の日記があると unique_user_date を作成できないため、先に統合・削除しておく必要がある
+ALTER TABLE diaries DROP CONSTRAINT IF EXISTS check_diaries_entry_time;
+ALTER TABLE diaries DROP CONSTRAINT IF EXISTS unique_user_date_entry;
+ALTER TABLE diaries ADD CONSTRAINT unique_user_date UNIQUE (user_id, date);
+
+ALTER TABLE diaries DROP COLUMN IF EXISTS entry_index;
+ALTER TABLE diaries DROP COLUMN IF EXISTS entry_time;
+ALTER TABLE diaries DROP COLUMN IF EXISTS title;
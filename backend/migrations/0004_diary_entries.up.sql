@@ -0,0 +1,11 @@
+-- 1日に複数の日記エントリ（ADR 0018）
+-- 既存の日記はデフォルト値により title = ''・entry_time = NULL・entry_index = 0 となり、その日の1件目のエントリになる
+ALTER TABLE diaries ADD COLUMN title VARCHAR(100) NOT NULL DEFAULT ''; -- 任意のタイトル
+ALTER TABLE diaries ADD COLUMN entry_time INTEGER; -- 時刻（0時からの経過分）。未指定の場合はNULL
+-- 同じ日付内の通し番号。1日1件の日記（追加前から存在する日記を含む）は0になるため、
+-- unique_user_dateからの移行時も既存の日記はそのまま一意性を保つ
+ALTER TABLE diaries ADD COLUMN entry_index INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE diaries DROP CONSTRAINT unique_user_date;
+ALTER TABLE diaries ADD CONSTRAINT unique_user_date_entry UNIQUE (user_id, date, entry_index); -- 同じ日付に複数の日記を書ける
+ALTER TABLE diaries ADD CONSTRAINT check_diaries_entry_time CHECK (entry_time IS NULL OR (entry_time >= 0 AND entry_time < 1440));
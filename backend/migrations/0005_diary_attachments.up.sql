@@ -0,0 +1,22 @@
+-- 日記の添付ファイル（ADR 0019）
+-- 日記に添付された写真・ファイル
+-- ファイルの実体はオブジェクトストレージ（ローカルファイルシステム/S3互換）に保存し、ここではメタデータのみを持つ
+-- 日記・ユーザーの削除時はCASCADEで行が消えるため、ストレージ上の実体はアプリケーション側で削除する
+CREATE TABLE IF NOT EXISTS diary_attachments (
+    id UUID PRIMARY KEY,
+    diary_id UUID NOT NULL REFERENCES diaries(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    filename VARCHAR(255) NOT NULL, -- アップロード時のファイル名
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL, -- 元ファイルのサイズ（容量制限の集計に使う）
+    storage_key TEXT NOT NULL, -- 元ファイルのストレージ上のキー
+    thumbnail_key TEXT NOT NULL DEFAULT '', -- サムネイルのキー（生成できない形式の場合は空文字）
+    taken_at BIGINT, -- EXIFの撮影日時（Unix timestamp）
+    latitude DOUBLE PRECISION, -- EXIFの撮影位置
+    longitude DOUBLE PRECISION,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_diary_attachments_diary_id ON diary_attachments(diary_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_diary_attachments_user_id ON diary_attachments(user_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS diary_tag_suggestions;
+DROP TABLE IF EXISTS diary_tags;
+DROP TABLE IF EXISTS tags;
+ALTER TABLE user_llms DROP COLUMN IF EXISTS auto_tagging_enabled;
@@ -0,0 +1,34 @@
+-- 日記のタグ（ADR 0020）
+ALTER TABLE user_llms ADD COLUMN auto_tagging_enabled BOOLEAN NOT NULL DEFAULT FALSE; -- 新しい日記へのタグの自動提案を行うかどうか
+
+-- ユーザーが定義する日記のタグ
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(7) NOT NULL, -- 表示色（#rrggbb形式）
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    CONSTRAINT unique_user_tag_name UNIQUE (user_id, name) -- 同一ユーザー内でタグ名の重複を禁止
+);
+
+-- 日記とタグの関連（多対多）
+CREATE TABLE IF NOT EXISTS diary_tags (
+    diary_id UUID NOT NULL REFERENCES diaries(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (diary_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_diary_tags_tag_id ON diary_tags(tag_id);
+
+-- LLMによる日記へのタグの提案
+-- ユーザーが日記のタグを設定すると、その日記の提案は削除する
+CREATE TABLE IF NOT EXISTS diary_tag_suggestions (
+    diary_id UUID NOT NULL REFERENCES diaries(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (diary_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_diary_tag_suggestions_tag_id ON diary_tag_suggestions(tag_id);
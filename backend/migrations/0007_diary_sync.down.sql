@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS diary_tombstones;
+DROP TABLE IF EXISTS diary_change_sequences;
+DROP INDEX IF EXISTS index_diaries_user_id_and_change_seq;
+ALTER TABLE diaries DROP COLUMN IF EXISTS change_seq;
+ALTER TABLE diaries DROP COLUMN IF EXISTS version;
@@ -0,0 +1,28 @@
+-- 日記の差分同期（ADR 0021）・楽観的な同時更新制御（ADR 0022）
+-- 楽観的な同時更新制御のためのバージョン。利用者による編集のたびに1ずつ増える
+ALTER TABLE diaries ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+-- 差分同期のための変更番号（diary_change_sequencesで採番するユーザーごとの単調増加値）
+-- 追加前から存在する日記は0になり、初回の同期で全件が返る
+ALTER TABLE diaries ADD COLUMN change_seq BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS index_diaries_user_id_and_change_seq ON diaries (user_id, change_seq, id);
+
+-- ユーザーごとの日記の変更番号の採番（差分同期用）
+-- 日記を変更するトランザクションの中で last_seq を1増やす。行ロックにより同じユーザーの変更は
+-- 採番順にコミットされるため、同期のカーソルより小さい変更番号が後から現れることはない
+CREATE TABLE IF NOT EXISTS diary_change_sequences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    last_seq BIGINT NOT NULL DEFAULT 0
+);
+
+-- 削除された日記の記録（差分同期でクライアントに削除を伝えるため）
+-- 日記の行は削除済みのため外部キーは張らない
+CREATE TABLE IF NOT EXISTS diary_tombstones (
+    diary_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    date DATE NOT NULL, -- 削除した日記の日付（クライアントが該当日の表示を更新するため）
+    change_seq BIGINT NOT NULL,
+    deleted_at BIGINT NOT NULL
+);
+
+CREATE INDEX index_diary_tombstones_user_id_and_change_seq ON diary_tombstones (user_id, change_seq, diary_id);
@@ -0,0 +1,2 @@
+-- エンティティの楽観的な同時更新制御（ADR 0022）
+ALTER TABLE entities ADD COLUMN version BIGINT NOT NULL DEFAULT 1; -- 楽観的な同時更新制御のためのバージョン。更新のたびに1ずつ増える
@@ -0,0 +1,8 @@
+-- ゴミ箱のエンティティと同じ名前のエンティティがあると一意制約を作成できないため、先にゴミ箱を空にしておく必要がある
+DROP INDEX IF EXISTS index_entities_deleted_at;
+DROP INDEX IF EXISTS entities_user_id_name_key;
+ALTER TABLE entities ADD CONSTRAINT entities_user_id_name_key UNIQUE (user_id, name);
+ALTER TABLE entities DROP COLUMN IF EXISTS deleted_at;
+
+DROP INDEX IF EXISTS index_diaries_deleted_at;
+ALTER TABLE diaries DROP COLUMN IF EXISTS deleted_at;
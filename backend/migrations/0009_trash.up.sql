@@ -0,0 +1,12 @@
+-- 日記・エンティティのゴミ箱（ADR 0023）
+-- ゴミ箱に移動した日時（UNIX秒）。NULLの場合はゴミ箱にない
+-- 保持期間を過ぎるとスケジューラーが行を削除する
+ALTER TABLE diaries ADD COLUMN deleted_at BIGINT;
+CREATE INDEX IF NOT EXISTS index_diaries_deleted_at ON diaries (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE entities ADD COLUMN deleted_at BIGINT; -- ゴミ箱に移動した日時（UNIX秒）。NULLの場合はゴミ箱にない
+-- 同一ユーザー内でエンティティ名の重複を禁止（ゴミ箱のエンティティと同じ名前は使える）
+-- ベースラインの UNIQUE(user_id, name) 制約を、ゴミ箱にない行だけを対象にした部分一意インデックスに置き換える
+ALTER TABLE entities DROP CONSTRAINT entities_user_id_name_key;
+CREATE UNIQUE INDEX entities_user_id_name_key ON entities (user_id, name) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS index_entities_deleted_at ON entities (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,18 @@
+-- 個人データのエクスポート（ADR 0024）
+-- 個人データの持ち出し（データエクスポート）のジョブと、生成したアーカイブ
+-- アーカイブの実体は添付ファイルと同じストレージに保存し、期限を過ぎたらスケジューラーが削除する
+CREATE TABLE IF NOT EXISTS user_data_exports (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL, -- queued / processing / succeeded / failed
+    storage_key TEXT NOT NULL DEFAULT '', -- アーカイブのストレージ上のキー（生成前は空文字）
+    size_bytes BIGINT NOT NULL DEFAULT 0, -- アーカイブのサイズ
+    error_message TEXT NOT NULL DEFAULT '', -- 失敗時のエラー内容
+    completed_at BIGINT, -- 生成の完了日時（Unix秒、未完了の場合はNULL）
+    expires_at BIGINT, -- ダウンロードの期限（Unix秒、未完了の場合はNULL）
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_data_exports_user_id ON user_data_exports(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_user_data_exports_expires_at ON user_data_exports(expires_at) WHERE expires_at IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_backups;
+DROP TABLE IF EXISTS user_backup_settings;
@@ -0,0 +1,46 @@
+-- 日記の定期バックアップ（ADR 0026）
+-- 日記の定期バックアップの設定と、直近の実行結果
+-- スケジューラーがnext_run_atを過ぎた設定を拾い、個人データのアーカイブを保存先へ書き込む
+-- 保存先の認証情報はスケジューラーが無人で使うため平文で保持する（LLMキーと同様、レスポンスには含めない）
+CREATE TABLE IF NOT EXISTS user_backup_settings (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    destination VARCHAR(16) NOT NULL, -- local / s3 / webdav
+    prefix TEXT NOT NULL DEFAULT '', -- 保存先でのキーの接頭辞（"umi-mikan/backups" など）
+    interval_hours INTEGER NOT NULL DEFAULT 24 CHECK (interval_hours > 0), -- 実行間隔（時間）
+    retention_count INTEGER NOT NULL DEFAULT 7 CHECK (retention_count BETWEEN 1 AND 100), -- 残すバックアップの数
+    s3_endpoint TEXT NOT NULL DEFAULT '',
+    s3_region TEXT NOT NULL DEFAULT '',
+    s3_bucket TEXT NOT NULL DEFAULT '',
+    s3_access_key_id TEXT NOT NULL DEFAULT '',
+    s3_secret_access_key TEXT NOT NULL DEFAULT '',
+    s3_use_path_style BOOLEAN NOT NULL DEFAULT FALSE,
+    webdav_url TEXT NOT NULL DEFAULT '',
+    webdav_username TEXT NOT NULL DEFAULT '',
+    webdav_password TEXT NOT NULL DEFAULT '',
+    -- 暗号化の公開鍵（X25519）。パスフレーズと鍵導出のソルトから作る秘密鍵はサーバーに保存しない（NULLの場合は暗号化しない）
+    encryption_public_key BYTEA,
+    encryption_salt BYTEA,
+    next_run_at BIGINT NOT NULL, -- 次回の実行予定日時（Unix秒）
+    last_run_at BIGINT, -- 最後に実行した日時（Unix秒、未実行の場合はNULL）
+    last_status VARCHAR(16) NOT NULL DEFAULT '', -- 最後の実行結果（succeeded / failed、未実行の場合は空文字）
+    last_error TEXT NOT NULL DEFAULT '', -- 最後の実行が失敗した場合のエラー内容
+    last_size_bytes BIGINT NOT NULL DEFAULT 0, -- 最後に成功したバックアップのサイズ
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_backup_settings_next_run_at ON user_backup_settings(next_run_at) WHERE enabled;
+
+-- 定期バックアップで保存したアーカイブ（保持数を超えた古いものを削除するために記録する）
+CREATE TABLE IF NOT EXISTS user_backups (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    location TEXT NOT NULL, -- 保存先の識別子（保存先を変更した場合、以前の保存先のバックアップは削除しない）
+    object_key TEXT NOT NULL, -- 保存先でのキー
+    size_bytes BIGINT NOT NULL,
+    encrypted BOOLEAN NOT NULL,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_backups_user_id ON user_backups(user_id, created_at);
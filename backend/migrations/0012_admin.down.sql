@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS admin_audit_logs;
+ALTER TABLE user_password_authes DROP COLUMN IF EXISTS reset_required;
+ALTER TABLE users DROP COLUMN IF EXISTS sessions_revoked_at;
+ALTER TABLE users DROP COLUMN IF EXISTS disabled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
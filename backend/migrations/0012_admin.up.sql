@@ -0,0 +1,22 @@
+-- 管理者の権限とユーザーの管理（ADR 0029）
+ALTER TABLE users ADD COLUMN role SMALLINT NOT NULL DEFAULT 0; -- 0:一般 1:管理者
+ALTER TABLE users ADD COLUMN disabled_at BIGINT; -- 管理者が無効にした日時（UNIX秒）。NULLの場合は有効
+-- この日時以前に発行したリフレッシュトークンを受け付けない（UNIX秒）。すべてのセッションの取り消しに使う
+ALTER TABLE users ADD COLUMN sessions_revoked_at BIGINT;
+
+-- 管理者がパスワードの再設定を求めている。パスワードを変更するとfalseに戻る
+ALTER TABLE user_password_authes ADD COLUMN reset_required BOOLEAN NOT NULL DEFAULT false;
+
+-- 管理者の操作の監査ログ
+-- ユーザーを削除しても記録を残すため、ユーザーへの外部キーは付けない
+CREATE TABLE IF NOT EXISTS admin_audit_logs (
+    id UUID PRIMARY KEY,
+    admin_user_id UUID NOT NULL, -- 操作した管理者
+    target_user_id UUID NOT NULL, -- 操作の対象のユーザー
+    action VARCHAR(50) NOT NULL, -- 操作（disable_user など）
+    detail JSONB NOT NULL DEFAULT '{}', -- 操作の詳細（変更前後の値、理由など）
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_audit_logs_created_at ON admin_audit_logs (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_logs_target_user_id ON admin_audit_logs (target_user_id, created_at DESC, id DESC);
@@ -0,0 +1,14 @@
+-- OpenID Connectのプロバイダーとの連携（ADR 0030）
+-- users.auth_type に 1:OpenID Connect（パスワードを持たず、OIDCで登録したユーザー）を追加する
+
+CREATE TABLE IF NOT EXISTS user_oauthes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL, -- プロバイダーの識別子（OIDC_PROVIDERSで設定した名前）
+    subject VARCHAR(255) NOT NULL, -- IDトークンのsub（プロバイダーでのユーザーの識別子）
+    email VARCHAR(255) NOT NULL, -- 連携した時点のプロバイダーのメールアドレス（表示用）
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    CONSTRAINT user_oauthes_provider_subject_key UNIQUE (provider, subject), -- プロバイダーのアカウントは1人のユーザーにのみ連携する
+    CONSTRAINT user_oauthes_user_id_provider_key UNIQUE (user_id, provider) -- ユーザーはプロバイダーごとに1つのアカウントを連携する
+);
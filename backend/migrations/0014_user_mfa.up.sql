@@ -0,0 +1,21 @@
+-- TOTPによる2段階認証とリカバリーコード（ADR 0031）
+
+-- ユーザーのTOTPの秘密鍵（1人1つ）
+CREATE TABLE IF NOT EXISTS user_totps (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL, -- Base32の秘密鍵
+    enabled_at BIGINT, -- 確認コードで有効にした日時（UNIX秒）。NULLの場合は登録中で、ログインには使わない
+    last_used_step BIGINT NOT NULL DEFAULT 0, -- 最後に使ったコードのタイムステップ（同じコードの再利用を防ぐ）
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+-- TOTPのコードを使えない場合のリカバリーコード（1回のみ使える）
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hashed VARCHAR(64) NOT NULL, -- SHA-256（16進数）
+    used_at BIGINT, -- 使った日時（UNIX秒）。NULLの場合は未使用
+    created_at BIGINT NOT NULL,
+    CONSTRAINT user_recovery_codes_user_id_code_hashed_key UNIQUE (user_id, code_hashed)
+);
@@ -0,0 +1,18 @@
+-- パスキー（WebAuthn）でのログインと2段階認証（ADR 0032）
+
+-- ユーザーが登録したパスキー（1人で複数登録できる）
+CREATE TABLE IF NOT EXISTS user_passkeys (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    credential_id VARCHAR(1400) NOT NULL, -- 認証器が発行したcredential ID（base64url）
+    public_key BYTEA NOT NULL, -- COSE形式の公開鍵
+    sign_count BIGINT NOT NULL DEFAULT 0, -- 署名カウンター（複製された認証器の検出に使う。同期するパスキーは常に0）
+    transports JSONB NOT NULL, -- 認証器との通信方法の配列 ["internal", "hybrid"]
+    name VARCHAR(100) NOT NULL, -- ユーザーが付けた名前（例: iPhone）
+    last_used_at BIGINT, -- 最後にログインに使った日時（UNIX秒）
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    CONSTRAINT user_passkeys_credential_id_key UNIQUE (credential_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_passkeys_user_id ON user_passkeys(user_id);
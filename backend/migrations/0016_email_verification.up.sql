@@ -0,0 +1,3 @@
+-- パスワードの再設定とメールアドレスの確認（ADR 0033）
+-- メールアドレスを確認した日時（UNIX秒）。NULLの場合は未確認（この変更以前に登録したユーザーを含む）
+ALTER TABLE users ADD COLUMN email_verified_at BIGINT;
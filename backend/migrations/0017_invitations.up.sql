@@ -0,0 +1,29 @@
+-- 招待コードでの新規登録の制限（ADR 0034）
+
+-- ユーザーが発行した招待コード（コード自体は保存せずハッシュのみ）
+CREATE TABLE IF NOT EXISTS invitations (
+    id UUID PRIMARY KEY,
+    code_hash VARCHAR(64) NOT NULL, -- 招待コードのSHA-256（hex）
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    note VARCHAR(100) NOT NULL DEFAULT '', -- 発行者が付けたメモ（例: 渡した相手）
+    max_uses INTEGER NOT NULL,
+    use_count INTEGER NOT NULL DEFAULT 0,
+    expires_at BIGINT NOT NULL,
+    revoked_at BIGINT, -- 発行者が取り消した日時（UNIX秒）
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    CONSTRAINT invitations_code_hash_key UNIQUE (code_hash),
+    CONSTRAINT invitations_use_count_check CHECK (use_count >= 0 AND use_count <= max_uses)
+);
+
+CREATE INDEX IF NOT EXISTS idx_invitations_created_by ON invitations(created_by, created_at);
+
+-- 招待コードで登録したユーザー（ユーザーを削除しても記録を残すため、usersへの外部キーは付けない）
+CREATE TABLE IF NOT EXISTS invitation_uses (
+    id UUID PRIMARY KEY,
+    invitation_id UUID NOT NULL REFERENCES invitations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_invitation_uses_invitation_id ON invitation_uses(invitation_id, created_at);
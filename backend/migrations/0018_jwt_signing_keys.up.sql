@@ -0,0 +1,14 @@
+-- JWTの非対称鍵での署名と鍵のローテーション（ADR 0035）
+
+-- JWTの署名鍵（activates_atが最も新しい有効な鍵で署名し、期限内の鍵はすべて検証に使う）
+CREATE TABLE IF NOT EXISTS jwt_signing_keys (
+    id VARCHAR(64) PRIMARY KEY, -- JWTのヘッダーのkid
+    algorithm VARCHAR(10) NOT NULL, -- EdDSA または RS256
+    private_key BYTEA NOT NULL, -- PKCS#8の秘密鍵（JWT_SECRETから導出した鍵でAES-256-GCMで暗号化）
+    public_key BYTEA NOT NULL, -- PKIXの公開鍵
+    activates_at BIGINT NOT NULL, -- 署名に使い始める日時（それまではJWKSで公開するだけ）
+    expires_at BIGINT, -- 検証に使わなくなる日時（次の鍵に切り替えるまではNULL）
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_jwt_signing_keys_activates_at ON jwt_signing_keys(activates_at);
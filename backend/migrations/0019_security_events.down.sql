@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS security_events;
+DROP FUNCTION IF EXISTS reject_security_event_update();
@@ -0,0 +1,26 @@
+-- 認証・アカウントのセキュリティイベントの記録（ADR 0036）
+
+-- ユーザーのセキュリティイベント（追記のみ。ユーザーを削除した場合はまとめて削除する）
+CREATE TABLE IF NOT EXISTS security_events (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL, -- login_success・login_failure・token_refresh・password_change・api_key_create・api_key_delete・api_key_use・oauth_consent
+    ip_address VARCHAR(64) NOT NULL, -- クライアントのIPアドレス（取得できない場合は unknown）
+    user_agent TEXT NOT NULL, -- クライアントのUser-Agent（取得できない場合は unknown）
+    detail JSONB NOT NULL DEFAULT '{}', -- イベントの詳細（ログインの方法、APIキーのIDなど）
+    suspicious BOOLEAN NOT NULL DEFAULT false, -- それまでのログインと異なるIPアドレス・User-Agentからのログイン
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_user_id ON security_events (user_id, created_at DESC, id DESC);
+
+-- 記録した後に書き換えられないよう、UPDATEを拒否する
+CREATE OR REPLACE FUNCTION reject_security_event_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'security_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER security_events_append_only
+    BEFORE UPDATE ON security_events
+    FOR EACH ROW EXECUTE FUNCTION reject_security_event_update();
@@ -0,0 +1,7 @@
+-- アカウントの削除の猶予期間（ADR 0037）
+-- 削除を依頼した日時と、データを完全に削除する予定の日時（UNIX秒）。NULLの場合は削除を依頼していない
+ALTER TABLE users ADD COLUMN deletion_requested_at BIGINT;
+ALTER TABLE users ADD COLUMN deletion_scheduled_at BIGINT;
+
+-- スケジューラーが削除の予定日時を過ぎたユーザーを探すため
+CREATE INDEX idx_users_deletion_scheduled_at ON users (deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
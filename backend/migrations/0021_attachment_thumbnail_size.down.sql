@@ -0,0 +1 @@
+ALTER TABLE diary_attachments DROP COLUMN IF EXISTS thumbnail_size_bytes;
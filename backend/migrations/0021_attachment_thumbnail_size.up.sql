@@ -0,0 +1,3 @@
+-- 添付ファイルの容量制限にサムネイルを含める（ADR 0019）
+-- 追加前に生成したサムネイルのサイズは分からないため0とし、容量の集計には含めない
+ALTER TABLE diary_attachments ADD COLUMN thumbnail_size_bytes BIGINT NOT NULL DEFAULT 0; -- サムネイルのサイズ（サムネイルがない場合は0）
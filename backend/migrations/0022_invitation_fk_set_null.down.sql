@@ -0,0 +1,12 @@
+-- 発行者・招待コードが削除された行はNOT NULLに戻せないため削除する
+DELETE FROM invitation_uses WHERE invitation_id IS NULL;
+ALTER TABLE invitation_uses DROP CONSTRAINT IF EXISTS invitation_uses_invitation_id_fkey;
+ALTER TABLE invitation_uses ADD CONSTRAINT invitation_uses_invitation_id_fkey
+    FOREIGN KEY (invitation_id) REFERENCES invitations(id) ON DELETE CASCADE;
+ALTER TABLE invitation_uses ALTER COLUMN invitation_id SET NOT NULL;
+
+DELETE FROM invitations WHERE created_by IS NULL;
+ALTER TABLE invitations DROP CONSTRAINT IF EXISTS invitations_created_by_fkey;
+ALTER TABLE invitations ADD CONSTRAINT invitations_created_by_fkey
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE invitations ALTER COLUMN created_by SET NOT NULL;
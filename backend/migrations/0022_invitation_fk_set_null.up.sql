@@ -0,0 +1,12 @@
+-- 招待コードを発行したユーザーを削除しても、他のユーザーの招待コードの使用の記録を残す（ADR 0034、ADR 0037）
+-- 発行者の削除で招待コードを消すと、invitation_usesもCASCADEで消えていたため、どちらもSET NULLにする
+-- 発行者が削除された招待コードは使えない（ConsumeInvitationで除外する）
+ALTER TABLE invitations ALTER COLUMN created_by DROP NOT NULL;
+ALTER TABLE invitations DROP CONSTRAINT IF EXISTS invitations_created_by_fkey;
+ALTER TABLE invitations ADD CONSTRAINT invitations_created_by_fkey
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE invitation_uses ALTER COLUMN invitation_id DROP NOT NULL;
+ALTER TABLE invitation_uses DROP CONSTRAINT IF EXISTS invitation_uses_invitation_id_fkey;
+ALTER TABLE invitation_uses ADD CONSTRAINT invitation_uses_invitation_id_fkey
+    FOREIGN KEY (invitation_id) REFERENCES invitations(id) ON DELETE SET NULL;
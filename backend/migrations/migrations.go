@@ -0,0 +1,11 @@
+// Package migrations はデータベースのマイグレーションのSQLファイルを埋め込む。
+// ファイル名は `<バージョン>_<名前>.up.sql` と、戻す場合の `<バージョン>_<名前>.down.sql` とする
+// （適用は infrastructure/migrate、ADR 0028）。
+package migrations
+
+import "embed"
+
+// FS はマイグレーションのSQLファイル
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,475 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/domain/model"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 監査ログに記録する操作
+const (
+	ActionSetUserRole              = "set_user_role"
+	ActionDisableUser              = "disable_user"
+	ActionEnableUser               = "enable_user"
+	ActionForcePasswordReset       = "force_password_reset"
+	ActionRevokeUserCredentials    = "revoke_user_credentials"
+	ActionRegenerateUserEmbeddings = "regenerate_user_embeddings"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// EmbeddingRegenerator は埋め込みベクトルが未生成の日記をキューに追加する（DiaryServiceのRegenerateAllEmbeddings）
+// 対象のユーザーはコンテキストのユーザーIDで指定する
+type EmbeddingRegenerator interface {
+	RegenerateAllEmbeddings(ctx context.Context, req *g.RegenerateAllEmbeddingsRequest) (*g.RegenerateAllEmbeddingsResponse, error)
+}
+
+type AdminEntry struct {
+	g.UnimplementedAdminServiceServer
+	DB         *sql.DB
+	Embeddings EmbeddingRegenerator
+}
+
+// pageCursor は一覧で最後に返した行の位置（作成日時とID）
+type pageCursor struct {
+	createdAt int64
+	id        uuid.UUID
+}
+
+// parsePageCursor はクライアントから受け取ったカーソル（"作成日時_ID"）を解析する。空の場合はinitialを返す
+func parsePageCursor(s string, initial pageCursor) (pageCursor, error) {
+	if s == "" {
+		return initial, nil
+	}
+	createdAtStr, idStr, ok := strings.Cut(s, "_")
+	if !ok {
+		return pageCursor{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	createdAt, err := strconv.ParseInt(createdAtStr, 10, 64)
+	if err != nil {
+		return pageCursor{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return pageCursor{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	return pageCursor{createdAt: createdAt, id: id}, nil
+}
+
+func (c pageCursor) String() string {
+	return fmt.Sprintf("%d_%s", c.createdAt, c.id)
+}
+
+// listLimit はリクエストの件数をデフォルト・最大値に収める
+func listLimit(limit int32) int {
+	if limit <= 0 {
+		return defaultListLimit
+	}
+	return min(int(limit), maxListLimit)
+}
+
+// parseTargetUserID はリクエストの対象ユーザーIDを解析する
+func parseTargetUserID(s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	return id, nil
+}
+
+// adminUserID はコンテキストから操作している管理者のユーザーIDを取得する
+func adminUserID(ctx context.Context) (uuid.UUID, error) {
+	userIDStr, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	id, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "invalid user id")
+	}
+	return id, nil
+}
+
+func (s *AdminEntry) ListUsers(ctx context.Context, req *g.ListUsersRequest) (*g.ListUsersResponse, error) {
+	cursor, err := parsePageCursor(req.GetCursor(), pageCursor{createdAt: math.MinInt64})
+	if err != nil {
+		return nil, err
+	}
+	limit := listLimit(req.GetLimit())
+
+	// 次のページがあるかを判定するため1件多く取得する
+	rows, err := database.AdminUsersAfter(ctx, s.DB, strings.TrimSpace(req.GetQuery()), cursor.createdAt, cursor.id, limit+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
+	}
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	resp := &g.ListUsersResponse{Users: make([]*g.AdminUser, 0, len(rows)), HasMore: hasMore}
+	for _, row := range rows {
+		resp.Users = append(resp.Users, toAdminUser(row))
+	}
+	if len(rows) > 0 {
+		last := rows[len(rows)-1].User
+		resp.NextCursor = pageCursor{createdAt: last.CreatedAt, id: last.ID}.String()
+	}
+	return resp, nil
+}
+
+func (s *AdminEntry) GetUserUsage(ctx context.Context, req *g.GetUserUsageRequest) (*g.GetUserUsageResponse, error) {
+	userID, err := parseTargetUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.adminUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := database.UserUsageStatsByUserID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user usage: %v", err)
+	}
+	metrics, err := database.HourlyPubSubMetrics(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get hourly metrics: %v", err)
+	}
+
+	hourly := make([]*g.UsageHourlyMetric, 0, len(metrics))
+	for _, m := range metrics {
+		hourly = append(hourly, &g.UsageHourlyMetric{
+			Timestamp:                 m.Hour.Unix(),
+			MonthlySummariesProcessed: m.MonthlySummariesProcessed,
+			DiaryEmbeddingsProcessed:  m.EmbeddingsProcessed,
+			SemanticSearchesProcessed: m.SemanticSearchesProcessed,
+		})
+	}
+	return &g.GetUserUsageResponse{
+		User: toAdminUser(row),
+		Usage: &g.UserUsage{
+			DiaryCount:              stats.DiaryCount,
+			TrashedDiaryCount:       stats.TrashedDiaryCount,
+			EmbeddingCount:          stats.EmbeddingCount,
+			EmbeddedDiaryCount:      stats.EmbeddedDiaryCount,
+			MonthlySummaryCount:     stats.MonthlySummaryCount,
+			SemanticSearchCount:     stats.SemanticSearchCount,
+			SemanticSearchCount_30D: stats.SemanticSearchCount30d,
+			AttachmentBytes:         stats.AttachmentBytes,
+			ApiKeyCount:             stats.APIKeyCount,
+			LlmKeyConfigured:        stats.LLMKeyConfigured,
+		},
+		HourlyMetrics: hourly,
+	}, nil
+}
+
+func (s *AdminEntry) SetUserRole(ctx context.Context, req *g.SetUserRoleRequest) (*g.SetUserRoleResponse, error) {
+	userID, err := parseTargetUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	role := model.UserRole(req.GetRole())
+	if role != model.UserRoleUser && role != model.UserRoleAdmin {
+		return nil, status.Error(codes.InvalidArgument, "invalid role")
+	}
+	if err := rejectSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	row, err := s.mutateUser(ctx, userID, ActionSetUserRole, func(tx *sql.Tx, user *database.User, now int64) (map[string]any, error) {
+		before := user.Role
+		user.Role = role.Int16()
+		user.UpdatedAt = now
+		if err := user.Update(ctx, tx); err != nil {
+			return nil, err
+		}
+		return map[string]any{"before": before, "after": user.Role}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &g.SetUserRoleResponse{User: toAdminUser(row)}, nil
+}
+
+func (s *AdminEntry) DisableUser(ctx context.Context, req *g.DisableUserRequest) (*g.DisableUserResponse, error) {
+	userID, err := parseTargetUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if err := rejectSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	row, err := s.mutateUser(ctx, userID, ActionDisableUser, func(tx *sql.Tx, user *database.User, now int64) (map[string]any, error) {
+		// 無効にした日時は最初に無効にした時のものを残す
+		if !user.DisabledAt.Valid {
+			user.DisabledAt = sql.NullInt64{Int64: now, Valid: true}
+			user.UpdatedAt = now
+			if err := user.Update(ctx, tx); err != nil {
+				return nil, err
+			}
+		}
+		return map[string]any{"reason": req.GetReason()}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &g.DisableUserResponse{User: toAdminUser(row)}, nil
+}
+
+func (s *AdminEntry) EnableUser(ctx context.Context, req *g.EnableUserRequest) (*g.EnableUserResponse, error) {
+	userID, err := parseTargetUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.mutateUser(ctx, userID, ActionEnableUser, func(tx *sql.Tx, user *database.User, now int64) (map[string]any, error) {
+		detail := map[string]any{"disabled_at": nil}
+		if user.DisabledAt.Valid {
+			detail["disabled_at"] = user.DisabledAt.Int64
+			user.DisabledAt = sql.NullInt64{}
+			user.UpdatedAt = now
+			if err := user.Update(ctx, tx); err != nil {
+				return nil, err
+			}
+		}
+		return detail, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &g.EnableUserResponse{User: toAdminUser(row)}, nil
+}
+
+func (s *AdminEntry) ForcePasswordReset(ctx context.Context, req *g.ForcePasswordResetRequest) (*g.ForcePasswordResetResponse, error) {
+	userID, err := parseTargetUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.mutateUser(ctx, userID, ActionForcePasswordReset, func(tx *sql.Tx, user *database.User, now int64) (map[string]any, error) {
+		updated, err := database.SetPasswordResetRequired(ctx, tx, user.ID, true, now)
+		if err != nil {
+			return nil, err
+		}
+		if updated == 0 {
+			return nil, status.Error(codes.NotFound, "password auth not found")
+		}
+		// 漏洩したパスワードで発行されたセッションを使い続けられないよう、すべてのセッションを取り消す
+		if err := database.RevokeUserSessions(ctx, tx, user.ID, now); err != nil {
+			return nil, err
+		}
+		return map[string]any{}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &g.ForcePasswordResetResponse{User: toAdminUser(row)}, nil
+}
+
+func (s *AdminEntry) RevokeUserCredentials(ctx context.Context, req *g.RevokeUserCredentialsRequest) (*g.RevokeUserCredentialsResponse, error) {
+	userID, err := parseTargetUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	var revoked int64
+	_, err = s.mutateUser(ctx, userID, ActionRevokeUserCredentials, func(tx *sql.Tx, user *database.User, now int64) (map[string]any, error) {
+		if err := database.RevokeUserSessions(ctx, tx, user.ID, now); err != nil {
+			return nil, err
+		}
+		n, err := database.DeleteUserAPIKeysByUserID(ctx, tx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		revoked = n
+		return map[string]any{"revoked_api_key_count": n}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &g.RevokeUserCredentialsResponse{RevokedApiKeyCount: int32(revoked)}, nil
+}
+
+func (s *AdminEntry) RegenerateUserEmbeddings(ctx context.Context, req *g.RegenerateUserEmbeddingsRequest) (*g.RegenerateUserEmbeddingsResponse, error) {
+	adminID, err := adminUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := parseTargetUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.adminUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// 対象のユーザーとして再生成する（APIキー・設定の確認やロックはDiaryServiceと共通）
+	res, err := s.Embeddings.RegenerateAllEmbeddings(context.WithValue(ctx, middleware.UserIDKey, userID.String()), &g.RegenerateAllEmbeddingsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	// キューへの追加は取り消せないため、監査ログの記録に失敗してもエラーにせずログに残す
+	if err := insertAuditLog(ctx, s.DB, adminID, userID, ActionRegenerateUserEmbeddings,
+		map[string]any{"queued_count": res.GetQueuedCount()}, time.Now().Unix()); err != nil {
+		log.Printf("admin: failed to record audit log for %s on user %s: %v", ActionRegenerateUserEmbeddings, userID, err)
+	}
+	return &g.RegenerateUserEmbeddingsResponse{QueuedCount: res.GetQueuedCount()}, nil
+}
+
+func (s *AdminEntry) ListAuditLogs(ctx context.Context, req *g.ListAuditLogsRequest) (*g.ListAuditLogsResponse, error) {
+	var targetUserID uuid.UUID
+	if req.GetTargetUserId() != "" {
+		id, err := parseTargetUserID(req.GetTargetUserId())
+		if err != nil {
+			return nil, err
+		}
+		targetUserID = id
+	}
+	cursor, err := parsePageCursor(req.GetCursor(), pageCursor{createdAt: math.MaxInt64, id: uuid.Max})
+	if err != nil {
+		return nil, err
+	}
+	limit := listLimit(req.GetLimit())
+
+	rows, err := database.AdminAuditLogsBefore(ctx, s.DB, targetUserID, cursor.createdAt, cursor.id, limit+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list audit logs: %v", err)
+	}
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	resp := &g.ListAuditLogsResponse{Logs: make([]*g.AuditLog, 0, len(rows)), HasMore: hasMore}
+	for _, row := range rows {
+		resp.Logs = append(resp.Logs, &g.AuditLog{
+			Id:           row.Log.ID.String(),
+			AdminUserId:  row.Log.AdminUserID.String(),
+			AdminEmail:   row.AdminEmail,
+			TargetUserId: row.Log.TargetUserID.String(),
+			Action:       row.Log.Action,
+			Detail:       string(row.Log.Detail),
+			CreatedAt:    row.Log.CreatedAt,
+		})
+	}
+	if len(rows) > 0 {
+		last := rows[len(rows)-1].Log
+		resp.NextCursor = pageCursor{createdAt: last.CreatedAt, id: last.ID}.String()
+	}
+	return resp, nil
+}
+
+// adminUser は管理者向けのユーザー情報を取得する（存在しない場合はNotFound）
+func (s *AdminEntry) adminUser(ctx context.Context, userID uuid.UUID) (*database.AdminUserRow, error) {
+	row, err := database.AdminUserByID(ctx, s.DB, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	return row, nil
+}
+
+// rejectSelf は管理者が自分自身を対象にした場合にエラーを返す
+// （自分の権限を外したり自分を無効にしたりして、管理者がいなくなるのを防ぐ）
+func rejectSelf(ctx context.Context, userID uuid.UUID) error {
+	adminID, err := adminUserID(ctx)
+	if err != nil {
+		return err
+	}
+	if adminID == userID {
+		return status.Error(codes.FailedPrecondition, "cannot change yourself")
+	}
+	return nil
+}
+
+// mutateUser はトランザクションの中で対象のユーザーを変更し、同じトランザクションで監査ログを記録する。
+// fnは監査ログに残す詳細を返す。fnが返したgRPCのステータスのエラーはそのまま返す
+func (s *AdminEntry) mutateUser(ctx context.Context, userID uuid.UUID, action string,
+	fn func(tx *sql.Tx, user *database.User, now int64) (map[string]any, error),
+) (*database.AdminUserRow, error) {
+	adminID, err := adminUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		user, err := database.UserByID(ctx, tx, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return status.Error(codes.NotFound, "user not found")
+			}
+			return err
+		}
+		detail, err := fn(tx, user, now)
+		if err != nil {
+			return err
+		}
+		return insertAuditLog(ctx, tx, adminID, userID, action, detail, now)
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to %s: %v", strings.ReplaceAll(action, "_", " "), err)
+	}
+	return s.adminUser(ctx, userID)
+}
+
+// insertAuditLog は管理者の操作を監査ログに記録する
+func insertAuditLog(ctx context.Context, db database.DB, adminID, targetUserID uuid.UUID, action string, detail map[string]any, now int64) error {
+	detailJSON, err := json.Marshal(detail)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit log detail: %w", err)
+	}
+	entry := &database.AdminAuditLog{
+		ID:           uuid.New(),
+		AdminUserID:  adminID,
+		TargetUserID: targetUserID,
+		Action:       action,
+		Detail:       detailJSON,
+		CreatedAt:    now,
+	}
+	if err := entry.Insert(ctx, db); err != nil {
+		return fmt.Errorf("failed to insert audit log: %w", err)
+	}
+	return nil
+}
+
+func toAdminUser(row *database.AdminUserRow) *g.AdminUser {
+	u := &g.AdminUser{
+		Id:                    row.User.ID.String(),
+		Email:                 row.User.Email,
+		Name:                  row.User.Name,
+		Role:                  g.UserRole(row.User.Role),
+		CreatedAt:             row.User.CreatedAt,
+		PasswordResetRequired: row.PasswordResetRequired,
+	}
+	if row.User.DisabledAt.Valid {
+		u.DisabledAt = row.User.DisabledAt.Int64
+	}
+	return u
+}
@@ -0,0 +1,282 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeEmbeddings はRegenerateAllEmbeddingsを呼び出したユーザーIDを記録する
+type fakeEmbeddings struct {
+	userID string
+	err    error
+}
+
+func (f *fakeEmbeddings) RegenerateAllEmbeddings(ctx context.Context, _ *g.RegenerateAllEmbeddingsRequest) (*g.RegenerateAllEmbeddingsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.userID, _ = middleware.GetUserIDFromContext(ctx)
+	return &g.RegenerateAllEmbeddingsResponse{Success: true, QueuedCount: 3}, nil
+}
+
+// setupAdmin は管理者のユーザーを作成し、その管理者として呼び出すコンテキストを返す
+func setupAdmin(t *testing.T, db *sql.DB) (uuid.UUID, context.Context) {
+	t.Helper()
+	adminID := testutil.CreateTestUser(t, db, "admin@example.com", "管理者")
+	_, err := db.Exec("UPDATE users SET role = 1 WHERE id = $1", adminID)
+	require.NoError(t, err)
+	return adminID, testutil.CreateAuthenticatedContext(adminID)
+}
+
+// auditLogs は対象のユーザーに対する監査ログを新しい順に返す
+func auditLogs(t *testing.T, s *AdminEntry, ctx context.Context, targetUserID uuid.UUID) []*g.AuditLog {
+	t.Helper()
+	resp, err := s.ListAuditLogs(ctx, &g.ListAuditLogsRequest{TargetUserId: targetUserID.String()})
+	require.NoError(t, err)
+	return resp.Logs
+}
+
+func TestParsePageCursor(t *testing.T) {
+	id := uuid.New()
+	initial := pageCursor{createdAt: -1}
+
+	t.Run("正常系: 空の場合は初期位置を返す", func(t *testing.T) {
+		c, err := parsePageCursor("", initial)
+		require.NoError(t, err)
+		assert.Equal(t, initial, c)
+	})
+
+	t.Run("正常系: Stringで作ったカーソルを解析できる", func(t *testing.T) {
+		c, err := parsePageCursor(pageCursor{createdAt: 1700000000, id: id}.String(), initial)
+		require.NoError(t, err)
+		assert.Equal(t, pageCursor{createdAt: 1700000000, id: id}, c)
+	})
+
+	for _, s := range []string{"1700000000", "abc_" + id.String(), "1700000000_not-uuid"} {
+		t.Run("異常系: 不正なカーソル "+s, func(t *testing.T) {
+			_, err := parsePageCursor(s, initial)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestAdminEntry_ListUsers(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	s := &AdminEntry{DB: db}
+	_, ctx := setupAdmin(t, db)
+
+	marker := uuid.NewString()[:8]
+	var ids []string
+	for range 3 {
+		ids = append(ids, testutil.CreateTestUser(t, db, "list-users@example.com", "一覧"+marker).String())
+	}
+
+	t.Run("正常系: 名前で絞り込み、カーソルで続きを取得できる", func(t *testing.T) {
+		first, err := s.ListUsers(ctx, &g.ListUsersRequest{Query: marker, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, first.Users, 2)
+		assert.True(t, first.HasMore)
+
+		second, err := s.ListUsers(ctx, &g.ListUsersRequest{Query: marker, Limit: 2, Cursor: first.NextCursor})
+		require.NoError(t, err)
+		require.Len(t, second.Users, 1)
+		assert.False(t, second.HasMore)
+
+		got := []string{first.Users[0].Id, first.Users[1].Id, second.Users[0].Id}
+		assert.ElementsMatch(t, ids, got)
+	})
+
+	t.Run("正常系: LIKEの特殊文字はそのまま検索する", func(t *testing.T) {
+		resp, err := s.ListUsers(ctx, &g.ListUsersRequest{Query: marker + "%"})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Users)
+	})
+
+	t.Run("異常系: 不正なカーソルはInvalidArgument", func(t *testing.T) {
+		_, err := s.ListUsers(ctx, &g.ListUsersRequest{Cursor: "invalid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestAdminEntry_DisableAndEnableUser(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	s := &AdminEntry{DB: db}
+	adminID, ctx := setupAdmin(t, db)
+	userID := testutil.CreateTestUser(t, db, "disable-user@example.com", "無効にするユーザー")
+
+	t.Run("正常系: ユーザーを無効にし、監査ログに理由を記録する", func(t *testing.T) {
+		resp, err := s.DisableUser(ctx, &g.DisableUserRequest{UserId: userID.String(), Reason: "スパム"})
+		require.NoError(t, err)
+		assert.NotZero(t, resp.User.DisabledAt)
+
+		logs := auditLogs(t, s, ctx, userID)
+		require.Len(t, logs, 1)
+		assert.Equal(t, ActionDisableUser, logs[0].Action)
+		assert.Equal(t, adminID.String(), logs[0].AdminUserId)
+		assert.NotEmpty(t, logs[0].AdminEmail)
+		assert.JSONEq(t, `{"reason":"スパム"}`, logs[0].Detail)
+	})
+
+	t.Run("正常系: 無効にしたユーザーを有効に戻せる", func(t *testing.T) {
+		resp, err := s.EnableUser(ctx, &g.EnableUserRequest{UserId: userID.String()})
+		require.NoError(t, err)
+		assert.Zero(t, resp.User.DisabledAt)
+
+		logs := auditLogs(t, s, ctx, userID)
+		require.Len(t, logs, 2)
+		assert.Equal(t, ActionEnableUser, logs[0].Action)
+	})
+
+	t.Run("異常系: 自分自身は無効にできない", func(t *testing.T) {
+		_, err := s.DisableUser(ctx, &g.DisableUserRequest{UserId: adminID.String()})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("異常系: 存在しないユーザーはNotFoundで、監査ログを残さない", func(t *testing.T) {
+		missing := uuid.New()
+		_, err := s.DisableUser(ctx, &g.DisableUserRequest{UserId: missing.String()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Empty(t, auditLogs(t, s, ctx, missing))
+	})
+}
+
+func TestAdminEntry_SetUserRole(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	s := &AdminEntry{DB: db}
+	adminID, ctx := setupAdmin(t, db)
+	userID := testutil.CreateTestUser(t, db, "set-role@example.com", "権限を変えるユーザー")
+
+	t.Run("正常系: 管理者にし、変更前後の権限を記録する", func(t *testing.T) {
+		resp, err := s.SetUserRole(ctx, &g.SetUserRoleRequest{UserId: userID.String(), Role: g.UserRole_USER_ROLE_ADMIN})
+		require.NoError(t, err)
+		assert.Equal(t, g.UserRole_USER_ROLE_ADMIN, resp.User.Role)
+
+		logs := auditLogs(t, s, ctx, userID)
+		require.Len(t, logs, 1)
+		assert.JSONEq(t, `{"before":0,"after":1}`, logs[0].Detail)
+	})
+
+	t.Run("異常系: 自分自身の権限は変更できない", func(t *testing.T) {
+		_, err := s.SetUserRole(ctx, &g.SetUserRoleRequest{UserId: adminID.String(), Role: g.UserRole_USER_ROLE_USER})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("異常系: 未定義の権限はInvalidArgument", func(t *testing.T) {
+		_, err := s.SetUserRole(ctx, &g.SetUserRoleRequest{UserId: userID.String(), Role: g.UserRole(9)})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestAdminEntry_ForcePasswordReset(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	s := &AdminEntry{DB: db}
+	_, ctx := setupAdmin(t, db)
+
+	t.Run("正常系: パスワードの再設定を求め、セッションを取り消す", func(t *testing.T) {
+		userID := testutil.CreateTestUserWithPassword(t, db, "force-reset@example.com", "再設定するユーザー", "password123")
+		resp, err := s.ForcePasswordReset(ctx, &g.ForcePasswordResetRequest{UserId: userID.String()})
+		require.NoError(t, err)
+		assert.True(t, resp.User.PasswordResetRequired)
+
+		user, err := database.UserByID(ctx, db, userID)
+		require.NoError(t, err)
+		assert.True(t, user.SessionsRevokedAt.Valid)
+	})
+
+	t.Run("異常系: パスワードでログインしないユーザーはNotFound", func(t *testing.T) {
+		userID := testutil.CreateTestUser(t, db, "force-reset-nopass@example.com", "パスワードなし")
+		_, err := s.ForcePasswordReset(ctx, &g.ForcePasswordResetRequest{UserId: userID.String()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		user, err := database.UserByID(ctx, db, userID)
+		require.NoError(t, err)
+		assert.False(t, user.SessionsRevokedAt.Valid, "失敗した場合はセッションの取り消しもロールバックする")
+	})
+}
+
+func TestAdminEntry_RevokeUserCredentials(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	s := &AdminEntry{DB: db}
+	_, ctx := setupAdmin(t, db)
+	userID := testutil.CreateTestUser(t, db, "revoke-credentials@example.com", "取り消すユーザー")
+
+	now := time.Now().Unix()
+	key := &database.UserAPIKey{ID: uuid.New(), UserID: userID, Name: "キー", KeyHash: "test-hash-" + uuid.NewString(),
+		KeyPrefix: "umi_test1234", ExpiresAt: now + 3600, CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, key.Insert(ctx, db))
+
+	t.Run("正常系: APIキーを削除し、セッションを取り消す", func(t *testing.T) {
+		resp, err := s.RevokeUserCredentials(ctx, &g.RevokeUserCredentialsRequest{UserId: userID.String()})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), resp.RevokedApiKeyCount)
+
+		_, err = database.UserAPIKeyByID(ctx, db, key.ID)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		user, err := database.UserByID(ctx, db, userID)
+		require.NoError(t, err)
+		assert.True(t, user.SessionsRevokedAt.Valid)
+
+		logs := auditLogs(t, s, ctx, userID)
+		require.Len(t, logs, 1)
+		assert.JSONEq(t, `{"revoked_api_key_count":1}`, logs[0].Detail)
+	})
+}
+
+func TestAdminEntry_RegenerateUserEmbeddings(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	_, ctx := setupAdmin(t, db)
+	userID := testutil.CreateTestUser(t, db, "regenerate-embeddings@example.com", "再生成するユーザー")
+
+	t.Run("正常系: 対象のユーザーとして再生成し、監査ログに件数を記録する", func(t *testing.T) {
+		embeddings := &fakeEmbeddings{}
+		s := &AdminEntry{DB: db, Embeddings: embeddings}
+		resp, err := s.RegenerateUserEmbeddings(ctx, &g.RegenerateUserEmbeddingsRequest{UserId: userID.String()})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), resp.QueuedCount)
+		assert.Equal(t, userID.String(), embeddings.userID)
+
+		logs := auditLogs(t, s, ctx, userID)
+		require.Len(t, logs, 1)
+		assert.Equal(t, ActionRegenerateUserEmbeddings, logs[0].Action)
+	})
+
+	t.Run("異常系: 再生成のエラーはそのまま返す", func(t *testing.T) {
+		s := &AdminEntry{DB: db, Embeddings: &fakeEmbeddings{err: status.Error(codes.FailedPrecondition, "Gemini API key not found")}}
+		_, err := s.RegenerateUserEmbeddings(ctx, &g.RegenerateUserEmbeddingsRequest{UserId: userID.String()})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestAdminEntry_GetUserUsage(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	s := &AdminEntry{DB: db}
+	_, ctx := setupAdmin(t, db)
+	userID := testutil.CreateTestUser(t, db, "user-usage@example.com", "利用状況")
+	testutil.CreateTestUserLLM(t, db, userID, "test-key")
+
+	t.Run("正常系: 利用状況と過去24時間の処理件数を返す", func(t *testing.T) {
+		resp, err := s.GetUserUsage(ctx, &g.GetUserUsageRequest{UserId: userID.String()})
+		require.NoError(t, err)
+		assert.Equal(t, userID.String(), resp.User.Id)
+		assert.Zero(t, resp.Usage.DiaryCount)
+		assert.True(t, resp.Usage.LlmKeyConfigured)
+		assert.Len(t, resp.HourlyMetrics, 24)
+	})
+
+	t.Run("異常系: 存在しないユーザーはNotFound", func(t *testing.T) {
+		_, err := s.GetUserUsage(ctx, &g.GetUserUsageRequest{UserId: uuid.NewString()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
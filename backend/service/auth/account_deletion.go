@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/emailtoken"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/securityevent"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkAccountUsable は無効にされたユーザーと、アカウントの削除を依頼したユーザー（猶予期間中）のログイン・トークンの更新を拒否する
+func checkAccountUsable(userDB *database.User) error {
+	if userDB.DisabledAt.Valid {
+		return status.Error(codes.PermissionDenied, "account is disabled")
+	}
+	if userDB.DeletionScheduledAt.Valid {
+		return status.Error(codes.PermissionDenied, "account deletion is pending")
+	}
+	return nil
+}
+
+// CancelAccountDeletion は削除を依頼したときにメールで送ったトークンで、猶予期間中のアカウントの削除を取り消す。
+// 削除を依頼した時点ですべてのセッションとAPIキーを取り消しているため、ログインせずに呼び出せる（ADR 0037）
+func (s *AuthEntry) CancelAccountDeletion(ctx context.Context, req *g.CancelAccountDeletionRequest) (*g.CancelAccountDeletionResponse, error) {
+	if err := s.checkLoginAttempts(ctx, s.getClientIdentifier(ctx)); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	claims, err := s.EmailTokens.Verify(req.GetToken(), emailtoken.PurposeAccountDeletionCancel, now)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+	}
+	// 削除を依頼した日時をトークンに含めているため、取り消した後に再び依頼した場合は以前のリンクを使えない
+	requestedAt, err := strconv.ParseInt(claims.Binding, 10, 64)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+	}
+	userDB, err := database.UserByID(ctx, s.DB, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user by ID: %v", err)
+	}
+	if !userDB.DeletionRequestedAt.Valid || userDB.DeletionRequestedAt.Int64 != requestedAt {
+		return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+	}
+	if err := s.consumeEmailToken(ctx, claims, now); err != nil {
+		return nil, err
+	}
+
+	// 確認した後に削除が始まった場合に備え、依頼した日時が同じ場合だけ取り消す
+	cancelled, err := database.CancelUserDeletion(ctx, s.DB, userID, requestedAt, now.Unix())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to cancel account deletion: %v", err)
+	}
+	if cancelled == 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+	}
+	s.recordSecurityEvent(ctx, userID, securityevent.AccountDeletionCancel, nil)
+	s.resetLoginAttempts(ctx, s.getClientIdentifier(ctx))
+	return &g.CancelAccountDeletionResponse{}, nil
+}
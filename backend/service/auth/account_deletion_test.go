@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/emailtoken"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scheduleDeletion はユーザーの削除を依頼した状態にし、削除を取り消すリンクのトークンを返す
+func scheduleDeletion(t *testing.T, s *AuthEntry, userID uuid.UUID, requestedAt int64) string {
+	t.Helper()
+	if err := database.ScheduleUserDeletion(context.Background(), s.DB, userID, requestedAt, requestedAt+3600); err != nil {
+		t.Fatalf("ScheduleUserDeletion失敗: %v", err)
+	}
+	token, _, err := s.EmailTokens.Sign(emailtoken.PurposeAccountDeletionCancel, userID.String(), strconv.FormatInt(requestedAt, 10), time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("Sign失敗: %v", err)
+	}
+	return token
+}
+
+func TestAuthEntry_CancelAccountDeletion(t *testing.T) {
+	t.Run("正常系: 猶予期間中はログインできず、取り消すとログインできる", func(t *testing.T) {
+		s, _ := setupEmailAuthEntry(t)
+		email, registered := registerEmailUser(t, s)
+		userID := userIDFromResponse(t, registered)
+		token := scheduleDeletion(t, s, userID, time.Now().Unix())
+
+		_, err := s.LoginByPassword(context.Background(), &g.LoginByPasswordRequest{Email: email, Password: emailTestPassword})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("削除を依頼したユーザーのログインはPermissionDeniedになるべき: %v", err)
+		}
+
+		if _, err := s.CancelAccountDeletion(context.Background(), &g.CancelAccountDeletionRequest{Token: token}); err != nil {
+			t.Fatalf("CancelAccountDeletion失敗: %v", err)
+		}
+		userDB, err := database.UserByID(context.Background(), s.DB, userID)
+		if err != nil {
+			t.Fatalf("UserByID失敗: %v", err)
+		}
+		if userDB.DeletionRequestedAt.Valid || userDB.DeletionScheduledAt.Valid {
+			t.Error("削除の予定が取り消されていない")
+		}
+		if _, err := s.LoginByPassword(context.Background(), &g.LoginByPasswordRequest{Email: email, Password: emailTestPassword}); err != nil {
+			t.Errorf("取り消した後はログインできるべき: %v", err)
+		}
+
+		_, err = s.CancelAccountDeletion(context.Background(), &g.CancelAccountDeletionRequest{Token: token})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("使用済みのトークンはInvalidArgumentになるべき: %v", err)
+		}
+	})
+
+	t.Run("異常系: 依頼し直した場合は以前に送ったリンクは使えない", func(t *testing.T) {
+		s, _ := setupEmailAuthEntry(t)
+		_, registered := registerEmailUser(t, s)
+		userID := userIDFromResponse(t, registered)
+		now := time.Now().Unix()
+		first := scheduleDeletion(t, s, userID, now-10)
+		scheduleDeletion(t, s, userID, now)
+
+		_, err := s.CancelAccountDeletion(context.Background(), &g.CancelAccountDeletionRequest{Token: first})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("InvalidArgumentになるべき: %v", err)
+		}
+	})
+
+	t.Run("異常系: パスワードの再設定のトークンでは取り消せない", func(t *testing.T) {
+		s, m := setupEmailAuthEntry(t)
+		email, registered := registerEmailUser(t, s)
+		if _, err := s.RequestPasswordReset(context.Background(), &g.RequestPasswordResetRequest{Email: email}); err != nil {
+			t.Fatalf("RequestPasswordReset失敗: %v", err)
+		}
+		_, token := lastMailToken(t, m, "/reset-password")
+		scheduleDeletion(t, s, userIDFromResponse(t, registered), time.Now().Unix())
+
+		_, err := s.CancelAccountDeletion(context.Background(), &g.CancelAccountDeletionRequest{Token: token})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("用途が異なるトークンはInvalidArgumentになるべき: %v", err)
+		}
+	})
+}
@@ -0,0 +1,255 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/domain/request"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/emailtoken"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/mailer"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/securityevent"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	passwordResetTokenTTL     = time.Hour      // パスワードの再設定のリンクの有効期限
+	emailVerificationTokenTTL = 24 * time.Hour // メールアドレスの確認のリンクの有効期限
+	minPasswordLength         = 8
+)
+
+func (s *AuthEntry) RequestPasswordReset(ctx context.Context, req *g.RequestPasswordResetRequest) (*g.RequestPasswordResetResponse, error) {
+	email := strings.TrimSpace(req.GetEmail())
+	if email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+	// 同じクライアントからの大量の送信と、同じメールアドレスへの大量の送信を両方防ぐ
+	if err := s.checkEmailAttempts(ctx, s.getClientIdentifier(ctx), "reset:"+strings.ToLower(email)); err != nil {
+		return nil, err
+	}
+
+	// セキュリティ: 登録されていない・無効にされた・削除を依頼したユーザーでも成功を返し、メールアドレスが登録されているかを漏らさない
+	userDB, err := database.UserByEmail(ctx, s.DB, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &g.RequestPasswordResetResponse{}, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user by email: %v", err)
+	}
+	if userDB.DisabledAt.Valid || userDB.DeletionScheduledAt.Valid {
+		return &g.RequestPasswordResetResponse{}, nil
+	}
+
+	binding, err := passwordResetBinding(ctx, s.DB, userDB.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get password auth: %v", err)
+	}
+	token, _, err := s.EmailTokens.Sign(emailtoken.PurposePasswordReset, userDB.ID.String(), binding, passwordResetTokenTTL, time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create reset token: %v", err)
+	}
+	msg := mailer.Message{
+		To:      userDB.Email,
+		Subject: "【umi.mikan】パスワードの再設定",
+		Body: fmt.Sprintf("%s さん\n\n以下のリンクからパスワードを再設定してください（1時間有効）。\n%s\n\n"+
+			"心当たりがない場合はこのメールを無視してください。パスワードは変更されません。\n",
+			userDB.Name, s.emailLink("/reset-password", token)),
+	}
+	// 送信に失敗してもエラーにすると登録されていることが分かるため、ログに残すだけにする
+	if err := s.Mailer.Send(ctx, msg); err != nil {
+		log.Printf("failed to send password reset email to user %s: %v", userDB.ID, err)
+	}
+	return &g.RequestPasswordResetResponse{}, nil
+}
+
+func (s *AuthEntry) ResetPassword(ctx context.Context, req *g.ResetPasswordRequest) (*g.ResetPasswordResponse, error) {
+	if err := s.checkLoginAttempts(ctx, s.getClientIdentifier(ctx)); err != nil {
+		return nil, err
+	}
+	if len(req.GetNewPassword()) < minPasswordLength {
+		return nil, status.Errorf(codes.InvalidArgument, "password must be at least %d characters", minPasswordLength)
+	}
+	now := time.Now()
+	claims, err := s.EmailTokens.Verify(req.GetToken(), emailtoken.PurposePasswordReset, now)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+	}
+	userDB, err := database.UserByID(ctx, s.DB, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user by ID: %v", err)
+	}
+	if err := checkAccountUsable(userDB); err != nil {
+		return nil, err
+	}
+	// トークンを送った後にパスワードを変えている場合は使えない
+	binding, err := passwordResetBinding(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get password auth: %v", err)
+	}
+	if binding != claims.Binding {
+		return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+	}
+	if err := s.consumeEmailToken(ctx, claims, now); err != nil {
+		return nil, err
+	}
+
+	hashed, err := request.EncryptPassword(req.GetNewPassword())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+	}
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		// OpenID Connectで登録したユーザーはパスワードを持たないため、ない場合は作成する
+		passwordAuthDB, err := database.UserPasswordAutheByUserID(ctx, tx, userID)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			passwordAuthDB = &database.UserPasswordAuthe{UserID: userID, CreatedAt: now.Unix()}
+		case err != nil:
+			return fmt.Errorf("failed to get password auth: %w", err)
+		}
+		passwordAuthDB.PasswordHashed = hashed
+		passwordAuthDB.ResetRequired = false // 管理者が求めたパスワードの再設定もこの再設定で完了する
+		passwordAuthDB.UpdatedAt = now.Unix()
+		if err := passwordAuthDB.Save(ctx, tx); err != nil {
+			return fmt.Errorf("failed to save password auth: %w", err)
+		}
+		// メールのリンクを開けたため、メールアドレスも確認できている
+		if _, err := database.MarkEmailVerified(ctx, tx, userID, userDB.Email, now.Unix()); err != nil {
+			return err
+		}
+		// パスワードを知られたために再設定した場合に備え、それまでのセッションを取り消す
+		return database.RevokeUserSessions(ctx, tx, userID, now.Unix())
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reset password: %v", err)
+	}
+	s.recordSecurityEvent(ctx, userID, securityevent.PasswordChange, map[string]any{"method": "reset"})
+	s.resetLoginAttempts(ctx, s.getClientIdentifier(ctx))
+	return &g.ResetPasswordResponse{}, nil
+}
+
+func (s *AuthEntry) VerifyEmail(ctx context.Context, req *g.VerifyEmailRequest) (*g.VerifyEmailResponse, error) {
+	if err := s.checkLoginAttempts(ctx, s.getClientIdentifier(ctx)); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	claims, err := s.EmailTokens.Verify(req.GetToken(), emailtoken.PurposeEmailVerification, now)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+	}
+	if err := s.consumeEmailToken(ctx, claims, now); err != nil {
+		return nil, err
+	}
+	// リンクを送った時点のメールアドレスのままの場合だけ確認済みにする
+	verified, err := database.MarkEmailVerified(ctx, s.DB, userID, claims.Binding, now.Unix())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify email: %v", err)
+	}
+	if !verified {
+		return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+	}
+	return &g.VerifyEmailResponse{}, nil
+}
+
+func (s *AuthEntry) RequestEmailVerification(ctx context.Context, req *g.RequestEmailVerificationRequest) (*g.RequestEmailVerificationResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userDB, err := database.UserByID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user by ID: %v", err)
+	}
+	if userDB.EmailVerifiedAt.Valid {
+		return nil, status.Error(codes.FailedPrecondition, "email is already verified")
+	}
+	if err := s.checkEmailAttempts(ctx, "verify:"+userID.String()); err != nil {
+		return nil, err
+	}
+	if err := s.sendVerificationEmail(ctx, userDB); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to send verification email: %v", err)
+	}
+	return &g.RequestEmailVerificationResponse{}, nil
+}
+
+// sendVerificationEmail はメールアドレスの確認のリンクを送る
+func (s *AuthEntry) sendVerificationEmail(ctx context.Context, userDB *database.User) error {
+	token, _, err := s.EmailTokens.Sign(emailtoken.PurposeEmailVerification, userDB.ID.String(), userDB.Email, emailVerificationTokenTTL, time.Now())
+	if err != nil {
+		return err
+	}
+	return s.Mailer.Send(ctx, mailer.Message{
+		To:      userDB.Email,
+		Subject: "【umi.mikan】メールアドレスの確認",
+		Body: fmt.Sprintf("%s さん\n\n以下のリンクからメールアドレスを確認してください（24時間有効）。\n%s\n\n"+
+			"心当たりがない場合はこのメールを無視してください。\n",
+			userDB.Name, s.emailLink("/verify-email", token)),
+	})
+}
+
+// consumeEmailToken はトークンを使用済みにする（使用済みの場合は不正なトークンと同じエラーを返す）
+func (s *AuthEntry) consumeEmailToken(ctx context.Context, claims *emailtoken.Claims, now time.Time) error {
+	ok, err := s.UsedEmailTokens.Consume(ctx, claims, now)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to consume token: %v", err)
+	}
+	if !ok {
+		return status.Error(codes.InvalidArgument, "invalid or expired token")
+	}
+	return nil
+}
+
+// checkEmailAttempts はメール送信のレート制限を識別子ごとに確認する
+func (s *AuthEntry) checkEmailAttempts(ctx context.Context, identifiers ...string) error {
+	if s.EmailLimiter == nil {
+		return nil
+	}
+	for _, id := range identifiers {
+		allowed, _, resetTime, err := s.EmailLimiter.CheckAttempt(ctx, id)
+		if err != nil {
+			return status.Errorf(codes.Internal, "rate limit check failed: %v", err)
+		}
+		if !allowed {
+			return status.Errorf(codes.ResourceExhausted,
+				"too many email requests, try again in %v", resetTime)
+		}
+	}
+	return nil
+}
+
+// emailLink はメールに載せるフロントエンドのリンクを作成する
+func (s *AuthEntry) emailLink(path, token string) string {
+	return strings.TrimSuffix(s.FrontendBaseURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+// passwordResetBinding はパスワードの再設定のトークンに含める現在のパスワードの指紋を返す（パスワードを持たない場合は空）。
+// パスワードを変えると以前に送ったリンクは使えなくなる
+func passwordResetBinding(ctx context.Context, db database.DB, userID uuid.UUID) (string, error) {
+	passwordAuthDB, err := database.UserPasswordAutheByUserID(ctx, db, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	return emailtoken.Fingerprint(passwordAuthDB.PasswordHashed), nil
+}
@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/emailtoken"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/mailer"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/redis/rueidis"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const emailTestPassword = "validPassword123"
+
+// setupEmailAuthEntry はメールを一時ディレクトリに書き出し、使用済みのトークンとレート制限（メールは2回）をminiredisに保存するAuthEntryを作成する
+func setupEmailAuthEntry(t *testing.T) (*AuthEntry, *mailer.FileMailer) {
+	t.Helper()
+	db := setupTestDB(t)
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis起動失敗: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	redisClient, err := rueidis.NewClient(rueidis.ClientOption{InitAddress: []string{mr.Addr()}, DisableCache: true})
+	if err != nil {
+		t.Fatalf("rueidisクライアント作成失敗: %v", err)
+	}
+	t.Cleanup(redisClient.Close)
+	limiter := ratelimiter.NewRedisRateLimiter(redisClient)
+	m := &mailer.FileMailer{Dir: t.TempDir(), From: "noreply@example.com"}
+	return &AuthEntry{
+		DB:              db,
+		LoginLimiter:    ratelimiter.NewLoginAttemptLimiter(limiter, 10, time.Minute),
+		Mailer:          m,
+		EmailTokens:     emailtoken.NewSigner("test-secret"),
+		UsedEmailTokens: &emailtoken.UsedStore{Redis: redisClient},
+		EmailLimiter:    ratelimiter.NewEmailAttemptLimiter(limiter, 2, time.Minute),
+		FrontendBaseURL: "http://localhost:5173",
+	}, m
+}
+
+// lastMailToken は最後に送ったメールの宛先と、リンクに含まれるトークンを返す
+func lastMailToken(t *testing.T, m *mailer.FileMailer, path string) (string, string) {
+	t.Helper()
+	messages, err := m.Messages()
+	if err != nil {
+		t.Fatalf("Messages失敗: %v", err)
+	}
+	if len(messages) == 0 {
+		t.Fatal("メールが送られていない")
+	}
+	msg := messages[len(messages)-1]
+	prefix := "http://localhost:5173" + path + "?token="
+	for _, line := range strings.Split(msg.Body, "\n") {
+		if encoded, ok := strings.CutPrefix(line, prefix); ok {
+			token, err := url.QueryUnescape(encoded)
+			if err != nil {
+				t.Fatalf("トークンのデコード失敗: %v", err)
+			}
+			return msg.To, token
+		}
+	}
+	t.Fatalf("メールにリンクが含まれていない: %q", msg.Body)
+	return "", ""
+}
+
+func registerEmailUser(t *testing.T, s *AuthEntry) (string, *g.AuthResponse) {
+	t.Helper()
+	email := generateTestEmail(t, "email")
+	resp, err := s.RegisterByPassword(context.Background(), &g.RegisterByPasswordRequest{Email: email, Password: emailTestPassword, Name: "Email"})
+	if err != nil {
+		t.Fatalf("RegisterByPassword失敗: %v", err)
+	}
+	return email, resp
+}
+
+func TestAuthEntry_VerifyEmail(t *testing.T) {
+	t.Run("正常系: 登録時に送ったリンクで確認済みになり、同じリンクは再利用できない", func(t *testing.T) {
+		s, m := setupEmailAuthEntry(t)
+		email, registered := registerEmailUser(t, s)
+		to, token := lastMailToken(t, m, "/verify-email")
+		if to != email {
+			t.Errorf("宛先が期待と異なる: %s", to)
+		}
+
+		if _, err := s.VerifyEmail(context.Background(), &g.VerifyEmailRequest{Token: token}); err != nil {
+			t.Fatalf("VerifyEmail失敗: %v", err)
+		}
+		userDB, err := database.UserByID(context.Background(), s.DB, userIDFromResponse(t, registered))
+		if err != nil {
+			t.Fatalf("UserByID失敗: %v", err)
+		}
+		if !userDB.EmailVerifiedAt.Valid {
+			t.Error("メールアドレスが確認済みになっていない")
+		}
+
+		_, err = s.VerifyEmail(context.Background(), &g.VerifyEmailRequest{Token: token})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("使用済みのトークンはInvalidArgumentになるべき: %v", err)
+		}
+	})
+
+	t.Run("異常系: パスワードの再設定のトークンでは確認できない", func(t *testing.T) {
+		s, m := setupEmailAuthEntry(t)
+		email, _ := registerEmailUser(t, s)
+		if _, err := s.RequestPasswordReset(context.Background(), &g.RequestPasswordResetRequest{Email: email}); err != nil {
+			t.Fatalf("RequestPasswordReset失敗: %v", err)
+		}
+		_, token := lastMailToken(t, m, "/reset-password")
+		_, err := s.VerifyEmail(context.Background(), &g.VerifyEmailRequest{Token: token})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("用途が異なるトークンはInvalidArgumentになるべき: %v", err)
+		}
+	})
+
+	t.Run("異常系: 確認済みのユーザーは送り直せない", func(t *testing.T) {
+		s, m := setupEmailAuthEntry(t)
+		_, registered := registerEmailUser(t, s)
+		_, token := lastMailToken(t, m, "/verify-email")
+		if _, err := s.VerifyEmail(context.Background(), &g.VerifyEmailRequest{Token: token}); err != nil {
+			t.Fatalf("VerifyEmail失敗: %v", err)
+		}
+		_, err := s.RequestEmailVerification(userContext(t, registered), &g.RequestEmailVerificationRequest{})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("FailedPreconditionになるべき: %v", err)
+		}
+	})
+}
+
+func TestAuthEntry_ResetPassword(t *testing.T) {
+	t.Run("正常系: 新しいパスワードでログインでき、以前のパスワードとリフレッシュトークンは使えなくなる", func(t *testing.T) {
+		s, m := setupEmailAuthEntry(t)
+		email, registered := registerEmailUser(t, s)
+		if _, err := s.RequestPasswordReset(context.Background(), &g.RequestPasswordResetRequest{Email: email}); err != nil {
+			t.Fatalf("RequestPasswordReset失敗: %v", err)
+		}
+		_, token := lastMailToken(t, m, "/reset-password")
+
+		if _, err := s.ResetPassword(context.Background(), &g.ResetPasswordRequest{Token: token, NewPassword: "newPassword456"}); err != nil {
+			t.Fatalf("ResetPassword失敗: %v", err)
+		}
+		if _, err := s.LoginByPassword(context.Background(), &g.LoginByPasswordRequest{Email: email, Password: "newPassword456"}); err != nil {
+			t.Errorf("新しいパスワードでログインできるべき: %v", err)
+		}
+		_, err := s.LoginByPassword(context.Background(), &g.LoginByPasswordRequest{Email: email, Password: emailTestPassword})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("以前のパスワードはUnauthenticatedになるべき: %v", err)
+		}
+		_, err = s.RefreshAccessToken(context.Background(), &g.RefreshAccessTokenRequest{RefreshToken: registered.RefreshToken})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("再設定前のリフレッシュトークンはUnauthenticatedになるべき: %v", err)
+		}
+		_, err = s.ResetPassword(context.Background(), &g.ResetPasswordRequest{Token: token, NewPassword: "otherPassword789"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("使用済みのトークンはInvalidArgumentになるべき: %v", err)
+		}
+	})
+
+	t.Run("異常系: パスワードを変えると以前に送ったリンクは使えない", func(t *testing.T) {
+		s, m := setupEmailAuthEntry(t)
+		email, _ := registerEmailUser(t, s)
+		if _, err := s.RequestPasswordReset(context.Background(), &g.RequestPasswordResetRequest{Email: email}); err != nil {
+			t.Fatalf("RequestPasswordReset失敗: %v", err)
+		}
+		_, first := lastMailToken(t, m, "/reset-password")
+		if _, err := s.RequestPasswordReset(context.Background(), &g.RequestPasswordResetRequest{Email: email}); err != nil {
+			t.Fatalf("RequestPasswordReset失敗: %v", err)
+		}
+		_, second := lastMailToken(t, m, "/reset-password")
+
+		if _, err := s.ResetPassword(context.Background(), &g.ResetPasswordRequest{Token: second, NewPassword: "newPassword456"}); err != nil {
+			t.Fatalf("ResetPassword失敗: %v", err)
+		}
+		_, err := s.ResetPassword(context.Background(), &g.ResetPasswordRequest{Token: first, NewPassword: "otherPassword789"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("InvalidArgumentになるべき: %v", err)
+		}
+	})
+
+	t.Run("異常系: 短いパスワードはInvalidArgument", func(t *testing.T) {
+		s, m := setupEmailAuthEntry(t)
+		email, _ := registerEmailUser(t, s)
+		if _, err := s.RequestPasswordReset(context.Background(), &g.RequestPasswordResetRequest{Email: email}); err != nil {
+			t.Fatalf("RequestPasswordReset失敗: %v", err)
+		}
+		_, token := lastMailToken(t, m, "/reset-password")
+		_, err := s.ResetPassword(context.Background(), &g.ResetPasswordRequest{Token: token, NewPassword: "short"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("InvalidArgumentになるべき: %v", err)
+		}
+	})
+}
+
+func TestAuthEntry_RequestPasswordReset(t *testing.T) {
+	t.Run("正常系: 登録されていないメールアドレスでも成功し、メールは送らない", func(t *testing.T) {
+		s, m := setupEmailAuthEntry(t)
+		if _, err := s.RequestPasswordReset(context.Background(), &g.RequestPasswordResetRequest{Email: generateTestEmail(t, "unknown")}); err != nil {
+			t.Fatalf("RequestPasswordReset失敗: %v", err)
+		}
+		messages, err := m.Messages()
+		if err != nil {
+			t.Fatalf("Messages失敗: %v", err)
+		}
+		if len(messages) != 0 {
+			t.Errorf("メールを送るべきでない: %d通", len(messages))
+		}
+	})
+
+	t.Run("異常系: 同じメールアドレスへの送信は上限を超えるとResourceExhausted", func(t *testing.T) {
+		s, _ := setupEmailAuthEntry(t)
+		email := generateTestEmail(t, "limit")
+		for i := range 2 {
+			if _, err := s.RequestPasswordReset(context.Background(), &g.RequestPasswordResetRequest{Email: email}); err != nil {
+				t.Fatalf("%d回目のRequestPasswordReset失敗: %v", i+1, err)
+			}
+		}
+		_, err := s.RequestPasswordReset(context.Background(), &g.RequestPasswordResetRequest{Email: email})
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("ResourceExhaustedになるべき: %v", err)
+		}
+	})
+}
+
+func userIDFromResponse(t *testing.T, resp *g.AuthResponse) uuid.UUID {
+	t.Helper()
+	userID, err := contextUserID(userContext(t, resp))
+	if err != nil {
+		t.Fatalf("ユーザーIDの取得失敗: %v", err)
+	}
+	return userID
+}
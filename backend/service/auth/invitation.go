@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/domain/model"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/invitation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 招待コードの使用回数と有効期限（日数）の上限。管理者はまとめて招待できるよう上限を広げる
+const (
+	defaultInvitationMaxUses       = 1
+	defaultInvitationExpiresInDays = 7
+	maxInvitationUses              = 10
+	maxInvitationExpiresInDays     = 30
+	maxAdminInvitationUses         = 1000
+	maxAdminInvitationExpiresDays  = 365
+	maxInvitationNoteLength        = 100
+)
+
+func (s *AuthEntry) CreateInvitation(ctx context.Context, req *g.CreateInvitationRequest) (*g.CreateInvitationResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userDB, err := database.UserByID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user by ID: %v", err)
+	}
+	// アクセストークンの有効期限までに無効にされたユーザーが招待できないよう、DBで確認する
+	if err := checkAccountUsable(userDB); err != nil {
+		return nil, err
+	}
+
+	maxUses, expiresInDays := int(req.GetMaxUses()), int(req.GetExpiresInDays())
+	if maxUses == 0 {
+		maxUses = defaultInvitationMaxUses
+	}
+	if expiresInDays == 0 {
+		expiresInDays = defaultInvitationExpiresInDays
+	}
+	usesLimit, daysLimit := maxInvitationUses, maxInvitationExpiresInDays
+	if userDB.Role == model.UserRoleAdmin.Int16() {
+		usesLimit, daysLimit = maxAdminInvitationUses, maxAdminInvitationExpiresDays
+	}
+	if maxUses < 1 || maxUses > usesLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "max_uses must be between 1 and %d", usesLimit)
+	}
+	if expiresInDays < 1 || expiresInDays > daysLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "expires_in_days must be between 1 and %d", daysLimit)
+	}
+	note := strings.TrimSpace(req.GetNote())
+	if len([]rune(note)) > maxInvitationNoteLength {
+		return nil, status.Errorf(codes.InvalidArgument, "note must be at most %d characters", maxInvitationNoteLength)
+	}
+
+	code, err := invitation.GenerateCode()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate invitation code: %v", err)
+	}
+	now := time.Now()
+	invitationDB := &database.Invitation{
+		ID:        uuid.New(),
+		CodeHash:  invitation.HashCode(code),
+		CreatedBy: uuid.NullUUID{UUID: userID, Valid: true},
+		Note:      note,
+		MaxUses:   maxUses,
+		ExpiresAt: now.Add(time.Duration(expiresInDays) * 24 * time.Hour).Unix(),
+		CreatedAt: now.Unix(),
+		UpdatedAt: now.Unix(),
+	}
+	if err := invitationDB.Insert(ctx, s.DB); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create invitation: %v", err)
+	}
+	return &g.CreateInvitationResponse{
+		Code:       code,
+		Invitation: convertInvitation(invitationDB, nil),
+	}, nil
+}
+
+func (s *AuthEntry) ListInvitations(ctx context.Context, req *g.ListInvitationsRequest) (*g.ListInvitationsResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invitations, err := database.InvitationsByCreatedBy(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list invitations: %v", err)
+	}
+	uses, err := database.InvitationUsesByCreatedBy(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list invitation uses: %v", err)
+	}
+	usesByInvitation := make(map[uuid.UUID][]*g.InvitationUse)
+	for _, u := range uses {
+		usesByInvitation[u.InvitationID] = append(usesByInvitation[u.InvitationID], &g.InvitationUse{
+			UserId:   u.UserID.String(),
+			UserName: u.UserName,
+			UsedAt:   u.UsedAt,
+		})
+	}
+
+	res := make([]*g.Invitation, 0, len(invitations))
+	for _, i := range invitations {
+		res = append(res, convertInvitation(i, usesByInvitation[i.ID]))
+	}
+	return &g.ListInvitationsResponse{Invitations: res}, nil
+}
+
+func (s *AuthEntry) RevokeInvitation(ctx context.Context, req *g.RevokeInvitationRequest) (*g.RevokeInvitationResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "invitation not found")
+	}
+	invitationDB, err := database.InvitationByID(ctx, s.DB, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "invitation not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get invitation: %v", err)
+	}
+	// 他のユーザーの招待コードは存在しない場合と同じエラーにする
+	if invitationDB.CreatedBy.UUID != userID {
+		return nil, status.Error(codes.NotFound, "invitation not found")
+	}
+	if invitationDB.RevokedAt.Valid {
+		return &g.RevokeInvitationResponse{}, nil
+	}
+	now := time.Now().Unix()
+	invitationDB.RevokedAt = sql.NullInt64{Int64: now, Valid: true}
+	invitationDB.UpdatedAt = now
+	if err := invitationDB.Update(ctx, s.DB); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke invitation: %v", err)
+	}
+	return &g.RevokeInvitationResponse{}, nil
+}
+
+// invitationRequired は新規登録に招待コードが必要かを返す（REGISTER_KEYが設定されている場合も招待制）
+func (s *AuthEntry) invitationRequired() bool {
+	return s.InvitationRequired || s.RegisterKey != ""
+}
+
+// useInvitation は招待制の場合に招待コードの使用回数を増やし、登録したユーザーを記録する。
+// 登録と同じトランザクション内で呼び、登録に失敗した場合は使用回数も戻す
+func (s *AuthEntry) useInvitation(ctx context.Context, tx *sql.Tx, code string, userID uuid.UUID) error {
+	if !s.invitationRequired() {
+		return nil
+	}
+	// 移行期間のため、REGISTER_KEYも招待コードとして受け付ける（使用回数は数えない）
+	if s.RegisterKey != "" && subtle.ConstantTimeCompare([]byte(code), []byte(s.RegisterKey)) == 1 {
+		return nil
+	}
+	now := time.Now().Unix()
+	invitationID, ok, err := database.ConsumeInvitation(ctx, tx, invitation.HashCode(code), now)
+	if err != nil {
+		return err
+	}
+	// 存在しない・期限切れ・上限に達した場合も同じエラーメッセージで情報漏洩を防ぐ
+	if !ok {
+		return status.Error(codes.PermissionDenied, "registration failed")
+	}
+	use := &database.InvitationUse{
+		ID:           uuid.New(),
+		InvitationID: uuid.NullUUID{UUID: invitationID, Valid: true},
+		UserID:       userID,
+		CreatedAt:    now,
+	}
+	if err := use.Insert(ctx, tx); err != nil {
+		return fmt.Errorf("failed to insert invitation use: %w", err)
+	}
+	return nil
+}
+
+func convertInvitation(i *database.Invitation, uses []*g.InvitationUse) *g.Invitation {
+	return &g.Invitation{
+		Id:        i.ID.String(),
+		Note:      i.Note,
+		MaxUses:   int32(i.MaxUses),
+		UseCount:  int32(i.UseCount),
+		ExpiresAt: i.ExpiresAt,
+		RevokedAt: i.RevokedAt.Int64,
+		CreatedAt: i.CreatedAt,
+		Uses:      uses,
+	}
+}
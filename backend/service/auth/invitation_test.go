@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const invitationTestPassword = "validPassword123"
+
+// setupInvitationAuthEntry は招待制のAuthEntryと、招待コードを発行するユーザー（招待制にする前に登録）のコンテキストを作成する
+func setupInvitationAuthEntry(t *testing.T) (*AuthEntry, context.Context) {
+	t.Helper()
+	s := &AuthEntry{DB: setupTestDB(t)}
+	inviter, err := s.RegisterByPassword(context.Background(), &g.RegisterByPasswordRequest{
+		Email: generateTestEmail(t, "inviter"), Password: invitationTestPassword, Name: "Inviter",
+	})
+	if err != nil {
+		t.Fatalf("RegisterByPassword失敗: %v", err)
+	}
+	s.InvitationRequired = true
+	return s, userContext(t, inviter)
+}
+
+func registerWithInvitation(t *testing.T, s *AuthEntry, prefix, code string) (*g.AuthResponse, error) {
+	t.Helper()
+	return s.RegisterByPassword(context.Background(), &g.RegisterByPasswordRequest{
+		Email: generateTestEmail(t, prefix), Password: invitationTestPassword, Name: "Invitee", RegisterKey: code,
+	})
+}
+
+func TestAuthEntry_Invitation(t *testing.T) {
+	t.Run("正常系: 招待コードで使用回数まで登録でき、一覧に登録したユーザーが記録される", func(t *testing.T) {
+		s, inviterCtx := setupInvitationAuthEntry(t)
+		config, err := s.GetRegistrationConfig(context.Background(), &g.GetRegistrationConfigRequest{})
+		if err != nil || !config.RegisterKeyRequired {
+			t.Fatalf("招待制では招待コードが必要と返すべき: %v, %v", config, err)
+		}
+
+		created, err := s.CreateInvitation(inviterCtx, &g.CreateInvitationRequest{MaxUses: 2, Note: "家族用"})
+		if err != nil {
+			t.Fatalf("CreateInvitation失敗: %v", err)
+		}
+		if created.Code == "" || created.Invitation.MaxUses != 2 || created.Invitation.ExpiresAt <= created.Invitation.CreatedAt {
+			t.Fatalf("招待コードが期待と異なる: %+v", created)
+		}
+		for i := range 2 {
+			if _, err := registerWithInvitation(t, s, "invitee", created.Code); err != nil {
+				t.Fatalf("%d人目の登録失敗: %v", i+1, err)
+			}
+		}
+		if _, err := registerWithInvitation(t, s, "invitee-over", created.Code); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("上限に達した招待コードはPermissionDeniedになるべき: %v", err)
+		}
+
+		list, err := s.ListInvitations(inviterCtx, &g.ListInvitationsRequest{})
+		if err != nil {
+			t.Fatalf("ListInvitations失敗: %v", err)
+		}
+		if len(list.Invitations) != 1 {
+			t.Fatalf("招待コードの数が期待と異なる: %d", len(list.Invitations))
+		}
+		got := list.Invitations[0]
+		if got.UseCount != 2 || len(got.Uses) != 2 || got.Uses[0].UserName != "Invitee" || got.Note != "家族用" {
+			t.Errorf("一覧が期待と異なる: %+v", got)
+		}
+	})
+
+	t.Run("異常系: 招待コードなし・不正なコードでは登録できない", func(t *testing.T) {
+		s, _ := setupInvitationAuthEntry(t)
+		if _, err := registerWithInvitation(t, s, "no-code", ""); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("PermissionDeniedになるべき: %v", err)
+		}
+		if _, err := registerWithInvitation(t, s, "wrong-code", "aaaa-bbbb-cccc-dddd"); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("PermissionDeniedになるべき: %v", err)
+		}
+	})
+
+	t.Run("異常系: 取り消した・期限切れの招待コードでは登録できない", func(t *testing.T) {
+		s, inviterCtx := setupInvitationAuthEntry(t)
+		revoked, err := s.CreateInvitation(inviterCtx, &g.CreateInvitationRequest{})
+		if err != nil {
+			t.Fatalf("CreateInvitation失敗: %v", err)
+		}
+		if _, err := s.RevokeInvitation(inviterCtx, &g.RevokeInvitationRequest{Id: revoked.Invitation.Id}); err != nil {
+			t.Fatalf("RevokeInvitation失敗: %v", err)
+		}
+		if _, err := registerWithInvitation(t, s, "revoked", revoked.Code); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("取り消した招待コードはPermissionDeniedになるべき: %v", err)
+		}
+
+		expired, err := s.CreateInvitation(inviterCtx, &g.CreateInvitationRequest{})
+		if err != nil {
+			t.Fatalf("CreateInvitation失敗: %v", err)
+		}
+		if _, err := s.DB.Exec("UPDATE invitations SET expires_at = 1 WHERE id = $1", expired.Invitation.Id); err != nil {
+			t.Fatalf("招待コードの更新失敗: %v", err)
+		}
+		if _, err := registerWithInvitation(t, s, "expired", expired.Code); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("期限切れの招待コードはPermissionDeniedになるべき: %v", err)
+		}
+	})
+
+	t.Run("正常系: 発行者を削除しても使用の記録は残り、その招待コードは使えなくなる", func(t *testing.T) {
+		s, inviterCtx := setupInvitationAuthEntry(t)
+		created, err := s.CreateInvitation(inviterCtx, &g.CreateInvitationRequest{MaxUses: 2})
+		if err != nil {
+			t.Fatalf("CreateInvitation失敗: %v", err)
+		}
+		if _, err := registerWithInvitation(t, s, "invitee-kept", created.Code); err != nil {
+			t.Fatalf("登録失敗: %v", err)
+		}
+
+		inviterID := uuid.MustParse(inviterCtx.Value(middleware.UserIDKey).(string))
+		err = database.RwTransaction(context.Background(), s.DB, func(tx *sql.Tx) error {
+			return database.DeleteUserData(context.Background(), tx, inviterID)
+		})
+		if err != nil {
+			t.Fatalf("DeleteUserData失敗: %v", err)
+		}
+
+		var uses int
+		if err := s.DB.QueryRow("SELECT COUNT(*) FROM invitation_uses WHERE invitation_id = $1", created.Invitation.Id).Scan(&uses); err != nil {
+			t.Fatalf("使用の記録の取得失敗: %v", err)
+		}
+		if uses != 1 {
+			t.Errorf("使用の記録が残っていない: %d", uses)
+		}
+		if _, err := registerWithInvitation(t, s, "invitee-orphan", created.Code); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("発行者が削除された招待コードはPermissionDeniedになるべき: %v", err)
+		}
+	})
+
+	t.Run("異常系: 他のユーザーの招待コードは取り消せない", func(t *testing.T) {
+		s, inviterCtx := setupInvitationAuthEntry(t)
+		created, err := s.CreateInvitation(inviterCtx, &g.CreateInvitationRequest{})
+		if err != nil {
+			t.Fatalf("CreateInvitation失敗: %v", err)
+		}
+		other, err := registerWithInvitation(t, s, "other", created.Code)
+		if err != nil {
+			t.Fatalf("登録失敗: %v", err)
+		}
+		_, err = s.RevokeInvitation(userContext(t, other), &g.RevokeInvitationRequest{Id: created.Invitation.Id})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("NotFoundになるべき: %v", err)
+		}
+	})
+
+	t.Run("異常系: 一般のユーザーは上限を超える使用回数・有効期限を指定できない", func(t *testing.T) {
+		s, inviterCtx := setupInvitationAuthEntry(t)
+		_, err := s.CreateInvitation(inviterCtx, &g.CreateInvitationRequest{MaxUses: maxInvitationUses + 1})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("InvalidArgumentになるべき: %v", err)
+		}
+		_, err = s.CreateInvitation(inviterCtx, &g.CreateInvitationRequest{ExpiresInDays: maxInvitationExpiresInDays + 1})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("InvalidArgumentになるべき: %v", err)
+		}
+	})
+}
@@ -0,0 +1,413 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/domain/model"
+	"github.com/project-mikan/umi.mikan/backend/domain/request"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/mfa"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/securityevent"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// loginResponse はパスワードやIdPでの確認（1段階目）が済んだユーザーに、
+// 2段階認証を有効にしている場合はチャレンジのトークンを、していない場合はJWTを返す。methodは1段階目の方法
+func (s *AuthEntry) loginResponse(ctx context.Context, userDB *database.User, method string) (*g.AuthResponse, error) {
+	if err := checkAccountUsable(userDB); err != nil {
+		return nil, err
+	}
+	totp, err := enabledTotp(ctx, s.DB, userDB.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get totp: %v", err)
+	}
+	if totp != nil {
+		token, err := s.MFAChallenges.Create(ctx, userDB.ID.String())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create mfa challenge: %v", err)
+		}
+		methods := []string{mfaMethodTotp}
+		passkeys, err := database.CountPasskeysByUserID(ctx, s.DB, userDB.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to count passkeys: %v", err)
+		}
+		if passkeys > 0 {
+			methods = append(methods, mfaMethodPasskey)
+		}
+		return &g.AuthResponse{MfaRequired: true, MfaToken: token, MfaMethods: methods}, nil
+	}
+	return s.issueTokens(ctx, userDB, method)
+}
+
+// issueTokens はJWTを発行し、ログインのセキュリティイベントを記録する。
+// 管理者がパスワードの再設定を求めている場合は password_reset_required を返す
+func (s *AuthEntry) issueTokens(ctx context.Context, userDB *database.User, method string) (*g.AuthResponse, error) {
+	token, err := model.GenerateAuthTokens(userDB.ID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate auth tokens: %v", err)
+	}
+	resp := token.ConvertAuthResponse()
+	passwordAuthDB, err := database.UserPasswordAutheByUserID(ctx, s.DB, userDB.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.Internal, "failed to get password auth: %v", err)
+	}
+	if passwordAuthDB != nil {
+		resp.PasswordResetRequired = passwordAuthDB.ResetRequired
+	}
+	s.recordSecurityEvent(ctx, userDB.ID, securityevent.LoginSuccess, map[string]any{"method": method})
+	return resp, nil
+}
+
+func (s *AuthEntry) VerifyMfa(ctx context.Context, req *g.VerifyMfaRequest) (*g.AuthResponse, error) {
+	if req.GetMfaToken() == "" || req.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "mfa_token and code are required")
+	}
+	userID, err := s.mfaChallengeUser(ctx, req.GetMfaToken())
+	if err != nil {
+		return nil, err
+	}
+
+	// パスワードと同じレート制限を、クライアントとユーザーの両方に適用する（IPを変えながら6桁のコードを総当たりされないようにする）
+	limitKeys := []string{s.getClientIdentifier(ctx), mfaLimitKey(userID)}
+	if err := s.checkLoginAttempts(ctx, limitKeys...); err != nil {
+		return nil, err
+	}
+
+	totp, err := enabledTotp(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get totp: %v", err)
+	}
+	if totp == nil {
+		// チャレンジの作成後に2段階認証を無効にした場合
+		return nil, status.Error(codes.Unauthenticated, "invalid mfa token")
+	}
+	verified, err := s.verifySecondFactor(ctx, totp, req.GetCode(), true)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify code: %v", err)
+	}
+	if !verified {
+		s.recordSecurityEvent(ctx, userID, securityevent.LoginFailure, map[string]any{"method": loginMethodMfaTotp, "reason": "invalid_code"})
+		return nil, status.Error(codes.Unauthenticated, "invalid code")
+	}
+	return s.finishMfa(ctx, req.GetMfaToken(), userID, limitKeys, loginMethodMfaTotp)
+}
+
+// mfaChallengeUser はチャレンジのトークンのユーザーIDを返す（無効・期限切れの場合はUnauthenticated）
+func (s *AuthEntry) mfaChallengeUser(ctx context.Context, mfaToken string) (uuid.UUID, error) {
+	userIDStr, ok, err := s.MFAChallenges.Get(ctx, mfaToken)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.Internal, "failed to get mfa challenge: %v", err)
+	}
+	if !ok {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "invalid mfa token")
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "invalid mfa token")
+	}
+	return userID, nil
+}
+
+// finishMfa は2段階目を確認したユーザーのチャレンジを削除してトークンを発行し、レート制限をリセットする
+func (s *AuthEntry) finishMfa(ctx context.Context, mfaToken string, userID uuid.UUID, limitKeys []string, method string) (*g.AuthResponse, error) {
+	// 同じトークンで同時に成功した場合も、トークンを発行するのは1回だけにする
+	consumed, err := s.MFAChallenges.Consume(ctx, mfaToken)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to consume mfa challenge: %v", err)
+	}
+	if !consumed {
+		return nil, status.Error(codes.Unauthenticated, "invalid mfa token")
+	}
+
+	userDB, err := database.UserByID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user by ID: %v", err)
+	}
+	if err := checkAccountUsable(userDB); err != nil {
+		return nil, err
+	}
+	resp, err := s.issueTokens(ctx, userDB, method)
+	if err != nil {
+		return nil, err
+	}
+	s.resetLoginAttempts(ctx, limitKeys...)
+	return resp, nil
+}
+
+func (s *AuthEntry) GetMfaStatus(ctx context.Context, req *g.GetMfaStatusRequest) (*g.GetMfaStatusResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totp, err := enabledTotp(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get totp: %v", err)
+	}
+	if totp == nil {
+		return &g.GetMfaStatusResponse{}, nil
+	}
+	remaining, err := database.CountUnusedRecoveryCodes(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count recovery codes: %v", err)
+	}
+	return &g.GetMfaStatusResponse{Enabled: true, RecoveryCodesRemaining: int32(remaining)}, nil
+}
+
+func (s *AuthEntry) StartTotpEnrollment(ctx context.Context, req *g.StartTotpEnrollmentRequest) (*g.StartTotpEnrollmentResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userDB, err := database.UserByID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user by ID: %v", err)
+	}
+	existing, err := database.UserTotpByUserID(ctx, s.DB, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.Internal, "failed to get totp: %v", err)
+	}
+	if existing != nil && existing.EnabledAt.Valid {
+		return nil, status.Error(codes.AlreadyExists, "mfa is already enabled")
+	}
+
+	// 登録をやり直した場合は秘密鍵を作り直す（確認前の秘密鍵は上書きする）
+	secret, err := mfa.GenerateSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate totp secret: %v", err)
+	}
+	now := time.Now().Unix()
+	totp := &database.UserTotp{UserID: userID, Secret: secret, CreatedAt: now, UpdatedAt: now}
+	if err := totp.Upsert(ctx, s.DB); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save totp: %v", err)
+	}
+	return &g.StartTotpEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningUri: mfa.ProvisioningURI(userDB.Email, secret),
+	}, nil
+}
+
+func (s *AuthEntry) ConfirmTotpEnrollment(ctx context.Context, req *g.ConfirmTotpEnrollmentRequest) (*g.ConfirmTotpEnrollmentResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totp, err := database.UserTotpByUserID(ctx, s.DB, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "totp enrollment is not started")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get totp: %v", err)
+	}
+	if totp.EnabledAt.Valid {
+		return nil, status.Error(codes.FailedPrecondition, "mfa is already enabled")
+	}
+	step, ok := mfa.ValidateTOTP(totp.Secret, req.GetCode(), time.Now(), totp.LastUsedStep)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid code")
+	}
+
+	var recoveryCodes []string
+	now := time.Now().Unix()
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		totp.EnabledAt = sql.NullInt64{Int64: now, Valid: true}
+		totp.LastUsedStep = step
+		totp.UpdatedAt = now
+		if err := totp.Update(ctx, tx); err != nil {
+			return fmt.Errorf("failed to enable totp: %w", err)
+		}
+		recoveryCodes, err = replaceRecoveryCodes(ctx, tx, userID, now)
+		return err
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to enable mfa: %v", err)
+	}
+	return &g.ConfirmTotpEnrollmentResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *AuthEntry) RegenerateRecoveryCodes(ctx context.Context, req *g.RegenerateRecoveryCodesRequest) (*g.ConfirmTotpEnrollmentResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totp, err := s.requireTotp(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkLoginAttempts(ctx, mfaLimitKey(userID)); err != nil {
+		return nil, err
+	}
+	verified, err := s.verifySecondFactor(ctx, totp, req.GetCode(), false)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify code: %v", err)
+	}
+	if !verified {
+		return nil, status.Error(codes.InvalidArgument, "invalid code")
+	}
+
+	var recoveryCodes []string
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		recoveryCodes, err = replaceRecoveryCodes(ctx, tx, userID, time.Now().Unix())
+		return err
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to regenerate recovery codes: %v", err)
+	}
+	s.resetLoginAttempts(ctx, mfaLimitKey(userID))
+	return &g.ConfirmTotpEnrollmentResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *AuthEntry) DisableMfa(ctx context.Context, req *g.DisableMfaRequest) (*g.DisableMfaResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totp, err := s.requireTotp(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetPassword() == "" && req.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "password or code is required")
+	}
+	if err := s.checkLoginAttempts(ctx, mfaLimitKey(userID)); err != nil {
+		return nil, err
+	}
+
+	// アクセストークンを盗まれただけでは無効にできないよう、パスワードかコードを改めて確認する
+	if req.GetPassword() != "" {
+		passwordAuthDB, err := database.UserPasswordAutheByUserID(ctx, s.DB, userID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.Internal, "failed to get password auth: %v", err)
+		}
+		if passwordAuthDB == nil || request.VerifyPassword(req.GetPassword(), passwordAuthDB.PasswordHashed) != nil {
+			return nil, status.Error(codes.PermissionDenied, "invalid password")
+		}
+	} else {
+		verified, err := s.verifySecondFactor(ctx, totp, req.GetCode(), false)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to verify code: %v", err)
+		}
+		if !verified {
+			return nil, status.Error(codes.PermissionDenied, "invalid code")
+		}
+	}
+
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		if err := totp.Delete(ctx, tx); err != nil {
+			return fmt.Errorf("failed to delete totp: %w", err)
+		}
+		return database.DeleteRecoveryCodesByUserID(ctx, tx, userID)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to disable mfa: %v", err)
+	}
+	s.resetLoginAttempts(ctx, mfaLimitKey(userID))
+	return &g.DisableMfaResponse{}, nil
+}
+
+// verifySecondFactor は認証アプリのコード（allowRecoveryの場合はリカバリーコードも）を確認し、使用済みにする
+func (s *AuthEntry) verifySecondFactor(ctx context.Context, totp *database.UserTotp, code string, allowRecovery bool) (bool, error) {
+	now := time.Now()
+	if allowRecovery && mfa.IsRecoveryCode(code) {
+		return database.UseRecoveryCode(ctx, s.DB, totp.UserID, mfa.HashRecoveryCode(code), now.Unix())
+	}
+	step, ok := mfa.ValidateTOTP(totp.Secret, code, now, totp.LastUsedStep)
+	if !ok {
+		return false, nil
+	}
+	return database.UseTotpStep(ctx, s.DB, totp.UserID, step, now.Unix())
+}
+
+// requireTotp は2段階認証を有効にしているユーザーのTOTPを返す（有効にしていない場合はFailedPrecondition）
+func (s *AuthEntry) requireTotp(ctx context.Context, userID uuid.UUID) (*database.UserTotp, error) {
+	totp, err := enabledTotp(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get totp: %v", err)
+	}
+	if totp == nil {
+		return nil, status.Error(codes.FailedPrecondition, "mfa is not enabled")
+	}
+	return totp, nil
+}
+
+// checkLoginAttempts はログインのレート制限を識別子ごとに確認する
+func (s *AuthEntry) checkLoginAttempts(ctx context.Context, identifiers ...string) error {
+	if s.LoginLimiter == nil {
+		return nil
+	}
+	for _, id := range identifiers {
+		allowed, _, resetTime, err := s.LoginLimiter.CheckAttempt(ctx, id)
+		if err != nil {
+			return status.Errorf(codes.Internal, "rate limit check failed: %v", err)
+		}
+		if !allowed {
+			return status.Errorf(codes.ResourceExhausted,
+				"too many login attempts, try again in %v", resetTime)
+		}
+	}
+	return nil
+}
+
+func (s *AuthEntry) resetLoginAttempts(ctx context.Context, identifiers ...string) {
+	if s.LoginLimiter == nil {
+		return
+	}
+	for _, id := range identifiers {
+		_ = s.LoginLimiter.ResetAttempts(ctx, id)
+	}
+}
+
+// 2段階目に使える方法（AuthResponseのmfa_methods）
+const (
+	mfaMethodTotp    = "totp"
+	mfaMethodPasskey = "passkey"
+)
+
+// mfaLimitKey は2段階目のコードの試行回数をユーザーごとに数えるレート制限の識別子
+func mfaLimitKey(userID uuid.UUID) string {
+	return "mfa:" + userID.String()
+}
+
+// enabledTotp は有効にしているTOTPを返す（登録中か未登録の場合はnil）
+func enabledTotp(ctx context.Context, db database.DB, userID uuid.UUID) (*database.UserTotp, error) {
+	totp, err := database.UserTotpByUserID(ctx, db, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !totp.EnabledAt.Valid {
+		return nil, nil
+	}
+	return totp, nil
+}
+
+// replaceRecoveryCodes はリカバリーコードを作り直し、ハッシュを保存してコードを返す
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userID uuid.UUID, now int64) ([]string, error) {
+	if err := database.DeleteRecoveryCodesByUserID(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+	generated, err := mfa.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range generated {
+		rc := &database.UserRecoveryCode{
+			ID:         uuid.New(),
+			UserID:     userID,
+			CodeHashed: mfa.HashRecoveryCode(code),
+			CreatedAt:  now,
+		}
+		if err := rc.Insert(ctx, tx); err != nil {
+			return nil, fmt.Errorf("failed to insert recovery code: %w", err)
+		}
+	}
+	return generated, nil
+}
@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/mfa"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/redis/rueidis"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const mfaTestPassword = "validPassword123"
+
+// setupMFAAuthEntry はチャレンジとレート制限（3回）をminiredisに保存するAuthEntryを作成する
+func setupMFAAuthEntry(t *testing.T) *AuthEntry {
+	t.Helper()
+	db := setupTestDB(t)
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis起動失敗: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	redisClient, err := rueidis.NewClient(rueidis.ClientOption{InitAddress: []string{mr.Addr()}, DisableCache: true})
+	if err != nil {
+		t.Fatalf("rueidisクライアント作成失敗: %v", err)
+	}
+	t.Cleanup(redisClient.Close)
+	return &AuthEntry{
+		DB:            db,
+		LoginLimiter:  ratelimiter.NewLoginAttemptLimiter(ratelimiter.NewRedisRateLimiter(redisClient), 3, time.Minute),
+		MFAChallenges: &mfa.ChallengeStore{Redis: redisClient},
+	}
+}
+
+// enrollTotp はユーザーを登録して2段階認証を有効にし、メールアドレス・ログイン中のコンテキスト・秘密鍵・リカバリーコードを返す
+func enrollTotp(t *testing.T, s *AuthEntry) (string, context.Context, string, []string) {
+	t.Helper()
+	email := generateTestEmail(t, "mfa")
+	registered, err := s.RegisterByPassword(context.Background(), &g.RegisterByPasswordRequest{Email: email, Password: mfaTestPassword, Name: "MFA"})
+	if err != nil {
+		t.Fatalf("RegisterByPassword失敗: %v", err)
+	}
+	userCtx := userContext(t, registered)
+
+	start, err := s.StartTotpEnrollment(userCtx, &g.StartTotpEnrollmentRequest{})
+	if err != nil {
+		t.Fatalf("StartTotpEnrollment失敗: %v", err)
+	}
+	confirm, err := s.ConfirmTotpEnrollment(userCtx, &g.ConfirmTotpEnrollmentRequest{Code: totpCodeAt(t, start.Secret, 0)})
+	if err != nil {
+		t.Fatalf("ConfirmTotpEnrollment失敗: %v", err)
+	}
+	return email, userCtx, start.Secret, confirm.RecoveryCodes
+}
+
+// totpCodeAt は現在からstepsタイムステップ後のコードを返す（使用済みのタイムステップを避けるため）
+func totpCodeAt(t *testing.T, secret string, steps int) string {
+	t.Helper()
+	code, err := mfa.GenerateCode(secret, time.Now().Add(time.Duration(steps)*30*time.Second))
+	if err != nil {
+		t.Fatalf("GenerateCode失敗: %v", err)
+	}
+	return code
+}
+
+func TestAuthEntry_TotpEnrollment(t *testing.T) {
+	t.Run("正常系: 確認コードで有効にするとリカバリーコードを返し、状態に反映される", func(t *testing.T) {
+		s := setupMFAAuthEntry(t)
+		_, userCtx, _, recoveryCodes := enrollTotp(t, s)
+		if len(recoveryCodes) != mfa.RecoveryCodeCount {
+			t.Errorf("リカバリーコードの数が期待と異なる: %d", len(recoveryCodes))
+		}
+		st, err := s.GetMfaStatus(userCtx, &g.GetMfaStatusRequest{})
+		if err != nil {
+			t.Fatalf("GetMfaStatus失敗: %v", err)
+		}
+		if !st.Enabled || st.RecoveryCodesRemaining != int32(mfa.RecoveryCodeCount) {
+			t.Errorf("状態が期待と異なる: %v", st)
+		}
+		if _, err := s.StartTotpEnrollment(userCtx, &g.StartTotpEnrollmentRequest{}); status.Code(err) != codes.AlreadyExists {
+			t.Errorf("AlreadyExistsを期待したが %v", err)
+		}
+	})
+
+	t.Run("異常系: 確認コードが不正な場合は有効にならず、ログインは1段階のまま", func(t *testing.T) {
+		s := setupMFAAuthEntry(t)
+		email := generateTestEmail(t, "mfa-invalid")
+		registered, err := s.RegisterByPassword(context.Background(), &g.RegisterByPasswordRequest{Email: email, Password: mfaTestPassword, Name: "MFA"})
+		if err != nil {
+			t.Fatalf("RegisterByPassword失敗: %v", err)
+		}
+		userCtx := userContext(t, registered)
+		if _, err := s.ConfirmTotpEnrollment(userCtx, &g.ConfirmTotpEnrollmentRequest{Code: "123456"}); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("登録前はFailedPreconditionを期待したが %v", err)
+		}
+		if _, err := s.StartTotpEnrollment(userCtx, &g.StartTotpEnrollmentRequest{}); err != nil {
+			t.Fatalf("StartTotpEnrollment失敗: %v", err)
+		}
+		if _, err := s.ConfirmTotpEnrollment(userCtx, &g.ConfirmTotpEnrollmentRequest{Code: "000000x"}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("InvalidArgumentを期待したが %v", err)
+		}
+
+		resp, err := s.LoginByPassword(context.Background(), &g.LoginByPasswordRequest{Email: email, Password: mfaTestPassword})
+		if err != nil {
+			t.Fatalf("LoginByPassword失敗: %v", err)
+		}
+		if resp.MfaRequired || resp.AccessToken == "" {
+			t.Errorf("登録中のユーザーに2段階認証を求めた: %v", resp)
+		}
+	})
+}
+
+func TestAuthEntry_VerifyMfa(t *testing.T) {
+	t.Run("正常系: パスワードの確認でチャレンジを返し、TOTPのコードでトークンを発行する", func(t *testing.T) {
+		s := setupMFAAuthEntry(t)
+		email, _, secret, _ := enrollTotp(t, s)
+
+		login, err := s.LoginByPassword(context.Background(), &g.LoginByPasswordRequest{Email: email, Password: mfaTestPassword})
+		if err != nil {
+			t.Fatalf("LoginByPassword失敗: %v", err)
+		}
+		if !login.MfaRequired || login.MfaToken == "" || login.AccessToken != "" {
+			t.Fatalf("チャレンジを期待したが %v", login)
+		}
+
+		resp, err := s.VerifyMfa(context.Background(), &g.VerifyMfaRequest{MfaToken: login.MfaToken, Code: totpCodeAt(t, secret, 1)})
+		if err != nil {
+			t.Fatalf("VerifyMfa失敗: %v", err)
+		}
+		if resp.AccessToken == "" || resp.RefreshToken == "" {
+			t.Error("トークンが発行されていない")
+		}
+		// チャレンジは1回だけ使える
+		if _, err := s.VerifyMfa(context.Background(), &g.VerifyMfaRequest{MfaToken: login.MfaToken, Code: totpCodeAt(t, secret, 1)}); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Unauthenticatedを期待したが %v", err)
+		}
+	})
+
+	t.Run("正常系: リカバリーコードは1回だけ使える", func(t *testing.T) {
+		s := setupMFAAuthEntry(t)
+		email, userCtx, _, recoveryCodes := enrollTotp(t, s)
+
+		for i, want := range []codes.Code{codes.OK, codes.Unauthenticated} {
+			login, err := s.LoginByPassword(context.Background(), &g.LoginByPasswordRequest{Email: email, Password: mfaTestPassword})
+			if err != nil {
+				t.Fatalf("LoginByPassword失敗: %v", err)
+			}
+			_, err = s.VerifyMfa(context.Background(), &g.VerifyMfaRequest{MfaToken: login.MfaToken, Code: recoveryCodes[0]})
+			if status.Code(err) != want {
+				t.Errorf("%d回目: %vを期待したが %v", i+1, want, err)
+			}
+		}
+		st, err := s.GetMfaStatus(userCtx, &g.GetMfaStatusRequest{})
+		if err != nil {
+			t.Fatalf("GetMfaStatus失敗: %v", err)
+		}
+		if st.RecoveryCodesRemaining != int32(mfa.RecoveryCodeCount-1) {
+			t.Errorf("残りのリカバリーコードの数が期待と異なる: %d", st.RecoveryCodesRemaining)
+		}
+	})
+
+	t.Run("異常系: コードを間違え続けるとレート制限で止まる", func(t *testing.T) {
+		s := setupMFAAuthEntry(t)
+		email, _, secret, _ := enrollTotp(t, s)
+		login, err := s.LoginByPassword(context.Background(), &g.LoginByPasswordRequest{Email: email, Password: mfaTestPassword})
+		if err != nil {
+			t.Fatalf("LoginByPassword失敗: %v", err)
+		}
+
+		var lastErr error
+		for range 4 {
+			_, lastErr = s.VerifyMfa(context.Background(), &g.VerifyMfaRequest{MfaToken: login.MfaToken, Code: "000000"})
+		}
+		if status.Code(lastErr) != codes.ResourceExhausted {
+			t.Errorf("ResourceExhaustedを期待したが %v", lastErr)
+		}
+		// 上限に達した後は正しいコードでも受け付けない
+		_, err = s.VerifyMfa(context.Background(), &g.VerifyMfaRequest{MfaToken: login.MfaToken, Code: totpCodeAt(t, secret, 1)})
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("ResourceExhaustedを期待したが %v", err)
+		}
+	})
+}
+
+func TestAuthEntry_DisableMfa(t *testing.T) {
+	t.Run("異常系: パスワードかコードがないか、不正な場合は無効にできない", func(t *testing.T) {
+		s := setupMFAAuthEntry(t)
+		_, userCtx, _, _ := enrollTotp(t, s)
+		if _, err := s.DisableMfa(userCtx, &g.DisableMfaRequest{}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("InvalidArgumentを期待したが %v", err)
+		}
+		if _, err := s.DisableMfa(userCtx, &g.DisableMfaRequest{Password: "wrongPassword123"}); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("PermissionDeniedを期待したが %v", err)
+		}
+	})
+
+	t.Run("正常系: コードで無効にすると、ログインは1段階に戻る", func(t *testing.T) {
+		s := setupMFAAuthEntry(t)
+		email, userCtx, secret, _ := enrollTotp(t, s)
+		if _, err := s.DisableMfa(userCtx, &g.DisableMfaRequest{Code: totpCodeAt(t, secret, 1)}); err != nil {
+			t.Fatalf("DisableMfa失敗: %v", err)
+		}
+		resp, err := s.LoginByPassword(context.Background(), &g.LoginByPasswordRequest{Email: email, Password: mfaTestPassword})
+		if err != nil {
+			t.Fatalf("LoginByPassword失敗: %v", err)
+		}
+		if resp.MfaRequired || resp.AccessToken == "" {
+			t.Errorf("2段階認証が無効になっていない: %v", resp)
+		}
+		if _, err := s.DisableMfa(userCtx, &g.DisableMfaRequest{Password: mfaTestPassword}); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("FailedPreconditionを期待したが %v", err)
+		}
+	})
+
+	t.Run("正常系: パスワードでも無効にでき、リカバリーコードも削除される", func(t *testing.T) {
+		s := setupMFAAuthEntry(t)
+		_, userCtx, _, _ := enrollTotp(t, s)
+		if _, err := s.DisableMfa(userCtx, &g.DisableMfaRequest{Password: mfaTestPassword}); err != nil {
+			t.Fatalf("DisableMfa失敗: %v", err)
+		}
+		st, err := s.GetMfaStatus(userCtx, &g.GetMfaStatusRequest{})
+		if err != nil {
+			t.Fatalf("GetMfaStatus失敗: %v", err)
+		}
+		if st.Enabled || st.RecoveryCodesRemaining != 0 {
+			t.Errorf("状態が期待と異なる: %v", st)
+		}
+	})
+}
@@ -0,0 +1,361 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/project-mikan/umi.mikan/backend/domain/model"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/oidc"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxUserNameLength は users.name の最大文字数（IdPの名前が長い場合は切り詰める）
+const maxUserNameLength = 20
+
+func (s *AuthEntry) ListOIDCProviders(ctx context.Context, req *g.ListOIDCProvidersRequest) (*g.ListOIDCProvidersResponse, error) {
+	providers := make([]*g.OIDCProvider, 0, len(s.OIDCProviders.Providers()))
+	for _, p := range s.OIDCProviders.Providers() {
+		providers = append(providers, &g.OIDCProvider{Id: p.ID(), Name: p.Name()})
+	}
+	return &g.ListOIDCProvidersResponse{Providers: providers}, nil
+}
+
+func (s *AuthEntry) StartOIDCLogin(ctx context.Context, req *g.StartOIDCLoginRequest) (*g.StartOIDCLoginResponse, error) {
+	return s.startOIDC(ctx, req.GetProvider(), "")
+}
+
+func (s *AuthEntry) StartOIDCLink(ctx context.Context, req *g.StartOIDCLoginRequest) (*g.StartOIDCLoginResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := database.UserOautheByUserIDProvider(ctx, s.DB, userID, req.GetProvider()); err == nil {
+		return nil, status.Error(codes.AlreadyExists, "provider is already linked")
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.Internal, "failed to get linked provider: %v", err)
+	}
+	return s.startOIDC(ctx, req.GetProvider(), userID.String())
+}
+
+// startOIDC はIdPの認可URLを作成し、コールバックで照合するstate・nonce・code_verifierを保存する
+func (s *AuthEntry) startOIDC(ctx context.Context, providerID, linkUserID string) (*g.StartOIDCLoginResponse, error) {
+	provider, ok := s.OIDCProviders.Provider(providerID)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "oidc provider not found")
+	}
+	authReq, err := provider.NewAuthRequest(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to start oidc login: %v", err)
+	}
+	err = s.OIDCStates.Save(ctx, authReq.State, oidc.LoginState{
+		Provider:     provider.ID(),
+		Nonce:        authReq.Nonce,
+		CodeVerifier: authReq.CodeVerifier,
+		LinkUserID:   linkUserID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save oidc state: %v", err)
+	}
+	return &g.StartOIDCLoginResponse{AuthorizationUrl: authReq.URL, State: authReq.State}, nil
+}
+
+// completeOIDC はstateを消費して認可コードを交換し、IDトークンで確認したユーザーを返す。
+// ログインで開始したstateを連携に、連携で開始したstateをログインや別のユーザーに使えないよう、開始したユーザーを照合する
+func (s *AuthEntry) completeOIDC(ctx context.Context, state, code, linkUserID string) (*oidc.Provider, *oidc.Identity, error) {
+	if state == "" || code == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "state and code are required")
+	}
+	loginState, ok, err := s.OIDCStates.Consume(ctx, state)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get oidc state: %v", err)
+	}
+	if !ok || loginState.LinkUserID != linkUserID {
+		return nil, nil, status.Error(codes.InvalidArgument, "invalid state")
+	}
+	provider, ok := s.OIDCProviders.Provider(loginState.Provider)
+	if !ok {
+		return nil, nil, status.Error(codes.InvalidArgument, "invalid state")
+	}
+	identity, err := provider.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Unauthenticated, "failed to verify oidc login: %v", err)
+	}
+	return provider, identity, nil
+}
+
+func (s *AuthEntry) CompleteOIDCLogin(ctx context.Context, req *g.CompleteOIDCLoginRequest) (*g.AuthResponse, error) {
+	provider, identity, err := s.completeOIDC(ctx, req.GetState(), req.GetCode(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	// --- 連携済みのユーザー ---
+	link, err := database.UserOautheByProviderSubject(ctx, s.DB, provider.ID(), identity.Subject)
+	if err == nil {
+		userDB, err := database.UserByID(ctx, s.DB, link.UserID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get user by ID: %v", err)
+		}
+		return s.loginResponse(ctx, userDB, loginMethodOIDC)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.Internal, "failed to get linked provider: %v", err)
+	}
+
+	// --- 未連携: IdPが確認したメールアドレスで既存のユーザーに連携するか、新規登録する ---
+	// 確認していないメールアドレスで連携すると、他人のメールアドレスでIdPに登録してアカウントを乗っ取れるため使わない
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, status.Error(codes.FailedPrecondition, "email is not verified by the provider")
+	}
+	userDB, err := database.UserByEmail(ctx, s.DB, identity.Email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.Internal, "failed to get user by email: %v", err)
+	}
+	if userDB != nil {
+		// 無効にされた・削除を依頼したユーザーには連携もしない（2段階認証を有効にしているユーザーは、連携した上でVerifyMfaに進む）
+		if err := checkAccountUsable(userDB); err != nil {
+			return nil, err
+		}
+		// 既存のユーザーのメールアドレスが未確認の場合も連携しない。他人のメールアドレスでパスワード登録しておくと、
+		// 本人がIdPでログインした時に本人のIdPのアカウントが登録者のアカウントに連携され、登録者のパスワードでもログインできてしまう。
+		// 本人のアカウントであれば、ログインしてからStartOIDCLinkで連携できる
+		if !userDB.EmailVerifiedAt.Valid {
+			return nil, status.Error(codes.FailedPrecondition, "email is not verified for the existing account; log in and link the provider instead")
+		}
+		if err := insertUserOauthe(ctx, s.DB, userDB.ID, provider.ID(), identity); err != nil {
+			return nil, err
+		}
+		return s.loginResponse(ctx, userDB, loginMethodOIDC)
+	}
+
+	userDB, err = s.registerByOIDC(ctx, req.GetRegisterKey(), provider.ID(), identity)
+	if err != nil {
+		return nil, err
+	}
+	return s.loginResponse(ctx, userDB, loginMethodOIDC)
+}
+
+// registerByOIDC はIdPのユーザーで新規登録する（パスワードは持たない）。
+// パスワードでの登録と同じく、レート制限と招待コードを確認する
+func (s *AuthEntry) registerByOIDC(ctx context.Context, registerKey, providerID string, identity *oidc.Identity) (*database.User, error) {
+	if s.RegisterLimiter != nil {
+		allowed, _, resetTime, err := s.RegisterLimiter.CheckAttempt(ctx, s.getClientIdentifier(ctx))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "rate limit check failed: %v", err)
+		}
+		if !allowed {
+			return nil, status.Errorf(codes.ResourceExhausted,
+				"too many registration attempts, try again in %v", resetTime)
+		}
+	}
+	if s.invitationRequired() && registerKey == "" {
+		return nil, status.Error(codes.PermissionDenied, "registration failed")
+	}
+
+	user := model.GenUser(identity.Email, oidcUserName(identity), model.AuthTypeOIDC)
+	userDB := user.ConvertToDBModel()
+	// IdPが確認したメールアドレスのため、確認済みにする
+	userDB.EmailVerifiedAt = sql.NullInt64{Int64: userDB.CreatedAt, Valid: true}
+	err := database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		if err := userDB.Save(ctx, tx); err != nil {
+			return fmt.Errorf("failed to insert user: %w", err)
+		}
+		if err := insertUserOauthe(ctx, tx, user.ID, providerID, identity); err != nil {
+			return err
+		}
+		return s.useInvitation(ctx, tx, registerKey, user.ID)
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		if isUniqueViolation(err, "users_email_key") {
+			return nil, status.Error(codes.AlreadyExists, "user with this email already exists")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to register user: %v", err)
+	}
+	return &userDB, nil
+}
+
+func (s *AuthEntry) CompleteOIDCLink(ctx context.Context, req *g.CompleteOIDCLinkRequest) (*g.CompleteOIDCLinkResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	provider, identity, err := s.completeOIDC(ctx, req.GetState(), req.GetCode(), userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// 連携はログイン中のユーザーの操作のため、メールアドレスが異なるIdPのアカウントも連携できる
+	if _, err := database.UserOautheByProviderSubject(ctx, s.DB, provider.ID(), identity.Subject); err == nil {
+		return nil, status.Error(codes.AlreadyExists, "provider account is already linked")
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.Internal, "failed to get linked provider: %v", err)
+	}
+	if err := insertUserOauthe(ctx, s.DB, userID, provider.ID(), identity); err != nil {
+		return nil, err
+	}
+
+	link, err := database.UserOautheByUserIDProvider(ctx, s.DB, userID, provider.ID())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get linked provider: %v", err)
+	}
+	return &g.CompleteOIDCLinkResponse{Provider: convertLinkedOIDCProvider(link)}, nil
+}
+
+func (s *AuthEntry) ListLinkedOIDCProviders(ctx context.Context, req *g.ListLinkedOIDCProvidersRequest) (*g.ListLinkedOIDCProvidersResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	links, err := database.UserOauthesByUserID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list linked providers: %v", err)
+	}
+	hasPassword, err := hasPasswordAuth(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get password auth: %v", err)
+	}
+
+	providers := make([]*g.LinkedOIDCProvider, 0, len(links))
+	for _, link := range links {
+		providers = append(providers, convertLinkedOIDCProvider(link))
+	}
+	return &g.ListLinkedOIDCProvidersResponse{Providers: providers, HasPassword: hasPassword}, nil
+}
+
+func (s *AuthEntry) UnlinkOIDCProvider(ctx context.Context, req *g.UnlinkOIDCProviderRequest) (*g.UnlinkOIDCProviderResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		link, err := database.UserOautheByUserIDProvider(ctx, tx, userID, req.GetProvider())
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return status.Error(codes.NotFound, "provider is not linked")
+			}
+			return err
+		}
+		// パスワード・他の連携・パスキーのいずれもない場合に解除すると、ログインできなくなる
+		methods, err := countLoginMethods(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if methods <= 1 {
+			return status.Error(codes.FailedPrecondition, "cannot unlink the last login method")
+		}
+		return link.Delete(ctx, tx)
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to unlink provider: %v", err)
+	}
+	return &g.UnlinkOIDCProviderResponse{}, nil
+}
+
+// insertUserOauthe はユーザーにプロバイダーのアカウントを連携する。
+// 同時に連携した場合などの一意制約の違反はAlreadyExistsにする
+func insertUserOauthe(ctx context.Context, db database.DB, userID uuid.UUID, providerID string, identity *oidc.Identity) error {
+	now := time.Now().Unix()
+	link := &database.UserOauthe{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Provider:  providerID,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := link.Insert(ctx, db); err != nil {
+		if isUniqueViolation(err, "user_oauthes_user_id_provider_key") {
+			return status.Error(codes.AlreadyExists, "provider is already linked")
+		}
+		if isUniqueViolation(err, "user_oauthes_provider_subject_key") {
+			return status.Error(codes.AlreadyExists, "provider account is already linked")
+		}
+		return status.Errorf(codes.Internal, "failed to link provider: %v", err)
+	}
+	return nil
+}
+
+func hasPasswordAuth(ctx context.Context, db database.DB, userID uuid.UUID) (bool, error) {
+	if _, err := database.UserPasswordAutheByUserID(ctx, db, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// countLoginMethods はユーザーがログインに使える方法（パスワード・連携しているプロバイダー・パスキー）の数を返す
+func countLoginMethods(ctx context.Context, db database.DB, userID uuid.UUID) (int, error) {
+	count := 0
+	hasPassword, err := hasPasswordAuth(ctx, db, userID)
+	if err != nil {
+		return 0, err
+	}
+	if hasPassword {
+		count++
+	}
+	links, err := database.UserOauthesByUserID(ctx, db, userID)
+	if err != nil {
+		return 0, err
+	}
+	passkeys, err := database.CountPasskeysByUserID(ctx, db, userID)
+	if err != nil {
+		return 0, err
+	}
+	return count + len(links) + passkeys, nil
+}
+
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
+
+// oidcUserName はIdPの名前をユーザー名にする（ない場合はメールアドレスの@より前）
+func oidcUserName(identity *oidc.Identity) string {
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+	if runes := []rune(name); len(runes) > maxUserNameLength {
+		name = string(runes[:maxUserNameLength])
+	}
+	return name
+}
+
+func convertLinkedOIDCProvider(link *database.UserOauthe) *g.LinkedOIDCProvider {
+	return &g.LinkedOIDCProvider{
+		Provider:  link.Provider,
+		Email:     link.Email,
+		CreatedAt: link.CreatedAt,
+	}
+}
+
+func contextUserID(ctx context.Context) (uuid.UUID, error) {
+	userIDStr, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "invalidUserId")
+	}
+	return userID, nil
+}
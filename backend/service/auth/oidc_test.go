@@ -0,0 +1,325 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/domain/model"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/oidc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/oidc/oidctest"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"github.com/redis/rueidis"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testOIDCProvider = "mock"
+
+// setupOIDCAuthEntry はモックのIdPを設定したAuthEntryを作成する
+func setupOIDCAuthEntry(t *testing.T) (*AuthEntry, *oidctest.Server) {
+	t.Helper()
+	db := setupTestDB(t)
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis起動失敗: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	redisClient, err := rueidis.NewClient(rueidis.ClientOption{InitAddress: []string{mr.Addr()}, DisableCache: true})
+	if err != nil {
+		t.Fatalf("rueidisクライアント作成失敗: %v", err)
+	}
+	t.Cleanup(redisClient.Close)
+
+	idp := oidctest.NewServer(t)
+	registry := oidc.NewRegistry([]oidc.Config{{
+		ID:          testOIDCProvider,
+		Name:        "Mock",
+		Issuer:      idp.Issuer(),
+		ClientID:    oidctest.ClientID,
+		RedirectURL: "http://localhost:5173/auth/oidc/callback",
+	}}, idp.Client())
+	return &AuthEntry{DB: db, OIDCProviders: registry, OIDCStates: &oidc.StateStore{Redis: redisClient}}, idp
+}
+
+// oidcLogin はIdPでログインしてコールバックまで進め、CompleteOIDCLoginの結果を返す
+func oidcLogin(t *testing.T, s *AuthEntry, idp *oidctest.Server, claims oidctest.Claims, registerKey string) (*g.AuthResponse, error) {
+	t.Helper()
+	start, err := s.StartOIDCLogin(context.Background(), &g.StartOIDCLoginRequest{Provider: testOIDCProvider})
+	if err != nil {
+		t.Fatalf("StartOIDCLogin失敗: %v", err)
+	}
+	state, code := idp.Authorize(t, start.AuthorizationUrl, claims)
+	return s.CompleteOIDCLogin(context.Background(), &g.CompleteOIDCLoginRequest{State: state, Code: code, RegisterKey: registerKey})
+}
+
+// oidcLink はログイン中のユーザーにIdPのアカウントを連携する
+func oidcLink(t *testing.T, s *AuthEntry, idp *oidctest.Server, userCtx context.Context, claims oidctest.Claims) (*g.CompleteOIDCLinkResponse, error) {
+	t.Helper()
+	start, err := s.StartOIDCLink(userCtx, &g.StartOIDCLoginRequest{Provider: testOIDCProvider})
+	if err != nil {
+		return nil, err
+	}
+	state, code := idp.Authorize(t, start.AuthorizationUrl, claims)
+	return s.CompleteOIDCLink(userCtx, &g.CompleteOIDCLinkRequest{State: state, Code: code})
+}
+
+func userContext(t *testing.T, resp *g.AuthResponse) context.Context {
+	t.Helper()
+	_, userID, err := model.ParseAccessToken(resp.AccessToken)
+	if err != nil {
+		t.Fatalf("アクセストークンの解析失敗: %v", err)
+	}
+	return context.WithValue(context.Background(), middleware.UserIDKey, userID)
+}
+
+func TestAuthEntry_ListOIDCProviders(t *testing.T) {
+	t.Run("正常系: 設定したプロバイダーを返す", func(t *testing.T) {
+		s, _ := setupOIDCAuthEntry(t)
+		resp, err := s.ListOIDCProviders(context.Background(), &g.ListOIDCProvidersRequest{})
+		if err != nil {
+			t.Fatalf("ListOIDCProviders失敗: %v", err)
+		}
+		if len(resp.Providers) != 1 || resp.Providers[0].Id != testOIDCProvider || resp.Providers[0].Name != "Mock" {
+			t.Errorf("プロバイダーが期待と異なる: %v", resp.Providers)
+		}
+	})
+
+	t.Run("正常系: 設定がない場合は空", func(t *testing.T) {
+		s := &AuthEntry{}
+		resp, err := s.ListOIDCProviders(context.Background(), &g.ListOIDCProvidersRequest{})
+		if err != nil || len(resp.Providers) != 0 {
+			t.Errorf("空の一覧を期待したが %v, %v", resp, err)
+		}
+	})
+}
+
+func TestAuthEntry_OIDCLogin(t *testing.T) {
+	t.Run("正常系: 未登録のユーザーはパスワードなしで新規登録され、2回目以降は同じユーザーでログインする", func(t *testing.T) {
+		s, idp := setupOIDCAuthEntry(t)
+		claims := oidctest.Claims{Subject: uuid.NewString(), Email: generateTestEmail(t, "oidc-new"), EmailVerified: true, Name: "とても長い名前のユーザーでユーザー名の上限を超える"}
+
+		first, err := oidcLogin(t, s, idp, claims, "")
+		if err != nil {
+			t.Fatalf("CompleteOIDCLogin失敗: %v", err)
+		}
+		second, err := oidcLogin(t, s, idp, claims, "")
+		if err != nil {
+			t.Fatalf("2回目のCompleteOIDCLogin失敗: %v", err)
+		}
+		firstCtx, secondCtx := userContext(t, first), userContext(t, second)
+		if firstCtx.Value(middleware.UserIDKey) != secondCtx.Value(middleware.UserIDKey) {
+			t.Error("2回目のログインで別のユーザーになった")
+		}
+
+		var authType int16
+		var name string
+		if err := s.DB.QueryRow("SELECT auth_type, name FROM users WHERE email = $1", claims.Email).Scan(&authType, &name); err != nil {
+			t.Fatalf("ユーザーの取得失敗: %v", err)
+		}
+		if authType != model.AuthTypeOIDC.Int16() || len([]rune(name)) != maxUserNameLength {
+			t.Errorf("登録したユーザーが期待と異なる: auth_type=%d, name=%q", authType, name)
+		}
+
+		// パスワードを持たないユーザーはパスワードでログインできない
+		_, err = s.LoginByPassword(context.Background(), &g.LoginByPasswordRequest{Email: claims.Email, Password: "validPassword123"})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Unauthenticatedを期待したが %v", err)
+		}
+	})
+
+	t.Run("正常系: 確認済みのメールアドレスが同じ既存のユーザーに連携してログインする", func(t *testing.T) {
+		s, idp := setupOIDCAuthEntry(t)
+		email := generateTestEmail(t, "oidc-existing")
+		registered, err := s.RegisterByPassword(context.Background(), &g.RegisterByPasswordRequest{Email: email, Password: "validPassword123", Name: "Existing"})
+		if err != nil {
+			t.Fatalf("RegisterByPassword失敗: %v", err)
+		}
+		if _, err := s.DB.Exec("UPDATE users SET email_verified_at = 1 WHERE email = $1", email); err != nil {
+			t.Fatalf("ユーザーの更新失敗: %v", err)
+		}
+
+		resp, err := oidcLogin(t, s, idp, oidctest.Claims{Subject: uuid.NewString(), Email: email, EmailVerified: true}, "")
+		if err != nil {
+			t.Fatalf("CompleteOIDCLogin失敗: %v", err)
+		}
+		if userContext(t, resp).Value(middleware.UserIDKey) != userContext(t, registered).Value(middleware.UserIDKey) {
+			t.Error("既存のユーザーに連携されていない")
+		}
+	})
+
+	t.Run("異常系: 未連携でメールアドレスが未確認の場合は既存のユーザーに連携しない", func(t *testing.T) {
+		s, idp := setupOIDCAuthEntry(t)
+		email := generateTestEmail(t, "oidc-unverified")
+		if _, err := s.RegisterByPassword(context.Background(), &g.RegisterByPasswordRequest{Email: email, Password: "validPassword123", Name: "Victim"}); err != nil {
+			t.Fatalf("RegisterByPassword失敗: %v", err)
+		}
+
+		_, err := oidcLogin(t, s, idp, oidctest.Claims{Subject: uuid.NewString(), Email: email, EmailVerified: false}, "")
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("FailedPreconditionを期待したが %v", err)
+		}
+	})
+
+	t.Run("異常系: 既存のユーザーのメールアドレスが未確認の場合は連携しない", func(t *testing.T) {
+		s, idp := setupOIDCAuthEntry(t)
+		email := generateTestEmail(t, "oidc-preclaimed")
+		// 攻撃者が本人のメールアドレスでパスワード登録し、確認しないままにしておく
+		if _, err := s.RegisterByPassword(context.Background(), &g.RegisterByPasswordRequest{Email: email, Password: "validPassword123", Name: "Attacker"}); err != nil {
+			t.Fatalf("RegisterByPassword失敗: %v", err)
+		}
+
+		subject := uuid.NewString()
+		_, err := oidcLogin(t, s, idp, oidctest.Claims{Subject: subject, Email: email, EmailVerified: true}, "")
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("FailedPreconditionを期待したが %v", err)
+		}
+		if _, err := database.UserOautheByProviderSubject(context.Background(), s.DB, testOIDCProvider, subject); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("IdPのアカウントが連携されている: %v", err)
+		}
+	})
+
+	t.Run("異常系: REGISTER_KEYが設定されている場合、新規登録には登録キーが必要", func(t *testing.T) {
+		s, idp := setupOIDCAuthEntry(t)
+		s.RegisterKey = "secret"
+		claims := oidctest.Claims{Subject: uuid.NewString(), Email: generateTestEmail(t, "oidc-key"), EmailVerified: true, Name: "Key"}
+
+		if _, err := oidcLogin(t, s, idp, claims, "wrong"); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("PermissionDeniedを期待したが %v", err)
+		}
+		if _, err := oidcLogin(t, s, idp, claims, "secret"); err != nil {
+			t.Errorf("正しい登録キーで失敗した: %v", err)
+		}
+	})
+
+	t.Run("異常系: 無効にしたユーザーはログインできない", func(t *testing.T) {
+		s, idp := setupOIDCAuthEntry(t)
+		claims := oidctest.Claims{Subject: uuid.NewString(), Email: generateTestEmail(t, "oidc-disabled"), EmailVerified: true, Name: "Disabled"}
+		if _, err := oidcLogin(t, s, idp, claims, ""); err != nil {
+			t.Fatalf("CompleteOIDCLogin失敗: %v", err)
+		}
+		if _, err := s.DB.Exec("UPDATE users SET disabled_at = 1 WHERE email = $1", claims.Email); err != nil {
+			t.Fatalf("ユーザーの更新失敗: %v", err)
+		}
+
+		if _, err := oidcLogin(t, s, idp, claims, ""); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("PermissionDeniedを期待したが %v", err)
+		}
+	})
+
+	t.Run("異常系: 同じstateは2回使えず、未知のプロバイダーは開始できない", func(t *testing.T) {
+		s, idp := setupOIDCAuthEntry(t)
+		start, err := s.StartOIDCLogin(context.Background(), &g.StartOIDCLoginRequest{Provider: testOIDCProvider})
+		if err != nil {
+			t.Fatalf("StartOIDCLogin失敗: %v", err)
+		}
+		claims := oidctest.Claims{Subject: uuid.NewString(), Email: generateTestEmail(t, "oidc-replay"), EmailVerified: true, Name: "Replay"}
+		state, code := idp.Authorize(t, start.AuthorizationUrl, claims)
+		if _, err := s.CompleteOIDCLogin(context.Background(), &g.CompleteOIDCLoginRequest{State: state, Code: code}); err != nil {
+			t.Fatalf("CompleteOIDCLogin失敗: %v", err)
+		}
+		if _, err := s.CompleteOIDCLogin(context.Background(), &g.CompleteOIDCLoginRequest{State: state, Code: code}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("InvalidArgumentを期待したが %v", err)
+		}
+		if _, err := s.StartOIDCLogin(context.Background(), &g.StartOIDCLoginRequest{Provider: "unknown"}); status.Code(err) != codes.NotFound {
+			t.Errorf("NotFoundを期待したが %v", err)
+		}
+	})
+}
+
+func TestAuthEntry_OIDCLink(t *testing.T) {
+	t.Run("正常系: 連携・一覧・解除ができる（メールアドレスが異なるアカウントも連携できる）", func(t *testing.T) {
+		s, idp := setupOIDCAuthEntry(t)
+		registered, err := s.RegisterByPassword(context.Background(), &g.RegisterByPasswordRequest{Email: generateTestEmail(t, "oidc-link"), Password: "validPassword123", Name: "Link"})
+		if err != nil {
+			t.Fatalf("RegisterByPassword失敗: %v", err)
+		}
+		userCtx := userContext(t, registered)
+		claims := oidctest.Claims{Subject: uuid.NewString(), Email: "other-address@example.com", EmailVerified: false}
+
+		linked, err := oidcLink(t, s, idp, userCtx, claims)
+		if err != nil {
+			t.Fatalf("CompleteOIDCLink失敗: %v", err)
+		}
+		if linked.Provider.Provider != testOIDCProvider || linked.Provider.Email != claims.Email {
+			t.Errorf("連携したプロバイダーが期待と異なる: %v", linked.Provider)
+		}
+
+		list, err := s.ListLinkedOIDCProviders(userCtx, &g.ListLinkedOIDCProvidersRequest{})
+		if err != nil {
+			t.Fatalf("ListLinkedOIDCProviders失敗: %v", err)
+		}
+		if len(list.Providers) != 1 || !list.HasPassword {
+			t.Errorf("一覧が期待と異なる: %v", list)
+		}
+
+		// 連携したアカウントでログインできる
+		resp, err := oidcLogin(t, s, idp, claims, "")
+		if err != nil {
+			t.Fatalf("CompleteOIDCLogin失敗: %v", err)
+		}
+		if userContext(t, resp).Value(middleware.UserIDKey) != userCtx.Value(middleware.UserIDKey) {
+			t.Error("連携したユーザーでログインしていない")
+		}
+
+		if _, err := s.UnlinkOIDCProvider(userCtx, &g.UnlinkOIDCProviderRequest{Provider: testOIDCProvider}); err != nil {
+			t.Fatalf("UnlinkOIDCProvider失敗: %v", err)
+		}
+		if _, err := s.UnlinkOIDCProvider(userCtx, &g.UnlinkOIDCProviderRequest{Provider: testOIDCProvider}); status.Code(err) != codes.NotFound {
+			t.Errorf("NotFoundを期待したが %v", err)
+		}
+	})
+
+	t.Run("異常系: 別のユーザーに連携済みのアカウントは連携できない", func(t *testing.T) {
+		s, idp := setupOIDCAuthEntry(t)
+		claims := oidctest.Claims{Subject: uuid.NewString(), Email: generateTestEmail(t, "oidc-taken"), EmailVerified: true, Name: "Taken"}
+		if _, err := oidcLogin(t, s, idp, claims, ""); err != nil {
+			t.Fatalf("CompleteOIDCLogin失敗: %v", err)
+		}
+		other, err := s.RegisterByPassword(context.Background(), &g.RegisterByPasswordRequest{Email: generateTestEmail(t, "oidc-other"), Password: "validPassword123", Name: "Other"})
+		if err != nil {
+			t.Fatalf("RegisterByPassword失敗: %v", err)
+		}
+
+		if _, err := oidcLink(t, s, idp, userContext(t, other), claims); status.Code(err) != codes.AlreadyExists {
+			t.Errorf("AlreadyExistsを期待したが %v", err)
+		}
+	})
+
+	t.Run("異常系: ログインで開始したstateは連携に使えない", func(t *testing.T) {
+		s, idp := setupOIDCAuthEntry(t)
+		registered, err := s.RegisterByPassword(context.Background(), &g.RegisterByPasswordRequest{Email: generateTestEmail(t, "oidc-state"), Password: "validPassword123", Name: "State"})
+		if err != nil {
+			t.Fatalf("RegisterByPassword失敗: %v", err)
+		}
+		start, err := s.StartOIDCLogin(context.Background(), &g.StartOIDCLoginRequest{Provider: testOIDCProvider})
+		if err != nil {
+			t.Fatalf("StartOIDCLogin失敗: %v", err)
+		}
+		state, code := idp.Authorize(t, start.AuthorizationUrl, oidctest.Claims{Subject: uuid.NewString(), Email: "x@example.com", EmailVerified: true})
+
+		_, err = s.CompleteOIDCLink(userContext(t, registered), &g.CompleteOIDCLinkRequest{State: state, Code: code})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("InvalidArgumentを期待したが %v", err)
+		}
+	})
+
+	t.Run("異常系: パスワードのないユーザーは最後の連携を解除できない", func(t *testing.T) {
+		s, idp := setupOIDCAuthEntry(t)
+		resp, err := oidcLogin(t, s, idp, oidctest.Claims{Subject: uuid.NewString(), Email: generateTestEmail(t, "oidc-last"), EmailVerified: true, Name: "Last"}, "")
+		if err != nil {
+			t.Fatalf("CompleteOIDCLogin失敗: %v", err)
+		}
+
+		_, err = s.UnlinkOIDCProvider(userContext(t, resp), &g.UnlinkOIDCProviderRequest{Provider: testOIDCProvider})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("FailedPreconditionを期待したが %v", err)
+		}
+	})
+}
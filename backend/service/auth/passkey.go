@@ -0,0 +1,332 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/webauthn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// パスキーの名前
+const (
+	defaultPasskeyName   = "Passkey"
+	maxPasskeyNameLength = 100
+)
+
+func (s *AuthEntry) StartPasskeyRegistration(ctx context.Context, req *g.StartPasskeyRegistrationRequest) (*g.PasskeyOptionsResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userDB, err := database.UserByID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user by ID: %v", err)
+	}
+	// 同じ認証器で2つ目のパスキーを作らないよう、登録済みのパスキーを除外する
+	exclude, err := passkeyDescriptors(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get passkeys: %v", err)
+	}
+	challenge, err := s.PasskeyChallenges.Create(ctx, webauthn.Session{Ceremony: webauthn.CeremonyRegistration, UserID: userID.String()})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create webauthn challenge: %v", err)
+	}
+	// userHandleはユーザーIDのバイト列にする（パスキーでのログイン時にユーザーの確認に使う）
+	user := webauthn.User{ID: userID[:], Name: userDB.Email, DisplayName: userDB.Name}
+	options, err := s.WebAuthn.CreationOptions(user, challenge, exclude)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create options: %v", err)
+	}
+	return &g.PasskeyOptionsResponse{OptionsJson: string(options)}, nil
+}
+
+func (s *AuthEntry) FinishPasskeyRegistration(ctx context.Context, req *g.FinishPasskeyRegistrationRequest) (*g.FinishPasskeyRegistrationResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		name = defaultPasskeyName
+	}
+	if utf8.RuneCountInString(name) > maxPasskeyNameLength {
+		return nil, status.Errorf(codes.InvalidArgument, "name must be at most %d characters", maxPasskeyNameLength)
+	}
+	registration, err := webauthn.ParseRegistration([]byte(req.GetCredentialJson()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid credential: %v", err)
+	}
+	session, ok, err := s.PasskeyChallenges.Consume(ctx, registration.Challenge())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to consume webauthn challenge: %v", err)
+	}
+	if !ok || session.Ceremony != webauthn.CeremonyRegistration || session.UserID != userID.String() {
+		return nil, status.Error(codes.InvalidArgument, "invalid or expired challenge")
+	}
+	credential, err := s.WebAuthn.VerifyRegistration(registration, registration.Challenge())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid credential: %v", err)
+	}
+
+	transports, err := json.Marshal(nonNilStrings(credential.Transports))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal transports: %v", err)
+	}
+	now := time.Now().Unix()
+	passkey := &database.UserPasskey{
+		ID:           uuid.New(),
+		UserID:       userID,
+		CredentialID: webauthn.EncodeID(credential.ID),
+		PublicKey:    credential.PublicKey,
+		SignCount:    int64(credential.SignCount),
+		Transports:   transports,
+		Name:         name,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := passkey.Insert(ctx, s.DB); err != nil {
+		if isUniqueViolation(err, "user_passkeys_credential_id_key") {
+			return nil, status.Error(codes.AlreadyExists, "passkey is already registered")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to save passkey: %v", err)
+	}
+	return &g.FinishPasskeyRegistrationResponse{Passkey: convertPasskey(passkey)}, nil
+}
+
+func (s *AuthEntry) StartPasskeyLogin(ctx context.Context, req *g.StartPasskeyLoginRequest) (*g.PasskeyOptionsResponse, error) {
+	challenge, err := s.PasskeyChallenges.Create(ctx, webauthn.Session{Ceremony: webauthn.CeremonyLogin})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create webauthn challenge: %v", err)
+	}
+	// 端末に保存されたパスキーから選ばせ、生体認証・PINでの確認を求める
+	options, err := s.WebAuthn.RequestOptions(challenge, nil, true)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create options: %v", err)
+	}
+	return &g.PasskeyOptionsResponse{OptionsJson: string(options)}, nil
+}
+
+func (s *AuthEntry) FinishPasskeyLogin(ctx context.Context, req *g.FinishPasskeyLoginRequest) (*g.AuthResponse, error) {
+	clientID := s.getClientIdentifier(ctx)
+	if err := s.checkLoginAttempts(ctx, clientID); err != nil {
+		return nil, err
+	}
+	assertion, err := webauthn.ParseAssertion([]byte(req.GetCredentialJson()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid credential: %v", err)
+	}
+	session, ok, err := s.PasskeyChallenges.Consume(ctx, assertion.Challenge())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to consume webauthn challenge: %v", err)
+	}
+	if !ok || session.Ceremony != webauthn.CeremonyLogin {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired challenge")
+	}
+	passkey, err := s.verifyPasskeyAssertion(ctx, assertion, uuid.Nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	userDB, err := database.UserByID(ctx, s.DB, passkey.UserID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user by ID: %v", err)
+	}
+	if err := checkAccountUsable(userDB); err != nil {
+		return nil, err
+	}
+	// 生体認証・PINで確認したパスキーは所持と本人確認の2つの要素を満たすため、2段階目は求めない
+	resp, err := s.issueTokens(ctx, userDB, loginMethodPasskey)
+	if err != nil {
+		return nil, err
+	}
+	s.resetLoginAttempts(ctx, clientID)
+	return resp, nil
+}
+
+func (s *AuthEntry) StartPasskeyMfa(ctx context.Context, req *g.StartPasskeyMfaRequest) (*g.PasskeyOptionsResponse, error) {
+	userID, err := s.mfaChallengeUser(ctx, req.GetMfaToken())
+	if err != nil {
+		return nil, err
+	}
+	allow, err := passkeyDescriptors(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get passkeys: %v", err)
+	}
+	if len(allow) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "no passkeys are registered")
+	}
+	challenge, err := s.PasskeyChallenges.Create(ctx, webauthn.Session{Ceremony: webauthn.CeremonyMFA, UserID: userID.String()})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create webauthn challenge: %v", err)
+	}
+	// 1段階目で本人を確認済みのため、認証器の所持だけを確認する
+	options, err := s.WebAuthn.RequestOptions(challenge, allow, false)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create options: %v", err)
+	}
+	return &g.PasskeyOptionsResponse{OptionsJson: string(options)}, nil
+}
+
+func (s *AuthEntry) FinishPasskeyMfa(ctx context.Context, req *g.FinishPasskeyMfaRequest) (*g.AuthResponse, error) {
+	userID, err := s.mfaChallengeUser(ctx, req.GetMfaToken())
+	if err != nil {
+		return nil, err
+	}
+	limitKeys := []string{s.getClientIdentifier(ctx), mfaLimitKey(userID)}
+	if err := s.checkLoginAttempts(ctx, limitKeys...); err != nil {
+		return nil, err
+	}
+	assertion, err := webauthn.ParseAssertion([]byte(req.GetCredentialJson()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid credential: %v", err)
+	}
+	session, ok, err := s.PasskeyChallenges.Consume(ctx, assertion.Challenge())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to consume webauthn challenge: %v", err)
+	}
+	if !ok || session.Ceremony != webauthn.CeremonyMFA || session.UserID != userID.String() {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired challenge")
+	}
+	if _, err := s.verifyPasskeyAssertion(ctx, assertion, userID, false); err != nil {
+		return nil, err
+	}
+	return s.finishMfa(ctx, req.GetMfaToken(), userID, limitKeys, loginMethodMfaPasskey)
+}
+
+func (s *AuthEntry) ListPasskeys(ctx context.Context, req *g.ListPasskeysRequest) (*g.ListPasskeysResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	passkeys, err := database.UserPasskeysByUserID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get passkeys: %v", err)
+	}
+	resp := &g.ListPasskeysResponse{Passkeys: make([]*g.Passkey, 0, len(passkeys))}
+	for _, p := range passkeys {
+		resp.Passkeys = append(resp.Passkeys, convertPasskey(p))
+	}
+	return resp, nil
+}
+
+func (s *AuthEntry) DeletePasskey(ctx context.Context, req *g.DeletePasskeyRequest) (*g.DeletePasskeyResponse, error) {
+	userID, err := contextUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid passkey id")
+	}
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		passkey, err := database.UserPasskeyByID(ctx, tx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return status.Error(codes.NotFound, "passkey not found")
+			}
+			return err
+		}
+		if passkey.UserID != userID {
+			return status.Error(codes.NotFound, "passkey not found")
+		}
+		// パスワードも連携もなく最後のパスキーを削除すると、ログインできなくなる
+		methods, err := countLoginMethods(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if methods <= 1 {
+			return status.Error(codes.FailedPrecondition, "cannot delete the last login method")
+		}
+		return passkey.Delete(ctx, tx)
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete passkey: %v", err)
+	}
+	return &g.DeletePasskeyResponse{}, nil
+}
+
+// verifyPasskeyAssertion は登録済みのパスキーで認証のレスポンスを検証し、署名カウンターを更新する。
+// userIDを指定した場合はそのユーザーのパスキーに限る。検証に失敗した場合はUnauthenticated
+func (s *AuthEntry) verifyPasskeyAssertion(ctx context.Context, assertion *webauthn.Assertion, userID uuid.UUID, requireUserVerification bool) (*database.UserPasskey, error) {
+	passkey, err := database.UserPasskeyByCredentialID(ctx, s.DB, webauthn.EncodeID(assertion.CredentialID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.Unauthenticated, "unknown passkey")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get passkey: %v", err)
+	}
+	if userID != uuid.Nil && passkey.UserID != userID {
+		return nil, status.Error(codes.Unauthenticated, "unknown passkey")
+	}
+	// 認証器が返したuserHandleは登録時のユーザーIDと一致する必要がある
+	if len(assertion.UserHandle) > 0 && !bytes.Equal(assertion.UserHandle, passkey.UserID[:]) {
+		return nil, status.Error(codes.Unauthenticated, "user handle mismatch")
+	}
+	signCount, err := s.WebAuthn.VerifyAssertion(assertion, assertion.Challenge(), passkey.PublicKey, uint32(passkey.SignCount), requireUserVerification)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid passkey assertion: %v", err)
+	}
+	used, err := database.UsePasskey(ctx, s.DB, passkey.ID, passkey.SignCount, int64(signCount), time.Now().Unix())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update passkey: %v", err)
+	}
+	if !used {
+		// 検証中に同じパスキーで別のログインが成功した場合
+		return nil, status.Error(codes.Unauthenticated, "invalid passkey assertion")
+	}
+	return passkey, nil
+}
+
+// passkeyDescriptors はユーザーが登録したパスキーをオプションに含める形式で返す
+func passkeyDescriptors(ctx context.Context, db database.DB, userID uuid.UUID) ([]webauthn.CredentialDescriptor, error) {
+	passkeys, err := database.UserPasskeysByUserID(ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+	descriptors := make([]webauthn.CredentialDescriptor, 0, len(passkeys))
+	for _, p := range passkeys {
+		id, err := webauthn.DecodeID(p.CredentialID)
+		if err != nil {
+			return nil, err
+		}
+		descriptors = append(descriptors, webauthn.CredentialDescriptor{ID: id, Transports: passkeyTransports(p)})
+	}
+	return descriptors, nil
+}
+
+func passkeyTransports(p *database.UserPasskey) []string {
+	var transports []string
+	_ = json.Unmarshal(p.Transports, &transports)
+	return transports
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func convertPasskey(p *database.UserPasskey) *g.Passkey {
+	return &g.Passkey{
+		Id:         p.ID.String(),
+		Name:       p.Name,
+		Transports: passkeyTransports(p),
+		CreatedAt:  p.CreatedAt,
+		LastUsedAt: p.LastUsedAt.Int64,
+	}
+}
@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/webauthn"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/webauthn/webauthntest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	passkeyTestRPID   = "localhost"
+	passkeyTestOrigin = "http://localhost:2000"
+)
+
+// setupPasskeyAuthEntry はパスキーのチャレンジもminiredisに保存するAuthEntryを作成する
+func setupPasskeyAuthEntry(t *testing.T) *AuthEntry {
+	t.Helper()
+	s := setupMFAAuthEntry(t)
+	s.WebAuthn = webauthn.NewRelyingParty(webauthn.Config{RPID: passkeyTestRPID, RPName: "umi.mikan", Origins: []string{passkeyTestOrigin}})
+	s.PasskeyChallenges = &webauthn.ChallengeStore{Redis: s.MFAChallenges.Redis}
+	return s
+}
+
+// registerPasskey はログイン中のユーザーにソフトウェアの認証器のパスキーを登録する
+func registerPasskey(t *testing.T, s *AuthEntry, userCtx context.Context, name string) (*webauthntest.Authenticator, *g.Passkey) {
+	t.Helper()
+	start, err := s.StartPasskeyRegistration(userCtx, &g.StartPasskeyRegistrationRequest{})
+	if err != nil {
+		t.Fatalf("StartPasskeyRegistration失敗: %v", err)
+	}
+	a := webauthntest.NewAuthenticator(t, passkeyTestRPID, passkeyTestOrigin)
+	resp, err := s.FinishPasskeyRegistration(userCtx, &g.FinishPasskeyRegistrationRequest{
+		CredentialJson: string(a.Register(t, []byte(start.OptionsJson))),
+		Name:           name,
+	})
+	if err != nil {
+		t.Fatalf("FinishPasskeyRegistration失敗: %v", err)
+	}
+	return a, resp.Passkey
+}
+
+// passkeyLogin はパスキーでログインする
+func passkeyLogin(t *testing.T, s *AuthEntry, a *webauthntest.Authenticator) (*g.AuthResponse, error) {
+	t.Helper()
+	start, err := s.StartPasskeyLogin(context.Background(), &g.StartPasskeyLoginRequest{})
+	if err != nil {
+		t.Fatalf("StartPasskeyLogin失敗: %v", err)
+	}
+	return s.FinishPasskeyLogin(context.Background(), &g.FinishPasskeyLoginRequest{CredentialJson: string(a.Login(t, []byte(start.OptionsJson)))})
+}
+
+func TestAuthEntry_PasskeyLogin(t *testing.T) {
+	t.Run("正常系: 登録したパスキーでログインでき、2段階認証を有効にしていても2段階目を求めない", func(t *testing.T) {
+		s := setupPasskeyAuthEntry(t)
+		_, userCtx, _, _ := enrollTotp(t, s)
+		a, passkey := registerPasskey(t, s, userCtx, "iPhone")
+		if passkey.Name != "iPhone" || len(passkey.Transports) != 2 {
+			t.Errorf("パスキーが期待と異なる: %v", passkey)
+		}
+
+		resp, err := passkeyLogin(t, s, a)
+		if err != nil {
+			t.Fatalf("FinishPasskeyLogin失敗: %v", err)
+		}
+		if resp.MfaRequired || resp.AccessToken == "" {
+			t.Errorf("トークンが発行されていない: %v", resp)
+		}
+		list, err := s.ListPasskeys(userCtx, &g.ListPasskeysRequest{})
+		if err != nil {
+			t.Fatalf("ListPasskeys失敗: %v", err)
+		}
+		if len(list.Passkeys) != 1 || list.Passkeys[0].LastUsedAt == 0 {
+			t.Errorf("最後に使った日時が記録されていない: %v", list.Passkeys)
+		}
+	})
+
+	t.Run("異常系: 同じレスポンスを2回使うとチャレンジが無効になっている", func(t *testing.T) {
+		s := setupPasskeyAuthEntry(t)
+		registered, err := s.RegisterByPassword(context.Background(), &g.RegisterByPasswordRequest{Email: generateTestEmail(t, "passkey-replay"), Password: mfaTestPassword, Name: "Passkey"})
+		if err != nil {
+			t.Fatalf("RegisterByPassword失敗: %v", err)
+		}
+		a, _ := registerPasskey(t, s, userContext(t, registered), "")
+		start, err := s.StartPasskeyLogin(context.Background(), &g.StartPasskeyLoginRequest{})
+		if err != nil {
+			t.Fatalf("StartPasskeyLogin失敗: %v", err)
+		}
+		credential := string(a.Login(t, []byte(start.OptionsJson)))
+		if _, err := s.FinishPasskeyLogin(context.Background(), &g.FinishPasskeyLoginRequest{CredentialJson: credential}); err != nil {
+			t.Fatalf("FinishPasskeyLogin失敗: %v", err)
+		}
+		if _, err := s.FinishPasskeyLogin(context.Background(), &g.FinishPasskeyLoginRequest{CredentialJson: credential}); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Unauthenticatedを期待したが %v", err)
+		}
+	})
+
+	t.Run("異常系: 生体認証・PINで確認していないパスキーではログインできない", func(t *testing.T) {
+		s := setupPasskeyAuthEntry(t)
+		registered, err := s.RegisterByPassword(context.Background(), &g.RegisterByPasswordRequest{Email: generateTestEmail(t, "passkey-uv"), Password: mfaTestPassword, Name: "Passkey"})
+		if err != nil {
+			t.Fatalf("RegisterByPassword失敗: %v", err)
+		}
+		a, _ := registerPasskey(t, s, userContext(t, registered), "")
+		a.UserVerified = false
+		if _, err := passkeyLogin(t, s, a); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Unauthenticatedを期待したが %v", err)
+		}
+	})
+}
+
+func TestAuthEntry_PasskeyMfa(t *testing.T) {
+	t.Run("正常系: パスワードの確認後にパスキーで2段階目を満たす", func(t *testing.T) {
+		s := setupPasskeyAuthEntry(t)
+		email, userCtx, _, _ := enrollTotp(t, s)
+		a, _ := registerPasskey(t, s, userCtx, "YubiKey")
+		// 2段階目では本人確認（UV）を求めない
+		a.UserVerified = false
+
+		login, err := s.LoginByPassword(context.Background(), &g.LoginByPasswordRequest{Email: email, Password: mfaTestPassword})
+		if err != nil {
+			t.Fatalf("LoginByPassword失敗: %v", err)
+		}
+		if !login.MfaRequired || len(login.MfaMethods) != 2 || login.MfaMethods[1] != mfaMethodPasskey {
+			t.Fatalf("パスキーを含むチャレンジを期待したが %v", login)
+		}
+		start, err := s.StartPasskeyMfa(context.Background(), &g.StartPasskeyMfaRequest{MfaToken: login.MfaToken})
+		if err != nil {
+			t.Fatalf("StartPasskeyMfa失敗: %v", err)
+		}
+		resp, err := s.FinishPasskeyMfa(context.Background(), &g.FinishPasskeyMfaRequest{
+			MfaToken:       login.MfaToken,
+			CredentialJson: string(a.Login(t, []byte(start.OptionsJson))),
+		})
+		if err != nil {
+			t.Fatalf("FinishPasskeyMfa失敗: %v", err)
+		}
+		if resp.AccessToken == "" {
+			t.Error("トークンが発行されていない")
+		}
+	})
+
+	t.Run("異常系: 別のユーザーのパスキーでは2段階目を満たせない", func(t *testing.T) {
+		s := setupPasskeyAuthEntry(t)
+		email, userCtx, _, _ := enrollTotp(t, s)
+		registerPasskey(t, s, userCtx, "")
+		_, otherCtx, _, _ := enrollTotp(t, s)
+		other, _ := registerPasskey(t, s, otherCtx, "")
+
+		login, err := s.LoginByPassword(context.Background(), &g.LoginByPasswordRequest{Email: email, Password: mfaTestPassword})
+		if err != nil {
+			t.Fatalf("LoginByPassword失敗: %v", err)
+		}
+		start, err := s.StartPasskeyMfa(context.Background(), &g.StartPasskeyMfaRequest{MfaToken: login.MfaToken})
+		if err != nil {
+			t.Fatalf("StartPasskeyMfa失敗: %v", err)
+		}
+		_, err = s.FinishPasskeyMfa(context.Background(), &g.FinishPasskeyMfaRequest{
+			MfaToken:       login.MfaToken,
+			CredentialJson: string(other.Login(t, []byte(start.OptionsJson))),
+		})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Unauthenticatedを期待したが %v", err)
+		}
+	})
+}
+
+func TestAuthEntry_DeletePasskey(t *testing.T) {
+	t.Run("正常系: パスワードがあれば最後のパスキーも削除できる", func(t *testing.T) {
+		s := setupPasskeyAuthEntry(t)
+		_, userCtx, _, _ := enrollTotp(t, s)
+		_, passkey := registerPasskey(t, s, userCtx, "")
+		if _, err := s.DeletePasskey(userCtx, &g.DeletePasskeyRequest{Id: passkey.Id}); err != nil {
+			t.Fatalf("DeletePasskey失敗: %v", err)
+		}
+		list, err := s.ListPasskeys(userCtx, &g.ListPasskeysRequest{})
+		if err != nil {
+			t.Fatalf("ListPasskeys失敗: %v", err)
+		}
+		if len(list.Passkeys) != 0 {
+			t.Errorf("パスキーが削除されていない: %v", list.Passkeys)
+		}
+	})
+
+	t.Run("異常系: 他のユーザーのパスキーは削除できない", func(t *testing.T) {
+		s := setupPasskeyAuthEntry(t)
+		_, userCtx, _, _ := enrollTotp(t, s)
+		_, otherCtx, _, _ := enrollTotp(t, s)
+		_, passkey := registerPasskey(t, s, otherCtx, "")
+		if _, err := s.DeletePasskey(userCtx, &g.DeletePasskeyRequest{Id: passkey.Id}); status.Code(err) != codes.NotFound {
+			t.Errorf("NotFoundを期待したが %v", err)
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/securityevent"
+)
+
+// ログインの方法（セキュリティイベントのdetailのmethod）
+const (
+	loginMethodPassword   = "password"
+	loginMethodOIDC       = "oidc"
+	loginMethodPasskey    = "passkey"
+	loginMethodMfaTotp    = "mfa_totp"
+	loginMethodMfaPasskey = "mfa_passkey"
+)
+
+// recordSecurityEvent はセキュリティイベントを記録する。認証の結果は変えないため、記録に失敗してもログに残すだけにする
+func (s *AuthEntry) recordSecurityEvent(ctx context.Context, userID uuid.UUID, eventType securityevent.Type, detail map[string]any) {
+	if err := securityevent.Record(ctx, s.DB, userID, eventType, detail); err != nil {
+		log.Printf("failed to record security event %s for user %s: %v", eventType, userID, err)
+	}
+}
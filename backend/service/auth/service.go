@@ -3,36 +3,50 @@ package auth
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"net"
-	"strings"
+	"log"
 
 	"github.com/google/uuid"
 	"github.com/project-mikan/umi.mikan/backend/domain/model"
 	"github.com/project-mikan/umi.mikan/backend/domain/request"
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/emailtoken"
 	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/mailer"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/mfa"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/oidc"
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/securityevent"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/webauthn"
 	"github.com/project-mikan/umi.mikan/backend/middleware"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
 type AuthEntry struct {
 	g.UnimplementedAuthServiceServer
-	DB              *sql.DB
-	LoginLimiter    *ratelimiter.LoginAttemptLimiter
-	RegisterLimiter *ratelimiter.RegisterAttemptLimiter
-	RegisterKey     string // REGISTER_KEY環境変数の値（空文字の場合は制限なし）
+	DB                 *sql.DB
+	LoginLimiter       *ratelimiter.LoginAttemptLimiter
+	RegisterLimiter    *ratelimiter.RegisterAttemptLimiter
+	RegisterKey        string                           // REGISTER_KEY環境変数の値（移行期間のため招待コードとして受け付ける）
+	InvitationRequired bool                             // 新規登録に招待コードを必須にするか
+	OIDCProviders      *oidc.Registry                   // OpenID Connectでログインできるプロバイダー（nilの場合はなし）
+	OIDCStates         *oidc.StateStore                 // IdPにリダイレクトしてからログインを完了するまでの状態
+	MFAChallenges      *mfa.ChallengeStore              // パスワードを確認してから2段階目のコードを確認するまでのチャレンジ
+	WebAuthn           *webauthn.RelyingParty           // パスキーの登録・認証のオプションの作成と検証
+	PasskeyChallenges  *webauthn.ChallengeStore         // パスキーの登録・認証を開始してから完了するまでのチャレンジ
+	Mailer             mailer.Mailer                    // パスワードの再設定・メールアドレスの確認のメールの送信
+	EmailTokens        *emailtoken.Signer               // メールで送るトークンの署名・検証
+	UsedEmailTokens    *emailtoken.UsedStore            // 使用済みのメールのトークン
+	EmailLimiter       *ratelimiter.EmailAttemptLimiter // メール送信のレート制限
+	FrontendBaseURL    string                           // メールに載せるリンクのベースURL
 }
 
 func (s *AuthEntry) GetRegistrationConfig(ctx context.Context, req *g.GetRegistrationConfigRequest) (*g.GetRegistrationConfigResponse, error) {
-	// REGISTER_KEYが設定されている場合は登録キーが必要
-	registerKeyRequired := s.RegisterKey != ""
+	// 招待制の場合は招待コードが必要
 	return &g.GetRegistrationConfigResponse{
-		RegisterKeyRequired: registerKeyRequired,
+		RegisterKeyRequired: s.invitationRequired(),
 	}, nil
 }
 
@@ -51,13 +65,10 @@ func (s *AuthEntry) RegisterByPassword(ctx context.Context, req *g.RegisterByPas
 		}
 	}
 
-	// --- REGISTER_KEYのチェック ---
-	if s.RegisterKey != "" {
-		// REGISTER_KEYが設定されている場合は、リクエストのregister_keyと一致する必要がある
-		// 空の場合も不一致の場合も同じエラーメッセージで情報漏洩を防ぐ
-		if req.GetRegisterKey() == "" || req.GetRegisterKey() != s.RegisterKey {
-			return nil, status.Error(codes.PermissionDenied, "registration failed")
-		}
+	// --- 招待コードのチェック ---
+	// 招待コードを使うのは登録と同じトランザクション内（空の場合はDBを見ずに拒否する）
+	if s.invitationRequired() && req.GetRegisterKey() == "" {
+		return nil, status.Error(codes.PermissionDenied, "registration failed")
 	}
 
 	passwordAuth, err := request.ValidateRegisterByPasswordRequest(req)
@@ -76,9 +87,9 @@ func (s *AuthEntry) RegisterByPassword(ctx context.Context, req *g.RegisterByPas
 
 	// --- 登録 ---
 	user := model.GenUser(passwordAuth.Email, passwordAuth.Name, model.AuthTypeEmailPassword)
+	userDB := user.ConvertToDBModel()
 	// トランザクション内でユーザー作成とパスワード認証を同時に実行
 	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
-		userDB := user.ConvertToDBModel()
 		if err := userDB.Save(ctx, tx); err != nil {
 			return fmt.Errorf("failed to insert user: %w", err)
 		}
@@ -86,12 +97,23 @@ func (s *AuthEntry) RegisterByPassword(ctx context.Context, req *g.RegisterByPas
 		if err := passwordAuthDB.Save(ctx, tx); err != nil {
 			return fmt.Errorf("failed to insert password auth: %w", err)
 		}
-		return nil
+		return s.useInvitation(ctx, tx, req.GetRegisterKey(), user.ID)
 	})
 	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
 		return nil, status.Errorf(codes.Internal, "failed to register user: %v", err)
 	}
 
+	// --- メールアドレスの確認 ---
+	// 送信に失敗しても登録は完了しているため、RequestEmailVerificationで送り直せるようログに残すだけにする
+	if s.Mailer != nil {
+		if err := s.sendVerificationEmail(ctx, &userDB); err != nil {
+			log.Printf("failed to send verification email to user %s: %v", user.ID, err)
+		}
+	}
+
 	// --- JWTトークンの生成 ---
 	token, err := model.GenerateAuthTokens(user.ID.String())
 	if err != nil {
@@ -112,61 +134,14 @@ func (s *AuthEntry) getClientIdentifier(ctx context.Context) string {
 	return fmt.Sprintf("%s:%s", clientIP, userAgent)
 }
 
-// getClientIP X-Forwarded-Forヘッダーを考慮してクライアントIPを取得。
-// ConnectRPC 経由の場合はインターセプターがコンテキストに注入した値を優先する。
+// getClientIP X-Forwarded-Forヘッダーを考慮してクライアントIPを取得
 func (s *AuthEntry) getClientIP(ctx context.Context) string {
-	// ConnectRPC インターセプターが注入したIPを優先する（gRPC metadata は使えないため）
-	if ip, ok := ctx.Value(middleware.ConnectClientIPKey).(string); ok && ip != "" {
-		return ip
-	}
-
-	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		// X-Forwarded-Forヘッダーをチェック（プロキシ/ロードバランサー経由の場合）
-		if xff := md.Get("x-forwarded-for"); len(xff) > 0 {
-			// 最初のIPアドレスを使用（カンマ区切りの場合）
-			ip := strings.TrimSpace(strings.Split(xff[0], ",")[0])
-			if ip != "" {
-				return ip
-			}
-		}
-
-		// X-Real-IPヘッダーもチェック
-		if xri := md.Get("x-real-ip"); len(xri) > 0 && xri[0] != "" {
-			return strings.TrimSpace(xri[0])
-		}
-	}
-
-	// フォールバック: ピア接続からIPアドレスを取得
-	if p, ok := peer.FromContext(ctx); ok {
-		if tcpAddr, ok := p.Addr.(*net.TCPAddr); ok {
-			return tcpAddr.IP.String()
-		}
-	}
-
-	return "unknown"
+	return middleware.ClientIP(ctx)
 }
 
-// getUserAgent 複数のヘッダー形式からUser-Agentを取得。
-// ConnectRPC 経由の場合はインターセプターがコンテキストに注入した値を優先する。
+// getUserAgent 複数のヘッダー形式からUser-Agentを取得
 func (s *AuthEntry) getUserAgent(ctx context.Context) string {
-	// ConnectRPC インターセプターが注入したUser-Agentを優先する
-	if ua, ok := ctx.Value(middleware.ConnectUserAgentKey).(string); ok && ua != "" {
-		return ua
-	}
-
-	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		// 標準的なUser-Agentヘッダー
-		if ua := md.Get("user-agent"); len(ua) > 0 && ua[0] != "" {
-			return ua[0]
-		}
-
-		// gRPC Gateway経由の場合のヘッダー
-		if ua := md.Get("grpcgateway-user-agent"); len(ua) > 0 && ua[0] != "" {
-			return ua[0]
-		}
-	}
-
-	return "unknown"
+	return middleware.UserAgent(ctx)
 }
 
 func (s *AuthEntry) LoginByPassword(ctx context.Context, req *g.LoginByPasswordRequest) (*g.AuthResponse, error) {
@@ -203,29 +178,36 @@ func (s *AuthEntry) LoginByPassword(ctx context.Context, req *g.LoginByPasswordR
 	// --- パスワードの検証 ---
 	passwordAuthDB, err := database.UserPasswordAutheByUserID(ctx, s.DB, userDB.ID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// OpenID Connectで登録したパスワードを持たないユーザーも、パスワード不一致と同じエラーを返す
+			s.recordSecurityEvent(ctx, userDB.ID, securityevent.LoginFailure, map[string]any{"method": loginMethodPassword, "reason": "no_password"})
+			return nil, status.Errorf(codes.Unauthenticated, "invalid email or password")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to get password auth: %v", err)
 	}
 	// bcryptを使って平文パスワードとハッシュを比較
 	if err := request.VerifyPassword(passwordAuth.Password, passwordAuthDB.PasswordHashed); err != nil {
+		s.recordSecurityEvent(ctx, userDB.ID, securityevent.LoginFailure, map[string]any{"method": loginMethodPassword, "reason": "invalid_password"})
 		return nil, status.Errorf(codes.Unauthenticated, "invalid email or password")
 	}
-
-	// --- JWTトークンの生成 ---
-	token, err := model.GenerateAuthTokens(userDB.ID.String())
+	// 無効にされたユーザーはパスワードが正しくてもログインできない
+	// （パスワードを検証した後に判定し、パスワードを知らない相手には無効かどうかを漏らさない）
+	// 2段階認証を有効にしている場合はトークンの代わりにチャレンジを返す。
+	// 管理者がパスワードの再設定を求めている場合は、クライアントにパスワードの変更を促させる
+	resp, err := s.loginResponse(ctx, userDB, loginMethodPassword)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate auth tokens: %v", err)
+		return nil, err
 	}
 
-	// ログイン成功時はレート制限をリセット
-	if s.LoginLimiter != nil {
-		_ = s.LoginLimiter.ResetAttempts(ctx, clientID)
+	// ログイン成功時はレート制限をリセット（2段階認証の場合はVerifyMfaの成功時）
+	if !resp.MfaRequired {
+		s.resetLoginAttempts(ctx, clientID)
 	}
-
-	return token.ConvertAuthResponse(), nil
+	return resp, nil
 }
 
 func (s *AuthEntry) RefreshAccessToken(ctx context.Context, req *g.RefreshAccessTokenRequest) (*g.AuthResponse, error) {
-	userID, err := request.ValidateRefreshTokenRequest(req)
+	userID, issuedAt, err := request.ValidateRefreshTokenRequest(req)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "validation error: %v", err)
 	}
@@ -242,12 +224,31 @@ func (s *AuthEntry) RefreshAccessToken(ctx context.Context, req *g.RefreshAccess
 	if userDB == nil {
 		return nil, status.Errorf(codes.Unauthenticated, "user not found")
 	}
+	if err := checkAccountUsable(userDB); err != nil {
+		return nil, err
+	}
+	// 管理者がセッションを取り消した時刻以前に発行したリフレッシュトークンは受け付けない
+	// （発行日時は秒単位のため、取り消しと同じ秒に発行したトークンも取り消す）
+	if userDB.SessionsRevokedAt.Valid && issuedAt <= userDB.SessionsRevokedAt.Int64 {
+		return nil, status.Error(codes.Unauthenticated, "session has been revoked")
+	}
+	// パスワードの再設定を求められている場合は、パスワードを変更するまでトークンを更新しない
+	if userDB.AuthType == model.AuthTypeEmailPassword.Int16() {
+		passwordAuthDB, err := database.UserPasswordAutheByUserID(ctx, s.DB, userDB.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.Internal, "failed to get password auth: %v", err)
+		}
+		if passwordAuthDB != nil && passwordAuthDB.ResetRequired {
+			return nil, status.Error(codes.FailedPrecondition, "password reset required")
+		}
+	}
 
 	// --- AccessTokenだけ再生成 ---
 	newToken, err := model.GenerateAccessToken(userDB.ID.String())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to generate new auth tokens: %v", err)
 	}
+	s.recordSecurityEvent(ctx, userDB.ID, securityevent.TokenRefresh, nil)
 
 	return newToken.ConvertAuthResponse(), nil
 }
@@ -11,6 +11,8 @@ import (
 
 	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
 	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func setupTestDB(t *testing.T) *sql.DB {
@@ -309,6 +311,71 @@ func TestAuthEntry_RefreshAccessToken(t *testing.T) {
 	}
 }
 
+func TestAuthEntry_AdminRestrictions(t *testing.T) {
+	db := setupTestDB(t)
+
+	authService := &AuthEntry{DB: db}
+	ctx := context.Background()
+
+	// register はユーザーを登録し、メールアドレスとトークンを返す
+	register := func(t *testing.T) (string, *g.AuthResponse) {
+		t.Helper()
+		email := generateTestEmail(t, "admin-restriction")
+		resp, err := authService.RegisterByPassword(ctx, &g.RegisterByPasswordRequest{Email: email, Password: "validPassword123", Name: "Restricted User"})
+		if err != nil {
+			t.Fatalf("Failed to register user: %v", err)
+		}
+		return email, resp
+	}
+	exec := func(t *testing.T, query string, args ...any) {
+		t.Helper()
+		if _, err := db.Exec(query, args...); err != nil {
+			t.Fatalf("Failed to update user: %v", err)
+		}
+	}
+
+	t.Run("異常系：無効にしたユーザーはログインもトークンの更新もできない", func(t *testing.T) {
+		email, tokens := register(t)
+		exec(t, "UPDATE users SET disabled_at = $2 WHERE email = $1", email, time.Now().Unix())
+
+		_, err := authService.LoginByPassword(ctx, &g.LoginByPasswordRequest{Email: email, Password: "validPassword123"})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("Expected PermissionDenied on login but got %v", err)
+		}
+		_, err = authService.RefreshAccessToken(ctx, &g.RefreshAccessTokenRequest{RefreshToken: tokens.RefreshToken})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("Expected PermissionDenied on refresh but got %v", err)
+		}
+	})
+
+	t.Run("異常系：取り消した時刻以前に発行したリフレッシュトークンは使えない", func(t *testing.T) {
+		email, tokens := register(t)
+		exec(t, "UPDATE users SET sessions_revoked_at = $2 WHERE email = $1", email, time.Now().Unix())
+
+		_, err := authService.RefreshAccessToken(ctx, &g.RefreshAccessTokenRequest{RefreshToken: tokens.RefreshToken})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Expected Unauthenticated but got %v", err)
+		}
+	})
+
+	t.Run("正常系：パスワードの再設定を求められている場合はログインで通知し、トークンを更新しない", func(t *testing.T) {
+		email, _ := register(t)
+		exec(t, "UPDATE user_password_authes SET reset_required = true WHERE user_id = (SELECT id FROM users WHERE email = $1)", email)
+
+		resp, err := authService.LoginByPassword(ctx, &g.LoginByPasswordRequest{Email: email, Password: "validPassword123"})
+		if err != nil {
+			t.Fatalf("Expected success but got error: %v", err)
+		}
+		if !resp.PasswordResetRequired {
+			t.Error("Expected password_reset_required to be true")
+		}
+		_, err = authService.RefreshAccessToken(ctx, &g.RefreshAccessTokenRequest{RefreshToken: resp.RefreshToken})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("Expected FailedPrecondition but got %v", err)
+		}
+	})
+}
+
 func TestAuthEntry_DuplicateRegistration(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -0,0 +1,484 @@
+package diary
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/media"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/storage"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// attachmentFilenameMaxLength は添付ファイル名の最大文字数（diary_attachments.filenameのVARCHAR(255)に合わせる）
+	attachmentFilenameMaxLength = 255
+	// attachmentContentTypeMaxLength はContent-Typeの最大文字数
+	attachmentContentTypeMaxLength = 100
+	// attachmentDownloadChunkSize はダウンロード時に1メッセージで送るバイト数
+	attachmentDownloadChunkSize = 256 * 1024
+)
+
+// AttachmentLimits は添付ファイルの容量制限
+type AttachmentLimits struct {
+	// QuotaBytes はユーザーごとの添付ファイルの合計容量の上限
+	QuotaBytes int64
+	// MaxFileBytes は1ファイルあたりの上限
+	MaxFileBytes int64
+}
+
+var (
+	errAttachmentsDisabled = status.Error(codes.FailedPrecondition, "attachments are not configured")
+	errAttachmentNotFound  = status.Error(codes.NotFound, "attachment not found")
+	errAttachmentTooLarge  = status.Error(codes.ResourceExhausted, "file is too large")
+	errAttachmentQuota     = status.Error(codes.ResourceExhausted, "attachment quota exceeded")
+)
+
+// attachmentStorageKey は添付ファイルの元ファイルのストレージ上のキー
+func attachmentStorageKey(userID, attachmentID uuid.UUID) string {
+	return userID.String() + "/" + attachmentID.String() + "/original"
+}
+
+// attachmentThumbnailKey は添付ファイルのサムネイルのストレージ上のキー
+func attachmentThumbnailKey(userID, attachmentID uuid.UUID) string {
+	return userID.String() + "/" + attachmentID.String() + "/thumbnail.jpg"
+}
+
+// toAttachmentProto はDB行をレスポンス用のAttachmentに変換する
+func toAttachmentProto(a *database.DiaryAttachment) *g.Attachment {
+	attachment := &g.Attachment{
+		Id:           a.ID.String(),
+		DiaryId:      a.DiaryID.String(),
+		Filename:     a.Filename,
+		ContentType:  a.ContentType,
+		SizeBytes:    a.SizeBytes,
+		HasThumbnail: a.ThumbnailKey != "",
+		CreatedAt:    a.CreatedAt,
+	}
+	if a.TakenAt.Valid {
+		attachment.TakenAt = a.TakenAt.Int64
+	}
+	if a.Latitude.Valid && a.Longitude.Valid {
+		attachment.Location = &g.GeoPoint{Latitude: a.Latitude.Float64, Longitude: a.Longitude.Float64}
+	}
+	return attachment
+}
+
+// toAttachmentProtos は複数のDB行をレスポンス用のAttachmentに変換する
+func toAttachmentProtos(attachments []*database.DiaryAttachment) []*g.Attachment {
+	result := make([]*g.Attachment, 0, len(attachments))
+	for _, a := range attachments {
+		result = append(result, toAttachmentProto(a))
+	}
+	return result
+}
+
+// normalizeAttachmentFilename はパス部分を除いたファイル名を返す（不正な場合は空文字）
+func normalizeAttachmentFilename(filename string) string {
+	name := strings.TrimSpace(path.Base(strings.ReplaceAll(filename, "\\", "/")))
+	if name == "" || name == "." || name == "/" || name == ".." || len([]rune(name)) > attachmentFilenameMaxLength {
+		return ""
+	}
+	return name
+}
+
+// normalizeAttachmentContentType はContent-Typeを小文字のメディアタイプに正規化する
+// 指定がない場合はファイルの先頭から判定する（不正な場合は空文字）
+func normalizeAttachmentContentType(contentType string, data []byte) string {
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || len(mediaType) > attachmentContentTypeMaxLength {
+		return ""
+	}
+	return mediaType
+}
+
+// ownedDiary は認証ユーザーが所有する日記を取得する
+// 他ユーザーの日記は存在を悟らせないためNotFoundを返す
+func (s *DiaryEntry) ownedDiary(ctx context.Context, userID uuid.UUID, id string) (*database.Diary, error) {
+	diaryID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid diary id")
+	}
+	diary, err := database.ActiveDiaryByID(ctx, s.DB, diaryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "diary entry not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get diary entry: %v", err)
+	}
+	if diary.UserID != userID {
+		return nil, status.Error(codes.NotFound, "diary entry not found")
+	}
+	return diary, nil
+}
+
+// ownedAttachment は認証ユーザーが所有する添付ファイルを取得する
+func (s *DiaryEntry) ownedAttachment(ctx context.Context, userID uuid.UUID, id string) (*database.DiaryAttachment, error) {
+	attachmentID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid attachment id")
+	}
+	attachment, err := database.DiaryAttachmentByID(ctx, s.DB, attachmentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errAttachmentNotFound
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get attachment: %v", err)
+	}
+	if attachment.UserID != userID {
+		return nil, errAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// authenticatedUserID はコンテキストから認証ユーザーのIDを取得する
+func authenticatedUserID(ctx context.Context) (uuid.UUID, error) {
+	userIDStr, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "invalid user id")
+	}
+	return userID, nil
+}
+
+// deleteAttachmentObjects はストレージ上の添付ファイルの実体を削除する
+// DBの行は削除済みのため、失敗してもエラーにはせずログに記録する（孤立したオブジェクトが残るのみ）
+func (s *DiaryEntry) deleteAttachmentObjects(ctx context.Context, attachments []*database.DiaryAttachment) {
+	if s.Storage == nil {
+		return
+	}
+	keys := make([]string, 0, len(attachments)*2)
+	for _, a := range attachments {
+		keys = append(keys, a.StorageKeys()...)
+	}
+	if err := storage.DeleteAll(context.WithoutCancel(ctx), s.Storage, keys); err != nil {
+		log.Printf("Failed to delete attachment objects: %v", err)
+	}
+}
+
+// attachmentChunkReader はアップロードのストリームで受信したチャンクをio.Readerとして読む
+// メタデータは最初のメッセージでのみ受け付けるため、以降に届いた場合はエラーにする
+type attachmentChunkReader struct {
+	recv func() (*g.UploadAttachmentRequest, error)
+	buf  []byte
+	err  error
+}
+
+func (r *attachmentChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		msg, err := r.recv()
+		switch {
+		case err != nil:
+			r.err = err
+		case msg.GetMetadata() != nil:
+			r.err = status.Error(codes.InvalidArgument, "metadata must be sent only once")
+		default:
+			r.buf = msg.GetChunk()
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (s *DiaryEntry) UploadAttachment(stream g.DiaryService_UploadAttachmentServer) error {
+	resp, err := s.ReceiveAttachment(stream.Context(), stream.Recv)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(resp)
+}
+
+// ReceiveAttachment はrecvから添付ファイルを受信して保存する
+// gRPCとConnectRPCでストリームの型が異なるため、受信処理を関数で受け取る（終端ではio.EOFを返すこと）
+func (s *DiaryEntry) ReceiveAttachment(ctx context.Context, recv func() (*g.UploadAttachmentRequest, error)) (*g.UploadAttachmentResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.Storage == nil {
+		return nil, errAttachmentsDisabled
+	}
+
+	first, err := recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, status.Error(codes.InvalidArgument, "metadata is required")
+		}
+		return nil, err
+	}
+	metadata := first.GetMetadata()
+	if metadata == nil {
+		return nil, status.Error(codes.InvalidArgument, "metadata is required")
+	}
+	filename := normalizeAttachmentFilename(metadata.GetFilename())
+	if filename == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid filename")
+	}
+	diary, err := s.ownedDiary(ctx, userID, metadata.GetDiaryId())
+	if err != nil {
+		return nil, err
+	}
+
+	// 受信前に容量を確認し、受信中も残り容量を超えた時点で打ち切る
+	usage, err := database.DiaryAttachmentUsageByUserID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get attachment usage: %v", err)
+	}
+	remaining := s.AttachmentLimits.QuotaBytes - usage
+	if remaining <= 0 {
+		return nil, errAttachmentQuota
+	}
+
+	// 受信した内容はメモリに溜めず一時ファイルに書き出す。上限+1バイトまでしか読まないため、超えた時点で受信を打ち切れる
+	limit := min(s.AttachmentLimits.MaxFileBytes, remaining)
+	tmp, err := os.CreateTemp("", "umi-attachment-*")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create temp file: %v", err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+	chunks := &attachmentChunkReader{recv: recv}
+	size, err := io.Copy(tmp, io.LimitReader(chunks, limit+1))
+	if err != nil {
+		// 受信側のエラー（メタデータの重複・ストリームの切断）はそのまま返す
+		if errors.Is(err, chunks.err) {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to write temp file: %v", err)
+	}
+	if size > limit {
+		if size > s.AttachmentLimits.MaxFileBytes {
+			return nil, errAttachmentTooLarge
+		}
+		return nil, errAttachmentQuota
+	}
+	if size == 0 {
+		return nil, status.Error(codes.InvalidArgument, "file is empty")
+	}
+
+	// http.DetectContentTypeは先頭512バイトしか見ない
+	head := make([]byte, 512)
+	n, err := tmp.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, status.Errorf(codes.Internal, "failed to read temp file: %v", err)
+	}
+	contentType := normalizeAttachmentContentType(metadata.GetContentType(), head[:n])
+	if contentType == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid content type")
+	}
+
+	// EXIFの読み取りとサムネイルの生成は画像全体が必要なため、対応する画像の場合だけ読み込む
+	var data []byte
+	if media.CanGenerateThumbnail(contentType) {
+		data = make([]byte, size)
+		if _, err := tmp.ReadAt(data, 0); err != nil && !errors.Is(err, io.EOF) {
+			return nil, status.Errorf(codes.Internal, "failed to read temp file: %v", err)
+		}
+	}
+
+	currentTime := time.Now().Unix()
+	attachment := &database.DiaryAttachment{
+		ID:          uuid.New(),
+		DiaryID:     diary.ID,
+		UserID:      userID,
+		Filename:    filename,
+		ContentType: contentType,
+		SizeBytes:   size,
+		CreatedAt:   currentTime,
+		UpdatedAt:   currentTime,
+	}
+	attachment.StorageKey = attachmentStorageKey(userID, attachment.ID)
+
+	var orientation int
+	if contentType == "image/jpeg" {
+		// EXIFにタイムゾーンがない撮影日時はサーバーのタイムゾーン（TZ）の時刻として扱う
+		meta := media.ExtractMetadata(data, time.Local)
+		orientation = meta.Orientation
+		if !meta.TakenAt.IsZero() {
+			attachment.TakenAt = sql.NullInt64{Int64: meta.TakenAt.Unix(), Valid: true}
+		}
+		if meta.HasLocation {
+			attachment.Latitude = sql.NullFloat64{Float64: meta.Latitude, Valid: true}
+			attachment.Longitude = sql.NullFloat64{Float64: meta.Longitude, Valid: true}
+		}
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read temp file: %v", err)
+	}
+	if err := s.Storage.Put(ctx, attachment.StorageKey, tmp, size, contentType); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to store attachment: %v", err)
+	}
+	if media.CanGenerateThumbnail(contentType) {
+		// サムネイルは補助的なものなので、生成できなくてもアップロード自体は成功させる
+		if thumb, err := media.GenerateThumbnail(data, orientation); err != nil {
+			log.Printf("Failed to generate thumbnail for attachment %s: %v", attachment.ID, err)
+		} else {
+			key := attachmentThumbnailKey(userID, attachment.ID)
+			if err := s.Storage.Put(ctx, key, bytes.NewReader(thumb), int64(len(thumb)), media.ThumbnailContentType); err != nil {
+				log.Printf("Failed to store thumbnail for attachment %s: %v", attachment.ID, err)
+			} else {
+				attachment.ThumbnailKey = key
+				attachment.ThumbnailSizeBytes = int64(len(thumb))
+			}
+		}
+	}
+
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		// 同時アップロードで容量制限を超えないよう、ロックを取ってから再確認する（サムネイルも容量に含める）
+		if err := database.LockDiaryAttachmentQuota(ctx, tx, userID); err != nil {
+			return err
+		}
+		usage, err := database.DiaryAttachmentUsageByUserID(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if usage+attachment.SizeBytes+attachment.ThumbnailSizeBytes > s.AttachmentLimits.QuotaBytes {
+			return errAttachmentQuota
+		}
+		return attachment.Insert(ctx, tx)
+	})
+	if err != nil {
+		s.deleteAttachmentObjects(ctx, []*database.DiaryAttachment{attachment})
+		if errors.Is(err, errAttachmentQuota) {
+			return nil, errAttachmentQuota
+		}
+		return nil, status.Errorf(codes.Internal, "failed to save attachment: %v", err)
+	}
+
+	return &g.UploadAttachmentResponse{Attachment: toAttachmentProto(attachment)}, nil
+}
+
+func (s *DiaryEntry) DownloadAttachment(req *g.DownloadAttachmentRequest, stream g.DiaryService_DownloadAttachmentServer) error {
+	return s.SendAttachment(stream.Context(), req, stream.Send)
+}
+
+// SendAttachment は添付ファイルの情報と内容をsendに渡す
+// gRPCとConnectRPCでストリームの型が異なるため、送信処理を関数で受け取る
+func (s *DiaryEntry) SendAttachment(ctx context.Context, req *g.DownloadAttachmentRequest, send func(*g.DownloadAttachmentResponse) error) error {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+	if s.Storage == nil {
+		return errAttachmentsDisabled
+	}
+	attachment, err := s.ownedAttachment(ctx, userID, req.GetId())
+	if err != nil {
+		return err
+	}
+
+	key := attachment.StorageKey
+	if req.GetThumbnail() {
+		if attachment.ThumbnailKey == "" {
+			return status.Error(codes.NotFound, "thumbnail not found")
+		}
+		key = attachment.ThumbnailKey
+	}
+	r, err := s.Storage.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return errAttachmentNotFound
+		}
+		return status.Errorf(codes.Internal, "failed to read attachment: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	info := toAttachmentProto(attachment)
+	if req.GetThumbnail() {
+		info.ContentType = media.ThumbnailContentType
+	}
+	if err := send(&g.DownloadAttachmentResponse{Payload: &g.DownloadAttachmentResponse_Attachment{Attachment: info}}); err != nil {
+		return err
+	}
+
+	chunk := make([]byte, attachmentDownloadChunkSize)
+	for {
+		n, err := io.ReadFull(r, chunk)
+		if n > 0 {
+			if sendErr := send(&g.DownloadAttachmentResponse{Payload: &g.DownloadAttachmentResponse_Chunk{Chunk: chunk[:n]}}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read attachment: %v", err)
+		}
+	}
+}
+
+func (s *DiaryEntry) ListAttachments(ctx context.Context, req *g.ListAttachmentsRequest) (*g.ListAttachmentsResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	diary, err := s.ownedDiary(ctx, userID, req.GetDiaryId())
+	if err != nil {
+		return nil, err
+	}
+	attachments, err := database.DiaryAttachmentsByDiaryID(ctx, s.DB, diary.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list attachments: %v", err)
+	}
+	return &g.ListAttachmentsResponse{Attachments: toAttachmentProtos(attachments)}, nil
+}
+
+func (s *DiaryEntry) DeleteAttachment(ctx context.Context, req *g.DeleteAttachmentRequest) (*g.DeleteAttachmentResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := s.ownedAttachment(ctx, userID, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := attachment.Delete(ctx, s.DB); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete attachment: %v", err)
+	}
+	s.deleteAttachmentObjects(ctx, []*database.DiaryAttachment{attachment})
+	return &g.DeleteAttachmentResponse{Success: true}, nil
+}
+
+func (s *DiaryEntry) GetAttachmentUsage(ctx context.Context, _ *g.GetAttachmentUsageRequest) (*g.GetAttachmentUsageResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := database.DiaryAttachmentUsageByUserID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get attachment usage: %v", err)
+	}
+	return &g.GetAttachmentUsageResponse{
+		UsedBytes:    usage,
+		QuotaBytes:   s.AttachmentLimits.QuotaBytes,
+		MaxFileBytes: s.AttachmentLimits.MaxFileBytes,
+	}, nil
+}
@@ -0,0 +1,236 @@
+package diary
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"image"
+	"image/jpeg"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/storage"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNormalizeAttachmentFilename(t *testing.T) {
+	tests := map[string]string{
+		"IMG_0001.jpg":             "IMG_0001.jpg",
+		"  memo.txt ":              "memo.txt",
+		"../../etc/passwd":         "passwd",
+		"C:\\Users\\me\\photo.png": "photo.png",
+		"":                         "",
+		"..":                       "",
+		"dir/":                     "dir",
+		string(bytes.Repeat([]byte("a"), attachmentFilenameMaxLength+1)): "",
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, normalizeAttachmentFilename(input), "input: %q", input)
+	}
+}
+
+func TestNormalizeAttachmentContentType(t *testing.T) {
+	assert.Equal(t, "image/jpeg", normalizeAttachmentContentType("Image/JPEG", nil))
+	assert.Equal(t, "text/plain", normalizeAttachmentContentType("text/plain; charset=utf-8", nil))
+	// 指定がない場合は内容から判定する
+	assert.Equal(t, "application/pdf", normalizeAttachmentContentType("", []byte("%PDF-1.7")))
+	assert.Equal(t, "", normalizeAttachmentContentType("not a type", nil))
+}
+
+func TestToAttachmentProto(t *testing.T) {
+	a := &database.DiaryAttachment{
+		ID:           uuid.New(),
+		DiaryID:      uuid.New(),
+		Filename:     "photo.jpg",
+		ContentType:  "image/jpeg",
+		SizeBytes:    100,
+		ThumbnailKey: "user/photo/thumbnail.jpg",
+		TakenAt:      sql.NullInt64{Int64: 1714516200, Valid: true},
+		Latitude:     sql.NullFloat64{Float64: 35.66, Valid: true},
+		Longitude:    sql.NullFloat64{Float64: 139.7, Valid: true},
+	}
+	attachment := toAttachmentProto(a)
+	assert.True(t, attachment.HasThumbnail)
+	assert.Equal(t, int64(1714516200), attachment.TakenAt)
+	assert.Equal(t, 35.66, attachment.Location.Latitude)
+
+	// EXIFがない場合は撮影日時・位置情報を設定しない
+	a.ThumbnailKey = ""
+	a.TakenAt = sql.NullInt64{}
+	a.Latitude = sql.NullFloat64{}
+	attachment = toAttachmentProto(a)
+	assert.False(t, attachment.HasThumbnail)
+	assert.Zero(t, attachment.TakenAt)
+	assert.Nil(t, attachment.Location)
+}
+
+// uploadMessages はストリームで受信するメッセージを順に返すrecv関数を作る
+func uploadMessages(messages ...*g.UploadAttachmentRequest) func() (*g.UploadAttachmentRequest, error) {
+	return func() (*g.UploadAttachmentRequest, error) {
+		if len(messages) == 0 {
+			return nil, io.EOF
+		}
+		msg := messages[0]
+		messages = messages[1:]
+		return msg, nil
+	}
+}
+
+func metadataMessage(diaryID, filename, contentType string) *g.UploadAttachmentRequest {
+	return &g.UploadAttachmentRequest{Payload: &g.UploadAttachmentRequest_Metadata{Metadata: &g.AttachmentMetadata{
+		DiaryId: diaryID, Filename: filename, ContentType: contentType,
+	}}}
+}
+
+func chunkMessage(data []byte) *g.UploadAttachmentRequest {
+	return &g.UploadAttachmentRequest{Payload: &g.UploadAttachmentRequest_Chunk{Chunk: data}}
+}
+
+func TestDiaryEntry_ReceiveAttachment_Disabled(t *testing.T) {
+	svc := &DiaryEntry{}
+	ctx := testutil.CreateAuthenticatedContext(uuid.New())
+	_, err := svc.ReceiveAttachment(ctx, uploadMessages())
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestDiaryEntry_Attachments(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "diary-attachment@example.com", "DiaryAttachmentUser")
+	otherUserID := testutil.CreateTestUser(t, db, "diary-attachment-other@example.com", "DiaryAttachmentOtherUser")
+	store, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	svc := &DiaryEntry{DB: db, Storage: store, AttachmentLimits: AttachmentLimits{QuotaBytes: 768 << 10, MaxFileBytes: 512 << 10}}
+	ctx := testutil.CreateAuthenticatedContext(userID)
+	otherCtx := testutil.CreateAuthenticatedContext(otherUserID)
+
+	created, err := svc.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{Content: "写真つきの日記", Date: &g.YMD{Year: 2024, Month: 5, Day: 1}})
+	require.NoError(t, err)
+	diaryID := created.Entry.Id
+
+	var photo bytes.Buffer
+	require.NoError(t, jpeg.Encode(&photo, image.NewRGBA(image.Rect(0, 0, 640, 480)), nil))
+	half := photo.Len() / 2
+
+	uploaded, err := svc.ReceiveAttachment(ctx, uploadMessages(
+		metadataMessage(diaryID, "photo.jpg", ""),
+		chunkMessage(photo.Bytes()[:half]),
+		chunkMessage(photo.Bytes()[half:]),
+	))
+	require.NoError(t, err)
+	attachment := uploaded.Attachment
+
+	t.Run("正常系: 形式の判定とサムネイル生成", func(t *testing.T) {
+		assert.Equal(t, "image/jpeg", attachment.ContentType)
+		assert.Equal(t, int64(photo.Len()), attachment.SizeBytes)
+		assert.True(t, attachment.HasThumbnail)
+	})
+
+	t.Run("正常系: 一覧・使用容量に反映される", func(t *testing.T) {
+		list, err := svc.ListAttachments(ctx, &g.ListAttachmentsRequest{DiaryId: diaryID})
+		require.NoError(t, err)
+		require.Len(t, list.Attachments, 1)
+		assert.Equal(t, attachment.Id, list.Attachments[0].Id)
+
+		// サムネイルも使用容量に含める
+		a, err := database.DiaryAttachmentByID(context.Background(), db, uuid.MustParse(attachment.Id))
+		require.NoError(t, err)
+		assert.Positive(t, a.ThumbnailSizeBytes)
+		usage, err := svc.GetAttachmentUsage(ctx, &g.GetAttachmentUsageRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(photo.Len())+a.ThumbnailSizeBytes, usage.UsedBytes)
+	})
+
+	t.Run("正常系: ダウンロードすると同じ内容が返る", func(t *testing.T) {
+		var got bytes.Buffer
+		var info *g.Attachment
+		err := svc.SendAttachment(ctx, &g.DownloadAttachmentRequest{Id: attachment.Id}, func(resp *g.DownloadAttachmentResponse) error {
+			if a := resp.GetAttachment(); a != nil {
+				info = a
+			}
+			got.Write(resp.GetChunk())
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, attachment.Id, info.Id)
+		assert.Equal(t, photo.Bytes(), got.Bytes())
+	})
+
+	t.Run("正常系: エクスポートに添付ファイルが含まれる", func(t *testing.T) {
+		resp, err := svc.ExportDiaryEntries(ctx, &g.ExportDiaryEntriesRequest{From: &g.YM{Year: 2024, Month: 5}, To: &g.YM{Year: 2024, Month: 5}})
+		require.NoError(t, err)
+		require.Len(t, resp.Attachments, 1)
+		assert.Equal(t, diaryID, resp.Attachments[0].DiaryId)
+	})
+
+	t.Run("異常系: 他ユーザーの日記・添付ファイルはNotFound", func(t *testing.T) {
+		_, err := svc.ReceiveAttachment(otherCtx, uploadMessages(metadataMessage(diaryID, "a.txt", ""), chunkMessage([]byte("a"))))
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = svc.ListAttachments(otherCtx, &g.ListAttachmentsRequest{DiaryId: diaryID})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = svc.DeleteAttachment(otherCtx, &g.DeleteAttachmentRequest{Id: attachment.Id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	errorCases := []struct {
+		name     string
+		messages []*g.UploadAttachmentRequest
+		code     codes.Code
+	}{
+		{"異常系: metadataがない", []*g.UploadAttachmentRequest{chunkMessage([]byte("a"))}, codes.InvalidArgument},
+		{"異常系: ファイル名が不正", []*g.UploadAttachmentRequest{metadataMessage(diaryID, "..", ""), chunkMessage([]byte("a"))}, codes.InvalidArgument},
+		{"異常系: 空のファイル", []*g.UploadAttachmentRequest{metadataMessage(diaryID, "a.txt", "")}, codes.InvalidArgument},
+		{"異常系: 1ファイルの上限を超える", []*g.UploadAttachmentRequest{metadataMessage(diaryID, "a.bin", ""), chunkMessage(make([]byte, 512<<10+1))}, codes.ResourceExhausted},
+		{"異常系: metadataが2回送られる", []*g.UploadAttachmentRequest{metadataMessage(diaryID, "a.txt", ""), chunkMessage([]byte("a")), metadataMessage(diaryID, "b.txt", "")}, codes.InvalidArgument},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.ReceiveAttachment(ctx, uploadMessages(tc.messages...))
+			assert.Equal(t, tc.code, status.Code(err))
+		})
+	}
+
+	t.Run("異常系: 上限を超えた時点で受信を打ち切る", func(t *testing.T) {
+		received := 0
+		recv := func() (*g.UploadAttachmentRequest, error) {
+			received++
+			if received == 1 {
+				return metadataMessage(diaryID, "a.bin", ""), nil
+			}
+			return chunkMessage(make([]byte, 64<<10)), nil
+		}
+		_, err := svc.ReceiveAttachment(ctx, recv)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		// metadata + 上限（512KiB）を超えるまでの9チャンク
+		assert.Equal(t, 10, received)
+	})
+
+	t.Run("異常系: ユーザーごとの容量制限を超える", func(t *testing.T) {
+		_, err := svc.ReceiveAttachment(ctx, uploadMessages(metadataMessage(diaryID, "a.bin", ""), chunkMessage(make([]byte, 500<<10))))
+		require.NoError(t, err)
+		_, err = svc.ReceiveAttachment(ctx, uploadMessages(metadataMessage(diaryID, "b.bin", ""), chunkMessage(make([]byte, 500<<10))))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("正常系: 日記を削除すると添付ファイルの実体も削除される", func(t *testing.T) {
+		a, err := database.DiaryAttachmentByID(context.Background(), db, uuid.MustParse(attachment.Id))
+		require.NoError(t, err)
+
+		_, err = svc.DeleteDiaryEntry(ctx, &g.DeleteDiaryEntryRequest{Id: diaryID})
+		require.NoError(t, err)
+
+		for _, key := range a.StorageKeys() {
+			_, err := store.Get(context.Background(), key)
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+		}
+		usage, err := svc.GetAttachmentUsage(ctx, &g.GetAttachmentUsageRequest{})
+		require.NoError(t, err)
+		assert.Zero(t, usage.UsedBytes)
+	})
+}
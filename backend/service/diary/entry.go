@@ -0,0 +1,103 @@
+package diary
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// diaryTitleMaxLength は日記タイトルの最大文字数（diaries.titleのVARCHAR(100)に合わせる）
+const diaryTitleMaxLength = 100
+
+// toDiaryEntryProto はDB行をレスポンス用のDiaryEntryに変換する
+func toDiaryEntryProto(d *database.Diary) *g.DiaryEntry {
+	entry := &g.DiaryEntry{
+		Id:        d.ID.String(),
+		Date:      &g.YMD{Year: uint32(d.Date.Year()), Month: uint32(d.Date.Month()), Day: uint32(d.Date.Day())},
+		Content:   d.Content,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+		Title:     d.Title,
+		Version:   d.Version,
+	}
+	if d.EntryTime.Valid {
+		entry.Time = &g.HM{Hour: uint32(d.EntryTime.Int64 / 60), Minute: uint32(d.EntryTime.Int64 % 60)}
+	}
+	return entry
+}
+
+// toDiaryEntryProtos は複数のDB行をレスポンス用のDiaryEntryに変換する
+func toDiaryEntryProtos(diaries []*database.Diary) []*g.DiaryEntry {
+	entries := make([]*g.DiaryEntry, 0, len(diaries))
+	for _, d := range diaries {
+		entries = append(entries, toDiaryEntryProto(d))
+	}
+	return entries
+}
+
+// entryTimeFromProto はリクエストの時刻をentry_time（0時からの経過分）に変換する
+// 未指定の場合はNULLを返す
+func entryTimeFromProto(hm *g.HM) (sql.NullInt64, error) {
+	if hm == nil {
+		return sql.NullInt64{}, nil
+	}
+	if hm.Hour > 23 || hm.Minute > 59 {
+		return sql.NullInt64{}, status.Error(codes.InvalidArgument, "invalid time")
+	}
+	return sql.NullInt64{Int64: int64(hm.Hour*60 + hm.Minute), Valid: true}, nil
+}
+
+// validateDiaryTitle はタイトルの長さを検証する
+func validateDiaryTitle(title string) error {
+	if len([]rune(title)) > diaryTitleMaxLength {
+		return status.Error(codes.InvalidArgument, "title is too long")
+	}
+	return nil
+}
+
+// errDiaryVersionConflict は更新の元にしたversionが古い場合にトランザクションを中断するためのエラー
+var errDiaryVersionConflict = errors.New("diary version conflict")
+
+// diaryVersionConflictError はサーバー側の現在の日記を詳細に含めたAbortedエラーを返す
+// クライアントはこの日記をもとにマージしてから更新し直す
+func (s *DiaryEntry) diaryVersionConflictError(ctx context.Context, current *database.Diary) error {
+	entries, err := s.withTags(ctx, []*g.DiaryEntry{toDiaryEntryProto(current)})
+	if err != nil {
+		return err
+	}
+	st, err := status.New(codes.Aborted, "diary entry was updated by another client").WithDetails(entries[0])
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to build conflict error: %v", err)
+	}
+	return st.Err()
+}
+
+// errDiaryAlreadyExists は同じ日付に日記があり、additionalが指定されていない場合のエラー
+var errDiaryAlreadyExists = status.Error(codes.AlreadyExists, "diary entry already exists for this date")
+
+// assignEntryIndex は日付dateに日記を置くためのentry_indexを決める
+// 既存の日記（ゴミ箱を除く）がある場合、additionalが指定されていなければAlreadyExistsを返す
+func assignEntryIndex(ctx context.Context, db database.DB, userID string, date time.Time, additional bool) (int, error) {
+	next, active, err := database.NextDiaryEntryIndex(ctx, db, userID, date)
+	if err != nil {
+		return 0, err
+	}
+	if active > 0 && !additional {
+		return 0, errDiaryAlreadyExists
+	}
+	return next, nil
+}
+
+// isDiaryEntryConflict は同じ日付・同じentry_indexの日記が同時に作成された場合のユニーク制約違反かを判定する
+func isDiaryEntryConflict(err error) bool {
+	var pqErr *pq.Error
+	// エラーコード 23505 はユニーク制約違反
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "unique_user_date_entry"
+}
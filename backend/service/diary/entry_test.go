@@ -0,0 +1,133 @@
+package diary
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestToDiaryEntryProto(t *testing.T) {
+	d := &database.Diary{
+		ID:        uuid.New(),
+		Content:   "朝のメモ",
+		Date:      time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Title:     "朝",
+		EntryTime: sql.NullInt64{Int64: 7*60 + 30, Valid: true},
+	}
+	entry := toDiaryEntryProto(d)
+	assert.Equal(t, &g.YMD{Year: 2024, Month: 5, Day: 1}, entry.Date)
+	assert.Equal(t, "朝", entry.Title)
+	assert.Equal(t, &g.HM{Hour: 7, Minute: 30}, entry.Time)
+
+	// 時刻未指定の場合はTimeが設定されない
+	d.EntryTime = sql.NullInt64{}
+	assert.Nil(t, toDiaryEntryProto(d).Time)
+}
+
+func TestEntryTimeFromProto(t *testing.T) {
+	got, err := entryTimeFromProto(&g.HM{Hour: 23, Minute: 59})
+	require.NoError(t, err)
+	assert.Equal(t, sql.NullInt64{Int64: 1439, Valid: true}, got)
+
+	got, err = entryTimeFromProto(nil)
+	require.NoError(t, err)
+	assert.False(t, got.Valid)
+
+	_, err = entryTimeFromProto(&g.HM{Hour: 24})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = entryTimeFromProto(&g.HM{Minute: 60})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestValidateDiaryTitle(t *testing.T) {
+	assert.NoError(t, validateDiaryTitle(strings.Repeat("あ", diaryTitleMaxLength)))
+	assert.Equal(t, codes.InvalidArgument, status.Code(validateDiaryTitle(strings.Repeat("あ", diaryTitleMaxLength+1))))
+}
+
+func TestDiaryEntry_MultipleEntriesPerDay(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "diary-multiple@example.com", "DiaryMultipleUser")
+	svc := &DiaryEntry{DB: db}
+	ctx := testutil.CreateAuthenticatedContext(userID)
+	date := &g.YMD{Year: 2024, Month: 5, Day: 1}
+
+	// 時刻未指定の既存の日記（1日1件の頃の日記）
+	first, err := svc.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{Content: "1日のまとめ", Date: date})
+	require.NoError(t, err)
+
+	t.Run("異常系: additionalなしでは同じ日付に作成できない", func(t *testing.T) {
+		_, err := svc.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{Content: "2件目", Date: date})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	evening, err := svc.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{
+		Content: "夜の振り返り", Date: date, Title: "夜", Time: &g.HM{Hour: 21}, Additional: true,
+	})
+	require.NoError(t, err)
+	morning, err := svc.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{
+		Content: "朝のメモ", Date: date, Title: "朝", Time: &g.HM{Hour: 7, Minute: 30}, Additional: true,
+	})
+	require.NoError(t, err)
+	// 別の日付の日記
+	_, err = svc.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{Content: "翌日", Date: &g.YMD{Year: 2024, Month: 5, Day: 2}})
+	require.NoError(t, err)
+
+	ids := func(entries []*g.DiaryEntry) []string {
+		result := make([]string, 0, len(entries))
+		for _, e := range entries {
+			result = append(result, e.Id)
+		}
+		return result
+	}
+	// 時刻未指定の日記が先頭、以降は時刻順
+	expectedOrder := []string{first.Entry.Id, morning.Entry.Id, evening.Entry.Id}
+
+	t.Run("正常系: GetDiaryEntryは同じ日付の全エントリを返す", func(t *testing.T) {
+		resp, err := svc.GetDiaryEntry(ctx, &g.GetDiaryEntryRequest{Date: date})
+		require.NoError(t, err)
+		assert.Equal(t, first.Entry.Id, resp.Entry.Id)
+		assert.Equal(t, expectedOrder, ids(resp.Entries))
+		assert.Equal(t, "朝", resp.Entries[1].Title)
+		assert.True(t, proto.Equal(&g.HM{Hour: 7, Minute: 30}, resp.Entries[1].Time))
+	})
+
+	t.Run("正常系: GetDiaryEntriesByMonthは日付ごとにまとまって並ぶ", func(t *testing.T) {
+		resp, err := svc.GetDiaryEntriesByMonth(ctx, &g.GetDiaryEntriesByMonthRequest{Month: &g.YM{Year: 2024, Month: 5}})
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 4)
+		assert.Equal(t, expectedOrder, ids(resp.Entries[:3]))
+		assert.Equal(t, uint32(2), resp.Entries[3].Date.Day)
+	})
+
+	t.Run("正常系: UpdateDiaryEntryはtitle未指定ならタイトルを維持し、clear_timeで時刻を外す", func(t *testing.T) {
+		resp, err := svc.UpdateDiaryEntry(ctx, &g.UpdateDiaryEntryRequest{Id: morning.Entry.Id, Content: "朝のメモ（追記）", Date: date, ClearTime: true})
+		require.NoError(t, err)
+		assert.Equal(t, "朝", resp.Entry.Title)
+		assert.Nil(t, resp.Entry.Time)
+	})
+
+	t.Run("異常系: additionalなしでは日記がある日付へ移動できない", func(t *testing.T) {
+		_, err := svc.UpdateDiaryEntry(ctx, &g.UpdateDiaryEntryRequest{Id: evening.Entry.Id, Content: "夜", Date: &g.YMD{Year: 2024, Month: 5, Day: 2}})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("正常系: additionalありなら日記がある日付へ移動できる", func(t *testing.T) {
+		_, err := svc.UpdateDiaryEntry(ctx, &g.UpdateDiaryEntryRequest{Id: evening.Entry.Id, Content: "夜", Date: &g.YMD{Year: 2024, Month: 5, Day: 2}, Additional: true})
+		require.NoError(t, err)
+
+		resp, err := svc.GetDiaryEntry(ctx, &g.GetDiaryEntryRequest{Date: &g.YMD{Year: 2024, Month: 5, Day: 2}})
+		require.NoError(t, err)
+		assert.Len(t, resp.Entries, 2)
+	})
+}
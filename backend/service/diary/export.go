@@ -0,0 +1,166 @@
+package diary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/diaryexport"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportPageSize はStreamExportDiaryEntriesで1回に読み込む日記の件数
+const exportPageSize = 200
+
+// exportFormats はリクエストの形式と変換の形式の対応（未指定はJSON Lines）
+var exportFormats = map[g.ExportFormat]diaryexport.Format{
+	g.ExportFormat_EXPORT_FORMAT_UNSPECIFIED: diaryexport.FormatJSONL,
+	g.ExportFormat_EXPORT_FORMAT_JSONL:       diaryexport.FormatJSONL,
+	g.ExportFormat_EXPORT_FORMAT_MARKDOWN:    diaryexport.FormatMarkdown,
+	g.ExportFormat_EXPORT_FORMAT_CSV:         diaryexport.FormatCSV,
+	g.ExportFormat_EXPORT_FORMAT_HTML:        diaryexport.FormatHTML,
+}
+
+// exportDateRange はエクスポートの開始年月の1日と終了年月の末日を返す
+func exportDateRange(from, to *g.YM) (time.Time, time.Time, error) {
+	if from == nil || to == nil {
+		return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "from and to are required")
+	}
+	if from.Year > to.Year || (from.Year == to.Year && from.Month > to.Month) {
+		return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "from must be before or equal to to")
+	}
+	fromDate := time.Date(int(from.Year), time.Month(from.Month), 1, 0, 0, 0, 0, time.UTC)
+	// 翌月の1日から1日引いて月末を取得する
+	toDate := time.Date(int(to.Year), time.Month(to.Month)+1, 0, 0, 0, 0, 0, time.UTC)
+	return fromDate, toDate, nil
+}
+
+// StreamExportDiaryEntries は指定期間の日記を指定した形式のファイルに変換してストリーミングで返す
+func (s *DiaryEntry) StreamExportDiaryEntries(
+	req *g.StreamExportDiaryEntriesRequest,
+	stream g.DiaryService_StreamExportDiaryEntriesServer,
+) error {
+	return s.SendExportDiaryEntries(stream.Context(), req, stream.Send)
+}
+
+// SendExportDiaryEntries は日記をexportPageSize件ずつ読み込んで変換し、変換した内容をsendに渡す
+// gRPCとConnectRPCでストリームの型が異なるため、送信処理を関数で受け取る
+func (s *DiaryEntry) SendExportDiaryEntries(
+	ctx context.Context,
+	req *g.StreamExportDiaryEntriesRequest,
+	send func(*g.StreamExportDiaryEntriesResponse) error,
+) error {
+	userIDStr, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "invalid user id")
+	}
+	fromDate, toDate, err := exportDateRange(req.From, req.To)
+	if err != nil {
+		return err
+	}
+	format, ok := exportFormats[req.Format]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unsupported export format")
+	}
+	tagIDs, err := parseTagIDs(req.TagIds)
+	if err != nil {
+		return err
+	}
+
+	// HTMLは目次を先頭に置くため、先に年月ごとの件数を取得する
+	var opts diaryexport.Options
+	if format == diaryexport.FormatHTML {
+		opts.Title = fmt.Sprintf("日記 %d年%d月〜%d年%d月", req.From.Year, req.From.Month, req.To.Year, req.To.Month)
+		opts.Months, err = database.DiaryMonthCountsByUserIDAndDateRange(ctx, s.DB, userID, fromDate, toDate, tagIDs)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to export diary entries: %v", err)
+		}
+	}
+
+	// 月次要約は年月をキーにし、その月の最初の日記の前に書き込む（日記のない月は含めない）
+	summaries := make(map[string]*database.DiarySummaryMonth)
+	if req.IncludeMonthlySummaries {
+		list, err := database.MonthlySummariesByUserIDAndRange(ctx, s.DB, userID, int(req.From.Year), int(req.From.Month), int(req.To.Year), int(req.To.Month))
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to export monthly summaries: %v", err)
+		}
+		for _, m := range list {
+			summaries[fmt.Sprintf("%04d-%02d", m.Year, m.Month)] = m
+		}
+	}
+
+	writer, err := diaryexport.NewWriter(format, func(path, contentType string, data []byte) error {
+		return send(&g.StreamExportDiaryEntriesResponse{Path: path, ContentType: contentType, Chunk: data})
+	}, opts)
+	if err != nil {
+		return err
+	}
+
+	// 同じ日付の日記はページをまたいでも1日分にまとめて書き込む
+	var day []diaryexport.Entry
+	var month string
+	flushDay := func() error {
+		if len(day) == 0 {
+			return nil
+		}
+		date := day[0].Diary.Date
+		if m := date.Format("2006-01"); m != month {
+			month = m
+			if summary, ok := summaries[m]; ok {
+				if err := writer.WriteMonthlySummary(summary); err != nil {
+					return err
+				}
+			}
+		}
+		err := writer.WriteDay(date, day)
+		day = nil
+		return err
+	}
+
+	var after *database.Diary
+	for {
+		page, err := database.DiariesPageByUserIDAndDateRange(ctx, s.DB, userID, fromDate, toDate, tagIDs, after, exportPageSize)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to export diary entries: %v", err)
+		}
+		ids := make([]uuid.UUID, len(page))
+		for i, d := range page {
+			ids[i] = d.ID
+		}
+		tags, err := database.TagsByDiaryIDs(ctx, s.DB, ids)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get diary tags: %v", err)
+		}
+
+		for _, d := range page {
+			if len(day) > 0 && !day[0].Diary.Date.Equal(d.Date) {
+				if err := flushDay(); err != nil {
+					return err
+				}
+			}
+			names := make([]string, 0, len(tags[d.ID]))
+			for _, t := range tags[d.ID] {
+				names = append(names, t.Name)
+			}
+			day = append(day, diaryexport.Entry{Diary: d, Tags: names})
+		}
+
+		if len(page) < exportPageSize {
+			break
+		}
+		after = page[len(page)-1]
+	}
+	if err := flushDay(); err != nil {
+		return err
+	}
+	return writer.Close()
+}
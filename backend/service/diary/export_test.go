@@ -0,0 +1,111 @@
+package diary
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// collectExport はストリーミングで受け取った内容をパスごとに連結して返す
+func collectExport(ctx context.Context, svc *DiaryEntry, req *g.StreamExportDiaryEntriesRequest) (map[string]string, error) {
+	files := make(map[string]string)
+	err := svc.SendExportDiaryEntries(ctx, req, func(resp *g.StreamExportDiaryEntriesResponse) error {
+		files[resp.Path] += string(resp.Chunk)
+		return nil
+	})
+	return files, err
+}
+
+func TestDiaryEntry_StreamExportDiaryEntries(t *testing.T) {
+	db := setupTestDB(t)
+
+	userID := createTestUser(t, db)
+	diaryService := &DiaryEntry{DB: db}
+	ctx := createAuthenticatedContext(userID)
+
+	// 1ページに収まらない件数の日記を作成する
+	const count = exportPageSize + 5
+	for i := range count {
+		_, err := diaryService.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{
+			Content: fmt.Sprintf("ストリーミングエクスポート %d", i),
+			Date:    &g.YMD{Year: 2023, Month: uint32(i/28) + 1, Day: uint32(i%28) + 1},
+		})
+		if err != nil {
+			t.Fatalf("日記作成に失敗: %v", err)
+		}
+	}
+	// 同じ日付に2件目の日記を追加する
+	_, err := diaryService.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{
+		Content:    "同じ日の2件目",
+		Date:       &g.YMD{Year: 2023, Month: 1, Day: 1},
+		Additional: true,
+	})
+	if err != nil {
+		t.Fatalf("日記作成に失敗: %v", err)
+	}
+	req := func(format g.ExportFormat) *g.StreamExportDiaryEntriesRequest {
+		return &g.StreamExportDiaryEntriesRequest{From: &g.YM{Year: 2023, Month: 1}, To: &g.YM{Year: 2023, Month: 12}, Format: format}
+	}
+
+	t.Run("正常系: JSON Linesはページをまたいで全件を日付順に返す", func(t *testing.T) {
+		files, err := collectExport(ctx, diaryService, req(g.ExportFormat_EXPORT_FORMAT_UNSPECIFIED))
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		scanner := bufio.NewScanner(strings.NewReader(files["diaries.jsonl"]))
+		var lines int
+		var prev string
+		for scanner.Scan() {
+			var record struct {
+				Date string `json:"date"`
+			}
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				t.Fatalf("JSONの解析に失敗: %v", err)
+			}
+			if record.Date < prev {
+				t.Errorf("日付順になっていない: %s < %s", record.Date, prev)
+			}
+			prev = record.Date
+			lines++
+		}
+		if lines != count+1 {
+			t.Errorf("期待件数 %d に対して %d 件", count+1, lines)
+		}
+	})
+
+	t.Run("正常系: Markdownは1日1ファイルにまとめる", func(t *testing.T) {
+		files, err := collectExport(ctx, diaryService, req(g.ExportFormat_EXPORT_FORMAT_MARKDOWN))
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(files) != count {
+			t.Errorf("期待ファイル数 %d に対して %d", count, len(files))
+		}
+		day := files["2023/01/2023-01-01.md"]
+		if !strings.HasPrefix(day, "---\ndate: 2023-01-01\n---\n") || !strings.Contains(day, "同じ日の2件目") {
+			t.Errorf("1日分のMarkdownが不正: %q", day)
+		}
+	})
+
+	t.Run("異常系: 未対応の形式はInvalidArgumentエラー", func(t *testing.T) {
+		r := req(g.ExportFormat(99))
+		_, err := collectExport(ctx, diaryService, r)
+		if st, ok := status.FromError(err); !ok || st.Code() != codes.InvalidArgument {
+			t.Errorf("InvalidArgument エラーを期待したが: %v", err)
+		}
+	})
+
+	t.Run("異常系: 開始が終了より後の場合はInvalidArgumentエラー", func(t *testing.T) {
+		_, err := collectExport(ctx, diaryService, &g.StreamExportDiaryEntriesRequest{From: &g.YM{Year: 2024, Month: 6}, To: &g.YM{Year: 2024, Month: 3}})
+		if st, ok := status.FromError(err); !ok || st.Code() != codes.InvalidArgument {
+			t.Errorf("InvalidArgument エラーを期待したが: %v", err)
+		}
+	})
+}
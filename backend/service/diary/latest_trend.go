@@ -10,6 +10,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
 	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/requestid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/taskevent"
 	"github.com/project-mikan/umi.mikan/backend/middleware"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -21,6 +23,7 @@ type LatestTrendGenerationMessage struct {
 	UserID      string `json:"user_id"`
 	PeriodStart string `json:"period_start"` // ISO 8601 format
 	PeriodEnd   string `json:"period_end"`   // ISO 8601 format
+	RequestID   string `json:"request_id,omitempty"`
 }
 
 // LatestTrendData はRedisに保存するトレンド分析データ
@@ -141,6 +144,7 @@ func (s *DiaryEntry) TriggerLatestTrend(
 		UserID:      userIDStr,
 		PeriodStart: periodStart.Format(time.RFC3339),
 		PeriodEnd:   periodEnd.Format(time.RFC3339),
+		RequestID:   requestid.FromContext(ctx),
 	}
 
 	messageBytes, err := json.Marshal(message)
@@ -153,6 +157,7 @@ func (s *DiaryEntry) TriggerLatestTrend(
 	if err := s.Redis.Do(ctx, publishCmd).Error(); err != nil {
 		return nil, status.Error(codes.Internal, "Failed to queue latest trend generation")
 	}
+	s.publishTaskQueued(ctx, userIDStr, taskevent.TypeLatestTrend, "")
 
 	return &g.TriggerLatestTrendResponse{
 		Success: true,
@@ -0,0 +1,173 @@
+package diary
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxMergeCells は行の対応付けで使う表の最大サイズ（前後の共通部分を除いた行数の積）
+// 通常の日記の編集（一部の段落の書き換え）では十分小さい
+const maxMergeCells = 1_000_000
+
+// 競合箇所を囲むマーカー（gitと同じ形式）
+const (
+	mergeConflictStart  = "<<<<<<< 手元の内容\n"
+	mergeConflictMiddle = "=======\n"
+	mergeConflictEnd    = ">>>>>>> サーバーの内容\n"
+)
+
+var errMergeTooLarge = status.Error(codes.InvalidArgument, "content is too large to merge")
+
+// splitLines は改行を残したまま行に分割する（最後の行は改行を含まない場合がある）
+func splitLines(s string) []string {
+	lines := make([]string, 0, strings.Count(s, "\n")+1)
+	for s != "" {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			lines = append(lines, s)
+			break
+		}
+		lines = append(lines, s[:i+1])
+		s = s[i+1:]
+	}
+	return lines
+}
+
+// matchLines はbaseの各行がotherのどの行に対応するか（最長共通部分列）を返す（対応しない行は-1）
+// 編集は一部の行に集中するため、前後の共通部分を除いてから対応を求める
+func matchLines(base, other []string) ([]int, error) {
+	match := make([]int, len(base))
+	for i := range match {
+		match[i] = -1
+	}
+
+	prefix := 0
+	for prefix < len(base) && prefix < len(other) && base[prefix] == other[prefix] {
+		match[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(base)-prefix && suffix < len(other)-prefix && base[len(base)-1-suffix] == other[len(other)-1-suffix] {
+		match[len(base)-1-suffix] = len(other) - 1 - suffix
+		suffix++
+	}
+
+	b, o := base[prefix:len(base)-suffix], other[prefix:len(other)-suffix]
+	n, m := len(b), len(o)
+	if n*m > maxMergeCells {
+		return nil, errMergeTooLarge
+	}
+	// lcs[i*w+j] はb[i:]とo[j:]の最長共通部分列の長さ
+	w := m + 1
+	lcs := make([]int32, (n+1)*w)
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if b[i] == o[j] {
+				lcs[i*w+j] = lcs[(i+1)*w+j+1] + 1
+			} else {
+				lcs[i*w+j] = max(lcs[(i+1)*w+j], lcs[i*w+j+1])
+			}
+		}
+	}
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case b[i] == o[j]:
+			match[prefix+i] = prefix + j
+			i++
+			j++
+		case lcs[(i+1)*w+j] >= lcs[i*w+j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match, nil
+}
+
+// writeLines は行を書き出し、最後の行に改行がなければ付ける（競合マーカーが同じ行に続かないように）
+func writeLines(out *strings.Builder, lines []string) {
+	for _, l := range lines {
+		out.WriteString(l)
+	}
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		out.WriteString("\n")
+	}
+}
+
+// mergeChunk はbaseの同じ範囲に対する2つの編集をマージして書き出し、競合した場合は1を返す
+func mergeChunk(out *strings.Builder, base, ours, theirs []string) int {
+	switch {
+	case slices.Equal(ours, theirs), slices.Equal(base, theirs):
+		out.WriteString(strings.Join(ours, ""))
+	case slices.Equal(base, ours):
+		out.WriteString(strings.Join(theirs, ""))
+	default:
+		out.WriteString(mergeConflictStart)
+		writeLines(out, ours)
+		out.WriteString(mergeConflictMiddle)
+		writeLines(out, theirs)
+		out.WriteString(mergeConflictEnd)
+		return 1
+	}
+	return 0
+}
+
+// mergeThreeWay はbaseからの2つの編集oursとtheirsを行単位でマージし、マージ結果と競合した箇所の数を返す（diff3と同じ方式）
+func mergeThreeWay(base, ours, theirs string) (string, int, error) {
+	o, a, b := splitLines(base), splitLines(ours), splitLines(theirs)
+	matchA, err := matchLines(o, a)
+	if err != nil {
+		return "", 0, err
+	}
+	matchB, err := matchLines(o, b)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var out strings.Builder
+	conflicts := 0
+	io, ia, ib := 0, 0, 0
+	for {
+		// 3つとも変わっていない行はそのまま書き出す
+		for io < len(o) && matchA[io] == ia && matchB[io] == ib {
+			out.WriteString(o[io])
+			io, ia, ib = io+1, ia+1, ib+1
+		}
+		// 次に3つとも対応するbaseの行までを、編集された範囲としてマージする
+		next := io
+		for next < len(o) && (matchA[next] < 0 || matchB[next] < 0) {
+			next++
+		}
+		endA, endB := len(a), len(b)
+		if next < len(o) {
+			endA, endB = matchA[next], matchB[next]
+		}
+		if io == next && ia == endA && ib == endB {
+			break
+		}
+		conflicts += mergeChunk(&out, o[io:next], a[ia:endA], b[ib:endB])
+		io, ia, ib = next, endA, endB
+	}
+	return out.String(), conflicts, nil
+}
+
+// MergeDiaryContent は同じ日記への2つの編集を3方向マージする（日記は保存しない）
+func (s *DiaryEntry) MergeDiaryContent(ctx context.Context, req *g.MergeDiaryContentRequest) (*g.MergeDiaryContentResponse, error) {
+	if _, err := authenticatedUserID(ctx); err != nil {
+		return nil, err
+	}
+	merged, conflicts, err := mergeThreeWay(req.GetBase(), req.GetOurs(), req.GetTheirs())
+	if err != nil {
+		return nil, err
+	}
+	return &g.MergeDiaryContentResponse{
+		Merged:        merged,
+		HasConflicts:  conflicts > 0,
+		ConflictCount: int32(conflicts),
+	}, nil
+}
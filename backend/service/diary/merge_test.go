@@ -0,0 +1,130 @@
+package diary
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSplitLines(t *testing.T) {
+	assert.Empty(t, splitLines(""))
+	assert.Equal(t, []string{"朝\n", "昼\n"}, splitLines("朝\n昼\n"))
+	assert.Equal(t, []string{"朝\n", "昼"}, splitLines("朝\n昼"))
+	assert.Equal(t, []string{"\n", "\n"}, splitLines("\n\n"))
+}
+
+func TestMergeThreeWay(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      string
+		ours      string
+		theirs    string
+		expected  string
+		conflicts int
+	}{
+		{
+			name:     "正常系: 別々の行の編集は両方反映される",
+			base:     "朝\n昼\n夜\n",
+			ours:     "朝ごはん\n昼\n夜\n",
+			theirs:   "朝\n昼\n夜ふかし\n",
+			expected: "朝ごはん\n昼\n夜ふかし\n",
+		},
+		{
+			name:     "正常系: 片方だけの編集はそのまま反映される",
+			base:     "朝\n昼\n",
+			ours:     "朝\n昼\n",
+			theirs:   "朝\n昼\n夜\n",
+			expected: "朝\n昼\n夜\n",
+		},
+		{
+			name:     "正常系: 両方が同じ編集をした場合は1つにまとめる",
+			base:     "朝\n",
+			ours:     "朝ごはん\n",
+			theirs:   "朝ごはん\n",
+			expected: "朝ごはん\n",
+		},
+		{
+			name:     "正常系: 行の追加と削除",
+			base:     "一\n二\n三\n四\n",
+			ours:     "一\n追加\n二\n三\n四\n",
+			theirs:   "一\n二\n四\n",
+			expected: "一\n追加\n二\n四\n",
+		},
+		{
+			name:     "正常系: 元の内容が空の場合",
+			base:     "",
+			ours:     "",
+			theirs:   "サーバーで書いた\n",
+			expected: "サーバーで書いた\n",
+		},
+		{
+			name:      "異常系: 同じ行を別々に編集した場合は競合する",
+			base:      "朝\n昼\n夜\n",
+			ours:      "朝\n昼はカレー\n夜\n",
+			theirs:    "朝\n昼はうどん\n夜\n",
+			expected:  "朝\n" + mergeConflictStart + "昼はカレー\n" + mergeConflictMiddle + "昼はうどん\n" + mergeConflictEnd + "夜\n",
+			conflicts: 1,
+		},
+		{
+			// diff3と同じく、間に変わっていない行がない隣り合った編集は競合として扱う
+			name:      "異常系: 隣り合った行の編集は競合する",
+			base:      "朝\n昼\n",
+			ours:      "朝ごはん\n昼\n",
+			theirs:    "朝\n昼ごはん\n",
+			expected:  mergeConflictStart + "朝ごはん\n昼\n" + mergeConflictMiddle + "朝\n昼ごはん\n" + mergeConflictEnd,
+			conflicts: 1,
+		},
+		{
+			name:      "異常系: 末尾に改行がない行の競合でもマーカーは別の行になる",
+			base:      "朝",
+			ours:      "朝ごはん",
+			theirs:    "朝寝坊",
+			expected:  mergeConflictStart + "朝ごはん\n" + mergeConflictMiddle + "朝寝坊\n" + mergeConflictEnd,
+			conflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, conflicts, err := mergeThreeWay(tt.base, tt.ours, tt.theirs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, merged)
+			assert.Equal(t, tt.conflicts, conflicts)
+		})
+	}
+}
+
+func TestMergeThreeWay_TooLarge(t *testing.T) {
+	// 前後の共通部分がなく、すべての行が異なる大きな編集はマージしない
+	var base, ours strings.Builder
+	for i := 0; i < 1100; i++ {
+		base.WriteString("元の行\n")
+		ours.WriteString("書き換えた行\n")
+	}
+	_, _, err := mergeThreeWay(base.String(), ours.String(), base.String())
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDiaryEntry_MergeDiaryContent(t *testing.T) {
+	svc := &DiaryEntry{}
+
+	t.Run("正常系: マージした内容を返す", func(t *testing.T) {
+		ctx := testutil.CreateAuthenticatedContext(uuid.New())
+		resp, err := svc.MergeDiaryContent(ctx, &g.MergeDiaryContentRequest{
+			Base:   "朝\n昼\n夜\n",
+			Ours:   "朝ごはん\n昼\n夜\n",
+			Theirs: "朝\n昼\n夜ごはん\n",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "朝ごはん\n昼\n夜ごはん\n", resp.Merged)
+		assert.False(t, resp.HasConflicts)
+		assert.Zero(t, resp.ConflictCount)
+	})
+}
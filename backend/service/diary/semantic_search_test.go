@@ -90,7 +90,7 @@ func TestDiaryEntry_SearchDiaryEntriesSemanticByUserID_Success(t *testing.T) {
 	}
 
 	// limit=100を渡して上限50へのクランプも通す
-	outcome, err := svc.SearchDiaryEntriesSemanticByUserID(ctx, userID, "旅行", 100)
+	outcome, err := svc.SearchDiaryEntriesSemanticByUserID(ctx, userID, "旅行", 100, nil)
 	if err != nil {
 		t.Fatalf("予期しないエラー: %v", err)
 	}
@@ -180,7 +180,7 @@ func TestDiaryEntry_SearchDiaryEntriesSemanticByUserID_ErrorBranches(t *testing.
 
 	t.Run("異常系: LLMFactory未設定の場合はエラー", func(t *testing.T) {
 		svc := &DiaryEntry{DB: db}
-		_, err := svc.SearchDiaryEntriesSemanticByUserID(ctx, userID, "クエリ", 10)
+		_, err := svc.SearchDiaryEntriesSemanticByUserID(ctx, userID, "クエリ", 10, nil)
 		if err == nil {
 			t.Fatal("LLMFactory未設定でエラーを期待したがnilが返った")
 		}
@@ -188,7 +188,7 @@ func TestDiaryEntry_SearchDiaryEntriesSemanticByUserID_ErrorBranches(t *testing.
 
 	t.Run("異常系: Geminiクライアント作成失敗の場合はエラー", func(t *testing.T) {
 		svc := &DiaryEntry{DB: db, LLMFactory: &mockLLMFactory{err: errors.New("client creation failed")}}
-		_, err := svc.SearchDiaryEntriesSemanticByUserID(ctx, userID, "クエリ", 10)
+		_, err := svc.SearchDiaryEntriesSemanticByUserID(ctx, userID, "クエリ", 10, nil)
 		if err == nil {
 			t.Fatal("クライアント作成失敗でエラーを期待したがnilが返った")
 		}
@@ -196,7 +196,7 @@ func TestDiaryEntry_SearchDiaryEntriesSemanticByUserID_ErrorBranches(t *testing.
 
 	t.Run("異常系: embedding生成失敗の場合はエラー", func(t *testing.T) {
 		svc := &DiaryEntry{DB: db, LLMFactory: &mockLLMFactory{embedder: &mockGeminiEmbedder{returnErr: errors.New("embedding failed")}}}
-		_, err := svc.SearchDiaryEntriesSemanticByUserID(ctx, userID, "クエリ", 10)
+		_, err := svc.SearchDiaryEntriesSemanticByUserID(ctx, userID, "クエリ", 10, nil)
 		if err == nil {
 			t.Fatal("embedding生成失敗でエラーを期待したがnilが返った")
 		}
@@ -206,7 +206,7 @@ func TestDiaryEntry_SearchDiaryEntriesSemanticByUserID_ErrorBranches(t *testing.
 		disabledUserID := testutil.CreateTestUser(t, db, "semantic-disabled@example.com", "SemDisabledUser")
 		testutil.CreateTestUserLLMWithSettings(t, db, disabledUserID, "test-api-key", false, false, false)
 		svc := &DiaryEntry{DB: db, LLMFactory: &mockLLMFactory{embedder: &mockGeminiEmbedder{}}}
-		_, err := svc.SearchDiaryEntriesSemanticByUserID(ctx, disabledUserID, "クエリ", 10)
+		_, err := svc.SearchDiaryEntriesSemanticByUserID(ctx, disabledUserID, "クエリ", 10, nil)
 		if err == nil {
 			t.Fatal("セマンティック検索無効でエラーを期待したがnilが返った")
 		}
@@ -216,7 +216,7 @@ func TestDiaryEntry_SearchDiaryEntriesSemanticByUserID_ErrorBranches(t *testing.
 		// embeddingを1件登録し、halfvec(3072)と次元の合わない3次元ベクトルを返すモックで検索する
 		insertTestDiaryWithEmbedding(t, db, userID, "次元不一致テスト用の日記", "2024-07-01", "テスト", makeTestUnitVector())
 		svc := &DiaryEntry{DB: db, LLMFactory: &mockLLMFactory{embedder: &mockGeminiEmbedder{}}}
-		_, err := svc.SearchDiaryEntriesSemanticByUserID(ctx, userID, "クエリ", 10)
+		_, err := svc.SearchDiaryEntriesSemanticByUserID(ctx, userID, "クエリ", 10, nil)
 		if err == nil {
 			t.Fatal("次元不一致でエラーを期待したがnilが返った")
 		}
@@ -274,7 +274,7 @@ func TestDiaryEntry_SearchDiaryEntriesByUserID_DBError(t *testing.T) {
 		t.Fatalf("DB クローズに失敗: %v", err)
 	}
 
-	_, err := svc.SearchDiaryEntriesByUserID(context.Background(), userID, "旅行")
+	_, err := svc.SearchDiaryEntriesByUserID(context.Background(), userID, "旅行", nil)
 	if err == nil {
 		t.Fatal("DBエラー時にエラーが返ることを期待したがnilが返った")
 	}
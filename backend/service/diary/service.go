@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -14,6 +15,10 @@ import (
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
 	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/lock"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/requestid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/storage"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/taskevent"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/webhook"
 	"github.com/project-mikan/umi.mikan/backend/middleware"
 	"github.com/redis/rueidis"
 	"google.golang.org/grpc/codes"
@@ -36,31 +41,40 @@ type DiaryEntry struct {
 	DB         *sql.DB
 	Redis      rueidis.Client
 	LLMFactory LLMFactory
+	// Storage は添付ファイルの保存先（nilの場合は添付ファイル機能を無効にする）
+	Storage          storage.Storage
+	AttachmentLimits AttachmentLimits
+	// TrashRetention はゴミ箱に移動してから完全に削除するまでの期間（ListTrashで削除予定日時を返すために使う）
+	TrashRetention time.Duration
 }
 
 type SummaryGenerationMessage struct {
-	Type   string `json:"type"`
-	UserID string `json:"user_id"`
-	Date   string `json:"date"` // YYYY-MM-DD format
+	Type      string `json:"type"`
+	UserID    string `json:"user_id"`
+	Date      string `json:"date"` // YYYY-MM-DD format
+	RequestID string `json:"request_id,omitempty"`
 }
 
 type MonthlySummaryGenerationMessage struct {
-	Type   string `json:"type"`
-	UserID string `json:"user_id"`
-	Year   int    `json:"year"`
-	Month  int    `json:"month"`
+	Type      string `json:"type"`
+	UserID    string `json:"user_id"`
+	Year      int    `json:"year"`
+	Month     int    `json:"month"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 type DiaryHighlightGenerationMessage struct {
-	Type    string `json:"type"`
-	UserID  string `json:"user_id"`
-	DiaryID string `json:"diary_id"`
+	Type      string `json:"type"`
+	UserID    string `json:"user_id"`
+	DiaryID   string `json:"diary_id"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 type DiaryEmbeddingMessage struct {
-	Type    string `json:"type"`
-	UserID  string `json:"user_id"`
-	DiaryID string `json:"diary_id"`
+	Type      string `json:"type"`
+	UserID    string `json:"user_id"`
+	DiaryID   string `json:"diary_id"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // semanticSimilarityThreshold はセマンティック検索のコサイン類似度下限値
@@ -113,6 +127,18 @@ func (s *DiaryEntry) CreateDiaryEntry(
 		return nil, err
 	}
 
+	if err := validateDiaryTitle(message.Title); err != nil {
+		return nil, err
+	}
+	entryTime, err := entryTimeFromProto(message.Time)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, err := s.ownedTagIDs(ctx, userID, message.TagIds)
+	if err != nil {
+		return nil, err
+	}
+
 	id := uuid.New()
 	currentTime := time.Now().Unix()
 	date := time.Date(int(message.Date.Year), time.Month(message.Date.Month), int(message.Date.Day), 0, 0, 0, 0, time.UTC)
@@ -124,15 +150,31 @@ func (s *DiaryEntry) CreateDiaryEntry(
 		Date:      date,
 		CreatedAt: currentTime,
 		UpdatedAt: currentTime,
+		Title:     message.Title,
+		EntryTime: entryTime,
+		Version:   1,
 	}
 
 	// トランザクション内でdiaryを保存
 	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		// 同じ日付への追加はadditionalを指定した場合のみ許可する
+		entryIndex, err := assignEntryIndex(ctx, tx, userIDStr, date, message.Additional)
+		if err != nil {
+			return err
+		}
+		diary.EntryIndex = entryIndex
+		if diary.ChangeSeq, err = database.NextDiaryChangeSeq(ctx, tx, userID); err != nil {
+			return err
+		}
+
 		if err := diary.Insert(ctx, tx); err != nil {
+			if isDiaryEntryConflict(err) {
+				return status.Error(codes.Aborted, "diary entry was created concurrently, please retry")
+			}
 			return err
 		}
 
-		return nil
+		return database.ReplaceDiaryTags(ctx, tx, diary.ID, tagIDs, currentTime)
 	})
 	if err != nil {
 		return nil, err
@@ -141,15 +183,18 @@ func (s *DiaryEntry) CreateDiaryEntry(
 	// 非同期で埋め込みベクトルを生成（Redis Pub/Sub経由）
 	// 当日の日記はスキップ（翌朝スケジューラーが処理する）
 	s.publishDiaryEmbeddingMessage(ctx, userID.String(), diary.ID.String(), diary.Date)
+	// タグを指定せずに作成した日記はLLMにタグを提案させる（自動タグ付けが有効な場合のみ）
+	if len(tagIDs) == 0 {
+		s.publishDiaryTagSuggestionMessage(ctx, userID, diary.ID.String())
+	}
+	s.enqueueDiaryWebhook(ctx, webhook.EventDiaryCreated, diary, true)
 
+	entries, err := s.withTags(ctx, []*g.DiaryEntry{toDiaryEntryProto(diary)})
+	if err != nil {
+		return nil, err
+	}
 	return &g.CreateDiaryEntryResponse{
-		Entry: &g.DiaryEntry{
-			Id:        diary.ID.String(),
-			Date:      message.Date,
-			Content:   diary.Content,
-			CreatedAt: diary.CreatedAt,
-			UpdatedAt: diary.UpdatedAt,
-		},
+		Entry: entries[0],
 	}, nil
 }
 
@@ -168,19 +213,22 @@ func (s *DiaryEntry) GetDiaryEntry(
 
 	date := time.Date(int(message.Date.Year), time.Month(message.Date.Month), int(message.Date.Day), 0, 0, 0, 0, time.UTC)
 
-	diary, err := database.DiaryByUserIDDate(ctx, s.DB, userID, date)
+	diaries, err := database.DiariesByUserIDAndDateOrdered(ctx, s.DB, userID.String(), date)
 	if err != nil {
 		return nil, err
 	}
+	if len(diaries) == 0 {
+		// 1日1エントリだった頃と同じく、日記がない場合はsql.ErrNoRowsを返す
+		return nil, sql.ErrNoRows
+	}
 
+	entries, err := s.withTags(ctx, toDiaryEntryProtos(diaries))
+	if err != nil {
+		return nil, err
+	}
 	return &g.GetDiaryEntryResponse{
-		Entry: &g.DiaryEntry{
-			Id:        diary.ID.String(),
-			Date:      message.Date,
-			Content:   diary.Content,
-			CreatedAt: diary.CreatedAt,
-			UpdatedAt: diary.UpdatedAt,
-		},
+		Entry:   entries[0],
+		Entries: entries,
 	}, nil
 }
 
@@ -197,36 +245,21 @@ func (s *DiaryEntry) GetDiaryEntries(
 		return nil, err
 	}
 
-	// 日記を収集
-	type diaryWithDate struct {
-		diary   *database.Diary
-		dateMsg *g.YMD
-	}
-	diariesWithDates := make([]diaryWithDate, 0)
-
+	// 指定された日付順に、各日付の全エントリを収集
+	entries := make([]*g.DiaryEntry, 0, len(message.Dates))
 	for _, dateMsg := range message.Dates {
 		date := time.Date(int(dateMsg.Year), time.Month(dateMsg.Month), int(dateMsg.Day), 0, 0, 0, 0, time.UTC)
-		diary, err := database.DiaryByUserIDDate(ctx, s.DB, userID, date)
+		diaries, err := database.DiariesByUserIDAndDateOrdered(ctx, s.DB, userID.String(), date)
 		if err != nil {
 			continue // Skip entries that don't exist
 		}
-		diariesWithDates = append(diariesWithDates, diaryWithDate{
-			diary:   diary,
-			dateMsg: dateMsg,
-		})
+		entries = append(entries, toDiaryEntryProtos(diaries)...)
 	}
 
-	entries := make([]*g.DiaryEntry, 0, len(diariesWithDates))
-	for _, dwd := range diariesWithDates {
-		entries = append(entries, &g.DiaryEntry{
-			Id:        dwd.diary.ID.String(),
-			Date:      dwd.dateMsg,
-			Content:   dwd.diary.Content,
-			CreatedAt: dwd.diary.CreatedAt,
-			UpdatedAt: dwd.diary.UpdatedAt,
-		})
+	entries, err = s.withTags(ctx, entries)
+	if err != nil {
+		return nil, err
 	}
-
 	return &g.GetDiaryEntriesResponse{
 		Entries: entries,
 	}, nil
@@ -245,32 +278,17 @@ func (s *DiaryEntry) GetDiaryEntriesByMonth(
 		return nil, err
 	}
 
-	// Query each day of the month to find diary entries
-	diaries := make([]*database.Diary, 0)
-
-	// Get the number of days in the month
-	daysInMonth := time.Date(int(message.Month.Year), time.Month(message.Month.Month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
-
-	for day := 1; day <= daysInMonth; day++ {
-		date := time.Date(int(message.Month.Year), time.Month(message.Month.Month), day, 0, 0, 0, 0, time.UTC)
-		diary, err := database.DiaryByUserIDDate(ctx, s.DB, userID, date)
-		if err != nil {
-			continue // Skip entries that don't exist
-		}
-		diaries = append(diaries, diary)
+	// 日付順（同じ日付内は時刻順）に取得するため、同じ日付のエントリは連続して並ぶ
+	year, month := int(message.Month.Year), int(message.Month.Month)
+	diaries, err := database.DiariesByUserIDAndDateRange(ctx, s.DB, userID.String(), year, month, year, month)
+	if err != nil {
+		return nil, err
 	}
 
-	entries := make([]*g.DiaryEntry, 0, len(diaries))
-	for _, diary := range diaries {
-		entries = append(entries, &g.DiaryEntry{
-			Id:        diary.ID.String(),
-			Date:      &g.YMD{Year: uint32(diary.Date.Year()), Month: uint32(diary.Date.Month()), Day: uint32(diary.Date.Day())},
-			Content:   diary.Content,
-			CreatedAt: diary.CreatedAt,
-			UpdatedAt: diary.UpdatedAt,
-		})
+	entries, err := s.withTags(ctx, toDiaryEntryProtos(diaries))
+	if err != nil {
+		return nil, err
 	}
-
 	return &g.GetDiaryEntriesByMonthResponse{
 		Entries: entries,
 	}, nil
@@ -294,7 +312,7 @@ func (s *DiaryEntry) UpdateDiaryEntry(
 		return nil, err
 	}
 
-	diary, err := database.DiaryByID(ctx, s.DB, diaryID)
+	diary, err := database.ActiveDiaryByID(ctx, s.DB, diaryID)
 	if err != nil {
 		return nil, err
 	}
@@ -304,21 +322,85 @@ func (s *DiaryEntry) UpdateDiaryEntry(
 		return nil, status.Errorf(codes.PermissionDenied, "not authorized to update this diary entry")
 	}
 
+	if message.Title != nil {
+		if err := validateDiaryTitle(message.GetTitle()); err != nil {
+			return nil, err
+		}
+	}
+	entryTime, err := entryTimeFromProto(message.Time)
+	if err != nil {
+		return nil, err
+	}
+	var tagIDs []uuid.UUID
+	if message.SetTags {
+		if tagIDs, err = s.ownedTagIDs(ctx, userID, message.TagIds); err != nil {
+			return nil, err
+		}
+	}
+
 	// トランザクション内で日記を更新
+	var current *database.Diary
 	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		// 変更番号の採番で同じユーザーの日記の変更が直列になるため、その後に読み直したversionで比較する
+		// 読み直すことで、その間にゴミ箱に移動された日記を元に戻してしまうこともない
+		seq, err := database.NextDiaryChangeSeq(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if current, err = database.ActiveDiaryByID(ctx, tx, diaryID); err != nil {
+			return err
+		}
+		if message.ExpectedVersion != nil && current.Version != message.GetExpectedVersion() {
+			return errDiaryVersionConflict
+		}
+		diary = current
+
 		diary.Content = message.Content
+		if message.Title != nil {
+			diary.Title = message.GetTitle()
+		}
+		switch {
+		case message.ClearTime:
+			diary.EntryTime = sql.NullInt64{}
+		case message.Time != nil:
+			diary.EntryTime = entryTime
+		}
 		if message.Date != nil {
-			diary.Date = time.Date(int(message.Date.Year), time.Month(message.Date.Month), int(message.Date.Day), 0, 0, 0, 0, time.UTC)
+			date := time.Date(int(message.Date.Year), time.Month(message.Date.Month), int(message.Date.Day), 0, 0, 0, 0, time.UTC)
+			if !date.Equal(diary.Date) {
+				// 別の日付へ移動する場合は、移動先の日付での通し番号を振り直す
+				entryIndex, err := assignEntryIndex(ctx, tx, userIDStr, date, message.Additional)
+				if err != nil {
+					return err
+				}
+				diary.Date = date
+				diary.EntryIndex = entryIndex
+			}
 		}
 		currentTime := time.Now().Unix()
 		diary.UpdatedAt = currentTime
+		diary.Version++
+		diary.ChangeSeq = seq
 
 		if err := diary.Update(ctx, tx); err != nil {
+			if isDiaryEntryConflict(err) {
+				return status.Error(codes.Aborted, "diary entry was created concurrently, please retry")
+			}
 			return err
 		}
 
+		if message.SetTags {
+			if err := database.ReplaceDiaryTags(ctx, tx, diary.ID, tagIDs, currentTime); err != nil {
+				return err
+			}
+			// ユーザーがタグを選び直したため、LLMの提案は不要になる
+			return database.ReplaceDiaryTagSuggestions(ctx, tx, diary.ID, nil, currentTime)
+		}
 		return nil
 	})
+	if errors.Is(err, errDiaryVersionConflict) {
+		return nil, s.diaryVersionConflictError(ctx, current)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -326,15 +408,14 @@ func (s *DiaryEntry) UpdateDiaryEntry(
 	// 非同期で埋め込みベクトルを再生成（Redis Pub/Sub経由）
 	// 当日の日記はスキップ（翌朝スケジューラーが処理する）
 	s.publishDiaryEmbeddingMessage(ctx, userID.String(), diary.ID.String(), diary.Date)
+	s.enqueueDiaryWebhook(ctx, webhook.EventDiaryUpdated, diary, true)
 
+	entries, err := s.withTags(ctx, []*g.DiaryEntry{toDiaryEntryProto(diary)})
+	if err != nil {
+		return nil, err
+	}
 	return &g.UpdateDiaryEntryResponse{
-		Entry: &g.DiaryEntry{
-			Id:        diary.ID.String(),
-			Date:      &g.YMD{Year: uint32(diary.Date.Year()), Month: uint32(diary.Date.Month()), Day: uint32(diary.Date.Day())},
-			Content:   diary.Content,
-			CreatedAt: diary.CreatedAt,
-			UpdatedAt: diary.UpdatedAt,
-		},
+		Entry: entries[0],
 	}, nil
 }
 
@@ -356,7 +437,7 @@ func (s *DiaryEntry) DeleteDiaryEntry(
 		return nil, err
 	}
 
-	diary, err := database.DiaryByID(ctx, s.DB, diaryID)
+	diary, err := database.ActiveDiaryByID(ctx, s.DB, diaryID)
 	if err != nil {
 		return nil, err
 	}
@@ -366,13 +447,23 @@ func (s *DiaryEntry) DeleteDiaryEntry(
 		return nil, status.Errorf(codes.PermissionDenied, "not authorized to delete this diary entry")
 	}
 
-	// トランザクション内で日記を削除
+	// トランザクション内で日記をゴミ箱に移動（差分同期のために削除の記録を残す）
+	// 添付ファイルなどは復元できるよう、ゴミ箱から完全に削除するまで残す
 	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
-		return diary.Delete(ctx, tx)
+		seq, err := database.NextDiaryChangeSeq(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		// 採番で変更が直列になった後に読み直し、その間の更新を古い内容で上書きしないようにする
+		if diary, err = database.ActiveDiaryByID(ctx, tx, diaryID); err != nil {
+			return err
+		}
+		return database.TrashDiary(ctx, tx, diary, seq, time.Now().Unix())
 	})
 	if err != nil {
 		return nil, err
 	}
+	s.enqueueDiaryWebhook(ctx, webhook.EventDiaryDeleted, diary, false)
 
 	return &g.DeleteDiaryEntryResponse{
 		Success: true,
@@ -387,7 +478,8 @@ type SearchDiaryEntriesResult struct {
 
 // SearchDiaryEntriesByUserID は指定ユーザーの日記をキーワードで全文検索する。
 // エンティティ名・エイリアスに基づく関連キーワード展開を含む。gRPC/MCPどちらからも利用する共通ロジック。
-func (s *DiaryEntry) SearchDiaryEntriesByUserID(ctx context.Context, userID uuid.UUID, keyword string) (*SearchDiaryEntriesResult, error) {
+// tagIDsを指定した場合は、そのタグをすべて持つ日記に絞り込む。
+func (s *DiaryEntry) SearchDiaryEntriesByUserID(ctx context.Context, userID uuid.UUID, keyword string, tagIDs []uuid.UUID) (*SearchDiaryEntriesResult, error) {
 	// エンティティ名・エイリアスに基づいて関連キーワードを展開
 	expandedKeywords, err := database.RelatedKeywordsByUserIDAndKeyword(ctx, s.DB, userID.String(), keyword)
 	if err != nil {
@@ -406,6 +498,10 @@ func (s *DiaryEntry) SearchDiaryEntriesByUserID(ctx context.Context, userID uuid
 	if err != nil {
 		return nil, err
 	}
+	ds, err = s.filterDiariesByTags(ctx, userID.String(), ds, tagIDs)
+	if err != nil {
+		return nil, err
+	}
 
 	return &SearchDiaryEntriesResult{Entries: ds, ExpandedKeywords: expandedKeywords}, nil
 }
@@ -424,21 +520,20 @@ func (s *DiaryEntry) SearchDiaryEntries(
 		return nil, err
 	}
 
-	result, err := s.SearchDiaryEntriesByUserID(ctx, userID, message.Keyword)
+	tagIDs, err := parseTagIDs(message.TagIds)
 	if err != nil {
 		return nil, err
 	}
 
-	entries := make([]*g.DiaryEntry, 0, len(result.Entries))
-	for _, d := range result.Entries {
-		entries = append(entries, &g.DiaryEntry{
-			Id:        d.ID.String(),
-			Content:   d.Content,
-			Date:      &g.YMD{Year: uint32(d.Date.Year()), Month: uint32(d.Date.Month()), Day: uint32(d.Date.Day())},
-			CreatedAt: d.CreatedAt,
-			UpdatedAt: d.UpdatedAt,
-		})
+	result, err := s.SearchDiaryEntriesByUserID(ctx, userID, message.Keyword, tagIDs)
+	if err != nil {
+		return nil, err
 	}
+	entries, err := s.withTags(ctx, toDiaryEntryProtos(result.Entries))
+	if err != nil {
+		return nil, err
+	}
+
 	return &g.SearchDiaryEntriesResponse{
 		SearchedKeyword:  message.Keyword,
 		Entries:          entries,
@@ -521,10 +616,11 @@ func (s *DiaryEntry) GenerateMonthlySummary(
 
 	// Redis Pub/Sub経由で月次要約生成を依頼
 	monthlyMessage := MonthlySummaryGenerationMessage{
-		Type:   "monthly_summary",
-		UserID: userID.String(),
-		Year:   int(message.Month.Year),
-		Month:  int(message.Month.Month),
+		Type:      "monthly_summary",
+		UserID:    userID.String(),
+		Year:      int(message.Month.Year),
+		Month:     int(message.Month.Month),
+		RequestID: requestid.FromContext(ctx),
 	}
 
 	messageBytes, err := json.Marshal(monthlyMessage)
@@ -539,6 +635,7 @@ func (s *DiaryEntry) GenerateMonthlySummary(
 		_ = s.deleteTaskStatus(ctx, taskKey)
 		return nil, status.Errorf(codes.Internal, "Failed to queue monthly summary generation")
 	}
+	s.publishTaskQueued(ctx, userID.String(), taskevent.TypeMonthlySummary, taskevent.MonthTarget(int(message.Month.Year), int(message.Month.Month)))
 
 	// 既存の要約があるかチェック（レスポンス用）
 	existingSummary, err := database.DiarySummaryMonthByUserIDYearMonth(ctx, s.DB, userID, int(message.Month.Year), int(message.Month.Month))
@@ -653,7 +750,7 @@ func (s *DiaryEntry) TriggerDiaryHighlight(
 	}
 
 	// 日記を取得
-	diary, err := database.DiaryByID(ctx, s.DB, diaryID)
+	diary, err := database.ActiveDiaryByID(ctx, s.DB, diaryID)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, "Diary entry not found")
 	}
@@ -694,9 +791,10 @@ func (s *DiaryEntry) TriggerDiaryHighlight(
 
 	// Redis Pub/Sub経由でハイライト生成を依頼
 	message := DiaryHighlightGenerationMessage{
-		Type:    "diary_highlight",
-		UserID:  userID.String(),
-		DiaryID: diaryID.String(),
+		Type:      "diary_highlight",
+		UserID:    userID.String(),
+		DiaryID:   diaryID.String(),
+		RequestID: requestid.FromContext(ctx),
 	}
 
 	messageBytes, err := json.Marshal(message)
@@ -711,6 +809,7 @@ func (s *DiaryEntry) TriggerDiaryHighlight(
 		_ = s.deleteTaskStatus(ctx, taskKey)
 		return nil, status.Error(codes.Internal, "Failed to queue highlight generation")
 	}
+	s.publishTaskQueued(ctx, userID.String(), taskevent.TypeDiaryHighlight, diaryID.String())
 
 	return &g.TriggerDiaryHighlightResponse{
 		Queued:  true,
@@ -739,7 +838,7 @@ func (s *DiaryEntry) GetDiaryHighlight(
 	}
 
 	// 日記を取得
-	diary, err := database.DiaryByID(ctx, s.DB, diaryID)
+	diary, err := database.ActiveDiaryByID(ctx, s.DB, diaryID)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, "Diary entry not found")
 	}
@@ -846,9 +945,10 @@ func (s *DiaryEntry) publishDiaryEmbeddingMessage(ctx context.Context, userID, d
 	}
 
 	message := DiaryEmbeddingMessage{
-		Type:    "diary_embedding",
-		UserID:  userID,
-		DiaryID: diaryID,
+		Type:      "diary_embedding",
+		UserID:    userID,
+		DiaryID:   diaryID,
+		RequestID: requestid.FromContext(ctx),
 	}
 	messageBytes, err := json.Marshal(message)
 	if err != nil {
@@ -859,7 +959,9 @@ func (s *DiaryEntry) publishDiaryEmbeddingMessage(ctx context.Context, userID, d
 	// 失敗しても日記の保存・更新レスポンスには影響せず、スケジューラーが翌朝リカバリする
 	if pubErr := s.Redis.Do(ctx, publishCmd).Error(); pubErr != nil {
 		log.Printf("Failed to publish diary embedding message for diary %s: %v", diaryID, pubErr)
+		return
 	}
+	s.publishTaskQueued(ctx, userID, taskevent.TypeDiaryEmbedding, diaryID)
 }
 
 // SemanticSearchResultItem は意味的検索1件分の結果
@@ -880,8 +982,8 @@ type SemanticSearchOutcome struct {
 }
 
 // SearchDiaryEntriesSemanticByUserID は指定ユーザーの日記を自然言語クエリで意味的に検索する。
-// gRPC/MCPどちらからも利用する共通ロジック。
-func (s *DiaryEntry) SearchDiaryEntriesSemanticByUserID(ctx context.Context, userID uuid.UUID, query string, limit int) (*SemanticSearchOutcome, error) {
+// gRPC/MCPどちらからも利用する共通ロジック。tagIDsを指定した場合は、そのタグをすべて持つ日記に絞り込む。
+func (s *DiaryEntry) SearchDiaryEntriesSemanticByUserID(ctx context.Context, userID uuid.UUID, query string, limit int, tagIDs []uuid.UUID) (*SemanticSearchOutcome, error) {
 	startTime := time.Now()
 
 	// クエリ検証
@@ -959,11 +1061,14 @@ func (s *DiaryEntry) SearchDiaryEntriesSemanticByUserID(ctx context.Context, use
 	kwResultCh := make(chan keywordSearchResult, 1)
 	go func() {
 		ds, err := database.DiariesByUserIDAndContent(ctx, s.DB, userID.String(), query)
+		if err == nil {
+			ds, err = s.filterDiariesByTags(ctx, userID.String(), ds, tagIDs)
+		}
 		kwResultCh <- keywordSearchResult{diaries: ds, err: err}
 	}()
 
 	// pgvectorでコサイン類似度ANN検索（txのef_search設定を使用）
-	searchResults, err := database.SearchDiaryEntriesByEmbedding(ctx, tx, userID, queryEmbedding, limit, semanticSimilarityThreshold)
+	searchResults, err := database.SearchDiaryEntriesByEmbeddingWithTags(ctx, tx, userID, queryEmbedding, limit, semanticSimilarityThreshold, tagIDs)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to search diary entries: %v", err)
 	}
@@ -1052,7 +1157,12 @@ func (s *DiaryEntry) SearchDiaryEntriesSemantic(
 		return nil, err
 	}
 
-	outcome, err := s.SearchDiaryEntriesSemanticByUserID(ctx, userID, req.Query, int(req.Limit))
+	tagIDs, err := parseTagIDs(req.TagIds)
+	if err != nil {
+		return nil, err
+	}
+
+	outcome, err := s.SearchDiaryEntriesSemanticByUserID(ctx, userID, req.Query, int(req.Limit), tagIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -1122,7 +1232,7 @@ func (s *DiaryEntry) RegenerateAllEmbeddings(
 
 	// 同一ユーザーによる同時実行を防ぐ分散ロック
 	regenLock := lock.NewDistributedLock(s.Redis, lock.EmbeddingRegenLockKey(userIDStr), lockTTL)
-	acquired, err := regenLock.TryLock(ctx)
+	_, acquired, err := regenLock.TryLock(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to acquire lock: %v", err)
 	}
@@ -1140,9 +1250,10 @@ func (s *DiaryEntry) RegenerateAllEmbeddings(
 	var count int32
 	for _, diaryID := range diaryIDs {
 		msg := DiaryEmbeddingMessage{
-			Type:    "diary_embedding",
-			UserID:  userIDStr,
-			DiaryID: diaryID,
+			Type:      "diary_embedding",
+			UserID:    userIDStr,
+			DiaryID:   diaryID,
+			RequestID: requestid.FromContext(ctx),
 		}
 		msgBytes, err := json.Marshal(msg)
 		if err != nil {
@@ -1154,6 +1265,8 @@ func (s *DiaryEntry) RegenerateAllEmbeddings(
 		// Redisへのpublishエラーはカウントから除外せず記録のみ（部分的な成功を許容）
 		if pubErr := s.Redis.Do(ctx, publishCmd).Error(); pubErr != nil {
 			log.Printf("Failed to publish embedding message for diary %s: %v", diaryID, pubErr)
+		} else {
+			s.publishTaskQueued(ctx, userIDStr, taskevent.TypeDiaryEmbedding, diaryID)
 		}
 		count++
 	}
@@ -1184,7 +1297,7 @@ func (s *DiaryEntry) GetDiaryEmbeddingStatus(
 	}
 
 	// 日記が存在し、このユーザーのものかを確認
-	diary, err := database.DiaryByID(ctx, s.DB, diaryID)
+	diary, err := database.ActiveDiaryByID(ctx, s.DB, diaryID)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "Diary not found")
 	}
@@ -1224,42 +1337,51 @@ func (s *DiaryEntry) ExportDiaryEntries(
 	}
 
 	// 開始・終了年月のバリデーション
-	if req.From == nil || req.To == nil {
-		return nil, status.Errorf(codes.InvalidArgument, "from and to are required")
+	fromDate, toDate, err := exportDateRange(req.From, req.To)
+	if err != nil {
+		return nil, err
 	}
-	fromYear := int(req.From.Year)
-	fromMonth := int(req.From.Month)
-	toYear := int(req.To.Year)
-	toMonth := int(req.To.Month)
-
-	// 開始が終了より後の場合はエラー
-	if fromYear > toYear || (fromYear == toYear && fromMonth > toMonth) {
-		return nil, status.Errorf(codes.InvalidArgument, "from must be before or equal to to")
+	tagIDs, err := parseTagIDs(req.TagIds)
+	if err != nil {
+		return nil, err
 	}
 
-	diaries, err := database.DiariesByUserIDAndDateRange(ctx, s.DB, userIDStr, fromYear, fromMonth, toYear, toMonth)
+	diaries, err := database.DiariesByUserIDAndDateRangeDays(ctx, s.DB, userIDStr, fromDate, toDate)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to export diary entries: %v", err)
 	}
+	diaries, err = s.filterDiariesByTags(ctx, userIDStr, diaries, tagIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to export diary entries: %v", err)
+	}
+
+	// 添付ファイルはメタデータのみ含め、内容はクライアントがDownloadAttachmentで取得する
+	attachments, err := database.DiaryAttachmentsByUserIDAndDateRangeDays(ctx, s.DB, userIDStr, fromDate, toDate)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to export diary attachments: %v", err)
+	}
 
-	entries := make([]*g.DiaryEntry, 0, len(diaries))
+	// タグで絞り込んだ場合は、エクスポートする日記の添付ファイルのみ含める
+	exported := make(map[uuid.UUID]bool, len(diaries))
 	for _, d := range diaries {
-		entries = append(entries, &g.DiaryEntry{
-			Id: d.ID.String(),
-			Date: &g.YMD{
-				Year:  uint32(d.Date.Year()),
-				Month: uint32(d.Date.Month()),
-				Day:   uint32(d.Date.Day()),
-			},
-			Content:   d.Content,
-			CreatedAt: d.CreatedAt,
-			UpdatedAt: d.UpdatedAt,
-		})
+		exported[d.ID] = true
+	}
+	exportedAttachments := make([]*database.DiaryAttachment, 0, len(attachments))
+	for _, a := range attachments {
+		if exported[a.DiaryID] {
+			exportedAttachments = append(exportedAttachments, a)
+		}
+	}
+
+	entries, err := s.withTags(ctx, toDiaryEntryProtos(diaries))
+	if err != nil {
+		return nil, err
 	}
 
 	return &g.ExportDiaryEntriesResponse{
-		Entries:    entries,
-		TotalCount: int32(len(entries)),
+		Entries:     entries,
+		TotalCount:  int32(len(entries)),
+		Attachments: toAttachmentProtos(exportedAttachments),
 	}, nil
 }
 
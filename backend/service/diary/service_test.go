@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
 	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -271,6 +272,45 @@ func TestDiaryEntry_UpdateDiaryEntry(t *testing.T) {
 	}
 }
 
+func TestDiaryEntry_UpdateDiaryEntry_ExpectedVersion(t *testing.T) {
+	db := setupTestDB(t)
+
+	userID := createTestUser(t, db)
+	diaryService := &DiaryEntry{DB: db}
+	ctx := createAuthenticatedContext(userID)
+
+	date := &g.YMD{Year: 2024, Month: 3, Day: 20}
+	createResp, err := diaryService.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{Content: "元の内容", Date: date})
+	require.NoError(t, err)
+	base := createResp.Entry
+
+	// Webで先に更新される
+	webResp, err := diaryService.UpdateDiaryEntry(ctx, &g.UpdateDiaryEntryRequest{
+		Id: base.Id, Content: "Webで更新", Date: date, ExpectedVersion: &base.Version,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, base.Version+1, webResp.Entry.Version)
+
+	t.Run("異常系：古いversionでの更新はAbortedで現在の日記を返す", func(t *testing.T) {
+		_, err := diaryService.UpdateDiaryEntry(ctx, &g.UpdateDiaryEntryRequest{
+			Id: base.Id, Content: "iOSで更新", Date: date, ExpectedVersion: &base.Version,
+		})
+		st := status.Convert(err)
+		require.Equal(t, codes.Aborted, st.Code())
+		require.Len(t, st.Details(), 1)
+		current, ok := st.Details()[0].(*g.DiaryEntry)
+		require.True(t, ok)
+		assert.Equal(t, "Webで更新", current.Content)
+		assert.Equal(t, webResp.Entry.Version, current.Version)
+	})
+
+	t.Run("正常系：expected_versionを指定しない場合は上書きする", func(t *testing.T) {
+		resp, err := diaryService.UpdateDiaryEntry(ctx, &g.UpdateDiaryEntryRequest{Id: base.Id, Content: "上書き", Date: date})
+		require.NoError(t, err)
+		assert.Equal(t, "上書き", resp.Entry.Content)
+	})
+}
+
 func TestDiaryEntry_DeleteDiaryEntry(t *testing.T) {
 	db := setupTestDB(t)
 
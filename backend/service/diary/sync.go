@@ -0,0 +1,544 @@
+package diary
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/webhook"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultSyncLimit = 200
+	maxSyncLimit     = 500
+	maxSyncMutations = 100
+)
+
+// syncCursor は差分同期で最後に返した変更の位置（変更番号と日記ID）
+// 1つのトランザクションで複数の日記に同じ変更番号を付けることがあるため、日記IDと組にして順序を一意にする
+type syncCursor struct {
+	seq int64
+	id  uuid.UUID
+}
+
+// initialSyncCursor は初回の同期の位置（変更番号0の既存の日記も含めて全件を返す）
+var initialSyncCursor = syncCursor{seq: -1}
+
+// parseSyncCursor はクライアントから受け取ったカーソル（"変更番号_日記ID"）を解析する
+func parseSyncCursor(s string) (syncCursor, error) {
+	if s == "" {
+		return initialSyncCursor, nil
+	}
+	seqStr, idStr, ok := strings.Cut(s, "_")
+	if !ok {
+		return syncCursor{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	seq, err := strconv.ParseInt(seqStr, 10, 64)
+	if err != nil || seq < 0 {
+		return syncCursor{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return syncCursor{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	return syncCursor{seq: seq, id: id}, nil
+}
+
+// String はクライアントに返すカーソルの文字列（初回の位置の場合は空）
+func (c syncCursor) String() string {
+	if c == initialSyncCursor {
+		return ""
+	}
+	return fmt.Sprintf("%d_%s", c.seq, c.id)
+}
+
+// less はcがotherより前の位置かどうかを返す（PostgreSQLのuuidの比較と同じくバイト列で比較する）
+func (c syncCursor) less(other syncCursor) bool {
+	if c.seq != other.seq {
+		return c.seq < other.seq
+	}
+	return bytes.Compare(c.id[:], other.id[:]) < 0
+}
+
+// diaryChange は差分同期で返す1件の変更（diaryかtombstoneのどちらか一方が設定される）
+type diaryChange struct {
+	cursor    syncCursor
+	diary     *database.Diary
+	tombstone *database.DiaryTombstone
+}
+
+// mergeDiaryChanges は変更番号順に並んだ日記と削除の記録を1つの順序にまとめ、先頭からlimit件を返す
+// それぞれlimit+1件まで取得しておき、残りがあればhasMoreをtrueにする
+func mergeDiaryChanges(diaries []*database.Diary, tombstones []*database.DiaryTombstone, limit int) ([]diaryChange, bool) {
+	changes := make([]diaryChange, 0, limit)
+	i, j := 0, 0
+	for len(changes) < limit && (i < len(diaries) || j < len(tombstones)) {
+		var d, t *diaryChange
+		if i < len(diaries) {
+			d = &diaryChange{cursor: syncCursor{seq: diaries[i].ChangeSeq, id: diaries[i].ID}, diary: diaries[i]}
+		}
+		if j < len(tombstones) {
+			t = &diaryChange{cursor: syncCursor{seq: tombstones[j].ChangeSeq, id: tombstones[j].DiaryID}, tombstone: tombstones[j]}
+		}
+		if t == nil || (d != nil && d.cursor.less(t.cursor)) {
+			changes = append(changes, *d)
+			i++
+		} else {
+			changes = append(changes, *t)
+			j++
+		}
+	}
+	return changes, i < len(diaries) || j < len(tombstones)
+}
+
+// syncLimit はリクエストのlimitを差分同期で返す件数に丸める
+func syncLimit(limit int32) int {
+	switch {
+	case limit <= 0:
+		return defaultSyncLimit
+	case limit > maxSyncLimit:
+		return maxSyncLimit
+	default:
+		return int(limit)
+	}
+}
+
+// diaryMutationFields は作成・更新の変更から日記の日付・時刻を検証して取り出す
+func diaryMutationFields(m *g.DiaryMutation) (time.Time, sql.NullInt64, error) {
+	if m.Date == nil {
+		return time.Time{}, sql.NullInt64{}, status.Error(codes.InvalidArgument, "date is required")
+	}
+	if err := validateDiaryTitle(m.Title); err != nil {
+		return time.Time{}, sql.NullInt64{}, err
+	}
+	entryTime, err := entryTimeFromProto(m.Time)
+	if err != nil {
+		return time.Time{}, sql.NullInt64{}, err
+	}
+	date := time.Date(int(m.Date.Year), time.Month(m.Date.Month), int(m.Date.Day), 0, 0, 0, 0, time.UTC)
+	return date, entryTime, nil
+}
+
+// diaryMatchesMutation はサーバー側の日記がすでに変更と同じ内容かどうかを返す
+// 応答を受け取れなかったクライアントが同じ変更を再送した場合に、競合ではなく反映済みとして扱うために使う
+func diaryMatchesMutation(d *database.Diary, m *g.DiaryMutation, date time.Time, entryTime sql.NullInt64) bool {
+	return d.Content == m.Content && d.Title == m.Title && d.Date.Equal(date) && d.EntryTime == entryTime
+}
+
+// isSyncRejection はmutationを反映できなかった理由が変更の内容にあるか（同期全体を失敗させずにREJECTEDとして返すか）を返す
+func isSyncRejection(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists:
+		return true
+	default:
+		return false
+	}
+}
+
+func syncApplied(m *g.DiaryMutation, d *database.Diary) *g.DiaryMutationResult {
+	return &g.DiaryMutationResult{
+		ClientMutationId: m.ClientMutationId,
+		Status:           g.DiaryMutationStatus_DIARY_MUTATION_STATUS_APPLIED,
+		Entry:            toDiaryEntryProto(d),
+	}
+}
+
+func syncConflict(m *g.DiaryMutation, d *database.Diary) *g.DiaryMutationResult {
+	return &g.DiaryMutationResult{
+		ClientMutationId: m.ClientMutationId,
+		Status:           g.DiaryMutationStatus_DIARY_MUTATION_STATUS_CONFLICT,
+		Entry:            toDiaryEntryProto(d),
+	}
+}
+
+func syncDeletedOnServer(m *g.DiaryMutation) *g.DiaryMutationResult {
+	return &g.DiaryMutationResult{
+		ClientMutationId: m.ClientMutationId,
+		Status:           g.DiaryMutationStatus_DIARY_MUTATION_STATUS_CONFLICT,
+		DeletedOnServer:  true,
+	}
+}
+
+var (
+	errSyncDiaryNotFound = status.Error(codes.NotFound, "diary entry not found")
+	// errSyncDiaryIDTaken は他ユーザーの日記（削除済みを含む）と同じIDを指定した場合のエラー
+	errSyncDiaryIDTaken = status.Error(codes.AlreadyExists, "diary id is already used")
+)
+
+// ownedDiaryForSync は変更対象の日記を取得する
+// 削除済み（ゴミ箱を含む）の場合はtombstoneを返す。日記も削除の記録もない場合はerrSyncDiaryNotFound、
+// 他ユーザーの日記の場合はerrSyncDiaryIDTakenを返す
+func ownedDiaryForSync(ctx context.Context, db database.DB, userID, id uuid.UUID) (*database.Diary, *database.DiaryTombstone, error) {
+	diary, err := database.DiaryByID(ctx, db, id)
+	switch {
+	case err == nil:
+		if diary.UserID != userID {
+			return nil, nil, errSyncDiaryIDTaken
+		}
+		if !diary.Trashed() {
+			return diary, nil, nil
+		}
+		// ゴミ箱の日記はクライアントには削除済みとして伝えているため、削除の記録を返す
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, nil, err
+	}
+
+	tombstone, err := database.DiaryTombstoneByDiaryID(ctx, db, id)
+	switch {
+	case err == nil && tombstone.UserID == userID:
+		return nil, tombstone, nil
+	case err == nil:
+		return nil, nil, errSyncDiaryIDTaken
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil, errSyncDiaryNotFound
+	default:
+		return nil, nil, err
+	}
+}
+
+// ownedDiaryToChange は更新・削除する日記を取得する（他ユーザーの日記は存在しないものとして扱う）
+func ownedDiaryToChange(ctx context.Context, db database.DB, userID, id uuid.UUID) (*database.Diary, *database.DiaryTombstone, error) {
+	diary, tombstone, err := ownedDiaryForSync(ctx, db, userID, id)
+	if errors.Is(err, errSyncDiaryIDTaken) {
+		return nil, nil, errSyncDiaryNotFound
+	}
+	return diary, tombstone, err
+}
+
+// SyncDiaryEntries はクライアントの変更を反映した後、cursor以降の変更を返す
+func (s *DiaryEntry) SyncDiaryEntries(ctx context.Context, req *g.SyncDiaryEntriesRequest) (*g.SyncDiaryEntriesResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := parseSyncCursor(req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	if len(req.GetMutations()) > maxSyncMutations {
+		return nil, status.Errorf(codes.InvalidArgument, "too many mutations (max %d)", maxSyncMutations)
+	}
+
+	// 1件ずつ別のトランザクションで反映し、反映できなかった変更があっても他の変更は反映する
+	results := make([]*g.DiaryMutationResult, 0, len(req.GetMutations()))
+	for _, m := range req.GetMutations() {
+		result, err := s.applyDiaryMutation(ctx, userID, m)
+		if err != nil {
+			if !isSyncRejection(err) {
+				return nil, err
+			}
+			result = &g.DiaryMutationResult{
+				ClientMutationId: m.ClientMutationId,
+				Status:           g.DiaryMutationStatus_DIARY_MUTATION_STATUS_REJECTED,
+				ErrorMessage:     status.Convert(err).Message(),
+			}
+		}
+		results = append(results, result)
+	}
+	entries := make([]*g.DiaryEntry, 0, len(results))
+	for _, r := range results {
+		if r.Entry != nil {
+			entries = append(entries, r.Entry)
+		}
+	}
+	// タグはエントリに直接設定される
+	if _, err := s.withTags(ctx, entries); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.diaryChangesSince(ctx, userID, cursor, syncLimit(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	resp.Results = results
+	return resp, nil
+}
+
+// diaryChangesSince はcursor以降に変更・削除された日記を変更番号順にlimit件まで返す
+func (s *DiaryEntry) diaryChangesSince(ctx context.Context, userID uuid.UUID, cursor syncCursor, limit int) (*g.SyncDiaryEntriesResponse, error) {
+	// 日記と削除の記録を同じ時点の状態から読む
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to begin transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	diaries, err := database.DiariesChangedSince(ctx, tx, userID, cursor.seq, cursor.id, limit+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get changed diaries: %v", err)
+	}
+	tombstones, err := database.DiaryTombstonesChangedSince(ctx, tx, userID, cursor.seq, cursor.id, limit+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get diary tombstones: %v", err)
+	}
+
+	changes, hasMore := mergeDiaryChanges(diaries, tombstones, limit)
+	resp := &g.SyncDiaryEntriesResponse{
+		ChangedEntries: make([]*g.DiaryEntry, 0, len(changes)),
+		Tombstones:     make([]*g.DiaryTombstone, 0),
+		HasMore:        hasMore,
+	}
+	next := cursor
+	for _, c := range changes {
+		next = c.cursor
+		if c.diary != nil {
+			resp.ChangedEntries = append(resp.ChangedEntries, toDiaryEntryProto(c.diary))
+			continue
+		}
+		t := c.tombstone
+		resp.Tombstones = append(resp.Tombstones, &g.DiaryTombstone{
+			Id:        t.DiaryID.String(),
+			Date:      &g.YMD{Year: uint32(t.Date.Year()), Month: uint32(t.Date.Month()), Day: uint32(t.Date.Day())},
+			DeletedAt: t.DeletedAt,
+		})
+	}
+	resp.NextCursor = next.String()
+
+	if resp.ChangedEntries, err = s.withTags(ctx, resp.ChangedEntries); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// applyDiaryMutation はクライアントの変更を1件反映する
+func (s *DiaryEntry) applyDiaryMutation(ctx context.Context, userID uuid.UUID, m *g.DiaryMutation) (*g.DiaryMutationResult, error) {
+	id, err := uuid.Parse(m.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid diary id")
+	}
+	switch m.Type {
+	case g.DiaryMutationType_DIARY_MUTATION_TYPE_CREATE:
+		return s.applyDiaryCreate(ctx, userID, id, m)
+	case g.DiaryMutationType_DIARY_MUTATION_TYPE_UPDATE:
+		return s.applyDiaryUpdate(ctx, userID, id, m)
+	case g.DiaryMutationType_DIARY_MUTATION_TYPE_DELETE:
+		return s.applyDiaryDelete(ctx, userID, id, m)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid mutation type")
+	}
+}
+
+// 以下のapplyDiary*はトランザクションの最初に変更番号を採番する。採番した行のロックで同じユーザーの変更が
+// 直列になるため、その後に読んだ日記のversionはコミットまで他の変更に追い越されない
+
+func (s *DiaryEntry) applyDiaryCreate(ctx context.Context, userID, id uuid.UUID, m *g.DiaryMutation) (*g.DiaryMutationResult, error) {
+	date, entryTime, err := diaryMutationFields(m)
+	if err != nil {
+		return nil, err
+	}
+	var tagIDs []uuid.UUID
+	if m.SetTags {
+		if tagIDs, err = s.ownedTagIDs(ctx, userID, m.TagIds); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().Unix()
+	diary := &database.Diary{
+		ID:        id,
+		UserID:    userID,
+		Content:   m.Content,
+		Date:      date,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Title:     m.Title,
+		EntryTime: entryTime,
+		Version:   1,
+	}
+	var result *g.DiaryMutationResult
+	created := false
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		seq, err := database.NextDiaryChangeSeq(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		existing, tombstone, err := ownedDiaryForSync(ctx, tx, userID, id)
+		switch {
+		case existing != nil:
+			// 再送された作成はそのまま反映済みとし、別の内容で作成済みの場合は競合とする
+			if diaryMatchesMutation(existing, m, date, entryTime) {
+				result = syncApplied(m, existing)
+			} else {
+				result = syncConflict(m, existing)
+			}
+			return nil
+		case tombstone != nil:
+			result = syncDeletedOnServer(m)
+			return nil
+		case !errors.Is(err, errSyncDiaryNotFound):
+			return err
+		}
+
+		// オフラインで書いた日記は、同じ日付にサーバー側の日記があっても別のエントリとして追加する
+		if diary.EntryIndex, err = assignEntryIndex(ctx, tx, userID.String(), date, true); err != nil {
+			return err
+		}
+		diary.ChangeSeq = seq
+		if err := diary.Insert(ctx, tx); err != nil {
+			if isDiaryEntryConflict(err) {
+				return status.Error(codes.Aborted, "diary entry was created concurrently, please retry")
+			}
+			return err
+		}
+		if err := database.ReplaceDiaryTags(ctx, tx, diary.ID, tagIDs, now); err != nil {
+			return err
+		}
+		created = true
+		result = syncApplied(m, diary)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return result, nil
+	}
+
+	s.publishDiaryEmbeddingMessage(ctx, userID.String(), diary.ID.String(), diary.Date)
+	if len(tagIDs) == 0 {
+		s.publishDiaryTagSuggestionMessage(ctx, userID, diary.ID.String())
+	}
+	s.enqueueDiaryWebhook(ctx, webhook.EventDiaryCreated, diary, true)
+	return result, nil
+}
+
+func (s *DiaryEntry) applyDiaryUpdate(ctx context.Context, userID, id uuid.UUID, m *g.DiaryMutation) (*g.DiaryMutationResult, error) {
+	date, entryTime, err := diaryMutationFields(m)
+	if err != nil {
+		return nil, err
+	}
+	var tagIDs []uuid.UUID
+	if m.SetTags {
+		if tagIDs, err = s.ownedTagIDs(ctx, userID, m.TagIds); err != nil {
+			return nil, err
+		}
+	}
+
+	var result *g.DiaryMutationResult
+	var updated *database.Diary
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		seq, err := database.NextDiaryChangeSeq(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		diary, tombstone, err := ownedDiaryToChange(ctx, tx, userID, id)
+		switch {
+		case tombstone != nil:
+			result = syncDeletedOnServer(m)
+			return nil
+		case err != nil:
+			return err
+		case diary.Version != m.BaseVersion:
+			if diaryMatchesMutation(diary, m, date, entryTime) {
+				result = syncApplied(m, diary)
+			} else {
+				result = syncConflict(m, diary)
+			}
+			return nil
+		}
+
+		if !date.Equal(diary.Date) {
+			// 別の日付へ移動する場合は、移動先の日付での通し番号を振り直す
+			if diary.EntryIndex, err = assignEntryIndex(ctx, tx, userID.String(), date, true); err != nil {
+				return err
+			}
+			diary.Date = date
+		}
+		now := time.Now().Unix()
+		diary.Content = m.Content
+		diary.Title = m.Title
+		diary.EntryTime = entryTime
+		diary.UpdatedAt = now
+		diary.Version++
+		diary.ChangeSeq = seq
+		if err := diary.Update(ctx, tx); err != nil {
+			if isDiaryEntryConflict(err) {
+				return status.Error(codes.Aborted, "diary entry was created concurrently, please retry")
+			}
+			return err
+		}
+		if m.SetTags {
+			if err := database.ReplaceDiaryTags(ctx, tx, diary.ID, tagIDs, now); err != nil {
+				return err
+			}
+			if err := database.ReplaceDiaryTagSuggestions(ctx, tx, diary.ID, nil, now); err != nil {
+				return err
+			}
+		}
+		updated = diary
+		result = syncApplied(m, diary)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return result, nil
+	}
+
+	s.publishDiaryEmbeddingMessage(ctx, userID.String(), updated.ID.String(), updated.Date)
+	s.enqueueDiaryWebhook(ctx, webhook.EventDiaryUpdated, updated, true)
+	return result, nil
+}
+
+func (s *DiaryEntry) applyDiaryDelete(ctx context.Context, userID, id uuid.UUID, m *g.DiaryMutation) (*g.DiaryMutationResult, error) {
+	var result *g.DiaryMutationResult
+	var deleted *database.Diary
+	err := database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		seq, err := database.NextDiaryChangeSeq(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		diary, tombstone, err := ownedDiaryToChange(ctx, tx, userID, id)
+		switch {
+		case tombstone != nil:
+			// すでに削除済みの場合は反映済みとする
+			result = &g.DiaryMutationResult{
+				ClientMutationId: m.ClientMutationId,
+				Status:           g.DiaryMutationStatus_DIARY_MUTATION_STATUS_APPLIED,
+			}
+			return nil
+		case err != nil:
+			return err
+		case diary.Version != m.BaseVersion:
+			// クライアントが知らない編集を消してしまわないよう、削除せずに競合とする
+			result = syncConflict(m, diary)
+			return nil
+		}
+
+		// Webと同じくゴミ箱に移動する（保持期間内は復元できる）
+		if err := database.TrashDiary(ctx, tx, diary, seq, time.Now().Unix()); err != nil {
+			return err
+		}
+		deleted = diary
+		result = &g.DiaryMutationResult{
+			ClientMutationId: m.ClientMutationId,
+			Status:           g.DiaryMutationStatus_DIARY_MUTATION_STATUS_APPLIED,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if deleted == nil {
+		return result, nil
+	}
+
+	s.enqueueDiaryWebhook(ctx, webhook.EventDiaryDeleted, deleted, false)
+	return result, nil
+}
@@ -0,0 +1,227 @@
+package diary
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseSyncCursor(t *testing.T) {
+	cursor, err := parseSyncCursor("")
+	require.NoError(t, err)
+	assert.Equal(t, initialSyncCursor, cursor)
+	assert.Equal(t, "", cursor.String())
+
+	id := uuid.New()
+	cursor, err = parseSyncCursor(syncCursor{seq: 42, id: id}.String())
+	require.NoError(t, err)
+	assert.Equal(t, syncCursor{seq: 42, id: id}, cursor)
+
+	for _, invalid := range []string{"42", "abc_" + id.String(), "-1_" + id.String(), "42_not-a-uuid"} {
+		_, err := parseSyncCursor(invalid)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), invalid)
+	}
+}
+
+func TestMergeDiaryChanges(t *testing.T) {
+	idA := uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	idB := uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	idC := uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+	diaries := []*database.Diary{
+		{ID: idB, ChangeSeq: 1},
+		{ID: idA, ChangeSeq: 3},
+		{ID: idC, ChangeSeq: 3},
+	}
+	tombstones := []*database.DiaryTombstone{
+		{DiaryID: idA, ChangeSeq: 2},
+		{DiaryID: idB, ChangeSeq: 3},
+	}
+
+	t.Run("正常系: 変更番号と日記IDの順にまとめる", func(t *testing.T) {
+		changes, hasMore := mergeDiaryChanges(diaries, tombstones, 10)
+		require.Len(t, changes, 5)
+		assert.False(t, hasMore)
+		expected := []syncCursor{{1, idB}, {2, idA}, {3, idA}, {3, idB}, {3, idC}}
+		for i, c := range changes {
+			assert.Equal(t, expected[i], c.cursor)
+		}
+		assert.NotNil(t, changes[1].tombstone)
+		assert.NotNil(t, changes[3].tombstone)
+	})
+
+	t.Run("正常系: limitを超える場合はhasMore", func(t *testing.T) {
+		changes, hasMore := mergeDiaryChanges(diaries, tombstones, 2)
+		require.Len(t, changes, 2)
+		assert.True(t, hasMore)
+	})
+
+	t.Run("正常系: 変更がない場合", func(t *testing.T) {
+		changes, hasMore := mergeDiaryChanges(nil, nil, 10)
+		assert.Empty(t, changes)
+		assert.False(t, hasMore)
+	})
+}
+
+func TestSyncLimit(t *testing.T) {
+	assert.Equal(t, defaultSyncLimit, syncLimit(0))
+	assert.Equal(t, 10, syncLimit(10))
+	assert.Equal(t, maxSyncLimit, syncLimit(maxSyncLimit+1))
+}
+
+func TestDiaryMatchesMutation(t *testing.T) {
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	d := &database.Diary{Content: "本文", Title: "タイトル", Date: date, EntryTime: sql.NullInt64{Int64: 600, Valid: true}}
+	m := &g.DiaryMutation{Content: "本文", Title: "タイトル"}
+
+	assert.True(t, diaryMatchesMutation(d, m, date, sql.NullInt64{Int64: 600, Valid: true}))
+	assert.False(t, diaryMatchesMutation(d, m, date, sql.NullInt64{}))
+	assert.False(t, diaryMatchesMutation(d, m, date.AddDate(0, 0, 1), sql.NullInt64{Int64: 600, Valid: true}))
+	assert.False(t, diaryMatchesMutation(d, &g.DiaryMutation{Content: "別の本文", Title: "タイトル"}, date, sql.NullInt64{Int64: 600, Valid: true}))
+}
+
+func TestDiaryEntry_SyncDiaryEntries(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "diary-sync@example.com", "DiarySyncUser")
+	otherUserID := testutil.CreateTestUser(t, db, "diary-sync-other@example.com", "DiarySyncOtherUser")
+	svc := &DiaryEntry{DB: db}
+	ctx := testutil.CreateAuthenticatedContext(userID)
+	otherCtx := testutil.CreateAuthenticatedContext(otherUserID)
+
+	// サーバー側（Web）で書いた日記
+	created, err := svc.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{
+		Content: "Webで書いた日記",
+		Date:    &g.YMD{Year: 2024, Month: 6, Day: 1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), created.Entry.Version)
+
+	var cursor string
+	t.Run("正常系: 初回は全件を返す", func(t *testing.T) {
+		resp, err := svc.SyncDiaryEntries(ctx, &g.SyncDiaryEntriesRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.ChangedEntries, 1)
+		assert.Equal(t, created.Entry.Id, resp.ChangedEntries[0].Id)
+		assert.False(t, resp.HasMore)
+		assert.NotEmpty(t, resp.NextCursor)
+		cursor = resp.NextCursor
+	})
+
+	t.Run("正常系: 変更がなければ同じカーソルを返す", func(t *testing.T) {
+		resp, err := svc.SyncDiaryEntries(ctx, &g.SyncDiaryEntriesRequest{Cursor: cursor})
+		require.NoError(t, err)
+		assert.Empty(t, resp.ChangedEntries)
+		assert.Empty(t, resp.Tombstones)
+		assert.Equal(t, cursor, resp.NextCursor)
+	})
+
+	offlineID := uuid.New().String()
+	createMutation := &g.DiaryMutation{
+		ClientMutationId: "m1",
+		Type:             g.DiaryMutationType_DIARY_MUTATION_TYPE_CREATE,
+		Id:               offlineID,
+		Content:          "オフラインで書いた日記",
+		Date:             &g.YMD{Year: 2024, Month: 6, Day: 1},
+	}
+	t.Run("正常系: オフラインで作成した日記を同じ日付の別エントリとして追加する", func(t *testing.T) {
+		resp, err := svc.SyncDiaryEntries(ctx, &g.SyncDiaryEntriesRequest{Cursor: cursor, Mutations: []*g.DiaryMutation{createMutation}})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 1)
+		assert.Equal(t, g.DiaryMutationStatus_DIARY_MUTATION_STATUS_APPLIED, resp.Results[0].Status)
+		assert.Equal(t, "m1", resp.Results[0].ClientMutationId)
+		require.Len(t, resp.ChangedEntries, 1)
+		assert.Equal(t, offlineID, resp.ChangedEntries[0].Id)
+		cursor = resp.NextCursor
+	})
+
+	t.Run("正常系: 同じ作成を再送しても反映済みになる", func(t *testing.T) {
+		resp, err := svc.SyncDiaryEntries(ctx, &g.SyncDiaryEntriesRequest{Cursor: cursor, Mutations: []*g.DiaryMutation{createMutation}})
+		require.NoError(t, err)
+		assert.Equal(t, g.DiaryMutationStatus_DIARY_MUTATION_STATUS_APPLIED, resp.Results[0].Status)
+		assert.Empty(t, resp.ChangedEntries)
+	})
+
+	t.Run("異常系: 古いversionを元にした更新は上書きせずに競合になる", func(t *testing.T) {
+		// Webで先に更新される
+		_, err := svc.UpdateDiaryEntry(ctx, &g.UpdateDiaryEntryRequest{Id: created.Entry.Id, Content: "Webで更新した日記", Date: created.Entry.Date})
+		require.NoError(t, err)
+
+		resp, err := svc.SyncDiaryEntries(ctx, &g.SyncDiaryEntriesRequest{Cursor: cursor, Mutations: []*g.DiaryMutation{{
+			ClientMutationId: "m2",
+			Type:             g.DiaryMutationType_DIARY_MUTATION_TYPE_UPDATE,
+			Id:               created.Entry.Id,
+			BaseVersion:      created.Entry.Version,
+			Content:          "iOSで更新した日記",
+			Date:             created.Entry.Date,
+		}}})
+		require.NoError(t, err)
+		assert.Equal(t, g.DiaryMutationStatus_DIARY_MUTATION_STATUS_CONFLICT, resp.Results[0].Status)
+		assert.Equal(t, "Webで更新した日記", resp.Results[0].Entry.Content)
+		assert.Equal(t, int64(2), resp.Results[0].Entry.Version)
+	})
+
+	t.Run("正常系: 最新のversionを元にした更新は反映される", func(t *testing.T) {
+		resp, err := svc.SyncDiaryEntries(ctx, &g.SyncDiaryEntriesRequest{Cursor: cursor, Mutations: []*g.DiaryMutation{{
+			Type:        g.DiaryMutationType_DIARY_MUTATION_TYPE_UPDATE,
+			Id:          created.Entry.Id,
+			BaseVersion: 2,
+			Content:     "iOSで更新した日記",
+			Date:        created.Entry.Date,
+		}}})
+		require.NoError(t, err)
+		assert.Equal(t, g.DiaryMutationStatus_DIARY_MUTATION_STATUS_APPLIED, resp.Results[0].Status)
+		assert.Equal(t, int64(3), resp.Results[0].Entry.Version)
+		require.Len(t, resp.ChangedEntries, 1)
+		assert.Equal(t, "iOSで更新した日記", resp.ChangedEntries[0].Content)
+		cursor = resp.NextCursor
+	})
+
+	t.Run("正常系: 削除は削除の記録として返る", func(t *testing.T) {
+		_, err := svc.DeleteDiaryEntry(ctx, &g.DeleteDiaryEntryRequest{Id: offlineID})
+		require.NoError(t, err)
+
+		resp, err := svc.SyncDiaryEntries(ctx, &g.SyncDiaryEntriesRequest{Cursor: cursor})
+		require.NoError(t, err)
+		assert.Empty(t, resp.ChangedEntries)
+		require.Len(t, resp.Tombstones, 1)
+		assert.Equal(t, offlineID, resp.Tombstones[0].Id)
+		cursor = resp.NextCursor
+	})
+
+	t.Run("異常系: サーバー側で削除済みの日記の更新は競合になる", func(t *testing.T) {
+		resp, err := svc.SyncDiaryEntries(ctx, &g.SyncDiaryEntriesRequest{Cursor: cursor, Mutations: []*g.DiaryMutation{{
+			Type:        g.DiaryMutationType_DIARY_MUTATION_TYPE_UPDATE,
+			Id:          offlineID,
+			BaseVersion: 1,
+			Content:     "削除された日記の更新",
+			Date:        &g.YMD{Year: 2024, Month: 6, Day: 1},
+		}}})
+		require.NoError(t, err)
+		assert.Equal(t, g.DiaryMutationStatus_DIARY_MUTATION_STATUS_CONFLICT, resp.Results[0].Status)
+		assert.True(t, resp.Results[0].DeletedOnServer)
+	})
+
+	t.Run("異常系: 他ユーザーの日記は変更できない", func(t *testing.T) {
+		resp, err := svc.SyncDiaryEntries(otherCtx, &g.SyncDiaryEntriesRequest{Mutations: []*g.DiaryMutation{{
+			Type:        g.DiaryMutationType_DIARY_MUTATION_TYPE_DELETE,
+			Id:          created.Entry.Id,
+			BaseVersion: 3,
+		}}})
+		require.NoError(t, err)
+		assert.Equal(t, g.DiaryMutationStatus_DIARY_MUTATION_STATUS_REJECTED, resp.Results[0].Status)
+		assert.Empty(t, resp.ChangedEntries)
+	})
+
+	t.Run("異常系: 不正なカーソル", func(t *testing.T) {
+		_, err := svc.SyncDiaryEntries(ctx, &g.SyncDiaryEntriesRequest{Cursor: "invalid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
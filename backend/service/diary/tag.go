@@ -0,0 +1,371 @@
+package diary
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/requestid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/taskevent"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tagNameMaxLength はタグ名の最大文字数（tags.nameのVARCHAR(50)に合わせる）
+const tagNameMaxLength = 50
+
+// defaultTagColor は色を指定せずに作成したタグの色
+const defaultTagColor = "#9ca3af"
+
+// tagColorPattern はタグの色の形式（#rrggbb）
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+var errTagNotFound = status.Error(codes.NotFound, "tag not found")
+
+// DiaryTagSuggestionMessage は日記へのタグの自動提案を依頼するメッセージ
+type DiaryTagSuggestionMessage struct {
+	Type      string `json:"type"`
+	UserID    string `json:"user_id"`
+	DiaryID   string `json:"diary_id"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// normalizeTagName は前後の空白を除いたタグ名を返す
+func normalizeTagName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", status.Error(codes.InvalidArgument, "tag name is required")
+	}
+	if len([]rune(name)) > tagNameMaxLength {
+		return "", status.Error(codes.InvalidArgument, "tag name is too long")
+	}
+	return name, nil
+}
+
+// normalizeTagColor は色を小文字の#rrggbb形式にする（空の場合はデフォルトの色）
+func normalizeTagColor(color string) (string, error) {
+	if color == "" {
+		return defaultTagColor, nil
+	}
+	color = strings.ToLower(strings.TrimSpace(color))
+	if !tagColorPattern.MatchString(color) {
+		return "", status.Error(codes.InvalidArgument, "tag color must be #rrggbb")
+	}
+	return color, nil
+}
+
+// parseTagIDs はリクエストのタグIDを重複を除いて変換する（空の場合はnil）
+func parseTagIDs(ids []string) ([]uuid.UUID, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	seen := make(map[uuid.UUID]bool, len(ids))
+	tagIDs := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		tagID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid tag id")
+		}
+		if !seen[tagID] {
+			seen[tagID] = true
+			tagIDs = append(tagIDs, tagID)
+		}
+	}
+	return tagIDs, nil
+}
+
+// isTagNameConflict は同じユーザーに同じ名前のタグがある場合のユニーク制約違反かを判定する
+func isTagNameConflict(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "unique_user_tag_name"
+}
+
+func toTagProto(t *database.Tag) *g.Tag {
+	return &g.Tag{Id: t.ID.String(), Name: t.Name, Color: t.Color}
+}
+
+func toTagProtos(tags []*database.Tag) []*g.Tag {
+	result := make([]*g.Tag, 0, len(tags))
+	for _, t := range tags {
+		result = append(result, toTagProto(t))
+	}
+	return result
+}
+
+// ownedTag は認証ユーザーが所有するタグを取得する
+// 他ユーザーのタグは存在を悟らせないためNotFoundを返す
+func (s *DiaryEntry) ownedTag(ctx context.Context, userID uuid.UUID, id string) (*database.Tag, error) {
+	tagID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid tag id")
+	}
+	tag, err := database.TagByID(ctx, s.DB, tagID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errTagNotFound
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get tag: %v", err)
+	}
+	if tag.UserID != userID {
+		return nil, errTagNotFound
+	}
+	return tag, nil
+}
+
+// ownedTagIDs はリクエストのタグIDを変換し、すべて認証ユーザーのタグであることを確認する
+func (s *DiaryEntry) ownedTagIDs(ctx context.Context, userID uuid.UUID, ids []string) ([]uuid.UUID, error) {
+	tagIDs, err := parseTagIDs(ids)
+	if err != nil || len(tagIDs) == 0 {
+		return tagIDs, err
+	}
+	tags, err := database.TagsByUserIDAndIDs(ctx, s.DB, userID, tagIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get tags: %v", err)
+	}
+	if len(tags) != len(tagIDs) {
+		return nil, errTagNotFound
+	}
+	return tagIDs, nil
+}
+
+// withTags はレスポンスの日記にタグとLLMが提案したタグを設定する
+// 提案のうち既に日記に付いているタグは除く
+func (s *DiaryEntry) withTags(ctx context.Context, entries []*g.DiaryEntry) ([]*g.DiaryEntry, error) {
+	diaryIDs := make([]uuid.UUID, 0, len(entries))
+	for _, e := range entries {
+		if id, err := uuid.Parse(e.Id); err == nil {
+			diaryIDs = append(diaryIDs, id)
+		}
+	}
+	tags, err := database.TagsByDiaryIDs(ctx, s.DB, diaryIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get diary tags: %v", err)
+	}
+	suggestions, err := database.SuggestedTagsByDiaryIDs(ctx, s.DB, diaryIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get diary tag suggestions: %v", err)
+	}
+	for _, e := range entries {
+		id, _ := uuid.Parse(e.Id)
+		e.Tags = toTagProtos(tags[id])
+		assigned := make(map[uuid.UUID]bool, len(tags[id]))
+		for _, t := range tags[id] {
+			assigned[t.ID] = true
+		}
+		e.SuggestedTags = make([]*g.Tag, 0, len(suggestions[id]))
+		for _, t := range suggestions[id] {
+			if !assigned[t.ID] {
+				e.SuggestedTags = append(e.SuggestedTags, toTagProto(t))
+			}
+		}
+	}
+	return entries, nil
+}
+
+// filterDiariesByTags はtagIDsのタグをすべて持つ日記のみを返す（tagIDsが空の場合はそのまま返す）
+func (s *DiaryEntry) filterDiariesByTags(ctx context.Context, userID string, diaries []*database.Diary, tagIDs []uuid.UUID) ([]*database.Diary, error) {
+	if len(tagIDs) == 0 {
+		return diaries, nil
+	}
+	ids, err := database.DiaryIDsByUserIDAndAllTags(ctx, s.DB, userID, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	matched := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		matched[id] = true
+	}
+	filtered := make([]*database.Diary, 0, len(diaries))
+	for _, d := range diaries {
+		if matched[d.ID.String()] {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered, nil
+}
+
+// publishDiaryTagSuggestionMessage は自動タグ付けが有効なユーザーの新しい日記について、
+// LLMによるタグの提案をRedis Pub/Sub経由でキューに追加する
+// エラーはログに記録するのみで、レスポンスには影響しない
+func (s *DiaryEntry) publishDiaryTagSuggestionMessage(ctx context.Context, userID uuid.UUID, diaryID string) {
+	if s.Redis == nil {
+		return
+	}
+	userLLM, err := database.UserLlmByUserIDLlmProvider(ctx, s.DB, userID, 1) // Gemini
+	if err != nil || !userLLM.AutoTaggingEnabled {
+		return
+	}
+
+	message := DiaryTagSuggestionMessage{
+		Type:      taskevent.TypeDiaryTagSuggestion,
+		UserID:    userID.String(),
+		DiaryID:   diaryID,
+		RequestID: requestid.FromContext(ctx),
+	}
+	messageBytes, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	publishCmd := s.Redis.B().Publish().Channel("diary_events").Message(string(messageBytes)).Build()
+	if pubErr := s.Redis.Do(ctx, publishCmd).Error(); pubErr != nil {
+		log.Printf("Failed to publish diary tag suggestion message for diary %s: %v", diaryID, pubErr)
+		return
+	}
+	s.publishTaskQueued(ctx, userID.String(), taskevent.TypeDiaryTagSuggestion, diaryID)
+}
+
+func (s *DiaryEntry) CreateTag(ctx context.Context, req *g.CreateTagRequest) (*g.CreateTagResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name, err := normalizeTagName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	color, err := normalizeTagColor(req.GetColor())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	tag := &database.Tag{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Color:     color,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := tag.Insert(ctx, s.DB); err != nil {
+		if isTagNameConflict(err) {
+			return nil, status.Error(codes.AlreadyExists, "tag already exists")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create tag: %v", err)
+	}
+	return &g.CreateTagResponse{Tag: toTagProto(tag)}, nil
+}
+
+func (s *DiaryEntry) ListTags(ctx context.Context, _ *g.ListTagsRequest) (*g.ListTagsResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := database.TagsWithCountByUserID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list tags: %v", err)
+	}
+	result := make([]*g.Tag, 0, len(tags))
+	for _, t := range tags {
+		tag := toTagProto(t.Tag)
+		tag.DiaryCount = int32(t.DiaryCount)
+		result = append(result, tag)
+	}
+	return &g.ListTagsResponse{Tags: result}, nil
+}
+
+func (s *DiaryEntry) RenameTag(ctx context.Context, req *g.RenameTagRequest) (*g.RenameTagResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := s.ownedTag(ctx, userID, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	name, err := normalizeTagName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if req.Color != nil {
+		color, err := normalizeTagColor(req.GetColor())
+		if err != nil {
+			return nil, err
+		}
+		tag.Color = color
+	}
+
+	tag.Name = name
+	tag.UpdatedAt = time.Now().Unix()
+	if err := tag.Update(ctx, s.DB); err != nil {
+		if isTagNameConflict(err) {
+			return nil, status.Error(codes.AlreadyExists, "tag already exists")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to rename tag: %v", err)
+	}
+	return &g.RenameTagResponse{Tag: toTagProto(tag)}, nil
+}
+
+func (s *DiaryEntry) MergeTags(ctx context.Context, req *g.MergeTagsRequest) (*g.MergeTagsResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.ownedTag(ctx, userID, req.GetTargetTagId())
+	if err != nil {
+		return nil, err
+	}
+	sourceIDs, err := s.ownedTagIDs(ctx, userID, req.GetSourceTagIds())
+	if err != nil {
+		return nil, err
+	}
+	if len(sourceIDs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "source_tag_ids is required")
+	}
+	for _, id := range sourceIDs {
+		if id == target.ID {
+			return nil, status.Error(codes.InvalidArgument, "source_tag_ids must not contain target_tag_id")
+		}
+	}
+
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		// タグが付け替わる日記を差分同期で再取得させる
+		if err := database.TouchDiariesByTagIDs(ctx, tx, userID, sourceIDs); err != nil {
+			return err
+		}
+		return database.MergeTags(ctx, tx, sourceIDs, target.ID, time.Now().Unix())
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to merge tags: %v", err)
+	}
+
+	count, err := database.DiaryCountByTagID(ctx, s.DB, target.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count tagged diaries: %v", err)
+	}
+	tag := toTagProto(target)
+	tag.DiaryCount = int32(count)
+	return &g.MergeTagsResponse{Tag: tag}, nil
+}
+
+func (s *DiaryEntry) DeleteTag(ctx context.Context, req *g.DeleteTagRequest) (*g.DeleteTagResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := s.ownedTag(ctx, userID, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		// タグが外れる日記を差分同期で再取得させる
+		if err := database.TouchDiariesByTagIDs(ctx, tx, userID, []uuid.UUID{tag.ID}); err != nil {
+			return err
+		}
+		// 日記との関連・提案はON DELETE CASCADEで削除される
+		return tag.Delete(ctx, tx)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete tag: %v", err)
+	}
+	return &g.DeleteTagResponse{Success: true}, nil
+}
@@ -0,0 +1,184 @@
+package diary
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNormalizeTagName(t *testing.T) {
+	name, err := normalizeTagName("  旅行 ")
+	require.NoError(t, err)
+	assert.Equal(t, "旅行", name)
+
+	_, err = normalizeTagName("   ")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// 文字数はバイト数ではなくルーン数で数える
+	_, err = normalizeTagName(strings.Repeat("あ", tagNameMaxLength))
+	require.NoError(t, err)
+	_, err = normalizeTagName(strings.Repeat("あ", tagNameMaxLength+1))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestNormalizeTagColor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		valid    bool
+	}{
+		{"", defaultTagColor, true},
+		{"#4F9DDE", "#4f9dde", true},
+		{" #00ff00 ", "#00ff00", true},
+		{"4f9dde", "", false},
+		{"#fff", "", false},
+		{"red", "", false},
+	}
+	for _, tc := range tests {
+		color, err := normalizeTagColor(tc.input)
+		if !tc.valid {
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), "input: %q", tc.input)
+			continue
+		}
+		require.NoError(t, err, "input: %q", tc.input)
+		assert.Equal(t, tc.expected, color, "input: %q", tc.input)
+	}
+}
+
+func TestParseTagIDs(t *testing.T) {
+	ids, err := parseTagIDs(nil)
+	require.NoError(t, err)
+	assert.Nil(t, ids)
+
+	// 重複は除く
+	id := uuid.New()
+	ids, err = parseTagIDs([]string{id.String(), id.String()})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{id}, ids)
+
+	_, err = parseTagIDs([]string{"not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDiaryEntry_Tags(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "diary-tag@example.com", "DiaryTagUser")
+	otherUserID := testutil.CreateTestUser(t, db, "diary-tag-other@example.com", "DiaryTagOtherUser")
+	svc := &DiaryEntry{DB: db}
+	ctx := testutil.CreateAuthenticatedContext(userID)
+	otherCtx := testutil.CreateAuthenticatedContext(otherUserID)
+
+	createTag := func(name string) *g.Tag {
+		t.Helper()
+		resp, err := svc.CreateTag(ctx, &g.CreateTagRequest{Name: name})
+		require.NoError(t, err)
+		return resp.Tag
+	}
+	travel := createTag("旅行")
+	food := createTag("ごはん")
+	trip := createTag("おでかけ")
+
+	created, err := svc.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{
+		Content: "京都で湯豆腐を食べた",
+		Date:    &g.YMD{Year: 2024, Month: 5, Day: 1},
+		TagIds:  []string{travel.Id, food.Id},
+	})
+	require.NoError(t, err)
+	_, err = svc.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{
+		Content: "近所の公園を散歩した",
+		Date:    &g.YMD{Year: 2024, Month: 5, Day: 2},
+		TagIds:  []string{trip.Id},
+	})
+	require.NoError(t, err)
+
+	t.Run("正常系: 作成した日記にタグが付く", func(t *testing.T) {
+		require.Len(t, created.Entry.Tags, 2)
+		assert.Equal(t, "ごはん", created.Entry.Tags[0].Name)
+		assert.Equal(t, defaultTagColor, created.Entry.Tags[0].Color)
+	})
+
+	t.Run("異常系: 同じ名前のタグはAlreadyExists", func(t *testing.T) {
+		_, err := svc.CreateTag(ctx, &g.CreateTagRequest{Name: "旅行"})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("異常系: 他ユーザーのタグは付けられない・変更できない", func(t *testing.T) {
+		_, err := svc.CreateDiaryEntry(otherCtx, &g.CreateDiaryEntryRequest{
+			Content: "他ユーザーの日記",
+			Date:    &g.YMD{Year: 2024, Month: 5, Day: 1},
+			TagIds:  []string{travel.Id},
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = svc.RenameTag(otherCtx, &g.RenameTagRequest{Id: travel.Id, Name: "乗っ取り"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("正常系: タグで絞り込んで検索する", func(t *testing.T) {
+		resp, err := svc.SearchDiaryEntries(ctx, &g.SearchDiaryEntriesRequest{TagIds: []string{travel.Id, food.Id}})
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 1)
+		assert.Equal(t, created.Entry.Id, resp.Entries[0].Id)
+
+		resp, err = svc.SearchDiaryEntries(ctx, &g.SearchDiaryEntriesRequest{Keyword: "散歩", TagIds: []string{travel.Id}})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Entries)
+	})
+
+	t.Run("正常系: タグで絞り込んでエクスポートする", func(t *testing.T) {
+		resp, err := svc.ExportDiaryEntries(ctx, &g.ExportDiaryEntriesRequest{
+			From:   &g.YM{Year: 2024, Month: 5},
+			To:     &g.YM{Year: 2024, Month: 5},
+			TagIds: []string{trip.Id},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 1)
+		assert.Equal(t, "近所の公園を散歩した", resp.Entries[0].Content)
+	})
+
+	t.Run("正常系: 名前と色を変更する", func(t *testing.T) {
+		color := "#FF0000"
+		resp, err := svc.RenameTag(ctx, &g.RenameTagRequest{Id: food.Id, Name: "グルメ", Color: &color})
+		require.NoError(t, err)
+		assert.Equal(t, "グルメ", resp.Tag.Name)
+		assert.Equal(t, "#ff0000", resp.Tag.Color)
+	})
+
+	t.Run("正常系: 統合すると日記のタグが付け替わる", func(t *testing.T) {
+		resp, err := svc.MergeTags(ctx, &g.MergeTagsRequest{SourceTagIds: []string{trip.Id}, TargetTagId: travel.Id})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), resp.Tag.DiaryCount)
+
+		list, err := svc.ListTags(ctx, &g.ListTagsRequest{})
+		require.NoError(t, err)
+		names := make([]string, 0, len(list.Tags))
+		for _, tag := range list.Tags {
+			names = append(names, tag.Name)
+		}
+		assert.ElementsMatch(t, []string{"旅行", "グルメ"}, names)
+	})
+
+	t.Run("正常系: set_tagsで日記のタグを置き換える", func(t *testing.T) {
+		resp, err := svc.UpdateDiaryEntry(ctx, &g.UpdateDiaryEntryRequest{
+			Id:      created.Entry.Id,
+			Content: created.Entry.Content,
+			SetTags: true,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Entry.Tags)
+	})
+
+	t.Run("正常系: タグを削除する", func(t *testing.T) {
+		_, err := svc.DeleteTag(ctx, &g.DeleteTagRequest{Id: travel.Id})
+		require.NoError(t, err)
+		_, err = svc.DeleteTag(context.Background(), &g.DeleteTagRequest{Id: travel.Id})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,135 @@
+package diary
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/webhook"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errTrashedDiaryNotFound = status.Error(codes.NotFound, "diary entry not found in trash")
+
+// purgeAt はゴミ箱に移動した日時から、完全に削除される日時の目安を返す
+// スケジューラーは1日1回削除するため、実際の削除は最大で1日遅れる
+func (s *DiaryEntry) purgeAt(deletedAt sql.NullInt64) int64 {
+	return deletedAt.Int64 + int64(s.TrashRetention/time.Second)
+}
+
+// ListTrash はゴミ箱の日記とエンティティを、ゴミ箱に移動した日時の新しい順に返す
+func (s *DiaryEntry) ListTrash(ctx context.Context, _ *g.ListTrashRequest) (*g.ListTrashResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	diaries, err := database.TrashedDiariesByUserID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get trashed diaries: %v", err)
+	}
+	entries, err := s.withTags(ctx, toDiaryEntryProtos(diaries))
+	if err != nil {
+		return nil, err
+	}
+	entities, err := database.TrashedEntitiesByUserID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get trashed entities: %v", err)
+	}
+
+	resp := &g.ListTrashResponse{
+		DiaryEntries:  make([]*g.TrashedDiaryEntry, 0, len(diaries)),
+		Entities:      make([]*g.TrashedEntity, 0, len(entities)),
+		RetentionDays: int32(s.TrashRetention / (24 * time.Hour)),
+	}
+	for i, d := range diaries {
+		resp.DiaryEntries = append(resp.DiaryEntries, &g.TrashedDiaryEntry{
+			Entry:     entries[i],
+			DeletedAt: d.DeletedAt.Int64,
+			PurgeAt:   s.purgeAt(d.DeletedAt),
+		})
+	}
+	for _, e := range entities {
+		resp.Entities = append(resp.Entities, &g.TrashedEntity{
+			Id:        e.ID.String(),
+			Name:      e.Name,
+			DeletedAt: e.DeletedAt.Int64,
+			PurgeAt:   s.purgeAt(e.DeletedAt),
+		})
+	}
+	return resp, nil
+}
+
+// RestoreDiaryEntry はゴミ箱の日記を元に戻す
+func (s *DiaryEntry) RestoreDiaryEntry(ctx context.Context, req *g.RestoreDiaryEntryRequest) (*g.RestoreDiaryEntryResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	diaryID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid diary id")
+	}
+
+	var restored *database.Diary
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		seq, err := database.NextDiaryChangeSeq(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		diary, err := database.DiaryByID(ctx, tx, diaryID)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return errTrashedDiaryNotFound
+		case err != nil:
+			return err
+		case diary.UserID != userID || !diary.Trashed():
+			return errTrashedDiaryNotFound
+		}
+		// 月次要約などの生成済みの内容に戻した日記を含め直すため、更新日時を進める
+		diary.UpdatedAt = time.Now().Unix()
+		if err := database.RestoreDiary(ctx, tx, diary, seq); err != nil {
+			return err
+		}
+		restored = diary
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.enqueueDiaryWebhook(ctx, webhook.EventDiaryRestored, restored, true)
+
+	entries, err := s.withTags(ctx, []*g.DiaryEntry{toDiaryEntryProto(restored)})
+	if err != nil {
+		return nil, err
+	}
+	return &g.RestoreDiaryEntryResponse{Entry: entries[0]}, nil
+}
+
+// EmptyTrash はゴミ箱の日記とエンティティを保持期間を待たずに完全に削除する
+func (s *DiaryEntry) EmptyTrash(ctx context.Context, _ *g.EmptyTrashRequest) (*g.EmptyTrashResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var purged *database.PurgedTrash
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		purged, err = database.EmptyTrashByUserID(ctx, tx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to empty trash: %v", err)
+	}
+	s.deleteAttachmentObjects(ctx, purged.Attachments)
+
+	return &g.EmptyTrashResponse{
+		DeletedDiaryCount:  int32(purged.Diaries),
+		DeletedEntityCount: int32(purged.Entities),
+	}, nil
+}
@@ -0,0 +1,99 @@
+package diary
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDiaryEntry_PurgeAt(t *testing.T) {
+	svc := &DiaryEntry{TrashRetention: 30 * 24 * time.Hour}
+	deletedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := svc.purgeAt(sql.NullInt64{Int64: deletedAt.Unix(), Valid: true})
+	assert.Equal(t, time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC).Unix(), got)
+}
+
+func TestDiaryEntry_Trash(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "diary-trash@example.com", "DiaryTrashUser")
+	otherUserID := testutil.CreateTestUser(t, db, "diary-trash-other@example.com", "DiaryTrashOtherUser")
+	svc := &DiaryEntry{DB: db, TrashRetention: 30 * 24 * time.Hour}
+	ctx := testutil.CreateAuthenticatedContext(userID)
+	otherCtx := testutil.CreateAuthenticatedContext(otherUserID)
+
+	date := &g.YMD{Year: 2024, Month: 7, Day: 1}
+	created, err := svc.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{Content: "ゴミ箱に移動する日記", Date: date})
+	require.NoError(t, err)
+
+	_, err = svc.DeleteDiaryEntry(ctx, &g.DeleteDiaryEntryRequest{Id: created.Entry.Id})
+	require.NoError(t, err)
+
+	t.Run("正常系: 削除した日記は取得できずゴミ箱に入る", func(t *testing.T) {
+		_, err := svc.GetDiaryEntry(ctx, &g.GetDiaryEntryRequest{Date: date})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		resp, err := svc.ListTrash(ctx, &g.ListTrashRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.DiaryEntries, 1)
+		assert.Equal(t, created.Entry.Id, resp.DiaryEntries[0].Entry.Id)
+		assert.Equal(t, resp.DiaryEntries[0].DeletedAt+int64(30*24*60*60), resp.DiaryEntries[0].PurgeAt)
+		assert.Equal(t, int32(30), resp.RetentionDays)
+	})
+
+	t.Run("正常系: ゴミ箱にある間は同じ日付に新しい日記を作成できる", func(t *testing.T) {
+		resp, err := svc.CreateDiaryEntry(ctx, &g.CreateDiaryEntryRequest{Content: "書き直した日記", Date: date})
+		require.NoError(t, err)
+		_, err = svc.DeleteDiaryEntry(ctx, &g.DeleteDiaryEntryRequest{Id: resp.Entry.Id})
+		require.NoError(t, err)
+	})
+
+	t.Run("異常系: 他のユーザーの日記は復元できない", func(t *testing.T) {
+		_, err := svc.RestoreDiaryEntry(otherCtx, &g.RestoreDiaryEntryRequest{Id: created.Entry.Id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("正常系: ゴミ箱の日記を復元できる", func(t *testing.T) {
+		resp, err := svc.RestoreDiaryEntry(ctx, &g.RestoreDiaryEntryRequest{Id: created.Entry.Id})
+		require.NoError(t, err)
+		assert.Equal(t, "ゴミ箱に移動する日記", resp.Entry.Content)
+
+		got, err := svc.GetDiaryEntry(ctx, &g.GetDiaryEntryRequest{Date: date})
+		require.NoError(t, err)
+		assert.Equal(t, created.Entry.Id, got.Entry.Id)
+	})
+
+	t.Run("異常系: ゴミ箱にない日記は復元できない", func(t *testing.T) {
+		_, err := svc.RestoreDiaryEntry(ctx, &g.RestoreDiaryEntryRequest{Id: created.Entry.Id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = svc.RestoreDiaryEntry(ctx, &g.RestoreDiaryEntryRequest{Id: uuid.New().String()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = svc.RestoreDiaryEntry(ctx, &g.RestoreDiaryEntryRequest{Id: "invalid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("正常系: ゴミ箱を空にすると完全に削除される", func(t *testing.T) {
+		resp, err := svc.EmptyTrash(ctx, &g.EmptyTrashRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), resp.DeletedDiaryCount)
+
+		list, err := svc.ListTrash(ctx, &g.ListTrashRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, list.DiaryEntries)
+
+		// 復元した日記は残る
+		_, err = database.ActiveDiaryByID(ctx, db, uuid.MustParse(created.Entry.Id))
+		assert.NoError(t, err)
+	})
+}
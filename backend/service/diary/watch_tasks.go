@@ -0,0 +1,229 @@
+package diary
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/taskevent"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// taskEventBufferSize は送信待ちイベントのバッファ数
+// 送信が追いつかずバッファが溢れた場合はストリームを終了し、再接続時のスナップショットで復元させる
+const taskEventBufferSize = 64
+
+// taskKeyScanCount はタスクキーをSCANで探すときに1回で確認するキーの数の目安
+const taskKeyScanCount = 100
+
+// publishTaskQueued はタスクをキューに追加したことをWatchTasksの購読者に通知する
+// 通知は補助的な機能のため、失敗してもログに記録するのみ
+func (s *DiaryEntry) publishTaskQueued(ctx context.Context, userID, taskType, target string) {
+	if s.Redis == nil {
+		return
+	}
+	event := taskevent.NewEvent(userID, taskType, target, taskevent.StatusQueued)
+	if err := taskevent.Publish(ctx, s.Redis, event); err != nil {
+		log.Printf("Failed to publish %s queued event for user %s: %v", taskType, userID, err)
+	}
+}
+
+// WatchTasks はユーザーの非同期タスクの状態変化をストリーミングで通知する
+func (s *DiaryEntry) WatchTasks(
+	_ *g.WatchTasksRequest,
+	stream g.DiaryService_WatchTasksServer,
+) error {
+	return s.StreamTasks(stream.Context(), stream.Send)
+}
+
+// StreamTasks は現在のタスク状態を送信した後、ctxが終了するまで状態変化をsendに渡し続ける
+// gRPCとConnectRPCでストリームの型が異なるため、送信処理を関数で受け取る
+func (s *DiaryEntry) StreamTasks(ctx context.Context, send func(*g.TaskEvent) error) error {
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// スナップショット取得中の変化を取りこぼさないよう、購読の確立を待ってからスナップショットを取る
+	events := make(chan taskevent.Event, taskEventBufferSize)
+	var overflowed atomic.Bool
+	subscribed := make(chan struct{})
+	var subscribeOnce sync.Once
+	subErr := make(chan error, 1)
+	go func() {
+		// 再接続時にも通知されるため、最初の1回だけを扱う
+		onSubscribed := func() { subscribeOnce.Do(func() { close(subscribed) }) }
+		subErr <- taskevent.Subscribe(ctx, s.Redis, userID, onSubscribed, func(e taskevent.Event) {
+			// 受信処理はRedisコネクションを共有しているため、ここではブロックしない
+			select {
+			case events <- e:
+			default:
+				overflowed.Store(true)
+				cancel()
+			}
+		})
+	}()
+
+	select {
+	case <-subscribed:
+	case <-ctx.Done():
+		return nil
+	case err := <-subErr:
+		if err != nil {
+			return status.Error(codes.Unavailable, "Failed to subscribe task events")
+		}
+		return nil
+	}
+
+	snapshot, err := s.currentTaskEvents(ctx, userID)
+	if err != nil {
+		return status.Error(codes.Internal, "Failed to get current tasks")
+	}
+	for _, e := range snapshot {
+		if err := send(e); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if overflowed.Load() {
+				return status.Error(codes.ResourceExhausted, "Too many task events. Please reconnect.")
+			}
+			return nil
+		case err := <-subErr:
+			if err != nil {
+				return status.Error(codes.Unavailable, "Task event subscription closed")
+			}
+			return nil
+		case e := <-events:
+			if err := send(toTaskEventProto(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// currentTaskEvents はRedisのタスクキーから、現在キュー済み・処理中のタスクを取得する
+func (s *DiaryEntry) currentTaskEvents(ctx context.Context, userID string) ([]*g.TaskEvent, error) {
+	var events []*g.TaskEvent
+	now := time.Now().Unix()
+
+	// 月次要約: task:monthly_summary:userID:YYYY-M
+	monthlyPrefix := fmt.Sprintf("task:monthly_summary:%s:", userID)
+	monthlyKeys, err := s.scanTaskKeys(ctx, monthlyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range monthlyKeys {
+		var year, month int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(key, monthlyPrefix), "%d-%d", &year, &month); err != nil {
+			continue
+		}
+		value, err := s.getTaskStatus(ctx, key)
+		if err != nil {
+			continue
+		}
+		events = append(events, &g.TaskEvent{
+			TaskType:  taskevent.TypeMonthlySummary,
+			Target:    taskevent.MonthTarget(year, month),
+			Status:    taskKeyStatus(value),
+			Timestamp: now,
+		})
+	}
+
+	// ハイライト: task:diary_highlight:userID:diaryID
+	highlightPrefix := fmt.Sprintf("task:diary_highlight:%s:", userID)
+	highlightKeys, err := s.scanTaskKeys(ctx, highlightPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range highlightKeys {
+		value, err := s.getTaskStatus(ctx, key)
+		if err != nil {
+			continue
+		}
+		events = append(events, &g.TaskEvent{
+			TaskType:  taskevent.TypeDiaryHighlight,
+			Target:    strings.TrimPrefix(key, highlightPrefix),
+			Status:    taskKeyStatus(value),
+			Timestamp: now,
+		})
+	}
+
+	// トレンド分析: task:latest_trend:userID（値はタスク開始時刻）
+	if value, err := s.getTaskStatus(ctx, fmt.Sprintf("task:latest_trend:%s", userID)); err == nil {
+		startedAt, parseErr := strconv.ParseInt(value, 10, 64)
+		if parseErr != nil {
+			startedAt = now
+		}
+		events = append(events, &g.TaskEvent{
+			TaskType:  taskevent.TypeLatestTrend,
+			Status:    g.TaskStatus_TASK_STATUS_PROCESSING,
+			Timestamp: startedAt,
+		})
+	}
+
+	return events, nil
+}
+
+// scanTaskKeys はprefixで始まるタスクキーをSCANで取得する（KEYSのようにRedisを止めない）
+func (s *DiaryEntry) scanTaskKeys(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	var cursor uint64
+	for {
+		entry, err := s.Redis.Do(ctx, s.Redis.B().Scan().Cursor(cursor).Match(prefix+"*").Count(taskKeyScanCount).Build()).AsScanEntry()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, entry.Elements...)
+		if entry.Cursor == 0 {
+			return keys, nil
+		}
+		cursor = entry.Cursor
+	}
+}
+
+// taskKeyStatus はタスクキーの値をTaskStatusに変換する
+func taskKeyStatus(value string) g.TaskStatus {
+	if value == string(taskevent.StatusQueued) {
+		return g.TaskStatus_TASK_STATUS_QUEUED
+	}
+	return g.TaskStatus_TASK_STATUS_PROCESSING
+}
+
+// toTaskEventProto はタスクイベントをレスポンス用のメッセージに変換する
+func toTaskEventProto(e taskevent.Event) *g.TaskEvent {
+	var taskStatus g.TaskStatus
+	switch e.Status {
+	case taskevent.StatusQueued:
+		taskStatus = g.TaskStatus_TASK_STATUS_QUEUED
+	case taskevent.StatusProcessing:
+		taskStatus = g.TaskStatus_TASK_STATUS_PROCESSING
+	case taskevent.StatusSucceeded:
+		taskStatus = g.TaskStatus_TASK_STATUS_SUCCEEDED
+	case taskevent.StatusFailed:
+		taskStatus = g.TaskStatus_TASK_STATUS_FAILED
+	default:
+		taskStatus = g.TaskStatus_TASK_STATUS_UNSPECIFIED
+	}
+	return &g.TaskEvent{
+		TaskType:  e.TaskType,
+		Target:    e.Target,
+		Status:    taskStatus,
+		Message:   e.Message,
+		Timestamp: e.Timestamp,
+	}
+}
@@ -0,0 +1,141 @@
+package diary
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/taskevent"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"github.com/redis/rueidis"
+)
+
+func TestDiaryEntry_StreamTasks(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis起動失敗: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	// miniredisはRESP3の購読中コネクションで他のコマンドを受け付けないため、
+	// RESP2で購読用の専用コネクションを使わせる
+	client, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{mr.Addr()},
+		DisableCache: true,
+		AlwaysRESP2:  true,
+	})
+	if err != nil {
+		t.Fatalf("rueidisクライアント作成失敗: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	svc := &DiaryEntry{Redis: client}
+	userID := uuid.New().String()
+	diaryID := uuid.New().String()
+
+	// 接続時点でキュー済み・処理中のタスク
+	mr.Set("task:monthly_summary:"+userID+":2024-5", "processing")
+	mr.Set("task:diary_highlight:"+userID+":"+diaryID, "queued")
+	mr.Set("task:latest_trend:"+userID, "1700000000")
+	// 他ユーザーのタスクは含まれない
+	mr.Set("task:monthly_summary:"+uuid.New().String()+":2024-5", "processing")
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), middleware.UserIDKey, userID))
+	defer cancel()
+
+	received := make(chan *g.TaskEvent, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- svc.StreamTasks(ctx, func(e *g.TaskEvent) error {
+			received <- e
+			return nil
+		})
+	}()
+
+	next := func() *g.TaskEvent {
+		t.Helper()
+		select {
+		case e := <-received:
+			return e
+		case <-time.After(2 * time.Second):
+			t.Fatal("イベントを受信できなかった")
+			return nil
+		}
+	}
+
+	// スナップショットを受信
+	snapshot := map[string]*g.TaskEvent{}
+	for range 3 {
+		e := next()
+		snapshot[e.TaskType] = e
+	}
+	if e := snapshot[taskevent.TypeMonthlySummary]; e == nil || e.Target != "2024-05" || e.Status != g.TaskStatus_TASK_STATUS_PROCESSING {
+		t.Errorf("月次要約のスナップショットが不正: %v", e)
+	}
+	if e := snapshot[taskevent.TypeDiaryHighlight]; e == nil || e.Target != diaryID || e.Status != g.TaskStatus_TASK_STATUS_QUEUED {
+		t.Errorf("ハイライトのスナップショットが不正: %v", e)
+	}
+	if e := snapshot[taskevent.TypeLatestTrend]; e == nil || e.Timestamp != 1700000000 || e.Status != g.TaskStatus_TASK_STATUS_PROCESSING {
+		t.Errorf("トレンド分析のスナップショットが不正: %v", e)
+	}
+
+	// スナップショットは購読の確立後に送られるため、この時点の状態変化は取りこぼさない
+	channel := taskevent.Channel(userID)
+	if n := mr.PubSubNumSub(channel)[channel]; n != 1 {
+		t.Fatalf("スナップショット送信時点で購読が確立されていない: %d", n)
+	}
+
+	event := taskevent.NewEvent(userID, taskevent.TypeMonthlySummary, "2024-05", taskevent.StatusFailed)
+	event.Message = "PROHIBITED_CONTENT"
+	if err := taskevent.Publish(ctx, client, event); err != nil {
+		t.Fatalf("イベント配信失敗: %v", err)
+	}
+
+	got := next()
+	if got.Status != g.TaskStatus_TASK_STATUS_FAILED || got.Target != "2024-05" || got.Message != "PROHIBITED_CONTENT" {
+		t.Errorf("受信イベントが不正: %v", got)
+	}
+
+	// クライアント切断でストリームが正常終了する
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("切断時はnilが期待されるが、実際 %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("切断後もストリームが終了しない")
+	}
+}
+
+func TestDiaryEntry_StreamTasks_Unauthenticated(t *testing.T) {
+	svc := &DiaryEntry{}
+	err := svc.StreamTasks(context.Background(), func(*g.TaskEvent) error { return nil })
+	if err == nil {
+		t.Fatal("認証なしの場合はエラーが期待される")
+	}
+}
+
+func TestToTaskEventProto(t *testing.T) {
+	tests := []struct {
+		status   taskevent.Status
+		expected g.TaskStatus
+	}{
+		{taskevent.StatusQueued, g.TaskStatus_TASK_STATUS_QUEUED},
+		{taskevent.StatusProcessing, g.TaskStatus_TASK_STATUS_PROCESSING},
+		{taskevent.StatusSucceeded, g.TaskStatus_TASK_STATUS_SUCCEEDED},
+		{taskevent.StatusFailed, g.TaskStatus_TASK_STATUS_FAILED},
+		{taskevent.Status("unknown"), g.TaskStatus_TASK_STATUS_UNSPECIFIED},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			got := toTaskEventProto(taskevent.Event{TaskType: taskevent.TypeDiaryEmbedding, Status: tt.status})
+			if got.Status != tt.expected {
+				t.Errorf("期待 %v, 実際 %v", tt.expected, got.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package diary
+
+import (
+	"context"
+	"log"
+
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/webhook"
+)
+
+// diaryWebhookData は日記イベントのWebhookで送信するデータ
+type diaryWebhookData struct {
+	ID      string `json:"id"`
+	Date    string `json:"date"` // YYYY-MM-DD
+	Title   string `json:"title,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// enqueueDiaryWebhook は日記の変更をユーザーのWebhookに配信登録する
+// 通知は補助的な機能のため、失敗しても日記の保存結果には影響させずログに記録するのみ
+func (s *DiaryEntry) enqueueDiaryWebhook(ctx context.Context, event string, diary *database.Diary, includeContent bool) {
+	if s.DB == nil {
+		return
+	}
+	data := diaryWebhookData{
+		ID:   diary.ID.String(),
+		Date: diary.Date.Format("2006-01-02"),
+	}
+	if includeContent {
+		data.Title = diary.Title
+		data.Content = diary.Content
+	}
+	if err := webhook.Enqueue(ctx, s.DB, s.Redis, diary.UserID, event, data); err != nil {
+		log.Printf("Failed to enqueue %s webhook for diary %s: %v", event, diary.ID, err)
+	}
+}
@@ -3,6 +3,7 @@ package entity
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"strings"
 	"time"
 
@@ -15,6 +16,9 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// errEntityVersionConflict は更新の元にしたversionが古い場合にトランザクションを中断するためのエラー
+var errEntityVersionConflict = errors.New("entity version conflict")
+
 type EntityEntry struct {
 	g.UnimplementedEntityServiceServer
 	DB *sql.DB
@@ -105,6 +109,7 @@ func (s *EntityEntry) CreateEntity(
 		CategoryID: int(message.Category),
 		CreatedAt:  currentTime,
 		UpdatedAt:  currentTime,
+		Version:    1,
 	}
 
 	// Memoフィールドの設定
@@ -153,6 +158,7 @@ func (s *EntityEntry) CreateEntity(
 			Aliases:   []*g.EntityAlias{},
 			CreatedAt: entity.CreatedAt,
 			UpdatedAt: entity.UpdatedAt,
+			Version:   entity.Version,
 		},
 	}, nil
 }
@@ -176,7 +182,7 @@ func (s *EntityEntry) UpdateEntity(
 		return nil, status.Errorf(codes.InvalidArgument, "invalid entity ID")
 	}
 
-	entity, err := database.EntityByID(ctx, s.DB, entityID)
+	entity, err := database.ActiveEntityByID(ctx, s.DB, entityID)
 	if err != nil {
 		return nil, err
 	}
@@ -193,9 +199,24 @@ func (s *EntityEntry) UpdateEntity(
 
 	// トランザクション内でエンティティを更新
 	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		// 他のクライアントの更新と入れ違わないよう、行をロックして読み直したversionで比較する
+		current, err := database.EntityByIDForUpdate(ctx, tx, entityID)
+		if err != nil {
+			return err
+		}
+		if current.Trashed() {
+			// 読み直すまでの間にゴミ箱に移動された
+			return sql.ErrNoRows
+		}
+		entity = current
+		if message.ExpectedVersion != nil && entity.Version != message.GetExpectedVersion() {
+			return errEntityVersionConflict
+		}
+
 		entity.Name = message.Name
 		entity.CategoryID = int(message.Category)
 		entity.UpdatedAt = time.Now().Unix()
+		entity.Version++
 
 		// Memoフィールドの更新
 		if message.Memo != "" {
@@ -212,9 +233,10 @@ func (s *EntityEntry) UpdateEntity(
 		}
 		return nil
 	})
-	if err != nil {
+	if err != nil && !errors.Is(err, errEntityVersionConflict) {
 		return nil, err
 	}
+	conflicted := err != nil
 
 	// エイリアスを取得
 	aliases, err := database.EntityAliasesByEntityID(ctx, s.DB, entityID)
@@ -233,16 +255,27 @@ func (s *EntityEntry) UpdateEntity(
 		})
 	}
 
+	protoEntity := &g.Entity{
+		Id:        entity.ID.String(),
+		Name:      entity.Name,
+		Category:  g.EntityCategory(entity.CategoryID),
+		Memo:      entity.Memo.String,
+		Aliases:   protoAliases,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+		Version:   entity.Version,
+	}
+	if conflicted {
+		// サーバー側の現在のエンティティを詳細に含めて返し、クライアントに再編集させる
+		st, err := status.New(codes.Aborted, "entity was updated by another client").WithDetails(protoEntity)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to build conflict error: %v", err)
+		}
+		return nil, st.Err()
+	}
+
 	return &g.UpdateEntityResponse{
-		Entity: &g.Entity{
-			Id:        entity.ID.String(),
-			Name:      entity.Name,
-			Category:  g.EntityCategory(entity.CategoryID),
-			Memo:      entity.Memo.String,
-			Aliases:   protoAliases,
-			CreatedAt: entity.CreatedAt,
-			UpdatedAt: entity.UpdatedAt,
-		},
+		Entity: protoEntity,
 	}, nil
 }
 
@@ -265,7 +298,7 @@ func (s *EntityEntry) DeleteEntity(
 		return nil, status.Errorf(codes.InvalidArgument, "invalid entity ID")
 	}
 
-	entity, err := database.EntityByID(ctx, s.DB, entityID)
+	entity, err := database.ActiveEntityByID(ctx, s.DB, entityID)
 	if err != nil {
 		return nil, err
 	}
@@ -275,9 +308,17 @@ func (s *EntityEntry) DeleteEntity(
 		return nil, status.Errorf(codes.PermissionDenied, "not authorized to delete this entity")
 	}
 
-	// トランザクション内でエンティティを削除
+	// トランザクション内でエンティティをゴミ箱に移動（エイリアスは復元できるよう完全に削除するまで残す）
 	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
-		return entity.Delete(ctx, tx)
+		current, err := database.EntityByIDForUpdate(ctx, tx, entityID)
+		if err != nil {
+			return err
+		}
+		if current.Trashed() {
+			return nil
+		}
+		current.DeletedAt = sql.NullInt64{Int64: time.Now().Unix(), Valid: true}
+		return current.Update(ctx, tx)
 	})
 	if err != nil {
 		return nil, err
@@ -307,7 +348,7 @@ func (s *EntityEntry) GetEntity(
 		return nil, status.Errorf(codes.InvalidArgument, "invalid entity ID")
 	}
 
-	entity, err := database.EntityByID(ctx, s.DB, entityID)
+	entity, err := database.ActiveEntityByID(ctx, s.DB, entityID)
 	if err != nil {
 		return nil, err
 	}
@@ -343,6 +384,7 @@ func (s *EntityEntry) GetEntity(
 			Aliases:   protoAliases,
 			CreatedAt: entity.CreatedAt,
 			UpdatedAt: entity.UpdatedAt,
+			Version:   entity.Version,
 		},
 	}, nil
 }
@@ -362,7 +404,7 @@ func (s *EntityEntry) ListEntities(
 	}
 
 	// ユーザーのエンティティを取得
-	entities, err := database.EntitiesByUserID(ctx, s.DB, userID)
+	entities, err := database.ActiveEntitiesByUserID(ctx, s.DB, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -402,6 +444,7 @@ func (s *EntityEntry) ListEntities(
 			Aliases:   protoAliases,
 			CreatedAt: entity.CreatedAt,
 			UpdatedAt: entity.UpdatedAt,
+			Version:   entity.Version,
 		})
 	}
 
@@ -430,7 +473,7 @@ func (s *EntityEntry) CreateEntityAlias(
 	}
 
 	// エンティティの所有者確認
-	entity, err := database.EntityByID(ctx, s.DB, entityID)
+	entity, err := database.ActiveEntityByID(ctx, s.DB, entityID)
 	if err != nil {
 		return nil, err
 	}
@@ -527,7 +570,7 @@ func (s *EntityEntry) UpdateEntityAlias(
 	}
 
 	// エンティティの所有者確認
-	entity, err := database.EntityByID(ctx, s.DB, alias.EntityID)
+	entity, err := database.ActiveEntityByID(ctx, s.DB, alias.EntityID)
 	if err != nil {
 		return nil, err
 	}
@@ -608,7 +651,7 @@ func (s *EntityEntry) DeleteEntityAlias(
 	}
 
 	// エンティティの所有者確認
-	entity, err := database.EntityByID(ctx, s.DB, alias.EntityID)
+	entity, err := database.ActiveEntityByID(ctx, s.DB, alias.EntityID)
 	if err != nil {
 		return nil, err
 	}
@@ -689,6 +732,7 @@ func (s *EntityEntry) SearchEntities(
 			Aliases:   protoAliases,
 			CreatedAt: entity.CreatedAt,
 			UpdatedAt: entity.UpdatedAt,
+			Version:   entity.Version,
 		})
 	}
 
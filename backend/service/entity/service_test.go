@@ -233,7 +233,7 @@ func TestDeleteEntity(t *testing.T) {
 
 	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID.String())
 
-	t.Run("エンティティ削除時にエイリアスもカスケード削除される", func(t *testing.T) {
+	t.Run("エンティティを削除するとゴミ箱に移動しエイリアスは残る", func(t *testing.T) {
 		// エンティティを削除
 		req := &g.DeleteEntityRequest{
 			Id: entity.ID.String(),
@@ -242,15 +242,23 @@ func TestDeleteEntity(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, resp.Success)
 
-		// エンティティが削除されていることを確認
-		_, err = database.EntityByID(context.Background(), db, entity.ID)
-		assert.Error(t, err)
+		// ゴミ箱にないエンティティとしては取得できないことを確認
+		_, err = database.ActiveEntityByID(context.Background(), db, entity.ID)
 		assert.Equal(t, sql.ErrNoRows, err)
 
-		// エイリアスも削除されていることを確認
+		// 行は復元できるよう残っていることを確認
+		trashed, err := database.EntityByID(context.Background(), db, entity.ID)
+		require.NoError(t, err)
+		assert.True(t, trashed.Trashed())
+
+		// エイリアスも残っていることを確認
 		_, err = database.EntityAliasByID(context.Background(), db, alias.ID)
-		assert.Error(t, err)
-		assert.Equal(t, sql.ErrNoRows, err)
+		assert.NoError(t, err)
+	})
+
+	t.Run("ゴミ箱のエンティティは削除済みとして扱う", func(t *testing.T) {
+		_, err := service.GetEntity(ctx, &g.GetEntityRequest{Id: entity.ID.String()})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
 	})
 
 	t.Run("他のユーザーのエンティティは削除できない", func(t *testing.T) {
@@ -522,6 +530,55 @@ func TestUpdateEntityAlias(t *testing.T) {
 	})
 }
 
+func TestUpdateEntity_ExpectedVersion(t *testing.T) {
+	db := testkit.Setup(t)
+	defer testkit.Teardown(db)
+
+	service := &EntityEntry{DB: db}
+
+	userID := uuid.New()
+	user := &database.User{
+		ID:        userID,
+		Email:     fmt.Sprintf("test-update-entity-version-%s@example.com", userID.String()),
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	}
+	require.NoError(t, user.Insert(context.Background(), db))
+
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID.String())
+	created, err := service.CreateEntity(ctx, &g.CreateEntityRequest{Name: "楽観ロックテスト", Category: g.EntityCategory_PEOPLE})
+	require.NoError(t, err)
+	base := created.Entity
+	assert.Equal(t, int64(1), base.Version)
+
+	// Webで先に更新される
+	webResp, err := service.UpdateEntity(ctx, &g.UpdateEntityRequest{
+		Id: base.Id, Name: base.Name, Category: base.Category, Memo: "Webで追記", ExpectedVersion: &base.Version,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), webResp.Entity.Version)
+
+	t.Run("古いversionでの更新はAbortedで現在のエンティティを返す", func(t *testing.T) {
+		_, err := service.UpdateEntity(ctx, &g.UpdateEntityRequest{
+			Id: base.Id, Name: base.Name, Category: base.Category, Memo: "iOSで追記", ExpectedVersion: &base.Version,
+		})
+		st := status.Convert(err)
+		require.Equal(t, codes.Aborted, st.Code())
+		require.Len(t, st.Details(), 1)
+		current, ok := st.Details()[0].(*g.Entity)
+		require.True(t, ok)
+		assert.Equal(t, "Webで追記", current.Memo)
+		assert.Equal(t, int64(2), current.Version)
+	})
+
+	t.Run("expected_versionを指定しない場合は上書きする", func(t *testing.T) {
+		resp, err := service.UpdateEntity(ctx, &g.UpdateEntityRequest{Id: base.Id, Name: base.Name, Category: base.Category, Memo: "上書き"})
+		require.NoError(t, err)
+		assert.Equal(t, "上書き", resp.Entity.Memo)
+		assert.Equal(t, int64(3), resp.Entity.Version)
+	})
+}
+
 func TestSearchEntities(t *testing.T) {
 	db := testkit.Setup(t)
 	defer testkit.Teardown(db)
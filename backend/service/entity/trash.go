@@ -0,0 +1,138 @@
+package entity
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errTrashedEntityNotFound = status.Error(codes.NotFound, "entity not found in trash")
+
+// checkRestorable はゴミ箱のエンティティを戻しても、名前・エイリアスがゴミ箱にないエンティティと重複しないか確認する
+// ゴミ箱にある間に同じ名前・エイリアスが使われている場合がある
+func checkRestorable(ctx context.Context, tx *sql.Tx, userID uuid.UUID, entity *database.Entity, aliases []*database.EntityAlias) error {
+	entityCount, err := database.CountEntityMatchingAlias(ctx, tx, userID, entity.Name)
+	if err != nil {
+		return err
+	}
+	if entityCount > 0 {
+		return status.Errorf(codes.AlreadyExists, "entity with name '%s' already exists", entity.Name)
+	}
+	aliasCount, err := database.CountAliasMatchingName(ctx, tx, userID, entity.Name)
+	if err != nil {
+		return err
+	}
+	if aliasCount > 0 {
+		return status.Errorf(codes.AlreadyExists, "name '%s' is already used as an alias", entity.Name)
+	}
+
+	for _, alias := range aliases {
+		entityCount, err := database.CountEntityMatchingAlias(ctx, tx, userID, alias.Alias)
+		if err != nil {
+			return err
+		}
+		if entityCount > 0 {
+			return status.Errorf(codes.AlreadyExists, "alias '%s' is already used as an entity name", alias.Alias)
+		}
+		otherAliasCount, err := database.CountAliasDuplicate(ctx, tx, userID, alias.Alias)
+		if err != nil {
+			return err
+		}
+		if otherAliasCount > 0 {
+			return status.Errorf(codes.AlreadyExists, "alias '%s' is already used", alias.Alias)
+		}
+	}
+	return nil
+}
+
+// RestoreEntity ゴミ箱のエンティティを元に戻す
+func (s *EntityEntry) RestoreEntity(
+	ctx context.Context,
+	message *g.RestoreEntityRequest,
+) (*g.RestoreEntityResponse, error) {
+	userIDStr, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	entityID, err := uuid.Parse(message.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid entity ID")
+	}
+
+	var (
+		entity  *database.Entity
+		aliases []*database.EntityAlias
+	)
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		entity, err = database.EntityByIDForUpdate(ctx, tx, entityID)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return errTrashedEntityNotFound
+		case err != nil:
+			return err
+		case entity.UserID != userID || !entity.Trashed():
+			return errTrashedEntityNotFound
+		}
+
+		if aliases, err = database.EntityAliasesByEntityID(ctx, tx, entityID); err != nil {
+			return err
+		}
+		if err := checkRestorable(ctx, tx, userID, entity, aliases); err != nil {
+			return err
+		}
+
+		entity.DeletedAt = sql.NullInt64{}
+		entity.UpdatedAt = time.Now().Unix()
+		if err := entity.Update(ctx, tx); err != nil {
+			// 同時に同じ名前のエンティティが作成された場合
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == "23505" && strings.Contains(pqErr.Message, "entities_user_id_name_key") {
+				return status.Errorf(codes.AlreadyExists, "entity with name '%s' already exists", entity.Name)
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	protoAliases := make([]*g.EntityAlias, 0, len(aliases))
+	for _, alias := range aliases {
+		protoAliases = append(protoAliases, &g.EntityAlias{
+			Id:        alias.ID.String(),
+			EntityId:  alias.EntityID.String(),
+			Alias:     alias.Alias,
+			CreatedAt: alias.CreatedAt,
+			UpdatedAt: alias.UpdatedAt,
+		})
+	}
+
+	return &g.RestoreEntityResponse{
+		Entity: &g.Entity{
+			Id:        entity.ID.String(),
+			Name:      entity.Name,
+			Category:  g.EntityCategory(entity.CategoryID),
+			Memo:      entity.Memo.String,
+			Aliases:   protoAliases,
+			CreatedAt: entity.CreatedAt,
+			UpdatedAt: entity.UpdatedAt,
+			Version:   entity.Version,
+		},
+	}, nil
+}
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"github.com/project-mikan/umi.mikan/backend/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRestoreEntity(t *testing.T) {
+	db := testkit.Setup(t)
+	defer testkit.Teardown(db)
+
+	service := &EntityEntry{DB: db}
+
+	// テスト用ユーザーを作成
+	userID := uuid.New()
+	user := &database.User{
+		ID:        userID,
+		Email:     fmt.Sprintf("test-%s@example.com", userID.String()),
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	}
+	require.NoError(t, user.Insert(context.Background(), db))
+
+	ctx := context.WithValue(context.Background(), middleware.UserIDKey, userID.String())
+
+	created, err := service.CreateEntity(ctx, &g.CreateEntityRequest{
+		Name:     "復元テスト",
+		Category: g.EntityCategory_PEOPLE,
+	})
+	require.NoError(t, err)
+	_, err = service.CreateEntityAlias(ctx, &g.CreateEntityAliasRequest{
+		EntityId: created.Entity.Id,
+		Alias:    "復元テストエイリアス",
+	})
+	require.NoError(t, err)
+
+	t.Run("ゴミ箱にないエンティティは復元できない", func(t *testing.T) {
+		_, err := service.RestoreEntity(ctx, &g.RestoreEntityRequest{Id: created.Entity.Id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	_, err = service.DeleteEntity(ctx, &g.DeleteEntityRequest{Id: created.Entity.Id})
+	require.NoError(t, err)
+
+	t.Run("ゴミ箱にある間は同じ名前のエンティティを作成できる", func(t *testing.T) {
+		sameName, err := service.CreateEntity(ctx, &g.CreateEntityRequest{
+			Name:     "復元テスト",
+			Category: g.EntityCategory_PEOPLE,
+		})
+		require.NoError(t, err)
+
+		// 名前が重複するため復元できない
+		_, err = service.RestoreEntity(ctx, &g.RestoreEntityRequest{Id: created.Entity.Id})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+		_, err = service.DeleteEntity(ctx, &g.DeleteEntityRequest{Id: sameName.Entity.Id})
+		require.NoError(t, err)
+	})
+
+	t.Run("他のユーザーのエンティティは復元できない", func(t *testing.T) {
+		otherUserID := uuid.New()
+		otherCtx := context.WithValue(context.Background(), middleware.UserIDKey, otherUserID.String())
+		_, err := service.RestoreEntity(otherCtx, &g.RestoreEntityRequest{Id: created.Entity.Id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("ゴミ箱のエンティティをエイリアスごと復元できる", func(t *testing.T) {
+		resp, err := service.RestoreEntity(ctx, &g.RestoreEntityRequest{Id: created.Entity.Id})
+		require.NoError(t, err)
+		assert.Equal(t, "復元テスト", resp.Entity.Name)
+		require.Len(t, resp.Entity.Aliases, 1)
+		assert.Equal(t, "復元テストエイリアス", resp.Entity.Aliases[0].Alias)
+
+		got, err := service.GetEntity(ctx, &g.GetEntityRequest{Id: created.Entity.Id})
+		require.NoError(t, err)
+		assert.Equal(t, created.Entity.Id, got.Entity.Id)
+	})
+
+	t.Run("不正なIDの場合はエラー", func(t *testing.T) {
+		_, err := service.RestoreEntity(ctx, &g.RestoreEntityRequest{Id: "invalid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,126 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/accountdeletion"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/emailtoken"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/mailer"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/securityevent"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+)
+
+// DeleteAccount はアカウントの削除を依頼する。すべてのセッション・APIキー・ログインの途中の状態をすぐに取り消し、
+// 猶予期間が過ぎた後にsubscriberがデータをすべて削除する。猶予期間中はメールのリンク（CancelAccountDeletion）で取り消せる（ADR 0037）
+func (s *UserEntry) DeleteAccount(ctx context.Context, req *g.DeleteAccountRequest) (*g.DeleteAccountResponse, error) {
+	// コンテキストからユーザーIDを取得
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return &g.DeleteAccountResponse{
+			Success: false,
+			Message: "unauthorized",
+		}, nil
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return &g.DeleteAccountResponse{
+			Success: false,
+			Message: "invalidUserId",
+		}, nil
+	}
+
+	// ユーザーの存在確認
+	userDB, err := database.UserByID(ctx, s.DB, parsedUserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &g.DeleteAccountResponse{
+				Success: false,
+				Message: "userNotFound",
+			}, nil
+		}
+		return &g.DeleteAccountResponse{
+			Success: false,
+			Message: "updateFailed",
+		}, nil
+	}
+	// アクセストークンの有効期限までは再び呼び出せるため、既に依頼している場合は予定を変えずに返す
+	if userDB.DeletionScheduledAt.Valid {
+		return &g.DeleteAccountResponse{
+			Success:             true,
+			Message:             "accountDeleteSuccess",
+			DeletionScheduledAt: userDB.DeletionScheduledAt.Int64,
+		}, nil
+	}
+
+	now := time.Now()
+	requestedAt := now.Unix()
+	scheduledAt := now.Add(s.DeletionGracePeriod).Unix()
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		if err := database.ScheduleUserDeletion(ctx, tx, parsedUserID, requestedAt, scheduledAt); err != nil {
+			return err
+		}
+		// 発行済みのリフレッシュトークンとAPIキーをすぐに使えなくする
+		if err := database.RevokeUserSessions(ctx, tx, parsedUserID, requestedAt); err != nil {
+			return err
+		}
+		if _, err := database.DeleteUserAPIKeysByUserID(ctx, tx, parsedUserID); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return &g.DeleteAccountResponse{
+			Success: false,
+			Message: "updateFailed",
+		}, nil
+	}
+
+	// ログインの途中の状態はRedisにしかないため、削除を依頼した後は失敗してもログに残すだけにする
+	if s.RedisClient != nil {
+		if err := accountdeletion.RevokeCredentials(ctx, s.RedisClient, parsedUserID); err != nil {
+			log.Printf("Failed to revoke pending credentials for user %s: %v", parsedUserID, err)
+		}
+	}
+	s.recordSecurityEvent(ctx, parsedUserID, securityevent.AccountDeletionRequest, map[string]any{"scheduled_at": scheduledAt})
+	if err := s.sendAccountDeletionCancelEmail(ctx, userDB, requestedAt, scheduledAt, now); err != nil {
+		log.Printf("Failed to send account deletion email to user %s: %v", parsedUserID, err)
+	}
+
+	return &g.DeleteAccountResponse{
+		Success:             true,
+		Message:             "accountDeleteSuccess",
+		DeletionScheduledAt: scheduledAt,
+	}, nil
+}
+
+// sendAccountDeletionCancelEmail は削除を取り消すリンクを送る。
+// 猶予期間がない場合は取り消せないため送らない（削除が完了したときのメールだけを送る）
+func (s *UserEntry) sendAccountDeletionCancelEmail(ctx context.Context, userDB *database.User, requestedAt, scheduledAt int64, now time.Time) error {
+	if s.Mailer == nil || s.EmailTokens == nil || s.DeletionGracePeriod <= 0 {
+		return nil
+	}
+	token, _, err := s.EmailTokens.Sign(emailtoken.PurposeAccountDeletionCancel, userDB.ID.String(), strconv.FormatInt(requestedAt, 10), s.DeletionGracePeriod, now)
+	if err != nil {
+		return err
+	}
+	link := strings.TrimSuffix(s.FrontendBaseURL, "/") + "/cancel-account-deletion?token=" + url.QueryEscape(token)
+	return s.Mailer.Send(ctx, mailer.Message{
+		To:      userDB.Email,
+		Subject: "【umi.mikan】アカウントの削除を受け付けました",
+		Body: fmt.Sprintf("%s さん\n\nアカウントの削除を受け付けました。%s に日記などのデータをすべて削除します。\n"+
+			"それまでにログインすることはできません。削除を取り消す場合は以下のリンクを開いてください。\n%s\n\n"+
+			"心当たりがない場合は、すぐにリンクを開いて削除を取り消し、パスワードを変更してください。\n",
+			userDB.Name, time.Unix(scheduledAt, 0).Format("2006-01-02 15:04"), link),
+	})
+}
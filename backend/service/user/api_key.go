@@ -12,6 +12,7 @@ import (
 	"github.com/project-mikan/umi.mikan/backend/domain/model"
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
 	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/securityevent"
 	"github.com/project-mikan/umi.mikan/backend/middleware"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -82,6 +83,7 @@ func (s *UserEntry) CreateApiKeyForUser(ctx context.Context, userID uuid.UUID, n
 	if err := key.Insert(ctx, s.DB); err != nil {
 		return nil, "", fmt.Errorf("failed to insert api key: %w", err)
 	}
+	s.recordSecurityEvent(ctx, userID, securityevent.APIKeyCreate, map[string]any{"api_key_id": key.ID, "name": key.Name})
 
 	return key, generated.Key, nil
 }
@@ -176,6 +178,7 @@ func (s *UserEntry) DeleteApiKey(ctx context.Context, req *g.DeleteApiKeyRequest
 	if err := key.Delete(ctx, s.DB); err != nil {
 		return nil, status.Error(codes.Internal, "deleteFailed")
 	}
+	s.recordSecurityEvent(ctx, userID, securityevent.APIKeyDelete, map[string]any{"api_key_id": key.ID, "name": key.Name})
 
 	return &g.DeleteApiKeyResponse{
 		Success: true,
@@ -0,0 +1,234 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/backup"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// backupDefaultIntervalHours / backupMaxIntervalHours はバックアップの実行間隔（時間）
+	backupDefaultIntervalHours = 24
+	backupMaxIntervalHours     = 720
+	// backupDefaultRetention / backupMaxRetention は残すバックアップの数
+	backupDefaultRetention = 7
+	backupMaxRetention     = 100
+	// backupPrefixMaxLength は保存先でのキーの接頭辞の最大文字数
+	backupPrefixMaxLength = 200
+	// backupURLMaxLength は保存先のURLの最大文字数
+	backupURLMaxLength = 2048
+	// backupPassphraseMinLength はパスフレーズの最小文字数
+	// パスフレーズを忘れると復号できず、弱いと総当たりされるため、長めの文字列を求める
+	backupPassphraseMinLength = 12
+)
+
+// toBackupStatus はDB行をレスポンス用のBackupStatusに変換する（認証情報は含めない）
+func toBackupStatus(b *database.UserBackupSetting) *g.BackupStatus {
+	resp := &g.BackupStatus{
+		Enabled:        b.Enabled,
+		Destination:    b.Destination,
+		Prefix:         b.Prefix,
+		IntervalHours:  int32(b.IntervalHours),
+		RetentionCount: int32(b.RetentionCount),
+		Encrypted:      backup.Encrypted(b),
+		LastRunAt:      b.LastRunAt.Int64,
+		LastStatus:     b.LastStatus,
+		LastError:      b.LastError,
+		LastSizeBytes:  b.LastSizeBytes,
+		NextRunAt:      b.NextRunAt,
+	}
+	switch b.Destination {
+	case backup.DestinationS3:
+		resp.S3 = &g.S3BackupDestination{
+			Endpoint:     b.S3Endpoint,
+			Region:       b.S3Region,
+			Bucket:       b.S3Bucket,
+			AccessKeyId:  b.S3AccessKeyID,
+			UsePathStyle: b.S3UsePathStyle,
+		}
+	case backup.DestinationWebDAV:
+		resp.Webdav = &g.WebDAVBackupDestination{
+			Url:      b.WebdavURL,
+			Username: b.WebdavUsername,
+		}
+	}
+	return resp
+}
+
+// backupStatus はユーザーの定期バックアップの状態を返す（未設定の場合はnil）
+func (s *UserEntry) backupStatus(ctx context.Context, userID uuid.UUID) (*g.BackupStatus, error) {
+	setting, err := database.UserBackupSettingByUserID(ctx, s.DB, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toBackupStatus(setting), nil
+}
+
+// validateBackupURL は保存先のURLがhttp/httpsの絶対URLかを検証する
+// NASなど同一ネットワーク内の保存先を想定し、プライベートアドレスは許可する
+func validateBackupURL(rawURL string) bool {
+	if rawURL == "" || len(rawURL) > backupURLMaxLength {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.User == nil
+}
+
+// normalizeBackupPrefix は接頭辞の前後のスラッシュを取り除き、"a/b" 形式の相対パスかを検証する
+func normalizeBackupPrefix(prefix string) (string, bool) {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return "", true
+	}
+	if len(prefix) > backupPrefixMaxLength || strings.Contains(prefix, "\\") {
+		return "", false
+	}
+	for _, part := range strings.Split(prefix, "/") {
+		if part == "" || part == "." || part == ".." {
+			return "", false
+		}
+	}
+	return prefix, true
+}
+
+// applyBackupDestination はリクエストの保存先の設定をsettingに反映する
+// 認証情報は空の場合、以前と同じ保存先であれば以前の値を使う
+func (s *UserEntry) applyBackupDestination(setting *database.UserBackupSetting, req *g.UpdateBackupSettingsRequest) error {
+	switch req.GetDestination() {
+	case backup.DestinationLocal:
+		if s.BackupLocalDir == "" {
+			return status.Error(codes.FailedPrecondition, "backupDestinationUnavailable")
+		}
+	case backup.DestinationS3:
+		s3 := req.GetS3()
+		if !validateBackupURL(s3.GetEndpoint()) || s3.GetBucket() == "" || s3.GetAccessKeyId() == "" {
+			return status.Error(codes.InvalidArgument, "invalidS3Settings")
+		}
+		secret := s3.GetSecretAccessKey()
+		if secret == "" && setting.Destination == backup.DestinationS3 && setting.S3Endpoint == s3.GetEndpoint() && setting.S3Bucket == s3.GetBucket() {
+			secret = setting.S3SecretAccessKey
+		}
+		if secret == "" {
+			return status.Error(codes.InvalidArgument, "invalidS3Settings")
+		}
+		setting.S3Endpoint = s3.GetEndpoint()
+		setting.S3Region = s3.GetRegion()
+		setting.S3Bucket = s3.GetBucket()
+		setting.S3AccessKeyID = s3.GetAccessKeyId()
+		setting.S3SecretAccessKey = secret
+		setting.S3UsePathStyle = s3.GetUsePathStyle()
+	case backup.DestinationWebDAV:
+		webdav := req.GetWebdav()
+		if !validateBackupURL(webdav.GetUrl()) {
+			return status.Error(codes.InvalidArgument, "invalidWebdavSettings")
+		}
+		password := webdav.GetPassword()
+		if password == "" && setting.Destination == backup.DestinationWebDAV && setting.WebdavURL == webdav.GetUrl() {
+			password = setting.WebdavPassword
+		}
+		setting.WebdavURL = webdav.GetUrl()
+		setting.WebdavUsername = webdav.GetUsername()
+		setting.WebdavPassword = password
+	default:
+		return status.Error(codes.InvalidArgument, "invalidBackupDestination")
+	}
+
+	// 使わなくなった保存先の認証情報は残さない
+	if req.GetDestination() != backup.DestinationS3 {
+		setting.S3Endpoint, setting.S3Region, setting.S3Bucket = "", "", ""
+		setting.S3AccessKeyID, setting.S3SecretAccessKey, setting.S3UsePathStyle = "", "", false
+	}
+	if req.GetDestination() != backup.DestinationWebDAV {
+		setting.WebdavURL, setting.WebdavUsername, setting.WebdavPassword = "", "", ""
+	}
+	setting.Destination = req.GetDestination()
+	return nil
+}
+
+// UpdateBackupSettings は定期バックアップの設定を登録・更新する
+func (s *UserEntry) UpdateBackupSettings(ctx context.Context, req *g.UpdateBackupSettingsRequest) (*g.UpdateBackupSettingsResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	intervalHours := int(req.GetIntervalHours())
+	if intervalHours == 0 {
+		intervalHours = backupDefaultIntervalHours
+	}
+	if intervalHours < 1 || intervalHours > backupMaxIntervalHours {
+		return nil, status.Error(codes.InvalidArgument, "invalidBackupInterval")
+	}
+	retention := int(req.GetRetentionCount())
+	if retention == 0 {
+		retention = backupDefaultRetention
+	}
+	if retention < 1 || retention > backupMaxRetention {
+		return nil, status.Error(codes.InvalidArgument, "invalidBackupRetention")
+	}
+	prefix, ok := normalizeBackupPrefix(req.GetPrefix())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalidBackupPrefix")
+	}
+	if req.GetPassphrase() != "" && !req.GetDisableEncryption() && utf8.RuneCountInString(req.GetPassphrase()) < backupPassphraseMinLength {
+		return nil, status.Error(codes.InvalidArgument, "passphraseTooShort")
+	}
+
+	now := time.Now().Unix()
+	setting, err := database.UserBackupSettingByUserID(ctx, s.DB, userID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.Internal, "updateBackupSettingsFailed")
+		}
+		setting = &database.UserBackupSetting{UserID: userID, CreatedAt: now}
+	}
+	if err := s.applyBackupDestination(setting, req); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case req.GetDisableEncryption():
+		setting.EncryptionPublicKey, setting.EncryptionSalt = nil, nil
+	case req.GetPassphrase() != "":
+		// パスフレーズは保存せず、暗号化に使う公開鍵だけを保存する
+		recipient, err := backup.NewRecipient(req.GetPassphrase())
+		if err != nil {
+			return nil, status.Error(codes.Internal, "updateBackupSettingsFailed")
+		}
+		setting.EncryptionPublicKey, setting.EncryptionSalt = recipient.PublicKey, recipient.Salt
+	}
+
+	// 次回の実行は前回の実行から間隔を空ける（未実行・無効から有効にした場合は次の確認時に実行する）
+	nextRunAt := now
+	if setting.Enabled && setting.LastRunAt.Valid {
+		nextRunAt = max(now, setting.LastRunAt.Int64+int64(intervalHours)*3600)
+	}
+	setting.Enabled = req.GetEnabled()
+	setting.Prefix = prefix
+	setting.IntervalHours = intervalHours
+	setting.RetentionCount = retention
+	setting.NextRunAt = nextRunAt
+	setting.UpdatedAt = now
+	if err := setting.Save(ctx, s.DB); err != nil {
+		return nil, status.Error(codes.Internal, "updateBackupSettingsFailed")
+	}
+
+	return &g.UpdateBackupSettingsResponse{Backup: toBackupStatus(setting)}, nil
+}
@@ -0,0 +1,115 @@
+package user
+
+import (
+	"testing"
+
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNormalizeBackupPrefix(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		expected string
+		ok       bool
+	}{
+		{"", "", true},
+		{"/umi-mikan/backups/", "umi-mikan/backups", true},
+		{"umi/../other", "", false},
+		{"umi//backups", "", false},
+		{"umi\\backups", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			got, ok := normalizeBackupPrefix(tt.prefix)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("期待 (%q, %v), 実際 (%q, %v)", tt.expected, tt.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestUserEntry_UpdateBackupSettings(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "backup-settings@example.com", "BackupSettingsUser")
+	svc := &UserEntry{DB: db}
+	ctx := testutil.CreateAuthenticatedContext(userID)
+
+	webdavReq := func() *g.UpdateBackupSettingsRequest {
+		return &g.UpdateBackupSettingsRequest{
+			Enabled:     true,
+			Destination: "webdav",
+			Webdav:      &g.WebDAVBackupDestination{Url: "https://cloud.example.com/dav/", Username: "me", Password: "secret"},
+			Passphrase:  "correct horse battery staple",
+		}
+	}
+
+	t.Run("正常系: 登録した設定をGetUserInfoで認証情報を除いて返す", func(t *testing.T) {
+		resp, err := svc.UpdateBackupSettings(ctx, webdavReq())
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if !resp.Backup.Encrypted || resp.Backup.IntervalHours != backupDefaultIntervalHours || resp.Backup.RetentionCount != backupDefaultRetention {
+			t.Errorf("設定が不正: %+v", resp.Backup)
+		}
+
+		info, err := svc.GetUserInfo(ctx, &g.GetUserInfoRequest{})
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if info.Backup == nil || info.Backup.Webdav.GetUrl() != "https://cloud.example.com/dav/" {
+			t.Fatalf("バックアップの状態が返されていない: %+v", info.Backup)
+		}
+		if info.Backup.Webdav.GetPassword() != "" {
+			t.Error("パスワードがレスポンスに含まれている")
+		}
+	})
+
+	t.Run("正常系: パスワードを省略した場合は以前の値を使う", func(t *testing.T) {
+		req := webdavReq()
+		req.Webdav.Password = ""
+		req.Passphrase = ""
+		if _, err := svc.UpdateBackupSettings(ctx, req); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		var password string
+		var encrypted bool
+		if err := db.QueryRow("SELECT webdav_password, encryption_public_key IS NOT NULL FROM user_backup_settings WHERE user_id = $1", userID).Scan(&password, &encrypted); err != nil {
+			t.Fatalf("設定の取得に失敗: %v", err)
+		}
+		if password != "secret" || !encrypted {
+			t.Errorf("以前の設定が保持されていない: password=%q encrypted=%v", password, encrypted)
+		}
+	})
+
+	t.Run("異常系: 不正な設定はエラー", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			modify func(*g.UpdateBackupSettingsRequest)
+			code   codes.Code
+		}{
+			{"未対応の保存先", func(r *g.UpdateBackupSettingsRequest) { r.Destination = "ftp" }, codes.InvalidArgument},
+			{"http/https以外のURL", func(r *g.UpdateBackupSettingsRequest) { r.Webdav.Url = "file:///etc" }, codes.InvalidArgument},
+			{"範囲外の間隔", func(r *g.UpdateBackupSettingsRequest) { r.IntervalHours = backupMaxIntervalHours + 1 }, codes.InvalidArgument},
+			{"範囲外の保持数", func(r *g.UpdateBackupSettingsRequest) { r.RetentionCount = backupMaxRetention + 1 }, codes.InvalidArgument},
+			{"短いパスフレーズ", func(r *g.UpdateBackupSettingsRequest) { r.Passphrase = "short" }, codes.InvalidArgument},
+			{"シークレットのないS3", func(r *g.UpdateBackupSettingsRequest) {
+				r.Destination = "s3"
+				r.S3 = &g.S3BackupDestination{Endpoint: "https://s3.example.com", Bucket: "b", AccessKeyId: "a"}
+			}, codes.InvalidArgument},
+			{"サーバーにローカルの保存先がない", func(r *g.UpdateBackupSettingsRequest) { r.Destination = "local" }, codes.FailedPrecondition},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := webdavReq()
+				tt.modify(req)
+				_, err := svc.UpdateBackupSettings(ctx, req)
+				if st, ok := status.FromError(err); !ok || st.Code() != tt.code {
+					t.Errorf("%v エラーを期待したが: %v", tt.code, err)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,199 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/requestid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/storage"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/takeout"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/taskevent"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// dataExportListLimit はListDataExportsで返す件数
+	dataExportListLimit = 20
+	// dataExportDownloadChunkSize はダウンロード時に1メッセージで送るバイト数
+	dataExportDownloadChunkSize = 256 * 1024
+)
+
+var errDataExportNotFound = status.Error(codes.NotFound, "dataExportNotFound")
+
+// toDataExport はDB行をレスポンス用のDataExportに変換する
+func toDataExport(e *database.UserDataExport) *g.DataExport {
+	return &g.DataExport{
+		Id:           e.ID.String(),
+		Status:       e.Status,
+		SizeBytes:    e.SizeBytes,
+		ErrorMessage: e.ErrorMessage,
+		CreatedAt:    e.CreatedAt,
+		CompletedAt:  e.CompletedAt.Int64,
+		ExpiresAt:    e.ExpiresAt.Int64,
+	}
+}
+
+// authenticatedUserID は認証ユーザーのIDを返す
+func authenticatedUserID(ctx context.Context) (uuid.UUID, error) {
+	userIDStr, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "invalidUserId")
+	}
+	return userID, nil
+}
+
+// RequestDataExport は個人データのアーカイブの作成をsubscriberに依頼する
+// 作成中のエクスポートがある場合は、同じ内容のアーカイブを重複して作らないよう受け付けない
+func (s *UserEntry) RequestDataExport(ctx context.Context, _ *g.RequestDataExportRequest) (*g.RequestDataExportResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.Storage == nil || s.RedisClient == nil {
+		return nil, status.Error(codes.FailedPrecondition, "dataExportUnavailable")
+	}
+
+	now := time.Now()
+	export := &database.UserDataExport{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    database.DataExportStatusQueued,
+		CreatedAt: now.Unix(),
+		UpdatedAt: now.Unix(),
+	}
+	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
+		if err := database.LockDataExportRequest(ctx, tx, userID); err != nil {
+			return err
+		}
+		inProgress, err := database.InProgressDataExportCountByUserID(ctx, tx, userID, now.Add(-takeout.StaleAfter).Unix())
+		if err != nil {
+			return err
+		}
+		if inProgress > 0 {
+			return status.Error(codes.FailedPrecondition, "dataExportInProgress")
+		}
+		return export.Insert(ctx, tx)
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, "createFailed")
+	}
+
+	message, err := json.Marshal(takeout.Message{Type: takeout.MessageType, UserID: userID.String(), ExportID: export.ID.String(), RequestID: requestid.FromContext(ctx)})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "createFailed")
+	}
+	publishCmd := s.RedisClient.B().Publish().Channel("diary_events").Message(string(message)).Build()
+	if err := s.RedisClient.Do(ctx, publishCmd).Error(); err != nil {
+		// キューに追加できなかった行は作成されないため、失敗として残す（期限を過ぎるとスケジューラーが削除する）
+		export.Status = database.DataExportStatusFailed
+		export.ErrorMessage = "failed to queue data export"
+		export.ExpiresAt = sql.NullInt64{Int64: now.Add(takeout.StaleAfter).Unix(), Valid: true}
+		if updateErr := export.Update(context.WithoutCancel(ctx), s.DB); updateErr != nil {
+			log.Printf("Failed to mark data export %s as failed: %v", export.ID, updateErr)
+		}
+		return nil, status.Error(codes.Internal, "queueFailed")
+	}
+
+	event := taskevent.NewEvent(userID.String(), taskevent.TypeDataExport, export.ID.String(), taskevent.StatusQueued)
+	if err := taskevent.Publish(ctx, s.RedisClient, event); err != nil {
+		log.Printf("Failed to publish data export queued event for user %s: %v", userID, err)
+	}
+
+	return &g.RequestDataExportResponse{Export: toDataExport(export)}, nil
+}
+
+// ListDataExports は依頼したデータエクスポートを新しい順に返す
+func (s *UserEntry) ListDataExports(ctx context.Context, _ *g.ListDataExportsRequest) (*g.ListDataExportsResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exports, err := database.RecentDataExportsByUserID(ctx, s.DB, userID, dataExportListLimit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "listFailed")
+	}
+	resp := &g.ListDataExportsResponse{Exports: make([]*g.DataExport, 0, len(exports))}
+	for _, e := range exports {
+		resp.Exports = append(resp.Exports, toDataExport(e))
+	}
+	return resp, nil
+}
+
+func (s *UserEntry) DownloadDataExport(req *g.DownloadDataExportRequest, stream g.UserService_DownloadDataExportServer) error {
+	return s.SendDataExport(stream.Context(), req, stream.Send)
+}
+
+// SendDataExport は作成済みのアーカイブの情報と内容をsendに渡す
+// gRPCとConnectRPCでストリームの型が異なるため、送信処理を関数で受け取る
+func (s *UserEntry) SendDataExport(ctx context.Context, req *g.DownloadDataExportRequest, send func(*g.DownloadDataExportResponse) error) error {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+	if s.Storage == nil {
+		return status.Error(codes.FailedPrecondition, "dataExportUnavailable")
+	}
+	exportID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalidDataExportId")
+	}
+	export, err := database.UserDataExportByID(ctx, s.DB, exportID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return errDataExportNotFound
+	case err != nil:
+		return status.Error(codes.Internal, "getDataExportFailed")
+	case export.UserID != userID:
+		// 他ユーザーのエクスポートは存在を悟らせないためNotFoundを返す
+		return errDataExportNotFound
+	case export.Status != database.DataExportStatusSucceeded:
+		return status.Error(codes.FailedPrecondition, "dataExportNotReady")
+	case export.ExpiresAt.Valid && export.ExpiresAt.Int64 <= time.Now().Unix():
+		return errDataExportNotFound
+	}
+
+	r, err := s.Storage.Get(ctx, export.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return errDataExportNotFound
+		}
+		return status.Error(codes.Internal, "getDataExportFailed")
+	}
+	defer func() { _ = r.Close() }()
+
+	if err := send(&g.DownloadDataExportResponse{Payload: &g.DownloadDataExportResponse_Export{Export: toDataExport(export)}}); err != nil {
+		return err
+	}
+	chunk := make([]byte, dataExportDownloadChunkSize)
+	for {
+		n, err := io.ReadFull(r, chunk)
+		if n > 0 {
+			if sendErr := send(&g.DownloadDataExportResponse{Payload: &g.DownloadDataExportResponse_Chunk{Chunk: chunk[:n]}}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, "getDataExportFailed")
+		}
+	}
+}
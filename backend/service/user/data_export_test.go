@@ -0,0 +1,133 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/storage"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/takeout"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUserEntry_DataExports(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "data-export-service@example.com", "DataExportServiceUser")
+	otherUserID := testutil.CreateTestUser(t, db, "data-export-service-other@example.com", "DataExportServiceOtherUser")
+	st, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("ストレージの作成に失敗: %v", err)
+	}
+	svc := &UserEntry{DB: db, Storage: st}
+	ctx := testutil.CreateAuthenticatedContext(userID)
+	otherCtx := testutil.CreateAuthenticatedContext(otherUserID)
+
+	now := time.Now()
+	insertExport := func(exportStatus string, expiresAt int64, content string) *database.UserDataExport {
+		t.Helper()
+		e := &database.UserDataExport{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Status:    exportStatus,
+			ExpiresAt: sql.NullInt64{Int64: expiresAt, Valid: expiresAt != 0},
+			CreatedAt: now.Unix(),
+			UpdatedAt: now.Unix(),
+		}
+		if content != "" {
+			e.StorageKey = takeout.StorageKey(userID, e.ID)
+			e.SizeBytes = int64(len(content))
+			if err := st.Put(context.Background(), e.StorageKey, strings.NewReader(content), e.SizeBytes, takeout.ContentType); err != nil {
+				t.Fatalf("アーカイブの保存に失敗: %v", err)
+			}
+		}
+		if err := e.Insert(context.Background(), db); err != nil {
+			t.Fatalf("データエクスポートの作成に失敗: %v", err)
+		}
+		return e
+	}
+	content := strings.Repeat("z", dataExportDownloadChunkSize+10)
+	succeeded := insertExport(database.DataExportStatusSucceeded, now.Add(time.Hour).Unix(), content)
+	queued := insertExport(database.DataExportStatusQueued, 0, "")
+	expired := insertExport(database.DataExportStatusSucceeded, now.Add(-time.Hour).Unix(), "old")
+
+	download := func(ctx context.Context, id string) (*g.DataExport, []byte, error) {
+		var info *g.DataExport
+		var buf bytes.Buffer
+		err := svc.SendDataExport(ctx, &g.DownloadDataExportRequest{Id: id}, func(resp *g.DownloadDataExportResponse) error {
+			if e := resp.GetExport(); e != nil {
+				info = e
+			}
+			buf.Write(resp.GetChunk())
+			return nil
+		})
+		return info, buf.Bytes(), err
+	}
+
+	t.Run("正常系: 作成済みのアーカイブを分割してダウンロードできる", func(t *testing.T) {
+		info, got, err := download(ctx, succeeded.ID.String())
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if info.GetId() != succeeded.ID.String() || info.GetSizeBytes() != int64(len(content)) {
+			t.Errorf("エクスポートの情報が不正: %+v", info)
+		}
+		if string(got) != content {
+			t.Errorf("内容が一致しない: 期待 %d bytes, 実際 %d bytes", len(content), len(got))
+		}
+	})
+
+	t.Run("異常系: ダウンロードできない場合", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			ctx      context.Context
+			id       string
+			expected codes.Code
+		}{
+			{"他ユーザーのエクスポート", otherCtx, succeeded.ID.String(), codes.NotFound},
+			{"作成中のエクスポート", ctx, queued.ID.String(), codes.FailedPrecondition},
+			{"期限切れのエクスポート", ctx, expired.ID.String(), codes.NotFound},
+			{"存在しないエクスポート", ctx, uuid.New().String(), codes.NotFound},
+			{"不正なID", ctx, "invalid", codes.InvalidArgument},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, _, err := download(tt.ctx, tt.id)
+				if status.Code(err) != tt.expected {
+					t.Errorf("期待 %v, 実際 %v", tt.expected, err)
+				}
+			})
+		}
+	})
+
+	t.Run("正常系: 自分のエクスポートのみ一覧に含まれる", func(t *testing.T) {
+		resp, err := svc.ListDataExports(ctx, &g.ListDataExportsRequest{})
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(resp.Exports) != 3 {
+			t.Errorf("件数: 期待 3件, 実際 %d件", len(resp.Exports))
+		}
+		resp, err = svc.ListDataExports(otherCtx, &g.ListDataExportsRequest{})
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(resp.Exports) != 0 {
+			t.Errorf("他ユーザーのエクスポートが含まれている: %v", resp.Exports)
+		}
+	})
+
+	t.Run("異常系: Redisが未設定の場合は依頼できない", func(t *testing.T) {
+		_, err := svc.RequestDataExport(ctx, &g.RequestDataExportRequest{})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("期待 FailedPrecondition, 実際 %v", err)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/securityevent"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	securityEventsDefaultLimit = 50
+	securityEventsMaxLimit     = 200
+)
+
+func (s *UserEntry) ListSecurityEvents(ctx context.Context, req *g.ListSecurityEventsRequest) (*g.ListSecurityEventsResponse, error) {
+	userIDStr, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalidUserId")
+	}
+
+	if req.GetEventType() != "" {
+		if _, ok := securityevent.ParseType(req.GetEventType()); !ok {
+			return nil, status.Error(codes.InvalidArgument, "invalidEventType")
+		}
+	}
+	// カーソルは最後に返したイベントの "作成日時_ID"
+	beforeCreatedAt, beforeID := int64(math.MaxInt64), uuid.Max
+	if req.GetCursor() != "" {
+		createdAtStr, idStr, ok := strings.Cut(req.GetCursor(), "_")
+		createdAt, parseErr := strconv.ParseInt(createdAtStr, 10, 64)
+		id, idErr := uuid.Parse(idStr)
+		if !ok || parseErr != nil || idErr != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalidCursor")
+		}
+		beforeCreatedAt, beforeID = createdAt, id
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = securityEventsDefaultLimit
+	}
+	limit = min(limit, securityEventsMaxLimit)
+
+	events, err := database.SecurityEventsBefore(ctx, s.DB, userID, req.GetEventType(), beforeCreatedAt, beforeID, limit+1)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "listFailed")
+	}
+	hasMore := len(events) > limit
+	if hasMore {
+		events = events[:limit]
+	}
+
+	resp := &g.ListSecurityEventsResponse{Events: make([]*g.SecurityEvent, 0, len(events)), HasMore: hasMore}
+	for _, e := range events {
+		resp.Events = append(resp.Events, &g.SecurityEvent{
+			Id:         e.ID.String(),
+			EventType:  e.EventType,
+			IpAddress:  e.IPAddress,
+			UserAgent:  e.UserAgent,
+			Detail:     string(e.Detail),
+			Suspicious: e.Suspicious,
+			CreatedAt:  e.CreatedAt,
+		})
+	}
+	if len(events) > 0 {
+		last := events[len(events)-1]
+		resp.NextCursor = fmt.Sprintf("%d_%s", last.CreatedAt, last.ID)
+	}
+	return resp, nil
+}
+
+// recordSecurityEvent はセキュリティイベントを記録する。操作は完了しているため、記録に失敗してもエラーにせずログに残す
+func (s *UserEntry) recordSecurityEvent(ctx context.Context, userID uuid.UUID, eventType securityevent.Type, detail map[string]any) {
+	if err := securityevent.Record(ctx, s.DB, userID, eventType, detail); err != nil {
+		log.Printf("failed to record security event %s for user %s: %v", eventType, userID, err)
+	}
+}
@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/securityevent"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+)
+
+func TestUserEntry_ListSecurityEvents(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	userID := testutil.CreateTestUser(t, db, "security-event-list@example.com", "SecurityEventListUser")
+	otherUserID := testutil.CreateTestUser(t, db, "security-event-list-other@example.com", "SecurityEventListOtherUser")
+	svc := &UserEntry{DB: db}
+	ctx := testutil.CreateAuthenticatedContext(userID)
+
+	for range 3 {
+		if err := securityevent.Record(ctx, db, userID, securityevent.TokenRefresh, nil); err != nil {
+			t.Fatalf("イベントの記録に失敗: %v", err)
+		}
+	}
+	if err := securityevent.Record(ctx, db, userID, securityevent.PasswordChange, map[string]any{"method": "change"}); err != nil {
+		t.Fatalf("イベントの記録に失敗: %v", err)
+	}
+	if err := securityevent.Record(ctx, db, otherUserID, securityevent.TokenRefresh, nil); err != nil {
+		t.Fatalf("イベントの記録に失敗: %v", err)
+	}
+
+	t.Run("正常系: 自分のイベントだけを新しい順に返す", func(t *testing.T) {
+		resp, err := svc.ListSecurityEvents(ctx, &g.ListSecurityEventsRequest{})
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(resp.Events) != 4 {
+			t.Fatalf("期待件数 4 に対して %d 件", len(resp.Events))
+		}
+		if resp.HasMore {
+			t.Error("HasMoreがtrue")
+		}
+		for i := 1; i < len(resp.Events); i++ {
+			if resp.Events[i-1].CreatedAt < resp.Events[i].CreatedAt {
+				t.Errorf("新しい順になっていない: %d, %d", resp.Events[i-1].CreatedAt, resp.Events[i].CreatedAt)
+			}
+		}
+	})
+
+	t.Run("正常系: 種類で絞り込む", func(t *testing.T) {
+		resp, err := svc.ListSecurityEvents(ctx, &g.ListSecurityEventsRequest{EventType: string(securityevent.PasswordChange)})
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(resp.Events) != 1 || !strings.Contains(resp.Events[0].Detail, `"change"`) {
+			t.Fatalf("絞り込みの結果が不正: %v", resp.Events)
+		}
+	})
+
+	t.Run("正常系: カーソルで続きを取得する", func(t *testing.T) {
+		seen := map[string]bool{}
+		cursor := ""
+		for page := 0; ; page++ {
+			resp, err := svc.ListSecurityEvents(ctx, &g.ListSecurityEventsRequest{Cursor: cursor, Limit: 3})
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			for _, e := range resp.Events {
+				if seen[e.Id] {
+					t.Errorf("イベント %s が重複した", e.Id)
+				}
+				seen[e.Id] = true
+			}
+			if !resp.HasMore {
+				break
+			}
+			if page > 2 {
+				t.Fatal("ページングが終わらない")
+			}
+			cursor = resp.NextCursor
+		}
+		if len(seen) != 4 {
+			t.Errorf("期待件数 4 に対して %d 件", len(seen))
+		}
+	})
+
+	errorCases := []struct {
+		name string
+		ctx  context.Context
+		req  *g.ListSecurityEventsRequest
+	}{
+		{"異常系: 未定義の種類はエラー", ctx, &g.ListSecurityEventsRequest{EventType: "unknown"}},
+		{"異常系: 不正なカーソルはエラー", ctx, &g.ListSecurityEventsRequest{Cursor: "invalid"}},
+		{"異常系: 未認証の場合はエラー", context.Background(), &g.ListSecurityEventsRequest{}},
+		{"異常系: ユーザーIDがUUID形式でない場合はエラー", context.WithValue(context.Background(), middleware.UserIDKey, "not-a-uuid"), &g.ListSecurityEventsRequest{}},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := svc.ListSecurityEvents(tc.ctx, tc.req); err == nil {
+				t.Fatal("エラーを期待したがnilが返った")
+			}
+		})
+	}
+}
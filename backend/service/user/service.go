@@ -12,7 +12,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/project-mikan/umi.mikan/backend/domain/request"
 	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/emailtoken"
 	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/mailer"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/securityevent"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/storage"
 	"github.com/project-mikan/umi.mikan/backend/middleware"
 	"github.com/redis/rueidis"
 	"google.golang.org/grpc/codes"
@@ -23,6 +28,22 @@ type UserEntry struct {
 	g.UnimplementedUserServiceServer
 	DB          *sql.DB
 	RedisClient rueidis.Client
+	// Storage は添付ファイル・データエクスポートの保存先（nilの場合はデータエクスポートを使えない）
+	Storage storage.Storage
+	// BackupLocalDir は定期バックアップのローカルの保存先（空の場合はローカルを保存先に選べない）
+	BackupLocalDir string
+	// Mailer はアカウントの削除を取り消すリンクの送信（nilの場合は送らない）
+	Mailer mailer.Mailer
+	// EmailTokens はアカウントの削除を取り消すリンクのトークンの署名
+	EmailTokens *emailtoken.Signer
+	// FrontendBaseURL はメールに載せるリンクのベースURL
+	FrontendBaseURL string
+	// DeletionGracePeriod はアカウントの削除を依頼してからデータを完全に削除するまでの期間
+	DeletionGracePeriod time.Duration
+	// RPCLimiter はRPCごとのレート制限（GetUsageで使用状況を返す。nilの場合は制限がない）
+	RPCLimiter *ratelimiter.RPCLimiter
+	// WebhookAllowPrivateNetworks はWebhookの通知先にプライベートネットワークのアドレスを許可するか
+	WebhookAllowPrivateNetworks bool
 }
 
 func (s *UserEntry) UpdateUserName(ctx context.Context, req *g.UpdateUserNameRequest) (*g.UpdateUserNameResponse, error) {
@@ -147,6 +168,7 @@ func (s *UserEntry) ChangePassword(ctx context.Context, req *g.ChangePasswordReq
 
 	// パスワードを更新
 	passwordAuthDB.PasswordHashed = hashedNewPassword
+	passwordAuthDB.ResetRequired = false // 管理者が求めたパスワードの再設定はこの変更で完了する
 	passwordAuthDB.UpdatedAt = time.Now().Unix()
 
 	if err := passwordAuthDB.Update(ctx, s.DB); err != nil {
@@ -155,6 +177,7 @@ func (s *UserEntry) ChangePassword(ctx context.Context, req *g.ChangePasswordReq
 			Message: "updateFailed",
 		}, nil
 	}
+	s.recordSecurityEvent(ctx, parsedUserID, securityevent.PasswordChange, map[string]any{"method": "change"})
 
 	return &g.ChangePasswordResponse{
 		Success: true,
@@ -284,13 +307,22 @@ func (s *UserEntry) GetUserInfo(ctx context.Context, req *g.GetUserInfoRequest)
 			AutoSummaryMonthly:     userLLM.AutoSummaryMonthly,
 			AutoLatestTrendEnabled: userLLM.AutoLatestTrendEnabled,
 			SemanticSearchEnabled:  userLLM.SemanticSearchEnabled,
+			AutoTaggingEnabled:     userLLM.AutoTaggingEnabled,
 		})
 	}
 
+	// 定期バックアップの設定と実行結果を取得（未設定の場合はnil）
+	backupStatus, err := s.backupStatus(ctx, parsedUserID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get backup settings: %v", err)
+	}
+
 	return &g.GetUserInfoResponse{
-		Name:    userDB.Name,
-		Email:   userDB.Email,
-		LlmKeys: llmKeys,
+		Name:          userDB.Name,
+		Email:         userDB.Email,
+		LlmKeys:       llmKeys,
+		Backup:        backupStatus,
+		EmailVerified: userDB.EmailVerifiedAt.Valid,
 	}, nil
 }
 
@@ -349,76 +381,6 @@ func (s *UserEntry) DeleteLLMKey(ctx context.Context, req *g.DeleteLLMKeyRequest
 	}, nil
 }
 
-func (s *UserEntry) DeleteAccount(ctx context.Context, req *g.DeleteAccountRequest) (*g.DeleteAccountResponse, error) {
-	// コンテキストからユーザーIDを取得
-	userID, err := middleware.GetUserIDFromContext(ctx)
-	if err != nil {
-		return &g.DeleteAccountResponse{
-			Success: false,
-			Message: "unauthorized",
-		}, nil
-	}
-
-	parsedUserID, err := uuid.Parse(userID)
-	if err != nil {
-		return &g.DeleteAccountResponse{
-			Success: false,
-			Message: "invalidUserId",
-		}, nil
-	}
-
-	// ユーザーの存在確認
-	userDB, err := database.UserByID(ctx, s.DB, parsedUserID)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return &g.DeleteAccountResponse{
-				Success: false,
-				Message: "userNotFound",
-			}, nil
-		}
-		return &g.DeleteAccountResponse{
-			Success: false,
-			Message: "updateFailed",
-		}, nil
-	}
-
-	// トランザクション内で関連データを削除
-	err = database.RwTransaction(ctx, s.DB, func(tx *sql.Tx) error {
-		// 1. 日記データを削除
-		if err := database.DeleteDiariesByUserID(ctx, tx, parsedUserID); err != nil {
-			return err
-		}
-
-		// 2. LLMトークンを削除
-		if err := database.DeleteUserLLMsByUserID(ctx, tx, parsedUserID); err != nil {
-			return err
-		}
-
-		// 3. パスワード認証を削除
-		if err := database.DeleteUserPasswordAuthesByUserID(ctx, tx, parsedUserID); err != nil {
-			return err
-		}
-
-		// 4. ユーザーを削除
-		if err := userDB.Delete(ctx, tx); err != nil {
-			return fmt.Errorf("failed to delete user: %w", err)
-		}
-
-		return nil
-	})
-	if err != nil {
-		return &g.DeleteAccountResponse{
-			Success: false,
-			Message: "updateFailed",
-		}, nil
-	}
-
-	return &g.DeleteAccountResponse{
-		Success: true,
-		Message: "accountDeleteSuccess",
-	}, nil
-}
-
 func (s *UserEntry) UpdateAutoSummarySettings(ctx context.Context, req *g.UpdateAutoSummarySettingsRequest) (*g.UpdateAutoSummarySettingsResponse, error) {
 	// プロバイダーの検証
 	if req.GetLlmProvider() < 0 {
@@ -464,6 +426,7 @@ func (s *UserEntry) UpdateAutoSummarySettings(ctx context.Context, req *g.Update
 	userLLMDB.AutoSummaryMonthly = req.GetAutoSummaryMonthly()
 	userLLMDB.AutoLatestTrendEnabled = req.GetAutoLatestTrendEnabled()
 	userLLMDB.SemanticSearchEnabled = req.GetSemanticSearchEnabled()
+	userLLMDB.AutoTaggingEnabled = req.GetAutoTaggingEnabled()
 	userLLMDB.UpdatedAt = time.Now().Unix()
 
 	if err := userLLMDB.Update(ctx, s.DB); err != nil {
@@ -519,6 +482,7 @@ func (s *UserEntry) GetAutoSummarySettings(ctx context.Context, req *g.GetAutoSu
 		AutoSummaryMonthly:     userLLMDB.AutoSummaryMonthly,
 		AutoLatestTrendEnabled: userLLMDB.AutoLatestTrendEnabled,
 		SemanticSearchEnabled:  userLLMDB.SemanticSearchEnabled,
+		AutoTaggingEnabled:     userLLMDB.AutoTaggingEnabled,
 	}, nil
 }
 
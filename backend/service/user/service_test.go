@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/alicebob/miniredis/v2"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
 	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
 	"github.com/project-mikan/umi.mikan/backend/testutil"
 	"github.com/redis/rueidis"
@@ -318,7 +320,7 @@ func TestUserEntry_DeleteLLMKey(t *testing.T) {
 func TestUserEntry_DeleteAccount(t *testing.T) {
 	db := setupUserTestDB(t)
 	userID := testutil.CreateTestUserWithPassword(t, db, "user-delete-account@example.com", "Delete User", "password123")
-	svc := &UserEntry{DB: db}
+	svc := &UserEntry{DB: db, DeletionGracePeriod: 14 * 24 * time.Hour}
 	ctx := testutil.CreateAuthenticatedContext(userID)
 
 	resp, err := svc.DeleteAccount(ctx, &g.DeleteAccountRequest{})
@@ -331,6 +333,30 @@ func TestUserEntry_DeleteAccount(t *testing.T) {
 	if resp.Message != "accountDeleteSuccess" {
 		t.Errorf("Message: got %q, want %q", resp.Message, "accountDeleteSuccess")
 	}
+
+	// 猶予期間が過ぎるまではユーザーを削除せず、削除の予定だけを記録する
+	userDB, err := database.UserByID(context.Background(), db, userID)
+	if err != nil {
+		t.Fatalf("ユーザーの取得に失敗: %v", err)
+	}
+	if !userDB.DeletionScheduledAt.Valid || userDB.DeletionScheduledAt.Int64 != resp.DeletionScheduledAt {
+		t.Errorf("deletion_scheduled_at: got %v, want %d", userDB.DeletionScheduledAt, resp.DeletionScheduledAt)
+	}
+	if resp.DeletionScheduledAt-userDB.DeletionRequestedAt.Int64 != int64((14 * 24 * time.Hour).Seconds()) {
+		t.Errorf("猶予期間が設定どおりでない: requested=%d scheduled=%d", userDB.DeletionRequestedAt.Int64, resp.DeletionScheduledAt)
+	}
+	if !userDB.SessionsRevokedAt.Valid {
+		t.Error("セッションが取り消されていない")
+	}
+
+	// 再び呼び出しても予定は変わらない
+	again, err := svc.DeleteAccount(ctx, &g.DeleteAccountRequest{})
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if again.DeletionScheduledAt != resp.DeletionScheduledAt {
+		t.Errorf("DeletionScheduledAt: got %d, want %d", again.DeletionScheduledAt, resp.DeletionScheduledAt)
+	}
 }
 
 func TestUserEntry_DeleteAccount_Unauthenticated(t *testing.T) {
@@ -0,0 +1,78 @@
+package user
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetUsage はレート制限のあるRPC・MCPのツールごとの使用状況を返す。
+// ログイン（JWT）のバケットと、ユーザーのAPIキーごとのバケットを返す（ADR 0038）
+func (s *UserEntry) GetUsage(ctx context.Context, _ *g.GetUsageRequest) (*g.GetUsageResponse, error) {
+	userIDStr, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalidUserId")
+	}
+
+	// レート制限を設定していない場合は空で返す
+	if s.RPCLimiter == nil {
+		return &g.GetUsageResponse{Usages: []*g.RateLimitUsage{}, ApiKeys: []*g.ApiKeyUsage{}}, nil
+	}
+
+	usages, err := s.RPCLimiter.Usage(ctx, ratelimiter.UserSubject(userID.String()))
+	if err != nil {
+		log.Printf("Failed to get rate limit usage for user %s: %v", userID, err)
+		return nil, status.Error(codes.Internal, "getUsageFailed")
+	}
+
+	keys, err := database.UserAPIKeysByUserID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "getUsageFailed")
+	}
+	// ListApiKeys と同じく作成日時の降順
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt > keys[j].CreatedAt })
+
+	apiKeys := make([]*g.ApiKeyUsage, 0, len(keys))
+	for _, key := range keys {
+		keyUsages, err := s.RPCLimiter.Usage(ctx, ratelimiter.APIKeySubject(key.ID.String()))
+		if err != nil {
+			log.Printf("Failed to get rate limit usage for API key %s: %v", key.ID, err)
+			return nil, status.Error(codes.Internal, "getUsageFailed")
+		}
+		apiKeys = append(apiKeys, &g.ApiKeyUsage{
+			ApiKeyId: key.ID.String(),
+			Name:     key.Name,
+			Usages:   toRateLimitUsages(keyUsages),
+		})
+	}
+
+	return &g.GetUsageResponse{Usages: toRateLimitUsages(usages), ApiKeys: apiKeys}, nil
+}
+
+func toRateLimitUsages(usages []ratelimiter.MethodUsage) []*g.RateLimitUsage {
+	result := make([]*g.RateLimitUsage, 0, len(usages))
+	for _, usage := range usages {
+		result = append(result, &g.RateLimitUsage{
+			Method:         usage.Method,
+			Limit:          int32(usage.Limit),
+			WindowSeconds:  int64(usage.Window.Seconds()),
+			Used:           int32(usage.Used),
+			Remaining:      int32(max(usage.Limit-usage.Used, 0)),
+			ResetInSeconds: int64(usage.ResetIn.Round(time.Second).Seconds()),
+		})
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/ratelimiter"
+	"github.com/project-mikan/umi.mikan/backend/testutil"
+	"github.com/redis/rueidis"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const usageTestMethod = "/diary.DiaryService/SearchDiaryEntriesSemantic"
+
+func TestUserEntry_GetUsage(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis起動失敗: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	client, err := rueidis.NewClient(rueidis.ClientOption{InitAddress: []string{mr.Addr()}, DisableCache: true})
+	if err != nil {
+		t.Fatalf("rueidisクライアント作成失敗: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	limiter := ratelimiter.NewRPCLimiter(ratelimiter.NewRedisRateLimiter(client), map[string]ratelimiter.Policy{
+		usageTestMethod: {Limit: 5, Window: time.Minute},
+	})
+	userID := testutil.CreateTestUser(t, db, "usage@example.com", "UsageUser")
+	svc := &UserEntry{DB: db, RPCLimiter: limiter}
+	ctx := testutil.CreateAuthenticatedContext(userID)
+
+	t.Run("正常系: ユーザーとAPIキーごとの使用状況を返す", func(t *testing.T) {
+		created, err := svc.CreateApiKey(ctx, &g.CreateApiKeyRequest{Name: "Claude Desktop"})
+		if err != nil {
+			t.Fatalf("CreateApiKey失敗: %v", err)
+		}
+		for range 2 {
+			if _, err := limiter.Check(context.Background(), usageTestMethod, ratelimiter.UserSubject(userID.String())); err != nil {
+				t.Fatalf("Check失敗: %v", err)
+			}
+		}
+		if _, err := limiter.Check(context.Background(), usageTestMethod, ratelimiter.APIKeySubject(created.Info.Id)); err != nil {
+			t.Fatalf("Check失敗: %v", err)
+		}
+
+		resp, err := svc.GetUsage(ctx, &g.GetUsageRequest{})
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(resp.Usages) != 1 {
+			t.Fatalf("Usages: 期待 1件, 実際 %d件", len(resp.Usages))
+		}
+		usage := resp.Usages[0]
+		if usage.Method != usageTestMethod || usage.Limit != 5 || usage.WindowSeconds != 60 || usage.Used != 2 || usage.Remaining != 3 {
+			t.Errorf("ユーザーの使用状況が正しくない: %+v", usage)
+		}
+		if usage.ResetInSeconds <= 0 || usage.ResetInSeconds > 60 {
+			t.Errorf("ResetInSeconds: 0より大きく60以下を期待したが %d", usage.ResetInSeconds)
+		}
+
+		if len(resp.ApiKeys) != 1 {
+			t.Fatalf("ApiKeys: 期待 1件, 実際 %d件", len(resp.ApiKeys))
+		}
+		apiKey := resp.ApiKeys[0]
+		if apiKey.ApiKeyId != created.Info.Id || apiKey.Name != "Claude Desktop" {
+			t.Errorf("APIキーが正しくない: %+v", apiKey)
+		}
+		if len(apiKey.Usages) != 1 || apiKey.Usages[0].Used != 1 {
+			t.Errorf("APIキーの使用状況が正しくない: %+v", apiKey.Usages)
+		}
+	})
+
+	t.Run("正常系: レート制限を設定していない場合は空", func(t *testing.T) {
+		resp, err := (&UserEntry{DB: db}).GetUsage(ctx, &g.GetUsageRequest{})
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(resp.Usages) != 0 || len(resp.ApiKeys) != 0 {
+			t.Errorf("空を期待したが %+v", resp)
+		}
+	})
+
+	t.Run("異常系: 未認証の場合はUnauthenticated", func(t *testing.T) {
+		_, err := svc.GetUsage(context.Background(), &g.GetUsageRequest{})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Unauthenticatedを期待したが %v", err)
+		}
+	})
+}
@@ -0,0 +1,261 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/database"
+	g "github.com/project-mikan/umi.mikan/backend/infrastructure/grpc"
+	"github.com/project-mikan/umi.mikan/backend/infrastructure/webhook"
+	"github.com/project-mikan/umi.mikan/backend/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// webhookURLMaxLength は通知先URLの最大文字数
+	webhookURLMaxLength = 2048
+	// webhookMaxPerUser はユーザーごとに登録できるWebhookの上限
+	// 1イベントごとに登録数分の送信が発生するため、配信ワーカーの負荷を抑える
+	webhookMaxPerUser = 10
+	// webhookDeliveriesDefaultLimit / webhookDeliveriesMaxLimit は配信ログの取得件数
+	webhookDeliveriesDefaultLimit = 20
+	webhookDeliveriesMaxLimit     = 100
+)
+
+// toWebhookInfo はDB行をレスポンス用のWebhookInfoに変換する
+func toWebhookInfo(w *database.UserWebhook) *g.WebhookInfo {
+	var events []string
+	// eventsは登録時に検証済みのJSON配列のため、壊れている場合は空として扱う
+	_ = json.Unmarshal(w.Events, &events)
+	return &g.WebhookInfo{
+		Id:        w.ID.String(),
+		Url:       w.URL,
+		Events:    events,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+// toWebhookDelivery はDB行をレスポンス用のWebhookDeliveryに変換する
+func toWebhookDelivery(d *database.UserWebhookDelivery) *g.WebhookDelivery {
+	deliveredAt := int64(0)
+	if d.DeliveredAt.Valid {
+		deliveredAt = d.DeliveredAt.Int64
+	}
+	return &g.WebhookDelivery{
+		Id:             d.ID.String(),
+		Event:          d.Event,
+		Status:         d.Status,
+		Attempts:       int32(d.Attempts),
+		ResponseStatus: int32(d.ResponseStatus),
+		LastError:      d.LastError,
+		NextAttemptAt:  d.NextAttemptAt,
+		DeliveredAt:    deliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+// validateWebhookURL は通知先URLがhttp/httpsの絶対URLかを検証する
+// allowPrivateNetworksがfalseの場合、localhostやループバック・プライベートネットワークのIPアドレスは拒否する
+func validateWebhookURL(rawURL string, allowPrivateNetworks bool) bool {
+	if rawURL == "" || len(rawURL) > webhookURLMaxLength {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+		return false
+	}
+	return webhook.ValidateHost(u.Hostname(), allowPrivateNetworks) == nil
+}
+
+// normalizeWebhookEvents はイベント名を検証し、重複を除いてソートした一覧を返す
+func normalizeWebhookEvents(events []string) ([]string, bool) {
+	if len(events) == 0 {
+		return nil, false
+	}
+	normalized := make([]string, 0, len(events))
+	for _, event := range events {
+		if !webhook.IsValidEvent(event) {
+			return nil, false
+		}
+		if !slices.Contains(normalized, event) {
+			normalized = append(normalized, event)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized, true
+}
+
+// ownedWebhook は認証ユーザーが所有するWebhookを取得する
+// 他ユーザーのWebhookは存在を悟らせないためNotFoundを返す
+func (s *UserEntry) ownedWebhook(ctx context.Context, userID uuid.UUID, id string) (*database.UserWebhook, error) {
+	webhookID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalidWebhookId")
+	}
+	w, err := database.UserWebhookByID(ctx, s.DB, webhookID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "webhookNotFound")
+		}
+		return nil, status.Error(codes.Internal, "getWebhookFailed")
+	}
+	if w.UserID != userID {
+		return nil, status.Error(codes.NotFound, "webhookNotFound")
+	}
+	return w, nil
+}
+
+// CreateWebhook はイベント通知用のWebhookエンドポイントを登録する。
+// 署名用シークレットはこのレスポンスで一度だけ返す（受信側で署名検証に使うためDBには平文で保存する）。
+func (s *UserEntry) CreateWebhook(ctx context.Context, req *g.CreateWebhookRequest) (*g.CreateWebhookResponse, error) {
+	userIDStr, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalidUserId")
+	}
+
+	if !validateWebhookURL(req.GetUrl(), s.WebhookAllowPrivateNetworks) {
+		return nil, status.Error(codes.InvalidArgument, "invalidWebhookUrl")
+	}
+	events, ok := normalizeWebhookEvents(req.GetEvents())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalidWebhookEvents")
+	}
+
+	existing, err := database.UserWebhooksByUserID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "createFailed")
+	}
+	if len(existing) >= webhookMaxPerUser {
+		return nil, status.Error(codes.ResourceExhausted, "webhookLimitExceeded")
+	}
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "createFailed")
+	}
+	eventsJSON, err := json.Marshal(events)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "createFailed")
+	}
+
+	currentTime := time.Now().Unix()
+	w := &database.UserWebhook{
+		ID:        uuid.New(),
+		UserID:    userID,
+		URL:       req.GetUrl(),
+		Secret:    secret,
+		Events:    eventsJSON,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+	if err := w.Insert(ctx, s.DB); err != nil {
+		return nil, status.Error(codes.Internal, "createFailed")
+	}
+
+	return &g.CreateWebhookResponse{
+		Secret: secret,
+		Info:   toWebhookInfo(w),
+	}, nil
+}
+
+// ListWebhooks は登録済みWebhookの一覧を作成日時の降順で返す（シークレットは含まれない）
+func (s *UserEntry) ListWebhooks(ctx context.Context, _ *g.ListWebhooksRequest) (*g.ListWebhooksResponse, error) {
+	userIDStr, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalidUserId")
+	}
+
+	webhooks, err := database.UserWebhooksByUserID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "listFailed")
+	}
+
+	// 作成日時の降順（新しいWebhookが先頭）
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].CreatedAt > webhooks[j].CreatedAt })
+
+	infos := make([]*g.WebhookInfo, 0, len(webhooks))
+	for _, w := range webhooks {
+		infos = append(infos, toWebhookInfo(w))
+	}
+
+	return &g.ListWebhooksResponse{Webhooks: infos}, nil
+}
+
+// DeleteWebhook は指定されたWebhookを削除する（配信ログもCASCADEで削除される）
+func (s *UserEntry) DeleteWebhook(ctx context.Context, req *g.DeleteWebhookRequest) (*g.DeleteWebhookResponse, error) {
+	userIDStr, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalidUserId")
+	}
+
+	w, err := s.ownedWebhook(ctx, userID, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Delete(ctx, s.DB); err != nil {
+		return nil, status.Error(codes.Internal, "deleteFailed")
+	}
+
+	return &g.DeleteWebhookResponse{
+		Success: true,
+		Message: "webhookDeleted",
+	}, nil
+}
+
+// ListWebhookDeliveries は指定されたWebhookの配信ログを新しい順に返す
+func (s *UserEntry) ListWebhookDeliveries(ctx context.Context, req *g.ListWebhookDeliveriesRequest) (*g.ListWebhookDeliveriesResponse, error) {
+	userIDStr, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalidUserId")
+	}
+
+	w, err := s.ownedWebhook(ctx, userID, req.GetWebhookId())
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = webhookDeliveriesDefaultLimit
+	}
+	limit = min(limit, webhookDeliveriesMaxLimit)
+
+	deliveries, err := database.RecentWebhookDeliveries(ctx, s.DB, w.ID, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "listFailed")
+	}
+
+	result := make([]*g.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, toWebhookDelivery(d))
+	}
+
+	return &g.ListWebhookDeliveriesResponse{Deliveries: result}, nil
+}
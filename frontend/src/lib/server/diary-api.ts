@@ -71,7 +71,8 @@ export interface GetDiaryEntriesByMonthParams {
 
 export interface UpdateDiaryEntryParams {
   id: string;
-  title: string;
+  // 指定した場合のみ更新される（未指定ならサーバー側のタイトルを維持する）
+  title?: string;
   content: string;
   date: YMD;
   accessToken: string;
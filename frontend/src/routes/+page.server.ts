@@ -223,7 +223,6 @@ export const actions: Actions = {
         // 既存の日記を更新
         await updateDiaryEntry({
           id,
-          title: "",
           content,
           date,
           accessToken: authResult.accessToken,
@@ -267,7 +266,6 @@ export const actions: Actions = {
         // 既存の日記を更新
         await updateDiaryEntry({
           id,
-          title: "",
           content,
           date,
           accessToken: authResult.accessToken,
@@ -311,7 +309,6 @@ export const actions: Actions = {
         // 既存の日記を更新
         await updateDiaryEntry({
           id,
-          title: "",
           content,
           date,
           accessToken: authResult.accessToken,
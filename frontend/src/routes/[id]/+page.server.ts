@@ -209,7 +209,6 @@ export const actions: Actions = {
         // 既存の日記を更新
         await updateDiaryEntry({
           id,
-          title: "",
           content,
           date: ymd,
           accessToken: authResult.accessToken,
@@ -1,17 +0,0 @@
-CREATE TABLE IF NOT EXISTS diary_summary_months (
-    id UUID PRIMARY KEY,
-    user_id UUID REFERENCES users(id) NOT NULL,
-    year INTEGER NOT NULL,
-    month INTEGER NOT NULL,
-    summary TEXT NOT NULL DEFAULT '',
-    -- サマリー生成に使用したLLMモデル
-    model_version TEXT NOT NULL DEFAULT 'gemini-2.5-flash-lite',
-    created_at BIGINT NOT NULL,
-    updated_at BIGINT NOT NULL,
-    -- LLMがコンテンツポリシーなどで生成を拒否した場合の理由（空文字は正常）
-    error_reason TEXT NOT NULL DEFAULT '',
-    CONSTRAINT unique_user_month UNIQUE (user_id, year, month),
-    CONSTRAINT check_month CHECK (month >= 1 AND month <= 12)
-);
-
-CREATE INDEX index_diary_summary_months_user_id_year_month ON diary_summary_months (user_id, year, month);